@@ -20,6 +20,7 @@ import (
 	"github.com/HMB-research/open-accounting/internal/analytics"
 	"github.com/HMB-research/open-accounting/internal/apierror"
 	"github.com/HMB-research/open-accounting/internal/apitoken"
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/auth"
 	"github.com/HMB-research/open-accounting/internal/banking"
@@ -36,9 +37,12 @@ import (
 	"github.com/HMB-research/open-accounting/internal/payroll"
 	"github.com/HMB-research/open-accounting/internal/pdf"
 	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
 	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 	"github.com/HMB-research/open-accounting/internal/reports"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
 	"github.com/HMB-research/open-accounting/internal/tax"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/HMB-research/open-accounting/internal/webhooks"
@@ -79,6 +83,10 @@ type Handlers struct {
 	ordersService            *orders.Service
 	assetsService            *assets.Service
 	inventoryService         *inventory.Service
+	purchasingService        *purchasing.Service
+	stocktakeService         *stocktake.Service
+	pricingService           *pricing.Service
+	assemblyService          *assembly.Service
 	reportsService           *reports.Service
 	reminderService          *invoicing.ReminderService
 	automatedReminderService *invoicing.AutomatedReminderService
@@ -283,6 +291,10 @@ func (h *Handlers) TenantContext(next http.Handler) http.Handler {
 			respondError(w, http.StatusForbidden, "Access denied to this tenant")
 			return
 		}
+		if role == tenant.RoleEmployee {
+			respondError(w, http.StatusForbidden, errEmployeeSelfServiceOnly)
+			return
+		}
 
 		// Update claims with tenant context
 		claims.TenantID = tenantID
@@ -292,6 +304,55 @@ func (h *Handlers) TenantContext(next http.Handler) http.Handler {
 	})
 }
 
+const errEmployeeSelfServiceOnly = "Employee accounts can only use self-service endpoints"
+
+type selfServiceEmployeeContextKey struct{}
+
+// EmployeeSelfServiceContext middleware admits only employee memberships and
+// scopes the request to the payroll employee record linked to the membership.
+func (h *Handlers) EmployeeSelfServiceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := auth.GetClaims(r.Context())
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+
+		tenantID := chi.URLParam(r, "tenantID")
+		if tenantID == "" {
+			respondError(w, http.StatusBadRequest, "Tenant ID required")
+			return
+		}
+
+		if claims.TokenKind == auth.TokenKindAPIToken && claims.TenantID != "" && claims.TenantID != tenantID {
+			respondError(w, http.StatusForbidden, "API token is scoped to a different tenant")
+			return
+		}
+
+		membership, err := h.tenantService.GetTenantUser(r.Context(), tenantID, claims.UserID)
+		if err != nil || !membership.IsActive {
+			respondError(w, http.StatusForbidden, "Access denied to this tenant")
+			return
+		}
+		if membership.Role != tenant.RoleEmployee || membership.EmployeeID == "" {
+			respondError(w, http.StatusForbidden, "Insufficient permissions")
+			return
+		}
+
+		claims.TenantID = tenantID
+		claims.Role = membership.Role
+
+		ctx := context.WithValue(r.Context(), selfServiceEmployeeContextKey{}, membership.EmployeeID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// selfServiceEmployeeID returns the employee record resolved by EmployeeSelfServiceContext.
+func selfServiceEmployeeID(ctx context.Context) string {
+	employeeID, _ := ctx.Value(selfServiceEmployeeContextKey{}).(string)
+	return employeeID
+}
+
 // Register creates a new user account
 // @Summary Register new user
 // @Description Create a new user account
@@ -1209,11 +1270,15 @@ func (h *Handlers) GetTenant(w http.ResponseWriter, r *http.Request) {
 	tenantID := chi.URLParam(r, "tenantID")
 
 	// Verify user has access
-	_, err := h.tenantService.GetUserRole(r.Context(), tenantID, claims.UserID)
+	role, err := h.tenantService.GetUserRole(r.Context(), tenantID, claims.UserID)
 	if err != nil {
 		respondError(w, http.StatusForbidden, "Access denied")
 		return
 	}
+	if role == tenant.RoleEmployee {
+		respondError(w, http.StatusForbidden, errEmployeeSelfServiceOnly)
+		return
+	}
 
 	t, err := h.tenantService.GetTenant(r.Context(), tenantID)
 	if err != nil {
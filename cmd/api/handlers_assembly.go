@@ -0,0 +1,458 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/assembly"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/orders"
+)
+
+// ListBOMs returns bills of materials for a tenant.
+// @Summary List bills of materials
+// @Description List bills of materials with their components, with optional type, product and active filters
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param bom_type query string false "Filter by type (ASSEMBLY, KIT)"
+// @Param product_id query string false "Filter by finished product ID"
+// @Param active_only query bool false "Only active bills of materials"
+// @Success 200 {array} assembly.BillOfMaterials
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/boms [get]
+func (h *Handlers) ListBOMs(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	boms, err := h.assemblyService.ListBOMs(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &assembly.BOMFilter{
+		BOMType:    assembly.BOMType(query.Get("bom_type")),
+		ProductID:  query.Get("product_id"),
+		ActiveOnly: query.Get("active_only") == "true",
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list bills of materials")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, boms)
+}
+
+// CreateBOM creates the bill of materials of a product.
+// @Summary Create bill of materials
+// @Description Create the bill of materials of a goods product. ASSEMBLY bills build a stock-tracked product with assembly orders and may carry labour and overhead per output_quantity. KIT bills belong to products that are not stock-tracked; their components are issued when the kit is shipped or invoiced. Components are goods products and may have bills of their own, which makes the bill multi-level; cycles are rejected.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body assembly.CreateBOMRequest true "Bill of materials"
+// @Success 201 {object} assembly.BillOfMaterials
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/boms [post]
+func (h *Handlers) CreateBOM(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req assembly.CreateBOMRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	bom, err := h.assemblyService.CreateBOM(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, bom)
+}
+
+// GetBOM returns a bill of materials with its components.
+// @Summary Get bill of materials
+// @Description Get a bill of materials with its components
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param bomID path string true "Bill of materials ID"
+// @Success 200 {object} assembly.BillOfMaterials
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/boms/{bomID} [get]
+func (h *Handlers) GetBOM(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	bom, err := h.assemblyService.GetBOM(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "bomID"))
+	if err != nil {
+		respondAssemblyError(w, err, "Failed to get bill of materials")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, bom)
+}
+
+// UpdateBOM replaces the components and costs of a bill of materials.
+// @Summary Update bill of materials
+// @Description Replace the type, output quantity, labour, overhead and components of a bill of materials, or deactivate it with is_active false. Draft assembly orders keep the components they were created with.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param bomID path string true "Bill of materials ID"
+// @Param request body assembly.UpdateBOMRequest true "Bill of materials"
+// @Success 200 {object} assembly.BillOfMaterials
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/boms/{bomID} [put]
+func (h *Handlers) UpdateBOM(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req assembly.UpdateBOMRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	bom, err := h.assemblyService.UpdateBOM(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "bomID"), &req)
+	if err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, bom)
+}
+
+// DeleteBOM deletes a bill of materials that no assembly order uses.
+// @Summary Delete bill of materials
+// @Description Delete a bill of materials. Bills used by assembly orders must be deactivated instead.
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param bomID path string true "Bill of materials ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/boms/{bomID} [delete]
+func (h *Handlers) DeleteBOM(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	if err := h.assemblyService.DeleteBOM(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "bomID")); err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// GetBOMExplosion returns the multi-level explosion and rolled-up cost of a bill of materials.
+// @Summary Explode bill of materials
+// @Description Break a bill of materials down through every level for a quantity of its product (default: output_quantity). Leaf components are valued at the valuation method (default: tenant inventory valuation policy), falling back to the purchase price when out of stock; sub-assemblies and kits are valued at the rolled-up cost of their own bills including labour and overhead. Supports CSV, XLSX and PDF export.
+// @Tags Inventory
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param bomID path string true "Bill of materials ID"
+// @Param quantity query number false "Quantity of the finished product"
+// @Param valuation_method query string false "Valuation method: STANDARD_COST, FIFO, or WEIGHTED_AVERAGE"
+// @Param format query string false "Response format: json, csv, xlsx, or pdf"
+// @Success 200 {object} assembly.BOMExplosion
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/boms/{bomID}/explosion [get]
+func (h *Handlers) GetBOMExplosion(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	format, err := reportResponseFormat(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	quantity, err := optionalDecimalQuery(r, "quantity")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tenantRecord, err := h.tenantService.GetTenant(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Tenant not found")
+		return
+	}
+	valuationMethod := tenantInventoryValuationMethod(tenantRecord, r.URL.Query().Get("valuation_method"))
+
+	explosion, err := h.assemblyService.Explode(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "bomID"), quantity, valuationMethod)
+	if err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	fileStem := "bom-explosion-" + explosion.ProductCode
+	if format == "csv" {
+		content, err := exportBOMExplosionCSV(explosion)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export bill of materials explosion CSV")
+			return
+		}
+		respondReportCSV(w, fileStem+".csv", content)
+		return
+	}
+	if format == "xlsx" {
+		content, err := exportBOMExplosionXLSX(explosion)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export bill of materials explosion XLSX")
+			return
+		}
+		respondReportXLSX(w, fileStem+".xlsx", content)
+		return
+	}
+	if format == "pdf" {
+		content, err := exportBOMExplosionPDF(explosion)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export bill of materials explosion PDF")
+			return
+		}
+		respondReportPDF(w, fileStem+".pdf", content)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, explosion)
+}
+
+// ListAssemblyOrders returns assembly orders for a tenant.
+// @Summary List assembly orders
+// @Description List assembly and disassembly orders, newest first, with optional filters
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param status query string false "Filter by status (DRAFT, COMPLETED, CANCELED)"
+// @Param order_type query string false "Filter by type (ASSEMBLY, DISASSEMBLY)"
+// @Param product_id query string false "Filter by finished product ID"
+// @Param warehouse_id query string false "Filter by warehouse ID"
+// @Success 200 {array} assembly.AssemblyOrder
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/assembly-orders [get]
+func (h *Handlers) ListAssemblyOrders(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	list, err := h.assemblyService.ListOrders(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &assembly.AssemblyOrderFilter{
+		Status:      assembly.AssemblyOrderStatus(query.Get("status")),
+		OrderType:   assembly.AssemblyOrderType(query.Get("order_type")),
+		ProductID:   query.Get("product_id"),
+		WarehouseID: query.Get("warehouse_id"),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list assembly orders")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// CreateAssemblyOrder drafts an assembly or disassembly order.
+// @Summary Create assembly order
+// @Description Draft an ASSEMBLY or DISASSEMBLY order for a product with an active ASSEMBLY bill of materials in one warehouse. Component lines are the bill's components scaled to the quantity, with kit components exploded; sub-assemblies are consumed from stock. Labour and overhead default to the bill's costs scaled to the quantity and are ignored for disassembly.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body assembly.CreateAssemblyOrderRequest true "Assembly order"
+// @Success 201 {object} assembly.AssemblyOrder
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/assembly-orders [post]
+func (h *Handlers) CreateAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req assembly.CreateAssemblyOrderRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	order, err := h.assemblyService.CreateOrder(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, order)
+}
+
+// GetAssemblyOrder returns an assembly order with its component lines.
+// @Summary Get assembly order
+// @Description Get an assembly order with its component lines and, once completed, their issued or received costs
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assemblyOrderID path string true "Assembly order ID"
+// @Success 200 {object} assembly.AssemblyOrder
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/assembly-orders/{assemblyOrderID} [get]
+func (h *Handlers) GetAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	order, err := h.assemblyService.GetOrder(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "assemblyOrderID"))
+	if err != nil {
+		respondAssemblyError(w, err, "Failed to get assembly order")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, order)
+}
+
+// CompleteAssemblyOrder books a draft assembly order to stock and the ledger.
+// @Summary Complete assembly order
+// @Description Complete a draft order in one journal entry. An assembly issues the components with the costing method (default: tenant issue costing policy) and receives the product at their cost plus labour and overhead, which are credited to absorption_account_id (an EXPENSE account). A disassembly issues the product and receives the components, splitting its cost by the components' rolled-up cost. Orders dated in a locked period are rejected.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assemblyOrderID path string true "Assembly order ID"
+// @Param request body assembly.CompleteAssemblyOrderRequest true "Completion"
+// @Success 200 {object} assembly.AssemblyOrder
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/assembly-orders/{assemblyOrderID}/complete [post]
+func (h *Handlers) CompleteAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req assembly.CompleteAssemblyOrderRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	tenantRecord, err := h.tenantService.GetTenant(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Tenant not found")
+		return
+	}
+	req.CostingMethod = tenantInventoryIssueCostingMethod(tenantRecord, req.CostingMethod)
+	lockDate, err := h.getTenantPeriodLockDate(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate period lock")
+		return
+	}
+	req.PeriodLockDate = lockDate
+
+	order, err := h.assemblyService.CompleteOrder(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "assemblyOrderID"), &req)
+	if err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, order)
+}
+
+// CancelAssemblyOrder cancels a draft assembly order.
+// @Summary Cancel assembly order
+// @Description Cancel a draft assembly order without moving stock
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assemblyOrderID path string true "Assembly order ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/assembly-orders/{assemblyOrderID}/cancel [post]
+func (h *Handlers) CancelAssemblyOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	if err := h.assemblyService.CancelOrder(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "assemblyOrderID")); err != nil {
+		respondAssemblyError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": string(assembly.AssemblyOrderStatusCanceled)})
+}
+
+// respondAssemblyError maps assembly errors to responses. An empty fallback
+// reports any other error as a bad request with its message.
+func respondAssemblyError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, assembly.ErrBOMNotFound):
+		respondError(w, http.StatusNotFound, "Bill of materials not found")
+	case errors.Is(err, assembly.ErrAssemblyOrderNotFound):
+		respondError(w, http.StatusNotFound, "Assembly order not found")
+	case errors.Is(err, assembly.ErrPeriodLocked):
+		respondError(w, http.StatusConflict, err.Error())
+	case fallback == "":
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// issueInvoiceKits issues the components of kits sold on a sent sales invoice
+// from the default warehouse. Invoices billed from orders, whether partial,
+// prepayment or final, are skipped because their kits were issued when the
+// order shipped, and an invoice whose kits were already issued is not issued
+// again.
+func (h *Handlers) issueInvoiceKits(ctx context.Context, tenantID, schemaName, invoiceID, userID string) error {
+	if h.assemblyService == nil {
+		return nil
+	}
+	invoice, err := h.invoicingService.GetByID(ctx, tenantID, schemaName, invoiceID)
+	if err != nil {
+		return fmt.Errorf("get invoice: %w", err)
+	}
+	if invoice.InvoiceType != invoicing.InvoiceTypeSales || invoice.Status == invoicing.StatusDraft || invoice.Status == invoicing.StatusVoided {
+		return nil
+	}
+	lines := make([]assembly.InvoiceProductLine, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		if line.ProductID == nil || strings.TrimSpace(*line.ProductID) == "" {
+			continue
+		}
+		lines = append(lines, assembly.InvoiceProductLine{
+			LineNumber: line.LineNumber,
+			ProductID:  *line.ProductID,
+			Quantity:   line.Quantity,
+		})
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+	if h.ordersService != nil {
+		_, err := h.ordersService.GetInvoiceByInvoiceID(ctx, tenantID, schemaName, invoice.ID)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, orders.ErrOrderInvoiceNotFound) {
+			return err
+		}
+	}
+
+	tenantRecord, err := h.tenantService.GetTenant(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("get tenant: %w", err)
+	}
+	_, err = h.assemblyService.IssueInvoiceKits(ctx, tenantID, schemaName, &assembly.IssueInvoiceKitsRequest{
+		InvoiceID:     invoice.ID,
+		InvoiceNumber: invoice.InvoiceNumber,
+		CostingMethod: tenantInventoryIssueCostingMethod(tenantRecord, ""),
+		Lines:         lines,
+		UserID:        userID,
+	})
+	if err != nil {
+		return fmt.Errorf("issue kit components: %w", err)
+	}
+	return nil
+}
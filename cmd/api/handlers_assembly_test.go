@@ -0,0 +1,446 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/assembly"
+	"github.com/HMB-research/open-accounting/internal/auth"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/orders"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+const assemblyHandlerWarehouse = "44444444-4444-4444-8444-444444444444"
+
+// assemblyHandlerRepository is an in-memory assembly repository for handler tests.
+type assemblyHandlerRepository struct {
+	boms   map[string]*assembly.BillOfMaterials
+	orders map[string]*assembly.AssemblyOrder
+}
+
+func (m *assemblyHandlerRepository) CreateBOM(_ context.Context, _ string, bom *assembly.BillOfMaterials) error {
+	stored := *bom
+	m.boms[bom.ID] = &stored
+	return nil
+}
+
+func (m *assemblyHandlerRepository) UpdateBOM(_ context.Context, _ string, bom *assembly.BillOfMaterials) error {
+	stored := *bom
+	m.boms[bom.ID] = &stored
+	return nil
+}
+
+func (m *assemblyHandlerRepository) GetBOM(_ context.Context, _, _, bomID string) (*assembly.BillOfMaterials, error) {
+	bom, ok := m.boms[bomID]
+	if !ok {
+		return nil, assembly.ErrBOMNotFound
+	}
+	copyBOM := *bom
+	return &copyBOM, nil
+}
+
+func (m *assemblyHandlerRepository) GetBOMByProduct(_ context.Context, _, _, productID string) (*assembly.BillOfMaterials, error) {
+	for _, bom := range m.boms {
+		if bom.ProductID == productID {
+			copyBOM := *bom
+			return &copyBOM, nil
+		}
+	}
+	return nil, assembly.ErrBOMNotFound
+}
+
+func (m *assemblyHandlerRepository) ListBOMs(_ context.Context, _, _ string, filter *assembly.BOMFilter) ([]assembly.BillOfMaterials, error) {
+	result := []assembly.BillOfMaterials{}
+	for _, bom := range m.boms {
+		if filter.BOMType == "" || bom.BOMType == filter.BOMType {
+			result = append(result, *bom)
+		}
+	}
+	return result, nil
+}
+
+func (m *assemblyHandlerRepository) DeleteBOM(_ context.Context, _, _, bomID string) error {
+	delete(m.boms, bomID)
+	return nil
+}
+
+func (m *assemblyHandlerRepository) CreateOrder(_ context.Context, _ string, order *assembly.AssemblyOrder) error {
+	stored := *order
+	m.orders[order.ID] = &stored
+	return nil
+}
+
+func (m *assemblyHandlerRepository) GetOrder(_ context.Context, _, _, orderID string) (*assembly.AssemblyOrder, error) {
+	order, ok := m.orders[orderID]
+	if !ok {
+		return nil, assembly.ErrAssemblyOrderNotFound
+	}
+	copyOrder := *order
+	copyOrder.Lines = append([]assembly.AssemblyOrderLine(nil), order.Lines...)
+	return &copyOrder, nil
+}
+
+func (m *assemblyHandlerRepository) ListOrders(_ context.Context, _, _ string, filter *assembly.AssemblyOrderFilter) ([]assembly.AssemblyOrder, error) {
+	result := []assembly.AssemblyOrder{}
+	for _, order := range m.orders {
+		if filter.Status == "" || order.Status == filter.Status {
+			result = append(result, *order)
+		}
+	}
+	return result, nil
+}
+
+func (m *assemblyHandlerRepository) CompleteOrder(_ context.Context, _ string, order *assembly.AssemblyOrder) error {
+	stored := *order
+	m.orders[order.ID] = &stored
+	return nil
+}
+
+func (m *assemblyHandlerRepository) UpdateOrderStatus(_ context.Context, _, _, orderID string, status assembly.AssemblyOrderStatus) error {
+	order, ok := m.orders[orderID]
+	if !ok {
+		return assembly.ErrAssemblyOrderNotFound
+	}
+	order.Status = status
+	return nil
+}
+
+func (m *assemblyHandlerRepository) GenerateOrderNumber(context.Context, string, string) (string, error) {
+	return fmt.Sprintf("AO-%05d", len(m.orders)+1), nil
+}
+
+type assemblyHandlerStock struct {
+	products  map[string]*inventory.Product
+	assembled *inventory.PostAssemblyRequest
+	issued    []inventory.IssueStockRequest
+	issueErr  error
+}
+
+func newAssemblyHandlerStock() *assemblyHandlerStock {
+	return &assemblyHandlerStock{products: map[string]*inventory.Product{
+		"table": {ID: "table", Code: "TABLE", Name: "Table", ProductType: inventory.ProductTypeGoods, TrackInventory: true},
+		"leg":   {ID: "leg", Code: "LEG", Name: "Leg", ProductType: inventory.ProductTypeGoods, TrackInventory: true, PurchasePrice: decimal.NewFromInt(3)},
+		"top":   {ID: "top", Code: "TOP", Name: "Top", ProductType: inventory.ProductTypeGoods, TrackInventory: true, PurchasePrice: decimal.NewFromInt(20)},
+		"set":   {ID: "set", Code: "SET", Name: "Gift set", ProductType: inventory.ProductTypeGoods},
+	}}
+}
+
+func (s *assemblyHandlerStock) GetProductByID(_ context.Context, _, _, productID string) (*inventory.Product, error) {
+	product, ok := s.products[productID]
+	if !ok {
+		return nil, fmt.Errorf("product not found")
+	}
+	return product, nil
+}
+
+func (s *assemblyHandlerStock) GetWarehouseByID(_ context.Context, _, _, warehouseID string) (*inventory.Warehouse, error) {
+	return &inventory.Warehouse{ID: warehouseID, Name: "Main", IsActive: true}, nil
+}
+
+func (s *assemblyHandlerStock) ListWarehouses(context.Context, string, string, bool) ([]inventory.Warehouse, error) {
+	return []inventory.Warehouse{{ID: assemblyHandlerWarehouse, Name: "Main", IsDefault: true, IsActive: true}}, nil
+}
+
+func (s *assemblyHandlerStock) GetInventoryValuation(_ context.Context, _, _, _, method string) (*inventory.InventoryValuationReport, error) {
+	return &inventory.InventoryValuationReport{ValuationMethod: method}, nil
+}
+
+func (s *assemblyHandlerStock) IssueStock(_ context.Context, _, _ string, req *inventory.IssueStockRequest) (*inventory.IssueStockResult, error) {
+	if s.issueErr != nil {
+		return nil, s.issueErr
+	}
+	s.issued = append(s.issued, *req)
+	quantity := decimal.RequireFromString(req.Quantity)
+	unitCost := s.products[req.ProductID].PurchasePrice
+	return &inventory.IssueStockResult{ProductID: req.ProductID, Quantity: quantity, UnitCost: unitCost, TotalCost: quantity.Mul(unitCost)}, nil
+}
+
+func (s *assemblyHandlerStock) HasSourceMovements(_ context.Context, _, _, sourceType, sourceID string) (bool, error) {
+	for _, issued := range s.issued {
+		if issued.SourceType == sourceType && issued.SourceID == sourceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *assemblyHandlerStock) PostAssembly(_ context.Context, _, _ string, req *inventory.PostAssemblyRequest) (*inventory.PostAssemblyResult, error) {
+	s.assembled = req
+	result := &inventory.PostAssemblyResult{WarehouseID: req.WarehouseID, CostingMethod: req.CostingMethod, AdditionalCost: req.AdditionalCost, JournalID: "assembly-journal"}
+	for _, line := range req.Consumed {
+		unitCost := s.products[line.ProductID].PurchasePrice
+		result.Consumed = append(result.Consumed, inventory.PostAssemblyLineCost{ProductID: line.ProductID, Quantity: line.Quantity, UnitCost: unitCost, TotalCost: line.Quantity.Mul(unitCost)})
+		result.ConsumedCost = result.ConsumedCost.Add(line.Quantity.Mul(unitCost))
+	}
+	result.TotalCost = result.ConsumedCost.Add(req.AdditionalCost)
+	for _, line := range req.Produced {
+		result.Produced = append(result.Produced, inventory.PostAssemblyLineCost{ProductID: line.ProductID, Quantity: line.Quantity, UnitCost: result.TotalCost.Div(line.Quantity), TotalCost: result.TotalCost})
+	}
+	return result, nil
+}
+
+func setupAssemblyHandlers(t *testing.T) (*Handlers, *assemblyHandlerRepository, *assemblyHandlerStock) {
+	t.Helper()
+
+	repo := &assemblyHandlerRepository{boms: map[string]*assembly.BillOfMaterials{}, orders: map[string]*assembly.AssemblyOrder{}}
+	stock := newAssemblyHandlerStock()
+	tenantRepo := newMockTenantRepository()
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{
+		ID:         "tenant-1",
+		SchemaName: "tenant_test",
+		Settings: tenant.TenantSettings{
+			InventoryValuationMethod:    tenant.InventoryValuationMethodFIFO,
+			InventoryIssueCostingMethod: tenant.InventoryIssueCostingMethodWeightedAverage,
+		},
+	}
+	h := &Handlers{
+		tenantService:   tenant.NewServiceWithRepository(tenantRepo),
+		assemblyService: assembly.NewServiceWithRepository(repo, stock),
+	}
+	return h, repo, stock
+}
+
+func TestAssemblyHandlersLifecycle(t *testing.T) {
+	h, repo, stock := setupAssemblyHandlers(t)
+
+	createBody := assembly.CreateBOMRequest{
+		ProductID:  "table",
+		LabourCost: decimal.NewFromInt(8),
+		Lines: []assembly.CreateBOMLineRequest{
+			{ComponentProductID: "leg", Quantity: decimal.NewFromInt(4)},
+			{ComponentProductID: "top", Quantity: decimal.NewFromInt(1)},
+		},
+	}
+	rr := httptest.NewRecorder()
+	h.CreateBOM(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/boms", createBody, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var bom assembly.BillOfMaterials
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &bom))
+	assert.Equal(t, "user-1", bom.CreatedBy)
+	require.Len(t, bom.Lines, 2)
+	bomParams := map[string]string{"bomID": bom.ID}
+
+	rr = httptest.NewRecorder()
+	h.GetBOMExplosion(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/boms/"+bom.ID+"/explosion?quantity=2", nil, bomParams))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var explosion assembly.BOMExplosion
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &explosion))
+	assert.Equal(t, tenant.InventoryValuationMethodFIFO, explosion.ValuationMethod)
+	assert.True(t, explosion.MaterialCost.Equal(decimal.NewFromInt(64)), explosion.MaterialCost.String())
+	assert.True(t, explosion.TotalCost.Equal(decimal.NewFromInt(80)), explosion.TotalCost.String())
+
+	rr = httptest.NewRecorder()
+	h.GetBOMExplosion(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/boms/"+bom.ID+"/explosion?format=csv", nil, bomParams))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "bom-explosion-TABLE.csv")
+	assert.Contains(t, rr.Body.String(), "level,product_code,product_name")
+
+	orderBody := assembly.CreateAssemblyOrderRequest{
+		ProductID:   "table",
+		WarehouseID: assemblyHandlerWarehouse,
+		Quantity:    decimal.NewFromInt(2),
+		OrderDate:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	}
+	rr = httptest.NewRecorder()
+	h.CreateAssemblyOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/assembly-orders", orderBody, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var order assembly.AssemblyOrder
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &order))
+	assert.Equal(t, "AO-00001", order.OrderNumber)
+	assert.True(t, order.LabourCost.Equal(decimal.NewFromInt(16)))
+	orderParams := map[string]string{"assemblyOrderID": order.ID}
+
+	completeBody := assembly.CompleteAssemblyOrderRequest{AbsorptionAccountID: "absorption"}
+	rr = httptest.NewRecorder()
+	h.CompleteAssemblyOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/assembly-orders/"+order.ID+"/complete", completeBody, orderParams))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var completed assembly.AssemblyOrder
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &completed))
+	assert.Equal(t, assembly.AssemblyOrderStatusCompleted, completed.Status)
+	assert.True(t, completed.TotalCost.Equal(decimal.NewFromInt(80)), completed.TotalCost.String())
+	require.NotNil(t, stock.assembled)
+	assert.Equal(t, tenant.InventoryIssueCostingMethodWeightedAverage, stock.assembled.CostingMethod)
+	assert.Equal(t, "user-1", repo.orders[order.ID].CompletedBy)
+
+	rr = httptest.NewRecorder()
+	h.GetAssemblyOrder(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/assembly-orders/"+order.ID, nil, orderParams))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	h.ListAssemblyOrders(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/assembly-orders?status=COMPLETED", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var listed []assembly.AssemblyOrder
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+
+	rr = httptest.NewRecorder()
+	h.DeleteBOM(rr, depreciationRunRequest(t, http.MethodDelete, "/tenants/tenant-1/inventory/boms/"+bom.ID, nil, bomParams))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "deactivate it instead")
+
+	inactive := false
+	updateBody := assembly.UpdateBOMRequest{IsActive: &inactive, Lines: createBody.Lines}
+	rr = httptest.NewRecorder()
+	h.UpdateBOM(rr, depreciationRunRequest(t, http.MethodPut, "/tenants/tenant-1/inventory/boms/"+bom.ID, updateBody, bomParams))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.False(t, repo.boms[bom.ID].IsActive)
+
+	rr = httptest.NewRecorder()
+	h.ListBOMs(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/boms", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var boms []assembly.BillOfMaterials
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &boms))
+	assert.Len(t, boms, 1)
+}
+
+func TestAssemblyHandlersErrors(t *testing.T) {
+	h, repo, _ := setupAssemblyHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetBOM(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/boms/missing", nil, map[string]string{"bomID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.GetAssemblyOrder(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/assembly-orders/missing", nil, map[string]string{"assemblyOrderID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreateBOM(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/boms", assembly.CreateBOMRequest{}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "product_id is required")
+
+	rr = httptest.NewRecorder()
+	h.GetBOMExplosion(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/boms/missing/explosion?quantity=abc", nil, map[string]string{"bomID": "missing"}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreateAssemblyOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/assembly-orders", assembly.CreateAssemblyOrderRequest{ProductID: "table", WarehouseID: assemblyHandlerWarehouse, Quantity: decimal.NewFromInt(1)}, nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	repo.orders["order-1"] = &assembly.AssemblyOrder{
+		ID:          "order-1",
+		TenantID:    "tenant-1",
+		OrderNumber: "AO-00001",
+		Status:      assembly.AssemblyOrderStatusDraft,
+	}
+	params := map[string]string{"assemblyOrderID": "order-1"}
+
+	rr = httptest.NewRecorder()
+	h.CancelAssemblyOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/assembly-orders/order-1/cancel", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, assembly.AssemblyOrderStatusCanceled, repo.orders["order-1"].Status)
+
+	rr = httptest.NewRecorder()
+	h.CompleteAssemblyOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/assembly-orders/order-1/complete", assembly.CompleteAssemblyOrderRequest{}, params))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "only draft")
+
+	rr = httptest.NewRecorder()
+	respondAssemblyError(rr, fmt.Errorf("complete: %w", assembly.ErrPeriodLocked), "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestSendInvoiceIssuesKitComponents(t *testing.T) {
+	h, tenantRepo, invoiceRepo := setupInvoiceTestHandlers()
+	assemblyRepo := &assemblyHandlerRepository{boms: map[string]*assembly.BillOfMaterials{}, orders: map[string]*assembly.AssemblyOrder{}}
+	stock := newAssemblyHandlerStock()
+	h.assemblyService = assembly.NewServiceWithRepository(assemblyRepo, stock)
+	ordersRepo := newMockOrdersRepository()
+	h.ordersService = orders.NewServiceWithRepository(ordersRepo)
+	_, err := h.assemblyService.CreateBOM(context.Background(), "tenant-1", "test-tenant", &assembly.CreateBOMRequest{
+		ProductID: "set",
+		BOMType:   assembly.BOMTypeKit,
+		Lines:     []assembly.CreateBOMLineRequest{{ComponentProductID: "leg", Quantity: decimal.NewFromInt(2)}},
+		UserID:    "user-1",
+	})
+	require.NoError(t, err)
+
+	tenantRepo.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+	kitID := "set"
+	invoice := invoiceRepo.addTestInvoice("inv-1", "tenant-1", "contact-1", invoicing.InvoiceTypeSales, invoicing.StatusDraft)
+	invoice.Lines = []invoicing.InvoiceLine{{LineNumber: 1, Description: "Gift set", Quantity: decimal.NewFromInt(3), ProductID: &kitID}}
+	for _, billing := range []orders.OrderInvoice{
+		{ID: "billing-1", TenantID: "tenant-1", OrderID: "order-1", InvoiceID: "inv-2", Kind: invoicing.BillingKindPrepayment},
+		{ID: "billing-2", TenantID: "tenant-1", OrderID: "order-1", InvoiceID: "inv-3", Kind: invoicing.BillingKindQuantity},
+	} {
+		billed := invoiceRepo.addTestInvoice(billing.InvoiceID, "tenant-1", "contact-1", invoicing.InvoiceTypeSales, invoicing.StatusDraft)
+		billed.Lines = invoice.Lines
+		ordersRepo.billings = append(ordersRepo.billings, billing)
+	}
+
+	claims := &auth.Claims{UserID: "user-1", TenantID: "tenant-1", Role: tenant.RoleOwner}
+	for _, invoiceID := range []string{"inv-1", "inv-2", "inv-3"} {
+		req := makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/invoices/"+invoiceID+"/send", nil, claims)
+		req = withURLParams(req, map[string]string{"tenantID": "tenant-1", "invoiceID": invoiceID})
+		w := httptest.NewRecorder()
+
+		h.SendInvoice(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code, "response body: %s", w.Body.String())
+	}
+
+	require.Len(t, stock.issued, 1)
+	assert.Equal(t, "leg", stock.issued[0].ProductID)
+	assert.Equal(t, "6", stock.issued[0].Quantity)
+	assert.Equal(t, assemblyHandlerWarehouse, stock.issued[0].WarehouseID)
+	assert.Equal(t, assembly.KitSaleSourceType, stock.issued[0].SourceType)
+	assert.Equal(t, "inv-1", stock.issued[0].SourceID)
+	assert.Equal(t, "user-1", stock.issued[0].UserID)
+}
+
+func TestSendInvoiceIssuesKitsOnlyAfterSending(t *testing.T) {
+	h, tenantRepo, invoiceRepo := setupInvoiceTestHandlers()
+	assemblyRepo := &assemblyHandlerRepository{boms: map[string]*assembly.BillOfMaterials{}, orders: map[string]*assembly.AssemblyOrder{}}
+	stock := newAssemblyHandlerStock()
+	h.assemblyService = assembly.NewServiceWithRepository(assemblyRepo, stock)
+	_, err := h.assemblyService.CreateBOM(context.Background(), "tenant-1", "test-tenant", &assembly.CreateBOMRequest{
+		ProductID: "set",
+		BOMType:   assembly.BOMTypeKit,
+		Lines:     []assembly.CreateBOMLineRequest{{ComponentProductID: "leg", Quantity: decimal.NewFromInt(2)}},
+		UserID:    "user-1",
+	})
+	require.NoError(t, err)
+
+	tenantRepo.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+	kitID := "set"
+	invoice := invoiceRepo.addTestInvoice("inv-1", "tenant-1", "contact-1", invoicing.InvoiceTypeSales, invoicing.StatusDraft)
+	invoice.Lines = []invoicing.InvoiceLine{{LineNumber: 1, Description: "Gift set", Quantity: decimal.NewFromInt(1), ProductID: &kitID}}
+	claims := &auth.Claims{UserID: "user-1", TenantID: "tenant-1", Role: tenant.RoleOwner}
+	send := func() *httptest.ResponseRecorder {
+		req := makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/invoices/inv-1/send", nil, claims)
+		req = withURLParams(req, map[string]string{"tenantID": "tenant-1", "invoiceID": "inv-1"})
+		w := httptest.NewRecorder()
+		h.SendInvoice(w, req)
+		return w
+	}
+
+	invoiceRepo.updateStatusErr = fmt.Errorf("connection reset")
+	w := send()
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Empty(t, stock.issued)
+
+	invoiceRepo.updateStatusErr = nil
+	stock.issueErr = fmt.Errorf("insufficient stock")
+	w = send()
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Invoice sent but kit components were not issued")
+	assert.Equal(t, invoicing.StatusSent, invoice.Status)
+	assert.Empty(t, stock.issued)
+
+	stock.issueErr = nil
+	require.NoError(t, h.issueInvoiceKits(context.Background(), "tenant-1", "test-tenant", "inv-1", "user-1"))
+	require.NoError(t, h.issueInvoiceKits(context.Background(), "tenant-1", "test-tenant", "inv-1", "user-1"))
+	require.Len(t, stock.issued, 1)
+	assert.Equal(t, "inv-1", stock.issued[0].SourceID)
+}
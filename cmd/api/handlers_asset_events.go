@@ -0,0 +1,112 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+)
+
+// CreateAssetEvent records an improvement, impairment or estimate change on an asset.
+// @Summary Create asset event
+// @Description Capitalise an improvement (debit the asset account, credit offset_account_id), book an impairment (debit the expense offset_account_id, credit accumulated depreciation) or revise useful life and residual value. Depreciation is revised prospectively from the event month; posted depreciation is not restated. The event date must be on or after the last depreciated period.
+// @Tags Fixed Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assetID path string true "Asset ID"
+// @Param request body assets.CreateAssetEventRequest true "Asset event"
+// @Success 201 {object} assets.AssetEvent
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/assets/{assetID}/events [post]
+func (h *Handlers) CreateAssetEvent(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	assetID := chi.URLParam(r, "assetID")
+
+	var req assets.CreateAssetEventRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	if !req.EventDate.IsZero() && h.rejectLockedPeriod(w, r.Context(), tenantCtx.tenantID, req.EventDate) {
+		return
+	}
+
+	event, err := h.assetsService.CreateAssetEvent(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, assetID, &req)
+	if err != nil {
+		respondAssetEventError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, event)
+}
+
+// ListAssetEvents lists an asset's events.
+// @Summary List asset events
+// @Description List an asset's improvements, impairments and estimate changes in date order with the values before and after each
+// @Tags Fixed Assets
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assetID path string true "Asset ID"
+// @Success 200 {array} assets.AssetEvent
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/assets/{assetID}/events [get]
+func (h *Handlers) ListAssetEvents(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	events, err := h.assetsService.ListAssetEvents(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "assetID"))
+	if err != nil {
+		respondAssetEventError(w, err, "Failed to list asset events")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, events)
+}
+
+// GetAssetHistory returns the changes in an asset's net book value.
+// @Summary Get asset book value history
+// @Description Explain every change in an asset's net book value in date order: acquisition, posted depreciation, improvements, impairments, estimate changes and disposal
+// @Tags Fixed Assets
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assetID path string true "Asset ID"
+// @Success 200 {object} assets.AssetHistory
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/assets/{assetID}/history [get]
+func (h *Handlers) GetAssetHistory(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	history, err := h.assetsService.GetAssetHistory(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "assetID"))
+	if err != nil {
+		respondAssetEventError(w, err, "Failed to get asset history")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, history)
+}
+
+// respondAssetEventError maps asset event errors to responses. An empty
+// fallback reports any other error as a bad request with its message.
+func respondAssetEventError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, assets.ErrAssetNotFound):
+		respondError(w, http.StatusNotFound, "Asset not found")
+	case errors.Is(err, assets.ErrAssetEventsUnavailable), errors.Is(err, assets.ErrAssetAccountingInvalid):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case fallback == "":
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
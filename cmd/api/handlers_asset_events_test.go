@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+)
+
+// assetEventHandlerRepository adds asset event storage to the handler assets mock.
+type assetEventHandlerRepository struct {
+	*mockAssetsRepository
+	events map[string][]assets.AssetEvent
+}
+
+func (m *assetEventHandlerRepository) CreateAssetEvent(_ context.Context, _ string, event *assets.AssetEvent, asset *assets.FixedAsset) error {
+	m.events[event.AssetID] = append(m.events[event.AssetID], *event)
+	updated := *asset
+	m.assets[asset.ID] = &updated
+	return nil
+}
+
+func (m *assetEventHandlerRepository) ListAssetEvents(_ context.Context, _, _, assetID string) ([]assets.AssetEvent, error) {
+	return m.events[assetID], nil
+}
+
+func TestAssetEventHandlers(t *testing.T) {
+	h, assetsRepo := setupAssetRegisterHandlers(t)
+	repo := &assetEventHandlerRepository{mockAssetsRepository: assetsRepo, events: map[string][]assets.AssetEvent{}}
+	h.assetsService = assets.NewServiceWithRepositoryAndAccounting(repo, newAssetHandlerAccounting())
+	repo.assets["asset-1"].AssetAccountID = stringPtr("fixed-assets")
+	params := map[string]string{"assetID": "asset-1"}
+
+	body := assets.CreateAssetEventRequest{
+		EventType:       assets.AssetEventImprovement,
+		EventDate:       time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		Amount:          decimal.NewFromInt(660),
+		OffsetAccountID: stringPtr("cash-account"),
+	}
+	rr := httptest.NewRecorder()
+	h.CreateAssetEvent(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/assets/asset-1/events", body, params))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var event assets.AssetEvent
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &event))
+	assert.Equal(t, "user-1", event.CreatedBy)
+	assert.True(t, event.BookValueAfter.Equal(decimal.NewFromInt(3960)))
+	assert.True(t, event.MonthlyDepreciationAfter.Equal(decimal.NewFromInt(120)))
+	require.NotNil(t, event.JournalEntryID)
+
+	rr = httptest.NewRecorder()
+	h.ListAssetEvents(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/events", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var events []assets.AssetEvent
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &events))
+	assert.Len(t, events, 1)
+
+	rr = httptest.NewRecorder()
+	h.GetAssetHistory(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/history", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var history assets.AssetHistory
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &history))
+	require.Len(t, history.Lines, 2)
+	assert.Equal(t, "IMPROVEMENT", history.Lines[1].Type)
+	assert.True(t, history.Lines[1].BookValueAfter.Equal(decimal.NewFromInt(3960)))
+
+	body.EventType = assets.AssetEventImpairment
+	rr = httptest.NewRecorder()
+	h.CreateAssetEvent(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/assets/asset-1/events", body, params))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "accumulated depreciation account is required for impairment posting")
+
+	repo.getErr = assets.ErrAssetNotFound
+	rr = httptest.NewRecorder()
+	h.GetAssetHistory(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/missing/history", nil, map[string]string{"assetID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAssetEventHandlersUnavailable(t *testing.T) {
+	h, _ := setupAssetRegisterHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.ListAssetEvents(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/events", nil, map[string]string{"assetID": "asset-1"}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "asset events are unavailable")
+}
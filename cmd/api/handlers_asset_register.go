@@ -0,0 +1,196 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+)
+
+// GetAssetDepreciationSchedule returns the projected depreciation schedule for an asset.
+// @Summary Get asset depreciation schedule
+// @Description Project monthly depreciation from the month after the last posted depreciation through the end of useful life. UNITS_OF_PRODUCTION assets can pass planned units_total and units_per_month; without them the schedule uses the same monthly amount depreciation posting records.
+// @Tags Fixed Assets
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param assetID path string true "Asset ID"
+// @Param units_total query number false "Planned total units over the asset's life (UNITS_OF_PRODUCTION only)"
+// @Param units_per_month query number false "Planned units per month (UNITS_OF_PRODUCTION only)"
+// @Param format query string false "Response format: json, csv, xlsx, or pdf"
+// @Success 200 {object} assets.DepreciationSchedule
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/assets/{assetID}/depreciation-schedule [get]
+func (h *Handlers) GetAssetDepreciationSchedule(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	assetID := chi.URLParam(r, "assetID")
+
+	format, err := reportResponseFormat(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	req := &assets.DepreciationScheduleRequest{}
+	if req.UnitsTotal, err = optionalDecimalQuery(r, "units_total"); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if req.UnitsPerMonth, err = optionalDecimalQuery(r, "units_per_month"); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	schedule, err := h.assetsService.GetDepreciationSchedule(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, assetID, req)
+	if err != nil {
+		if errors.Is(err, assets.ErrAssetNotFound) {
+			respondError(w, http.StatusNotFound, "Asset not found")
+			return
+		}
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fileStem := "depreciation-schedule-" + schedule.AssetNumber
+	if format == "csv" {
+		content, err := exportDepreciationScheduleCSV(schedule)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export depreciation schedule CSV")
+			return
+		}
+		respondReportCSV(w, fileStem+".csv", content)
+		return
+	}
+	if format == "xlsx" {
+		content, err := exportDepreciationScheduleXLSX(schedule)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export depreciation schedule XLSX")
+			return
+		}
+		respondReportXLSX(w, fileStem+".xlsx", content)
+		return
+	}
+	if format == "pdf" {
+		content, err := exportDepreciationSchedulePDF(schedule)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export depreciation schedule PDF")
+			return
+		}
+		respondReportPDF(w, fileStem+".pdf", content)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedule)
+}
+
+// GetFixedAssetRegister returns the fixed asset register roll-forward for a period.
+// @Summary Get fixed asset register
+// @Description Roll forward opening cost, additions, disposals, depreciation charge and net book value per asset category and per asset for a period. Draft assets are excluded.
+// @Tags Reports
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param start_date query string true "Start date (YYYY-MM-DD)"
+// @Param end_date query string true "End date (YYYY-MM-DD)"
+// @Param format query string false "Response format: json, csv, xlsx, or pdf"
+// @Success 200 {object} assets.AssetRegisterReport
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/reports/fixed-asset-register [get]
+func (h *Handlers) GetFixedAssetRegister(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	format, err := reportResponseFormat(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	startDate, endDate, err := assetRegisterPeriodFromQuery(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.assetsService.GetAssetRegister(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, startDate, endDate)
+	if err != nil {
+		log.Error().Err(err).Str("tenant", tenantCtx.tenantID).Msg("Failed to get fixed asset register")
+		respondError(w, http.StatusInternalServerError, "Failed to get fixed asset register")
+		return
+	}
+
+	fileStem := fmt.Sprintf("fixed-asset-register-%s-%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if format == "csv" {
+		content, err := exportAssetRegisterCSV(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export fixed asset register CSV")
+			return
+		}
+		respondReportCSV(w, fileStem+".csv", content)
+		return
+	}
+	if format == "xlsx" {
+		content, err := exportAssetRegisterXLSX(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export fixed asset register XLSX")
+			return
+		}
+		respondReportXLSX(w, fileStem+".xlsx", content)
+		return
+	}
+	if format == "pdf" {
+		content, err := exportAssetRegisterPDF(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export fixed asset register PDF")
+			return
+		}
+		respondReportPDF(w, fileStem+".pdf", content)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+func assetRegisterPeriodFromQuery(r *http.Request) (time.Time, time.Time, error) {
+	startDate := strings.TrimSpace(r.URL.Query().Get("start_date"))
+	if startDate == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("start_date parameter is required")
+	}
+	parsedStart, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start_date format. Use YYYY-MM-DD")
+	}
+
+	endDate := strings.TrimSpace(r.URL.Query().Get("end_date"))
+	if endDate == "" {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date parameter is required")
+	}
+	parsedEnd, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end_date format. Use YYYY-MM-DD")
+	}
+	if parsedEnd.Before(parsedStart) {
+		return time.Time{}, time.Time{}, fmt.Errorf("end_date must be on or after start_date")
+	}
+	return parsedStart, parsedEnd, nil
+}
+
+func optionalDecimalQuery(r *http.Request, name string) (decimal.Decimal, error) {
+	value := strings.TrimSpace(r.URL.Query().Get(name))
+	if value == "" {
+		return decimal.Zero, nil
+	}
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("invalid %s", name)
+	}
+	return parsed, nil
+}
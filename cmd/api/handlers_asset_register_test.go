@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+func setupAssetRegisterHandlers(t *testing.T) (*Handlers, *mockAssetsRepository) {
+	t.Helper()
+
+	h, repo, tenantRepo := setupAssetsTestHandlers()
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{ID: "tenant-1", SchemaName: "tenant_test"}
+	categoryID := "cat-it"
+	repo.categories[categoryID] = &assets.AssetCategory{ID: categoryID, TenantID: "tenant-1", Name: "IT equipment"}
+	lastDepreciation := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	repo.assets["asset-1"] = &assets.FixedAsset{
+		ID:                      "asset-1",
+		TenantID:                "tenant-1",
+		AssetNumber:             "FA-00001",
+		Name:                    "Dell Laptop",
+		CategoryID:              &categoryID,
+		Status:                  assets.AssetStatusActive,
+		PurchaseDate:            time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		PurchaseCost:            decimal.NewFromInt(3600),
+		UsefulLifeMonths:        36,
+		DepreciationMethod:      assets.DepreciationStraightLine,
+		AccumulatedDepreciation: decimal.NewFromInt(300),
+		BookValue:               decimal.NewFromInt(3300),
+		LastDepreciationDate:    &lastDepreciation,
+	}
+	return h, repo
+}
+
+func TestGetAssetDepreciationSchedule(t *testing.T) {
+	h, repo := setupAssetRegisterHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetAssetDepreciationSchedule(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/depreciation-schedule", nil, map[string]string{"assetID": "asset-1"}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var schedule assets.DepreciationSchedule
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &schedule))
+	assert.Len(t, schedule.Lines, 33)
+	assert.True(t, schedule.Lines[0].DepreciationAmount.Equal(decimal.NewFromInt(100)))
+
+	rr = httptest.NewRecorder()
+	h.GetAssetDepreciationSchedule(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/depreciation-schedule?format=csv", nil, map[string]string{"assetID": "asset-1"}))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "depreciation-schedule-FA-00001.csv")
+	assert.Contains(t, rr.Body.String(), "2026-04-01,2026-04-30,,100,400,3200")
+
+	rr = httptest.NewRecorder()
+	h.GetAssetDepreciationSchedule(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/depreciation-schedule?units_total=1000&units_per_month=10", nil, map[string]string{"assetID": "asset-1"}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "UNITS_OF_PRODUCTION")
+
+	rr = httptest.NewRecorder()
+	h.GetAssetDepreciationSchedule(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/asset-1/depreciation-schedule?units_total=abc", nil, map[string]string{"assetID": "asset-1"}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	repo.getErr = assets.ErrAssetNotFound
+	rr = httptest.NewRecorder()
+	h.GetAssetDepreciationSchedule(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/assets/missing/depreciation-schedule", nil, map[string]string{"assetID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestGetFixedAssetRegister(t *testing.T) {
+	h, repo := setupAssetRegisterHandlers(t)
+	repo.depreciationEntries["asset-1"] = []assets.DepreciationEntry{{
+		TenantID:           "tenant-1",
+		AssetID:            "asset-1",
+		PeriodStart:        time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:          time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		DepreciationAmount: decimal.NewFromInt(100),
+	}}
+
+	rr := httptest.NewRecorder()
+	h.GetFixedAssetRegister(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/reports/fixed-asset-register?start_date=2026-01-01&end_date=2026-12-31", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var report assets.AssetRegisterReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	require.Len(t, report.Categories, 1)
+	assert.Equal(t, "IT equipment", report.Categories[0].CategoryName)
+	assert.True(t, report.Totals.Additions.Equal(decimal.NewFromInt(3600)))
+	assert.True(t, report.Totals.OpeningAccumulatedDepreciation.IsZero())
+	assert.True(t, report.Totals.DepreciationCharge.Equal(decimal.NewFromInt(300)))
+
+	rr = httptest.NewRecorder()
+	h.GetFixedAssetRegister(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/reports/fixed-asset-register?start_date=2026-01-01&end_date=2026-12-31&format=xlsx", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "fixed-asset-register-2026-01-01-2026-12-31.xlsx")
+
+	for _, query := range []string{"end_date=2026-12-31", "start_date=2026-13-01&end_date=2026-12-31", "start_date=2026-12-31&end_date=2026-01-01", "start_date=2026-01-01&end_date=2026-12-31&format=doc"} {
+		rr = httptest.NewRecorder()
+		h.GetFixedAssetRegister(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/reports/fixed-asset-register?"+query, nil, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+}
@@ -271,6 +271,18 @@ func (m *mockTenantRepository) UpdateTenantUserRole(ctx context.Context, tenantI
 	return nil
 }
 
+func (m *mockTenantRepository) UpdateTenantUserEmployee(ctx context.Context, tenantID, userID, employeeID string) error {
+	users := m.tenantUsers[tenantID]
+	for i := range users {
+		if users[i].UserID == userID {
+			m.tenantUsers[tenantID][i].Role = tenant.RoleEmployee
+			m.tenantUsers[tenantID][i].EmployeeID = employeeID
+			return nil
+		}
+	}
+	return tenant.ErrUserNotInTenant
+}
+
 func (m *mockTenantRepository) SetTenantUserActive(ctx context.Context, tenantID, userID string, active bool) error {
 	users := m.tenantUsers[tenantID]
 	for i := range users {
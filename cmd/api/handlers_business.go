@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"strconv"
@@ -59,6 +60,15 @@ var (
 	generateOrderPDF = func(pdfService *internalpdf.Service, order *orders.Order, tenantRecord *tenant.Tenant, pdfSettings internalpdf.PDFSettings) ([]byte, error) {
 		return pdfService.GenerateOrderPDF(order, tenantRecord, pdfSettings)
 	}
+	generateDeliveryNotePDF = func(pdfService *internalpdf.Service, order *orders.Order, shipment *orders.OrderShipment, tenantRecord *tenant.Tenant, pdfSettings internalpdf.PDFSettings) ([]byte, error) {
+		return pdfService.GenerateDeliveryNotePDF(order, shipment, tenantRecord, pdfSettings)
+	}
+	generateReminderPDF = func(pdfService *internalpdf.Service, invoice *invoicing.Invoice, tenantRecord *tenant.Tenant, pdfSettings internalpdf.PDFSettings, asOf time.Time) ([]byte, error) {
+		return pdfService.GenerateReminderPDF(invoice, tenantRecord, pdfSettings, asOf)
+	}
+	generateTemplatePreviewPDF = func(pdfService *internalpdf.Service, documentType string, tmpl tenant.DocumentTemplate, tenantRecord *tenant.Tenant, pdfSettings internalpdf.PDFSettings) ([]byte, error) {
+		return pdfService.GenerateTemplatePreviewPDF(documentType, tmpl, tenantRecord, pdfSettings)
+	}
 	generatePayslipPDF = func(pdfService *internalpdf.Service, payslip *payroll.Payslip, run *payroll.PayrollRun, tenantRecord *tenant.Tenant) ([]byte, error) {
 		return pdfService.GeneratePayslipPDF(payslip, run, tenantRecord)
 	}
@@ -764,7 +774,7 @@ func (h *Handlers) GetInvoice(w http.ResponseWriter, r *http.Request) {
 
 // SendInvoice marks an invoice as sent
 // @Summary Send invoice
-// @Description Mark an invoice as sent to the customer. Draft purchase invoices require approved invoice evidence before sending.
+// @Description Mark an invoice as sent to the customer. Draft purchase invoices require approved invoice evidence before sending. Once a draft sales invoice is sent, the components of its kit products are issued once from the default warehouse unless the invoice was billed from an order. A failed kit issue leaves the invoice sent and returns 500.
 // @Tags Invoices
 // @Produce json
 // @Security BearerAuth
@@ -773,6 +783,7 @@ func (h *Handlers) GetInvoice(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} object{status=string}
 // @Failure 400 {object} object{error=string}
 // @Failure 409 {object} object{error=string,evidence_policy_results=[]documents.EvidencePolicyResult,remediation_actions=[]documents.DocumentRemediationAction}
+// @Failure 500 {object} object{error=string}
 // @Router /tenants/{tenantID}/invoices/{invoiceID}/send [post]
 func (h *Handlers) SendInvoice(w http.ResponseWriter, r *http.Request) {
 	tenantID := chi.URLParam(r, "tenantID")
@@ -800,8 +811,13 @@ func (h *Handlers) SendInvoice(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-
 	h.emitWebhookEvent(plugin.EventInvoiceSent, tenantID, map[string]string{"invoice_id": invoiceID})
+
+	if err := h.issueInvoiceKits(r.Context(), tenantID, schemaName, invoiceID, userIDFromRequest(r)); err != nil {
+		respondError(w, http.StatusInternalServerError, "Invoice sent but kit components were not issued: "+err.Error())
+		return
+	}
+
 	respondJSON(w, http.StatusOK, map[string]string{"status": "sent"})
 }
 
@@ -883,6 +899,140 @@ func (h *Handlers) VoidInvoice(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]string{"status": "voided"})
 }
 
+// CreateCreditNote credits lines of an issued invoice
+// @Summary Create credit note
+// @Description Create a credit note for selected lines and quantities of an issued invoice and offset the invoice's open balance
+// @Tags Invoices
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param invoiceID path string true "Invoice ID"
+// @Param request body invoicing.CreateCreditNoteRequest true "Credited lines; omit lines to credit the full remaining quantity"
+// @Success 201 {object} invoicing.Invoice
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/invoices/{invoiceID}/credit-notes [post]
+func (h *Handlers) CreateCreditNote(w http.ResponseWriter, r *http.Request) {
+	claims, _ := auth.GetClaims(r.Context())
+	tenantID := chi.URLParam(r, "tenantID")
+	invoiceID := chi.URLParam(r, "invoiceID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req invoicing.CreateCreditNoteRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	req.UserID = claims.UserID
+
+	if req.IssueDate.IsZero() {
+		req.IssueDate = time.Now()
+	}
+
+	if h.rejectLockedPeriod(w, r.Context(), tenantID, req.IssueDate) {
+		return
+	}
+
+	creditNote, err := h.invoicingService.CreateCreditNote(r.Context(), tenantID, schemaName, invoiceID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.emitWebhookEvent(plugin.EventInvoiceCreated, tenantID, creditNote)
+	respondJSON(w, http.StatusCreated, creditNote)
+}
+
+// ExportEInvoice renders issued invoices as an Estonian e-invoice file
+// @Summary Export e-invoice XML
+// @Description Render issued sales invoices and credit notes to one Estonian e-invoice 1.2 XML file, or one invoice to a Peppol BIS 3.0 UBL document, and record the export on each invoice
+// @Tags Invoices
+// @Accept json
+// @Produce application/xml
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body invoicing.ExportEInvoiceRequest true "Invoices to export; payment account defaults to the default bank account"
+// @Success 200 {file} binary
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/invoices/export-einvoice [post]
+func (h *Handlers) ExportEInvoice(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req invoicing.ExportEInvoiceRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	t, err := h.tenantService.GetTenant(r.Context(), tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tenant")
+		return
+	}
+
+	opts := invoicing.EInvoiceExportOptions{
+		Seller: invoicing.EInvoiceSeller{
+			Name:      t.Name,
+			RegCode:   t.Settings.RegCode,
+			VATNumber: t.Settings.VATNumber,
+			Email:     t.Settings.Email,
+			Address:   t.Settings.Address,
+			Country:   t.Settings.CountryCode,
+		},
+	}
+	if req.PayToIBAN == "" && h.bankingService != nil {
+		active := true
+		accounts, err := h.bankingService.ListBankAccounts(r.Context(), schemaName, tenantID, &banking.BankAccountFilter{IsActive: &active})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to get bank accounts")
+			return
+		}
+		for _, account := range accounts {
+			if account.IsDefault || opts.Seller.IBAN == "" {
+				opts.Seller.IBAN = account.AccountNumber
+				opts.Seller.BIC = account.SwiftCode
+			}
+		}
+	}
+	if h.contactsService != nil {
+		opts.LoadContact = func(ctx context.Context, contactID string) (*contacts.Contact, error) {
+			return h.contactsService.GetByID(ctx, tenantID, schemaName, contactID)
+		}
+	}
+	if h.pdfService != nil {
+		pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+		opts.RenderPDF = func(invoice *invoicing.Invoice) ([]byte, error) {
+			invoice.Contact = h.pdfContact(r.Context(), tenantID, schemaName, invoice.Contact, invoice.ContactID)
+			return generateInvoicePDF(h.pdfService, invoice, t, pdfSettings)
+		}
+	}
+
+	export, err := h.invoicingService.ExportEInvoiceXML(r.Context(), tenantID, schemaName, &req, opts)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondReportXML(w, export.FileName, export.XML)
+}
+
+// pdfContact returns the contact shown on a generated document, loading it when
+// the record was fetched without one. Lookup failures render without a contact
+// block in the tenant default language rather than failing the download.
+func (h *Handlers) pdfContact(ctx context.Context, tenantID, schemaName string, contact *contacts.Contact, contactID string) *contacts.Contact {
+	if contact != nil || h.contactsService == nil || contactID == "" {
+		return contact
+	}
+	loaded, err := h.contactsService.GetByID(ctx, tenantID, schemaName, contactID)
+	if err != nil {
+		return nil
+	}
+	return loaded
+}
+
 // GetInvoicePDF generates and returns a PDF for an invoice
 // @Summary Download invoice PDF
 // @Description Generate and download a PDF for an invoice
@@ -918,6 +1068,7 @@ func (h *Handlers) GetInvoicePDF(w http.ResponseWriter, r *http.Request) {
 	pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
 
 	// Generate PDF
+	invoice.Contact = h.pdfContact(r.Context(), tenantID, schemaName, invoice.Contact, invoice.ContactID)
 	pdfBytes, err := generateInvoicePDF(h.pdfService, invoice, t, pdfSettings)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
@@ -934,6 +1085,61 @@ func (h *Handlers) GetInvoicePDF(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(pdfBytes)
 }
 
+// GetInvoiceReminderPDF generates a payment reminder letter for an outstanding invoice
+// @Summary Download payment reminder PDF
+// @Description Generate a payment reminder letter for an outstanding sales invoice in the contact's document language
+// @Tags Reminders
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param invoiceID path string true "Invoice ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/invoices/{invoiceID}/reminder-pdf [get]
+func (h *Handlers) GetInvoiceReminderPDF(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	invoiceID := chi.URLParam(r, "invoiceID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	invoice, err := h.invoicingService.GetByID(r.Context(), tenantID, schemaName, invoiceID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Invoice not found")
+		return
+	}
+	if invoice.InvoiceType != invoicing.InvoiceTypeSales {
+		respondError(w, http.StatusBadRequest, "Payment reminders are only available for sales invoices")
+		return
+	}
+	if invoice.Status == invoicing.StatusDraft || invoice.Status == invoicing.StatusVoided || !invoice.AmountDue().IsPositive() {
+		respondError(w, http.StatusBadRequest, "Invoice has no outstanding balance to remind about")
+		return
+	}
+
+	t, err := h.tenantService.GetTenant(r.Context(), tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tenant")
+		return
+	}
+
+	pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+	invoice.Contact = h.pdfContact(r.Context(), tenantID, schemaName, invoice.Contact, invoice.ContactID)
+	pdfBytes, err := generateReminderPDF(h.pdfService, invoice, t, pdfSettings, time.Now())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
+		return
+	}
+
+	filename := "reminder-" + invoice.InvoiceNumber + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdfBytes)
+}
+
 // =============================================================================
 // PAYMENTS HANDLERS
 // =============================================================================
@@ -1412,6 +1618,160 @@ func (h *Handlers) UpdateEmailTemplate(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, template)
 }
 
+// ListDocumentTemplates returns the PDF template for every document type
+// @Summary List document templates
+// @Description List the effective PDF layout template for invoices, quotes, orders and payment reminders
+// @Tags Document Templates
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Success 200 {array} tenant.DocumentTemplateEntry
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/document-templates [get]
+func (h *Handlers) ListDocumentTemplates(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+
+	entries, err := h.tenantService.ListDocumentTemplates(r.Context(), tenantID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Tenant not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+// UpdateDocumentTemplate saves the PDF template for a document type
+// @Summary Update document template
+// @Description Save the PDF layout for a document type. Header, footer and custom field values are Go text/template strings and are rendered against sample data before saving.
+// @Tags Document Templates
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param documentType path string true "Document type (INVOICE, QUOTE, ORDER, REMINDER)"
+// @Param request body tenant.DocumentTemplate true "Template layout"
+// @Success 200 {object} tenant.DocumentTemplateEntry
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/document-templates/{documentType} [put]
+func (h *Handlers) UpdateDocumentTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+
+	documentType, err := tenant.NormalizeDocumentType(chi.URLParam(r, "documentType"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req tenant.DocumentTemplate
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := internalpdf.ValidateDocumentTemplate(documentType, req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entry, err := h.tenantService.SetDocumentTemplate(r.Context(), tenantID, documentType, &req)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to save document template")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+// DeleteDocumentTemplate restores the built-in PDF layout for a document type
+// @Summary Reset document template
+// @Description Remove the custom PDF layout for a document type and restore the built-in layout
+// @Tags Document Templates
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param documentType path string true "Document type (INVOICE, QUOTE, ORDER, REMINDER)"
+// @Success 200 {object} tenant.DocumentTemplateEntry
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/document-templates/{documentType} [delete]
+func (h *Handlers) DeleteDocumentTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+
+	documentType, err := tenant.NormalizeDocumentType(chi.URLParam(r, "documentType"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entry, err := h.tenantService.SetDocumentTemplate(r.Context(), tenantID, documentType, nil)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reset document template")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entry)
+}
+
+// PreviewDocumentTemplate renders a document template against a sample document
+// @Summary Preview document template
+// @Description Render a draft template, or the saved template when none is supplied, against a sample document using the tenant's company details and logo
+// @Tags Document Templates
+// @Accept json
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param documentType path string true "Document type (INVOICE, QUOTE, ORDER, REMINDER)"
+// @Param request body tenant.DocumentTemplatePreviewRequest false "Draft template"
+// @Success 200 {file} binary
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/document-templates/{documentType}/preview [post]
+func (h *Handlers) PreviewDocumentTemplate(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+
+	documentType, err := tenant.NormalizeDocumentType(chi.URLParam(r, "documentType"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var req tenant.DocumentTemplatePreviewRequest
+	if err := decodeJSON(r, &req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	t, err := h.tenantService.GetTenant(r.Context(), tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tenant")
+		return
+	}
+
+	tmpl := t.Settings.DocumentTemplates[documentType]
+	if req.Template != nil {
+		tmpl = *req.Template
+	}
+	if err := internalpdf.ValidateDocumentTemplate(documentType, tmpl); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+	pdfBytes, err := generateTemplatePreviewPDF(h.pdfService, documentType, tmpl, t, pdfSettings)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
+		return
+	}
+
+	filename := "template-preview-" + strings.ToLower(documentType) + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdfBytes)
+}
+
 // GetEmailLog returns the email log for a tenant
 // @Summary Get email log
 // @Description Get the email sending history for a tenant
@@ -1539,6 +1899,7 @@ func (h *Handlers) EmailInvoice(w http.ResponseWriter, r *http.Request) {
 	var attachments []email.Attachment
 	if req.AttachPDF {
 		pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+		invoice.Contact = h.pdfContact(r.Context(), tenantID, schemaName, invoice.Contact, invoice.ContactID)
 		pdfBytes, err := generateInvoicePDF(h.pdfService, invoice, t, pdfSettings)
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
@@ -1653,6 +2014,7 @@ func (h *Handlers) EmailQuote(w http.ResponseWriter, r *http.Request) {
 	var attachments []email.Attachment
 	if req.AttachPDF {
 		pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+		quote.Contact = h.pdfContact(r.Context(), tenantID, schemaName, quote.Contact, quote.ContactID)
 		pdfBytes, err := generateQuotePDF(h.pdfService, quote, t, pdfSettings)
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
@@ -1765,6 +2127,7 @@ func (h *Handlers) EmailOrder(w http.ResponseWriter, r *http.Request) {
 	var attachments []email.Attachment
 	if req.AttachPDF {
 		pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+		order.Contact = h.pdfContact(r.Context(), tenantID, schemaName, order.Contact, order.ContactID)
 		pdfBytes, err := generateOrderPDF(h.pdfService, order, t, pdfSettings)
 		if err != nil {
 			respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
@@ -3285,14 +3648,14 @@ func (h *Handlers) RemoveTenantUser(w http.ResponseWriter, r *http.Request) {
 
 // UpdateTenantUserRole updates a user's role in the tenant
 // @Summary Update user role
-// @Description Update a user's role in the tenant organization
+// @Description Update a user's role in the tenant organization. The employee role requires employee_id, the payroll employee record the user may self-serve.
 // @Tags Users
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param tenantID path string true "Tenant ID"
 // @Param userID path string true "User ID"
-// @Param request body object{role=string} true "New role"
+// @Param request body object{role=string,employee_id=string} true "New role"
 // @Success 200 {object} object{status=string}
 // @Failure 400 {object} object{error=string}
 // @Failure 403 {object} object{error=string}
@@ -3314,7 +3677,8 @@ func (h *Handlers) UpdateTenantUserRole(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req struct {
-		Role string `json:"role"`
+		Role       string `json:"role"`
+		EmployeeID string `json:"employee_id"`
 	}
 	if err := decodeJSON(r, &req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
@@ -3325,22 +3689,35 @@ func (h *Handlers) UpdateTenantUserRole(w http.ResponseWriter, r *http.Request)
 		respondError(w, http.StatusBadRequest, "Role is required")
 		return
 	}
+	if !h.validateMembershipEmployee(w, r, tenantID, req.Role, req.EmployeeID) {
+		return
+	}
 
 	previousRole, _ := h.tenantService.GetUserRole(r.Context(), tenantID, userID)
-	if err := h.tenantService.UpdateTenantUserRole(r.Context(), tenantID, userID, req.Role); err != nil {
+	var err error
+	if req.Role == tenant.RoleEmployee {
+		err = h.tenantService.AssignEmployeeRole(r.Context(), tenantID, userID, req.EmployeeID)
+	} else {
+		err = h.tenantService.UpdateTenantUserRole(r.Context(), tenantID, userID, req.Role)
+	}
+	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	metadata := map[string]string{
+		"previous_role": previousRole,
+		"new_role":      req.Role,
+	}
+	if req.Role == tenant.RoleEmployee {
+		metadata["employee_id"] = strings.TrimSpace(req.EmployeeID)
+	}
 	if !h.recordTenantAuditEvent(w, r, &tenant.TenantAuditEvent{
 		TenantID:    tenantID,
 		ActorUserID: claims.UserID,
 		Action:      tenant.AuditActionUserRoleUpdated,
 		TargetType:  tenant.AuditTargetUser,
 		TargetID:    userID,
-		Metadata: map[string]string{
-			"previous_role": previousRole,
-			"new_role":      req.Role,
-		},
+		Metadata:    metadata,
 	}) {
 		return
 	}
@@ -3350,7 +3727,7 @@ func (h *Handlers) UpdateTenantUserRole(w http.ResponseWriter, r *http.Request)
 
 // CreateInvitation creates a new invitation to join a tenant
 // @Summary Create invitation
-// @Description Invite a user to join the tenant organization
+// @Description Invite a user to join the tenant organization. The employee role requires employee_id and grants self-service access to that employee record only.
 // @Tags Invitations
 // @Accept json
 // @Produce json
@@ -3372,8 +3749,9 @@ func (h *Handlers) CreateInvitation(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req struct {
-		Email string `json:"email"`
-		Role  string `json:"role"`
+		Email      string `json:"email"`
+		Role       string `json:"role"`
+		EmployeeID string `json:"employee_id"`
 	}
 	if err := decodeJSON(r, &req); err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid request body")
@@ -3384,10 +3762,14 @@ func (h *Handlers) CreateInvitation(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, "Email and role are required")
 		return
 	}
+	if !h.validateMembershipEmployee(w, r, tenantID, req.Role, req.EmployeeID) {
+		return
+	}
 
 	invitation, err := h.tenantService.CreateInvitation(r.Context(), tenantID, claims.UserID, &tenant.CreateInvitationRequest{
-		Email: req.Email,
-		Role:  req.Role,
+		Email:      req.Email,
+		Role:       req.Role,
+		EmployeeID: req.EmployeeID,
 	})
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
@@ -3410,13 +3792,31 @@ func (h *Handlers) CreateInvitation(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusCreated, invitation)
 }
 
-// ListInvitations returns pending invitations for a tenant
-// @Summary List invitations
-// @Description Get all pending invitations for a tenant
-// @Tags Invitations
-// @Produce json
-// @Security BearerAuth
-// @Param tenantID path string true "Tenant ID"
+// validateMembershipEmployee checks the employee link of a role assignment and
+// that a linked employee record exists in the tenant.
+func (h *Handlers) validateMembershipEmployee(w http.ResponseWriter, r *http.Request, tenantID, role, employeeID string) bool {
+	if err := tenant.ValidateEmployeeLink(role, employeeID); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	if role != tenant.RoleEmployee {
+		return true
+	}
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+	if _, err := h.payrollService.GetEmployee(r.Context(), schemaName, tenantID, strings.TrimSpace(employeeID)); err != nil {
+		respondError(w, http.StatusBadRequest, "Employee not found")
+		return false
+	}
+	return true
+}
+
+// ListInvitations returns pending invitations for a tenant
+// @Summary List invitations
+// @Description Get all pending invitations for a tenant
+// @Tags Invitations
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
 // @Success 200 {array} tenant.UserInvitation
 // @Failure 403 {object} object{error=string}
 // @Failure 500 {object} object{error=string}
@@ -3750,6 +4150,38 @@ func (h *Handlers) ListSalaryComponents(w http.ResponseWriter, r *http.Request)
 	respondJSON(w, http.StatusOK, components)
 }
 
+// GetAverageEarnings returns an employee's average daily earnings
+// @Summary Get average earnings
+// @Description Calculate an employee's average daily earnings for leave starting on a date, from finalized payslips of the six preceding calendar months including imported payroll history. Vacation pay and employer sick pay use this rate.
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param employeeID path string true "Employee ID"
+// @Param date query string true "Leave start date (YYYY-MM-DD)"
+// @Success 200 {object} payroll.AverageEarnings
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/employees/{employeeID}/average-earnings [get]
+func (h *Handlers) GetAverageEarnings(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	employeeID := chi.URLParam(r, "employeeID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	referenceDate, err := time.Parse("2006-01-02", strings.TrimSpace(r.URL.Query().Get("date")))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid date")
+		return
+	}
+
+	average, err := h.payrollService.CalculateAverageEarnings(r.Context(), schemaName, tenantID, employeeID, referenceDate)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, average)
+}
+
 // AddSalaryComponent creates a salary component for an employee
 // @Summary Add salary component
 // @Description Add a recurring or one-off salary component, such as secondary employment income, bonus, commission, or taxable benefit
@@ -3957,6 +4389,14 @@ func (h *Handlers) ProcessPayrollRun(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if req.Approve {
+		if run, err := h.payrollService.GetPayrollRun(r.Context(), schemaName, tenantID, runID); err == nil {
+			if h.rejectLockedPeriod(w, r.Context(), tenantID, payroll.PayrollRunPostingDate(run)) {
+				return
+			}
+		}
+	}
+
 	result, err := h.payrollService.ProcessPayrollRun(r.Context(), schemaName, tenantID, runID, claims.UserID, &req)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
@@ -3983,6 +4423,12 @@ func (h *Handlers) ApprovePayroll(w http.ResponseWriter, r *http.Request) {
 	runID := chi.URLParam(r, "runID")
 	schemaName := h.getSchemaName(r.Context(), tenantID)
 
+	if run, err := h.payrollService.GetPayrollRun(r.Context(), schemaName, tenantID, runID); err == nil {
+		if h.rejectLockedPeriod(w, r.Context(), tenantID, payroll.PayrollRunPostingDate(run)) {
+			return
+		}
+	}
+
 	if err := h.payrollService.ApprovePayrollRun(r.Context(), schemaName, tenantID, runID, claims.UserID); err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
@@ -3992,6 +4438,430 @@ func (h *Handlers) ApprovePayroll(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]string{"status": "approved"})
 }
 
+// ReopenPayrollRun returns an approved payroll run to draft
+// @Summary Reopen payroll run
+// @Description Return an approved payroll run to DRAFT for recalculation. A posted payroll journal entry is voided with a reversal.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param runID path string true "Payroll Run ID"
+// @Param request body object{reason=string} true "Reopen reason"
+// @Success 200 {object} payroll.PayrollRun
+// @Failure 400 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/payroll-runs/{runID}/reopen [post]
+func (h *Handlers) ReopenPayrollRun(w http.ResponseWriter, r *http.Request) {
+	claims, _ := auth.GetClaims(r.Context())
+	tenantID := chi.URLParam(r, "tenantID")
+	runID := chi.URLParam(r, "runID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	run, err := h.payrollService.GetPayrollRun(r.Context(), schemaName, tenantID, runID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if h.rejectLockedPeriod(w, r.Context(), tenantID, payroll.PayrollRunPostingDate(run)) {
+		return
+	}
+
+	reopened, err := h.payrollService.ReopenPayrollRun(r.Context(), schemaName, tenantID, runID, claims.UserID, req.Reason)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, reopened)
+}
+
+// ListPayrollPostingAccounts lists payroll general-ledger posting accounts
+// @Summary List payroll posting accounts
+// @Description List the tenant default payroll posting accounts and department overrides
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Success 200 {array} payroll.PayrollPostingAccounts
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/payroll/posting-accounts [get]
+func (h *Handlers) ListPayrollPostingAccounts(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	accounts, err := h.payrollService.ListPayrollPostingAccounts(r.Context(), schemaName, tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list payroll posting accounts")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, accounts)
+}
+
+// SetPayrollPostingAccounts stores payroll posting accounts
+// @Summary Set payroll posting accounts
+// @Description Set the tenant default payroll posting accounts, or a department override with optional cost center
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body payroll.SetPayrollPostingAccountsRequest true "Posting accounts"
+// @Success 200 {object} payroll.PayrollPostingAccounts
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/payroll/posting-accounts [put]
+func (h *Handlers) SetPayrollPostingAccounts(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req payroll.SetPayrollPostingAccountsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	accounts, err := h.payrollService.SetPayrollPostingAccounts(r.Context(), schemaName, tenantID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, accounts)
+}
+
+// DeletePayrollPostingAccounts removes payroll posting accounts
+// @Summary Delete payroll posting accounts
+// @Description Remove a department override, or the tenant default when no department is given
+// @Tags Payroll
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param department query string false "Department name; omit for the tenant default"
+// @Success 204
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/payroll/posting-accounts [delete]
+func (h *Handlers) DeletePayrollPostingAccounts(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	err := h.payrollService.DeletePayrollPostingAccounts(r.Context(), schemaName, tenantID, r.URL.Query().Get("department"))
+	if errors.Is(err, payroll.ErrPostingAccountsNotFound) {
+		respondError(w, http.StatusNotFound, "Payroll posting accounts not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete payroll posting accounts")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PayPayrollRun generates the net salary payment file for a payroll run
+// @Summary Pay payroll run
+// @Description Generate a SEPA pain.001 credit-transfer file for the unpaid net salaries of an approved payroll run on its payment date, optionally adding the TSD tax transfer with the tenant prepayment reference. Records the paid payslips and an outgoing payment for bank reconciliation, and posts the liability clearing entry when payroll posting accounts are configured.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param runID path string true "Payroll Run ID"
+// @Param request body payroll.PayPayrollRunRequest true "Paying bank account and tax transfer option"
+// @Success 201 {object} payroll.PayPayrollRunResult
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/payroll-runs/{runID}/pay [post]
+func (h *Handlers) PayPayrollRun(w http.ResponseWriter, r *http.Request) {
+	claims, _ := auth.GetClaims(r.Context())
+	tenantID := chi.URLParam(r, "tenantID")
+	runID := chi.URLParam(r, "runID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req payroll.PayPayrollRunRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	run, err := h.payrollService.GetPayrollRun(r.Context(), schemaName, tenantID, runID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if run.PaymentDate != nil && h.rejectLockedPeriod(w, r.Context(), tenantID, *run.PaymentDate) {
+		return
+	}
+
+	result, err := h.payrollService.PayPayrollRun(r.Context(), schemaName, tenantID, runID, claims.UserID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+// ListPayrollPayments lists the payment files generated for a payroll run
+// @Summary List payroll payments
+// @Description List the net salary payment files generated for a payroll run
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param runID path string true "Payroll Run ID"
+// @Success 200 {array} payroll.PayrollPayment
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/payroll-runs/{runID}/payments [get]
+func (h *Handlers) ListPayrollPayments(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	runID := chi.URLParam(r, "runID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	paid, err := h.payrollService.ListPayrollPayments(r.Context(), schemaName, tenantID, runID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, paid)
+}
+
+// ListTimesheets lists daily timesheet entries
+// @Summary List timesheets
+// @Description List daily timesheet entries with regular, overtime, night and public holiday hours
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param employee_id query string false "Filter by employee"
+// @Param from_date query string false "First work date (YYYY-MM-DD)"
+// @Param to_date query string false "Last work date (YYYY-MM-DD)"
+// @Param status query string false "Filter by status (PENDING, APPROVED)"
+// @Success 200 {array} payroll.TimesheetEntry
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/timesheets [get]
+func (h *Handlers) ListTimesheets(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	filter := payroll.TimesheetFilter{
+		EmployeeID: r.URL.Query().Get("employee_id"),
+		Status:     payroll.TimesheetStatus(r.URL.Query().Get("status")),
+	}
+	if fromDate := r.URL.Query().Get("from_date"); fromDate != "" {
+		if parsed, err := time.Parse("2006-01-02", fromDate); err == nil {
+			filter.From = &parsed
+		}
+	}
+	if toDate := r.URL.Query().Get("to_date"); toDate != "" {
+		if parsed, err := time.Parse("2006-01-02", toDate); err == nil {
+			filter.To = &parsed
+		}
+	}
+
+	entries, err := h.payrollService.ListTimesheetEntries(r.Context(), schemaName, tenantID, filter)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+// SaveTimesheet records the hours an employee worked on a day
+// @Summary Save timesheet entry
+// @Description Record regular, overtime, night and public holiday hours for an employee on one day. A pending entry for the same day is replaced; approved days cannot be changed.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body payroll.CreateTimesheetEntryRequest true "Timesheet entry"
+// @Success 200 {object} payroll.TimesheetEntry
+// @Success 201 {object} payroll.TimesheetEntry
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/timesheets [post]
+func (h *Handlers) SaveTimesheet(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req payroll.CreateTimesheetEntryRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	entry, created, err := h.payrollService.SaveTimesheetEntry(r.Context(), schemaName, tenantID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	respondJSON(w, status, entry)
+}
+
+// ApproveTimesheets approves pending timesheet entries
+// @Summary Approve timesheets
+// @Description Approve the pending timesheet entries of a date range so payroll calculation pays them
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body payroll.ApproveTimesheetsRequest true "Date range and optional employee"
+// @Success 200 {object} payroll.ApproveTimesheetsResult
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/timesheets/approve [post]
+func (h *Handlers) ApproveTimesheets(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	claims, _ := auth.GetClaims(r.Context())
+
+	var req payroll.ApproveTimesheetsRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	result, err := h.payrollService.ApproveTimesheets(r.Context(), schemaName, tenantID, claims.UserID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// ListEmploymentEvents returns an employee's employment register history
+// @Summary List employment register events
+// @Description List an employee's employment register (TÖR) history: starts, ends, suspensions and working-time changes in date order
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param employeeID path string true "Employee ID"
+// @Success 200 {array} payroll.EmploymentEvent
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/employees/{employeeID}/employment-events [get]
+func (h *Handlers) ListEmploymentEvents(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	employeeID := chi.URLParam(r, "employeeID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	events, err := h.payrollService.ListEmploymentEvents(r.Context(), schemaName, tenantID, employeeID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, events)
+}
+
+// CreateEmploymentEvent records an employment register event for an employee
+// @Summary Record employment register event
+// @Description Append a start, end (with termination code), suspension start or end, or working-time change to an employee's employment register history. END events also set the employee end date.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param employeeID path string true "Employee ID"
+// @Param request body payroll.CreateEmploymentEventRequest true "Employment event"
+// @Success 201 {object} payroll.EmploymentEvent
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/employees/{employeeID}/employment-events [post]
+func (h *Handlers) CreateEmploymentEvent(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	employeeID := chi.URLParam(r, "employeeID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	claims, _ := auth.GetClaims(r.Context())
+
+	var req payroll.CreateEmploymentEventRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	event, err := h.payrollService.RecordEmploymentEvent(r.Context(), schemaName, tenantID, employeeID, claims.UserID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, event)
+}
+
+// ListPendingEmploymentRegister returns employees with unexported register events
+// @Summary List pending employment register changes
+// @Description List employees whose employment register events have not been exported to TÖR yet
+// @Tags Payroll
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Success 200 {array} payroll.EmploymentRegisterPendingEmployee
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/employment-register/pending [get]
+func (h *Handlers) ListPendingEmploymentRegister(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	pending, err := h.payrollService.ListPendingEmploymentRegisterEmployees(r.Context(), schemaName, tenantID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, pending)
+}
+
+// ExportEmploymentRegister generates the TÖR bulk-upload file
+// @Summary Export employment register file
+// @Description Generate the Tax Board employment register (TÖR) bulk-upload CSV for events dated in a range and mark them exported. Already exported events are included only when include_exported is set.
+// @Tags Payroll
+// @Accept json
+// @Produce text/csv
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body payroll.ExportEmploymentRegisterRequest true "Date range"
+// @Success 200 {file} binary
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/employment-register/export [post]
+func (h *Handlers) ExportEmploymentRegister(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	var req payroll.ExportEmploymentRegisterRequest
+	if err := decodeJSON(r, &req); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	export, err := h.payrollService.ExportEmploymentRegisterCSV(r.Context(), schemaName, tenantID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", export.FileName))
+	_, _ = w.Write(export.Content)
+}
+
 // GetPayslips returns all payslips for a payroll run
 // @Summary Get payslips
 // @Description Get all payslips for a specific payroll run
@@ -4059,19 +4929,24 @@ func (h *Handlers) GetPayslipPDF(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.writePayslipPDF(w, r, tenantID, selected, run)
+}
+
+// writePayslipPDF renders one payslip of a payroll run as a PDF download.
+func (h *Handlers) writePayslipPDF(w http.ResponseWriter, r *http.Request, tenantID string, payslip *payroll.Payslip, run *payroll.PayrollRun) {
 	tenantRecord, err := h.tenantService.GetTenant(r.Context(), tenantID)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to get tenant")
 		return
 	}
 
-	pdfBytes, err := generatePayslipPDF(h.pdfService, selected, run, tenantRecord)
+	pdfBytes, err := generatePayslipPDF(h.pdfService, payslip, run, tenantRecord)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
 		return
 	}
 
-	filename := fmt.Sprintf("payslip-%04d-%02d-%s.pdf", run.PeriodYear, run.PeriodMonth, safeArchiveFileName(payslipID))
+	filename := fmt.Sprintf("payslip-%04d-%02d-%s.pdf", run.PeriodYear, run.PeriodMonth, safeArchiveFileName(payslip.ID))
 	w.Header().Set("Content-Type", "application/pdf")
 	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
 	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
@@ -4732,6 +5607,7 @@ func (h *Handlers) GetQuotePDF(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+	quote.Contact = h.pdfContact(r.Context(), tenantID, schemaName, quote.Contact, quote.ContactID)
 	pdfBytes, err := generateQuotePDF(h.pdfService, quote, t, pdfSettings)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
@@ -4749,7 +5625,7 @@ func (h *Handlers) GetQuotePDF(w http.ResponseWriter, r *http.Request) {
 
 // UpdateQuote updates a quote
 // @Summary Update quote
-// @Description Update a draft quote
+// @Description Update a quote. Drafts are edited in place; editing a sent, rejected or expired quote keeps the sent revision frozen and starts the next revision as a draft. Accepted and converted quotes cannot be updated.
 // @Tags Quotes
 // @Accept json
 // @Produce json
@@ -4808,7 +5684,7 @@ func (h *Handlers) DeleteQuote(w http.ResponseWriter, r *http.Request) {
 
 // SendQuote marks a quote as sent
 // @Summary Send quote
-// @Description Mark a quote as sent to the customer, optionally requiring approved quote evidence first
+// @Description Mark a draft quote as sent to the customer and freeze its content as a revision, optionally requiring approved quote evidence first
 // @Tags Quotes
 // @Accept json
 // @Produce json
@@ -4897,7 +5773,7 @@ func (h *Handlers) requireApprovedCommercialEvidence(ctx context.Context, schema
 
 // AcceptQuote marks a quote as accepted
 // @Summary Accept quote
-// @Description Mark a quote as accepted by the customer
+// @Description Mark a quote as accepted by the customer and emit a quote.accepted webhook event
 // @Tags Quotes
 // @Produce json
 // @Security BearerAuth
@@ -4915,13 +5791,16 @@ func (h *Handlers) AcceptQuote(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if quote, err := h.quotesService.GetByID(r.Context(), tenantID, schemaName, quoteID); err == nil {
+		h.emitQuoteAnswerEvent(plugin.EventQuoteAccepted, tenantID, quote, quoteAnswerSourceUser)
+	}
 
 	respondJSON(w, http.StatusOK, map[string]string{"status": "accepted"})
 }
 
 // RejectQuote marks a quote as rejected
 // @Summary Reject quote
-// @Description Mark a quote as rejected by the customer
+// @Description Mark a quote as rejected by the customer and emit a quote.rejected webhook event
 // @Tags Quotes
 // @Produce json
 // @Security BearerAuth
@@ -4939,13 +5818,16 @@ func (h *Handlers) RejectQuote(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	if quote, err := h.quotesService.GetByID(r.Context(), tenantID, schemaName, quoteID); err == nil {
+		h.emitQuoteAnswerEvent(plugin.EventQuoteRejected, tenantID, quote, quoteAnswerSourceUser)
+	}
 
 	respondJSON(w, http.StatusOK, map[string]string{"status": "rejected"})
 }
 
 // ConvertQuoteToInvoice creates a draft sales invoice from an accepted quote.
 // @Summary Convert quote to invoice
-// @Description Create a draft sales invoice from an accepted quote and mark the quote converted
+// @Description Create a draft sales invoice from an accepted quote, for every remaining quantity or in instalments by line quantity, percentage or prepayment. Later invoices net off prepayments and the quote is marked converted once fully invoiced
 // @Tags Quotes
 // @Accept json
 // @Produce json
@@ -4999,51 +5881,69 @@ func (h *Handlers) ConvertQuoteToInvoice(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	notes := strings.TrimSpace(req.Notes)
-	if notes == "" {
-		notes = quote.Notes
-	}
-	invoiceReq := invoicing.CreateInvoiceRequest{
-		InvoiceType:  invoicing.InvoiceTypeSales,
-		ContactID:    quote.ContactID,
-		IssueDate:    req.IssueDate,
-		DueDate:      req.DueDate,
-		Currency:     quote.Currency,
-		ExchangeRate: quote.ExchangeRate,
-		Reference:    quote.QuoteNumber,
-		Notes:        notes,
-		UserID:       req.UserID,
-	}
-	for _, line := range quote.Lines {
-		invoiceReq.Lines = append(invoiceReq.Lines, invoicing.CreateInvoiceLineRequest{
-			Description:     line.Description,
-			Quantity:        line.Quantity,
-			Unit:            line.Unit,
-			UnitPrice:       line.UnitPrice,
-			DiscountPercent: line.DiscountPercent,
-			VATRate:         line.VATRate,
-			ProductID:       line.ProductID,
-		})
+	invoiceReq, plan, err := h.quotesService.PlanInvoice(r.Context(), tenantID, schemaName, quote, &req)
+	if errors.Is(err, invoicing.ErrNothingToInvoice) {
+		respondError(w, http.StatusConflict, "quote has nothing left to invoice")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	invoice, err := h.invoicingService.Create(r.Context(), tenantID, schemaName, &invoiceReq)
+	invoice, err := h.invoicingService.Create(r.Context(), tenantID, schemaName, invoiceReq)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := h.quotesService.ConvertToInvoice(r.Context(), tenantID, schemaName, quoteID, invoice.ID); err != nil {
+	billing, err := h.quotesService.RecordInvoice(r.Context(), tenantID, schemaName, quote, plan, invoice, req.UserID)
+	if err != nil {
+		// A concurrent conversion may have billed the quote first; drop the
+		// draft so no invoice is left without a billing record.
+		if deleteErr := h.invoicingService.DeleteDraft(r.Context(), tenantID, schemaName, invoice.ID); deleteErr != nil {
+			log.Printf("Failed to delete unrecorded draft invoice %s of quote %s: %v", invoice.ID, quote.ID, deleteErr)
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to mark quote converted")
 		return
 	}
-	quote.Status = quotes.QuoteStatusConverted
-	quote.ConvertedToInvoiceID = &invoice.ID
 
 	respondJSON(w, http.StatusCreated, &quotes.QuoteInvoiceConversionResult{
 		Quote:   quote,
 		Invoice: invoice,
+		Billing: billing,
 	})
 }
 
+// ListQuoteInvoices lists the invoices billed from a quote.
+// @Summary List quote invoices
+// @Description List the full, partial and prepayment invoices billed from a quote with the prepayment amounts netted so far
+// @Tags Quotes
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param quoteID path string true "Quote ID"
+// @Success 200 {array} quotes.QuoteInvoice
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/quotes/{quoteID}/invoices [get]
+func (h *Handlers) ListQuoteInvoices(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	quoteID := chi.URLParam(r, "quoteID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	if _, err := h.quotesService.GetByID(r.Context(), tenantID, schemaName, quoteID); err != nil {
+		respondError(w, http.StatusNotFound, "Quote not found")
+		return
+	}
+	billings, err := h.quotesService.ListInvoices(r.Context(), tenantID, schemaName, quoteID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list quote invoices")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, billings)
+}
+
 // =============================================================================
 // ORDERS HANDLERS
 // =============================================================================
@@ -5254,6 +6154,7 @@ func (h *Handlers) GetOrderPDF(w http.ResponseWriter, r *http.Request) {
 	}
 
 	pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+	order.Contact = h.pdfContact(r.Context(), tenantID, schemaName, order.Contact, order.ContactID)
 	pdfBytes, err := generateOrderPDF(h.pdfService, order, t, pdfSettings)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
@@ -5915,28 +6816,143 @@ func (h *Handlers) ProcessOrder(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]string{"status": "processing"})
 }
 
-// ShipOrder marks an order as shipped
+// ShipOrder books a full or partial shipment of an order
 // @Summary Ship order
-// @Description Mark an order as shipped
+// @Description Ship open order quantities, all of them when no lines are given. Stock-tracked product lines consume the order's stock reservations and are issued from warehouse_id (default: the single reserved warehouse) with the tenant's issue costing method, posting the cost to cost_of_goods_sold_account_id (default: the product's purchase account). The order becomes SHIPPED once every line is shipped in full and PROCESSING until then. Shipped quantities limit what convert-to-invoice bills.
 // @Tags Orders
+// @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param tenantID path string true "Tenant ID"
 // @Param orderID path string true "Order ID"
-// @Success 200 {object} object{status=string}
+// @Param request body orders.ShipOrderRequest false "Shipment lines and accounts"
+// @Success 201 {object} orders.OrderShipment
 // @Failure 400 {object} object{error=string}
+// @Failure 401 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
 // @Router /tenants/{tenantID}/orders/{orderID}/ship [post]
 func (h *Handlers) ShipOrder(w http.ResponseWriter, r *http.Request) {
 	tenantID := chi.URLParam(r, "tenantID")
 	orderID := chi.URLParam(r, "orderID")
-	schemaName := h.getSchemaName(r.Context(), tenantID)
+	tenantRecord, err := h.tenantService.GetTenant(r.Context(), tenantID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Tenant not found")
+		return
+	}
+	schemaName := tenantRecord.SchemaName
+
+	claims, ok := auth.GetClaims(r.Context())
+	if !ok {
+		respondError(w, http.StatusUnauthorized, "Invalid or missing authentication")
+		return
+	}
 
-	if err := h.ordersService.Ship(r.Context(), tenantID, schemaName, orderID); err != nil {
+	var req orders.ShipOrderRequest
+	if r.Body != nil && r.ContentLength != 0 {
+		if err := decodeJSON(r, &req); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+	req.UserID = claims.UserID
+	req.CostingMethod = tenantInventoryIssueCostingMethod(tenantRecord, "")
+	if h.rejectLockedPeriod(w, r.Context(), tenantID, time.Now()) {
+		return
+	}
+
+	shipment, err := h.ordersService.Ship(r.Context(), tenantID, schemaName, orderID, &req)
+	if err != nil {
+		if errors.Is(err, orders.ErrOrderNotFound) {
+			respondError(w, http.StatusNotFound, "Order not found")
+			return
+		}
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{"status": "shipped"})
+	respondJSON(w, http.StatusCreated, shipment)
+}
+
+// ListOrderShipments returns the shipments booked for an order
+// @Summary List order shipments
+// @Description List the shipments of an order with shipped quantities, lots and issued cost per line
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param orderID path string true "Order ID"
+// @Success 200 {array} orders.OrderShipment
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/orders/{orderID}/shipments [get]
+func (h *Handlers) ListOrderShipments(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	orderID := chi.URLParam(r, "orderID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	shipments, err := h.ordersService.ListShipments(r.Context(), tenantID, schemaName, orderID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list order shipments")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, shipments)
+}
+
+// GetOrderDeliveryNotePDF generates the delivery note PDF of an order shipment
+// @Summary Get delivery note PDF
+// @Description Download the delivery note of one order shipment as PDF
+// @Tags Orders
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param orderID path string true "Order ID"
+// @Param shipmentID path string true "Shipment ID"
+// @Success 200 {file} binary
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/orders/{orderID}/shipments/{shipmentID}/delivery-note [get]
+func (h *Handlers) GetOrderDeliveryNotePDF(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	orderID := chi.URLParam(r, "orderID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	order, err := h.ordersService.GetByID(r.Context(), tenantID, schemaName, orderID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Order not found")
+		return
+	}
+	shipment, err := h.ordersService.GetShipment(r.Context(), tenantID, schemaName, orderID, chi.URLParam(r, "shipmentID"))
+	if err != nil {
+		if errors.Is(err, orders.ErrOrderShipmentNotFound) {
+			respondError(w, http.StatusNotFound, "Shipment not found")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to get shipment")
+		return
+	}
+
+	t, err := h.tenantService.GetTenant(r.Context(), tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tenant")
+		return
+	}
+
+	pdfSettings := h.pdfService.PDFSettingsFromTenant(t)
+	order.Contact = h.pdfContact(r.Context(), tenantID, schemaName, order.Contact, order.ContactID)
+	pdfBytes, err := generateDeliveryNotePDF(h.pdfService, order, shipment, t, pdfSettings)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
+		return
+	}
+
+	filename := "delivery-note-" + shipment.ShipmentNumber + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdfBytes)
 }
 
 // DeliverOrder marks an order as delivered
@@ -5987,9 +7003,9 @@ func (h *Handlers) CancelOrder(w http.ResponseWriter, r *http.Request) {
 	respondJSON(w, http.StatusOK, map[string]string{"status": "canceled"})
 }
 
-// ConvertOrderToInvoice creates a draft sales invoice from a delivered order.
+// ConvertOrderToInvoice creates a draft sales invoice from an order.
 // @Summary Convert order to invoice
-// @Description Create a draft sales invoice from a delivered order and mark the order converted
+// @Description Create a draft sales invoice from an order. Without lines, percent or a prepayment kind a delivered order is billed for the shipped quantities not yet invoiced; confirmed orders can be billed in instalments by line quantity, percentage or prepayment, and later invoices net off prepayments. The order is marked converted once fully invoiced
 // @Tags Orders
 // @Accept json
 // @Produce json
@@ -6034,7 +7050,15 @@ func (h *Handlers) ConvertOrderToInvoice(w http.ResponseWriter, r *http.Request)
 		respondError(w, http.StatusNotFound, "Order not found")
 		return
 	}
-	if order.Status != orders.OrderStatusDelivered {
+	switch {
+	case req.IsPartial():
+		switch order.Status {
+		case orders.OrderStatusConfirmed, orders.OrderStatusProcessing, orders.OrderStatusShipped, orders.OrderStatusDelivered:
+		default:
+			respondError(w, http.StatusConflict, "order must be confirmed before partial invoicing")
+			return
+		}
+	case order.Status != orders.OrderStatusDelivered:
 		respondError(w, http.StatusConflict, "order must be delivered before conversion")
 		return
 	}
@@ -6043,50 +7067,73 @@ func (h *Handlers) ConvertOrderToInvoice(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	notes := strings.TrimSpace(req.Notes)
-	if notes == "" {
-		notes = order.Notes
-	}
-	invoiceReq := invoicing.CreateInvoiceRequest{
-		InvoiceType:  invoicing.InvoiceTypeSales,
-		ContactID:    order.ContactID,
-		IssueDate:    req.IssueDate,
-		DueDate:      req.DueDate,
-		Currency:     order.Currency,
-		ExchangeRate: order.ExchangeRate,
-		Reference:    order.OrderNumber,
-		Notes:        notes,
-		UserID:       req.UserID,
+	invoiceReq, plan, err := h.ordersService.PlanInvoice(r.Context(), tenantID, schemaName, order, &req)
+	if errors.Is(err, invoicing.ErrNothingToInvoice) {
+		if req.IsPartial() {
+			respondError(w, http.StatusConflict, "order has nothing left to invoice")
+		} else {
+			respondError(w, http.StatusConflict, "order has no shipped quantities to invoice")
+		}
+		return
 	}
-	for _, line := range order.Lines {
-		invoiceReq.Lines = append(invoiceReq.Lines, invoicing.CreateInvoiceLineRequest{
-			Description:     line.Description,
-			Quantity:        line.Quantity,
-			Unit:            line.Unit,
-			UnitPrice:       line.UnitPrice,
-			DiscountPercent: line.DiscountPercent,
-			VATRate:         line.VATRate,
-			ProductID:       line.ProductID,
-		})
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
-	invoice, err := h.invoicingService.Create(r.Context(), tenantID, schemaName, &invoiceReq)
+	invoice, err := h.invoicingService.Create(r.Context(), tenantID, schemaName, invoiceReq)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := h.ordersService.ConvertToInvoice(r.Context(), tenantID, schemaName, orderID, invoice.ID); err != nil {
+	billing, err := h.ordersService.RecordInvoice(r.Context(), tenantID, schemaName, order, plan, invoice, req.UserID)
+	if err != nil {
+		// A concurrent conversion may have billed the order first; drop the
+		// draft so no invoice is left without a billing record.
+		if deleteErr := h.invoicingService.DeleteDraft(r.Context(), tenantID, schemaName, invoice.ID); deleteErr != nil {
+			log.Printf("Failed to delete unrecorded draft invoice %s of order %s: %v", invoice.ID, order.ID, deleteErr)
+		}
 		respondError(w, http.StatusInternalServerError, "Failed to mark order converted")
 		return
 	}
-	order.ConvertedToInvoiceID = &invoice.ID
 
 	respondJSON(w, http.StatusCreated, &orders.OrderInvoiceConversionResult{
 		Order:   order,
 		Invoice: invoice,
+		Billing: billing,
 	})
 }
 
+// ListOrderInvoices lists the invoices billed from an order.
+// @Summary List order invoices
+// @Description List the full, partial and prepayment invoices billed from an order with the prepayment amounts netted so far
+// @Tags Orders
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param orderID path string true "Order ID"
+// @Success 200 {array} orders.OrderInvoice
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/orders/{orderID}/invoices [get]
+func (h *Handlers) ListOrderInvoices(w http.ResponseWriter, r *http.Request) {
+	tenantID := chi.URLParam(r, "tenantID")
+	orderID := chi.URLParam(r, "orderID")
+	schemaName := h.getSchemaName(r.Context(), tenantID)
+
+	if _, err := h.ordersService.GetByID(r.Context(), tenantID, schemaName, orderID); err != nil {
+		respondError(w, http.StatusNotFound, "Order not found")
+		return
+	}
+	billings, err := h.ordersService.ListInvoices(r.Context(), tenantID, schemaName, orderID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list order invoices")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, billings)
+}
+
 // =============================================================================
 // FIXED ASSETS HANDLERS
 // =============================================================================
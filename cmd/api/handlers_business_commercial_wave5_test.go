@@ -103,6 +103,9 @@ func wave5Order(status orders.OrderStatus) *orders.Order {
 		}},
 	}
 	o.Calculate()
+	if status == orders.OrderStatusShipped || status == orders.OrderStatusDelivered {
+		o.Lines[0].ShippedQuantity = o.Lines[0].Quantity
+	}
 	return o
 }
 
@@ -110,7 +113,7 @@ func wave5QuoteLine() quotes.CreateQuoteLineRequest {
 	return quotes.CreateQuoteLineRequest{
 		Description: "Consulting",
 		Quantity:    decimal.NewFromInt(1),
-		UnitPrice:   decimal.NewFromInt(100),
+		UnitPrice:   new(decimal.NewFromInt(100)),
 		VATRate:     decimal.NewFromInt(22),
 	}
 }
@@ -119,7 +122,7 @@ func wave5OrderLine() orders.CreateOrderLineRequest {
 	return orders.CreateOrderLineRequest{
 		Description: "Implementation",
 		Quantity:    decimal.NewFromInt(1),
-		UnitPrice:   decimal.NewFromInt(100),
+		UnitPrice:   new(decimal.NewFromInt(100)),
 		VATRate:     decimal.NewFromInt(22),
 	}
 }
@@ -825,9 +828,10 @@ func TestBusinessCommercialWave5QuoteConvertBranches(t *testing.T) {
 
 	t.Run("mark converted error", func(t *testing.T) {
 		repo := &wave5QuoteConvertFailRepository{mockQuotesRepository: newMockQuotesRepository(), convertErr: errors.New("convert marker down")}
+		invoiceRepo := newMockInvoicingRepository()
 		h := &Handlers{
 			quotesService:    quotes.NewServiceWithRepository(repo),
-			invoicingService: invoicing.NewServiceWithRepository(newMockInvoicingRepository(), nil),
+			invoicingService: invoicing.NewServiceWithRepository(invoiceRepo, nil),
 		}
 		tenantRepo := newMockTenantRepository()
 		h.tenantService = tenant.NewServiceWithRepository(tenantRepo)
@@ -839,6 +843,7 @@ func TestBusinessCommercialWave5QuoteConvertBranches(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		assert.Contains(t, rr.Body.String(), "Failed to mark quote converted")
+		assert.Empty(t, invoiceRepo.invoices)
 	})
 }
 
@@ -1185,9 +1190,10 @@ func TestBusinessCommercialWave5OrderConvertBranches(t *testing.T) {
 
 	t.Run("mark converted error", func(t *testing.T) {
 		repo := &wave5OrderConvertFailRepository{mockOrdersRepository: newMockOrdersRepository(), convertErr: errors.New("convert marker down")}
+		invoiceRepo := newMockInvoicingRepository()
 		h := &Handlers{
 			ordersService:    orders.NewServiceWithRepository(repo),
-			invoicingService: invoicing.NewServiceWithRepository(newMockInvoicingRepository(), nil),
+			invoicingService: invoicing.NewServiceWithRepository(invoiceRepo, nil),
 		}
 		tenantRepo := newMockTenantRepository()
 		h.tenantService = tenant.NewServiceWithRepository(tenantRepo)
@@ -1199,6 +1205,7 @@ func TestBusinessCommercialWave5OrderConvertBranches(t *testing.T) {
 
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		assert.Contains(t, rr.Body.String(), "Failed to mark order converted")
+		assert.Empty(t, invoiceRepo.invoices)
 	})
 }
 
@@ -1270,11 +1277,11 @@ type wave5QuoteConvertFailRepository struct {
 	convertErr error
 }
 
-func (m *wave5QuoteConvertFailRepository) SetConvertedToInvoice(ctx context.Context, schemaName, tenantID, quoteID, invoiceID string) error {
-	if m.convertErr != nil {
+func (m *wave5QuoteConvertFailRepository) RecordInvoice(ctx context.Context, schemaName string, billing *quotes.QuoteInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	if completes && m.convertErr != nil {
 		return m.convertErr
 	}
-	return m.mockQuotesRepository.SetConvertedToInvoice(ctx, schemaName, tenantID, quoteID, invoiceID)
+	return m.mockQuotesRepository.RecordInvoice(ctx, schemaName, billing, quantities, deductions, completes)
 }
 
 type wave5OrderConvertFailRepository struct {
@@ -1282,11 +1289,11 @@ type wave5OrderConvertFailRepository struct {
 	convertErr error
 }
 
-func (m *wave5OrderConvertFailRepository) SetConvertedToInvoice(ctx context.Context, schemaName, tenantID, orderID, invoiceID string) error {
-	if m.convertErr != nil {
+func (m *wave5OrderConvertFailRepository) RecordInvoice(ctx context.Context, schemaName string, billing *orders.OrderInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	if completes && m.convertErr != nil {
 		return m.convertErr
 	}
-	return m.mockOrdersRepository.SetConvertedToInvoice(ctx, schemaName, tenantID, orderID, invoiceID)
+	return m.mockOrdersRepository.RecordInvoice(ctx, schemaName, billing, quantities, deductions, completes)
 }
 
 type wave5OrderStockListFailRepository struct {
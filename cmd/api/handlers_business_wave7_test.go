@@ -44,7 +44,7 @@ func wave7InvoiceLine() invoicing.CreateInvoiceLineRequest {
 	return invoicing.CreateInvoiceLineRequest{
 		Description: "Consulting",
 		Quantity:    decimal.NewFromInt(1),
-		UnitPrice:   decimal.NewFromInt(100),
+		UnitPrice:   new(decimal.NewFromInt(100)),
 		VATRate:     decimal.NewFromInt(22),
 	}
 }
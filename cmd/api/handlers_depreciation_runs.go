@@ -0,0 +1,217 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+)
+
+// PreviewDepreciationRun previews the depreciation run for a month.
+// @Summary Preview depreciation run
+// @Description Compute depreciation for every active fixed asset not yet depreciated in the month, with totals per asset category. Month-end close can use posted_run and issues to check whether depreciation is done.
+// @Tags Fixed Assets
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param year query int true "Period year"
+// @Param month query int true "Period month (1-12)"
+// @Success 200 {object} assets.DepreciationRunPreview
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/depreciation-runs/preview [get]
+func (h *Handlers) PreviewDepreciationRun(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	year, month, ok := parseDepreciationRunMonth(w, r)
+	if !ok {
+		return
+	}
+
+	preview, err := h.assetsService.PreviewDepreciationRun(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, year, month)
+	if err != nil {
+		respondDepreciationRunError(w, err, "Failed to preview depreciation run")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, preview)
+}
+
+// ListDepreciationRuns lists depreciation runs.
+// @Summary List depreciation runs
+// @Description List posted and reversed depreciation runs, newest period first
+// @Tags Fixed Assets
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param year query int false "Filter by period year"
+// @Success 200 {array} assets.DepreciationRun
+// @Failure 400 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/depreciation-runs [get]
+func (h *Handlers) ListDepreciationRuns(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	year := 0
+	if yearParam := strings.TrimSpace(r.URL.Query().Get("year")); yearParam != "" {
+		parsed, err := strconv.Atoi(yearParam)
+		if err != nil || parsed < 1900 {
+			respondError(w, http.StatusBadRequest, "Invalid year")
+			return
+		}
+		year = parsed
+	}
+
+	runs, err := h.assetsService.ListDepreciationRuns(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, year)
+	if err != nil {
+		respondDepreciationRunError(w, err, "Failed to list depreciation runs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, runs)
+}
+
+// CreateDepreciationRun posts the depreciation run for a month.
+// @Summary Run depreciation for a month
+// @Description Post depreciation for every active fixed asset not yet depreciated in the month as one aggregated journal entry or one entry per asset. Repeating the request for a month that already has a posted run returns that run with status 200.
+// @Tags Fixed Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body assets.CreateDepreciationRunRequest true "Depreciation run period and posting mode"
+// @Success 200 {object} assets.DepreciationRun
+// @Success 201 {object} assets.DepreciationRun
+// @Failure 400 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/depreciation-runs [post]
+func (h *Handlers) CreateDepreciationRun(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req assets.CreateDepreciationRunRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	_, periodEnd, err := assets.DepreciationRunPeriod(req.Year, req.Month)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if h.rejectLockedPeriod(w, r.Context(), tenantCtx.tenantID, periodEnd) {
+		return
+	}
+
+	run, created, err := h.assetsService.RunDepreciation(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondDepreciationRunError(w, err, "")
+		return
+	}
+
+	status := http.StatusOK
+	if created {
+		status = http.StatusCreated
+	}
+	respondJSON(w, status, run)
+}
+
+// GetDepreciationRun returns a depreciation run.
+// @Summary Get depreciation run
+// @Description Get a depreciation run with its per-asset depreciation entries
+// @Tags Fixed Assets
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param runID path string true "Depreciation run ID"
+// @Success 200 {object} assets.DepreciationRun
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/depreciation-runs/{runID} [get]
+func (h *Handlers) GetDepreciationRun(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	run, err := h.assetsService.GetDepreciationRun(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "runID"))
+	if err != nil {
+		respondDepreciationRunError(w, err, "Failed to get depreciation run")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, run)
+}
+
+// ReverseDepreciationRun reverses a posted depreciation run.
+// @Summary Reverse depreciation run
+// @Description Void the run's journal entries, remove its depreciation entries and restore asset book values as one unit
+// @Tags Fixed Assets
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param runID path string true "Depreciation run ID"
+// @Param request body assets.ReverseDepreciationRunRequest true "Reversal reason"
+// @Success 200 {object} assets.DepreciationRun
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/depreciation-runs/{runID}/reverse [post]
+func (h *Handlers) ReverseDepreciationRun(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	runID := chi.URLParam(r, "runID")
+
+	var req assets.ReverseDepreciationRunRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	run, err := h.assetsService.GetDepreciationRun(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, runID)
+	if err != nil {
+		respondDepreciationRunError(w, err, "Failed to get depreciation run")
+		return
+	}
+	if h.rejectLockedPeriod(w, r.Context(), tenantCtx.tenantID, run.PeriodEnd) {
+		return
+	}
+
+	run, err = h.assetsService.ReverseDepreciationRun(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, runID, &req)
+	if err != nil {
+		respondDepreciationRunError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, run)
+}
+
+// respondDepreciationRunError maps depreciation run errors to responses. An
+// empty fallback reports any other error as a bad request with its message.
+func respondDepreciationRunError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, assets.ErrDepreciationRunNotFound):
+		respondError(w, http.StatusNotFound, "Depreciation run not found")
+	case errors.Is(err, assets.ErrDepreciationRunsUnavailable), errors.Is(err, assets.ErrAssetAccountingInvalid):
+		respondError(w, http.StatusBadRequest, err.Error())
+	case fallback == "":
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+func parseDepreciationRunMonth(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	year, yearErr := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("year")))
+	month, monthErr := strconv.Atoi(strings.TrimSpace(r.URL.Query().Get("month")))
+	if yearErr != nil || monthErr != nil {
+		respondError(w, http.StatusBadRequest, "year and month are required")
+		return 0, 0, false
+	}
+	if _, _, err := assets.DepreciationRunPeriod(year, month); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return 0, 0, false
+	}
+	return year, month, true
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+// depreciationRunHandlerRepository adds depreciation run storage to the
+// handler assets mock.
+type depreciationRunHandlerRepository struct {
+	*mockAssetsRepository
+	runs map[string]*assets.DepreciationRun
+}
+
+func (m *depreciationRunHandlerRepository) CreateDepreciationRun(_ context.Context, _ string, run *assets.DepreciationRun) error {
+	copied := *run
+	m.runs[run.ID] = &copied
+	return nil
+}
+
+func (m *depreciationRunHandlerRepository) GetDepreciationRun(_ context.Context, _, tenantID, runID string) (*assets.DepreciationRun, error) {
+	run, ok := m.runs[runID]
+	if !ok || run.TenantID != tenantID {
+		return nil, assets.ErrDepreciationRunNotFound
+	}
+	copied := *run
+	return &copied, nil
+}
+
+func (m *depreciationRunHandlerRepository) GetPostedDepreciationRun(_ context.Context, _, tenantID string, periodStart, periodEnd time.Time) (*assets.DepreciationRun, error) {
+	for _, run := range m.runs {
+		if run.TenantID == tenantID && run.Status == assets.DepreciationRunPosted && run.PeriodStart.Equal(periodStart) && run.PeriodEnd.Equal(periodEnd) {
+			copied := *run
+			return &copied, nil
+		}
+	}
+	return nil, assets.ErrDepreciationRunNotFound
+}
+
+func (m *depreciationRunHandlerRepository) ListDepreciationRuns(_ context.Context, _, tenantID string, year int) ([]assets.DepreciationRun, error) {
+	var result []assets.DepreciationRun
+	for _, run := range m.runs {
+		if run.TenantID == tenantID && (year == 0 || run.PeriodStart.Year() == year) {
+			result = append(result, *run)
+		}
+	}
+	return result, nil
+}
+
+func (m *depreciationRunHandlerRepository) MarkDepreciationRunReversed(_ context.Context, _, _, runID, userID, reason string, reversedAt time.Time) error {
+	run := m.runs[runID]
+	run.Status = assets.DepreciationRunReversed
+	run.ReversedBy = &userID
+	run.ReversalReason = reason
+	run.ReversedAt = &reversedAt
+	return nil
+}
+
+func (m *depreciationRunHandlerRepository) ListPeriodDepreciationEntries(_ context.Context, _, _ string, periodStart, periodEnd time.Time) ([]assets.DepreciationEntry, error) {
+	var result []assets.DepreciationEntry
+	for _, entries := range m.depreciationEntries {
+		for _, entry := range entries {
+			if !entry.PeriodStart.Before(periodStart) && !entry.PeriodEnd.After(periodEnd) {
+				result = append(result, entry)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *depreciationRunHandlerRepository) ListDepreciationRunEntries(_ context.Context, _, _, runID string) ([]assets.DepreciationEntry, error) {
+	var result []assets.DepreciationEntry
+	for _, entries := range m.depreciationEntries {
+		for _, entry := range entries {
+			if entry.RunID != nil && *entry.RunID == runID {
+				result = append(result, entry)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (m *depreciationRunHandlerRepository) DeleteDepreciationRunEntries(_ context.Context, _, _, runID string) error {
+	for assetID, entries := range m.depreciationEntries {
+		kept := entries[:0]
+		for _, entry := range entries {
+			if entry.RunID == nil || *entry.RunID != runID {
+				kept = append(kept, entry)
+			}
+		}
+		m.depreciationEntries[assetID] = kept
+	}
+	return nil
+}
+
+func setupDepreciationRunHandlers(t *testing.T) (*Handlers, *depreciationRunHandlerRepository, *mockTenantRepository) {
+	t.Helper()
+
+	h, assetsRepo, tenantRepo := setupAssetsTestHandlers()
+	repo := &depreciationRunHandlerRepository{mockAssetsRepository: assetsRepo, runs: map[string]*assets.DepreciationRun{}}
+	h.assetsService = assets.NewServiceWithRepositoryAndAccounting(repo, newAssetHandlerAccounting())
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{ID: "tenant-1", SchemaName: "tenant_test"}
+
+	repo.assets["asset-1"] = &assets.FixedAsset{
+		ID:                            "asset-1",
+		TenantID:                      "tenant-1",
+		AssetNumber:                   "FA-00001",
+		Name:                          "Dell Laptop",
+		Status:                        assets.AssetStatusActive,
+		PurchaseDate:                  time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC),
+		PurchaseCost:                  decimal.NewFromInt(3600),
+		UsefulLifeMonths:              36,
+		DepreciationMethod:            assets.DepreciationStraightLine,
+		BookValue:                     decimal.NewFromInt(3600),
+		DepreciationExpenseAccountID:  stringPtr("depreciation-expense"),
+		AccumulatedDepreciationAcctID: stringPtr("accumulated-depreciation"),
+	}
+	return h, repo, tenantRepo
+}
+
+func depreciationRunRequest(t *testing.T, method, target string, body any, params map[string]string) *http.Request {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	req := httptest.NewRequest(method, target, &payload)
+	if params == nil {
+		params = map[string]string{}
+	}
+	params["tenantID"] = "tenant-1"
+	req = withURLParams(req, params)
+	return req.WithContext(contextWithClaims(req.Context(), createTestClaims("user-1", "test@example.com", "tenant-1", "owner")))
+}
+
+func TestDepreciationRunHandlersLifecycle(t *testing.T) {
+	h, repo, _ := setupDepreciationRunHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.PreviewDepreciationRun(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs/preview?year=2026&month=3", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var preview assets.DepreciationRunPreview
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &preview))
+	assert.Equal(t, 1, preview.AssetCount)
+	assert.True(t, preview.TotalAmount.Equal(decimal.NewFromInt(100)))
+	assert.Nil(t, preview.PostedRun)
+
+	body := assets.CreateDepreciationRunRequest{Year: 2026, Month: 3}
+	rr = httptest.NewRecorder()
+	h.CreateDepreciationRun(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/depreciation-runs", body, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var run assets.DepreciationRun
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &run))
+	assert.Equal(t, assets.DepreciationPostingAggregated, run.PostingMode)
+	assert.Equal(t, "user-1", run.CreatedBy)
+
+	rr = httptest.NewRecorder()
+	h.CreateDepreciationRun(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/depreciation-runs", body, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Len(t, repo.runs, 1)
+	assert.Len(t, repo.depreciationEntries["asset-1"], 1)
+
+	rr = httptest.NewRecorder()
+	h.ListDepreciationRuns(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs?year=2026", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var runs []assets.DepreciationRun
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &runs))
+	assert.Len(t, runs, 1)
+
+	rr = httptest.NewRecorder()
+	h.GetDepreciationRun(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs/"+run.ID, nil, map[string]string{"runID": run.ID}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var loaded assets.DepreciationRun
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &loaded))
+	assert.Len(t, loaded.Entries, 1)
+
+	rr = httptest.NewRecorder()
+	h.GetDepreciationRun(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs/missing", nil, map[string]string{"runID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	// The handler test ledger cannot void journal entries, so the reversal is refused.
+	rr = httptest.NewRecorder()
+	h.ReverseDepreciationRun(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/depreciation-runs/"+run.ID+"/reverse", assets.ReverseDepreciationRunRequest{Reason: "wrong month"}, map[string]string{"runID": run.ID}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "journal reversal is unavailable")
+}
+
+func TestDepreciationRunHandlersRejectInvalidRequests(t *testing.T) {
+	h, _, tenantRepo := setupDepreciationRunHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.PreviewDepreciationRun(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs/preview?year=2026", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.ListDepreciationRuns(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs?year=abc", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreateDepreciationRun(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/depreciation-runs", assets.CreateDepreciationRunRequest{Year: 2026, Month: 13}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreateDepreciationRun(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/depreciation-runs", assets.CreateDepreciationRunRequest{Year: 2026, Month: 3, PostingMode: "DAILY"}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	lockDate := "2026-03-31"
+	tenantRepo.tenants["tenant-1"].Settings.PeriodLockDate = &lockDate
+	rr = httptest.NewRecorder()
+	h.CreateDepreciationRun(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/depreciation-runs", assets.CreateDepreciationRunRequest{Year: 2026, Month: 3}, nil))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+
+	plain, _, _ := setupAssetsTestHandlers()
+	rr = httptest.NewRecorder()
+	plain.ListDepreciationRuns(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/depreciation-runs", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), assets.ErrDepreciationRunsUnavailable.Error())
+}
@@ -0,0 +1,291 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/expenses"
+	"github.com/HMB-research/open-accounting/internal/payroll"
+	"github.com/HMB-research/open-accounting/internal/plugin"
+)
+
+// GetSelfEmployee returns the employee record linked to the caller.
+// @Summary Get own employee record
+// @Description Return the payroll employee record linked to the caller's employee membership
+// @Tags Self-Service
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Success 200 {object} payroll.Employee
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/employee [get]
+func (h *Handlers) GetSelfEmployee(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	employee, err := h.payrollService.GetEmployee(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, selfServiceEmployeeID(r.Context()))
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Employee not found")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, employee)
+}
+
+// ListSelfPayslips returns the caller's released payslips.
+// @Summary List own payslips
+// @Description List the caller's payslips from approved, paid, or declared payroll runs
+// @Tags Self-Service
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param year query int false "Filter by payroll period year"
+// @Success 200 {array} payroll.EmployeePayslip
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/payslips [get]
+func (h *Handlers) ListSelfPayslips(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	year, ok := parseSelfServiceYear(w, r, 0)
+	if !ok {
+		return
+	}
+
+	payslips, err := h.payrollService.ListEmployeePayslips(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, selfServiceEmployeeID(r.Context()), year)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list payslips")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, payslips)
+}
+
+// GetSelfPayslipPDF downloads one of the caller's released payslips.
+// @Summary Download own payslip PDF
+// @Description Generate and download a PDF for one of the caller's released payslips
+// @Tags Self-Service
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param payslipID path string true "Payslip ID"
+// @Success 200 {file} binary
+// @Failure 403 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/payslips/{payslipID}/pdf [get]
+func (h *Handlers) GetSelfPayslipPDF(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	payslipID := chi.URLParam(r, "payslipID")
+
+	payslip, run, err := h.payrollService.GetEmployeePayslip(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, selfServiceEmployeeID(r.Context()), payslipID)
+	if errors.Is(err, payroll.ErrPayslipNotFound) {
+		respondError(w, http.StatusNotFound, "Payslip not found")
+		return
+	}
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get payslip")
+		return
+	}
+
+	h.writePayslipPDF(w, r, tenantCtx.tenantID, payslip, run)
+}
+
+// ListSelfLeaveBalances returns the caller's leave balances.
+// @Summary List own leave balances
+// @Description Get the caller's leave balances for a year (defaults to the current year)
+// @Tags Self-Service
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param year query int false "Balance year"
+// @Success 200 {array} payroll.LeaveBalance
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/leave-balances [get]
+func (h *Handlers) ListSelfLeaveBalances(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	year, ok := parseSelfServiceYear(w, r, time.Now().Year())
+	if !ok {
+		return
+	}
+
+	balances, err := h.absenceService.GetLeaveBalances(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, selfServiceEmployeeID(r.Context()), year)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list leave balances")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, balances)
+}
+
+// ListSelfLeaveRecords returns the caller's leave requests.
+// @Summary List own leave records
+// @Description List the caller's leave requests and their approval status
+// @Tags Self-Service
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param year query int false "Filter by year"
+// @Success 200 {array} payroll.LeaveRecord
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/leave-records [get]
+func (h *Handlers) ListSelfLeaveRecords(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	year, ok := parseSelfServiceYear(w, r, 0)
+	if !ok {
+		return
+	}
+
+	records, err := h.absenceService.ListLeaveRecords(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, selfServiceEmployeeID(r.Context()), year)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list leave records")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, records)
+}
+
+// CreateSelfLeaveRecord requests leave for the caller.
+// @Summary Request leave
+// @Description Create a pending leave request for the caller. The employee_id field is ignored; the request always applies to the caller's employee record and waits for approval.
+// @Tags Self-Service
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body payroll.CreateLeaveRecordRequest true "Leave request details"
+// @Success 201 {object} payroll.LeaveRecord
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/leave-records [post]
+func (h *Handlers) CreateSelfLeaveRecord(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req payroll.CreateLeaveRecordRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.EmployeeID = selfServiceEmployeeID(r.Context())
+
+	record, err := h.absenceService.CreateLeaveRecord(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, userIDFromRequest(r), &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, record)
+}
+
+// ListSelfExpenses returns the caller's expense claims.
+// @Summary List own expense claims
+// @Description List expense claims attributed to the caller's employee record
+// @Tags Self-Service
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param status query string false "Expense status"
+// @Success 200 {array} expenses.Expense
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/expenses [get]
+func (h *Handlers) ListSelfExpenses(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	result, err := h.expensesService.ListExpenses(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, expenses.ListExpensesFilter{
+		Status:     expenses.ExpenseStatus(strings.TrimSpace(r.URL.Query().Get("status"))),
+		EmployeeID: selfServiceEmployeeID(r.Context()),
+	})
+	if err != nil {
+		respondExpenseError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// SubmitSelfExpense creates and submits an expense claim for the caller.
+// @Summary Submit own expense claim
+// @Description Create an expense claim attributed to the caller and submit it for approval. The employee_id, expense_account_id and payment_account_id fields are ignored: claims post to the tenant's expense_claim_account_id and employee_reimbursement_account_id settings.
+// @Tags Self-Service
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body expenses.CreateExpenseRequest true "Expense details"
+// @Success 201 {object} expenses.Expense
+// @Failure 400 {object} object{error=string}
+// @Failure 403 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/self/expenses [post]
+func (h *Handlers) SubmitSelfExpense(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	userID := userIDFromRequest(r)
+
+	var req expenses.CreateExpenseRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	employeeID := selfServiceEmployeeID(r.Context())
+	req.EmployeeID = &employeeID
+	req.UserID = userID
+
+	// Employees cannot choose ledger accounts; claims always debit the
+	// tenant's claim expense account and credit the reimbursement payable.
+	t, err := h.tenantService.GetTenant(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to get tenant")
+		return
+	}
+	req.ExpenseAccountID = strings.TrimSpace(t.Settings.ExpenseClaimAccountID)
+	req.PaymentAccountID = strings.TrimSpace(t.Settings.EmployeeReimbursementAccountID)
+	if req.ExpenseAccountID == "" || req.PaymentAccountID == "" {
+		respondError(w, http.StatusConflict, "Expense claim accounts are not configured for this tenant")
+		return
+	}
+
+	if h.rejectLockedPeriod(w, r.Context(), tenantCtx.tenantID, expenseOperationDate(req.ExpenseDate)) {
+		return
+	}
+
+	expense, err := h.expensesService.CreateExpense(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, &req)
+	if err != nil {
+		respondExpenseError(w, err)
+		return
+	}
+	h.emitWebhookEvent(plugin.EventExpenseCreated, tenantCtx.tenantID, expense)
+
+	expense, err = h.expensesService.SubmitExpense(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, expense.ID, &expenses.ExpenseActionRequest{UserID: userID})
+	if err != nil {
+		respondExpenseError(w, err)
+		return
+	}
+	h.emitWebhookEvent(plugin.EventExpenseSubmitted, tenantCtx.tenantID, expense)
+
+	respondJSON(w, http.StatusCreated, expense)
+}
+
+func parseSelfServiceYear(w http.ResponseWriter, r *http.Request, fallback int) (int, bool) {
+	yearParam := strings.TrimSpace(r.URL.Query().Get("year"))
+	if yearParam == "" {
+		return fallback, true
+	}
+	year, err := strconv.Atoi(yearParam)
+	if err != nil || year < 1900 {
+		respondError(w, http.StatusBadRequest, "Invalid year")
+		return 0, false
+	}
+	return year, true
+}
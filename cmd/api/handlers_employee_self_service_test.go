@@ -0,0 +1,227 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/auth"
+	"github.com/HMB-research/open-accounting/internal/expenses"
+	"github.com/HMB-research/open-accounting/internal/payroll"
+	internalpdf "github.com/HMB-research/open-accounting/internal/pdf"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+type selfServiceRouterTest struct {
+	router      *chi.Mux
+	tokens      *auth.TokenService
+	absenceRepo *payroll.MockAbsenceRepository
+	expenseRepo *expenseHandlerRepository
+	tenantRepo  *mockTenantRepository
+}
+
+func setupSelfServiceRouterTest(t *testing.T) *selfServiceRouterTest {
+	t.Helper()
+
+	h, importRepo, absenceRepo := setupPayrollImportHandlerTest(t)
+	tenantRepo := newMockTenantRepository()
+	tenantRepo.addTestTenant("tenant-1", "Tenant One", "tenant-one")
+	tenantRepo.tenantUsers["tenant-1"] = []tenant.TenantUser{
+		{TenantID: "tenant-1", UserID: "emp-user", Role: tenant.RoleEmployee, EmployeeID: "emp-1", IsActive: true, CreatedAt: time.Now()},
+		{TenantID: "tenant-1", UserID: "viewer-1", Role: tenant.RoleViewer, IsActive: true, CreatedAt: time.Now()},
+	}
+	h.tenantService = newTestTenantService(tenantRepo)
+
+	importRepo.seedEmployee(payrollImportEmployee("emp-1", "E001"))
+	importRepo.seedEmployee(payrollImportEmployee("emp-2", "E002"))
+	importRepo.payrollRuns["run-jan"] = &payroll.PayrollRun{ID: "run-jan", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 1, Status: payroll.PayrollPaid}
+	importRepo.payrollRuns["run-feb"] = &payroll.PayrollRun{ID: "run-feb", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 2, Status: payroll.PayrollCalculated}
+	for _, runID := range []string{"run-jan", "run-feb"} {
+		for _, employeeID := range []string{"emp-1", "emp-2"} {
+			importRepo.payslips = append(importRepo.payslips, payroll.Payslip{
+				ID:           runID + "-" + employeeID,
+				TenantID:     "tenant-1",
+				PayrollRunID: runID,
+				EmployeeID:   employeeID,
+				GrossSalary:  decimal.NewFromInt(2000),
+			})
+		}
+	}
+
+	absenceRepo.AbsenceTypes["annual"] = &payroll.AbsenceType{ID: "annual", TenantID: "tenant-1", Code: "ANNUAL_LEAVE", Name: "Annual leave", IsPaid: true}
+	for _, employeeID := range []string{"emp-1", "emp-2"} {
+		absenceRepo.LeaveBalances["tenant-1-"+employeeID+"-annual-2026"] = &payroll.LeaveBalance{
+			ID:            "balance-" + employeeID,
+			TenantID:      "tenant-1",
+			EmployeeID:    employeeID,
+			AbsenceTypeID: "annual",
+			Year:          2026,
+			EntitledDays:  decimal.NewFromInt(28),
+			RemainingDays: decimal.NewFromInt(28),
+		}
+	}
+
+	expenseHandlers, expenseRepo, _ := setupExpenseHandlers()
+	h.expensesService = expenseHandlers.expensesService
+
+	original := generatePayslipPDF
+	generatePayslipPDF = func(_ *internalpdf.Service, payslip *payroll.Payslip, _ *payroll.PayrollRun, _ *tenant.Tenant) ([]byte, error) {
+		return []byte("%PDF-" + payslip.ID), nil
+	}
+	t.Cleanup(func() { generatePayslipPDF = original })
+
+	t.Setenv("DEMO_MODE", "true")
+	t.Setenv("CORS_DEBUG", "")
+	tokens := auth.NewTokenService("secret", time.Minute, time.Hour)
+	router := setupRouter(&Config{AllowedOrigins: []string{"http://localhost:5173"}}, h, tokens)
+
+	return &selfServiceRouterTest{router: router, tokens: tokens, absenceRepo: absenceRepo, expenseRepo: expenseRepo, tenantRepo: tenantRepo}
+}
+
+func (s *selfServiceRouterTest) do(t *testing.T, userID, method, path string, body any) *httptest.ResponseRecorder {
+	t.Helper()
+
+	token, err := s.tokens.GenerateAccessToken(userID, userID+"@example.com", "tenant-1", tenant.RoleAdmin)
+	require.NoError(t, err)
+
+	var payload bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&payload).Encode(body))
+	}
+	req := httptest.NewRequest(method, "/api/v1/tenants/tenant-1"+path, &payload)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, req)
+	return rec
+}
+
+func decodeSelfServiceResponse[T any](t *testing.T, rec *httptest.ResponseRecorder, wantStatus int) T {
+	t.Helper()
+
+	require.Equal(t, wantStatus, rec.Code, rec.Body.String())
+	var result T
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	return result
+}
+
+func TestEmployeeSelfServicePayslipsAndLeave(t *testing.T) {
+	s := setupSelfServiceRouterTest(t)
+
+	employee := decodeSelfServiceResponse[payroll.Employee](t, s.do(t, "emp-user", http.MethodGet, "/self/employee", nil), http.StatusOK)
+	assert.Equal(t, "emp-1", employee.ID)
+
+	payslips := decodeSelfServiceResponse[[]payroll.EmployeePayslip](t, s.do(t, "emp-user", http.MethodGet, "/self/payslips?year=2026", nil), http.StatusOK)
+	require.Len(t, payslips, 1)
+	assert.Equal(t, "run-jan-emp-1", payslips[0].ID)
+	assert.Equal(t, payroll.PayrollPaid, payslips[0].RunStatus)
+
+	rec := s.do(t, "emp-user", http.MethodGet, "/self/payslips/run-jan-emp-1/pdf", nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "%PDF-run-jan-emp-1", rec.Body.String())
+	for _, payslipID := range []string{"run-jan-emp-2", "run-feb-emp-1"} {
+		assert.Equal(t, http.StatusNotFound, s.do(t, "emp-user", http.MethodGet, "/self/payslips/"+payslipID+"/pdf", nil).Code, payslipID)
+	}
+	assert.Equal(t, http.StatusBadRequest, s.do(t, "emp-user", http.MethodGet, "/self/payslips?year=abc", nil).Code)
+
+	balances := decodeSelfServiceResponse[[]payroll.LeaveBalance](t, s.do(t, "emp-user", http.MethodGet, "/self/leave-balances?year=2026", nil), http.StatusOK)
+	require.Len(t, balances, 1)
+	assert.Equal(t, "emp-1", balances[0].EmployeeID)
+
+	record := decodeSelfServiceResponse[payroll.LeaveRecord](t, s.do(t, "emp-user", http.MethodPost, "/self/leave-records", payroll.CreateLeaveRecordRequest{
+		EmployeeID:    "emp-2",
+		AbsenceTypeID: "annual",
+		StartDate:     time.Date(2026, 7, 6, 0, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2026, 7, 10, 0, 0, 0, 0, time.UTC),
+		TotalDays:     decimal.NewFromInt(5),
+		WorkingDays:   decimal.NewFromInt(5),
+	}), http.StatusCreated)
+	assert.Equal(t, "emp-1", record.EmployeeID)
+	assert.Equal(t, payroll.LeavePending, record.Status)
+	assert.Equal(t, "emp-user", record.RequestedBy)
+	assert.True(t, s.absenceRepo.LeaveBalances["tenant-1-emp-1-annual-2026"].PendingDays.Equal(decimal.NewFromInt(5)))
+	assert.True(t, s.absenceRepo.LeaveBalances["tenant-1-emp-2-annual-2026"].PendingDays.IsZero())
+
+	records := decodeSelfServiceResponse[[]payroll.LeaveRecord](t, s.do(t, "emp-user", http.MethodGet, "/self/leave-records", nil), http.StatusOK)
+	require.Len(t, records, 1)
+	assert.Equal(t, record.ID, records[0].ID)
+}
+
+func TestEmployeeSelfServiceExpenses(t *testing.T) {
+	s := setupSelfServiceRouterTest(t)
+	claim := expenses.CreateExpenseRequest{
+		ExpenseDate: time.Date(2026, 5, 30, 0, 0, 0, 0, time.UTC),
+		Merchant:    "Taxi",
+		Amount:      decimal.RequireFromString("18.40"),
+	}
+	rec := s.do(t, "emp-user", http.MethodPost, "/self/expenses", claim)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Expense claim accounts are not configured")
+
+	s.tenantRepo.tenants["tenant-1"].Settings.ExpenseClaimAccountID = "travel-expense"
+	s.tenantRepo.tenants["tenant-1"].Settings.EmployeeReimbursementAccountID = "employee-payable"
+	otherEmployee := "emp-2"
+	s.expenseRepo.expenses["other"] = &expenses.Expense{ID: "other", TenantID: "tenant-1", EmployeeID: &otherEmployee, Status: expenses.StatusSubmitted}
+
+	expense := decodeSelfServiceResponse[expenses.Expense](t, s.do(t, "emp-user", http.MethodPost, "/self/expenses", expenses.CreateExpenseRequest{
+		EmployeeID:       &otherEmployee,
+		ExpenseDate:      time.Date(2026, 5, 30, 0, 0, 0, 0, time.UTC),
+		Merchant:         "Taxi",
+		Description:      "Client visit",
+		ExpenseAccountID: "expense-account",
+		PaymentAccountID: "cash-account",
+		Amount:           decimal.RequireFromString("18.40"),
+	}), http.StatusCreated)
+	assert.Equal(t, expenses.StatusSubmitted, expense.Status)
+	require.NotNil(t, expense.EmployeeID)
+	assert.Equal(t, "emp-1", *expense.EmployeeID)
+	assert.Equal(t, "travel-expense", expense.ExpenseAccountID)
+	assert.Equal(t, "employee-payable", expense.PaymentAccountID)
+
+	claims := decodeSelfServiceResponse[[]expenses.Expense](t, s.do(t, "emp-user", http.MethodGet, "/self/expenses", nil), http.StatusOK)
+	require.Len(t, claims, 1)
+	assert.Equal(t, expense.ID, claims[0].ID)
+
+	assert.Equal(t, http.StatusBadRequest, s.do(t, "emp-user", http.MethodPost, "/self/expenses", expenses.CreateExpenseRequest{Merchant: "Taxi"}).Code)
+}
+
+func TestEmployeeSelfServiceAccessBoundaries(t *testing.T) {
+	s := setupSelfServiceRouterTest(t)
+
+	rec := s.do(t, "viewer-1", http.MethodGet, "/self/payslips", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Insufficient permissions")
+	assert.Equal(t, http.StatusForbidden, s.do(t, "outsider", http.MethodGet, "/self/payslips", nil).Code)
+
+	rec = s.do(t, "emp-user", http.MethodGet, "", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), errEmployeeSelfServiceOnly)
+
+	tenantRoutes := 0
+	err := chi.Walk(s.router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		if !strings.HasPrefix(route, "/api/v1/tenants/{tenantID}/") || strings.HasPrefix(route, "/api/v1/tenants/{tenantID}/self/") {
+			return nil
+		}
+		if method == http.MethodOptions || method == http.MethodHead {
+			return nil
+		}
+
+		tenantRoutes++
+		path := strings.TrimPrefix(materializeTenantRouteForAuthorizationTest(route), "/api/v1/tenants/tenant-1")
+		rec := s.do(t, "emp-user", method, path, nil)
+		assert.Equalf(t, http.StatusForbidden, rec.Code, "%s %s should reject employee accounts: %s", method, route, rec.Body.String())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Positive(t, tenantRoutes)
+}
@@ -22,6 +22,7 @@ import (
 // @Security BearerAuth
 // @Param tenantID path string true "Tenant ID"
 // @Param status query string false "Expense status"
+// @Param employee_id query string false "Filter by employee ID"
 // @Param limit query int false "Maximum expenses to return"
 // @Success 200 {array} expenses.Expense
 // @Failure 400 {object} object{error=string}
@@ -41,8 +42,9 @@ func (h *Handlers) ListExpenses(w http.ResponseWriter, r *http.Request) {
 	}
 
 	result, err := h.expensesService.ListExpenses(r.Context(), schemaName, tenantID, expenses.ListExpensesFilter{
-		Status: expenses.ExpenseStatus(strings.TrimSpace(r.URL.Query().Get("status"))),
-		Limit:  limit,
+		Status:     expenses.ExpenseStatus(strings.TrimSpace(r.URL.Query().Get("status"))),
+		EmployeeID: strings.TrimSpace(r.URL.Query().Get("employee_id")),
+		Limit:      limit,
 	})
 	if err != nil {
 		respondExpenseError(w, err)
@@ -283,6 +283,9 @@ func (r *expenseHandlerRepository) List(_ context.Context, _, tenantID string, f
 		if filter.Status != "" && expense.Status != filter.Status {
 			continue
 		}
+		if filter.EmployeeID != "" && (expense.EmployeeID == nil || *expense.EmployeeID != filter.EmployeeID) {
+			continue
+		}
 		result = append(result, *expense)
 	}
 	return result, nil
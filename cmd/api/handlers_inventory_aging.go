@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+// GetInventoryAgingReport returns on-hand stock bucketed by age and expiry.
+// @Summary Get inventory aging report
+// @Description Bucket on-hand tracked stock value by age since receipt (0-30, 31-90, 91-180, 181-365, over 365 days) and by days to expiry, per warehouse and product category. Stock on hand is matched to each lot's newest receipts. Lots are flagged EXPIRED, EXPIRING (within expiring_within_days) or SLOW_MOVING (oldest remaining receipt and last issue older than slow_moving_days). Net realisable value is zero for expired lots and otherwise the sales price less selling_cost_percent, further reduced by slow_moving_discount_percent for slow-moving lots; the shortfall below cost is the proposed write-down.
+// @Tags Inventory
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param as_of_date query string false "As-of date (YYYY-MM-DD, default today)"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param category_id query string false "Product category ID"
+// @Param slow_moving_days query int false "Days without issues before a lot is slow-moving (default 180)"
+// @Param expiring_within_days query int false "Days to expiry flagged as expiring (default 90)"
+// @Param selling_cost_percent query number false "Costs to sell as a percentage of the sales price (default 0)"
+// @Param slow_moving_discount_percent query number false "Price reduction for slow-moving lots in percent (default 50)"
+// @Param format query string false "Response format: json, csv, xlsx, or pdf"
+// @Success 200 {object} inventory.InventoryAgingReport
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/aging [get]
+func (h *Handlers) GetInventoryAgingReport(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	format, err := reportResponseFormat(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req, err := inventoryAgingRequestFromQuery(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.inventoryService.GetInventoryAgingReport(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fileStem := "inventory-aging-" + report.AsOfDate.Format("2006-01-02")
+	if format == "csv" {
+		content, err := exportInventoryAgingCSV(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export inventory aging report CSV")
+			return
+		}
+		respondReportCSV(w, fileStem+".csv", content)
+		return
+	}
+	if format == "xlsx" {
+		content, err := exportInventoryAgingXLSX(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export inventory aging report XLSX")
+			return
+		}
+		respondReportXLSX(w, fileStem+".xlsx", content)
+		return
+	}
+	if format == "pdf" {
+		content, err := exportInventoryAgingPDF(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export inventory aging report PDF")
+			return
+		}
+		respondReportPDF(w, fileStem+".pdf", content)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// ProposeInventoryWriteDown drafts the net realisable value write-down entry.
+// @Summary Propose inventory write-down
+// @Description Recompute the inventory aging report and draft a journal entry that brings the inventory allowance (contra-asset) account to the required write-down: the EXPENSE account is debited for an increase and credited for a release. The entry stays in DRAFT until the accountant posts it through the journal entry post endpoint.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body inventory.ProposeInventoryWriteDownRequest true "Aging assumptions and accounts"
+// @Success 201 {object} inventory.InventoryWriteDownProposal
+// @Failure 400 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/aging/write-down [post]
+func (h *Handlers) ProposeInventoryWriteDown(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req inventory.ProposeInventoryWriteDownRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+	if req.AsOfDate.IsZero() {
+		req.AsOfDate = time.Now()
+	}
+	if h.rejectLockedPeriod(w, r.Context(), tenantCtx.tenantID, req.AsOfDate) {
+		return
+	}
+
+	proposal, err := h.inventoryService.ProposeInventoryWriteDown(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, proposal)
+}
+
+func inventoryAgingRequestFromQuery(r *http.Request) (*inventory.InventoryAgingRequest, error) {
+	query := r.URL.Query()
+	req := &inventory.InventoryAgingRequest{
+		WarehouseID: strings.TrimSpace(query.Get("warehouse_id")),
+		CategoryID:  strings.TrimSpace(query.Get("category_id")),
+	}
+	asOfDate, err := inventorySubledgerAsOfDate(r)
+	if err != nil {
+		return nil, err
+	}
+	req.AsOfDate = asOfDate
+	for name, target := range map[string]*int{"slow_moving_days": &req.SlowMovingDays, "expiring_within_days": &req.ExpiringWithinDays} {
+		raw := strings.TrimSpace(query.Get(name))
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%s must be a positive integer", name)
+		}
+		*target = parsed
+	}
+	if raw := strings.TrimSpace(query.Get("selling_cost_percent")); raw != "" {
+		parsed, err := decimal.NewFromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("selling_cost_percent must be a number")
+		}
+		req.SellingCostPercent = parsed
+	}
+	if raw := strings.TrimSpace(query.Get("slow_moving_discount_percent")); raw != "" {
+		parsed, err := decimal.NewFromString(raw)
+		if err != nil {
+			return nil, fmt.Errorf("slow_moving_discount_percent must be a number")
+		}
+		req.SlowMovingDiscountPercent = &parsed
+	}
+	return req, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+const (
+	agingHandlerWriteDownAccountID = "77777777-7777-4777-8777-777777777777"
+	agingHandlerAllowanceAccountID = "88888888-8888-4888-8888-888888888888"
+)
+
+type agingHandlerLedger struct {
+	created *accounting.CreateJournalEntryRequest
+}
+
+func (l *agingHandlerLedger) ListAccounts(context.Context, string, string, bool) ([]accounting.Account, error) {
+	return []accounting.Account{
+		{ID: agingHandlerWriteDownAccountID, AccountType: accounting.AccountTypeExpense},
+		{ID: agingHandlerAllowanceAccountID, AccountType: accounting.AccountTypeAsset},
+	}, nil
+}
+
+func (l *agingHandlerLedger) CreateJournalEntry(_ context.Context, _, _ string, req *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error) {
+	l.created = req
+	return &accounting.JournalEntry{ID: "write-down-journal", EntryNumber: "JE-00009", Status: accounting.StatusDraft}, nil
+}
+
+func (l *agingHandlerLedger) PostJournalEntry(context.Context, string, string, string, string, string) error {
+	return nil
+}
+
+// setupInventoryAgingHandlers holds one expired lot of 4 units at 2.50.
+func setupInventoryAgingHandlers(t *testing.T) (*Handlers, *agingHandlerLedger, *tenant.Tenant) {
+	t.Helper()
+
+	h, repo, tenantRepo := setupInventoryTestHandlers()
+	ledger := &agingHandlerLedger{}
+	h.inventoryService = inventory.NewServiceWithRepositoryAndAccounting(repo, ledger)
+	tenantRecord := &tenant.Tenant{ID: "tenant-1", SchemaName: "tenant_test"}
+	tenantRepo.tenants["tenant-1"] = tenantRecord
+	repo.products[apiInventoryStockProductID] = &inventory.Product{
+		ID:             apiInventoryStockProductID,
+		TenantID:       "tenant-1",
+		Code:           "MILK",
+		Name:           "Milk",
+		ProductType:    inventory.ProductTypeGoods,
+		SalesPrice:     decimal.NewFromInt(4),
+		TrackInventory: true,
+		IsActive:       true,
+	}
+	repo.warehouses[apiInventoryStockWarehouseID] = &inventory.Warehouse{ID: apiInventoryStockWarehouseID, TenantID: "tenant-1", Code: "MAIN", Name: "Main"}
+	repo.movements[apiInventoryStockProductID] = []inventory.InventoryMovement{{
+		ID:           "mov-1",
+		TenantID:     "tenant-1",
+		ProductID:    apiInventoryStockProductID,
+		WarehouseID:  apiInventoryStockWarehouseID,
+		MovementType: inventory.MovementTypeIn,
+		Quantity:     decimal.NewFromInt(4),
+		UnitCost:     decimal.RequireFromString("2.50"),
+		LotNumber:    "LOT-1",
+		ExpiryDate:   "2026-03-15",
+		MovementDate: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}}
+	return h, ledger, tenantRecord
+}
+
+func TestInventoryAgingHandlers(t *testing.T) {
+	h, ledger, _ := setupInventoryAgingHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetInventoryAgingReport(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/aging?as_of_date=2026-03-31&selling_cost_percent=10&slow_moving_discount_percent=40", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var report inventory.InventoryAgingReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.True(t, report.SlowMovingDiscountPercent.Equal(decimal.NewFromInt(40)))
+	require.Len(t, report.Lines, 1)
+	assert.Equal(t, inventory.InventoryAgingStatusExpired, report.Lines[0].Status)
+	assert.True(t, report.WriteDownAmount.Equal(decimal.NewFromInt(10)))
+
+	rr = httptest.NewRecorder()
+	h.GetInventoryAgingReport(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/aging?as_of_date=2026-03-31&format=csv", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "inventory-aging-2026-03-31.csv")
+	assert.Contains(t, rr.Body.String(), "LOT-1")
+	assert.Contains(t, rr.Body.String(), "EXPIRED")
+
+	rr = httptest.NewRecorder()
+	h.ProposeInventoryWriteDown(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/aging/write-down", inventory.ProposeInventoryWriteDownRequest{
+		InventoryAgingRequest: inventory.InventoryAgingRequest{AsOfDate: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		WriteDownAccountID:    agingHandlerWriteDownAccountID,
+		AllowanceAccountID:    agingHandlerAllowanceAccountID,
+	}, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var proposal inventory.InventoryWriteDownProposal
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &proposal))
+	assert.True(t, proposal.Adjustment.Equal(decimal.NewFromInt(10)))
+	assert.Equal(t, "write-down-journal", proposal.JournalID)
+	assert.Equal(t, string(accounting.StatusDraft), proposal.JournalStatus)
+	require.NotNil(t, ledger.created)
+	assert.Equal(t, "user-1", ledger.created.UserID)
+}
+
+func TestInventoryAgingHandlersErrors(t *testing.T) {
+	h, ledger, tenantRecord := setupInventoryAgingHandlers(t)
+
+	for target, want := range map[string]string{
+		"/tenants/tenant-1/inventory/aging?slow_moving_days=0":                           "slow_moving_days must be a positive integer",
+		"/tenants/tenant-1/inventory/aging?selling_cost_percent=abc":                     "selling_cost_percent must be a number",
+		"/tenants/tenant-1/inventory/aging?slow_moving_discount_percent=x":               "slow_moving_discount_percent must be a number",
+		"/tenants/tenant-1/inventory/aging?as_of_date=31.03.2026":                        "as_of_date must be in YYYY-MM-DD format",
+		"/tenants/tenant-1/inventory/aging?format=doc":                                   "format",
+		"/tenants/tenant-1/inventory/aging?category_id=" + apiInventoryStockWarehouseID2: "category not found",
+	} {
+		rr := httptest.NewRecorder()
+		h.GetInventoryAgingReport(rr, depreciationRunRequest(t, http.MethodGet, target, nil, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+		assert.Contains(t, rr.Body.String(), want, target)
+	}
+
+	rr := httptest.NewRecorder()
+	h.ProposeInventoryWriteDown(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/aging/write-down", inventory.ProposeInventoryWriteDownRequest{
+		InventoryAgingRequest: inventory.InventoryAgingRequest{AsOfDate: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		WriteDownAccountID:    agingHandlerAllowanceAccountID,
+		AllowanceAccountID:    agingHandlerWriteDownAccountID,
+	}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "must reference an EXPENSE account")
+
+	lockDate := "2026-03-31"
+	tenantRecord.Settings.PeriodLockDate = &lockDate
+	rr = httptest.NewRecorder()
+	h.ProposeInventoryWriteDown(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/aging/write-down", inventory.ProposeInventoryWriteDownRequest{
+		InventoryAgingRequest: inventory.InventoryAgingRequest{AsOfDate: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		WriteDownAccountID:    agingHandlerWriteDownAccountID,
+		AllowanceAccountID:    agingHandlerAllowanceAccountID,
+	}, nil))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Nil(t, ledger.created)
+}
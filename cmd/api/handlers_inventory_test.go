@@ -346,6 +346,33 @@ func (m *mockInventoryRepository) ListMovements(ctx context.Context, schemaName,
 	return m.movements[productID], nil
 }
 
+func (m *mockInventoryRepository) HasSourceMovements(ctx context.Context, schemaName, tenantID, sourceType, sourceID string) (bool, error) {
+	if m.listMovementsErr != nil {
+		return false, m.listMovementsErr
+	}
+	for _, movements := range m.movements {
+		for _, movement := range movements {
+			if movement.TenantID == tenantID && movement.SourceType == sourceType && movement.SourceID == sourceID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (m *mockInventoryRepository) UpdateMovementCost(ctx context.Context, schemaName, tenantID, movementID string, unitCost, totalCost decimal.Decimal) error {
+	for productID, movements := range m.movements {
+		for i := range movements {
+			if movements[i].ID == movementID {
+				m.movements[productID][i].UnitCost = unitCost
+				m.movements[productID][i].TotalCost = totalCost
+				return nil
+			}
+		}
+	}
+	return errors.New("inventory movement not found")
+}
+
 func (m *mockInventoryRepository) UpdateProductStock(ctx context.Context, schemaName, tenantID, productID string, newStock decimal.Decimal) error {
 	if m.updateProductStock != nil {
 		return m.updateProductStock
@@ -150,6 +150,15 @@ func (m *mockInvoicingRepository) UpdateOverdueStatus(ctx context.Context, schem
 	return count, nil
 }
 
+func (m *mockInvoicingRepository) DeleteDraft(ctx context.Context, schemaName, tenantID, invoiceID string) error {
+	inv, ok := m.invoices[invoiceID]
+	if !ok || inv.TenantID != tenantID || inv.Status != invoicing.StatusDraft {
+		return invoicing.ErrInvoiceNotFound
+	}
+	delete(m.invoices, invoiceID)
+	return nil
+}
+
 // Helper to pad numbers
 func padNumber(n, width int) string {
 	s := ""
@@ -935,6 +944,68 @@ func TestGetInvoicePDF(t *testing.T) {
 	requirePDF(t, rr.Body.Bytes())
 }
 
+func TestGetInvoiceReminderPDF(t *testing.T) {
+	claims := createTestClaims("user-1", "test@example.com", "tenant-1", "owner")
+	setup := func(invoiceType invoicing.InvoiceType, amountPaid decimal.Decimal) (*Handlers, *invoicing.Invoice) {
+		h, tenantRepo, invoiceRepo, contactsRepo := setupInvoiceImportTestHandlers()
+		h.pdfService = pdf.NewService()
+		tenantRepo.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+		contactsRepo.contacts["contact-1"] = &contacts.Contact{ID: "contact-1", TenantID: "tenant-1", Name: "Klient OÜ", Language: "et"}
+		invoice := invoiceRepo.addTestInvoice("inv-1", "tenant-1", "contact-1", invoiceType, invoicing.StatusOverdue)
+		invoice.InvoiceNumber = "INV-00031"
+		invoice.Total = decimal.NewFromInt(122)
+		invoice.AmountPaid = amountPaid
+		return h, invoice
+	}
+	request := func(invoiceID string) *http.Request {
+		req := makeAuthenticatedRequest(http.MethodGet, "/tenants/tenant-1/invoices/"+invoiceID+"/reminder-pdf", nil, claims)
+		return withURLParams(req, map[string]string{"tenantID": "tenant-1", "invoiceID": invoiceID})
+	}
+
+	t.Run("renders reminder in contact language", func(t *testing.T) {
+		h, _ := setup(invoicing.InvoiceTypeSales, decimal.NewFromInt(22))
+		original := generateReminderPDF
+		var rendered *invoicing.Invoice
+		generateReminderPDF = func(pdfService *pdf.Service, invoice *invoicing.Invoice, tenantRecord *tenant.Tenant, pdfSettings pdf.PDFSettings, asOf time.Time) ([]byte, error) {
+			rendered = invoice
+			return original(pdfService, invoice, tenantRecord, pdfSettings, asOf)
+		}
+		t.Cleanup(func() { generateReminderPDF = original })
+
+		rr := httptest.NewRecorder()
+		h.GetInvoiceReminderPDF(rr, request("inv-1"))
+
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "reminder-INV-00031.pdf")
+		requirePDF(t, rr.Body.Bytes())
+		require.NotNil(t, rendered.Contact)
+		assert.Equal(t, "et", rendered.Contact.Language)
+	})
+
+	t.Run("rejects purchase invoices", func(t *testing.T) {
+		h, _ := setup(invoicing.InvoiceTypePurchase, decimal.Zero)
+		rr := httptest.NewRecorder()
+		h.GetInvoiceReminderPDF(rr, request("inv-1"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "sales invoices")
+	})
+
+	t.Run("rejects settled invoices", func(t *testing.T) {
+		h, _ := setup(invoicing.InvoiceTypeSales, decimal.NewFromInt(122))
+		rr := httptest.NewRecorder()
+		h.GetInvoiceReminderPDF(rr, request("inv-1"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "no outstanding balance")
+	})
+
+	t.Run("missing invoice", func(t *testing.T) {
+		h, _ := setup(invoicing.InvoiceTypeSales, decimal.Zero)
+		rr := httptest.NewRecorder()
+		h.GetInvoiceReminderPDF(rr, request("missing"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
+
 // =============================================================================
 // SendInvoice Handler Tests
 // =============================================================================
@@ -1429,3 +1500,176 @@ func TestVoidInvoice(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateCreditNote(t *testing.T) {
+	claims := &auth.Claims{UserID: "user-1", TenantID: "tenant-1", Role: tenant.RoleOwner}
+	addCreditableInvoice := func(ir *mockInvoicingRepository, status invoicing.InvoiceStatus) *invoicing.Invoice {
+		inv := ir.addTestInvoice("inv-1", "tenant-1", "contact-1", invoicing.InvoiceTypeSales, status)
+		inv.Lines = []invoicing.InvoiceLine{{
+			ID:          "line-1",
+			TenantID:    "tenant-1",
+			LineNumber:  1,
+			Description: "Consulting",
+			Quantity:    decimal.NewFromInt(4),
+			UnitPrice:   decimal.NewFromInt(25),
+			VATRate:     decimal.NewFromInt(20),
+		}}
+		return inv
+	}
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		setupMock      func(*mockTenantRepository, *mockInvoicingRepository)
+		wantStatus     int
+		wantErrContain string
+	}{
+		{
+			name: "credits selected quantity",
+			body: map[string]interface{}{
+				"issue_date": "2026-03-10T00:00:00Z",
+				"lines":      []map[string]string{{"original_line_id": "line-1", "quantity": "1"}},
+			},
+			setupMock: func(tr *mockTenantRepository, ir *mockInvoicingRepository) {
+				tr.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+				addCreditableInvoice(ir, invoicing.StatusSent)
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name: "rejects quantity above invoiced",
+			body: map[string]interface{}{
+				"lines": []map[string]string{{"original_line_id": "line-1", "quantity": "5"}},
+			},
+			setupMock: func(tr *mockTenantRepository, ir *mockInvoicingRepository) {
+				tr.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+				addCreditableInvoice(ir, invoicing.StatusSent)
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantErrContain: "exceeds remaining quantity",
+		},
+		{
+			name: "rejects draft invoice",
+			body: map[string]interface{}{},
+			setupMock: func(tr *mockTenantRepository, ir *mockInvoicingRepository) {
+				tr.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+				addCreditableInvoice(ir, invoicing.StatusDraft)
+			},
+			wantStatus:     http.StatusBadRequest,
+			wantErrContain: "DRAFT",
+		},
+		{
+			name:           "invalid body",
+			body:           "not-an-object",
+			setupMock:      func(tr *mockTenantRepository, ir *mockInvoicingRepository) {},
+			wantStatus:     http.StatusBadRequest,
+			wantErrContain: "Invalid request body",
+		},
+		{
+			name: "blocked by period lock",
+			body: map[string]interface{}{"issue_date": "2026-01-15T00:00:00Z"},
+			setupMock: func(tr *mockTenantRepository, ir *mockInvoicingRepository) {
+				lockedTenant := tr.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+				lockDate := "2026-01-31"
+				lockedTenant.Settings.PeriodLockDate = &lockDate
+				addCreditableInvoice(ir, invoicing.StatusSent)
+			},
+			wantStatus:     http.StatusConflict,
+			wantErrContain: "period locked through 2026-01-31",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, tenantRepo, invoiceRepo := setupInvoiceTestHandlers()
+			tt.setupMock(tenantRepo, invoiceRepo)
+
+			req := makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/invoices/inv-1/credit-notes", tt.body, claims)
+			req = withURLParams(req, map[string]string{"tenantID": "tenant-1", "invoiceID": "inv-1"})
+			w := httptest.NewRecorder()
+
+			h.CreateCreditNote(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, "response body: %s", w.Body.String())
+			if tt.wantErrContain != "" {
+				var resp map[string]string
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Contains(t, resp["error"], tt.wantErrContain)
+				return
+			}
+
+			var creditNote invoicing.Invoice
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&creditNote))
+			assert.Equal(t, invoicing.InvoiceTypeCreditNote, creditNote.InvoiceType)
+			require.NotNil(t, creditNote.OriginalInvoiceID)
+			assert.Equal(t, "inv-1", *creditNote.OriginalInvoiceID)
+			assert.True(t, creditNote.Total.Equal(decimal.NewFromInt(30)))
+			assert.True(t, invoiceRepo.invoices["inv-1"].AmountPaid.Equal(decimal.NewFromInt(30)))
+		})
+	}
+}
+
+func TestExportEInvoice(t *testing.T) {
+	claims := &auth.Claims{UserID: "user-1", TenantID: "tenant-1", Role: tenant.RoleOwner}
+	setup := func(status invoicing.InvoiceStatus, regCode string) *Handlers {
+		h, tenantRepo, invoiceRepo, contactsRepo := setupInvoiceImportTestHandlers()
+		tenantRecord := tenantRepo.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+		tenantRecord.Settings.RegCode = regCode
+		inv := invoiceRepo.addTestInvoice("inv-1", "tenant-1", "contact-1", invoicing.InvoiceTypeSales, status)
+		inv.InvoiceNumber = "INV-00012"
+		inv.Lines = []invoicing.InvoiceLine{{
+			ID:          "line-1",
+			TenantID:    "tenant-1",
+			LineNumber:  1,
+			Description: "Consulting",
+			Quantity:    decimal.NewFromInt(4),
+			UnitPrice:   decimal.NewFromInt(25),
+			VATRate:     decimal.NewFromInt(24),
+		}}
+		inv.Calculate()
+		contactsRepo.contacts["contact-1"] = &contacts.Contact{ID: "contact-1", TenantID: "tenant-1", Name: "Buyer AS", RegCode: "87654321"}
+		return h
+	}
+
+	tests := []struct {
+		name           string
+		body           interface{}
+		status         invoicing.InvoiceStatus
+		regCode        string
+		wantStatus     int
+		wantErrContain string
+	}{
+		{name: "exports sent invoice", body: map[string]interface{}{"invoice_ids": []string{"inv-1"}, "pay_to_iban": "EE382200221020145685"}, status: invoicing.StatusSent, regCode: "12345678", wantStatus: http.StatusOK},
+		{name: "rejects draft invoice", body: map[string]interface{}{"invoice_ids": []string{"inv-1"}}, status: invoicing.StatusDraft, regCode: "12345678", wantStatus: http.StatusBadRequest, wantErrContain: "DRAFT"},
+		{name: "requires company registry code", body: map[string]interface{}{"invoice_ids": []string{"inv-1"}}, status: invoicing.StatusSent, wantStatus: http.StatusBadRequest, wantErrContain: "registry code"},
+		{name: "rejects UBL failing EN 16931", body: map[string]interface{}{"invoice_ids": []string{"inv-1"}, "format": "UBL"}, status: invoicing.StatusSent, regCode: "12345678", wantStatus: http.StatusBadRequest, wantErrContain: "BR-11"},
+		{name: "invalid body", body: "not-an-object", status: invoicing.StatusSent, wantStatus: http.StatusBadRequest, wantErrContain: "Invalid request body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := setup(tt.status, tt.regCode)
+
+			req := makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/invoices/export-einvoice", tt.body, claims)
+			req = withURLParams(req, map[string]string{"tenantID": "tenant-1"})
+			w := httptest.NewRecorder()
+
+			h.ExportEInvoice(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code, "response body: %s", w.Body.String())
+			if tt.wantErrContain != "" {
+				var resp map[string]string
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Contains(t, resp["error"], tt.wantErrContain)
+				return
+			}
+
+			assert.Equal(t, "application/xml", w.Header().Get("Content-Type"))
+			assert.Contains(t, w.Header().Get("Content-Disposition"), "einvoice-INV-00012.xml")
+			body := w.Body.String()
+			assert.Contains(t, body, "<RegNumber>12345678</RegNumber>")
+			assert.Contains(t, body, "<Name>Buyer AS</Name>")
+			assert.Contains(t, body, "<PayToAccount>EE382200221020145685</PayToAccount>")
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -35,6 +37,10 @@ type mockOrdersRepository struct {
 	updateErr         error
 	statusErr         error
 	deleteErr         error
+	shipments         []orders.OrderShipment
+	shipmentListErr   error
+	billings          []orders.OrderInvoice
+	billingErr        error
 }
 
 func newMockOrdersRepository() *mockOrdersRepository {
@@ -182,6 +188,84 @@ func (m *mockOrdersRepository) ReleaseStockReservation(ctx context.Context, sche
 	return reservation, nil
 }
 
+func (m *mockOrdersRepository) GenerateShipmentNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	return fmt.Sprintf("DN-%05d", len(m.shipments)+1), nil
+}
+
+func (m *mockOrdersRepository) CreateShipment(ctx context.Context, schemaName string, shipment *orders.OrderShipment, status orders.OrderStatus) error {
+	if m.statusErr != nil {
+		return m.statusErr
+	}
+	order, ok := m.orders[shipment.OrderID]
+	if !ok || order.TenantID != shipment.TenantID {
+		return orders.ErrOrderNotFound
+	}
+	for _, shipped := range shipment.Lines {
+		for i := range order.Lines {
+			if order.Lines[i].ID == shipped.OrderLineID {
+				order.Lines[i].ShippedQuantity = order.Lines[i].ShippedQuantity.Add(shipped.Quantity)
+			}
+		}
+	}
+	order.Status = status
+	m.shipments = append(m.shipments, *shipment)
+	return nil
+}
+
+func (m *mockOrdersRepository) ListShipments(ctx context.Context, schemaName, tenantID, orderID string) ([]orders.OrderShipment, error) {
+	if m.shipmentListErr != nil {
+		return nil, m.shipmentListErr
+	}
+	result := []orders.OrderShipment{}
+	for _, shipment := range m.shipments {
+		if shipment.TenantID == tenantID && shipment.OrderID == orderID {
+			result = append(result, shipment)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOrdersRepository) RecordInvoice(ctx context.Context, schemaName string, billing *orders.OrderInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	if m.billingErr != nil {
+		return m.billingErr
+	}
+	if completes {
+		if err := m.SetConvertedToInvoice(ctx, schemaName, billing.TenantID, billing.OrderID, billing.InvoiceID); err != nil {
+			return err
+		}
+	}
+	for i := range m.billings {
+		m.billings[i].NettedAmount = m.billings[i].NettedAmount.Add(deductions[m.billings[i].ID])
+	}
+	m.billings = append(m.billings, *billing)
+	return nil
+}
+
+func (m *mockOrdersRepository) ListInvoices(ctx context.Context, schemaName, tenantID, orderID string) ([]orders.OrderInvoice, error) {
+	if m.billingErr != nil {
+		return nil, m.billingErr
+	}
+	result := []orders.OrderInvoice{}
+	for _, billing := range m.billings {
+		if billing.TenantID == tenantID && billing.OrderID == orderID {
+			result = append(result, billing)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockOrdersRepository) GetInvoiceByInvoiceID(ctx context.Context, schemaName, tenantID, invoiceID string) (*orders.OrderInvoice, error) {
+	if m.billingErr != nil {
+		return nil, m.billingErr
+	}
+	for i := range m.billings {
+		if m.billings[i].TenantID == tenantID && m.billings[i].InvoiceID == invoiceID {
+			return &m.billings[i], nil
+		}
+	}
+	return nil, orders.ErrOrderInvoiceNotFound
+}
+
 func orderStockReservationKey(orderID, productID, warehouseID string) string {
 	return orderID + "|" + productID + "|" + warehouseID
 }
@@ -1055,7 +1139,7 @@ func TestOrderStatusTransitions(t *testing.T) {
 			initialStatus:  orders.OrderStatusProcessing,
 			handler:        "ship",
 			expectedStatus: orders.OrderStatusShipped,
-			wantStatus:     http.StatusOK,
+			wantStatus:     http.StatusCreated,
 		},
 		{
 			name:           "deliver shipped order",
@@ -1086,7 +1170,12 @@ func TestOrderStatusTransitions(t *testing.T) {
 				ID:       "order-1",
 				TenantID: "tenant-1",
 				Status:   tt.initialStatus,
-				Lines:    []orders.OrderLine{},
+				Lines: []orders.OrderLine{{
+					ID:          "line-1",
+					LineNumber:  1,
+					Description: "Consulting",
+					Quantity:    decimal.NewFromInt(2),
+				}},
 			}
 
 			req := httptest.NewRequest(http.MethodPost, "/tenants/tenant-1/orders/order-1/"+tt.handler, nil)
@@ -1110,7 +1199,7 @@ func TestOrderStatusTransitions(t *testing.T) {
 
 			assert.Equal(t, tt.wantStatus, rr.Code)
 
-			if tt.wantStatus == http.StatusOK {
+			if tt.wantStatus == http.StatusOK || tt.wantStatus == http.StatusCreated {
 				updatedOrder := repo.orders["order-1"]
 				assert.Equal(t, tt.expectedStatus, updatedOrder.Status)
 			}
@@ -1151,6 +1240,17 @@ func TestConvertOrderToInvoice(t *testing.T) {
 				UnitPrice:       decimal.NewFromInt(120),
 				DiscountPercent: decimal.NewFromInt(5),
 				VATRate:         decimal.NewFromInt(22),
+				ShippedQuantity: decimal.NewFromInt(2),
+			},
+			{
+				ID:          "line-2",
+				TenantID:    "tenant-1",
+				OrderID:     "order-1",
+				LineNumber:  2,
+				Description: "Backordered part",
+				Quantity:    decimal.NewFromInt(1),
+				UnitPrice:   decimal.NewFromInt(40),
+				VATRate:     decimal.NewFromInt(22),
 			},
 		},
 	}
@@ -1187,12 +1287,299 @@ func TestConvertOrderToInvoice(t *testing.T) {
 	assert.Equal(t, "2026-03-24", result.Invoice.DueDate.Format("2006-01-02"))
 	require.Len(t, result.Invoice.Lines, 1)
 	assert.Equal(t, "Implementation", result.Invoice.Lines[0].Description)
-	assert.True(t, result.Invoice.Lines[0].Quantity.Equal(decimal.NewFromInt(3)))
+	assert.True(t, result.Invoice.Lines[0].Quantity.Equal(decimal.NewFromInt(2)))
 	assert.True(t, result.Invoice.Lines[0].UnitPrice.Equal(decimal.NewFromInt(120)))
 	assert.True(t, result.Invoice.Lines[0].DiscountPercent.Equal(decimal.NewFromInt(5)))
 	assert.True(t, result.Invoice.Lines[0].VATRate.Equal(decimal.NewFromInt(22)))
 }
 
+func TestConvertOrderToInvoiceInInstalments(t *testing.T) {
+	h, ordersRepo, tenantRepo := setupOrdersTestHandlers()
+	h.invoicingService = invoicing.NewServiceWithRepository(newMockInvoicingRepository(), nil)
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{ID: "tenant-1", SchemaName: "tenant_test"}
+	ordersRepo.orders["order-1"] = &orders.Order{
+		ID:           "order-1",
+		TenantID:     "tenant-1",
+		OrderNumber:  "ORD-001",
+		ContactID:    "contact-1",
+		OrderDate:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Status:       orders.OrderStatusConfirmed,
+		Currency:     "EUR",
+		ExchangeRate: decimal.NewFromInt(1),
+		Lines: []orders.OrderLine{
+			{ID: "line-1", TenantID: "tenant-1", OrderID: "order-1", LineNumber: 1, Description: "Milestone work", Quantity: decimal.NewFromInt(4), UnitPrice: decimal.NewFromInt(250), VATRate: decimal.NewFromInt(22)},
+		},
+	}
+
+	convert := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tenants/tenant-1/orders/order-1/convert-to-invoice", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = withURLParams(req, map[string]string{"tenantID": "tenant-1", "orderID": "order-1"})
+		req = req.WithContext(contextWithClaims(req.Context(), createTestClaims("user-1", "test@example.com", "tenant-1", "owner")))
+		rr := httptest.NewRecorder()
+		h.ConvertOrderToInvoice(rr, req)
+		return rr
+	}
+
+	rr := convert(`{"issue_date":"2026-03-10T00:00:00Z"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "order must be delivered before conversion")
+
+	rr = convert(`{"issue_date":"2026-03-10T00:00:00Z","kind":"PREPAYMENT","percent":"20"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var prepayment orders.OrderInvoiceConversionResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&prepayment))
+	require.NotNil(t, prepayment.Billing)
+	assert.Equal(t, invoicing.BillingKindPrepayment, prepayment.Billing.Kind)
+	assert.True(t, prepayment.Invoice.Subtotal.Equal(decimal.NewFromInt(200)))
+
+	rr = convert(`{"issue_date":"2026-03-20T00:00:00Z","lines":[{"line_id":"line-1","quantity":"5"}]}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "exceeds uninvoiced quantity 4")
+
+	rr = convert(`{"issue_date":"2026-03-20T00:00:00Z","lines":[{"line_id":"line-1","quantity":"2"}]}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var milestone orders.OrderInvoiceConversionResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&milestone))
+	require.Len(t, milestone.Invoice.Lines, 2)
+	assert.True(t, milestone.Invoice.Subtotal.Equal(decimal.NewFromInt(400)))
+	assert.True(t, milestone.Order.Lines[0].InvoicedQuantity.Equal(decimal.NewFromInt(2)))
+	assert.Nil(t, milestone.Order.ConvertedToInvoiceID)
+
+	rr = convert(`{"issue_date":"2026-03-30T00:00:00Z","percent":"50"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var final orders.OrderInvoiceConversionResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&final))
+	assert.True(t, final.Invoice.Subtotal.Equal(decimal.NewFromInt(400)))
+	require.NotNil(t, final.Order.ConvertedToInvoiceID)
+	assert.Equal(t, final.Invoice.ID, *final.Order.ConvertedToInvoiceID)
+
+	rr = convert(`{"issue_date":"2026-03-30T00:00:00Z","percent":"10"}`)
+	assert.Equal(t, http.StatusConflict, rr.Code)
+	assert.Contains(t, rr.Body.String(), "order has already been converted to an invoice")
+
+	list := httptest.NewRequest(http.MethodGet, "/tenants/tenant-1/orders/order-1/invoices", nil)
+	list = withURLParams(list, map[string]string{"tenantID": "tenant-1", "orderID": "order-1"})
+	rr = httptest.NewRecorder()
+	h.ListOrderInvoices(rr, list)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var billings []orders.OrderInvoice
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&billings))
+	require.Len(t, billings, 3)
+	assert.True(t, billings[0].NettedAmount.Equal(decimal.NewFromInt(200)))
+
+	ordersRepo.billingErr = errors.New("db error")
+	rr = httptest.NewRecorder()
+	h.ListOrderInvoices(rr, list)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	missing := httptest.NewRequest(http.MethodGet, "/tenants/tenant-1/orders/missing/invoices", nil)
+	missing = withURLParams(missing, map[string]string{"tenantID": "tenant-1", "orderID": "missing"})
+	rr = httptest.NewRecorder()
+	h.ListOrderInvoices(rr, missing)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// orderShippingHandlerStock records stock issued by order shipments.
+type orderShippingHandlerStock struct {
+	issued []inventory.IssueStockRequest
+}
+
+func (orderShippingHandlerStock) GetProductByID(_ context.Context, _, _, productID string) (*inventory.Product, error) {
+	return &inventory.Product{ID: productID, Name: "Widget", ProductType: inventory.ProductTypeGoods, TrackInventory: true, PurchaseAccountID: "cogs-1"}, nil
+}
+
+func (orderShippingHandlerStock) ReserveStock(_ context.Context, _, _ string, _ *inventory.StockReservationRequest) (*inventory.StockLevel, error) {
+	return &inventory.StockLevel{}, nil
+}
+
+func (orderShippingHandlerStock) ReleaseStock(_ context.Context, _, _ string, _ *inventory.StockReservationRequest) (*inventory.StockLevel, error) {
+	return &inventory.StockLevel{}, nil
+}
+
+func (s *orderShippingHandlerStock) IssueStock(_ context.Context, _, _ string, req *inventory.IssueStockRequest) (*inventory.IssueStockResult, error) {
+	s.issued = append(s.issued, *req)
+	quantity := decimal.RequireFromString(req.Quantity)
+	return &inventory.IssueStockResult{
+		ProductID:     req.ProductID,
+		WarehouseID:   req.WarehouseID,
+		Quantity:      quantity,
+		CostingMethod: req.CostingMethod,
+		UnitCost:      decimal.NewFromInt(8),
+		TotalCost:     quantity.Mul(decimal.NewFromInt(8)),
+		Accounting:    &inventory.InventoryIssueAccounting{Posted: true, JournalID: "journal-1"},
+	}, nil
+}
+
+func setupOrderShippingTestHandlers() (*Handlers, *mockOrdersRepository, *orderShippingHandlerStock) {
+	h, repo, tenantRepo := setupOrdersTestHandlers()
+	stock := &orderShippingHandlerStock{}
+	h.ordersService = orders.NewServiceWithRepository(repo).WithInventory(stock)
+	h.pdfService = pdf.NewService()
+
+	settings := tenant.DefaultSettings()
+	settings.InventoryIssueCostingMethod = tenant.InventoryIssueCostingMethodLot
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{ID: "tenant-1", Name: "Test Tenant", SchemaName: "tenant_test", Settings: settings}
+
+	productID := "product-1"
+	repo.orders["order-1"] = &orders.Order{
+		ID:          "order-1",
+		TenantID:    "tenant-1",
+		OrderNumber: "ORD-001",
+		ContactID:   "contact-1",
+		Contact:     &contacts.Contact{Name: "Acme OU"},
+		Status:      orders.OrderStatusProcessing,
+		Currency:    "EUR",
+		Lines: []orders.OrderLine{{
+			ID:          "line-1",
+			LineNumber:  1,
+			Description: "Widget",
+			Quantity:    decimal.NewFromInt(5),
+			Unit:        "pcs",
+			ProductID:   &productID,
+		}},
+	}
+	repo.stockReservations[orderStockReservationKey("order-1", productID, "warehouse-1")] = &orders.OrderStockReservation{
+		TenantID:    "tenant-1",
+		OrderID:     "order-1",
+		ProductID:   productID,
+		WarehouseID: "warehouse-1",
+		Quantity:    decimal.NewFromInt(5),
+		Status:      orders.OrderStockReservationStatusReserved,
+	}
+	return h, repo, stock
+}
+
+func orderShippingRequest(method, target, body string, params map[string]string) *http.Request {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req = withURLParams(req, params)
+	return req.WithContext(contextWithClaims(req.Context(), createTestClaims("user-1", "test@example.com", "tenant-1", "owner")))
+}
+
+func TestShipOrderIssuesReservedStock(t *testing.T) {
+	h, repo, stock := setupOrderShippingTestHandlers()
+	params := map[string]string{"tenantID": "tenant-1", "orderID": "order-1"}
+
+	rr := httptest.NewRecorder()
+	h.ShipOrder(rr, orderShippingRequest(http.MethodPost, "/tenants/tenant-1/orders/order-1/ship", `{"lines":[{"order_line_id":"line-1","quantity":"2","lot_number":"LOT-1"}]}`, params))
+
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var shipment orders.OrderShipment
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&shipment))
+	assert.Equal(t, "DN-00001", shipment.ShipmentNumber)
+	assert.Equal(t, "warehouse-1", shipment.WarehouseID)
+	assert.True(t, shipment.TotalCost.Equal(decimal.NewFromInt(16)))
+	require.Len(t, stock.issued, 1)
+	assert.Equal(t, tenant.InventoryIssueCostingMethodLot, stock.issued[0].CostingMethod)
+	assert.Equal(t, "LOT-1", stock.issued[0].LotNumber)
+	assert.Equal(t, "user-1", stock.issued[0].UserID)
+	assert.Equal(t, orders.OrderStatusProcessing, repo.orders["order-1"].Status)
+	assert.True(t, repo.orders["order-1"].Lines[0].ShippedQuantity.Equal(decimal.NewFromInt(2)))
+
+	rr = httptest.NewRecorder()
+	h.ShipOrder(rr, orderShippingRequest(http.MethodPost, "/tenants/tenant-1/orders/order-1/ship", "", params))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, orders.OrderStatusShipped, repo.orders["order-1"].Status)
+	require.Len(t, stock.issued, 2)
+	assert.Equal(t, "3", stock.issued[1].Quantity)
+
+	rr = httptest.NewRecorder()
+	h.ListOrderShipments(rr, orderShippingRequest(http.MethodGet, "/tenants/tenant-1/orders/order-1/shipments", "", params))
+	require.Equal(t, http.StatusOK, rr.Code)
+	var shipments []orders.OrderShipment
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&shipments))
+	require.Len(t, shipments, 2)
+
+	rr = httptest.NewRecorder()
+	h.GetOrderDeliveryNotePDF(rr, orderShippingRequest(http.MethodGet, "/tenants/tenant-1/orders/order-1/shipments/"+shipment.ID+"/delivery-note", "", map[string]string{
+		"tenantID":   "tenant-1",
+		"orderID":    "order-1",
+		"shipmentID": shipment.ID,
+	}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "delivery-note-DN-00001.pdf")
+	assert.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF")))
+}
+
+func TestShipOrderErrors(t *testing.T) {
+	params := map[string]string{"tenantID": "tenant-1", "orderID": "order-1"}
+
+	tests := []struct {
+		name     string
+		body     string
+		setup    func(*mockOrdersRepository)
+		wantCode int
+		wantBody string
+	}{
+		{name: "invalid json", body: "{", wantCode: http.StatusBadRequest, wantBody: "Invalid request body"},
+		{name: "missing order", setup: func(repo *mockOrdersRepository) { delete(repo.orders, "order-1") }, wantCode: http.StatusNotFound, wantBody: "Order not found"},
+		{name: "over shipment", body: `{"lines":[{"order_line_id":"line-1","quantity":"6"}]}`, wantCode: http.StatusBadRequest, wantBody: "exceeds open quantity"},
+		{name: "pending order", setup: func(repo *mockOrdersRepository) { repo.orders["order-1"].Status = orders.OrderStatusPending }, wantCode: http.StatusBadRequest, wantBody: "cannot be shipped"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, repo, _ := setupOrderShippingTestHandlers()
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+
+			rr := httptest.NewRecorder()
+			h.ShipOrder(rr, orderShippingRequest(http.MethodPost, "/tenants/tenant-1/orders/order-1/ship", tt.body, params))
+
+			assert.Equal(t, tt.wantCode, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.wantBody)
+		})
+	}
+
+	t.Run("requires authentication", func(t *testing.T) {
+		h, _, _ := setupOrderShippingTestHandlers()
+		req := withURLParams(httptest.NewRequest(http.MethodPost, "/tenants/tenant-1/orders/order-1/ship", nil), params)
+
+		rr := httptest.NewRecorder()
+		h.ShipOrder(rr, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("shipment lookups", func(t *testing.T) {
+		h, repo, _ := setupOrderShippingTestHandlers()
+
+		rr := httptest.NewRecorder()
+		h.GetOrderDeliveryNotePDF(rr, orderShippingRequest(http.MethodGet, "/tenants/tenant-1/orders/order-1/shipments/missing/delivery-note", "", map[string]string{
+			"tenantID":   "tenant-1",
+			"orderID":    "order-1",
+			"shipmentID": "missing",
+		}))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Shipment not found")
+
+		rr = httptest.NewRecorder()
+		h.GetOrderDeliveryNotePDF(rr, orderShippingRequest(http.MethodGet, "/tenants/tenant-1/orders/order-2/shipments/missing/delivery-note", "", map[string]string{
+			"tenantID":   "tenant-1",
+			"orderID":    "order-2",
+			"shipmentID": "missing",
+		}))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Order not found")
+
+		repo.shipmentListErr = errors.New("shipments down")
+		rr = httptest.NewRecorder()
+		h.ListOrderShipments(rr, orderShippingRequest(http.MethodGet, "/tenants/tenant-1/orders/order-1/shipments", "", params))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+		rr = httptest.NewRecorder()
+		h.GetOrderDeliveryNotePDF(rr, orderShippingRequest(http.MethodGet, "/tenants/tenant-1/orders/order-1/shipments/missing/delivery-note", "", map[string]string{
+			"tenantID":   "tenant-1",
+			"orderID":    "order-1",
+			"shipmentID": "missing",
+		}))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Contains(t, rr.Body.String(), "Failed to get shipment")
+	})
+}
+
 func TestConvertOrderToInvoiceRejectsInvalidState(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1248,6 +1635,28 @@ func TestConvertOrderToInvoiceRejectsInvalidState(t *testing.T) {
 			wantStatus: http.StatusConflict,
 			wantBody:   "order has already been converted to an invoice",
 		},
+		{
+			name: "requires shipped quantities",
+			order: &orders.Order{
+				ID:           "order-1",
+				TenantID:     "tenant-1",
+				OrderNumber:  "ORD-001",
+				ContactID:    "contact-1",
+				OrderDate:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+				Status:       orders.OrderStatusDelivered,
+				Currency:     "EUR",
+				ExchangeRate: decimal.NewFromInt(1),
+				Lines: []orders.OrderLine{{
+					ID:          "line-1",
+					Description: "Implementation",
+					Quantity:    decimal.NewFromInt(1),
+					UnitPrice:   decimal.NewFromInt(100),
+					VATRate:     decimal.NewFromInt(22),
+				}},
+			},
+			wantStatus: http.StatusConflict,
+			wantBody:   "order has no shipped quantities to invoice",
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/payroll"
+)
+
+type payrollEmploymentEventHandlerRepository struct {
+	*payrollImportHandlerRepository
+	events []payroll.EmploymentEvent
+}
+
+func (r *payrollEmploymentEventHandlerRepository) WithTransaction(ctx context.Context, fn func(txRepo payroll.Repository) error) error {
+	return fn(r)
+}
+
+func (r *payrollEmploymentEventHandlerRepository) ListEmploymentEvents(ctx context.Context, schemaName, tenantID string, filter payroll.EmploymentEventFilter) ([]payroll.EmploymentEvent, error) {
+	result := []payroll.EmploymentEvent{}
+	for _, event := range r.events {
+		if event.TenantID != tenantID || (filter.EmployeeID != "" && event.EmployeeID != filter.EmployeeID) {
+			continue
+		}
+		if (filter.From != nil && event.EventDate.Before(*filter.From)) || (filter.To != nil && event.EventDate.After(*filter.To)) {
+			continue
+		}
+		if filter.PendingOnly && event.ExportedAt != nil {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func (r *payrollEmploymentEventHandlerRepository) CreateEmploymentEvent(ctx context.Context, schemaName string, event *payroll.EmploymentEvent) error {
+	r.events = append(r.events, *event)
+	return nil
+}
+
+func (r *payrollEmploymentEventHandlerRepository) MarkEmploymentEventsExported(ctx context.Context, schemaName, tenantID string, eventIDs []string, exportedAt time.Time) error {
+	for i := range r.events {
+		for _, id := range eventIDs {
+			if r.events[i].ID == id {
+				r.events[i].ExportedAt = &exportedAt
+			}
+		}
+	}
+	return nil
+}
+
+func TestEmploymentRegisterHandlers(t *testing.T) {
+	h, importRepo, _ := setupPayrollImportHandlerTest(t)
+	importRepo.seedEmployee(payrollImportEmployee("emp-1", "E001"))
+	params := map[string]string{"tenantID": "tenant-1", "employeeID": "emp-1"}
+	eventRequest := payroll.CreateEmploymentEventRequest{
+		EventType: payroll.EmploymentEventStart,
+		EventDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	rec := invokePayrollImportRaw(t, http.StatusBadRequest, h.CreateEmploymentEvent, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/employees/emp-1/employment-events", eventRequest, params))
+	assert.Contains(t, rec.Body.String(), "employment register events are unavailable")
+
+	repo := &payrollEmploymentEventHandlerRepository{payrollImportHandlerRepository: importRepo}
+	h.payrollService = payroll.NewServiceWithRepository(repo, &payroll.DefaultUUIDGenerator{})
+
+	created := invokePayrollImportJSON[payroll.EmploymentEvent](t, http.StatusCreated, h.CreateEmploymentEvent, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/employees/emp-1/employment-events", eventRequest, params))
+	assert.Equal(t, payroll.EmploymentEventStart, created.EventType)
+	assert.Equal(t, "user-1", created.CreatedBy)
+
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.CreateEmploymentEvent, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/employees/emp-1/employment-events", payroll.CreateEmploymentEventRequest{
+		EventType: payroll.EmploymentEventEnd,
+		EventDate: time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC),
+	}, params))
+
+	events := invokePayrollImportJSON[[]payroll.EmploymentEvent](t, http.StatusOK, h.ListEmploymentEvents, payrollHandlerRequest(http.MethodGet, "/tenants/tenant-1/employees/emp-1/employment-events", nil, params))
+	require.Len(t, events, 1)
+
+	pending := invokePayrollImportJSON[[]payroll.EmploymentRegisterPendingEmployee](t, http.StatusOK, h.ListPendingEmploymentRegister, payrollHandlerRequest(http.MethodGet, "/tenants/tenant-1/employment-register/pending", nil, params))
+	require.Len(t, pending, 1)
+	assert.Equal(t, "E001", pending[0].EmployeeNumber)
+
+	rec = invokePayrollImportRaw(t, http.StatusOK, h.ExportEmploymentRegister, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/employment-register/export", payroll.ExportEmploymentRegisterRequest{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+	}, params))
+	assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=TOR_20250101_20250131.csv", rec.Header().Get("Content-Disposition"))
+	assert.Contains(t, rec.Body.String(), "1;49001010001;Mari;Maasikas;START;2025-01-01;")
+	require.NotNil(t, repo.events[0].ExportedAt)
+
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.ExportEmploymentRegister, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/employment-register/export", payroll.ExportEmploymentRegisterRequest{}, params))
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.ListEmploymentEvents, payrollHandlerRequest(http.MethodGet, "/tenants/tenant-1/employees/missing/employment-events", nil, map[string]string{"tenantID": "tenant-1", "employeeID": "missing"}))
+}
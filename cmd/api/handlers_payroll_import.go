@@ -159,3 +159,41 @@ func (h *Handlers) ImportLeaveBalances(w http.ResponseWriter, r *http.Request) {
 
 	respondJSON(w, http.StatusOK, result)
 }
+
+// ImportTimesheets imports daily timesheet entries from CSV.
+// @Summary Import timesheets
+// @Description Import daily regular, overtime, night and public holiday hours per employee from CSV. Rows replace pending entries for the same day; approved days are reported as row errors.
+// @Tags Payroll
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body payroll.ImportTimesheetsRequest true "Timesheet CSV import request"
+// @Success 200 {object} payroll.ImportTimesheetsResult
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/timesheets/import [post]
+func (h *Handlers) ImportTimesheets(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req payroll.ImportTimesheetsRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	if strings.TrimSpace(req.CSVContent) == "" {
+		respondError(w, http.StatusBadRequest, "csv_content is required")
+		return
+	}
+
+	if req.FileName == "" {
+		req.FileName = "timesheets.csv"
+	}
+
+	result, err := h.payrollService.ImportTimesheetsCSV(r.Context(), tenantCtx.schemaName, tenantCtx.tenantID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/payroll"
+)
+
+type payrollLeavePayHandlerRepository struct {
+	*payrollImportHandlerRepository
+}
+
+func (r *payrollLeavePayHandlerRepository) ListEarningsHistory(ctx context.Context, schemaName, tenantID, employeeID string, from, to time.Time) ([]payroll.EarningsHistoryEntry, error) {
+	result := []payroll.EarningsHistoryEntry{}
+	for _, payslip := range r.payslips {
+		run, ok := r.payrollRuns[payslip.PayrollRunID]
+		if !ok || payslip.TenantID != tenantID || payslip.EmployeeID != employeeID {
+			continue
+		}
+		period := time.Date(run.PeriodYear, time.Month(run.PeriodMonth), 1, 0, 0, 0, 0, time.UTC)
+		if period.Before(from) || !period.Before(to) {
+			continue
+		}
+		result = append(result, payroll.EarningsHistoryEntry{
+			PayrollRunID: run.ID,
+			PeriodYear:   run.PeriodYear,
+			PeriodMonth:  run.PeriodMonth,
+			GrossSalary:  payslip.GrossSalary,
+		})
+	}
+	return result, nil
+}
+
+func (r *payrollLeavePayHandlerRepository) ListApprovedLeave(ctx context.Context, schemaName, tenantID string, from, to time.Time) ([]payroll.LeaveRecord, error) {
+	return []payroll.LeaveRecord{}, nil
+}
+
+func (r *payrollLeavePayHandlerRepository) CreatePayslipComponents(ctx context.Context, schemaName string, components []payroll.PayslipComponent) error {
+	return nil
+}
+
+func (r *payrollLeavePayHandlerRepository) ListPayslipComponents(ctx context.Context, schemaName, tenantID, runID string) ([]payroll.PayslipComponent, error) {
+	return []payroll.PayslipComponent{}, nil
+}
+
+func TestGetAverageEarningsHandler(t *testing.T) {
+	h, importRepo, _ := setupPayrollImportHandlerTest(t)
+	importRepo.seedEmployee(payrollImportEmployee("emp-1", "E001"))
+	params := map[string]string{"tenantID": "tenant-1", "employeeID": "emp-1"}
+	averagePath := "/tenants/tenant-1/employees/emp-1/average-earnings"
+
+	rec := invokePayrollImportRaw(t, http.StatusBadRequest, h.GetAverageEarnings, payrollHandlerRequest(http.MethodGet, averagePath+"?date=2026-07-06", nil, params))
+	assert.Contains(t, rec.Body.String(), "average earnings are unavailable")
+
+	repo := &payrollLeavePayHandlerRepository{payrollImportHandlerRepository: importRepo}
+	h.payrollService = payroll.NewServiceWithRepository(repo, &payroll.DefaultUUIDGenerator{})
+	for month := 1; month <= 6; month++ {
+		runID := "run-" + time.Month(month).String()
+		repo.payrollRuns[runID] = &payroll.PayrollRun{ID: runID, TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: month, Status: payroll.PayrollPaid}
+		repo.payslips = append(repo.payslips, payroll.Payslip{
+			ID:           "payslip-" + runID,
+			TenantID:     "tenant-1",
+			PayrollRunID: runID,
+			EmployeeID:   "emp-1",
+			GrossSalary:  decimal.NewFromInt(3200),
+		})
+	}
+
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.GetAverageEarnings, payrollHandlerRequest(http.MethodGet, averagePath+"?date=July", nil, params))
+
+	average := invokePayrollImportJSON[payroll.AverageEarnings](t, http.StatusOK, h.GetAverageEarnings, payrollHandlerRequest(http.MethodGet, averagePath+"?date=2026-07-06", nil, params))
+	assert.Equal(t, payroll.AverageEarningsSourcePayslips, average.Source)
+	assert.Equal(t, 6, average.PayslipCount)
+	assert.Equal(t, "19200", average.TotalEarnings.String())
+	require.Len(t, average.History, 6)
+	assert.True(t, average.AverageDailyEarnings.GreaterThan(decimal.NewFromInt(100)))
+}
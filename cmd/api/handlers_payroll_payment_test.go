@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/banking"
+	"github.com/HMB-research/open-accounting/internal/payments"
+	"github.com/HMB-research/open-accounting/internal/payroll"
+)
+
+type payrollPaymentHandlerRepository struct {
+	*payrollPostingHandlerRepository
+	payrollPayments []payroll.PayrollPayment
+}
+
+func (r *payrollPaymentHandlerRepository) CreatePayrollPayment(ctx context.Context, schemaName string, payment *payroll.PayrollPayment, payslipIDs []string, markRunPaid bool) error {
+	for _, id := range payslipIDs {
+		for i := range r.payslips {
+			if r.payslips[i].ID == id {
+				r.payslips[i].PaymentStatus = "PAID"
+				r.payslips[i].PayrollPaymentID = &payment.ID
+			}
+		}
+	}
+	if markRunPaid {
+		r.payrollRuns[payment.PayrollRunID].Status = payroll.PayrollPaid
+	}
+	r.payrollPayments = append(r.payrollPayments, *payment)
+	return nil
+}
+
+func (r *payrollPaymentHandlerRepository) ListPayrollPayments(ctx context.Context, schemaName, tenantID, runID string) ([]payroll.PayrollPayment, error) {
+	result := []payroll.PayrollPayment{}
+	for _, payment := range r.payrollPayments {
+		if payment.TenantID == tenantID && payment.PayrollRunID == runID {
+			result = append(result, payment)
+		}
+	}
+	return result, nil
+}
+
+func TestPayrollPaymentHandlers(t *testing.T) {
+	h, postingRepo, accountingRepo, tenantRepo := setupPayrollPostingHandlerTest(t)
+	repo := &payrollPaymentHandlerRepository{payrollPostingHandlerRepository: postingRepo}
+
+	bankingRepo := newMockBankingRepository()
+	glAccountID := "acc-bank"
+	bankingRepo.accounts["bank-1"] = &banking.BankAccount{
+		ID:            "bank-1",
+		TenantID:      "tenant-1",
+		Name:          "Main",
+		AccountNumber: "EE382200221020145685",
+		Currency:      "EUR",
+		GLAccountID:   &glAccountID,
+		IsActive:      true,
+	}
+	paymentsRepo := newMockPaymentsRepository()
+	h.payrollService = payroll.NewServiceWithRepositoryAndAccounting(
+		repo,
+		&payroll.DefaultUUIDGenerator{},
+		h.accountingService,
+		nil,
+	).WithPaymentServices(
+		banking.NewServiceWithRepository(bankingRepo),
+		payments.NewServiceWithRepository(paymentsRepo, nil),
+		h.tenantService,
+	)
+
+	repo.postingAccounts[""] = payroll.PayrollPostingAccounts{
+		TenantID:                      "tenant-1",
+		SalaryExpenseAccountID:        "acc-salary",
+		EmployerTaxExpenseAccountID:   "acc-employer-tax",
+		IncomeTaxPayableAccountID:     "acc-income-tax",
+		SocialTaxPayableAccountID:     "acc-social-tax",
+		UnemploymentPayableAccountID:  "acc-unemployment",
+		FundedPensionPayableAccountID: "acc-funded-pension",
+		NetPayPayableAccountID:        "acc-net-pay",
+	}
+
+	employee := payrollImportEmployee("emp-1", "E001")
+	employee.BankAccount = "EE457700771000676899"
+	repo.seedEmployee(employee)
+	paymentDate := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+	repo.payrollRuns[payrollPostingRunID] = &payroll.PayrollRun{
+		ID:          payrollPostingRunID,
+		TenantID:    "tenant-1",
+		PeriodYear:  2026,
+		PeriodMonth: 3,
+		Status:      payroll.PayrollApproved,
+		PaymentDate: &paymentDate,
+	}
+	repo.payslips = append(repo.payslips, payroll.Payslip{
+		ID:                      "payslip-1",
+		TenantID:                "tenant-1",
+		PayrollRunID:            payrollPostingRunID,
+		EmployeeID:              employee.ID,
+		GrossSalary:             decimal.RequireFromString("2000.00"),
+		IncomeTax:               decimal.RequireFromString("286.00"),
+		UnemploymentInsuranceEE: decimal.RequireFromString("32.00"),
+		FundedPension:           decimal.RequireFromString("40.00"),
+		NetSalary:               decimal.RequireFromString("1642.00"),
+		SocialTax:               decimal.RequireFromString("660.00"),
+		UnemploymentInsuranceER: decimal.RequireFromString("16.00"),
+		TotalEmployerCost:       decimal.RequireFromString("2676.00"),
+		PaymentStatus:           "PENDING",
+	})
+	runParams := map[string]string{"tenantID": "tenant-1", "runID": payrollPostingRunID}
+	payPath := "/tenants/tenant-1/payroll-runs/" + payrollPostingRunID + "/pay"
+
+	invokePayrollImportRaw(t, http.StatusNotFound, h.PayPayrollRun, payrollHandlerRequest(
+		http.MethodPost,
+		"/tenants/tenant-1/payroll-runs/missing/pay",
+		payroll.PayPayrollRunRequest{BankAccountID: "bank-1"},
+		map[string]string{"tenantID": "tenant-1", "runID": "missing"},
+	))
+
+	rec := invokePayrollImportRaw(t, http.StatusBadRequest, h.PayPayrollRun, payrollHandlerRequest(
+		http.MethodPost,
+		payPath,
+		payroll.PayPayrollRunRequest{BankAccountID: "bank-1", IncludeTaxPayment: true},
+		runParams,
+	))
+	assert.Contains(t, rec.Body.String(), "tax prepayment reference is not set")
+
+	tenantRecord := tenantRepo.tenants["tenant-1"]
+	tenantRecord.Settings.TaxPrepaymentReference = "10123456789"
+	lockDate := "2026-04-30"
+	tenantRecord.Settings.PeriodLockDate = &lockDate
+	invokePayrollImportRaw(t, http.StatusConflict, h.PayPayrollRun, payrollHandlerRequest(
+		http.MethodPost,
+		payPath,
+		payroll.PayPayrollRunRequest{BankAccountID: "bank-1", IncludeTaxPayment: true},
+		runParams,
+	))
+	tenantRecord.Settings.PeriodLockDate = nil
+
+	result := invokePayrollImportJSON[payroll.PayPayrollRunResult](t, http.StatusCreated, h.PayPayrollRun, payrollHandlerRequest(
+		http.MethodPost,
+		payPath,
+		payroll.PayPayrollRunRequest{BankAccountID: "bank-1", IncludeTaxPayment: true},
+		runParams,
+	))
+	require.NotNil(t, result.PayrollPayment)
+	require.NotNil(t, result.Payment)
+	require.NotNil(t, result.SEPA)
+	assert.Equal(t, "1642", result.PayrollPayment.NetAmount.String())
+	assert.Equal(t, "1034", result.PayrollPayment.TaxAmount.String())
+	assert.Equal(t, 2, result.SEPA.TransactionCount)
+	assert.Equal(t, "2026-04-10", result.SEPA.ExecutionDate)
+	assert.Contains(t, result.SEPA.XML, "<Ref>10123456789</Ref>")
+	assert.Equal(t, payments.PaymentTypeMade, result.Payment.PaymentType)
+	assert.Equal(t, "2676", result.Payment.Amount.String())
+	assert.Equal(t, payroll.PayrollPaid, repo.payrollRuns[payrollPostingRunID].Status)
+	require.NotNil(t, result.PayrollPayment.JournalEntryID)
+	entry := accountingRepo.journalEntries[*result.PayrollPayment.JournalEntryID]
+	require.NotNil(t, entry)
+	assert.Equal(t, payroll.SourceTypePayrollPayment, entry.SourceType)
+
+	listed := invokePayrollImportJSON[[]payroll.PayrollPayment](t, http.StatusOK, h.ListPayrollPayments, payrollHandlerRequest(
+		http.MethodGet,
+		"/tenants/tenant-1/payroll-runs/"+payrollPostingRunID+"/payments",
+		nil,
+		runParams,
+	))
+	require.Len(t, listed, 1)
+	assert.Equal(t, result.PayrollPayment.MessageID, listed[0].MessageID)
+}
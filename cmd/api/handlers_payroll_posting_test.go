@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/payroll"
+)
+
+const payrollPostingRunID = "00000000-0000-4000-8000-000000000031"
+
+type payrollPostingHandlerRepository struct {
+	*payrollImportHandlerRepository
+	postingAccounts map[string]payroll.PayrollPostingAccounts
+}
+
+func (r *payrollPostingHandlerRepository) ApprovePayrollRun(ctx context.Context, schemaName, tenantID, runID, approverID string) error {
+	run, ok := r.payrollRuns[runID]
+	if !ok || run.Status != payroll.PayrollCalculated {
+		return payroll.ErrPayrollRunNotFound
+	}
+	run.Status = payroll.PayrollApproved
+	run.ApprovedBy = approverID
+	return nil
+}
+
+func (r *payrollPostingHandlerRepository) ListPostingAccounts(ctx context.Context, schemaName, tenantID string) ([]payroll.PayrollPostingAccounts, error) {
+	result := []payroll.PayrollPostingAccounts{}
+	for _, accounts := range r.postingAccounts {
+		if accounts.TenantID == tenantID {
+			result = append(result, accounts)
+		}
+	}
+	return result, nil
+}
+
+func (r *payrollPostingHandlerRepository) UpsertPostingAccounts(ctx context.Context, schemaName string, accounts *payroll.PayrollPostingAccounts) error {
+	r.postingAccounts[accounts.Department] = *accounts
+	return nil
+}
+
+func (r *payrollPostingHandlerRepository) DeletePostingAccounts(ctx context.Context, schemaName, tenantID, department string) error {
+	if _, ok := r.postingAccounts[department]; !ok {
+		return payroll.ErrPostingAccountsNotFound
+	}
+	delete(r.postingAccounts, department)
+	return nil
+}
+
+func (r *payrollPostingHandlerRepository) SetPayrollRunJournalEntry(ctx context.Context, schemaName, tenantID, runID string, journalEntryID *string) error {
+	run, ok := r.payrollRuns[runID]
+	if !ok {
+		return payroll.ErrPayrollRunNotFound
+	}
+	run.JournalEntryID = journalEntryID
+	return nil
+}
+
+func (r *payrollPostingHandlerRepository) ReopenPayrollRun(ctx context.Context, schemaName, tenantID, runID string) error {
+	run, ok := r.payrollRuns[runID]
+	if !ok || run.Status != payroll.PayrollApproved {
+		return payroll.ErrPayrollRunNotFound
+	}
+	run.Status = payroll.PayrollDraft
+	run.ApprovedBy = ""
+	run.ApprovedAt = nil
+	run.JournalEntryID = nil
+	return nil
+}
+
+func setupPayrollPostingHandlerTest(t *testing.T) (*Handlers, *payrollPostingHandlerRepository, *mockAccountingRepository, *mockTenantRepository) {
+	t.Helper()
+
+	h, tenantRepo := setupTenantTestHandlers()
+	tenantRepo.addTestTenant("tenant-1", "Tenant One", "tenant-one")
+
+	accountingRepo := newMockAccountingRepository()
+	for id, accountType := range map[string]accounting.AccountType{
+		"acc-salary":         accounting.AccountTypeExpense,
+		"acc-employer-tax":   accounting.AccountTypeExpense,
+		"acc-income-tax":     accounting.AccountTypeLiability,
+		"acc-social-tax":     accounting.AccountTypeLiability,
+		"acc-unemployment":   accounting.AccountTypeLiability,
+		"acc-funded-pension": accounting.AccountTypeLiability,
+		"acc-net-pay":        accounting.AccountTypeLiability,
+		"acc-bank":           accounting.AccountTypeAsset,
+	} {
+		accountingRepo.accounts[id] = &accounting.Account{ID: id, TenantID: "tenant-1", Code: id, Name: id, AccountType: accountType, IsActive: true}
+	}
+	h.accountingService = accounting.NewServiceWithRepository(accountingRepo)
+
+	repo := &payrollPostingHandlerRepository{
+		payrollImportHandlerRepository: newPayrollImportHandlerRepository(),
+		postingAccounts:                make(map[string]payroll.PayrollPostingAccounts),
+	}
+	h.payrollService = payroll.NewServiceWithRepositoryAndAccounting(
+		repo,
+		&payrollImportHandlerIDGenerator{prefix: "payroll-posting"},
+		h.accountingService,
+		nil,
+	)
+	return h, repo, accountingRepo, tenantRepo
+}
+
+func TestPayrollPostingHandlers(t *testing.T) {
+	h, repo, accountingRepo, tenantRepo := setupPayrollPostingHandlerTest(t)
+	tenantParams := map[string]string{"tenantID": "tenant-1"}
+
+	rec := invokePayrollImportRaw(t, http.StatusBadRequest, h.SetPayrollPostingAccounts, payrollHandlerRequest(
+		http.MethodPut,
+		"/tenants/tenant-1/payroll/posting-accounts",
+		payroll.SetPayrollPostingAccountsRequest{SalaryExpenseAccountID: "acc-salary"},
+		tenantParams,
+	))
+	assert.Contains(t, rec.Body.String(), "is required for the default posting accounts")
+
+	rec = invokePayrollImportRaw(t, http.StatusBadRequest, h.SetPayrollPostingAccounts, payrollHandlerRequest(
+		http.MethodPut,
+		"/tenants/tenant-1/payroll/posting-accounts",
+		payroll.SetPayrollPostingAccountsRequest{Department: "Sales", NetPayPayableAccountID: "acc-bank"},
+		tenantParams,
+	))
+	assert.Contains(t, rec.Body.String(), "net pay payable account must be LIABILITY")
+
+	saved := invokePayrollImportJSON[payroll.PayrollPostingAccounts](t, http.StatusOK, h.SetPayrollPostingAccounts, payrollHandlerRequest(
+		http.MethodPut,
+		"/tenants/tenant-1/payroll/posting-accounts",
+		payroll.SetPayrollPostingAccountsRequest{
+			SalaryExpenseAccountID:        "acc-salary",
+			EmployerTaxExpenseAccountID:   "acc-employer-tax",
+			IncomeTaxPayableAccountID:     "acc-income-tax",
+			SocialTaxPayableAccountID:     "acc-social-tax",
+			UnemploymentPayableAccountID:  "acc-unemployment",
+			FundedPensionPayableAccountID: "acc-funded-pension",
+			NetPayPayableAccountID:        "acc-net-pay",
+		},
+		tenantParams,
+	))
+	assert.Equal(t, "acc-net-pay", saved.NetPayPayableAccountID)
+
+	listed := invokePayrollImportJSON[[]payroll.PayrollPostingAccounts](t, http.StatusOK, h.ListPayrollPostingAccounts, payrollHandlerRequest(
+		http.MethodGet,
+		"/tenants/tenant-1/payroll/posting-accounts",
+		nil,
+		tenantParams,
+	))
+	require.Len(t, listed, 1)
+
+	invokePayrollImportRaw(t, http.StatusNotFound, h.DeletePayrollPostingAccounts, payrollHandlerRequest(
+		http.MethodDelete,
+		"/tenants/tenant-1/payroll/posting-accounts?department=Sales",
+		nil,
+		tenantParams,
+	))
+
+	employee := payrollImportEmployee("emp-1", "E001")
+	repo.seedEmployee(employee)
+	repo.payrollRuns[payrollPostingRunID] = &payroll.PayrollRun{ID: payrollPostingRunID, TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 3, Status: payroll.PayrollCalculated}
+	repo.payslips = append(repo.payslips, payroll.Payslip{
+		ID:                      "payslip-1",
+		TenantID:                "tenant-1",
+		PayrollRunID:            payrollPostingRunID,
+		EmployeeID:              employee.ID,
+		GrossSalary:             decimal.RequireFromString("2000.00"),
+		IncomeTax:               decimal.RequireFromString("286.00"),
+		UnemploymentInsuranceEE: decimal.RequireFromString("32.00"),
+		FundedPension:           decimal.RequireFromString("40.00"),
+		NetSalary:               decimal.RequireFromString("1642.00"),
+		SocialTax:               decimal.RequireFromString("660.00"),
+		UnemploymentInsuranceER: decimal.RequireFromString("16.00"),
+		TotalEmployerCost:       decimal.RequireFromString("2676.00"),
+	})
+	runParams := map[string]string{"tenantID": "tenant-1", "runID": payrollPostingRunID}
+
+	tenantRecord := tenantRepo.tenants["tenant-1"]
+	lockDate := "2026-03-31"
+	tenantRecord.Settings.PeriodLockDate = &lockDate
+	invokePayrollImportRaw(t, http.StatusConflict, h.ApprovePayroll, payrollHandlerRequest(
+		http.MethodPost,
+		"/tenants/tenant-1/payroll-runs/"+payrollPostingRunID+"/approve",
+		nil,
+		runParams,
+	))
+	tenantRecord.Settings.PeriodLockDate = nil
+
+	approval := invokePayrollImportJSON[map[string]string](t, http.StatusOK, h.ApprovePayroll, payrollHandlerRequest(
+		http.MethodPost,
+		"/tenants/tenant-1/payroll-runs/"+payrollPostingRunID+"/approve",
+		nil,
+		runParams,
+	))
+	assert.Equal(t, "approved", approval["status"])
+	require.NotNil(t, repo.payrollRuns[payrollPostingRunID].JournalEntryID)
+	entry := accountingRepo.journalEntries[*repo.payrollRuns[payrollPostingRunID].JournalEntryID]
+	require.NotNil(t, entry)
+	assert.Equal(t, accounting.StatusPosted, entry.Status)
+	assert.Equal(t, payroll.SourceTypePayrollRun, entry.SourceType)
+
+	rec = invokePayrollImportRaw(t, http.StatusBadRequest, h.ReopenPayrollRun, payrollHandlerRequest(
+		http.MethodPost,
+		"/tenants/tenant-1/payroll-runs/"+payrollPostingRunID+"/reopen",
+		map[string]string{"reason": ""},
+		runParams,
+	))
+	assert.Contains(t, rec.Body.String(), "reopen reason is required")
+
+	reopened := invokePayrollImportJSON[payroll.PayrollRun](t, http.StatusOK, h.ReopenPayrollRun, payrollHandlerRequest(
+		http.MethodPost,
+		"/tenants/tenant-1/payroll-runs/"+payrollPostingRunID+"/reopen",
+		map[string]string{"reason": "Missed overtime"},
+		runParams,
+	))
+	assert.Equal(t, payroll.PayrollDraft, reopened.Status)
+	assert.Nil(t, reopened.JournalEntryID)
+	assert.Equal(t, accounting.StatusVoided, entry.Status)
+
+	invokePayrollImportRaw(t, http.StatusNoContent, h.DeletePayrollPostingAccounts, payrollHandlerRequest(
+		http.MethodDelete,
+		"/tenants/tenant-1/payroll/posting-accounts",
+		nil,
+		tenantParams,
+	))
+	assert.Empty(t, repo.postingAccounts)
+}
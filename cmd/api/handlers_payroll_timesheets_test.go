@@ -0,0 +1,113 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/payroll"
+)
+
+type payrollTimesheetHandlerRepository struct {
+	*payrollImportHandlerRepository
+	entries []payroll.TimesheetEntry
+}
+
+func (r *payrollTimesheetHandlerRepository) matches(entry payroll.TimesheetEntry, tenantID string, filter payroll.TimesheetFilter) bool {
+	if entry.TenantID != tenantID || (filter.EmployeeID != "" && entry.EmployeeID != filter.EmployeeID) {
+		return false
+	}
+	if filter.From != nil && entry.WorkDate.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && entry.WorkDate.After(*filter.To) {
+		return false
+	}
+	return filter.Status == "" || entry.Status == filter.Status
+}
+
+func (r *payrollTimesheetHandlerRepository) ListTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter payroll.TimesheetFilter) ([]payroll.TimesheetEntry, error) {
+	result := []payroll.TimesheetEntry{}
+	for _, entry := range r.entries {
+		if r.matches(entry, tenantID, filter) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (r *payrollTimesheetHandlerRepository) CreateTimesheetEntry(ctx context.Context, schemaName string, entry *payroll.TimesheetEntry) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *payrollTimesheetHandlerRepository) UpdateTimesheetEntry(ctx context.Context, schemaName string, entry *payroll.TimesheetEntry) error {
+	for i := range r.entries {
+		if r.entries[i].ID == entry.ID {
+			r.entries[i] = *entry
+		}
+	}
+	return nil
+}
+
+func (r *payrollTimesheetHandlerRepository) ApproveTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter payroll.TimesheetFilter, approverID string, approvedAt time.Time) (int, error) {
+	approved := 0
+	for i := range r.entries {
+		if r.matches(r.entries[i], tenantID, filter) {
+			r.entries[i].Status = payroll.TimesheetApproved
+			r.entries[i].ApprovedBy = approverID
+			approved++
+		}
+	}
+	return approved, nil
+}
+
+func TestTimesheetHandlers(t *testing.T) {
+	h, importRepo, _ := setupPayrollImportHandlerTest(t)
+	employee := payrollImportEmployee("emp-1", "E001")
+	employee.HourlyRate = decimal.NewFromInt(12)
+	importRepo.seedEmployee(employee)
+	params := map[string]string{"tenantID": "tenant-1"}
+	workDate := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	entryRequest := payroll.CreateTimesheetEntryRequest{EmployeeID: "emp-1", WorkDate: workDate, RegularHours: decimal.NewFromInt(8)}
+
+	rec := invokePayrollImportRaw(t, http.StatusBadRequest, h.SaveTimesheet, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets", entryRequest, params))
+	assert.Contains(t, rec.Body.String(), "timesheets are unavailable")
+
+	repo := &payrollTimesheetHandlerRepository{payrollImportHandlerRepository: importRepo}
+	h.payrollService = payroll.NewServiceWithRepository(repo, &payroll.DefaultUUIDGenerator{})
+
+	created := invokePayrollImportJSON[payroll.TimesheetEntry](t, http.StatusCreated, h.SaveTimesheet, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets", entryRequest, params))
+	assert.Equal(t, payroll.TimesheetPending, created.Status)
+
+	entryRequest.NightHours = decimal.NewFromInt(2)
+	updated := invokePayrollImportJSON[payroll.TimesheetEntry](t, http.StatusOK, h.SaveTimesheet, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets", entryRequest, params))
+	assert.Equal(t, created.ID, updated.ID)
+	assert.Equal(t, "2", updated.NightHours.String())
+
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.SaveTimesheet, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets", payroll.CreateTimesheetEntryRequest{EmployeeID: "emp-1", WorkDate: workDate}, params))
+
+	imported := invokePayrollImportJSON[payroll.ImportTimesheetsResult](t, http.StatusOK, h.ImportTimesheets, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets/import", payroll.ImportTimesheetsRequest{
+		CSVContent: "employee_number,work_date,regular_hours,overtime_hours\nE001,2026-03-03,8,2\n",
+	}, params))
+	assert.Equal(t, "timesheets.csv", imported.FileName)
+	assert.Equal(t, 1, imported.EntriesCreated)
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.ImportTimesheets, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets/import", payroll.ImportTimesheetsRequest{}, params))
+
+	invokePayrollImportRaw(t, http.StatusBadRequest, h.ApproveTimesheets, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets/approve", payroll.ApproveTimesheetsRequest{}, params))
+	approved := invokePayrollImportJSON[payroll.ApproveTimesheetsResult](t, http.StatusOK, h.ApproveTimesheets, payrollHandlerRequest(http.MethodPost, "/tenants/tenant-1/timesheets/approve", payroll.ApproveTimesheetsRequest{
+		From: workDate,
+		To:   workDate,
+	}, params))
+	assert.Equal(t, 1, approved.Approved)
+	assert.Equal(t, "user-1", repo.entries[0].ApprovedBy)
+
+	entries := invokePayrollImportJSON[[]payroll.TimesheetEntry](t, http.StatusOK, h.ListTimesheets, payrollHandlerRequest(http.MethodGet, "/tenants/tenant-1/timesheets?employee_id=emp-1&from_date=2026-03-03&to_date=2026-03-31&status=PENDING", nil, params))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "2", entries[0].OvertimeHours.String())
+}
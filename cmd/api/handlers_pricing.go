@@ -0,0 +1,325 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+)
+
+// ListPriceLists returns sales price lists for a tenant.
+// @Summary List price lists
+// @Description List sales price lists ordered by code, without their product prices
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param currency query string false "Filter by currency"
+// @Param active_only query bool false "Only active price lists"
+// @Success 200 {array} pricing.PriceList
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/price-lists [get]
+func (h *Handlers) ListPriceLists(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	lists, err := h.pricingService.ListPriceLists(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &pricing.PriceListFilter{
+		Currency:   query.Get("currency"),
+		ActiveOnly: query.Get("active_only") == "true",
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list price lists")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, lists)
+}
+
+// CreatePriceList creates a sales price list.
+// @Summary Create price list
+// @Description Create a named price list in one currency (default EUR) with optional inclusive validity dates. Each item prices a product from a minimum quantity; several items for one product form quantity breaks. A default list applies to customers without their own or a customer group list in the currency; active default lists in one currency cannot overlap.
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body pricing.CreatePriceListRequest true "Price list"
+// @Success 201 {object} pricing.PriceList
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/price-lists [post]
+func (h *Handlers) CreatePriceList(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req pricing.CreatePriceListRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	list, err := h.pricingService.CreatePriceList(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, list)
+}
+
+// GetPriceList returns a price list with its product prices.
+// @Summary Get price list
+// @Description Get a price list with its product prices and quantity breaks
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param priceListID path string true "Price list ID"
+// @Success 200 {object} pricing.PriceList
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/price-lists/{priceListID} [get]
+func (h *Handlers) GetPriceList(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	list, err := h.pricingService.GetPriceList(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "priceListID"))
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// UpdatePriceList replaces a price list.
+// @Summary Update price list
+// @Description Replace the name, currency, validity, default flag, active flag and product prices of a price list
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param priceListID path string true "Price list ID"
+// @Param request body pricing.UpdatePriceListRequest true "Price list"
+// @Success 200 {object} pricing.PriceList
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/price-lists/{priceListID} [put]
+func (h *Handlers) UpdatePriceList(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req pricing.UpdatePriceListRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	list, err := h.pricingService.UpdatePriceList(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "priceListID"), &req)
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, list)
+}
+
+// ListCustomerGroups returns customer pricing groups.
+// @Summary List customer groups
+// @Description List customer groups with their price list and discount
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Success 200 {array} pricing.CustomerGroup
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/customer-groups [get]
+func (h *Handlers) ListCustomerGroups(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	groups, err := h.pricingService.ListCustomerGroups(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list customer groups")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, groups)
+}
+
+// CreateCustomerGroup creates a customer pricing group.
+// @Summary Create customer group
+// @Description Create a customer group with an optional price list and a discount percent applied to its members
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body pricing.CustomerGroupRequest true "Customer group"
+// @Success 201 {object} pricing.CustomerGroup
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/customer-groups [post]
+func (h *Handlers) CreateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req pricing.CustomerGroupRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	group, err := h.pricingService.CreateCustomerGroup(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, group)
+}
+
+// UpdateCustomerGroup updates a customer pricing group.
+// @Summary Update customer group
+// @Description Replace the code, name, price list and discount percent of a customer group
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param customerGroupID path string true "Customer group ID"
+// @Param request body pricing.CustomerGroupRequest true "Customer group"
+// @Success 200 {object} pricing.CustomerGroup
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/customer-groups/{customerGroupID} [put]
+func (h *Handlers) UpdateCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req pricing.CustomerGroupRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	group, err := h.pricingService.UpdateCustomerGroup(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "customerGroupID"), &req)
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, group)
+}
+
+// GetContactPricing returns the pricing terms of a contact.
+// @Summary Get contact pricing
+// @Description Get the customer group, customer-specific price list and discount of a contact
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param contactID path string true "Contact ID"
+// @Success 200 {object} pricing.CustomerPricing
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/contacts/{contactID}/pricing [get]
+func (h *Handlers) GetContactPricing(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	terms, err := h.pricingService.GetCustomerPricing(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "contactID"))
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, terms)
+}
+
+// SetContactPricing sets the pricing terms of a contact.
+// @Summary Set contact pricing
+// @Description Link a contact to a customer group and a customer-specific price list, and set its discount percent. A customer discount replaces the customer group discount.
+// @Tags Pricing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param contactID path string true "Contact ID"
+// @Param request body pricing.SetCustomerPricingRequest true "Pricing terms"
+// @Success 200 {object} pricing.CustomerPricing
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/contacts/{contactID}/pricing [put]
+func (h *Handlers) SetContactPricing(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req pricing.SetCustomerPricingRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+
+	terms, err := h.pricingService.SetCustomerPricing(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "contactID"), &req)
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, terms)
+}
+
+// ResolvePrice returns the price a contact pays for a product.
+// @Summary Resolve sales price
+// @Description Resolve the unit price and discount that quotes, orders, sales invoices and recurring invoices use for a product line sent without a unit price. The customer's own price list wins over the customer group list and the default list; only lists in the currency that are active and valid on the date count, and the quantity break with the highest minimum quantity not above the quantity applies. Without a matching list the product sales price is used, which is in the tenant's default currency; other currencies without a matching list are rejected.
+// @Tags Pricing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param product_id query string true "Product ID"
+// @Param contact_id query string false "Contact ID"
+// @Param currency query string false "Currency (default EUR)"
+// @Param quantity query number false "Quantity (default 1)"
+// @Param date query string false "Price date (YYYY-MM-DD, default today)"
+// @Success 200 {object} pricing.ResolvedPrice
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/prices/resolve [get]
+func (h *Handlers) ResolvePrice(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	req := &pricing.ResolvePriceRequest{
+		ContactID: strings.TrimSpace(query.Get("contact_id")),
+		ProductID: strings.TrimSpace(query.Get("product_id")),
+		Currency:  query.Get("currency"),
+	}
+	if raw := strings.TrimSpace(query.Get("quantity")); raw != "" {
+		quantity, err := decimal.NewFromString(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "quantity must be a number")
+			return
+		}
+		req.Quantity = quantity
+	}
+	if raw := strings.TrimSpace(query.Get("date")); raw != "" {
+		date, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "date must be in YYYY-MM-DD format")
+			return
+		}
+		req.Date = date
+	}
+
+	resolved, err := h.pricingService.ResolvePrice(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, req)
+	if err != nil {
+		respondPricingError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resolved)
+}
+
+func respondPricingError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, pricing.ErrPriceListNotFound):
+		respondError(w, http.StatusNotFound, "Price list not found")
+	case errors.Is(err, pricing.ErrCustomerGroupNotFound):
+		respondError(w, http.StatusNotFound, "Customer group not found")
+	case errors.Is(err, contacts.ErrContactNotFound):
+		respondError(w, http.StatusNotFound, "Contact not found")
+	default:
+		respondError(w, http.StatusBadRequest, err.Error())
+	}
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+)
+
+const pricingHandlerContactID = "99999999-9999-4999-8999-999999999999"
+
+// pricingHandlerRepository is an in-memory pricing repository for handler tests.
+type pricingHandlerRepository struct {
+	lists  map[string]*pricing.PriceList
+	groups map[string]*pricing.CustomerGroup
+	terms  map[string]*pricing.CustomerPricing
+}
+
+func (m *pricingHandlerRepository) CreatePriceList(_ context.Context, _ string, list *pricing.PriceList) error {
+	stored := *list
+	m.lists[list.ID] = &stored
+	return nil
+}
+
+func (m *pricingHandlerRepository) UpdatePriceList(ctx context.Context, schemaName string, list *pricing.PriceList) error {
+	return m.CreatePriceList(ctx, schemaName, list)
+}
+
+func (m *pricingHandlerRepository) GetPriceList(_ context.Context, _, _, priceListID string) (*pricing.PriceList, error) {
+	list, ok := m.lists[priceListID]
+	if !ok {
+		return nil, pricing.ErrPriceListNotFound
+	}
+	stored := *list
+	return &stored, nil
+}
+
+func (m *pricingHandlerRepository) ListPriceLists(context.Context, string, string, *pricing.PriceListFilter) ([]pricing.PriceList, error) {
+	result := []pricing.PriceList{}
+	for _, list := range m.lists {
+		result = append(result, *list)
+	}
+	return result, nil
+}
+
+func (m *pricingHandlerRepository) CreateCustomerGroup(_ context.Context, _ string, group *pricing.CustomerGroup) error {
+	stored := *group
+	m.groups[group.ID] = &stored
+	return nil
+}
+
+func (m *pricingHandlerRepository) UpdateCustomerGroup(ctx context.Context, schemaName string, group *pricing.CustomerGroup) error {
+	return m.CreateCustomerGroup(ctx, schemaName, group)
+}
+
+func (m *pricingHandlerRepository) GetCustomerGroup(_ context.Context, _, _, groupID string) (*pricing.CustomerGroup, error) {
+	group, ok := m.groups[groupID]
+	if !ok {
+		return nil, pricing.ErrCustomerGroupNotFound
+	}
+	stored := *group
+	return &stored, nil
+}
+
+func (m *pricingHandlerRepository) ListCustomerGroups(context.Context, string, string) ([]pricing.CustomerGroup, error) {
+	result := []pricing.CustomerGroup{}
+	for _, group := range m.groups {
+		result = append(result, *group)
+	}
+	return result, nil
+}
+
+func (m *pricingHandlerRepository) GetCustomerPricing(_ context.Context, _, _, contactID string) (*pricing.CustomerPricing, error) {
+	return m.terms[contactID], nil
+}
+
+func (m *pricingHandlerRepository) SaveCustomerPricing(_ context.Context, _ string, terms *pricing.CustomerPricing) error {
+	stored := *terms
+	m.terms[terms.ContactID] = &stored
+	return nil
+}
+
+func setupPricingHandlers(t *testing.T) *Handlers {
+	t.Helper()
+
+	h, inventoryRepo, _ := setupInventoryTestHandlers()
+	inventoryRepo.products[apiInventoryStockProductID] = &inventory.Product{
+		ID:          apiInventoryStockProductID,
+		TenantID:    "tenant-1",
+		Code:        "WIDGET",
+		Name:        "Widget",
+		ProductType: inventory.ProductTypeGoods,
+		SalesPrice:  decimal.NewFromInt(20),
+		IsActive:    true,
+	}
+	repo := &pricingHandlerRepository{
+		lists:  map[string]*pricing.PriceList{},
+		groups: map[string]*pricing.CustomerGroup{},
+		terms:  map[string]*pricing.CustomerPricing{},
+	}
+	h.pricingService = pricing.NewServiceWithRepository(repo, h.inventoryService, nil, nil)
+	return h
+}
+
+func TestPricingHandlers(t *testing.T) {
+	h := setupPricingHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.CreatePriceList(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/price-lists", pricing.CreatePriceListRequest{
+		Code:      "RETAIL",
+		Name:      "Retail",
+		IsDefault: true,
+		Items: []pricing.PriceListItemRequest{
+			{ProductID: apiInventoryStockProductID, UnitPrice: decimal.NewFromInt(18)},
+			{ProductID: apiInventoryStockProductID, MinQuantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(16)},
+		},
+	}, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var list pricing.PriceList
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	assert.Equal(t, "EUR", list.Currency)
+	assert.Len(t, list.Items, 2)
+
+	rr = httptest.NewRecorder()
+	h.GetPriceList(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/price-lists/"+list.ID, nil, map[string]string{"priceListID": list.ID}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	h.ListPriceLists(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/price-lists?currency=eur", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "RETAIL")
+
+	rr = httptest.NewRecorder()
+	h.CreateCustomerGroup(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/customer-groups", pricing.CustomerGroupRequest{
+		Code:            "DEALERS",
+		Name:            "Dealers",
+		DiscountPercent: decimal.NewFromInt(5),
+	}, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var group pricing.CustomerGroup
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &group))
+
+	rr = httptest.NewRecorder()
+	h.SetContactPricing(rr, depreciationRunRequest(t, http.MethodPut, "/tenants/tenant-1/contacts/"+pricingHandlerContactID+"/pricing", pricing.SetCustomerPricingRequest{
+		CustomerGroupID: &group.ID,
+	}, map[string]string{"contactID": pricingHandlerContactID}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	h.ResolvePrice(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/prices/resolve?product_id="+apiInventoryStockProductID+"&contact_id="+pricingHandlerContactID+"&quantity=12&date=2026-03-01", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var resolved pricing.ResolvedPrice
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resolved))
+	assert.Equal(t, pricing.PriceSourceDefaultPriceList, resolved.Source)
+	assert.True(t, resolved.UnitPrice.Equal(decimal.NewFromInt(16)))
+	assert.True(t, resolved.DiscountPercent.Equal(decimal.NewFromInt(5)))
+	assert.Equal(t, pricing.DiscountSourceGroup, resolved.DiscountSource)
+}
+
+func TestPricingHandlersErrors(t *testing.T) {
+	h := setupPricingHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetPriceList(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/price-lists/missing", nil, map[string]string{"priceListID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreatePriceList(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/price-lists", pricing.CreatePriceListRequest{Code: "X", Name: "X", Currency: "EURO"}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "currency must be a 3-letter code")
+
+	missing := "missing"
+	rr = httptest.NewRecorder()
+	h.SetContactPricing(rr, depreciationRunRequest(t, http.MethodPut, "/tenants/tenant-1/contacts/"+pricingHandlerContactID+"/pricing", pricing.SetCustomerPricingRequest{
+		CustomerGroupID: &missing,
+	}, map[string]string{"contactID": pricingHandlerContactID}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Contains(t, rr.Body.String(), "Customer group not found")
+
+	rr = httptest.NewRecorder()
+	h.UpdateCustomerGroup(rr, depreciationRunRequest(t, http.MethodPut, "/tenants/tenant-1/customer-groups/missing", pricing.CustomerGroupRequest{Code: "X", Name: "X"}, map[string]string{"customerGroupID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	for target, want := range map[string]string{
+		"/tenants/tenant-1/prices/resolve?product_id=" + apiInventoryStockProductID + "&quantity=abc":    "quantity must be a number",
+		"/tenants/tenant-1/prices/resolve?product_id=" + apiInventoryStockProductID + "&date=01.03.2026": "date must be in YYYY-MM-DD format",
+		"/tenants/tenant-1/prices/resolve": "product_id is required",
+		"/tenants/tenant-1/prices/resolve?product_id=" + apiInventoryStockProductID + "&currency=dollars": "currency must be a 3-letter code",
+	} {
+		rr = httptest.NewRecorder()
+		h.ResolvePrice(rr, depreciationRunRequest(t, http.MethodGet, target, nil, nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+		assert.Contains(t, rr.Body.String(), want, target)
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/purchasing"
+)
+
+// ListPurchaseOrders returns purchase orders for a tenant.
+// @Summary List purchase orders
+// @Description List supplier purchase orders with optional status, supplier, date and number filters
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param status query string false "Filter by status (DRAFT, APPROVED, PARTIALLY_RECEIVED, RECEIVED, CLOSED, CANCELED)"
+// @Param contact_id query string false "Filter by supplier contact ID"
+// @Param from_date query string false "Filter from order date (YYYY-MM-DD)"
+// @Param to_date query string false "Filter to order date (YYYY-MM-DD)"
+// @Param search query string false "Search in purchase order number"
+// @Success 200 {array} purchasing.PurchaseOrder
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders [get]
+func (h *Handlers) ListPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	filter := &purchasing.PurchaseOrderFilter{
+		Status:    purchasing.PurchaseOrderStatus(query.Get("status")),
+		ContactID: query.Get("contact_id"),
+		Search:    query.Get("search"),
+	}
+	if fromDate := query.Get("from_date"); fromDate != "" {
+		if parsed, err := time.Parse("2006-01-02", fromDate); err == nil {
+			filter.FromDate = &parsed
+		}
+	}
+	if toDate := query.Get("to_date"); toDate != "" {
+		if parsed, err := time.Parse("2006-01-02", toDate); err == nil {
+			filter.ToDate = &parsed
+		}
+	}
+
+	orderList, err := h.purchasingService.List(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list purchase orders")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, orderList)
+}
+
+// CreatePurchaseOrder creates a draft purchase order.
+// @Summary Create purchase order
+// @Description Create a draft purchase order to a supplier. Every line must reference a goods product that tracks inventory; description and unit default from the product.
+// @Tags Purchasing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body purchasing.CreatePurchaseOrderRequest true "Purchase order"
+// @Success 201 {object} purchasing.PurchaseOrder
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders [post]
+func (h *Handlers) CreatePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req purchasing.CreatePurchaseOrderRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	po, err := h.purchasingService.Create(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, po)
+}
+
+// GetPurchaseOrder returns a purchase order with its lines.
+// @Summary Get purchase order
+// @Description Get a purchase order with ordered, received and invoiced quantities per line
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param purchaseOrderID path string true "Purchase order ID"
+// @Success 200 {object} purchasing.PurchaseOrder
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders/{purchaseOrderID} [get]
+func (h *Handlers) GetPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	po, err := h.purchasingService.GetByID(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "purchaseOrderID"))
+	if err != nil {
+		respondPurchasingError(w, err, "Failed to get purchase order")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, po)
+}
+
+// ApprovePurchaseOrder approves a draft purchase order.
+// @Summary Approve purchase order
+// @Description Approve a draft purchase order so goods can be received against it
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param purchaseOrderID path string true "Purchase order ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders/{purchaseOrderID}/approve [post]
+func (h *Handlers) ApprovePurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	if err := h.purchasingService.Approve(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "purchaseOrderID"), userIDFromRequest(r)); err != nil {
+		respondPurchasingError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": string(purchasing.PurchaseOrderStatusApproved)})
+}
+
+// CancelPurchaseOrder cancels a purchase order without receipts.
+// @Summary Cancel purchase order
+// @Description Cancel a draft or approved purchase order that has no received goods
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param purchaseOrderID path string true "Purchase order ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders/{purchaseOrderID}/cancel [post]
+func (h *Handlers) CancelPurchaseOrder(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	if err := h.purchasingService.Cancel(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "purchaseOrderID"), userIDFromRequest(r)); err != nil {
+		respondPurchasingError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": string(purchasing.PurchaseOrderStatusCanceled)})
+}
+
+// ReceivePurchaseOrderGoods books a goods receipt note against a purchase order.
+// @Summary Receive purchase order goods
+// @Description Book a goods receipt note against an approved purchase order. Received quantities are added to stock per warehouse and lot at the received cost (default: discounted order price in base currency), and the cost is posted to inventory against accrual_account_id until the supplier invoice is matched.
+// @Tags Purchasing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param purchaseOrderID path string true "Purchase order ID"
+// @Param request body purchasing.ReceiveGoodsRequest true "Goods receipt"
+// @Success 201 {object} purchasing.GoodsReceipt
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders/{purchaseOrderID}/receipts [post]
+func (h *Handlers) ReceivePurchaseOrderGoods(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req purchasing.ReceiveGoodsRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	receiptDate := req.ReceiptDate
+	if receiptDate.IsZero() {
+		receiptDate = time.Now()
+	}
+	if h.rejectLockedPeriod(w, r.Context(), tenantCtx.tenantID, receiptDate) {
+		return
+	}
+
+	receipt, err := h.purchasingService.ReceiveGoods(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "purchaseOrderID"), &req)
+	if err != nil {
+		respondPurchasingError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, receipt)
+}
+
+// MatchPurchaseOrderInvoice matches a supplier invoice to a purchase order.
+// @Summary Match purchase invoice
+// @Description Three-way match a purchase invoice against the purchase order and its received but not invoiced quantities. The matched receipt cost is cleared from accrual_account_id, any difference to the invoiced net amount is posted to price_variance_account_id, input VAT to vat_account_id and the invoice total to payable_account_id in one posted journal entry linked to the invoice. Invoices dated in a locked period are rejected.
+// @Tags Purchasing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param purchaseOrderID path string true "Purchase order ID"
+// @Param request body purchasing.MatchInvoiceRequest true "Invoice match"
+// @Success 201 {object} purchasing.PurchaseInvoiceMatch
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders/{purchaseOrderID}/invoice-matches [post]
+func (h *Handlers) MatchPurchaseOrderInvoice(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req purchasing.MatchInvoiceRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	lockDate, err := h.getTenantPeriodLockDate(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate period lock")
+		return
+	}
+	req.PeriodLockDate = lockDate
+
+	match, err := h.purchasingService.MatchInvoice(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "purchaseOrderID"), &req)
+	if err != nil {
+		respondPurchasingError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, match)
+}
+
+// GetPurchaseOrderMatching returns the three-way matching status of a purchase order.
+// @Summary Get purchase order matching
+// @Description Compare ordered, received and invoiced quantities and amounts per line, with the cost still accrued for goods received but not invoiced, price variances, receipts and invoice matches
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param purchaseOrderID path string true "Purchase order ID"
+// @Success 200 {object} purchasing.PurchaseOrderMatching
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/purchase-orders/{purchaseOrderID}/matching [get]
+func (h *Handlers) GetPurchaseOrderMatching(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	report, err := h.purchasingService.GetMatching(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "purchaseOrderID"))
+	if err != nil {
+		respondPurchasingError(w, err, "Failed to get purchase order matching")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// ListLandedCosts returns landed cost allocations for a tenant.
+// @Summary List landed costs
+// @Description List freight, duty and broker invoices allocated onto received stock, optionally for one goods receipt or a cost date range
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param goods_receipt_id query string false "Filter by allocated goods receipt ID"
+// @Param from_date query string false "Filter from cost date (YYYY-MM-DD)"
+// @Param to_date query string false "Filter to cost date (YYYY-MM-DD)"
+// @Success 200 {array} purchasing.LandedCost
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/landed-costs [get]
+func (h *Handlers) ListLandedCosts(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	filter := &purchasing.LandedCostFilter{GoodsReceiptID: query.Get("goods_receipt_id")}
+	if fromDate := query.Get("from_date"); fromDate != "" {
+		if parsed, err := time.Parse("2006-01-02", fromDate); err == nil {
+			filter.FromDate = &parsed
+		}
+	}
+	if toDate := query.Get("to_date"); toDate != "" {
+		if parsed, err := time.Parse("2006-01-02", toDate); err == nil {
+			filter.ToDate = &parsed
+		}
+	}
+
+	landedCosts, err := h.purchasingService.ListLandedCosts(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, filter)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list landed costs")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, landedCosts)
+}
+
+// AllocateLandedCost allocates a purchase invoice onto received stock.
+// @Summary Allocate landed cost
+// @Description Allocate a freight, customs duty or broker fee purchase invoice onto goods receipts, receipt lines or product lots instead of expensing it. The invoice net amount is spread by received VALUE (default), QUANTITY or WEIGHT and added to the cost of the receipt stock movements, so FIFO, weighted-average and lot valuation use the landed cost. The share of units still on hand is debited to inventory and the share already issued to cost_of_goods_sold_account_id (default: the product purchase account), with input VAT to vat_account_id and the invoice total to payable_account_id in one posted journal entry linked to the invoice. Invoices dated in a locked period are rejected.
+// @Tags Purchasing
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body purchasing.AllocateLandedCostRequest true "Landed cost allocation"
+// @Success 201 {object} purchasing.LandedCost
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/landed-costs [post]
+func (h *Handlers) AllocateLandedCost(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req purchasing.AllocateLandedCostRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	lockDate, err := h.getTenantPeriodLockDate(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate period lock")
+		return
+	}
+	req.PeriodLockDate = lockDate
+
+	landedCost, err := h.purchasingService.AllocateLandedCost(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondPurchasingError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, landedCost)
+}
+
+// GetLandedCost returns a landed cost allocation with its lines.
+// @Summary Get landed cost
+// @Description Get a landed cost with the amount allocated to each receipt line, the on-hand and issued split and the unit cost before and after allocation
+// @Tags Purchasing
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param landedCostID path string true "Landed cost ID"
+// @Success 200 {object} purchasing.LandedCost
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/landed-costs/{landedCostID} [get]
+func (h *Handlers) GetLandedCost(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	landedCost, err := h.purchasingService.GetLandedCost(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "landedCostID"))
+	if err != nil {
+		respondPurchasingError(w, err, "Failed to get landed cost")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, landedCost)
+}
+
+// respondPurchasingError maps purchasing errors to responses. An empty
+// fallback reports any other error as a bad request with its message.
+func respondPurchasingError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, purchasing.ErrPurchaseOrderNotFound):
+		respondError(w, http.StatusNotFound, "Purchase order not found")
+	case errors.Is(err, purchasing.ErrGoodsReceiptNotFound):
+		respondError(w, http.StatusNotFound, "Goods receipt not found")
+	case errors.Is(err, purchasing.ErrLandedCostNotFound):
+		respondError(w, http.StatusNotFound, "Landed cost not found")
+	case errors.Is(err, purchasing.ErrInvoiceAlreadyPosted), errors.Is(err, purchasing.ErrPeriodLocked):
+		respondError(w, http.StatusConflict, err.Error())
+	case fallback == "":
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
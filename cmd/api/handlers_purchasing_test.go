@@ -0,0 +1,434 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+const (
+	purchasingHandlerSupplierID = "11111111-1111-4111-8111-111111111111"
+	purchasingHandlerWarehouse  = "22222222-2222-4222-8222-222222222222"
+	purchasingHandlerProductID  = "33333333-3333-4333-8333-333333333333"
+)
+
+// purchasingHandlerRepository is an in-memory purchasing repository for handler tests.
+type purchasingHandlerRepository struct {
+	orders      map[string]*purchasing.PurchaseOrder
+	receipts    []purchasing.GoodsReceipt
+	matches     []purchasing.PurchaseInvoiceMatch
+	landedCosts []purchasing.LandedCost
+}
+
+func (m *purchasingHandlerRepository) Create(_ context.Context, _ string, po *purchasing.PurchaseOrder) error {
+	stored := *po
+	stored.Lines = append([]purchasing.PurchaseOrderLine(nil), po.Lines...)
+	m.orders[po.ID] = &stored
+	return nil
+}
+
+func (m *purchasingHandlerRepository) GetByID(_ context.Context, _, _, poID string) (*purchasing.PurchaseOrder, error) {
+	po, ok := m.orders[poID]
+	if !ok {
+		return nil, purchasing.ErrPurchaseOrderNotFound
+	}
+	copyPO := *po
+	copyPO.Lines = append([]purchasing.PurchaseOrderLine(nil), po.Lines...)
+	return &copyPO, nil
+}
+
+func (m *purchasingHandlerRepository) List(_ context.Context, _, _ string, filter *purchasing.PurchaseOrderFilter) ([]purchasing.PurchaseOrder, error) {
+	result := []purchasing.PurchaseOrder{}
+	for _, po := range m.orders {
+		if filter.Status == "" || po.Status == filter.Status {
+			result = append(result, *po)
+		}
+	}
+	return result, nil
+}
+
+func (m *purchasingHandlerRepository) ListOpen(context.Context, string, string) ([]purchasing.PurchaseOrder, error) {
+	result := []purchasing.PurchaseOrder{}
+	for _, po := range m.orders {
+		switch po.Status {
+		case purchasing.PurchaseOrderStatusDraft, purchasing.PurchaseOrderStatusApproved, purchasing.PurchaseOrderStatusPartiallyReceived:
+			result = append(result, *po)
+		}
+	}
+	return result, nil
+}
+
+func (m *purchasingHandlerRepository) UpdateStatus(_ context.Context, _, _, poID string, status purchasing.PurchaseOrderStatus, _ string) error {
+	po, ok := m.orders[poID]
+	if !ok {
+		return purchasing.ErrPurchaseOrderNotFound
+	}
+	po.Status = status
+	return nil
+}
+
+func (m *purchasingHandlerRepository) GenerateNumber(context.Context, string, string) (string, error) {
+	return fmt.Sprintf("PO-%05d", len(m.orders)+1), nil
+}
+
+func (m *purchasingHandlerRepository) GenerateReceiptNumber(context.Context, string, string) (string, error) {
+	return fmt.Sprintf("GRN-%05d", len(m.receipts)+1), nil
+}
+
+func (m *purchasingHandlerRepository) CreateReceipt(_ context.Context, _ string, receipt *purchasing.GoodsReceipt, status purchasing.PurchaseOrderStatus) error {
+	po := m.orders[receipt.PurchaseOrderID]
+	for _, line := range receipt.Lines {
+		for i := range po.Lines {
+			if po.Lines[i].ID == line.PurchaseOrderLineID {
+				po.Lines[i].ReceivedQuantity = po.Lines[i].ReceivedQuantity.Add(line.Quantity)
+				po.Lines[i].ReceivedAmount = po.Lines[i].ReceivedAmount.Add(line.TotalCost)
+			}
+		}
+	}
+	po.Status = status
+	m.receipts = append(m.receipts, *receipt)
+	return nil
+}
+
+func (m *purchasingHandlerRepository) ListReceipts(context.Context, string, string, string) ([]purchasing.GoodsReceipt, error) {
+	return m.receipts, nil
+}
+
+func (m *purchasingHandlerRepository) CreateInvoiceMatch(_ context.Context, _ string, match *purchasing.PurchaseInvoiceMatch, status purchasing.PurchaseOrderStatus) error {
+	for _, existing := range m.matches {
+		if existing.InvoiceID == match.InvoiceID {
+			return purchasing.ErrInvoiceAlreadyPosted
+		}
+	}
+	po := m.orders[match.PurchaseOrderID]
+	for _, line := range match.Lines {
+		for i := range po.Lines {
+			if po.Lines[i].ID == line.PurchaseOrderLineID {
+				po.Lines[i].InvoicedQuantity = po.Lines[i].InvoicedQuantity.Add(line.Quantity)
+				po.Lines[i].InvoicedAmount = po.Lines[i].InvoicedAmount.Add(line.InvoicedAmount)
+				po.Lines[i].AccrualCleared = po.Lines[i].AccrualCleared.Add(line.AccrualCleared)
+			}
+		}
+	}
+	po.Status = status
+	m.matches = append(m.matches, *match)
+	return nil
+}
+
+func (m *purchasingHandlerRepository) ListInvoiceMatches(context.Context, string, string, string) ([]purchasing.PurchaseInvoiceMatch, error) {
+	return m.matches, nil
+}
+
+func (m *purchasingHandlerRepository) GetReceipt(_ context.Context, _, _, receiptID string) (*purchasing.GoodsReceipt, error) {
+	for _, receipt := range m.receipts {
+		if receipt.ID == receiptID {
+			copyReceipt := receipt
+			return &copyReceipt, nil
+		}
+	}
+	return nil, purchasing.ErrGoodsReceiptNotFound
+}
+
+func (m *purchasingHandlerRepository) ListReceiptLines(_ context.Context, _, _ string, filter *purchasing.GoodsReceiptLineFilter) ([]purchasing.GoodsReceiptLine, error) {
+	result := []purchasing.GoodsReceiptLine{}
+	for _, receipt := range m.receipts {
+		for _, line := range receipt.Lines {
+			if len(filter.LineIDs) > 0 && line.ID != filter.LineIDs[0] {
+				continue
+			}
+			if filter.LotNumber != "" && line.LotNumber != filter.LotNumber {
+				continue
+			}
+			result = append(result, line)
+		}
+	}
+	return result, nil
+}
+
+func (m *purchasingHandlerRepository) GenerateLandedCostNumber(context.Context, string, string) (string, error) {
+	return fmt.Sprintf("LC-%05d", len(m.landedCosts)+1), nil
+}
+
+func (m *purchasingHandlerRepository) CreateLandedCost(_ context.Context, _ string, landedCost *purchasing.LandedCost) error {
+	m.landedCosts = append(m.landedCosts, *landedCost)
+	return nil
+}
+
+func (m *purchasingHandlerRepository) GetLandedCost(_ context.Context, _, _, landedCostID string) (*purchasing.LandedCost, error) {
+	for _, landedCost := range m.landedCosts {
+		if landedCost.ID == landedCostID {
+			copyLandedCost := landedCost
+			return &copyLandedCost, nil
+		}
+	}
+	return nil, purchasing.ErrLandedCostNotFound
+}
+
+func (m *purchasingHandlerRepository) ListLandedCosts(context.Context, string, string, *purchasing.LandedCostFilter) ([]purchasing.LandedCost, error) {
+	return m.landedCosts, nil
+}
+
+type purchasingHandlerStock struct{}
+
+func (purchasingHandlerStock) GetProductByID(_ context.Context, _, _, productID string) (*inventory.Product, error) {
+	if productID != purchasingHandlerProductID {
+		return nil, errors.New("product not found")
+	}
+	return &inventory.Product{ID: productID, Code: "SKU-1", Name: "Widget", ProductType: inventory.ProductTypeGoods, TrackInventory: true}, nil
+}
+
+func (purchasingHandlerStock) GetWarehouseByID(_ context.Context, _, _, warehouseID string) (*inventory.Warehouse, error) {
+	return &inventory.Warehouse{ID: warehouseID, Name: "Main", IsActive: true}, nil
+}
+
+func (purchasingHandlerStock) ReceiveStock(_ context.Context, _, _ string, req *inventory.ReceiveStockRequest) (*inventory.ReceiveStockResult, error) {
+	total := decimal.Zero
+	for _, line := range req.Lines {
+		total = total.Add(line.Quantity.Mul(line.UnitCost).Round(2))
+	}
+	return &inventory.ReceiveStockResult{WarehouseID: req.WarehouseID, TotalCost: total, JournalID: "receipt-journal"}, nil
+}
+
+func (purchasingHandlerStock) ApplyLandedCost(_ context.Context, _, _ string, req *inventory.ApplyLandedCostRequest) (*inventory.ApplyLandedCostResult, error) {
+	result := &inventory.ApplyLandedCostResult{JournalID: "landed-journal"}
+	for _, line := range req.Lines {
+		result.Lines = append(result.Lines, inventory.AppliedLandedCostLine{
+			ProductID:       line.ProductID,
+			Quantity:        line.Quantity,
+			OnHandQuantity:  line.Quantity,
+			Amount:          line.Amount,
+			InventoryAmount: line.Amount,
+		})
+		result.TotalAmount = result.TotalAmount.Add(line.Amount)
+		result.InventoryAmount = result.InventoryAmount.Add(line.Amount)
+	}
+	return result, nil
+}
+
+type purchasingHandlerInvoices map[string]*invoicing.Invoice
+
+func (m purchasingHandlerInvoices) GetByID(_ context.Context, _, _, invoiceID string) (*invoicing.Invoice, error) {
+	invoice, ok := m[invoiceID]
+	if !ok {
+		return nil, errors.New("invoice not found")
+	}
+	return invoice, nil
+}
+
+type purchasingHandlerLedger struct{}
+
+func (purchasingHandlerLedger) ListAccounts(context.Context, string, string, bool) ([]accounting.Account, error) {
+	return []accounting.Account{
+		{ID: "accrual", AccountType: accounting.AccountTypeLiability},
+		{ID: "payable", AccountType: accounting.AccountTypeLiability},
+		{ID: "input-vat", AccountType: accounting.AccountTypeAsset},
+	}, nil
+}
+
+func (purchasingHandlerLedger) CreateJournalEntry(context.Context, string, string, *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error) {
+	return &accounting.JournalEntry{ID: "match-journal"}, nil
+}
+
+func (purchasingHandlerLedger) PostJournalEntry(context.Context, string, string, string, string, string) error {
+	return nil
+}
+
+func setupPurchasingHandlers(t *testing.T) (*Handlers, *purchasingHandlerRepository, purchasingHandlerInvoices) {
+	t.Helper()
+
+	repo := &purchasingHandlerRepository{orders: map[string]*purchasing.PurchaseOrder{}}
+	invoices := purchasingHandlerInvoices{}
+	tenantRepo := newMockTenantRepository()
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{ID: "tenant-1", SchemaName: "tenant_test"}
+	h := &Handlers{
+		tenantService:     tenant.NewServiceWithRepository(tenantRepo),
+		purchasingService: purchasing.NewServiceWithRepository(repo, purchasingHandlerStock{}, invoices, purchasingHandlerLedger{}),
+	}
+	return h, repo, invoices
+}
+
+func TestPurchaseOrderHandlersLifecycle(t *testing.T) {
+	h, repo, invoices := setupPurchasingHandlers(t)
+
+	createBody := purchasing.CreatePurchaseOrderRequest{
+		ContactID:   purchasingHandlerSupplierID,
+		WarehouseID: purchasingHandlerWarehouse,
+		OrderDate:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Lines: []purchasing.CreatePurchaseOrderLineRequest{
+			{ProductID: purchasingHandlerProductID, Quantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(5), VATRate: decimal.NewFromInt(22)},
+		},
+	}
+	rr := httptest.NewRecorder()
+	h.CreatePurchaseOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders", createBody, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var po purchasing.PurchaseOrder
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &po))
+	assert.Equal(t, "PO-00001", po.PONumber)
+	assert.Equal(t, "user-1", po.CreatedBy)
+	params := map[string]string{"purchaseOrderID": po.ID}
+
+	rr = httptest.NewRecorder()
+	h.ApprovePurchaseOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders/"+po.ID+"/approve", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	receiveBody := purchasing.ReceiveGoodsRequest{
+		ReceiptDate:      time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC),
+		AccrualAccountID: "accrual",
+		Lines:            []purchasing.ReceiveGoodsLineRequest{{PurchaseOrderLineID: po.Lines[0].ID, Quantity: decimal.NewFromInt(10), LotNumber: "LOT-1"}},
+	}
+	rr = httptest.NewRecorder()
+	h.ReceivePurchaseOrderGoods(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders/"+po.ID+"/receipts", receiveBody, params))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var receipt purchasing.GoodsReceipt
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &receipt))
+	assert.Equal(t, "GRN-00001", receipt.ReceiptNumber)
+	assert.True(t, receipt.TotalCost.Equal(decimal.NewFromInt(50)))
+
+	productID := purchasingHandlerProductID
+	invoice := &invoicing.Invoice{
+		ID:            "invoice-1",
+		InvoiceNumber: "SUP-1",
+		InvoiceType:   invoicing.InvoiceTypePurchase,
+		ContactID:     purchasingHandlerSupplierID,
+		Currency:      "EUR",
+		ExchangeRate:  decimal.NewFromInt(1),
+		Status:        invoicing.StatusSent,
+		Lines:         []invoicing.InvoiceLine{{ID: "invoice-line-1", Quantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(5), VATRate: decimal.NewFromInt(22), ProductID: &productID}},
+	}
+	invoice.Calculate()
+	invoices[invoice.ID] = invoice
+
+	matchBody := purchasing.MatchInvoiceRequest{InvoiceID: invoice.ID, AccrualAccountID: "accrual", PayableAccountID: "payable", VATAccountID: "input-vat"}
+	rr = httptest.NewRecorder()
+	h.MatchPurchaseOrderInvoice(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders/"+po.ID+"/invoice-matches", matchBody, params))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var match purchasing.PurchaseInvoiceMatch
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &match))
+	assert.True(t, match.PayableAmount.Equal(decimal.NewFromInt(61)))
+	assert.Equal(t, purchasing.PurchaseOrderStatusClosed, repo.orders[po.ID].Status)
+
+	rr = httptest.NewRecorder()
+	h.GetPurchaseOrderMatching(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/purchase-orders/"+po.ID+"/matching", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var report purchasing.PurchaseOrderMatching
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	require.Len(t, report.Lines, 1)
+	assert.Equal(t, purchasing.MatchingLineStatusMatched, report.Lines[0].Status)
+	assert.True(t, report.AccruedNotInvoiced.IsZero())
+
+	rr = httptest.NewRecorder()
+	h.ListPurchaseOrders(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/purchase-orders?status=CLOSED", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var listed []purchasing.PurchaseOrder
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+}
+
+func TestPurchaseOrderHandlersErrors(t *testing.T) {
+	h, repo, _ := setupPurchasingHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetPurchaseOrder(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/purchase-orders/missing", nil, map[string]string{"purchaseOrderID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreatePurchaseOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders", purchasing.CreatePurchaseOrderRequest{ContactID: purchasingHandlerSupplierID}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "at least one line")
+
+	repo.orders["po-1"] = &purchasing.PurchaseOrder{ID: "po-1", Status: purchasing.PurchaseOrderStatusDraft}
+	params := map[string]string{"purchaseOrderID": "po-1"}
+	rr = httptest.NewRecorder()
+	h.ReceivePurchaseOrderGoods(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders/po-1/receipts", purchasing.ReceiveGoodsRequest{
+		AccrualAccountID: "accrual",
+		Lines:            []purchasing.ReceiveGoodsLineRequest{{PurchaseOrderLineID: "line-1", Quantity: decimal.NewFromInt(1)}},
+	}, params))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "approved purchase orders")
+
+	rr = httptest.NewRecorder()
+	h.CancelPurchaseOrder(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/purchase-orders/po-1/cancel", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, purchasing.PurchaseOrderStatusCanceled, repo.orders["po-1"].Status)
+
+	rr = httptest.NewRecorder()
+	respondPurchasingError(rr, fmt.Errorf("create invoice match: %w", purchasing.ErrInvoiceAlreadyPosted), "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestLandedCostHandlers(t *testing.T) {
+	h, repo, invoices := setupPurchasingHandlers(t)
+	repo.receipts = append(repo.receipts, purchasing.GoodsReceipt{
+		ID:          "grn-1",
+		WarehouseID: purchasingHandlerWarehouse,
+		Lines: []purchasing.GoodsReceiptLine{
+			{ID: "grn-line-1", GoodsReceiptID: "grn-1", ProductID: purchasingHandlerProductID, Quantity: decimal.NewFromInt(4), TotalCost: decimal.NewFromInt(20), LotNumber: "LOT-1"},
+			{ID: "grn-line-2", GoodsReceiptID: "grn-1", ProductID: purchasingHandlerProductID, Quantity: decimal.NewFromInt(6), TotalCost: decimal.NewFromInt(30), LotNumber: "LOT-2"},
+		},
+	})
+	invoice := &invoicing.Invoice{
+		ID:            "freight-1",
+		InvoiceNumber: "FREIGHT-1",
+		InvoiceType:   invoicing.InvoiceTypePurchase,
+		IssueDate:     time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Currency:      "EUR",
+		ExchangeRate:  decimal.NewFromInt(1),
+		Status:        invoicing.StatusSent,
+		Lines:         []invoicing.InvoiceLine{{ID: "freight-line-1", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(10), VATRate: decimal.NewFromInt(22)}},
+	}
+	invoice.Calculate()
+	invoices[invoice.ID] = invoice
+
+	body := purchasing.AllocateLandedCostRequest{InvoiceID: invoice.ID, GoodsReceiptIDs: []string{"grn-1"}, PayableAccountID: "payable", VATAccountID: "input-vat"}
+	rr := httptest.NewRecorder()
+	h.AllocateLandedCost(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/landed-costs", body, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var landedCost purchasing.LandedCost
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &landedCost))
+	assert.Equal(t, "LC-00001", landedCost.LandedCostNumber)
+	assert.Equal(t, "user-1", landedCost.CreatedBy)
+	require.Len(t, landedCost.Lines, 2)
+	assert.True(t, landedCost.Lines[0].Amount.Equal(decimal.NewFromInt(4)))
+	assert.True(t, landedCost.InventoryAmount.Equal(decimal.NewFromInt(10)))
+
+	rr = httptest.NewRecorder()
+	h.GetLandedCost(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/landed-costs/"+landedCost.ID, nil, map[string]string{"landedCostID": landedCost.ID}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	h.ListLandedCosts(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/landed-costs?goods_receipt_id=grn-1&from_date=2026-03-01", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var listed []purchasing.LandedCost
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+
+	rr = httptest.NewRecorder()
+	h.GetLandedCost(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/landed-costs/missing", nil, map[string]string{"landedCostID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	body.GoodsReceiptIDs = []string{"missing"}
+	rr = httptest.NewRecorder()
+	h.AllocateLandedCost(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/landed-costs", body, nil))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	body.GoodsReceiptIDs = nil
+	rr = httptest.NewRecorder()
+	h.AllocateLandedCost(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/landed-costs", body, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "at least one goods receipt")
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/quotes"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+const (
+	quoteAnswerSourceUser       = "user"
+	quoteAnswerSourcePublicLink = "public_link"
+)
+
+// quoteAnswerEvent is the payload of quote.accepted and quote.rejected webhook events
+type quoteAnswerEvent struct {
+	QuoteID     string             `json:"quote_id"`
+	QuoteNumber string             `json:"quote_number"`
+	Revision    int                `json:"revision"`
+	ContactID   string             `json:"contact_id"`
+	Status      quotes.QuoteStatus `json:"status"`
+	Source      string             `json:"source"`
+}
+
+// ListQuoteRevisions lists the sent revisions of a quote.
+// @Summary List quote revisions
+// @Description List the revisions of a quote frozen each time it was sent, oldest first, without their content
+// @Tags Quotes
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param quoteID path string true "Quote ID"
+// @Success 200 {array} quotes.QuoteRevision
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/quotes/{quoteID}/revisions [get]
+func (h *Handlers) ListQuoteRevisions(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	quoteID := chi.URLParam(r, "quoteID")
+
+	revisions, err := h.quotesService.ListRevisions(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, quoteID)
+	if err != nil {
+		respondQuoteLinkError(w, err, "Failed to list quote revisions")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, revisions)
+}
+
+// GetQuoteRevision returns one sent revision of a quote.
+// @Summary Get quote revision
+// @Description Get a sent revision of a quote with the quote content frozen when it was sent
+// @Tags Quotes
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param quoteID path string true "Quote ID"
+// @Param revision path int true "Revision number"
+// @Success 200 {object} quotes.QuoteRevision
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/quotes/{quoteID}/revisions/{revision} [get]
+func (h *Handlers) GetQuoteRevision(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	quoteID := chi.URLParam(r, "quoteID")
+	revision, err := strconv.Atoi(chi.URLParam(r, "revision"))
+	if err != nil || revision < 1 {
+		respondError(w, http.StatusBadRequest, "revision must be a positive number")
+		return
+	}
+
+	result, err := h.quotesService.GetRevision(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, quoteID, revision)
+	if err != nil {
+		respondQuoteLinkError(w, err, "Failed to get quote revision")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// CreateQuoteLink creates a signed public link to a sent quote.
+// @Summary Create quote customer link
+// @Description Sign a time-limited public link to the current revision of a sent quote. The customer opens the token at /public/quotes/{token} to view the quote and its PDF and to accept or reject it without logging in. The link expires after expires_in_days (default 14, at most 90) and never after the end of the quote's valid until day; editing the quote supersedes the link.
+// @Tags Quotes
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param quoteID path string true "Quote ID"
+// @Param request body quotes.CreateQuoteLinkRequest false "Link options"
+// @Success 201 {object} quotes.QuoteLink
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 410 {object} object{error=string}
+// @Failure 503 {object} object{error=string}
+// @Router /tenants/{tenantID}/quotes/{quoteID}/public-link [post]
+func (h *Handlers) CreateQuoteLink(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	quoteID := chi.URLParam(r, "quoteID")
+
+	var req quotes.CreateQuoteLinkRequest
+	if r.Body != nil && r.ContentLength != 0 {
+		if !decodeJSONRequest(w, r, &req) {
+			return
+		}
+	}
+
+	link, err := h.quotesService.CreateLink(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, quoteID, &req)
+	if err != nil {
+		respondQuoteLinkError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, link)
+}
+
+// GetPublicQuote shows a quote to the customer through a public link.
+// @Summary View quote through public link
+// @Description Show the quote revision a signed public link was created for. No authentication is required; the token grants access to this quote only.
+// @Tags Quotes
+// @Produce json
+// @Param token path string true "Quote link token"
+// @Success 200 {object} quotes.PublicQuote
+// @Failure 404 {object} object{error=string}
+// @Failure 410 {object} object{error=string}
+// @Router /public/quotes/{token} [get]
+func (h *Handlers) GetPublicQuote(w http.ResponseWriter, r *http.Request) {
+	claims, t, ok := h.verifyQuoteLink(w, r)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quotesService.OpenLink(r.Context(), t.SchemaName, claims)
+	if err != nil {
+		respondQuoteLinkError(w, err, "Failed to open quote")
+		return
+	}
+	quote.Contact = h.pdfContact(r.Context(), t.ID, t.SchemaName, quote.Contact, quote.ContactID)
+
+	view := quotes.NewPublicQuote(quote, claims.ExpiresAt.Time)
+	view.CompanyName = t.Name
+	respondJSON(w, http.StatusOK, view)
+}
+
+// GetPublicQuotePDF returns the quote PDF through a public link.
+// @Summary Download quote PDF through public link
+// @Description Download the PDF of the quote revision a signed public link was created for. No authentication is required.
+// @Tags Quotes
+// @Produce application/pdf
+// @Param token path string true "Quote link token"
+// @Success 200 {file} binary
+// @Failure 404 {object} object{error=string}
+// @Failure 410 {object} object{error=string}
+// @Failure 500 {object} object{error=string}
+// @Router /public/quotes/{token}/pdf [get]
+func (h *Handlers) GetPublicQuotePDF(w http.ResponseWriter, r *http.Request) {
+	claims, t, ok := h.verifyQuoteLink(w, r)
+	if !ok {
+		return
+	}
+
+	quote, err := h.quotesService.OpenLink(r.Context(), t.SchemaName, claims)
+	if err != nil {
+		respondQuoteLinkError(w, err, "Failed to open quote")
+		return
+	}
+	quote.Contact = h.pdfContact(r.Context(), t.ID, t.SchemaName, quote.Contact, quote.ContactID)
+	pdfBytes, err := generateQuotePDF(h.pdfService, quote, t, h.pdfService.PDFSettingsFromTenant(t))
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to generate PDF")
+		return
+	}
+
+	filename := "quote-" + quote.QuoteNumber + ".pdf"
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", "inline; filename=\""+filename+"\"")
+	w.Header().Set("Content-Length", fmt.Sprintf("%d", len(pdfBytes)))
+
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(pdfBytes)
+}
+
+// AcceptPublicQuote lets the customer accept a quote through a public link.
+// @Summary Accept quote through public link
+// @Description Accept the sent quote revision a signed public link was created for and emit a quote.accepted webhook event. No authentication is required.
+// @Tags Quotes
+// @Produce json
+// @Param token path string true "Quote link token"
+// @Success 200 {object} quotes.PublicQuote
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Failure 410 {object} object{error=string}
+// @Router /public/quotes/{token}/accept [post]
+func (h *Handlers) AcceptPublicQuote(w http.ResponseWriter, r *http.Request) {
+	h.answerPublicQuote(w, r, h.quotesService.AcceptLink, plugin.EventQuoteAccepted)
+}
+
+// RejectPublicQuote lets the customer reject a quote through a public link.
+// @Summary Reject quote through public link
+// @Description Reject the sent quote revision a signed public link was created for and emit a quote.rejected webhook event. No authentication is required.
+// @Tags Quotes
+// @Produce json
+// @Param token path string true "Quote link token"
+// @Success 200 {object} quotes.PublicQuote
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Failure 410 {object} object{error=string}
+// @Router /public/quotes/{token}/reject [post]
+func (h *Handlers) RejectPublicQuote(w http.ResponseWriter, r *http.Request) {
+	h.answerPublicQuote(w, r, h.quotesService.RejectLink, plugin.EventQuoteRejected)
+}
+
+func (h *Handlers) answerPublicQuote(w http.ResponseWriter, r *http.Request, answer func(context.Context, string, *quotes.LinkClaims) (*quotes.Quote, error), eventType string) {
+	claims, t, ok := h.verifyQuoteLink(w, r)
+	if !ok {
+		return
+	}
+
+	quote, err := answer(r.Context(), t.SchemaName, claims)
+	if err != nil {
+		respondQuoteLinkError(w, err, "")
+		return
+	}
+	h.emitQuoteAnswerEvent(eventType, t.ID, quote, quoteAnswerSourcePublicLink)
+
+	view := quotes.NewPublicQuote(quote, claims.ExpiresAt.Time)
+	view.CompanyName = t.Name
+	respondJSON(w, http.StatusOK, view)
+}
+
+// verifyQuoteLink checks the link token in the path and loads its tenant
+func (h *Handlers) verifyQuoteLink(w http.ResponseWriter, r *http.Request) (*quotes.LinkClaims, *tenant.Tenant, bool) {
+	claims, err := h.quotesService.VerifyLink(chi.URLParam(r, "token"))
+	if err != nil {
+		respondQuoteLinkError(w, err, "")
+		return nil, nil, false
+	}
+	t, err := h.tenantService.GetTenant(r.Context(), claims.TenantID)
+	if err != nil {
+		respondQuoteLinkError(w, quotes.ErrInvalidQuoteLink, "")
+		return nil, nil, false
+	}
+	return claims, t, true
+}
+
+// emitQuoteAnswerEvent sends a quote.accepted or quote.rejected webhook event
+func (h *Handlers) emitQuoteAnswerEvent(eventType, tenantID string, quote *quotes.Quote, source string) {
+	h.emitWebhookEvent(eventType, tenantID, quoteAnswerEvent{
+		QuoteID:     quote.ID,
+		QuoteNumber: quote.QuoteNumber,
+		Revision:    quote.Revision,
+		ContactID:   quote.ContactID,
+		Status:      quote.Status,
+		Source:      source,
+	})
+}
+
+// respondQuoteLinkError maps quote revision and link errors to responses. An
+// empty fallback reports any other error as a bad request with its message.
+func respondQuoteLinkError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, quotes.ErrInvalidQuoteLink):
+		respondError(w, http.StatusNotFound, "Quote link is invalid or has expired")
+	case errors.Is(err, quotes.ErrQuoteNotFound):
+		respondError(w, http.StatusNotFound, "Quote not found")
+	case errors.Is(err, quotes.ErrQuoteRevisionNotFound):
+		respondError(w, http.StatusNotFound, "Quote revision not found")
+	case errors.Is(err, quotes.ErrQuoteLinkSuperseded), errors.Is(err, quotes.ErrQuoteLapsed):
+		respondError(w, http.StatusGone, err.Error())
+	case errors.Is(err, quotes.ErrQuoteNotOpen):
+		respondError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, quotes.ErrQuoteLinksNotConfigured):
+		respondError(w, http.StatusServiceUnavailable, "Quote links are not configured")
+	case fallback == "":
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/pdf"
+	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/quotes"
+	"github.com/HMB-research/open-accounting/internal/webhooks"
+)
+
+func setupQuoteLinkTestHandlers(t *testing.T) (*Handlers, *mockQuotesRepository) {
+	t.Helper()
+	h, repo, tenantRepo := setupQuotesTestHandlers()
+	wave5AddTenant(tenantRepo)
+	h.quotesService = quotes.NewServiceWithRepository(repo).WithLinks(quotes.NewLinkSigner("test-secret"))
+	h.pdfService = pdf.NewService()
+
+	quote := wave5Quote(quotes.QuoteStatusSent)
+	quote.Revision = 1
+	validUntil := time.Now().AddDate(0, 0, 30)
+	quote.ValidUntil = &validUntil
+	repo.quotes[quote.ID] = quote
+	return h, repo
+}
+
+func publicQuoteRequest(method, target, token string) *http.Request {
+	return withURLParams(httptest.NewRequest(method, target, nil), map[string]string{"token": token})
+}
+
+func createTestQuoteLink(t *testing.T, h *Handlers, body any) quotes.QuoteLink {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	h.CreateQuoteLink(rr, wave5Request(http.MethodPost, "/tenants/tenant-1/quotes/quote-1/public-link", body, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1"}))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var link quotes.QuoteLink
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &link))
+	return link
+}
+
+func TestQuoteRevisionHandlers(t *testing.T) {
+	h, repo := setupQuoteLinkTestHandlers(t)
+	repo.revisions = []quotes.QuoteRevision{{
+		ID:       "revision-1",
+		TenantID: "tenant-1",
+		QuoteID:  "quote-1",
+		Revision: 1,
+		Currency: "EUR",
+		Total:    decimal.NewFromInt(122),
+		SentAt:   time.Now(),
+		Quote:    wave5Quote(quotes.QuoteStatusSent),
+	}}
+	params := map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1"}
+
+	rr := httptest.NewRecorder()
+	h.ListQuoteRevisions(rr, wave5Request(http.MethodGet, "/tenants/tenant-1/quotes/quote-1/revisions", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var revisions []quotes.QuoteRevision
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &revisions))
+	require.Len(t, revisions, 1)
+	assert.Nil(t, revisions[0].Quote)
+
+	rr = httptest.NewRecorder()
+	h.GetQuoteRevision(rr, wave5Request(http.MethodGet, "/tenants/tenant-1/quotes/quote-1/revisions/1", nil, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1", "revision": "1"}))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var revision quotes.QuoteRevision
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &revision))
+	require.NotNil(t, revision.Quote)
+	assert.Equal(t, "QT-001", revision.Quote.QuoteNumber)
+
+	rr = httptest.NewRecorder()
+	h.GetQuoteRevision(rr, wave5Request(http.MethodGet, "/tenants/tenant-1/quotes/quote-1/revisions/2", nil, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1", "revision": "2"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.GetQuoteRevision(rr, wave5Request(http.MethodGet, "/tenants/tenant-1/quotes/quote-1/revisions/zero", nil, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1", "revision": "zero"}))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestPublicQuoteLinkFlow(t *testing.T) {
+	h, repo := setupQuoteLinkTestHandlers(t)
+
+	delivered := make(chan string, 1)
+	client := &http.Client{Transport: webhookRoundTripper(func(r *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(r.Body)
+		delivered <- r.Header.Get("X-Open-Accounting-Event") + " " + string(body)
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: http.NoBody, Request: r}, nil
+	})}
+	h.webhookService = webhooks.NewServiceWithRepository(newMemoryWebhookRepository(), client)
+	active := true
+	_, err := h.webhookService.CreateEndpoint(testCtx(), "tenant-1", &webhooks.CreateEndpointRequest{
+		Name:     "CRM",
+		URL:      "https://93.184.216.34/webhook",
+		Events:   []string{plugin.EventQuoteAccepted},
+		Secret:   "secret",
+		IsActive: &active,
+	})
+	require.NoError(t, err)
+
+	link := createTestQuoteLink(t, h, quotes.CreateQuoteLinkRequest{ExpiresInDays: 7})
+	assert.NotEmpty(t, link.Token)
+	assert.Equal(t, 1, link.Revision)
+
+	rr := httptest.NewRecorder()
+	h.GetPublicQuote(rr, publicQuoteRequest(http.MethodGet, "/public/quotes/"+link.Token, link.Token))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var view quotes.PublicQuote
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &view))
+	assert.Equal(t, "QT-001", view.QuoteNumber)
+	assert.Equal(t, "Test Tenant", view.CompanyName)
+	assert.Equal(t, "Acme OU", view.CustomerName)
+
+	rr = httptest.NewRecorder()
+	h.GetPublicQuotePDF(rr, publicQuoteRequest(http.MethodGet, "/public/quotes/"+link.Token+"/pdf", link.Token))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, "application/pdf", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "inline")
+	assert.True(t, bytes.HasPrefix(rr.Body.Bytes(), []byte("%PDF")))
+
+	rr = httptest.NewRecorder()
+	h.AcceptPublicQuote(rr, publicQuoteRequest(http.MethodPost, "/public/quotes/"+link.Token+"/accept", link.Token))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, quotes.QuoteStatusAccepted, repo.quotes["quote-1"].Status)
+
+	select {
+	case event := <-delivered:
+		assert.Contains(t, event, plugin.EventQuoteAccepted)
+		assert.Contains(t, event, `"source":"public_link"`)
+	case <-time.After(5 * time.Second):
+		t.Fatal("quote.accepted webhook was not delivered")
+	}
+
+	// An answered quote can no longer be answered through the link
+	rr = httptest.NewRecorder()
+	h.RejectPublicQuote(rr, publicQuoteRequest(http.MethodPost, "/public/quotes/"+link.Token+"/reject", link.Token))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
+
+func TestPublicQuoteLinkErrors(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		h, _ := setupQuoteLinkTestHandlers(t)
+		rr := httptest.NewRecorder()
+		h.GetPublicQuote(rr, publicQuoteRequest(http.MethodGet, "/public/quotes/bogus", "bogus"))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("superseded revision", func(t *testing.T) {
+		h, repo := setupQuoteLinkTestHandlers(t)
+		link := createTestQuoteLink(t, h, nil)
+		repo.quotes["quote-1"].Revision = 2
+
+		rr := httptest.NewRecorder()
+		h.AcceptPublicQuote(rr, publicQuoteRequest(http.MethodPost, "/public/quotes/"+link.Token+"/accept", link.Token))
+		assert.Equal(t, http.StatusGone, rr.Code)
+		assert.Equal(t, quotes.QuoteStatusSent, repo.quotes["quote-1"].Status)
+	})
+
+	t.Run("expired quote", func(t *testing.T) {
+		h, repo := setupQuoteLinkTestHandlers(t)
+		link := createTestQuoteLink(t, h, nil)
+		repo.quotes["quote-1"].Status = quotes.QuoteStatusExpired
+
+		rr := httptest.NewRecorder()
+		h.RejectPublicQuote(rr, publicQuoteRequest(http.MethodPost, "/public/quotes/"+link.Token+"/reject", link.Token))
+		assert.Equal(t, http.StatusGone, rr.Code)
+	})
+
+	t.Run("draft quote cannot be shared", func(t *testing.T) {
+		h, repo := setupQuoteLinkTestHandlers(t)
+		repo.quotes["quote-1"].Status = quotes.QuoteStatusDraft
+
+		rr := httptest.NewRecorder()
+		h.CreateQuoteLink(rr, wave5Request(http.MethodPost, "/tenants/tenant-1/quotes/quote-1/public-link", nil, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1"}))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "only sent quotes can be shared")
+	})
+
+	t.Run("links not configured", func(t *testing.T) {
+		h, repo := setupQuoteLinkTestHandlers(t)
+		h.quotesService = quotes.NewServiceWithRepository(repo)
+
+		rr := httptest.NewRecorder()
+		h.CreateQuoteLink(rr, wave5Request(http.MethodPost, "/tenants/tenant-1/quotes/quote-1/public-link", nil, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1"}))
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	})
+}
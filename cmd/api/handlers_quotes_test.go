@@ -37,6 +37,9 @@ type mockQuotesRepository struct {
 	updateErr       error
 	deleteErr       error
 	updateStatusErr error
+	billings        []quotes.QuoteInvoice
+	billingErr      error
+	revisions       []quotes.QuoteRevision
 }
 
 func newMockQuotesRepository() *mockQuotesRepository {
@@ -140,6 +143,80 @@ func (m *mockQuotesRepository) SetConvertedToInvoice(ctx context.Context, schema
 	return errQuoteNotFound
 }
 
+func (m *mockQuotesRepository) RecordInvoice(ctx context.Context, schemaName string, billing *quotes.QuoteInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	if m.billingErr != nil {
+		return m.billingErr
+	}
+	if completes {
+		if err := m.SetConvertedToInvoice(ctx, schemaName, billing.TenantID, billing.QuoteID, billing.InvoiceID); err != nil {
+			return err
+		}
+	}
+	for i := range m.billings {
+		m.billings[i].NettedAmount = m.billings[i].NettedAmount.Add(deductions[m.billings[i].ID])
+	}
+	m.billings = append(m.billings, *billing)
+	return nil
+}
+
+func (m *mockQuotesRepository) ListInvoices(ctx context.Context, schemaName, tenantID, quoteID string) ([]quotes.QuoteInvoice, error) {
+	if m.billingErr != nil {
+		return nil, m.billingErr
+	}
+	result := []quotes.QuoteInvoice{}
+	for _, billing := range m.billings {
+		if billing.TenantID == tenantID && billing.QuoteID == quoteID {
+			result = append(result, billing)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockQuotesRepository) MarkSent(ctx context.Context, schemaName string, revision *quotes.QuoteRevision) error {
+	if m.updateStatusErr != nil {
+		return m.updateStatusErr
+	}
+	q, ok := m.quotes[revision.QuoteID]
+	if !ok || q.TenantID != revision.TenantID {
+		return errQuoteNotFound
+	}
+	q.Status = quotes.QuoteStatusSent
+	q.Revision = revision.Revision
+	m.revisions = append(m.revisions, *revision)
+	return nil
+}
+
+func (m *mockQuotesRepository) ListRevisions(ctx context.Context, schemaName, tenantID, quoteID string) ([]quotes.QuoteRevision, error) {
+	result := []quotes.QuoteRevision{}
+	for _, revision := range m.revisions {
+		if revision.TenantID == tenantID && revision.QuoteID == quoteID {
+			revision.Quote = nil
+			result = append(result, revision)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockQuotesRepository) GetRevision(ctx context.Context, schemaName, tenantID, quoteID string, revision int) (*quotes.QuoteRevision, error) {
+	for i := range m.revisions {
+		if m.revisions[i].TenantID == tenantID && m.revisions[i].QuoteID == quoteID && m.revisions[i].Revision == revision {
+			return &m.revisions[i], nil
+		}
+	}
+	return nil, quotes.ErrQuoteRevisionNotFound
+}
+
+func (m *mockQuotesRepository) ExpireDue(ctx context.Context, schemaName, tenantID string, asOf time.Time) ([]quotes.Quote, error) {
+	var expired []quotes.Quote
+	for _, q := range m.quotes {
+		if q.TenantID == tenantID && q.Status == quotes.QuoteStatusSent && q.ValidUntil != nil && q.ValidUntil.Before(asOf) {
+			q.Status = quotes.QuoteStatusExpired
+			expired = append(expired, *q)
+		}
+	}
+	return expired, nil
+}
+
 func setupQuotesTestHandlers() (*Handlers, *mockQuotesRepository, *mockTenantRepository) {
 	quotesRepo := newMockQuotesRepository()
 	quotesSvc := quotes.NewServiceWithRepository(quotesRepo)
@@ -877,3 +954,72 @@ func TestConvertQuoteToInvoice(t *testing.T) {
 	assert.True(t, result.Invoice.Lines[0].DiscountPercent.Equal(decimal.NewFromInt(10)))
 	assert.True(t, result.Invoice.Lines[0].VATRate.Equal(decimal.NewFromInt(22)))
 }
+
+func TestConvertQuoteToInvoiceByPercentage(t *testing.T) {
+	h, quotesRepo, tenantRepo := setupQuotesTestHandlers()
+	h.invoicingService = invoicing.NewServiceWithRepository(newMockInvoicingRepository(), nil)
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{ID: "tenant-1", SchemaName: "tenant_test"}
+	quotesRepo.quotes["quote-1"] = &quotes.Quote{
+		ID:           "quote-1",
+		TenantID:     "tenant-1",
+		QuoteNumber:  "QT-001",
+		ContactID:    "contact-1",
+		QuoteDate:    time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Status:       quotes.QuoteStatusAccepted,
+		Currency:     "EUR",
+		ExchangeRate: decimal.NewFromInt(1),
+		Lines: []quotes.QuoteLine{
+			{ID: "line-1", TenantID: "tenant-1", QuoteID: "quote-1", LineNumber: 1, Description: "Project", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(1000), VATRate: decimal.NewFromInt(22)},
+		},
+	}
+
+	convert := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tenants/tenant-1/quotes/quote-1/convert-to-invoice", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		req = withURLParams(req, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1"})
+		req = req.WithContext(contextWithClaims(req.Context(), createTestClaims("user-1", "test@example.com", "tenant-1", "owner")))
+		rr := httptest.NewRecorder()
+		h.ConvertQuoteToInvoice(rr, req)
+		return rr
+	}
+
+	rr := convert(`{"issue_date":"2026-03-10T00:00:00Z","kind":"INSTALMENT"}`)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "kind must be QUANTITY, PERCENTAGE or PREPAYMENT")
+
+	rr = convert(`{"issue_date":"2026-03-10T00:00:00Z","percent":"30"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var first quotes.QuoteInvoiceConversionResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&first))
+	assert.Equal(t, quotes.QuoteStatusAccepted, first.Quote.Status)
+	assert.True(t, first.Invoice.Subtotal.Equal(decimal.NewFromInt(300)))
+	assert.Equal(t, invoicing.BillingKindPercentage, first.Billing.Kind)
+
+	rr = convert(`{"issue_date":"2026-03-20T00:00:00Z"}`)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var rest quotes.QuoteInvoiceConversionResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&rest))
+	assert.True(t, rest.Invoice.Subtotal.Equal(decimal.NewFromInt(700)))
+	assert.Equal(t, quotes.QuoteStatusConverted, rest.Quote.Status)
+	assert.Equal(t, rest.Invoice.ID, *quotesRepo.quotes["quote-1"].ConvertedToInvoiceID)
+
+	list := httptest.NewRequest(http.MethodGet, "/tenants/tenant-1/quotes/quote-1/invoices", nil)
+	list = withURLParams(list, map[string]string{"tenantID": "tenant-1", "quoteID": "quote-1"})
+	rr = httptest.NewRecorder()
+	h.ListQuoteInvoices(rr, list)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var billings []quotes.QuoteInvoice
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&billings))
+	assert.Len(t, billings, 2)
+
+	quotesRepo.billingErr = errors.New("db error")
+	rr = httptest.NewRecorder()
+	h.ListQuoteInvoices(rr, list)
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	missing := httptest.NewRequest(http.MethodGet, "/tenants/tenant-1/quotes/missing/invoices", nil)
+	missing = withURLParams(missing, map[string]string{"tenantID": "tenant-1", "quoteID": "missing"})
+	rr = httptest.NewRecorder()
+	h.ListQuoteInvoices(rr, missing)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
@@ -281,6 +282,7 @@ func (h *Handlers) ResumeRecurringInvoice(w http.ResponseWriter, r *http.Request
 // @Param recurringID path string true "Recurring Invoice ID"
 // @Success 200 {object} recurring.GenerationResult
 // @Failure 400 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
 // @Router /tenants/{tenantID}/recurring-invoices/{recurringID}/generate [post]
 func (h *Handlers) GenerateRecurringInvoice(w http.ResponseWriter, r *http.Request) {
 	tenantCtx := h.tenantContextFromRequest(r)
@@ -288,7 +290,11 @@ func (h *Handlers) GenerateRecurringInvoice(w http.ResponseWriter, r *http.Reque
 
 	result, err := h.recurringService.GenerateInvoice(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, recurringID, userIDFromRequest(r))
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		status := http.StatusBadRequest
+		if errors.Is(err, recurring.ErrUsageMissing) {
+			status = http.StatusConflict
+		}
+		respondError(w, status, err.Error())
 		return
 	}
 
@@ -316,3 +322,190 @@ func (h *Handlers) GenerateDueRecurringInvoices(w http.ResponseWriter, r *http.R
 
 	respondJSON(w, http.StatusOK, results)
 }
+
+// RecordRecurringUsage records usage for the usage-based lines of a recurring invoice
+// @Summary Record recurring invoice usage
+// @Description Report usage quantities for usage-based recurring invoice lines; the usage is billed by the next generation run after its period ends
+// @Tags Recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param recurringID path string true "Recurring Invoice ID"
+// @Param request body recurring.RecordUsageRequest true "Usage records"
+// @Success 201 {array} recurring.UsageRecord
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/recurring-invoices/{recurringID}/usage [post]
+func (h *Handlers) RecordRecurringUsage(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	recurringID := chi.URLParam(r, "recurringID")
+
+	var req recurring.RecordUsageRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	records, err := h.recurringService.RecordUsage(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, recurringID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, records)
+}
+
+// ListRecurringUsage lists the usage records of a recurring invoice
+// @Summary List recurring invoice usage
+// @Description List usage reported for a recurring invoice, optionally only usage not yet invoiced
+// @Tags Recurring
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param recurringID path string true "Recurring Invoice ID"
+// @Param unbilled query bool false "Only list usage not yet invoiced"
+// @Success 200 {array} recurring.UsageRecord
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/recurring-invoices/{recurringID}/usage [get]
+func (h *Handlers) ListRecurringUsage(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	recurringID := chi.URLParam(r, "recurringID")
+	unbilledOnly := r.URL.Query().Get("unbilled") == "true"
+
+	records, err := h.recurringService.ListUsage(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, recurringID, unbilledOnly)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, records)
+}
+
+// DeleteRecurringUsage deletes a usage record that has not been invoiced
+// @Summary Delete recurring invoice usage
+// @Description Delete a usage record that has not been invoiced yet
+// @Tags Recurring
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param recurringID path string true "Recurring Invoice ID"
+// @Param usageID path string true "Usage record ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/recurring-invoices/{recurringID}/usage/{usageID} [delete]
+func (h *Handlers) DeleteRecurringUsage(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	recurringID := chi.URLParam(r, "recurringID")
+	usageID := chi.URLParam(r, "usageID")
+
+	if err := h.recurringService.DeleteUsage(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, recurringID, usageID); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, recurring.ErrUsageRecordNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// ImportRecurringUsage imports usage records from CSV data.
+// @Summary Import recurring invoice usage
+// @Description Import usage records for any recurring invoices from CSV data and skip invalid rows
+// @Tags Recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body recurring.ImportUsageRequest true "CSV import payload"
+// @Success 200 {object} recurring.ImportUsageResult
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/recurring-invoices/usage/import [post]
+func (h *Handlers) ImportRecurringUsage(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req recurring.ImportUsageRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.CSVContent) == "" {
+		respondError(w, http.StatusBadRequest, "csv_content is required")
+		return
+	}
+	if req.FileName == "" {
+		req.FileName = "recurring_usage_import.csv"
+	}
+	req.UserID = userIDFromRequest(r)
+
+	result, err := h.recurringService.ImportUsageCSV(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// CreateRecurringPriceChange schedules a price change on a recurring invoice
+// @Summary Schedule recurring invoice price change
+// @Description Schedule a new unit price or a percentage uplift for one line or all lines from an effective date; periods spanning the date are split and prorated
+// @Tags Recurring
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param recurringID path string true "Recurring Invoice ID"
+// @Param request body recurring.CreatePriceChangeRequest true "Price change"
+// @Success 201 {object} recurring.PriceChange
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/recurring-invoices/{recurringID}/price-changes [post]
+func (h *Handlers) CreateRecurringPriceChange(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	recurringID := chi.URLParam(r, "recurringID")
+
+	var req recurring.CreatePriceChangeRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	change, err := h.recurringService.CreatePriceChange(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, recurringID, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, change)
+}
+
+// DeleteRecurringPriceChange deletes a scheduled price change
+// @Summary Delete recurring invoice price change
+// @Description Delete a scheduled price change that has not been invoiced yet
+// @Tags Recurring
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param recurringID path string true "Recurring Invoice ID"
+// @Param changeID path string true "Price change ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/recurring-invoices/{recurringID}/price-changes/{changeID} [delete]
+func (h *Handlers) DeleteRecurringPriceChange(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+	recurringID := chi.URLParam(r, "recurringID")
+	changeID := chi.URLParam(r, "changeID")
+
+	if err := h.recurringService.DeletePriceChange(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, recurringID, changeID); err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, recurring.ErrPriceChangeNotFound) {
+			status = http.StatusNotFound
+		}
+		respondError(w, status, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -37,6 +37,8 @@ type mockRecurringRepository struct {
 	setActiveErr             error
 	getDueRecurringIDsErr    error
 	updateAfterGenerationErr error
+	usage                    []recurring.UsageRecord
+	priceChanges             []recurring.PriceChange
 }
 
 func newMockRecurringRepository() *mockRecurringRepository {
@@ -189,6 +191,75 @@ func (m *mockRecurringRepository) UpdateInvoiceEmailStatus(ctx context.Context,
 	return nil
 }
 
+func (m *mockRecurringRepository) RecordBilling(ctx context.Context, schemaName, tenantID string, record *recurring.BillingRecord) error {
+	ri, ok := m.invoices[record.RecurringInvoiceID]
+	if !ok || ri.TenantID != tenantID {
+		return recurring.ErrRecurringInvoiceNotFound
+	}
+	periodStart := record.PeriodStart
+	ri.LastPeriodStart = &periodStart
+	ri.NextGenerationDate = record.NextGenerationDate
+	for i := range m.usage {
+		for _, id := range record.UsageIDs {
+			if m.usage[i].ID == id && record.InvoiceID != "" {
+				invoiceID := record.InvoiceID
+				m.usage[i].InvoiceID = &invoiceID
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockRecurringRepository) CreateUsageRecords(ctx context.Context, schemaName string, records []recurring.UsageRecord) error {
+	m.usage = append(m.usage, records...)
+	return nil
+}
+
+func (m *mockRecurringRepository) ListUsageRecords(ctx context.Context, schemaName, tenantID, recurringInvoiceID string, unbilledOnly bool) ([]recurring.UsageRecord, error) {
+	var records []recurring.UsageRecord
+	for _, record := range m.usage {
+		if record.TenantID == tenantID && record.RecurringInvoiceID == recurringInvoiceID && (!unbilledOnly || record.InvoiceID == nil) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (m *mockRecurringRepository) DeleteUsageRecord(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error {
+	for i, record := range m.usage {
+		if record.ID == id && record.RecurringInvoiceID == recurringInvoiceID && record.InvoiceID == nil {
+			m.usage = append(m.usage[:i], m.usage[i+1:]...)
+			return nil
+		}
+	}
+	return recurring.ErrUsageRecordNotFound
+}
+
+func (m *mockRecurringRepository) CreatePriceChange(ctx context.Context, schemaName string, change *recurring.PriceChange) error {
+	m.priceChanges = append(m.priceChanges, *change)
+	return nil
+}
+
+func (m *mockRecurringRepository) ListPriceChanges(ctx context.Context, schemaName, tenantID, recurringInvoiceID string) ([]recurring.PriceChange, error) {
+	var changes []recurring.PriceChange
+	for _, change := range m.priceChanges {
+		if change.RecurringInvoiceID == recurringInvoiceID {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (m *mockRecurringRepository) DeletePriceChange(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error {
+	for i, change := range m.priceChanges {
+		if change.ID == id && change.RecurringInvoiceID == recurringInvoiceID && change.AppliedAt == nil {
+			m.priceChanges = append(m.priceChanges[:i], m.priceChanges[i+1:]...)
+			return nil
+		}
+	}
+	return recurring.ErrPriceChangeNotFound
+}
+
 type mockRecurringInvoicingService struct {
 	getByIDInvoice *invoicing.Invoice
 	getByIDErr     error
@@ -234,7 +305,7 @@ func (m *mockRecurringInvoicingService) Create(ctx context.Context, tenantID, sc
 			Description:     line.Description,
 			Quantity:        line.Quantity,
 			Unit:            line.Unit,
-			UnitPrice:       line.UnitPrice,
+			UnitPrice:       *line.UnitPrice,
 			DiscountPercent: line.DiscountPercent,
 			VATRate:         line.VATRate,
 			AccountID:       line.AccountID,
@@ -539,3 +610,162 @@ func TestRecurringHandlersErrorPaths(t *testing.T) {
 	require.Equal(t, http.StatusInternalServerError, rr.Code, rr.Body.String())
 	assert.Contains(t, rr.Body.String(), "Failed to generate invoices")
 }
+
+func TestRecurringUsageHandlers(t *testing.T) {
+	h, tenantRepo, recurringRepo, invoicingSvc := setupRecurringTestHandlers()
+	tenantRepo.addTestTenant("tenant-1", "Recurring Tenant", "recurring-tenant")
+	claims := &auth.Claims{UserID: "user-1", Email: "user@example.com", TenantID: "tenant-1", Role: tenant.RoleOwner}
+
+	ri := seedRecurringInvoice(recurringRepo, "tenant-1", "rec-1")
+	lastPeriodStart := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	ri.LastPeriodStart = &lastPeriodStart
+	ri.NextGenerationDate = time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	recurringRepo.lines["rec-1"] = append(recurringRepo.lines["rec-1"], recurring.RecurringInvoiceLine{
+		ID:                 "line-2",
+		RecurringInvoiceID: "rec-1",
+		LineNumber:         2,
+		Description:        "Electricity",
+		Quantity:           decimal.NewFromInt(1),
+		Unit:               "kWh",
+		UnitPrice:          decimal.RequireFromString("0.20"),
+		VATRate:            decimal.NewFromInt(22),
+		UsageMetric:        "kwh",
+	})
+	params := map[string]string{"tenantID": "tenant-1", "recurringID": "rec-1"}
+
+	req := withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/rec-1/generate", nil, claims), params)
+	rr := httptest.NewRecorder()
+	h.GenerateRecurringInvoice(rr, req)
+	require.Equal(t, http.StatusConflict, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "no usage reported for kwh")
+	assert.Empty(t, invoicingSvc.createRequests)
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/rec-1/usage", map[string]any{
+		"records": []map[string]any{{
+			"metric":     "kwh",
+			"usage_date": time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+			"quantity":   "150",
+			"reference":  "meter-1",
+		}},
+	}, claims), params)
+	rr = httptest.NewRecorder()
+	h.RecordRecurringUsage(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var recorded []recurring.UsageRecord
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&recorded))
+	require.Len(t, recorded, 1)
+	assert.Equal(t, "user-1", recorded[0].CreatedBy)
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/rec-1/usage", map[string]any{
+		"records": []map[string]any{{"metric": "water", "usage_date": time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), "quantity": "1"}},
+	}, claims), params)
+	rr = httptest.NewRecorder()
+	h.RecordRecurringUsage(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/rec-1/generate", nil, claims), params)
+	rr = httptest.NewRecorder()
+	h.GenerateRecurringInvoice(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	require.Len(t, invoicingSvc.createRequests, 1)
+	require.Len(t, invoicingSvc.createRequests[0].Lines, 2)
+	assert.True(t, decimal.NewFromInt(150).Equal(invoicingSvc.createRequests[0].Lines[1].Quantity))
+
+	req = withURLParams(httptest.NewRequest(http.MethodGet, "/tenants/tenant-1/recurring-invoices/rec-1/usage?unbilled=true", nil), params)
+	rr = httptest.NewRecorder()
+	h.ListRecurringUsage(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var unbilled []recurring.UsageRecord
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&unbilled))
+	assert.Empty(t, unbilled)
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodDelete, "/tenants/tenant-1/recurring-invoices/rec-1/usage/"+recorded[0].ID, nil, claims), map[string]string{
+		"tenantID":    "tenant-1",
+		"recurringID": "rec-1",
+		"usageID":     recorded[0].ID,
+	})
+	rr = httptest.NewRecorder()
+	h.DeleteRecurringUsage(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/usage/import", map[string]any{
+		"csv_content": "recurring_invoice,metric,usage_date,quantity\nMonthly Retainer,kwh,2026-02-10,80\nMonthly Retainer,gas,2026-02-10,5\n",
+	}, claims), map[string]string{"tenantID": "tenant-1"})
+	rr = httptest.NewRecorder()
+	h.ImportRecurringUsage(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	var imported recurring.ImportUsageResult
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&imported))
+	assert.Equal(t, "recurring_usage_import.csv", imported.FileName)
+	assert.Equal(t, 1, imported.RecordsCreated)
+	assert.Equal(t, 1, imported.RowsSkipped)
+
+	var importedID string
+	for _, record := range recurringRepo.usage {
+		if record.InvoiceID == nil {
+			importedID = record.ID
+		}
+	}
+	require.NotEmpty(t, importedID)
+	req = withURLParams(makeAuthenticatedRequest(http.MethodDelete, "/tenants/tenant-1/recurring-invoices/rec-1/usage/"+importedID, nil, claims), map[string]string{
+		"tenantID":    "tenant-1",
+		"recurringID": "rec-1",
+		"usageID":     importedID,
+	})
+	rr = httptest.NewRecorder()
+	h.DeleteRecurringUsage(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/usage/import", map[string]any{}, claims), map[string]string{"tenantID": "tenant-1"})
+	rr = httptest.NewRecorder()
+	h.ImportRecurringUsage(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "csv_content is required")
+}
+
+func TestRecurringPriceChangeHandlers(t *testing.T) {
+	h, tenantRepo, recurringRepo, _ := setupRecurringTestHandlers()
+	tenantRepo.addTestTenant("tenant-1", "Recurring Tenant", "recurring-tenant")
+	claims := &auth.Claims{UserID: "user-1", Email: "user@example.com", TenantID: "tenant-1", Role: tenant.RoleOwner}
+	seedRecurringInvoice(recurringRepo, "tenant-1", "rec-1")
+	params := map[string]string{"tenantID": "tenant-1", "recurringID": "rec-1"}
+
+	req := withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/rec-1/price-changes", map[string]any{
+		"effective_date": time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		"uplift_percent": "3.5",
+		"note":           "CPI",
+	}, claims), params)
+	rr := httptest.NewRecorder()
+	h.CreateRecurringPriceChange(rr, req)
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+
+	var change recurring.PriceChange
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&change))
+	require.NotNil(t, change.UpliftPercent)
+	assert.Equal(t, "3.5", change.UpliftPercent.String())
+	assert.Equal(t, "user-1", change.CreatedBy)
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodPost, "/tenants/tenant-1/recurring-invoices/rec-1/price-changes", map[string]any{
+		"effective_date": time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		"unit_price":     "120",
+	}, claims), params)
+	rr = httptest.NewRecorder()
+	h.CreateRecurringPriceChange(rr, req)
+	require.Equal(t, http.StatusBadRequest, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Body.String(), "before the next generation date")
+
+	deleteParams := map[string]string{"tenantID": "tenant-1", "recurringID": "rec-1", "changeID": change.ID}
+	req = withURLParams(makeAuthenticatedRequest(http.MethodDelete, "/tenants/tenant-1/recurring-invoices/rec-1/price-changes/"+change.ID, nil, claims), deleteParams)
+	rr = httptest.NewRecorder()
+	h.DeleteRecurringPriceChange(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Empty(t, recurringRepo.priceChanges)
+
+	req = withURLParams(makeAuthenticatedRequest(http.MethodDelete, "/tenants/tenant-1/recurring-invoices/rec-1/price-changes/"+change.ID, nil, claims), deleteParams)
+	rr = httptest.NewRecorder()
+	h.DeleteRecurringPriceChange(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code, rr.Body.String())
+}
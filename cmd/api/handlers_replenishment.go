@@ -0,0 +1,208 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
+	"github.com/HMB-research/open-accounting/internal/webhooks"
+)
+
+type lowStockEventResponse struct {
+	AsOfDate    time.Time                      `json:"as_of_date"`
+	WarehouseID string                         `json:"warehouse_id,omitempty"`
+	Lines       []purchasing.ReplenishmentLine `json:"lines"`
+	Event       *webhooks.DeliveryResult       `json:"event,omitempty"`
+}
+
+// GetReplenishmentReport returns reorder proposals grouped by supplier.
+// @Summary Get replenishment report
+// @Description Compare available stock (on hand minus reservations) plus quantities open on purchase orders with each tracked product's reorder level per warehouse and propose order quantities grouped by supplier and warehouse. The reorder level is the product's reorder point, or its minimum stock level plus lead-time demand at the average daily issue rate over velocity_days when higher; proposals restore stock to the reorder level plus coverage_days of demand.
+// @Tags Inventory
+// @Produce json,text/csv,application/vnd.openxmlformats-officedocument.spreadsheetml.sheet,application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param warehouse_id query string false "Warehouse ID"
+// @Param supplier_id query string false "Supplier contact ID"
+// @Param as_of_date query string false "As-of date (YYYY-MM-DD, default today)"
+// @Param velocity_days query int false "Consumption window in days (default 90)"
+// @Param coverage_days query int false "Days of demand to cover beyond the reorder level (default 30)"
+// @Param format query string false "Response format: json, csv, xlsx, or pdf"
+// @Success 200 {object} purchasing.ReplenishmentReport
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/replenishment [get]
+func (h *Handlers) GetReplenishmentReport(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	format, err := reportResponseFormat(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	req, err := replenishmentRequestFromQuery(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.purchasingService.GetReplenishmentReport(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	fileStem := "replenishment-" + report.AsOfDate.Format("2006-01-02")
+	if format == "csv" {
+		content, err := exportReplenishmentCSV(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export replenishment report CSV")
+			return
+		}
+		respondReportCSV(w, fileStem+".csv", content)
+		return
+	}
+	if format == "xlsx" {
+		content, err := exportReplenishmentXLSX(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export replenishment report XLSX")
+			return
+		}
+		respondReportXLSX(w, fileStem+".xlsx", content)
+		return
+	}
+	if format == "pdf" {
+		content, err := exportReplenishmentPDF(report)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export replenishment report PDF")
+			return
+		}
+		respondReportPDF(w, fileStem+".pdf", content)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, report)
+}
+
+// CreateReplenishmentPurchaseOrders converts replenishment proposals into draft purchase orders.
+// @Summary Create purchase orders from replenishment proposals
+// @Description Recompute the replenishment report and create one draft purchase order per supplier and warehouse at the products' purchase prices, expected after the longest supplier lead time. Lines of products without a supplier are returned as unassigned.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body purchasing.CreateReplenishmentOrdersRequest true "Replenishment selection"
+// @Success 201 {object} purchasing.ReplenishmentOrdersResult
+// @Failure 400 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/replenishment/purchase-orders [post]
+func (h *Handlers) CreateReplenishmentPurchaseOrders(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req purchasing.CreateReplenishmentOrdersRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	result, err := h.purchasingService.CreateReplenishmentOrders(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, result)
+}
+
+// EmitLowStockEvent sends an inventory.low_stock webhook event for stock below minimum levels.
+// @Summary Emit low-stock webhook event
+// @Description Recompute the replenishment report and, when any product's available stock is below its minimum stock level, deliver one inventory.low_stock event with those lines to subscribed webhook endpoints. No event is sent when nothing is below minimum.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body purchasing.ReplenishmentRequest false "Replenishment selection"
+// @Success 200 {object} lowStockEventResponse
+// @Failure 400 {object} object{error=string}
+// @Failure 503 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/replenishment/low-stock-events [post]
+func (h *Handlers) EmitLowStockEvent(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req purchasing.ReplenishmentRequest
+	if r.ContentLength != 0 && !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	if h.webhookService == nil {
+		respondError(w, http.StatusServiceUnavailable, "Webhook service is unavailable")
+		return
+	}
+
+	report, err := h.purchasingService.GetReplenishmentReport(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	response := lowStockEventResponse{
+		AsOfDate:    report.AsOfDate,
+		WarehouseID: report.WarehouseID,
+		Lines:       report.LowStockLines(),
+	}
+	if len(response.Lines) > 0 {
+		payload, err := json.Marshal(map[string]any{
+			"as_of_date":   report.AsOfDate.Format("2006-01-02"),
+			"warehouse_id": report.WarehouseID,
+			"lines":        response.Lines,
+		})
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to encode low-stock event")
+			return
+		}
+		result, err := h.webhookService.Dispatch(r.Context(), webhooks.Event{
+			Type:     plugin.EventInventoryLowStock,
+			TenantID: tenantCtx.tenantID,
+			Data:     payload,
+		})
+		if err != nil {
+			log.Error().Err(err).Str("tenant", tenantCtx.tenantID).Msg("Failed to dispatch low-stock event")
+			respondError(w, http.StatusInternalServerError, "Failed to dispatch low-stock event")
+			return
+		}
+		response.Event = result
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+func replenishmentRequestFromQuery(r *http.Request) (*purchasing.ReplenishmentRequest, error) {
+	query := r.URL.Query()
+	req := &purchasing.ReplenishmentRequest{
+		WarehouseID: strings.TrimSpace(query.Get("warehouse_id")),
+		SupplierID:  strings.TrimSpace(query.Get("supplier_id")),
+	}
+	asOfDate, err := inventorySubledgerAsOfDate(r)
+	if err != nil {
+		return nil, err
+	}
+	req.AsOfDate = asOfDate
+	for name, target := range map[string]*int{"velocity_days": &req.VelocityDays, "coverage_days": &req.CoverageDays} {
+		raw := strings.TrimSpace(query.Get(name))
+		if raw == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%s must be a positive integer", name)
+		}
+		*target = parsed
+	}
+	return req, nil
+}
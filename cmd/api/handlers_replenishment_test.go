@@ -0,0 +1,140 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
+	"github.com/HMB-research/open-accounting/internal/webhooks"
+)
+
+// replenishmentHandlerPlanner serves one product below its minimum stock level.
+type replenishmentHandlerPlanner struct{}
+
+func (replenishmentHandlerPlanner) ListProducts(context.Context, string, string, *inventory.ProductFilter) ([]inventory.Product, error) {
+	return []inventory.Product{{
+		ID:             purchasingHandlerProductID,
+		Code:           "SKU-1",
+		Name:           "Widget",
+		ProductType:    inventory.ProductTypeGoods,
+		TrackInventory: true,
+		IsActive:       true,
+		SupplierID:     purchasingHandlerSupplierID,
+		MinStockLevel:  decimal.NewFromInt(5),
+		ReorderPoint:   decimal.NewFromInt(10),
+		LeadTimeDays:   7,
+		PurchasePrice:  decimal.NewFromInt(2),
+		VATRate:        decimal.NewFromInt(22),
+	}}, nil
+}
+
+func (replenishmentHandlerPlanner) ListWarehouses(context.Context, string, string, bool) ([]inventory.Warehouse, error) {
+	return []inventory.Warehouse{{ID: purchasingHandlerWarehouse, Code: "MAIN", Name: "Main", IsActive: true}}, nil
+}
+
+func (replenishmentHandlerPlanner) GetStockLevels(_ context.Context, _, _, productID string) ([]inventory.StockLevel, error) {
+	return []inventory.StockLevel{{ProductID: productID, WarehouseID: purchasingHandlerWarehouse, Quantity: decimal.NewFromInt(2)}}, nil
+}
+
+func (replenishmentHandlerPlanner) GetMovements(context.Context, string, string, string) ([]inventory.InventoryMovement, error) {
+	return nil, nil
+}
+
+func setupReplenishmentHandlers(t *testing.T) (*Handlers, *purchasingHandlerRepository) {
+	t.Helper()
+
+	h, repo, _ := setupPurchasingHandlers(t)
+	h.purchasingService.WithStockPlanner(replenishmentHandlerPlanner{})
+	return h, repo
+}
+
+func TestReplenishmentHandlers(t *testing.T) {
+	h, repo := setupReplenishmentHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetReplenishmentReport(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/replenishment?as_of_date=2026-03-31&coverage_days=10", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var report purchasing.ReplenishmentReport
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(t, 10, report.CoverageDays)
+	require.Len(t, report.Proposals, 1)
+	require.Len(t, report.Proposals[0].Lines, 1)
+	assert.True(t, report.Proposals[0].Lines[0].SuggestedQuantity.Equal(decimal.NewFromInt(8)))
+
+	rr = httptest.NewRecorder()
+	h.GetReplenishmentReport(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/replenishment?as_of_date=2026-03-31&format=csv", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "replenishment-2026-03-31.csv")
+	assert.Contains(t, rr.Body.String(), "SKU-1")
+
+	rr = httptest.NewRecorder()
+	h.CreateReplenishmentPurchaseOrders(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/replenishment/purchase-orders", purchasing.CreateReplenishmentOrdersRequest{
+		ReplenishmentRequest: purchasing.ReplenishmentRequest{AsOfDate: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+		OrderDate:            time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+	}, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var result purchasing.ReplenishmentOrdersResult
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	require.Len(t, result.PurchaseOrders, 1)
+	assert.Equal(t, purchasing.PurchaseOrderStatusDraft, result.PurchaseOrders[0].Status)
+	assert.Equal(t, "user-1", result.PurchaseOrders[0].CreatedBy)
+	assert.Len(t, repo.orders, 1)
+}
+
+func TestReplenishmentHandlersErrors(t *testing.T) {
+	h, _ := setupReplenishmentHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetReplenishmentReport(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/replenishment?velocity_days=0", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "velocity_days must be a positive integer")
+
+	rr = httptest.NewRecorder()
+	h.GetReplenishmentReport(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/replenishment?warehouse_id=missing", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.EmitLowStockEvent(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/replenishment/low-stock-events", nil, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+}
+
+func TestEmitLowStockEventHandler(t *testing.T) {
+	h, _ := setupReplenishmentHandlers(t)
+
+	var deliveredEvent string
+	client := &http.Client{Transport: webhookRoundTripper(func(r *http.Request) (*http.Response, error) {
+		deliveredEvent = r.Header.Get("X-Open-Accounting-Event")
+		return &http.Response{StatusCode: http.StatusOK, Header: make(http.Header), Body: http.NoBody, Request: r}, nil
+	})}
+	h.webhookService = webhooks.NewServiceWithRepository(newMemoryWebhookRepository(), client)
+	active := true
+	_, err := h.webhookService.CreateEndpoint(testCtx(), "tenant-1", &webhooks.CreateEndpointRequest{
+		Name:     "Buyer",
+		URL:      "https://93.184.216.34/webhook",
+		Events:   []string{plugin.EventInventoryLowStock},
+		Secret:   "secret",
+		IsActive: &active,
+	})
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	h.EmitLowStockEvent(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/replenishment/low-stock-events", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var response lowStockEventResponse
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+	require.Len(t, response.Lines, 1)
+	assert.True(t, response.Lines[0].BelowMinimum)
+	require.NotNil(t, response.Event)
+	assert.Len(t, response.Event.Deliveries, 1)
+	assert.Equal(t, plugin.EventInventoryLowStock, deliveredEvent)
+}
@@ -0,0 +1,327 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/HMB-research/open-accounting/internal/stocktake"
+)
+
+// ListStockCounts returns stock count sessions for a tenant.
+// @Summary List stock counts
+// @Description List stock count sessions, newest first, with optional status and warehouse filters
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param status query string false "Filter by status (OPEN, SUBMITTED, APPROVED, CANCELED)"
+// @Param warehouse_id query string false "Filter by warehouse ID"
+// @Success 200 {array} stocktake.StockCount
+// @Failure 500 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts [get]
+func (h *Handlers) ListStockCounts(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	query := r.URL.Query()
+	counts, err := h.stocktakeService.List(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &stocktake.StockCountFilter{
+		Status:      stocktake.StockCountStatus(query.Get("status")),
+		WarehouseID: query.Get("warehouse_id"),
+	})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to list stock counts")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, counts)
+}
+
+// CreateStockCount opens a stock count session for a warehouse.
+// @Summary Create stock count
+// @Description Open a stock count for one warehouse. The expected quantity of every product, lot and serial on hand is frozen together with its unit cost at the valuation method (default: tenant inventory valuation policy). A warehouse can have only one open or submitted count.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param request body stocktake.CreateStockCountRequest true "Stock count"
+// @Success 201 {object} stocktake.StockCount
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts [post]
+func (h *Handlers) CreateStockCount(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req stocktake.CreateStockCountRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	tenantRecord, err := h.tenantService.GetTenant(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Tenant not found")
+		return
+	}
+	req.ValuationMethod = tenantInventoryValuationMethod(tenantRecord, req.ValuationMethod)
+
+	count, err := h.stocktakeService.Create(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, &req)
+	if err != nil {
+		respondStockCountError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, count)
+}
+
+// GetStockCount returns a stock count with its lines.
+// @Summary Get stock count
+// @Description Get a stock count with frozen expected quantities, unit costs and counted quantities per line
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Success 200 {object} stocktake.StockCount
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID} [get]
+func (h *Handlers) GetStockCount(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	count, err := h.stocktakeService.GetByID(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"))
+	if err != nil {
+		respondStockCountError(w, err, "Failed to get stock count")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, count)
+}
+
+// RecordStockCounts enters counted quantities on an open stock count.
+// @Summary Record stock counts
+// @Description Enter counted quantities by line_id, or by product_id or barcode with an optional lot_number and serial_number. Entries for the same line are summed and replace earlier counts unless accumulate is set. Counts for products or lots that were not expected add a line with zero expected quantity.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Param request body stocktake.RecordCountsRequest true "Counted quantities"
+// @Success 200 {object} stocktake.StockCount
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID}/counts [post]
+func (h *Handlers) RecordStockCounts(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req stocktake.RecordCountsRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	count, err := h.stocktakeService.RecordCounts(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"), &req)
+	if err != nil {
+		respondStockCountError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, count)
+}
+
+// ImportStockCounts records counted quantities from scanner CSV output.
+// @Summary Import stock counts from CSV
+// @Description Import scanner output with barcode and quantity columns and optional lot_number, serial_number and expiry_date columns. Comma, semicolon and tab delimiters are detected. Repeated scans of the same item are summed; rows that cannot be matched are reported and skipped.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Param request body stocktake.ImportCountsRequest true "Scanner CSV"
+// @Success 200 {object} stocktake.ImportCountsResult
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID}/counts/import [post]
+func (h *Handlers) ImportStockCounts(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req stocktake.ImportCountsRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	result, err := h.stocktakeService.ImportCountsCSV(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"), &req)
+	if err != nil {
+		respondStockCountError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, result)
+}
+
+// SubmitStockCount hands an open stock count over for review.
+// @Summary Submit stock count
+// @Description Close counting on an open stock count with at least one counted line and hand it over for review
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID}/submit [post]
+func (h *Handlers) SubmitStockCount(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	if err := h.stocktakeService.Submit(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"), userIDFromRequest(r)); err != nil {
+		respondStockCountError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": string(stocktake.StockCountStatusSubmitted)})
+}
+
+// ApproveStockCount approves a submitted stock count and posts its variances.
+// @Summary Approve stock count
+// @Description Approve a submitted stock count. Every difference between counted and expected quantities is booked to stock in the warehouse and posted at the frozen unit costs against variance_account_id (an EXPENSE account) in one journal entry. Uncounted lines are rejected unless zero_uncounted is set. Counts dated in a locked period are rejected.
+// @Tags Inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Param request body stocktake.ApproveStockCountRequest true "Approval"
+// @Success 200 {object} stocktake.StockCount
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Failure 409 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID}/approve [post]
+func (h *Handlers) ApproveStockCount(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	var req stocktake.ApproveStockCountRequest
+	if !decodeJSONRequest(w, r, &req) {
+		return
+	}
+	req.UserID = userIDFromRequest(r)
+
+	lockDate, err := h.getTenantPeriodLockDate(r.Context(), tenantCtx.tenantID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to validate period lock")
+		return
+	}
+	req.PeriodLockDate = lockDate
+
+	count, err := h.stocktakeService.Approve(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"), &req)
+	if err != nil {
+		respondStockCountError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, count)
+}
+
+// CancelStockCount cancels an open or submitted stock count.
+// @Summary Cancel stock count
+// @Description Cancel an open or submitted stock count without posting it
+// @Tags Inventory
+// @Produce json
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Success 200 {object} object{status=string}
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID}/cancel [post]
+func (h *Handlers) CancelStockCount(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	if err := h.stocktakeService.Cancel(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"), userIDFromRequest(r)); err != nil {
+		respondStockCountError(w, err, "")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"status": string(stocktake.StockCountStatusCanceled)})
+}
+
+// GetStockCountVariance returns the valued variances of a stock count.
+// @Summary Get stock count variance
+// @Description Compare counted with frozen expected quantities per line and value the differences at the unit costs frozen under the count's valuation method, with shortage, surplus and net totals. Supports CSV, XLSX and PDF export for review.
+// @Tags Inventory
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param tenantID path string true "Tenant ID"
+// @Param stockCountID path string true "Stock count ID"
+// @Param format query string false "Response format: json, csv, xlsx, or pdf"
+// @Success 200 {object} stocktake.StockCountVariance
+// @Failure 400 {object} object{error=string}
+// @Failure 404 {object} object{error=string}
+// @Router /tenants/{tenantID}/inventory/stock-counts/{stockCountID}/variance [get]
+func (h *Handlers) GetStockCountVariance(w http.ResponseWriter, r *http.Request) {
+	tenantCtx := h.tenantContextFromRequest(r)
+
+	format, err := reportResponseFormat(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	variance, err := h.stocktakeService.GetVariance(r.Context(), tenantCtx.tenantID, tenantCtx.schemaName, chi.URLParam(r, "stockCountID"))
+	if err != nil {
+		respondStockCountError(w, err, "Failed to get stock count variance")
+		return
+	}
+
+	fileStem := "stock-count-variance-" + variance.CountNumber
+	if format == "csv" {
+		content, err := exportStockCountVarianceCSV(variance)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export stock count variance CSV")
+			return
+		}
+		respondReportCSV(w, fileStem+".csv", content)
+		return
+	}
+	if format == "xlsx" {
+		content, err := exportStockCountVarianceXLSX(variance)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export stock count variance XLSX")
+			return
+		}
+		respondReportXLSX(w, fileStem+".xlsx", content)
+		return
+	}
+	if format == "pdf" {
+		content, err := exportStockCountVariancePDF(variance)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to export stock count variance PDF")
+			return
+		}
+		respondReportPDF(w, fileStem+".pdf", content)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, variance)
+}
+
+// respondStockCountError maps stocktake errors to responses. An empty
+// fallback reports any other error as a bad request with its message.
+func respondStockCountError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, stocktake.ErrStockCountNotFound):
+		respondError(w, http.StatusNotFound, "Stock count not found")
+	case errors.Is(err, stocktake.ErrPeriodLocked):
+		respondError(w, http.StatusConflict, err.Error())
+	case fallback == "":
+		respondError(w, http.StatusBadRequest, err.Error())
+	default:
+		respondError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+const (
+	stockCountHandlerWarehouse = "44444444-4444-4444-8444-444444444444"
+	stockCountHandlerProductID = "55555555-5555-4555-8555-555555555555"
+)
+
+// stockCountHandlerRepository is an in-memory stocktake repository for handler tests.
+type stockCountHandlerRepository struct {
+	counts map[string]*stocktake.StockCount
+}
+
+func (m *stockCountHandlerRepository) Create(_ context.Context, _ string, count *stocktake.StockCount) error {
+	stored := *count
+	stored.Lines = append([]stocktake.StockCountLine(nil), count.Lines...)
+	m.counts[count.ID] = &stored
+	return nil
+}
+
+func (m *stockCountHandlerRepository) GetByID(_ context.Context, _, _, countID string) (*stocktake.StockCount, error) {
+	count, ok := m.counts[countID]
+	if !ok {
+		return nil, stocktake.ErrStockCountNotFound
+	}
+	copyCount := *count
+	copyCount.Lines = append([]stocktake.StockCountLine(nil), count.Lines...)
+	return &copyCount, nil
+}
+
+func (m *stockCountHandlerRepository) List(_ context.Context, _, _ string, filter *stocktake.StockCountFilter) ([]stocktake.StockCount, error) {
+	result := []stocktake.StockCount{}
+	for _, count := range m.counts {
+		if filter.Status == "" || count.Status == filter.Status {
+			result = append(result, *count)
+		}
+	}
+	return result, nil
+}
+
+func (m *stockCountHandlerRepository) SaveCounts(_ context.Context, _, _, countID string, counted, added []stocktake.StockCountLine) error {
+	count := m.counts[countID]
+	for _, line := range counted {
+		for i := range count.Lines {
+			if count.Lines[i].ID == line.ID {
+				count.Lines[i] = line
+			}
+		}
+	}
+	count.Lines = append(count.Lines, added...)
+	return nil
+}
+
+func (m *stockCountHandlerRepository) UpdateStatus(_ context.Context, _, _, countID string, status stocktake.StockCountStatus, _ string) error {
+	count, ok := m.counts[countID]
+	if !ok {
+		return stocktake.ErrStockCountNotFound
+	}
+	count.Status = status
+	return nil
+}
+
+func (m *stockCountHandlerRepository) Approve(_ context.Context, _ string, count *stocktake.StockCount) error {
+	stored := *count
+	m.counts[count.ID] = &stored
+	return nil
+}
+
+func (m *stockCountHandlerRepository) GenerateNumber(context.Context, string, string) (string, error) {
+	return fmt.Sprintf("SC-%05d", len(m.counts)+1), nil
+}
+
+type stockCountHandlerStock struct {
+	posted *inventory.PostStockCountRequest
+}
+
+func (s *stockCountHandlerStock) GetWarehouseByID(_ context.Context, _, _, warehouseID string) (*inventory.Warehouse, error) {
+	return &inventory.Warehouse{ID: warehouseID, Name: "Main", IsActive: true}, nil
+}
+
+func (s *stockCountHandlerStock) ListProducts(context.Context, string, string, *inventory.ProductFilter) ([]inventory.Product, error) {
+	return []inventory.Product{
+		{ID: stockCountHandlerProductID, Code: "SKU-1", Name: "Widget", Barcode: "4740001", ProductType: inventory.ProductTypeGoods, TrackInventory: true},
+	}, nil
+}
+
+func (s *stockCountHandlerStock) GetInventoryLotReport(context.Context, string, string, string, string, bool) (*inventory.InventoryLotReport, error) {
+	return &inventory.InventoryLotReport{Lines: []inventory.InventoryLotLine{
+		{ProductID: stockCountHandlerProductID, Quantity: decimal.NewFromInt(10)},
+	}}, nil
+}
+
+func (s *stockCountHandlerStock) GetInventoryValuation(_ context.Context, _, _, warehouseID, method string) (*inventory.InventoryValuationReport, error) {
+	return &inventory.InventoryValuationReport{
+		WarehouseID:     warehouseID,
+		ValuationMethod: method,
+		Lines:           []inventory.InventoryValuationLine{{ProductID: stockCountHandlerProductID, UnitCost: decimal.NewFromInt(4)}},
+	}, nil
+}
+
+func (s *stockCountHandlerStock) PostStockCountVariances(_ context.Context, _, _ string, req *inventory.PostStockCountRequest) (*inventory.PostStockCountResult, error) {
+	s.posted = req
+	return &inventory.PostStockCountResult{WarehouseID: req.WarehouseID, JournalID: "count-journal"}, nil
+}
+
+func setupStockCountHandlers(t *testing.T) (*Handlers, *stockCountHandlerRepository, *stockCountHandlerStock) {
+	t.Helper()
+
+	repo := &stockCountHandlerRepository{counts: map[string]*stocktake.StockCount{}}
+	stock := &stockCountHandlerStock{}
+	tenantRepo := newMockTenantRepository()
+	tenantRepo.tenants["tenant-1"] = &tenant.Tenant{
+		ID:         "tenant-1",
+		SchemaName: "tenant_test",
+		Settings:   tenant.TenantSettings{InventoryValuationMethod: tenant.InventoryValuationMethodFIFO},
+	}
+	h := &Handlers{
+		tenantService:    tenant.NewServiceWithRepository(tenantRepo),
+		stocktakeService: stocktake.NewServiceWithRepository(repo, stock),
+	}
+	return h, repo, stock
+}
+
+func TestStockCountHandlersLifecycle(t *testing.T) {
+	h, repo, stock := setupStockCountHandlers(t)
+
+	createBody := stocktake.CreateStockCountRequest{
+		WarehouseID: stockCountHandlerWarehouse,
+		CountDate:   time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+	}
+	rr := httptest.NewRecorder()
+	h.CreateStockCount(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts", createBody, nil))
+	require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	var count stocktake.StockCount
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &count))
+	assert.Equal(t, "SC-00001", count.CountNumber)
+	assert.Equal(t, "user-1", count.CreatedBy)
+	assert.Equal(t, tenant.InventoryValuationMethodFIFO, count.ValuationMethod)
+	require.Len(t, count.Lines, 1)
+	params := map[string]string{"stockCountID": count.ID}
+
+	importBody := stocktake.ImportCountsRequest{FileName: "scanner.csv", CSVContent: "barcode;qty\n4740001;5\n4740001;3\n9999999;1\n"}
+	rr = httptest.NewRecorder()
+	h.ImportStockCounts(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts/"+count.ID+"/counts/import", importBody, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var imported stocktake.ImportCountsResult
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &imported))
+	assert.Equal(t, 2, imported.RowsImported)
+	assert.Equal(t, 1, imported.RowsSkipped)
+	require.Len(t, imported.Errors, 1)
+	assert.Equal(t, 4, imported.Errors[0].Row)
+
+	rr = httptest.NewRecorder()
+	h.SubmitStockCount(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts/"+count.ID+"/submit", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, stocktake.StockCountStatusSubmitted, repo.counts[count.ID].Status)
+
+	rr = httptest.NewRecorder()
+	h.GetStockCountVariance(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/stock-counts/"+count.ID+"/variance", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var variance stocktake.StockCountVariance
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &variance))
+	assert.True(t, variance.ShortageValue.Equal(decimal.NewFromInt(8)), variance.ShortageValue.String())
+	assert.True(t, variance.NetVariance.Equal(decimal.NewFromInt(-8)), variance.NetVariance.String())
+
+	rr = httptest.NewRecorder()
+	h.GetStockCountVariance(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/stock-counts/"+count.ID+"/variance?format=csv", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Contains(t, rr.Header().Get("Content-Disposition"), "stock-count-variance-SC-00001.csv")
+	assert.Contains(t, rr.Body.String(), "line_number,product_code")
+
+	approveBody := stocktake.ApproveStockCountRequest{VarianceAccountID: "variance"}
+	rr = httptest.NewRecorder()
+	h.ApproveStockCount(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts/"+count.ID+"/approve", approveBody, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var approved stocktake.StockCount
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &approved))
+	assert.Equal(t, stocktake.StockCountStatusApproved, approved.Status)
+	require.NotNil(t, stock.posted)
+	assert.Equal(t, "SC-00001", stock.posted.Reference)
+	require.Len(t, stock.posted.Lines, 1)
+	assert.True(t, stock.posted.Lines[0].Quantity.Equal(decimal.NewFromInt(-2)))
+
+	rr = httptest.NewRecorder()
+	h.ListStockCounts(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/stock-counts?status=APPROVED", nil, nil))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	var listed []stocktake.StockCount
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &listed))
+	assert.Len(t, listed, 1)
+}
+
+func TestStockCountHandlersErrors(t *testing.T) {
+	h, repo, _ := setupStockCountHandlers(t)
+
+	rr := httptest.NewRecorder()
+	h.GetStockCount(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/stock-counts/missing", nil, map[string]string{"stockCountID": "missing"}))
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CreateStockCount(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts", stocktake.CreateStockCountRequest{}, nil))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "warehouse_id is required")
+
+	repo.counts["count-1"] = &stocktake.StockCount{
+		ID:          "count-1",
+		TenantID:    "tenant-1",
+		CountNumber: "SC-00001",
+		WarehouseID: stockCountHandlerWarehouse,
+		CountDate:   time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		Status:      stocktake.StockCountStatusOpen,
+	}
+	params := map[string]string{"stockCountID": "count-1"}
+
+	rr = httptest.NewRecorder()
+	h.SubmitStockCount(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts/count-1/submit", nil, params))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.GetStockCountVariance(rr, depreciationRunRequest(t, http.MethodGet, "/tenants/tenant-1/inventory/stock-counts/count-1/variance?format=xml", nil, params))
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	rr = httptest.NewRecorder()
+	h.CancelStockCount(rr, depreciationRunRequest(t, http.MethodPost, "/tenants/tenant-1/inventory/stock-counts/count-1/cancel", nil, params))
+	require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.Equal(t, stocktake.StockCountStatusCanceled, repo.counts["count-1"].Status)
+
+	rr = httptest.NewRecorder()
+	respondStockCountError(rr, fmt.Errorf("approve: %w", stocktake.ErrPeriodLocked), "")
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/auth"
 	"github.com/HMB-research/open-accounting/internal/documents"
+	"github.com/HMB-research/open-accounting/internal/pdf"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 )
 
@@ -2490,3 +2491,152 @@ func TestRevokeInvitation(t *testing.T) {
 func stringPtr(value string) *string {
 	return &value
 }
+
+// =============================================================================
+// Document Template Handler Tests
+// =============================================================================
+
+func TestDocumentTemplateHandlers(t *testing.T) {
+	claims := createTestClaims("user-1", "test@example.com", "tenant-1", "owner")
+	setup := func() (*Handlers, *mockTenantRepository) {
+		h, repo := setupTenantTestHandlers()
+		h.pdfService = pdf.NewService()
+		repo.addTestTenant("tenant-1", "Test Tenant", "test-tenant")
+		return h, repo
+	}
+	request := func(method, path string, body interface{}, documentType string) *http.Request {
+		req := makeAuthenticatedRequest(method, path, body, claims)
+		params := map[string]string{"tenantID": "tenant-1"}
+		if documentType != "" {
+			params["documentType"] = documentType
+		}
+		return withURLParams(req, params)
+	}
+
+	t.Run("save, list and reset", func(t *testing.T) {
+		h, repo := setup()
+
+		rr := httptest.NewRecorder()
+		h.UpdateDocumentTemplate(rr, request(http.MethodPut, "/tenants/tenant-1/document-templates/invoice", map[string]interface{}{
+			"paper_size":           "a5",
+			"hide_discount_column": true,
+			"footer_text":          "Questions? {{.Company.Email}}",
+			"custom_fields":        []map[string]string{{"label": "PO", "value": "{{.Reference}}"}},
+		}, "invoice"))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		var entry tenant.DocumentTemplateEntry
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entry))
+		assert.Equal(t, tenant.DocumentTypeInvoice, entry.DocumentType)
+		assert.True(t, entry.Customized)
+		assert.Equal(t, tenant.PaperSizeA5, repo.tenants["tenant-1"].Settings.DocumentTemplates[tenant.DocumentTypeInvoice].PaperSize)
+
+		rr = httptest.NewRecorder()
+		h.ListDocumentTemplates(rr, request(http.MethodGet, "/tenants/tenant-1/document-templates", nil, ""))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var entries []tenant.DocumentTemplateEntry
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &entries))
+		require.Len(t, entries, len(tenant.DocumentTypes))
+		assert.True(t, entries[0].Customized)
+		assert.False(t, entries[1].Customized)
+
+		rr = httptest.NewRecorder()
+		h.DeleteDocumentTemplate(rr, request(http.MethodDelete, "/tenants/tenant-1/document-templates/INVOICE", nil, "INVOICE"))
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, repo.tenants["tenant-1"].Settings.DocumentTemplates)
+	})
+
+	t.Run("rejects invalid templates", func(t *testing.T) {
+		h, _ := setup()
+		for _, tt := range []struct {
+			documentType string
+			body         interface{}
+			want         string
+		}{
+			{documentType: "payslip", body: map[string]interface{}{}, want: "invalid document type"},
+			{documentType: "quote", body: map[string]interface{}{"orientation": "sideways"}, want: "invalid orientation"},
+			{documentType: "quote", body: map[string]interface{}{"header_text": "{{.Missing}}"}, want: "render template header_text"},
+			{documentType: "quote", body: "not-json", want: "Invalid request body"},
+		} {
+			rr := httptest.NewRecorder()
+			h.UpdateDocumentTemplate(rr, request(http.MethodPut, "/tenants/tenant-1/document-templates/"+tt.documentType, tt.body, tt.documentType))
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.want)
+		}
+
+		rr := httptest.NewRecorder()
+		h.DeleteDocumentTemplate(rr, request(http.MethodDelete, "/tenants/tenant-1/document-templates/payslip", nil, "payslip"))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("missing tenant", func(t *testing.T) {
+		h, _ := setupTenantTestHandlers()
+		rr := httptest.NewRecorder()
+		h.ListDocumentTemplates(rr, request(http.MethodGet, "/tenants/tenant-1/document-templates", nil, ""))
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+
+		rr = httptest.NewRecorder()
+		h.UpdateDocumentTemplate(rr, request(http.MethodPut, "/tenants/tenant-1/document-templates/order", map[string]interface{}{}, "order"))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	t.Run("preview draft template", func(t *testing.T) {
+		h, repo := setup()
+		repo.tenants["tenant-1"].Settings.DocumentTemplates = map[string]tenant.DocumentTemplate{
+			tenant.DocumentTypeQuote: {PaperSize: tenant.PaperSizeLetter},
+		}
+		original := generateTemplatePreviewPDF
+		var previewed tenant.DocumentTemplate
+		generateTemplatePreviewPDF = func(pdfService *pdf.Service, documentType string, tmpl tenant.DocumentTemplate, tenantRecord *tenant.Tenant, pdfSettings pdf.PDFSettings) ([]byte, error) {
+			previewed = tmpl
+			return original(pdfService, documentType, tmpl, tenantRecord, pdfSettings)
+		}
+		t.Cleanup(func() { generateTemplatePreviewPDF = original })
+
+		rr := httptest.NewRecorder()
+		h.PreviewDocumentTemplate(rr, request(http.MethodPost, "/tenants/tenant-1/document-templates/quote/preview", map[string]interface{}{
+			"template": map[string]interface{}{"orientation": "landscape", "header_text": "{{.Customer.Name}}"},
+		}, "quote"))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), "template-preview-quote.pdf")
+		requirePDF(t, rr.Body.Bytes())
+		assert.Equal(t, "landscape", previewed.Orientation)
+
+		rr = httptest.NewRecorder()
+		h.PreviewDocumentTemplate(rr, request(http.MethodPost, "/tenants/tenant-1/document-templates/quote/preview", nil, "quote"))
+		require.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.Equal(t, tenant.PaperSizeLetter, previewed.PaperSize, "saved template is previewed when no draft is sent")
+		assert.Empty(t, repo.tenants["tenant-1"].Settings.DocumentTemplates[tenant.DocumentTypeQuote].Orientation)
+	})
+
+	t.Run("preview errors", func(t *testing.T) {
+		h, _ := setup()
+		for _, tt := range []struct {
+			documentType string
+			body         interface{}
+			want         string
+		}{
+			{documentType: "payslip", want: "invalid document type"},
+			{documentType: "invoice", body: "not-json", want: "Invalid request body"},
+			{documentType: "invoice", body: map[string]interface{}{"template": map[string]string{"footer_text": "{{.Nope}}"}}, want: "render template footer_text"},
+		} {
+			rr := httptest.NewRecorder()
+			h.PreviewDocumentTemplate(rr, request(http.MethodPost, "/tenants/tenant-1/document-templates/"+tt.documentType+"/preview", tt.body, tt.documentType))
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Contains(t, rr.Body.String(), tt.want)
+		}
+
+		original := generateTemplatePreviewPDF
+		generateTemplatePreviewPDF = func(*pdf.Service, string, tenant.DocumentTemplate, *tenant.Tenant, pdf.PDFSettings) ([]byte, error) {
+			return nil, errors.New("boom")
+		}
+		t.Cleanup(func() { generateTemplatePreviewPDF = original })
+		rr := httptest.NewRecorder()
+		h.PreviewDocumentTemplate(rr, request(http.MethodPost, "/tenants/tenant-1/document-templates/invoice/preview", nil, "invoice"))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+
+		h, _ = setupTenantTestHandlers()
+		rr = httptest.NewRecorder()
+		h.PreviewDocumentTemplate(rr, request(http.MethodPost, "/tenants/tenant-1/document-templates/invoice/preview", nil, "invoice"))
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
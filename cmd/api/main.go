@@ -23,6 +23,7 @@ import (
 	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/analytics"
 	"github.com/HMB-research/open-accounting/internal/apitoken"
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/auth"
 	"github.com/HMB-research/open-accounting/internal/banking"
@@ -40,10 +41,13 @@ import (
 	"github.com/HMB-research/open-accounting/internal/payroll"
 	"github.com/HMB-research/open-accounting/internal/pdf"
 	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
 	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 	"github.com/HMB-research/open-accounting/internal/reports"
 	"github.com/HMB-research/open-accounting/internal/scheduler"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
 	"github.com/HMB-research/open-accounting/internal/tax"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/HMB-research/open-accounting/internal/webhooks"
@@ -246,23 +250,27 @@ func buildProductionAPIApplication(ctx context.Context, cfg *Config, pool apiPoo
 	tenantService := tenant.NewService(pgxPool)
 	accountingService := accounting.NewService(pgxPool)
 	contactsService := contacts.NewService(pgxPool)
+	inventoryService := inventory.NewService(pgxPool)
+	pricingService := pricing.NewService(pgxPool, inventoryService, contactsService, tenantService)
 	documentsService := documents.NewService(documents.NewRepository(pgxPool), documentStore)
-	invoicingService := invoicing.NewService(pgxPool, accountingService)
+	invoicingService := invoicing.NewService(pgxPool, accountingService).WithPricing(pricingService)
 	paymentsService := payments.NewService(pgxPool, invoicingService)
 	pdfService := pdf.NewService()
 	analyticsService := analytics.NewService(pgxPool)
 	emailService := email.NewService(pgxPool)
-	recurringService := recurring.NewService(pgxPool, invoicingService, emailService, pdfService, tenantService, contactsService)
+	recurringService := recurring.NewService(pgxPool, invoicingService, emailService, pdfService, tenantService, contactsService).WithPricing(pricingService)
 	bankingService := banking.NewService(pgxPool)
 	taxService := tax.NewService(pgxPool)
 	payrollService := payroll.NewService(pgxPool)
 	absenceService := payroll.NewAbsenceServiceWithPoolAndEvidence(pgxPool, documentsService)
 	pluginService := plugin.NewService(pgxPool, "./plugins")
-	quotesService := quotes.NewService(pgxPool)
-	ordersService := orders.NewService(pgxPool)
+	quotesService := quotes.NewService(pgxPool).WithPricing(pricingService).WithLinks(quotes.NewLinkSigner(cfg.JWTSecret))
 	assetsService := assets.NewService(pgxPool)
 	reportsService := reports.NewService(pgxPool)
-	inventoryService := inventory.NewService(pgxPool)
+	assemblyService := assembly.NewService(pgxPool, inventoryService)
+	ordersService := orders.NewService(pgxPool).WithInventory(inventoryService).WithKits(assemblyService).WithPricing(pricingService)
+	purchasingService := purchasing.NewService(pgxPool, inventoryService, invoicingService, accountingService)
+	stocktakeService := stocktake.NewService(pgxPool, inventoryService)
 	reminderService := invoicing.NewReminderService(pgxPool, emailService)
 	automatedReminderService := invoicing.NewAutomatedReminderService(pgxPool, emailService)
 	costCenterService := accounting.NewCostCenterService(pgxPool)
@@ -276,6 +284,8 @@ func buildProductionAPIApplication(ctx context.Context, cfg *Config, pool apiPoo
 	appScheduler := scheduler.NewScheduler(pgxPool, recurringService, automatedReminderService, schedulerConfig)
 	appScheduler.SetRecurringJournalEntryService(accountingService)
 	appScheduler.SetDocumentRetentionReminderService(documentRetentionReminderService)
+	appScheduler.SetDepreciationRunService(assetsService)
+	appScheduler.SetQuoteExpiryService(quotesService)
 
 	// Create handlers
 	handlers := &Handlers{
@@ -308,6 +318,10 @@ func buildProductionAPIApplication(ctx context.Context, cfg *Config, pool apiPoo
 		ordersService:            ordersService,
 		assetsService:            assetsService,
 		inventoryService:         inventoryService,
+		purchasingService:        purchasingService,
+		stocktakeService:         stocktakeService,
+		pricingService:           pricingService,
+		assemblyService:          assemblyService,
 		reportsService:           reportsService,
 		reminderService:          reminderService,
 		automatedReminderService: automatedReminderService,
@@ -340,6 +354,12 @@ func loadSchedulerConfig(getenv func(string) string) scheduler.Config {
 	if schedule := getenv("DOCUMENT_RETENTION_REMINDER_SCHEDULE"); schedule != "" {
 		schedulerConfig.DocumentRetentionReminderSchedule = schedule
 	}
+	if schedule := getenv("DEPRECIATION_RUN_SCHEDULE"); schedule != "" {
+		schedulerConfig.DepreciationRunSchedule = schedule
+	}
+	if schedule := getenv("QUOTE_EXPIRY_SCHEDULE"); schedule != "" {
+		schedulerConfig.QuoteExpirySchedule = schedule
+	}
 	if horizon := getenv("DOCUMENT_RETENTION_REMINDER_HORIZON_DAYS"); horizon != "" {
 		parsed, err := strconv.Atoi(horizon)
 		if err != nil || parsed < 0 {
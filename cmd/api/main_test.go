@@ -451,6 +451,8 @@ func TestLoadSchedulerConfigBranches(t *testing.T) {
 			"RECURRING_INVOICE_SCHEDULE":                  "0 4 * * *",
 			"RECURRING_JOURNAL_ENTRY_SCHEDULE":            "0 5 * * *",
 			"DOCUMENT_RETENTION_REMINDER_SCHEDULE":        "0 6 * * *",
+			"DEPRECIATION_RUN_SCHEDULE":                   "0 7 1 * *",
+			"QUOTE_EXPIRY_SCHEDULE":                       "0 2 * * *",
 			"DOCUMENT_RETENTION_REMINDER_HORIZON_DAYS":    "60",
 			"DOCUMENT_RETENTION_REMINDER_INCLUDE_MISSING": "true",
 			"SCHEDULER_ENABLED":                           "false",
@@ -460,6 +462,8 @@ func TestLoadSchedulerConfigBranches(t *testing.T) {
 	assert.Equal(t, "0 4 * * *", cfg.RecurringInvoiceSchedule)
 	assert.Equal(t, "0 5 * * *", cfg.RecurringJournalEntrySchedule)
 	assert.Equal(t, "0 6 * * *", cfg.DocumentRetentionReminderSchedule)
+	assert.Equal(t, "0 7 1 * *", cfg.DepreciationRunSchedule)
+	assert.Equal(t, "0 2 * * *", cfg.QuoteExpirySchedule)
 	assert.Equal(t, 60, cfg.DocumentRetentionReminderHorizonDays)
 	assert.True(t, cfg.DocumentRetentionReminderIncludeMissing)
 	assert.False(t, cfg.Enabled)
@@ -574,8 +578,63 @@ func TestSetupRouterRegistersCoreRoutes(t *testing.T) {
 	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/orders/{orderID}/pick-list")
 	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/orders/{orderID}/reserve-stock")
 	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/orders/{orderID}/release-stock")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/orders/{orderID}/shipments")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/orders/{orderID}/shipments/{shipmentID}/delivery-note")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/price-lists")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/price-lists")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/price-lists/{priceListID}")
+	assert.Contains(t, routes, "PUT /api/v1/tenants/{tenantID}/price-lists/{priceListID}")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/customer-groups")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/customer-groups")
+	assert.Contains(t, routes, "PUT /api/v1/tenants/{tenantID}/customer-groups/{customerGroupID}")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/contacts/{contactID}/pricing")
+	assert.Contains(t, routes, "PUT /api/v1/tenants/{tenantID}/contacts/{contactID}/pricing")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/prices/resolve")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/aging")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/aging/write-down")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/replenishment")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/replenishment/purchase-orders")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/replenishment/low-stock-events")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/stock-counts")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/stock-counts")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}/counts")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}/counts/import")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}/submit")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}/approve")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}/cancel")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/stock-counts/{stockCountID}/variance")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/boms")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/boms")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/boms/{bomID}")
+	assert.Contains(t, routes, "PUT /api/v1/tenants/{tenantID}/inventory/boms/{bomID}")
+	assert.Contains(t, routes, "DELETE /api/v1/tenants/{tenantID}/inventory/boms/{bomID}")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/boms/{bomID}/explosion")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/assembly-orders")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/assembly-orders")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/inventory/assembly-orders/{assemblyOrderID}")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/assembly-orders/{assemblyOrderID}/complete")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/inventory/assembly-orders/{assemblyOrderID}/cancel")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/landed-costs")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/landed-costs")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/landed-costs/{landedCostID}")
 	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/orders/{orderID}/convert-to-invoice")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/orders/{orderID}/invoices")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/quotes/{quoteID}/invoices")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/quotes/{quoteID}/revisions")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/quotes/{quoteID}/revisions/{revision}")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/quotes/{quoteID}/public-link")
+	assert.Contains(t, routes, "GET /api/v1/public/quotes/{token}")
+	assert.Contains(t, routes, "GET /api/v1/public/quotes/{token}/pdf")
+	assert.Contains(t, routes, "POST /api/v1/public/quotes/{token}/accept")
+	assert.Contains(t, routes, "POST /api/v1/public/quotes/{token}/reject")
 	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/recurring-invoices/import")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/recurring-invoices/usage/import")
+	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/recurring-invoices/{recurringID}/usage")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/recurring-invoices/{recurringID}/usage")
+	assert.Contains(t, routes, "DELETE /api/v1/tenants/{tenantID}/recurring-invoices/{recurringID}/usage/{usageID}")
+	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/recurring-invoices/{recurringID}/price-changes")
+	assert.Contains(t, routes, "DELETE /api/v1/tenants/{tenantID}/recurring-invoices/{recurringID}/price-changes/{changeID}")
 	assert.Contains(t, routes, "GET /api/v1/tenants/{tenantID}/documents")
 	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/documents/review-summary")
 	assert.Contains(t, routes, "POST /api/v1/tenants/{tenantID}/documents/evidence-policy")
@@ -0,0 +1,54 @@
+package main
+
+import "github.com/HMB-research/open-accounting/internal/assembly"
+
+var (
+	exportBOMExplosionCSV  = bomExplosionCSV
+	exportBOMExplosionXLSX = bomExplosionXLSX
+	exportBOMExplosionPDF  = bomExplosionPDF
+)
+
+func bomExplosionCSV(explosion *assembly.BOMExplosion) ([]byte, error) {
+	return rowsToCSV(bomExplosionRows(explosion))
+}
+
+func bomExplosionXLSX(explosion *assembly.BOMExplosion) ([]byte, error) {
+	return exportReportRowsXLSX("BOM Explosion", bomExplosionRows(explosion))
+}
+
+func bomExplosionPDF(explosion *assembly.BOMExplosion) ([]byte, error) {
+	subtitle := explosion.ProductCode + " " + explosion.ProductName + " x " + explosion.Quantity.String() + " at " + explosion.ValuationMethod
+	return exportReportRowsPDF("BOM Explosion", subtitle, bomExplosionRows(explosion))
+}
+
+func bomExplosionRows(explosion *assembly.BOMExplosion) [][]string {
+	rows := [][]string{{
+		"level",
+		"product_code",
+		"product_name",
+		"bom_type",
+		"quantity",
+		"unit",
+		"unit_cost",
+		"total_cost",
+	}}
+	for _, line := range explosion.Lines {
+		rows = append(rows, []string{
+			intString(line.Level),
+			line.ProductCode,
+			line.ProductName,
+			string(line.BOMType),
+			line.Quantity.String(),
+			line.Unit,
+			line.UnitCost.String(),
+			line.TotalCost.String(),
+		})
+	}
+	rows = append(rows,
+		[]string{"", "", "Material cost", "", "", "", "", explosion.MaterialCost.String()},
+		[]string{"", "", "Labour cost", "", "", "", "", explosion.LabourCost.String()},
+		[]string{"", "", "Overhead cost", "", "", "", "", explosion.OverheadCost.String()},
+		[]string{"", "", "Total cost", "", explosion.Quantity.String(), "", explosion.UnitCost.String(), explosion.TotalCost.String()},
+	)
+	return rows
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/HMB-research/open-accounting/internal/assets"
+)
+
+var (
+	exportDepreciationScheduleCSV  = depreciationScheduleCSV
+	exportDepreciationScheduleXLSX = depreciationScheduleXLSX
+	exportDepreciationSchedulePDF  = depreciationSchedulePDF
+	exportAssetRegisterCSV         = assetRegisterCSV
+	exportAssetRegisterXLSX        = assetRegisterXLSX
+	exportAssetRegisterPDF         = assetRegisterPDF
+)
+
+func depreciationScheduleCSV(schedule *assets.DepreciationSchedule) ([]byte, error) {
+	return rowsToCSV(depreciationScheduleRows(schedule))
+}
+
+func depreciationScheduleXLSX(schedule *assets.DepreciationSchedule) ([]byte, error) {
+	return exportReportRowsXLSX("Depreciation Schedule", depreciationScheduleRows(schedule))
+}
+
+func depreciationSchedulePDF(schedule *assets.DepreciationSchedule) ([]byte, error) {
+	return exportReportRowsPDF("Depreciation Schedule", fmt.Sprintf("%s %s, %s", schedule.AssetNumber, schedule.AssetName, schedule.DepreciationMethod), depreciationScheduleRows(schedule))
+}
+
+func assetRegisterCSV(report *assets.AssetRegisterReport) ([]byte, error) {
+	return rowsToCSV(assetRegisterRows(report))
+}
+
+func assetRegisterXLSX(report *assets.AssetRegisterReport) ([]byte, error) {
+	return exportReportRowsXLSX("Fixed Asset Register", assetRegisterRows(report))
+}
+
+func assetRegisterPDF(report *assets.AssetRegisterReport) ([]byte, error) {
+	return exportReportRowsPDF("Fixed Asset Register", fmt.Sprintf("%s to %s", reportExportDate(report.StartDate), reportExportDate(report.EndDate)), assetRegisterRows(report))
+}
+
+func depreciationScheduleRows(schedule *assets.DepreciationSchedule) [][]string {
+	rows := [][]string{{
+		"asset_number",
+		"asset_name",
+		"depreciation_method",
+		"period_start",
+		"period_end",
+		"units",
+		"depreciation_amount",
+		"accumulated_total",
+		"book_value_after",
+	}}
+	for _, line := range schedule.Lines {
+		units := ""
+		if line.Units != nil {
+			units = line.Units.String()
+		}
+		rows = append(rows, []string{
+			schedule.AssetNumber,
+			schedule.AssetName,
+			string(schedule.DepreciationMethod),
+			reportExportDate(line.PeriodStart),
+			reportExportDate(line.PeriodEnd),
+			units,
+			line.DepreciationAmount.String(),
+			line.AccumulatedTotal.String(),
+			line.BookValueAfter.String(),
+		})
+	}
+	return rows
+}
+
+func assetRegisterRows(report *assets.AssetRegisterReport) [][]string {
+	rows := [][]string{{
+		"row_type",
+		"category_name",
+		"asset_number",
+		"asset_name",
+		"status",
+		"asset_count",
+		"opening_cost",
+		"additions",
+		"disposals",
+		"closing_cost",
+		"opening_accumulated_depreciation",
+		"depreciation_charge",
+		"impairments",
+		"disposal_depreciation",
+		"closing_accumulated_depreciation",
+		"opening_net_book_value",
+		"closing_net_book_value",
+	}}
+	for _, category := range report.Categories {
+		rows = append(rows, append([]string{"category", category.CategoryName, "", "", "", intString(category.AssetCount)}, assetRegisterAmountCells(category.AssetRegisterAmounts)...))
+	}
+	for _, asset := range report.Assets {
+		rows = append(rows, append([]string{"asset", asset.CategoryName, asset.AssetNumber, asset.AssetName, string(asset.Status), ""}, assetRegisterAmountCells(asset.AssetRegisterAmounts)...))
+	}
+	rows = append(rows, append([]string{"total", "", "", "", "", intString(len(report.Assets))}, assetRegisterAmountCells(report.Totals)...))
+	return rows
+}
+
+func assetRegisterAmountCells(amounts assets.AssetRegisterAmounts) []string {
+	return []string{
+		amounts.OpeningCost.String(),
+		amounts.Additions.String(),
+		amounts.Disposals.String(),
+		amounts.ClosingCost.String(),
+		amounts.OpeningAccumulatedDepreciation.String(),
+		amounts.DepreciationCharge.String(),
+		amounts.Impairments.String(),
+		amounts.DisposalDepreciation.String(),
+		amounts.ClosingAccumulatedDepreciation.String(),
+		amounts.OpeningNetBookValue.String(),
+		amounts.ClosingNetBookValue.String(),
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+var (
+	exportInventoryAgingCSV  = inventoryAgingCSV
+	exportInventoryAgingXLSX = inventoryAgingXLSX
+	exportInventoryAgingPDF  = inventoryAgingPDF
+)
+
+var inventoryAgingExportBuckets = []string{"0-30", "31-90", "91-180", "181-365", "OVER_365"}
+
+func inventoryAgingCSV(report *inventory.InventoryAgingReport) ([]byte, error) {
+	return rowsToCSV(inventoryAgingRows(report))
+}
+
+func inventoryAgingXLSX(report *inventory.InventoryAgingReport) ([]byte, error) {
+	return exportReportRowsXLSX("Inventory Aging", inventoryAgingRows(report))
+}
+
+func inventoryAgingPDF(report *inventory.InventoryAgingReport) ([]byte, error) {
+	subtitle := "As of " + reportExportDate(report.AsOfDate) + ", proposed write-down " + report.WriteDownAmount.StringFixed(2)
+	return exportReportRowsPDF("Inventory Aging and Expiry", subtitle, inventoryAgingRows(report))
+}
+
+func inventoryAgingRows(report *inventory.InventoryAgingReport) [][]string {
+	header := []string{
+		"warehouse_code",
+		"warehouse_name",
+		"category_name",
+		"product_code",
+		"product_name",
+		"lot_number",
+		"serial_number",
+		"expiry_date",
+		"received_date",
+		"age_days",
+		"days_to_expiry",
+		"expiry_bucket",
+		"last_issue_date",
+		"status",
+		"flags",
+		"quantity",
+		"unit_cost",
+		"inventory_value",
+	}
+	for _, bucket := range inventoryAgingExportBuckets {
+		header = append(header, "age_"+strings.ToLower(bucket))
+	}
+	header = append(header, "net_realisable_value", "write_down_amount")
+
+	rows := [][]string{header}
+	for _, line := range report.Lines {
+		row := []string{
+			line.WarehouseCode,
+			line.WarehouseName,
+			line.CategoryName,
+			line.ProductCode,
+			line.ProductName,
+			line.LotNumber,
+			line.SerialNumber,
+			line.ExpiryDate,
+			inventoryAgingExportDate(line.ReceivedDate),
+			intString(line.AgeDays),
+			"",
+			line.ExpiryBucket,
+			inventoryAgingExportDate(line.LastIssueDate),
+			line.Status,
+			strings.Join(line.Flags, " "),
+			line.Quantity.String(),
+			line.UnitCost.String(),
+			line.InventoryValue.String(),
+		}
+		if line.DaysToExpiry != nil {
+			row[10] = strconv.Itoa(*line.DaysToExpiry)
+		}
+		for _, label := range inventoryAgingExportBuckets {
+			value := decimal.Zero
+			for _, bucket := range line.AgeBuckets {
+				if bucket.Bucket == label {
+					value = bucket.Value
+				}
+			}
+			row = append(row, value.String())
+		}
+		rows = append(rows, append(row, line.NetRealisableValue.String(), line.WriteDownAmount.String()))
+	}
+	return rows
+}
+
+func inventoryAgingExportDate(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return reportExportDate(*value)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/HMB-research/open-accounting/internal/purchasing"
+)
+
+var (
+	exportReplenishmentCSV  = replenishmentCSV
+	exportReplenishmentXLSX = replenishmentXLSX
+	exportReplenishmentPDF  = replenishmentPDF
+)
+
+func replenishmentCSV(report *purchasing.ReplenishmentReport) ([]byte, error) {
+	return rowsToCSV(replenishmentRows(report))
+}
+
+func replenishmentXLSX(report *purchasing.ReplenishmentReport) ([]byte, error) {
+	return exportReportRowsXLSX("Replenishment", replenishmentRows(report))
+}
+
+func replenishmentPDF(report *purchasing.ReplenishmentReport) ([]byte, error) {
+	return exportReportRowsPDF("Replenishment Proposals", "As of "+reportExportDate(report.AsOfDate), replenishmentRows(report))
+}
+
+func replenishmentRows(report *purchasing.ReplenishmentReport) [][]string {
+	rows := [][]string{{
+		"supplier_id",
+		"warehouse_code",
+		"warehouse_name",
+		"product_code",
+		"product_name",
+		"unit",
+		"on_hand",
+		"reserved",
+		"available",
+		"incoming",
+		"projected",
+		"min_stock_level",
+		"reorder_point",
+		"lead_time_days",
+		"daily_usage",
+		"reorder_level",
+		"target_level",
+		"suggested_quantity",
+		"unit_price",
+		"estimated_cost",
+		"below_minimum",
+	}}
+	for _, proposal := range report.Proposals {
+		for _, line := range proposal.Lines {
+			rows = append(rows, []string{
+				proposal.SupplierID,
+				proposal.WarehouseCode,
+				proposal.WarehouseName,
+				line.ProductCode,
+				line.ProductName,
+				line.Unit,
+				line.OnHand.String(),
+				line.Reserved.String(),
+				line.Available.String(),
+				line.Incoming.String(),
+				line.Projected.String(),
+				line.MinStockLevel.String(),
+				line.ReorderPoint.String(),
+				intString(line.LeadTimeDays),
+				line.DailyUsage.String(),
+				line.ReorderLevel.String(),
+				line.TargetLevel.String(),
+				line.SuggestedQuantity.String(),
+				line.UnitPrice.String(),
+				line.EstimatedCost.String(),
+				strconv.FormatBool(line.BelowMinimum),
+			})
+		}
+	}
+	return rows
+}
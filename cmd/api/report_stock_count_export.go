@@ -0,0 +1,60 @@
+package main
+
+import "github.com/HMB-research/open-accounting/internal/stocktake"
+
+var (
+	exportStockCountVarianceCSV  = stockCountVarianceCSV
+	exportStockCountVarianceXLSX = stockCountVarianceXLSX
+	exportStockCountVariancePDF  = stockCountVariancePDF
+)
+
+func stockCountVarianceCSV(variance *stocktake.StockCountVariance) ([]byte, error) {
+	return rowsToCSV(stockCountVarianceRows(variance))
+}
+
+func stockCountVarianceXLSX(variance *stocktake.StockCountVariance) ([]byte, error) {
+	return exportReportRowsXLSX("Stock Count Variance", stockCountVarianceRows(variance))
+}
+
+func stockCountVariancePDF(variance *stocktake.StockCountVariance) ([]byte, error) {
+	subtitle := variance.CountNumber + " counted " + reportExportDate(variance.CountDate) + " at " + variance.ValuationMethod
+	return exportReportRowsPDF("Stock Count Variance", subtitle, stockCountVarianceRows(variance))
+}
+
+func stockCountVarianceRows(variance *stocktake.StockCountVariance) [][]string {
+	rows := [][]string{{
+		"line_number",
+		"product_code",
+		"product_name",
+		"barcode",
+		"lot_number",
+		"serial_number",
+		"expiry_date",
+		"expected_quantity",
+		"counted_quantity",
+		"variance_quantity",
+		"unit_cost",
+		"variance_value",
+	}}
+	for _, line := range variance.Lines {
+		counted := ""
+		if line.CountedQuantity != nil {
+			counted = line.CountedQuantity.String()
+		}
+		rows = append(rows, []string{
+			intString(line.LineNumber),
+			line.ProductCode,
+			line.ProductName,
+			line.Barcode,
+			line.LotNumber,
+			line.SerialNumber,
+			line.ExpiryDate,
+			line.ExpectedQuantity.String(),
+			counted,
+			line.VarianceQuantity.String(),
+			line.UnitCost.String(),
+			line.VarianceValue.String(),
+		})
+	}
+	return rows
+}
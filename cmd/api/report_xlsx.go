@@ -186,7 +186,7 @@ func xlsxCellRef(row, column int) string {
 
 func isNumericReportColumn(header string) bool {
 	switch strings.ToLower(strings.TrimSpace(header)) {
-	case "amount", "amount_paid", "balance", "budget_amount", "budget_used_percentage", "contact_count", "contact_invoice_count", "count", "credit_balance", "current", "days_1_30", "days_31_60", "days_61_90", "days_90_plus", "days_overdue", "debit_balance", "invoice_count", "net_balance", "outstanding_amount", "total", "total_amount", "total_balance", "total_expenses":
+	case "accumulated_total", "additions", "amount", "amount_paid", "asset_count", "balance", "book_value_after", "budget_amount", "budget_used_percentage", "closing_accumulated_depreciation", "closing_cost", "closing_net_book_value", "contact_count", "contact_invoice_count", "count", "credit_balance", "current", "days_1_30", "days_31_60", "days_61_90", "days_90_plus", "days_overdue", "debit_balance", "depreciation_amount", "depreciation_charge", "disposal_depreciation", "disposals", "impairments", "invoice_count", "net_balance", "opening_accumulated_depreciation", "opening_cost", "opening_net_book_value", "outstanding_amount", "total", "total_amount", "total_balance", "total_expenses", "units":
 		return true
 	default:
 		return false
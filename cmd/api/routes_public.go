@@ -31,4 +31,10 @@ func registerAPIPublicRoutes(r chi.Router, h *Handlers) {
 	// Public invitation endpoints (no auth required)
 	r.Get("/invitations/{token}", h.GetInvitationByToken)
 	r.Post("/invitations/accept", h.AcceptInvitation)
+
+	// Public quote links (signed token, no auth required)
+	r.Get("/public/quotes/{token}", h.GetPublicQuote)
+	r.Get("/public/quotes/{token}/pdf", h.GetPublicQuotePDF)
+	r.Post("/public/quotes/{token}/accept", h.AcceptPublicQuote)
+	r.Post("/public/quotes/{token}/reject", h.RejectPublicQuote)
 }
@@ -12,6 +12,21 @@ func registerTenantRoutes(
 	canCreateEntries func(tenant.RolePermissions) bool,
 	canManageSettings func(tenant.RolePermissions) bool,
 ) {
+	// Employee self-service. TenantContext rejects employee memberships, so
+	// these routes are the only tenant endpoints an employee can reach.
+	r.Route("/tenants/{tenantID}/self", func(r chi.Router) {
+		r.Use(h.EmployeeSelfServiceContext)
+
+		r.Get("/employee", h.GetSelfEmployee)
+		r.Get("/payslips", h.ListSelfPayslips)
+		r.Get("/payslips/{payslipID}/pdf", h.GetSelfPayslipPDF)
+		r.Get("/leave-balances", h.ListSelfLeaveBalances)
+		r.Get("/leave-records", h.ListSelfLeaveRecords)
+		r.Post("/leave-records", h.CreateSelfLeaveRecord)
+		r.Get("/expenses", h.ListSelfExpenses)
+		r.Post("/expenses", h.SubmitSelfExpense)
+	})
+
 	r.Route("/tenants/{tenantID}", func(r chi.Router) {
 		r.Use(h.TenantContext)
 		r.Use(h.RequireTenantWritePermission(canCreateEntries))
@@ -85,16 +100,31 @@ func registerTenantRoutes(
 		r.Get("/contacts/{contactID}", h.GetContact)
 		r.Put("/contacts/{contactID}", h.UpdateContact)
 		r.Delete("/contacts/{contactID}", h.DeleteContact)
+		r.Get("/contacts/{contactID}/pricing", h.GetContactPricing)
+		r.Put("/contacts/{contactID}/pricing", h.SetContactPricing)
+
+		// Pricing
+		r.Get("/price-lists", h.ListPriceLists)
+		r.Post("/price-lists", h.CreatePriceList)
+		r.Get("/price-lists/{priceListID}", h.GetPriceList)
+		r.Put("/price-lists/{priceListID}", h.UpdatePriceList)
+		r.Get("/customer-groups", h.ListCustomerGroups)
+		r.Post("/customer-groups", h.CreateCustomerGroup)
+		r.Put("/customer-groups/{customerGroupID}", h.UpdateCustomerGroup)
+		r.Get("/prices/resolve", h.ResolvePrice)
 
 		// Invoices
 		r.Get("/invoices", h.ListInvoices)
 		r.Post("/invoices", h.CreateInvoice)
 		r.Post("/invoices/import", h.ImportInvoices)
 		r.Post("/invoices/import-einvoice", h.ImportEInvoice)
+		r.Post("/invoices/export-einvoice", h.ExportEInvoice)
 		r.Get("/invoices/{invoiceID}", h.GetInvoice)
 		r.Get("/invoices/{invoiceID}/pdf", h.GetInvoicePDF)
+		r.Get("/invoices/{invoiceID}/reminder-pdf", h.GetInvoiceReminderPDF)
 		r.Post("/invoices/{invoiceID}/send", h.SendInvoice)
 		r.Post("/invoices/{invoiceID}/void", h.VoidInvoice)
+		r.Post("/invoices/{invoiceID}/credit-notes", h.CreateCreditNote)
 		r.Get("/invoices/{invoiceID}/reminders", h.GetInvoiceReminderHistory)
 
 		// Payment Reminders
@@ -115,6 +145,10 @@ func registerTenantRoutes(
 		r.Post("/quotes/{quoteID}/accept", h.AcceptQuote)
 		r.Post("/quotes/{quoteID}/reject", h.RejectQuote)
 		r.Post("/quotes/{quoteID}/convert-to-invoice", h.ConvertQuoteToInvoice)
+		r.Get("/quotes/{quoteID}/invoices", h.ListQuoteInvoices)
+		r.Get("/quotes/{quoteID}/revisions", h.ListQuoteRevisions)
+		r.Get("/quotes/{quoteID}/revisions/{revision}", h.GetQuoteRevision)
+		r.Post("/quotes/{quoteID}/public-link", h.CreateQuoteLink)
 
 		// Orders
 		r.Get("/orders", h.ListOrders)
@@ -133,9 +167,25 @@ func registerTenantRoutes(
 		r.Post("/orders/{orderID}/confirm", h.ConfirmOrder)
 		r.Post("/orders/{orderID}/process", h.ProcessOrder)
 		r.Post("/orders/{orderID}/ship", h.ShipOrder)
+		r.Get("/orders/{orderID}/shipments", h.ListOrderShipments)
+		r.Get("/orders/{orderID}/shipments/{shipmentID}/delivery-note", h.GetOrderDeliveryNotePDF)
 		r.Post("/orders/{orderID}/deliver", h.DeliverOrder)
 		r.Post("/orders/{orderID}/cancel", h.CancelOrder)
 		r.Post("/orders/{orderID}/convert-to-invoice", h.ConvertOrderToInvoice)
+		r.Get("/orders/{orderID}/invoices", h.ListOrderInvoices)
+
+		// Purchase Orders
+		r.Get("/purchase-orders", h.ListPurchaseOrders)
+		r.Post("/purchase-orders", h.CreatePurchaseOrder)
+		r.Get("/purchase-orders/{purchaseOrderID}", h.GetPurchaseOrder)
+		r.Post("/purchase-orders/{purchaseOrderID}/approve", h.ApprovePurchaseOrder)
+		r.Post("/purchase-orders/{purchaseOrderID}/cancel", h.CancelPurchaseOrder)
+		r.Post("/purchase-orders/{purchaseOrderID}/receipts", h.ReceivePurchaseOrderGoods)
+		r.Post("/purchase-orders/{purchaseOrderID}/invoice-matches", h.MatchPurchaseOrderInvoice)
+		r.Get("/purchase-orders/{purchaseOrderID}/matching", h.GetPurchaseOrderMatching)
+		r.Get("/landed-costs", h.ListLandedCosts)
+		r.Post("/landed-costs", h.AllocateLandedCost)
+		r.Get("/landed-costs/{landedCostID}", h.GetLandedCost)
 
 		// Fixed Assets
 		r.Get("/asset-categories", h.ListAssetCategories)
@@ -152,6 +202,15 @@ func registerTenantRoutes(
 		r.Post("/assets/{assetID}/dispose", h.DisposeAsset)
 		r.Post("/assets/{assetID}/depreciation", h.RecordDepreciation)
 		r.Get("/assets/{assetID}/depreciation", h.GetDepreciationHistory)
+		r.Get("/assets/{assetID}/depreciation-schedule", h.GetAssetDepreciationSchedule)
+		r.Get("/assets/{assetID}/events", h.ListAssetEvents)
+		r.Post("/assets/{assetID}/events", h.CreateAssetEvent)
+		r.Get("/assets/{assetID}/history", h.GetAssetHistory)
+		r.Get("/depreciation-runs", h.ListDepreciationRuns)
+		r.Post("/depreciation-runs", h.CreateDepreciationRun)
+		r.Get("/depreciation-runs/preview", h.PreviewDepreciationRun)
+		r.Get("/depreciation-runs/{runID}", h.GetDepreciationRun)
+		r.Post("/depreciation-runs/{runID}/reverse", h.ReverseDepreciationRun)
 
 		// Inventory - Product Categories
 		r.Get("/product-categories", h.ListProductCategories)
@@ -172,6 +231,31 @@ func registerTenantRoutes(
 		r.Get("/inventory/valuation", h.GetInventoryValuation)
 		r.Get("/inventory/subledger-reconciliation", h.GetInventorySubledgerReconciliation)
 		r.Get("/inventory/lots", h.GetInventoryLotReport)
+		r.Get("/inventory/aging", h.GetInventoryAgingReport)
+		r.Post("/inventory/aging/write-down", h.ProposeInventoryWriteDown)
+		r.Get("/inventory/replenishment", h.GetReplenishmentReport)
+		r.Post("/inventory/replenishment/purchase-orders", h.CreateReplenishmentPurchaseOrders)
+		r.Post("/inventory/replenishment/low-stock-events", h.EmitLowStockEvent)
+		r.Get("/inventory/stock-counts", h.ListStockCounts)
+		r.Post("/inventory/stock-counts", h.CreateStockCount)
+		r.Get("/inventory/stock-counts/{stockCountID}", h.GetStockCount)
+		r.Post("/inventory/stock-counts/{stockCountID}/counts", h.RecordStockCounts)
+		r.Post("/inventory/stock-counts/{stockCountID}/counts/import", h.ImportStockCounts)
+		r.Post("/inventory/stock-counts/{stockCountID}/submit", h.SubmitStockCount)
+		r.Post("/inventory/stock-counts/{stockCountID}/approve", h.ApproveStockCount)
+		r.Post("/inventory/stock-counts/{stockCountID}/cancel", h.CancelStockCount)
+		r.Get("/inventory/stock-counts/{stockCountID}/variance", h.GetStockCountVariance)
+		r.Get("/inventory/boms", h.ListBOMs)
+		r.Post("/inventory/boms", h.CreateBOM)
+		r.Get("/inventory/boms/{bomID}", h.GetBOM)
+		r.Put("/inventory/boms/{bomID}", h.UpdateBOM)
+		r.Delete("/inventory/boms/{bomID}", h.DeleteBOM)
+		r.Get("/inventory/boms/{bomID}/explosion", h.GetBOMExplosion)
+		r.Get("/inventory/assembly-orders", h.ListAssemblyOrders)
+		r.Post("/inventory/assembly-orders", h.CreateAssemblyOrder)
+		r.Get("/inventory/assembly-orders/{assemblyOrderID}", h.GetAssemblyOrder)
+		r.Post("/inventory/assembly-orders/{assemblyOrderID}/complete", h.CompleteAssemblyOrder)
+		r.Post("/inventory/assembly-orders/{assemblyOrderID}/cancel", h.CancelAssemblyOrder)
 
 		// Inventory - Warehouses
 		r.Get("/warehouses", h.ListWarehouses)
@@ -215,6 +299,7 @@ func registerTenantRoutes(
 		r.Get("/reports/sales-margin", h.GetSalesMarginReport)
 		r.Get("/reports/customer-profitability", h.GetCustomerProfitabilityReport)
 		r.Get("/reports/budget-vs-actual", h.GetBudgetVsActualReport)
+		r.Get("/reports/fixed-asset-register", h.GetFixedAssetRegister)
 
 		// Cost Centers
 		r.Get("/cost-centers", h.ListCostCenters)
@@ -240,6 +325,7 @@ func registerTenantRoutes(
 		r.Get("/recurring-invoices", h.ListRecurringInvoices)
 		r.Post("/recurring-invoices", h.CreateRecurringInvoice)
 		r.Post("/recurring-invoices/import", h.ImportRecurringInvoices)
+		r.Post("/recurring-invoices/usage/import", h.ImportRecurringUsage)
 		r.Post("/recurring-invoices/from-invoice/{invoiceID}", h.CreateRecurringInvoiceFromInvoice)
 		r.Post("/recurring-invoices/generate-due", h.GenerateDueRecurringInvoices)
 		r.Get("/recurring-invoices/{recurringID}", h.GetRecurringInvoice)
@@ -248,6 +334,11 @@ func registerTenantRoutes(
 		r.Post("/recurring-invoices/{recurringID}/pause", h.PauseRecurringInvoice)
 		r.Post("/recurring-invoices/{recurringID}/resume", h.ResumeRecurringInvoice)
 		r.Post("/recurring-invoices/{recurringID}/generate", h.GenerateRecurringInvoice)
+		r.Get("/recurring-invoices/{recurringID}/usage", h.ListRecurringUsage)
+		r.Post("/recurring-invoices/{recurringID}/usage", h.RecordRecurringUsage)
+		r.Delete("/recurring-invoices/{recurringID}/usage/{usageID}", h.DeleteRecurringUsage)
+		r.Post("/recurring-invoices/{recurringID}/price-changes", h.CreateRecurringPriceChange)
+		r.Delete("/recurring-invoices/{recurringID}/price-changes/{changeID}", h.DeleteRecurringPriceChange)
 
 		// Email Settings
 		r.With(h.RequireTenantPermission(canManageSettings)).Get("/settings/smtp", h.GetSMTPConfig)
@@ -257,6 +348,12 @@ func registerTenantRoutes(
 		r.With(h.RequireTenantPermission(canManageSettings)).Put("/email-templates/{templateType}", h.UpdateEmailTemplate)
 		r.With(h.RequireTenantPermission(canManageSettings)).Get("/email-log", h.GetEmailLog)
 
+		// PDF Document Templates
+		r.With(h.RequireTenantPermission(canManageSettings)).Get("/document-templates", h.ListDocumentTemplates)
+		r.With(h.RequireTenantPermission(canManageSettings)).Put("/document-templates/{documentType}", h.UpdateDocumentTemplate)
+		r.With(h.RequireTenantPermission(canManageSettings)).Delete("/document-templates/{documentType}", h.DeleteDocumentTemplate)
+		r.With(h.RequireTenantPermission(canManageSettings)).Post("/document-templates/{documentType}/preview", h.PreviewDocumentTemplate)
+
 		// Reminder Rules (Automated Payment Reminders)
 		r.With(h.RequireTenantPermission(canManageSettings)).Get("/reminder-rules", h.ListReminderRules)
 		r.With(h.RequireTenantPermission(canManageSettings)).Post("/reminder-rules", h.CreateReminderRule)
@@ -326,6 +423,19 @@ func registerTenantRoutes(
 		r.Post("/employees/{employeeID}/salary", h.SetBaseSalary)
 		r.Get("/employees/{employeeID}/salary-components", h.ListSalaryComponents)
 		r.Post("/employees/{employeeID}/salary-components", h.AddSalaryComponent)
+		r.Get("/employees/{employeeID}/average-earnings", h.GetAverageEarnings)
+
+		// Payroll - Employment register (TÖR)
+		r.Get("/employees/{employeeID}/employment-events", h.ListEmploymentEvents)
+		r.Post("/employees/{employeeID}/employment-events", h.CreateEmploymentEvent)
+		r.Get("/employment-register/pending", h.ListPendingEmploymentRegister)
+		r.Post("/employment-register/export", h.ExportEmploymentRegister)
+
+		// Payroll - Timesheets
+		r.Get("/timesheets", h.ListTimesheets)
+		r.Post("/timesheets", h.SaveTimesheet)
+		r.With(h.RequireTenantPermission(canCreateEntries)).Post("/timesheets/import", h.ImportTimesheets)
+		r.Post("/timesheets/approve", h.ApproveTimesheets)
 
 		// Payroll - Runs
 		r.Get("/payroll-runs", h.ListPayrollRuns)
@@ -336,10 +446,18 @@ func registerTenantRoutes(
 		r.Post("/payroll-runs/{runID}/calculate", h.CalculatePayroll)
 		r.Post("/payroll-runs/{runID}/process", h.ProcessPayrollRun)
 		r.Post("/payroll-runs/{runID}/approve", h.ApprovePayroll)
+		r.With(h.RequireTenantPermission(canCreateEntries)).Post("/payroll-runs/{runID}/reopen", h.ReopenPayrollRun)
+		r.With(h.RequireTenantPermission(canCreateEntries)).Post("/payroll-runs/{runID}/pay", h.PayPayrollRun)
+		r.Get("/payroll-runs/{runID}/payments", h.ListPayrollPayments)
 		r.Get("/payroll-runs/{runID}/payslips", h.GetPayslips)
 		r.Get("/payroll-runs/{runID}/payslips/{payslipID}/pdf", h.GetPayslipPDF)
 		r.Post("/payroll-runs/{runID}/tsd", h.GenerateTSD)
 
+		// Payroll - General ledger posting
+		r.Get("/payroll/posting-accounts", h.ListPayrollPostingAccounts)
+		r.With(h.RequireTenantPermission(canManageSettings)).Put("/payroll/posting-accounts", h.SetPayrollPostingAccounts)
+		r.With(h.RequireTenantPermission(canManageSettings)).Delete("/payroll/posting-accounts", h.DeletePayrollPostingAccounts)
+
 		// Payroll - Tax Preview
 		r.Post("/payroll/tax-preview", h.CalculateTaxPreview)
 
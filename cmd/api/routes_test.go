@@ -33,9 +33,11 @@ func TestSetupRouterRegistersRepresentativeRouteGroups(t *testing.T) {
 	for _, route := range []string{
 		"POST /api/v1/auth/login",
 		"GET /api/v1/invitations/{token}",
+		"POST /api/v1/public/quotes/{token}/accept",
 		"GET /api/v1/me",
 		"GET /api/v1/admin/plugins",
 		"GET /api/v1/tenants/{tenantID}/accounts",
+		"GET /api/v1/tenants/{tenantID}/self/payslips",
 	} {
 		assert.Contains(t, routes, route)
 	}
@@ -46,4 +48,11 @@ func TestSetupRouterRegistersRepresentativeRouteGroups(t *testing.T) {
 		router.ServeHTTP(rr, req)
 		assert.Equal(t, http.StatusUnauthorized, rr.Code, "%s exact tenant route should be registered", method)
 	}
+
+	for _, path := range []string{"/api/v1/tenants/tenant-1/self/payslips", "/api/v1/tenants/tenant-1/accounts"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code, "%s should be registered behind authentication", path)
+	}
 }
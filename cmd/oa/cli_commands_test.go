@@ -22,6 +22,7 @@ import (
 
 	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/apitoken"
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/auth"
 	"github.com/HMB-research/open-accounting/internal/banking"
@@ -36,9 +37,12 @@ import (
 	"github.com/HMB-research/open-accounting/internal/payments"
 	"github.com/HMB-research/open-accounting/internal/payroll"
 	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
 	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 	"github.com/HMB-research/open-accounting/internal/reports"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
 	"github.com/HMB-research/open-accounting/internal/tax"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 )
@@ -390,6 +394,35 @@ func cliOrderPayload(id, number, status string) map[string]any {
 	}
 }
 
+func cliOrderShipmentPayload() orders.OrderShipment {
+	productID := "prod-1"
+	journalID := "journal-1"
+	return orders.OrderShipment{
+		ID:             "shipment-1",
+		TenantID:       "tenant-1",
+		ShipmentNumber: "DN-00001",
+		OrderID:        "order-1",
+		WarehouseID:    "wh-1",
+		ShipmentDate:   time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC),
+		CostingMethod:  "FIFO",
+		TotalCost:      decimal.RequireFromString("16"),
+		Lines: []orders.OrderShipmentLine{{
+			ID:             "shipment-line-1",
+			ShipmentID:     "shipment-1",
+			OrderLineID:    "line-1",
+			LineNumber:     1,
+			ProductID:      &productID,
+			Description:    "Consulting",
+			Quantity:       decimal.RequireFromString("2"),
+			UnitCost:       decimal.RequireFromString("8"),
+			TotalCost:      decimal.RequireFromString("16"),
+			LotNumber:      "LOT-1",
+			JournalEntryID: &journalID,
+		}},
+		CreatedBy: "user-1",
+	}
+}
+
 func cliTSDDeclarationPayload(id string, year, month int, status string) map[string]any {
 	return map[string]any{
 		"id":                          id,
@@ -2482,6 +2515,8 @@ func TestCLIUsersBranches(t *testing.T) {
 		{name: "update role missing id", args: []string{"users", "update-role", "--role", "viewer"}, want: "id and role are required"},
 		{name: "update role missing role", args: []string{"users", "update-role", "--id", "user-branch"}, want: "id and role are required"},
 		{name: "update role invalid role", args: []string{"users", "update-role", "--id", "user-branch", "--role", "owner"}, want: "role must be one of"},
+		{name: "update role employee missing link", args: []string{"users", "update-role", "--id", "user-branch", "--role", "employee"}, want: "employee_id is required for the employee role"},
+		{name: "update role link without employee role", args: []string{"users", "update-role", "--id", "user-branch", "--role", "viewer", "--employee-id", "emp-1"}, want: "employee_id is only valid for the employee role"},
 		{name: "set status bad flag", args: []string{"users", "set-status", "--unknown"}, want: "flag provided but not defined"},
 		{name: "set status missing id", args: []string{"users", "set-status", "--active", "true"}, want: "id and active are required"},
 		{name: "set status missing active", args: []string{"users", "set-status", "--id", "user-branch"}, want: "id and active are required"},
@@ -2882,6 +2917,7 @@ func TestCLIInvitationBranches(t *testing.T) {
 		{name: "list bad flag", args: []string{"invitations", "list", "--unknown"}, want: "flag provided but not defined"},
 		{name: "create missing email", args: []string{"invitations", "create", "--role", tenant.RoleViewer}, want: "email and role are required"},
 		{name: "create missing role", args: []string{"invitations", "create", "--email", "branch@example.com", "--role", " "}, want: "email and role are required"},
+		{name: "create employee missing link", args: []string{"invitations", "create", "--email", "branch@example.com", "--role", tenant.RoleEmployee}, want: "employee_id is required for the employee role"},
 		{name: "create bad flag", args: []string{"invitations", "create", "--unknown"}, want: "flag provided but not defined"},
 		{name: "revoke missing id", args: []string{"invitations", "revoke"}, want: "id is required"},
 		{name: "revoke bad flag", args: []string{"invitations", "revoke", "--unknown"}, want: "flag provided but not defined"},
@@ -7993,12 +8029,46 @@ func TestCLIInvoiceLifecycleCommands(t *testing.T) {
 		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/invoices/inv-1/pdf":
 			w.Header().Set("Content-Type", "application/pdf")
 			_, _ = w.Write([]byte("%PDF-1.4 invoice"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/invoices/inv-1/reminder-pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-1.4 reminder"))
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/invoices/inv-1/send":
 			w.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(w).Encode(map[string]string{"status": "sent"})
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/invoices/inv-1/void":
 			w.Header().Set("Content-Type", "application/json")
 			_ = json.NewEncoder(w).Encode(map[string]string{"status": "voided"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/invoices/export-einvoice":
+			var req invoicing.ExportEInvoiceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			if req.Format == invoicing.EInvoiceFormatPeppol {
+				assert.Equal(t, []string{"inv-1"}, req.InvoiceIDs)
+				w.Header().Set("Content-Type", "application/xml")
+				_, _ = w.Write([]byte("<Invoice xmlns=\"urn:oasis:names:specification:ubl:schema:xsd:Invoice-2\"/>"))
+				return
+			}
+			assert.Equal(t, []string{"inv-1", "cn-1"}, req.InvoiceIDs)
+			assert.True(t, req.IncludePDF)
+			assert.Equal(t, "EE382200221020145685", req.PayToIBAN)
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte("<E_Invoice><Invoice invoiceId=\"INV-00001\"/></E_Invoice>"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/invoices/inv-1/credit-notes":
+			w.Header().Set("Content-Type", "application/json")
+			var req invoicing.CreateCreditNoteRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-03-20", req.IssueDate.Format("2006-01-02"))
+			assert.Equal(t, "Returned hours", req.Notes)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "line-1", req.Lines[0].OriginalLineID)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.NewFromInt(1)))
+			creditNote := invoicePayload("PAID")
+			creditNote["id"] = "cn-1"
+			creditNote["invoice_number"] = "CN-00001"
+			creditNote["invoice_type"] = "CREDIT_NOTE"
+			creditNote["original_invoice_id"] = "inv-1"
+			creditNote["original_invoice_number"] = "INV-00001"
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(creditNote)
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
@@ -8052,6 +8122,14 @@ func TestCLIInvoiceLifecycleCommands(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "%PDF-1.4 invoice", string(pdf))
 
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"invoices", "reminder-pdf", "--id", "inv-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "%PDF-1.4 reminder")
+
+	err = app.run(context.Background(), []string{"invoices", "reminder-pdf"})
+	require.ErrorContains(t, err, "id is required")
+
 	stdout.Reset()
 	err = app.run(context.Background(), []string{"invoices", "send", "--id", "inv-1"})
 	require.NoError(t, err)
@@ -8061,6 +8139,44 @@ func TestCLIInvoiceLifecycleCommands(t *testing.T) {
 	err = app.run(context.Background(), []string{"invoices", "void", "--id", "inv-1"})
 	require.NoError(t, err)
 	assert.Contains(t, stdout.String(), "Voided invoice inv-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"invoices", "credit-note",
+		"--id", "inv-1",
+		"--issue-date", "2026-03-20",
+		"--notes", "Returned hours",
+		"--line", "line-1:1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created credit note CN-00001 (cn-1) for invoice INV-00001")
+
+	err = app.run(context.Background(), []string{"invoices", "credit-note", "--id", "inv-1", "--line", "line-1"})
+	require.ErrorContains(t, err, "original-line-id:quantity")
+
+	stdout.Reset()
+	eInvoicePath := filepath.Join(t.TempDir(), "einvoice.xml")
+	err = app.run(context.Background(), []string{
+		"invoices", "export-einvoice",
+		"--id", "inv-1",
+		"--id", "cn-1",
+		"--include-pdf",
+		"--pay-to-iban", "EE382200221020145685",
+		"--output", eInvoicePath,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Wrote E-invoice XML")
+	eInvoiceXML, err := os.ReadFile(eInvoicePath)
+	require.NoError(t, err)
+	assert.Contains(t, string(eInvoiceXML), "INV-00001")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"invoices", "export-einvoice", "--id", "inv-1", "--format", "ubl"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "ubl:schema:xsd:Invoice-2")
+
+	err = app.run(context.Background(), []string{"invoices", "export-einvoice"})
+	require.ErrorContains(t, err, "at least one id is required")
 }
 
 func TestCLIPurchaseInvoiceCommands(t *testing.T) {
@@ -8674,6 +8790,31 @@ func TestCLIQuoteCommands(t *testing.T) {
 			_ = json.NewEncoder(w).Encode(map[string]string{"status": "sent"})
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-1/accept":
 			_ = json.NewEncoder(w).Encode(map[string]string{"status": "accepted"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-1/invoices":
+			_ = json.NewEncoder(w).Encode([]quotes.QuoteInvoice{{
+				ID:            "billing-1",
+				TenantID:      "tenant-1",
+				QuoteID:       "quote-1",
+				InvoiceID:     "inv-1",
+				InvoiceNumber: "INV-2026-0001",
+				Kind:          invoicing.BillingKindPercentage,
+				Percent:       decimal.NewFromInt(30),
+				Subtotal:      decimal.NewFromInt(60),
+				VATRate:       decimal.NewFromInt(22),
+				CreatedAt:     time.Date(2026, 3, 20, 9, 0, 0, 0, time.UTC),
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-partial/convert-to-invoice":
+			var req quotes.ConvertQuoteToInvoiceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, invoicing.BillingKindPrepayment, req.Kind)
+			assert.True(t, req.Amount.Equal(decimal.NewFromInt(50)))
+			require.NotNil(t, req.VATRate)
+			assert.True(t, req.VATRate.Equal(decimal.NewFromInt(22)))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"quote":   cliQuotePayload("quote-partial", "QUO-00003", "ACCEPTED"),
+				"invoice": map[string]any{"id": "inv-3", "invoice_number": "INV-2026-0003"},
+			})
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-1/convert-to-invoice":
 			var req quotes.ConvertQuoteToInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
@@ -8813,6 +8954,22 @@ func TestCLIQuoteCommands(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, stdout.String(), "Converted quote QUO-00001 to invoice INV-2026-0001 (inv-1)")
 
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "convert-to-invoice", "--id", "quote-partial", "--kind", "PREPAYMENT", "--amount", "50", "--vat-rate", "22"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Invoiced part of quote QUO-00003 on invoice INV-2026-0003 (inv-3)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "invoices", "--id", "quote-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "PERCENTAGE")
+	assert.Contains(t, stdout.String(), "60.00")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "invoices", "--id", "quote-1", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"invoice_number": "INV-2026-0001"`)
+
 	stdout.Reset()
 	err = app.run(context.Background(), []string{"quotes", "reject", "--id", "quote-1"})
 	require.NoError(t, err)
@@ -8824,6 +8981,127 @@ func TestCLIQuoteCommands(t *testing.T) {
 	assert.Contains(t, stdout.String(), "Deleted quote quote-1")
 }
 
+func TestCLIQuoteRevisionAndLinkCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	publicQuote := map[string]any{
+		"quote_number":    "QUO-00001",
+		"revision":        2,
+		"company_name":    "Alpha",
+		"customer_name":   "Acme",
+		"quote_date":      "2026-03-15T00:00:00Z",
+		"valid_until":     "2026-04-15T00:00:00Z",
+		"status":          "SENT",
+		"currency":        "EUR",
+		"subtotal":        "180.00",
+		"vat_amount":      "39.60",
+		"total":           "219.60",
+		"link_expires_at": "2026-03-29T00:00:00Z",
+		"lines": []map[string]any{{
+			"line_number": 1,
+			"description": "Consulting",
+			"quantity":    "2.00",
+			"unit_price":  "90.00",
+			"vat_rate":    "22.00",
+			"line_total":  "219.60",
+		}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasPrefix(r.URL.Path, "/api/v1/tenants/") {
+			require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+		} else {
+			assert.Empty(t, r.Header.Get("Authorization"))
+		}
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-1/revisions":
+			_ = json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "rev-1", "tenant_id": "tenant-1", "quote_id": "quote-1", "revision": 1, "currency": "EUR", "total": "100.00", "sent_at": "2026-03-15T12:00:00Z"},
+				{"id": "rev-2", "tenant_id": "tenant-1", "quote_id": "quote-1", "revision": 2, "currency": "EUR", "total": "219.60", "sent_at": "2026-03-16T12:00:00Z"},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-1/revisions/2":
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "rev-2", "tenant_id": "tenant-1", "quote_id": "quote-1", "revision": 2, "currency": "EUR", "total": "219.60", "sent_at": "2026-03-16T12:00:00Z",
+				"quote": cliQuotePayload("quote-1", "QUO-00001", "SENT"),
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/quotes/quote-1/public-link":
+			var req quotes.CreateQuoteLinkRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, 7, req.ExpiresInDays)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"token": "link-token", "quote_id": "quote-1", "revision": 2, "expires_at": "2026-03-23T12:00:00Z"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/public/quotes/link-token":
+			_ = json.NewEncoder(w).Encode(publicQuote)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/public/quotes/link-token/pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF public quote"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/public/quotes/link-token/accept":
+			publicQuote["status"] = "ACCEPTED"
+			_ = json.NewEncoder(w).Encode(publicQuote)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/public/quotes/link-token/reject":
+			w.WriteHeader(http.StatusConflict)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "quote has already been answered"})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{"quotes", "revisions", "--id", "quote-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "REVISION")
+	assert.Contains(t, stdout.String(), "219.60 EUR")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "revision", "--id", "quote-1", "--revision", "2"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Revision 2 sent 2026-03-16T12:00:00Z")
+	assert.Contains(t, stdout.String(), "Quote QUO-00001 (SENT)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "link", "--id", "quote-1", "--expires-in-days", "7"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created link to revision 2 of quote quote-1")
+	assert.Contains(t, stdout.String(), "Token: link-token")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "link-view", "--token", "link-token", "--base-url", server.URL})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Quote QUO-00001 revision 2 (SENT)")
+	assert.Contains(t, stdout.String(), "To: Acme")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "link-pdf", "--token", "link-token", "--output", "-"})
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF public quote", stdout.String())
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"quotes", "link-accept", "--token", "link-token", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "ACCEPTED"`)
+
+	err = app.run(context.Background(), []string{"quotes", "link-reject", "--token", "link-token"})
+	require.ErrorContains(t, err, "quote has already been answered")
+
+	err = app.run(context.Background(), []string{"quotes", "link-view"})
+	require.EqualError(t, err, "token is required")
+	err = app.run(context.Background(), []string{"quotes", "revision", "--id", "quote-1"})
+	require.EqualError(t, err, "revision must be positive")
+}
+
 func TestCLIQuoteBranches(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
@@ -9037,6 +9315,10 @@ func TestCLIQuoteValidationBranches(t *testing.T) {
 		{name: "convert missing id", args: []string{"convert-to-invoice"}, want: "id is required"},
 		{name: "convert invalid issue date", args: []string{"convert-to-invoice", "--id", "quote-1", "--issue-date", "bad"}, want: "parse issue-date"},
 		{name: "convert invalid due date", args: []string{"convert-to-invoice", "--id", "quote-1", "--due-date", "bad"}, want: "parse due-date"},
+		{name: "convert invalid kind", args: []string{"convert-to-invoice", "--id", "quote-1", "--kind", "milestone"}, want: "invalid billing kind"},
+		{name: "convert invalid line", args: []string{"convert-to-invoice", "--id", "quote-1", "--line", "quantity=1"}, want: "line line_id is required"},
+		{name: "invoices bad flag", args: []string{"invoices", "--bad"}, want: "flag provided but not defined"},
+		{name: "invoices missing id", args: []string{"invoices"}, want: "id is required"},
 	}
 
 	for _, tc := range tests {
@@ -9328,9 +9610,49 @@ func TestCLIOrderCommands(t *testing.T) {
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/process":
 			_ = json.NewEncoder(w).Encode(map[string]string{"status": "processing"})
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/ship":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "shipped"})
+			var req orders.ShipOrderRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "wh-1", req.WarehouseID)
+			assert.Equal(t, "cogs-1", req.CostOfGoodsSoldAccountID)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "line-1", req.Lines[0].OrderLineID)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.RequireFromString("2")))
+			assert.Equal(t, "LOT-1", req.Lines[0].LotNumber)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(cliOrderShipmentPayload())
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/shipments":
+			_ = json.NewEncoder(w).Encode([]orders.OrderShipment{cliOrderShipmentPayload()})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/shipments/shipment-1/delivery-note":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF delivery note"))
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/deliver":
 			_ = json.NewEncoder(w).Encode(map[string]string{"status": "delivered"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/invoices":
+			_ = json.NewEncoder(w).Encode([]orders.OrderInvoice{{
+				ID:                 "billing-1",
+				TenantID:           "tenant-1",
+				OrderID:            "order-1",
+				InvoiceID:          "inv-prepay",
+				InvoiceNumber:      "INV-2026-0000",
+				Kind:               invoicing.BillingKindPrepayment,
+				Subtotal:           decimal.NewFromInt(50),
+				VATRate:            decimal.NewFromInt(22),
+				PrepaymentDeducted: decimal.Zero,
+				NettedAmount:       decimal.NewFromInt(20),
+				CreatedAt:          time.Date(2026, 3, 18, 9, 0, 0, 0, time.UTC),
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-partial/convert-to-invoice":
+			var req orders.ConvertOrderToInvoiceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, invoicing.BillingKindQuantity, req.Kind)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "line-1", req.Lines[0].LineID)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.NewFromInt(1)))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"order":   cliOrderPayload("order-partial", "ORD-00003", "SHIPPED"),
+				"invoice": map[string]any{"id": "inv-2", "invoice_number": "INV-2026-0002"},
+			})
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/orders/order-1/convert-to-invoice":
 			var req orders.ConvertOrderToInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
@@ -9491,9 +9813,26 @@ func TestCLIOrderCommands(t *testing.T) {
 	assert.Contains(t, stdout.String(), `"status": "processing"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"orders", "ship", "--id", "order-1"})
+	err = app.run(context.Background(), []string{
+		"orders", "ship",
+		"--id", "order-1",
+		"--warehouse-id", "wh-1",
+		"--cogs-account-id", "cogs-1",
+		"--line", "line_id=line-1,quantity=2,lot=LOT-1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Shipped order order-1 as DN-00001 (shipment-1), cost of goods 16")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"orders", "shipments", "--id", "order-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "DN-00001")
+	assert.Contains(t, stdout.String(), "2026-03-20")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"orders", "delivery-note", "--id", "order-1", "--shipment-id", "shipment-1", "--output", "-"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Shipped order order-1")
+	assert.Equal(t, "%PDF delivery note", stdout.String())
 
 	stdout.Reset()
 	err = app.run(context.Background(), []string{"orders", "deliver", "--id", "order-1"})
@@ -9511,6 +9850,23 @@ func TestCLIOrderCommands(t *testing.T) {
 	require.NoError(t, err)
 	assert.Contains(t, stdout.String(), "Converted order ORD-00001 to invoice INV-2026-0001 (inv-1)")
 
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"orders", "convert-to-invoice", "--id", "order-partial", "--kind", "quantity", "--line", "line_id=line-1,quantity=1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Invoiced part of order ORD-00003 on invoice INV-2026-0002 (inv-2)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"orders", "invoices", "--id", "order-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "INV-2026-0000")
+	assert.Contains(t, stdout.String(), "PREPAYMENT")
+	assert.Contains(t, stdout.String(), "20.00")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"orders", "invoices", "--id", "order-1", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"netted_amount": "20"`)
+
 	stdout.Reset()
 	err = app.run(context.Background(), []string{"orders", "cancel", "--id", "order-1"})
 	require.NoError(t, err)
@@ -9723,10 +10079,30 @@ func TestCLIOrderBranches(t *testing.T) {
 		{name: "status bad flag", args: []string{"orders", "confirm", "--bad"}, want: "flag provided but not defined"},
 		{name: "confirm missing id", args: []string{"orders", "confirm"}, want: "id is required"},
 		{name: "evidence gate only confirm", args: []string{"orders", "process", "--id", "order-branch", "--require-approved-evidence"}, want: "require-approved-evidence is only supported for orders confirm"},
+		{name: "ship bad flag", args: []string{"orders", "ship", "--bad"}, want: "flag provided but not defined"},
+		{name: "ship missing id", args: []string{"orders", "ship"}, want: "id is required"},
+		{name: "ship line missing id", args: []string{"orders", "ship", "--id", "order-branch", "--line", "quantity=1"}, want: "line line_id is required"},
+		{name: "ship line bad quantity", args: []string{"orders", "ship", "--id", "order-branch", "--line", "line_id=line-1,quantity=0"}, want: "line quantity"},
+		{name: "ship line bad expiry", args: []string{"orders", "ship", "--id", "order-branch", "--line", "line_id=line-1,quantity=1,expiry=bad"}, want: "line expiry_date"},
+		{name: "ship line malformed field", args: []string{"orders", "ship", "--id", "order-branch", "--line", "line_id"}, want: "must be key=value"},
+		{name: "shipments bad flag", args: []string{"orders", "shipments", "--bad"}, want: "flag provided but not defined"},
+		{name: "shipments missing id", args: []string{"orders", "shipments"}, want: "id is required"},
+		{name: "delivery note bad flag", args: []string{"orders", "delivery-note", "--bad"}, want: "flag provided but not defined"},
+		{name: "delivery note missing id", args: []string{"orders", "delivery-note", "--shipment-id", "shipment-1"}, want: "id is required"},
+		{name: "delivery note missing shipment", args: []string{"orders", "delivery-note", "--id", "order-branch"}, want: "shipment-id is required"},
 		{name: "convert bad flag", args: []string{"orders", "convert-to-invoice", "--bad"}, want: "flag provided but not defined"},
 		{name: "convert missing id", args: []string{"orders", "convert-to-invoice"}, want: "id is required"},
 		{name: "convert invalid issue date", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--issue-date", "bad"}, want: "parse issue-date"},
 		{name: "convert invalid due date", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--due-date", "bad"}, want: "parse due-date"},
+		{name: "convert invalid kind", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--kind", "milestone"}, want: "invalid billing kind"},
+		{name: "convert invalid percent", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--percent", "-5"}, want: "percent"},
+		{name: "convert invalid amount", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--kind", "prepayment", "--amount", "abc"}, want: "amount"},
+		{name: "convert invalid vat rate", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--kind", "prepayment", "--amount", "10", "--vat-rate", "-1"}, want: "vat-rate"},
+		{name: "convert line missing id", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--line", "quantity=1"}, want: "line line_id is required"},
+		{name: "convert line bad quantity", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--line", "line_id=line-1,quantity=0"}, want: "line quantity"},
+		{name: "convert line malformed field", args: []string{"orders", "convert-to-invoice", "--id", "order-branch", "--line", "line_id"}, want: "must be key=value"},
+		{name: "invoices bad flag", args: []string{"orders", "invoices", "--bad"}, want: "flag provided but not defined"},
+		{name: "invoices missing id", args: []string{"orders", "invoices"}, want: "id is required"},
 	}
 	for _, tc := range errorCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -9946,7 +10322,7 @@ func TestCLIOrderAuthFlagsAndAPIErrorBranches(t *testing.T) {
 	}
 }
 
-func TestCLIRecurringInvoiceCommands(t *testing.T) {
+func TestCLIPurchaseOrderCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -9956,104 +10332,110 @@ func TestCLIRecurringInvoiceCommands(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	generationPayload := recurringGenerationPayload("rec-1")
+	poPayload := purchasing.PurchaseOrder{
+		ID:           "po-1",
+		TenantID:     "tenant-1",
+		PONumber:     "PO-00001",
+		ContactID:    "supplier-1",
+		WarehouseID:  "wh-1",
+		OrderDate:    time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+		Status:       purchasing.PurchaseOrderStatusApproved,
+		Currency:     "EUR",
+		ExchangeRate: decimal.NewFromInt(1),
+		Subtotal:     decimal.RequireFromString("50.00"),
+		VATAmount:    decimal.RequireFromString("11.00"),
+		Total:        decimal.RequireFromString("61.00"),
+		Lines: []purchasing.PurchaseOrderLine{{
+			ID:               "line-1",
+			LineNumber:       1,
+			ProductID:        "prod-1",
+			Description:      "Widget",
+			Quantity:         decimal.NewFromInt(10),
+			UnitPrice:        decimal.RequireFromString("5.00"),
+			LineTotal:        decimal.RequireFromString("61.00"),
+			ReceivedQuantity: decimal.NewFromInt(6),
+		}},
+	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
-			require.Equal(t, "true", r.URL.Query().Get("active_only"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{recurringInvoicePayload("rec-1", "Monthly retainer", true)})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
-			var req recurring.CreateRecurringInvoiceRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders":
+			require.Equal(t, "APPROVED", r.URL.Query().Get("status"))
+			require.Equal(t, "supplier-1", r.URL.Query().Get("contact_id"))
+			require.Equal(t, "2026-03-01", r.URL.Query().Get("from_date"))
+			_ = json.NewEncoder(w).Encode([]purchasing.PurchaseOrder{poPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders":
+			var req purchasing.CreatePurchaseOrderRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Monthly retainer", req.Name)
-			assert.Equal(t, "contact-1", req.ContactID)
-			assert.Equal(t, "SALES", req.InvoiceType)
-			assert.Equal(t, "EUR", req.Currency)
-			assert.Equal(t, recurring.FrequencyMonthly, req.Frequency)
-			assert.Equal(t, "2026-03-15", req.StartDate.Format("2006-01-02"))
-			require.NotNil(t, req.EndDate)
-			assert.Equal(t, "2026-12-31", req.EndDate.Format("2006-01-02"))
-			assert.Equal(t, 21, req.PaymentTermsDays)
-			assert.True(t, req.SendEmailOnGeneration)
-			assert.Equal(t, "billing@example.com", req.RecipientEmailOverride)
-			require.NotNil(t, req.AttachPDFToEmail)
-			assert.True(t, *req.AttachPDFToEmail)
+			assert.Equal(t, "supplier-1", req.ContactID)
+			assert.Equal(t, "wh-1", req.WarehouseID)
+			assert.Equal(t, "2026-03-02", req.OrderDate.Format("2006-01-02"))
+			require.NotNil(t, req.ExpectedDate)
+			assert.Equal(t, "USD", req.Currency)
+			assert.True(t, req.ExchangeRate.Equal(decimal.RequireFromString("1.1")))
 			require.Len(t, req.Lines, 1)
-			assert.Equal(t, "Consulting", req.Lines[0].Description)
-			assert.True(t, req.Lines[0].Quantity.Equal(decimal.RequireFromString("2.00")))
-			assert.True(t, req.Lines[0].DiscountPercent.Equal(decimal.RequireFromString("10.00")))
-			require.NotNil(t, req.Lines[0].AccountID)
-			require.NotNil(t, req.Lines[0].ProductID)
+			assert.Equal(t, "prod-1", req.Lines[0].ProductID)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.NewFromInt(10)))
+			assert.True(t, req.Lines[0].DiscountPercent.Equal(decimal.NewFromInt(5)))
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-1", "Monthly retainer", true))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/import":
-			var req recurring.ImportRecurringInvoicesRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "recurring.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "Monthly Legacy")
-			_ = json.NewEncoder(w).Encode(recurring.ImportRecurringInvoicesResult{
-				FileName:         req.FileName,
-				RowsProcessed:    1,
-				TemplatesCreated: 1,
-				LinesImported:    1,
-				RowsSkipped:      0,
-			})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/from-invoice/inv-template":
-			var req recurring.CreateFromInvoiceRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "From invoice", req.Name)
-			assert.Equal(t, recurring.FrequencyBiweekly, req.Frequency)
-			assert.Equal(t, "2026-04-01", req.StartDate.Format("2006-01-02"))
-			assert.Equal(t, 14, req.PaymentTermsDays)
+			_ = json.NewEncoder(w).Encode(poPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders/po-1":
+			_ = json.NewEncoder(w).Encode(poPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders/po-1/approve":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "APPROVED"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders/po-1/cancel":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "CANCELED"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders/po-1/receipts":
+			var req purchasing.ReceiveGoodsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-03-09", req.ReceiptDate.Format("2006-01-02"))
+			assert.Equal(t, "grni", req.AccrualAccountID)
+			assert.Equal(t, "DN-1042", req.SupplierReference)
+			require.Len(t, req.Lines, 1)
+			line := req.Lines[0]
+			assert.Equal(t, "line-1", line.PurchaseOrderLineID)
+			assert.True(t, line.Quantity.Equal(decimal.NewFromInt(6)))
+			require.NotNil(t, line.UnitCost)
+			assert.True(t, line.UnitCost.Equal(decimal.RequireFromString("4.75")))
+			assert.Equal(t, "LOT-1", line.LotNumber)
+			assert.Equal(t, "2027-03-31", line.ExpiryDate)
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-2", "From invoice", true))
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1":
-			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-1", "Monthly retainer", true))
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1":
-			var req recurring.UpdateRecurringInvoiceRequest
+			_ = json.NewEncoder(w).Encode(purchasing.GoodsReceipt{ID: "grn-1", ReceiptNumber: "GRN-00001", TotalCost: decimal.RequireFromString("28.50")})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders/po-1/invoice-matches":
+			var req purchasing.MatchInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			require.NotNil(t, req.Name)
-			assert.Equal(t, "Quarterly retainer", *req.Name)
-			require.NotNil(t, req.Frequency)
-			assert.Equal(t, recurring.FrequencyQuarterly, *req.Frequency)
-			require.NotNil(t, req.PaymentTermsDays)
-			assert.Equal(t, 30, *req.PaymentTermsDays)
-			require.NotNil(t, req.SendEmailOnGeneration)
-			assert.False(t, *req.SendEmailOnGeneration)
-			require.NotNil(t, req.AttachPDFToEmail)
-			assert.False(t, *req.AttachPDFToEmail)
-			require.Len(t, req.Lines, 1)
-			assert.Equal(t, "Updated consulting", req.Lines[0].Description)
-			payload := recurringInvoicePayload("rec-1", "Quarterly retainer", true)
-			payload["frequency"] = "QUARTERLY"
-			payload["payment_terms_days"] = 30
-			payload["send_email_on_generation"] = false
-			payload["attach_pdf_to_email"] = false
-			payload["lines"] = []map[string]any{{
-				"id":                   "line-2",
-				"recurring_invoice_id": "rec-1",
-				"line_number":          1,
-				"description":          "Updated consulting",
-				"quantity":             "3.00",
-				"unit":                 "hour",
-				"unit_price":           "100.00",
-				"vat_rate":             "22.00",
-			}}
-			_ = json.NewEncoder(w).Encode(payload)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/pause":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "paused"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/resume":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "resumed"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/generate":
-			_ = json.NewEncoder(w).Encode(generationPayload)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/generate-due":
-			_ = json.NewEncoder(w).Encode([]map[string]any{generationPayload})
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+			assert.Equal(t, "inv-1", req.InvoiceID)
+			assert.Equal(t, "grni", req.AccrualAccountID)
+			assert.Equal(t, "payables", req.PayableAccountID)
+			assert.Equal(t, "vat", req.VATAccountID)
+			assert.Equal(t, "variance", req.PriceVarianceAccountID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(purchasing.PurchaseInvoiceMatch{
+				ID:             "match-1",
+				InvoiceID:      "inv-1",
+				AccrualCleared: decimal.RequireFromString("28.50"),
+				PriceVariance:  decimal.RequireFromString("1.50"),
+				PayableAmount:  decimal.RequireFromString("36.60"),
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/purchase-orders/po-1/matching":
+			_ = json.NewEncoder(w).Encode(purchasing.PurchaseOrderMatching{
+				PurchaseOrderID:    "po-1",
+				PONumber:           "PO-00001",
+				Status:             purchasing.PurchaseOrderStatusPartiallyReceived,
+				Currency:           "EUR",
+				AccruedNotInvoiced: decimal.RequireFromString("10.00"),
+				Lines: []purchasing.PurchaseOrderMatchingLine{{
+					LineNumber:       1,
+					Description:      "Widget",
+					OrderedQuantity:  decimal.NewFromInt(10),
+					ReceivedQuantity: decimal.NewFromInt(6),
+					InvoicedQuantity: decimal.NewFromInt(4),
+					Status:           string(purchasing.MatchingLineStatusReceivedNotInvoiced),
+				}},
+			})
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
@@ -10064,98 +10446,116 @@ func TestCLIRecurringInvoiceCommands(t *testing.T) {
 
 	app, stdout, _ := newTestCLIApp()
 
-	err := app.run(context.Background(), []string{"recurring-invoices", "list", "--active-only", "--json"})
+	err := app.run(context.Background(), []string{
+		"purchase-orders", "list",
+		"--status", "approved",
+		"--contact-id", "supplier-1",
+		"--from", "2026-03-01",
+	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"name": "Monthly retainer"`)
+	assert.Contains(t, stdout.String(), "PO-00001")
+	assert.Contains(t, stdout.String(), "61 EUR")
 
 	stdout.Reset()
 	err = app.run(context.Background(), []string{
-		"recurring-invoices", "create",
-		"--name", "Monthly retainer",
-		"--contact-id", "contact-1",
-		"--type", "sales",
-		"--currency", "eur",
-		"--frequency", "monthly",
-		"--start-date", "2026-03-15",
-		"--end-date", "2026-12-31",
-		"--payment-terms-days", "21",
-		"--reference", "RET-1",
-		"--notes", "Monthly services",
-		"--send-email",
-		"--recipient-email", "billing@example.com",
-		"--attach-pdf",
-		"--email-subject", "Monthly invoice",
-		"--email-message", "Please see attached invoice.",
-		"--line", "description=Consulting,quantity=2,unit=hour,unit_price=100.00,discount_percent=10.00,vat_rate=22.00,account_id=acc-1,product_id=prod-1",
+		"purchase-orders", "create",
+		"--contact-id", "supplier-1",
+		"--warehouse-id", "wh-1",
+		"--order-date", "2026-03-02",
+		"--expected-date", "2026-03-09",
+		"--currency", "usd",
+		"--exchange-rate", "1.1",
+		"--line", "product_id=prod-1,quantity=10,unit_price=5.00,discount=5,vat_rate=22",
 	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Created recurring invoice Monthly retainer (rec-1)")
+	assert.Contains(t, stdout.String(), "Created purchase order PO-00001 (po-1)")
 
-	recurringImportFile := writeTempCSV(t, "recurring.csv", "name,contact_id,frequency,start_date,line_description,quantity,unit_price,vat_rate\nMonthly Legacy,contact-1,MONTHLY,2026-03-15,Consulting,1,100,22\n")
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "import", "--file", recurringImportFile})
+	err = app.run(context.Background(), []string{"purchase-orders", "get", "--id", "po-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 recurring invoices, imported 1 lines, skipped 0 rows")
+	assert.Contains(t, stdout.String(), "Purchase order PO-00001 (APPROVED)")
+	assert.Contains(t, stdout.String(), "RECEIVED")
+	assert.Contains(t, stdout.String(), "Widget")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"recurring-invoices", "from-invoice",
-		"--invoice-id", "inv-template",
-		"--name", "From invoice",
-		"--frequency", "biweekly",
-		"--start-date", "2026-04-01",
-	})
+	err = app.run(context.Background(), []string{"purchase-orders", "approve", "--id", "po-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Created recurring invoice From invoice (rec-2) from invoice inv-template")
+	assert.Contains(t, stdout.String(), "Purchase order po-1 is APPROVED")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "get", "--id", "rec-1"})
+	err = app.run(context.Background(), []string{"purchase-orders", "cancel", "--id", "po-1", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Recurring invoice Monthly retainer")
-	assert.Contains(t, stdout.String(), "Consulting")
+	assert.Contains(t, stdout.String(), `"status": "CANCELED"`)
 
 	stdout.Reset()
 	err = app.run(context.Background(), []string{
-		"recurring-invoices", "update",
-		"--id", "rec-1",
-		"--name", "Quarterly retainer",
-		"--frequency", "quarterly",
-		"--payment-terms-days", "30",
-		"--send-email", "false",
-		"--attach-pdf", "false",
-		"--line", "description=Updated consulting,quantity=3,unit=hour,unit_price=100.00,vat_rate=22.00",
+		"purchase-orders", "receive",
+		"--id", "po-1",
+		"--receipt-date", "2026-03-09",
+		"--accrual-account-id", "grni",
+		"--supplier-reference", "DN-1042",
+		"--line", "line_id=line-1,quantity=6,unit_cost=4.75,lot=LOT-1,expiry=2027-03-31",
 	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Recurring invoice Quarterly retainer")
-	assert.Contains(t, stdout.String(), "Attach PDF: false")
-
-	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "pause", "--id", "rec-1"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Paused recurring invoice rec-1")
+	assert.Contains(t, stdout.String(), "Received goods GRN-00001 (grn-1), total cost 28.5")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "resume", "--id", "rec-1", "--json"})
+	err = app.run(context.Background(), []string{
+		"purchase-orders", "match-invoice",
+		"--id", "po-1",
+		"--invoice-id", "inv-1",
+		"--accrual-account-id", "grni",
+		"--payable-account-id", "payables",
+		"--vat-account-id", "vat",
+		"--price-variance-account-id", "variance",
+	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "resumed"`)
+	assert.Contains(t, stdout.String(), "Matched invoice inv-1: accrual cleared 28.5, price variance 1.5, payable 36.6")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "generate", "--id", "rec-1"})
+	err = app.run(context.Background(), []string{"purchase-orders", "matching", "--id", "po-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Generated invoice INV-00001 (inv-1) from recurring invoice rec-1")
+	assert.Contains(t, stdout.String(), "Purchase order matching PO-00001 (PARTIALLY_RECEIVED)")
+	assert.Contains(t, stdout.String(), "Received not invoiced: 10")
+	assert.Contains(t, stdout.String(), "RECEIVED_NOT_INVOICED")
+}
 
-	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "generate-due"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "INV-00001")
+func TestCLIPurchaseOrderValidation(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:  "https://placeholder.example.com",
+		TenantID: "tenant-1",
+		APIToken: "oa_saved_token",
+	}))
 
-	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "delete", "--id", "rec-1"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Deleted recurring invoice rec-1")
+	app, _, _ := newTestCLIApp()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: []string{"purchase-orders"}, want: "purchase-orders subcommand required"},
+		{name: "unknown subcommand", args: []string{"purchase-orders", "reopen"}, want: `unknown purchase-orders subcommand "reopen"`},
+		{name: "invalid status", args: []string{"purchase-orders", "list", "--status", "shipped"}, want: `invalid purchase order status "shipped"`},
+		{name: "create without warehouse", args: []string{"purchase-orders", "create", "--contact-id", "supplier-1"}, want: "warehouse-id is required"},
+		{name: "create without lines", args: []string{"purchase-orders", "create", "--contact-id", "supplier-1", "--warehouse-id", "wh-1", "--order-date", "2026-03-02"}, want: "at least one line is required"},
+		{name: "create line without product", args: []string{"purchase-orders", "create", "--line", "quantity=1,unit_price=1,vat_rate=22"}, want: "line product_id is required"},
+		{name: "receive without accrual account", args: []string{"purchase-orders", "receive", "--id", "po-1"}, want: "accrual-account-id is required"},
+		{name: "receive line without id", args: []string{"purchase-orders", "receive", "--line", "quantity=1"}, want: "line line_id is required"},
+		{name: "receive line bad expiry", args: []string{"purchase-orders", "receive", "--line", "line_id=line-1,quantity=1,expiry=31.03.2027"}, want: "line expiry_date"},
+		{name: "match without payable account", args: []string{"purchase-orders", "match-invoice", "--id", "po-1", "--invoice-id", "inv-1", "--accrual-account-id", "grni"}, want: "payable-account-id is required"},
+		{name: "matching without id", args: []string{"purchase-orders", "matching"}, want: "id is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
 }
 
-func TestCLIRecurringInvoiceBranches(t *testing.T) {
+func TestCLILandedCostCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -10165,162 +10565,58 @@ func TestCLIRecurringInvoiceBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	app, stdout, _ := newTestCLIApp()
-	validationCases := []struct {
-		name string
-		args []string
-		want string
-	}{
-		{name: "missing subcommand", args: []string{"recurring-invoices"}, want: "recurring-invoices subcommand required"},
-		{name: "unknown subcommand", args: []string{"recurring-invoices", "unknown"}, want: `unknown recurring-invoices subcommand "unknown"`},
-		{name: "list bad flag", args: []string{"recurring-invoices", "list", "--unknown"}, want: "flag provided but not defined"},
-		{name: "create missing name", args: []string{"recurring-invoices", "create", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "name is required"},
-		{name: "create missing contact", args: []string{"recurring-invoices", "create", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "contact-id is required"},
-		{name: "create invalid type", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--type", "memo", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "invalid invoice type"},
-		{name: "create missing frequency", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "frequency is required"},
-		{name: "create bad start date", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "bad", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "parse start-date"},
-		{name: "create bad end date", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--end-date", "bad", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "parse end-date"},
-		{name: "create bad payment terms", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--payment-terms-days", "-1", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "payment-terms-days must be non-negative"},
-		{name: "create missing line", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01"}, want: "at least one line is required"},
-		{name: "create bad line", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=0,unit_price=100,vat_rate=22"}, want: "line quantity"},
-		{name: "import missing file", args: []string{"recurring-invoices", "import"}, want: "file is required"},
-		{name: "import unreadable file", args: []string{"recurring-invoices", "import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "read file"},
-		{name: "from invoice missing invoice", args: []string{"recurring-invoices", "from-invoice", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01"}, want: "invoice-id is required"},
-		{name: "from invoice missing name", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--frequency", "monthly", "--start-date", "2026-05-01"}, want: "name is required"},
-		{name: "from invoice invalid frequency", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "daily", "--start-date", "2026-05-01"}, want: "invalid recurring invoice frequency"},
-		{name: "from invoice bad start date", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "monthly", "--start-date", "bad"}, want: "parse start-date"},
-		{name: "from invoice bad end date", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--end-date", "bad"}, want: "parse end-date"},
-		{name: "from invoice bad payment terms", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--payment-terms-days", "-1"}, want: "payment-terms-days must be non-negative"},
-		{name: "get missing id", args: []string{"recurring-invoices", "get", "--id", " "}, want: "id is required"},
-		{name: "update missing id", args: []string{"recurring-invoices", "update", "--name", "Branch"}, want: "id is required"},
-		{name: "update invalid frequency", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--frequency", "daily"}, want: "invalid recurring invoice frequency"},
-		{name: "update bad end date", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--end-date", "bad"}, want: "parse end-date"},
-		{name: "update bad payment terms", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--payment-terms-days", "-1"}, want: "payment-terms-days must be non-negative"},
-		{name: "update bad send email bool", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--send-email", "sometimes"}, want: "parse send-email"},
-		{name: "update bad attach pdf bool", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--attach-pdf", "sometimes"}, want: "parse attach-pdf"},
-		{name: "delete missing id", args: []string{"recurring-invoices", "delete", "--id", " "}, want: "id is required"},
-		{name: "pause missing id", args: []string{"recurring-invoices", "pause", "--id", " "}, want: "id is required"},
-		{name: "resume missing id", args: []string{"recurring-invoices", "resume", "--id", " "}, want: "id is required"},
-		{name: "generate missing id", args: []string{"recurring-invoices", "generate", "--id", " "}, want: "id is required"},
-		{name: "generate due bad flag", args: []string{"recurring-invoices", "generate-due", "--unknown"}, want: "flag provided but not defined"},
-	}
-	for _, tc := range validationCases {
-		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
-			require.Error(t, err)
-			assert.Contains(t, err.Error(), tc.want)
-		})
+	journalID := "journal-1"
+	landedCostPayload := purchasing.LandedCost{
+		ID:               "lc-1",
+		LandedCostNumber: "LC-00001",
+		InvoiceID:        "inv-1",
+		CostDate:         time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		AllocationMethod: purchasing.LandedCostAllocationByWeight,
+		Amount:           decimal.RequireFromString("10.00"),
+		InventoryAmount:  decimal.RequireFromString("8.00"),
+		COGSAmount:       decimal.RequireFromString("2.00"),
+		JournalEntryID:   &journalID,
+		Lines: []purchasing.LandedCostLine{{
+			GoodsReceiptLineID: "grn-line-1",
+			ProductID:          "prod-1",
+			LotNumber:          "LOT-1",
+			Quantity:           decimal.NewFromInt(5),
+			OnHandQuantity:     decimal.NewFromInt(4),
+			IssuedQuantity:     decimal.NewFromInt(1),
+			Amount:             decimal.RequireFromString("10.00"),
+			PreviousUnitCost:   decimal.RequireFromString("5"),
+			NewUnitCost:        decimal.RequireFromString("7"),
+		}},
 	}
 
-	generationPayload := recurringGenerationPayload("rec-branch")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
-			assert.Empty(t, r.URL.Query().Get("active_only"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{recurringInvoicePayload("rec-branch", "Branch retainer", false)})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
-			var req recurring.CreateRecurringInvoiceRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Branch retainer", req.Name)
-			assert.Equal(t, "contact-branch", req.ContactID)
-			assert.Equal(t, "PURCHASE", req.InvoiceType)
-			assert.Equal(t, "GBP", req.Currency)
-			assert.Equal(t, recurring.FrequencyYearly, req.Frequency)
-			assert.Equal(t, "2026-05-01", req.StartDate.Format("2006-01-02"))
-			require.Nil(t, req.EndDate)
-			assert.Equal(t, 0, req.PaymentTermsDays)
-			assert.Equal(t, "BR-1", req.Reference)
-			assert.Equal(t, "Branch notes", req.Notes)
-			assert.True(t, req.SendEmailOnGeneration)
-			assert.Equal(t, "REMINDER", req.EmailTemplateType)
-			assert.Equal(t, "branch@example.com", req.RecipientEmailOverride)
-			require.NotNil(t, req.AttachPDFToEmail)
-			assert.False(t, *req.AttachPDFToEmail)
-			assert.Equal(t, "Branch subject", req.EmailSubjectOverride)
-			assert.Equal(t, "Branch message", req.EmailMessage)
-			require.Len(t, req.Lines, 1)
-			assert.Equal(t, "Branch work", req.Lines[0].Description)
-			assert.Equal(t, "day", req.Lines[0].Unit)
-			assert.True(t, req.Lines[0].Quantity.Equal(decimal.RequireFromString("1")))
-			assert.True(t, req.Lines[0].UnitPrice.Equal(decimal.RequireFromString("200")))
-			assert.True(t, req.Lines[0].VATRate.Equal(decimal.RequireFromString("20")))
-			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-branch", "Branch retainer", true))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/import":
-			var req recurring.ImportRecurringInvoicesRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "branch-recurring.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "Branch Legacy")
-			_ = json.NewEncoder(w).Encode(recurring.ImportRecurringInvoicesResult{
-				FileName:         req.FileName,
-				RowsProcessed:    2,
-				TemplatesCreated: 1,
-				LinesImported:    1,
-				RowsSkipped:      1,
-				Errors: []recurring.ImportRecurringInvoicesRowError{{
-					Row:     2,
-					Message: "missing contact",
-				}},
-			})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/from-invoice/inv-branch":
-			var req recurring.CreateFromInvoiceRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/landed-costs":
+			require.Equal(t, "grn-1", r.URL.Query().Get("goods_receipt_id"))
+			require.Equal(t, "2026-03-01", r.URL.Query().Get("from_date"))
+			_ = json.NewEncoder(w).Encode([]purchasing.LandedCost{landedCostPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/landed-costs":
+			var req purchasing.AllocateLandedCostRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "From branch", req.Name)
-			assert.Equal(t, recurring.FrequencyQuarterly, req.Frequency)
-			assert.Equal(t, "2026-06-01", req.StartDate.Format("2006-01-02"))
-			require.NotNil(t, req.EndDate)
-			assert.Equal(t, "2026-12-31", req.EndDate.Format("2006-01-02"))
-			assert.Equal(t, 45, req.PaymentTermsDays)
+			assert.Equal(t, "inv-1", req.InvoiceID)
+			assert.Equal(t, purchasing.LandedCostAllocationByWeight, req.AllocationMethod)
+			assert.Equal(t, []string{"grn-2"}, req.GoodsReceiptIDs)
+			assert.Equal(t, "payables", req.PayableAccountID)
+			assert.Equal(t, "vat", req.VATAccountID)
+			assert.Equal(t, "cogs", req.CostOfGoodsSoldAccountID)
+			require.Len(t, req.Lines, 2)
+			assert.Equal(t, "grn-line-1", req.Lines[0].GoodsReceiptLineID)
+			require.NotNil(t, req.Lines[0].Weight)
+			assert.True(t, req.Lines[0].Weight.Equal(decimal.NewFromInt(120)))
+			assert.Equal(t, "prod-1", req.Lines[1].ProductID)
+			assert.Equal(t, "LOT-1", req.Lines[1].LotNumber)
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-from-branch", "From branch", true))
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch":
-			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-branch", "Branch retainer", true))
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch":
-			var req recurring.UpdateRecurringInvoiceRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			require.NotNil(t, req.Name)
-			assert.Equal(t, "Updated branch", *req.Name)
-			require.NotNil(t, req.ContactID)
-			assert.Equal(t, "contact-updated", *req.ContactID)
-			require.NotNil(t, req.Frequency)
-			assert.Equal(t, recurring.FrequencyBiweekly, *req.Frequency)
-			require.NotNil(t, req.EndDate)
-			assert.Equal(t, "2026-11-30", req.EndDate.Format("2006-01-02"))
-			require.NotNil(t, req.PaymentTermsDays)
-			assert.Equal(t, 7, *req.PaymentTermsDays)
-			require.NotNil(t, req.Reference)
-			assert.Equal(t, "UPDATED", *req.Reference)
-			require.NotNil(t, req.Notes)
-			assert.Equal(t, "Updated notes", *req.Notes)
-			require.NotNil(t, req.SendEmailOnGeneration)
-			assert.True(t, *req.SendEmailOnGeneration)
-			require.NotNil(t, req.EmailTemplateType)
-			assert.Equal(t, "FOLLOW_UP", *req.EmailTemplateType)
-			require.NotNil(t, req.RecipientEmailOverride)
-			assert.Equal(t, "updated@example.com", *req.RecipientEmailOverride)
-			require.NotNil(t, req.AttachPDFToEmail)
-			assert.True(t, *req.AttachPDFToEmail)
-			require.NotNil(t, req.EmailSubjectOverride)
-			assert.Equal(t, "Updated subject", *req.EmailSubjectOverride)
-			require.NotNil(t, req.EmailMessage)
-			assert.Equal(t, "Updated message", *req.EmailMessage)
-			require.Len(t, req.Lines, 1)
-			assert.Equal(t, "Updated branch work", req.Lines[0].Description)
-			payload := recurringInvoicePayload("rec-branch", "Updated branch", true)
-			payload["frequency"] = "BIWEEKLY"
-			_ = json.NewEncoder(w).Encode(payload)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch/pause":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "paused"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch/generate":
-			_ = json.NewEncoder(w).Encode(generationPayload)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/generate-due":
-			_ = json.NewEncoder(w).Encode([]map[string]any{})
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+			_ = json.NewEncoder(w).Encode(landedCostPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/landed-costs/lc-1":
+			_ = json.NewEncoder(w).Encode(landedCostPayload)
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
@@ -10329,116 +10625,303 @@ func TestCLIRecurringInvoiceBranches(t *testing.T) {
 
 	t.Setenv("OA_BASE_URL", server.URL)
 
-	stdout.Reset()
-	err := app.run(context.Background(), []string{"recurring-invoices", "list"})
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{"landed-costs", "list", "--receipt-id", "grn-1", "--from", "2026-03-01"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "ID")
-	assert.Contains(t, stdout.String(), "Branch retainer")
+	assert.Contains(t, stdout.String(), "LC-00001")
+	assert.Contains(t, stdout.String(), "WEIGHT")
 
 	stdout.Reset()
 	err = app.run(context.Background(), []string{
-		"recurring-invoices", "create",
-		"--name", " Branch retainer ",
-		"--contact-id", " contact-branch ",
-		"--type", " purchase ",
-		"--currency", " gbp ",
-		"--frequency", " yearly ",
-		"--start-date", "2026-05-01",
-		"--payment-terms-days", "0",
-		"--reference", " BR-1 ",
-		"--notes", " Branch notes ",
-		"--send-email",
-		"--email-template-type", " REMINDER ",
-		"--recipient-email", " branch@example.com ",
-		"--attach-pdf=false",
-		"--email-subject", " Branch subject ",
-		"--email-message", " Branch message ",
-		"--line", "description=Branch work,quantity=1,unit=day,unit_price=200,vat_rate=20,account=acc-branch,product=prod-branch",
-		"--json",
+		"landed-costs", "allocate",
+		"--invoice-id", "inv-1",
+		"--method", "weight",
+		"--receipt-id", "grn-2",
+		"--line", "receipt_line_id=grn-line-1,weight=120",
+		"--line", "product_id=prod-1,lot=LOT-1,weight=80",
+		"--payable-account-id", "payables",
+		"--vat-account-id", "vat",
+		"--cogs-account-id", "cogs",
 	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"id": "rec-branch"`)
-	assert.Contains(t, stdout.String(), `"name": "Branch retainer"`)
+	assert.Contains(t, stdout.String(), "Allocated landed cost LC-00001 (lc-1): inventory 8, cost of goods sold 2")
 
-	importFile := writeTempCSV(t, "branch-recurring.csv", "name,contact_id,frequency,start_date,line_description,quantity,unit_price,vat_rate\nBranch Legacy,contact-branch,MONTHLY,2026-05-01,Work,1,100,22\n")
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "import", "--file", importFile, "--json"})
+	err = app.run(context.Background(), []string{"landed-costs", "get", "--id", "lc-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"templates_created": 1`)
-	assert.Contains(t, stdout.String(), `"message": "missing contact"`)
+	assert.Contains(t, stdout.String(), "Landed cost LC-00001 (WEIGHT)")
+	assert.Contains(t, stdout.String(), "Journal entry: journal-1")
+	assert.Contains(t, stdout.String(), "5 -> 7")
+}
+
+func TestCLILandedCostValidation(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:  "https://placeholder.example.com",
+		TenantID: "tenant-1",
+		APIToken: "oa_saved_token",
+	}))
+
+	app, _, _ := newTestCLIApp()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: []string{"landed-costs"}, want: "landed-costs subcommand required"},
+		{name: "unknown subcommand", args: []string{"landed-costs", "reverse"}, want: `unknown landed-costs subcommand "reverse"`},
+		{name: "list bad date", args: []string{"landed-costs", "list", "--from", "01.03.2026"}, want: "from"},
+		{name: "allocate without invoice", args: []string{"landed-costs", "allocate", "--receipt-id", "grn-1"}, want: "invoice-id is required"},
+		{name: "allocate without payable", args: []string{"landed-costs", "allocate", "--invoice-id", "inv-1"}, want: "payable-account-id is required"},
+		{name: "allocate without targets", args: []string{"landed-costs", "allocate", "--invoice-id", "inv-1", "--payable-account-id", "payables"}, want: "at least one receipt-id or line is required"},
+		{name: "line without target", args: []string{"landed-costs", "allocate", "--line", "product_id=prod-1,weight=1"}, want: "line receipt_line_id or product_id with lot is required"},
+		{name: "line negative weight", args: []string{"landed-costs", "allocate", "--line", "receipt_line_id=grn-line-1,weight=-1"}, want: "line weight"},
+		{name: "get without id", args: []string{"landed-costs", "get"}, want: "id is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestCLIPricingCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	groupID := "group-1"
+	validFrom := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	priceListPayload := pricing.PriceList{
+		ID:        "pl-1",
+		Code:      "RETAIL",
+		Name:      "Retail 2026",
+		Currency:  "EUR",
+		ValidFrom: &validFrom,
+		IsDefault: true,
+		IsActive:  true,
+		Items: []pricing.PriceListItem{
+			{ProductID: "prod-1", UnitPrice: decimal.NewFromInt(18)},
+			{ProductID: "prod-1", MinQuantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(16)},
+		},
+	}
+	groupPayload := pricing.CustomerGroup{ID: groupID, Code: "DEALERS", Name: "Dealers", DiscountPercent: decimal.NewFromInt(5)}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/price-lists":
+			require.Equal(t, "EUR", r.URL.Query().Get("currency"))
+			require.Equal(t, "true", r.URL.Query().Get("active_only"))
+			_ = json.NewEncoder(w).Encode([]pricing.PriceList{priceListPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/price-lists":
+			var req pricing.CreatePriceListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "RETAIL", req.Code)
+			assert.True(t, req.IsDefault)
+			require.NotNil(t, req.ValidFrom)
+			require.Len(t, req.Items, 2)
+			assert.True(t, req.Items[1].MinQuantity.Equal(decimal.NewFromInt(10)))
+			assert.True(t, req.Items[1].UnitPrice.Equal(decimal.NewFromInt(16)))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(priceListPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/price-lists/pl-1":
+			_ = json.NewEncoder(w).Encode(priceListPayload)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/price-lists/pl-1":
+			var req pricing.UpdatePriceListRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Retail 2026", req.Name)
+			require.NotNil(t, req.IsActive)
+			assert.False(t, *req.IsActive)
+			require.NotNil(t, req.ValidTo)
+			assert.Len(t, req.Items, 2)
+			_ = json.NewEncoder(w).Encode(priceListPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/prices/resolve":
+			require.Equal(t, "prod-1", r.URL.Query().Get("product_id"))
+			require.Equal(t, "contact-1", r.URL.Query().Get("contact_id"))
+			require.Equal(t, "12", r.URL.Query().Get("quantity"))
+			_ = json.NewEncoder(w).Encode(pricing.ResolvedPrice{
+				ProductID:       "prod-1",
+				ProductName:     "Widget",
+				Currency:        "EUR",
+				Quantity:        decimal.NewFromInt(12),
+				UnitPrice:       decimal.NewFromInt(16),
+				Source:          pricing.PriceSourceDefaultPriceList,
+				PriceListCode:   "RETAIL",
+				MinQuantity:     decimal.NewFromInt(10),
+				DiscountPercent: decimal.NewFromInt(5),
+				DiscountSource:  pricing.DiscountSourceGroup,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/customer-groups":
+			_ = json.NewEncoder(w).Encode([]pricing.CustomerGroup{groupPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/customer-groups":
+			var req pricing.CustomerGroupRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "DEALERS", req.Code)
+			assert.True(t, req.DiscountPercent.Equal(decimal.NewFromInt(5)))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(groupPayload)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/customer-groups/group-1":
+			var req pricing.CustomerGroupRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.True(t, req.DiscountPercent.Equal(decimal.RequireFromString("7.5")))
+			_ = json.NewEncoder(w).Encode(groupPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/contacts/contact-1/pricing":
+			_ = json.NewEncoder(w).Encode(pricing.CustomerPricing{ContactID: "contact-1", DiscountPercent: decimal.NewFromInt(3)})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/contacts/contact-1/pricing":
+			var req pricing.SetCustomerPricingRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.NotNil(t, req.CustomerGroupID)
+			assert.Equal(t, groupID, *req.CustomerGroupID)
+			_ = json.NewEncoder(w).Encode(pricing.CustomerPricing{ContactID: "contact-1", CustomerGroupID: &groupID})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{"price-lists", "list", "--currency", "eur", "--active-only"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "RETAIL")
+	assert.Contains(t, stdout.String(), "2026-01-01")
 
 	stdout.Reset()
 	err = app.run(context.Background(), []string{
-		"recurring-invoices", "from-invoice",
-		"--invoice-id", " inv-branch ",
-		"--name", " From branch ",
-		"--frequency", " quarterly ",
-		"--start-date", "2026-06-01",
-		"--end-date", "2026-12-31",
-		"--payment-terms-days", "45",
-		"--json",
+		"price-lists", "create",
+		"--code", "RETAIL",
+		"--name", "Retail 2026",
+		"--valid-from", "2026-01-01",
+		"--default",
+		"--item", "product_id=prod-1,unit_price=18",
+		"--item", "product_id=prod-1,min_quantity=10,unit_price=16",
 	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"id": "rec-from-branch"`)
+	assert.Contains(t, stdout.String(), "Created price list RETAIL (pl-1) with 2 items")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "get", "--id", " rec-branch ", "--json"})
+	err = app.run(context.Background(), []string{"price-lists", "get", "--id", "pl-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"id": "rec-branch"`)
+	assert.Contains(t, stdout.String(), "Valid: 2026-01-01 - -")
+	assert.Contains(t, stdout.String(), "prod-1")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"recurring-invoices", "update",
-		"--id", " rec-branch ",
-		"--name", " Updated branch ",
-		"--contact-id", " contact-updated ",
-		"--frequency", " biweekly ",
-		"--end-date", "2026-11-30",
-		"--payment-terms-days", "7",
-		"--reference", " UPDATED ",
-		"--notes", " Updated notes ",
-		"--send-email", "true",
-		"--email-template-type", " FOLLOW_UP ",
-		"--recipient-email", " updated@example.com ",
-		"--attach-pdf", "true",
-		"--email-subject", " Updated subject ",
-		"--email-message", " Updated message ",
-		"--line", "description=Updated branch work,quantity=2,unit_price=150,vat_rate=22",
-		"--json",
-	})
+	err = app.run(context.Background(), []string{"price-lists", "update", "--id", "pl-1", "--valid-to", "2026-12-31", "--active", "false"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"frequency": "BIWEEKLY"`)
+	assert.Contains(t, stdout.String(), "Price list RETAIL (Retail 2026)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "pause", "--id", " rec-branch ", "--json"})
+	err = app.run(context.Background(), []string{"price-lists", "resolve", "--product-id", "prod-1", "--contact-id", "contact-1", "--quantity", "12"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "paused"`)
+	assert.Contains(t, stdout.String(), "Unit price: 16 EUR")
+	assert.Contains(t, stdout.String(), "Source: DEFAULT_PRICE_LIST RETAIL (from quantity 10)")
+	assert.Contains(t, stdout.String(), "Discount: 5% (CUSTOMER_GROUP)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "generate", "--id", " rec-branch ", "--json"})
+	err = app.run(context.Background(), []string{"customer-groups", "list"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"recurring_invoice_id": "rec-branch"`)
+	assert.Contains(t, stdout.String(), "DEALERS")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "generate-due", "--json"})
+	err = app.run(context.Background(), []string{"customer-groups", "create", "--code", "DEALERS", "--name", "Dealers", "--discount-percent", "5"})
 	require.NoError(t, err)
-	assert.Equal(t, "[]\n", stdout.String())
+	assert.Contains(t, stdout.String(), "Saved customer group DEALERS (group-1)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"recurring-invoices", "delete", "--id", " rec-branch ", "--json"})
+	err = app.run(context.Background(), []string{"customer-groups", "update", "--id", "group-1", "--code", "DEALERS", "--name", "Dealers", "--discount-percent", "7.5"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "deleted"`)
+	assert.Contains(t, stdout.String(), "Saved customer group DEALERS")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"contacts", "pricing", "--id", "contact-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Discount percent: 3")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"contacts", "set-pricing", "--id", "contact-1", "--customer-group-id", groupID})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Customer group: group-1")
 }
 
-func TestCLIRecurringInvoiceFlagAndAPIErrors(t *testing.T) {
+func TestCLIPricingValidation(t *testing.T) {
 	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:  "https://placeholder.example.com",
+		TenantID: "tenant-1",
+		APIToken: "oa_saved_token",
+	}))
 
-	app, stdout, _ := newTestCLIApp()
-	err := app.run(context.Background(), []string{"recurring-invoices", "list"})
-	require.Error(t, err)
-	assert.ErrorContains(t, err, "no API token configured")
-	assert.Empty(t, stdout.String())
+	app, _, _ := newTestCLIApp()
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing price-lists subcommand", args: []string{"price-lists"}, want: "price-lists subcommand required"},
+		{name: "unknown price-lists subcommand", args: []string{"price-lists", "archive"}, want: `unknown price-lists subcommand "archive"`},
+		{name: "create without code", args: []string{"price-lists", "create", "--name", "Retail"}, want: "code is required"},
+		{name: "create without name", args: []string{"price-lists", "create", "--code", "RETAIL"}, want: "name is required"},
+		{name: "create bad valid from", args: []string{"price-lists", "create", "--code", "RETAIL", "--name", "Retail", "--valid-from", "01.01.2026"}, want: "valid-from"},
+		{name: "item without product", args: []string{"price-lists", "create", "--item", "unit_price=10"}, want: "item product_id is required"},
+		{name: "item without price", args: []string{"price-lists", "create", "--item", "product_id=prod-1"}, want: "item unit_price is required"},
+		{name: "item negative break", args: []string{"price-lists", "create", "--item", "product_id=prod-1,min_quantity=-1,unit_price=10"}, want: "item min_quantity"},
+		{name: "get without id", args: []string{"price-lists", "get"}, want: "id is required"},
+		{name: "update bad active", args: []string{"price-lists", "update", "--id", "pl-1", "--active", "maybe"}, want: "parse active"},
+		{name: "resolve without product", args: []string{"price-lists", "resolve"}, want: "product-id is required"},
+		{name: "resolve bad date", args: []string{"price-lists", "resolve", "--product-id", "prod-1", "--date", "01.03.2026"}, want: "parse date"},
+		{name: "missing customer-groups subcommand", args: []string{"customer-groups"}, want: "customer-groups subcommand required"},
+		{name: "update group without id", args: []string{"customer-groups", "update", "--code", "DEALERS", "--name", "Dealers"}, want: "id is required"},
+		{name: "group negative discount", args: []string{"customer-groups", "create", "--code", "DEALERS", "--name", "Dealers", "--discount-percent", "-1"}, want: "discount-percent must be non-negative"},
+		{name: "contact pricing without id", args: []string{"contacts", "pricing"}, want: "id is required"},
+		{name: "set contact pricing without id", args: []string{"contacts", "set-pricing"}, want: "id is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestSalesLineFlagsAllowProductPriceResolution(t *testing.T) {
+	quoteLines := quoteLineFlags{}
+	require.NoError(t, quoteLines.Set("product_id=prod-1,quantity=12,vat_rate=22"))
+	require.Len(t, quoteLines, 1)
+	assert.Empty(t, quoteLines[0].Description)
+	assert.Nil(t, quoteLines[0].UnitPrice)
+	require.NotNil(t, quoteLines[0].ProductID)
+	require.NoError(t, quoteLines.Set("product_id=prod-1,quantity=1,vat_rate=22,unit_price=0"))
+	require.NotNil(t, quoteLines[1].UnitPrice)
+	assert.True(t, quoteLines[1].UnitPrice.IsZero())
 
+	orderLines := orderLineFlags{}
+	require.NoError(t, orderLines.Set("product=prod-1,qty=2,vat=22,description=Custom"))
+	assert.Equal(t, "Custom", orderLines[0].Description)
+
+	invoiceLines := invoiceLineFlags{}
+	assert.ErrorContains(t, invoiceLines.Set("quantity=1,vat_rate=22,unit_price=10"), "line description is required")
+	assert.ErrorContains(t, invoiceLines.Set("description=Work,quantity=1,vat_rate=22"), "line unit_price is required")
+
+	recurringLines := recurringLineFlags{}
+	assert.ErrorContains(t, recurringLines.Set("product_id=prod-1,quantity=1,vat_rate=22,unit_price=-1"), "line unit_price must be non-negative")
+}
+
+func TestCLIInventoryAgingCommands(t *testing.T) {
+	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
 		TenantID:   "tenant-1",
@@ -10447,105 +10930,138 @@ func TestCLIRecurringInvoiceFlagAndAPIErrors(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	badFlagCases := []struct {
-		name string
-		args []string
-	}{
-		{name: "import bad flag", args: []string{"recurring-invoices", "import", "--bad"}},
-		{name: "from invoice bad flag", args: []string{"recurring-invoices", "from-invoice", "--bad"}},
-		{name: "get bad flag", args: []string{"recurring-invoices", "get", "--bad"}},
-		{name: "update bad flag", args: []string{"recurring-invoices", "update", "--bad"}},
-		{name: "delete bad flag", args: []string{"recurring-invoices", "delete", "--bad"}},
-		{name: "pause bad flag", args: []string{"recurring-invoices", "pause", "--bad"}},
-		{name: "resume bad flag", args: []string{"recurring-invoices", "resume", "--bad"}},
-		{name: "generate bad flag", args: []string{"recurring-invoices", "generate", "--bad"}},
-	}
-	for _, tc := range badFlagCases {
-		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, "flag provided but not defined")
-			assert.Empty(t, stdout.String())
-		})
+	daysToExpiry := -5
+	report := inventory.InventoryAgingReport{
+		AsOfDate:                  time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+		SlowMovingDays:            120,
+		ExpiringWithinDays:        90,
+		SellingCostPercent:        decimal.NewFromInt(10),
+		SlowMovingDiscountPercent: decimal.NewFromInt(30),
+		Lines: []inventory.InventoryAgingLine{{
+			ProductCode:        "MILK",
+			ProductName:        "Milk",
+			WarehouseCode:      "MAIN",
+			LotNumber:          "LOT-1",
+			ExpiryDate:         "2026-03-26",
+			AgeDays:            30,
+			DaysToExpiry:       &daysToExpiry,
+			Quantity:           decimal.NewFromInt(4),
+			InventoryValue:     decimal.NewFromInt(10),
+			NetRealisableValue: decimal.Zero,
+			WriteDownAmount:    decimal.NewFromInt(10),
+			Status:             inventory.InventoryAgingStatusExpired,
+		}},
+		Groups: []inventory.InventoryAgingGroup{{
+			WarehouseCode:   "MAIN",
+			CategoryName:    "Dairy",
+			InventoryValue:  decimal.NewFromInt(10),
+			AgeBuckets:      []inventory.InventoryAgingBucket{{Bucket: "0-30", Value: decimal.NewFromInt(10)}},
+			ExpiredValue:    decimal.NewFromInt(10),
+			WriteDownAmount: decimal.NewFromInt(10),
+		}},
+		TotalValue:      decimal.NewFromInt(10),
+		WriteDownAmount: decimal.NewFromInt(10),
 	}
 
-	importFile := writeTempCSV(t, "recurring-error.csv", "name,contact_id,frequency,start_date,line_description,quantity,unit_price,vat_rate\nError Legacy,contact-error,MONTHLY,2026-07-01,Work,1,100,22\n")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
-			assert.Equal(t, "true", r.URL.Query().Get("active_only"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
-			var req recurring.CreateRecurringInvoiceRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Error retainer", req.Name)
-			assert.Equal(t, "contact-error", req.ContactID)
-			assert.Equal(t, recurring.FrequencyMonthly, req.Frequency)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/import":
-			var req recurring.ImportRecurringInvoicesRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "recurring-error.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "Error Legacy")
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/from-invoice/inv-error":
-			var req recurring.CreateFromInvoiceRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "From error", req.Name)
-			assert.Equal(t, recurring.FrequencyMonthly, req.Frequency)
-			assert.Equal(t, "2026-07-01", req.StartDate.Format("2006-01-02"))
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error":
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error":
-			var req recurring.UpdateRecurringInvoiceRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			require.NotNil(t, req.Name)
-			assert.Equal(t, "Error updated", *req.Name)
-			require.NotNil(t, req.PaymentTermsDays)
-			assert.Equal(t, 10, *req.PaymentTermsDays)
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error/pause":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error/resume":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error/generate":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/generate-due":
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/aging":
+			assert.Equal(t, "2026-03-31", r.URL.Query().Get("as_of_date"))
+			assert.Equal(t, "wh-1", r.URL.Query().Get("warehouse_id"))
+			assert.Equal(t, "120", r.URL.Query().Get("slow_moving_days"))
+			assert.Equal(t, "10", r.URL.Query().Get("selling_cost_percent"))
+			assert.Equal(t, "30", r.URL.Query().Get("slow_moving_discount_percent"))
+			_ = json.NewEncoder(w).Encode(report)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/aging/write-down":
+			var req inventory.ProposeInventoryWriteDownRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "expense", req.WriteDownAccountID)
+			assert.Equal(t, "allowance", req.AllowanceAccountID)
+			assert.Equal(t, "cat-1", req.CategoryID)
+			assert.Nil(t, req.SlowMovingDiscountPercent)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(inventory.InventoryWriteDownProposal{
+				AsOfDate:          report.AsOfDate,
+				RequiredAllowance: decimal.NewFromInt(10),
+				ExistingAllowance: decimal.NewFromInt(4),
+				Adjustment:        decimal.NewFromInt(6),
+				Lines: []inventory.InventoryIssueAccountingLine{
+					{Role: "WRITE_DOWN_EXPENSE", AccountID: "expense", DebitAmount: decimal.NewFromInt(6)},
+					{Role: "INVENTORY_ALLOWANCE", AccountID: "allowance", CreditAmount: decimal.NewFromInt(6)},
+				},
+				JournalID:     "journal-1",
+				JournalNo:     "JE-00007",
+				JournalStatus: "DRAFT",
+			})
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
-
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "recurring backend unavailable"})
 	}))
 	defer server.Close()
 
 	t.Setenv("OA_BASE_URL", server.URL)
-	apiErrorCases := []struct {
+
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{
+		"inventory", "aging",
+		"--as-of", "2026-03-31",
+		"--warehouse-id", "wh-1",
+		"--slow-moving-days", "120",
+		"--selling-cost-percent", "10",
+		"--slow-moving-discount-percent", "30",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Inventory aging as of 2026-03-31")
+	assert.Contains(t, stdout.String(), "Dairy")
+	assert.Contains(t, stdout.String(), "EXPIRED")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"inventory", "write-down",
+		"--category-id", "cat-1",
+		"--write-down-account-id", "expense",
+		"--allowance-account-id", "allowance",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Adjustment: 6")
+	assert.Contains(t, stdout.String(), "Journal entry: JE-00007 (DRAFT)")
+	assert.Contains(t, stdout.String(), "INVENTORY_ALLOWANCE")
+}
+
+func TestCLIInventoryAgingValidation(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:  "https://placeholder.example.com",
+		TenantID: "tenant-1",
+		APIToken: "oa_saved_token",
+	}))
+
+	app, _, _ := newTestCLIApp()
+	tests := []struct {
 		name string
 		args []string
+		want string
 	}{
-		{name: "list api error", args: []string{"recurring-invoices", "list", "--active-only"}},
-		{name: "create api error", args: []string{"recurring-invoices", "create", "--name", "Error retainer", "--contact-id", "contact-error", "--frequency", "monthly", "--start-date", "2026-07-01", "--line", "description=Error work,quantity=1,unit_price=100,vat_rate=22"}},
-		{name: "import api error", args: []string{"recurring-invoices", "import", "--file", importFile}},
-		{name: "from invoice api error", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-error", "--name", "From error", "--frequency", "monthly", "--start-date", "2026-07-01"}},
-		{name: "get api error", args: []string{"recurring-invoices", "get", "--id", "rec-error"}},
-		{name: "update api error", args: []string{"recurring-invoices", "update", "--id", "rec-error", "--name", "Error updated", "--payment-terms-days", "10"}},
-		{name: "delete api error", args: []string{"recurring-invoices", "delete", "--id", "rec-error"}},
-		{name: "pause api error", args: []string{"recurring-invoices", "pause", "--id", "rec-error"}},
-		{name: "resume api error", args: []string{"recurring-invoices", "resume", "--id", "rec-error"}},
-		{name: "generate api error", args: []string{"recurring-invoices", "generate", "--id", "rec-error"}},
-		{name: "generate due api error", args: []string{"recurring-invoices", "generate-due"}},
+		{name: "negative slow-moving days", args: []string{"inventory", "aging", "--slow-moving-days", "-1"}, want: "slow-moving-days must not be negative"},
+		{name: "bad date", args: []string{"inventory", "aging", "--as-of", "31.03.2026"}, want: "as-of"},
+		{name: "bad selling cost", args: []string{"inventory", "aging", "--selling-cost-percent", "ten"}, want: "selling-cost-percent"},
+		{name: "write-down without expense account", args: []string{"inventory", "write-down", "--allowance-account-id", "allowance"}, want: "write-down-account-id is required"},
+		{name: "write-down without allowance account", args: []string{"inventory", "write-down", "--write-down-account-id", "expense"}, want: "allowance-account-id is required"},
 	}
-	for _, tc := range apiErrorCases {
-		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
 			require.Error(t, err)
-			assert.ErrorContains(t, err, "recurring backend unavailable")
-			assert.Empty(t, stdout.String())
+			assert.ErrorContains(t, err, tt.want)
 		})
 	}
 }
 
-func TestCLIAssetCommands(t *testing.T) {
+func TestCLIInventoryReplenishmentCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -10555,268 +11071,289 @@ func TestCLIAssetCommands(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	categoryPayload := map[string]any{
-		"id":                                  "cat-1",
-		"tenant_id":                           "tenant-1",
-		"name":                                "Equipment",
-		"description":                         "Office equipment",
-		"depreciation_method":                 "STRAIGHT_LINE",
-		"default_useful_life_months":          60,
-		"default_residual_value_percent":      "10.00",
-		"asset_account_id":                    "acc-asset",
-		"depreciation_expense_account_id":     "acc-expense",
-		"accumulated_depreciation_account_id": "acc-accum",
-		"created_at":                          "2026-03-15T12:00:00Z",
-		"updated_at":                          "2026-03-15T12:00:00Z",
-	}
-	assetPayload := func(status string) map[string]any {
-		return map[string]any{
-			"id":                                  "asset-1",
-			"tenant_id":                           "tenant-1",
-			"asset_number":                        "FA-00001",
-			"name":                                "Laptop",
-			"description":                         "Developer laptop",
-			"category_id":                         "cat-1",
-			"status":                              status,
-			"purchase_date":                       "2026-03-15T00:00:00Z",
-			"purchase_cost":                       "1200.00",
-			"supplier_id":                         "supplier-1",
-			"serial_number":                       "SN-1",
-			"location":                            "Tallinn",
-			"depreciation_method":                 "STRAIGHT_LINE",
-			"useful_life_months":                  36,
-			"residual_value":                      "100.00",
-			"depreciation_start_date":             "2026-04-01T00:00:00Z",
-			"accumulated_depreciation":            "50.00",
-			"book_value":                          "1150.00",
-			"last_depreciation_date":              "2026-04-30T00:00:00Z",
-			"asset_account_id":                    "acc-asset",
-			"depreciation_expense_account_id":     "acc-expense",
-			"accumulated_depreciation_account_id": "acc-accum",
-			"created_at":                          "2026-03-15T12:00:00Z",
-			"created_by":                          "user-1",
-			"updated_at":                          "2026-03-15T12:00:00Z",
-		}
-	}
-	depreciationPayload := map[string]any{
-		"id":                  "dep-1",
-		"tenant_id":           "tenant-1",
-		"asset_id":            "asset-1",
-		"depreciation_date":   "2026-04-30T00:00:00Z",
-		"period_start":        "2026-04-01T00:00:00Z",
-		"period_end":          "2026-04-30T00:00:00Z",
-		"depreciation_amount": "25.00",
-		"accumulated_total":   "75.00",
-		"book_value_after":    "1125.00",
-		"created_at":          "2026-04-30T12:00:00Z",
-		"created_by":          "user-1",
+	line := purchasing.ReplenishmentLine{
+		ProductID:         "prod-1",
+		ProductCode:       "PRD-001",
+		ProductName:       "Widget",
+		SupplierID:        "supplier-1",
+		WarehouseID:       "wh-1",
+		Available:         decimal.NewFromInt(2),
+		Incoming:          decimal.NewFromInt(3),
+		DailyUsage:        decimal.NewFromInt(1),
+		ReorderLevel:      decimal.NewFromInt(12),
+		SuggestedQuantity: decimal.NewFromInt(37),
+		UnitPrice:         decimal.RequireFromString("4.50"),
+		EstimatedCost:     decimal.RequireFromString("166.50"),
+		BelowMinimum:      true,
+	}
+	reportPayload := purchasing.ReplenishmentReport{
+		AsOfDate:      time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC),
+		VelocityDays:  60,
+		CoverageDays:  14,
+		LineCount:     1,
+		EstimatedCost: line.EstimatedCost,
+		Proposals: []purchasing.ReplenishmentProposal{{
+			SupplierID:    "supplier-1",
+			WarehouseID:   "wh-1",
+			WarehouseCode: "MAIN",
+			LeadTimeDays:  7,
+			Lines:         []purchasing.ReplenishmentLine{line},
+			EstimatedCost: line.EstimatedCost,
+		}},
 	}
-	importFile := writeTempCSV(t, "assets.csv", "asset_number,name,purchase_date,purchase_cost\nLEG-001,Laptop,2025-01-10,1200.00\n")
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
-			_ = json.NewEncoder(w).Encode([]map[string]any{categoryPayload})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
-			var req assets.CreateCategoryRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Equipment", req.Name)
-			assert.Equal(t, assets.DepreciationStraightLine, req.DepreciationMethod)
-			assert.Equal(t, 60, req.DefaultUsefulLifeMonths)
-			assert.True(t, req.DefaultResidualValuePercent.Equal(decimal.RequireFromString("10.00")))
-			require.NotNil(t, req.AssetAccountID)
-			assert.Equal(t, "acc-asset", *req.AssetAccountID)
-			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(categoryPayload)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-1":
-			_ = json.NewEncoder(w).Encode(categoryPayload)
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-1":
-			w.WriteHeader(http.StatusNoContent)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
-			require.Equal(t, "ACTIVE", r.URL.Query().Get("status"))
-			require.Equal(t, "cat-1", r.URL.Query().Get("category_id"))
-			require.Equal(t, "Laptop", r.URL.Query().Get("search"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{assetPayload("ACTIVE")})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
-			var req assets.CreateAssetRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/replenishment":
+			require.Equal(t, "wh-1", r.URL.Query().Get("warehouse_id"))
+			require.Equal(t, "supplier-1", r.URL.Query().Get("supplier_id"))
+			require.Equal(t, "2026-03-31", r.URL.Query().Get("as_of_date"))
+			require.Equal(t, "60", r.URL.Query().Get("velocity_days"))
+			require.Equal(t, "14", r.URL.Query().Get("coverage_days"))
+			_ = json.NewEncoder(w).Encode(reportPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/replenishment/purchase-orders":
+			var req purchasing.CreateReplenishmentOrdersRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Laptop", req.Name)
-			assert.Equal(t, "2026-03-15", req.PurchaseDate.Format("2006-01-02"))
-			assert.True(t, req.PurchaseCost.Equal(decimal.RequireFromString("1200.00")))
-			assert.Equal(t, assets.DepreciationStraightLine, req.DepreciationMethod)
-			assert.Equal(t, 36, req.UsefulLifeMonths)
-			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("100.00")))
-			require.NotNil(t, req.DepreciationStartDate)
-			assert.Equal(t, "2026-04-01", req.DepreciationStartDate.Format("2006-01-02"))
-			require.NotNil(t, req.CategoryID)
-			require.NotNil(t, req.SupplierID)
-			assert.Equal(t, "cat-1", *req.CategoryID)
-			assert.Equal(t, "supplier-1", *req.SupplierID)
+			assert.Equal(t, "supplier-1", req.SupplierID)
+			assert.Equal(t, "2026-04-01", req.OrderDate.Format("2006-01-02"))
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(assetPayload("DRAFT"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/import":
-			var req assets.ImportAssetsRequest
+			_ = json.NewEncoder(w).Encode(purchasing.ReplenishmentOrdersResult{
+				PurchaseOrders: []purchasing.PurchaseOrder{{
+					ID:        "po-1",
+					PONumber:  "PO-00007",
+					ContactID: "supplier-1",
+					Status:    purchasing.PurchaseOrderStatusDraft,
+					OrderDate: time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC),
+					Currency:  "EUR",
+					Total:     decimal.RequireFromString("203.13"),
+				}},
+				UnassignedLines: []purchasing.ReplenishmentLine{{ProductCode: "PRD-002", ProductName: "Gadget", SuggestedQuantity: decimal.NewFromInt(2)}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/replenishment/low-stock-events":
+			var req purchasing.ReplenishmentRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "assets.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "LEG-001")
+			assert.Equal(t, "wh-1", req.WarehouseID)
 			_ = json.NewEncoder(w).Encode(map[string]any{
-				"rows_processed": 1,
-				"assets_created": 1,
-				"rows_skipped":   0,
+				"as_of_date": "2026-03-31T00:00:00Z",
+				"lines":      []purchasing.ReplenishmentLine{line},
+				"event": map[string]any{
+					"event":      map[string]any{"id": "evt-1", "type": "inventory.low_stock"},
+					"deliveries": []map[string]any{{"id": "delivery-1", "status": "SUCCEEDED", "status_code": 200}},
+				},
 			})
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1":
-			_ = json.NewEncoder(w).Encode(assetPayload("ACTIVE"))
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1":
-			var req assets.UpdateAssetRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Updated laptop", req.Name)
-			assert.Equal(t, "Tartu", req.Location)
-			assert.Equal(t, 48, req.UsefulLifeMonths)
-			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("150.00")))
-			_ = json.NewEncoder(w).Encode(assetPayload("ACTIVE"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/activate":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "active"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/dispose":
-			var req assets.DisposeAssetRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "2026-05-01", req.DisposalDate.Format("2006-01-02"))
-			assert.Equal(t, assets.DisposalSold, req.DisposalMethod)
-			assert.True(t, req.DisposalProceeds.Equal(decimal.RequireFromString("900.00")))
-			assert.Equal(t, "Sold to employee", req.DisposalNotes)
-			require.NotNil(t, req.DisposalProceedsAccountID)
-			assert.Equal(t, "cash-account", *req.DisposalProceedsAccountID)
-			require.NotNil(t, req.DisposalGainLossAccountID)
-			assert.Equal(t, "asset-disposal-gain", *req.DisposalGainLossAccountID)
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "disposed"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/depreciation":
-			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(depreciationPayload)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/depreciation":
-			_ = json.NewEncoder(w).Encode([]map[string]any{depreciationPayload})
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1":
-			w.WriteHeader(http.StatusNoContent)
 		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
 		}
 	}))
 	defer server.Close()
-
 	t.Setenv("OA_BASE_URL", server.URL)
 
 	app, stdout, _ := newTestCLIApp()
-
-	err := app.run(context.Background(), []string{"assets", "categories", "list", "--json"})
+	err := app.run(context.Background(), []string{"inventory", "replenishment", "--warehouse-id", "wh-1", "--supplier-id", "supplier-1", "--as-of", "2026-03-31", "--velocity-days", "60", "--coverage-days", "14"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"name": "Equipment"`)
+	assert.Contains(t, stdout.String(), "Replenishment as of 2026-03-31")
+	assert.Contains(t, stdout.String(), "Supplier supplier-1 -> MAIN (lead time 7 days, estimated 166.5)")
+	assert.Contains(t, stdout.String(), "PRD-001 Widget")
+	assert.Contains(t, stdout.String(), "37")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"assets", "categories", "create",
-		"--name", "Equipment",
-		"--description", "Office equipment",
-		"--depreciation-method", "straight_line",
-		"--useful-life-months", "60",
-		"--residual-percent", "10.00",
-		"--asset-account-id", "acc-asset",
-	})
+	err = app.run(context.Background(), []string{"inventory", "replenishment", "--warehouse-id", "wh-1", "--supplier-id", "supplier-1", "--as-of", "2026-03-31", "--velocity-days", "60", "--coverage-days", "14", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Created asset category Equipment (cat-1)")
+	assert.Contains(t, stdout.String(), `"suggested_quantity": "37"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "categories", "get", "--id", "cat-1"})
+	err = app.run(context.Background(), []string{"inventory", "replenishment-orders", "--supplier-id", "supplier-1", "--order-date", "2026-04-01"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Asset category Equipment")
+	assert.Contains(t, stdout.String(), "Created 1 draft purchase orders")
+	assert.Contains(t, stdout.String(), "PO-00007")
+	assert.Contains(t, stdout.String(), "Products without a supplier:")
+	assert.Contains(t, stdout.String(), "PRD-002 Gadget")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "categories", "delete", "--id", "cat-1"})
+	err = app.run(context.Background(), []string{"inventory", "low-stock-event", "--warehouse-id", "wh-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Deleted asset category cat-1")
+	assert.Contains(t, stdout.String(), "Products below minimum stock as of 2026-03-31: 1")
+	assert.Contains(t, stdout.String(), "Webhook event inventory.low_stock (evt-1)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "list", "--status", "active", "--category-id", "cat-1", "--search", "Laptop", "--json"})
+	err = app.run(context.Background(), []string{"inventory", "low-stock-event", "--warehouse-id", "wh-1", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"asset_number": "FA-00001"`)
+	assert.Contains(t, stdout.String(), `"below_minimum": true`)
+}
+
+func TestCLIInventoryStockCountCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	counted := decimal.NewFromInt(8)
+	countPayload := stocktake.StockCount{
+		ID:              "count-1",
+		CountNumber:     "SC-00003",
+		WarehouseID:     "wh-1",
+		CountDate:       time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC),
+		Status:          stocktake.StockCountStatusOpen,
+		ValuationMethod: "FIFO",
+		Lines: []stocktake.StockCountLine{
+			{ID: "line-1", LineNumber: 1, ProductCode: "PRD-001", Barcode: "4740001", ExpectedQuantity: decimal.NewFromInt(10), CountedQuantity: &counted, UnitCost: decimal.NewFromInt(4)},
+			{ID: "line-2", LineNumber: 2, ProductCode: "PRD-002", LotNumber: "LOT-A", ExpectedQuantity: decimal.NewFromInt(3), UnitCost: decimal.NewFromInt(2)},
+		},
+	}
+	variancePayload := stocktake.StockCountVariance{
+		StockCountID:    "count-1",
+		CountNumber:     "SC-00003",
+		CountDate:       countPayload.CountDate,
+		Status:          stocktake.StockCountStatusSubmitted,
+		ValuationMethod: "FIFO",
+		CountedLines:    1,
+		UncountedLines:  1,
+		ShortageValue:   decimal.NewFromInt(8),
+		NetVariance:     decimal.NewFromInt(-8),
+		Lines: []stocktake.StockCountVarianceLine{{
+			LineID: "line-1", LineNumber: 1, ProductCode: "PRD-001", ExpectedQuantity: decimal.NewFromInt(10), CountedQuantity: &counted,
+			VarianceQuantity: decimal.NewFromInt(-2), UnitCost: decimal.NewFromInt(4), VarianceValue: decimal.NewFromInt(-8),
+		}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts":
+			require.Equal(t, "OPEN", r.URL.Query().Get("status"))
+			require.Equal(t, "wh-1", r.URL.Query().Get("warehouse_id"))
+			_ = json.NewEncoder(w).Encode([]stocktake.StockCount{countPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts":
+			var req stocktake.CreateStockCountRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "wh-1", req.WarehouseID)
+			assert.Equal(t, "2026-12-31", req.CountDate.Format("2006-01-02"))
+			assert.Equal(t, "fifo", req.ValuationMethod)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(countPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-1":
+			_ = json.NewEncoder(w).Encode(countPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-1/counts":
+			var req stocktake.RecordCountsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Len(t, req.Counts, 2)
+			assert.Equal(t, "4740001", req.Counts[0].Barcode)
+			assert.True(t, req.Counts[0].Quantity.Equal(decimal.NewFromInt(8)))
+			assert.Equal(t, "LOT-A", req.Counts[1].LotNumber)
+			assert.True(t, req.Accumulate)
+			_ = json.NewEncoder(w).Encode(countPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-1/counts/import":
+			var req stocktake.ImportCountsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "scan.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "4740001;8")
+			_ = json.NewEncoder(w).Encode(stocktake.ImportCountsResult{
+				FileName:      "scan.csv",
+				RowsProcessed: 2,
+				RowsImported:  1,
+				RowsSkipped:   1,
+				Errors:        []stocktake.ImportCountRowError{{Row: 3, Barcode: "999", Message: "no product has barcode 999"}},
+				StockCount:    &countPayload,
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-1/submit":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "SUBMITTED"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-2/cancel":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "CANCELED"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-1/approve":
+			var req stocktake.ApproveStockCountRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "variance", req.VarianceAccountID)
+			assert.True(t, req.ZeroUncounted)
+			journalID := "journal-1"
+			approved := countPayload
+			approved.Status = stocktake.StockCountStatusApproved
+			approved.JournalEntryID = &journalID
+			_ = json.NewEncoder(w).Encode(approved)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/stock-counts/count-1/variance":
+			if r.URL.Query().Get("format") == "csv" {
+				w.Header().Set("Content-Type", "text/csv")
+				_, _ = w.Write([]byte("line_number,product_code\n1,PRD-001\n"))
+				return
+			}
+			_ = json.NewEncoder(w).Encode(variancePayload)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer server.Close()
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+	err := app.run(context.Background(), []string{"inventory", "stock-counts", "list", "--status", "open", "--warehouse-id", "wh-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "SC-00003")
+	assert.Contains(t, stdout.String(), "FIFO")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"assets", "create",
-		"--name", "Laptop",
-		"--description", "Developer laptop",
-		"--category-id", "cat-1",
-		"--purchase-date", "2026-03-15",
-		"--purchase-cost", "1200.00",
-		"--supplier-id", "supplier-1",
-		"--serial-number", "SN-1",
-		"--location", "Tallinn",
-		"--depreciation-method", "straight_line",
-		"--useful-life-months", "36",
-		"--residual-value", "100.00",
-		"--depreciation-start-date", "2026-04-01",
-	})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "create", "--warehouse-id", "wh-1", "--count-date", "2026-12-31", "--method", "fifo"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Created asset FA-00001 (asset-1)")
+	assert.Contains(t, stdout.String(), "Created stock count SC-00003 (count-1) with 2 expected lines")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "import", "--file", importFile})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "get", "--id", "count-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 assets, skipped 0 rows")
+	assert.Contains(t, stdout.String(), "Stock count SC-00003 (OPEN)")
+	assert.Contains(t, stdout.String(), "Uncounted lines: 1 of 2")
+	assert.Contains(t, stdout.String(), "LOT-A")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "get", "--id", "asset-1"})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "count", "--id", "count-1", "--accumulate", "--entry", "barcode=4740001,qty=8", "--entry", "line-id=line-2,lot=LOT-A,quantity=3"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Asset FA-00001 Laptop")
+	assert.Contains(t, stdout.String(), "Recorded 2 counts on stock count SC-00003, 1 lines uncounted")
 
+	csvPath := filepath.Join(t.TempDir(), "scan.csv")
+	require.NoError(t, os.WriteFile(csvPath, []byte("barcode;qty\n4740001;8\n999;1\n"), 0o600))
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"assets", "update",
-		"--id", "asset-1",
-		"--name", "Updated laptop",
-		"--location", "Tartu",
-		"--useful-life-months", "48",
-		"--residual-value", "150.00",
-	})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "import", "--id", "count-1", "--file", csvPath})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Asset FA-00001")
+	assert.Contains(t, stdout.String(), "Processed 2 rows, imported 1 counts, skipped 1 rows")
+	assert.Contains(t, stdout.String(), "Row 3 (999): no product has barcode 999")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "activate", "--id", "asset-1"})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "submit", "--id", "count-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Activated asset asset-1")
+	assert.Contains(t, stdout.String(), "Stock count count-1 is SUBMITTED")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"assets", "dispose",
-		"--id", "asset-1",
-		"--disposal-date", "2026-05-01",
-		"--method", "sold",
-		"--proceeds", "900.00",
-		"--proceeds-account-id", "cash-account",
-		"--gain-loss-account-id", "asset-disposal-gain",
-		"--notes", "Sold to employee",
-	})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "variance", "--id", "count-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Disposed asset asset-1")
+	assert.Contains(t, stdout.String(), "Stock count variance SC-00003 (SUBMITTED)")
+	assert.Contains(t, stdout.String(), "Net variance: -8")
 
+	outputPath := filepath.Join(t.TempDir(), "variance.csv")
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "depreciate", "--id", "asset-1"})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "variance", "--id", "count-1", "--csv", "--output", outputPath})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Recorded depreciation 25")
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1,PRD-001")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "depreciation", "--id", "asset-1"})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "approve", "--id", "count-1", "--variance-account-id", "variance", "--zero-uncounted"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "dep-1")
+	assert.Contains(t, stdout.String(), "Approved stock count SC-00003, variance journal journal-1")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "delete", "--id", "asset-1"})
+	err = app.run(context.Background(), []string{"inventory", "stock-counts", "cancel", "--id", "count-2", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Deleted asset asset-1")
+	assert.Contains(t, stdout.String(), `"status": "CANCELED"`)
 }
 
-func TestCLIAssetBranches(t *testing.T) {
+func TestCLIAssemblyCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -10826,327 +11363,204 @@ func TestCLIAssetBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	app, stdout, _ := newTestCLIApp()
-	ctx := context.Background()
-
-	for _, tt := range []struct {
-		name string
-		args []string
-		want string
-	}{
-		{name: "missing subcommand", args: nil, want: "assets subcommand required"},
-		{name: "unknown subcommand", args: []string{"archive"}, want: `unknown assets subcommand "archive"`},
-		{name: "list bad flag", args: []string{"list", "--unknown"}, want: "flag provided but not defined"},
-		{name: "list invalid status", args: []string{"list", "--status", "retired"}, want: `invalid asset status "retired"`},
-		{name: "create bad flag", args: []string{"create", "--unknown"}, want: "flag provided but not defined"},
-		{name: "create missing name", args: []string{"create"}, want: "name is required"},
-		{name: "create missing purchase date", args: []string{"create", "--name", "Laptop"}, want: "purchase-date is required"},
-		{name: "create invalid purchase date", args: []string{"create", "--name", "Laptop", "--purchase-date", "tomorrow"}, want: "parse purchase-date"},
-		{name: "create missing purchase cost", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15"}, want: "purchase-cost is required"},
-		{name: "create invalid purchase cost", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "many"}, want: "parse purchase-cost"},
-		{name: "create nonpositive purchase cost", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "0"}, want: "purchase-cost must be positive"},
-		{name: "create invalid depreciation method", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--depreciation-method", "accelerated"}, want: `invalid depreciation method "accelerated"`},
-		{name: "create invalid useful life", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--useful-life-months", "many"}, want: "parse useful-life-months"},
-		{name: "create nonpositive useful life", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--useful-life-months", "0"}, want: "useful-life-months must be positive"},
-		{name: "create invalid residual value", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--residual-value", "many"}, want: "parse residual-value"},
-		{name: "create negative residual value", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--residual-value", "-1"}, want: "residual-value must be non-negative"},
-		{name: "create invalid depreciation start date", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--depreciation-start-date", "next-month"}, want: "parse depreciation-start-date"},
-		{name: "import bad flag", args: []string{"import", "--unknown"}, want: "flag provided but not defined"},
-		{name: "import missing file", args: []string{"import"}, want: "file is required"},
-		{name: "import unreadable file", args: []string{"import", "--file", "/tmp/open-accounting-missing-assets.csv"}, want: "read file"},
-		{name: "get bad flag", args: []string{"get", "--unknown"}, want: "flag provided but not defined"},
-		{name: "get missing id", args: []string{"get"}, want: "id is required"},
-		{name: "update bad flag", args: []string{"update", "--unknown"}, want: "flag provided but not defined"},
-		{name: "update missing id", args: []string{"update"}, want: "id is required"},
-		{name: "update missing name", args: []string{"update", "--id", "asset-branch"}, want: "name is required"},
-		{name: "update invalid depreciation method", args: []string{"update", "--id", "asset-branch", "--name", "Laptop", "--depreciation-method", "accelerated"}, want: `invalid depreciation method "accelerated"`},
-		{name: "update invalid useful life", args: []string{"update", "--id", "asset-branch", "--name", "Laptop", "--useful-life-months", "many"}, want: "parse useful-life-months"},
-		{name: "update negative residual value", args: []string{"update", "--id", "asset-branch", "--name", "Laptop", "--residual-value", "-1"}, want: "residual-value must be non-negative"},
-		{name: "delete bad flag", args: []string{"delete", "--unknown"}, want: "flag provided but not defined"},
-		{name: "delete missing id", args: []string{"delete"}, want: "id is required"},
-		{name: "activate bad flag", args: []string{"activate", "--unknown"}, want: "flag provided but not defined"},
-		{name: "activate missing id", args: []string{"activate"}, want: "id is required"},
-		{name: "dispose bad flag", args: []string{"dispose", "--unknown"}, want: "flag provided but not defined"},
-		{name: "dispose missing id", args: []string{"dispose"}, want: "id is required"},
-		{name: "dispose missing date", args: []string{"dispose", "--id", "asset-branch"}, want: "disposal-date is required"},
-		{name: "dispose missing method", args: []string{"dispose", "--id", "asset-branch", "--disposal-date", "2026-05-01"}, want: "method is required"},
-		{name: "dispose invalid method", args: []string{"dispose", "--id", "asset-branch", "--disposal-date", "2026-05-01", "--method", "trashed"}, want: `invalid disposal method "trashed"`},
-		{name: "dispose negative proceeds", args: []string{"dispose", "--id", "asset-branch", "--disposal-date", "2026-05-01", "--method", "lost", "--proceeds", "-1"}, want: "proceeds must be non-negative"},
-		{name: "depreciate bad flag", args: []string{"depreciate", "--unknown"}, want: "flag provided but not defined"},
-		{name: "depreciate missing id", args: []string{"depreciate"}, want: "id is required"},
-		{name: "depreciation bad flag", args: []string{"depreciation", "--unknown"}, want: "flag provided but not defined"},
-		{name: "depreciation missing id", args: []string{"depreciation"}, want: "id is required"},
-	} {
-		t.Run(tt.name, func(t *testing.T) {
-			err := app.runAssets(ctx, tt.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, tt.want)
-		})
-	}
-
-	importFile := writeTempCSV(t, "asset-branches.csv", "asset_number,name,purchase_date,purchase_cost\nBR-001,Branch laptop,2026-03-15,1250.50\n")
-	assetPayload := func(status string) map[string]any {
-		return map[string]any{
-			"id":                                  "asset-branch",
-			"tenant_id":                           "tenant-1",
-			"asset_number":                        "FA-BRANCH",
-			"name":                                "Branch laptop",
-			"description":                         "Branch coverage laptop",
-			"category_id":                         "cat-branch",
-			"status":                              status,
-			"purchase_date":                       "2026-03-15T00:00:00Z",
-			"purchase_cost":                       "1250.50",
-			"supplier_id":                         "supplier-branch",
-			"serial_number":                       "SN-BRANCH",
-			"location":                            "Tallinn",
-			"depreciation_method":                 "UNITS_OF_PRODUCTION",
-			"useful_life_months":                  24,
-			"residual_value":                      "50.25",
-			"depreciation_start_date":             "2026-04-01T00:00:00Z",
-			"accumulated_depreciation":            "100.00",
-			"book_value":                          "1150.50",
-			"asset_account_id":                    "asset-account",
-			"depreciation_expense_account_id":     "depreciation-expense-account",
-			"accumulated_depreciation_account_id": "accumulated-depreciation-account",
-			"created_at":                          "2026-03-15T12:00:00Z",
-			"created_by":                          "user-1",
-			"updated_at":                          "2026-03-15T12:00:00Z",
-		}
-	}
-	depreciationPayload := map[string]any{
-		"id":                  "dep-branch",
-		"tenant_id":           "tenant-1",
-		"asset_id":            "asset-branch",
-		"depreciation_date":   "2026-04-30T00:00:00Z",
-		"period_start":        "2026-04-01T00:00:00Z",
-		"period_end":          "2026-04-30T00:00:00Z",
-		"depreciation_amount": "33.33",
-		"accumulated_total":   "133.33",
-		"book_value_after":    "1117.17",
-		"journal_entry_id":    "journal-branch",
-		"created_at":          "2026-04-30T12:00:00Z",
-		"created_by":          "user-1",
-	}
+	bomPayload := assembly.BillOfMaterials{
+		ID:             "bom-1",
+		ProductID:      "table",
+		BOMType:        assembly.BOMTypeAssembly,
+		OutputQuantity: decimal.NewFromInt(1),
+		LabourCost:     decimal.NewFromInt(3),
+		IsActive:       true,
+		Lines: []assembly.BOMLine{
+			{ID: "bom-line-1", LineNumber: 1, ComponentProductID: "leg", Quantity: decimal.NewFromInt(4)},
+			{ID: "bom-line-2", LineNumber: 2, ComponentProductID: "top", Quantity: decimal.NewFromInt(1), Notes: "Oak"},
+		},
+	}
+	explosionPayload := assembly.BOMExplosion{
+		BOMID:           "bom-1",
+		ProductID:       "table",
+		ProductCode:     "TBL",
+		ProductName:     "Table",
+		BOMType:         assembly.BOMTypeAssembly,
+		Quantity:        decimal.NewFromInt(2),
+		ValuationMethod: "FIFO",
+		Lines: []assembly.BOMExplosionLine{
+			{Level: 1, ProductID: "leg", ProductCode: "LEG", ProductName: "Leg", Quantity: decimal.NewFromInt(8), UnitCost: decimal.NewFromInt(2), TotalCost: decimal.NewFromInt(16)},
+		},
+		MaterialCost: decimal.NewFromInt(16),
+		LabourCost:   decimal.NewFromInt(6),
+		TotalCost:    decimal.NewFromInt(22),
+		UnitCost:     decimal.NewFromInt(11),
+	}
+	orderPayload := assembly.AssemblyOrder{
+		ID:          "order-1",
+		OrderNumber: "AO-00001",
+		OrderType:   assembly.AssemblyOrderTypeAssembly,
+		BOMID:       "bom-1",
+		ProductID:   "table",
+		WarehouseID: "wh-1",
+		Quantity:    decimal.NewFromInt(2),
+		OrderDate:   time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC),
+		Status:      assembly.AssemblyOrderStatusDraft,
+		LabourCost:  decimal.NewFromInt(6),
+		Lines: []assembly.AssemblyOrderLine{
+			{ID: "order-line-1", LineNumber: 1, ProductID: "leg", Quantity: decimal.NewFromInt(8), LotNumber: "LOT-L"},
+		},
+	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
-			require.Empty(t, r.URL.RawQuery)
-			_ = json.NewEncoder(w).Encode([]map[string]any{assetPayload("ACTIVE")})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
-			var req assets.CreateAssetRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/boms":
+			require.Equal(t, "KIT", r.URL.Query().Get("bom_type"))
+			require.Equal(t, "true", r.URL.Query().Get("active_only"))
+			_ = json.NewEncoder(w).Encode([]assembly.BillOfMaterials{bomPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/boms":
+			var req assembly.CreateBOMRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Branch laptop", req.Name)
-			assert.Equal(t, "Branch coverage laptop", req.Description)
-			require.NotNil(t, req.CategoryID)
-			assert.Equal(t, "cat-branch", *req.CategoryID)
-			assert.Equal(t, "2026-03-15", req.PurchaseDate.Format("2006-01-02"))
-			assert.True(t, req.PurchaseCost.Equal(decimal.RequireFromString("1250.50")))
-			require.NotNil(t, req.SupplierID)
-			assert.Equal(t, "supplier-branch", *req.SupplierID)
-			assert.Equal(t, "SN-BRANCH", req.SerialNumber)
-			assert.Equal(t, "Tallinn", req.Location)
-			assert.Equal(t, assets.DepreciationUnitsOfProd, req.DepreciationMethod)
-			assert.Equal(t, 24, req.UsefulLifeMonths)
-			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("50.25")))
-			require.NotNil(t, req.DepreciationStartDate)
-			assert.Equal(t, "2026-04-01", req.DepreciationStartDate.Format("2006-01-02"))
-			require.NotNil(t, req.AssetAccountID)
-			require.NotNil(t, req.DepreciationExpenseAccountID)
-			require.NotNil(t, req.AccumulatedDepreciationAcctID)
-			assert.Equal(t, "asset-account", *req.AssetAccountID)
-			assert.Equal(t, "depreciation-expense-account", *req.DepreciationExpenseAccountID)
-			assert.Equal(t, "accumulated-depreciation-account", *req.AccumulatedDepreciationAcctID)
+			assert.Equal(t, "table", req.ProductID)
+			assert.True(t, req.LabourCost.Equal(decimal.NewFromInt(3)))
+			require.Len(t, req.Lines, 2)
+			assert.Equal(t, "leg", req.Lines[0].ComponentProductID)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.NewFromInt(4)))
+			assert.Equal(t, "Oak", req.Lines[1].Notes)
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(assetPayload("DRAFT"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/import":
-			var req assets.ImportAssetsRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "asset-branches.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "BR-001")
-			_ = json.NewEncoder(w).Encode(assets.ImportAssetsResult{
-				FileName:      "asset-branches.csv",
-				RowsProcessed: 2,
-				AssetsCreated: 1,
-				RowsSkipped:   1,
-				Errors: []assets.ImportAssetsRowError{{
-					Row:         3,
-					AssetNumber: "BR-002",
-					Name:        "Duplicate asset",
-					Message:     "duplicate asset number",
-				}},
-			})
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch":
-			_ = json.NewEncoder(w).Encode(assetPayload("ACTIVE"))
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch":
-			var req assets.UpdateAssetRequest
+			_ = json.NewEncoder(w).Encode(bomPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/boms/bom-1":
+			_ = json.NewEncoder(w).Encode(bomPayload)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/boms/bom-1":
+			var req assembly.UpdateBOMRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Updated branch laptop", req.Name)
-			assert.Equal(t, "Updated branch description", req.Description)
-			require.NotNil(t, req.CategoryID)
-			assert.Equal(t, "cat-updated", *req.CategoryID)
-			assert.Equal(t, "SN-UPDATED", req.SerialNumber)
-			assert.Equal(t, "Tartu", req.Location)
-			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
-			assert.Equal(t, 30, req.UsefulLifeMonths)
-			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("75.00")))
-			require.NotNil(t, req.AssetAccountID)
-			require.NotNil(t, req.DepreciationExpenseAccountID)
-			require.NotNil(t, req.AccumulatedDepreciationAcctID)
-			assert.Equal(t, "asset-account-updated", *req.AssetAccountID)
-			assert.Equal(t, "depreciation-expense-updated", *req.DepreciationExpenseAccountID)
-			assert.Equal(t, "accumulated-depreciation-updated", *req.AccumulatedDepreciationAcctID)
-			payload := assetPayload("ACTIVE")
-			payload["name"] = "Updated branch laptop"
-			payload["description"] = "Updated branch description"
-			payload["category_id"] = "cat-updated"
-			payload["serial_number"] = "SN-UPDATED"
-			payload["location"] = "Tartu"
-			payload["depreciation_method"] = "DECLINING_BALANCE"
-			payload["useful_life_months"] = 30
-			payload["residual_value"] = "75.00"
-			_ = json.NewEncoder(w).Encode(payload)
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch":
-			w.WriteHeader(http.StatusNoContent)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/activate":
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "active"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/dispose":
-			var req assets.DisposeAssetRequest
+			assert.Equal(t, assembly.BOMTypeKit, req.BOMType)
+			require.NotNil(t, req.IsActive)
+			assert.False(t, *req.IsActive)
+			require.Len(t, req.Lines, 1)
+			updated := bomPayload
+			updated.BOMType = assembly.BOMTypeKit
+			updated.Lines = updated.Lines[:1]
+			_ = json.NewEncoder(w).Encode(updated)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/boms/bom-2":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/boms/bom-1/explosion":
+			require.Equal(t, "2", r.URL.Query().Get("quantity"))
+			if r.URL.Query().Get("format") == "csv" {
+				w.Header().Set("Content-Type", "text/csv")
+				_, _ = w.Write([]byte("level,product_code\n1,LEG\n"))
+				return
+			}
+			require.Equal(t, "fifo", r.URL.Query().Get("valuation_method"))
+			_ = json.NewEncoder(w).Encode(explosionPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/assembly-orders":
+			require.Equal(t, "DRAFT", r.URL.Query().Get("status"))
+			require.Equal(t, "DISASSEMBLY", r.URL.Query().Get("order_type"))
+			_ = json.NewEncoder(w).Encode([]assembly.AssemblyOrder{orderPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/assembly-orders":
+			var req assembly.CreateAssemblyOrderRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "2026-05-01", req.DisposalDate.Format("2006-01-02"))
-			assert.Equal(t, assets.DisposalDonated, req.DisposalMethod)
-			assert.True(t, req.DisposalProceeds.Equal(decimal.RequireFromString("0")))
-			assert.Equal(t, "Donated to school", req.DisposalNotes)
-			require.NotNil(t, req.DisposalProceedsAccountID)
-			require.NotNil(t, req.DisposalGainLossAccountID)
-			assert.Equal(t, "cash-account", *req.DisposalProceedsAccountID)
-			assert.Equal(t, "asset-disposal-loss", *req.DisposalGainLossAccountID)
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "disposed"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/depreciation":
+			assert.Equal(t, "table", req.ProductID)
+			assert.Equal(t, "wh-1", req.WarehouseID)
+			assert.True(t, req.Quantity.Equal(decimal.NewFromInt(2)))
+			assert.Equal(t, "2026-03-02", req.OrderDate.Format("2006-01-02"))
+			assert.Equal(t, "LOT-T", req.LotNumber)
+			require.NotNil(t, req.OverheadCost)
+			assert.True(t, req.OverheadCost.Equal(decimal.NewFromInt(1)))
+			assert.Nil(t, req.LabourCost)
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(depreciationPayload)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/depreciation":
-			_ = json.NewEncoder(w).Encode([]map[string]any{depreciationPayload})
+			_ = json.NewEncoder(w).Encode(orderPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/assembly-orders/order-1":
+			_ = json.NewEncoder(w).Encode(orderPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/assembly-orders/order-1/complete":
+			var req assembly.CompleteAssemblyOrderRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "lot", req.CostingMethod)
+			assert.Equal(t, "absorption", req.AbsorptionAccountID)
+			journalID := "journal-1"
+			completed := orderPayload
+			completed.Status = assembly.AssemblyOrderStatusCompleted
+			completed.UnitCost = decimal.NewFromInt(11)
+			completed.JournalEntryID = &journalID
+			_ = json.NewEncoder(w).Encode(completed)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/inventory/assembly-orders/order-2/cancel":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "CANCELED"})
 		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
 		}
 	}))
 	defer server.Close()
 	t.Setenv("OA_BASE_URL", server.URL)
 
-	err := app.run(ctx, []string{"assets", "list"})
+	app, stdout, _ := newTestCLIApp()
+	err := app.run(context.Background(), []string{"inventory", "boms", "list", "--type", "kit", "--active-only"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "FA-BRANCH")
-	assert.Contains(t, stdout.String(), "Branch laptop")
+	assert.Contains(t, stdout.String(), "bom-1")
+	assert.Contains(t, stdout.String(), "ASSEMBLY")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{
-		"assets", "create",
-		"--name", " Branch laptop ",
-		"--description", " Branch coverage laptop ",
-		"--category-id", " cat-branch ",
-		"--purchase-date", " 2026-03-15 ",
-		"--purchase-cost", " 1250.50 ",
-		"--supplier-id", " supplier-branch ",
-		"--serial-number", " SN-BRANCH ",
-		"--location", " Tallinn ",
-		"--depreciation-method", " units_of_production ",
-		"--useful-life-months", " 24 ",
-		"--residual-value", " 50.25 ",
-		"--depreciation-start-date", " 2026-04-01 ",
-		"--asset-account-id", " asset-account ",
-		"--depreciation-expense-account-id", " depreciation-expense-account ",
-		"--accumulated-depreciation-account-id", " accumulated-depreciation-account ",
-		"--json",
-	})
+	err = app.run(context.Background(), []string{"inventory", "boms", "create", "--product-id", "table", "--labour-cost", "3", "--component", "product_id=leg,qty=4", "--component", "component-product-id=top,quantity=1,notes=Oak"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"id": "asset-branch"`)
-	assert.Contains(t, stdout.String(), `"status": "DRAFT"`)
+	assert.Contains(t, stdout.String(), "Created ASSEMBLY bill of materials bom-1 with 2 components")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{"assets", "import", "--file", importFile, "--json"})
+	err = app.run(context.Background(), []string{"inventory", "boms", "get", "--id", "bom-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"assets_created": 1`)
-	assert.Contains(t, stdout.String(), `"duplicate asset number"`)
+	assert.Contains(t, stdout.String(), "Bill of materials bom-1 (ASSEMBLY)")
+	assert.Contains(t, stdout.String(), "Oak")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{"assets", "get", "--id", " asset-branch ", "--json"})
+	err = app.run(context.Background(), []string{"inventory", "boms", "update", "--id", "bom-1", "--type", "kit", "--active", "false", "--component", "product_id=leg,qty=4"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"asset_number": "FA-BRANCH"`)
-	assert.Contains(t, stdout.String(), `"name": "Branch laptop"`)
+	assert.Contains(t, stdout.String(), "Updated KIT bill of materials bom-1 with 1 components")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{
-		"assets", "update",
-		"--id", " asset-branch ",
-		"--name", " Updated branch laptop ",
-		"--description", " Updated branch description ",
-		"--category-id", " cat-updated ",
-		"--serial-number", " SN-UPDATED ",
-		"--location", " Tartu ",
-		"--depreciation-method", " declining_balance ",
-		"--useful-life-months", " 30 ",
-		"--residual-value", " 75.00 ",
-		"--asset-account-id", " asset-account-updated ",
-		"--depreciation-expense-account-id", " depreciation-expense-updated ",
-		"--accumulated-depreciation-account-id", " accumulated-depreciation-updated ",
-		"--json",
-	})
+	err = app.run(context.Background(), []string{"inventory", "boms", "delete", "--id", "bom-2"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"name": "Updated branch laptop"`)
-	assert.Contains(t, stdout.String(), `"depreciation_method": "DECLINING_BALANCE"`)
+	assert.Contains(t, stdout.String(), "Deleted bill of materials bom-2")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{"assets", "activate", "--id", " asset-branch ", "--json"})
+	err = app.run(context.Background(), []string{"inventory", "boms", "explode", "--id", "bom-1", "--quantity", "2", "--method", "fifo"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "active"`)
+	assert.Contains(t, stdout.String(), "Bill of materials explosion TBL Table (ASSEMBLY)")
+	assert.Contains(t, stdout.String(), "Unit cost: 11")
+	assert.Contains(t, stdout.String(), "LEG")
 
+	outputPath := filepath.Join(t.TempDir(), "explosion.csv")
 	stdout.Reset()
-	err = app.run(ctx, []string{
-		"assets", "dispose",
-		"--id", " asset-branch ",
-		"--disposal-date", " 2026-05-01 ",
-		"--method", " donated ",
-		"--proceeds", " 0 ",
-		"--proceeds-account-id", " cash-account ",
-		"--gain-loss-account-id", " asset-disposal-loss ",
-		"--notes", " Donated to school ",
-		"--json",
-	})
+	err = app.run(context.Background(), []string{"inventory", "boms", "explode", "--id", "bom-1", "--quantity", "2", "--csv", "--output", outputPath})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "disposed"`)
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1,LEG")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{"assets", "depreciate", "--id", " asset-branch ", "--json"})
+	err = app.run(context.Background(), []string{"inventory", "assembly-orders", "list", "--status", "draft", "--type", "disassembly"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"id": "dep-branch"`)
-	assert.Contains(t, stdout.String(), `"journal_entry_id": "journal-branch"`)
+	assert.Contains(t, stdout.String(), "AO-00001")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{"assets", "depreciation", "--id", " asset-branch ", "--json"})
+	err = app.run(context.Background(), []string{"inventory", "assembly-orders", "create", "--product-id", "table", "--warehouse-id", "wh-1", "--quantity", "2", "--order-date", "2026-03-02", "--lot", "LOT-T", "--overhead-cost", "1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"depreciation_amount": "33.33"`)
+	assert.Contains(t, stdout.String(), "Created assembly order AO-00001 (order-1) with 1 component lines")
 
 	stdout.Reset()
-	err = app.run(ctx, []string{"assets", "delete", "--id", " asset-branch ", "--json"})
+	err = app.run(context.Background(), []string{"inventory", "assembly-orders", "get", "--id", "order-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "deleted"`)
-}
-
-func TestCLIAssetAuthAndAPIErrors(t *testing.T) {
-	configureCLIEnv(t)
+	assert.Contains(t, stdout.String(), "Assembly order AO-00001 (ASSEMBLY, DRAFT)")
+	assert.Contains(t, stdout.String(), "LOT-L")
 
-	app, stdout, _ := newTestCLIApp()
-	ctx := context.Background()
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"inventory", "assembly-orders", "complete", "--id", "order-1", "--method", "lot", "--absorption-account-id", "absorption"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Completed assembly order AO-00001 at unit cost 11, journal journal-1")
 
-	err := app.run(ctx, []string{"assets", "list"})
-	require.Error(t, err)
-	assert.ErrorContains(t, err, "no API token configured")
-	assert.Empty(t, stdout.String())
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"inventory", "assembly-orders", "cancel", "--id", "order-2", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "CANCELED"`)
+}
 
+func TestCLIRecurringInvoiceCommands(t *testing.T) {
+	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
 		TenantID:   "tenant-1",
@@ -11155,212 +11569,206 @@ func TestCLIAssetAuthAndAPIErrors(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	importFile := writeTempCSV(t, "assets-error.csv", "asset_number,name,purchase_date,purchase_cost\nFA-ERR,Error laptop,2026-07-01,1500\n")
+	generationPayload := recurringGenerationPayload("rec-1")
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
-			assert.Equal(t, "ACTIVE", r.URL.Query().Get("status"))
-			assert.Equal(t, "cat-error", r.URL.Query().Get("category_id"))
-			assert.Equal(t, "Laptop", r.URL.Query().Get("search"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
-			var req assets.CreateAssetRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			require.Equal(t, "true", r.URL.Query().Get("active_only"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{recurringInvoicePayload("rec-1", "Monthly retainer", true)})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			var req recurring.CreateRecurringInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Error laptop", req.Name)
-			assert.Equal(t, "2026-07-01", req.PurchaseDate.Format("2006-01-02"))
-			assert.True(t, req.PurchaseCost.Equal(decimal.NewFromInt(1500)))
-			assert.Equal(t, assets.DepreciationStraightLine, req.DepreciationMethod)
-			assert.Equal(t, 60, req.UsefulLifeMonths)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/import":
-			var req assets.ImportAssetsRequest
+			assert.Equal(t, "Monthly retainer", req.Name)
+			assert.Equal(t, "contact-1", req.ContactID)
+			assert.Equal(t, "SALES", req.InvoiceType)
+			assert.Equal(t, "EUR", req.Currency)
+			assert.Equal(t, recurring.FrequencyMonthly, req.Frequency)
+			assert.Equal(t, "2026-03-15", req.StartDate.Format("2006-01-02"))
+			require.NotNil(t, req.EndDate)
+			assert.Equal(t, "2026-12-31", req.EndDate.Format("2006-01-02"))
+			assert.Equal(t, 21, req.PaymentTermsDays)
+			assert.True(t, req.SendEmailOnGeneration)
+			assert.Equal(t, "billing@example.com", req.RecipientEmailOverride)
+			require.NotNil(t, req.AttachPDFToEmail)
+			assert.True(t, *req.AttachPDFToEmail)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "Consulting", req.Lines[0].Description)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.RequireFromString("2.00")))
+			assert.True(t, req.Lines[0].DiscountPercent.Equal(decimal.RequireFromString("10.00")))
+			require.NotNil(t, req.Lines[0].AccountID)
+			require.NotNil(t, req.Lines[0].ProductID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-1", "Monthly retainer", true))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/import":
+			var req recurring.ImportRecurringInvoicesRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "assets-error.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "FA-ERR")
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error":
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error":
-			var req assets.UpdateAssetRequest
+			assert.Equal(t, "recurring.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "Monthly Legacy")
+			_ = json.NewEncoder(w).Encode(recurring.ImportRecurringInvoicesResult{
+				FileName:         req.FileName,
+				RowsProcessed:    1,
+				TemplatesCreated: 1,
+				LinesImported:    1,
+				RowsSkipped:      0,
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/from-invoice/inv-template":
+			var req recurring.CreateFromInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Updated error laptop", req.Name)
-			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
-			assert.Equal(t, 48, req.UsefulLifeMonths)
-			assert.True(t, req.ResidualValue.Equal(decimal.NewFromInt(100)))
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/activate":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/dispose":
-			var req assets.DisposeAssetRequest
+			assert.Equal(t, "From invoice", req.Name)
+			assert.Equal(t, recurring.FrequencyBiweekly, req.Frequency)
+			assert.Equal(t, "2026-04-01", req.StartDate.Format("2006-01-02"))
+			assert.Equal(t, 14, req.PaymentTermsDays)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-2", "From invoice", true))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1":
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-1", "Monthly retainer", true))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1":
+			var req recurring.UpdateRecurringInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "2026-08-01", req.DisposalDate.Format("2006-01-02"))
-			assert.Equal(t, assets.DisposalSold, req.DisposalMethod)
-			assert.True(t, req.DisposalProceeds.Equal(decimal.NewFromInt(250)))
-			assert.Equal(t, "Sold damaged asset", req.DisposalNotes)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/depreciation":
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/depreciation":
+			require.NotNil(t, req.Name)
+			assert.Equal(t, "Quarterly retainer", *req.Name)
+			require.NotNil(t, req.Frequency)
+			assert.Equal(t, recurring.FrequencyQuarterly, *req.Frequency)
+			require.NotNil(t, req.PaymentTermsDays)
+			assert.Equal(t, 30, *req.PaymentTermsDays)
+			require.NotNil(t, req.SendEmailOnGeneration)
+			assert.False(t, *req.SendEmailOnGeneration)
+			require.NotNil(t, req.AttachPDFToEmail)
+			assert.False(t, *req.AttachPDFToEmail)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "Updated consulting", req.Lines[0].Description)
+			payload := recurringInvoicePayload("rec-1", "Quarterly retainer", true)
+			payload["frequency"] = "QUARTERLY"
+			payload["payment_terms_days"] = 30
+			payload["send_email_on_generation"] = false
+			payload["attach_pdf_to_email"] = false
+			payload["lines"] = []map[string]any{{
+				"id":                   "line-2",
+				"recurring_invoice_id": "rec-1",
+				"line_number":          1,
+				"description":          "Updated consulting",
+				"quantity":             "3.00",
+				"unit":                 "hour",
+				"unit_price":           "100.00",
+				"vat_rate":             "22.00",
+			}}
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/pause":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "paused"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/resume":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "resumed"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/generate":
+			_ = json.NewEncoder(w).Encode(generationPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/generate-due":
+			_ = json.NewEncoder(w).Encode([]map[string]any{generationPayload})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
 		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
-
-		w.WriteHeader(http.StatusBadGateway)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "asset backend unavailable"})
 	}))
 	defer server.Close()
+
 	t.Setenv("OA_BASE_URL", server.URL)
 
-	cases := []struct {
-		name string
-		args []string
-	}{
-		{name: "list api error", args: []string{"assets", "list", "--status", "active", "--category-id", "cat-error", "--search", "Laptop"}},
-		{name: "create api error", args: []string{"assets", "create", "--name", "Error laptop", "--purchase-date", "2026-07-01", "--purchase-cost", "1500", "--depreciation-method", "straight_line", "--useful-life-months", "60"}},
-		{name: "import api error", args: []string{"assets", "import", "--file", importFile}},
-		{name: "get api error", args: []string{"assets", "get", "--id", "asset-error"}},
-		{name: "update api error", args: []string{"assets", "update", "--id", "asset-error", "--name", "Updated error laptop", "--depreciation-method", "declining_balance", "--useful-life-months", "48", "--residual-value", "100"}},
-		{name: "delete api error", args: []string{"assets", "delete", "--id", "asset-error"}},
-		{name: "activate api error", args: []string{"assets", "activate", "--id", "asset-error"}},
-		{name: "dispose api error", args: []string{"assets", "dispose", "--id", "asset-error", "--disposal-date", "2026-08-01", "--method", "sold", "--proceeds", "250", "--notes", "Sold damaged asset"}},
-		{name: "depreciate api error", args: []string{"assets", "depreciate", "--id", "asset-error"}},
-		{name: "depreciation api error", args: []string{"assets", "depreciation", "--id", "asset-error"}},
-	}
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(ctx, tc.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, "asset backend unavailable")
-			assert.Empty(t, stdout.String())
-		})
-	}
-}
+	app, stdout, _ := newTestCLIApp()
 
-func TestCLIAssetCategoryBranches(t *testing.T) {
-	configureCLIEnv(t)
-	require.NoError(t, saveConfig(&cliConfig{
-		BaseURL:    "https://placeholder.example.com",
-		TenantID:   "tenant-1",
-		TenantName: "Alpha",
-		TenantSlug: "alpha",
-		APIToken:   "oa_saved_token",
-	}))
+	err := app.run(context.Background(), []string{"recurring-invoices", "list", "--active-only", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"name": "Monthly retainer"`)
 
-	app, stdout, _ := newTestCLIApp()
-	cfg := &cliConfig{TenantID: "tenant-1"}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"recurring-invoices", "create",
+		"--name", "Monthly retainer",
+		"--contact-id", "contact-1",
+		"--type", "sales",
+		"--currency", "eur",
+		"--frequency", "monthly",
+		"--start-date", "2026-03-15",
+		"--end-date", "2026-12-31",
+		"--payment-terms-days", "21",
+		"--reference", "RET-1",
+		"--notes", "Monthly services",
+		"--send-email",
+		"--recipient-email", "billing@example.com",
+		"--attach-pdf",
+		"--email-subject", "Monthly invoice",
+		"--email-message", "Please see attached invoice.",
+		"--line", "description=Consulting,quantity=2,unit=hour,unit_price=100.00,discount_percent=10.00,vat_rate=22.00,account_id=acc-1,product_id=prod-1",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created recurring invoice Monthly retainer (rec-1)")
 
-	for _, tt := range []struct {
-		name string
-		args []string
-		want string
-	}{
-		{name: "missing subcommand", args: nil, want: "assets categories subcommand required"},
-		{name: "unknown subcommand", args: []string{"archive"}, want: `unknown assets categories subcommand "archive"`},
-		{name: "list bad flag", args: []string{"list", "--unknown"}, want: "flag provided but not defined"},
-		{name: "create bad flag", args: []string{"create", "--unknown"}, want: "flag provided but not defined"},
-		{name: "create missing name", args: []string{"create"}, want: "name is required"},
-		{name: "create invalid depreciation method", args: []string{"create", "--name", "Vehicles", "--depreciation-method", "accelerated"}, want: `invalid depreciation method "accelerated"`},
-		{name: "create invalid useful life", args: []string{"create", "--name", "Vehicles", "--useful-life-months", "zero"}, want: "parse useful-life-months"},
-		{name: "create non-positive useful life", args: []string{"create", "--name", "Vehicles", "--useful-life-months", "0"}, want: "useful-life-months must be positive"},
-		{name: "create invalid residual percent", args: []string{"create", "--name", "Vehicles", "--residual-percent", "many"}, want: "parse residual-percent"},
-		{name: "create negative residual percent", args: []string{"create", "--name", "Vehicles", "--residual-percent", "-1"}, want: "residual-percent must be non-negative"},
-		{name: "get bad flag", args: []string{"get", "--unknown"}, want: "flag provided but not defined"},
-		{name: "get missing id", args: []string{"get"}, want: "id is required"},
-		{name: "delete bad flag", args: []string{"delete", "--unknown"}, want: "flag provided but not defined"},
-		{name: "delete missing id", args: []string{"delete"}, want: "id is required"},
-	} {
-		t.Run(tt.name, func(t *testing.T) {
-			err := app.runAssetCategories(context.Background(), cfg, &apiClient{}, tt.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, tt.want)
-		})
-	}
+	recurringImportFile := writeTempCSV(t, "recurring.csv", "name,contact_id,frequency,start_date,line_description,quantity,unit_price,vat_rate\nMonthly Legacy,contact-1,MONTHLY,2026-03-15,Consulting,1,100,22\n")
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "import", "--file", recurringImportFile})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 recurring invoices, imported 1 lines, skipped 0 rows")
 
-	assetAccountID := "asset-account"
-	depreciationExpenseAccountID := "depreciation-expense-account"
-	accumulatedDepreciationAccountID := "accumulated-depreciation-account"
-	categoryPayload := map[string]any{
-		"id":                                  "cat-branch",
-		"tenant_id":                           "tenant-1",
-		"name":                                "Vehicles",
-		"description":                         "Fleet assets",
-		"depreciation_method":                 "DECLINING_BALANCE",
-		"default_useful_life_months":          72,
-		"default_residual_value_percent":      "15.25",
-		"asset_account_id":                    assetAccountID,
-		"depreciation_expense_account_id":     depreciationExpenseAccountID,
-		"accumulated_depreciation_account_id": accumulatedDepreciationAccountID,
-		"created_at":                          "2026-03-15T12:00:00Z",
-		"updated_at":                          "2026-03-15T12:00:00Z",
-	}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"recurring-invoices", "from-invoice",
+		"--invoice-id", "inv-template",
+		"--name", "From invoice",
+		"--frequency", "biweekly",
+		"--start-date", "2026-04-01",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created recurring invoice From invoice (rec-2) from invoice inv-template")
 
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "get", "--id", "rec-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Recurring invoice Monthly retainer")
+	assert.Contains(t, stdout.String(), "Consulting")
 
-		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
-			_ = json.NewEncoder(w).Encode([]map[string]any{categoryPayload})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
-			var req assets.CreateCategoryRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Vehicles", req.Name)
-			assert.Equal(t, "Fleet assets", req.Description)
-			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
-			assert.Equal(t, 72, req.DefaultUsefulLifeMonths)
-			assert.True(t, req.DefaultResidualValuePercent.Equal(decimal.RequireFromString("15.25")))
-			require.NotNil(t, req.AssetAccountID)
-			require.NotNil(t, req.DepreciationExpenseAccountID)
-			require.NotNil(t, req.AccumulatedDepreciationAcctID)
-			assert.Equal(t, assetAccountID, *req.AssetAccountID)
-			assert.Equal(t, depreciationExpenseAccountID, *req.DepreciationExpenseAccountID)
-			assert.Equal(t, accumulatedDepreciationAccountID, *req.AccumulatedDepreciationAcctID)
-			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(categoryPayload)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-branch":
-			_ = json.NewEncoder(w).Encode(categoryPayload)
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-branch":
-			w.WriteHeader(http.StatusNoContent)
-		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
-		}
-	}))
-	defer server.Close()
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"recurring-invoices", "update",
+		"--id", "rec-1",
+		"--name", "Quarterly retainer",
+		"--frequency", "quarterly",
+		"--payment-terms-days", "30",
+		"--send-email", "false",
+		"--attach-pdf", "false",
+		"--line", "description=Updated consulting,quantity=3,unit=hour,unit_price=100.00,vat_rate=22.00",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Recurring invoice Quarterly retainer")
+	assert.Contains(t, stdout.String(), "Attach PDF: false")
 
-	t.Setenv("OA_BASE_URL", server.URL)
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "pause", "--id", "rec-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Paused recurring invoice rec-1")
 
-	err := app.run(context.Background(), []string{"assets", "categories", "list"})
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "resume", "--id", "rec-1", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Vehicles")
-	assert.Contains(t, stdout.String(), "DECLINING_BALANCE")
+	assert.Contains(t, stdout.String(), `"status": "resumed"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"assets", "categories", "create",
-		"--name", "Vehicles",
-		"--description", "Fleet assets",
-		"--depreciation-method", "declining_balance",
-		"--useful-life-months", "72",
-		"--residual-percent", "15.25",
-		"--asset-account-id", assetAccountID,
-		"--depreciation-expense-account-id", depreciationExpenseAccountID,
-		"--accumulated-depreciation-account-id", accumulatedDepreciationAccountID,
-		"--json",
-	})
+	err = app.run(context.Background(), []string{"recurring-invoices", "generate", "--id", "rec-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"id": "cat-branch"`)
-	assert.Contains(t, stdout.String(), `"default_useful_life_months": 72`)
+	assert.Contains(t, stdout.String(), "Generated invoice INV-00001 (inv-1) from recurring invoice rec-1")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "categories", "get", "--id", "cat-branch", "--json"})
+	err = app.run(context.Background(), []string{"recurring-invoices", "generate-due"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"name": "Vehicles"`)
-	assert.Contains(t, stdout.String(), `"depreciation_method": "DECLINING_BALANCE"`)
+	assert.Contains(t, stdout.String(), "INV-00001")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"assets", "categories", "delete", "--id", "cat-branch", "--json"})
+	err = app.run(context.Background(), []string{"recurring-invoices", "delete", "--id", "rec-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "deleted"`)
+	assert.Contains(t, stdout.String(), "Deleted recurring invoice rec-1")
 }
 
-func TestCLIAssetCategoryAPIErrorBranches(t *testing.T) {
+func TestCLIRecurringUsageCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -11370,112 +11778,166 @@ func TestCLIAssetCategoryAPIErrorBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
+	usagePayload := map[string]any{
+		"id":                   "usage-1",
+		"tenant_id":            "tenant-1",
+		"recurring_invoice_id": "rec-1",
+		"metric":               "kwh",
+		"usage_date":           "2026-03-31T00:00:00Z",
+		"quantity":             "812.5",
+		"reference":            "meter-7",
+		"created_at":           "2026-04-01T08:00:00Z",
+	}
+
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
-			var req assets.CreateCategoryRequest
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			var req recurring.CreateRecurringInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Vehicles", req.Name)
-			assert.Equal(t, "Fleet assets", req.Description)
-			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
-			assert.Equal(t, 72, req.DefaultUsefulLifeMonths)
-			assert.True(t, req.DefaultResidualValuePercent.Equal(decimal.RequireFromString("15.25")))
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-error":
-		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-error":
+			require.NotNil(t, req.BillingAnchorDate)
+			assert.Equal(t, "2026-04-01", req.BillingAnchorDate.Format("2006-01-02"))
+			require.Len(t, req.Lines, 2)
+			assert.Empty(t, req.Lines[0].UsageMetric)
+			assert.Equal(t, "kwh", req.Lines[1].UsageMetric)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-1", "Hosting", true))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/usage":
+			require.Equal(t, "true", r.URL.Query().Get("unbilled"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{usagePayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/usage":
+			var req recurring.RecordUsageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Len(t, req.Records, 1)
+			assert.Equal(t, "kwh", req.Records[0].Metric)
+			assert.Equal(t, "2026-03-31", req.Records[0].UsageDate.Format("2006-01-02"))
+			assert.True(t, req.Records[0].Quantity.Equal(decimal.RequireFromString("812.5")))
+			assert.Equal(t, "meter-7", req.Records[0].Reference)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode([]map[string]any{usagePayload})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/usage/usage-1":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/usage/import":
+			var req recurring.ImportUsageRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "usage.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "Hosting,kwh")
+			_ = json.NewEncoder(w).Encode(recurring.ImportUsageResult{
+				FileName:       req.FileName,
+				RowsProcessed:  2,
+				RecordsCreated: 1,
+				RowsSkipped:    1,
+				Errors:         []recurring.ImportRecurringInvoicesRowError{{Row: 3, Template: "Hosting", Message: "quantity is required"}},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/price-changes":
+			var req recurring.CreatePriceChangeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2027-01-01", req.EffectiveDate.Format("2006-01-02"))
+			require.NotNil(t, req.UpliftPercent)
+			assert.True(t, req.UpliftPercent.Equal(decimal.RequireFromString("3.5")))
+			assert.Nil(t, req.UnitPrice)
+			require.NotNil(t, req.LineNumber)
+			assert.Equal(t, 1, *req.LineNumber)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id":                   "change-1",
+				"tenant_id":            "tenant-1",
+				"recurring_invoice_id": "rec-1",
+				"line_number":          1,
+				"effective_date":       "2027-01-01T00:00:00Z",
+				"uplift_percent":       "3.5",
+				"note":                 req.Note,
+				"created_at":           "2026-04-01T08:00:00Z",
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-1/price-changes/change-1":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
 		default:
-			t.Fatalf("unexpected asset category error request: %s %s", r.Method, r.URL.String())
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
-
-		w.WriteHeader(http.StatusBadGateway)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "asset category service unavailable"})
 	}))
 	defer server.Close()
+
 	t.Setenv("OA_BASE_URL", server.URL)
 
 	app, stdout, _ := newTestCLIApp()
-	ctx := context.Background()
-	for _, tt := range []struct {
+
+	err := app.run(context.Background(), []string{
+		"recurring-invoices", "create",
+		"--name", "Hosting",
+		"--contact-id", "contact-1",
+		"--frequency", "monthly",
+		"--start-date", "2026-03-10",
+		"--billing-anchor-date", "2026-04-01",
+		"--line", "description=Platform fee,quantity=1,unit_price=300.00,vat_rate=22.00",
+		"--line", "description=Electricity,quantity=1,unit=kWh,unit_price=0.20,vat_rate=22.00,usage_metric=kwh",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created recurring invoice Hosting (rec-1)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "usage", "add", "--id", "rec-1", "--metric", "kwh", "--date", "2026-03-31", "--quantity", "812.5", "--reference", "meter-7"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "usage-1")
+	assert.Contains(t, stdout.String(), "812.5")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "usage", "list", "--id", "rec-1", "--unbilled", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"metric": "kwh"`)
+
+	usageFile := writeTempCSV(t, "usage.csv", "recurring_invoice,metric,usage_date,quantity\nHosting,kwh,2026-03-31,812.5\nHosting,kwh,2026-03-31,\n")
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "usage", "import", "--file", usageFile})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Processed 2 rows, recorded 1 usage records, skipped 1 rows")
+	assert.Contains(t, stdout.String(), "Row 3: quantity is required")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "usage", "delete", "--id", "rec-1", "--usage-id", "usage-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Deleted usage record usage-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "price-changes", "add", "--id", "rec-1", "--effective-date", "2027-01-01", "--line", "1", "--uplift-percent", "3.5", "--note", "CPI 2026"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "uplift 3.5%")
+	assert.Contains(t, stdout.String(), "CPI 2026")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "price-changes", "delete", "--id", "rec-1", "--change-id", "change-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Deleted price change change-1")
+
+	validationCases := []struct {
 		name string
 		args []string
+		want string
 	}{
-		{name: "list API error", args: []string{"assets", "categories", "list"}},
-		{name: "create API error", args: []string{"assets", "categories", "create", "--name", "Vehicles", "--description", "Fleet assets", "--depreciation-method", "declining_balance", "--useful-life-months", "72", "--residual-percent", "15.25"}},
-		{name: "get API error", args: []string{"assets", "categories", "get", "--id", "cat-error"}},
-		{name: "delete API error", args: []string{"assets", "categories", "delete", "--id", "cat-error"}},
-	} {
-		t.Run(tt.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(ctx, tt.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, "asset category service unavailable")
-			assert.Empty(t, stdout.String())
-		})
-	}
-}
-
-func cliExpensePayload(status string) map[string]any {
-	payload := map[string]any{
-		"id":                 "expense-1",
-		"tenant_id":          "tenant-1",
-		"expense_number":     "EXP-00001",
-		"expense_date":       "2026-05-30T00:00:00Z",
-		"merchant":           "Office Store",
-		"description":        "Printer toner",
-		"employee_id":        "employee-1",
-		"contact_id":         "supplier-1",
-		"expense_account_id": "expense-account",
-		"payment_account_id": "cash-account",
-		"amount":             "120.50",
-		"currency":           "EUR",
-		"exchange_rate":      "1",
-		"base_amount":        "120.50",
-		"requires_receipt":   false,
-		"status":             status,
-		"created_at":         "2026-05-30T12:00:00Z",
-		"created_by":         "user-1",
-		"updated_at":         "2026-05-30T12:00:00Z",
-		"remediation_actions": []map[string]any{{
-			"code":        cliExpenseRemediationCode(status),
-			"severity":    "ACTION",
-			"scope":       "expenses",
-			"owner_role":  "accountant",
-			"message":     "Expense follow-up is required.",
-			"action":      "Review the expense workflow state.",
-			"entity_type": "expense",
-			"entity_id":   "expense-1",
-			"ui_path":     "/expenses?expense_id=expense-1",
-			"cli_command": "oa expenses get --id expense-1",
-		}},
-	}
-	if status == "POSTED" {
-		payload["journal_entry_id"] = "je-1"
+		{name: "usage missing subcommand", args: []string{"recurring-invoices", "usage"}, want: "recurring-invoices usage subcommand required"},
+		{name: "usage unknown subcommand", args: []string{"recurring-invoices", "usage", "unknown"}, want: `unknown recurring-invoices usage subcommand "unknown"`},
+		{name: "usage list missing id", args: []string{"recurring-invoices", "usage", "list"}, want: "id is required"},
+		{name: "usage add missing metric", args: []string{"recurring-invoices", "usage", "add", "--id", "rec-1", "--date", "2026-03-31", "--quantity", "1"}, want: "metric is required"},
+		{name: "usage add negative quantity", args: []string{"recurring-invoices", "usage", "add", "--id", "rec-1", "--metric", "kwh", "--date", "2026-03-31", "--quantity", "-1"}, want: "quantity must be non-negative"},
+		{name: "usage delete missing usage id", args: []string{"recurring-invoices", "usage", "delete", "--id", "rec-1"}, want: "usage-id is required"},
+		{name: "usage import missing file", args: []string{"recurring-invoices", "usage", "import"}, want: "file is required"},
+		{name: "price changes missing subcommand", args: []string{"recurring-invoices", "price-changes"}, want: "recurring-invoices price-changes subcommand required"},
+		{name: "price changes unknown subcommand", args: []string{"recurring-invoices", "price-changes", "unknown"}, want: `unknown recurring-invoices price-changes subcommand "unknown"`},
+		{name: "price change missing date", args: []string{"recurring-invoices", "price-changes", "add", "--id", "rec-1", "--unit-price", "10"}, want: "effective-date is required"},
+		{name: "price change both kinds", args: []string{"recurring-invoices", "price-changes", "add", "--id", "rec-1", "--effective-date", "2027-01-01", "--unit-price", "10", "--uplift-percent", "2"}, want: "give either unit-price or uplift-percent"},
+		{name: "price change bad line", args: []string{"recurring-invoices", "price-changes", "add", "--id", "rec-1", "--effective-date", "2027-01-01", "--line", "0", "--unit-price", "10"}, want: "line must be positive"},
+		{name: "price change delete missing change id", args: []string{"recurring-invoices", "price-changes", "delete", "--id", "rec-1"}, want: "change-id is required"},
 	}
-	if status == "REJECTED" {
-		payload["rejection_reason"] = "Need project code"
-	}
-	return payload
-}
-
-func cliExpenseRemediationCode(status string) string {
-	switch status {
-	case "SUBMITTED":
-		return "expense_approve_or_reject"
-	case "APPROVED":
-		return "expense_post_to_ledger"
-	case "REJECTED":
-		return "expense_rejection_review"
-	case "POSTED":
-		return "expense_posted_archive"
-	default:
-		return "expense_submit_for_approval"
+	for _, tc := range validationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := app.run(context.Background(), tc.args)
+			require.ErrorContains(t, err, tc.want)
+		})
 	}
 }
 
-func TestCLIExpenseCommands(t *testing.T) {
+func TestCLIRecurringInvoiceBranches(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -11485,54 +11947,162 @@ func TestCLIExpenseCommands(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	importFile := writeTempCSV(t, "expenses.csv", "expense_number,expense_date,merchant,expense_account_id,payment_account_id,amount,status\nEXP-IMP-1,2026-05-30,Office Store,expense-account,cash-account,120.50,DRAFT\n")
-
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
-
-		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
-			require.Equal(t, "DRAFT", r.URL.Query().Get("status"))
-			require.Equal(t, "25", r.URL.Query().Get("limit"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{cliExpensePayload("DRAFT")})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
-			var req expenses.CreateExpenseRequest
+	app, stdout, _ := newTestCLIApp()
+	validationCases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: []string{"recurring-invoices"}, want: "recurring-invoices subcommand required"},
+		{name: "unknown subcommand", args: []string{"recurring-invoices", "unknown"}, want: `unknown recurring-invoices subcommand "unknown"`},
+		{name: "list bad flag", args: []string{"recurring-invoices", "list", "--unknown"}, want: "flag provided but not defined"},
+		{name: "create missing name", args: []string{"recurring-invoices", "create", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "name is required"},
+		{name: "create missing contact", args: []string{"recurring-invoices", "create", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "contact-id is required"},
+		{name: "create invalid type", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--type", "memo", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "invalid invoice type"},
+		{name: "create missing frequency", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--start-date", "2026-05-01", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "frequency is required"},
+		{name: "create bad start date", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "bad", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "parse start-date"},
+		{name: "create bad end date", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--end-date", "bad", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "parse end-date"},
+		{name: "create bad payment terms", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--payment-terms-days", "-1", "--line", "description=Work,quantity=1,unit_price=100,vat_rate=22"}, want: "payment-terms-days must be non-negative"},
+		{name: "create missing line", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01"}, want: "at least one line is required"},
+		{name: "create bad line", args: []string{"recurring-invoices", "create", "--name", "Branch", "--contact-id", "contact-branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--line", "description=Work,quantity=0,unit_price=100,vat_rate=22"}, want: "line quantity"},
+		{name: "import missing file", args: []string{"recurring-invoices", "import"}, want: "file is required"},
+		{name: "import unreadable file", args: []string{"recurring-invoices", "import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "read file"},
+		{name: "from invoice missing invoice", args: []string{"recurring-invoices", "from-invoice", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01"}, want: "invoice-id is required"},
+		{name: "from invoice missing name", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--frequency", "monthly", "--start-date", "2026-05-01"}, want: "name is required"},
+		{name: "from invoice invalid frequency", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "daily", "--start-date", "2026-05-01"}, want: "invalid recurring invoice frequency"},
+		{name: "from invoice bad start date", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "monthly", "--start-date", "bad"}, want: "parse start-date"},
+		{name: "from invoice bad end date", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--end-date", "bad"}, want: "parse end-date"},
+		{name: "from invoice bad payment terms", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-branch", "--name", "Branch", "--frequency", "monthly", "--start-date", "2026-05-01", "--payment-terms-days", "-1"}, want: "payment-terms-days must be non-negative"},
+		{name: "get missing id", args: []string{"recurring-invoices", "get", "--id", " "}, want: "id is required"},
+		{name: "update missing id", args: []string{"recurring-invoices", "update", "--name", "Branch"}, want: "id is required"},
+		{name: "update invalid frequency", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--frequency", "daily"}, want: "invalid recurring invoice frequency"},
+		{name: "update bad end date", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--end-date", "bad"}, want: "parse end-date"},
+		{name: "update bad payment terms", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--payment-terms-days", "-1"}, want: "payment-terms-days must be non-negative"},
+		{name: "update bad send email bool", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--send-email", "sometimes"}, want: "parse send-email"},
+		{name: "update bad attach pdf bool", args: []string{"recurring-invoices", "update", "--id", "rec-branch", "--attach-pdf", "sometimes"}, want: "parse attach-pdf"},
+		{name: "delete missing id", args: []string{"recurring-invoices", "delete", "--id", " "}, want: "id is required"},
+		{name: "pause missing id", args: []string{"recurring-invoices", "pause", "--id", " "}, want: "id is required"},
+		{name: "resume missing id", args: []string{"recurring-invoices", "resume", "--id", " "}, want: "id is required"},
+		{name: "generate missing id", args: []string{"recurring-invoices", "generate", "--id", " "}, want: "id is required"},
+		{name: "generate due bad flag", args: []string{"recurring-invoices", "generate-due", "--unknown"}, want: "flag provided but not defined"},
+	}
+	for _, tc := range validationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+
+	generationPayload := recurringGenerationPayload("rec-branch")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			assert.Empty(t, r.URL.Query().Get("active_only"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{recurringInvoicePayload("rec-branch", "Branch retainer", false)})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			var req recurring.CreateRecurringInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Office Store", req.Merchant)
-			assert.Equal(t, "2026-05-30", req.ExpenseDate.Format("2006-01-02"))
-			assert.Equal(t, "expense-account", req.ExpenseAccountID)
-			assert.Equal(t, "cash-account", req.PaymentAccountID)
-			assert.True(t, req.Amount.Equal(decimal.RequireFromString("120.50")))
-			require.NotNil(t, req.EmployeeID)
-			require.NotNil(t, req.ContactID)
-			require.NotNil(t, req.RequiresReceipt)
-			assert.False(t, *req.RequiresReceipt)
+			assert.Equal(t, "Branch retainer", req.Name)
+			assert.Equal(t, "contact-branch", req.ContactID)
+			assert.Equal(t, "PURCHASE", req.InvoiceType)
+			assert.Equal(t, "GBP", req.Currency)
+			assert.Equal(t, recurring.FrequencyYearly, req.Frequency)
+			assert.Equal(t, "2026-05-01", req.StartDate.Format("2006-01-02"))
+			require.Nil(t, req.EndDate)
+			assert.Equal(t, 0, req.PaymentTermsDays)
+			assert.Equal(t, "BR-1", req.Reference)
+			assert.Equal(t, "Branch notes", req.Notes)
+			assert.True(t, req.SendEmailOnGeneration)
+			assert.Equal(t, "REMINDER", req.EmailTemplateType)
+			assert.Equal(t, "branch@example.com", req.RecipientEmailOverride)
+			require.NotNil(t, req.AttachPDFToEmail)
+			assert.False(t, *req.AttachPDFToEmail)
+			assert.Equal(t, "Branch subject", req.EmailSubjectOverride)
+			assert.Equal(t, "Branch message", req.EmailMessage)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "Branch work", req.Lines[0].Description)
+			assert.Equal(t, "day", req.Lines[0].Unit)
+			assert.True(t, req.Lines[0].Quantity.Equal(decimal.RequireFromString("1")))
+			assert.True(t, req.Lines[0].UnitPrice.Equal(decimal.RequireFromString("200")))
+			assert.True(t, req.Lines[0].VATRate.Equal(decimal.RequireFromString("20")))
 			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("DRAFT"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/import":
-			var req expenses.ImportExpensesRequest
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-branch", "Branch retainer", true))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/import":
+			var req recurring.ImportRecurringInvoicesRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "expenses.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "EXP-IMP-1")
-			_ = json.NewEncoder(w).Encode(expenses.ImportExpensesResult{
-				FileName:        "expenses.csv",
-				RowsProcessed:   1,
-				ExpensesCreated: 1,
+			assert.Equal(t, "branch-recurring.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "Branch Legacy")
+			_ = json.NewEncoder(w).Encode(recurring.ImportRecurringInvoicesResult{
+				FileName:         req.FileName,
+				RowsProcessed:    2,
+				TemplatesCreated: 1,
+				LinesImported:    1,
+				RowsSkipped:      1,
+				Errors: []recurring.ImportRecurringInvoicesRowError{{
+					Row:     2,
+					Message: "missing contact",
+				}},
 			})
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1":
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("SUBMITTED"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/submit":
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("SUBMITTED"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/approve":
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("APPROVED"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/post":
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("POSTED"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/reject":
-			var req expenses.RejectExpenseRequest
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/from-invoice/inv-branch":
+			var req recurring.CreateFromInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Need project code", req.Reason)
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("REJECTED"))
+			assert.Equal(t, "From branch", req.Name)
+			assert.Equal(t, recurring.FrequencyQuarterly, req.Frequency)
+			assert.Equal(t, "2026-06-01", req.StartDate.Format("2006-01-02"))
+			require.NotNil(t, req.EndDate)
+			assert.Equal(t, "2026-12-31", req.EndDate.Format("2006-01-02"))
+			assert.Equal(t, 45, req.PaymentTermsDays)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-from-branch", "From branch", true))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch":
+			_ = json.NewEncoder(w).Encode(recurringInvoicePayload("rec-branch", "Branch retainer", true))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch":
+			var req recurring.UpdateRecurringInvoiceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.NotNil(t, req.Name)
+			assert.Equal(t, "Updated branch", *req.Name)
+			require.NotNil(t, req.ContactID)
+			assert.Equal(t, "contact-updated", *req.ContactID)
+			require.NotNil(t, req.Frequency)
+			assert.Equal(t, recurring.FrequencyBiweekly, *req.Frequency)
+			require.NotNil(t, req.EndDate)
+			assert.Equal(t, "2026-11-30", req.EndDate.Format("2006-01-02"))
+			require.NotNil(t, req.PaymentTermsDays)
+			assert.Equal(t, 7, *req.PaymentTermsDays)
+			require.NotNil(t, req.Reference)
+			assert.Equal(t, "UPDATED", *req.Reference)
+			require.NotNil(t, req.Notes)
+			assert.Equal(t, "Updated notes", *req.Notes)
+			require.NotNil(t, req.SendEmailOnGeneration)
+			assert.True(t, *req.SendEmailOnGeneration)
+			require.NotNil(t, req.EmailTemplateType)
+			assert.Equal(t, "FOLLOW_UP", *req.EmailTemplateType)
+			require.NotNil(t, req.RecipientEmailOverride)
+			assert.Equal(t, "updated@example.com", *req.RecipientEmailOverride)
+			require.NotNil(t, req.AttachPDFToEmail)
+			assert.True(t, *req.AttachPDFToEmail)
+			require.NotNil(t, req.EmailSubjectOverride)
+			assert.Equal(t, "Updated subject", *req.EmailSubjectOverride)
+			require.NotNil(t, req.EmailMessage)
+			assert.Equal(t, "Updated message", *req.EmailMessage)
+			require.Len(t, req.Lines, 1)
+			assert.Equal(t, "Updated branch work", req.Lines[0].Description)
+			payload := recurringInvoicePayload("rec-branch", "Updated branch", true)
+			payload["frequency"] = "BIWEEKLY"
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch/pause":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "paused"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch/generate":
+			_ = json.NewEncoder(w).Encode(generationPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/generate-due":
+			_ = json.NewEncoder(w).Encode([]map[string]any{})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-branch":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
@@ -11540,68 +12110,113 @@ func TestCLIExpenseCommands(t *testing.T) {
 	defer server.Close()
 
 	t.Setenv("OA_BASE_URL", server.URL)
-	app, stdout, _ := newTestCLIApp()
 
-	err := app.run(context.Background(), []string{"expenses", "list", "--status", "draft", "--limit", "25", "--json"})
+	stdout.Reset()
+	err := app.run(context.Background(), []string{"recurring-invoices", "list"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"expense_number": "EXP-00001"`)
-	assert.Contains(t, stdout.String(), `"remediation_actions": [`)
+	assert.Contains(t, stdout.String(), "ID")
+	assert.Contains(t, stdout.String(), "Branch retainer")
 
 	stdout.Reset()
 	err = app.run(context.Background(), []string{
-		"expenses", "create",
-		"--merchant", "Office Store",
-		"--description", "Printer toner",
-		"--expense-date", "2026-05-30",
-		"--employee-id", "employee-1",
-		"--contact-id", "supplier-1",
-		"--expense-account-id", "expense-account",
-		"--payment-account-id", "cash-account",
-		"--amount", "120.50",
-		"--requires-receipt=false",
+		"recurring-invoices", "create",
+		"--name", " Branch retainer ",
+		"--contact-id", " contact-branch ",
+		"--type", " purchase ",
+		"--currency", " gbp ",
+		"--frequency", " yearly ",
+		"--start-date", "2026-05-01",
+		"--payment-terms-days", "0",
+		"--reference", " BR-1 ",
+		"--notes", " Branch notes ",
+		"--send-email",
+		"--email-template-type", " REMINDER ",
+		"--recipient-email", " branch@example.com ",
+		"--attach-pdf=false",
+		"--email-subject", " Branch subject ",
+		"--email-message", " Branch message ",
+		"--line", "description=Branch work,quantity=1,unit=day,unit_price=200,vat_rate=20,account=acc-branch,product=prod-branch",
+		"--json",
 	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Created expense EXP-00001 (expense-1)")
-
-	stdout.Reset()
-	err = app.run(context.Background(), []string{"expenses", "import", "--file", importFile})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 expenses, skipped 0 rows")
+	assert.Contains(t, stdout.String(), `"id": "rec-branch"`)
+	assert.Contains(t, stdout.String(), `"name": "Branch retainer"`)
 
+	importFile := writeTempCSV(t, "branch-recurring.csv", "name,contact_id,frequency,start_date,line_description,quantity,unit_price,vat_rate\nBranch Legacy,contact-branch,MONTHLY,2026-05-01,Work,1,100,22\n")
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"expenses", "get", "--id", "expense-1"})
+	err = app.run(context.Background(), []string{"recurring-invoices", "import", "--file", importFile, "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Expense EXP-00001 Office Store")
-	assert.Contains(t, stdout.String(), "Expense remediation actions")
-	assert.Contains(t, stdout.String(), "expense_approve_or_reject")
+	assert.Contains(t, stdout.String(), `"templates_created": 1`)
+	assert.Contains(t, stdout.String(), `"message": "missing contact"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"expenses", "submit", "--id", "expense-1"})
+	err = app.run(context.Background(), []string{
+		"recurring-invoices", "from-invoice",
+		"--invoice-id", " inv-branch ",
+		"--name", " From branch ",
+		"--frequency", " quarterly ",
+		"--start-date", "2026-06-01",
+		"--end-date", "2026-12-31",
+		"--payment-terms-days", "45",
+		"--json",
+	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Submitted expense expense-1")
+	assert.Contains(t, stdout.String(), `"id": "rec-from-branch"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"expenses", "approve", "--id", "expense-1"})
+	err = app.run(context.Background(), []string{"recurring-invoices", "get", "--id", " rec-branch ", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Approved expense expense-1")
+	assert.Contains(t, stdout.String(), `"id": "rec-branch"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"expenses", "post", "--id", "expense-1"})
+	err = app.run(context.Background(), []string{
+		"recurring-invoices", "update",
+		"--id", " rec-branch ",
+		"--name", " Updated branch ",
+		"--contact-id", " contact-updated ",
+		"--frequency", " biweekly ",
+		"--end-date", "2026-11-30",
+		"--payment-terms-days", "7",
+		"--reference", " UPDATED ",
+		"--notes", " Updated notes ",
+		"--send-email", "true",
+		"--email-template-type", " FOLLOW_UP ",
+		"--recipient-email", " updated@example.com ",
+		"--attach-pdf", "true",
+		"--email-subject", " Updated subject ",
+		"--email-message", " Updated message ",
+		"--line", "description=Updated branch work,quantity=2,unit_price=150,vat_rate=22",
+		"--json",
+	})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Posted expense expense-1")
+	assert.Contains(t, stdout.String(), `"frequency": "BIWEEKLY"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"expenses", "reject", "--id", "expense-1", "--reason", "Need project code"})
+	err = app.run(context.Background(), []string{"recurring-invoices", "pause", "--id", " rec-branch ", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Rejected expense expense-1")
+	assert.Contains(t, stdout.String(), `"status": "paused"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "generate", "--id", " rec-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"recurring_invoice_id": "rec-branch"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "generate-due", "--json"})
+	require.NoError(t, err)
+	assert.Equal(t, "[]\n", stdout.String())
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"recurring-invoices", "delete", "--id", " rec-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "deleted"`)
 }
 
-func TestCLIExpenseBranches(t *testing.T) {
+func TestCLIRecurringInvoiceFlagAndAPIErrors(t *testing.T) {
 	configureCLIEnv(t)
-	app, stdout, _ := newTestCLIApp()
-	ctx := context.Background()
 
-	err := app.run(ctx, []string{"expenses", "list"})
+	app, stdout, _ := newTestCLIApp()
+	err := app.run(context.Background(), []string{"recurring-invoices", "list"})
 	require.Error(t, err)
 	assert.ErrorContains(t, err, "no API token configured")
 	assert.Empty(t, stdout.String())
@@ -11614,157 +12229,105 @@ func TestCLIExpenseBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	for _, tc := range []struct {
+	badFlagCases := []struct {
 		name string
 		args []string
-		want string
 	}{
-		{name: "missing subcommand", args: nil, want: "expenses subcommand required"},
-		{name: "unknown subcommand", args: []string{"archive"}, want: `unknown expenses subcommand "archive"`},
-		{name: "list bad flag", args: []string{"list", "--unknown"}, want: "flag provided but not defined"},
-		{name: "list invalid status", args: []string{"list", "--status", "waiting"}, want: `invalid expense status "waiting"`},
-		{name: "create bad flag", args: []string{"create", "--unknown"}, want: "flag provided but not defined"},
-		{name: "create missing merchant", args: []string{"create"}, want: "merchant is required"},
-		{name: "create missing expense account", args: []string{"create", "--merchant", "Office Store"}, want: "expense-account-id is required"},
-		{name: "create missing payment account", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account"}, want: "payment-account-id is required"},
-		{name: "create missing date", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account"}, want: "expense-date is required"},
-		{name: "create invalid date", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026/05/30"}, want: "parse expense-date"},
-		{name: "create missing amount", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30"}, want: "amount is required"},
-		{name: "create non-positive amount", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30", "--amount", "0"}, want: "amount must be positive"},
-		{name: "create invalid exchange rate", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30", "--amount", "10", "--exchange-rate", "many"}, want: "parse exchange-rate"},
-		{name: "create non-positive exchange rate", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30", "--amount", "10", "--exchange-rate", "0"}, want: "exchange-rate must be positive"},
-		{name: "import bad flag", args: []string{"import", "--unknown"}, want: "flag provided but not defined"},
-		{name: "import missing file", args: []string{"import"}, want: "file is required"},
-		{name: "import unreadable file", args: []string{"import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "no such file"},
-		{name: "get bad flag", args: []string{"get", "--unknown"}, want: "flag provided but not defined"},
-		{name: "get missing id", args: []string{"get"}, want: "id is required"},
-		{name: "submit bad flag", args: []string{"submit", "--unknown"}, want: "flag provided but not defined"},
-		{name: "submit missing id", args: []string{"submit"}, want: "id is required"},
-		{name: "approve missing id", args: []string{"approve"}, want: "id is required"},
-		{name: "post missing id", args: []string{"post"}, want: "id is required"},
-		{name: "reject bad flag", args: []string{"reject", "--unknown"}, want: "flag provided but not defined"},
-		{name: "reject missing id", args: []string{"reject"}, want: "id is required"},
-		{name: "reject missing reason", args: []string{"reject", "--id", "expense-1"}, want: "reason is required"},
-	} {
+		{name: "import bad flag", args: []string{"recurring-invoices", "import", "--bad"}},
+		{name: "from invoice bad flag", args: []string{"recurring-invoices", "from-invoice", "--bad"}},
+		{name: "get bad flag", args: []string{"recurring-invoices", "get", "--bad"}},
+		{name: "update bad flag", args: []string{"recurring-invoices", "update", "--bad"}},
+		{name: "delete bad flag", args: []string{"recurring-invoices", "delete", "--bad"}},
+		{name: "pause bad flag", args: []string{"recurring-invoices", "pause", "--bad"}},
+		{name: "resume bad flag", args: []string{"recurring-invoices", "resume", "--bad"}},
+		{name: "generate bad flag", args: []string{"recurring-invoices", "generate", "--bad"}},
+	}
+	for _, tc := range badFlagCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := app.runExpenses(ctx, tc.args)
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
 			require.Error(t, err)
-			assert.ErrorContains(t, err, tc.want)
+			assert.ErrorContains(t, err, "flag provided but not defined")
+			assert.Empty(t, stdout.String())
 		})
 	}
 
-	importFile := writeTempCSV(t, "expenses-json.csv", "expense_number,expense_date,merchant,expense_account_id,payment_account_id,amount,status\nEXP-IMP-2,2026-05-31,Hotel,expense-account,cash-account,99.50,SUBMITTED\n")
-
+	importFile := writeTempCSV(t, "recurring-error.csv", "name,contact_id,frequency,start_date,line_description,quantity,unit_price,vat_rate\nError Legacy,contact-error,MONTHLY,2026-07-01,Work,1,100,22\n")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
-			require.Empty(t, r.URL.Query().Get("status"))
-			require.Equal(t, "100", r.URL.Query().Get("limit"))
-			postedPayload := cliExpensePayload("POSTED")
-			postedPayload["journal_entry_id"] = "je-branch"
-			postedPayload["requires_receipt"] = true
-			_ = json.NewEncoder(w).Encode([]map[string]any{postedPayload})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
-			var req expenses.CreateExpenseRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			assert.Equal(t, "true", r.URL.Query().Get("active_only"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices":
+			var req recurring.CreateRecurringInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Office Store", req.Merchant)
-			assert.Equal(t, "Trimmed description", req.Description)
-			assert.Nil(t, req.EmployeeID)
-			assert.Nil(t, req.ContactID)
-			assert.Equal(t, "expense-account", req.ExpenseAccountID)
-			assert.Equal(t, "cash-account", req.PaymentAccountID)
-			assert.Equal(t, "USD", req.Currency)
-			assert.True(t, req.ExchangeRate.Equal(decimal.RequireFromString("1.25")))
-			require.NotNil(t, req.RequiresReceipt)
-			assert.True(t, *req.RequiresReceipt)
-			w.WriteHeader(http.StatusCreated)
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("DRAFT"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/import":
-			var req expenses.ImportExpensesRequest
+			assert.Equal(t, "Error retainer", req.Name)
+			assert.Equal(t, "contact-error", req.ContactID)
+			assert.Equal(t, recurring.FrequencyMonthly, req.Frequency)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/import":
+			var req recurring.ImportRecurringInvoicesRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "expenses-json.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "EXP-IMP-2")
-			_ = json.NewEncoder(w).Encode(expenses.ImportExpensesResult{
-				FileName:        "expenses-json.csv",
-				RowsProcessed:   2,
-				ExpensesCreated: 1,
-				RowsSkipped:     1,
-				Errors: []expenses.ImportExpensesRowError{
-					{Row: 2, ExpenseNumber: "EXP-IMP-ERR", Merchant: "Hotel", Message: "duplicate expense number"},
-				},
-			})
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1":
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("APPROVED"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/submit":
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("SUBMITTED"))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/reject":
-			var req expenses.RejectExpenseRequest
+			assert.Equal(t, "recurring-error.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "Error Legacy")
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/from-invoice/inv-error":
+			var req recurring.CreateFromInvoiceRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Need project code", req.Reason)
-			_ = json.NewEncoder(w).Encode(cliExpensePayload("REJECTED"))
+			assert.Equal(t, "From error", req.Name)
+			assert.Equal(t, recurring.FrequencyMonthly, req.Frequency)
+			assert.Equal(t, "2026-07-01", req.StartDate.Format("2006-01-02"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error":
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error":
+			var req recurring.UpdateRecurringInvoiceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.NotNil(t, req.Name)
+			assert.Equal(t, "Error updated", *req.Name)
+			require.NotNil(t, req.PaymentTermsDays)
+			assert.Equal(t, 10, *req.PaymentTermsDays)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error/pause":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error/resume":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/rec-error/generate":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/recurring-invoices/generate-due":
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "recurring backend unavailable"})
 	}))
 	defer server.Close()
 
 	t.Setenv("OA_BASE_URL", server.URL)
-
-	err = app.run(ctx, []string{"expenses", "list"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "EXP-00001")
-	assert.Contains(t, stdout.String(), "POSTED")
-	assert.Contains(t, stdout.String(), "je-branch")
-
-	stdout.Reset()
-	err = app.run(ctx, []string{
-		"expenses", "create",
-		"--merchant", " Office Store ",
-		"--description", " Trimmed description ",
-		"--expense-date", "2026-05-30",
-		"--expense-account-id", " expense-account ",
-		"--payment-account-id", " cash-account ",
-		"--amount", "120.50",
-		"--currency", " usd ",
-		"--exchange-rate", "1.25",
-		"--json",
-	})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"expense_number": "EXP-00001"`)
-	assert.Contains(t, stdout.String(), `"status": "DRAFT"`)
-	assert.Contains(t, stdout.String(), `"code": "expense_submit_for_approval"`)
-
-	stdout.Reset()
-	err = app.run(ctx, []string{"expenses", "import", "--file", importFile, "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"file_name": "expenses-json.csv"`)
-	assert.Contains(t, stdout.String(), `"rows_skipped": 1`)
-	assert.Contains(t, stdout.String(), `"message": "duplicate expense number"`)
-
-	stdout.Reset()
-	err = app.run(ctx, []string{"expenses", "get", "--id", " expense-1 ", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "APPROVED"`)
-	assert.Contains(t, stdout.String(), `"code": "expense_post_to_ledger"`)
-
-	stdout.Reset()
-	err = app.run(ctx, []string{"expenses", "submit", "--id", " expense-1 ", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "SUBMITTED"`)
-	assert.Contains(t, stdout.String(), `"code": "expense_approve_or_reject"`)
-
-	stdout.Reset()
-	err = app.run(ctx, []string{"expenses", "reject", "--id", " expense-1 ", "--reason", " Need project code ", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "REJECTED"`)
-	assert.Contains(t, stdout.String(), `"rejection_reason": "Need project code"`)
-	assert.Contains(t, stdout.String(), `"code": "expense_rejection_review"`)
+	apiErrorCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "list api error", args: []string{"recurring-invoices", "list", "--active-only"}},
+		{name: "create api error", args: []string{"recurring-invoices", "create", "--name", "Error retainer", "--contact-id", "contact-error", "--frequency", "monthly", "--start-date", "2026-07-01", "--line", "description=Error work,quantity=1,unit_price=100,vat_rate=22"}},
+		{name: "import api error", args: []string{"recurring-invoices", "import", "--file", importFile}},
+		{name: "from invoice api error", args: []string{"recurring-invoices", "from-invoice", "--invoice-id", "inv-error", "--name", "From error", "--frequency", "monthly", "--start-date", "2026-07-01"}},
+		{name: "get api error", args: []string{"recurring-invoices", "get", "--id", "rec-error"}},
+		{name: "update api error", args: []string{"recurring-invoices", "update", "--id", "rec-error", "--name", "Error updated", "--payment-terms-days", "10"}},
+		{name: "delete api error", args: []string{"recurring-invoices", "delete", "--id", "rec-error"}},
+		{name: "pause api error", args: []string{"recurring-invoices", "pause", "--id", "rec-error"}},
+		{name: "resume api error", args: []string{"recurring-invoices", "resume", "--id", "rec-error"}},
+		{name: "generate api error", args: []string{"recurring-invoices", "generate", "--id", "rec-error"}},
+		{name: "generate due api error", args: []string{"recurring-invoices", "generate-due"}},
+	}
+	for _, tc := range apiErrorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "recurring backend unavailable")
+			assert.Empty(t, stdout.String())
+		})
+	}
 }
 
-func TestCLIExpenseAPIErrorBranches(t *testing.T) {
+func TestCLIAssetCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -11774,15 +12337,1559 @@ func TestCLIExpenseAPIErrorBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	importFile := writeTempCSV(t, "expenses-error.csv", "expense_number,expense_date,merchant,expense_account_id,payment_account_id,amount,status\nEXP-ERR-1,2026-06-01,Taxi,expense-account,cash-account,35.25,DRAFT\n")
-	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
-
-		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
-			assert.Equal(t, "SUBMITTED", r.URL.Query().Get("status"))
-			assert.Equal(t, "7", r.URL.Query().Get("limit"))
+	categoryPayload := map[string]any{
+		"id":                                  "cat-1",
+		"tenant_id":                           "tenant-1",
+		"name":                                "Equipment",
+		"description":                         "Office equipment",
+		"depreciation_method":                 "STRAIGHT_LINE",
+		"default_useful_life_months":          60,
+		"default_residual_value_percent":      "10.00",
+		"asset_account_id":                    "acc-asset",
+		"depreciation_expense_account_id":     "acc-expense",
+		"accumulated_depreciation_account_id": "acc-accum",
+		"created_at":                          "2026-03-15T12:00:00Z",
+		"updated_at":                          "2026-03-15T12:00:00Z",
+	}
+	assetPayload := func(status string) map[string]any {
+		return map[string]any{
+			"id":                                  "asset-1",
+			"tenant_id":                           "tenant-1",
+			"asset_number":                        "FA-00001",
+			"name":                                "Laptop",
+			"description":                         "Developer laptop",
+			"category_id":                         "cat-1",
+			"status":                              status,
+			"purchase_date":                       "2026-03-15T00:00:00Z",
+			"purchase_cost":                       "1200.00",
+			"supplier_id":                         "supplier-1",
+			"serial_number":                       "SN-1",
+			"location":                            "Tallinn",
+			"depreciation_method":                 "STRAIGHT_LINE",
+			"useful_life_months":                  36,
+			"residual_value":                      "100.00",
+			"depreciation_start_date":             "2026-04-01T00:00:00Z",
+			"accumulated_depreciation":            "50.00",
+			"book_value":                          "1150.00",
+			"last_depreciation_date":              "2026-04-30T00:00:00Z",
+			"asset_account_id":                    "acc-asset",
+			"depreciation_expense_account_id":     "acc-expense",
+			"accumulated_depreciation_account_id": "acc-accum",
+			"created_at":                          "2026-03-15T12:00:00Z",
+			"created_by":                          "user-1",
+			"updated_at":                          "2026-03-15T12:00:00Z",
+		}
+	}
+	depreciationPayload := map[string]any{
+		"id":                  "dep-1",
+		"tenant_id":           "tenant-1",
+		"asset_id":            "asset-1",
+		"depreciation_date":   "2026-04-30T00:00:00Z",
+		"period_start":        "2026-04-01T00:00:00Z",
+		"period_end":          "2026-04-30T00:00:00Z",
+		"depreciation_amount": "25.00",
+		"accumulated_total":   "75.00",
+		"book_value_after":    "1125.00",
+		"created_at":          "2026-04-30T12:00:00Z",
+		"created_by":          "user-1",
+	}
+	importFile := writeTempCSV(t, "assets.csv", "asset_number,name,purchase_date,purchase_cost\nLEG-001,Laptop,2025-01-10,1200.00\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
+			_ = json.NewEncoder(w).Encode([]map[string]any{categoryPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
+			var req assets.CreateCategoryRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Equipment", req.Name)
+			assert.Equal(t, assets.DepreciationStraightLine, req.DepreciationMethod)
+			assert.Equal(t, 60, req.DefaultUsefulLifeMonths)
+			assert.True(t, req.DefaultResidualValuePercent.Equal(decimal.RequireFromString("10.00")))
+			require.NotNil(t, req.AssetAccountID)
+			assert.Equal(t, "acc-asset", *req.AssetAccountID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(categoryPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-1":
+			_ = json.NewEncoder(w).Encode(categoryPayload)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
+			require.Equal(t, "ACTIVE", r.URL.Query().Get("status"))
+			require.Equal(t, "cat-1", r.URL.Query().Get("category_id"))
+			require.Equal(t, "Laptop", r.URL.Query().Get("search"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{assetPayload("ACTIVE")})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
+			var req assets.CreateAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Laptop", req.Name)
+			assert.Equal(t, "2026-03-15", req.PurchaseDate.Format("2006-01-02"))
+			assert.True(t, req.PurchaseCost.Equal(decimal.RequireFromString("1200.00")))
+			assert.Equal(t, assets.DepreciationStraightLine, req.DepreciationMethod)
+			assert.Equal(t, 36, req.UsefulLifeMonths)
+			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("100.00")))
+			require.NotNil(t, req.DepreciationStartDate)
+			assert.Equal(t, "2026-04-01", req.DepreciationStartDate.Format("2006-01-02"))
+			require.NotNil(t, req.CategoryID)
+			require.NotNil(t, req.SupplierID)
+			assert.Equal(t, "cat-1", *req.CategoryID)
+			assert.Equal(t, "supplier-1", *req.SupplierID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(assetPayload("DRAFT"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/import":
+			var req assets.ImportAssetsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "assets.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "LEG-001")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"rows_processed": 1,
+				"assets_created": 1,
+				"rows_skipped":   0,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1":
+			_ = json.NewEncoder(w).Encode(assetPayload("ACTIVE"))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1":
+			var req assets.UpdateAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Updated laptop", req.Name)
+			assert.Equal(t, "Tartu", req.Location)
+			assert.Equal(t, 48, req.UsefulLifeMonths)
+			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("150.00")))
+			_ = json.NewEncoder(w).Encode(assetPayload("ACTIVE"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/activate":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "active"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/dispose":
+			var req assets.DisposeAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-05-01", req.DisposalDate.Format("2006-01-02"))
+			assert.Equal(t, assets.DisposalSold, req.DisposalMethod)
+			assert.True(t, req.DisposalProceeds.Equal(decimal.RequireFromString("900.00")))
+			assert.Equal(t, "Sold to employee", req.DisposalNotes)
+			require.NotNil(t, req.DisposalProceedsAccountID)
+			assert.Equal(t, "cash-account", *req.DisposalProceedsAccountID)
+			require.NotNil(t, req.DisposalGainLossAccountID)
+			assert.Equal(t, "asset-disposal-gain", *req.DisposalGainLossAccountID)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "disposed"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/depreciation":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(depreciationPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/depreciation":
+			_ = json.NewEncoder(w).Encode([]map[string]any{depreciationPayload})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{"assets", "categories", "list", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"name": "Equipment"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"assets", "categories", "create",
+		"--name", "Equipment",
+		"--description", "Office equipment",
+		"--depreciation-method", "straight_line",
+		"--useful-life-months", "60",
+		"--residual-percent", "10.00",
+		"--asset-account-id", "acc-asset",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created asset category Equipment (cat-1)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "categories", "get", "--id", "cat-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Asset category Equipment")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "categories", "delete", "--id", "cat-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Deleted asset category cat-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "list", "--status", "active", "--category-id", "cat-1", "--search", "Laptop", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"asset_number": "FA-00001"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"assets", "create",
+		"--name", "Laptop",
+		"--description", "Developer laptop",
+		"--category-id", "cat-1",
+		"--purchase-date", "2026-03-15",
+		"--purchase-cost", "1200.00",
+		"--supplier-id", "supplier-1",
+		"--serial-number", "SN-1",
+		"--location", "Tallinn",
+		"--depreciation-method", "straight_line",
+		"--useful-life-months", "36",
+		"--residual-value", "100.00",
+		"--depreciation-start-date", "2026-04-01",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created asset FA-00001 (asset-1)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "import", "--file", importFile})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 assets, skipped 0 rows")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "get", "--id", "asset-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Asset FA-00001 Laptop")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"assets", "update",
+		"--id", "asset-1",
+		"--name", "Updated laptop",
+		"--location", "Tartu",
+		"--useful-life-months", "48",
+		"--residual-value", "150.00",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Asset FA-00001")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "activate", "--id", "asset-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Activated asset asset-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"assets", "dispose",
+		"--id", "asset-1",
+		"--disposal-date", "2026-05-01",
+		"--method", "sold",
+		"--proceeds", "900.00",
+		"--proceeds-account-id", "cash-account",
+		"--gain-loss-account-id", "asset-disposal-gain",
+		"--notes", "Sold to employee",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Disposed asset asset-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "depreciate", "--id", "asset-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Recorded depreciation 25")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "depreciation", "--id", "asset-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "dep-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "delete", "--id", "asset-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Deleted asset asset-1")
+}
+
+func TestCLIAssetDepreciationRunCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	runPayload := func(status string) map[string]any {
+		return map[string]any{
+			"id":                "run-1",
+			"tenant_id":         "tenant-1",
+			"period_start":      "2026-04-01T00:00:00Z",
+			"period_end":        "2026-04-30T00:00:00Z",
+			"posting_mode":      "AGGREGATED",
+			"status":            status,
+			"asset_count":       2,
+			"total_amount":      "150.00",
+			"category_totals":   []map[string]any{{"category_name": "Equipment", "asset_count": 2, "amount": "150.00"}},
+			"journal_entry_ids": []string{"je-1"},
+			"created_by":        "user-1",
+			"created_at":        "2026-05-01T05:00:00Z",
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/depreciation-runs/preview":
+			assert.Equal(t, "2026", r.URL.Query().Get("year"))
+			assert.Equal(t, "4", r.URL.Query().Get("month"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"period_start":    "2026-04-01T00:00:00Z",
+				"period_end":      "2026-04-30T00:00:00Z",
+				"asset_count":     2,
+				"total_amount":    "150.00",
+				"category_totals": []map[string]any{{"category_name": "Equipment", "asset_count": 2, "amount": "150.00"}},
+				"lines":           []map[string]any{},
+				"skipped":         []map[string]any{{"asset_id": "asset-3", "asset_number": "FA-00003", "asset_name": "Van", "reason": "fully depreciated"}},
+				"issues":          []string{"asset FA-00004 has no depreciation expense or accumulated depreciation account"},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/depreciation-runs":
+			assert.Equal(t, "2026", r.URL.Query().Get("year"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{runPayload("POSTED")})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/depreciation-runs":
+			var req assets.CreateDepreciationRunRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, 2026, req.Year)
+			assert.Equal(t, 4, req.Month)
+			assert.Equal(t, assets.DepreciationPostingPerAsset, req.PostingMode)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(runPayload("POSTED"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/depreciation-runs/run-1":
+			_ = json.NewEncoder(w).Encode(runPayload("POSTED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/depreciation-runs/run-1/reverse":
+			var req assets.ReverseDepreciationRunRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Wrong useful life", req.Reason)
+			_ = json.NewEncoder(w).Encode(runPayload("REVERSED"))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+
+	require.NoError(t, app.run(ctx, []string{"assets", "depreciation-runs", "preview", "--year", "2026", "--month", "4"}))
+	assert.Contains(t, stdout.String(), "Total: 150")
+	assert.Contains(t, stdout.String(), "Equipment")
+	assert.Contains(t, stdout.String(), "Skipped FA-00003: fully depreciated")
+	assert.Contains(t, stdout.String(), "Issue: asset FA-00004")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "depreciation-runs", "list", "--year", "2026"}))
+	assert.Contains(t, stdout.String(), "run-1")
+	assert.Contains(t, stdout.String(), "AGGREGATED")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "depreciation-runs", "create", "--year", "2026", "--month", "4", "--posting-mode", "per_asset"}))
+	assert.Contains(t, stdout.String(), "Depreciation run 2026-04 posted 150 for 2 assets (run-1)")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "depreciation-runs", "get", "--id", "run-1"}))
+	assert.Contains(t, stdout.String(), "Journal entries: je-1")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "depreciation-runs", "reverse", "--id", "run-1", "--reason", "Wrong useful life", "--json"}))
+	assert.Contains(t, stdout.String(), `"status": "REVERSED"`)
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: nil, want: "assets depreciation-runs subcommand required"},
+		{args: []string{"rerun"}, want: `unknown assets depreciation-runs subcommand "rerun"`},
+		{args: []string{"preview", "--year", "2026"}, want: "month is required"},
+		{args: []string{"preview", "--year", "2026", "--month", "13"}, want: "month must be between 1 and 12"},
+		{args: []string{"list", "--year", "soon"}, want: "parse year"},
+		{args: []string{"create", "--month", "4"}, want: "year is required"},
+		{args: []string{"get"}, want: "id is required"},
+		{args: []string{"reverse", "--id", "run-1"}, want: "reason is required"},
+	} {
+		err := app.run(ctx, append([]string{"assets", "depreciation-runs"}, tt.args...))
+		require.Error(t, err, tt.args)
+		assert.Contains(t, err.Error(), tt.want, tt.args)
+	}
+}
+
+func TestCLIAssetScheduleAndRegisterCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/depreciation-schedule":
+			if r.URL.Query().Get("format") == "csv" {
+				w.Header().Set("Content-Type", "text/csv")
+				_, _ = w.Write([]byte("asset_number,period_start\nFA-00001,2026-05-01\n"))
+				return
+			}
+			assert.Equal(t, "100000", r.URL.Query().Get("units_total"))
+			assert.Equal(t, "4000", r.URL.Query().Get("units_per_month"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"asset_id":               "asset-1",
+				"asset_number":           "FA-00001",
+				"asset_name":             "Press",
+				"status":                 "ACTIVE",
+				"depreciation_method":    "UNITS_OF_PRODUCTION",
+				"uses_planned_units":     true,
+				"end_of_life_date":       "2030-12-31T00:00:00Z",
+				"book_value":             "11000",
+				"remaining_depreciation": "10000",
+				"lines": []map[string]any{{
+					"period_start":        "2026-05-01T00:00:00Z",
+					"period_end":          "2026-05-31T00:00:00Z",
+					"units":               "4000",
+					"depreciation_amount": "400",
+					"accumulated_total":   "400",
+					"book_value_after":    "10600",
+				}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/reports/fixed-asset-register":
+			assert.Equal(t, "2026-01-01", r.URL.Query().Get("start_date"))
+			assert.Equal(t, "2026-12-31", r.URL.Query().Get("end_date"))
+			if r.URL.Query().Get("format") == "xlsx" {
+				w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+				_, _ = w.Write([]byte("xlsx-bytes"))
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"start_date": "2026-01-01T00:00:00Z",
+				"end_date":   "2026-12-31T00:00:00Z",
+				"categories": []map[string]any{{"category_name": "IT equipment", "asset_count": 2, "opening_cost": "1200", "additions": "900", "closing_cost": "2100", "depreciation_charge": "250", "closing_net_book_value": "1350"}},
+				"assets":     []map[string]any{{"asset_number": "FA-00001"}, {"asset_number": "FA-00002"}},
+				"totals":     map[string]any{"opening_cost": "1200", "additions": "900", "closing_cost": "2100", "depreciation_charge": "250", "closing_net_book_value": "1350"},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+
+	require.NoError(t, app.run(ctx, []string{"assets", "schedule", "--id", "asset-1", "--units-total", "100000", "--units-per-month", "4000"}))
+	assert.Contains(t, stdout.String(), "FA-00001 Press (UNITS_OF_PRODUCTION, ACTIVE)")
+	assert.Contains(t, stdout.String(), "Remaining depreciation: 10000")
+	assert.Contains(t, stdout.String(), "2026-05-01..2026-05-31")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "schedule", "--id", "asset-1", "--csv"}))
+	assert.Contains(t, stdout.String(), "FA-00001,2026-05-01")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"reports", "fixed-asset-register", "--start", "2026-01-01", "--end", "2026-12-31"}))
+	assert.Contains(t, stdout.String(), "Fixed asset register from 2026-01-01 to 2026-12-31")
+	assert.Contains(t, stdout.String(), "IT equipment")
+	assert.Contains(t, stdout.String(), "TOTAL")
+
+	outputPath := filepath.Join(t.TempDir(), "register.xlsx")
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"reports", "fixed-asset-register", "--start", "2026-01-01", "--end", "2026-12-31", "--xlsx", "--output", outputPath}))
+	assert.Contains(t, stdout.String(), "Wrote fixed asset register XLSX to "+outputPath)
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "xlsx-bytes", string(content))
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"assets", "schedule"}, want: "id is required"},
+		{args: []string{"assets", "schedule", "--id", "asset-1", "--units-total", "1000"}, want: "units-per-month"},
+		{args: []string{"assets", "schedule", "--id", "asset-1", "--csv", "--pdf"}, want: "cannot be combined"},
+		{args: []string{"reports", "fixed-asset-register", "--end", "2026-12-31"}, want: "start is required"},
+		{args: []string{"reports", "fixed-asset-register", "--start", "2026-12-31", "--end", "2026-01-01"}, want: "end must be on or after start"},
+	} {
+		err := app.run(ctx, tt.args)
+		require.Error(t, err, tt.args)
+		assert.Contains(t, err.Error(), tt.want, tt.args)
+	}
+}
+
+func TestCLIAssetEventCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	event := map[string]any{
+		"id":                          "event-1",
+		"asset_id":                    "asset-1",
+		"event_type":                  "IMPROVEMENT",
+		"event_date":                  "2026-04-01T00:00:00Z",
+		"amount":                      "1800",
+		"book_value_before":           "3300",
+		"book_value_after":            "5100",
+		"useful_life_before":          36,
+		"useful_life_after":           36,
+		"residual_value_before":       "0",
+		"residual_value_after":        "0",
+		"monthly_depreciation_before": "100",
+		"monthly_depreciation_after":  "154.55",
+		"description":                 "Engine overhaul",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/events":
+			var body map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "IMPROVEMENT", body["event_type"])
+			assert.Equal(t, "1800", body["amount"])
+			assert.Equal(t, "cash-account", body["offset_account_id"])
+			assert.Equal(t, float64(48), body["useful_life_months"])
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(event)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/events":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]map[string]any{event})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-1/history":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"asset_id":     "asset-1",
+				"asset_number": "FA-00001",
+				"asset_name":   "Press",
+				"status":       "ACTIVE",
+				"book_value":   "5100",
+				"lines": []map[string]any{
+					{"date": "2026-01-01T00:00:00Z", "type": "ACQUISITION", "cost_after": "3600", "accumulated_after": "0", "book_value_change": "3600", "book_value_after": "3600", "description": "Acquired"},
+					{"date": "2026-04-01T00:00:00Z", "type": "IMPROVEMENT", "cost_after": "5400", "accumulated_after": "300", "book_value_change": "1800", "book_value_after": "5100", "description": "Improvement: Engine overhaul"},
+				},
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+
+	require.NoError(t, app.run(ctx, []string{"assets", "events", "create", "--id", "asset-1", "--type", "improvement", "--date", "2026-04-01", "--amount", "1800", "--useful-life-months", "48", "--offset-account-id", "cash-account", "--description", "Engine overhaul"}))
+	assert.Contains(t, stdout.String(), "Recorded IMPROVEMENT on asset asset-1: book value 3300.00 -> 5100.00, monthly depreciation 100.00 -> 154.55")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "events", "list", "--id", "asset-1"}))
+	assert.Contains(t, stdout.String(), "MONTHLY DEPRECIATION")
+	assert.Contains(t, stdout.String(), "3300 -> 5100")
+	assert.Contains(t, stdout.String(), "Engine overhaul")
+
+	stdout.Reset()
+	require.NoError(t, app.run(ctx, []string{"assets", "history", "--id", "asset-1"}))
+	assert.Contains(t, stdout.String(), "FA-00001 Press (ACTIVE)")
+	assert.Contains(t, stdout.String(), "Book value: 5100")
+	assert.Contains(t, stdout.String(), "IMPROVEMENT")
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: []string{"assets", "events"}, want: "assets events subcommand required"},
+		{args: []string{"assets", "events", "list"}, want: "id is required"},
+		{args: []string{"assets", "events", "create", "--id", "asset-1", "--type", "REVALUATION", "--date", "2026-04-01"}, want: "invalid asset event type"},
+		{args: []string{"assets", "events", "create", "--id", "asset-1", "--type", "IMPAIRMENT", "--date", "2026-04-01"}, want: "amount is required"},
+		{args: []string{"assets", "events", "create", "--id", "asset-1", "--type", "ESTIMATE_CHANGE", "--date", "2026-04-01", "--amount", "10"}, want: "amount is not used"},
+		{args: []string{"assets", "events", "create", "--id", "asset-1", "--type", "ESTIMATE_CHANGE", "--date", "2026-04-01", "--useful-life-months", "0"}, want: "useful-life-months"},
+		{args: []string{"assets", "history"}, want: "id is required"},
+	} {
+		err := app.run(ctx, tt.args)
+		require.Error(t, err, tt.args)
+		assert.Contains(t, err.Error(), tt.want, tt.args)
+	}
+}
+
+func TestCLIAssetBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: nil, want: "assets subcommand required"},
+		{name: "unknown subcommand", args: []string{"archive"}, want: `unknown assets subcommand "archive"`},
+		{name: "list bad flag", args: []string{"list", "--unknown"}, want: "flag provided but not defined"},
+		{name: "list invalid status", args: []string{"list", "--status", "retired"}, want: `invalid asset status "retired"`},
+		{name: "create bad flag", args: []string{"create", "--unknown"}, want: "flag provided but not defined"},
+		{name: "create missing name", args: []string{"create"}, want: "name is required"},
+		{name: "create missing purchase date", args: []string{"create", "--name", "Laptop"}, want: "purchase-date is required"},
+		{name: "create invalid purchase date", args: []string{"create", "--name", "Laptop", "--purchase-date", "tomorrow"}, want: "parse purchase-date"},
+		{name: "create missing purchase cost", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15"}, want: "purchase-cost is required"},
+		{name: "create invalid purchase cost", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "many"}, want: "parse purchase-cost"},
+		{name: "create nonpositive purchase cost", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "0"}, want: "purchase-cost must be positive"},
+		{name: "create invalid depreciation method", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--depreciation-method", "accelerated"}, want: `invalid depreciation method "accelerated"`},
+		{name: "create invalid useful life", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--useful-life-months", "many"}, want: "parse useful-life-months"},
+		{name: "create nonpositive useful life", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--useful-life-months", "0"}, want: "useful-life-months must be positive"},
+		{name: "create invalid residual value", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--residual-value", "many"}, want: "parse residual-value"},
+		{name: "create negative residual value", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--residual-value", "-1"}, want: "residual-value must be non-negative"},
+		{name: "create invalid depreciation start date", args: []string{"create", "--name", "Laptop", "--purchase-date", "2026-03-15", "--purchase-cost", "1200", "--depreciation-start-date", "next-month"}, want: "parse depreciation-start-date"},
+		{name: "import bad flag", args: []string{"import", "--unknown"}, want: "flag provided but not defined"},
+		{name: "import missing file", args: []string{"import"}, want: "file is required"},
+		{name: "import unreadable file", args: []string{"import", "--file", "/tmp/open-accounting-missing-assets.csv"}, want: "read file"},
+		{name: "get bad flag", args: []string{"get", "--unknown"}, want: "flag provided but not defined"},
+		{name: "get missing id", args: []string{"get"}, want: "id is required"},
+		{name: "update bad flag", args: []string{"update", "--unknown"}, want: "flag provided but not defined"},
+		{name: "update missing id", args: []string{"update"}, want: "id is required"},
+		{name: "update missing name", args: []string{"update", "--id", "asset-branch"}, want: "name is required"},
+		{name: "update invalid depreciation method", args: []string{"update", "--id", "asset-branch", "--name", "Laptop", "--depreciation-method", "accelerated"}, want: `invalid depreciation method "accelerated"`},
+		{name: "update invalid useful life", args: []string{"update", "--id", "asset-branch", "--name", "Laptop", "--useful-life-months", "many"}, want: "parse useful-life-months"},
+		{name: "update negative residual value", args: []string{"update", "--id", "asset-branch", "--name", "Laptop", "--residual-value", "-1"}, want: "residual-value must be non-negative"},
+		{name: "delete bad flag", args: []string{"delete", "--unknown"}, want: "flag provided but not defined"},
+		{name: "delete missing id", args: []string{"delete"}, want: "id is required"},
+		{name: "activate bad flag", args: []string{"activate", "--unknown"}, want: "flag provided but not defined"},
+		{name: "activate missing id", args: []string{"activate"}, want: "id is required"},
+		{name: "dispose bad flag", args: []string{"dispose", "--unknown"}, want: "flag provided but not defined"},
+		{name: "dispose missing id", args: []string{"dispose"}, want: "id is required"},
+		{name: "dispose missing date", args: []string{"dispose", "--id", "asset-branch"}, want: "disposal-date is required"},
+		{name: "dispose missing method", args: []string{"dispose", "--id", "asset-branch", "--disposal-date", "2026-05-01"}, want: "method is required"},
+		{name: "dispose invalid method", args: []string{"dispose", "--id", "asset-branch", "--disposal-date", "2026-05-01", "--method", "trashed"}, want: `invalid disposal method "trashed"`},
+		{name: "dispose negative proceeds", args: []string{"dispose", "--id", "asset-branch", "--disposal-date", "2026-05-01", "--method", "lost", "--proceeds", "-1"}, want: "proceeds must be non-negative"},
+		{name: "depreciate bad flag", args: []string{"depreciate", "--unknown"}, want: "flag provided but not defined"},
+		{name: "depreciate missing id", args: []string{"depreciate"}, want: "id is required"},
+		{name: "depreciation bad flag", args: []string{"depreciation", "--unknown"}, want: "flag provided but not defined"},
+		{name: "depreciation missing id", args: []string{"depreciation"}, want: "id is required"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.runAssets(ctx, tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	importFile := writeTempCSV(t, "asset-branches.csv", "asset_number,name,purchase_date,purchase_cost\nBR-001,Branch laptop,2026-03-15,1250.50\n")
+	assetPayload := func(status string) map[string]any {
+		return map[string]any{
+			"id":                                  "asset-branch",
+			"tenant_id":                           "tenant-1",
+			"asset_number":                        "FA-BRANCH",
+			"name":                                "Branch laptop",
+			"description":                         "Branch coverage laptop",
+			"category_id":                         "cat-branch",
+			"status":                              status,
+			"purchase_date":                       "2026-03-15T00:00:00Z",
+			"purchase_cost":                       "1250.50",
+			"supplier_id":                         "supplier-branch",
+			"serial_number":                       "SN-BRANCH",
+			"location":                            "Tallinn",
+			"depreciation_method":                 "UNITS_OF_PRODUCTION",
+			"useful_life_months":                  24,
+			"residual_value":                      "50.25",
+			"depreciation_start_date":             "2026-04-01T00:00:00Z",
+			"accumulated_depreciation":            "100.00",
+			"book_value":                          "1150.50",
+			"asset_account_id":                    "asset-account",
+			"depreciation_expense_account_id":     "depreciation-expense-account",
+			"accumulated_depreciation_account_id": "accumulated-depreciation-account",
+			"created_at":                          "2026-03-15T12:00:00Z",
+			"created_by":                          "user-1",
+			"updated_at":                          "2026-03-15T12:00:00Z",
+		}
+	}
+	depreciationPayload := map[string]any{
+		"id":                  "dep-branch",
+		"tenant_id":           "tenant-1",
+		"asset_id":            "asset-branch",
+		"depreciation_date":   "2026-04-30T00:00:00Z",
+		"period_start":        "2026-04-01T00:00:00Z",
+		"period_end":          "2026-04-30T00:00:00Z",
+		"depreciation_amount": "33.33",
+		"accumulated_total":   "133.33",
+		"book_value_after":    "1117.17",
+		"journal_entry_id":    "journal-branch",
+		"created_at":          "2026-04-30T12:00:00Z",
+		"created_by":          "user-1",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
+			require.Empty(t, r.URL.RawQuery)
+			_ = json.NewEncoder(w).Encode([]map[string]any{assetPayload("ACTIVE")})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
+			var req assets.CreateAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Branch laptop", req.Name)
+			assert.Equal(t, "Branch coverage laptop", req.Description)
+			require.NotNil(t, req.CategoryID)
+			assert.Equal(t, "cat-branch", *req.CategoryID)
+			assert.Equal(t, "2026-03-15", req.PurchaseDate.Format("2006-01-02"))
+			assert.True(t, req.PurchaseCost.Equal(decimal.RequireFromString("1250.50")))
+			require.NotNil(t, req.SupplierID)
+			assert.Equal(t, "supplier-branch", *req.SupplierID)
+			assert.Equal(t, "SN-BRANCH", req.SerialNumber)
+			assert.Equal(t, "Tallinn", req.Location)
+			assert.Equal(t, assets.DepreciationUnitsOfProd, req.DepreciationMethod)
+			assert.Equal(t, 24, req.UsefulLifeMonths)
+			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("50.25")))
+			require.NotNil(t, req.DepreciationStartDate)
+			assert.Equal(t, "2026-04-01", req.DepreciationStartDate.Format("2006-01-02"))
+			require.NotNil(t, req.AssetAccountID)
+			require.NotNil(t, req.DepreciationExpenseAccountID)
+			require.NotNil(t, req.AccumulatedDepreciationAcctID)
+			assert.Equal(t, "asset-account", *req.AssetAccountID)
+			assert.Equal(t, "depreciation-expense-account", *req.DepreciationExpenseAccountID)
+			assert.Equal(t, "accumulated-depreciation-account", *req.AccumulatedDepreciationAcctID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(assetPayload("DRAFT"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/import":
+			var req assets.ImportAssetsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "asset-branches.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "BR-001")
+			_ = json.NewEncoder(w).Encode(assets.ImportAssetsResult{
+				FileName:      "asset-branches.csv",
+				RowsProcessed: 2,
+				AssetsCreated: 1,
+				RowsSkipped:   1,
+				Errors: []assets.ImportAssetsRowError{{
+					Row:         3,
+					AssetNumber: "BR-002",
+					Name:        "Duplicate asset",
+					Message:     "duplicate asset number",
+				}},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch":
+			_ = json.NewEncoder(w).Encode(assetPayload("ACTIVE"))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch":
+			var req assets.UpdateAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Updated branch laptop", req.Name)
+			assert.Equal(t, "Updated branch description", req.Description)
+			require.NotNil(t, req.CategoryID)
+			assert.Equal(t, "cat-updated", *req.CategoryID)
+			assert.Equal(t, "SN-UPDATED", req.SerialNumber)
+			assert.Equal(t, "Tartu", req.Location)
+			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
+			assert.Equal(t, 30, req.UsefulLifeMonths)
+			assert.True(t, req.ResidualValue.Equal(decimal.RequireFromString("75.00")))
+			require.NotNil(t, req.AssetAccountID)
+			require.NotNil(t, req.DepreciationExpenseAccountID)
+			require.NotNil(t, req.AccumulatedDepreciationAcctID)
+			assert.Equal(t, "asset-account-updated", *req.AssetAccountID)
+			assert.Equal(t, "depreciation-expense-updated", *req.DepreciationExpenseAccountID)
+			assert.Equal(t, "accumulated-depreciation-updated", *req.AccumulatedDepreciationAcctID)
+			payload := assetPayload("ACTIVE")
+			payload["name"] = "Updated branch laptop"
+			payload["description"] = "Updated branch description"
+			payload["category_id"] = "cat-updated"
+			payload["serial_number"] = "SN-UPDATED"
+			payload["location"] = "Tartu"
+			payload["depreciation_method"] = "DECLINING_BALANCE"
+			payload["useful_life_months"] = 30
+			payload["residual_value"] = "75.00"
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/activate":
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "active"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/dispose":
+			var req assets.DisposeAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-05-01", req.DisposalDate.Format("2006-01-02"))
+			assert.Equal(t, assets.DisposalDonated, req.DisposalMethod)
+			assert.True(t, req.DisposalProceeds.Equal(decimal.RequireFromString("0")))
+			assert.Equal(t, "Donated to school", req.DisposalNotes)
+			require.NotNil(t, req.DisposalProceedsAccountID)
+			require.NotNil(t, req.DisposalGainLossAccountID)
+			assert.Equal(t, "cash-account", *req.DisposalProceedsAccountID)
+			assert.Equal(t, "asset-disposal-loss", *req.DisposalGainLossAccountID)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "disposed"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/depreciation":
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(depreciationPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-branch/depreciation":
+			_ = json.NewEncoder(w).Encode([]map[string]any{depreciationPayload})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	err := app.run(ctx, []string{"assets", "list"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "FA-BRANCH")
+	assert.Contains(t, stdout.String(), "Branch laptop")
+
+	stdout.Reset()
+	err = app.run(ctx, []string{
+		"assets", "create",
+		"--name", " Branch laptop ",
+		"--description", " Branch coverage laptop ",
+		"--category-id", " cat-branch ",
+		"--purchase-date", " 2026-03-15 ",
+		"--purchase-cost", " 1250.50 ",
+		"--supplier-id", " supplier-branch ",
+		"--serial-number", " SN-BRANCH ",
+		"--location", " Tallinn ",
+		"--depreciation-method", " units_of_production ",
+		"--useful-life-months", " 24 ",
+		"--residual-value", " 50.25 ",
+		"--depreciation-start-date", " 2026-04-01 ",
+		"--asset-account-id", " asset-account ",
+		"--depreciation-expense-account-id", " depreciation-expense-account ",
+		"--accumulated-depreciation-account-id", " accumulated-depreciation-account ",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"id": "asset-branch"`)
+	assert.Contains(t, stdout.String(), `"status": "DRAFT"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"assets", "import", "--file", importFile, "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"assets_created": 1`)
+	assert.Contains(t, stdout.String(), `"duplicate asset number"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"assets", "get", "--id", " asset-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"asset_number": "FA-BRANCH"`)
+	assert.Contains(t, stdout.String(), `"name": "Branch laptop"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{
+		"assets", "update",
+		"--id", " asset-branch ",
+		"--name", " Updated branch laptop ",
+		"--description", " Updated branch description ",
+		"--category-id", " cat-updated ",
+		"--serial-number", " SN-UPDATED ",
+		"--location", " Tartu ",
+		"--depreciation-method", " declining_balance ",
+		"--useful-life-months", " 30 ",
+		"--residual-value", " 75.00 ",
+		"--asset-account-id", " asset-account-updated ",
+		"--depreciation-expense-account-id", " depreciation-expense-updated ",
+		"--accumulated-depreciation-account-id", " accumulated-depreciation-updated ",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"name": "Updated branch laptop"`)
+	assert.Contains(t, stdout.String(), `"depreciation_method": "DECLINING_BALANCE"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"assets", "activate", "--id", " asset-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "active"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{
+		"assets", "dispose",
+		"--id", " asset-branch ",
+		"--disposal-date", " 2026-05-01 ",
+		"--method", " donated ",
+		"--proceeds", " 0 ",
+		"--proceeds-account-id", " cash-account ",
+		"--gain-loss-account-id", " asset-disposal-loss ",
+		"--notes", " Donated to school ",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "disposed"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"assets", "depreciate", "--id", " asset-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"id": "dep-branch"`)
+	assert.Contains(t, stdout.String(), `"journal_entry_id": "journal-branch"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"assets", "depreciation", "--id", " asset-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"depreciation_amount": "33.33"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"assets", "delete", "--id", " asset-branch ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "deleted"`)
+}
+
+func TestCLIAssetAuthAndAPIErrors(t *testing.T) {
+	configureCLIEnv(t)
+
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+
+	err := app.run(ctx, []string{"assets", "list"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "no API token configured")
+	assert.Empty(t, stdout.String())
+
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	importFile := writeTempCSV(t, "assets-error.csv", "asset_number,name,purchase_date,purchase_cost\nFA-ERR,Error laptop,2026-07-01,1500\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
+			assert.Equal(t, "ACTIVE", r.URL.Query().Get("status"))
+			assert.Equal(t, "cat-error", r.URL.Query().Get("category_id"))
+			assert.Equal(t, "Laptop", r.URL.Query().Get("search"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets":
+			var req assets.CreateAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Error laptop", req.Name)
+			assert.Equal(t, "2026-07-01", req.PurchaseDate.Format("2006-01-02"))
+			assert.True(t, req.PurchaseCost.Equal(decimal.NewFromInt(1500)))
+			assert.Equal(t, assets.DepreciationStraightLine, req.DepreciationMethod)
+			assert.Equal(t, 60, req.UsefulLifeMonths)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/import":
+			var req assets.ImportAssetsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "assets-error.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "FA-ERR")
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error":
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error":
+			var req assets.UpdateAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Updated error laptop", req.Name)
+			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
+			assert.Equal(t, 48, req.UsefulLifeMonths)
+			assert.True(t, req.ResidualValue.Equal(decimal.NewFromInt(100)))
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/activate":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/dispose":
+			var req assets.DisposeAssetRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-08-01", req.DisposalDate.Format("2006-01-02"))
+			assert.Equal(t, assets.DisposalSold, req.DisposalMethod)
+			assert.True(t, req.DisposalProceeds.Equal(decimal.NewFromInt(250)))
+			assert.Equal(t, "Sold damaged asset", req.DisposalNotes)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/depreciation":
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/assets/asset-error/depreciation":
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "asset backend unavailable"})
+	}))
+	defer server.Close()
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	cases := []struct {
+		name string
+		args []string
+	}{
+		{name: "list api error", args: []string{"assets", "list", "--status", "active", "--category-id", "cat-error", "--search", "Laptop"}},
+		{name: "create api error", args: []string{"assets", "create", "--name", "Error laptop", "--purchase-date", "2026-07-01", "--purchase-cost", "1500", "--depreciation-method", "straight_line", "--useful-life-months", "60"}},
+		{name: "import api error", args: []string{"assets", "import", "--file", importFile}},
+		{name: "get api error", args: []string{"assets", "get", "--id", "asset-error"}},
+		{name: "update api error", args: []string{"assets", "update", "--id", "asset-error", "--name", "Updated error laptop", "--depreciation-method", "declining_balance", "--useful-life-months", "48", "--residual-value", "100"}},
+		{name: "delete api error", args: []string{"assets", "delete", "--id", "asset-error"}},
+		{name: "activate api error", args: []string{"assets", "activate", "--id", "asset-error"}},
+		{name: "dispose api error", args: []string{"assets", "dispose", "--id", "asset-error", "--disposal-date", "2026-08-01", "--method", "sold", "--proceeds", "250", "--notes", "Sold damaged asset"}},
+		{name: "depreciate api error", args: []string{"assets", "depreciate", "--id", "asset-error"}},
+		{name: "depreciation api error", args: []string{"assets", "depreciation", "--id", "asset-error"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(ctx, tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "asset backend unavailable")
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestCLIAssetCategoryBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, stdout, _ := newTestCLIApp()
+	cfg := &cliConfig{TenantID: "tenant-1"}
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: nil, want: "assets categories subcommand required"},
+		{name: "unknown subcommand", args: []string{"archive"}, want: `unknown assets categories subcommand "archive"`},
+		{name: "list bad flag", args: []string{"list", "--unknown"}, want: "flag provided but not defined"},
+		{name: "create bad flag", args: []string{"create", "--unknown"}, want: "flag provided but not defined"},
+		{name: "create missing name", args: []string{"create"}, want: "name is required"},
+		{name: "create invalid depreciation method", args: []string{"create", "--name", "Vehicles", "--depreciation-method", "accelerated"}, want: `invalid depreciation method "accelerated"`},
+		{name: "create invalid useful life", args: []string{"create", "--name", "Vehicles", "--useful-life-months", "zero"}, want: "parse useful-life-months"},
+		{name: "create non-positive useful life", args: []string{"create", "--name", "Vehicles", "--useful-life-months", "0"}, want: "useful-life-months must be positive"},
+		{name: "create invalid residual percent", args: []string{"create", "--name", "Vehicles", "--residual-percent", "many"}, want: "parse residual-percent"},
+		{name: "create negative residual percent", args: []string{"create", "--name", "Vehicles", "--residual-percent", "-1"}, want: "residual-percent must be non-negative"},
+		{name: "get bad flag", args: []string{"get", "--unknown"}, want: "flag provided but not defined"},
+		{name: "get missing id", args: []string{"get"}, want: "id is required"},
+		{name: "delete bad flag", args: []string{"delete", "--unknown"}, want: "flag provided but not defined"},
+		{name: "delete missing id", args: []string{"delete"}, want: "id is required"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.runAssetCategories(context.Background(), cfg, &apiClient{}, tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	assetAccountID := "asset-account"
+	depreciationExpenseAccountID := "depreciation-expense-account"
+	accumulatedDepreciationAccountID := "accumulated-depreciation-account"
+	categoryPayload := map[string]any{
+		"id":                                  "cat-branch",
+		"tenant_id":                           "tenant-1",
+		"name":                                "Vehicles",
+		"description":                         "Fleet assets",
+		"depreciation_method":                 "DECLINING_BALANCE",
+		"default_useful_life_months":          72,
+		"default_residual_value_percent":      "15.25",
+		"asset_account_id":                    assetAccountID,
+		"depreciation_expense_account_id":     depreciationExpenseAccountID,
+		"accumulated_depreciation_account_id": accumulatedDepreciationAccountID,
+		"created_at":                          "2026-03-15T12:00:00Z",
+		"updated_at":                          "2026-03-15T12:00:00Z",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
+			_ = json.NewEncoder(w).Encode([]map[string]any{categoryPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
+			var req assets.CreateCategoryRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Vehicles", req.Name)
+			assert.Equal(t, "Fleet assets", req.Description)
+			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
+			assert.Equal(t, 72, req.DefaultUsefulLifeMonths)
+			assert.True(t, req.DefaultResidualValuePercent.Equal(decimal.RequireFromString("15.25")))
+			require.NotNil(t, req.AssetAccountID)
+			require.NotNil(t, req.DepreciationExpenseAccountID)
+			require.NotNil(t, req.AccumulatedDepreciationAcctID)
+			assert.Equal(t, assetAccountID, *req.AssetAccountID)
+			assert.Equal(t, depreciationExpenseAccountID, *req.DepreciationExpenseAccountID)
+			assert.Equal(t, accumulatedDepreciationAccountID, *req.AccumulatedDepreciationAcctID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(categoryPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-branch":
+			_ = json.NewEncoder(w).Encode(categoryPayload)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-branch":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	err := app.run(context.Background(), []string{"assets", "categories", "list"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Vehicles")
+	assert.Contains(t, stdout.String(), "DECLINING_BALANCE")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"assets", "categories", "create",
+		"--name", "Vehicles",
+		"--description", "Fleet assets",
+		"--depreciation-method", "declining_balance",
+		"--useful-life-months", "72",
+		"--residual-percent", "15.25",
+		"--asset-account-id", assetAccountID,
+		"--depreciation-expense-account-id", depreciationExpenseAccountID,
+		"--accumulated-depreciation-account-id", accumulatedDepreciationAccountID,
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"id": "cat-branch"`)
+	assert.Contains(t, stdout.String(), `"default_useful_life_months": 72`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "categories", "get", "--id", "cat-branch", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"name": "Vehicles"`)
+	assert.Contains(t, stdout.String(), `"depreciation_method": "DECLINING_BALANCE"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"assets", "categories", "delete", "--id", "cat-branch", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "deleted"`)
+}
+
+func TestCLIAssetCategoryAPIErrorBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories":
+			var req assets.CreateCategoryRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Vehicles", req.Name)
+			assert.Equal(t, "Fleet assets", req.Description)
+			assert.Equal(t, assets.DepreciationDecliningBalance, req.DepreciationMethod)
+			assert.Equal(t, 72, req.DefaultUsefulLifeMonths)
+			assert.True(t, req.DefaultResidualValuePercent.Equal(decimal.RequireFromString("15.25")))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-error":
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/asset-categories/cat-error":
+		default:
+			t.Fatalf("unexpected asset category error request: %s %s", r.Method, r.URL.String())
+		}
+
+		w.WriteHeader(http.StatusBadGateway)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "asset category service unavailable"})
+	}))
+	defer server.Close()
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+	for _, tt := range []struct {
+		name string
+		args []string
+	}{
+		{name: "list API error", args: []string{"assets", "categories", "list"}},
+		{name: "create API error", args: []string{"assets", "categories", "create", "--name", "Vehicles", "--description", "Fleet assets", "--depreciation-method", "declining_balance", "--useful-life-months", "72", "--residual-percent", "15.25"}},
+		{name: "get API error", args: []string{"assets", "categories", "get", "--id", "cat-error"}},
+		{name: "delete API error", args: []string{"assets", "categories", "delete", "--id", "cat-error"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(ctx, tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "asset category service unavailable")
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func cliExpensePayload(status string) map[string]any {
+	payload := map[string]any{
+		"id":                 "expense-1",
+		"tenant_id":          "tenant-1",
+		"expense_number":     "EXP-00001",
+		"expense_date":       "2026-05-30T00:00:00Z",
+		"merchant":           "Office Store",
+		"description":        "Printer toner",
+		"employee_id":        "employee-1",
+		"contact_id":         "supplier-1",
+		"expense_account_id": "expense-account",
+		"payment_account_id": "cash-account",
+		"amount":             "120.50",
+		"currency":           "EUR",
+		"exchange_rate":      "1",
+		"base_amount":        "120.50",
+		"requires_receipt":   false,
+		"status":             status,
+		"created_at":         "2026-05-30T12:00:00Z",
+		"created_by":         "user-1",
+		"updated_at":         "2026-05-30T12:00:00Z",
+		"remediation_actions": []map[string]any{{
+			"code":        cliExpenseRemediationCode(status),
+			"severity":    "ACTION",
+			"scope":       "expenses",
+			"owner_role":  "accountant",
+			"message":     "Expense follow-up is required.",
+			"action":      "Review the expense workflow state.",
+			"entity_type": "expense",
+			"entity_id":   "expense-1",
+			"ui_path":     "/expenses?expense_id=expense-1",
+			"cli_command": "oa expenses get --id expense-1",
+		}},
+	}
+	if status == "POSTED" {
+		payload["journal_entry_id"] = "je-1"
+	}
+	if status == "REJECTED" {
+		payload["rejection_reason"] = "Need project code"
+	}
+	return payload
+}
+
+func cliExpenseRemediationCode(status string) string {
+	switch status {
+	case "SUBMITTED":
+		return "expense_approve_or_reject"
+	case "APPROVED":
+		return "expense_post_to_ledger"
+	case "REJECTED":
+		return "expense_rejection_review"
+	case "POSTED":
+		return "expense_posted_archive"
+	default:
+		return "expense_submit_for_approval"
+	}
+}
+
+func TestCLIExpenseCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	importFile := writeTempCSV(t, "expenses.csv", "expense_number,expense_date,merchant,expense_account_id,payment_account_id,amount,status\nEXP-IMP-1,2026-05-30,Office Store,expense-account,cash-account,120.50,DRAFT\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
+			require.Equal(t, "DRAFT", r.URL.Query().Get("status"))
+			require.Equal(t, "25", r.URL.Query().Get("limit"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{cliExpensePayload("DRAFT")})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
+			var req expenses.CreateExpenseRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Office Store", req.Merchant)
+			assert.Equal(t, "2026-05-30", req.ExpenseDate.Format("2006-01-02"))
+			assert.Equal(t, "expense-account", req.ExpenseAccountID)
+			assert.Equal(t, "cash-account", req.PaymentAccountID)
+			assert.True(t, req.Amount.Equal(decimal.RequireFromString("120.50")))
+			require.NotNil(t, req.EmployeeID)
+			require.NotNil(t, req.ContactID)
+			require.NotNil(t, req.RequiresReceipt)
+			assert.False(t, *req.RequiresReceipt)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("DRAFT"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/import":
+			var req expenses.ImportExpensesRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "expenses.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "EXP-IMP-1")
+			_ = json.NewEncoder(w).Encode(expenses.ImportExpensesResult{
+				FileName:        "expenses.csv",
+				RowsProcessed:   1,
+				ExpensesCreated: 1,
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1":
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("SUBMITTED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/submit":
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("SUBMITTED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/approve":
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("APPROVED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/post":
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("POSTED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/reject":
+			var req expenses.RejectExpenseRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Need project code", req.Reason)
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("REJECTED"))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{"expenses", "list", "--status", "draft", "--limit", "25", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"expense_number": "EXP-00001"`)
+	assert.Contains(t, stdout.String(), `"remediation_actions": [`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"expenses", "create",
+		"--merchant", "Office Store",
+		"--description", "Printer toner",
+		"--expense-date", "2026-05-30",
+		"--employee-id", "employee-1",
+		"--contact-id", "supplier-1",
+		"--expense-account-id", "expense-account",
+		"--payment-account-id", "cash-account",
+		"--amount", "120.50",
+		"--requires-receipt=false",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created expense EXP-00001 (expense-1)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"expenses", "import", "--file", importFile})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 expenses, skipped 0 rows")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"expenses", "get", "--id", "expense-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Expense EXP-00001 Office Store")
+	assert.Contains(t, stdout.String(), "Expense remediation actions")
+	assert.Contains(t, stdout.String(), "expense_approve_or_reject")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"expenses", "submit", "--id", "expense-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Submitted expense expense-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"expenses", "approve", "--id", "expense-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Approved expense expense-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"expenses", "post", "--id", "expense-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Posted expense expense-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"expenses", "reject", "--id", "expense-1", "--reason", "Need project code"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Rejected expense expense-1")
+}
+
+func TestCLIExpenseBranches(t *testing.T) {
+	configureCLIEnv(t)
+	app, stdout, _ := newTestCLIApp()
+	ctx := context.Background()
+
+	err := app.run(ctx, []string{"expenses", "list"})
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "no API token configured")
+	assert.Empty(t, stdout.String())
+
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: nil, want: "expenses subcommand required"},
+		{name: "unknown subcommand", args: []string{"archive"}, want: `unknown expenses subcommand "archive"`},
+		{name: "list bad flag", args: []string{"list", "--unknown"}, want: "flag provided but not defined"},
+		{name: "list invalid status", args: []string{"list", "--status", "waiting"}, want: `invalid expense status "waiting"`},
+		{name: "create bad flag", args: []string{"create", "--unknown"}, want: "flag provided but not defined"},
+		{name: "create missing merchant", args: []string{"create"}, want: "merchant is required"},
+		{name: "create missing expense account", args: []string{"create", "--merchant", "Office Store"}, want: "expense-account-id is required"},
+		{name: "create missing payment account", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account"}, want: "payment-account-id is required"},
+		{name: "create missing date", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account"}, want: "expense-date is required"},
+		{name: "create invalid date", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026/05/30"}, want: "parse expense-date"},
+		{name: "create missing amount", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30"}, want: "amount is required"},
+		{name: "create non-positive amount", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30", "--amount", "0"}, want: "amount must be positive"},
+		{name: "create invalid exchange rate", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30", "--amount", "10", "--exchange-rate", "many"}, want: "parse exchange-rate"},
+		{name: "create non-positive exchange rate", args: []string{"create", "--merchant", "Office Store", "--expense-account-id", "expense-account", "--payment-account-id", "cash-account", "--expense-date", "2026-05-30", "--amount", "10", "--exchange-rate", "0"}, want: "exchange-rate must be positive"},
+		{name: "import bad flag", args: []string{"import", "--unknown"}, want: "flag provided but not defined"},
+		{name: "import missing file", args: []string{"import"}, want: "file is required"},
+		{name: "import unreadable file", args: []string{"import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "no such file"},
+		{name: "get bad flag", args: []string{"get", "--unknown"}, want: "flag provided but not defined"},
+		{name: "get missing id", args: []string{"get"}, want: "id is required"},
+		{name: "submit bad flag", args: []string{"submit", "--unknown"}, want: "flag provided but not defined"},
+		{name: "submit missing id", args: []string{"submit"}, want: "id is required"},
+		{name: "approve missing id", args: []string{"approve"}, want: "id is required"},
+		{name: "post missing id", args: []string{"post"}, want: "id is required"},
+		{name: "reject bad flag", args: []string{"reject", "--unknown"}, want: "flag provided but not defined"},
+		{name: "reject missing id", args: []string{"reject"}, want: "id is required"},
+		{name: "reject missing reason", args: []string{"reject", "--id", "expense-1"}, want: "reason is required"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := app.runExpenses(ctx, tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+
+	importFile := writeTempCSV(t, "expenses-json.csv", "expense_number,expense_date,merchant,expense_account_id,payment_account_id,amount,status\nEXP-IMP-2,2026-05-31,Hotel,expense-account,cash-account,99.50,SUBMITTED\n")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
+			require.Empty(t, r.URL.Query().Get("status"))
+			require.Equal(t, "100", r.URL.Query().Get("limit"))
+			postedPayload := cliExpensePayload("POSTED")
+			postedPayload["journal_entry_id"] = "je-branch"
+			postedPayload["requires_receipt"] = true
+			_ = json.NewEncoder(w).Encode([]map[string]any{postedPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
+			var req expenses.CreateExpenseRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Office Store", req.Merchant)
+			assert.Equal(t, "Trimmed description", req.Description)
+			assert.Nil(t, req.EmployeeID)
+			assert.Nil(t, req.ContactID)
+			assert.Equal(t, "expense-account", req.ExpenseAccountID)
+			assert.Equal(t, "cash-account", req.PaymentAccountID)
+			assert.Equal(t, "USD", req.Currency)
+			assert.True(t, req.ExchangeRate.Equal(decimal.RequireFromString("1.25")))
+			require.NotNil(t, req.RequiresReceipt)
+			assert.True(t, *req.RequiresReceipt)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("DRAFT"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/import":
+			var req expenses.ImportExpensesRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "expenses-json.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "EXP-IMP-2")
+			_ = json.NewEncoder(w).Encode(expenses.ImportExpensesResult{
+				FileName:        "expenses-json.csv",
+				RowsProcessed:   2,
+				ExpensesCreated: 1,
+				RowsSkipped:     1,
+				Errors: []expenses.ImportExpensesRowError{
+					{Row: 2, ExpenseNumber: "EXP-IMP-ERR", Merchant: "Hotel", Message: "duplicate expense number"},
+				},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1":
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("APPROVED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/submit":
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("SUBMITTED"))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses/expense-1/reject":
+			var req expenses.RejectExpenseRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Need project code", req.Reason)
+			_ = json.NewEncoder(w).Encode(cliExpensePayload("REJECTED"))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	err = app.run(ctx, []string{"expenses", "list"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "EXP-00001")
+	assert.Contains(t, stdout.String(), "POSTED")
+	assert.Contains(t, stdout.String(), "je-branch")
+
+	stdout.Reset()
+	err = app.run(ctx, []string{
+		"expenses", "create",
+		"--merchant", " Office Store ",
+		"--description", " Trimmed description ",
+		"--expense-date", "2026-05-30",
+		"--expense-account-id", " expense-account ",
+		"--payment-account-id", " cash-account ",
+		"--amount", "120.50",
+		"--currency", " usd ",
+		"--exchange-rate", "1.25",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"expense_number": "EXP-00001"`)
+	assert.Contains(t, stdout.String(), `"status": "DRAFT"`)
+	assert.Contains(t, stdout.String(), `"code": "expense_submit_for_approval"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"expenses", "import", "--file", importFile, "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"file_name": "expenses-json.csv"`)
+	assert.Contains(t, stdout.String(), `"rows_skipped": 1`)
+	assert.Contains(t, stdout.String(), `"message": "duplicate expense number"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"expenses", "get", "--id", " expense-1 ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "APPROVED"`)
+	assert.Contains(t, stdout.String(), `"code": "expense_post_to_ledger"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"expenses", "submit", "--id", " expense-1 ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "SUBMITTED"`)
+	assert.Contains(t, stdout.String(), `"code": "expense_approve_or_reject"`)
+
+	stdout.Reset()
+	err = app.run(ctx, []string{"expenses", "reject", "--id", " expense-1 ", "--reason", " Need project code ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "REJECTED"`)
+	assert.Contains(t, stdout.String(), `"rejection_reason": "Need project code"`)
+	assert.Contains(t, stdout.String(), `"code": "expense_rejection_review"`)
+}
+
+func TestCLIExpenseAPIErrorBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	importFile := writeTempCSV(t, "expenses-error.csv", "expense_number,expense_date,merchant,expense_account_id,payment_account_id,amount,status\nEXP-ERR-1,2026-06-01,Taxi,expense-account,cash-account,35.25,DRAFT\n")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
+			assert.Equal(t, "SUBMITTED", r.URL.Query().Get("status"))
+			assert.Equal(t, "7", r.URL.Query().Get("limit"))
 		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/expenses":
 			var req expenses.CreateExpenseRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
@@ -13008,6 +15115,50 @@ func TestCLIInventoryTopLevelAuthFlagsAndAPIErrorBranches(t *testing.T) {
 		{name: "subledger reconciliation bad flag", args: []string{"inventory", "subledger-reconciliation", "--bad"}, want: "flag provided but not defined"},
 		{name: "subledger reconciliation bad as-of", args: []string{"inventory", "subledger-reconciliation", "--as-of", "2026/03/31"}, want: "parse as-of"},
 		{name: "lots bad flag", args: []string{"inventory", "lots", "--bad"}, want: "flag provided but not defined"},
+		{name: "replenishment bad flag", args: []string{"inventory", "replenishment", "--bad"}, want: "flag provided but not defined"},
+		{name: "replenishment bad as-of", args: []string{"inventory", "replenishment", "--as-of", "2026/03/31"}, want: "parse as-of"},
+		{name: "replenishment negative velocity", args: []string{"inventory", "replenishment", "--velocity-days", "-1"}, want: "velocity-days must not be negative"},
+		{name: "replenishment orders negative coverage", args: []string{"inventory", "replenishment-orders", "--coverage-days", "-1"}, want: "coverage-days must not be negative"},
+		{name: "replenishment orders bad order date", args: []string{"inventory", "replenishment-orders", "--order-date", "01.04.2026"}, want: "parse order-date"},
+		{name: "low-stock event bad flag", args: []string{"inventory", "low-stock-event", "--bad"}, want: "flag provided but not defined"},
+		{name: "stock counts missing subcommand", args: []string{"inventory", "stock-counts"}, want: "inventory stock-counts subcommand required"},
+		{name: "stock counts unknown subcommand", args: []string{"inventory", "stock-counts", "bogus"}, want: "unknown inventory stock-counts subcommand"},
+		{name: "stock counts list bad status", args: []string{"inventory", "stock-counts", "list", "--status", "done"}, want: "invalid stock count status"},
+		{name: "stock counts create missing warehouse", args: []string{"inventory", "stock-counts", "create"}, want: "warehouse-id is required"},
+		{name: "stock counts create bad date", args: []string{"inventory", "stock-counts", "create", "--warehouse-id", "wh-1", "--count-date", "31.12.2026"}, want: "parse count-date"},
+		{name: "stock counts get missing id", args: []string{"inventory", "stock-counts", "get"}, want: "id is required"},
+		{name: "stock counts count missing entry", args: []string{"inventory", "stock-counts", "count", "--id", "count-1"}, want: "at least one entry is required"},
+		{name: "stock counts count entry without key", args: []string{"inventory", "stock-counts", "count", "--id", "count-1", "--entry", "quantity=2"}, want: "entry line_id, product_id or barcode is required"},
+		{name: "stock counts count negative quantity", args: []string{"inventory", "stock-counts", "count", "--id", "count-1", "--entry", "barcode=4740001,quantity=-1"}, want: "entry quantity"},
+		{name: "stock counts import missing file", args: []string{"inventory", "stock-counts", "import", "--id", "count-1"}, want: "file is required"},
+		{name: "stock counts submit missing id", args: []string{"inventory", "stock-counts", "submit"}, want: "id is required"},
+		{name: "stock counts approve missing account", args: []string{"inventory", "stock-counts", "approve", "--id", "count-1"}, want: "variance-account-id is required"},
+		{name: "stock counts variance conflicting formats", args: []string{"inventory", "stock-counts", "variance", "--id", "count-1", "--csv", "--pdf"}, want: "cannot be combined"},
+		{name: "boms missing subcommand", args: []string{"inventory", "boms"}, want: "inventory boms subcommand required"},
+		{name: "boms unknown subcommand", args: []string{"inventory", "boms", "bogus"}, want: "unknown inventory boms subcommand"},
+		{name: "boms list bad type", args: []string{"inventory", "boms", "list", "--type", "recipe"}, want: "invalid bill of materials type"},
+		{name: "boms create missing product", args: []string{"inventory", "boms", "create"}, want: "product-id is required"},
+		{name: "boms create missing component", args: []string{"inventory", "boms", "create", "--product-id", "table"}, want: "at least one component is required"},
+		{name: "boms create component without product", args: []string{"inventory", "boms", "create", "--product-id", "table", "--component", "qty=2"}, want: "component product_id is required"},
+		{name: "boms create zero component quantity", args: []string{"inventory", "boms", "create", "--product-id", "table", "--component", "product_id=leg,qty=0"}, want: "component quantity must be positive"},
+		{name: "boms create negative labour", args: []string{"inventory", "boms", "create", "--product-id", "table", "--labour-cost", "-1", "--component", "product_id=leg,qty=1"}, want: "labour-cost must be non-negative"},
+		{name: "boms update missing id", args: []string{"inventory", "boms", "update", "--component", "product_id=leg,qty=1"}, want: "id is required"},
+		{name: "boms update bad active", args: []string{"inventory", "boms", "update", "--id", "bom-1", "--active", "maybe", "--component", "product_id=leg,qty=1"}, want: "parse active"},
+		{name: "boms get missing id", args: []string{"inventory", "boms", "get"}, want: "id is required"},
+		{name: "boms delete missing id", args: []string{"inventory", "boms", "delete"}, want: "id is required"},
+		{name: "boms explode bad quantity", args: []string{"inventory", "boms", "explode", "--id", "bom-1", "--quantity", "0"}, want: "quantity must be positive"},
+		{name: "boms explode conflicting formats", args: []string{"inventory", "boms", "explode", "--id", "bom-1", "--csv", "--xlsx"}, want: "cannot be combined"},
+		{name: "assembly orders missing subcommand", args: []string{"inventory", "assembly-orders"}, want: "inventory assembly-orders subcommand required"},
+		{name: "assembly orders unknown subcommand", args: []string{"inventory", "assembly-orders", "bogus"}, want: "unknown inventory assembly-orders subcommand"},
+		{name: "assembly orders list bad status", args: []string{"inventory", "assembly-orders", "list", "--status", "done"}, want: "invalid assembly order status"},
+		{name: "assembly orders list bad type", args: []string{"inventory", "assembly-orders", "list", "--type", "repair"}, want: "invalid assembly order type"},
+		{name: "assembly orders create missing product", args: []string{"inventory", "assembly-orders", "create"}, want: "product-id is required"},
+		{name: "assembly orders create missing warehouse", args: []string{"inventory", "assembly-orders", "create", "--product-id", "table"}, want: "warehouse-id is required"},
+		{name: "assembly orders create missing quantity", args: []string{"inventory", "assembly-orders", "create", "--product-id", "table", "--warehouse-id", "wh-1"}, want: "quantity is required"},
+		{name: "assembly orders create bad expiry", args: []string{"inventory", "assembly-orders", "create", "--product-id", "table", "--warehouse-id", "wh-1", "--quantity", "1", "--expiry-date", "2027/01/01"}, want: "parse expiry-date"},
+		{name: "assembly orders get missing id", args: []string{"inventory", "assembly-orders", "get"}, want: "id is required"},
+		{name: "assembly orders complete missing id", args: []string{"inventory", "assembly-orders", "complete"}, want: "id is required"},
+		{name: "assembly orders cancel missing id", args: []string{"inventory", "assembly-orders", "cancel"}, want: "id is required"},
 		{name: "adjust bad flag", args: []string{"inventory", "adjust", "--bad"}, want: "flag provided but not defined"},
 		{name: "issue bad flag", args: []string{"inventory", "issue", "--bad"}, want: "flag provided but not defined"},
 		{name: "issue missing product", args: []string{"inventory", "issue", "--warehouse-id", "wh-1", "--quantity", "1"}, want: "product-id is required"},
@@ -13145,6 +15296,9 @@ func TestCLIInventoryTopLevelAuthFlagsAndAPIErrorBranches(t *testing.T) {
 		{name: "valuation", args: []string{"inventory", "valuation", "--warehouse-id", "wh-1", "--method", "fifo"}},
 		{name: "subledger reconciliation", args: []string{"inventory", "subledger-reconciliation", "--warehouse-id", "wh-1", "--method", "fifo", "--as-of", "2026-03-31"}},
 		{name: "lots", args: []string{"inventory", "lots"}},
+		{name: "replenishment", args: []string{"inventory", "replenishment"}},
+		{name: "replenishment orders", args: []string{"inventory", "replenishment-orders"}},
+		{name: "low-stock event", args: []string{"inventory", "low-stock-event"}},
 		{name: "adjust", args: []string{"inventory", "adjust", "--product-id", stockProductID, "--warehouse-id", stockWarehouseID, "--quantity", "1", "--unit-cost", "10"}},
 		{name: "issue", args: []string{"inventory", "issue", "--product-id", stockProductID, "--warehouse-id", stockWarehouseID, "--quantity", "1"}},
 		{name: "transfer", args: []string{"inventory", "transfer", "--product-id", stockProductID, "--from-warehouse-id", stockWarehouseID, "--to-warehouse-id", stockWarehouseID2, "--quantity", "1"}},
@@ -15909,6 +18063,7 @@ func TestCLIContactsBranches(t *testing.T) {
 			assert.Equal(t, "Tartu", req.City)
 			assert.Equal(t, "50090", req.PostalCode)
 			assert.Equal(t, "EE", req.CountryCode)
+			assert.Equal(t, "en", req.Language)
 			assert.Equal(t, 21, req.PaymentTermsDays)
 			assert.True(t, req.CreditLimit.Equal(decimal.RequireFromString("99.50")))
 			require.NotNil(t, req.DefaultAccountID)
@@ -15943,6 +18098,8 @@ func TestCLIContactsBranches(t *testing.T) {
 			assert.Equal(t, "80010", *req.PostalCode)
 			require.NotNil(t, req.CountryCode)
 			assert.Equal(t, "EE", *req.CountryCode)
+			require.NotNil(t, req.Language)
+			assert.Equal(t, "et", *req.Language)
 			require.NotNil(t, req.PaymentTermsDays)
 			assert.Equal(t, 45, *req.PaymentTermsDays)
 			require.NotNil(t, req.CreditLimit)
@@ -16001,6 +18158,7 @@ func TestCLIContactsBranches(t *testing.T) {
 		"--city", " Tartu ",
 		"--postal-code", " 50090 ",
 		"--country-code", " ee ",
+		"--language", " EN ",
 		"--payment-terms-days", "21",
 		"--credit-limit", " 99.50 ",
 		"--default-account-id", " " + branchDefaultAccountID + " ",
@@ -16030,6 +18188,7 @@ func TestCLIContactsBranches(t *testing.T) {
 		"--city", " Parnu ",
 		"--postal-code", " 80010 ",
 		"--country-code", " ee ",
+		"--language", " ET ",
 		"--payment-terms-days", "45",
 		"--credit-limit", "199.99",
 		"--default-account-id", " " + updatedDefaultAccountID + " ",
@@ -18201,43 +20360,146 @@ func TestCLIEmailTemplateBranches(t *testing.T) {
 
 	t.Setenv("OA_BASE_URL", server.URL)
 
-	err := app.run(context.Background(), []string{"email", "templates", "list"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "PAYMENT_RECEIPT")
-	assert.Contains(t, stdout.String(), "Payment receipt")
+	err := app.run(context.Background(), []string{"email", "templates", "list"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "PAYMENT_RECEIPT")
+	assert.Contains(t, stdout.String(), "Payment receipt")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"email", "templates", "list", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"template_type": "PAYMENT_RECEIPT"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"email", "templates", "update",
+		"--type", "payment_receipt",
+		"--subject", "Updated receipt",
+		"--body-html-file", htmlFile,
+		"--body-text-file", textFile,
+		"--active", "false",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"template_type": "PAYMENT_RECEIPT"`)
+	assert.Contains(t, stdout.String(), `"subject": "Updated receipt"`)
+	assert.Contains(t, stdout.String(), `"is_active": false`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"email", "templates", "update",
+		"--type", "payment_receipt",
+		"--subject", "Updated receipt",
+		"--body-html-file", htmlFile,
+		"--body-text-file", textFile,
+		"--active", "false",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Email template PAYMENT_RECEIPT")
+	assert.Contains(t, stdout.String(), "Active: false")
+}
+
+func TestCLIDocumentTemplateCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, stdout, _ := newTestCLIApp()
+	templateFile := writeTempCSV(t, "template.json", `{"paper_size":"a5","logo_position":"right","show_payment_qr_code":true,"custom_fields":[{"label":"Project","value":"{{.Reference}}"}]}`)
+	unknownFieldFile := writeTempCSV(t, "unknown.json", `{"paper":"A5"}`)
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: []string{"document-templates"}, want: "document-templates subcommand required"},
+		{name: "unknown subcommand", args: []string{"document-templates", "archive"}, want: `unknown document-templates subcommand "archive"`},
+		{name: "update missing type", args: []string{"document-templates", "update"}, want: "type is required"},
+		{name: "update invalid type", args: []string{"document-templates", "update", "--type", "payslip"}, want: `invalid document type "payslip"`},
+		{name: "update missing file", args: []string{"document-templates", "update", "--type", "invoice"}, want: "file is required"},
+		{name: "update unknown field", args: []string{"document-templates", "update", "--type", "invoice", "--file", unknownFieldFile}, want: "parse template JSON"},
+		{name: "reset missing type", args: []string{"document-templates", "reset"}, want: "type is required"},
+		{name: "preview unreadable file", args: []string{"document-templates", "preview", "--type", "quote", "--file", filepath.Join(t.TempDir(), "missing.json")}, want: "read file"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/document-templates":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]tenant.DocumentTemplateEntry{
+				{DocumentType: tenant.DocumentTypeInvoice, Customized: true, Template: tenant.DocumentTemplate{PaperSize: tenant.PaperSizeA5, ShowPaymentQRCode: true}},
+				{DocumentType: tenant.DocumentTypeQuote},
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/document-templates/INVOICE":
+			var req tenant.DocumentTemplate
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "a5", req.PaperSize)
+			assert.True(t, req.ShowPaymentQRCode)
+			require.Len(t, req.CustomFields, 1)
+			assert.Equal(t, "{{.Reference}}", req.CustomFields[0].Value)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(tenant.DocumentTemplateEntry{
+				DocumentType: tenant.DocumentTypeInvoice,
+				Customized:   true,
+				Template:     tenant.DocumentTemplate{PaperSize: tenant.PaperSizeA5, LogoPosition: tenant.LogoPositionRight, ShowPaymentQRCode: true, CustomFields: req.CustomFields},
+			})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/document-templates/INVOICE":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(tenant.DocumentTemplateEntry{DocumentType: tenant.DocumentTypeInvoice})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/document-templates/QUOTE/preview":
+			var req tenant.DocumentTemplatePreviewRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.NotNil(t, req.Template)
+			assert.Equal(t, tenant.LogoPositionRight, strings.ToUpper(req.Template.LogoPosition))
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-preview"))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	require.NoError(t, app.run(context.Background(), []string{"document-templates", "list"}))
+	assert.Contains(t, stdout.String(), "INVOICE")
+	assert.Contains(t, stdout.String(), "A5")
+	assert.Contains(t, stdout.String(), "QUOTE")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"email", "templates", "list", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"template_type": "PAYMENT_RECEIPT"`)
+	require.NoError(t, app.run(context.Background(), []string{"document-templates", "list", "--json"}))
+	assert.Contains(t, stdout.String(), `"document_type": "INVOICE"`)
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"email", "templates", "update",
-		"--type", "payment_receipt",
-		"--subject", "Updated receipt",
-		"--body-html-file", htmlFile,
-		"--body-text-file", textFile,
-		"--active", "false",
-		"--json",
-	})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"template_type": "PAYMENT_RECEIPT"`)
-	assert.Contains(t, stdout.String(), `"subject": "Updated receipt"`)
-	assert.Contains(t, stdout.String(), `"is_active": false`)
+	require.NoError(t, app.run(context.Background(), []string{"document-templates", "update", "--type", "invoice", "--file", templateFile}))
+	assert.Contains(t, stdout.String(), "Document template INVOICE")
+	assert.Contains(t, stdout.String(), "Payment QR code: true")
+	assert.Contains(t, stdout.String(), "Field Project: {{.Reference}}")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"email", "templates", "update",
-		"--type", "payment_receipt",
-		"--subject", "Updated receipt",
-		"--body-html-file", htmlFile,
-		"--body-text-file", textFile,
-		"--active", "false",
-	})
+	require.NoError(t, app.run(context.Background(), []string{"document-templates", "reset", "--type", "invoice", "--json"}))
+	assert.Contains(t, stdout.String(), `"customized": false`)
+
+	outputPath := filepath.Join(t.TempDir(), "preview.pdf")
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"document-templates", "preview", "--type", "quote", "--file", templateFile, "--output", outputPath}))
+	content, err := os.ReadFile(outputPath)
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Email template PAYMENT_RECEIPT")
-	assert.Contains(t, stdout.String(), "Active: false")
+	assert.Equal(t, "%PDF-preview", string(content))
 }
 
 func TestCLIEmailTemplateAPIErrorBranches(t *testing.T) {
@@ -21966,34 +24228,386 @@ func TestCLIReportsValidationBranches(t *testing.T) {
 		{name: "budget inverted range", args: []string{"budget-vs-actual", "--start", "2026-04-01", "--end", "2026-03-31"}, want: "end must be on or after start"},
 	}
 
-	for _, tc := range tests {
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			app, _, _ := newTestCLIApp()
+			err := app.run(context.Background(), append([]string{"reports"}, tc.args...))
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestCLIReportsAuthFlagsAndAPIErrorBranches(t *testing.T) {
+	configureCLIEnv(t)
+
+	app, stdout, _ := newTestCLIApp()
+	err := app.run(context.Background(), []string{"reports", "trial-balance"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no API token configured")
+	assert.Empty(t, stdout.String())
+
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:  "https://placeholder.example.com",
+		APIToken: "oa_saved_token",
+	}))
+	err = app.run(context.Background(), []string{"reports", "trial-balance"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no tenant configured")
+	assert.Empty(t, stdout.String())
+
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	validationCases := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "account balance bad flag", args: []string{"reports", "account-balance", "--bad"}, want: "flag provided but not defined"},
+		{name: "account balance conflicting output", args: []string{"reports", "account-balance", "--account-id", "acc-1", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "balance sheet bad flag", args: []string{"reports", "balance-sheet", "--bad"}, want: "flag provided but not defined"},
+		{name: "balance sheet conflicting output", args: []string{"reports", "balance-sheet", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "income statement bad flag", args: []string{"reports", "income-statement", "--bad"}, want: "flag provided but not defined"},
+		{name: "income statement conflicting output", args: []string{"reports", "income-statement", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "consolidated bad flag", args: []string{"reports", "consolidated", "--bad"}, want: "flag provided but not defined"},
+		{name: "annual bad flag", args: []string{"reports", "annual", "--bad"}, want: "flag provided but not defined"},
+		{name: "cash flow bad flag", args: []string{"reports", "cash-flow", "--bad"}, want: "flag provided but not defined"},
+		{name: "cash flow conflicting output", args: []string{"reports", "cash-flow", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "aging bad flag", args: []string{"reports", "aging", "--bad"}, want: "flag provided but not defined"},
+		{name: "aging conflicting output", args: []string{"reports", "aging", "--type", "receivables", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "balance confirmations bad flag", args: []string{"reports", "balance-confirmations", "--bad"}, want: "flag provided but not defined"},
+		{name: "balance confirmations conflicting output", args: []string{"reports", "balance-confirmations", "--type", "RECEIVABLE", "--as-of", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "balance confirmation bad flag", args: []string{"reports", "balance-confirmation", "--bad"}, want: "flag provided but not defined"},
+		{name: "balance confirmation conflicting output", args: []string{"reports", "balance-confirmation", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--as-of", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "contact statement bad flag", args: []string{"reports", "contact-statement", "--bad"}, want: "flag provided but not defined"},
+		{name: "contact statement conflicting output", args: []string{"reports", "contact-statement", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--start", "2026-01-01", "--end", "2026-01-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "sales margin bad flag", args: []string{"reports", "sales-margin", "--bad"}, want: "flag provided but not defined"},
+		{name: "sales margin conflicting output", args: []string{"reports", "sales-margin", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "customer profitability bad flag", args: []string{"reports", "customer-profitability", "--bad"}, want: "flag provided but not defined"},
+		{name: "customer profitability conflicting output", args: []string{"reports", "customer-profitability", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+		{name: "budget vs actual bad flag", args: []string{"reports", "budget-vs-actual", "--bad"}, want: "flag provided but not defined"},
+		{name: "budget vs actual conflicting output", args: []string{"reports", "budget-vs-actual", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
+	}
+	for _, tc := range validationCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tc.want)
+			assert.Empty(t, stdout.String())
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "reports API unavailable"})
+	}))
+	defer server.Close()
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	appendArgs := func(base []string, more ...string) []string {
+		args := append([]string{}, base...)
+		return append(args, more...)
+	}
+	exportModes := []struct {
+		name string
+		flag string
+	}{
+		{name: "csv", flag: "--csv"},
+		{name: "xlsx", flag: "--xlsx"},
+		{name: "pdf", flag: "--pdf"},
+	}
+	exportCommands := []struct {
+		name string
+		base []string
+	}{
+		{name: "trial balance", base: []string{"reports", "trial-balance", "--as-of", "2026-03-31"}},
+		{name: "account balance", base: []string{"reports", "account-balance", "--account-id", "acc-1", "--as-of", "2026-03-31"}},
+		{name: "balance sheet", base: []string{"reports", "balance-sheet", "--as-of", "2026-03-31"}},
+		{name: "income statement", base: []string{"reports", "income-statement", "--start", "2026-01-01", "--end", "2026-03-31"}},
+		{name: "cash flow", base: []string{"reports", "cash-flow", "--start", "2026-01-01", "--end", "2026-03-31", "--method", "direct"}},
+		{name: "aging", base: []string{"reports", "aging", "--type", "receivables"}},
+		{name: "balance confirmations", base: []string{"reports", "balance-confirmations", "--type", "RECEIVABLE", "--as-of", "2026-03-31"}},
+		{name: "balance confirmation", base: []string{"reports", "balance-confirmation", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--as-of", "2026-03-31"}},
+		{name: "contact statement", base: []string{"reports", "contact-statement", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--start", "2026-01-01", "--end", "2026-01-31"}},
+		{name: "sales margin", base: []string{"reports", "sales-margin", "--start", "2026-01-01", "--end", "2026-03-31"}},
+		{name: "customer profitability", base: []string{"reports", "customer-profitability", "--start", "2026-01-01", "--end", "2026-03-31"}},
+		{name: "budget vs actual", base: []string{"reports", "budget-vs-actual", "--start", "2026-03-01", "--end", "2026-03-31"}},
+	}
+	errorCases := []struct {
+		name string
+		args []string
+	}{
+		{name: "consolidated", args: []string{"reports", "consolidated", "--as-of", "2026-03-31", "--start", "2026-01-01", "--end", "2026-03-31", "--tenant-ids", "tenant-1,tenant-2"}},
+		{name: "annual", args: []string{"reports", "annual", "--period-end", "2026-12-31", "--cash-flow-method", "indirect"}},
+	}
+	for _, command := range exportCommands {
+		errorCases = append(errorCases, struct {
+			name string
+			args []string
+		}{name: command.name, args: appendArgs(command.base)})
+		for _, mode := range exportModes {
+			errorCases = append(errorCases, struct {
+				name string
+				args []string
+			}{name: command.name + " " + mode.name, args: appendArgs(command.base, mode.flag)})
+		}
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name+" API error", func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "reports API unavailable")
+			assert.Empty(t, stdout.String())
+		})
+	}
+}
+
+func TestCLICashFlowMappingValidationBranches(t *testing.T) {
+	app, _, _ := newTestCLIApp()
+	ctx := context.Background()
+	cfg := &cliConfig{TenantID: "tenant-1"}
+
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "missing subcommand",
+			args: nil,
+			want: "reports cash-flow-mapping subcommand required",
+		},
+		{
+			name: "unknown subcommand",
+			args: []string{"legacy"},
+			want: `unknown reports cash-flow-mapping subcommand "legacy"`,
+		},
+		{
+			name: "conflicting account mapping",
+			args: []string{
+				"update",
+				"--operating-accounts", "prepay",
+				"--investing-accounts", "PREPAY",
+			},
+			want: "cannot be assigned to both",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := app.runCashFlowMapping(ctx, cfg, nil, tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tc.want)
+		})
+	}
+}
+
+func TestCLICashFlowMappingFlagsAndAPIErrorBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, stdout, _ := newTestCLIApp()
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{name: "get bad flag", args: []string{"reports", "cash-flow-mapping", "get", "--bad"}},
+		{name: "update bad flag", args: []string{"reports", "cash-flow-mapping", "update", "--bad"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, "flag provided but not defined")
+			assert.Empty(t, stdout.String())
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/reports/cash-flow/mapping":
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/reports/cash-flow/mapping":
+			var req reports.CashFlowMappingOverrides
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, []string{"PREPAY"}, req.OperatingAccountCodes)
+			assert.Equal(t, []string{"CAPEX-1"}, req.InvestingAccountCodes)
+			assert.Equal(t, []string{"FOUNDERS"}, req.FinancingAccountCodes)
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "cash flow mapping API unavailable"})
+	}))
+	defer server.Close()
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{name: "get API error", args: []string{"reports", "cash-flow-mapping", "get"}},
+		{
+			name: "update API error",
+			args: []string{
+				"reports", "cash-flow-mapping", "update",
+				"--operating-accounts", "prepay",
+				"--investing-accounts", "capex-1",
+				"--financing-accounts", "founders",
+			},
+		},
+	} {
 		t.Run(tc.name, func(t *testing.T) {
-			app, _, _ := newTestCLIApp()
-			err := app.run(context.Background(), append([]string{"reports"}, tc.args...))
+			stdout.Reset()
+			err := app.run(context.Background(), tc.args)
 			require.Error(t, err)
-			assert.ErrorContains(t, err, tc.want)
+			assert.ErrorContains(t, err, "cash flow mapping API unavailable")
+			assert.Empty(t, stdout.String())
 		})
 	}
 }
 
-func TestCLIReportsAuthFlagsAndAPIErrorBranches(t *testing.T) {
-	configureCLIEnv(t)
+func cliEmployeePayload(firstName, lastName string, active bool) map[string]any {
+	return map[string]any{
+		"id":                     "emp-1",
+		"tenant_id":              "tenant-1",
+		"employee_number":        "EMP-001",
+		"first_name":             firstName,
+		"last_name":              lastName,
+		"personal_code":          "49001010001",
+		"email":                  "mari@example.com",
+		"phone":                  "+372 555 1234",
+		"address":                "Tallinn",
+		"bank_account":           "EE471000001020145685",
+		"start_date":             "2026-01-15T00:00:00Z",
+		"position":               "Accountant",
+		"department":             "Finance",
+		"employment_type":        "FULL_TIME",
+		"tax_residency":          "EE",
+		"apply_basic_exemption":  true,
+		"basic_exemption_amount": "700.00",
+		"funded_pension_rate":    "0.02",
+		"is_active":              active,
+		"created_at":             "2026-01-15T00:00:00Z",
+		"updated_at":             "2026-01-15T00:00:00Z",
+	}
+}
 
-	app, stdout, _ := newTestCLIApp()
-	err := app.run(context.Background(), []string{"reports", "trial-balance"})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "no API token configured")
-	assert.Empty(t, stdout.String())
+func cliSalaryComponentPayload(name string, taxable, recurring bool) map[string]any {
+	return map[string]any{
+		"id":             "comp-1",
+		"tenant_id":      "tenant-1",
+		"employee_id":    "emp-1",
+		"component_type": "SECONDARY_EMPLOYMENT",
+		"name":           name,
+		"amount":         "600.00",
+		"is_taxable":     taxable,
+		"is_recurring":   recurring,
+		"effective_from": "2026-03-01T00:00:00Z",
+		"created_at":     "2026-03-01T00:00:00Z",
+	}
+}
 
-	require.NoError(t, saveConfig(&cliConfig{
-		BaseURL:  "https://placeholder.example.com",
-		APIToken: "oa_saved_token",
-	}))
-	err = app.run(context.Background(), []string{"reports", "trial-balance"})
-	require.Error(t, err)
-	assert.Contains(t, err.Error(), "no tenant configured")
-	assert.Empty(t, stdout.String())
+func cliPayrollRunPayload(id, status string, year, month int) map[string]any {
+	return map[string]any{
+		"id":                  id,
+		"tenant_id":           "tenant-1",
+		"period_year":         year,
+		"period_month":        month,
+		"status":              status,
+		"payment_date":        "2026-03-31T00:00:00Z",
+		"total_gross":         "3200.00",
+		"total_net":           "2534.80",
+		"total_employer_cost": "4281.60",
+		"notes":               "March payroll",
+		"remediation_actions": cliPayrollRunRemediationPayload(id, status, year, month),
+		"created_at":          "2026-03-20T12:00:00Z",
+		"updated_at":          "2026-03-20T12:00:00Z",
+	}
+}
+
+func cliPayrollRunRemediationPayload(id, status string, year, month int) []map[string]any {
+	code := "payroll_run_calculate"
+	action := "Verify active employees and salary components, then calculate payslips for the period."
+	command := "oa payroll runs calculate --id " + id
+	switch status {
+	case "CALCULATED":
+		code = "payroll_run_approve"
+		action = "Review payroll totals and payslips, then approve the run for salary payment and TSD generation."
+		command = "oa payroll runs approve --id " + id
+	case "APPROVED":
+		code = "payroll_generate_tsd"
+		action = "Generate the TSD declaration, export it, and file it through e-MTA."
+		command = "oa tsd generate --run-id " + id
+	}
+	period := fmt.Sprintf("%04d-%02d", year, month)
+	return []map[string]any{{
+		"code":        code,
+		"severity":    "ACTION",
+		"scope":       "payroll",
+		"owner_role":  "accountant",
+		"message":     fmt.Sprintf("Payroll run %s has follow-up.", period),
+		"action":      action,
+		"period":      period,
+		"entity_type": "payroll_run",
+		"entity_id":   id,
+		"ui_path":     "/payroll?run_id=" + id,
+		"cli_command": command,
+	}}
+}
+
+func cliPayslipPayload(id, runID string) map[string]any {
+	return map[string]any{
+		"id":                              id,
+		"tenant_id":                       "tenant-1",
+		"payroll_run_id":                  runID,
+		"employee_id":                     "emp-1",
+		"gross_salary":                    "3200.00",
+		"taxable_income":                  "2500.00",
+		"income_tax":                      "550.00",
+		"unemployment_insurance_employee": "51.20",
+		"funded_pension":                  "64.00",
+		"net_salary":                      "2534.80",
+		"social_tax":                      "1056.00",
+		"unemployment_insurance_employer": "25.60",
+		"total_employer_cost":             "4281.60",
+		"basic_exemption_applied":         "700.00",
+		"payment_status":                  "PENDING",
+		"created_at":                      "2026-03-20T12:00:00Z",
+		"employee": map[string]any{
+			"id":              "emp-1",
+			"tenant_id":       "tenant-1",
+			"employee_number": "EMP-001",
+			"first_name":      "Mari",
+			"last_name":       "Maasikas",
+			"start_date":      "2026-01-01T00:00:00Z",
+			"employment_type": "FULL_TIME",
+			"is_active":       true,
+			"created_at":      "2026-01-01T00:00:00Z",
+			"updated_at":      "2026-01-01T00:00:00Z",
+		},
+	}
+}
 
+func TestCLIEmployeesCommands(t *testing.T) {
+	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
 		TenantID:   "tenant-1",
@@ -22002,153 +24616,167 @@ func TestCLIReportsAuthFlagsAndAPIErrorBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	validationCases := []struct {
-		name string
-		args []string
-		want string
-	}{
-		{name: "account balance bad flag", args: []string{"reports", "account-balance", "--bad"}, want: "flag provided but not defined"},
-		{name: "account balance conflicting output", args: []string{"reports", "account-balance", "--account-id", "acc-1", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "balance sheet bad flag", args: []string{"reports", "balance-sheet", "--bad"}, want: "flag provided but not defined"},
-		{name: "balance sheet conflicting output", args: []string{"reports", "balance-sheet", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "income statement bad flag", args: []string{"reports", "income-statement", "--bad"}, want: "flag provided but not defined"},
-		{name: "income statement conflicting output", args: []string{"reports", "income-statement", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "consolidated bad flag", args: []string{"reports", "consolidated", "--bad"}, want: "flag provided but not defined"},
-		{name: "annual bad flag", args: []string{"reports", "annual", "--bad"}, want: "flag provided but not defined"},
-		{name: "cash flow bad flag", args: []string{"reports", "cash-flow", "--bad"}, want: "flag provided but not defined"},
-		{name: "cash flow conflicting output", args: []string{"reports", "cash-flow", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "aging bad flag", args: []string{"reports", "aging", "--bad"}, want: "flag provided but not defined"},
-		{name: "aging conflicting output", args: []string{"reports", "aging", "--type", "receivables", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "balance confirmations bad flag", args: []string{"reports", "balance-confirmations", "--bad"}, want: "flag provided but not defined"},
-		{name: "balance confirmations conflicting output", args: []string{"reports", "balance-confirmations", "--type", "RECEIVABLE", "--as-of", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "balance confirmation bad flag", args: []string{"reports", "balance-confirmation", "--bad"}, want: "flag provided but not defined"},
-		{name: "balance confirmation conflicting output", args: []string{"reports", "balance-confirmation", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--as-of", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "contact statement bad flag", args: []string{"reports", "contact-statement", "--bad"}, want: "flag provided but not defined"},
-		{name: "contact statement conflicting output", args: []string{"reports", "contact-statement", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--start", "2026-01-01", "--end", "2026-01-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "sales margin bad flag", args: []string{"reports", "sales-margin", "--bad"}, want: "flag provided but not defined"},
-		{name: "sales margin conflicting output", args: []string{"reports", "sales-margin", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "customer profitability bad flag", args: []string{"reports", "customer-profitability", "--bad"}, want: "flag provided but not defined"},
-		{name: "customer profitability conflicting output", args: []string{"reports", "customer-profitability", "--start", "2026-01-01", "--end", "2026-03-31", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-		{name: "budget vs actual bad flag", args: []string{"reports", "budget-vs-actual", "--bad"}, want: "flag provided but not defined"},
-		{name: "budget vs actual conflicting output", args: []string{"reports", "budget-vs-actual", "--json", "--csv"}, want: "json, csv, xlsx, and pdf cannot be combined"},
-	}
-	for _, tc := range validationCases {
-		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, tc.want)
-			assert.Empty(t, stdout.String())
-		})
-	}
+	employeesFile := writeTempCSV(t, "employees.csv", "employee_number,first_name,last_name,start_date,base_salary\nEMP-001,Mari,Maasikas,2026-01-15,3200\n")
+	salaryComponentPayload := cliSalaryComponentPayload("Evening contract", true, true)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "reports API unavailable"})
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
+			require.Equal(t, "true", r.URL.Query().Get("active_only"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{cliEmployeePayload("Mari", "Maasikas", true)})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
+			var req payroll.CreateEmployeeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Mari", req.FirstName)
+			assert.Equal(t, "Maasikas", req.LastName)
+			assert.Equal(t, payroll.EmploymentFullTime, req.EmploymentType)
+			assert.Equal(t, "14.5", req.HourlyRate.String())
+			_ = json.NewEncoder(w).Encode(cliEmployeePayload(req.FirstName, req.LastName, true))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1":
+			_ = json.NewEncoder(w).Encode(cliEmployeePayload("Mari", "Maasikas", true))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1":
+			var req payroll.UpdateEmployeeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Maria", req.FirstName)
+			assert.Equal(t, "Finance", req.Department)
+			require.NotNil(t, req.ApplyBasicExemption)
+			assert.False(t, *req.ApplyBasicExemption)
+			require.NotNil(t, req.IsActive)
+			assert.True(t, *req.IsActive)
+			require.NotNil(t, req.HourlyRate)
+			assert.Equal(t, "15", req.HourlyRate.String())
+			_ = json.NewEncoder(w).Encode(cliEmployeePayload("Maria", "Maasikas", true))
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/salary":
+			var req map[string]any
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Contains(t, req, "amount")
+			assert.Contains(t, req, "effective_from")
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "salary updated"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/salary-components":
+			var req payroll.CreateSalaryComponentRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, payroll.SalaryComponentSecondaryEmployment, req.ComponentType)
+			assert.Equal(t, "Evening contract", req.Name)
+			assert.True(t, req.Amount.Equal(decimal.NewFromInt(600)))
+			require.NotNil(t, req.IsTaxable)
+			assert.True(t, *req.IsTaxable)
+			require.NotNil(t, req.IsRecurring)
+			assert.True(t, *req.IsRecurring)
+			_ = json.NewEncoder(w).Encode(salaryComponentPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/salary-components":
+			assert.Equal(t, "2026-03-15", r.URL.Query().Get("active_on"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{salaryComponentPayload})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/average-earnings":
+			assert.Equal(t, "2026-07-06", r.URL.Query().Get("date"))
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"employee_id":            "emp-1",
+				"reference_date":         "2026-07-06T00:00:00Z",
+				"period_start":           "2026-01-01T00:00:00Z",
+				"period_end":             "2026-06-30T00:00:00Z",
+				"source":                 payroll.AverageEarningsSourcePayslips,
+				"payslip_count":          6,
+				"total_earnings":         "19200",
+				"calendar_days":          174,
+				"average_daily_earnings": "110.3448",
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/import":
+			var req payroll.ImportEmployeesRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "employees.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "EMP-001")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"rows_processed":    1,
+				"employees_created": 1,
+				"salaries_created":  1,
+				"rows_skipped":      0,
+			})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
 	}))
 	defer server.Close()
+
 	t.Setenv("OA_BASE_URL", server.URL)
 
-	appendArgs := func(base []string, more ...string) []string {
-		args := append([]string{}, base...)
-		return append(args, more...)
-	}
-	exportModes := []struct {
-		name string
-		flag string
-	}{
-		{name: "csv", flag: "--csv"},
-		{name: "xlsx", flag: "--xlsx"},
-		{name: "pdf", flag: "--pdf"},
-	}
-	exportCommands := []struct {
-		name string
-		base []string
-	}{
-		{name: "trial balance", base: []string{"reports", "trial-balance", "--as-of", "2026-03-31"}},
-		{name: "account balance", base: []string{"reports", "account-balance", "--account-id", "acc-1", "--as-of", "2026-03-31"}},
-		{name: "balance sheet", base: []string{"reports", "balance-sheet", "--as-of", "2026-03-31"}},
-		{name: "income statement", base: []string{"reports", "income-statement", "--start", "2026-01-01", "--end", "2026-03-31"}},
-		{name: "cash flow", base: []string{"reports", "cash-flow", "--start", "2026-01-01", "--end", "2026-03-31", "--method", "direct"}},
-		{name: "aging", base: []string{"reports", "aging", "--type", "receivables"}},
-		{name: "balance confirmations", base: []string{"reports", "balance-confirmations", "--type", "RECEIVABLE", "--as-of", "2026-03-31"}},
-		{name: "balance confirmation", base: []string{"reports", "balance-confirmation", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--as-of", "2026-03-31"}},
-		{name: "contact statement", base: []string{"reports", "contact-statement", "--contact-id", "contact-1", "--type", "RECEIVABLE", "--start", "2026-01-01", "--end", "2026-01-31"}},
-		{name: "sales margin", base: []string{"reports", "sales-margin", "--start", "2026-01-01", "--end", "2026-03-31"}},
-		{name: "customer profitability", base: []string{"reports", "customer-profitability", "--start", "2026-01-01", "--end", "2026-03-31"}},
-		{name: "budget vs actual", base: []string{"reports", "budget-vs-actual", "--start", "2026-03-01", "--end", "2026-03-31"}},
-	}
-	errorCases := []struct {
-		name string
-		args []string
-	}{
-		{name: "consolidated", args: []string{"reports", "consolidated", "--as-of", "2026-03-31", "--start", "2026-01-01", "--end", "2026-03-31", "--tenant-ids", "tenant-1,tenant-2"}},
-		{name: "annual", args: []string{"reports", "annual", "--period-end", "2026-12-31", "--cash-flow-method", "indirect"}},
-	}
-	for _, command := range exportCommands {
-		errorCases = append(errorCases, struct {
-			name string
-			args []string
-		}{name: command.name, args: appendArgs(command.base)})
-		for _, mode := range exportModes {
-			errorCases = append(errorCases, struct {
-				name string
-				args []string
-			}{name: command.name + " " + mode.name, args: appendArgs(command.base, mode.flag)})
-		}
-	}
-	for _, tc := range errorCases {
-		t.Run(tc.name+" API error", func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, "reports API unavailable")
-			assert.Empty(t, stdout.String())
-		})
-	}
-}
+	app, stdout, _ := newTestCLIApp()
 
-func TestCLICashFlowMappingValidationBranches(t *testing.T) {
-	app, _, _ := newTestCLIApp()
-	ctx := context.Background()
-	cfg := &cliConfig{TenantID: "tenant-1"}
+	err := app.run(context.Background(), []string{"employees", "list", "--active-only", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"employee_number": "EMP-001"`)
 
-	for _, tc := range []struct {
-		name string
-		args []string
-		want string
-	}{
-		{
-			name: "missing subcommand",
-			args: nil,
-			want: "reports cash-flow-mapping subcommand required",
-		},
-		{
-			name: "unknown subcommand",
-			args: []string{"legacy"},
-			want: `unknown reports cash-flow-mapping subcommand "legacy"`,
-		},
-		{
-			name: "conflicting account mapping",
-			args: []string{
-				"update",
-				"--operating-accounts", "prepay",
-				"--investing-accounts", "PREPAY",
-			},
-			want: "cannot be assigned to both",
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			err := app.runCashFlowMapping(ctx, cfg, nil, tc.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, tc.want)
-		})
-	}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"employees",
+		"create",
+		"--employee-number", "EMP-001",
+		"--first-name", "Mari",
+		"--last-name", "Maasikas",
+		"--start-date", "2026-01-15",
+		"--employment-type", "FULL_TIME",
+		"--hourly-rate", "14.50",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Created employee Mari Maasikas (emp-1)")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "get", "--id", "emp-1"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Employee Mari Maasikas")
+	assert.Contains(t, stdout.String(), "Position: Accountant")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"employees", "update",
+		"--id", "emp-1",
+		"--first-name", "Maria",
+		"--department", "Finance",
+		"--apply-basic-exemption", "false",
+		"--active", "true",
+		"--hourly-rate", "15",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Employee Maria Maasikas")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "set-salary", "--id", "emp-1", "--amount", "3200.00", "--effective-from", "2026-03-01"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Set base salary for employee emp-1 to 3200")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"employees", "add-salary-component",
+		"--id", "emp-1",
+		"--type", "SECONDARY_EMPLOYMENT",
+		"--name", "Evening contract",
+		"--amount", "600.00",
+		"--effective-from", "2026-03-01",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Added salary component Evening contract (comp-1) for employee emp-1")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "salary-components", "--id", "emp-1", "--active-on", "2026-03-15"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "SECONDARY_EMPLOYMENT")
+	assert.Contains(t, stdout.String(), "Evening contract")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "average-earnings", "--id", "emp-1", "--date", "2026-07-06"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Period: 2026-01-01 to 2026-06-30")
+	assert.Contains(t, stdout.String(), "Source: PAYSLIPS (6 payslips)")
+	assert.Contains(t, stdout.String(), "Average daily earnings: 110.3448")
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "import", "--file", employeesFile})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 employees, set 1 salaries, skipped 0 rows")
 }
 
-func TestCLICashFlowMappingFlagsAndAPIErrorBranches(t *testing.T) {
+func TestCLIEmployeesBranches(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -22162,189 +24790,224 @@ func TestCLICashFlowMappingFlagsAndAPIErrorBranches(t *testing.T) {
 	for _, tc := range []struct {
 		name string
 		args []string
+		want string
 	}{
-		{name: "get bad flag", args: []string{"reports", "cash-flow-mapping", "get", "--bad"}},
-		{name: "update bad flag", args: []string{"reports", "cash-flow-mapping", "update", "--bad"}},
+		{name: "missing subcommand", args: nil, want: "employees subcommand required"},
+		{name: "unknown subcommand", args: []string{"legacy"}, want: `unknown employees subcommand "legacy"`},
+		{name: "list bad flag", args: []string{"list", "--bogus"}, want: "flag provided but not defined"},
+		{name: "create missing required", args: []string{"create", "--first-name", "Mari"}, want: "first-name, last-name, and start-date are required"},
+		{name: "create invalid start date", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "soon"}, want: "parse start-date:"},
+		{name: "create invalid basic exemption amount", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "2026-01-15", "--basic-exemption-amount", "seven"}, want: "parse basic-exemption-amount:"},
+		{name: "create invalid funded pension rate", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "2026-01-15", "--funded-pension-rate", "two"}, want: "parse funded-pension-rate:"},
+		{name: "create invalid hourly rate", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "2026-01-15", "--hourly-rate", "twelve"}, want: "parse hourly-rate:"},
+		{name: "get missing id", args: []string{"get"}, want: "id is required"},
+		{name: "update missing id", args: []string{"update", "--first-name", "Mari"}, want: "id is required"},
+		{name: "update invalid end date", args: []string{"update", "--id", "emp-1", "--end-date", "later"}, want: "parse end-date:"},
+		{name: "update invalid basic exemption bool", args: []string{"update", "--id", "emp-1", "--apply-basic-exemption", "maybe"}, want: "parse apply-basic-exemption:"},
+		{name: "update invalid basic exemption amount", args: []string{"update", "--id", "emp-1", "--basic-exemption-amount", "many"}, want: "parse basic-exemption-amount:"},
+		{name: "update invalid funded pension rate", args: []string{"update", "--id", "emp-1", "--funded-pension-rate", "two"}, want: "parse funded-pension-rate:"},
+		{name: "update invalid active bool", args: []string{"update", "--id", "emp-1", "--active", "sometimes"}, want: "parse active:"},
+		{name: "update negative hourly rate", args: []string{"update", "--id", "emp-1", "--hourly-rate", "-1"}, want: "hourly-rate must be non-negative"},
+		{name: "set salary missing id", args: []string{"set-salary", "--amount", "3200", "--effective-from", "2026-03-01"}, want: "id is required"},
+		{name: "set salary missing amount", args: []string{"set-salary", "--id", "emp-1", "--effective-from", "2026-03-01"}, want: "amount is required"},
+		{name: "set salary invalid date", args: []string{"set-salary", "--id", "emp-1", "--amount", "3200", "--effective-from", "march"}, want: "parse effective-from:"},
+		{name: "salary components missing id", args: []string{"salary-components"}, want: "id is required"},
+		{name: "salary components invalid active on", args: []string{"salary-components", "--id", "emp-1", "--active-on", "today"}, want: "parse active-on:"},
+		{name: "average earnings missing id", args: []string{"average-earnings", "--date", "2026-07-06"}, want: "id is required"},
+		{name: "average earnings missing date", args: []string{"average-earnings", "--id", "emp-1"}, want: "date is required"},
+		{name: "average earnings invalid date", args: []string{"average-earnings", "--id", "emp-1", "--date", "july"}, want: "parse date:"},
+		{name: "add salary component missing id", args: []string{"add-salary-component", "--amount", "600", "--effective-from", "2026-03-01"}, want: "id is required"},
+		{name: "add salary component invalid amount", args: []string{"add-salary-component", "--id", "emp-1", "--amount", "bonus", "--effective-from", "2026-03-01"}, want: "parse amount:"},
+		{name: "add salary component missing effective from", args: []string{"add-salary-component", "--id", "emp-1", "--amount", "600"}, want: "effective-from is required"},
+		{name: "add salary component invalid effective to", args: []string{"add-salary-component", "--id", "emp-1", "--amount", "600", "--effective-from", "2026-03-01", "--effective-to", "forever"}, want: "parse effective-to:"},
+		{name: "import missing file", args: []string{"import"}, want: "file is required"},
+		{name: "import unreadable file", args: []string{"import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "read file"},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
+			err := app.run(context.Background(), append([]string{"employees"}, tc.args...))
 			require.Error(t, err)
-			assert.ErrorContains(t, err, "flag provided but not defined")
-			assert.Empty(t, stdout.String())
+			assert.Contains(t, err.Error(), tc.want)
 		})
 	}
 
+	importFile := writeTempCSV(t, "employees-branch.csv", "employee_number,first_name,last_name,start_date,base_salary\nEMP-002,Jaan,Tamm,2026-02-01,2800\n")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/reports/cash-flow/mapping":
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/reports/cash-flow/mapping":
-			var req reports.CashFlowMappingOverrides
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
+			require.Equal(t, "", r.URL.Query().Get("active_only"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{cliEmployeePayload("Jaan", "Tamm", false)})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
+			var req payroll.CreateEmployeeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "EMP-002", req.EmployeeNumber)
+			assert.Equal(t, "Jaan", req.FirstName)
+			assert.Equal(t, "Tamm", req.LastName)
+			assert.Equal(t, "2026-02-01", req.StartDate.Format("2006-01-02"))
+			assert.Equal(t, payroll.EmploymentType("PART_TIME"), req.EmploymentType)
+			assert.False(t, req.ApplyBasicExemption)
+			assert.True(t, req.BasicExemptionAmount.Equal(decimal.Zero))
+			assert.True(t, req.FundedPensionRate.Equal(decimal.RequireFromString("0.03")))
+			payload := cliEmployeePayload(req.FirstName, req.LastName, true)
+			payload["employee_number"] = req.EmployeeNumber
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2":
+			_ = json.NewEncoder(w).Encode(cliEmployeePayload("Jaan", "Tamm", true))
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2":
+			var req payroll.UpdateEmployeeRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "EMP-003", req.EmployeeNumber)
+			assert.Equal(t, "Jaan", req.FirstName)
+			assert.Equal(t, "Kask", req.LastName)
+			assert.Equal(t, "Developer", req.Position)
+			assert.Equal(t, "Engineering", req.Department)
+			assert.Equal(t, payroll.EmploymentType("CONTRACT"), req.EmploymentType)
+			require.NotNil(t, req.EndDate)
+			assert.Equal(t, "2026-12-31", req.EndDate.Format("2006-01-02"))
+			require.NotNil(t, req.ApplyBasicExemption)
+			assert.True(t, *req.ApplyBasicExemption)
+			require.NotNil(t, req.BasicExemptionAmount)
+			assert.True(t, req.BasicExemptionAmount.Equal(decimal.RequireFromString("500.00")))
+			require.NotNil(t, req.FundedPensionRate)
+			assert.True(t, req.FundedPensionRate.Equal(decimal.RequireFromString("0.04")))
+			require.NotNil(t, req.IsActive)
+			assert.False(t, *req.IsActive)
+			payload := cliEmployeePayload(req.FirstName, req.LastName, false)
+			payload["employee_number"] = req.EmployeeNumber
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2/salary":
+			var req map[string]any
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, []string{"PREPAY"}, req.OperatingAccountCodes)
-			assert.Equal(t, []string{"CAPEX-1"}, req.InvestingAccountCodes)
-			assert.Equal(t, []string{"FOUNDERS"}, req.FinancingAccountCodes)
+			assert.Equal(t, "2800", req["amount"])
+			assert.Contains(t, req["effective_from"], "2026-02-01")
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "salary updated"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2/salary-components":
+			require.Equal(t, "", r.URL.Query().Get("active_on"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{cliSalaryComponentPayload("Branch bonus", false, false)})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2/salary-components":
+			var req payroll.CreateSalaryComponentRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, payroll.SalaryComponentBonus, req.ComponentType)
+			assert.Equal(t, "Branch bonus", req.Name)
+			assert.True(t, req.Amount.Equal(decimal.NewFromInt(500)))
+			require.NotNil(t, req.IsTaxable)
+			assert.False(t, *req.IsTaxable)
+			require.NotNil(t, req.IsRecurring)
+			assert.False(t, *req.IsRecurring)
+			require.NotNil(t, req.EffectiveTo)
+			assert.Equal(t, "2026-12-31", req.EffectiveTo.Format("2006-01-02"))
+			payload := cliSalaryComponentPayload(req.Name, false, false)
+			payload["effective_to"] = "2026-12-31T00:00:00Z"
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/import":
+			var req payroll.ImportEmployeesRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "employees-branch.csv", req.FileName)
+			assert.Contains(t, req.CSVContent, "EMP-002")
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"file_name":         req.FileName,
+				"rows_processed":    2,
+				"employees_created": 1,
+				"salaries_created":  0,
+				"rows_skipped":      1,
+				"errors": []map[string]any{
+					{"row": 2, "employee_number": "EMP-404", "message": "missing first name"},
+				},
+			})
 		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
 		}
-
-		w.WriteHeader(http.StatusServiceUnavailable)
-		_ = json.NewEncoder(w).Encode(map[string]string{"error": "cash flow mapping API unavailable"})
 	}))
 	defer server.Close()
+
 	t.Setenv("OA_BASE_URL", server.URL)
 
-	for _, tc := range []struct {
-		name string
-		args []string
-	}{
-		{name: "get API error", args: []string{"reports", "cash-flow-mapping", "get"}},
-		{
-			name: "update API error",
-			args: []string{
-				"reports", "cash-flow-mapping", "update",
-				"--operating-accounts", "prepay",
-				"--investing-accounts", "capex-1",
-				"--financing-accounts", "founders",
-			},
-		},
-	} {
-		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), tc.args)
-			require.Error(t, err)
-			assert.ErrorContains(t, err, "cash flow mapping API unavailable")
-			assert.Empty(t, stdout.String())
-		})
-	}
-}
+	stdout.Reset()
+	err := app.run(context.Background(), []string{"employees", "list"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), "Jaan Tamm")
 
-func cliEmployeePayload(firstName, lastName string, active bool) map[string]any {
-	return map[string]any{
-		"id":                     "emp-1",
-		"tenant_id":              "tenant-1",
-		"employee_number":        "EMP-001",
-		"first_name":             firstName,
-		"last_name":              lastName,
-		"personal_code":          "49001010001",
-		"email":                  "mari@example.com",
-		"phone":                  "+372 555 1234",
-		"address":                "Tallinn",
-		"bank_account":           "EE471000001020145685",
-		"start_date":             "2026-01-15T00:00:00Z",
-		"position":               "Accountant",
-		"department":             "Finance",
-		"employment_type":        "FULL_TIME",
-		"tax_residency":          "EE",
-		"apply_basic_exemption":  true,
-		"basic_exemption_amount": "700.00",
-		"funded_pension_rate":    "0.02",
-		"is_active":              active,
-		"created_at":             "2026-01-15T00:00:00Z",
-		"updated_at":             "2026-01-15T00:00:00Z",
-	}
-}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"employees", "create",
+		"--employee-number", " EMP-002 ",
+		"--first-name", " Jaan ",
+		"--last-name", " Tamm ",
+		"--start-date", "2026-02-01",
+		"--employment-type", "part_time",
+		"--apply-basic-exemption=false",
+		"--funded-pension-rate", "0.03",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"employee_number": "EMP-002"`)
+	assert.Contains(t, stdout.String(), `"first_name": "Jaan"`)
 
-func cliSalaryComponentPayload(name string, taxable, recurring bool) map[string]any {
-	return map[string]any{
-		"id":             "comp-1",
-		"tenant_id":      "tenant-1",
-		"employee_id":    "emp-1",
-		"component_type": "SECONDARY_EMPLOYMENT",
-		"name":           name,
-		"amount":         "600.00",
-		"is_taxable":     taxable,
-		"is_recurring":   recurring,
-		"effective_from": "2026-03-01T00:00:00Z",
-		"created_at":     "2026-03-01T00:00:00Z",
-	}
-}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "get", "--id", " emp-2 ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"first_name": "Jaan"`)
 
-func cliPayrollRunPayload(id, status string, year, month int) map[string]any {
-	return map[string]any{
-		"id":                  id,
-		"tenant_id":           "tenant-1",
-		"period_year":         year,
-		"period_month":        month,
-		"status":              status,
-		"payment_date":        "2026-03-31T00:00:00Z",
-		"total_gross":         "3200.00",
-		"total_net":           "2534.80",
-		"total_employer_cost": "4281.60",
-		"notes":               "March payroll",
-		"remediation_actions": cliPayrollRunRemediationPayload(id, status, year, month),
-		"created_at":          "2026-03-20T12:00:00Z",
-		"updated_at":          "2026-03-20T12:00:00Z",
-	}
-}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"employees", "update",
+		"--id", " emp-2 ",
+		"--employee-number", " EMP-003 ",
+		"--first-name", " Jaan ",
+		"--last-name", " Kask ",
+		"--position", " Developer ",
+		"--department", " Engineering ",
+		"--employment-type", "contract",
+		"--end-date", "2026-12-31",
+		"--apply-basic-exemption", "true",
+		"--basic-exemption-amount", "500.00",
+		"--funded-pension-rate", "0.04",
+		"--active", "false",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"last_name": "Kask"`)
+	assert.Contains(t, stdout.String(), `"is_active": false`)
 
-func cliPayrollRunRemediationPayload(id, status string, year, month int) []map[string]any {
-	code := "payroll_run_calculate"
-	action := "Verify active employees and salary components, then calculate payslips for the period."
-	command := "oa payroll runs calculate --id " + id
-	switch status {
-	case "CALCULATED":
-		code = "payroll_run_approve"
-		action = "Review payroll totals and payslips, then approve the run for salary payment and TSD generation."
-		command = "oa payroll runs approve --id " + id
-	case "APPROVED":
-		code = "payroll_generate_tsd"
-		action = "Generate the TSD declaration, export it, and file it through e-MTA."
-		command = "oa tsd generate --run-id " + id
-	}
-	period := fmt.Sprintf("%04d-%02d", year, month)
-	return []map[string]any{{
-		"code":        code,
-		"severity":    "ACTION",
-		"scope":       "payroll",
-		"owner_role":  "accountant",
-		"message":     fmt.Sprintf("Payroll run %s has follow-up.", period),
-		"action":      action,
-		"period":      period,
-		"entity_type": "payroll_run",
-		"entity_id":   id,
-		"ui_path":     "/payroll?run_id=" + id,
-		"cli_command": command,
-	}}
-}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "set-salary", "--id", " emp-2 ", "--amount", "2800", "--effective-from", "2026-02-01", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"status": "salary updated"`)
 
-func cliPayslipPayload(id, runID string) map[string]any {
-	return map[string]any{
-		"id":                              id,
-		"tenant_id":                       "tenant-1",
-		"payroll_run_id":                  runID,
-		"employee_id":                     "emp-1",
-		"gross_salary":                    "3200.00",
-		"taxable_income":                  "2500.00",
-		"income_tax":                      "550.00",
-		"unemployment_insurance_employee": "51.20",
-		"funded_pension":                  "64.00",
-		"net_salary":                      "2534.80",
-		"social_tax":                      "1056.00",
-		"unemployment_insurance_employer": "25.60",
-		"total_employer_cost":             "4281.60",
-		"basic_exemption_applied":         "700.00",
-		"payment_status":                  "PENDING",
-		"created_at":                      "2026-03-20T12:00:00Z",
-		"employee": map[string]any{
-			"id":              "emp-1",
-			"tenant_id":       "tenant-1",
-			"employee_number": "EMP-001",
-			"first_name":      "Mari",
-			"last_name":       "Maasikas",
-			"start_date":      "2026-01-01T00:00:00Z",
-			"employment_type": "FULL_TIME",
-			"is_active":       true,
-			"created_at":      "2026-01-01T00:00:00Z",
-			"updated_at":      "2026-01-01T00:00:00Z",
-		},
-	}
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "salary-components", "--id", " emp-2 ", "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"name": "Branch bonus"`)
+	assert.Contains(t, stdout.String(), `"is_taxable": false`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{
+		"employees", "add-salary-component",
+		"--id", " emp-2 ",
+		"--type", "bonus",
+		"--name", " Branch bonus ",
+		"--amount", "500",
+		"--effective-from", "2026-02-01",
+		"--effective-to", "2026-12-31",
+		"--taxable=false",
+		"--recurring=false",
+		"--json",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"name": "Branch bonus"`)
+	assert.Contains(t, stdout.String(), `"effective_to": "2026-12-31T00:00:00Z"`)
+
+	stdout.Reset()
+	err = app.run(context.Background(), []string{"employees", "import", "--file", importFile, "--json"})
+	require.NoError(t, err)
+	assert.Contains(t, stdout.String(), `"rows_skipped": 1`)
+	assert.Contains(t, stdout.String(), `"message": "missing first name"`)
 }
 
-func TestCLIEmployeesCommands(t *testing.T) {
+func TestCLITimesheetsCommands(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -22354,67 +25017,52 @@ func TestCLIEmployeesCommands(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	employeesFile := writeTempCSV(t, "employees.csv", "employee_number,first_name,last_name,start_date,base_salary\nEMP-001,Mari,Maasikas,2026-01-15,3200\n")
-	salaryComponentPayload := cliSalaryComponentPayload("Evening contract", true, true)
+	timesheetsFile := writeTempCSV(t, "timesheets.csv", "employee_number,work_date,regular_hours,overtime_hours\nEMP-001,2026-03-02,8,2\n")
+	entryPayload := map[string]any{
+		"id":             "ts-1",
+		"tenant_id":      "tenant-1",
+		"employee_id":    "emp-1",
+		"work_date":      "2026-03-02T00:00:00Z",
+		"regular_hours":  "8",
+		"overtime_hours": "2",
+		"night_hours":    "0",
+		"holiday_hours":  "0",
+		"status":         payroll.TimesheetPending,
+	}
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
-			require.Equal(t, "true", r.URL.Query().Get("active_only"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{cliEmployeePayload("Mari", "Maasikas", true)})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
-			var req payroll.CreateEmployeeRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Mari", req.FirstName)
-			assert.Equal(t, "Maasikas", req.LastName)
-			assert.Equal(t, payroll.EmploymentFullTime, req.EmploymentType)
-			_ = json.NewEncoder(w).Encode(cliEmployeePayload(req.FirstName, req.LastName, true))
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1":
-			_ = json.NewEncoder(w).Encode(cliEmployeePayload("Mari", "Maasikas", true))
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1":
-			var req payroll.UpdateEmployeeRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "Maria", req.FirstName)
-			assert.Equal(t, "Finance", req.Department)
-			require.NotNil(t, req.ApplyBasicExemption)
-			assert.False(t, *req.ApplyBasicExemption)
-			require.NotNil(t, req.IsActive)
-			assert.True(t, *req.IsActive)
-			_ = json.NewEncoder(w).Encode(cliEmployeePayload("Maria", "Maasikas", true))
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/salary":
-			var req map[string]any
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Contains(t, req, "amount")
-			assert.Contains(t, req, "effective_from")
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "salary updated"})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/salary-components":
-			var req payroll.CreateSalaryComponentRequest
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/timesheets":
+			assert.Equal(t, "emp-1", r.URL.Query().Get("employee_id"))
+			assert.Equal(t, "2026-03-01", r.URL.Query().Get("from_date"))
+			assert.Equal(t, "2026-03-31", r.URL.Query().Get("to_date"))
+			assert.Equal(t, "PENDING", r.URL.Query().Get("status"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{entryPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/timesheets":
+			var req payroll.CreateTimesheetEntryRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, payroll.SalaryComponentSecondaryEmployment, req.ComponentType)
-			assert.Equal(t, "Evening contract", req.Name)
-			assert.True(t, req.Amount.Equal(decimal.NewFromInt(600)))
-			require.NotNil(t, req.IsTaxable)
-			assert.True(t, *req.IsTaxable)
-			require.NotNil(t, req.IsRecurring)
-			assert.True(t, *req.IsRecurring)
-			_ = json.NewEncoder(w).Encode(salaryComponentPayload)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/salary-components":
-			assert.Equal(t, "2026-03-15", r.URL.Query().Get("active_on"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{salaryComponentPayload})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/import":
-			var req payroll.ImportEmployeesRequest
+			assert.Equal(t, "emp-1", req.EmployeeID)
+			assert.Equal(t, "2026-03-02", req.WorkDate.Format("2006-01-02"))
+			assert.Equal(t, "8", req.RegularHours.String())
+			assert.Equal(t, "2", req.OvertimeHours.String())
+			assert.True(t, req.NightHours.IsZero())
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(entryPayload)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/timesheets/import":
+			var req payroll.ImportTimesheetsRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "employees.csv", req.FileName)
+			assert.Equal(t, "timesheets.csv", req.FileName)
 			assert.Contains(t, req.CSVContent, "EMP-001")
-			_ = json.NewEncoder(w).Encode(map[string]any{
-				"rows_processed":    1,
-				"employees_created": 1,
-				"salaries_created":  1,
-				"rows_skipped":      0,
-			})
+			_ = json.NewEncoder(w).Encode(payroll.ImportTimesheetsResult{RowsProcessed: 1, EntriesCreated: 1})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/timesheets/approve":
+			var req payroll.ApproveTimesheetsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-03-01", req.From.Format("2006-01-02"))
+			assert.Equal(t, "2026-03-31", req.To.Format("2006-01-02"))
+			_ = json.NewEncoder(w).Encode(payroll.ApproveTimesheetsResult{Approved: 21})
 		default:
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
@@ -22425,71 +25073,152 @@ func TestCLIEmployeesCommands(t *testing.T) {
 
 	app, stdout, _ := newTestCLIApp()
 
-	err := app.run(context.Background(), []string{"employees", "list", "--active-only", "--json"})
+	err := app.run(context.Background(), []string{"timesheets", "list", "--employee-id", "emp-1", "--from", "2026-03-01", "--to", "2026-03-31", "--status", "pending"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"employee_number": "EMP-001"`)
+	assert.Contains(t, stdout.String(), "OVERTIME")
+	assert.Contains(t, stdout.String(), "2026-03-02")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"employees",
-		"create",
-		"--employee-number", "EMP-001",
-		"--first-name", "Mari",
-		"--last-name", "Maasikas",
-		"--start-date", "2026-01-15",
-		"--employment-type", "FULL_TIME",
-	})
+	err = app.run(context.Background(), []string{"timesheets", "create", "--employee-id", "emp-1", "--date", "2026-03-02", "--regular-hours", "8", "--overtime-hours", "2"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Created employee Mari Maasikas (emp-1)")
+	assert.Contains(t, stdout.String(), "Saved timesheet ts-1 for 2026-03-02 (10 hours)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "get", "--id", "emp-1"})
+	err = app.run(context.Background(), []string{"timesheets", "import", "--file", timesheetsFile})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Employee Mari Maasikas")
-	assert.Contains(t, stdout.String(), "Position: Accountant")
+	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 entries, updated 0 entries, skipped 0 rows")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"employees", "update",
-		"--id", "emp-1",
-		"--first-name", "Maria",
-		"--department", "Finance",
-		"--apply-basic-exemption", "false",
-		"--active", "true",
-	})
+	err = app.run(context.Background(), []string{"timesheets", "approve", "--from", "2026-03-01", "--to", "2026-03-31", "--json"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Employee Maria Maasikas")
+	assert.Contains(t, stdout.String(), `"approved": 21`)
+}
 
-	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "set-salary", "--id", "emp-1", "--amount", "3200.00", "--effective-from", "2026-03-01"})
+func TestCLITimesheetsBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, _, _ := newTestCLIApp()
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: nil, want: "timesheets subcommand required"},
+		{name: "unknown subcommand", args: []string{"legacy"}, want: `unknown timesheets subcommand "legacy"`},
+		{name: "list invalid from", args: []string{"list", "--from", "march"}, want: "parse from:"},
+		{name: "list invalid to", args: []string{"list", "--to", "april"}, want: "parse to:"},
+		{name: "create missing employee", args: []string{"create", "--date", "2026-03-02"}, want: "employee-id is required"},
+		{name: "create missing date", args: []string{"create", "--employee-id", "emp-1"}, want: "date is required"},
+		{name: "create invalid hours", args: []string{"create", "--employee-id", "emp-1", "--date", "2026-03-02", "--night-hours", "late"}, want: "parse night-hours:"},
+		{name: "create negative hours", args: []string{"create", "--employee-id", "emp-1", "--date", "2026-03-02", "--holiday-hours", "-2"}, want: "holiday-hours must be non-negative"},
+		{name: "import missing file", args: []string{"import"}, want: "file is required"},
+		{name: "import unreadable file", args: []string{"import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "read file"},
+		{name: "approve missing from", args: []string{"approve", "--to", "2026-03-31"}, want: "from is required"},
+		{name: "approve invalid to", args: []string{"approve", "--from", "2026-03-01", "--to", "end"}, want: "parse to:"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := app.run(context.Background(), append([]string{"timesheets"}, tc.args...))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestCLIEmploymentRegisterCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	eventPayload := map[string]any{
+		"id":                 "event-1",
+		"tenant_id":          "tenant-1",
+		"employee_id":        "emp-1",
+		"event_type":         payroll.EmploymentEventSuspensionStart,
+		"event_date":         "2026-04-01T00:00:00Z",
+		"suspension_reason":  "PARENTAL_LEAVE",
+		"working_time_ratio": "1",
+	}
+	outputPath := filepath.Join(t.TempDir(), "tor.csv")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/employment-events":
+			_ = json.NewEncoder(w).Encode([]map[string]any{eventPayload})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-1/employment-events":
+			var req payroll.CreateEmploymentEventRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, payroll.EmploymentEventSuspensionStart, req.EventType)
+			assert.Equal(t, "2026-04-01", req.EventDate.Format("2006-01-02"))
+			assert.Equal(t, "PARENTAL_LEAVE", req.SuspensionReason)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(eventPayload)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employment-register/pending":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{
+				"employee_id":     "emp-1",
+				"employee_number": "EMP-001",
+				"employee_name":   "Mari Maasikas",
+				"events":          []map[string]any{eventPayload},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employment-register/export":
+			var req payroll.ExportEmploymentRegisterRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "2026-04-01", req.From.Format("2006-01-02"))
+			assert.Equal(t, "2026-04-30", req.To.Format("2006-01-02"))
+			assert.True(t, req.IncludeExported)
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("row_number;personal_code\n1;49001010001\n"))
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	app, stdout, _ := newTestCLIApp()
+
+	err := app.run(context.Background(), []string{"employees", "employment-events", "--id", "emp-1"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Set base salary for employee emp-1 to 3200")
+	assert.Contains(t, stdout.String(), "SUSPENSION_START")
+	assert.Contains(t, stdout.String(), "PARENTAL_LEAVE")
+	assert.Contains(t, stdout.String(), "pending")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"employees", "add-salary-component",
-		"--id", "emp-1",
-		"--type", "SECONDARY_EMPLOYMENT",
-		"--name", "Evening contract",
-		"--amount", "600.00",
-		"--effective-from", "2026-03-01",
-	})
+	err = app.run(context.Background(), []string{"employees", "add-employment-event", "--id", "emp-1", "--type", "suspension_start", "--date", "2026-04-01", "--suspension-reason", "PARENTAL_LEAVE"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Added salary component Evening contract (comp-1) for employee emp-1")
+	assert.Contains(t, stdout.String(), "Recorded SUSPENSION_START employment event event-1 on 2026-04-01")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "salary-components", "--id", "emp-1", "--active-on", "2026-03-15"})
+	err = app.run(context.Background(), []string{"employment-register", "pending"})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "SECONDARY_EMPLOYMENT")
-	assert.Contains(t, stdout.String(), "Evening contract")
+	assert.Contains(t, stdout.String(), "Mari Maasikas")
+	assert.Contains(t, stdout.String(), "2026-04-01")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "import", "--file", employeesFile})
+	err = app.run(context.Background(), []string{"employment-register", "export", "--from", "2026-04-01", "--to", "2026-04-30", "--include-exported", "--output", outputPath})
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Processed 1 rows, created 1 employees, set 1 salaries, skipped 0 rows")
+	assert.Contains(t, stdout.String(), "Wrote employment register CSV to "+outputPath)
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1;49001010001")
 }
 
-func TestCLIEmployeesBranches(t *testing.T) {
+func TestCLIEmploymentRegisterBranches(t *testing.T) {
 	configureCLIEnv(t)
 	require.NoError(t, saveConfig(&cliConfig{
 		BaseURL:    "https://placeholder.example.com",
@@ -22499,220 +25228,193 @@ func TestCLIEmployeesBranches(t *testing.T) {
 		APIToken:   "oa_saved_token",
 	}))
 
-	app, stdout, _ := newTestCLIApp()
+	app, _, _ := newTestCLIApp()
 	for _, tc := range []struct {
 		name string
 		args []string
 		want string
 	}{
-		{name: "missing subcommand", args: nil, want: "employees subcommand required"},
-		{name: "unknown subcommand", args: []string{"legacy"}, want: `unknown employees subcommand "legacy"`},
-		{name: "list bad flag", args: []string{"list", "--bogus"}, want: "flag provided but not defined"},
-		{name: "create missing required", args: []string{"create", "--first-name", "Mari"}, want: "first-name, last-name, and start-date are required"},
-		{name: "create invalid start date", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "soon"}, want: "parse start-date:"},
-		{name: "create invalid basic exemption amount", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "2026-01-15", "--basic-exemption-amount", "seven"}, want: "parse basic-exemption-amount:"},
-		{name: "create invalid funded pension rate", args: []string{"create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "2026-01-15", "--funded-pension-rate", "two"}, want: "parse funded-pension-rate:"},
-		{name: "get missing id", args: []string{"get"}, want: "id is required"},
-		{name: "update missing id", args: []string{"update", "--first-name", "Mari"}, want: "id is required"},
-		{name: "update invalid end date", args: []string{"update", "--id", "emp-1", "--end-date", "later"}, want: "parse end-date:"},
-		{name: "update invalid basic exemption bool", args: []string{"update", "--id", "emp-1", "--apply-basic-exemption", "maybe"}, want: "parse apply-basic-exemption:"},
-		{name: "update invalid basic exemption amount", args: []string{"update", "--id", "emp-1", "--basic-exemption-amount", "many"}, want: "parse basic-exemption-amount:"},
-		{name: "update invalid funded pension rate", args: []string{"update", "--id", "emp-1", "--funded-pension-rate", "two"}, want: "parse funded-pension-rate:"},
-		{name: "update invalid active bool", args: []string{"update", "--id", "emp-1", "--active", "sometimes"}, want: "parse active:"},
-		{name: "set salary missing id", args: []string{"set-salary", "--amount", "3200", "--effective-from", "2026-03-01"}, want: "id is required"},
-		{name: "set salary missing amount", args: []string{"set-salary", "--id", "emp-1", "--effective-from", "2026-03-01"}, want: "amount is required"},
-		{name: "set salary invalid date", args: []string{"set-salary", "--id", "emp-1", "--amount", "3200", "--effective-from", "march"}, want: "parse effective-from:"},
-		{name: "salary components missing id", args: []string{"salary-components"}, want: "id is required"},
-		{name: "salary components invalid active on", args: []string{"salary-components", "--id", "emp-1", "--active-on", "today"}, want: "parse active-on:"},
-		{name: "add salary component missing id", args: []string{"add-salary-component", "--amount", "600", "--effective-from", "2026-03-01"}, want: "id is required"},
-		{name: "add salary component invalid amount", args: []string{"add-salary-component", "--id", "emp-1", "--amount", "bonus", "--effective-from", "2026-03-01"}, want: "parse amount:"},
-		{name: "add salary component missing effective from", args: []string{"add-salary-component", "--id", "emp-1", "--amount", "600"}, want: "effective-from is required"},
-		{name: "add salary component invalid effective to", args: []string{"add-salary-component", "--id", "emp-1", "--amount", "600", "--effective-from", "2026-03-01", "--effective-to", "forever"}, want: "parse effective-to:"},
-		{name: "import missing file", args: []string{"import"}, want: "file is required"},
-		{name: "import unreadable file", args: []string{"import", "--file", filepath.Join(t.TempDir(), "missing.csv")}, want: "read file"},
+		{name: "missing subcommand", args: []string{"employment-register"}, want: "employment-register subcommand required"},
+		{name: "unknown subcommand", args: []string{"employment-register", "submit"}, want: `unknown employment-register subcommand "submit"`},
+		{name: "export missing from", args: []string{"employment-register", "export", "--to", "2026-04-30"}, want: "from is required"},
+		{name: "export invalid to", args: []string{"employment-register", "export", "--from", "2026-04-01", "--to", "end"}, want: "parse to:"},
+		{name: "events missing id", args: []string{"employees", "employment-events"}, want: "id is required"},
+		{name: "add event missing id", args: []string{"employees", "add-employment-event", "--type", "END"}, want: "id is required"},
+		{name: "add event missing type", args: []string{"employees", "add-employment-event", "--id", "emp-1"}, want: "type is required"},
+		{name: "add event missing date", args: []string{"employees", "add-employment-event", "--id", "emp-1", "--type", "END"}, want: "date is required"},
+		{name: "add event invalid ratio", args: []string{"employees", "add-employment-event", "--id", "emp-1", "--type", "WORKING_TIME_CHANGE", "--date", "2026-04-01", "--working-time-ratio", "0"}, want: "working-time-ratio must be positive"},
+		{name: "create employee invalid ratio", args: []string{"employees", "create", "--first-name", "Mari", "--last-name", "Maasikas", "--start-date", "2026-04-01", "--working-time-ratio", "half"}, want: "parse working-time-ratio:"},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			stdout.Reset()
-			err := app.run(context.Background(), append([]string{"employees"}, tc.args...))
+			err := app.run(context.Background(), tc.args)
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), tc.want)
 		})
 	}
+}
 
-	importFile := writeTempCSV(t, "employees-branch.csv", "employee_number,first_name,last_name,start_date,base_salary\nEMP-002,Jaan,Tamm,2026-02-01,2800\n")
+func TestCLISelfServiceCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	outputPath := filepath.Join(t.TempDir(), "payslip.pdf")
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
 
 		switch {
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
-			require.Equal(t, "", r.URL.Query().Get("active_only"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{cliEmployeePayload("Jaan", "Tamm", false)})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees":
-			var req payroll.CreateEmployeeRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "EMP-002", req.EmployeeNumber)
-			assert.Equal(t, "Jaan", req.FirstName)
-			assert.Equal(t, "Tamm", req.LastName)
-			assert.Equal(t, "2026-02-01", req.StartDate.Format("2006-01-02"))
-			assert.Equal(t, payroll.EmploymentType("PART_TIME"), req.EmploymentType)
-			assert.False(t, req.ApplyBasicExemption)
-			assert.True(t, req.BasicExemptionAmount.Equal(decimal.Zero))
-			assert.True(t, req.FundedPensionRate.Equal(decimal.RequireFromString("0.03")))
-			payload := cliEmployeePayload(req.FirstName, req.LastName, true)
-			payload["employee_number"] = req.EmployeeNumber
-			_ = json.NewEncoder(w).Encode(payload)
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2":
-			_ = json.NewEncoder(w).Encode(cliEmployeePayload("Jaan", "Tamm", true))
-		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2":
-			var req payroll.UpdateEmployeeRequest
-			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "EMP-003", req.EmployeeNumber)
-			assert.Equal(t, "Jaan", req.FirstName)
-			assert.Equal(t, "Kask", req.LastName)
-			assert.Equal(t, "Developer", req.Position)
-			assert.Equal(t, "Engineering", req.Department)
-			assert.Equal(t, payroll.EmploymentType("CONTRACT"), req.EmploymentType)
-			require.NotNil(t, req.EndDate)
-			assert.Equal(t, "2026-12-31", req.EndDate.Format("2006-01-02"))
-			require.NotNil(t, req.ApplyBasicExemption)
-			assert.True(t, *req.ApplyBasicExemption)
-			require.NotNil(t, req.BasicExemptionAmount)
-			assert.True(t, req.BasicExemptionAmount.Equal(decimal.RequireFromString("500.00")))
-			require.NotNil(t, req.FundedPensionRate)
-			assert.True(t, req.FundedPensionRate.Equal(decimal.RequireFromString("0.04")))
-			require.NotNil(t, req.IsActive)
-			assert.False(t, *req.IsActive)
-			payload := cliEmployeePayload(req.FirstName, req.LastName, false)
-			payload["employee_number"] = req.EmployeeNumber
-			_ = json.NewEncoder(w).Encode(payload)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2/salary":
-			var req map[string]any
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/users/user-2/role":
+			var req map[string]string
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "2800", req["amount"])
-			assert.Contains(t, req["effective_from"], "2026-02-01")
-			_ = json.NewEncoder(w).Encode(map[string]string{"status": "salary updated"})
-		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2/salary-components":
-			require.Equal(t, "", r.URL.Query().Get("active_on"))
-			_ = json.NewEncoder(w).Encode([]map[string]any{cliSalaryComponentPayload("Branch bonus", false, false)})
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/emp-2/salary-components":
-			var req payroll.CreateSalaryComponentRequest
+			assert.Equal(t, map[string]string{"role": "employee", "employee_id": "emp-1"}, req)
+			_ = json.NewEncoder(w).Encode(map[string]string{"status": "updated"})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/invitations":
+			var req tenant.CreateInvitationRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, payroll.SalaryComponentBonus, req.ComponentType)
-			assert.Equal(t, "Branch bonus", req.Name)
-			assert.True(t, req.Amount.Equal(decimal.NewFromInt(500)))
-			require.NotNil(t, req.IsTaxable)
-			assert.False(t, *req.IsTaxable)
-			require.NotNil(t, req.IsRecurring)
-			assert.False(t, *req.IsRecurring)
-			require.NotNil(t, req.EffectiveTo)
-			assert.Equal(t, "2026-12-31", req.EffectiveTo.Format("2006-01-02"))
-			payload := cliSalaryComponentPayload(req.Name, false, false)
-			payload["effective_to"] = "2026-12-31T00:00:00Z"
-			_ = json.NewEncoder(w).Encode(payload)
-		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/employees/import":
-			var req payroll.ImportEmployeesRequest
+			assert.Equal(t, tenant.RoleEmployee, req.Role)
+			assert.Equal(t, "emp-1", req.EmployeeID)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "inv-1", "email": req.Email, "role": req.Role, "employee_id": req.EmployeeID})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/self/employee":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "emp-1", "employee_number": "EMP-001", "first_name": "Mari", "last_name": "Maasikas"})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/self/payslips":
+			assert.Equal(t, "2026", r.URL.Query().Get("year"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{{
+				"id":           "payslip-1",
+				"employee_id":  "emp-1",
+				"gross_salary": "2000",
+				"net_salary":   "1550.40",
+				"period_year":  2026,
+				"period_month": 1,
+				"run_status":   payroll.PayrollPaid,
+				"payment_date": "2026-02-10T00:00:00Z",
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/self/payslips/payslip-1/pdf":
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-payslip"))
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/self/leave-balances":
+			assert.Equal(t, "2026", r.URL.Query().Get("year"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "balance-1", "employee_id": "emp-1", "absence_type_id": "annual", "year": 2026, "entitled_days": "28", "remaining_days": "23"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/self/leave-records":
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "leave-1", "employee_id": "emp-1", "absence_type_id": "annual", "start_date": "2026-07-06T00:00:00Z", "end_date": "2026-07-10T00:00:00Z", "working_days": "5", "status": payroll.LeavePending}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/self/leave-records":
+			var req payroll.CreateLeaveRecordRequest
 			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
-			assert.Equal(t, "employees-branch.csv", req.FileName)
-			assert.Contains(t, req.CSVContent, "EMP-002")
-			_ = json.NewEncoder(w).Encode(map[string]any{
-				"file_name":         req.FileName,
-				"rows_processed":    2,
-				"employees_created": 1,
-				"salaries_created":  0,
-				"rows_skipped":      1,
-				"errors": []map[string]any{
-					{"row": 2, "employee_number": "EMP-404", "message": "missing first name"},
-				},
-			})
+			assert.Empty(t, req.EmployeeID)
+			assert.Equal(t, "annual", req.AbsenceTypeID)
+			assert.Equal(t, "2026-07-06", req.StartDate.Format("2006-01-02"))
+			assert.True(t, req.WorkingDays.Equal(decimal.NewFromInt(5)))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "leave-1", "employee_id": "emp-1", "status": payroll.LeavePending})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/self/expenses":
+			assert.Equal(t, "SUBMITTED", r.URL.Query().Get("status"))
+			_ = json.NewEncoder(w).Encode([]map[string]any{{"id": "expense-1", "expense_number": "EXP-00001", "merchant": "Taxi", "amount": "18.40", "status": expenses.StatusSubmitted}})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/self/expenses":
+			var req expenses.CreateExpenseRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Nil(t, req.EmployeeID)
+			assert.Equal(t, "Taxi", req.Merchant)
+			assert.Empty(t, req.ExpenseAccountID)
+			assert.True(t, req.Amount.Equal(decimal.RequireFromString("18.40")))
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "expense-1", "expense_number": "EXP-00001", "status": expenses.StatusSubmitted})
 		default:
-			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
 		}
 	}))
 	defer server.Close()
 
 	t.Setenv("OA_BASE_URL", server.URL)
 
+	app, stdout, _ := newTestCLIApp()
+
+	require.NoError(t, app.run(context.Background(), []string{"users", "update-role", "--id", "user-2", "--role", "employee", "--employee-id", "emp-1"}))
+	assert.Contains(t, stdout.String(), "Updated user user-2 role to employee")
+
 	stdout.Reset()
-	err := app.run(context.Background(), []string{"employees", "list"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), "Jaan Tamm")
+	require.NoError(t, app.run(context.Background(), []string{"invitations", "create", "--email", "worker@example.com", "--role", "employee", "--employee-id", "emp-1"}))
+	assert.Contains(t, stdout.String(), "Invited worker@example.com as employee (inv-1)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"employees", "create",
-		"--employee-number", " EMP-002 ",
-		"--first-name", " Jaan ",
-		"--last-name", " Tamm ",
-		"--start-date", "2026-02-01",
-		"--employment-type", "part_time",
-		"--apply-basic-exemption=false",
-		"--funded-pension-rate", "0.03",
-		"--json",
-	})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"employee_number": "EMP-002"`)
-	assert.Contains(t, stdout.String(), `"first_name": "Jaan"`)
+	require.NoError(t, app.run(context.Background(), []string{"self", "employee"}))
+	assert.Contains(t, stdout.String(), "Mari")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "get", "--id", " emp-2 ", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"first_name": "Jaan"`)
+	require.NoError(t, app.run(context.Background(), []string{"self", "payslips", "--year", "2026"}))
+	assert.Contains(t, stdout.String(), "payslip-1")
+	assert.Contains(t, stdout.String(), "2026-01")
+	assert.Contains(t, stdout.String(), "2026-02-10")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"employees", "update",
-		"--id", " emp-2 ",
-		"--employee-number", " EMP-003 ",
-		"--first-name", " Jaan ",
-		"--last-name", " Kask ",
-		"--position", " Developer ",
-		"--department", " Engineering ",
-		"--employment-type", "contract",
-		"--end-date", "2026-12-31",
-		"--apply-basic-exemption", "true",
-		"--basic-exemption-amount", "500.00",
-		"--funded-pension-rate", "0.04",
-		"--active", "false",
-		"--json",
-	})
+	require.NoError(t, app.run(context.Background(), []string{"self", "payslip-pdf", "--id", "payslip-1", "--output", outputPath}))
+	assert.Contains(t, stdout.String(), "Wrote payslip PDF to "+outputPath)
+	content, err := os.ReadFile(outputPath)
 	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"last_name": "Kask"`)
-	assert.Contains(t, stdout.String(), `"is_active": false`)
+	assert.Equal(t, "%PDF-payslip", string(content))
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "set-salary", "--id", " emp-2 ", "--amount", "2800", "--effective-from", "2026-02-01", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"status": "salary updated"`)
+	require.NoError(t, app.run(context.Background(), []string{"self", "leave-balances", "--year", "2026"}))
+	assert.Contains(t, stdout.String(), "annual")
+	assert.Contains(t, stdout.String(), "23")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "salary-components", "--id", " emp-2 ", "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"name": "Branch bonus"`)
-	assert.Contains(t, stdout.String(), `"is_taxable": false`)
+	require.NoError(t, app.run(context.Background(), []string{"self", "leave-records"}))
+	assert.Contains(t, stdout.String(), "leave-1")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{
-		"employees", "add-salary-component",
-		"--id", " emp-2 ",
-		"--type", "bonus",
-		"--name", " Branch bonus ",
-		"--amount", "500",
-		"--effective-from", "2026-02-01",
-		"--effective-to", "2026-12-31",
-		"--taxable=false",
-		"--recurring=false",
-		"--json",
-	})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"name": "Branch bonus"`)
-	assert.Contains(t, stdout.String(), `"effective_to": "2026-12-31T00:00:00Z"`)
+	require.NoError(t, app.run(context.Background(), []string{"self", "request-leave", "--absence-type-id", "annual", "--start-date", "2026-07-06", "--end-date", "2026-07-10", "--total-days", "5", "--working-days", "5"}))
+	assert.Contains(t, stdout.String(), "Requested leave leave-1 (PENDING)")
 
 	stdout.Reset()
-	err = app.run(context.Background(), []string{"employees", "import", "--file", importFile, "--json"})
-	require.NoError(t, err)
-	assert.Contains(t, stdout.String(), `"rows_skipped": 1`)
-	assert.Contains(t, stdout.String(), `"message": "missing first name"`)
+	require.NoError(t, app.run(context.Background(), []string{"self", "expenses", "--status", "submitted"}))
+	assert.Contains(t, stdout.String(), "EXP-00001")
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"self", "submit-expense", "--merchant", "Taxi", "--expense-date", "2026-05-30", "--amount", "18.40"}))
+	assert.Contains(t, stdout.String(), "Submitted expense EXP-00001 (expense-1)")
+}
+
+func TestCLISelfServiceBranches(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, _, _ := newTestCLIApp()
+	for _, tc := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: []string{"self"}, want: "self subcommand required"},
+		{name: "unknown subcommand", args: []string{"self", "salary"}, want: `unknown self subcommand "salary"`},
+		{name: "payslips invalid year", args: []string{"self", "payslips", "--year", "last"}, want: "invalid syntax"},
+		{name: "payslip pdf missing id", args: []string{"self", "payslip-pdf"}, want: "id is required"},
+		{name: "leave balances invalid year", args: []string{"self", "leave-balances", "--year", "next"}, want: "invalid syntax"},
+		{name: "leave records invalid year", args: []string{"self", "leave-records", "--year", "next"}, want: "invalid syntax"},
+		{name: "request leave missing type", args: []string{"self", "request-leave", "--start-date", "2026-07-06"}, want: "absence-type-id is required"},
+		{name: "request leave missing start", args: []string{"self", "request-leave", "--absence-type-id", "annual"}, want: "start-date is required"},
+		{name: "request leave missing working days", args: []string{"self", "request-leave", "--absence-type-id", "annual", "--start-date", "2026-07-06", "--end-date", "2026-07-10", "--total-days", "5"}, want: "working-days is required"},
+		{name: "submit expense missing merchant", args: []string{"self", "submit-expense"}, want: "merchant is required"},
+		{name: "submit expense missing date", args: []string{"self", "submit-expense", "--merchant", "Taxi"}, want: "expense-date is required"},
+		{name: "submit expense invalid amount", args: []string{"self", "submit-expense", "--merchant", "Taxi", "--expense-date", "2026-05-30", "--amount", "-1"}, want: "amount must be positive"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := app.run(context.Background(), tc.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
 }
 
 func TestCLIEmployeesFlagAndAPIErrors(t *testing.T) {
@@ -27145,3 +29847,191 @@ func TestParseDaysToExpiryAndOptionalIntEdgeCases(t *testing.T) {
 	require.NotNil(t, expiresAt)
 	assert.WithinDuration(t, time.Now().Add(24*time.Hour), *expiresAt, 2*time.Second)
 }
+
+func TestCLIPayrollPostingCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, stdout, _ := newTestCLIApp()
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "missing subcommand", args: []string{"payroll", "posting-accounts"}, want: "payroll posting-accounts subcommand required"},
+		{name: "unknown subcommand", args: []string{"payroll", "posting-accounts", "archive"}, want: `unknown payroll posting-accounts subcommand "archive"`},
+		{name: "set bad flag", args: []string{"payroll", "posting-accounts", "set", "--bad"}, want: "flag provided but not defined"},
+		{name: "reopen missing id", args: []string{"payroll", "runs", "reopen", "--reason", "Missed overtime"}, want: "id is required"},
+		{name: "reopen missing reason", args: []string{"payroll", "runs", "reopen", "--id", "run-1"}, want: "reason is required"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/payroll/posting-accounts":
+			costCenter := "cc-sales"
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]payroll.PayrollPostingAccounts{
+				{Department: "", SalaryExpenseAccountID: "acc-5000", NetPayPayableAccountID: "acc-2500"},
+				{Department: "Sales", CostCenterID: &costCenter, SalaryExpenseAccountID: "acc-5010"},
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/api/v1/tenants/tenant-1/payroll/posting-accounts":
+			var req payroll.SetPayrollPostingAccountsRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Sales", req.Department)
+			require.NotNil(t, req.CostCenterID)
+			assert.Equal(t, "cc-sales", *req.CostCenterID)
+			assert.Equal(t, "acc-5010", req.SalaryExpenseAccountID)
+			assert.Empty(t, req.NetPayPayableAccountID)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(payroll.PayrollPostingAccounts{Department: req.Department, CostCenterID: req.CostCenterID, SalaryExpenseAccountID: req.SalaryExpenseAccountID})
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/v1/tenants/tenant-1/payroll/posting-accounts":
+			assert.Equal(t, "Sales", r.URL.Query().Get("department"))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/payroll-runs/run-1/reopen":
+			var req map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "Missed overtime", req["reason"])
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(payroll.PayrollRun{ID: "run-1", PeriodYear: 2026, PeriodMonth: 3, Status: payroll.PayrollDraft})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	require.NoError(t, app.run(context.Background(), []string{"payroll", "posting-accounts", "list"}))
+	assert.Contains(t, stdout.String(), "(default)")
+	assert.Contains(t, stdout.String(), "cc-sales")
+	assert.Contains(t, stdout.String(), "acc-2500")
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"payroll", "posting-accounts", "list", "--json"}))
+	assert.Contains(t, stdout.String(), `"salary_expense_account_id": "acc-5000"`)
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{
+		"payroll", "posting-accounts", "set",
+		"--department", " Sales ",
+		"--cost-center-id", "cc-sales",
+		"--salary-expense-account-id", "acc-5010",
+	}))
+	assert.Contains(t, stdout.String(), "Sales")
+	assert.Contains(t, stdout.String(), "acc-5010")
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"payroll", "posting-accounts", "delete", "--department", "Sales"}))
+	assert.Contains(t, stdout.String(), "Deleted payroll posting accounts for Sales")
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"payroll", "runs", "reopen", "--id", "run-1", "--reason", "Missed overtime"}))
+	assert.Contains(t, stdout.String(), "Reopened payroll run run-1")
+	assert.Contains(t, stdout.String(), "Payroll run 2026-03 (DRAFT)")
+}
+
+func TestCLIPayrollPaymentCommands(t *testing.T) {
+	configureCLIEnv(t)
+	require.NoError(t, saveConfig(&cliConfig{
+		BaseURL:    "https://placeholder.example.com",
+		TenantID:   "tenant-1",
+		TenantName: "Alpha",
+		TenantSlug: "alpha",
+		APIToken:   "oa_saved_token",
+	}))
+
+	app, stdout, _ := newTestCLIApp()
+
+	for _, tt := range []struct {
+		name string
+		args []string
+		want string
+	}{
+		{name: "pay missing id", args: []string{"payroll", "runs", "pay", "--bank-account-id", "bank-1"}, want: "id is required"},
+		{name: "pay missing bank account", args: []string{"payroll", "runs", "pay", "--id", "run-1"}, want: "bank-account-id is required"},
+		{name: "payments missing id", args: []string{"payroll", "runs", "payments"}, want: "id is required"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := app.run(context.Background(), tt.args)
+			require.Error(t, err)
+			assert.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	paymentID := "payment-1"
+	paid := payroll.PayrollPayment{
+		ID:            "payroll-payment-1",
+		PayrollRunID:  "run-1",
+		PaymentID:     &paymentID,
+		ExecutionDate: time.Date(2026, time.April, 10, 0, 0, 0, 0, time.UTC),
+		MessageID:     "PAYROLL-202603-1",
+		NetAmount:     decimal.RequireFromString("1642"),
+		TaxAmount:     decimal.RequireFromString("1034"),
+		TaxReference:  "10123456781",
+		PayslipCount:  1,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer oa_saved_token", r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/v1/tenants/tenant-1/payroll-runs/run-1/pay":
+			var req payroll.PayPayrollRunRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "bank-1", req.BankAccountID)
+			assert.True(t, req.IncludeTaxPayment)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(payroll.PayPayrollRunResult{
+				PayrollPayment: &paid,
+				SEPA:           &payments.SEPAExportResult{MessageID: paid.MessageID, XML: "<Document>payroll</Document>"},
+			})
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/tenants/tenant-1/payroll-runs/run-1/payments":
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode([]payroll.PayrollPayment{paid})
+		default:
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.String())
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("OA_BASE_URL", server.URL)
+
+	outputPath := filepath.Join(t.TempDir(), "payroll.xml")
+	require.NoError(t, app.run(context.Background(), []string{
+		"payroll", "runs", "pay",
+		"--id", " run-1 ",
+		"--bank-account-id", "bank-1",
+		"--include-tax",
+		"--output", outputPath,
+	}))
+	assert.Contains(t, stdout.String(), "Wrote payroll SEPA XML to "+outputPath)
+	assert.Contains(t, stdout.String(), "PAYROLL-202603-1")
+	assert.Contains(t, stdout.String(), "1034.00")
+	content, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	assert.Equal(t, "<Document>payroll</Document>", string(content))
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"payroll", "runs", "payments", "--id", "run-1"}))
+	assert.Contains(t, stdout.String(), "10123456781")
+	assert.Contains(t, stdout.String(), "payment-1")
+
+	stdout.Reset()
+	require.NoError(t, app.run(context.Background(), []string{"payroll", "runs", "payments", "--id", "run-1", "--json"}))
+	assert.Contains(t, stdout.String(), `"message_id": "PAYROLL-202603-1"`)
+}
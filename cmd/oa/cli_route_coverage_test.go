@@ -821,6 +821,14 @@ func cliCommandForRoute(route apiRoute) (string, bool) {
 		return commandForMethod(route.Method, map[string]string{"GET": "invitations get"})
 	case "/invitations/accept":
 		return commandForMethod(route.Method, map[string]string{"POST": "invitations accept"})
+	case "/public/quotes/{token}":
+		return commandForMethod(route.Method, map[string]string{"GET": "quotes link-view"})
+	case "/public/quotes/{token}/pdf":
+		return commandForMethod(route.Method, map[string]string{"GET": "quotes link-pdf"})
+	case "/public/quotes/{token}/accept":
+		return commandForMethod(route.Method, map[string]string{"POST": "quotes link-accept"})
+	case "/public/quotes/{token}/reject":
+		return commandForMethod(route.Method, map[string]string{"POST": "quotes link-reject"})
 	case "/me":
 		return commandForMethod(route.Method, map[string]string{"GET": "auth status"})
 	case "/me/tenants":
@@ -1038,6 +1046,11 @@ func tenantCLICommand(method, path string) (string, bool) {
 			"PUT":    "contacts update",
 			"DELETE": "contacts delete",
 		})
+	case "/contacts/{contactID}/pricing":
+		return commandForMethod(method, map[string]string{
+			"GET": "contacts pricing",
+			"PUT": "contacts set-pricing",
+		})
 	case "/invoices":
 		return commandForMethod(method, map[string]string{
 			"GET":  "invoices list",
@@ -1047,6 +1060,8 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"POST": "invoices import"})
 	case "/invoices/import-einvoice":
 		return commandForMethod(method, map[string]string{"POST": "invoices import-einvoice"})
+	case "/invoices/export-einvoice":
+		return commandForMethod(method, map[string]string{"POST": "invoices export-einvoice"})
 	case "/invoices/overdue":
 		return commandForMethod(method, map[string]string{"GET": "reminders overdue"})
 	case "/invoices/reminders":
@@ -1059,10 +1074,14 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"GET": "invoices get"})
 	case "/invoices/{invoiceID}/pdf":
 		return commandForMethod(method, map[string]string{"GET": "invoices pdf"})
+	case "/invoices/{invoiceID}/reminder-pdf":
+		return commandForMethod(method, map[string]string{"GET": "invoices reminder-pdf"})
 	case "/invoices/{invoiceID}/send":
 		return commandForMethod(method, map[string]string{"POST": "invoices send"})
 	case "/invoices/{invoiceID}/void":
 		return commandForMethod(method, map[string]string{"POST": "invoices void"})
+	case "/invoices/{invoiceID}/credit-notes":
+		return commandForMethod(method, map[string]string{"POST": "invoices credit-note"})
 	case "/invoices/{invoiceID}/reminders":
 		return commandForMethod(method, map[string]string{"GET": "reminders history"})
 	case "/invoices/{invoiceID}/interest":
@@ -1096,6 +1115,14 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"POST": "quotes reject"})
 	case "/quotes/{quoteID}/convert-to-invoice":
 		return commandForMethod(method, map[string]string{"POST": "quotes convert-to-invoice"})
+	case "/quotes/{quoteID}/invoices":
+		return commandForMethod(method, map[string]string{"GET": "quotes invoices"})
+	case "/quotes/{quoteID}/revisions":
+		return commandForMethod(method, map[string]string{"GET": "quotes revisions"})
+	case "/quotes/{quoteID}/revisions/{revision}":
+		return commandForMethod(method, map[string]string{"GET": "quotes revision"})
+	case "/quotes/{quoteID}/public-link":
+		return commandForMethod(method, map[string]string{"POST": "quotes link"})
 	case "/orders":
 		return commandForMethod(method, map[string]string{
 			"GET":  "orders list",
@@ -1129,12 +1156,61 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"POST": "orders process"})
 	case "/orders/{orderID}/ship":
 		return commandForMethod(method, map[string]string{"POST": "orders ship"})
+	case "/orders/{orderID}/invoices":
+		return commandForMethod(method, map[string]string{"GET": "orders invoices"})
+	case "/orders/{orderID}/shipments":
+		return commandForMethod(method, map[string]string{"GET": "orders shipments"})
+	case "/orders/{orderID}/shipments/{shipmentID}/delivery-note":
+		return commandForMethod(method, map[string]string{"GET": "orders delivery-note"})
 	case "/orders/{orderID}/deliver":
 		return commandForMethod(method, map[string]string{"POST": "orders deliver"})
 	case "/orders/{orderID}/cancel":
 		return commandForMethod(method, map[string]string{"POST": "orders cancel"})
 	case "/orders/{orderID}/convert-to-invoice":
 		return commandForMethod(method, map[string]string{"POST": "orders convert-to-invoice"})
+	case "/purchase-orders":
+		return commandForMethod(method, map[string]string{
+			"GET":  "purchase-orders list",
+			"POST": "purchase-orders create",
+		})
+	case "/purchase-orders/{purchaseOrderID}":
+		return commandForMethod(method, map[string]string{"GET": "purchase-orders get"})
+	case "/purchase-orders/{purchaseOrderID}/approve":
+		return commandForMethod(method, map[string]string{"POST": "purchase-orders approve"})
+	case "/purchase-orders/{purchaseOrderID}/cancel":
+		return commandForMethod(method, map[string]string{"POST": "purchase-orders cancel"})
+	case "/purchase-orders/{purchaseOrderID}/receipts":
+		return commandForMethod(method, map[string]string{"POST": "purchase-orders receive"})
+	case "/purchase-orders/{purchaseOrderID}/invoice-matches":
+		return commandForMethod(method, map[string]string{"POST": "purchase-orders match-invoice"})
+	case "/purchase-orders/{purchaseOrderID}/matching":
+		return commandForMethod(method, map[string]string{"GET": "purchase-orders matching"})
+	case "/landed-costs":
+		return commandForMethod(method, map[string]string{
+			"GET":  "landed-costs list",
+			"POST": "landed-costs allocate",
+		})
+	case "/landed-costs/{landedCostID}":
+		return commandForMethod(method, map[string]string{"GET": "landed-costs get"})
+	case "/price-lists":
+		return commandForMethod(method, map[string]string{
+			"GET":  "price-lists list",
+			"POST": "price-lists create",
+		})
+	case "/price-lists/{priceListID}":
+		return commandForMethod(method, map[string]string{
+			"GET": "price-lists get",
+			"PUT": "price-lists update",
+		})
+	case "/prices/resolve":
+		return commandForMethod(method, map[string]string{"GET": "price-lists resolve"})
+	case "/customer-groups":
+		return commandForMethod(method, map[string]string{
+			"GET":  "customer-groups list",
+			"POST": "customer-groups create",
+		})
+	case "/customer-groups/{customerGroupID}":
+		return commandForMethod(method, map[string]string{"PUT": "customer-groups update"})
 	case "/asset-categories":
 		return commandForMethod(method, map[string]string{
 			"GET":  "assets categories list",
@@ -1162,6 +1238,26 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"POST": "assets activate"})
 	case "/assets/{assetID}/dispose":
 		return commandForMethod(method, map[string]string{"POST": "assets dispose"})
+	case "/assets/{assetID}/depreciation-schedule":
+		return commandForMethod(method, map[string]string{"GET": "assets schedule"})
+	case "/assets/{assetID}/events":
+		return commandForMethod(method, map[string]string{
+			"GET":  "assets events list",
+			"POST": "assets events create",
+		})
+	case "/assets/{assetID}/history":
+		return commandForMethod(method, map[string]string{"GET": "assets history"})
+	case "/depreciation-runs":
+		return commandForMethod(method, map[string]string{
+			"GET":  "assets depreciation-runs list",
+			"POST": "assets depreciation-runs create",
+		})
+	case "/depreciation-runs/preview":
+		return commandForMethod(method, map[string]string{"GET": "assets depreciation-runs preview"})
+	case "/depreciation-runs/{runID}":
+		return commandForMethod(method, map[string]string{"GET": "assets depreciation-runs get"})
+	case "/depreciation-runs/{runID}/reverse":
+		return commandForMethod(method, map[string]string{"POST": "assets depreciation-runs reverse"})
 	case "/assets/{assetID}/depreciation":
 		return commandForMethod(method, map[string]string{
 			"GET":  "assets depreciation",
@@ -1202,6 +1298,59 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"GET": "inventory subledger-reconciliation"})
 	case "/inventory/lots":
 		return commandForMethod(method, map[string]string{"GET": "inventory lots"})
+	case "/inventory/aging":
+		return commandForMethod(method, map[string]string{"GET": "inventory aging"})
+	case "/inventory/aging/write-down":
+		return commandForMethod(method, map[string]string{"POST": "inventory write-down"})
+	case "/inventory/replenishment":
+		return commandForMethod(method, map[string]string{"GET": "inventory replenishment"})
+	case "/inventory/replenishment/purchase-orders":
+		return commandForMethod(method, map[string]string{"POST": "inventory replenishment-orders"})
+	case "/inventory/replenishment/low-stock-events":
+		return commandForMethod(method, map[string]string{"POST": "inventory low-stock-event"})
+	case "/inventory/stock-counts":
+		return commandForMethod(method, map[string]string{
+			"GET":  "inventory stock-counts list",
+			"POST": "inventory stock-counts create",
+		})
+	case "/inventory/stock-counts/{stockCountID}":
+		return commandForMethod(method, map[string]string{"GET": "inventory stock-counts get"})
+	case "/inventory/stock-counts/{stockCountID}/counts":
+		return commandForMethod(method, map[string]string{"POST": "inventory stock-counts count"})
+	case "/inventory/stock-counts/{stockCountID}/counts/import":
+		return commandForMethod(method, map[string]string{"POST": "inventory stock-counts import"})
+	case "/inventory/stock-counts/{stockCountID}/submit":
+		return commandForMethod(method, map[string]string{"POST": "inventory stock-counts submit"})
+	case "/inventory/stock-counts/{stockCountID}/approve":
+		return commandForMethod(method, map[string]string{"POST": "inventory stock-counts approve"})
+	case "/inventory/stock-counts/{stockCountID}/cancel":
+		return commandForMethod(method, map[string]string{"POST": "inventory stock-counts cancel"})
+	case "/inventory/stock-counts/{stockCountID}/variance":
+		return commandForMethod(method, map[string]string{"GET": "inventory stock-counts variance"})
+	case "/inventory/boms":
+		return commandForMethod(method, map[string]string{
+			"GET":  "inventory boms list",
+			"POST": "inventory boms create",
+		})
+	case "/inventory/boms/{bomID}":
+		return commandForMethod(method, map[string]string{
+			"GET":    "inventory boms get",
+			"PUT":    "inventory boms update",
+			"DELETE": "inventory boms delete",
+		})
+	case "/inventory/boms/{bomID}/explosion":
+		return commandForMethod(method, map[string]string{"GET": "inventory boms explode"})
+	case "/inventory/assembly-orders":
+		return commandForMethod(method, map[string]string{
+			"GET":  "inventory assembly-orders list",
+			"POST": "inventory assembly-orders create",
+		})
+	case "/inventory/assembly-orders/{assemblyOrderID}":
+		return commandForMethod(method, map[string]string{"GET": "inventory assembly-orders get"})
+	case "/inventory/assembly-orders/{assemblyOrderID}/complete":
+		return commandForMethod(method, map[string]string{"POST": "inventory assembly-orders complete"})
+	case "/inventory/assembly-orders/{assemblyOrderID}/cancel":
+		return commandForMethod(method, map[string]string{"POST": "inventory assembly-orders cancel"})
 	case "/warehouses":
 		return commandForMethod(method, map[string]string{
 			"GET":  "inventory warehouses list",
@@ -1275,6 +1424,8 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"GET": "reports sales-margin"})
 	case "/reports/customer-profitability":
 		return commandForMethod(method, map[string]string{"GET": "reports customer-profitability"})
+	case "/reports/fixed-asset-register":
+		return commandForMethod(method, map[string]string{"GET": "reports fixed-asset-register"})
 	case "/reports/budget-vs-actual":
 		return commandForMethod(method, map[string]string{"GET": "reports budget-vs-actual"})
 	case "/reports/aging/receivables":
@@ -1334,6 +1485,19 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"POST": "recurring-invoices resume"})
 	case "/recurring-invoices/{recurringID}/generate":
 		return commandForMethod(method, map[string]string{"POST": "recurring-invoices generate"})
+	case "/recurring-invoices/usage/import":
+		return commandForMethod(method, map[string]string{"POST": "recurring-invoices usage import"})
+	case "/recurring-invoices/{recurringID}/usage":
+		return commandForMethod(method, map[string]string{
+			"GET":  "recurring-invoices usage list",
+			"POST": "recurring-invoices usage add",
+		})
+	case "/recurring-invoices/{recurringID}/usage/{usageID}":
+		return commandForMethod(method, map[string]string{"DELETE": "recurring-invoices usage delete"})
+	case "/recurring-invoices/{recurringID}/price-changes":
+		return commandForMethod(method, map[string]string{"POST": "recurring-invoices price-changes add"})
+	case "/recurring-invoices/{recurringID}/price-changes/{changeID}":
+		return commandForMethod(method, map[string]string{"DELETE": "recurring-invoices price-changes delete"})
 	case "/settings/smtp":
 		return commandForMethod(method, map[string]string{
 			"GET": "email smtp get",
@@ -1345,6 +1509,15 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"GET": "email templates list"})
 	case "/email-templates/{templateType}":
 		return commandForMethod(method, map[string]string{"PUT": "email templates update"})
+	case "/document-templates":
+		return commandForMethod(method, map[string]string{"GET": "document-templates list"})
+	case "/document-templates/{documentType}":
+		return commandForMethod(method, map[string]string{
+			"PUT":    "document-templates update",
+			"DELETE": "document-templates reset",
+		})
+	case "/document-templates/{documentType}/preview":
+		return commandForMethod(method, map[string]string{"POST": "document-templates preview"})
 	case "/email-log":
 		return commandForMethod(method, map[string]string{"GET": "email log"})
 	case "/reminder-rules":
@@ -1448,6 +1621,44 @@ func tenantCLICommand(method, path string) (string, bool) {
 		})
 	case "/employees/{employeeID}/salary":
 		return commandForMethod(method, map[string]string{"POST": "employees set-salary"})
+	case "/employees/{employeeID}/average-earnings":
+		return commandForMethod(method, map[string]string{"GET": "employees average-earnings"})
+	case "/employees/{employeeID}/employment-events":
+		return commandForMethod(method, map[string]string{
+			"GET":  "employees employment-events",
+			"POST": "employees add-employment-event",
+		})
+	case "/employment-register/pending":
+		return commandForMethod(method, map[string]string{"GET": "employment-register pending"})
+	case "/employment-register/export":
+		return commandForMethod(method, map[string]string{"POST": "employment-register export"})
+	case "/self/employee":
+		return commandForMethod(method, map[string]string{"GET": "self employee"})
+	case "/self/payslips":
+		return commandForMethod(method, map[string]string{"GET": "self payslips"})
+	case "/self/payslips/{payslipID}/pdf":
+		return commandForMethod(method, map[string]string{"GET": "self payslip-pdf"})
+	case "/self/leave-balances":
+		return commandForMethod(method, map[string]string{"GET": "self leave-balances"})
+	case "/self/leave-records":
+		return commandForMethod(method, map[string]string{
+			"GET":  "self leave-records",
+			"POST": "self request-leave",
+		})
+	case "/self/expenses":
+		return commandForMethod(method, map[string]string{
+			"GET":  "self expenses",
+			"POST": "self submit-expense",
+		})
+	case "/timesheets":
+		return commandForMethod(method, map[string]string{
+			"GET":  "timesheets list",
+			"POST": "timesheets create",
+		})
+	case "/timesheets/import":
+		return commandForMethod(method, map[string]string{"POST": "timesheets import"})
+	case "/timesheets/approve":
+		return commandForMethod(method, map[string]string{"POST": "timesheets approve"})
 	case "/employees/{employeeID}/salary-components":
 		return commandForMethod(method, map[string]string{
 			"GET":  "employees salary-components",
@@ -1470,12 +1681,24 @@ func tenantCLICommand(method, path string) (string, bool) {
 		return commandForMethod(method, map[string]string{"POST": "payroll runs process"})
 	case "/payroll-runs/{runID}/approve":
 		return commandForMethod(method, map[string]string{"POST": "payroll runs approve"})
+	case "/payroll-runs/{runID}/reopen":
+		return commandForMethod(method, map[string]string{"POST": "payroll runs reopen"})
+	case "/payroll-runs/{runID}/pay":
+		return commandForMethod(method, map[string]string{"POST": "payroll runs pay"})
+	case "/payroll-runs/{runID}/payments":
+		return commandForMethod(method, map[string]string{"GET": "payroll runs payments"})
 	case "/payroll-runs/{runID}/payslips":
 		return commandForMethod(method, map[string]string{"GET": "payroll runs payslips"})
 	case "/payroll-runs/{runID}/payslips/{payslipID}/pdf":
 		return commandForMethod(method, map[string]string{"GET": "payroll runs payslip-pdf"})
 	case "/payroll-runs/{runID}/tsd":
 		return commandForMethod(method, map[string]string{"POST": "tsd generate"})
+	case "/payroll/posting-accounts":
+		return commandForMethod(method, map[string]string{
+			"GET":    "payroll posting-accounts list",
+			"PUT":    "payroll posting-accounts set",
+			"DELETE": "payroll posting-accounts delete",
+		})
 	case "/payroll/tax-preview":
 		return commandForMethod(method, map[string]string{"POST": "payroll tax-preview"})
 	case "/absence-types":
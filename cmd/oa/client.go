@@ -21,6 +21,7 @@ import (
 	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/analytics"
 	"github.com/HMB-research/open-accounting/internal/apitoken"
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/auth"
 	"github.com/HMB-research/open-accounting/internal/banking"
@@ -35,9 +36,12 @@ import (
 	"github.com/HMB-research/open-accounting/internal/payments"
 	"github.com/HMB-research/open-accounting/internal/payroll"
 	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
 	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 	"github.com/HMB-research/open-accounting/internal/reports"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
 	"github.com/HMB-research/open-accounting/internal/tax"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/HMB-research/open-accounting/internal/webhooks"
@@ -90,6 +94,13 @@ type periodCloseMutationResponse struct {
 	Event  *tenant.PeriodCloseEvent `json:"event"`
 }
 
+type inventoryLowStockEventResponse struct {
+	AsOfDate    time.Time                      `json:"as_of_date"`
+	WarehouseID string                         `json:"warehouse_id,omitempty"`
+	Lines       []purchasing.ReplenishmentLine `json:"lines"`
+	Event       *webhooks.DeliveryResult       `json:"event,omitempty"`
+}
+
 type documentReviewSummaryRequest struct {
 	EntityType string   `json:"entity_type"`
 	EntityIDs  []string `json:"entity_ids"`
@@ -362,8 +373,12 @@ func (c *apiClient) removeTenantUser(ctx context.Context, tenantID, userID strin
 	return c.request(ctx, http.MethodDelete, path.Join("/api/v1/tenants", tenantID, "users", userID), nil, c.apiToken, nil)
 }
 
-func (c *apiClient) updateTenantUserRole(ctx context.Context, tenantID, userID, role string) error {
-	return c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "users", userID, "role"), map[string]string{"role": role}, c.apiToken, nil)
+func (c *apiClient) updateTenantUserRole(ctx context.Context, tenantID, userID, role, employeeID string) error {
+	body := map[string]string{"role": role}
+	if employeeID != "" {
+		body["employee_id"] = employeeID
+	}
+	return c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "users", userID, "role"), body, c.apiToken, nil)
 }
 
 func (c *apiClient) updateTenantUserStatus(ctx context.Context, tenantID, userID string, active bool) error {
@@ -1005,6 +1020,10 @@ func (c *apiClient) importEInvoice(ctx context.Context, tenantID string, req *in
 	return &resp, nil
 }
 
+func (c *apiClient) exportEInvoice(ctx context.Context, tenantID string, req *invoicing.ExportEInvoiceRequest) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "invoices", "export-einvoice"), req, c.apiToken)
+}
+
 func (c *apiClient) listInvoices(ctx context.Context, tenantID string, filter invoicing.InvoiceFilter) ([]invoicing.Invoice, error) {
 	values := url.Values{}
 	if filter.InvoiceType != "" {
@@ -1053,6 +1072,38 @@ func (c *apiClient) downloadInvoicePDF(ctx context.Context, tenantID, invoiceID
 	return c.requestRaw(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "invoices", invoiceID, "pdf"), nil, c.apiToken)
 }
 
+func (c *apiClient) listDocumentTemplates(ctx context.Context, tenantID string) ([]tenant.DocumentTemplateEntry, error) {
+	var resp []tenant.DocumentTemplateEntry
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "document-templates"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) updateDocumentTemplate(ctx context.Context, tenantID, documentType string, req *tenant.DocumentTemplate) (*tenant.DocumentTemplateEntry, error) {
+	var resp tenant.DocumentTemplateEntry
+	if err := c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "document-templates", documentType), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) resetDocumentTemplate(ctx context.Context, tenantID, documentType string) (*tenant.DocumentTemplateEntry, error) {
+	var resp tenant.DocumentTemplateEntry
+	if err := c.request(ctx, http.MethodDelete, path.Join("/api/v1/tenants", tenantID, "document-templates", documentType), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) previewDocumentTemplate(ctx context.Context, tenantID, documentType string, req *tenant.DocumentTemplatePreviewRequest) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "document-templates", documentType, "preview"), req, c.apiToken)
+}
+
+func (c *apiClient) downloadInvoiceReminderPDF(ctx context.Context, tenantID, invoiceID string) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "invoices", invoiceID, "reminder-pdf"), nil, c.apiToken)
+}
+
 func (c *apiClient) sendInvoice(ctx context.Context, tenantID, invoiceID string) (map[string]string, error) {
 	var resp map[string]string
 	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "invoices", invoiceID, "send"), nil, c.apiToken, &resp); err != nil {
@@ -1069,6 +1120,14 @@ func (c *apiClient) voidInvoice(ctx context.Context, tenantID, invoiceID string)
 	return resp, nil
 }
 
+func (c *apiClient) createCreditNote(ctx context.Context, tenantID, invoiceID string, req *invoicing.CreateCreditNoteRequest) (*invoicing.Invoice, error) {
+	var resp invoicing.Invoice
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "invoices", invoiceID, "credit-notes"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *apiClient) listPayments(ctx context.Context, tenantID string, filter payments.PaymentFilter) ([]payments.Payment, error) {
 	values := url.Values{}
 	if filter.PaymentType != "" {
@@ -1759,6 +1818,58 @@ func (c *apiClient) convertQuoteToInvoice(ctx context.Context, tenantID, quoteID
 	return &resp, nil
 }
 
+func (c *apiClient) listQuoteInvoices(ctx context.Context, tenantID, quoteID string) ([]quotes.QuoteInvoice, error) {
+	var resp []quotes.QuoteInvoice
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "quotes", quoteID, "invoices"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) listQuoteRevisions(ctx context.Context, tenantID, quoteID string) ([]quotes.QuoteRevision, error) {
+	var resp []quotes.QuoteRevision
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "quotes", quoteID, "revisions"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) getQuoteRevision(ctx context.Context, tenantID, quoteID string, revision int) (*quotes.QuoteRevision, error) {
+	var resp quotes.QuoteRevision
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "quotes", quoteID, "revisions", strconv.Itoa(revision)), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) createQuoteLink(ctx context.Context, tenantID, quoteID string, req *quotes.CreateQuoteLinkRequest) (*quotes.QuoteLink, error) {
+	var resp quotes.QuoteLink
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "quotes", quoteID, "public-link"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getPublicQuote(ctx context.Context, token string) (*quotes.PublicQuote, error) {
+	var resp quotes.PublicQuote
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/public/quotes", token), nil, "", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) downloadPublicQuotePDF(ctx context.Context, token string) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodGet, path.Join("/api/v1/public/quotes", token, "pdf"), nil, "")
+}
+
+func (c *apiClient) answerPublicQuote(ctx context.Context, token, action string) (*quotes.PublicQuote, error) {
+	var resp quotes.PublicQuote
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/public/quotes", token, action), nil, "", &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *apiClient) listOrders(ctx context.Context, tenantID string, filter orders.OrderFilter) ([]orders.Order, error) {
 	values := url.Values{}
 	if filter.Status != "" {
@@ -1884,6 +1995,34 @@ func (c *apiClient) updateOrderStatus(ctx context.Context, tenantID, orderID, ac
 	return resp, nil
 }
 
+func (c *apiClient) shipOrder(ctx context.Context, tenantID, orderID string, req *orders.ShipOrderRequest) (*orders.OrderShipment, error) {
+	var resp orders.OrderShipment
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "orders", orderID, "ship"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listOrderShipments(ctx context.Context, tenantID, orderID string) ([]orders.OrderShipment, error) {
+	var resp []orders.OrderShipment
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "orders", orderID, "shipments"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) listOrderInvoices(ctx context.Context, tenantID, orderID string) ([]orders.OrderInvoice, error) {
+	var resp []orders.OrderInvoice
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "orders", orderID, "invoices"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) downloadOrderDeliveryNote(ctx context.Context, tenantID, orderID, shipmentID string) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "orders", orderID, "shipments", shipmentID, "delivery-note"), nil, c.apiToken)
+}
+
 func (c *apiClient) convertOrderToInvoice(ctx context.Context, tenantID, orderID string, req *orders.ConvertOrderToInvoiceRequest) (*orders.OrderInvoiceConversionResult, error) {
 	var resp orders.OrderInvoiceConversionResult
 	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "orders", orderID, "convert-to-invoice"), req, c.apiToken, &resp); err != nil {
@@ -1892,6 +2031,217 @@ func (c *apiClient) convertOrderToInvoice(ctx context.Context, tenantID, orderID
 	return &resp, nil
 }
 
+func (c *apiClient) listPurchaseOrders(ctx context.Context, tenantID string, filter purchasing.PurchaseOrderFilter) ([]purchasing.PurchaseOrder, error) {
+	values := url.Values{}
+	if filter.Status != "" {
+		values.Set("status", string(filter.Status))
+	}
+	if strings.TrimSpace(filter.ContactID) != "" {
+		values.Set("contact_id", strings.TrimSpace(filter.ContactID))
+	}
+	if filter.FromDate != nil {
+		values.Set("from_date", filter.FromDate.Format("2006-01-02"))
+	}
+	if filter.ToDate != nil {
+		values.Set("to_date", filter.ToDate.Format("2006-01-02"))
+	}
+	if strings.TrimSpace(filter.Search) != "" {
+		values.Set("search", strings.TrimSpace(filter.Search))
+	}
+
+	var resp []purchasing.PurchaseOrder
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "purchase-orders"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createPurchaseOrder(ctx context.Context, tenantID string, req *purchasing.CreatePurchaseOrderRequest) (*purchasing.PurchaseOrder, error) {
+	var resp purchasing.PurchaseOrder
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "purchase-orders"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getPurchaseOrder(ctx context.Context, tenantID, poID string) (*purchasing.PurchaseOrder, error) {
+	var resp purchasing.PurchaseOrder
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "purchase-orders", poID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) updatePurchaseOrderStatus(ctx context.Context, tenantID, poID, action string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "purchase-orders", poID, action), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) receivePurchaseOrderGoods(ctx context.Context, tenantID, poID string, req *purchasing.ReceiveGoodsRequest) (*purchasing.GoodsReceipt, error) {
+	var resp purchasing.GoodsReceipt
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "purchase-orders", poID, "receipts"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) matchPurchaseOrderInvoice(ctx context.Context, tenantID, poID string, req *purchasing.MatchInvoiceRequest) (*purchasing.PurchaseInvoiceMatch, error) {
+	var resp purchasing.PurchaseInvoiceMatch
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "purchase-orders", poID, "invoice-matches"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getPurchaseOrderMatching(ctx context.Context, tenantID, poID string) (*purchasing.PurchaseOrderMatching, error) {
+	var resp purchasing.PurchaseOrderMatching
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "purchase-orders", poID, "matching"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listLandedCosts(ctx context.Context, tenantID string, filter purchasing.LandedCostFilter) ([]purchasing.LandedCost, error) {
+	values := url.Values{}
+	if strings.TrimSpace(filter.GoodsReceiptID) != "" {
+		values.Set("goods_receipt_id", strings.TrimSpace(filter.GoodsReceiptID))
+	}
+	if filter.FromDate != nil {
+		values.Set("from_date", filter.FromDate.Format("2006-01-02"))
+	}
+	if filter.ToDate != nil {
+		values.Set("to_date", filter.ToDate.Format("2006-01-02"))
+	}
+
+	var resp []purchasing.LandedCost
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "landed-costs"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) allocateLandedCost(ctx context.Context, tenantID string, req *purchasing.AllocateLandedCostRequest) (*purchasing.LandedCost, error) {
+	var resp purchasing.LandedCost
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "landed-costs"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getLandedCost(ctx context.Context, tenantID, landedCostID string) (*purchasing.LandedCost, error) {
+	var resp purchasing.LandedCost
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "landed-costs", landedCostID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listPriceLists(ctx context.Context, tenantID string, filter pricing.PriceListFilter) ([]pricing.PriceList, error) {
+	values := url.Values{}
+	if strings.TrimSpace(filter.Currency) != "" {
+		values.Set("currency", strings.TrimSpace(filter.Currency))
+	}
+	if filter.ActiveOnly {
+		values.Set("active_only", "true")
+	}
+
+	var resp []pricing.PriceList
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "price-lists"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createPriceList(ctx context.Context, tenantID string, req *pricing.CreatePriceListRequest) (*pricing.PriceList, error) {
+	var resp pricing.PriceList
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "price-lists"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getPriceList(ctx context.Context, tenantID, priceListID string) (*pricing.PriceList, error) {
+	var resp pricing.PriceList
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "price-lists", priceListID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) updatePriceList(ctx context.Context, tenantID, priceListID string, req *pricing.UpdatePriceListRequest) (*pricing.PriceList, error) {
+	var resp pricing.PriceList
+	if err := c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "price-lists", priceListID), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listCustomerGroups(ctx context.Context, tenantID string) ([]pricing.CustomerGroup, error) {
+	var resp []pricing.CustomerGroup
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "customer-groups"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createCustomerGroup(ctx context.Context, tenantID string, req *pricing.CustomerGroupRequest) (*pricing.CustomerGroup, error) {
+	var resp pricing.CustomerGroup
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "customer-groups"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) updateCustomerGroup(ctx context.Context, tenantID, groupID string, req *pricing.CustomerGroupRequest) (*pricing.CustomerGroup, error) {
+	var resp pricing.CustomerGroup
+	if err := c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "customer-groups", groupID), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getContactPricing(ctx context.Context, tenantID, contactID string) (*pricing.CustomerPricing, error) {
+	var resp pricing.CustomerPricing
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "contacts", contactID, "pricing"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) setContactPricing(ctx context.Context, tenantID, contactID string, req *pricing.SetCustomerPricingRequest) (*pricing.CustomerPricing, error) {
+	var resp pricing.CustomerPricing
+	if err := c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "contacts", contactID, "pricing"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) resolvePrice(ctx context.Context, tenantID, productID, contactID, currency string, quantity *decimal.Decimal, date *time.Time) (*pricing.ResolvedPrice, error) {
+	values := url.Values{}
+	values.Set("product_id", productID)
+	if contactID != "" {
+		values.Set("contact_id", contactID)
+	}
+	if currency != "" {
+		values.Set("currency", currency)
+	}
+	if quantity != nil {
+		values.Set("quantity", quantity.String())
+	}
+	if date != nil {
+		values.Set("date", date.Format("2006-01-02"))
+	}
+
+	var resp pricing.ResolvedPrice
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "prices", "resolve"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *apiClient) listRecurringInvoices(ctx context.Context, tenantID string, activeOnly bool) ([]recurring.RecurringInvoice, error) {
 	values := url.Values{}
 	if activeOnly {
@@ -1977,6 +2327,58 @@ func (c *apiClient) generateDueRecurringInvoices(ctx context.Context, tenantID s
 	return resp, nil
 }
 
+func (c *apiClient) listRecurringUsage(ctx context.Context, tenantID, recurringID string, unbilledOnly bool) ([]recurring.UsageRecord, error) {
+	values := url.Values{}
+	if unbilledOnly {
+		values.Set("unbilled", "true")
+	}
+	var resp []recurring.UsageRecord
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "recurring-invoices", recurringID, "usage"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) recordRecurringUsage(ctx context.Context, tenantID, recurringID string, req *recurring.RecordUsageRequest) ([]recurring.UsageRecord, error) {
+	var resp []recurring.UsageRecord
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "recurring-invoices", recurringID, "usage"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) deleteRecurringUsage(ctx context.Context, tenantID, recurringID, usageID string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.request(ctx, http.MethodDelete, path.Join("/api/v1/tenants", tenantID, "recurring-invoices", recurringID, "usage", usageID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) importRecurringUsage(ctx context.Context, tenantID string, req *recurring.ImportUsageRequest) (*recurring.ImportUsageResult, error) {
+	var resp recurring.ImportUsageResult
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "recurring-invoices", "usage", "import"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) createRecurringPriceChange(ctx context.Context, tenantID, recurringID string, req *recurring.CreatePriceChangeRequest) (*recurring.PriceChange, error) {
+	var resp recurring.PriceChange
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "recurring-invoices", recurringID, "price-changes"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) deleteRecurringPriceChange(ctx context.Context, tenantID, recurringID, changeID string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.request(ctx, http.MethodDelete, path.Join("/api/v1/tenants", tenantID, "recurring-invoices", recurringID, "price-changes", changeID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *apiClient) listAssetCategories(ctx context.Context, tenantID string) ([]assets.AssetCategory, error) {
 	var resp []assets.AssetCategory
 	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "asset-categories"), nil, c.apiToken, &resp); err != nil {
@@ -2092,12 +2494,110 @@ func (c *apiClient) listAssetDepreciation(ctx context.Context, tenantID, assetID
 	return resp, nil
 }
 
-func (c *apiClient) listProductCategories(ctx context.Context, tenantID string) ([]inventory.ProductCategory, error) {
-	var resp []inventory.ProductCategory
-	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "product-categories"), nil, c.apiToken, &resp); err != nil {
+func (c *apiClient) getAssetDepreciationSchedule(ctx context.Context, tenantID, assetID, unitsTotal, unitsPerMonth string) (*assets.DepreciationSchedule, error) {
+	var resp assets.DepreciationSchedule
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "assets", assetID, "depreciation-schedule"), depreciationScheduleQuery(unitsTotal, unitsPerMonth)), nil, c.apiToken, &resp); err != nil {
 		return nil, err
 	}
-	return resp, nil
+	return &resp, nil
+}
+
+func (c *apiClient) exportAssetDepreciationSchedule(ctx context.Context, tenantID, assetID, unitsTotal, unitsPerMonth, format string) ([]byte, error) {
+	values := depreciationScheduleQuery(unitsTotal, unitsPerMonth)
+	values.Set("format", strings.TrimSpace(format))
+	return c.requestRaw(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "assets", assetID, "depreciation-schedule"), values), nil, c.apiToken)
+}
+
+func depreciationScheduleQuery(unitsTotal, unitsPerMonth string) url.Values {
+	values := url.Values{}
+	if strings.TrimSpace(unitsTotal) != "" {
+		values.Set("units_total", strings.TrimSpace(unitsTotal))
+	}
+	if strings.TrimSpace(unitsPerMonth) != "" {
+		values.Set("units_per_month", strings.TrimSpace(unitsPerMonth))
+	}
+	return values
+}
+
+func (c *apiClient) previewDepreciationRun(ctx context.Context, tenantID string, year, month int) (*assets.DepreciationRunPreview, error) {
+	values := url.Values{}
+	values.Set("year", strconv.Itoa(year))
+	values.Set("month", strconv.Itoa(month))
+
+	var resp assets.DepreciationRunPreview
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "depreciation-runs", "preview"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listDepreciationRuns(ctx context.Context, tenantID string, year int) ([]assets.DepreciationRun, error) {
+	values := url.Values{}
+	if year > 0 {
+		values.Set("year", strconv.Itoa(year))
+	}
+
+	var resp []assets.DepreciationRun
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "depreciation-runs"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createDepreciationRun(ctx context.Context, tenantID string, req *assets.CreateDepreciationRunRequest) (*assets.DepreciationRun, error) {
+	var resp assets.DepreciationRun
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "depreciation-runs"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getDepreciationRun(ctx context.Context, tenantID, runID string) (*assets.DepreciationRun, error) {
+	var resp assets.DepreciationRun
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "depreciation-runs", runID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listAssetEvents(ctx context.Context, tenantID, assetID string) ([]assets.AssetEvent, error) {
+	var resp []assets.AssetEvent
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "assets", assetID, "events"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createAssetEvent(ctx context.Context, tenantID, assetID string, req *assets.CreateAssetEventRequest) (*assets.AssetEvent, error) {
+	var resp assets.AssetEvent
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "assets", assetID, "events"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getAssetHistory(ctx context.Context, tenantID, assetID string) (*assets.AssetHistory, error) {
+	var resp assets.AssetHistory
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "assets", assetID, "history"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) reverseDepreciationRun(ctx context.Context, tenantID, runID string, req *assets.ReverseDepreciationRunRequest) (*assets.DepreciationRun, error) {
+	var resp assets.DepreciationRun
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "depreciation-runs", runID, "reverse"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listProductCategories(ctx context.Context, tenantID string) ([]inventory.ProductCategory, error) {
+	var resp []inventory.ProductCategory
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "product-categories"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
 }
 
 func (c *apiClient) createProductCategory(ctx context.Context, tenantID string, req *inventory.CreateCategoryRequest) (*inventory.ProductCategory, error) {
@@ -2267,6 +2767,295 @@ func (c *apiClient) getInventoryLotReport(ctx context.Context, tenantID, product
 	return &resp, nil
 }
 
+func (c *apiClient) getInventoryAgingReport(ctx context.Context, tenantID string, req *inventory.InventoryAgingRequest) (*inventory.InventoryAgingReport, error) {
+	values := url.Values{}
+	if strings.TrimSpace(req.WarehouseID) != "" {
+		values.Set("warehouse_id", strings.TrimSpace(req.WarehouseID))
+	}
+	if strings.TrimSpace(req.CategoryID) != "" {
+		values.Set("category_id", strings.TrimSpace(req.CategoryID))
+	}
+	if !req.AsOfDate.IsZero() {
+		values.Set("as_of_date", req.AsOfDate.Format("2006-01-02"))
+	}
+	if req.SlowMovingDays > 0 {
+		values.Set("slow_moving_days", strconv.Itoa(req.SlowMovingDays))
+	}
+	if req.ExpiringWithinDays > 0 {
+		values.Set("expiring_within_days", strconv.Itoa(req.ExpiringWithinDays))
+	}
+	if !req.SellingCostPercent.IsZero() {
+		values.Set("selling_cost_percent", req.SellingCostPercent.String())
+	}
+	if req.SlowMovingDiscountPercent != nil {
+		values.Set("slow_moving_discount_percent", req.SlowMovingDiscountPercent.String())
+	}
+
+	var resp inventory.InventoryAgingReport
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "aging"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) proposeInventoryWriteDown(ctx context.Context, tenantID string, req *inventory.ProposeInventoryWriteDownRequest) (*inventory.InventoryWriteDownProposal, error) {
+	var resp inventory.InventoryWriteDownProposal
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "aging", "write-down"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getReplenishmentReport(ctx context.Context, tenantID string, req *purchasing.ReplenishmentRequest) (*purchasing.ReplenishmentReport, error) {
+	values := url.Values{}
+	if strings.TrimSpace(req.WarehouseID) != "" {
+		values.Set("warehouse_id", strings.TrimSpace(req.WarehouseID))
+	}
+	if strings.TrimSpace(req.SupplierID) != "" {
+		values.Set("supplier_id", strings.TrimSpace(req.SupplierID))
+	}
+	if !req.AsOfDate.IsZero() {
+		values.Set("as_of_date", req.AsOfDate.Format("2006-01-02"))
+	}
+	if req.VelocityDays > 0 {
+		values.Set("velocity_days", strconv.Itoa(req.VelocityDays))
+	}
+	if req.CoverageDays > 0 {
+		values.Set("coverage_days", strconv.Itoa(req.CoverageDays))
+	}
+
+	var resp purchasing.ReplenishmentReport
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "replenishment"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) createReplenishmentOrders(ctx context.Context, tenantID string, req *purchasing.CreateReplenishmentOrdersRequest) (*purchasing.ReplenishmentOrdersResult, error) {
+	var resp purchasing.ReplenishmentOrdersResult
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "replenishment", "purchase-orders"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) emitLowStockEvent(ctx context.Context, tenantID string, req *purchasing.ReplenishmentRequest) (*inventoryLowStockEventResponse, error) {
+	var resp inventoryLowStockEventResponse
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "replenishment", "low-stock-events"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listStockCounts(ctx context.Context, tenantID string, filter stocktake.StockCountFilter) ([]stocktake.StockCount, error) {
+	values := url.Values{}
+	if filter.Status != "" {
+		values.Set("status", string(filter.Status))
+	}
+	if strings.TrimSpace(filter.WarehouseID) != "" {
+		values.Set("warehouse_id", strings.TrimSpace(filter.WarehouseID))
+	}
+
+	var resp []stocktake.StockCount
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createStockCount(ctx context.Context, tenantID string, req *stocktake.CreateStockCountRequest) (*stocktake.StockCount, error) {
+	var resp stocktake.StockCount
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getStockCount(ctx context.Context, tenantID, countID string) (*stocktake.StockCount, error) {
+	var resp stocktake.StockCount
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) recordStockCounts(ctx context.Context, tenantID, countID string, req *stocktake.RecordCountsRequest) (*stocktake.StockCount, error) {
+	var resp stocktake.StockCount
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID, "counts"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) importStockCounts(ctx context.Context, tenantID, countID string, req *stocktake.ImportCountsRequest) (*stocktake.ImportCountsResult, error) {
+	var resp stocktake.ImportCountsResult
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID, "counts", "import"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) updateStockCountStatus(ctx context.Context, tenantID, countID, action string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID, action), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) approveStockCount(ctx context.Context, tenantID, countID string, req *stocktake.ApproveStockCountRequest) (*stocktake.StockCount, error) {
+	var resp stocktake.StockCount
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID, "approve"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getStockCountVariance(ctx context.Context, tenantID, countID string) (*stocktake.StockCountVariance, error) {
+	var resp stocktake.StockCountVariance
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID, "variance"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) exportStockCountVariance(ctx context.Context, tenantID, countID, format string) ([]byte, error) {
+	values := url.Values{}
+	values.Set("format", strings.TrimSpace(format))
+	return c.requestRaw(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "stock-counts", countID, "variance"), values), nil, c.apiToken)
+}
+
+func (c *apiClient) listBOMs(ctx context.Context, tenantID string, filter assembly.BOMFilter) ([]assembly.BillOfMaterials, error) {
+	values := url.Values{}
+	if filter.BOMType != "" {
+		values.Set("bom_type", string(filter.BOMType))
+	}
+	if strings.TrimSpace(filter.ProductID) != "" {
+		values.Set("product_id", strings.TrimSpace(filter.ProductID))
+	}
+	if filter.ActiveOnly {
+		values.Set("active_only", "true")
+	}
+
+	var resp []assembly.BillOfMaterials
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "boms"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createBOM(ctx context.Context, tenantID string, req *assembly.CreateBOMRequest) (*assembly.BillOfMaterials, error) {
+	var resp assembly.BillOfMaterials
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "boms"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getBOM(ctx context.Context, tenantID, bomID string) (*assembly.BillOfMaterials, error) {
+	var resp assembly.BillOfMaterials
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "inventory", "boms", bomID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) updateBOM(ctx context.Context, tenantID, bomID string, req *assembly.UpdateBOMRequest) (*assembly.BillOfMaterials, error) {
+	var resp assembly.BillOfMaterials
+	if err := c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "inventory", "boms", bomID), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) deleteBOM(ctx context.Context, tenantID, bomID string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.request(ctx, http.MethodDelete, path.Join("/api/v1/tenants", tenantID, "inventory", "boms", bomID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func bomExplosionQuery(quantity, valuationMethod, format string) url.Values {
+	values := url.Values{}
+	if strings.TrimSpace(quantity) != "" {
+		values.Set("quantity", strings.TrimSpace(quantity))
+	}
+	if strings.TrimSpace(valuationMethod) != "" {
+		values.Set("valuation_method", strings.TrimSpace(valuationMethod))
+	}
+	if strings.TrimSpace(format) != "" {
+		values.Set("format", strings.TrimSpace(format))
+	}
+	return values
+}
+
+func (c *apiClient) getBOMExplosion(ctx context.Context, tenantID, bomID, quantity, valuationMethod string) (*assembly.BOMExplosion, error) {
+	var resp assembly.BOMExplosion
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "boms", bomID, "explosion"), bomExplosionQuery(quantity, valuationMethod, "")), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) exportBOMExplosion(ctx context.Context, tenantID, bomID, quantity, valuationMethod, format string) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "boms", bomID, "explosion"), bomExplosionQuery(quantity, valuationMethod, format)), nil, c.apiToken)
+}
+
+func (c *apiClient) listAssemblyOrders(ctx context.Context, tenantID string, filter assembly.AssemblyOrderFilter) ([]assembly.AssemblyOrder, error) {
+	values := url.Values{}
+	if filter.Status != "" {
+		values.Set("status", string(filter.Status))
+	}
+	if filter.OrderType != "" {
+		values.Set("order_type", string(filter.OrderType))
+	}
+	if strings.TrimSpace(filter.ProductID) != "" {
+		values.Set("product_id", strings.TrimSpace(filter.ProductID))
+	}
+	if strings.TrimSpace(filter.WarehouseID) != "" {
+		values.Set("warehouse_id", strings.TrimSpace(filter.WarehouseID))
+	}
+
+	var resp []assembly.AssemblyOrder
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "inventory", "assembly-orders"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createAssemblyOrder(ctx context.Context, tenantID string, req *assembly.CreateAssemblyOrderRequest) (*assembly.AssemblyOrder, error) {
+	var resp assembly.AssemblyOrder
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "assembly-orders"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) getAssemblyOrder(ctx context.Context, tenantID, orderID string) (*assembly.AssemblyOrder, error) {
+	var resp assembly.AssemblyOrder
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "inventory", "assembly-orders", orderID), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) completeAssemblyOrder(ctx context.Context, tenantID, orderID string, req *assembly.CompleteAssemblyOrderRequest) (*assembly.AssemblyOrder, error) {
+	var resp assembly.AssemblyOrder
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "assembly-orders", orderID, "complete"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) cancelAssemblyOrder(ctx context.Context, tenantID, orderID string) (map[string]string, error) {
+	var resp map[string]string
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "inventory", "assembly-orders", orderID, "cancel"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *apiClient) listWarehouses(ctx context.Context, tenantID string, activeOnly bool) ([]inventory.Warehouse, error) {
 	values := url.Values{}
 	if activeOnly {
@@ -2689,6 +3478,17 @@ func (c *apiClient) listSalaryComponents(ctx context.Context, tenantID, employee
 	return resp, nil
 }
 
+func (c *apiClient) getAverageEarnings(ctx context.Context, tenantID, employeeID, date string) (*payroll.AverageEarnings, error) {
+	values := url.Values{}
+	values.Set("date", strings.TrimSpace(date))
+
+	var resp payroll.AverageEarnings
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "employees", employeeID, "average-earnings"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *apiClient) addSalaryComponent(ctx context.Context, tenantID, employeeID string, req *payroll.CreateSalaryComponentRequest) (*payroll.SalaryComponent, error) {
 	var resp payroll.SalaryComponent
 	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "employees", employeeID, "salary-components"), req, c.apiToken, &resp); err != nil {
@@ -2729,6 +3529,160 @@ func (c *apiClient) importLeaveBalances(ctx context.Context, tenantID string, re
 	return &resp, nil
 }
 
+func (c *apiClient) listTimesheets(ctx context.Context, tenantID, employeeID, fromDate, toDate, status string) ([]payroll.TimesheetEntry, error) {
+	values := url.Values{}
+	if strings.TrimSpace(employeeID) != "" {
+		values.Set("employee_id", strings.TrimSpace(employeeID))
+	}
+	if strings.TrimSpace(fromDate) != "" {
+		values.Set("from_date", strings.TrimSpace(fromDate))
+	}
+	if strings.TrimSpace(toDate) != "" {
+		values.Set("to_date", strings.TrimSpace(toDate))
+	}
+	if strings.TrimSpace(status) != "" {
+		values.Set("status", strings.TrimSpace(status))
+	}
+
+	var resp []payroll.TimesheetEntry
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "timesheets"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) saveTimesheet(ctx context.Context, tenantID string, req *payroll.CreateTimesheetEntryRequest) (*payroll.TimesheetEntry, error) {
+	var resp payroll.TimesheetEntry
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "timesheets"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) importTimesheets(ctx context.Context, tenantID string, req *payroll.ImportTimesheetsRequest) (*payroll.ImportTimesheetsResult, error) {
+	var resp payroll.ImportTimesheetsResult
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "timesheets", "import"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) approveTimesheets(ctx context.Context, tenantID string, req *payroll.ApproveTimesheetsRequest) (*payroll.ApproveTimesheetsResult, error) {
+	var resp payroll.ApproveTimesheetsResult
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "timesheets", "approve"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listEmploymentEvents(ctx context.Context, tenantID, employeeID string) ([]payroll.EmploymentEvent, error) {
+	var resp []payroll.EmploymentEvent
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "employees", employeeID, "employment-events"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createEmploymentEvent(ctx context.Context, tenantID, employeeID string, req *payroll.CreateEmploymentEventRequest) (*payroll.EmploymentEvent, error) {
+	var resp payroll.EmploymentEvent
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "employees", employeeID, "employment-events"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listPendingEmploymentRegister(ctx context.Context, tenantID string) ([]payroll.EmploymentRegisterPendingEmployee, error) {
+	var resp []payroll.EmploymentRegisterPendingEmployee
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "employment-register", "pending"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) exportEmploymentRegister(ctx context.Context, tenantID string, req *payroll.ExportEmploymentRegisterRequest) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "employment-register", "export"), req, c.apiToken)
+}
+
+func (c *apiClient) getSelfEmployee(ctx context.Context, tenantID string) (*payroll.Employee, error) {
+	var resp payroll.Employee
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "self", "employee"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listSelfPayslips(ctx context.Context, tenantID string, year int) ([]payroll.EmployeePayslip, error) {
+	values := url.Values{}
+	if year > 0 {
+		values.Set("year", strconv.Itoa(year))
+	}
+
+	var resp []payroll.EmployeePayslip
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "self", "payslips"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) downloadSelfPayslipPDF(ctx context.Context, tenantID, payslipID string) ([]byte, error) {
+	return c.requestRaw(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "self", "payslips", payslipID, "pdf"), nil, c.apiToken)
+}
+
+func (c *apiClient) listSelfLeaveBalances(ctx context.Context, tenantID string, year int) ([]payroll.LeaveBalance, error) {
+	values := url.Values{}
+	if year > 0 {
+		values.Set("year", strconv.Itoa(year))
+	}
+
+	var resp []payroll.LeaveBalance
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "self", "leave-balances"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) listSelfLeaveRecords(ctx context.Context, tenantID string, year int) ([]payroll.LeaveRecord, error) {
+	values := url.Values{}
+	if year > 0 {
+		values.Set("year", strconv.Itoa(year))
+	}
+
+	var resp []payroll.LeaveRecord
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "self", "leave-records"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) createSelfLeaveRecord(ctx context.Context, tenantID string, req *payroll.CreateLeaveRecordRequest) (*payroll.LeaveRecord, error) {
+	var resp payroll.LeaveRecord
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "self", "leave-records"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listSelfExpenses(ctx context.Context, tenantID string, status expenses.ExpenseStatus) ([]expenses.Expense, error) {
+	values := url.Values{}
+	if status != "" {
+		values.Set("status", string(status))
+	}
+
+	var resp []expenses.Expense
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "self", "expenses"), values), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) submitSelfExpense(ctx context.Context, tenantID string, req *expenses.CreateExpenseRequest) (*expenses.Expense, error) {
+	var resp expenses.Expense
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "self", "expenses"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 func (c *apiClient) listAbsenceTypes(ctx context.Context, tenantID string, activeOnly bool) ([]payroll.AbsenceType, error) {
 	values := url.Values{}
 	if activeOnly {
@@ -2904,6 +3858,56 @@ func (c *apiClient) approvePayrollRun(ctx context.Context, tenantID, runID strin
 	return resp, nil
 }
 
+func (c *apiClient) reopenPayrollRun(ctx context.Context, tenantID, runID, reason string) (*payroll.PayrollRun, error) {
+	var resp payroll.PayrollRun
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "payroll-runs", runID, "reopen"), map[string]string{"reason": reason}, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listPayrollPostingAccounts(ctx context.Context, tenantID string) ([]payroll.PayrollPostingAccounts, error) {
+	var resp []payroll.PayrollPostingAccounts
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "payroll", "posting-accounts"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *apiClient) setPayrollPostingAccounts(ctx context.Context, tenantID string, req *payroll.SetPayrollPostingAccountsRequest) (*payroll.PayrollPostingAccounts, error) {
+	var resp payroll.PayrollPostingAccounts
+	if err := c.request(ctx, http.MethodPut, path.Join("/api/v1/tenants", tenantID, "payroll", "posting-accounts"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) deletePayrollPostingAccounts(ctx context.Context, tenantID, department string) error {
+	endpoint := path.Join("/api/v1/tenants", tenantID, "payroll", "posting-accounts")
+	if department != "" {
+		values := url.Values{}
+		values.Set("department", department)
+		endpoint += "?" + values.Encode()
+	}
+	return c.request(ctx, http.MethodDelete, endpoint, nil, c.apiToken, nil)
+}
+
+func (c *apiClient) payPayrollRun(ctx context.Context, tenantID, runID string, req *payroll.PayPayrollRunRequest) (*payroll.PayPayrollRunResult, error) {
+	var resp payroll.PayPayrollRunResult
+	if err := c.request(ctx, http.MethodPost, path.Join("/api/v1/tenants", tenantID, "payroll-runs", runID, "pay"), req, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) listPayrollPayments(ctx context.Context, tenantID, runID string) ([]payroll.PayrollPayment, error) {
+	var resp []payroll.PayrollPayment
+	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "payroll-runs", runID, "payments"), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 func (c *apiClient) listPayslips(ctx context.Context, tenantID, runID string) ([]payroll.Payslip, error) {
 	var resp []payroll.Payslip
 	if err := c.request(ctx, http.MethodGet, path.Join("/api/v1/tenants", tenantID, "payroll-runs", runID, "payslips"), nil, c.apiToken, &resp); err != nil {
@@ -3348,6 +4352,27 @@ func (c *apiClient) exportContactStatement(ctx context.Context, tenantID, contac
 	return c.requestRaw(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "reports", "contact-statements", contactID), values), nil, c.apiToken)
 }
 
+func (c *apiClient) getFixedAssetRegister(ctx context.Context, tenantID, startDate, endDate string) (*assets.AssetRegisterReport, error) {
+	var resp assets.AssetRegisterReport
+	if err := c.request(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "reports", "fixed-asset-register"), fixedAssetRegisterQuery(startDate, endDate)), nil, c.apiToken, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+func (c *apiClient) exportFixedAssetRegister(ctx context.Context, tenantID, startDate, endDate, format string) ([]byte, error) {
+	values := fixedAssetRegisterQuery(startDate, endDate)
+	values.Set("format", strings.TrimSpace(format))
+	return c.requestRaw(ctx, http.MethodGet, withQuery(path.Join("/api/v1/tenants", tenantID, "reports", "fixed-asset-register"), values), nil, c.apiToken)
+}
+
+func fixedAssetRegisterQuery(startDate, endDate string) url.Values {
+	values := url.Values{}
+	values.Set("start_date", strings.TrimSpace(startDate))
+	values.Set("end_date", strings.TrimSpace(endDate))
+	return values
+}
+
 func contactStatementQuery(balanceType, startDate, endDate string) url.Values {
 	values := url.Values{}
 	values.Set("type", strings.TrimSpace(balanceType))
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/csv"
 	"encoding/json"
@@ -22,6 +23,7 @@ import (
 
 	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/apitoken"
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/banking"
 	"github.com/HMB-research/open-accounting/internal/banking/mappers"
@@ -37,9 +39,12 @@ import (
 	"github.com/HMB-research/open-accounting/internal/payments"
 	"github.com/HMB-research/open-accounting/internal/payroll"
 	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
 	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 	"github.com/HMB-research/open-accounting/internal/reports"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
 	"github.com/HMB-research/open-accounting/internal/tax"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/HMB-research/open-accounting/internal/webhooks"
@@ -122,6 +127,12 @@ func (a *cliApp) run(ctx context.Context, args []string) error {
 		return a.runPayroll(ctx, args[1:])
 	case "leave":
 		return a.runLeave(ctx, args[1:])
+	case "employment-register":
+		return a.runEmploymentRegister(ctx, args[1:])
+	case "self":
+		return a.runSelf(ctx, args[1:])
+	case "timesheets":
+		return a.runTimesheets(ctx, args[1:])
 	case "tsd":
 		return a.runTSD(ctx, args[1:])
 	case "tax":
@@ -134,6 +145,8 @@ func (a *cliApp) run(ctx context.Context, args []string) error {
 		return a.runReminders(ctx, args[1:])
 	case "email":
 		return a.runEmail(ctx, args[1:])
+	case "document-templates":
+		return a.runDocumentTemplates(ctx, args[1:])
 	case "interest":
 		return a.runInterest(ctx, args[1:])
 	case "close":
@@ -144,6 +157,14 @@ func (a *cliApp) run(ctx context.Context, args []string) error {
 		return a.runQuotes(ctx, args[1:])
 	case "orders":
 		return a.runOrders(ctx, args[1:])
+	case "purchase-orders":
+		return a.runPurchaseOrders(ctx, args[1:])
+	case "landed-costs":
+		return a.runLandedCosts(ctx, args[1:])
+	case "price-lists":
+		return a.runPriceLists(ctx, args[1:])
+	case "customer-groups":
+		return a.runCustomerGroups(ctx, args[1:])
 	case "recurring-invoices":
 		return a.runRecurringInvoices(ctx, args[1:])
 	case "assets":
@@ -269,6 +290,8 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  contacts update           Update a contact")
 	_, _ = fmt.Fprintln(a.stdout, "  contacts delete           Delete a contact")
 	_, _ = fmt.Fprintln(a.stdout, "  contacts import           Import contacts from CSV")
+	_, _ = fmt.Fprintln(a.stdout, "  contacts pricing          Show a customer's price list, group and discount")
+	_, _ = fmt.Fprintln(a.stdout, "  contacts set-pricing      Set a customer's price list, group and discount")
 	_, _ = fmt.Fprintln(a.stdout, "  employees list            List employees")
 	_, _ = fmt.Fprintln(a.stdout, "  employees create          Create an employee")
 	_, _ = fmt.Fprintln(a.stdout, "  employees get             Show one employee")
@@ -276,6 +299,9 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  employees set-salary      Set an employee base salary")
 	_, _ = fmt.Fprintln(a.stdout, "  employees salary-components     List salary components")
 	_, _ = fmt.Fprintln(a.stdout, "  employees add-salary-component  Add a salary component")
+	_, _ = fmt.Fprintln(a.stdout, "  employees average-earnings      Show average daily earnings for leave pay")
+	_, _ = fmt.Fprintln(a.stdout, "  employees employment-events     List an employee's employment register history")
+	_, _ = fmt.Fprintln(a.stdout, "  employees add-employment-event  Record a start, end, suspension or working-time change")
 	_, _ = fmt.Fprintln(a.stdout, "  employees import          Import employees from CSV")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs list         List payroll runs")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs create       Create a payroll run")
@@ -284,8 +310,14 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs set-payment-date Set payment date for a payroll run")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs process      Bulk process a payroll run")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs approve      Approve a payroll run")
+	_, _ = fmt.Fprintln(a.stdout, "  payroll runs reopen       Reopen an approved payroll run and void its journal")
+	_, _ = fmt.Fprintln(a.stdout, "  payroll runs pay          Generate the net salary SEPA payment file for a payroll run")
+	_, _ = fmt.Fprintln(a.stdout, "  payroll runs payments     List payment files generated for a payroll run")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs payslips     List payslips for a payroll run")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll runs payslip-pdf  Download one payslip PDF")
+	_, _ = fmt.Fprintln(a.stdout, "  payroll posting-accounts list    List payroll general-ledger posting accounts")
+	_, _ = fmt.Fprintln(a.stdout, "  payroll posting-accounts set     Set default or department payroll posting accounts")
+	_, _ = fmt.Fprintln(a.stdout, "  payroll posting-accounts delete  Delete default or department payroll posting accounts")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll tax-preview       Preview Estonian payroll taxes")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll import-history    Import historical payroll runs from CSV")
 	_, _ = fmt.Fprintln(a.stdout, "  payroll import-leave-balances  Import leave balances from CSV")
@@ -302,6 +334,20 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  leave records approve     Approve a leave record")
 	_, _ = fmt.Fprintln(a.stdout, "  leave records reject      Reject a leave record")
 	_, _ = fmt.Fprintln(a.stdout, "  leave records cancel      Cancel a leave record")
+	_, _ = fmt.Fprintln(a.stdout, "  employment-register pending  List employees with changes not yet exported to TÖR")
+	_, _ = fmt.Fprintln(a.stdout, "  employment-register export   Export the TÖR bulk-upload file for a date range")
+	_, _ = fmt.Fprintln(a.stdout, "  self employee             Show the employee record linked to your account")
+	_, _ = fmt.Fprintln(a.stdout, "  self payslips             List your released payslips")
+	_, _ = fmt.Fprintln(a.stdout, "  self payslip-pdf          Download one of your payslips as PDF")
+	_, _ = fmt.Fprintln(a.stdout, "  self leave-balances       Show your leave balances")
+	_, _ = fmt.Fprintln(a.stdout, "  self leave-records        List your leave requests")
+	_, _ = fmt.Fprintln(a.stdout, "  self request-leave        Request leave for approval")
+	_, _ = fmt.Fprintln(a.stdout, "  self expenses             List your expense claims")
+	_, _ = fmt.Fprintln(a.stdout, "  self submit-expense       Submit an expense claim for approval")
+	_, _ = fmt.Fprintln(a.stdout, "  timesheets list           List daily timesheet entries")
+	_, _ = fmt.Fprintln(a.stdout, "  timesheets create         Record an employee's hours for one day")
+	_, _ = fmt.Fprintln(a.stdout, "  timesheets import         Import timesheet entries from CSV")
+	_, _ = fmt.Fprintln(a.stdout, "  timesheets approve        Approve pending timesheet entries for a date range")
 	_, _ = fmt.Fprintln(a.stdout, "  tsd list                  List TSD declarations")
 	_, _ = fmt.Fprintln(a.stdout, "  tsd get                   Show one TSD declaration")
 	_, _ = fmt.Fprintln(a.stdout, "  tsd generate              Generate TSD from a payroll run")
@@ -323,10 +369,13 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  invoices create           Create an invoice")
 	_, _ = fmt.Fprintln(a.stdout, "  invoices get              Show one invoice")
 	_, _ = fmt.Fprintln(a.stdout, "  invoices pdf              Download an invoice PDF")
+	_, _ = fmt.Fprintln(a.stdout, "  invoices reminder-pdf     Download a payment reminder PDF for an invoice")
 	_, _ = fmt.Fprintln(a.stdout, "  invoices send             Mark an invoice sent")
 	_, _ = fmt.Fprintln(a.stdout, "  invoices void             Void an invoice")
+	_, _ = fmt.Fprintln(a.stdout, "  invoices credit-note      Credit lines of an issued invoice")
 	_, _ = fmt.Fprintln(a.stdout, "  invoices import           Import invoices from CSV")
 	_, _ = fmt.Fprintln(a.stdout, "  invoices import-einvoice  Import Estonian e-invoice XML")
+	_, _ = fmt.Fprintln(a.stdout, "  invoices export-einvoice  Export invoices as Estonian e-invoice or Peppol UBL XML")
 	_, _ = fmt.Fprintln(a.stdout, "  expenses import           Import expenses from CSV")
 	_, _ = fmt.Fprintln(a.stdout, "  payments list             List payments")
 	_, _ = fmt.Fprintln(a.stdout, "  payments create           Create a payment")
@@ -356,6 +405,10 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  email quote               Send a quote email")
 	_, _ = fmt.Fprintln(a.stdout, "  email order               Send an order confirmation email")
 	_, _ = fmt.Fprintln(a.stdout, "  email payment-receipt     Send a payment receipt email")
+	_, _ = fmt.Fprintln(a.stdout, "  document-templates list   List PDF document templates")
+	_, _ = fmt.Fprintln(a.stdout, "  document-templates update Save a PDF document template from JSON")
+	_, _ = fmt.Fprintln(a.stdout, "  document-templates reset  Restore the built-in PDF layout")
+	_, _ = fmt.Fprintln(a.stdout, "  document-templates preview Render a template against a sample document")
 	_, _ = fmt.Fprintln(a.stdout, "  interest settings get     Show late-payment interest settings")
 	_, _ = fmt.Fprintln(a.stdout, "  interest settings update  Update late-payment interest settings")
 	_, _ = fmt.Fprintln(a.stdout, "  interest overdue          List overdue invoices with interest")
@@ -400,12 +453,20 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  quotes import             Import quotes from CSV")
 	_, _ = fmt.Fprintln(a.stdout, "  quotes get                Show one quote")
 	_, _ = fmt.Fprintln(a.stdout, "  quotes pdf                Download a quote PDF")
-	_, _ = fmt.Fprintln(a.stdout, "  quotes update             Update a draft quote")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes update             Update a quote, starting a new revision once sent")
 	_, _ = fmt.Fprintln(a.stdout, "  quotes delete             Delete a draft quote")
 	_, _ = fmt.Fprintln(a.stdout, "  quotes send               Mark a quote sent")
 	_, _ = fmt.Fprintln(a.stdout, "  quotes accept             Mark a quote accepted")
 	_, _ = fmt.Fprintln(a.stdout, "  quotes reject             Mark a quote rejected")
-	_, _ = fmt.Fprintln(a.stdout, "  quotes convert-to-invoice Convert an accepted quote to an invoice")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes convert-to-invoice Invoice an accepted quote in full or in part")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes invoices          List invoices billed from a quote")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes revisions          List the sent revisions of a quote")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes revision           Show one sent revision of a quote")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes link               Create a signed customer link to a sent quote")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes link-view          Show a quote through a customer link")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes link-pdf           Download a quote PDF through a customer link")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes link-accept        Accept a quote through a customer link")
+	_, _ = fmt.Fprintln(a.stdout, "  quotes link-reject        Reject a quote through a customer link")
 	_, _ = fmt.Fprintln(a.stdout, "  orders list               List orders")
 	_, _ = fmt.Fprintln(a.stdout, "  orders create             Create an order")
 	_, _ = fmt.Fprintln(a.stdout, "  orders import             Import orders from CSV")
@@ -420,10 +481,32 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  orders delete             Delete a pending order")
 	_, _ = fmt.Fprintln(a.stdout, "  orders confirm            Mark an order confirmed")
 	_, _ = fmt.Fprintln(a.stdout, "  orders process            Mark an order processing")
-	_, _ = fmt.Fprintln(a.stdout, "  orders ship               Mark an order shipped")
+	_, _ = fmt.Fprintln(a.stdout, "  orders ship               Ship order lines from stock and post COGS")
+	_, _ = fmt.Fprintln(a.stdout, "  orders shipments          List order shipments")
+	_, _ = fmt.Fprintln(a.stdout, "  orders delivery-note      Download a shipment delivery note PDF")
 	_, _ = fmt.Fprintln(a.stdout, "  orders deliver            Mark an order delivered")
 	_, _ = fmt.Fprintln(a.stdout, "  orders cancel             Cancel an order")
-	_, _ = fmt.Fprintln(a.stdout, "  orders convert-to-invoice Convert a delivered order to an invoice")
+	_, _ = fmt.Fprintln(a.stdout, "  orders convert-to-invoice Invoice an order in full or in part")
+	_, _ = fmt.Fprintln(a.stdout, "  orders invoices          List invoices billed from an order")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders list      List supplier purchase orders")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders create    Create a draft purchase order")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders get       Show a purchase order")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders approve   Approve a draft purchase order")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders cancel    Cancel a purchase order without receipts")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders receive   Book a goods receipt into stock")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders match-invoice  Match and post a supplier invoice")
+	_, _ = fmt.Fprintln(a.stdout, "  purchase-orders matching  Show three-way matching status")
+	_, _ = fmt.Fprintln(a.stdout, "  landed-costs list         List landed cost allocations")
+	_, _ = fmt.Fprintln(a.stdout, "  landed-costs allocate     Allocate a freight or duty invoice onto received stock")
+	_, _ = fmt.Fprintln(a.stdout, "  landed-costs get          Show a landed cost allocation")
+	_, _ = fmt.Fprintln(a.stdout, "  price-lists list          List sales price lists")
+	_, _ = fmt.Fprintln(a.stdout, "  price-lists create        Create a price list with quantity breaks")
+	_, _ = fmt.Fprintln(a.stdout, "  price-lists get           Show a price list and its items")
+	_, _ = fmt.Fprintln(a.stdout, "  price-lists update        Update a price list")
+	_, _ = fmt.Fprintln(a.stdout, "  price-lists resolve       Resolve the price a customer pays for a product")
+	_, _ = fmt.Fprintln(a.stdout, "  customer-groups list      List customer pricing groups")
+	_, _ = fmt.Fprintln(a.stdout, "  customer-groups create    Create a customer pricing group")
+	_, _ = fmt.Fprintln(a.stdout, "  customer-groups update    Update a customer pricing group")
 	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices list   List recurring invoice templates")
 	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices create Create a recurring invoice template")
 	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices import Import recurring invoice templates from CSV")
@@ -435,6 +518,12 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices resume Resume a recurring invoice template")
 	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices generate  Generate one recurring invoice")
 	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices generate-due  Generate all due recurring invoices")
+	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices usage list    List usage records of a recurring invoice")
+	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices usage add     Record usage for a usage-based line")
+	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices usage delete  Delete an uninvoiced usage record")
+	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices usage import  Import usage records from CSV")
+	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices price-changes add     Schedule a price change or uplift")
+	_, _ = fmt.Fprintln(a.stdout, "  recurring-invoices price-changes delete  Delete a scheduled price change")
 	_, _ = fmt.Fprintln(a.stdout, "  expenses list             List expense claims")
 	_, _ = fmt.Fprintln(a.stdout, "  expenses create           Create an expense claim")
 	_, _ = fmt.Fprintln(a.stdout, "  expenses get              Show one expense claim")
@@ -456,6 +545,15 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  assets dispose            Dispose or sell a fixed asset")
 	_, _ = fmt.Fprintln(a.stdout, "  assets depreciate         Record monthly depreciation")
 	_, _ = fmt.Fprintln(a.stdout, "  assets depreciation       List depreciation history")
+	_, _ = fmt.Fprintln(a.stdout, "  assets schedule           Project depreciation through end of useful life")
+	_, _ = fmt.Fprintln(a.stdout, "  assets history            Explain every change in net book value")
+	_, _ = fmt.Fprintln(a.stdout, "  assets events list        List improvements, impairments and estimate changes")
+	_, _ = fmt.Fprintln(a.stdout, "  assets events create      Record an improvement, impairment or estimate change")
+	_, _ = fmt.Fprintln(a.stdout, "  assets depreciation-runs preview  Preview a monthly depreciation run")
+	_, _ = fmt.Fprintln(a.stdout, "  assets depreciation-runs list     List depreciation runs")
+	_, _ = fmt.Fprintln(a.stdout, "  assets depreciation-runs create   Post depreciation for all active assets")
+	_, _ = fmt.Fprintln(a.stdout, "  assets depreciation-runs get      Show one depreciation run")
+	_, _ = fmt.Fprintln(a.stdout, "  assets depreciation-runs reverse  Reverse a posted depreciation run")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory categories list List product categories")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory categories create  Create a product category")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory categories import  Import product categories from CSV")
@@ -472,6 +570,31 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  inventory valuation       Show inventory valuation")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory subledger-reconciliation  Reconcile inventory subledger to GL")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory lots            Show lot and serial stock report")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory aging           Show stock aging, expiry and write-down report")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory write-down      Draft the net realisable value write-down entry")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory replenishment   Show reorder proposals by supplier")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory replenishment-orders  Create draft purchase orders from reorder proposals")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory low-stock-event  Send an inventory.low_stock webhook event")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts list  List stock counts")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts create  Open a stock count for a warehouse")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts get  Show one stock count with its lines")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts count  Enter counted quantities")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts import  Import counted quantities from scanner CSV")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts submit  Submit a stock count for review")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts approve  Approve a stock count and post its variances")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts cancel  Cancel a stock count")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory stock-counts variance  Show valued stock count variances")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory boms list  List bills of materials")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory boms create  Create a bill of materials")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory boms get  Show one bill of materials with its components")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory boms update  Replace the components and costs of a bill of materials")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory boms delete  Delete an unused bill of materials")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory boms explode  Show a multi-level costed explosion")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory assembly-orders list  List assembly orders")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory assembly-orders create  Draft an assembly or disassembly order")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory assembly-orders get  Show one assembly order with its lines")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory assembly-orders complete  Complete an assembly order and post it")
+	_, _ = fmt.Fprintln(a.stdout, "  inventory assembly-orders cancel  Cancel a draft assembly order")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory warehouses list List warehouses")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory warehouses create  Create a warehouse")
 	_, _ = fmt.Fprintln(a.stdout, "  inventory warehouses import  Import warehouses from CSV")
@@ -514,6 +637,7 @@ func (a *cliApp) printUsage() {
 	_, _ = fmt.Fprintln(a.stdout, "  reports sales-margin      Show sales margin by invoice line")
 	_, _ = fmt.Fprintln(a.stdout, "  reports customer-profitability  Show customer profitability by margin")
 	_, _ = fmt.Fprintln(a.stdout, "  reports budget-vs-actual  Show budget versus actual expenses")
+	_, _ = fmt.Fprintln(a.stdout, "  reports fixed-asset-register  Show fixed asset cost and depreciation roll-forward")
 	_, _ = fmt.Fprintln(a.stdout, "  documents list            List documents for a record")
 	_, _ = fmt.Fprintln(a.stdout, "  documents review-summary  Summarize document review state")
 	_, _ = fmt.Fprintln(a.stdout, "  documents review-queue    List documents waiting for reviewer action")
@@ -1424,7 +1548,8 @@ func (a *cliApp) runUsers(ctx context.Context, args []string) error {
 		fs := flag.NewFlagSet("users update-role", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
 		userID := fs.String("id", "", "User id")
-		role := fs.String("role", "", "New role: admin, accountant, viewer")
+		role := fs.String("role", "", "New role: admin, accountant, viewer, employee")
+		employeeID := fs.String("employee-id", "", "Payroll employee id linked to the employee role")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
@@ -1432,10 +1557,13 @@ func (a *cliApp) runUsers(ctx context.Context, args []string) error {
 			return errors.New("id and role are required")
 		}
 		if !tenant.IsValidRole(strings.TrimSpace(*role)) {
-			return errors.New("role must be one of: admin, accountant, viewer")
+			return errors.New("role must be one of: admin, accountant, viewer, employee")
+		}
+		if err := tenant.ValidateEmployeeLink(strings.TrimSpace(*role), strings.TrimSpace(*employeeID)); err != nil {
+			return err
 		}
 
-		if err := client.updateTenantUserRole(ctx, cfg.TenantID, strings.TrimSpace(*userID), strings.TrimSpace(*role)); err != nil {
+		if err := client.updateTenantUserRole(ctx, cfg.TenantID, strings.TrimSpace(*userID), strings.TrimSpace(*role), strings.TrimSpace(*employeeID)); err != nil {
 			return err
 		}
 		_, _ = fmt.Fprintf(a.stdout, "Updated user %s role to %s\n", strings.TrimSpace(*userID), strings.TrimSpace(*role))
@@ -1647,7 +1775,8 @@ func (a *cliApp) runInvitations(ctx context.Context, args []string) error {
 		fs := flag.NewFlagSet("invitations create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
 		email := fs.String("email", "", "Invitee email")
-		role := fs.String("role", tenant.RoleViewer, "Role: admin, accountant, viewer")
+		role := fs.String("role", tenant.RoleViewer, "Role: admin, accountant, viewer, employee")
+		employeeID := fs.String("employee-id", "", "Payroll employee id, required for the employee role")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
@@ -1655,10 +1784,14 @@ func (a *cliApp) runInvitations(ctx context.Context, args []string) error {
 		if strings.TrimSpace(*email) == "" || strings.TrimSpace(*role) == "" {
 			return errors.New("email and role are required")
 		}
+		if err := tenant.ValidateEmployeeLink(strings.TrimSpace(*role), strings.TrimSpace(*employeeID)); err != nil {
+			return err
+		}
 
 		invitation, err := client.createInvitation(ctx, cfg.TenantID, &tenant.CreateInvitationRequest{
-			Email: strings.TrimSpace(*email),
-			Role:  strings.TrimSpace(*role),
+			Email:      strings.TrimSpace(*email),
+			Role:       strings.TrimSpace(*role),
+			EmployeeID: strings.TrimSpace(*employeeID),
 		})
 		if err != nil {
 			return err
@@ -3111,6 +3244,7 @@ func (a *cliApp) runContacts(ctx context.Context, args []string) error {
 		city := fs.String("city", "", "City")
 		postalCode := fs.String("postal-code", "", "Postal code")
 		countryCode := fs.String("country-code", "EE", "Country code")
+		language := fs.String("language", "", "Document language: et or en; defaults to the tenant document language")
 		paymentTermsDays := fs.Int("payment-terms-days", 14, "Payment terms in days")
 		creditLimit := fs.String("credit-limit", "", "Credit limit")
 		defaultAccountID := fs.String("default-account-id", "", "Default account id")
@@ -3149,6 +3283,7 @@ func (a *cliApp) runContacts(ctx context.Context, args []string) error {
 			City:             strings.TrimSpace(*city),
 			PostalCode:       strings.TrimSpace(*postalCode),
 			CountryCode:      strings.ToUpper(strings.TrimSpace(*countryCode)),
+			Language:         strings.ToLower(strings.TrimSpace(*language)),
 			PaymentTermsDays: *paymentTermsDays,
 			CreditLimit:      creditLimitValue,
 			DefaultAccountID: parsedDefaultAccountID,
@@ -3199,6 +3334,7 @@ func (a *cliApp) runContacts(ctx context.Context, args []string) error {
 		city := fs.String("city", "", "City")
 		postalCode := fs.String("postal-code", "", "Postal code")
 		countryCode := fs.String("country-code", "", "Country code")
+		language := fs.String("language", "", "Document language: et or en")
 		paymentTermsDays := fs.String("payment-terms-days", "", "Payment terms in days")
 		creditLimit := fs.String("credit-limit", "", "Credit limit")
 		defaultAccountID := fs.String("default-account-id", "", "Default account id")
@@ -3227,6 +3363,7 @@ func (a *cliApp) runContacts(ctx context.Context, args []string) error {
 			City:             optionalStringPtr(*city),
 			PostalCode:       optionalStringPtr(*postalCode),
 			CountryCode:      optionalUpperStringPtr(*countryCode),
+			Language:         optionalStringPtr(strings.ToLower(*language)),
 			DefaultAccountID: parsedDefaultAccountID,
 			Notes:            optionalStringPtr(*notes),
 		}
@@ -3313,6 +3450,61 @@ func (a *cliApp) runContacts(ctx context.Context, args []string) error {
 		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d contacts, skipped %d rows\n", result.RowsProcessed, result.ContactsCreated, result.RowsSkipped)
 		return nil
 
+	case "pricing":
+		fs := flag.NewFlagSet("contacts pricing", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		contactID := fs.String("id", "", "Contact id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*contactID) == "" {
+			return errors.New("id is required")
+		}
+
+		terms, err := client.getContactPricing(ctx, cfg.TenantID, strings.TrimSpace(*contactID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, terms)
+		}
+		printCustomerPricing(a.stdout, terms)
+		return nil
+
+	case "set-pricing":
+		fs := flag.NewFlagSet("contacts set-pricing", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		contactID := fs.String("id", "", "Contact id")
+		groupID := fs.String("customer-group-id", "", "Customer group id")
+		priceListID := fs.String("price-list-id", "", "Customer-specific price list id")
+		discountPercent := fs.String("discount-percent", "", "Customer-specific line discount percent")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*contactID) == "" {
+			return errors.New("id is required")
+		}
+		discount, err := parseOptionalNonNegativeDecimalPtr("discount-percent", *discountPercent)
+		if err != nil {
+			return err
+		}
+
+		terms, err := client.setContactPricing(ctx, cfg.TenantID, strings.TrimSpace(*contactID), &pricing.SetCustomerPricingRequest{
+			CustomerGroupID: optionalStringPtr(*groupID),
+			PriceListID:     optionalStringPtr(*priceListID),
+			DiscountPercent: decimalValueOrZero(discount),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, terms)
+		}
+		printCustomerPricing(a.stdout, terms)
+		return nil
+
 	default:
 		return fmt.Errorf("unknown contacts subcommand %q", args[0])
 	}
@@ -3477,6 +3669,24 @@ func (a *cliApp) runInvoices(ctx context.Context, args []string) error {
 		}
 		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "Invoice PDF")
 
+	case "reminder-pdf":
+		fs := flag.NewFlagSet("invoices reminder-pdf", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		invoiceID := fs.String("id", "", "Invoice id")
+		outputPath := fs.String("output", "", "Optional output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*invoiceID) == "" {
+			return errors.New("id is required")
+		}
+
+		content, err := client.downloadInvoiceReminderPDF(ctx, cfg.TenantID, strings.TrimSpace(*invoiceID))
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "Reminder PDF")
+
 	case "send":
 		fs := flag.NewFlagSet("invoices send", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
@@ -3521,6 +3731,45 @@ func (a *cliApp) runInvoices(ctx context.Context, args []string) error {
 		_, _ = fmt.Fprintf(a.stdout, "Voided invoice %s\n", strings.TrimSpace(*invoiceID))
 		return nil
 
+	case "credit-note":
+		fs := flag.NewFlagSet("invoices credit-note", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		invoiceID := fs.String("id", "", "Invoice id to credit")
+		issueDate := fs.String("issue-date", "", "Credit note issue date in YYYY-MM-DD")
+		reference := fs.String("reference", "", "Reference; defaults to the original invoice number")
+		notes := fs.String("notes", "", "Notes")
+		lines := creditNoteLineFlags{}
+		fs.Var(&lines, "line", "Credited line as original-line-id:quantity; repeatable, omit to credit everything remaining")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*invoiceID) == "" {
+			return errors.New("id is required")
+		}
+		issueDateValue, err := parseOptionalDate("issue-date", *issueDate)
+		if err != nil {
+			return err
+		}
+
+		req := &invoicing.CreateCreditNoteRequest{
+			Reference: strings.TrimSpace(*reference),
+			Notes:     strings.TrimSpace(*notes),
+			Lines:     []invoicing.CreateCreditNoteLineRequest(lines),
+		}
+		if issueDateValue != nil {
+			req.IssueDate = *issueDateValue
+		}
+		creditNote, err := client.createCreditNote(ctx, cfg.TenantID, strings.TrimSpace(*invoiceID), req)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, creditNote)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Created credit note %s (%s) for invoice %s\n", creditNote.InvoiceNumber, creditNote.ID, creditNote.OriginalInvoiceNumber)
+		return nil
+
 	case "import":
 		fs := flag.NewFlagSet("invoices import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
@@ -3585,6 +3834,35 @@ func (a *cliApp) runInvoices(ctx context.Context, args []string) error {
 		_, _ = fmt.Fprintf(a.stdout, "Processed %d e-invoices, created %d invoices, imported %d lines, skipped %d e-invoices\n", result.RowsProcessed, result.InvoicesCreated, result.LinesImported, result.RowsSkipped)
 		return nil
 
+	case "export-einvoice":
+		fs := flag.NewFlagSet("invoices export-einvoice", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		invoiceIDs := stringListFlags{}
+		fs.Var(&invoiceIDs, "id", "Sales invoice or credit note id; repeatable")
+		format := fs.String("format", "", "E-invoice format: EVS923 (default) or UBL for Peppol BIS Billing 3.0")
+		includePDF := fs.Bool("include-pdf", false, "Embed the invoice PDF in each e-invoice")
+		payToIBAN := fs.String("pay-to-iban", "", "Payee IBAN; defaults to the default bank account")
+		payToBIC := fs.String("pay-to-bic", "", "Payee BIC")
+		outputPath := fs.String("output", "", "Optional XML output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if len(invoiceIDs) == 0 {
+			return errors.New("at least one id is required")
+		}
+
+		content, err := client.exportEInvoice(ctx, cfg.TenantID, &invoicing.ExportEInvoiceRequest{
+			InvoiceIDs: invoiceIDs,
+			Format:     strings.ToUpper(strings.TrimSpace(*format)),
+			IncludePDF: *includePDF,
+			PayToIBAN:  strings.TrimSpace(*payToIBAN),
+			PayToBIC:   strings.TrimSpace(*payToBIC),
+		})
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "E-invoice XML")
+
 	default:
 		return fmt.Errorf("unknown invoices subcommand %q", args[0])
 	}
@@ -4466,6 +4744,115 @@ func (a *cliApp) runEmailSMTP(ctx context.Context, cfg *cliConfig, client *apiCl
 	}
 }
 
+func (a *cliApp) runDocumentTemplates(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("document-templates subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("document-templates list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		entries, err := client.listDocumentTemplates(ctx, cfg.TenantID)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entries)
+		}
+		printDocumentTemplatesTable(a.stdout, entries)
+		return nil
+	case "update":
+		fs := flag.NewFlagSet("document-templates update", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentTypeFlag := fs.String("type", "", "Document type: INVOICE, QUOTE, ORDER, REMINDER")
+		file := fs.String("file", "", "Template JSON file or '-'")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		documentType, err := parseRequiredDocumentType(*documentTypeFlag)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(*file) == "" {
+			return errors.New("file is required")
+		}
+		tmpl, err := readDocumentTemplateFile(*file)
+		if err != nil {
+			return err
+		}
+
+		entry, err := client.updateDocumentTemplate(ctx, cfg.TenantID, documentType, tmpl)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entry)
+		}
+		printDocumentTemplate(a.stdout, entry)
+		return nil
+	case "reset":
+		fs := flag.NewFlagSet("document-templates reset", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentTypeFlag := fs.String("type", "", "Document type: INVOICE, QUOTE, ORDER, REMINDER")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		documentType, err := parseRequiredDocumentType(*documentTypeFlag)
+		if err != nil {
+			return err
+		}
+
+		entry, err := client.resetDocumentTemplate(ctx, cfg.TenantID, documentType)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entry)
+		}
+		printDocumentTemplate(a.stdout, entry)
+		return nil
+	case "preview":
+		fs := flag.NewFlagSet("document-templates preview", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentTypeFlag := fs.String("type", "", "Document type: INVOICE, QUOTE, ORDER, REMINDER")
+		file := fs.String("file", "", "Draft template JSON file or '-'; defaults to the saved template")
+		output := fs.String("output", "", "Output PDF path; defaults to stdout")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		documentType, err := parseRequiredDocumentType(*documentTypeFlag)
+		if err != nil {
+			return err
+		}
+		req := &tenant.DocumentTemplatePreviewRequest{}
+		if strings.TrimSpace(*file) != "" {
+			if req.Template, err = readDocumentTemplateFile(*file); err != nil {
+				return err
+			}
+		}
+
+		content, err := client.previewDocumentTemplate(ctx, cfg.TenantID, documentType, req)
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, *output, content, "document template preview")
+	default:
+		return fmt.Errorf("unknown document-templates subcommand %q", args[0])
+	}
+}
+
 func (a *cliApp) runEmailTemplates(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
 		return errors.New("email templates subcommand required")
@@ -5765,6 +6152,10 @@ func (a *cliApp) runQuotes(ctx context.Context, args []string) error {
 	if len(args) == 0 {
 		return errors.New("quotes subcommand required")
 	}
+	switch args[0] {
+	case "link-view", "link-pdf", "link-accept", "link-reject":
+		return a.runPublicQuoteLink(ctx, args)
+	}
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
 		return err
@@ -6044,6 +6435,12 @@ func (a *cliApp) runQuotes(ctx context.Context, args []string) error {
 		issueDateFlag := fs.String("issue-date", "", "Invoice issue date in YYYY-MM-DD")
 		dueDateFlag := fs.String("due-date", "", "Invoice due date in YYYY-MM-DD")
 		notes := fs.String("notes", "", "Invoice notes")
+		kind := fs.String("kind", "", "Billing kind: QUANTITY, PERCENTAGE, or PREPAYMENT")
+		percent := fs.String("percent", "", "Percentage of each line, or of the subtotal for a prepayment")
+		amount := fs.String("amount", "", "Net prepayment amount")
+		vatRate := fs.String("vat-rate", "", "Prepayment VAT rate when lines have different rates")
+		lines := billingLineFlags{}
+		fs.Var(&lines, "line", "Line to bill as line_id=...,quantity=... (repeatable)")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
@@ -6052,6 +6449,10 @@ func (a *cliApp) runQuotes(ctx context.Context, args []string) error {
 		if trimmedQuoteID == "" {
 			return errors.New("id is required")
 		}
+		billing, err := parsePartialInvoiceRequest(*kind, *percent, *amount, *vatRate, lines)
+		if err != nil {
+			return err
+		}
 
 		var issueDate time.Time
 		if strings.TrimSpace(*issueDateFlag) != "" {
@@ -6069,9 +6470,10 @@ func (a *cliApp) runQuotes(ctx context.Context, args []string) error {
 		}
 
 		result, err := client.convertQuoteToInvoice(ctx, cfg.TenantID, trimmedQuoteID, &quotes.ConvertQuoteToInvoiceRequest{
-			IssueDate: issueDate,
-			DueDate:   dueDate,
-			Notes:     strings.TrimSpace(*notes),
+			IssueDate:             issueDate,
+			DueDate:               dueDate,
+			Notes:                 strings.TrimSpace(*notes),
+			PartialInvoiceRequest: billing,
 		})
 		if err != nil {
 			return err
@@ -6090,95 +6492,248 @@ func (a *cliApp) runQuotes(ctx context.Context, args []string) error {
 			invoiceNumber = result.Invoice.InvoiceNumber
 			invoiceID = result.Invoice.ID
 		}
+		if result.Quote != nil && result.Quote.ConvertedToInvoiceID == nil {
+			_, _ = fmt.Fprintf(a.stdout, "Invoiced part of quote %s on invoice %s (%s)\n", quoteNumber, invoiceNumber, invoiceID)
+			return nil
+		}
 		_, _ = fmt.Fprintf(a.stdout, "Converted quote %s to invoice %s (%s)\n", quoteNumber, invoiceNumber, invoiceID)
 		return nil
 
-	default:
-		return fmt.Errorf("unknown quotes subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runOrders(ctx context.Context, args []string) error {
-	if len(args) == 0 {
-		return errors.New("orders subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("orders list", flag.ContinueOnError)
+	case "invoices":
+		fs := flag.NewFlagSet("quotes invoices", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		statusFlag := fs.String("status", "", "Order status")
-		contactID := fs.String("contact-id", "", "Contact id")
-		fromDate := fs.String("from", "", "From order date in YYYY-MM-DD")
-		toDate := fs.String("to", "", "To order date in YYYY-MM-DD")
-		search := fs.String("search", "", "Search term")
+		quoteID := fs.String("id", "", "Quote id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*quoteID) == "" {
+			return errors.New("id is required")
+		}
 
-		status, err := parseOptionalOrderStatus(*statusFlag)
+		billings, err := client.listQuoteInvoices(ctx, cfg.TenantID, strings.TrimSpace(*quoteID))
 		if err != nil {
 			return err
 		}
-		fromDateValue, err := parseOptionalDate("from", *fromDate)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, billings)
 		}
-		toDateValue, err := parseOptionalDate("to", *toDate)
-		if err != nil {
+		printQuoteInvoices(a.stdout, billings)
+		return nil
+
+	case "revisions":
+		fs := flag.NewFlagSet("quotes revisions", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		quoteID := fs.String("id", "", "Quote id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*quoteID) == "" {
+			return errors.New("id is required")
+		}
 
-		ordersList, err := client.listOrders(ctx, cfg.TenantID, orders.OrderFilter{
-			Status:    status,
-			ContactID: strings.TrimSpace(*contactID),
-			FromDate:  fromDateValue,
-			ToDate:    toDateValue,
-			Search:    strings.TrimSpace(*search),
-		})
+		revisions, err := client.listQuoteRevisions(ctx, cfg.TenantID, strings.TrimSpace(*quoteID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, ordersList)
+			return printJSON(a.stdout, revisions)
 		}
-		printOrdersTable(a.stdout, ordersList)
+		printQuoteRevisions(a.stdout, revisions)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("orders create", flag.ContinueOnError)
+	case "revision":
+		fs := flag.NewFlagSet("quotes revision", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		contactID := fs.String("contact-id", "", "Contact id")
-		orderDate := fs.String("order-date", "", "Order date in YYYY-MM-DD")
-		expectedDelivery := fs.String("expected-delivery", "", "Expected delivery date in YYYY-MM-DD")
-		currency := fs.String("currency", "EUR", "Currency code")
-		exchangeRateFlag := fs.String("exchange-rate", "1", "Exchange rate to base currency")
-		notes := fs.String("notes", "", "Notes")
-		quoteID := fs.String("quote-id", "", "Source quote id")
-		lines := orderLineFlags{}
-		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		quoteID := fs.String("id", "", "Quote id")
+		revision := fs.Int("revision", 0, "Revision number")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*contactID) == "" {
-			return errors.New("contact-id is required")
+		if strings.TrimSpace(*quoteID) == "" {
+			return errors.New("id is required")
 		}
-		orderDateValue, err := parseRequiredDate("order-date", *orderDate)
-		if err != nil {
-			return err
+		if *revision < 1 {
+			return errors.New("revision must be positive")
 		}
-		expectedDeliveryValue, err := parseOptionalDate("expected-delivery", *expectedDelivery)
+
+		result, err := client.getQuoteRevision(ctx, cfg.TenantID, strings.TrimSpace(*quoteID), *revision)
 		if err != nil {
 			return err
 		}
-		if len(lines) == 0 {
-			return errors.New("at least one line is required")
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Revision %d sent %s\n", result.Revision, result.SentAt.Format(time.RFC3339))
+		if result.Quote != nil {
+			printQuote(a.stdout, result.Quote)
+		}
+		return nil
+
+	case "link":
+		fs := flag.NewFlagSet("quotes link", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		quoteID := fs.String("id", "", "Quote id")
+		expiresInDays := fs.Int("expires-in-days", 0, "Days until the link expires (default 14, at most 90)")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*quoteID) == "" {
+			return errors.New("id is required")
+		}
+
+		link, err := client.createQuoteLink(ctx, cfg.TenantID, strings.TrimSpace(*quoteID), &quotes.CreateQuoteLinkRequest{
+			ExpiresInDays: *expiresInDays,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, link)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Created link to revision %d of quote %s, expires %s\n", link.Revision, link.QuoteID, link.ExpiresAt.Format(time.RFC3339))
+		_, _ = fmt.Fprintf(a.stdout, "Token: %s\n", link.Token)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown quotes subcommand %q", args[0])
+	}
+}
+
+// runPublicQuoteLink runs the quote commands a customer can use with a link
+// token and no login
+func (a *cliApp) runPublicQuoteLink(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("quotes "+args[0], flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	token := fs.String("token", "", "Quote link token")
+	baseURL := fs.String("base-url", "", "API base URL; defaults to config or OA_BASE_URL")
+	outputPath := new(string)
+	asJSON := new(bool)
+	if args[0] == "link-pdf" {
+		outputPath = fs.String("output", "", "Optional output file path")
+	} else {
+		asJSON = fs.Bool("json", false, "Output JSON")
+	}
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*token) == "" {
+		return errors.New("token is required")
+	}
+	client, err := a.loadPublicClient(*baseURL)
+	if err != nil {
+		return err
+	}
+
+	var quote *quotes.PublicQuote
+	switch args[0] {
+	case "link-pdf":
+		content, err := client.downloadPublicQuotePDF(ctx, strings.TrimSpace(*token))
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "Quote PDF")
+	case "link-accept":
+		quote, err = client.answerPublicQuote(ctx, strings.TrimSpace(*token), "accept")
+	case "link-reject":
+		quote, err = client.answerPublicQuote(ctx, strings.TrimSpace(*token), "reject")
+	default:
+		quote, err = client.getPublicQuote(ctx, strings.TrimSpace(*token))
+	}
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(a.stdout, quote)
+	}
+	printPublicQuote(a.stdout, quote)
+	return nil
+}
+
+func (a *cliApp) runOrders(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("orders subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("orders list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		statusFlag := fs.String("status", "", "Order status")
+		contactID := fs.String("contact-id", "", "Contact id")
+		fromDate := fs.String("from", "", "From order date in YYYY-MM-DD")
+		toDate := fs.String("to", "", "To order date in YYYY-MM-DD")
+		search := fs.String("search", "", "Search term")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		status, err := parseOptionalOrderStatus(*statusFlag)
+		if err != nil {
+			return err
+		}
+		fromDateValue, err := parseOptionalDate("from", *fromDate)
+		if err != nil {
+			return err
+		}
+		toDateValue, err := parseOptionalDate("to", *toDate)
+		if err != nil {
+			return err
+		}
+
+		ordersList, err := client.listOrders(ctx, cfg.TenantID, orders.OrderFilter{
+			Status:    status,
+			ContactID: strings.TrimSpace(*contactID),
+			FromDate:  fromDateValue,
+			ToDate:    toDateValue,
+			Search:    strings.TrimSpace(*search),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, ordersList)
+		}
+		printOrdersTable(a.stdout, ordersList)
+		return nil
+
+	case "create":
+		fs := flag.NewFlagSet("orders create", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		contactID := fs.String("contact-id", "", "Contact id")
+		orderDate := fs.String("order-date", "", "Order date in YYYY-MM-DD")
+		expectedDelivery := fs.String("expected-delivery", "", "Expected delivery date in YYYY-MM-DD")
+		currency := fs.String("currency", "EUR", "Currency code")
+		exchangeRateFlag := fs.String("exchange-rate", "1", "Exchange rate to base currency")
+		notes := fs.String("notes", "", "Notes")
+		quoteID := fs.String("quote-id", "", "Source quote id")
+		lines := orderLineFlags{}
+		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*contactID) == "" {
+			return errors.New("contact-id is required")
+		}
+		orderDateValue, err := parseRequiredDate("order-date", *orderDate)
+		if err != nil {
+			return err
+		}
+		expectedDeliveryValue, err := parseOptionalDate("expected-delivery", *expectedDelivery)
+		if err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return errors.New("at least one line is required")
 		}
 		exchangeRate, err := parseRequiredPositiveDecimal("exchange-rate", *exchangeRateFlag)
 		if err != nil {
@@ -6457,7 +7012,85 @@ func (a *cliApp) runOrders(ctx context.Context, args []string) error {
 		_, _ = fmt.Fprintf(a.stdout, "Deleted order %s\n", strings.TrimSpace(*orderID))
 		return nil
 
-	case "confirm", "process", "ship", "deliver", "cancel":
+	case "ship":
+		fs := flag.NewFlagSet("orders ship", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		orderID := fs.String("id", "", "Order id")
+		warehouseID := fs.String("warehouse-id", "", "Shipping warehouse id (default the single reserved warehouse)")
+		cogsAccountID := fs.String("cogs-account-id", "", "Cost of goods sold EXPENSE account id (default product purchase account)")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory ASSET account id for products without one")
+		notes := fs.String("notes", "", "Delivery note remarks")
+		lines := shipmentLineFlags{}
+		fs.Var(&lines, "line", "Shipment line as comma-separated key=value pairs; repeatable (default all open quantities)")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*orderID) == "" {
+			return errors.New("id is required")
+		}
+
+		shipment, err := client.shipOrder(ctx, cfg.TenantID, strings.TrimSpace(*orderID), &orders.ShipOrderRequest{
+			WarehouseID:              strings.TrimSpace(*warehouseID),
+			CostOfGoodsSoldAccountID: strings.TrimSpace(*cogsAccountID),
+			InventoryAccountID:       strings.TrimSpace(*inventoryAccountID),
+			Notes:                    strings.TrimSpace(*notes),
+			Lines:                    []orders.ShipOrderLineRequest(lines),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, shipment)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Shipped order %s as %s (%s), cost of goods %s\n", strings.TrimSpace(*orderID), shipment.ShipmentNumber, shipment.ID, shipment.TotalCost.String())
+		return nil
+
+	case "shipments":
+		fs := flag.NewFlagSet("orders shipments", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		orderID := fs.String("id", "", "Order id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*orderID) == "" {
+			return errors.New("id is required")
+		}
+
+		shipments, err := client.listOrderShipments(ctx, cfg.TenantID, strings.TrimSpace(*orderID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, shipments)
+		}
+		printOrderShipments(a.stdout, shipments)
+		return nil
+
+	case "delivery-note":
+		fs := flag.NewFlagSet("orders delivery-note", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		orderID := fs.String("id", "", "Order id")
+		shipmentID := fs.String("shipment-id", "", "Shipment id")
+		outputPath := fs.String("output", "", "Optional output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*orderID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*shipmentID) == "" {
+			return errors.New("shipment-id is required")
+		}
+
+		content, err := client.downloadOrderDeliveryNote(ctx, cfg.TenantID, strings.TrimSpace(*orderID), strings.TrimSpace(*shipmentID))
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "Delivery note PDF")
+
+	case "confirm", "process", "deliver", "cancel":
 		fs := flag.NewFlagSet("orders "+args[0], flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
 		orderID := fs.String("id", "", "Order id")
@@ -6490,6 +7123,12 @@ func (a *cliApp) runOrders(ctx context.Context, args []string) error {
 		issueDateFlag := fs.String("issue-date", "", "Invoice issue date in YYYY-MM-DD")
 		dueDateFlag := fs.String("due-date", "", "Invoice due date in YYYY-MM-DD")
 		notes := fs.String("notes", "", "Invoice notes")
+		kind := fs.String("kind", "", "Billing kind: QUANTITY, PERCENTAGE, or PREPAYMENT")
+		percent := fs.String("percent", "", "Percentage of each line, or of the subtotal for a prepayment")
+		amount := fs.String("amount", "", "Net prepayment amount")
+		vatRate := fs.String("vat-rate", "", "Prepayment VAT rate when lines have different rates")
+		lines := billingLineFlags{}
+		fs.Var(&lines, "line", "Line to bill as line_id=...,quantity=... (repeatable)")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
@@ -6498,6 +7137,10 @@ func (a *cliApp) runOrders(ctx context.Context, args []string) error {
 		if trimmedOrderID == "" {
 			return errors.New("id is required")
 		}
+		billing, err := parsePartialInvoiceRequest(*kind, *percent, *amount, *vatRate, lines)
+		if err != nil {
+			return err
+		}
 
 		var issueDate time.Time
 		if strings.TrimSpace(*issueDateFlag) != "" {
@@ -6515,9 +7158,10 @@ func (a *cliApp) runOrders(ctx context.Context, args []string) error {
 		}
 
 		result, err := client.convertOrderToInvoice(ctx, cfg.TenantID, trimmedOrderID, &orders.ConvertOrderToInvoiceRequest{
-			IssueDate: issueDate,
-			DueDate:   dueDate,
-			Notes:     strings.TrimSpace(*notes),
+			IssueDate:             issueDate,
+			DueDate:               dueDate,
+			Notes:                 strings.TrimSpace(*notes),
+			PartialInvoiceRequest: billing,
 		})
 		if err != nil {
 			return err
@@ -6536,17 +7180,43 @@ func (a *cliApp) runOrders(ctx context.Context, args []string) error {
 			invoiceNumber = result.Invoice.InvoiceNumber
 			invoiceID = result.Invoice.ID
 		}
+		if result.Order != nil && result.Order.ConvertedToInvoiceID == nil {
+			_, _ = fmt.Fprintf(a.stdout, "Invoiced part of order %s on invoice %s (%s)\n", orderNumber, invoiceNumber, invoiceID)
+			return nil
+		}
 		_, _ = fmt.Fprintf(a.stdout, "Converted order %s to invoice %s (%s)\n", orderNumber, invoiceNumber, invoiceID)
 		return nil
 
+	case "invoices":
+		fs := flag.NewFlagSet("orders invoices", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		orderID := fs.String("id", "", "Order id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*orderID) == "" {
+			return errors.New("id is required")
+		}
+
+		billings, err := client.listOrderInvoices(ctx, cfg.TenantID, strings.TrimSpace(*orderID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, billings)
+		}
+		printOrderInvoices(a.stdout, billings)
+		return nil
+
 	default:
 		return fmt.Errorf("unknown orders subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runRecurringInvoices(ctx context.Context, args []string) error {
+func (a *cliApp) runPurchaseOrders(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("recurring-invoices subcommand required")
+		return errors.New("purchase-orders subcommand required")
 	}
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
@@ -6555,400 +7225,389 @@ func (a *cliApp) runRecurringInvoices(ctx context.Context, args []string) error
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("recurring-invoices list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("purchase-orders list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		activeOnly := fs.Bool("active-only", false, "List only active recurring invoices")
+		statusFlag := fs.String("status", "", "Purchase order status")
+		contactID := fs.String("contact-id", "", "Supplier contact id")
+		fromDate := fs.String("from", "", "From order date in YYYY-MM-DD")
+		toDate := fs.String("to", "", "To order date in YYYY-MM-DD")
+		search := fs.String("search", "", "Search term")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		invoices, err := client.listRecurringInvoices(ctx, cfg.TenantID, *activeOnly)
+		status, err := parseOptionalPurchaseOrderStatus(*statusFlag)
+		if err != nil {
+			return err
+		}
+		fromDateValue, err := parseOptionalDate("from", *fromDate)
+		if err != nil {
+			return err
+		}
+		toDateValue, err := parseOptionalDate("to", *toDate)
+		if err != nil {
+			return err
+		}
+
+		orderList, err := client.listPurchaseOrders(ctx, cfg.TenantID, purchasing.PurchaseOrderFilter{
+			Status:    status,
+			ContactID: strings.TrimSpace(*contactID),
+			FromDate:  fromDateValue,
+			ToDate:    toDateValue,
+			Search:    strings.TrimSpace(*search),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, invoices)
+			return printJSON(a.stdout, orderList)
 		}
-		printRecurringInvoicesTable(a.stdout, invoices)
+		printPurchaseOrdersTable(a.stdout, orderList)
 		return nil
 
 	case "create":
-		fs := flag.NewFlagSet("recurring-invoices create", flag.ContinueOnError)
+		fs := flag.NewFlagSet("purchase-orders create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		name := fs.String("name", "", "Recurring invoice name")
-		contactID := fs.String("contact-id", "", "Contact id")
-		invoiceTypeFlag := fs.String("type", "SALES", "Invoice type")
+		contactID := fs.String("contact-id", "", "Supplier contact id")
+		warehouseID := fs.String("warehouse-id", "", "Receiving warehouse id")
+		orderDate := fs.String("order-date", "", "Order date in YYYY-MM-DD")
+		expectedDate := fs.String("expected-date", "", "Expected delivery date in YYYY-MM-DD")
 		currency := fs.String("currency", "EUR", "Currency code")
-		frequencyFlag := fs.String("frequency", "", "Frequency")
-		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
-		paymentTermsDaysFlag := fs.String("payment-terms-days", "14", "Payment terms in days")
-		reference := fs.String("reference", "", "Reference")
+		exchangeRateFlag := fs.String("exchange-rate", "1", "Exchange rate to base currency")
 		notes := fs.String("notes", "", "Notes")
-		sendEmail := fs.Bool("send-email", false, "Send generated invoices by email")
-		emailTemplateType := fs.String("email-template-type", "", "Email template type")
-		recipientEmail := fs.String("recipient-email", "", "Recipient email override")
-		attachPDF := fs.Bool("attach-pdf", true, "Attach generated invoice PDF to email")
-		emailSubject := fs.String("email-subject", "", "Email subject override")
-		emailMessage := fs.String("email-message", "", "Email message override")
-		lines := recurringLineFlags{}
+		lines := purchaseOrderLineFlags{}
 		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
-		}
 		if strings.TrimSpace(*contactID) == "" {
 			return errors.New("contact-id is required")
 		}
-		invoiceType, err := parseRequiredInvoiceType(*invoiceTypeFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
 		}
-		frequency, err := parseRequiredRecurringFrequency(*frequencyFlag)
+		orderDateValue, err := parseRequiredDate("order-date", *orderDate)
 		if err != nil {
 			return err
 		}
-		startDateValue, err := parseRequiredDate("start-date", *startDate)
+		expectedDateValue, err := parseOptionalDate("expected-date", *expectedDate)
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseOptionalDate("end-date", *endDate)
-		if err != nil {
-			return err
+		if len(lines) == 0 {
+			return errors.New("at least one line is required")
 		}
-		paymentTermsDays, err := parseRequiredNonNegativeInt("payment-terms-days", *paymentTermsDaysFlag)
+		exchangeRate, err := parseRequiredPositiveDecimal("exchange-rate", *exchangeRateFlag)
 		if err != nil {
 			return err
 		}
-		if len(lines) == 0 {
-			return errors.New("at least one line is required")
-		}
 
-		attachPDFValue := *attachPDF
-		invoice, err := client.createRecurringInvoice(ctx, cfg.TenantID, &recurring.CreateRecurringInvoiceRequest{
-			Name:                   strings.TrimSpace(*name),
-			ContactID:              strings.TrimSpace(*contactID),
-			InvoiceType:            string(invoiceType),
-			Currency:               strings.ToUpper(strings.TrimSpace(*currency)),
-			Frequency:              frequency,
-			StartDate:              startDateValue,
-			EndDate:                endDateValue,
-			PaymentTermsDays:       paymentTermsDays,
-			Reference:              strings.TrimSpace(*reference),
-			Notes:                  strings.TrimSpace(*notes),
-			Lines:                  []recurring.CreateRecurringInvoiceLineRequest(lines),
-			SendEmailOnGeneration:  *sendEmail,
-			EmailTemplateType:      strings.TrimSpace(*emailTemplateType),
-			RecipientEmailOverride: strings.TrimSpace(*recipientEmail),
-			AttachPDFToEmail:       &attachPDFValue,
-			EmailSubjectOverride:   strings.TrimSpace(*emailSubject),
-			EmailMessage:           strings.TrimSpace(*emailMessage),
+		po, err := client.createPurchaseOrder(ctx, cfg.TenantID, &purchasing.CreatePurchaseOrderRequest{
+			ContactID:    strings.TrimSpace(*contactID),
+			WarehouseID:  strings.TrimSpace(*warehouseID),
+			OrderDate:    orderDateValue,
+			ExpectedDate: expectedDateValue,
+			Currency:     strings.ToUpper(strings.TrimSpace(*currency)),
+			ExchangeRate: exchangeRate,
+			Notes:        strings.TrimSpace(*notes),
+			Lines:        []purchasing.CreatePurchaseOrderLineRequest(lines),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, invoice)
+			return printJSON(a.stdout, po)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created recurring invoice %s (%s)\n", invoice.Name, invoice.ID)
+		_, _ = fmt.Fprintf(a.stdout, "Created purchase order %s (%s)\n", po.PONumber, po.ID)
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("recurring-invoices import", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("purchase-orders get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		poID := fs.String("id", "", "Purchase order id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*poID) == "" {
+			return errors.New("id is required")
 		}
 
-		content, fileName, err := readCSVInput(*filePath)
+		po, err := client.getPurchaseOrder(ctx, cfg.TenantID, strings.TrimSpace(*poID))
 		if err != nil {
 			return err
 		}
-		result, err := client.importRecurringInvoices(ctx, cfg.TenantID, &recurring.ImportRecurringInvoicesRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		if *asJSON {
+			return printJSON(a.stdout, po)
+		}
+		printPurchaseOrder(a.stdout, po)
+		return nil
+
+	case "approve", "cancel":
+		fs := flag.NewFlagSet("purchase-orders "+args[0], flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		poID := fs.String("id", "", "Purchase order id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*poID) == "" {
+			return errors.New("id is required")
+		}
+
+		result, err := client.updatePurchaseOrderStatus(ctx, cfg.TenantID, strings.TrimSpace(*poID), args[0])
 		if err != nil {
 			return err
 		}
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d recurring invoices, imported %d lines, skipped %d rows\n", result.RowsProcessed, result.TemplatesCreated, result.LinesImported, result.RowsSkipped)
+		_, _ = fmt.Fprintf(a.stdout, "Purchase order %s is %s\n", strings.TrimSpace(*poID), result["status"])
 		return nil
 
-	case "from-invoice":
-		fs := flag.NewFlagSet("recurring-invoices from-invoice", flag.ContinueOnError)
+	case "receive":
+		fs := flag.NewFlagSet("purchase-orders receive", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		invoiceID := fs.String("invoice-id", "", "Source invoice id")
-		name := fs.String("name", "", "Recurring invoice name")
-		frequencyFlag := fs.String("frequency", "", "Frequency")
-		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
-		paymentTermsDaysFlag := fs.String("payment-terms-days", "14", "Payment terms in days")
+		poID := fs.String("id", "", "Purchase order id")
+		receiptDate := fs.String("receipt-date", "", "Receipt date in YYYY-MM-DD (default today)")
+		warehouseID := fs.String("warehouse-id", "", "Receiving warehouse id (default purchase order warehouse)")
+		accrualAccountID := fs.String("accrual-account-id", "", "Goods received not invoiced LIABILITY account id")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory ASSET account id for products without one")
+		supplierReference := fs.String("supplier-reference", "", "Supplier delivery note reference")
+		notes := fs.String("notes", "", "Notes")
+		lines := goodsReceiptLineFlags{}
+		fs.Var(&lines, "line", "Receipt line as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*invoiceID) == "" {
-			return errors.New("invoice-id is required")
+		if strings.TrimSpace(*poID) == "" {
+			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
+		if strings.TrimSpace(*accrualAccountID) == "" {
+			return errors.New("accrual-account-id is required")
 		}
-		frequency, err := parseRequiredRecurringFrequency(*frequencyFlag)
-		if err != nil {
-			return err
+		if len(lines) == 0 {
+			return errors.New("at least one line is required")
 		}
-		startDateValue, err := parseRequiredDate("start-date", *startDate)
+		receiptDateValue, err := parseOptionalDate("receipt-date", *receiptDate)
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseOptionalDate("end-date", *endDate)
-		if err != nil {
-			return err
+		req := &purchasing.ReceiveGoodsRequest{
+			WarehouseID:        strings.TrimSpace(*warehouseID),
+			AccrualAccountID:   strings.TrimSpace(*accrualAccountID),
+			InventoryAccountID: strings.TrimSpace(*inventoryAccountID),
+			SupplierReference:  strings.TrimSpace(*supplierReference),
+			Notes:              strings.TrimSpace(*notes),
+			Lines:              []purchasing.ReceiveGoodsLineRequest(lines),
 		}
-		paymentTermsDays, err := parseRequiredNonNegativeInt("payment-terms-days", *paymentTermsDaysFlag)
-		if err != nil {
-			return err
+		if receiptDateValue != nil {
+			req.ReceiptDate = *receiptDateValue
 		}
 
-		invoice, err := client.createRecurringInvoiceFromInvoice(ctx, cfg.TenantID, strings.TrimSpace(*invoiceID), &recurring.CreateFromInvoiceRequest{
-			Name:             strings.TrimSpace(*name),
-			Frequency:        frequency,
-			StartDate:        startDateValue,
-			EndDate:          endDateValue,
-			PaymentTermsDays: paymentTermsDays,
-		})
+		receipt, err := client.receivePurchaseOrderGoods(ctx, cfg.TenantID, strings.TrimSpace(*poID), req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, invoice)
+			return printJSON(a.stdout, receipt)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created recurring invoice %s (%s) from invoice %s\n", invoice.Name, invoice.ID, strings.TrimSpace(*invoiceID))
+		_, _ = fmt.Fprintf(a.stdout, "Received goods %s (%s), total cost %s\n", receipt.ReceiptNumber, receipt.ID, receipt.TotalCost.String())
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("recurring-invoices get", flag.ContinueOnError)
+	case "match-invoice":
+		fs := flag.NewFlagSet("purchase-orders match-invoice", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recurringID := fs.String("id", "", "Recurring invoice id")
+		poID := fs.String("id", "", "Purchase order id")
+		invoiceID := fs.String("invoice-id", "", "Purchase invoice id")
+		accrualAccountID := fs.String("accrual-account-id", "", "Goods received not invoiced LIABILITY account id")
+		payableAccountID := fs.String("payable-account-id", "", "Accounts payable LIABILITY account id")
+		vatAccountID := fs.String("vat-account-id", "", "Input VAT ASSET account id")
+		varianceAccountID := fs.String("price-variance-account-id", "", "Purchase price variance EXPENSE account id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recurringID) == "" {
+		if strings.TrimSpace(*poID) == "" {
 			return errors.New("id is required")
 		}
+		if strings.TrimSpace(*invoiceID) == "" {
+			return errors.New("invoice-id is required")
+		}
+		if strings.TrimSpace(*accrualAccountID) == "" {
+			return errors.New("accrual-account-id is required")
+		}
+		if strings.TrimSpace(*payableAccountID) == "" {
+			return errors.New("payable-account-id is required")
+		}
 
-		invoice, err := client.getRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID))
+		match, err := client.matchPurchaseOrderInvoice(ctx, cfg.TenantID, strings.TrimSpace(*poID), &purchasing.MatchInvoiceRequest{
+			InvoiceID:              strings.TrimSpace(*invoiceID),
+			AccrualAccountID:       strings.TrimSpace(*accrualAccountID),
+			PayableAccountID:       strings.TrimSpace(*payableAccountID),
+			VATAccountID:           strings.TrimSpace(*vatAccountID),
+			PriceVarianceAccountID: strings.TrimSpace(*varianceAccountID),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, invoice)
+			return printJSON(a.stdout, match)
 		}
-		printRecurringInvoice(a.stdout, invoice)
+		_, _ = fmt.Fprintf(a.stdout, "Matched invoice %s: accrual cleared %s, price variance %s, payable %s\n", match.InvoiceID, match.AccrualCleared.String(), match.PriceVariance.String(), match.PayableAmount.String())
 		return nil
 
-	case "update":
-		fs := flag.NewFlagSet("recurring-invoices update", flag.ContinueOnError)
+	case "matching":
+		fs := flag.NewFlagSet("purchase-orders matching", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recurringID := fs.String("id", "", "Recurring invoice id")
-		name := fs.String("name", "", "Recurring invoice name")
-		contactID := fs.String("contact-id", "", "Contact id")
-		frequencyFlag := fs.String("frequency", "", "Frequency")
-		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
-		paymentTermsDaysFlag := fs.String("payment-terms-days", "", "Payment terms in days")
-		reference := fs.String("reference", "", "Reference")
-		notes := fs.String("notes", "", "Notes")
-		sendEmailFlag := fs.String("send-email", "", "Send generated invoices by email: true or false")
-		emailTemplateType := fs.String("email-template-type", "", "Email template type")
-		recipientEmail := fs.String("recipient-email", "", "Recipient email override")
-		attachPDFFlag := fs.String("attach-pdf", "", "Attach generated invoice PDF to email: true or false")
-		emailSubject := fs.String("email-subject", "", "Email subject override")
-		emailMessage := fs.String("email-message", "", "Email message override")
-		lines := recurringLineFlags{}
-		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		poID := fs.String("id", "", "Purchase order id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recurringID) == "" {
+		if strings.TrimSpace(*poID) == "" {
 			return errors.New("id is required")
 		}
 
-		req := &recurring.UpdateRecurringInvoiceRequest{}
-		if trimmed := strings.TrimSpace(*name); trimmed != "" {
-			req.Name = &trimmed
-		}
-		if trimmed := strings.TrimSpace(*contactID); trimmed != "" {
-			req.ContactID = &trimmed
-		}
-		if strings.TrimSpace(*frequencyFlag) != "" {
-			frequency, err := parseRequiredRecurringFrequency(*frequencyFlag)
-			if err != nil {
-				return err
-			}
-			req.Frequency = &frequency
-		}
-		if strings.TrimSpace(*endDate) != "" {
-			endDateValue, err := parseRequiredDate("end-date", *endDate)
-			if err != nil {
-				return err
-			}
-			req.EndDate = &endDateValue
-		}
-		if strings.TrimSpace(*paymentTermsDaysFlag) != "" {
-			paymentTermsDays, err := parseRequiredNonNegativeInt("payment-terms-days", *paymentTermsDaysFlag)
-			if err != nil {
-				return err
-			}
-			req.PaymentTermsDays = &paymentTermsDays
-		}
-		if trimmed := strings.TrimSpace(*reference); trimmed != "" {
-			req.Reference = &trimmed
-		}
-		if trimmed := strings.TrimSpace(*notes); trimmed != "" {
-			req.Notes = &trimmed
-		}
-		sendEmailValue, err := parseOptionalBoolPtr("send-email", *sendEmailFlag)
-		if err != nil {
-			return err
-		}
-		req.SendEmailOnGeneration = sendEmailValue
-		if trimmed := strings.TrimSpace(*emailTemplateType); trimmed != "" {
-			req.EmailTemplateType = &trimmed
-		}
-		if trimmed := strings.TrimSpace(*recipientEmail); trimmed != "" {
-			req.RecipientEmailOverride = &trimmed
-		}
-		attachPDFValue, err := parseOptionalBoolPtr("attach-pdf", *attachPDFFlag)
-		if err != nil {
-			return err
-		}
-		req.AttachPDFToEmail = attachPDFValue
-		if trimmed := strings.TrimSpace(*emailSubject); trimmed != "" {
-			req.EmailSubjectOverride = &trimmed
-		}
-		if trimmed := strings.TrimSpace(*emailMessage); trimmed != "" {
-			req.EmailMessage = &trimmed
-		}
-		if len(lines) > 0 {
-			req.Lines = []recurring.CreateRecurringInvoiceLineRequest(lines)
-		}
-
-		invoice, err := client.updateRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), req)
+		report, err := client.getPurchaseOrderMatching(ctx, cfg.TenantID, strings.TrimSpace(*poID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, invoice)
+			return printJSON(a.stdout, report)
 		}
-		printRecurringInvoice(a.stdout, invoice)
+		printPurchaseOrderMatching(a.stdout, report)
 		return nil
 
-	case "delete":
-		fs := flag.NewFlagSet("recurring-invoices delete", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown purchase-orders subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runLandedCosts(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("landed-costs subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("landed-costs list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recurringID := fs.String("id", "", "Recurring invoice id")
+		receiptID := fs.String("receipt-id", "", "Goods receipt id")
+		fromDate := fs.String("from", "", "From cost date in YYYY-MM-DD")
+		toDate := fs.String("to", "", "To cost date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recurringID) == "" {
-			return errors.New("id is required")
+		fromDateValue, err := parseOptionalDate("from", *fromDate)
+		if err != nil {
+			return err
+		}
+		toDateValue, err := parseOptionalDate("to", *toDate)
+		if err != nil {
+			return err
 		}
 
-		result, err := client.deleteRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID))
+		landedCosts, err := client.listLandedCosts(ctx, cfg.TenantID, purchasing.LandedCostFilter{
+			GoodsReceiptID: strings.TrimSpace(*receiptID),
+			FromDate:       fromDateValue,
+			ToDate:         toDateValue,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, landedCosts)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted recurring invoice %s\n", strings.TrimSpace(*recurringID))
+		printLandedCostsTable(a.stdout, landedCosts)
 		return nil
 
-	case "pause", "resume":
-		fs := flag.NewFlagSet("recurring-invoices "+args[0], flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		recurringID := fs.String("id", "", "Recurring invoice id")
+	case "allocate":
+		fs := flag.NewFlagSet("landed-costs allocate", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		invoiceID := fs.String("invoice-id", "", "Freight, duty or broker purchase invoice id")
+		method := fs.String("method", "VALUE", "Allocation method: VALUE, QUANTITY or WEIGHT")
+		payableAccountID := fs.String("payable-account-id", "", "Accounts payable LIABILITY account id")
+		vatAccountID := fs.String("vat-account-id", "", "Input VAT ASSET account id")
+		cogsAccountID := fs.String("cogs-account-id", "", "Cost of goods sold EXPENSE account id (default product purchase account)")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory ASSET account id for products without one")
+		notes := fs.String("notes", "", "Notes")
+		receiptIDs := stringListFlags{}
+		fs.Var(&receiptIDs, "receipt-id", "Goods receipt id to allocate onto; repeatable")
+		lines := landedCostTargetFlags{}
+		fs.Var(&lines, "line", "Receipt line or lot target as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recurringID) == "" {
-			return errors.New("id is required")
+		if strings.TrimSpace(*invoiceID) == "" {
+			return errors.New("invoice-id is required")
+		}
+		if strings.TrimSpace(*payableAccountID) == "" {
+			return errors.New("payable-account-id is required")
+		}
+		if len(receiptIDs) == 0 && len(lines) == 0 {
+			return errors.New("at least one receipt-id or line is required")
 		}
 
-		result, err := client.updateRecurringInvoiceStatus(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), args[0])
+		landedCost, err := client.allocateLandedCost(ctx, cfg.TenantID, &purchasing.AllocateLandedCostRequest{
+			InvoiceID:                strings.TrimSpace(*invoiceID),
+			AllocationMethod:         purchasing.LandedCostAllocationMethod(strings.ToUpper(strings.TrimSpace(*method))),
+			GoodsReceiptIDs:          []string(receiptIDs),
+			Lines:                    []purchasing.LandedCostTargetRequest(lines),
+			PayableAccountID:         strings.TrimSpace(*payableAccountID),
+			VATAccountID:             strings.TrimSpace(*vatAccountID),
+			CostOfGoodsSoldAccountID: strings.TrimSpace(*cogsAccountID),
+			InventoryAccountID:       strings.TrimSpace(*inventoryAccountID),
+			Notes:                    strings.TrimSpace(*notes),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, landedCost)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "%s recurring invoice %s\n", titleLabel(args[0])+"d", strings.TrimSpace(*recurringID))
+		_, _ = fmt.Fprintf(a.stdout, "Allocated landed cost %s (%s): inventory %s, cost of goods sold %s\n", landedCost.LandedCostNumber, landedCost.ID, landedCost.InventoryAmount.String(), landedCost.COGSAmount.String())
 		return nil
 
-	case "generate":
-		fs := flag.NewFlagSet("recurring-invoices generate", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("landed-costs get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recurringID := fs.String("id", "", "Recurring invoice id")
+		landedCostID := fs.String("id", "", "Landed cost id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recurringID) == "" {
+		if strings.TrimSpace(*landedCostID) == "" {
 			return errors.New("id is required")
 		}
 
-		result, err := client.generateRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID))
+		landedCost, err := client.getLandedCost(ctx, cfg.TenantID, strings.TrimSpace(*landedCostID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, landedCost)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Generated invoice %s (%s) from recurring invoice %s\n", result.GeneratedInvoiceNumber, result.GeneratedInvoiceID, result.RecurringInvoiceID)
-		return nil
-
-	case "generate-due":
-		fs := flag.NewFlagSet("recurring-invoices generate-due", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-
-		results, err := client.generateDueRecurringInvoices(ctx, cfg.TenantID)
-		if err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, results)
-		}
-		printRecurringGenerationResultsTable(a.stdout, results)
+		printLandedCost(a.stdout, landedCost)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown recurring-invoices subcommand %q", args[0])
+		return fmt.Errorf("unknown landed-costs subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runExpenses(ctx context.Context, args []string) error {
+func (a *cliApp) runPriceLists(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("expenses subcommand required")
+		return errors.New("price-lists subcommand required")
 	}
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
@@ -6957,1157 +7616,1215 @@ func (a *cliApp) runExpenses(ctx context.Context, args []string) error {
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("expenses list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("price-lists list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		statusFlag := fs.String("status", "", "Expense status")
-		limit := fs.Int("limit", 100, "Maximum expenses to return")
+		currency := fs.String("currency", "", "Currency code")
+		activeOnly := fs.Bool("active-only", false, "List only active price lists")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		status, err := parseOptionalExpenseStatus(*statusFlag)
-		if err != nil {
-			return err
-		}
 
-		expenseList, err := client.listExpenses(ctx, cfg.TenantID, expenses.ListExpensesFilter{
-			Status: status,
-			Limit:  *limit,
+		priceLists, err := client.listPriceLists(ctx, cfg.TenantID, pricing.PriceListFilter{
+			Currency:   strings.ToUpper(strings.TrimSpace(*currency)),
+			ActiveOnly: *activeOnly,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, expenseList)
+			return printJSON(a.stdout, priceLists)
 		}
-		printExpensesTable(a.stdout, expenseList)
+		printPriceListsTable(a.stdout, priceLists)
 		return nil
 
 	case "create":
-		fs := flag.NewFlagSet("expenses create", flag.ContinueOnError)
+		fs := flag.NewFlagSet("price-lists create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		merchant := fs.String("merchant", "", "Merchant")
-		description := fs.String("description", "", "Description")
-		expenseDate := fs.String("expense-date", "", "Expense date in YYYY-MM-DD")
-		employeeID := fs.String("employee-id", "", "Employee id")
-		contactID := fs.String("contact-id", "", "Supplier/contact id")
-		expenseAccountID := fs.String("expense-account-id", "", "Expense account id")
-		paymentAccountID := fs.String("payment-account-id", "", "Payment or reimbursement account id")
-		amountFlag := fs.String("amount", "", "Expense amount")
+		code := fs.String("code", "", "Price list code")
+		name := fs.String("name", "", "Price list name")
 		currency := fs.String("currency", "EUR", "Currency code")
-		exchangeRateFlag := fs.String("exchange-rate", "1", "Exchange rate to base currency")
-		requiresReceipt := fs.Bool("requires-receipt", true, "Require an approved receipt before approval/posting")
+		validFrom := fs.String("valid-from", "", "First valid date in YYYY-MM-DD")
+		validTo := fs.String("valid-to", "", "Last valid date in YYYY-MM-DD")
+		isDefault := fs.Bool("default", false, "Use as the default list for customers without their own")
+		notes := fs.String("notes", "", "Notes")
+		items := priceListItemFlags{}
+		fs.Var(&items, "item", "Item as comma-separated key=value pairs (product_id, min_quantity, unit_price); repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*merchant) == "" {
-			return errors.New("merchant is required")
-		}
-		if strings.TrimSpace(*expenseAccountID) == "" {
-			return errors.New("expense-account-id is required")
-		}
-		if strings.TrimSpace(*paymentAccountID) == "" {
-			return errors.New("payment-account-id is required")
+		if strings.TrimSpace(*code) == "" {
+			return errors.New("code is required")
 		}
-		expenseDateValue, err := parseRequiredDate("expense-date", *expenseDate)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
-		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
+		validFromValue, err := parseOptionalDate("valid-from", *validFrom)
 		if err != nil {
 			return err
 		}
-		exchangeRate, err := parseRequiredPositiveDecimal("exchange-rate", *exchangeRateFlag)
+		validToValue, err := parseOptionalDate("valid-to", *validTo)
 		if err != nil {
 			return err
 		}
 
-		expense, err := client.createExpense(ctx, cfg.TenantID, &expenses.CreateExpenseRequest{
-			ExpenseDate:      expenseDateValue,
-			Merchant:         strings.TrimSpace(*merchant),
-			Description:      strings.TrimSpace(*description),
-			EmployeeID:       optionalStringPtr(*employeeID),
-			ContactID:        optionalStringPtr(*contactID),
-			ExpenseAccountID: strings.TrimSpace(*expenseAccountID),
-			PaymentAccountID: strings.TrimSpace(*paymentAccountID),
-			Amount:           amount,
-			Currency:         strings.ToUpper(strings.TrimSpace(*currency)),
-			ExchangeRate:     exchangeRate,
-			RequiresReceipt:  requiresReceipt,
+		priceList, err := client.createPriceList(ctx, cfg.TenantID, &pricing.CreatePriceListRequest{
+			Code:      strings.TrimSpace(*code),
+			Name:      strings.TrimSpace(*name),
+			Currency:  strings.ToUpper(strings.TrimSpace(*currency)),
+			ValidFrom: validFromValue,
+			ValidTo:   validToValue,
+			IsDefault: *isDefault,
+			Notes:     strings.TrimSpace(*notes),
+			Items:     []pricing.PriceListItemRequest(items),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, expense)
+			return printJSON(a.stdout, priceList)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created expense %s (%s)\n", expense.ExpenseNumber, expense.ID)
+		_, _ = fmt.Fprintf(a.stdout, "Created price list %s (%s) with %d items\n", priceList.Code, priceList.ID, len(priceList.Items))
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("expenses import", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("price-lists get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		priceListID := fs.String("id", "", "Price list id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
-		}
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*priceListID) == "" {
+			return errors.New("id is required")
 		}
-		result, err := client.importExpenses(ctx, cfg.TenantID, &expenses.ImportExpensesRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+
+		priceList, err := client.getPriceList(ctx, cfg.TenantID, strings.TrimSpace(*priceListID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, priceList)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d expenses, skipped %d rows\n", result.RowsProcessed, result.ExpensesCreated, result.RowsSkipped)
+		printPriceList(a.stdout, priceList)
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("expenses get", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		expenseID := fs.String("id", "", "Expense id")
+	case "update":
+		fs := flag.NewFlagSet("price-lists update", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		priceListID := fs.String("id", "", "Price list id")
+		name := fs.String("name", "", "Price list name")
+		currency := fs.String("currency", "", "Currency code")
+		validFrom := fs.String("valid-from", "", "First valid date in YYYY-MM-DD")
+		validTo := fs.String("valid-to", "", "Last valid date in YYYY-MM-DD")
+		isDefault := fs.String("default", "", "Set default state: true or false")
+		active := fs.String("active", "", "Set active state: true or false")
+		notes := fs.String("notes", "", "Notes")
+		items := priceListItemFlags{}
+		fs.Var(&items, "item", "Replacement item as comma-separated key=value pairs; repeatable, omit to keep current items")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*expenseID) == "" {
+		if strings.TrimSpace(*priceListID) == "" {
 			return errors.New("id is required")
 		}
-
-		expense, err := client.getExpense(ctx, cfg.TenantID, strings.TrimSpace(*expenseID))
+		validFromValue, err := parseOptionalDate("valid-from", *validFrom)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, expense)
+		validToValue, err := parseOptionalDate("valid-to", *validTo)
+		if err != nil {
+			return err
+		}
+		defaultValue, err := parseOptionalBoolPtr("default", *isDefault)
+		if err != nil {
+			return err
+		}
+		activeValue, err := parseOptionalBoolPtr("active", *active)
+		if err != nil {
+			return err
 		}
-		printExpense(a.stdout, expense)
-		return nil
 
-	case "submit", "approve", "post":
-		fs := flag.NewFlagSet("expenses "+args[0], flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		expenseID := fs.String("id", "", "Expense id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		existing, err := client.getPriceList(ctx, cfg.TenantID, strings.TrimSpace(*priceListID))
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*expenseID) == "" {
-			return errors.New("id is required")
+		req := &pricing.UpdatePriceListRequest{
+			Name:      firstNonEmpty(strings.TrimSpace(*name), existing.Name),
+			Currency:  firstNonEmpty(strings.ToUpper(strings.TrimSpace(*currency)), existing.Currency),
+			ValidFrom: existing.ValidFrom,
+			ValidTo:   existing.ValidTo,
+			IsDefault: existing.IsDefault,
+			IsActive:  activeValue,
+			Notes:     firstNonEmpty(strings.TrimSpace(*notes), existing.Notes),
+			Items:     []pricing.PriceListItemRequest(items),
+		}
+		if validFromValue != nil {
+			req.ValidFrom = validFromValue
+		}
+		if validToValue != nil {
+			req.ValidTo = validToValue
+		}
+		if defaultValue != nil {
+			req.IsDefault = *defaultValue
+		}
+		if len(items) == 0 {
+			req.Items = make([]pricing.PriceListItemRequest, 0, len(existing.Items))
+			for _, item := range existing.Items {
+				req.Items = append(req.Items, pricing.PriceListItemRequest{
+					ProductID:   item.ProductID,
+					MinQuantity: item.MinQuantity,
+					UnitPrice:   item.UnitPrice,
+				})
+			}
 		}
 
-		expense, err := client.updateExpenseStatus(ctx, cfg.TenantID, strings.TrimSpace(*expenseID), args[0])
+		priceList, err := client.updatePriceList(ctx, cfg.TenantID, existing.ID, req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, expense)
+			return printJSON(a.stdout, priceList)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "%s expense %s\n", expenseActionPastTense(args[0]), strings.TrimSpace(*expenseID))
+		printPriceList(a.stdout, priceList)
 		return nil
 
-	case "reject":
-		fs := flag.NewFlagSet("expenses reject", flag.ContinueOnError)
+	case "resolve":
+		fs := flag.NewFlagSet("price-lists resolve", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		expenseID := fs.String("id", "", "Expense id")
-		reason := fs.String("reason", "", "Rejection reason")
+		productID := fs.String("product-id", "", "Product id")
+		contactID := fs.String("contact-id", "", "Customer contact id")
+		currency := fs.String("currency", "", "Currency code (default EUR)")
+		quantity := fs.String("quantity", "", "Quantity (default 1)")
+		date := fs.String("date", "", "Pricing date in YYYY-MM-DD (default today)")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*expenseID) == "" {
-			return errors.New("id is required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
 		}
-		if strings.TrimSpace(*reason) == "" {
-			return errors.New("reason is required")
+		quantityValue, err := parseOptionalNonNegativeDecimalPtr("quantity", *quantity)
+		if err != nil {
+			return err
+		}
+		dateValue, err := parseOptionalDate("date", *date)
+		if err != nil {
+			return err
 		}
 
-		expense, err := client.rejectExpense(ctx, cfg.TenantID, strings.TrimSpace(*expenseID), &expenses.RejectExpenseRequest{Reason: strings.TrimSpace(*reason)})
+		resolved, err := client.resolvePrice(ctx, cfg.TenantID, strings.TrimSpace(*productID), strings.TrimSpace(*contactID), strings.ToUpper(strings.TrimSpace(*currency)), quantityValue, dateValue)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, expense)
+			return printJSON(a.stdout, resolved)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Rejected expense %s\n", strings.TrimSpace(*expenseID))
+		printResolvedPrice(a.stdout, resolved)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown expenses subcommand %q", args[0])
+		return fmt.Errorf("unknown price-lists subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runAssets(ctx context.Context, args []string) error {
+func (a *cliApp) runCustomerGroups(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("assets subcommand required")
+		return errors.New("customer-groups subcommand required")
 	}
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
 		return err
 	}
-	if args[0] == "categories" {
-		return a.runAssetCategories(ctx, cfg, client, args[1:])
-	}
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("assets list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("customer-groups list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		statusFlag := fs.String("status", "", "Asset status")
-		categoryID := fs.String("category-id", "", "Category id")
-		search := fs.String("search", "", "Search term")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		status, err := parseOptionalAssetStatus(*statusFlag)
-		if err != nil {
-			return err
-		}
 
-		assetList, err := client.listAssets(ctx, cfg.TenantID, assets.AssetFilter{
-			Status:     status,
-			CategoryID: strings.TrimSpace(*categoryID),
-			Search:     strings.TrimSpace(*search),
-		})
+		groups, err := client.listCustomerGroups(ctx, cfg.TenantID)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, assetList)
+			return printJSON(a.stdout, groups)
 		}
-		printAssetsTable(a.stdout, assetList)
+		printCustomerGroupsTable(a.stdout, groups)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("assets create", flag.ContinueOnError)
+	case "create", "update":
+		fs := flag.NewFlagSet("customer-groups "+args[0], flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		name := fs.String("name", "", "Asset name")
-		description := fs.String("description", "", "Description")
-		categoryID := fs.String("category-id", "", "Category id")
-		purchaseDate := fs.String("purchase-date", "", "Purchase date in YYYY-MM-DD")
-		purchaseCostFlag := fs.String("purchase-cost", "", "Purchase cost")
-		supplierID := fs.String("supplier-id", "", "Supplier id")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		location := fs.String("location", "", "Location")
-		depreciationMethodFlag := fs.String("depreciation-method", "STRAIGHT_LINE", "Depreciation method")
-		usefulLifeMonthsFlag := fs.String("useful-life-months", "60", "Useful life in months")
-		residualValueFlag := fs.String("residual-value", "0", "Residual value")
-		depreciationStartDate := fs.String("depreciation-start-date", "", "Depreciation start date in YYYY-MM-DD")
-		assetAccountID := fs.String("asset-account-id", "", "Asset account id")
-		depreciationExpenseAccountID := fs.String("depreciation-expense-account-id", "", "Depreciation expense account id")
-		accumulatedDepreciationAccountID := fs.String("accumulated-depreciation-account-id", "", "Accumulated depreciation account id")
+		groupID := fs.String("id", "", "Customer group id (update only)")
+		code := fs.String("code", "", "Customer group code")
+		name := fs.String("name", "", "Customer group name")
+		priceListID := fs.String("price-list-id", "", "Price list id for group members")
+		discountPercent := fs.String("discount-percent", "", "Line discount percent for group members")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if args[0] == "update" && strings.TrimSpace(*groupID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*code) == "" {
+			return errors.New("code is required")
+		}
 		if strings.TrimSpace(*name) == "" {
 			return errors.New("name is required")
 		}
-		purchaseDateValue, err := parseRequiredDate("purchase-date", *purchaseDate)
+		discount, err := parseOptionalNonNegativeDecimalPtr("discount-percent", *discountPercent)
 		if err != nil {
 			return err
 		}
-		purchaseCost, err := parseRequiredPositiveDecimal("purchase-cost", *purchaseCostFlag)
-		if err != nil {
-			return err
+
+		req := &pricing.CustomerGroupRequest{
+			Code:            strings.TrimSpace(*code),
+			Name:            strings.TrimSpace(*name),
+			PriceListID:     optionalStringPtr(*priceListID),
+			DiscountPercent: decimalValueOrZero(discount),
 		}
-		depreciationMethod, err := parseOptionalDepreciationMethod(*depreciationMethodFlag)
-		if err != nil {
-			return err
+		var group *pricing.CustomerGroup
+		if args[0] == "create" {
+			group, err = client.createCustomerGroup(ctx, cfg.TenantID, req)
+		} else {
+			group, err = client.updateCustomerGroup(ctx, cfg.TenantID, strings.TrimSpace(*groupID), req)
 		}
-		usefulLifeMonths, err := parseRequiredPositiveInt("useful-life-months", *usefulLifeMonthsFlag)
 		if err != nil {
 			return err
 		}
-		residualValue, err := parseRequiredNonNegativeDecimal("residual-value", *residualValueFlag)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, group)
 		}
-		depreciationStartDateValue, err := parseOptionalDate("depreciation-start-date", *depreciationStartDate)
-		if err != nil {
+		_, _ = fmt.Fprintf(a.stdout, "Saved customer group %s (%s)\n", group.Code, group.ID)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown customer-groups subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runRecurringInvoices(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("recurring-invoices subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("recurring-invoices list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		activeOnly := fs.Bool("active-only", false, "List only active recurring invoices")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		asset, err := client.createAsset(ctx, cfg.TenantID, &assets.CreateAssetRequest{
-			Name:                          strings.TrimSpace(*name),
-			Description:                   strings.TrimSpace(*description),
-			CategoryID:                    optionalStringPtr(*categoryID),
-			PurchaseDate:                  purchaseDateValue,
-			PurchaseCost:                  purchaseCost,
-			SupplierID:                    optionalStringPtr(*supplierID),
-			SerialNumber:                  strings.TrimSpace(*serialNumber),
-			Location:                      strings.TrimSpace(*location),
-			DepreciationMethod:            depreciationMethod,
-			UsefulLifeMonths:              usefulLifeMonths,
-			ResidualValue:                 residualValue,
-			DepreciationStartDate:         depreciationStartDateValue,
-			AssetAccountID:                optionalStringPtr(*assetAccountID),
-			DepreciationExpenseAccountID:  optionalStringPtr(*depreciationExpenseAccountID),
-			AccumulatedDepreciationAcctID: optionalStringPtr(*accumulatedDepreciationAccountID),
-		})
+		invoices, err := client.listRecurringInvoices(ctx, cfg.TenantID, *activeOnly)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, asset)
+			return printJSON(a.stdout, invoices)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created asset %s (%s)\n", asset.AssetNumber, asset.ID)
+		printRecurringInvoicesTable(a.stdout, invoices)
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("assets import", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("recurring-invoices create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		name := fs.String("name", "", "Recurring invoice name")
+		contactID := fs.String("contact-id", "", "Contact id")
+		invoiceTypeFlag := fs.String("type", "SALES", "Invoice type")
+		currency := fs.String("currency", "EUR", "Currency code")
+		frequencyFlag := fs.String("frequency", "", "Frequency")
+		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
+		billingAnchorDate := fs.String("billing-anchor-date", "", "Start of the first full billing period in YYYY-MM-DD; the first invoice is prorated up to it")
+		paymentTermsDaysFlag := fs.String("payment-terms-days", "14", "Payment terms in days")
+		reference := fs.String("reference", "", "Reference")
+		notes := fs.String("notes", "", "Notes")
+		sendEmail := fs.Bool("send-email", false, "Send generated invoices by email")
+		emailTemplateType := fs.String("email-template-type", "", "Email template type")
+		recipientEmail := fs.String("recipient-email", "", "Recipient email override")
+		attachPDF := fs.Bool("attach-pdf", true, "Attach generated invoice PDF to email")
+		emailSubject := fs.String("email-subject", "", "Email subject override")
+		emailMessage := fs.String("email-message", "", "Email message override")
+		lines := recurringLineFlags{}
+		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
-
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*contactID) == "" {
+			return errors.New("contact-id is required")
 		}
-		result, err := client.importAssets(ctx, cfg.TenantID, &assets.ImportAssetsRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		invoiceType, err := parseRequiredInvoiceType(*invoiceTypeFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d assets, skipped %d rows\n", result.RowsProcessed, result.AssetsCreated, result.RowsSkipped)
-		return nil
-
-	case "get":
-		fs := flag.NewFlagSet("assets get", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-		if strings.TrimSpace(*assetID) == "" {
-			return errors.New("id is required")
-		}
-
-		asset, err := client.getAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
+		frequency, err := parseRequiredRecurringFrequency(*frequencyFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, asset)
-		}
-		printAsset(a.stdout, asset)
-		return nil
-
-	case "update":
-		fs := flag.NewFlagSet("assets update", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
-		name := fs.String("name", "", "Asset name")
-		description := fs.String("description", "", "Description")
-		categoryID := fs.String("category-id", "", "Category id")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		location := fs.String("location", "", "Location")
-		depreciationMethodFlag := fs.String("depreciation-method", "STRAIGHT_LINE", "Depreciation method")
-		usefulLifeMonthsFlag := fs.String("useful-life-months", "60", "Useful life in months")
-		residualValueFlag := fs.String("residual-value", "0", "Residual value")
-		assetAccountID := fs.String("asset-account-id", "", "Asset account id")
-		depreciationExpenseAccountID := fs.String("depreciation-expense-account-id", "", "Depreciation expense account id")
-		accumulatedDepreciationAccountID := fs.String("accumulated-depreciation-account-id", "", "Accumulated depreciation account id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		startDateValue, err := parseRequiredDate("start-date", *startDate)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*assetID) == "" {
-			return errors.New("id is required")
-		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
-		}
-		depreciationMethod, err := parseOptionalDepreciationMethod(*depreciationMethodFlag)
+		endDateValue, err := parseOptionalDate("end-date", *endDate)
 		if err != nil {
 			return err
 		}
-		usefulLifeMonths, err := parseRequiredPositiveInt("useful-life-months", *usefulLifeMonthsFlag)
+		billingAnchorDateValue, err := parseOptionalDate("billing-anchor-date", *billingAnchorDate)
 		if err != nil {
 			return err
 		}
-		residualValue, err := parseRequiredNonNegativeDecimal("residual-value", *residualValueFlag)
+		paymentTermsDays, err := parseRequiredNonNegativeInt("payment-terms-days", *paymentTermsDaysFlag)
 		if err != nil {
 			return err
 		}
+		if len(lines) == 0 {
+			return errors.New("at least one line is required")
+		}
 
-		asset, err := client.updateAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID), &assets.UpdateAssetRequest{
-			Name:                          strings.TrimSpace(*name),
-			Description:                   strings.TrimSpace(*description),
-			CategoryID:                    optionalStringPtr(*categoryID),
-			SerialNumber:                  strings.TrimSpace(*serialNumber),
-			Location:                      strings.TrimSpace(*location),
-			DepreciationMethod:            depreciationMethod,
-			UsefulLifeMonths:              usefulLifeMonths,
-			ResidualValue:                 residualValue,
-			AssetAccountID:                optionalStringPtr(*assetAccountID),
-			DepreciationExpenseAccountID:  optionalStringPtr(*depreciationExpenseAccountID),
-			AccumulatedDepreciationAcctID: optionalStringPtr(*accumulatedDepreciationAccountID),
+		attachPDFValue := *attachPDF
+		invoice, err := client.createRecurringInvoice(ctx, cfg.TenantID, &recurring.CreateRecurringInvoiceRequest{
+			Name:                   strings.TrimSpace(*name),
+			ContactID:              strings.TrimSpace(*contactID),
+			InvoiceType:            string(invoiceType),
+			Currency:               strings.ToUpper(strings.TrimSpace(*currency)),
+			Frequency:              frequency,
+			StartDate:              startDateValue,
+			EndDate:                endDateValue,
+			BillingAnchorDate:      billingAnchorDateValue,
+			PaymentTermsDays:       paymentTermsDays,
+			Reference:              strings.TrimSpace(*reference),
+			Notes:                  strings.TrimSpace(*notes),
+			Lines:                  []recurring.CreateRecurringInvoiceLineRequest(lines),
+			SendEmailOnGeneration:  *sendEmail,
+			EmailTemplateType:      strings.TrimSpace(*emailTemplateType),
+			RecipientEmailOverride: strings.TrimSpace(*recipientEmail),
+			AttachPDFToEmail:       &attachPDFValue,
+			EmailSubjectOverride:   strings.TrimSpace(*emailSubject),
+			EmailMessage:           strings.TrimSpace(*emailMessage),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, asset)
+			return printJSON(a.stdout, invoice)
 		}
-		printAsset(a.stdout, asset)
+		_, _ = fmt.Fprintf(a.stdout, "Created recurring invoice %s (%s)\n", invoice.Name, invoice.ID)
 		return nil
 
-	case "delete":
-		fs := flag.NewFlagSet("assets delete", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("recurring-invoices import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
+		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*assetID) == "" {
-			return errors.New("id is required")
-		}
-
-		if err := client.deleteAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID)); err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, map[string]string{"status": "deleted"})
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted asset %s\n", strings.TrimSpace(*assetID))
-		return nil
 
-	case "activate":
-		fs := flag.NewFlagSet("assets activate", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*assetID) == "" {
-			return errors.New("id is required")
-		}
-
-		result, err := client.activateAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
+		result, err := client.importRecurringInvoices(ctx, cfg.TenantID, &recurring.ImportRecurringInvoicesRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Activated asset %s\n", strings.TrimSpace(*assetID))
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d recurring invoices, imported %d lines, skipped %d rows\n", result.RowsProcessed, result.TemplatesCreated, result.LinesImported, result.RowsSkipped)
 		return nil
 
-	case "dispose":
-		fs := flag.NewFlagSet("assets dispose", flag.ContinueOnError)
+	case "from-invoice":
+		fs := flag.NewFlagSet("recurring-invoices from-invoice", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
-		disposalDate := fs.String("disposal-date", "", "Disposal date in YYYY-MM-DD")
-		methodFlag := fs.String("method", "", "Disposal method")
-		proceedsFlag := fs.String("proceeds", "0", "Disposal proceeds")
-		proceedsAccountID := fs.String("proceeds-account-id", "", "Asset account receiving disposal proceeds")
-		gainLossAccountID := fs.String("gain-loss-account-id", "", "Revenue or expense account for disposal gain/loss")
-		notes := fs.String("notes", "", "Disposal notes")
+		invoiceID := fs.String("invoice-id", "", "Source invoice id")
+		name := fs.String("name", "", "Recurring invoice name")
+		frequencyFlag := fs.String("frequency", "", "Frequency")
+		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
+		paymentTermsDaysFlag := fs.String("payment-terms-days", "14", "Payment terms in days")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*assetID) == "" {
-			return errors.New("id is required")
+		if strings.TrimSpace(*invoiceID) == "" {
+			return errors.New("invoice-id is required")
 		}
-		disposalDateValue, err := parseRequiredDate("disposal-date", *disposalDate)
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
+		}
+		frequency, err := parseRequiredRecurringFrequency(*frequencyFlag)
 		if err != nil {
 			return err
 		}
-		method, err := parseRequiredDisposalMethod(*methodFlag)
+		startDateValue, err := parseRequiredDate("start-date", *startDate)
 		if err != nil {
 			return err
 		}
-		proceeds, err := parseRequiredNonNegativeDecimal("proceeds", *proceedsFlag)
+		endDateValue, err := parseOptionalDate("end-date", *endDate)
+		if err != nil {
+			return err
+		}
+		paymentTermsDays, err := parseRequiredNonNegativeInt("payment-terms-days", *paymentTermsDaysFlag)
 		if err != nil {
 			return err
 		}
 
-		result, err := client.disposeAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID), &assets.DisposeAssetRequest{
-			DisposalDate:              disposalDateValue,
-			DisposalMethod:            method,
-			DisposalProceeds:          proceeds,
-			DisposalNotes:             strings.TrimSpace(*notes),
-			DisposalProceedsAccountID: optionalStringPtr(*proceedsAccountID),
-			DisposalGainLossAccountID: optionalStringPtr(*gainLossAccountID),
+		invoice, err := client.createRecurringInvoiceFromInvoice(ctx, cfg.TenantID, strings.TrimSpace(*invoiceID), &recurring.CreateFromInvoiceRequest{
+			Name:             strings.TrimSpace(*name),
+			Frequency:        frequency,
+			StartDate:        startDateValue,
+			EndDate:          endDateValue,
+			PaymentTermsDays: paymentTermsDays,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, invoice)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Disposed asset %s\n", strings.TrimSpace(*assetID))
+		_, _ = fmt.Fprintf(a.stdout, "Created recurring invoice %s (%s) from invoice %s\n", invoice.Name, invoice.ID, strings.TrimSpace(*invoiceID))
 		return nil
 
-	case "depreciate":
-		fs := flag.NewFlagSet("assets depreciate", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("recurring-invoices get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
+		recurringID := fs.String("id", "", "Recurring invoice id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*assetID) == "" {
+		if strings.TrimSpace(*recurringID) == "" {
 			return errors.New("id is required")
 		}
 
-		entry, err := client.recordAssetDepreciation(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
+		invoice, err := client.getRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, entry)
+			return printJSON(a.stdout, invoice)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Recorded depreciation %s for asset %s\n", entry.DepreciationAmount.String(), strings.TrimSpace(*assetID))
+		printRecurringInvoice(a.stdout, invoice)
 		return nil
 
-	case "depreciation":
-		fs := flag.NewFlagSet("assets depreciation", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		assetID := fs.String("id", "", "Asset id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-		if strings.TrimSpace(*assetID) == "" {
-			return errors.New("id is required")
-		}
-
-		entries, err := client.listAssetDepreciation(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
-		if err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, entries)
-		}
-		printDepreciationEntriesTable(a.stdout, entries)
-		return nil
-
-	default:
-		return fmt.Errorf("unknown assets subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runAssetCategories(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
-	if len(args) == 0 {
-		return errors.New("assets categories subcommand required")
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("assets categories list", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("recurring-invoices update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		name := fs.String("name", "", "Recurring invoice name")
+		contactID := fs.String("contact-id", "", "Contact id")
+		frequencyFlag := fs.String("frequency", "", "Frequency")
+		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
+		paymentTermsDaysFlag := fs.String("payment-terms-days", "", "Payment terms in days")
+		reference := fs.String("reference", "", "Reference")
+		notes := fs.String("notes", "", "Notes")
+		sendEmailFlag := fs.String("send-email", "", "Send generated invoices by email: true or false")
+		emailTemplateType := fs.String("email-template-type", "", "Email template type")
+		recipientEmail := fs.String("recipient-email", "", "Recipient email override")
+		attachPDFFlag := fs.String("attach-pdf", "", "Attach generated invoice PDF to email: true or false")
+		emailSubject := fs.String("email-subject", "", "Email subject override")
+		emailMessage := fs.String("email-message", "", "Email message override")
+		lines := recurringLineFlags{}
+		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
+		}
 
-		categories, err := client.listAssetCategories(ctx, cfg.TenantID)
+		req := &recurring.UpdateRecurringInvoiceRequest{}
+		if trimmed := strings.TrimSpace(*name); trimmed != "" {
+			req.Name = &trimmed
+		}
+		if trimmed := strings.TrimSpace(*contactID); trimmed != "" {
+			req.ContactID = &trimmed
+		}
+		if strings.TrimSpace(*frequencyFlag) != "" {
+			frequency, err := parseRequiredRecurringFrequency(*frequencyFlag)
+			if err != nil {
+				return err
+			}
+			req.Frequency = &frequency
+		}
+		if strings.TrimSpace(*endDate) != "" {
+			endDateValue, err := parseRequiredDate("end-date", *endDate)
+			if err != nil {
+				return err
+			}
+			req.EndDate = &endDateValue
+		}
+		if strings.TrimSpace(*paymentTermsDaysFlag) != "" {
+			paymentTermsDays, err := parseRequiredNonNegativeInt("payment-terms-days", *paymentTermsDaysFlag)
+			if err != nil {
+				return err
+			}
+			req.PaymentTermsDays = &paymentTermsDays
+		}
+		if trimmed := strings.TrimSpace(*reference); trimmed != "" {
+			req.Reference = &trimmed
+		}
+		if trimmed := strings.TrimSpace(*notes); trimmed != "" {
+			req.Notes = &trimmed
+		}
+		sendEmailValue, err := parseOptionalBoolPtr("send-email", *sendEmailFlag)
+		if err != nil {
+			return err
+		}
+		req.SendEmailOnGeneration = sendEmailValue
+		if trimmed := strings.TrimSpace(*emailTemplateType); trimmed != "" {
+			req.EmailTemplateType = &trimmed
+		}
+		if trimmed := strings.TrimSpace(*recipientEmail); trimmed != "" {
+			req.RecipientEmailOverride = &trimmed
+		}
+		attachPDFValue, err := parseOptionalBoolPtr("attach-pdf", *attachPDFFlag)
+		if err != nil {
+			return err
+		}
+		req.AttachPDFToEmail = attachPDFValue
+		if trimmed := strings.TrimSpace(*emailSubject); trimmed != "" {
+			req.EmailSubjectOverride = &trimmed
+		}
+		if trimmed := strings.TrimSpace(*emailMessage); trimmed != "" {
+			req.EmailMessage = &trimmed
+		}
+		if len(lines) > 0 {
+			req.Lines = []recurring.CreateRecurringInvoiceLineRequest(lines)
+		}
+
+		invoice, err := client.updateRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, categories)
+			return printJSON(a.stdout, invoice)
 		}
-		printAssetCategoriesTable(a.stdout, categories)
+		printRecurringInvoice(a.stdout, invoice)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("assets categories create", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("recurring-invoices delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		name := fs.String("name", "", "Category name")
-		description := fs.String("description", "", "Description")
-		depreciationMethodFlag := fs.String("depreciation-method", "STRAIGHT_LINE", "Depreciation method")
-		usefulLifeMonthsFlag := fs.String("useful-life-months", "60", "Default useful life in months")
-		residualPercentFlag := fs.String("residual-percent", "0", "Default residual value percentage")
-		assetAccountID := fs.String("asset-account-id", "", "Asset account id")
-		depreciationExpenseAccountID := fs.String("depreciation-expense-account-id", "", "Depreciation expense account id")
-		accumulatedDepreciationAccountID := fs.String("accumulated-depreciation-account-id", "", "Accumulated depreciation account id")
+		recurringID := fs.String("id", "", "Recurring invoice id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
 		}
-		depreciationMethod, err := parseOptionalDepreciationMethod(*depreciationMethodFlag)
+
+		result, err := client.deleteRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID))
 		if err != nil {
 			return err
 		}
-		usefulLifeMonths, err := parseRequiredPositiveInt("useful-life-months", *usefulLifeMonthsFlag)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, result)
 		}
-		residualPercent, err := parseRequiredNonNegativeDecimal("residual-percent", *residualPercentFlag)
-		if err != nil {
+		_, _ = fmt.Fprintf(a.stdout, "Deleted recurring invoice %s\n", strings.TrimSpace(*recurringID))
+		return nil
+
+	case "pause", "resume":
+		fs := flag.NewFlagSet("recurring-invoices "+args[0], flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
+		}
 
-		category, err := client.createAssetCategory(ctx, cfg.TenantID, &assets.CreateCategoryRequest{
-			Name:                          strings.TrimSpace(*name),
-			Description:                   strings.TrimSpace(*description),
-			DepreciationMethod:            depreciationMethod,
-			DefaultUsefulLifeMonths:       usefulLifeMonths,
-			DefaultResidualValuePercent:   residualPercent,
-			AssetAccountID:                optionalStringPtr(*assetAccountID),
-			DepreciationExpenseAccountID:  optionalStringPtr(*depreciationExpenseAccountID),
-			AccumulatedDepreciationAcctID: optionalStringPtr(*accumulatedDepreciationAccountID),
-		})
+		result, err := client.updateRecurringInvoiceStatus(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), args[0])
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, category)
+			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created asset category %s (%s)\n", category.Name, category.ID)
+		_, _ = fmt.Fprintf(a.stdout, "%s recurring invoice %s\n", titleLabel(args[0])+"d", strings.TrimSpace(*recurringID))
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("assets categories get", flag.ContinueOnError)
+	case "generate":
+		fs := flag.NewFlagSet("recurring-invoices generate", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		categoryID := fs.String("id", "", "Category id")
+		recurringID := fs.String("id", "", "Recurring invoice id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*categoryID) == "" {
+		if strings.TrimSpace(*recurringID) == "" {
 			return errors.New("id is required")
 		}
 
-		category, err := client.getAssetCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID))
+		result, err := client.generateRecurringInvoice(ctx, cfg.TenantID, strings.TrimSpace(*recurringID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, category)
+			return printJSON(a.stdout, result)
 		}
-		printAssetCategory(a.stdout, category)
+		if result.Skipped {
+			_, _ = fmt.Fprintf(a.stdout, "Nothing to bill for recurring invoice %s this period\n", result.RecurringInvoiceID)
+			return nil
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Generated invoice %s (%s) from recurring invoice %s\n", result.GeneratedInvoiceNumber, result.GeneratedInvoiceID, result.RecurringInvoiceID)
 		return nil
 
-	case "delete":
-		fs := flag.NewFlagSet("assets categories delete", flag.ContinueOnError)
+	case "generate-due":
+		fs := flag.NewFlagSet("recurring-invoices generate-due", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		categoryID := fs.String("id", "", "Category id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*categoryID) == "" {
-			return errors.New("id is required")
-		}
 
-		if err := client.deleteAssetCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID)); err != nil {
+		results, err := client.generateDueRecurringInvoices(ctx, cfg.TenantID)
+		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, map[string]string{"status": "deleted"})
+			return printJSON(a.stdout, results)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted asset category %s\n", strings.TrimSpace(*categoryID))
+		printRecurringGenerationResultsTable(a.stdout, results)
 		return nil
 
+	case "usage":
+		return a.runRecurringUsage(ctx, cfg, client, args[1:])
+
+	case "price-changes":
+		return a.runRecurringPriceChanges(ctx, cfg, client, args[1:])
+
 	default:
-		return fmt.Errorf("unknown assets categories subcommand %q", args[0])
+		return fmt.Errorf("unknown recurring-invoices subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runInventory(ctx context.Context, args []string) error {
+func (a *cliApp) runRecurringUsage(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("inventory subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
+		return errors.New("recurring-invoices usage subcommand required")
 	}
 
 	switch args[0] {
-	case "categories":
-		return a.runInventoryCategories(ctx, cfg, client, args[1:])
-	case "products":
-		return a.runInventoryProducts(ctx, cfg, client, args[1:])
-	case "warehouses":
-		return a.runInventoryWarehouses(ctx, cfg, client, args[1:])
-	case "stock":
-		return a.runInventoryStock(ctx, cfg, client, args[1:])
-	case "valuation":
-		fs := flag.NewFlagSet("inventory valuation", flag.ContinueOnError)
+	case "list":
+		fs := flag.NewFlagSet("recurring-invoices usage list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		method := fs.String("method", "", "Valuation method: standard-cost, weighted-average, or fifo")
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		unbilled := fs.Bool("unbilled", false, "List only usage not yet invoiced")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
+		}
 
-		report, err := client.getInventoryValuation(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID), strings.TrimSpace(*method))
+		records, err := client.listRecurringUsage(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), *unbilled)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, records)
 		}
-		printInventoryValuation(a.stdout, report)
+		printRecurringUsageTable(a.stdout, records)
 		return nil
-	case "subledger-reconciliation":
-		fs := flag.NewFlagSet("inventory subledger-reconciliation", flag.ContinueOnError)
+
+	case "add":
+		fs := flag.NewFlagSet("recurring-invoices usage add", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		method := fs.String("method", "", "Valuation method: standard-cost, weighted-average, or fifo")
-		asOf := fs.String("as-of", "", "GL balance date in YYYY-MM-DD")
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		metric := fs.String("metric", "", "Usage metric of the line")
+		usageDate := fs.String("date", "", "Usage date in YYYY-MM-DD")
+		quantityFlag := fs.String("quantity", "", "Used quantity")
+		reference := fs.String("reference", "", "Meter reading or source reference")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if _, err := parseOptionalDate("as-of", *asOf); err != nil {
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*metric) == "" {
+			return errors.New("metric is required")
+		}
+		usageDateValue, err := parseRequiredDate("date", *usageDate)
+		if err != nil {
+			return err
+		}
+		quantity, err := parseRequiredNonNegativeDecimal("quantity", *quantityFlag)
+		if err != nil {
 			return err
 		}
 
-		report, err := client.getInventorySubledgerReconciliation(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID), strings.TrimSpace(*method), strings.TrimSpace(*asOf))
+		records, err := client.recordRecurringUsage(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), &recurring.RecordUsageRequest{
+			Records: []recurring.UsageRecordInput{{
+				Metric:    strings.TrimSpace(*metric),
+				UsageDate: usageDateValue,
+				Quantity:  quantity,
+				Reference: strings.TrimSpace(*reference),
+			}},
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, records)
 		}
-		printInventorySubledgerReconciliation(a.stdout, report)
+		printRecurringUsageTable(a.stdout, records)
 		return nil
-	case "lots":
-		fs := flag.NewFlagSet("inventory lots", flag.ContinueOnError)
+
+	case "delete":
+		fs := flag.NewFlagSet("recurring-invoices usage delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("product-id", "", "Product id")
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		includeEmpty := fs.Bool("include-empty", false, "Include zero or negative lot positions")
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		usageID := fs.String("usage-id", "", "Usage record id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*usageID) == "" {
+			return errors.New("usage-id is required")
+		}
 
-		report, err := client.getInventoryLotReport(ctx, cfg.TenantID, strings.TrimSpace(*productID), strings.TrimSpace(*warehouseID), *includeEmpty)
+		result, err := client.deleteRecurringUsage(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), strings.TrimSpace(*usageID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, result)
 		}
-		printInventoryLotReport(a.stdout, report)
+		_, _ = fmt.Fprintf(a.stdout, "Deleted usage record %s\n", strings.TrimSpace(*usageID))
 		return nil
-	case "adjust":
-		fs := flag.NewFlagSet("inventory adjust", flag.ContinueOnError)
+
+	case "import":
+		fs := flag.NewFlagSet("recurring-invoices usage import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("product-id", "", "Product id")
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		quantityFlag := fs.String("quantity", "", "Signed quantity adjustment")
-		unitCostFlag := fs.String("unit-cost", "0", "Unit cost")
-		lotNumber := fs.String("lot-number", "", "Lot or batch number")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		expiryDate := fs.String("expiry-date", "", "Lot expiry date in YYYY-MM-DD")
-		reason := fs.String("reason", "", "Adjustment reason")
+		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
-			return errors.New("product-id is required")
-		}
-		if strings.TrimSpace(*warehouseID) == "" {
-			return errors.New("warehouse-id is required")
-		}
-		quantity, err := parseRequiredDecimal("quantity", *quantityFlag)
-		if err != nil {
-			return err
-		}
-		if quantity.IsZero() {
-			return errors.New("quantity must not be zero")
-		}
-		unitCost, err := parseRequiredNonNegativeDecimal("unit-cost", *unitCostFlag)
-		if err != nil {
-			return err
-		}
-		if _, err := parseOptionalDate("expiry-date", *expiryDate); err != nil {
-			return err
-		}
-		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
 		}
-		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
+
+		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-
-		movement, err := client.adjustStock(ctx, cfg.TenantID, &inventory.AdjustStockRequest{
-			ProductID:    productIDValue,
-			WarehouseID:  warehouseIDValue,
-			Quantity:     quantity.String(),
-			UnitCost:     unitCost.String(),
-			LotNumber:    strings.TrimSpace(*lotNumber),
-			SerialNumber: strings.TrimSpace(*serialNumber),
-			ExpiryDate:   strings.TrimSpace(*expiryDate),
-			Reason:       strings.TrimSpace(*reason),
+		result, err := client.importRecurringUsage(ctx, cfg.TenantID, &recurring.ImportUsageRequest{
+			FileName:   fileName,
+			CSVContent: content,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, movement)
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, recorded %d usage records, skipped %d rows\n", result.RowsProcessed, result.RecordsCreated, result.RowsSkipped)
+		for _, rowErr := range result.Errors {
+			_, _ = fmt.Fprintf(a.stdout, "Row %d: %s\n", rowErr.Row, rowErr.Message)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Adjusted stock for product %s by %s in warehouse %s\n", productIDValue, quantity.String(), warehouseIDValue)
 		return nil
 
-	case "issue":
-		fs := flag.NewFlagSet("inventory issue", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown recurring-invoices usage subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runRecurringPriceChanges(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("recurring-invoices price-changes subcommand required")
+	}
+
+	switch args[0] {
+	case "add":
+		fs := flag.NewFlagSet("recurring-invoices price-changes add", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("product-id", "", "Product id")
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		quantityFlag := fs.String("quantity", "", "Quantity to issue")
-		costingMethod := fs.String("costing-method", "", "Costing method: lot, weighted-average, or standard-cost")
-		lotNumber := fs.String("lot-number", "", "Lot or batch number")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		expiryDate := fs.String("expiry-date", "", "Lot expiry date in YYYY-MM-DD")
-		reference := fs.String("reference", "", "Issue reference")
-		sourceType := fs.String("source-type", "", "Source type")
-		sourceID := fs.String("source-id", "", "Source id")
-		reason := fs.String("reason", "", "Issue reason")
-		cogsAccountID := fs.String("cogs-account-id", "", "Cost of goods sold account id")
-		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory asset account id")
-		postToLedger := fs.Bool("post-to-ledger", false, "Create and post inventory issue accounting entry")
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		effectiveDate := fs.String("effective-date", "", "Effective date in YYYY-MM-DD")
+		lineFlag := fs.String("line", "", "Line number; all lines when omitted")
+		unitPriceFlag := fs.String("unit-price", "", "New unit price")
+		upliftFlag := fs.String("uplift-percent", "", "Percentage uplift, such as the annual CPI change")
+		note := fs.String("note", "", "Note")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
-			return errors.New("product-id is required")
-		}
-		if strings.TrimSpace(*warehouseID) == "" {
-			return errors.New("warehouse-id is required")
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
 		}
-		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
+		effectiveDateValue, err := parseRequiredDate("effective-date", *effectiveDate)
 		if err != nil {
 			return err
 		}
-		if _, err := parseOptionalDate("expiry-date", *expiryDate); err != nil {
-			return err
-		}
-		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
-		if err != nil {
-			return err
+		req := &recurring.CreatePriceChangeRequest{
+			EffectiveDate: effectiveDateValue,
+			Note:          strings.TrimSpace(*note),
 		}
-		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*lineFlag) != "" {
+			lineNumber, err := parseRequiredPositiveInt("line", *lineFlag)
+			if err != nil {
+				return err
+			}
+			req.LineNumber = &lineNumber
 		}
-		sourceIDValue, err := optionalUUIDStringValue("source-id", *sourceID)
-		if err != nil {
-			return err
+		if (strings.TrimSpace(*unitPriceFlag) == "") == (strings.TrimSpace(*upliftFlag) == "") {
+			return errors.New("give either unit-price or uplift-percent")
 		}
-		cogsAccountIDValue, err := optionalUUIDStringValue("cogs-account-id", *cogsAccountID)
+		req.UnitPrice, err = parseOptionalNonNegativeDecimalPtr("unit-price", *unitPriceFlag)
 		if err != nil {
 			return err
 		}
-		inventoryAccountIDValue, err := optionalUUIDStringValue("inventory-account-id", *inventoryAccountID)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*upliftFlag) != "" {
+			uplift, err := parseRequiredDecimal("uplift-percent", *upliftFlag)
+			if err != nil {
+				return err
+			}
+			req.UpliftPercent = &uplift
 		}
 
-		result, err := client.issueStock(ctx, cfg.TenantID, &inventory.IssueStockRequest{
-			ProductID:                productIDValue,
-			WarehouseID:              warehouseIDValue,
-			Quantity:                 quantity.String(),
-			CostingMethod:            strings.TrimSpace(*costingMethod),
-			LotNumber:                strings.TrimSpace(*lotNumber),
-			SerialNumber:             strings.TrimSpace(*serialNumber),
-			ExpiryDate:               strings.TrimSpace(*expiryDate),
-			Reference:                strings.TrimSpace(*reference),
-			SourceType:               strings.TrimSpace(*sourceType),
-			SourceID:                 sourceIDValue,
-			Reason:                   strings.TrimSpace(*reason),
-			CostOfGoodsSoldAccountID: cogsAccountIDValue,
-			InventoryAccountID:       inventoryAccountIDValue,
-			PostToLedger:             *postToLedger,
-		})
+		change, err := client.createRecurringPriceChange(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Issued %s of product %s from warehouse %s at total cost %s\n", quantity.String(), productIDValue, warehouseIDValue, result.TotalCost.String())
-		if result.Accounting != nil && len(result.Accounting.Lines) > 0 {
-			if result.Accounting.Posted {
-				_, _ = fmt.Fprintf(a.stdout, "Posted accounting journal entry: %s\n", result.Accounting.JournalID)
-			} else {
-				_, _ = fmt.Fprintf(a.stdout, "Accounting lines prepared: %d\n", len(result.Accounting.Lines))
-			}
+			return printJSON(a.stdout, change)
 		}
+		printRecurringPriceChangesTable(a.stdout, []recurring.PriceChange{*change})
 		return nil
 
-	case "transfer":
-		fs := flag.NewFlagSet("inventory transfer", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("recurring-invoices price-changes delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("product-id", "", "Product id")
-		fromWarehouseID := fs.String("from-warehouse-id", "", "Source warehouse id")
-		toWarehouseID := fs.String("to-warehouse-id", "", "Destination warehouse id")
-		quantityFlag := fs.String("quantity", "", "Quantity to transfer")
-		lotNumber := fs.String("lot-number", "", "Lot or batch number")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		expiryDate := fs.String("expiry-date", "", "Lot expiry date in YYYY-MM-DD")
-		notes := fs.String("notes", "", "Transfer notes")
+		recurringID := fs.String("id", "", "Recurring invoice id")
+		changeID := fs.String("change-id", "", "Price change id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
-			return errors.New("product-id is required")
-		}
-		if strings.TrimSpace(*fromWarehouseID) == "" {
-			return errors.New("from-warehouse-id is required")
+		if strings.TrimSpace(*recurringID) == "" {
+			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*toWarehouseID) == "" {
-			return errors.New("to-warehouse-id is required")
+		if strings.TrimSpace(*changeID) == "" {
+			return errors.New("change-id is required")
 		}
-		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
+
+		result, err := client.deleteRecurringPriceChange(ctx, cfg.TenantID, strings.TrimSpace(*recurringID), strings.TrimSpace(*changeID))
 		if err != nil {
 			return err
 		}
-		if _, err := parseOptionalDate("expiry-date", *expiryDate); err != nil {
-			return err
-		}
-		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, result)
 		}
-		fromWarehouseIDValue, err := optionalUUIDStringValue("from-warehouse-id", *fromWarehouseID)
-		if err != nil {
+		_, _ = fmt.Fprintf(a.stdout, "Deleted price change %s\n", strings.TrimSpace(*changeID))
+		return nil
+
+	default:
+		return fmt.Errorf("unknown recurring-invoices price-changes subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runExpenses(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("expenses subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("expenses list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		statusFlag := fs.String("status", "", "Expense status")
+		limit := fs.Int("limit", 100, "Maximum expenses to return")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		toWarehouseIDValue, err := optionalUUIDStringValue("to-warehouse-id", *toWarehouseID)
+		status, err := parseOptionalExpenseStatus(*statusFlag)
 		if err != nil {
 			return err
 		}
 
-		result, err := client.transferStock(ctx, cfg.TenantID, &inventory.TransferStockRequest{
-			ProductID:       productIDValue,
-			FromWarehouseID: fromWarehouseIDValue,
-			ToWarehouseID:   toWarehouseIDValue,
-			Quantity:        quantity.String(),
-			LotNumber:       strings.TrimSpace(*lotNumber),
-			SerialNumber:    strings.TrimSpace(*serialNumber),
-			ExpiryDate:      strings.TrimSpace(*expiryDate),
-			Notes:           strings.TrimSpace(*notes),
+		expenseList, err := client.listExpenses(ctx, cfg.TenantID, expenses.ListExpensesFilter{
+			Status: status,
+			Limit:  *limit,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, expenseList)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Transferred %s of product %s from %s to %s\n", quantity.String(), productIDValue, fromWarehouseIDValue, toWarehouseIDValue)
+		printExpensesTable(a.stdout, expenseList)
 		return nil
 
-	case "reserve":
-		fs := flag.NewFlagSet("inventory reserve", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("expenses create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("product-id", "", "Product id")
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		quantityFlag := fs.String("quantity", "", "Quantity to reserve")
-		lotNumber := fs.String("lot-number", "", "Lot or batch number")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		expiryDate := fs.String("expiry-date", "", "Expiry date in YYYY-MM-DD")
-		reason := fs.String("reason", "", "Reservation reason")
+		merchant := fs.String("merchant", "", "Merchant")
+		description := fs.String("description", "", "Description")
+		expenseDate := fs.String("expense-date", "", "Expense date in YYYY-MM-DD")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		contactID := fs.String("contact-id", "", "Supplier/contact id")
+		expenseAccountID := fs.String("expense-account-id", "", "Expense account id")
+		paymentAccountID := fs.String("payment-account-id", "", "Payment or reimbursement account id")
+		amountFlag := fs.String("amount", "", "Expense amount")
+		currency := fs.String("currency", "EUR", "Currency code")
+		exchangeRateFlag := fs.String("exchange-rate", "1", "Exchange rate to base currency")
+		requiresReceipt := fs.Bool("requires-receipt", true, "Require an approved receipt before approval/posting")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
-			return errors.New("product-id is required")
+		if strings.TrimSpace(*merchant) == "" {
+			return errors.New("merchant is required")
 		}
-		if strings.TrimSpace(*warehouseID) == "" {
-			return errors.New("warehouse-id is required")
+		if strings.TrimSpace(*expenseAccountID) == "" {
+			return errors.New("expense-account-id is required")
 		}
-		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
+		if strings.TrimSpace(*paymentAccountID) == "" {
+			return errors.New("payment-account-id is required")
+		}
+		expenseDateValue, err := parseRequiredDate("expense-date", *expenseDate)
 		if err != nil {
 			return err
 		}
-		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
+		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
 		if err != nil {
 			return err
 		}
-		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
+		exchangeRate, err := parseRequiredPositiveDecimal("exchange-rate", *exchangeRateFlag)
 		if err != nil {
 			return err
 		}
 
-		level, err := client.reserveStock(ctx, cfg.TenantID, &inventory.StockReservationRequest{
-			ProductID:    productIDValue,
-			WarehouseID:  warehouseIDValue,
-			Quantity:     quantity.String(),
-			LotNumber:    strings.TrimSpace(*lotNumber),
-			SerialNumber: strings.TrimSpace(*serialNumber),
-			ExpiryDate:   strings.TrimSpace(*expiryDate),
-			Reason:       strings.TrimSpace(*reason),
+		expense, err := client.createExpense(ctx, cfg.TenantID, &expenses.CreateExpenseRequest{
+			ExpenseDate:      expenseDateValue,
+			Merchant:         strings.TrimSpace(*merchant),
+			Description:      strings.TrimSpace(*description),
+			EmployeeID:       optionalStringPtr(*employeeID),
+			ContactID:        optionalStringPtr(*contactID),
+			ExpenseAccountID: strings.TrimSpace(*expenseAccountID),
+			PaymentAccountID: strings.TrimSpace(*paymentAccountID),
+			Amount:           amount,
+			Currency:         strings.ToUpper(strings.TrimSpace(*currency)),
+			ExchangeRate:     exchangeRate,
+			RequiresReceipt:  requiresReceipt,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, level)
+			return printJSON(a.stdout, expense)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Reserved %s of product %s in warehouse %s\n", quantity.String(), productIDValue, warehouseIDValue)
+		_, _ = fmt.Fprintf(a.stdout, "Created expense %s (%s)\n", expense.ExpenseNumber, expense.ID)
 		return nil
 
-	case "release":
-		fs := flag.NewFlagSet("inventory release", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("expenses import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("product-id", "", "Product id")
-		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
-		quantityFlag := fs.String("quantity", "", "Quantity to release")
-		lotNumber := fs.String("lot-number", "", "Lot or batch number")
-		serialNumber := fs.String("serial-number", "", "Serial number")
-		expiryDate := fs.String("expiry-date", "", "Expiry date in YYYY-MM-DD")
-		reason := fs.String("reason", "", "Release reason")
+		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
-			return errors.New("product-id is required")
-		}
-		if strings.TrimSpace(*warehouseID) == "" {
-			return errors.New("warehouse-id is required")
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
 		}
-		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
+		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
+		result, err := client.importExpenses(ctx, cfg.TenantID, &expenses.ImportExpensesRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
 		if err != nil {
 			return err
 		}
-		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
-		if err != nil {
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d expenses, skipped %d rows\n", result.RowsProcessed, result.ExpensesCreated, result.RowsSkipped)
+		return nil
+
+	case "get":
+		fs := flag.NewFlagSet("expenses get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		expenseID := fs.String("id", "", "Expense id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*expenseID) == "" {
+			return errors.New("id is required")
+		}
 
-		level, err := client.releaseStock(ctx, cfg.TenantID, &inventory.StockReservationRequest{
-			ProductID:    productIDValue,
-			WarehouseID:  warehouseIDValue,
-			Quantity:     quantity.String(),
-			LotNumber:    strings.TrimSpace(*lotNumber),
-			SerialNumber: strings.TrimSpace(*serialNumber),
-			ExpiryDate:   strings.TrimSpace(*expiryDate),
-			Reason:       strings.TrimSpace(*reason),
-		})
+		expense, err := client.getExpense(ctx, cfg.TenantID, strings.TrimSpace(*expenseID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, level)
+			return printJSON(a.stdout, expense)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Released %s of product %s in warehouse %s\n", quantity.String(), productIDValue, warehouseIDValue)
+		printExpense(a.stdout, expense)
 		return nil
 
-	default:
-		return fmt.Errorf("unknown inventory subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runInventoryStock(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
-	if len(args) == 0 {
-		return errors.New("inventory stock subcommand required")
-	}
-
-	switch args[0] {
-	case "import":
-		fs := flag.NewFlagSet("inventory stock import", flag.ContinueOnError)
+	case "submit", "approve", "post":
+		fs := flag.NewFlagSet("expenses "+args[0], flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		expenseID := fs.String("id", "", "Expense id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*expenseID) == "" {
+			return errors.New("id is required")
 		}
 
-		content, fileName, err := readCSVInput(*filePath)
+		expense, err := client.updateExpenseStatus(ctx, cfg.TenantID, strings.TrimSpace(*expenseID), args[0])
 		if err != nil {
 			return err
 		}
-		result, err := client.importStockAdjustments(ctx, cfg.TenantID, &inventory.ImportStockAdjustmentsRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		if *asJSON {
+			return printJSON(a.stdout, expense)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "%s expense %s\n", expenseActionPastTense(args[0]), strings.TrimSpace(*expenseID))
+		return nil
+
+	case "reject":
+		fs := flag.NewFlagSet("expenses reject", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		expenseID := fs.String("id", "", "Expense id")
+		reason := fs.String("reason", "", "Rejection reason")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*expenseID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*reason) == "" {
+			return errors.New("reason is required")
+		}
+
+		expense, err := client.rejectExpense(ctx, cfg.TenantID, strings.TrimSpace(*expenseID), &expenses.RejectExpenseRequest{Reason: strings.TrimSpace(*reason)})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, expense)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, imported %d stock adjustments, skipped %d rows\n", result.RowsProcessed, result.AdjustmentsImported, result.RowsSkipped)
+		_, _ = fmt.Fprintf(a.stdout, "Rejected expense %s\n", strings.TrimSpace(*expenseID))
 		return nil
 
 	default:
-		return fmt.Errorf("unknown inventory stock subcommand %q", args[0])
+		return fmt.Errorf("unknown expenses subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runInventoryCategories(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+func (a *cliApp) runAssets(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("inventory categories subcommand required")
+		return errors.New("assets subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+	if args[0] == "categories" {
+		return a.runAssetCategories(ctx, cfg, client, args[1:])
+	}
+	if args[0] == "depreciation-runs" {
+		return a.runDepreciationRuns(ctx, cfg, client, args[1:])
+	}
+	if args[0] == "events" {
+		return a.runAssetEvents(ctx, cfg, client, args[1:])
 	}
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("inventory categories list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
+		statusFlag := fs.String("status", "", "Asset status")
+		categoryID := fs.String("category-id", "", "Category id")
+		search := fs.String("search", "", "Search term")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		status, err := parseOptionalAssetStatus(*statusFlag)
+		if err != nil {
+			return err
+		}
 
-		categories, err := client.listProductCategories(ctx, cfg.TenantID)
+		assetList, err := client.listAssets(ctx, cfg.TenantID, assets.AssetFilter{
+			Status:     status,
+			CategoryID: strings.TrimSpace(*categoryID),
+			Search:     strings.TrimSpace(*search),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, categories)
+			return printJSON(a.stdout, assetList)
 		}
-		printProductCategoriesTable(a.stdout, categories)
+		printAssetsTable(a.stdout, assetList)
 		return nil
 
 	case "create":
-		fs := flag.NewFlagSet("inventory categories create", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		name := fs.String("name", "", "Category name")
+		name := fs.String("name", "", "Asset name")
 		description := fs.String("description", "", "Description")
-		parentID := fs.String("parent-id", "", "Parent category id")
+		categoryID := fs.String("category-id", "", "Category id")
+		purchaseDate := fs.String("purchase-date", "", "Purchase date in YYYY-MM-DD")
+		purchaseCostFlag := fs.String("purchase-cost", "", "Purchase cost")
+		supplierID := fs.String("supplier-id", "", "Supplier id")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		location := fs.String("location", "", "Location")
+		depreciationMethodFlag := fs.String("depreciation-method", "STRAIGHT_LINE", "Depreciation method")
+		usefulLifeMonthsFlag := fs.String("useful-life-months", "60", "Useful life in months")
+		residualValueFlag := fs.String("residual-value", "0", "Residual value")
+		depreciationStartDate := fs.String("depreciation-start-date", "", "Depreciation start date in YYYY-MM-DD")
+		assetAccountID := fs.String("asset-account-id", "", "Asset account id")
+		depreciationExpenseAccountID := fs.String("depreciation-expense-account-id", "", "Depreciation expense account id")
+		accumulatedDepreciationAccountID := fs.String("accumulated-depreciation-account-id", "", "Accumulated depreciation account id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
@@ -8115,33 +8832,61 @@ func (a *cliApp) runInventoryCategories(ctx context.Context, cfg *cliConfig, cli
 		if strings.TrimSpace(*name) == "" {
 			return errors.New("name is required")
 		}
-		parsedParentID, err := optionalUUIDStringPtr("parent-id", *parentID)
+		purchaseDateValue, err := parseRequiredDate("purchase-date", *purchaseDate)
 		if err != nil {
 			return err
 		}
-		parentIDValue := ""
-		if parsedParentID != nil {
-			parentIDValue = *parsedParentID
+		purchaseCost, err := parseRequiredPositiveDecimal("purchase-cost", *purchaseCostFlag)
+		if err != nil {
+			return err
+		}
+		depreciationMethod, err := parseOptionalDepreciationMethod(*depreciationMethodFlag)
+		if err != nil {
+			return err
+		}
+		usefulLifeMonths, err := parseRequiredPositiveInt("useful-life-months", *usefulLifeMonthsFlag)
+		if err != nil {
+			return err
+		}
+		residualValue, err := parseRequiredNonNegativeDecimal("residual-value", *residualValueFlag)
+		if err != nil {
+			return err
+		}
+		depreciationStartDateValue, err := parseOptionalDate("depreciation-start-date", *depreciationStartDate)
+		if err != nil {
+			return err
 		}
 
-		category, err := client.createProductCategory(ctx, cfg.TenantID, &inventory.CreateCategoryRequest{
-			Name:        strings.TrimSpace(*name),
-			Description: strings.TrimSpace(*description),
-			ParentID:    parentIDValue,
+		asset, err := client.createAsset(ctx, cfg.TenantID, &assets.CreateAssetRequest{
+			Name:                          strings.TrimSpace(*name),
+			Description:                   strings.TrimSpace(*description),
+			CategoryID:                    optionalStringPtr(*categoryID),
+			PurchaseDate:                  purchaseDateValue,
+			PurchaseCost:                  purchaseCost,
+			SupplierID:                    optionalStringPtr(*supplierID),
+			SerialNumber:                  strings.TrimSpace(*serialNumber),
+			Location:                      strings.TrimSpace(*location),
+			DepreciationMethod:            depreciationMethod,
+			UsefulLifeMonths:              usefulLifeMonths,
+			ResidualValue:                 residualValue,
+			DepreciationStartDate:         depreciationStartDateValue,
+			AssetAccountID:                optionalStringPtr(*assetAccountID),
+			DepreciationExpenseAccountID:  optionalStringPtr(*depreciationExpenseAccountID),
+			AccumulatedDepreciationAcctID: optionalStringPtr(*accumulatedDepreciationAccountID),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, category)
+			return printJSON(a.stdout, asset)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created product category %s (%s)\n", category.Name, category.ID)
+		_, _ = fmt.Fprintf(a.stdout, "Created asset %s (%s)\n", asset.AssetNumber, asset.ID)
 		return nil
 
 	case "import":
-		fs := flag.NewFlagSet("inventory categories import", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path or - for stdin")
+		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
@@ -8149,14 +8894,14 @@ func (a *cliApp) runInventoryCategories(ctx context.Context, cfg *cliConfig, cli
 		if strings.TrimSpace(*filePath) == "" {
 			return errors.New("file is required")
 		}
+
 		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-
-		result, err := client.importProductCategories(ctx, cfg.TenantID, &inventory.ImportProductCategoriesRequest{
-			CSVContent: content,
+		result, err := client.importAssets(ctx, cfg.TenantID, &assets.ImportAssetsRequest{
 			FileName:   fileName,
+			CSVContent: content,
 		})
 		if err != nil {
 			return err
@@ -8164,1465 +8909,1316 @@ func (a *cliApp) runInventoryCategories(ctx context.Context, cfg *cliConfig, cli
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d product categories, skipped %d rows\n", result.RowsProcessed, result.CategoriesCreated, result.RowsSkipped)
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d assets, skipped %d rows\n", result.RowsProcessed, result.AssetsCreated, result.RowsSkipped)
 		return nil
 
 	case "get":
-		fs := flag.NewFlagSet("inventory categories get", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		categoryID := fs.String("id", "", "Category id")
+		assetID := fs.String("id", "", "Asset id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*categoryID) == "" {
+		if strings.TrimSpace(*assetID) == "" {
 			return errors.New("id is required")
 		}
 
-		category, err := client.getProductCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID))
+		asset, err := client.getAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, category)
+			return printJSON(a.stdout, asset)
 		}
-		printProductCategory(a.stdout, category)
+		printAsset(a.stdout, asset)
 		return nil
 
-	case "delete":
-		fs := flag.NewFlagSet("inventory categories delete", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("assets update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		categoryID := fs.String("id", "", "Category id")
+		assetID := fs.String("id", "", "Asset id")
+		name := fs.String("name", "", "Asset name")
+		description := fs.String("description", "", "Description")
+		categoryID := fs.String("category-id", "", "Category id")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		location := fs.String("location", "", "Location")
+		depreciationMethodFlag := fs.String("depreciation-method", "STRAIGHT_LINE", "Depreciation method")
+		usefulLifeMonthsFlag := fs.String("useful-life-months", "60", "Useful life in months")
+		residualValueFlag := fs.String("residual-value", "0", "Residual value")
+		assetAccountID := fs.String("asset-account-id", "", "Asset account id")
+		depreciationExpenseAccountID := fs.String("depreciation-expense-account-id", "", "Depreciation expense account id")
+		accumulatedDepreciationAccountID := fs.String("accumulated-depreciation-account-id", "", "Accumulated depreciation account id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*categoryID) == "" {
+		if strings.TrimSpace(*assetID) == "" {
 			return errors.New("id is required")
 		}
-
-		result, err := client.deleteProductCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID))
-		if err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted product category %s\n", strings.TrimSpace(*categoryID))
-		return nil
-
-	default:
-		return fmt.Errorf("unknown inventory categories subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runInventoryProducts(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
-	if len(args) == 0 {
-		return errors.New("inventory products subcommand required")
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("inventory products list", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		typeFlag := fs.String("type", "", "Product type")
-		statusFlag := fs.String("status", "", "Product status")
-		categoryID := fs.String("category-id", "", "Category id")
-		search := fs.String("search", "", "Search term")
-		lowStock := fs.Bool("low-stock", false, "List products below reorder threshold")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
-		productType, err := parseOptionalProductType(*typeFlag)
+		depreciationMethod, err := parseOptionalDepreciationMethod(*depreciationMethodFlag)
 		if err != nil {
 			return err
 		}
-		status, err := parseOptionalProductStatus(*statusFlag)
+		usefulLifeMonths, err := parseRequiredPositiveInt("useful-life-months", *usefulLifeMonthsFlag)
 		if err != nil {
 			return err
 		}
-		categoryIDValue, err := optionalUUIDStringValue("category-id", *categoryID)
+		residualValue, err := parseRequiredNonNegativeDecimal("residual-value", *residualValueFlag)
 		if err != nil {
 			return err
 		}
 
-		products, err := client.listProducts(ctx, cfg.TenantID, inventory.ProductFilter{
-			ProductType: productType,
-			Status:      status,
-			CategoryID:  categoryIDValue,
-			Search:      strings.TrimSpace(*search),
-			LowStock:    *lowStock,
+		asset, err := client.updateAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID), &assets.UpdateAssetRequest{
+			Name:                          strings.TrimSpace(*name),
+			Description:                   strings.TrimSpace(*description),
+			CategoryID:                    optionalStringPtr(*categoryID),
+			SerialNumber:                  strings.TrimSpace(*serialNumber),
+			Location:                      strings.TrimSpace(*location),
+			DepreciationMethod:            depreciationMethod,
+			UsefulLifeMonths:              usefulLifeMonths,
+			ResidualValue:                 residualValue,
+			AssetAccountID:                optionalStringPtr(*assetAccountID),
+			DepreciationExpenseAccountID:  optionalStringPtr(*depreciationExpenseAccountID),
+			AccumulatedDepreciationAcctID: optionalStringPtr(*accumulatedDepreciationAccountID),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, products)
+			return printJSON(a.stdout, asset)
 		}
-		printProductsTable(a.stdout, products)
+		printAsset(a.stdout, asset)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("inventory products create", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("assets delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		code := fs.String("code", "", "Product code")
-		name := fs.String("name", "", "Product name")
-		description := fs.String("description", "", "Description")
-		typeFlag := fs.String("type", string(inventory.ProductTypeGoods), "Product type")
-		categoryID := fs.String("category-id", "", "Category id")
-		unit := fs.String("unit", "pcs", "Unit of measure")
-		purchasePriceFlag := fs.String("purchase-price", "0", "Purchase price")
-		salesPriceFlag := fs.String("sales-price", "", "Sales price")
-		vatRateFlag := fs.String("vat-rate", "22", "VAT rate")
-		minStockLevelFlag := fs.String("min-stock-level", "0", "Minimum stock level")
-		reorderPointFlag := fs.String("reorder-point", "0", "Reorder point")
-		saleAccountID := fs.String("sale-account-id", "", "Sale account id")
-		purchaseAccountID := fs.String("purchase-account-id", "", "Purchase account id")
-		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory account id")
-		trackInventory := fs.Bool("track-inventory", true, "Track inventory for this product")
-		barcode := fs.String("barcode", "", "Barcode")
-		supplierID := fs.String("supplier-id", "", "Supplier id")
-		leadTimeDaysFlag := fs.String("lead-time-days", "0", "Lead time in days")
+		assetID := fs.String("id", "", "Asset id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
-		}
-		productType, err := parseRequiredProductType(*typeFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*assetID) == "" {
+			return errors.New("id is required")
 		}
-		purchasePrice, err := parseRequiredNonNegativeDecimal("purchase-price", *purchasePriceFlag)
-		if err != nil {
+
+		if err := client.deleteAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID)); err != nil {
 			return err
 		}
-		salesPrice, err := parseRequiredNonNegativeDecimal("sales-price", *salesPriceFlag)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, map[string]string{"status": "deleted"})
 		}
-		vatRate, err := parseRequiredNonNegativeDecimal("vat-rate", *vatRateFlag)
-		if err != nil {
+		_, _ = fmt.Fprintf(a.stdout, "Deleted asset %s\n", strings.TrimSpace(*assetID))
+		return nil
+
+	case "activate":
+		fs := flag.NewFlagSet("assets activate", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		assetID := fs.String("id", "", "Asset id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		minStockLevel, err := parseRequiredNonNegativeDecimal("min-stock-level", *minStockLevelFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*assetID) == "" {
+			return errors.New("id is required")
 		}
-		reorderPoint, err := parseRequiredNonNegativeDecimal("reorder-point", *reorderPointFlag)
+
+		result, err := client.activateAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
 		if err != nil {
 			return err
 		}
-		leadTimeDays, err := parseRequiredNonNegativeInt("lead-time-days", *leadTimeDaysFlag)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, result)
 		}
-		categoryIDValue, err := optionalUUIDStringValue("category-id", *categoryID)
-		if err != nil {
+		_, _ = fmt.Fprintf(a.stdout, "Activated asset %s\n", strings.TrimSpace(*assetID))
+		return nil
+
+	case "dispose":
+		fs := flag.NewFlagSet("assets dispose", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		assetID := fs.String("id", "", "Asset id")
+		disposalDate := fs.String("disposal-date", "", "Disposal date in YYYY-MM-DD")
+		methodFlag := fs.String("method", "", "Disposal method")
+		proceedsFlag := fs.String("proceeds", "0", "Disposal proceeds")
+		proceedsAccountID := fs.String("proceeds-account-id", "", "Asset account receiving disposal proceeds")
+		gainLossAccountID := fs.String("gain-loss-account-id", "", "Revenue or expense account for disposal gain/loss")
+		notes := fs.String("notes", "", "Disposal notes")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		saleAccountIDValue, err := optionalUUIDStringValue("sale-account-id", *saleAccountID)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*assetID) == "" {
+			return errors.New("id is required")
 		}
-		purchaseAccountIDValue, err := optionalUUIDStringValue("purchase-account-id", *purchaseAccountID)
+		disposalDateValue, err := parseRequiredDate("disposal-date", *disposalDate)
 		if err != nil {
 			return err
 		}
-		inventoryAccountIDValue, err := optionalUUIDStringValue("inventory-account-id", *inventoryAccountID)
+		method, err := parseRequiredDisposalMethod(*methodFlag)
 		if err != nil {
 			return err
 		}
-		supplierIDValue, err := optionalUUIDStringValue("supplier-id", *supplierID)
+		proceeds, err := parseRequiredNonNegativeDecimal("proceeds", *proceedsFlag)
 		if err != nil {
 			return err
 		}
 
-		product, err := client.createProduct(ctx, cfg.TenantID, &inventory.CreateProductRequest{
-			Code:               strings.TrimSpace(*code),
-			Name:               strings.TrimSpace(*name),
-			Description:        strings.TrimSpace(*description),
-			ProductType:        string(productType),
-			CategoryID:         categoryIDValue,
-			Unit:               strings.TrimSpace(*unit),
-			PurchasePrice:      purchasePrice.String(),
-			SalesPrice:         salesPrice.String(),
-			VATRate:            vatRate.String(),
-			MinStockLevel:      minStockLevel.String(),
-			ReorderPoint:       reorderPoint.String(),
-			SaleAccountID:      saleAccountIDValue,
-			PurchaseAccountID:  purchaseAccountIDValue,
-			InventoryAccountID: inventoryAccountIDValue,
-			TrackInventory:     *trackInventory,
-			Barcode:            strings.TrimSpace(*barcode),
-			SupplierID:         supplierIDValue,
-			LeadTimeDays:       leadTimeDays,
+		result, err := client.disposeAsset(ctx, cfg.TenantID, strings.TrimSpace(*assetID), &assets.DisposeAssetRequest{
+			DisposalDate:              disposalDateValue,
+			DisposalMethod:            method,
+			DisposalProceeds:          proceeds,
+			DisposalNotes:             strings.TrimSpace(*notes),
+			DisposalProceedsAccountID: optionalStringPtr(*proceedsAccountID),
+			DisposalGainLossAccountID: optionalStringPtr(*gainLossAccountID),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, product)
+			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created product %s %s (%s)\n", product.Code, product.Name, product.ID)
+		_, _ = fmt.Fprintf(a.stdout, "Disposed asset %s\n", strings.TrimSpace(*assetID))
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("inventory products import", flag.ContinueOnError)
+	case "depreciate":
+		fs := flag.NewFlagSet("assets depreciate", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		assetID := fs.String("id", "", "Asset id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*assetID) == "" {
+			return errors.New("id is required")
 		}
 
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
-		}
-		result, err := client.importProducts(ctx, cfg.TenantID, &inventory.ImportProductsRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		entry, err := client.recordAssetDepreciation(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, entry)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d products, skipped %d rows\n", result.RowsProcessed, result.ProductsCreated, result.RowsSkipped)
+		_, _ = fmt.Fprintf(a.stdout, "Recorded depreciation %s for asset %s\n", entry.DepreciationAmount.String(), strings.TrimSpace(*assetID))
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("inventory products get", flag.ContinueOnError)
+	case "depreciation":
+		fs := flag.NewFlagSet("assets depreciation", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("id", "", "Product id")
+		assetID := fs.String("id", "", "Asset id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
+		if strings.TrimSpace(*assetID) == "" {
 			return errors.New("id is required")
 		}
 
-		product, err := client.getProduct(ctx, cfg.TenantID, strings.TrimSpace(*productID))
+		entries, err := client.listAssetDepreciation(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, product)
+			return printJSON(a.stdout, entries)
 		}
-		printProduct(a.stdout, product)
+		printDepreciationEntriesTable(a.stdout, entries)
 		return nil
 
-	case "update":
-		fs := flag.NewFlagSet("inventory products update", flag.ContinueOnError)
+	case "schedule":
+		fs := flag.NewFlagSet("assets schedule", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("id", "", "Product id")
-		name := fs.String("name", "", "Product name")
-		description := fs.String("description", "", "Description")
-		categoryID := fs.String("category-id", "", "Category id")
-		unit := fs.String("unit", "pcs", "Unit of measure")
-		purchasePriceFlag := fs.String("purchase-price", "0", "Purchase price")
-		salesPriceFlag := fs.String("sales-price", "", "Sales price")
-		vatRateFlag := fs.String("vat-rate", "22", "VAT rate")
-		minStockLevelFlag := fs.String("min-stock-level", "0", "Minimum stock level")
-		reorderPointFlag := fs.String("reorder-point", "0", "Reorder point")
-		saleAccountID := fs.String("sale-account-id", "", "Sale account id")
-		purchaseAccountID := fs.String("purchase-account-id", "", "Purchase account id")
-		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory account id")
-		trackInventory := fs.Bool("track-inventory", true, "Track inventory for this product")
-		active := fs.Bool("active", true, "Set active")
-		barcode := fs.String("barcode", "", "Barcode")
-		supplierID := fs.String("supplier-id", "", "Supplier id")
-		leadTimeDaysFlag := fs.String("lead-time-days", "0", "Lead time in days")
+		assetID := fs.String("id", "", "Asset id")
+		unitsTotal := fs.String("units-total", "", "Planned total units over the asset's life (UNITS_OF_PRODUCTION only)")
+		unitsPerMonth := fs.String("units-per-month", "", "Planned units per month (UNITS_OF_PRODUCTION only)")
 		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
-			return errors.New("id is required")
-		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
-		}
-		purchasePrice, err := parseRequiredNonNegativeDecimal("purchase-price", *purchasePriceFlag)
-		if err != nil {
-			return err
-		}
-		salesPrice, err := parseRequiredNonNegativeDecimal("sales-price", *salesPriceFlag)
-		if err != nil {
-			return err
-		}
-		vatRate, err := parseRequiredNonNegativeDecimal("vat-rate", *vatRateFlag)
-		if err != nil {
-			return err
-		}
-		minStockLevel, err := parseRequiredNonNegativeDecimal("min-stock-level", *minStockLevelFlag)
-		if err != nil {
-			return err
-		}
-		reorderPoint, err := parseRequiredNonNegativeDecimal("reorder-point", *reorderPointFlag)
-		if err != nil {
-			return err
-		}
-		leadTimeDays, err := parseRequiredNonNegativeInt("lead-time-days", *leadTimeDaysFlag)
-		if err != nil {
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
 			return err
 		}
-		categoryIDValue, err := optionalUUIDStringValue("category-id", *categoryID)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*assetID) == "" {
+			return errors.New("id is required")
 		}
-		saleAccountIDValue, err := optionalUUIDStringValue("sale-account-id", *saleAccountID)
-		if err != nil {
-			return err
+		total, perMonth := "", ""
+		if strings.TrimSpace(*unitsTotal) != "" || strings.TrimSpace(*unitsPerMonth) != "" {
+			totalValue, err := parseRequiredPositiveDecimal("units-total", *unitsTotal)
+			if err != nil {
+				return err
+			}
+			perMonthValue, err := parseRequiredPositiveDecimal("units-per-month", *unitsPerMonth)
+			if err != nil {
+				return err
+			}
+			total, perMonth = totalValue.String(), perMonthValue.String()
 		}
-		purchaseAccountIDValue, err := optionalUUIDStringValue("purchase-account-id", *purchaseAccountID)
-		if err != nil {
-			return err
+
+		if *asCSV {
+			content, err := client.exportAssetDepreciationSchedule(ctx, cfg.TenantID, strings.TrimSpace(*assetID), total, perMonth, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "depreciation schedule CSV")
 		}
-		inventoryAccountIDValue, err := optionalUUIDStringValue("inventory-account-id", *inventoryAccountID)
-		if err != nil {
-			return err
+		if *asXLSX {
+			content, err := client.exportAssetDepreciationSchedule(ctx, cfg.TenantID, strings.TrimSpace(*assetID), total, perMonth, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "depreciation schedule XLSX")
 		}
-		supplierIDValue, err := optionalUUIDStringValue("supplier-id", *supplierID)
-		if err != nil {
-			return err
+		if *asPDF {
+			content, err := client.exportAssetDepreciationSchedule(ctx, cfg.TenantID, strings.TrimSpace(*assetID), total, perMonth, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "depreciation schedule PDF")
 		}
 
-		product, err := client.updateProduct(ctx, cfg.TenantID, strings.TrimSpace(*productID), &inventory.UpdateProductRequest{
-			Name:               strings.TrimSpace(*name),
-			Description:        strings.TrimSpace(*description),
-			CategoryID:         categoryIDValue,
-			Unit:               strings.TrimSpace(*unit),
-			PurchasePrice:      purchasePrice.String(),
-			SalesPrice:         salesPrice.String(),
-			VATRate:            vatRate.String(),
-			MinStockLevel:      minStockLevel.String(),
-			ReorderPoint:       reorderPoint.String(),
-			SaleAccountID:      saleAccountIDValue,
-			PurchaseAccountID:  purchaseAccountIDValue,
-			InventoryAccountID: inventoryAccountIDValue,
-			TrackInventory:     *trackInventory,
-			IsActive:           *active,
-			Barcode:            strings.TrimSpace(*barcode),
-			SupplierID:         supplierIDValue,
-			LeadTimeDays:       leadTimeDays,
-		})
+		schedule, err := client.getAssetDepreciationSchedule(ctx, cfg.TenantID, strings.TrimSpace(*assetID), total, perMonth)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, product)
+			return printJSON(a.stdout, schedule)
 		}
-		printProduct(a.stdout, product)
+		printDepreciationSchedule(a.stdout, schedule)
 		return nil
 
-	case "delete":
-		fs := flag.NewFlagSet("inventory products delete", flag.ContinueOnError)
+	case "history":
+		fs := flag.NewFlagSet("assets history", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("id", "", "Product id")
+		assetID := fs.String("id", "", "Asset id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
+		if strings.TrimSpace(*assetID) == "" {
 			return errors.New("id is required")
 		}
 
-		result, err := client.deleteProduct(ctx, cfg.TenantID, strings.TrimSpace(*productID))
+		history, err := client.getAssetHistory(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, history)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted product %s\n", strings.TrimSpace(*productID))
+		printAssetHistory(a.stdout, history)
 		return nil
 
-	case "stock-levels":
-		fs := flag.NewFlagSet("inventory products stock-levels", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown assets subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runAssetEvents(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("assets events subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("assets events list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("id", "", "Product id")
+		assetID := fs.String("id", "", "Asset id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
+		if strings.TrimSpace(*assetID) == "" {
 			return errors.New("id is required")
 		}
 
-		levels, err := client.listStockLevels(ctx, cfg.TenantID, strings.TrimSpace(*productID))
+		events, err := client.listAssetEvents(ctx, cfg.TenantID, strings.TrimSpace(*assetID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, levels)
+			return printJSON(a.stdout, events)
 		}
-		printStockLevelsTable(a.stdout, levels)
+		printAssetEventsTable(a.stdout, events)
 		return nil
 
-	case "movements":
-		fs := flag.NewFlagSet("inventory products movements", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("assets events create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		productID := fs.String("id", "", "Product id")
+		assetID := fs.String("id", "", "Asset id")
+		typeFlag := fs.String("type", "", "Event type: IMPROVEMENT, IMPAIRMENT or ESTIMATE_CHANGE")
+		dateFlag := fs.String("date", "", "Event date (YYYY-MM-DD)")
+		amountFlag := fs.String("amount", "", "Amount capitalised or written down (not used for ESTIMATE_CHANGE)")
+		usefulLife := fs.String("useful-life-months", "", "Optional revised total useful life in months")
+		residualValue := fs.String("residual-value", "", "Optional revised residual value")
+		offsetAccountID := fs.String("offset-account-id", "", "Account credited for an improvement or debited for an impairment")
+		description := fs.String("description", "", "Optional description")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*productID) == "" {
+		if strings.TrimSpace(*assetID) == "" {
 			return errors.New("id is required")
 		}
+		eventType, err := parseRequiredAssetEventType(*typeFlag)
+		if err != nil {
+			return err
+		}
+		eventDate, err := parseRequiredDate("date", *dateFlag)
+		if err != nil {
+			return err
+		}
+		amount := decimal.Zero
+		if eventType != assets.AssetEventEstimateChange {
+			if amount, err = parseRequiredPositiveDecimal("amount", *amountFlag); err != nil {
+				return err
+			}
+		} else if strings.TrimSpace(*amountFlag) != "" {
+			return errors.New("amount is not used for ESTIMATE_CHANGE")
+		}
+		req := &assets.CreateAssetEventRequest{
+			EventType:       eventType,
+			EventDate:       eventDate,
+			Amount:          amount,
+			OffsetAccountID: optionalStringPtr(*offsetAccountID),
+			Description:     strings.TrimSpace(*description),
+		}
+		if strings.TrimSpace(*usefulLife) != "" {
+			months, err := parseRequiredPositiveInt("useful-life-months", *usefulLife)
+			if err != nil {
+				return err
+			}
+			req.UsefulLifeMonths = &months
+		}
+		if req.ResidualValue, err = parseOptionalNonNegativeDecimalPtr("residual-value", *residualValue); err != nil {
+			return err
+		}
 
-		movements, err := client.listInventoryMovements(ctx, cfg.TenantID, strings.TrimSpace(*productID))
+		event, err := client.createAssetEvent(ctx, cfg.TenantID, strings.TrimSpace(*assetID), req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, movements)
+			return printJSON(a.stdout, event)
 		}
-		printInventoryMovementsTable(a.stdout, movements)
+		_, _ = fmt.Fprintf(a.stdout, "Recorded %s on asset %s: book value %s -> %s, monthly depreciation %s -> %s\n", event.EventType, strings.TrimSpace(*assetID), event.BookValueBefore.StringFixed(2), event.BookValueAfter.StringFixed(2), event.MonthlyDepreciationBefore.StringFixed(2), event.MonthlyDepreciationAfter.StringFixed(2))
 		return nil
 
 	default:
-		return fmt.Errorf("unknown inventory products subcommand %q", args[0])
+		return fmt.Errorf("unknown assets events subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runInventoryWarehouses(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+func (a *cliApp) runDepreciationRuns(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("inventory warehouses subcommand required")
+		return errors.New("assets depreciation-runs subcommand required")
 	}
 
 	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("inventory warehouses list", flag.ContinueOnError)
+	case "preview":
+		fs := flag.NewFlagSet("assets depreciation-runs preview", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		activeOnly := fs.Bool("active-only", false, "List only active warehouses")
+		yearFlag := fs.String("year", "", "Period year")
+		monthFlag := fs.String("month", "", "Period month")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
 
-		warehouses, err := client.listWarehouses(ctx, cfg.TenantID, *activeOnly)
+		preview, err := client.previewDepreciationRun(ctx, cfg.TenantID, year, month)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, warehouses)
+			return printJSON(a.stdout, preview)
 		}
-		printWarehousesTable(a.stdout, warehouses)
+		printDepreciationRunPreview(a.stdout, preview)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("inventory warehouses create", flag.ContinueOnError)
+	case "list":
+		fs := flag.NewFlagSet("assets depreciation-runs list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		code := fs.String("code", "", "Warehouse code")
-		name := fs.String("name", "", "Warehouse name")
-		address := fs.String("address", "", "Address")
-		isDefault := fs.Bool("default", false, "Set as default warehouse")
+		yearFlag := fs.String("year", "", "Optional period year")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*code) == "" {
-			return errors.New("code is required")
-		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
+		year := 0
+		if strings.TrimSpace(*yearFlag) != "" {
+			parsed, err := parseRequiredPositiveInt("year", *yearFlag)
+			if err != nil {
+				return err
+			}
+			year = parsed
 		}
 
-		warehouse, err := client.createWarehouse(ctx, cfg.TenantID, &inventory.CreateWarehouseRequest{
-			Code:      strings.TrimSpace(*code),
-			Name:      strings.TrimSpace(*name),
-			Address:   strings.TrimSpace(*address),
-			IsDefault: *isDefault,
-		})
+		runs, err := client.listDepreciationRuns(ctx, cfg.TenantID, year)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, warehouse)
+			return printJSON(a.stdout, runs)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created warehouse %s %s (%s)\n", warehouse.Code, warehouse.Name, warehouse.ID)
+		printDepreciationRunsTable(a.stdout, runs)
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("inventory warehouses import", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("assets depreciation-runs create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path or - for stdin")
+		yearFlag := fs.String("year", "", "Period year")
+		monthFlag := fs.String("month", "", "Period month")
+		postingMode := fs.String("posting-mode", "AGGREGATED", "Posting mode: AGGREGATED or PER_ASSET")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
-		}
-		content, fileName, err := readCSVInput(*filePath)
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
 		if err != nil {
 			return err
 		}
 
-		result, err := client.importWarehouses(ctx, cfg.TenantID, &inventory.ImportWarehousesRequest{
-			CSVContent: content,
-			FileName:   fileName,
+		run, err := client.createDepreciationRun(ctx, cfg.TenantID, &assets.CreateDepreciationRunRequest{
+			Year:        year,
+			Month:       month,
+			PostingMode: assets.DepreciationPostingMode(strings.ToUpper(strings.TrimSpace(*postingMode))),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, run)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d warehouses, skipped %d rows\n", result.RowsProcessed, result.WarehousesCreated, result.RowsSkipped)
+		_, _ = fmt.Fprintf(a.stdout, "Depreciation run %04d-%02d posted %s for %d assets (%s)\n", year, month, run.TotalAmount.String(), run.AssetCount, run.ID)
 		return nil
 
 	case "get":
-		fs := flag.NewFlagSet("inventory warehouses get", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets depreciation-runs get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		warehouseID := fs.String("id", "", "Warehouse id")
+		runID := fs.String("id", "", "Depreciation run id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*warehouseID) == "" {
+		if strings.TrimSpace(*runID) == "" {
 			return errors.New("id is required")
 		}
 
-		warehouse, err := client.getWarehouse(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID))
+		run, err := client.getDepreciationRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, warehouse)
+			return printJSON(a.stdout, run)
 		}
-		printWarehouse(a.stdout, warehouse)
+		printDepreciationRun(a.stdout, run)
 		return nil
 
-	case "update":
-		fs := flag.NewFlagSet("inventory warehouses update", flag.ContinueOnError)
+	case "reverse":
+		fs := flag.NewFlagSet("assets depreciation-runs reverse", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		warehouseID := fs.String("id", "", "Warehouse id")
-		name := fs.String("name", "", "Warehouse name")
-		address := fs.String("address", "", "Address")
-		isDefault := fs.Bool("default", false, "Set as default warehouse")
-		active := fs.Bool("active", true, "Set active")
+		runID := fs.String("id", "", "Depreciation run id")
+		reason := fs.String("reason", "", "Reversal reason")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*warehouseID) == "" {
+		if strings.TrimSpace(*runID) == "" {
 			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
+		if strings.TrimSpace(*reason) == "" {
+			return errors.New("reason is required")
 		}
 
-		warehouse, err := client.updateWarehouse(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID), &inventory.UpdateWarehouseRequest{
-			Name:      strings.TrimSpace(*name),
-			Address:   strings.TrimSpace(*address),
-			IsDefault: *isDefault,
-			IsActive:  *active,
+		run, err := client.reverseDepreciationRun(ctx, cfg.TenantID, strings.TrimSpace(*runID), &assets.ReverseDepreciationRunRequest{
+			Reason: strings.TrimSpace(*reason),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, warehouse)
+			return printJSON(a.stdout, run)
 		}
-		printWarehouse(a.stdout, warehouse)
-		return nil
-
-	case "delete":
-		fs := flag.NewFlagSet("inventory warehouses delete", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		warehouseID := fs.String("id", "", "Warehouse id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-		if strings.TrimSpace(*warehouseID) == "" {
-			return errors.New("id is required")
-		}
-
-		result, err := client.deleteWarehouse(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID))
-		if err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted warehouse %s\n", strings.TrimSpace(*warehouseID))
+		_, _ = fmt.Fprintf(a.stdout, "Reversed depreciation run %s\n", run.ID)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown inventory warehouses subcommand %q", args[0])
+		return fmt.Errorf("unknown assets depreciation-runs subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runCostCenters(ctx context.Context, args []string) error {
+func (a *cliApp) runAssetCategories(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("cost-centers subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
+		return errors.New("assets categories subcommand required")
 	}
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("cost-centers list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets categories list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		activeOnly := fs.Bool("active-only", false, "List only active cost centers")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		costCenters, err := client.listCostCenters(ctx, cfg.TenantID, *activeOnly)
+		categories, err := client.listAssetCategories(ctx, cfg.TenantID)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, costCenters)
+			return printJSON(a.stdout, categories)
 		}
-		printCostCentersTable(a.stdout, costCenters)
+		printAssetCategoriesTable(a.stdout, categories)
 		return nil
 
 	case "create":
-		fs := flag.NewFlagSet("cost-centers create", flag.ContinueOnError)
+		fs := flag.NewFlagSet("assets categories create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		code := fs.String("code", "", "Cost center code")
-		name := fs.String("name", "", "Cost center name")
+		name := fs.String("name", "", "Category name")
 		description := fs.String("description", "", "Description")
-		parentID := fs.String("parent-id", "", "Parent cost center id")
-		active := fs.Bool("active", true, "Create as active")
-		budgetAmountFlag := fs.String("budget-amount", "", "Budget amount")
-		budgetPeriodFlag := fs.String("budget-period", "ANNUAL", "Budget period")
+		depreciationMethodFlag := fs.String("depreciation-method", "STRAIGHT_LINE", "Depreciation method")
+		usefulLifeMonthsFlag := fs.String("useful-life-months", "60", "Default useful life in months")
+		residualPercentFlag := fs.String("residual-percent", "0", "Default residual value percentage")
+		assetAccountID := fs.String("asset-account-id", "", "Asset account id")
+		depreciationExpenseAccountID := fs.String("depreciation-expense-account-id", "", "Depreciation expense account id")
+		accumulatedDepreciationAccountID := fs.String("accumulated-depreciation-account-id", "", "Accumulated depreciation account id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*code) == "" {
-			return errors.New("code is required")
-		}
 		if strings.TrimSpace(*name) == "" {
 			return errors.New("name is required")
 		}
-		budgetAmount, err := parseOptionalNonNegativeDecimalPtr("budget-amount", *budgetAmountFlag)
+		depreciationMethod, err := parseOptionalDepreciationMethod(*depreciationMethodFlag)
 		if err != nil {
 			return err
 		}
-		budgetPeriod, err := parseOptionalBudgetPeriod(*budgetPeriodFlag)
+		usefulLifeMonths, err := parseRequiredPositiveInt("useful-life-months", *usefulLifeMonthsFlag)
 		if err != nil {
 			return err
 		}
-		parentRef, err := optionalUUIDStringPtr("parent-id", *parentID)
+		residualPercent, err := parseRequiredNonNegativeDecimal("residual-percent", *residualPercentFlag)
 		if err != nil {
 			return err
 		}
 
-		costCenter, err := client.createCostCenter(ctx, cfg.TenantID, &accounting.CreateCostCenterRequest{
-			Code:         strings.TrimSpace(*code),
-			Name:         strings.TrimSpace(*name),
-			Description:  strings.TrimSpace(*description),
-			ParentID:     parentRef,
-			IsActive:     *active,
-			BudgetAmount: budgetAmount,
-			BudgetPeriod: budgetPeriod,
+		category, err := client.createAssetCategory(ctx, cfg.TenantID, &assets.CreateCategoryRequest{
+			Name:                          strings.TrimSpace(*name),
+			Description:                   strings.TrimSpace(*description),
+			DepreciationMethod:            depreciationMethod,
+			DefaultUsefulLifeMonths:       usefulLifeMonths,
+			DefaultResidualValuePercent:   residualPercent,
+			AssetAccountID:                optionalStringPtr(*assetAccountID),
+			DepreciationExpenseAccountID:  optionalStringPtr(*depreciationExpenseAccountID),
+			AccumulatedDepreciationAcctID: optionalStringPtr(*accumulatedDepreciationAccountID),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, costCenter)
+			return printJSON(a.stdout, category)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created cost center %s %s (%s)\n", costCenter.Code, costCenter.Name, costCenter.ID)
+		_, _ = fmt.Fprintf(a.stdout, "Created asset category %s (%s)\n", category.Name, category.ID)
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("cost-centers import", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("assets categories get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path or - for stdin")
+		categoryID := fs.String("id", "", "Category id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
-		}
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*categoryID) == "" {
+			return errors.New("id is required")
 		}
 
-		result, err := client.importCostCenters(ctx, cfg.TenantID, &accounting.ImportCostCentersRequest{
-			CSVContent: content,
-			FileName:   fileName,
-		})
+		category, err := client.getAssetCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, category)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d cost centers, skipped %d rows\n", result.RowsProcessed, result.CostCentersCreated, result.RowsSkipped)
+		printAssetCategory(a.stdout, category)
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("cost-centers get", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("assets categories delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		costCenterID := fs.String("id", "", "Cost center id")
+		categoryID := fs.String("id", "", "Category id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*costCenterID) == "" {
+		if strings.TrimSpace(*categoryID) == "" {
 			return errors.New("id is required")
 		}
 
-		costCenter, err := client.getCostCenter(ctx, cfg.TenantID, strings.TrimSpace(*costCenterID))
-		if err != nil {
+		if err := client.deleteAssetCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID)); err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, costCenter)
+			return printJSON(a.stdout, map[string]string{"status": "deleted"})
 		}
-		printCostCenter(a.stdout, costCenter)
+		_, _ = fmt.Fprintf(a.stdout, "Deleted asset category %s\n", strings.TrimSpace(*categoryID))
 		return nil
 
-	case "update":
-		fs := flag.NewFlagSet("cost-centers update", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown assets categories subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runInventory(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("inventory subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "categories":
+		return a.runInventoryCategories(ctx, cfg, client, args[1:])
+	case "products":
+		return a.runInventoryProducts(ctx, cfg, client, args[1:])
+	case "warehouses":
+		return a.runInventoryWarehouses(ctx, cfg, client, args[1:])
+	case "stock-counts":
+		return a.runInventoryStockCounts(ctx, cfg, client, args[1:])
+	case "boms":
+		return a.runInventoryBOMs(ctx, cfg, client, args[1:])
+	case "assembly-orders":
+		return a.runInventoryAssemblyOrders(ctx, cfg, client, args[1:])
+	case "stock":
+		return a.runInventoryStock(ctx, cfg, client, args[1:])
+	case "valuation":
+		fs := flag.NewFlagSet("inventory valuation", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		costCenterID := fs.String("id", "", "Cost center id")
-		code := fs.String("code", "", "Cost center code")
-		name := fs.String("name", "", "Cost center name")
-		description := fs.String("description", "", "Description")
-		parentID := fs.String("parent-id", "", "Parent cost center id")
-		active := fs.Bool("active", true, "Set active")
-		budgetAmountFlag := fs.String("budget-amount", "", "Budget amount")
-		budgetPeriodFlag := fs.String("budget-period", "ANNUAL", "Budget period")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		method := fs.String("method", "", "Valuation method: standard-cost, weighted-average, or fifo")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*costCenterID) == "" {
-			return errors.New("id is required")
+
+		report, err := client.getInventoryValuation(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID), strings.TrimSpace(*method))
+		if err != nil {
+			return err
 		}
-		if strings.TrimSpace(*code) == "" {
-			return errors.New("code is required")
+		if *asJSON {
+			return printJSON(a.stdout, report)
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
+		printInventoryValuation(a.stdout, report)
+		return nil
+	case "subledger-reconciliation":
+		fs := flag.NewFlagSet("inventory subledger-reconciliation", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		method := fs.String("method", "", "Valuation method: standard-cost, weighted-average, or fifo")
+		asOf := fs.String("as-of", "", "GL balance date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
 		}
-		budgetAmount, err := parseOptionalNonNegativeDecimalPtr("budget-amount", *budgetAmountFlag)
-		if err != nil {
+		if _, err := parseOptionalDate("as-of", *asOf); err != nil {
 			return err
 		}
-		budgetPeriod, err := parseOptionalBudgetPeriod(*budgetPeriodFlag)
+
+		report, err := client.getInventorySubledgerReconciliation(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID), strings.TrimSpace(*method), strings.TrimSpace(*asOf))
 		if err != nil {
 			return err
 		}
-		parentRef, err := optionalUUIDStringPtr("parent-id", *parentID)
-		if err != nil {
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printInventorySubledgerReconciliation(a.stdout, report)
+		return nil
+	case "lots":
+		fs := flag.NewFlagSet("inventory lots", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		productID := fs.String("product-id", "", "Product id")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		includeEmpty := fs.Bool("include-empty", false, "Include zero or negative lot positions")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		costCenter, err := client.updateCostCenter(ctx, cfg.TenantID, strings.TrimSpace(*costCenterID), &accounting.UpdateCostCenterRequest{
-			Code:         strings.TrimSpace(*code),
-			Name:         strings.TrimSpace(*name),
-			Description:  strings.TrimSpace(*description),
-			ParentID:     parentRef,
-			IsActive:     *active,
-			BudgetAmount: budgetAmount,
-			BudgetPeriod: budgetPeriod,
-		})
+		report, err := client.getInventoryLotReport(ctx, cfg.TenantID, strings.TrimSpace(*productID), strings.TrimSpace(*warehouseID), *includeEmpty)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, costCenter)
+			return printJSON(a.stdout, report)
 		}
-		printCostCenter(a.stdout, costCenter)
+		printInventoryLotReport(a.stdout, report)
 		return nil
-
-	case "delete":
-		fs := flag.NewFlagSet("cost-centers delete", flag.ContinueOnError)
+	case "aging":
+		fs := flag.NewFlagSet("inventory aging", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		costCenterID := fs.String("id", "", "Cost center id")
+		selection := bindInventoryAgingFlags(fs)
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*costCenterID) == "" {
-			return errors.New("id is required")
+		req, err := selection.request()
+		if err != nil {
+			return err
 		}
 
-		if err := client.deleteCostCenter(ctx, cfg.TenantID, strings.TrimSpace(*costCenterID)); err != nil {
+		report, err := client.getInventoryAgingReport(ctx, cfg.TenantID, req)
+		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, map[string]string{"status": "deleted"})
+			return printJSON(a.stdout, report)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted cost center %s\n", strings.TrimSpace(*costCenterID))
+		printInventoryAgingReport(a.stdout, report)
 		return nil
-
-	case "report":
-		fs := flag.NewFlagSet("cost-centers report", flag.ContinueOnError)
+	case "write-down":
+		fs := flag.NewFlagSet("inventory write-down", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		selection := bindInventoryAgingFlags(fs)
+		writeDownAccountID := fs.String("write-down-account-id", "", "Write-down expense account id")
+		allowanceAccountID := fs.String("allowance-account-id", "", "Inventory allowance (contra-asset) account id")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+		if strings.TrimSpace(*writeDownAccountID) == "" {
+			return errors.New("write-down-account-id is required")
+		}
+		if strings.TrimSpace(*allowanceAccountID) == "" {
+			return errors.New("allowance-account-id is required")
+		}
+		req, err := selection.request()
+		if err != nil {
 			return err
 		}
-		startDateValue, err := parseOptionalDate("start", *startDate)
+
+		proposal, err := client.proposeInventoryWriteDown(ctx, cfg.TenantID, &inventory.ProposeInventoryWriteDownRequest{
+			InventoryAgingRequest: *req,
+			WriteDownAccountID:    strings.TrimSpace(*writeDownAccountID),
+			AllowanceAccountID:    strings.TrimSpace(*allowanceAccountID),
+		})
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseOptionalDate("end", *endDate)
+		if *asJSON {
+			return printJSON(a.stdout, proposal)
+		}
+		printInventoryWriteDownProposal(a.stdout, proposal)
+		return nil
+	case "replenishment":
+		fs := flag.NewFlagSet("inventory replenishment", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		selection := bindReplenishmentFlags(fs)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		req, err := selection.request()
 		if err != nil {
 			return err
 		}
 
-		if *asCSV {
-			content, err := client.exportCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue, "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cost center report CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue, "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cost center report XLSX")
-		}
-		if *asPDF {
-			content, err := client.exportCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue, "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cost center report PDF")
-		}
-
-		report, err := client.getCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue)
-		if err != nil {
-			return err
+		report, err := client.getReplenishmentReport(ctx, cfg.TenantID, req)
+		if err != nil {
+			return err
 		}
 		if *asJSON {
 			return printJSON(a.stdout, report)
 		}
-		printCostCenterReport(a.stdout, report)
+		printReplenishmentReport(a.stdout, report)
 		return nil
-
-	case "allocations":
-		return a.runCostCenterAllocations(ctx, client, cfg.TenantID, args[1:])
-
-	default:
-		return fmt.Errorf("unknown cost-centers subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runCostCenterAllocations(ctx context.Context, client *apiClient, tenantID string, args []string) error {
-	if len(args) == 0 {
-		return errors.New("cost-centers allocations subcommand required")
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("cost-centers allocations list", flag.ContinueOnError)
+	case "replenishment-orders":
+		fs := flag.NewFlagSet("inventory replenishment-orders", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		costCenterID := fs.String("cost-center-id", "", "Filter by cost center id")
-		journalEntryLineID := fs.String("journal-entry-line-id", "", "Filter by journal entry line id")
-		startDate := fs.String("start", "", "Start allocation date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End allocation date in YYYY-MM-DD")
+		selection := bindReplenishmentFlags(fs)
+		orderDate := fs.String("order-date", "", "Purchase order date in YYYY-MM-DD (default today)")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		startDateValue, err := parseOptionalDate("start", *startDate)
+		req, err := selection.request()
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseOptionalDate("end", *endDate)
+		orderDateValue, err := parseOptionalDate("order-date", *orderDate)
 		if err != nil {
 			return err
 		}
-		if startDateValue != nil && endDateValue != nil && endDateValue.Before(*startDateValue) {
-			return errors.New("end must be on or after start")
+		createReq := &purchasing.CreateReplenishmentOrdersRequest{ReplenishmentRequest: *req}
+		if orderDateValue != nil {
+			createReq.OrderDate = *orderDateValue
 		}
-		costCenterFilter, err := optionalUUIDStringPtr("cost-center-id", *costCenterID)
+
+		result, err := client.createReplenishmentOrders(ctx, cfg.TenantID, createReq)
 		if err != nil {
 			return err
 		}
-		journalEntryLineFilter, err := optionalUUIDStringPtr("journal-entry-line-id", *journalEntryLineID)
-		if err != nil {
-			return err
+		if *asJSON {
+			return printJSON(a.stdout, result)
 		}
-		costCenterFilterValue := ""
-		if costCenterFilter != nil {
-			costCenterFilterValue = *costCenterFilter
+		printReplenishmentOrdersResult(a.stdout, result)
+		return nil
+	case "low-stock-event":
+		fs := flag.NewFlagSet("inventory low-stock-event", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		selection := bindReplenishmentFlags(fs)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
 		}
-		journalEntryLineFilterValue := ""
-		if journalEntryLineFilter != nil {
-			journalEntryLineFilterValue = *journalEntryLineFilter
+		req, err := selection.request()
+		if err != nil {
+			return err
 		}
 
-		allocations, err := client.listCostAllocations(ctx, tenantID, accounting.CostAllocationFilters{
-			CostCenterID:       costCenterFilterValue,
-			JournalEntryLineID: journalEntryLineFilterValue,
-			StartDate:          startDateValue,
-			EndDate:            endDateValue,
-		})
+		result, err := client.emitLowStockEvent(ctx, cfg.TenantID, req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, allocations)
+			return printJSON(a.stdout, result)
 		}
-		printCostAllocationsTable(a.stdout, allocations)
+		printLowStockEvent(a.stdout, result)
 		return nil
-
-	case "create":
-		fs := flag.NewFlagSet("cost-centers allocations create", flag.ContinueOnError)
+	case "adjust":
+		fs := flag.NewFlagSet("inventory adjust", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		costCenterID := fs.String("cost-center-id", "", "Cost center id")
-		journalEntryLineID := fs.String("journal-entry-line-id", "", "Journal entry line id")
-		amountFlag := fs.String("amount", "", "Positive allocation amount")
-		allocationPercentageFlag := fs.String("allocation-percentage", "", "Optional allocation percentage from 0 to 100")
-		allocationDate := fs.String("allocation-date", "", "Allocation date in YYYY-MM-DD")
-		notes := fs.String("notes", "", "Allocation notes")
+		productID := fs.String("product-id", "", "Product id")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		quantityFlag := fs.String("quantity", "", "Signed quantity adjustment")
+		unitCostFlag := fs.String("unit-cost", "0", "Unit cost")
+		lotNumber := fs.String("lot-number", "", "Lot or batch number")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		expiryDate := fs.String("expiry-date", "", "Lot expiry date in YYYY-MM-DD")
+		reason := fs.String("reason", "", "Adjustment reason")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*costCenterID) == "" {
-			return errors.New("cost-center-id is required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
 		}
-		if strings.TrimSpace(*journalEntryLineID) == "" {
-			return errors.New("journal-entry-line-id is required")
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
 		}
-		parsedCostCenterID, err := optionalUUIDStringPtr("cost-center-id", *costCenterID)
+		quantity, err := parseRequiredDecimal("quantity", *quantityFlag)
 		if err != nil {
 			return err
 		}
-		parsedJournalEntryLineID, err := optionalUUIDStringPtr("journal-entry-line-id", *journalEntryLineID)
+		if quantity.IsZero() {
+			return errors.New("quantity must not be zero")
+		}
+		unitCost, err := parseRequiredNonNegativeDecimal("unit-cost", *unitCostFlag)
 		if err != nil {
 			return err
 		}
-		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
-		if err != nil {
+		if _, err := parseOptionalDate("expiry-date", *expiryDate); err != nil {
 			return err
 		}
-		allocationPercentage, err := parseOptionalNonNegativeDecimalPtr("allocation-percentage", *allocationPercentageFlag)
+		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
 		if err != nil {
 			return err
 		}
-		if allocationPercentage != nil && allocationPercentage.GreaterThan(decimal.NewFromInt(100)) {
-			return errors.New("allocation-percentage must be between 0 and 100")
-		}
-		allocationDateValue, err := parseRequiredDate("allocation-date", *allocationDate)
+		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
 		if err != nil {
 			return err
 		}
 
-		allocation, err := client.createCostAllocation(ctx, tenantID, &accounting.CreateCostAllocationRequest{
-			CostCenterID:         *parsedCostCenterID,
-			JournalEntryLineID:   *parsedJournalEntryLineID,
-			Amount:               amount,
-			AllocationPercentage: allocationPercentage,
-			AllocationDate:       allocationDateValue,
-			Notes:                strings.TrimSpace(*notes),
+		movement, err := client.adjustStock(ctx, cfg.TenantID, &inventory.AdjustStockRequest{
+			ProductID:    productIDValue,
+			WarehouseID:  warehouseIDValue,
+			Quantity:     quantity.String(),
+			UnitCost:     unitCost.String(),
+			LotNumber:    strings.TrimSpace(*lotNumber),
+			SerialNumber: strings.TrimSpace(*serialNumber),
+			ExpiryDate:   strings.TrimSpace(*expiryDate),
+			Reason:       strings.TrimSpace(*reason),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, allocation)
+			return printJSON(a.stdout, movement)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created cost allocation %s for cost center %s\n", allocation.ID, allocation.CostCenterID)
+		_, _ = fmt.Fprintf(a.stdout, "Adjusted stock for product %s by %s in warehouse %s\n", productIDValue, quantity.String(), warehouseIDValue)
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("cost-centers allocations import", flag.ContinueOnError)
+	case "issue":
+		fs := flag.NewFlagSet("inventory issue", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path or - for stdin")
+		productID := fs.String("product-id", "", "Product id")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		quantityFlag := fs.String("quantity", "", "Quantity to issue")
+		costingMethod := fs.String("costing-method", "", "Costing method: lot, weighted-average, or standard-cost")
+		lotNumber := fs.String("lot-number", "", "Lot or batch number")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		expiryDate := fs.String("expiry-date", "", "Lot expiry date in YYYY-MM-DD")
+		reference := fs.String("reference", "", "Issue reference")
+		sourceType := fs.String("source-type", "", "Source type")
+		sourceID := fs.String("source-id", "", "Source id")
+		reason := fs.String("reason", "", "Issue reason")
+		cogsAccountID := fs.String("cogs-account-id", "", "Cost of goods sold account id")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory asset account id")
+		postToLedger := fs.Bool("post-to-ledger", false, "Create and post inventory issue accounting entry")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
 		}
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
 		}
-
-		result, err := client.importCostAllocations(ctx, tenantID, &accounting.ImportCostAllocationsRequest{
-			CSVContent: content,
-			FileName:   fileName,
-		})
+		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, imported %d cost allocations, skipped %d rows\n", result.RowsProcessed, result.AllocationsImported, result.RowsSkipped)
-		return nil
-
-	default:
-		return fmt.Errorf("unknown cost-centers allocations subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runAnalytics(ctx context.Context, args []string) error {
-	if len(args) == 0 {
-		return errors.New("analytics subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
-	}
-
-	switch args[0] {
-	case "dashboard":
-		fs := flag.NewFlagSet("analytics dashboard", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		if _, err := parseOptionalDate("expiry-date", *expiryDate); err != nil {
 			return err
 		}
-
-		summary, err := client.getDashboardSummary(ctx, cfg.TenantID)
+		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, summary)
-		}
-		printDashboardSummary(a.stdout, summary)
-		return nil
-
-	case "revenue-expense":
-		fs := flag.NewFlagSet("analytics revenue-expense", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		monthsFlag := fs.String("months", "12", "Number of months")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-		months, err := parseRequiredPositiveInt("months", *monthsFlag)
+		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
 		if err != nil {
 			return err
 		}
-
-		chart, err := client.getRevenueExpenseChart(ctx, cfg.TenantID, months)
+		sourceIDValue, err := optionalUUIDStringValue("source-id", *sourceID)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, chart)
-		}
-		printRevenueExpenseChart(a.stdout, chart)
-		return nil
-
-	case "cash-flow":
-		fs := flag.NewFlagSet("analytics cash-flow", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		monthsFlag := fs.String("months", "12", "Number of months")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		cogsAccountIDValue, err := optionalUUIDStringValue("cogs-account-id", *cogsAccountID)
+		if err != nil {
 			return err
 		}
-		months, err := parseRequiredPositiveInt("months", *monthsFlag)
+		inventoryAccountIDValue, err := optionalUUIDStringValue("inventory-account-id", *inventoryAccountID)
 		if err != nil {
 			return err
 		}
 
-		chart, err := client.getCashFlowChart(ctx, cfg.TenantID, months)
+		result, err := client.issueStock(ctx, cfg.TenantID, &inventory.IssueStockRequest{
+			ProductID:                productIDValue,
+			WarehouseID:              warehouseIDValue,
+			Quantity:                 quantity.String(),
+			CostingMethod:            strings.TrimSpace(*costingMethod),
+			LotNumber:                strings.TrimSpace(*lotNumber),
+			SerialNumber:             strings.TrimSpace(*serialNumber),
+			ExpiryDate:               strings.TrimSpace(*expiryDate),
+			Reference:                strings.TrimSpace(*reference),
+			SourceType:               strings.TrimSpace(*sourceType),
+			SourceID:                 sourceIDValue,
+			Reason:                   strings.TrimSpace(*reason),
+			CostOfGoodsSoldAccountID: cogsAccountIDValue,
+			InventoryAccountID:       inventoryAccountIDValue,
+			PostToLedger:             *postToLedger,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, chart)
+			return printJSON(a.stdout, result)
 		}
-		printCashFlowChart(a.stdout, chart)
-		return nil
-
-	case "activity":
-		fs := flag.NewFlagSet("analytics activity", flag.ContinueOnError)
+		_, _ = fmt.Fprintf(a.stdout, "Issued %s of product %s from warehouse %s at total cost %s\n", quantity.String(), productIDValue, warehouseIDValue, result.TotalCost.String())
+		if result.Accounting != nil && len(result.Accounting.Lines) > 0 {
+			if result.Accounting.Posted {
+				_, _ = fmt.Fprintf(a.stdout, "Posted accounting journal entry: %s\n", result.Accounting.JournalID)
+			} else {
+				_, _ = fmt.Fprintf(a.stdout, "Accounting lines prepared: %d\n", len(result.Accounting.Lines))
+			}
+		}
+		return nil
+
+	case "transfer":
+		fs := flag.NewFlagSet("inventory transfer", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		limitFlag := fs.String("limit", "10", "Number of activity items")
+		productID := fs.String("product-id", "", "Product id")
+		fromWarehouseID := fs.String("from-warehouse-id", "", "Source warehouse id")
+		toWarehouseID := fs.String("to-warehouse-id", "", "Destination warehouse id")
+		quantityFlag := fs.String("quantity", "", "Quantity to transfer")
+		lotNumber := fs.String("lot-number", "", "Lot or batch number")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		expiryDate := fs.String("expiry-date", "", "Lot expiry date in YYYY-MM-DD")
+		notes := fs.String("notes", "", "Transfer notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		limit, err := parseRequiredPositiveInt("limit", *limitFlag)
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
+		}
+		if strings.TrimSpace(*fromWarehouseID) == "" {
+			return errors.New("from-warehouse-id is required")
+		}
+		if strings.TrimSpace(*toWarehouseID) == "" {
+			return errors.New("to-warehouse-id is required")
+		}
+		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
 		if err != nil {
 			return err
 		}
-
-		activity, err := client.getRecentActivity(ctx, cfg.TenantID, limit)
+		if _, err := parseOptionalDate("expiry-date", *expiryDate); err != nil {
+			return err
+		}
+		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, activity)
+		fromWarehouseIDValue, err := optionalUUIDStringValue("from-warehouse-id", *fromWarehouseID)
+		if err != nil {
+			return err
 		}
-		printActivityItems(a.stdout, activity)
-		return nil
-
-	default:
-		return fmt.Errorf("unknown analytics subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runEmployees(ctx context.Context, args []string) error {
-	if len(args) == 0 {
-		return errors.New("employees subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("employees list", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		activeOnly := fs.Bool("active-only", false, "List only active employees")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		toWarehouseIDValue, err := optionalUUIDStringValue("to-warehouse-id", *toWarehouseID)
+		if err != nil {
 			return err
 		}
 
-		employees, err := client.listEmployees(ctx, cfg.TenantID, *activeOnly)
+		result, err := client.transferStock(ctx, cfg.TenantID, &inventory.TransferStockRequest{
+			ProductID:       productIDValue,
+			FromWarehouseID: fromWarehouseIDValue,
+			ToWarehouseID:   toWarehouseIDValue,
+			Quantity:        quantity.String(),
+			LotNumber:       strings.TrimSpace(*lotNumber),
+			SerialNumber:    strings.TrimSpace(*serialNumber),
+			ExpiryDate:      strings.TrimSpace(*expiryDate),
+			Notes:           strings.TrimSpace(*notes),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, employees)
+			return printJSON(a.stdout, result)
 		}
-		printEmployeesTable(a.stdout, employees)
+		_, _ = fmt.Fprintf(a.stdout, "Transferred %s of product %s from %s to %s\n", quantity.String(), productIDValue, fromWarehouseIDValue, toWarehouseIDValue)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("employees create", flag.ContinueOnError)
+	case "reserve":
+		fs := flag.NewFlagSet("inventory reserve", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeNumber := fs.String("employee-number", "", "Employee number")
-		firstName := fs.String("first-name", "", "First name")
-		lastName := fs.String("last-name", "", "Last name")
-		personalCode := fs.String("personal-code", "", "Personal code")
-		email := fs.String("email", "", "Email")
-		phone := fs.String("phone", "", "Phone")
-		address := fs.String("address", "", "Address")
-		bankAccount := fs.String("bank-account", "", "IBAN")
-		startDate := fs.String("start-date", "", "Employment start date in YYYY-MM-DD")
-		position := fs.String("position", "", "Position")
-		department := fs.String("department", "", "Department")
-		employmentType := fs.String("employment-type", "FULL_TIME", "Employment type: FULL_TIME, PART_TIME, CONTRACT")
-		applyBasicExemption := fs.Bool("apply-basic-exemption", true, "Apply basic exemption")
-		basicExemptionAmount := fs.String("basic-exemption-amount", "700.00", "Basic exemption amount")
-		fundedPensionRate := fs.String("funded-pension-rate", "0.02", "Funded pension rate")
+		productID := fs.String("product-id", "", "Product id")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		quantityFlag := fs.String("quantity", "", "Quantity to reserve")
+		lotNumber := fs.String("lot-number", "", "Lot or batch number")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		expiryDate := fs.String("expiry-date", "", "Expiry date in YYYY-MM-DD")
+		reason := fs.String("reason", "", "Reservation reason")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-
-		if strings.TrimSpace(*firstName) == "" || strings.TrimSpace(*lastName) == "" || strings.TrimSpace(*startDate) == "" {
-			return errors.New("first-name, last-name, and start-date are required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
 		}
-
-		parsedStartDate, err := time.Parse("2006-01-02", strings.TrimSpace(*startDate))
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
+		}
+		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
 		if err != nil {
-			return fmt.Errorf("parse start-date: %w", err)
+			return err
 		}
-
-		basicExemptionValue := decimal.Zero
-		if *applyBasicExemption {
-			basicExemptionValue, err = decimal.NewFromString(strings.TrimSpace(*basicExemptionAmount))
-			if err != nil {
-				return fmt.Errorf("parse basic-exemption-amount: %w", err)
-			}
+		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
+		if err != nil {
+			return err
 		}
-
-		fundedPensionValue := decimal.Zero
-		if trimmed := strings.TrimSpace(*fundedPensionRate); trimmed != "" {
-			fundedPensionValue, err = decimal.NewFromString(trimmed)
-			if err != nil {
-				return fmt.Errorf("parse funded-pension-rate: %w", err)
-			}
+		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
+		if err != nil {
+			return err
 		}
 
-		employee, err := client.createEmployee(ctx, cfg.TenantID, &payroll.CreateEmployeeRequest{
-			EmployeeNumber:       strings.TrimSpace(*employeeNumber),
-			FirstName:            strings.TrimSpace(*firstName),
-			LastName:             strings.TrimSpace(*lastName),
-			PersonalCode:         strings.TrimSpace(*personalCode),
-			Email:                strings.TrimSpace(*email),
-			Phone:                strings.TrimSpace(*phone),
-			Address:              strings.TrimSpace(*address),
-			BankAccount:          strings.TrimSpace(*bankAccount),
-			StartDate:            parsedStartDate,
-			Position:             strings.TrimSpace(*position),
-			Department:           strings.TrimSpace(*department),
-			EmploymentType:       payroll.EmploymentType(strings.ToUpper(strings.TrimSpace(*employmentType))),
-			ApplyBasicExemption:  *applyBasicExemption,
-			BasicExemptionAmount: basicExemptionValue,
-			FundedPensionRate:    fundedPensionValue,
+		level, err := client.reserveStock(ctx, cfg.TenantID, &inventory.StockReservationRequest{
+			ProductID:    productIDValue,
+			WarehouseID:  warehouseIDValue,
+			Quantity:     quantity.String(),
+			LotNumber:    strings.TrimSpace(*lotNumber),
+			SerialNumber: strings.TrimSpace(*serialNumber),
+			ExpiryDate:   strings.TrimSpace(*expiryDate),
+			Reason:       strings.TrimSpace(*reason),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, employee)
+			return printJSON(a.stdout, level)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created employee %s (%s)\n", employee.FullName(), employee.ID)
+		_, _ = fmt.Fprintf(a.stdout, "Reserved %s of product %s in warehouse %s\n", quantity.String(), productIDValue, warehouseIDValue)
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("employees get", flag.ContinueOnError)
+	case "release":
+		fs := flag.NewFlagSet("inventory release", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("id", "", "Employee id")
+		productID := fs.String("product-id", "", "Product id")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		quantityFlag := fs.String("quantity", "", "Quantity to release")
+		lotNumber := fs.String("lot-number", "", "Lot or batch number")
+		serialNumber := fs.String("serial-number", "", "Serial number")
+		expiryDate := fs.String("expiry-date", "", "Expiry date in YYYY-MM-DD")
+		reason := fs.String("reason", "", "Release reason")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("id is required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
+		}
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
+		}
+		quantity, err := parseRequiredPositiveDecimal("quantity", *quantityFlag)
+		if err != nil {
+			return err
+		}
+		productIDValue, err := optionalUUIDStringValue("product-id", *productID)
+		if err != nil {
+			return err
+		}
+		warehouseIDValue, err := optionalUUIDStringValue("warehouse-id", *warehouseID)
+		if err != nil {
+			return err
 		}
 
-		employee, err := client.getEmployee(ctx, cfg.TenantID, strings.TrimSpace(*employeeID))
+		level, err := client.releaseStock(ctx, cfg.TenantID, &inventory.StockReservationRequest{
+			ProductID:    productIDValue,
+			WarehouseID:  warehouseIDValue,
+			Quantity:     quantity.String(),
+			LotNumber:    strings.TrimSpace(*lotNumber),
+			SerialNumber: strings.TrimSpace(*serialNumber),
+			ExpiryDate:   strings.TrimSpace(*expiryDate),
+			Reason:       strings.TrimSpace(*reason),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, employee)
+			return printJSON(a.stdout, level)
 		}
-		printEmployee(a.stdout, employee)
+		_, _ = fmt.Fprintf(a.stdout, "Released %s of product %s in warehouse %s\n", quantity.String(), productIDValue, warehouseIDValue)
 		return nil
 
-	case "update":
-		fs := flag.NewFlagSet("employees update", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown inventory subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runInventoryStock(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("inventory stock subcommand required")
+	}
+
+	switch args[0] {
+	case "import":
+		fs := flag.NewFlagSet("inventory stock import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("id", "", "Employee id")
-		employeeNumber := fs.String("employee-number", "", "Employee number")
-		firstName := fs.String("first-name", "", "First name")
-		lastName := fs.String("last-name", "", "Last name")
-		personalCode := fs.String("personal-code", "", "Personal code")
-		email := fs.String("email", "", "Email")
-		phone := fs.String("phone", "", "Phone")
-		address := fs.String("address", "", "Address")
-		bankAccount := fs.String("bank-account", "", "IBAN")
-		endDate := fs.String("end-date", "", "Employment end date in YYYY-MM-DD")
-		position := fs.String("position", "", "Position")
-		department := fs.String("department", "", "Department")
-		employmentType := fs.String("employment-type", "", "Employment type: FULL_TIME, PART_TIME, CONTRACT")
-		applyBasicExemption := fs.String("apply-basic-exemption", "", "Apply basic exemption: true or false")
-		basicExemptionAmount := fs.String("basic-exemption-amount", "", "Basic exemption amount")
-		fundedPensionRate := fs.String("funded-pension-rate", "", "Funded pension rate")
-		active := fs.String("active", "", "Set active state: true or false")
+		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("id is required")
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
 		}
 
-		req := &payroll.UpdateEmployeeRequest{
-			EmployeeNumber: strings.TrimSpace(*employeeNumber),
-			FirstName:      strings.TrimSpace(*firstName),
-			LastName:       strings.TrimSpace(*lastName),
-			PersonalCode:   strings.TrimSpace(*personalCode),
-			Email:          strings.TrimSpace(*email),
-			Phone:          strings.TrimSpace(*phone),
-			Address:        strings.TrimSpace(*address),
-			BankAccount:    strings.TrimSpace(*bankAccount),
-			Position:       strings.TrimSpace(*position),
-			Department:     strings.TrimSpace(*department),
-		}
-		if strings.TrimSpace(*employmentType) != "" {
-			req.EmploymentType = payroll.EmploymentType(strings.ToUpper(strings.TrimSpace(*employmentType)))
-		}
-		if strings.TrimSpace(*endDate) != "" {
-			parsed, err := parseRequiredDate("end-date", *endDate)
-			if err != nil {
-				return err
-			}
-			req.EndDate = &parsed
-		}
-		if strings.TrimSpace(*applyBasicExemption) != "" {
-			parsed, err := strconv.ParseBool(strings.TrimSpace(*applyBasicExemption))
-			if err != nil {
-				return fmt.Errorf("parse apply-basic-exemption: %w", err)
-			}
-			req.ApplyBasicExemption = &parsed
-		}
-		if strings.TrimSpace(*basicExemptionAmount) != "" {
-			parsed, err := decimal.NewFromString(strings.TrimSpace(*basicExemptionAmount))
-			if err != nil {
-				return fmt.Errorf("parse basic-exemption-amount: %w", err)
-			}
-			req.BasicExemptionAmount = &parsed
-		}
-		if strings.TrimSpace(*fundedPensionRate) != "" {
-			parsed, err := decimal.NewFromString(strings.TrimSpace(*fundedPensionRate))
-			if err != nil {
-				return fmt.Errorf("parse funded-pension-rate: %w", err)
-			}
-			req.FundedPensionRate = &parsed
-		}
-		if strings.TrimSpace(*active) != "" {
-			parsed, err := strconv.ParseBool(strings.TrimSpace(*active))
-			if err != nil {
-				return fmt.Errorf("parse active: %w", err)
-			}
-			req.IsActive = &parsed
-		}
-
-		employee, err := client.updateEmployee(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), req)
-		if err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, employee)
-		}
-		printEmployee(a.stdout, employee)
-		return nil
-
-	case "set-salary":
-		fs := flag.NewFlagSet("employees set-salary", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		employeeID := fs.String("id", "", "Employee id")
-		amountFlag := fs.String("amount", "", "Base salary amount")
-		effectiveFrom := fs.String("effective-from", "", "Effective date in YYYY-MM-DD")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("id is required")
-		}
-		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
-		if err != nil {
-			return err
-		}
-		effectiveFromValue, err := parseRequiredDate("effective-from", *effectiveFrom)
+		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-
-		result, err := client.setBaseSalary(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), amount, effectiveFromValue)
+		result, err := client.importStockAdjustments(ctx, cfg.TenantID, &inventory.ImportStockAdjustmentsRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Set base salary for employee %s to %s\n", strings.TrimSpace(*employeeID), amount.String())
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, imported %d stock adjustments, skipped %d rows\n", result.RowsProcessed, result.AdjustmentsImported, result.RowsSkipped)
 		return nil
 
-	case "salary-components":
-		fs := flag.NewFlagSet("employees salary-components", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown inventory stock subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runInventoryCategories(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("inventory categories subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("inventory categories list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("id", "", "Employee id")
-		activeOn := fs.String("active-on", "", "Filter components active on date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("id is required")
-		}
-		activeOnValue := strings.TrimSpace(*activeOn)
-		if activeOnValue != "" {
-			if _, err := parseRequiredDate("active-on", activeOnValue); err != nil {
-				return err
-			}
-		}
 
-		components, err := client.listSalaryComponents(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), activeOnValue)
+		categories, err := client.listProductCategories(ctx, cfg.TenantID)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, components)
+			return printJSON(a.stdout, categories)
 		}
-		printSalaryComponentsTable(a.stdout, components)
+		printProductCategoriesTable(a.stdout, categories)
 		return nil
 
-	case "add-salary-component":
-		fs := flag.NewFlagSet("employees add-salary-component", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("inventory categories create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("id", "", "Employee id")
-		componentType := fs.String("type", payroll.SalaryComponentSecondaryEmployment, "Component type: SECONDARY_EMPLOYMENT, BONUS, COMMISSION, BENEFIT")
-		name := fs.String("name", "", "Component name")
-		amountFlag := fs.String("amount", "", "Component amount")
-		effectiveFrom := fs.String("effective-from", "", "Effective date in YYYY-MM-DD")
-		effectiveTo := fs.String("effective-to", "", "Optional end date in YYYY-MM-DD")
-		isTaxable := fs.Bool("taxable", true, "Component is taxable")
-		isRecurring := fs.Bool("recurring", true, "Component is recurring")
+		name := fs.String("name", "", "Category name")
+		description := fs.String("description", "", "Description")
+		parentID := fs.String("parent-id", "", "Parent category id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("id is required")
-		}
-		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
-		effectiveFromValue, err := parseRequiredDate("effective-from", *effectiveFrom)
+		parsedParentID, err := optionalUUIDStringPtr("parent-id", *parentID)
 		if err != nil {
 			return err
 		}
-		effectiveToValue, err := parseOptionalDate("effective-to", *effectiveTo)
-		if err != nil {
-			return err
+		parentIDValue := ""
+		if parsedParentID != nil {
+			parentIDValue = *parsedParentID
 		}
-		taxableValue := *isTaxable
-		recurringValue := *isRecurring
 
-		component, err := client.addSalaryComponent(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), &payroll.CreateSalaryComponentRequest{
-			ComponentType: strings.ToUpper(strings.TrimSpace(*componentType)),
-			Name:          strings.TrimSpace(*name),
-			Amount:        amount,
-			IsTaxable:     &taxableValue,
-			IsRecurring:   &recurringValue,
-			EffectiveFrom: effectiveFromValue,
-			EffectiveTo:   effectiveToValue,
+		category, err := client.createProductCategory(ctx, cfg.TenantID, &inventory.CreateCategoryRequest{
+			Name:        strings.TrimSpace(*name),
+			Description: strings.TrimSpace(*description),
+			ParentID:    parentIDValue,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, component)
+			return printJSON(a.stdout, category)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Added salary component %s (%s) for employee %s\n", component.Name, component.ID, strings.TrimSpace(*employeeID))
+		_, _ = fmt.Fprintf(a.stdout, "Created product category %s (%s)\n", category.Name, category.ID)
 		return nil
 
 	case "import":
-		fs := flag.NewFlagSet("employees import", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory categories import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		filePath := fs.String("file", "", "CSV file path or - for stdin")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
@@ -9630,14 +10226,14 @@ func (a *cliApp) runEmployees(ctx context.Context, args []string) error {
 		if strings.TrimSpace(*filePath) == "" {
 			return errors.New("file is required")
 		}
-
 		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-		result, err := client.importEmployees(ctx, cfg.TenantID, &payroll.ImportEmployeesRequest{
-			FileName:   fileName,
+
+		result, err := client.importProductCategories(ctx, cfg.TenantID, &inventory.ImportProductCategoriesRequest{
 			CSVContent: content,
+			FileName:   fileName,
 		})
 		if err != nil {
 			return err
@@ -9645,232 +10241,213 @@ func (a *cliApp) runEmployees(ctx context.Context, args []string) error {
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d employees, set %d salaries, skipped %d rows\n",
-			result.RowsProcessed,
-			result.EmployeesCreated,
-			result.SalariesCreated,
-			result.RowsSkipped,
-		)
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d product categories, skipped %d rows\n", result.RowsProcessed, result.CategoriesCreated, result.RowsSkipped)
 		return nil
 
-	default:
-		return fmt.Errorf("unknown employees subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runLeave(ctx context.Context, args []string) error {
-	if len(args) == 0 {
-		return errors.New("leave subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
-	}
-
-	switch args[0] {
-	case "absence-types":
-		return a.runLeaveAbsenceTypes(ctx, cfg, client, args[1:])
-	case "balances":
-		return a.runLeaveBalances(ctx, cfg, client, args[1:])
-	case "records":
-		return a.runLeaveRecords(ctx, cfg, client, args[1:])
-	default:
-		return fmt.Errorf("unknown leave subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runLeaveAbsenceTypes(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
-	if len(args) == 0 {
-		return errors.New("leave absence-types subcommand required")
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("leave absence-types list", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("inventory categories get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		activeOnly := fs.Bool("active-only", false, "List only active absence types")
+		categoryID := fs.String("id", "", "Category id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*categoryID) == "" {
+			return errors.New("id is required")
+		}
 
-		types, err := client.listAbsenceTypes(ctx, cfg.TenantID, *activeOnly)
+		category, err := client.getProductCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, types)
+			return printJSON(a.stdout, category)
 		}
-		printAbsenceTypesTable(a.stdout, types)
+		printProductCategory(a.stdout, category)
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("leave absence-types get", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("inventory categories delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		typeID := fs.String("id", "", "Absence type id")
+		categoryID := fs.String("id", "", "Category id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*typeID) == "" {
+		if strings.TrimSpace(*categoryID) == "" {
 			return errors.New("id is required")
 		}
 
-		absenceType, err := client.getAbsenceType(ctx, cfg.TenantID, strings.TrimSpace(*typeID))
+		result, err := client.deleteProductCategory(ctx, cfg.TenantID, strings.TrimSpace(*categoryID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, absenceType)
+			return printJSON(a.stdout, result)
 		}
-		printAbsenceType(a.stdout, absenceType)
+		_, _ = fmt.Fprintf(a.stdout, "Deleted product category %s\n", strings.TrimSpace(*categoryID))
 		return nil
 
 	default:
-		return fmt.Errorf("unknown leave absence-types subcommand %q", args[0])
+		return fmt.Errorf("unknown inventory categories subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runLeaveBalances(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+func (a *cliApp) runInventoryProducts(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("leave balances subcommand required")
+		return errors.New("inventory products subcommand required")
 	}
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("leave balances list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory products list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("employee-id", "", "Employee id")
-		yearFlag := fs.String("year", "", "Balance year")
+		typeFlag := fs.String("type", "", "Product type")
+		statusFlag := fs.String("status", "", "Product status")
+		categoryID := fs.String("category-id", "", "Category id")
+		search := fs.String("search", "", "Search term")
+		lowStock := fs.Bool("low-stock", false, "List products below reorder threshold")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("employee-id is required")
+		productType, err := parseOptionalProductType(*typeFlag)
+		if err != nil {
+			return err
 		}
-		year, err := parseOptionalInt(*yearFlag)
+		status, err := parseOptionalProductStatus(*statusFlag)
+		if err != nil {
+			return err
+		}
+		categoryIDValue, err := optionalUUIDStringValue("category-id", *categoryID)
 		if err != nil {
 			return err
 		}
 
-		balances, err := client.listLeaveBalances(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
+		products, err := client.listProducts(ctx, cfg.TenantID, inventory.ProductFilter{
+			ProductType: productType,
+			Status:      status,
+			CategoryID:  categoryIDValue,
+			Search:      strings.TrimSpace(*search),
+			LowStock:    *lowStock,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, balances)
+			return printJSON(a.stdout, products)
 		}
-		printLeaveBalancesTable(a.stdout, balances)
+		printProductsTable(a.stdout, products)
 		return nil
 
-	case "by-year":
-		fs := flag.NewFlagSet("leave balances by-year", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("inventory products create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("employee-id", "", "Employee id")
-		yearFlag := fs.String("year", "", "Balance year")
+		code := fs.String("code", "", "Product code")
+		name := fs.String("name", "", "Product name")
+		description := fs.String("description", "", "Description")
+		typeFlag := fs.String("type", string(inventory.ProductTypeGoods), "Product type")
+		categoryID := fs.String("category-id", "", "Category id")
+		unit := fs.String("unit", "pcs", "Unit of measure")
+		purchasePriceFlag := fs.String("purchase-price", "0", "Purchase price")
+		salesPriceFlag := fs.String("sales-price", "", "Sales price")
+		vatRateFlag := fs.String("vat-rate", "22", "VAT rate")
+		minStockLevelFlag := fs.String("min-stock-level", "0", "Minimum stock level")
+		reorderPointFlag := fs.String("reorder-point", "0", "Reorder point")
+		saleAccountID := fs.String("sale-account-id", "", "Sale account id")
+		purchaseAccountID := fs.String("purchase-account-id", "", "Purchase account id")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory account id")
+		trackInventory := fs.Bool("track-inventory", true, "Track inventory for this product")
+		barcode := fs.String("barcode", "", "Barcode")
+		supplierID := fs.String("supplier-id", "", "Supplier id")
+		leadTimeDaysFlag := fs.String("lead-time-days", "0", "Lead time in days")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("employee-id is required")
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
-		year, err := parseRequiredPositiveInt("year", *yearFlag)
+		productType, err := parseRequiredProductType(*typeFlag)
 		if err != nil {
 			return err
 		}
-
-		balances, err := client.getLeaveBalancesByYear(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
+		purchasePrice, err := parseRequiredNonNegativeDecimal("purchase-price", *purchasePriceFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, balances)
-		}
-		printLeaveBalancesTable(a.stdout, balances)
-		return nil
-
-	case "update":
-		fs := flag.NewFlagSet("leave balances update", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		employeeID := fs.String("employee-id", "", "Employee id")
-		absenceTypeID := fs.String("absence-type-id", "", "Absence type id")
-		yearFlag := fs.String("year", "", "Balance year")
-		entitledDaysFlag := fs.String("entitled-days", "", "Entitled days")
-		carryoverDaysFlag := fs.String("carryover-days", "", "Carryover days")
-		notes := fs.String("notes", "", "Notes")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		salesPrice, err := parseRequiredNonNegativeDecimal("sales-price", *salesPriceFlag)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("employee-id is required")
-		}
-		if strings.TrimSpace(*absenceTypeID) == "" {
-			return errors.New("absence-type-id is required")
-		}
-		year, err := parseRequiredPositiveInt("year", *yearFlag)
+		vatRate, err := parseRequiredNonNegativeDecimal("vat-rate", *vatRateFlag)
 		if err != nil {
 			return err
 		}
-		entitledDays, err := parseOptionalNonNegativeDecimalPtr("entitled-days", *entitledDaysFlag)
+		minStockLevel, err := parseRequiredNonNegativeDecimal("min-stock-level", *minStockLevelFlag)
 		if err != nil {
 			return err
 		}
-		carryoverDays, err := parseOptionalNonNegativeDecimalPtr("carryover-days", *carryoverDaysFlag)
+		reorderPoint, err := parseRequiredNonNegativeDecimal("reorder-point", *reorderPointFlag)
 		if err != nil {
 			return err
 		}
-		if entitledDays == nil && carryoverDays == nil && strings.TrimSpace(*notes) == "" {
-			return errors.New("entitled-days, carryover-days, or notes is required")
+		leadTimeDays, err := parseRequiredNonNegativeInt("lead-time-days", *leadTimeDaysFlag)
+		if err != nil {
+			return err
 		}
-
-		balance, err := client.updateLeaveBalance(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year, strings.TrimSpace(*absenceTypeID), &payroll.UpdateLeaveBalanceRequest{
-			EntitledDays:  entitledDays,
-			CarryoverDays: carryoverDays,
-			Notes:         strings.TrimSpace(*notes),
-		})
+		categoryIDValue, err := optionalUUIDStringValue("category-id", *categoryID)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, balance)
+		saleAccountIDValue, err := optionalUUIDStringValue("sale-account-id", *saleAccountID)
+		if err != nil {
+			return err
 		}
-		printLeaveBalancesTable(a.stdout, []payroll.LeaveBalance{*balance})
-		return nil
-
-	case "initialize":
-		fs := flag.NewFlagSet("leave balances initialize", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		employeeID := fs.String("employee-id", "", "Employee id")
-		yearFlag := fs.String("year", "", "Balance year")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		purchaseAccountIDValue, err := optionalUUIDStringValue("purchase-account-id", *purchaseAccountID)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("employee-id is required")
+		inventoryAccountIDValue, err := optionalUUIDStringValue("inventory-account-id", *inventoryAccountID)
+		if err != nil {
+			return err
 		}
-		year, err := parseRequiredPositiveInt("year", *yearFlag)
+		supplierIDValue, err := optionalUUIDStringValue("supplier-id", *supplierID)
 		if err != nil {
 			return err
 		}
 
-		balances, err := client.initializeLeaveBalances(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
+		product, err := client.createProduct(ctx, cfg.TenantID, &inventory.CreateProductRequest{
+			Code:               strings.TrimSpace(*code),
+			Name:               strings.TrimSpace(*name),
+			Description:        strings.TrimSpace(*description),
+			ProductType:        string(productType),
+			CategoryID:         categoryIDValue,
+			Unit:               strings.TrimSpace(*unit),
+			PurchasePrice:      purchasePrice.String(),
+			SalesPrice:         salesPrice.String(),
+			VATRate:            vatRate.String(),
+			MinStockLevel:      minStockLevel.String(),
+			ReorderPoint:       reorderPoint.String(),
+			SaleAccountID:      saleAccountIDValue,
+			PurchaseAccountID:  purchaseAccountIDValue,
+			InventoryAccountID: inventoryAccountIDValue,
+			TrackInventory:     *trackInventory,
+			Barcode:            strings.TrimSpace(*barcode),
+			SupplierID:         supplierIDValue,
+			LeadTimeDays:       leadTimeDays,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, balances)
+			return printJSON(a.stdout, product)
 		}
-		printLeaveBalancesTable(a.stdout, balances)
+		_, _ = fmt.Fprintf(a.stdout, "Created product %s %s (%s)\n", product.Code, product.Name, product.ID)
 		return nil
 
 	case "import":
-		fs := flag.NewFlagSet("leave balances import", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory products import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
 		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
@@ -9885,7 +10462,7 @@ func (a *cliApp) runLeaveBalances(ctx context.Context, cfg *cliConfig, client *a
 		if err != nil {
 			return err
 		}
-		result, err := client.importLeaveBalances(ctx, cfg.TenantID, &payroll.ImportLeaveBalancesRequest{
+		result, err := client.importProducts(ctx, cfg.TenantID, &inventory.ImportProductsRequest{
 			FileName:   fileName,
 			CSVContent: content,
 		})
@@ -9895,403 +10472,354 @@ func (a *cliApp) runLeaveBalances(ctx context.Context, cfg *cliConfig, client *a
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d leave balances, updated %d leave balances, skipped %d rows\n",
-			result.RowsProcessed,
-			result.LeaveBalancesCreated,
-			result.LeaveBalancesUpdated,
-			result.RowsSkipped,
-		)
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d products, skipped %d rows\n", result.RowsProcessed, result.ProductsCreated, result.RowsSkipped)
 		return nil
 
-	default:
-		return fmt.Errorf("unknown leave balances subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runLeaveRecords(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
-	if len(args) == 0 {
-		return errors.New("leave records subcommand required")
-	}
-
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("leave records list", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("inventory products get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("employee-id", "", "Employee id")
-		yearFlag := fs.String("year", "", "Record year")
+		productID := fs.String("id", "", "Product id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, err := parseOptionalInt(*yearFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("id is required")
 		}
 
-		records, err := client.listLeaveRecords(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
+		product, err := client.getProduct(ctx, cfg.TenantID, strings.TrimSpace(*productID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, records)
+			return printJSON(a.stdout, product)
 		}
-		printLeaveRecordsTable(a.stdout, records)
+		printProduct(a.stdout, product)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("leave records create", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("inventory products update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		employeeID := fs.String("employee-id", "", "Employee id")
-		absenceTypeID := fs.String("absence-type-id", "", "Absence type id")
-		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
-		totalDaysFlag := fs.String("total-days", "", "Total calendar days")
-		workingDaysFlag := fs.String("working-days", "", "Working days")
-		documentNumber := fs.String("document-number", "", "Document number")
-		documentDate := fs.String("document-date", "", "Document date in YYYY-MM-DD")
-		notes := fs.String("notes", "", "Notes")
+		productID := fs.String("id", "", "Product id")
+		name := fs.String("name", "", "Product name")
+		description := fs.String("description", "", "Description")
+		categoryID := fs.String("category-id", "", "Category id")
+		unit := fs.String("unit", "pcs", "Unit of measure")
+		purchasePriceFlag := fs.String("purchase-price", "0", "Purchase price")
+		salesPriceFlag := fs.String("sales-price", "", "Sales price")
+		vatRateFlag := fs.String("vat-rate", "22", "VAT rate")
+		minStockLevelFlag := fs.String("min-stock-level", "0", "Minimum stock level")
+		reorderPointFlag := fs.String("reorder-point", "0", "Reorder point")
+		saleAccountID := fs.String("sale-account-id", "", "Sale account id")
+		purchaseAccountID := fs.String("purchase-account-id", "", "Purchase account id")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory account id")
+		trackInventory := fs.Bool("track-inventory", true, "Track inventory for this product")
+		active := fs.Bool("active", true, "Set active")
+		barcode := fs.String("barcode", "", "Barcode")
+		supplierID := fs.String("supplier-id", "", "Supplier id")
+		leadTimeDaysFlag := fs.String("lead-time-days", "0", "Lead time in days")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*employeeID) == "" {
-			return errors.New("employee-id is required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*absenceTypeID) == "" {
-			return errors.New("absence-type-id is required")
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
-		start, err := parseRequiredDate("start-date", *startDate)
+		purchasePrice, err := parseRequiredNonNegativeDecimal("purchase-price", *purchasePriceFlag)
 		if err != nil {
 			return err
 		}
-		end, err := parseRequiredDate("end-date", *endDate)
+		salesPrice, err := parseRequiredNonNegativeDecimal("sales-price", *salesPriceFlag)
 		if err != nil {
 			return err
 		}
-		totalDays, err := parseRequiredPositiveDecimal("total-days", *totalDaysFlag)
+		vatRate, err := parseRequiredNonNegativeDecimal("vat-rate", *vatRateFlag)
 		if err != nil {
 			return err
 		}
-		workingDays, err := parseRequiredPositiveDecimal("working-days", *workingDaysFlag)
+		minStockLevel, err := parseRequiredNonNegativeDecimal("min-stock-level", *minStockLevelFlag)
 		if err != nil {
 			return err
 		}
-		docDate, err := parseOptionalDate("document-date", *documentDate)
+		reorderPoint, err := parseRequiredNonNegativeDecimal("reorder-point", *reorderPointFlag)
 		if err != nil {
 			return err
 		}
-
-		record, err := client.createLeaveRecord(ctx, cfg.TenantID, &payroll.CreateLeaveRecordRequest{
-			EmployeeID:     strings.TrimSpace(*employeeID),
-			AbsenceTypeID:  strings.TrimSpace(*absenceTypeID),
-			StartDate:      start,
-			EndDate:        end,
-			TotalDays:      totalDays,
-			WorkingDays:    workingDays,
-			DocumentNumber: strings.TrimSpace(*documentNumber),
-			DocumentDate:   docDate,
-			Notes:          strings.TrimSpace(*notes),
-		})
+		leadTimeDays, err := parseRequiredNonNegativeInt("lead-time-days", *leadTimeDaysFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, record)
+		categoryIDValue, err := optionalUUIDStringValue("category-id", *categoryID)
+		if err != nil {
+			return err
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created leave record %s\n", record.ID)
-		return nil
-
-	case "get":
-		fs := flag.NewFlagSet("leave records get", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		recordID := fs.String("id", "", "Leave record id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		saleAccountIDValue, err := optionalUUIDStringValue("sale-account-id", *saleAccountID)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recordID) == "" {
-			return errors.New("id is required")
+		purchaseAccountIDValue, err := optionalUUIDStringValue("purchase-account-id", *purchaseAccountID)
+		if err != nil {
+			return err
+		}
+		inventoryAccountIDValue, err := optionalUUIDStringValue("inventory-account-id", *inventoryAccountID)
+		if err != nil {
+			return err
+		}
+		supplierIDValue, err := optionalUUIDStringValue("supplier-id", *supplierID)
+		if err != nil {
+			return err
 		}
 
-		record, err := client.getLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID))
+		product, err := client.updateProduct(ctx, cfg.TenantID, strings.TrimSpace(*productID), &inventory.UpdateProductRequest{
+			Name:               strings.TrimSpace(*name),
+			Description:        strings.TrimSpace(*description),
+			CategoryID:         categoryIDValue,
+			Unit:               strings.TrimSpace(*unit),
+			PurchasePrice:      purchasePrice.String(),
+			SalesPrice:         salesPrice.String(),
+			VATRate:            vatRate.String(),
+			MinStockLevel:      minStockLevel.String(),
+			ReorderPoint:       reorderPoint.String(),
+			SaleAccountID:      saleAccountIDValue,
+			PurchaseAccountID:  purchaseAccountIDValue,
+			InventoryAccountID: inventoryAccountIDValue,
+			TrackInventory:     *trackInventory,
+			IsActive:           *active,
+			Barcode:            strings.TrimSpace(*barcode),
+			SupplierID:         supplierIDValue,
+			LeadTimeDays:       leadTimeDays,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, record)
+			return printJSON(a.stdout, product)
 		}
-		printLeaveRecord(a.stdout, record)
+		printProduct(a.stdout, product)
 		return nil
 
-	case "approve":
-		fs := flag.NewFlagSet("leave records approve", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("inventory products delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recordID := fs.String("id", "", "Leave record id")
+		productID := fs.String("id", "", "Product id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recordID) == "" {
+		if strings.TrimSpace(*productID) == "" {
 			return errors.New("id is required")
 		}
 
-		record, err := client.approveLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID))
+		result, err := client.deleteProduct(ctx, cfg.TenantID, strings.TrimSpace(*productID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, record)
+			return printJSON(a.stdout, result)
 		}
-		printLeaveRecord(a.stdout, record)
+		_, _ = fmt.Fprintf(a.stdout, "Deleted product %s\n", strings.TrimSpace(*productID))
 		return nil
 
-	case "reject":
-		fs := flag.NewFlagSet("leave records reject", flag.ContinueOnError)
+	case "stock-levels":
+		fs := flag.NewFlagSet("inventory products stock-levels", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recordID := fs.String("id", "", "Leave record id")
-		reason := fs.String("reason", "", "Rejection reason")
+		productID := fs.String("id", "", "Product id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recordID) == "" {
+		if strings.TrimSpace(*productID) == "" {
 			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*reason) == "" {
-			return errors.New("reason is required")
-		}
 
-		record, err := client.rejectLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID), &payroll.RejectLeaveRequest{Reason: strings.TrimSpace(*reason)})
+		levels, err := client.listStockLevels(ctx, cfg.TenantID, strings.TrimSpace(*productID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, record)
+			return printJSON(a.stdout, levels)
 		}
-		printLeaveRecord(a.stdout, record)
+		printStockLevelsTable(a.stdout, levels)
 		return nil
 
-	case "cancel":
-		fs := flag.NewFlagSet("leave records cancel", flag.ContinueOnError)
+	case "movements":
+		fs := flag.NewFlagSet("inventory products movements", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		recordID := fs.String("id", "", "Leave record id")
+		productID := fs.String("id", "", "Product id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*recordID) == "" {
+		if strings.TrimSpace(*productID) == "" {
 			return errors.New("id is required")
 		}
 
-		record, err := client.cancelLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID))
+		movements, err := client.listInventoryMovements(ctx, cfg.TenantID, strings.TrimSpace(*productID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, record)
+			return printJSON(a.stdout, movements)
 		}
-		printLeaveRecord(a.stdout, record)
+		printInventoryMovementsTable(a.stdout, movements)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown leave records subcommand %q", args[0])
+		return fmt.Errorf("unknown inventory products subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runJournal(ctx context.Context, args []string) error {
+func (a *cliApp) runInventoryStockCounts(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("journal subcommand required")
-	}
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
+		return errors.New("inventory stock-counts subcommand required")
 	}
 
 	switch args[0] {
-	case "templates":
-		return a.runJournalTemplates(ctx, cfg, client, args[1:])
-
 	case "list":
-		fs := flag.NewFlagSet("journal list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory stock-counts list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		limitFlag := fs.String("limit", "50", "Maximum entries to return")
+		statusFlag := fs.String("status", "", "Stock count status")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		limit, err := parseRequiredPositiveInt("limit", *limitFlag)
-		if err != nil {
-			return err
-		}
-		if limit > 200 {
-			return errors.New("limit must be between 1 and 200")
-		}
-
-		entries, err := client.listJournalEntries(ctx, cfg.TenantID, limit)
+		status, err := parseOptionalStockCountStatus(*statusFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, entries)
-		}
-		printJournalEntriesTable(a.stdout, entries)
-		return nil
-
-	case "get":
-		fs := flag.NewFlagSet("journal get", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		entryID := fs.String("id", "", "Journal entry id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
-		}
-		if strings.TrimSpace(*entryID) == "" {
-			return errors.New("id is required")
-		}
 
-		entry, err := client.getJournalEntry(ctx, cfg.TenantID, strings.TrimSpace(*entryID))
+		counts, err := client.listStockCounts(ctx, cfg.TenantID, stocktake.StockCountFilter{
+			Status:      status,
+			WarehouseID: strings.TrimSpace(*warehouseID),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, entry)
+			return printJSON(a.stdout, counts)
 		}
-		printJournalEntry(a.stdout, entry)
+		printStockCountsTable(a.stdout, counts)
 		return nil
 
 	case "create":
-		fs := flag.NewFlagSet("journal create", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory stock-counts create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entryDate := fs.String("entry-date", "", "Entry date in YYYY-MM-DD")
-		description := fs.String("description", "", "Journal entry description")
-		reference := fs.String("reference", "", "Reference")
-		sourceType := fs.String("source-type", "", "Source type")
-		sourceID := fs.String("source-id", "", "Source id")
-		requiresEvidence := fs.Bool("requires-evidence", false, "Require approved evidence before posting")
-		lines := journalLineFlags{}
-		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		countDate := fs.String("count-date", "", "Count date in YYYY-MM-DD (default today)")
+		method := fs.String("method", "", "Valuation method: standard-cost, weighted-average, or fifo (default tenant policy)")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		entryDateValue, err := parseRequiredDate("entry-date", *entryDate)
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
+		}
+		countDateValue, err := parseOptionalDate("count-date", *countDate)
 		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*description) == "" {
-			return errors.New("description is required")
-		}
-		if len(lines) < 2 {
-			return errors.New("at least two lines are required")
+		req := &stocktake.CreateStockCountRequest{
+			WarehouseID:     strings.TrimSpace(*warehouseID),
+			ValuationMethod: strings.TrimSpace(*method),
+			Notes:           strings.TrimSpace(*notes),
 		}
-		parsedSourceID, err := optionalUUIDStringPtr("source-id", *sourceID)
-		if err != nil {
-			return err
+		if countDateValue != nil {
+			req.CountDate = *countDateValue
 		}
 
-		entry, err := client.createJournalEntry(ctx, cfg.TenantID, &accounting.CreateJournalEntryRequest{
-			EntryDate:        entryDateValue,
-			Description:      strings.TrimSpace(*description),
-			Reference:        strings.TrimSpace(*reference),
-			SourceType:       strings.TrimSpace(*sourceType),
-			SourceID:         parsedSourceID,
-			RequiresEvidence: *requiresEvidence,
-			Lines:            []accounting.CreateJournalEntryLineReq(lines),
-		})
+		count, err := client.createStockCount(ctx, cfg.TenantID, req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, entry)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Created journal entry %s (%s)\n", entry.EntryNumber, entry.ID)
-		if entry.RequiresEvidence {
-			_, _ = fmt.Fprintln(a.stdout, "Approved evidence required before posting")
+			return printJSON(a.stdout, count)
 		}
+		_, _ = fmt.Fprintf(a.stdout, "Created stock count %s (%s) with %d expected lines\n", count.CountNumber, count.ID, len(count.Lines))
 		return nil
 
-	case "post":
-		fs := flag.NewFlagSet("journal post", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("inventory stock-counts get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entryID := fs.String("id", "", "Journal entry id")
-		reason := fs.String("reason", "", "Reason for posting")
+		countID := fs.String("id", "", "Stock count id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*entryID) == "" {
+		if strings.TrimSpace(*countID) == "" {
 			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*reason) == "" {
-			return errors.New("reason is required")
-		}
 
-		result, err := client.postJournalEntry(ctx, cfg.TenantID, strings.TrimSpace(*entryID), strings.TrimSpace(*reason))
+		count, err := client.getStockCount(ctx, cfg.TenantID, strings.TrimSpace(*countID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, count)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Posted journal entry %s\n", strings.TrimSpace(*entryID))
+		printStockCount(a.stdout, count)
 		return nil
 
-	case "void":
-		fs := flag.NewFlagSet("journal void", flag.ContinueOnError)
+	case "count":
+		fs := flag.NewFlagSet("inventory stock-counts count", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entryID := fs.String("id", "", "Journal entry id")
-		reason := fs.String("reason", "", "Void reason")
+		countID := fs.String("id", "", "Stock count id")
+		accumulate := fs.Bool("accumulate", false, "Add to quantities counted earlier instead of replacing them")
+		entries := stockCountEntryFlags{}
+		fs.Var(&entries, "entry", "Count as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*entryID) == "" {
+		if strings.TrimSpace(*countID) == "" {
 			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*reason) == "" {
-			return errors.New("reason is required")
+		if len(entries) == 0 {
+			return errors.New("at least one entry is required")
 		}
 
-		reversal, err := client.voidJournalEntry(ctx, cfg.TenantID, strings.TrimSpace(*entryID), strings.TrimSpace(*reason))
+		count, err := client.recordStockCounts(ctx, cfg.TenantID, strings.TrimSpace(*countID), &stocktake.RecordCountsRequest{
+			Counts:     []stocktake.CountEntry(entries),
+			Accumulate: *accumulate,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, reversal)
+			return printJSON(a.stdout, count)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Voided journal entry %s with reversal %s\n", strings.TrimSpace(*entryID), reversal.EntryNumber)
+		_, _ = fmt.Fprintf(a.stdout, "Recorded %d counts on stock count %s, %d lines uncounted\n", len(entries), count.CountNumber, count.UncountedLines())
 		return nil
 
-	case "import-opening-balances":
-		fs := flag.NewFlagSet("journal import-opening-balances", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("inventory stock-counts import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
-		entryDate := fs.String("entry-date", "", "Entry date in YYYY-MM-DD")
-		description := fs.String("description", "Opening balances", "Journal entry description")
-		reference := fs.String("reference", fmt.Sprintf("OB-%d", time.Now().Year()), "Reference")
+		countID := fs.String("id", "", "Stock count id")
+		filePath := fs.String("file", "", "Scanner CSV file path or - for stdin")
+		accumulate := fs.Bool("accumulate", false, "Add to quantities counted earlier instead of replacing them")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*countID) == "" {
+			return errors.New("id is required")
+		}
 		if strings.TrimSpace(*filePath) == "" {
 			return errors.New("file is required")
 		}
-		if strings.TrimSpace(*entryDate) == "" {
-			return errors.New("entry-date is required")
-		}
-
 		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-		result, err := client.importOpeningBalances(ctx, cfg.TenantID, &accounting.ImportOpeningBalancesRequest{
-			FileName:    fileName,
-			EntryDate:   strings.TrimSpace(*entryDate),
-			Description: strings.TrimSpace(*description),
-			Reference:   strings.TrimSpace(*reference),
-			CSVContent:  content,
+
+		result, err := client.importStockCounts(ctx, cfg.TenantID, strings.TrimSpace(*countID), &stocktake.ImportCountsRequest{
+			CSVContent: content,
+			FileName:   fileName,
+			Accumulate: *accumulate,
 		})
 		if err != nil {
 			return err
@@ -10299,557 +10827,622 @@ func (a *cliApp) runJournal(ctx context.Context, args []string) error {
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Created posted journal entry %s with %d lines, debit %s, credit %s\n",
-			result.JournalEntry.EntryNumber,
-			result.LinesImported,
-			result.TotalDebit.String(),
-			result.TotalCredit.String(),
-		)
+		printStockCountImportResult(a.stdout, result)
 		return nil
 
-	case "import":
-		fs := flag.NewFlagSet("journal import", flag.ContinueOnError)
+	case "submit", "cancel":
+		fs := flag.NewFlagSet("inventory stock-counts "+args[0], flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
-		sourceType := fs.String("source-type", "", "Default source type")
-		postEntries := fs.Bool("post", false, "Post imported entries immediately")
+		countID := fs.String("id", "", "Stock count id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*countID) == "" {
+			return errors.New("id is required")
 		}
 
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
-		}
-		result, err := client.importJournalEntries(ctx, cfg.TenantID, &accounting.ImportJournalEntriesRequest{
-			FileName:    fileName,
-			CSVContent:  content,
-			SourceType:  strings.TrimSpace(*sourceType),
-			PostEntries: *postEntries,
-		})
+		result, err := client.updateStockCountStatus(ctx, cfg.TenantID, strings.TrimSpace(*countID), args[0])
 		if err != nil {
 			return err
 		}
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d journal entries, imported %d lines, skipped %d rows\n",
-			result.RowsProcessed,
-			result.EntriesCreated,
-			result.LinesImported,
-			result.RowsSkipped,
-		)
+		_, _ = fmt.Fprintf(a.stdout, "Stock count %s is %s\n", strings.TrimSpace(*countID), result["status"])
+		return nil
+
+	case "approve":
+		fs := flag.NewFlagSet("inventory stock-counts approve", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		countID := fs.String("id", "", "Stock count id")
+		varianceAccountID := fs.String("variance-account-id", "", "Stock variance EXPENSE account id")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory ASSET account id for products without one")
+		zeroUncounted := fs.Bool("zero-uncounted", false, "Treat uncounted lines as counted at zero")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*countID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*varianceAccountID) == "" {
+			return errors.New("variance-account-id is required")
+		}
+
+		count, err := client.approveStockCount(ctx, cfg.TenantID, strings.TrimSpace(*countID), &stocktake.ApproveStockCountRequest{
+			VarianceAccountID:  strings.TrimSpace(*varianceAccountID),
+			InventoryAccountID: strings.TrimSpace(*inventoryAccountID),
+			ZeroUncounted:      *zeroUncounted,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, count)
+		}
+		journalEntryID := ""
+		if count.JournalEntryID != nil {
+			journalEntryID = *count.JournalEntryID
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Approved stock count %s, variance journal %s\n", count.CountNumber, formatOptionalString(journalEntryID))
+		return nil
+
+	case "variance":
+		fs := flag.NewFlagSet("inventory stock-counts variance", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		countID := fs.String("id", "", "Stock count id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*countID) == "" {
+			return errors.New("id is required")
+		}
+
+		if *asCSV {
+			content, err := client.exportStockCountVariance(ctx, cfg.TenantID, strings.TrimSpace(*countID), "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "stock count variance CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportStockCountVariance(ctx, cfg.TenantID, strings.TrimSpace(*countID), "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "stock count variance XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportStockCountVariance(ctx, cfg.TenantID, strings.TrimSpace(*countID), "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "stock count variance PDF")
+		}
+
+		variance, err := client.getStockCountVariance(ctx, cfg.TenantID, strings.TrimSpace(*countID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, variance)
+		}
+		printStockCountVariance(a.stdout, variance)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown journal subcommand %q", args[0])
+		return fmt.Errorf("unknown inventory stock-counts subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runJournalTemplates(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+func (a *cliApp) runInventoryBOMs(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("journal templates subcommand required")
+		return errors.New("inventory boms subcommand required")
 	}
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("journal templates list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory boms list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		activeOnly := fs.Bool("active-only", false, "Only show active templates")
+		bomTypeFlag := fs.String("type", "", "Bill of materials type: assembly or kit")
+		productID := fs.String("product-id", "", "Finished product id")
+		activeOnly := fs.Bool("active-only", false, "List only active bills of materials")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		bomType, err := parseOptionalBOMType(*bomTypeFlag)
+		if err != nil {
+			return err
+		}
 
-		templates, err := client.listJournalEntryTemplates(ctx, cfg.TenantID, *activeOnly)
+		boms, err := client.listBOMs(ctx, cfg.TenantID, assembly.BOMFilter{
+			BOMType:    bomType,
+			ProductID:  strings.TrimSpace(*productID),
+			ActiveOnly: *activeOnly,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, templates)
+			return printJSON(a.stdout, boms)
 		}
-		printJournalEntryTemplatesTable(a.stdout, templates)
+		printBOMsTable(a.stdout, boms)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("journal templates create", flag.ContinueOnError)
+	case "create", "update":
+		fs := flag.NewFlagSet("inventory boms "+args[0], flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		name := fs.String("name", "", "Template name")
-		description := fs.String("description", "", "Journal entry description")
-		reference := fs.String("reference", "", "Default reference")
-		requiresEvidence := fs.Bool("requires-evidence", false, "Require approved evidence before posting generated entries")
-		frequencyFlag := fs.String("frequency", "", "Recurring frequency: WEEKLY, BIWEEKLY, MONTHLY, QUARTERLY, YEARLY")
-		startDate := fs.String("start-date", "", "Recurring start date in YYYY-MM-DD")
-		endDate := fs.String("end-date", "", "Recurring end date in YYYY-MM-DD")
-		nextGenerationDate := fs.String("next-generation-date", "", "Next recurring generation date in YYYY-MM-DD")
-		lines := journalLineFlags{}
-		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		bomID := fs.String("id", "", "Bill of materials id (update only)")
+		productID := fs.String("product-id", "", "Finished product id (create only)")
+		bomTypeFlag := fs.String("type", "", "Bill of materials type: assembly or kit (default assembly)")
+		outputQuantity := fs.String("output-quantity", "", "Quantity made by the component quantities (default 1)")
+		labourCost := fs.String("labour-cost", "", "Labour cost per output quantity")
+		overheadCost := fs.String("overhead-cost", "", "Overhead cost per output quantity")
+		activeFlag := fs.String("active", "", "Set active true or false (update only)")
+		notes := fs.String("notes", "", "Notes")
+		components := bomComponentFlags{}
+		fs.Var(&components, "component", "Component as comma-separated key=value pairs; repeatable")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*name) == "" {
-			return errors.New("name is required")
+		if args[0] == "create" && strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
 		}
-		if strings.TrimSpace(*description) == "" {
-			return errors.New("description is required")
+		if args[0] == "update" && strings.TrimSpace(*bomID) == "" {
+			return errors.New("id is required")
 		}
-		if len(lines) < 2 {
-			return errors.New("at least two lines are required")
+		if len(components) == 0 {
+			return errors.New("at least one component is required")
 		}
-		frequency, err := parseOptionalJournalTemplateFrequency(*frequencyFlag)
+		bomType, err := parseOptionalBOMType(*bomTypeFlag)
 		if err != nil {
 			return err
 		}
-		startDateValue, err := parseOptionalDate("start-date", *startDate)
+		outputQuantityValue, err := parseOptionalNonNegativeDecimalPtr("output-quantity", *outputQuantity)
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseOptionalDate("end-date", *endDate)
+		labourCostValue, err := parseOptionalNonNegativeDecimalPtr("labour-cost", *labourCost)
 		if err != nil {
 			return err
 		}
-		nextGenerationDateValue, err := parseOptionalDate("next-generation-date", *nextGenerationDate)
+		overheadCostValue, err := parseOptionalNonNegativeDecimalPtr("overhead-cost", *overheadCost)
+		if err != nil {
+			return err
+		}
+		active, err := parseOptionalBoolPtr("active", *activeFlag)
 		if err != nil {
 			return err
 		}
 
-		template, err := client.createJournalEntryTemplate(ctx, cfg.TenantID, &accounting.CreateJournalEntryTemplateRequest{
-			Name:               strings.TrimSpace(*name),
-			Description:        strings.TrimSpace(*description),
-			Reference:          strings.TrimSpace(*reference),
-			RequiresEvidence:   *requiresEvidence,
-			Frequency:          frequency,
-			StartDate:          startDateValue,
-			EndDate:            endDateValue,
-			NextGenerationDate: nextGenerationDateValue,
-			Lines:              []accounting.CreateJournalEntryLineReq(lines),
-		})
+		var bom *assembly.BillOfMaterials
+		if args[0] == "create" {
+			bom, err = client.createBOM(ctx, cfg.TenantID, &assembly.CreateBOMRequest{
+				ProductID:      strings.TrimSpace(*productID),
+				BOMType:        bomType,
+				OutputQuantity: decimalValueOrZero(outputQuantityValue),
+				LabourCost:     decimalValueOrZero(labourCostValue),
+				OverheadCost:   decimalValueOrZero(overheadCostValue),
+				Notes:          strings.TrimSpace(*notes),
+				Lines:          []assembly.CreateBOMLineRequest(components),
+			})
+		} else {
+			bom, err = client.updateBOM(ctx, cfg.TenantID, strings.TrimSpace(*bomID), &assembly.UpdateBOMRequest{
+				BOMType:        bomType,
+				OutputQuantity: decimalValueOrZero(outputQuantityValue),
+				LabourCost:     decimalValueOrZero(labourCostValue),
+				OverheadCost:   decimalValueOrZero(overheadCostValue),
+				IsActive:       active,
+				Notes:          strings.TrimSpace(*notes),
+				Lines:          []assembly.CreateBOMLineRequest(components),
+			})
+		}
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, template)
+			return printJSON(a.stdout, bom)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created journal entry template %s (%s)\n", template.Name, template.ID)
+		verb := "Created"
+		if args[0] == "update" {
+			verb = "Updated"
+		}
+		_, _ = fmt.Fprintf(a.stdout, "%s %s bill of materials %s with %d components\n", verb, bom.BOMType, bom.ID, len(bom.Lines))
 		return nil
 
 	case "get":
-		fs := flag.NewFlagSet("journal templates get", flag.ContinueOnError)
+		fs := flag.NewFlagSet("inventory boms get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		templateID := fs.String("id", "", "Journal entry template id")
+		bomID := fs.String("id", "", "Bill of materials id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*templateID) == "" {
+		if strings.TrimSpace(*bomID) == "" {
 			return errors.New("id is required")
 		}
 
-		template, err := client.getJournalEntryTemplate(ctx, cfg.TenantID, strings.TrimSpace(*templateID))
+		bom, err := client.getBOM(ctx, cfg.TenantID, strings.TrimSpace(*bomID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, template)
+			return printJSON(a.stdout, bom)
 		}
-		printJournalEntryTemplate(a.stdout, template)
+		printBOM(a.stdout, bom)
 		return nil
 
-	case "generate":
-		fs := flag.NewFlagSet("journal templates generate", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("inventory boms delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		templateID := fs.String("id", "", "Journal entry template id")
-		entryDate := fs.String("entry-date", "", "Override entry date in YYYY-MM-DD")
-		postEntry := fs.Bool("post", false, "Post the generated entry immediately")
+		bomID := fs.String("id", "", "Bill of materials id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*templateID) == "" {
+		if strings.TrimSpace(*bomID) == "" {
 			return errors.New("id is required")
 		}
-		entryDateValue, err := parseOptionalDate("entry-date", *entryDate)
-		if err != nil {
-			return err
-		}
 
-		result, err := client.generateJournalEntryTemplate(ctx, cfg.TenantID, strings.TrimSpace(*templateID), &accounting.GenerateJournalEntryTemplateRequest{
-			EntryDate: entryDateValue,
-			Post:      *postEntry,
-		})
+		result, err := client.deleteBOM(ctx, cfg.TenantID, strings.TrimSpace(*bomID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		printJournalEntryTemplateGenerationResults(a.stdout, []accounting.JournalEntryTemplateGenerationResult{*result})
+		_, _ = fmt.Fprintf(a.stdout, "Deleted bill of materials %s\n", strings.TrimSpace(*bomID))
 		return nil
 
-	case "generate-due":
-		fs := flag.NewFlagSet("journal templates generate-due", flag.ContinueOnError)
+	case "explode":
+		fs := flag.NewFlagSet("inventory boms explode", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		asOfDate := fs.String("as-of", "", "Generate templates due on or before YYYY-MM-DD")
-		postEntries := fs.Bool("post", false, "Post generated entries immediately")
+		bomID := fs.String("id", "", "Bill of materials id")
+		quantity := fs.String("quantity", "", "Quantity of the finished product (default output quantity)")
+		method := fs.String("method", "", "Valuation method: standard-cost, weighted-average, or fifo (default tenant policy)")
 		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		asOfDateValue, err := parseOptionalDate("as-of", *asOfDate)
-		if err != nil {
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
 			return err
 		}
-
-		results, err := client.generateDueJournalEntryTemplates(ctx, cfg.TenantID, &accounting.GenerateDueJournalEntryTemplatesRequest{
-			AsOfDate: asOfDateValue,
-			Post:     *postEntries,
-		})
-		if err != nil {
-			return err
+		if strings.TrimSpace(*bomID) == "" {
+			return errors.New("id is required")
 		}
-		if *asJSON {
-			return printJSON(a.stdout, results)
+		if strings.TrimSpace(*quantity) != "" {
+			if _, err := parseRequiredPositiveDecimal("quantity", *quantity); err != nil {
+				return err
+			}
 		}
-		printJournalEntryTemplateGenerationResults(a.stdout, results)
-		return nil
 
-	case "apply":
-		fs := flag.NewFlagSet("journal templates apply", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		templateID := fs.String("id", "", "Journal entry template id")
-		entryDate := fs.String("entry-date", "", "Entry date in YYYY-MM-DD")
-		description := fs.String("description", "", "Override generated entry description")
-		reference := fs.String("reference", "", "Override generated entry reference")
-		postEntry := fs.Bool("post", false, "Post the generated entry immediately")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
-			return err
+		if *asCSV {
+			content, err := client.exportBOMExplosion(ctx, cfg.TenantID, strings.TrimSpace(*bomID), *quantity, *method, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "bill of materials explosion CSV")
 		}
-		if strings.TrimSpace(*templateID) == "" {
-			return errors.New("id is required")
+		if *asXLSX {
+			content, err := client.exportBOMExplosion(ctx, cfg.TenantID, strings.TrimSpace(*bomID), *quantity, *method, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "bill of materials explosion XLSX")
 		}
-		entryDateValue, err := parseRequiredDate("entry-date", *entryDate)
-		if err != nil {
-			return err
+		if *asPDF {
+			content, err := client.exportBOMExplosion(ctx, cfg.TenantID, strings.TrimSpace(*bomID), *quantity, *method, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "bill of materials explosion PDF")
 		}
 
-		entry, err := client.applyJournalEntryTemplate(ctx, cfg.TenantID, strings.TrimSpace(*templateID), &accounting.ApplyJournalEntryTemplateRequest{
-			EntryDate:   entryDateValue,
-			Description: strings.TrimSpace(*description),
-			Reference:   strings.TrimSpace(*reference),
-			Post:        *postEntry,
-		})
+		explosion, err := client.getBOMExplosion(ctx, cfg.TenantID, strings.TrimSpace(*bomID), *quantity, *method)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, entry)
-		}
-		_, _ = fmt.Fprintf(a.stdout, "Created journal entry %s (%s) from template %s\n", entry.EntryNumber, entry.ID, strings.TrimSpace(*templateID))
-		if entry.Status == accounting.StatusPosted {
-			_, _ = fmt.Fprintln(a.stdout, "Generated entry was posted")
+			return printJSON(a.stdout, explosion)
 		}
+		printBOMExplosion(a.stdout, explosion)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown journal templates subcommand %q", args[0])
+		return fmt.Errorf("unknown inventory boms subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runPayroll(ctx context.Context, args []string) error {
+func (a *cliApp) runInventoryAssemblyOrders(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
 	if len(args) == 0 {
-		return errors.New("payroll subcommand required")
-	}
-
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
+		return errors.New("inventory assembly-orders subcommand required")
 	}
 
 	switch args[0] {
-	case "runs":
-		return a.runPayrollRuns(ctx, cfg, client, args[1:])
-
-	case "tax-preview":
-		fs := flag.NewFlagSet("payroll tax-preview", flag.ContinueOnError)
+	case "list":
+		fs := flag.NewFlagSet("inventory assembly-orders list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		grossSalary := fs.String("gross-salary", "", "Gross salary")
-		applyBasicExemption := fs.Bool("apply-basic-exemption", true, "Apply basic exemption")
-		fundedPensionRate := fs.String("funded-pension-rate", "0.02", "Funded pension rate")
+		statusFlag := fs.String("status", "", "Assembly order status")
+		typeFlag := fs.String("type", "", "Order type: assembly or disassembly")
+		productID := fs.String("product-id", "", "Finished product id")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		grossSalaryValue, err := decimal.NewFromString(strings.TrimSpace(*grossSalary))
-		if err != nil || grossSalaryValue.LessThanOrEqual(decimal.Zero) {
-			return errors.New("gross-salary must be a positive decimal")
+		status, err := parseOptionalAssemblyOrderStatus(*statusFlag)
+		if err != nil {
+			return err
 		}
-		fundedPensionValue, err := decimal.NewFromString(strings.TrimSpace(*fundedPensionRate))
+		orderType, err := parseOptionalAssemblyOrderType(*typeFlag)
 		if err != nil {
-			return fmt.Errorf("parse funded-pension-rate: %w", err)
+			return err
 		}
 
-		calculation, err := client.calculateTaxPreview(ctx, cfg.TenantID, grossSalaryValue, *applyBasicExemption, fundedPensionValue)
+		list, err := client.listAssemblyOrders(ctx, cfg.TenantID, assembly.AssemblyOrderFilter{
+			Status:      status,
+			OrderType:   orderType,
+			ProductID:   strings.TrimSpace(*productID),
+			WarehouseID: strings.TrimSpace(*warehouseID),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, calculation)
+			return printJSON(a.stdout, list)
 		}
-		printTaxCalculation(a.stdout, calculation)
+		printAssemblyOrdersTable(a.stdout, list)
 		return nil
 
-	case "import-history":
-		fs := flag.NewFlagSet("payroll import-history", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("inventory assembly-orders create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		productID := fs.String("product-id", "", "Finished product id")
+		typeFlag := fs.String("type", "", "Order type: assembly or disassembly (default assembly)")
+		warehouseID := fs.String("warehouse-id", "", "Warehouse id")
+		quantity := fs.String("quantity", "", "Quantity of the finished product")
+		orderDate := fs.String("order-date", "", "Order date in YYYY-MM-DD (default today)")
+		lotNumber := fs.String("lot", "", "Lot number of the assembled product")
+		serialNumber := fs.String("serial", "", "Serial number of the assembled product")
+		expiryDate := fs.String("expiry-date", "", "Expiry date of the assembled product in YYYY-MM-DD")
+		labourCost := fs.String("labour-cost", "", "Labour cost (default from the bill of materials)")
+		overheadCost := fs.String("overhead-cost", "", "Overhead cost (default from the bill of materials)")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*productID) == "" {
+			return errors.New("product-id is required")
 		}
-
-		content, fileName, err := readCSVInput(*filePath)
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("warehouse-id is required")
+		}
+		orderType, err := parseOptionalAssemblyOrderType(*typeFlag)
 		if err != nil {
 			return err
 		}
-		result, err := client.importPayrollHistory(ctx, cfg.TenantID, &payroll.ImportPayrollHistoryRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		quantityValue, err := parseRequiredPositiveDecimal("quantity", *quantity)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d payroll runs, created %d payslips, skipped %d rows\n",
-			result.RowsProcessed,
-			result.PayrollRunsCreated,
-			result.PayslipsCreated,
-			result.RowsSkipped,
-		)
-		return nil
-	case "import-leave-balances":
-		fs := flag.NewFlagSet("payroll import-leave-balances", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		orderDateValue, err := parseOptionalDate("order-date", *orderDate)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*expiryDate) != "" {
+			if _, err := parseRequiredDate("expiry-date", *expiryDate); err != nil {
+				return err
+			}
 		}
-
-		content, fileName, err := readCSVInput(*filePath)
+		labourCostValue, err := parseOptionalNonNegativeDecimalPtr("labour-cost", *labourCost)
 		if err != nil {
 			return err
 		}
-		result, err := client.importLeaveBalances(ctx, cfg.TenantID, &payroll.ImportLeaveBalancesRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		overheadCostValue, err := parseOptionalNonNegativeDecimalPtr("overhead-cost", *overheadCost)
+		if err != nil {
+			return err
+		}
+		req := &assembly.CreateAssemblyOrderRequest{
+			ProductID:    strings.TrimSpace(*productID),
+			OrderType:    orderType,
+			WarehouseID:  strings.TrimSpace(*warehouseID),
+			Quantity:     quantityValue,
+			LotNumber:    strings.TrimSpace(*lotNumber),
+			SerialNumber: strings.TrimSpace(*serialNumber),
+			ExpiryDate:   strings.TrimSpace(*expiryDate),
+			LabourCost:   labourCostValue,
+			OverheadCost: overheadCostValue,
+			Notes:        strings.TrimSpace(*notes),
+		}
+		if orderDateValue != nil {
+			req.OrderDate = *orderDateValue
+		}
+
+		order, err := client.createAssemblyOrder(ctx, cfg.TenantID, req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, order)
 		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d leave balances, updated %d leave balances, skipped %d rows\n",
-			result.RowsProcessed,
-			result.LeaveBalancesCreated,
-			result.LeaveBalancesUpdated,
-			result.RowsSkipped,
-		)
+		_, _ = fmt.Fprintf(a.stdout, "Created %s order %s (%s) with %d component lines\n", strings.ToLower(string(order.OrderType)), order.OrderNumber, order.ID, len(order.Lines))
 		return nil
-	default:
-		return fmt.Errorf("unknown payroll subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) runPayrollRuns(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
-	if len(args) == 0 {
-		return errors.New("payroll runs subcommand required")
-	}
 
-	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("payroll runs list", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("inventory assembly-orders get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Optional period year")
+		orderID := fs.String("id", "", "Assembly order id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year := 0
-		if strings.TrimSpace(*yearFlag) != "" {
-			parsed, err := parseRequiredPositiveInt("year", *yearFlag)
-			if err != nil {
-				return err
-			}
-			year = parsed
+		if strings.TrimSpace(*orderID) == "" {
+			return errors.New("id is required")
 		}
 
-		runs, err := client.listPayrollRuns(ctx, cfg.TenantID, year)
+		order, err := client.getAssemblyOrder(ctx, cfg.TenantID, strings.TrimSpace(*orderID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, runs)
+			return printJSON(a.stdout, order)
 		}
-		printPayrollRunsTable(a.stdout, runs)
+		printAssemblyOrder(a.stdout, order)
 		return nil
 
-	case "create":
-		fs := flag.NewFlagSet("payroll runs create", flag.ContinueOnError)
+	case "complete":
+		fs := flag.NewFlagSet("inventory assembly-orders complete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Period year")
-		monthFlag := fs.String("month", "", "Period month")
-		paymentDate := fs.String("payment-date", "", "Optional payment date in YYYY-MM-DD")
-		notes := fs.String("notes", "", "Optional notes")
+		orderID := fs.String("id", "", "Assembly order id")
+		method := fs.String("method", "", "Issue costing method: lot, standard-cost, or weighted-average (default tenant policy)")
+		absorptionAccountID := fs.String("absorption-account-id", "", "Account credited with absorbed labour and overhead")
+		inventoryAccountID := fs.String("inventory-account-id", "", "Inventory ASSET account id for products without one")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
-		if err != nil {
-			return err
-		}
-		var paymentDateValue *time.Time
-		if strings.TrimSpace(*paymentDate) != "" {
-			parsed, err := time.Parse("2006-01-02", strings.TrimSpace(*paymentDate))
-			if err != nil {
-				return fmt.Errorf("parse payment-date: %w", err)
-			}
-			paymentDateValue = &parsed
+		if strings.TrimSpace(*orderID) == "" {
+			return errors.New("id is required")
 		}
 
-		run, err := client.createPayrollRun(ctx, cfg.TenantID, &payroll.CreatePayrollRunRequest{
-			PeriodYear:  year,
-			PeriodMonth: month,
-			PaymentDate: paymentDateValue,
-			Notes:       strings.TrimSpace(*notes),
+		order, err := client.completeAssemblyOrder(ctx, cfg.TenantID, strings.TrimSpace(*orderID), &assembly.CompleteAssemblyOrderRequest{
+			CostingMethod:       strings.TrimSpace(*method),
+			AbsorptionAccountID: strings.TrimSpace(*absorptionAccountID),
+			InventoryAccountID:  strings.TrimSpace(*inventoryAccountID),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, run)
+			return printJSON(a.stdout, order)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Created payroll run %04d-%02d (%s)\n", run.PeriodYear, run.PeriodMonth, run.ID)
+		journalEntryID := ""
+		if order.JournalEntryID != nil {
+			journalEntryID = *order.JournalEntryID
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Completed assembly order %s at unit cost %s, journal %s\n", order.OrderNumber, order.UnitCost.String(), formatOptionalString(journalEntryID))
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("payroll runs get", flag.ContinueOnError)
+	case "cancel":
+		fs := flag.NewFlagSet("inventory assembly-orders cancel", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("id", "", "Payroll run id")
+		orderID := fs.String("id", "", "Assembly order id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*runID) == "" {
+		if strings.TrimSpace(*orderID) == "" {
 			return errors.New("id is required")
 		}
 
-		run, err := client.getPayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		result, err := client.cancelAssemblyOrder(ctx, cfg.TenantID, strings.TrimSpace(*orderID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, run)
+			return printJSON(a.stdout, result)
 		}
-		printPayrollRun(a.stdout, run)
+		_, _ = fmt.Fprintf(a.stdout, "Assembly order %s is %s\n", strings.TrimSpace(*orderID), result["status"])
 		return nil
 
-	case "calculate":
-		fs := flag.NewFlagSet("payroll runs calculate", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown inventory assembly-orders subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runInventoryWarehouses(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("inventory warehouses subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("inventory warehouses list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("id", "", "Payroll run id")
+		activeOnly := fs.Bool("active-only", false, "List only active warehouses")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*runID) == "" {
-			return errors.New("id is required")
-		}
 
-		run, err := client.calculatePayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		warehouses, err := client.listWarehouses(ctx, cfg.TenantID, *activeOnly)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, run)
+			return printJSON(a.stdout, warehouses)
 		}
-		printPayrollRun(a.stdout, run)
+		printWarehousesTable(a.stdout, warehouses)
 		return nil
 
-	case "set-payment-date":
-		fs := flag.NewFlagSet("payroll runs set-payment-date", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("inventory warehouses create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("id", "", "Payroll run id")
-		paymentDate := fs.String("payment-date", "", "Payment date in YYYY-MM-DD")
+		code := fs.String("code", "", "Warehouse code")
+		name := fs.String("name", "", "Warehouse name")
+		address := fs.String("address", "", "Address")
+		isDefault := fs.Bool("default", false, "Set as default warehouse")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		trimmedRunID := strings.TrimSpace(*runID)
-		if trimmedRunID == "" {
-			return errors.New("id is required")
-		}
-		trimmedPaymentDate := strings.TrimSpace(*paymentDate)
-		if trimmedPaymentDate == "" {
-			return errors.New("payment-date is required")
+		if strings.TrimSpace(*code) == "" {
+			return errors.New("code is required")
 		}
-		parsedPaymentDate, err := time.Parse("2006-01-02", trimmedPaymentDate)
-		if err != nil {
-			return fmt.Errorf("parse payment-date: %w", err)
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
 		}
 
-		run, err := client.updatePayrollRunPaymentDate(ctx, cfg.TenantID, trimmedRunID, &payroll.UpdatePayrollRunPaymentDateRequest{
-			PaymentDate: parsedPaymentDate,
+		warehouse, err := client.createWarehouse(ctx, cfg.TenantID, &inventory.CreateWarehouseRequest{
+			Code:      strings.TrimSpace(*code),
+			Name:      strings.TrimSpace(*name),
+			Address:   strings.TrimSpace(*address),
+			IsDefault: *isDefault,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, run)
+			return printJSON(a.stdout, warehouse)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Updated payroll run %s payment date to %s\n", trimmedRunID, trimmedPaymentDate)
-		printPayrollRun(a.stdout, run)
+		_, _ = fmt.Fprintf(a.stdout, "Created warehouse %s %s (%s)\n", warehouse.Code, warehouse.Name, warehouse.ID)
 		return nil
 
-	case "process":
-		fs := flag.NewFlagSet("payroll runs process", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("inventory warehouses import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("id", "", "Payroll run id")
-		approve := fs.Bool("approve", false, "Approve after calculation")
+		filePath := fs.String("file", "", "CSV file path or - for stdin")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		trimmedRunID := strings.TrimSpace(*runID)
-		if trimmedRunID == "" {
-			return errors.New("id is required")
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
 		}
 
-		result, err := client.processPayrollRun(ctx, cfg.TenantID, trimmedRunID, &payroll.ProcessPayrollRunRequest{
-			Approve: *approve,
+		result, err := client.importWarehouses(ctx, cfg.TenantID, &inventory.ImportWarehousesRequest{
+			CSVContent: content,
+			FileName:   fileName,
 		})
 		if err != nil {
 			return err
@@ -10857,88 +11450,96 @@ func (a *cliApp) runPayrollRuns(ctx context.Context, cfg *cliConfig, client *api
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Processed payroll run %s with %d payslips\n", trimmedRunID, result.PayslipCount)
-		if result.Approved {
-			_, _ = fmt.Fprintln(a.stdout, "Payroll run was approved")
-		}
-		printPayrollRun(a.stdout, result.PayrollRun)
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d warehouses, skipped %d rows\n", result.RowsProcessed, result.WarehousesCreated, result.RowsSkipped)
 		return nil
 
-	case "approve":
-		fs := flag.NewFlagSet("payroll runs approve", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("inventory warehouses get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("id", "", "Payroll run id")
+		warehouseID := fs.String("id", "", "Warehouse id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*runID) == "" {
+		if strings.TrimSpace(*warehouseID) == "" {
 			return errors.New("id is required")
 		}
 
-		result, err := client.approvePayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		warehouse, err := client.getWarehouse(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, warehouse)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Approved payroll run %s\n", strings.TrimSpace(*runID))
+		printWarehouse(a.stdout, warehouse)
 		return nil
 
-	case "payslips":
-		fs := flag.NewFlagSet("payroll runs payslips", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("inventory warehouses update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("id", "", "Payroll run id")
+		warehouseID := fs.String("id", "", "Warehouse id")
+		name := fs.String("name", "", "Warehouse name")
+		address := fs.String("address", "", "Address")
+		isDefault := fs.Bool("default", false, "Set as default warehouse")
+		active := fs.Bool("active", true, "Set active")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*runID) == "" {
+		if strings.TrimSpace(*warehouseID) == "" {
 			return errors.New("id is required")
 		}
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
+		}
 
-		payslips, err := client.listPayslips(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		warehouse, err := client.updateWarehouse(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID), &inventory.UpdateWarehouseRequest{
+			Name:      strings.TrimSpace(*name),
+			Address:   strings.TrimSpace(*address),
+			IsDefault: *isDefault,
+			IsActive:  *active,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, payslips)
+			return printJSON(a.stdout, warehouse)
 		}
-		printPayslipsTable(a.stdout, payslips)
+		printWarehouse(a.stdout, warehouse)
 		return nil
 
-	case "payslip-pdf":
-		fs := flag.NewFlagSet("payroll runs payslip-pdf", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("inventory warehouses delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		runID := fs.String("run-id", "", "Payroll run id")
-		payslipID := fs.String("payslip-id", "", "Payslip id")
-		outputPath := fs.String("output", "", "Optional PDF output file path")
+		warehouseID := fs.String("id", "", "Warehouse id")
+		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*runID) == "" {
-			return errors.New("run-id is required")
-		}
-		if strings.TrimSpace(*payslipID) == "" {
-			return errors.New("payslip-id is required")
+		if strings.TrimSpace(*warehouseID) == "" {
+			return errors.New("id is required")
 		}
-		content, err := client.downloadPayslipPDF(ctx, cfg.TenantID, strings.TrimSpace(*runID), strings.TrimSpace(*payslipID))
+
+		result, err := client.deleteWarehouse(ctx, cfg.TenantID, strings.TrimSpace(*warehouseID))
 		if err != nil {
 			return err
 		}
-		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "payslip PDF")
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Deleted warehouse %s\n", strings.TrimSpace(*warehouseID))
+		return nil
 
 	default:
-		return fmt.Errorf("unknown payroll runs subcommand %q", args[0])
+		return fmt.Errorf("unknown inventory warehouses subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runTSD(ctx context.Context, args []string) error {
+func (a *cliApp) runCostCenters(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("tsd subcommand required")
+		return errors.New("cost-centers subcommand required")
 	}
-
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
 		return err
@@ -10946,352 +11547,406 @@ func (a *cliApp) runTSD(ctx context.Context, args []string) error {
 
 	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("tsd list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("cost-centers list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
+		activeOnly := fs.Bool("active-only", false, "List only active cost centers")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		yearFlag := fs.String("year", "", "Filter by declaration year")
-		monthFlag := fs.String("month", "", "Filter by declaration month")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		year, err := parseOptionalPositiveInt("year", *yearFlag)
-		if err != nil {
-			return err
-		}
-		month, err := parseOptionalBoundedInt("month", *monthFlag, 1, 12)
-		if err != nil {
-			return err
-		}
-		declarations, err := client.listTSD(ctx, cfg.TenantID, payroll.TSDListFilter{
-			Year:  year,
-			Month: month,
-		})
+		costCenters, err := client.listCostCenters(ctx, cfg.TenantID, *activeOnly)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, declarations)
+			return printJSON(a.stdout, costCenters)
 		}
-		printTSDDeclarationsTable(a.stdout, declarations)
+		printCostCentersTable(a.stdout, costCenters)
 		return nil
 
-	case "get":
-		fs := flag.NewFlagSet("tsd get", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("cost-centers create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
+		code := fs.String("code", "", "Cost center code")
+		name := fs.String("name", "", "Cost center name")
+		description := fs.String("description", "", "Description")
+		parentID := fs.String("parent-id", "", "Parent cost center id")
+		active := fs.Bool("active", true, "Create as active")
+		budgetAmountFlag := fs.String("budget-amount", "", "Budget amount")
+		budgetPeriodFlag := fs.String("budget-period", "ANNUAL", "Budget period")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if strings.TrimSpace(*code) == "" {
+			return errors.New("code is required")
+		}
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
+		}
+		budgetAmount, err := parseOptionalNonNegativeDecimalPtr("budget-amount", *budgetAmountFlag)
 		if err != nil {
 			return err
 		}
-
-		declaration, err := client.getTSD(ctx, cfg.TenantID, year, month)
+		budgetPeriod, err := parseOptionalBudgetPeriod(*budgetPeriodFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, declaration)
-		}
-		printTSDDeclaration(a.stdout, declaration)
-		return nil
-
-	case "generate":
-		fs := flag.NewFlagSet("tsd generate", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		runID := fs.String("run-id", "", "Payroll run id")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		parentRef, err := optionalUUIDStringPtr("parent-id", *parentID)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*runID) == "" {
-			return errors.New("run-id is required")
-		}
 
-		declaration, err := client.generateTSD(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		costCenter, err := client.createCostCenter(ctx, cfg.TenantID, &accounting.CreateCostCenterRequest{
+			Code:         strings.TrimSpace(*code),
+			Name:         strings.TrimSpace(*name),
+			Description:  strings.TrimSpace(*description),
+			ParentID:     parentRef,
+			IsActive:     *active,
+			BudgetAmount: budgetAmount,
+			BudgetPeriod: budgetPeriod,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, declaration)
+			return printJSON(a.stdout, costCenter)
 		}
-		printTSDDeclaration(a.stdout, declaration)
+		_, _ = fmt.Fprintf(a.stdout, "Created cost center %s %s (%s)\n", costCenter.Code, costCenter.Name, costCenter.ID)
 		return nil
 
-	case "export-xml":
-		fs := flag.NewFlagSet("tsd export-xml", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("cost-centers import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
-		outputPath := fs.String("output", "", "Optional output file path")
+		filePath := fs.String("file", "", "CSV file path or - for stdin")
+		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
 
-		content, err := client.exportTSDXML(ctx, cfg.TenantID, year, month)
+		result, err := client.importCostCenters(ctx, cfg.TenantID, &accounting.ImportCostCentersRequest{
+			CSVContent: content,
+			FileName:   fileName,
+		})
 		if err != nil {
 			return err
 		}
-		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "TSD XML")
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, created %d cost centers, skipped %d rows\n", result.RowsProcessed, result.CostCentersCreated, result.RowsSkipped)
+		return nil
 
-	case "export-csv":
-		fs := flag.NewFlagSet("tsd export-csv", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("cost-centers get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
-		outputPath := fs.String("output", "", "Optional output file path")
+		costCenterID := fs.String("id", "", "Cost center id")
+		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*costCenterID) == "" {
+			return errors.New("id is required")
 		}
 
-		content, err := client.exportTSDCSV(ctx, cfg.TenantID, year, month)
+		costCenter, err := client.getCostCenter(ctx, cfg.TenantID, strings.TrimSpace(*costCenterID))
 		if err != nil {
 			return err
 		}
-		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "TSD CSV")
+		if *asJSON {
+			return printJSON(a.stdout, costCenter)
+		}
+		printCostCenter(a.stdout, costCenter)
+		return nil
 
-	case "import-history":
-		fs := flag.NewFlagSet("tsd import-history", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("cost-centers update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		costCenterID := fs.String("id", "", "Cost center id")
+		code := fs.String("code", "", "Cost center code")
+		name := fs.String("name", "", "Cost center name")
+		description := fs.String("description", "", "Description")
+		parentID := fs.String("parent-id", "", "Parent cost center id")
+		active := fs.Bool("active", true, "Set active")
+		budgetAmountFlag := fs.String("budget-amount", "", "Budget amount")
+		budgetPeriodFlag := fs.String("budget-period", "ANNUAL", "Budget period")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*filePath) == "" {
-			return errors.New("file is required")
+		if strings.TrimSpace(*costCenterID) == "" {
+			return errors.New("id is required")
 		}
-
-		content, fileName, err := readCSVInput(*filePath)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*code) == "" {
+			return errors.New("code is required")
 		}
-		result, err := client.importTSDHistory(ctx, cfg.TenantID, &payroll.ImportTSDHistoryRequest{
-			FileName:   fileName,
-			CSVContent: content,
-		})
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
+		}
+		budgetAmount, err := parseOptionalNonNegativeDecimalPtr("budget-amount", *budgetAmountFlag)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, result)
-		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d TSD declarations, imported %d rows, skipped %d rows\n",
-			result.RowsProcessed,
-			result.DeclarationsCreated,
-			result.RowsImported,
-			result.RowsSkipped,
-		)
-		return nil
-
-	case "mark-submitted":
-		fs := flag.NewFlagSet("tsd mark-submitted", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
-		emtaReference := fs.String("emta-reference", "", "e-MTA submission reference")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[1:]); err != nil {
+		budgetPeriod, err := parseOptionalBudgetPeriod(*budgetPeriodFlag)
+		if err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		parentRef, err := optionalUUIDStringPtr("parent-id", *parentID)
 		if err != nil {
 			return err
 		}
 
-		result, err := client.markTSDSubmitted(ctx, cfg.TenantID, year, month, strings.TrimSpace(*emtaReference))
+		costCenter, err := client.updateCostCenter(ctx, cfg.TenantID, strings.TrimSpace(*costCenterID), &accounting.UpdateCostCenterRequest{
+			Code:         strings.TrimSpace(*code),
+			Name:         strings.TrimSpace(*name),
+			Description:  strings.TrimSpace(*description),
+			ParentID:     parentRef,
+			IsActive:     *active,
+			BudgetAmount: budgetAmount,
+			BudgetPeriod: budgetPeriod,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, costCenter)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked TSD %04d-%02d as submitted\n", year, month)
+		printCostCenter(a.stdout, costCenter)
 		return nil
 
-	case "mark-accepted":
-		fs := flag.NewFlagSet("tsd mark-accepted", flag.ContinueOnError)
+	case "delete":
+		fs := flag.NewFlagSet("cost-centers delete", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
+		costCenterID := fs.String("id", "", "Cost center id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*costCenterID) == "" {
+			return errors.New("id is required")
 		}
 
-		result, err := client.markTSDAccepted(ctx, cfg.TenantID, year, month)
-		if err != nil {
+		if err := client.deleteCostCenter(ctx, cfg.TenantID, strings.TrimSpace(*costCenterID)); err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, map[string]string{"status": "deleted"})
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked TSD %04d-%02d as accepted\n", year, month)
+		_, _ = fmt.Fprintf(a.stdout, "Deleted cost center %s\n", strings.TrimSpace(*costCenterID))
 		return nil
 
-	case "mark-rejected":
-		fs := flag.NewFlagSet("tsd mark-rejected", flag.ContinueOnError)
+	case "report":
+		fs := flag.NewFlagSet("cost-centers report", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		startDateValue, err := parseOptionalDate("start", *startDate)
 		if err != nil {
 			return err
 		}
-
-		result, err := client.markTSDRejected(ctx, cfg.TenantID, year, month)
+		endDateValue, err := parseOptionalDate("end", *endDate)
+		if err != nil {
+			return err
+		}
+
+		if *asCSV {
+			content, err := client.exportCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cost center report CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cost center report XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cost center report PDF")
+		}
+
+		report, err := client.getCostCenterReport(ctx, cfg.TenantID, startDateValue, endDateValue)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, report)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked TSD %04d-%02d as rejected\n", year, month)
+		printCostCenterReport(a.stdout, report)
 		return nil
 
+	case "allocations":
+		return a.runCostCenterAllocations(ctx, client, cfg.TenantID, args[1:])
+
 	default:
-		return fmt.Errorf("unknown tsd subcommand %q", args[0])
+		return fmt.Errorf("unknown cost-centers subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runTax(ctx context.Context, args []string) error {
+func (a *cliApp) runCostCenterAllocations(ctx context.Context, client *apiClient, tenantID string, args []string) error {
 	if len(args) == 0 {
-		return errors.New("tax subcommand required")
-	}
-	if args[0] == "oss" {
-		return a.runTaxOSS(ctx, args[1:])
-	}
-	if args[0] != "kmd" {
-		return fmt.Errorf("unknown tax subcommand %q", args[0])
-	}
-	if len(args) == 1 {
-		return errors.New("tax kmd subcommand required")
-	}
-
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
+		return errors.New("cost-centers allocations subcommand required")
 	}
 
-	switch args[1] {
+	switch args[0] {
 	case "list":
-		fs := flag.NewFlagSet("tax kmd list", flag.ContinueOnError)
+		fs := flag.NewFlagSet("cost-centers allocations list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
+		costCenterID := fs.String("cost-center-id", "", "Filter by cost center id")
+		journalEntryLineID := fs.String("journal-entry-line-id", "", "Filter by journal entry line id")
+		startDate := fs.String("start", "", "Start allocation date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End allocation date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[2:]); err != nil {
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-
-		declarations, err := client.listKMD(ctx, cfg.TenantID)
+		startDateValue, err := parseOptionalDate("start", *startDate)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, declarations)
+		endDateValue, err := parseOptionalDate("end", *endDate)
+		if err != nil {
+			return err
 		}
-		printKMDDeclarationsTable(a.stdout, declarations)
-		return nil
-
-	case "generate":
-		fs := flag.NewFlagSet("tax kmd generate", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[2:]); err != nil {
+		if startDateValue != nil && endDateValue != nil && endDateValue.Before(*startDateValue) {
+			return errors.New("end must be on or after start")
+		}
+		costCenterFilter, err := optionalUUIDStringPtr("cost-center-id", *costCenterID)
+		if err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		journalEntryLineFilter, err := optionalUUIDStringPtr("journal-entry-line-id", *journalEntryLineID)
 		if err != nil {
 			return err
 		}
+		costCenterFilterValue := ""
+		if costCenterFilter != nil {
+			costCenterFilterValue = *costCenterFilter
+		}
+		journalEntryLineFilterValue := ""
+		if journalEntryLineFilter != nil {
+			journalEntryLineFilterValue = *journalEntryLineFilter
+		}
 
-		declaration, err := client.generateKMD(ctx, cfg.TenantID, &tax.CreateKMDRequest{
-			Year:  year,
-			Month: month,
+		allocations, err := client.listCostAllocations(ctx, tenantID, accounting.CostAllocationFilters{
+			CostCenterID:       costCenterFilterValue,
+			JournalEntryLineID: journalEntryLineFilterValue,
+			StartDate:          startDateValue,
+			EndDate:            endDateValue,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, declaration)
+			return printJSON(a.stdout, allocations)
 		}
-		printKMDDeclaration(a.stdout, declaration)
+		printCostAllocationsTable(a.stdout, allocations)
 		return nil
 
-	case "inf":
-		fs := flag.NewFlagSet("tax kmd inf", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("cost-centers allocations create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
-		thresholdFlag := fs.String("threshold", "", "Optional partner-period threshold excluding VAT")
+		costCenterID := fs.String("cost-center-id", "", "Cost center id")
+		journalEntryLineID := fs.String("journal-entry-line-id", "", "Journal entry line id")
+		amountFlag := fs.String("amount", "", "Positive allocation amount")
+		allocationPercentageFlag := fs.String("allocation-percentage", "", "Optional allocation percentage from 0 to 100")
+		allocationDate := fs.String("allocation-date", "", "Allocation date in YYYY-MM-DD")
+		notes := fs.String("notes", "", "Allocation notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[2:]); err != nil {
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if strings.TrimSpace(*costCenterID) == "" {
+			return errors.New("cost-center-id is required")
+		}
+		if strings.TrimSpace(*journalEntryLineID) == "" {
+			return errors.New("journal-entry-line-id is required")
+		}
+		parsedCostCenterID, err := optionalUUIDStringPtr("cost-center-id", *costCenterID)
 		if err != nil {
 			return err
 		}
-		threshold := decimal.Zero
-		if strings.TrimSpace(*thresholdFlag) != "" {
-			threshold, err = parseRequiredPositiveDecimal("threshold", *thresholdFlag)
-			if err != nil {
-				return err
-			}
+		parsedJournalEntryLineID, err := optionalUUIDStringPtr("journal-entry-line-id", *journalEntryLineID)
+		if err != nil {
+			return err
+		}
+		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
+		if err != nil {
+			return err
+		}
+		allocationPercentage, err := parseOptionalNonNegativeDecimalPtr("allocation-percentage", *allocationPercentageFlag)
+		if err != nil {
+			return err
+		}
+		if allocationPercentage != nil && allocationPercentage.GreaterThan(decimal.NewFromInt(100)) {
+			return errors.New("allocation-percentage must be between 0 and 100")
+		}
+		allocationDateValue, err := parseRequiredDate("allocation-date", *allocationDate)
+		if err != nil {
+			return err
 		}
 
-		report, err := client.generateKMDINF(ctx, cfg.TenantID, year, month, threshold)
+		allocation, err := client.createCostAllocation(ctx, tenantID, &accounting.CreateCostAllocationRequest{
+			CostCenterID:         *parsedCostCenterID,
+			JournalEntryLineID:   *parsedJournalEntryLineID,
+			Amount:               amount,
+			AllocationPercentage: allocationPercentage,
+			AllocationDate:       allocationDateValue,
+			Notes:                strings.TrimSpace(*notes),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, allocation)
 		}
-		printKMDINFReport(a.stdout, report)
+		_, _ = fmt.Fprintf(a.stdout, "Created cost allocation %s for cost center %s\n", allocation.ID, allocation.CostCenterID)
 		return nil
 
-	case "import-history":
-		fs := flag.NewFlagSet("tax kmd import-history", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("cost-centers allocations import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		filePath := fs.String("file", "", "CSV file path")
+		filePath := fs.String("file", "", "CSV file path or - for stdin")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[2:]); err != nil {
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 		if strings.TrimSpace(*filePath) == "" {
 			return errors.New("file is required")
 		}
-
 		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-		result, err := client.importKMDHistory(ctx, cfg.TenantID, &tax.ImportKMDHistoryRequest{
-			FileName:   fileName,
+
+		result, err := client.importCostAllocations(ctx, tenantID, &accounting.ImportCostAllocationsRequest{
 			CSVContent: content,
+			FileName:   fileName,
 		})
 		if err != nil {
 			return err
@@ -11299,960 +11954,917 @@ func (a *cliApp) runTax(ctx context.Context, args []string) error {
 		if *asJSON {
 			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(
-			a.stdout,
-			"Processed %d rows, created %d KMD declarations, imported %d rows, skipped %d rows\n",
-			result.RowsProcessed,
-			result.DeclarationsCreated,
-			result.RowsImported,
-			result.RowsSkipped,
-		)
+		_, _ = fmt.Fprintf(a.stdout, "Processed %d rows, imported %d cost allocations, skipped %d rows\n", result.RowsProcessed, result.AllocationsImported, result.RowsSkipped)
 		return nil
 
-	case "export-xml":
-		fs := flag.NewFlagSet("tax kmd export-xml", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
-		outputPath := fs.String("output", "", "Optional output file path")
-		if err := fs.Parse(args[2:]); err != nil {
-			return err
-		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
-		if err != nil {
-			return err
-		}
+	default:
+		return fmt.Errorf("unknown cost-centers allocations subcommand %q", args[0])
+	}
+}
 
-		content, err := client.exportKMDXML(ctx, cfg.TenantID, year, month)
-		if err != nil {
-			return err
-		}
-		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "KMD XML")
+func (a *cliApp) runAnalytics(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("analytics subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
 
-	case "mark-submitted":
-		fs := flag.NewFlagSet("tax kmd mark-submitted", flag.ContinueOnError)
+	switch args[0] {
+	case "dashboard":
+		fs := flag.NewFlagSet("analytics dashboard", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[2:]); err != nil {
-			return err
-		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
-		if err != nil {
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		result, err := client.markKMDSubmitted(ctx, cfg.TenantID, year, month)
+		summary, err := client.getDashboardSummary(ctx, cfg.TenantID)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, summary)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked KMD %04d-%02d as submitted\n", year, month)
+		printDashboardSummary(a.stdout, summary)
 		return nil
 
-	case "mark-accepted":
-		fs := flag.NewFlagSet("tax kmd mark-accepted", flag.ContinueOnError)
+	case "revenue-expense":
+		fs := flag.NewFlagSet("analytics revenue-expense", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		yearFlag := fs.String("year", "", "Declaration year")
-		monthFlag := fs.String("month", "", "Declaration month")
+		monthsFlag := fs.String("months", "12", "Number of months")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		if err := fs.Parse(args[2:]); err != nil {
+		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		months, err := parseRequiredPositiveInt("months", *monthsFlag)
 		if err != nil {
 			return err
 		}
 
-		result, err := client.markKMDAccepted(ctx, cfg.TenantID, year, month)
+		chart, err := client.getRevenueExpenseChart(ctx, cfg.TenantID, months)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, chart)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked KMD %04d-%02d as accepted\n", year, month)
+		printRevenueExpenseChart(a.stdout, chart)
 		return nil
 
-	default:
-		return fmt.Errorf("unknown tax kmd subcommand %q", args[1])
-	}
-}
+	case "cash-flow":
+		fs := flag.NewFlagSet("analytics cash-flow", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		monthsFlag := fs.String("months", "12", "Number of months")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		months, err := parseRequiredPositiveInt("months", *monthsFlag)
+		if err != nil {
+			return err
+		}
 
-func (a *cliApp) runTaxOSS(ctx context.Context, args []string) error {
-	if len(args) == 0 {
-		return errors.New("tax oss subcommand required")
-	}
-	if args[0] != "report" {
-		return fmt.Errorf("unknown tax oss subcommand %q", args[0])
-	}
+		chart, err := client.getCashFlowChart(ctx, cfg.TenantID, months)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, chart)
+		}
+		printCashFlowChart(a.stdout, chart)
+		return nil
 
-	cfg, client, err := a.loadAuthenticatedClient()
-	if err != nil {
-		return err
-	}
+	case "activity":
+		fs := flag.NewFlagSet("analytics activity", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		limitFlag := fs.String("limit", "10", "Number of activity items")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		limit, err := parseRequiredPositiveInt("limit", *limitFlag)
+		if err != nil {
+			return err
+		}
 
-	fs := flag.NewFlagSet("tax oss report", flag.ContinueOnError)
-	fs.SetOutput(a.stderr)
-	yearFlag := fs.String("year", "", "Report year")
-	quarterFlag := fs.String("quarter", "", "Report quarter")
-	includeB2B := fs.Bool("include-b2b", false, "Include contacts with VAT numbers")
-	asJSON := fs.Bool("json", false, "Output JSON")
-	if err := fs.Parse(args[1:]); err != nil {
-		return err
-	}
-	year, err := strconv.Atoi(strings.TrimSpace(*yearFlag))
-	if err != nil || year < 2020 || year > 2100 {
-		return errors.New("year must be between 2020 and 2100")
-	}
-	quarter, err := strconv.Atoi(strings.TrimSpace(*quarterFlag))
-	if err != nil || quarter < 1 || quarter > 4 {
-		return errors.New("quarter must be between 1 and 4")
-	}
+		activity, err := client.getRecentActivity(ctx, cfg.TenantID, limit)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, activity)
+		}
+		printActivityItems(a.stdout, activity)
+		return nil
 
-	report, err := client.generateEUVATOSS(ctx, cfg.TenantID, year, quarter, *includeB2B)
-	if err != nil {
-		return err
-	}
-	if *asJSON {
-		return printJSON(a.stdout, report)
+	default:
+		return fmt.Errorf("unknown analytics subcommand %q", args[0])
 	}
-	printEUVATOSSReport(a.stdout, report)
-	return nil
 }
 
-func (a *cliApp) runReports(ctx context.Context, args []string) error {
+func (a *cliApp) runEmployees(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("reports subcommand required")
+		return errors.New("employees subcommand required")
 	}
-
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
 		return err
 	}
 
 	switch args[0] {
-	case "trial-balance":
-		fs := flag.NewFlagSet("reports trial-balance", flag.ContinueOnError)
+	case "list":
+		fs := flag.NewFlagSet("employees list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		activeOnly := fs.Bool("active-only", false, "List only active employees")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
-		}
-		if *asCSV {
-			content, err := client.exportTrialBalanceCSV(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "trial balance CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportTrialBalanceXLSX(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "trial balance XLSX")
-		}
-		if *asPDF {
-			content, err := client.exportTrialBalancePDF(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "trial balance PDF")
-		}
 
-		report, err := client.getTrialBalance(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+		employees, err := client.listEmployees(ctx, cfg.TenantID, *activeOnly)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, employees)
 		}
-		printTrialBalance(a.stdout, report)
+		printEmployeesTable(a.stdout, employees)
 		return nil
 
-	case "account-balance":
-		fs := flag.NewFlagSet("reports account-balance", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("employees create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		accountID := fs.String("account-id", "", "Account id")
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		employeeNumber := fs.String("employee-number", "", "Employee number")
+		firstName := fs.String("first-name", "", "First name")
+		lastName := fs.String("last-name", "", "Last name")
+		personalCode := fs.String("personal-code", "", "Personal code")
+		email := fs.String("email", "", "Email")
+		phone := fs.String("phone", "", "Phone")
+		address := fs.String("address", "", "Address")
+		bankAccount := fs.String("bank-account", "", "IBAN")
+		startDate := fs.String("start-date", "", "Employment start date in YYYY-MM-DD")
+		position := fs.String("position", "", "Position")
+		department := fs.String("department", "", "Department")
+		employmentType := fs.String("employment-type", "FULL_TIME", "Employment type: FULL_TIME, PART_TIME, CONTRACT")
+		applyBasicExemption := fs.Bool("apply-basic-exemption", true, "Apply basic exemption")
+		basicExemptionAmount := fs.String("basic-exemption-amount", "700.00", "Basic exemption amount")
+		fundedPensionRate := fs.String("funded-pension-rate", "0.02", "Funded pension rate")
+		hourlyRate := fs.String("hourly-rate", "", "Hourly rate for pay from approved timesheets")
+		workingTimeRatio := fs.String("working-time-ratio", "", "Working time ratio for the employment register, 1 is full time")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
+
+		if strings.TrimSpace(*firstName) == "" || strings.TrimSpace(*lastName) == "" || strings.TrimSpace(*startDate) == "" {
+			return errors.New("first-name, last-name, and start-date are required")
 		}
-		if strings.TrimSpace(*accountID) == "" {
-			return errors.New("account-id is required")
+
+		parsedStartDate, err := time.Parse("2006-01-02", strings.TrimSpace(*startDate))
+		if err != nil {
+			return fmt.Errorf("parse start-date: %w", err)
 		}
-		if *asCSV {
-			content, err := client.exportAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf), "csv")
+
+		basicExemptionValue := decimal.Zero
+		if *applyBasicExemption {
+			basicExemptionValue, err = decimal.NewFromString(strings.TrimSpace(*basicExemptionAmount))
 			if err != nil {
-				return err
+				return fmt.Errorf("parse basic-exemption-amount: %w", err)
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "account balance CSV")
 		}
-		if *asXLSX {
-			content, err := client.exportAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf), "xlsx")
+
+		fundedPensionValue := decimal.Zero
+		if trimmed := strings.TrimSpace(*fundedPensionRate); trimmed != "" {
+			fundedPensionValue, err = decimal.NewFromString(trimmed)
 			if err != nil {
-				return err
+				return fmt.Errorf("parse funded-pension-rate: %w", err)
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "account balance XLSX")
 		}
-		if *asPDF {
-			content, err := client.exportAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf), "pdf")
+		hourlyRateValue, err := parseOptionalNonNegativeDecimalPtr("hourly-rate", *hourlyRate)
+		if err != nil {
+			return err
+		}
+
+		createReq := &payroll.CreateEmployeeRequest{
+			EmployeeNumber:       strings.TrimSpace(*employeeNumber),
+			FirstName:            strings.TrimSpace(*firstName),
+			LastName:             strings.TrimSpace(*lastName),
+			PersonalCode:         strings.TrimSpace(*personalCode),
+			Email:                strings.TrimSpace(*email),
+			Phone:                strings.TrimSpace(*phone),
+			Address:              strings.TrimSpace(*address),
+			BankAccount:          strings.TrimSpace(*bankAccount),
+			StartDate:            parsedStartDate,
+			Position:             strings.TrimSpace(*position),
+			Department:           strings.TrimSpace(*department),
+			EmploymentType:       payroll.EmploymentType(strings.ToUpper(strings.TrimSpace(*employmentType))),
+			ApplyBasicExemption:  *applyBasicExemption,
+			BasicExemptionAmount: basicExemptionValue,
+			FundedPensionRate:    fundedPensionValue,
+		}
+		if hourlyRateValue != nil {
+			createReq.HourlyRate = *hourlyRateValue
+		}
+		if trimmed := strings.TrimSpace(*workingTimeRatio); trimmed != "" {
+			createReq.WorkingTimeRatio, err = parseRequiredPositiveDecimal("working-time-ratio", trimmed)
 			if err != nil {
 				return err
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "account balance PDF")
 		}
 
-		report, err := client.getAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf))
+		employee, err := client.createEmployee(ctx, cfg.TenantID, createReq)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, employee)
 		}
-		printAccountBalance(a.stdout, report)
+		_, _ = fmt.Fprintf(a.stdout, "Created employee %s (%s)\n", employee.FullName(), employee.ID)
 		return nil
 
-	case "balance-sheet":
-		fs := flag.NewFlagSet("reports balance-sheet", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("employees get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		employeeID := fs.String("id", "", "Employee id")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
-		}
-		if *asCSV {
-			content, err := client.exportBalanceSheetCSV(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance sheet CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportBalanceSheetXLSX(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance sheet XLSX")
-		}
-		if *asPDF {
-			content, err := client.exportBalanceSheetPDF(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance sheet PDF")
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
 		}
 
-		report, err := client.getBalanceSheet(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+		employee, err := client.getEmployee(ctx, cfg.TenantID, strings.TrimSpace(*employeeID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, employee)
 		}
-		printBalanceSheet(a.stdout, report)
+		printEmployee(a.stdout, employee)
 		return nil
 
-	case "income-statement":
-		fs := flag.NewFlagSet("reports income-statement", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("employees update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		employeeID := fs.String("id", "", "Employee id")
+		employeeNumber := fs.String("employee-number", "", "Employee number")
+		firstName := fs.String("first-name", "", "First name")
+		lastName := fs.String("last-name", "", "Last name")
+		personalCode := fs.String("personal-code", "", "Personal code")
+		email := fs.String("email", "", "Email")
+		phone := fs.String("phone", "", "Phone")
+		address := fs.String("address", "", "Address")
+		bankAccount := fs.String("bank-account", "", "IBAN")
+		endDate := fs.String("end-date", "", "Employment end date in YYYY-MM-DD")
+		position := fs.String("position", "", "Position")
+		department := fs.String("department", "", "Department")
+		employmentType := fs.String("employment-type", "", "Employment type: FULL_TIME, PART_TIME, CONTRACT")
+		applyBasicExemption := fs.String("apply-basic-exemption", "", "Apply basic exemption: true or false")
+		basicExemptionAmount := fs.String("basic-exemption-amount", "", "Basic exemption amount")
+		fundedPensionRate := fs.String("funded-pension-rate", "", "Funded pension rate")
+		hourlyRate := fs.String("hourly-rate", "", "Hourly rate for pay from approved timesheets")
+		active := fs.String("active", "", "Set active state: true or false")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
 		}
-		if strings.TrimSpace(*startDate) == "" || strings.TrimSpace(*endDate) == "" {
-			return errors.New("start and end are required")
+
+		req := &payroll.UpdateEmployeeRequest{
+			EmployeeNumber: strings.TrimSpace(*employeeNumber),
+			FirstName:      strings.TrimSpace(*firstName),
+			LastName:       strings.TrimSpace(*lastName),
+			PersonalCode:   strings.TrimSpace(*personalCode),
+			Email:          strings.TrimSpace(*email),
+			Phone:          strings.TrimSpace(*phone),
+			Address:        strings.TrimSpace(*address),
+			BankAccount:    strings.TrimSpace(*bankAccount),
+			Position:       strings.TrimSpace(*position),
+			Department:     strings.TrimSpace(*department),
 		}
-		if *asCSV {
-			content, err := client.exportIncomeStatementCSV(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+		if strings.TrimSpace(*employmentType) != "" {
+			req.EmploymentType = payroll.EmploymentType(strings.ToUpper(strings.TrimSpace(*employmentType)))
+		}
+		if strings.TrimSpace(*endDate) != "" {
+			parsed, err := parseRequiredDate("end-date", *endDate)
 			if err != nil {
 				return err
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "income statement CSV")
+			req.EndDate = &parsed
 		}
-		if *asXLSX {
-			content, err := client.exportIncomeStatementXLSX(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+		if strings.TrimSpace(*applyBasicExemption) != "" {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(*applyBasicExemption))
 			if err != nil {
-				return err
+				return fmt.Errorf("parse apply-basic-exemption: %w", err)
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "income statement XLSX")
+			req.ApplyBasicExemption = &parsed
 		}
-		if *asPDF {
-			content, err := client.exportIncomeStatementPDF(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+		if strings.TrimSpace(*basicExemptionAmount) != "" {
+			parsed, err := decimal.NewFromString(strings.TrimSpace(*basicExemptionAmount))
 			if err != nil {
-				return err
+				return fmt.Errorf("parse basic-exemption-amount: %w", err)
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "income statement PDF")
+			req.BasicExemptionAmount = &parsed
+		}
+		if strings.TrimSpace(*fundedPensionRate) != "" {
+			parsed, err := decimal.NewFromString(strings.TrimSpace(*fundedPensionRate))
+			if err != nil {
+				return fmt.Errorf("parse funded-pension-rate: %w", err)
+			}
+			req.FundedPensionRate = &parsed
+		}
+		if req.HourlyRate, err = parseOptionalNonNegativeDecimalPtr("hourly-rate", *hourlyRate); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*active) != "" {
+			parsed, err := strconv.ParseBool(strings.TrimSpace(*active))
+			if err != nil {
+				return fmt.Errorf("parse active: %w", err)
+			}
+			req.IsActive = &parsed
 		}
 
-		report, err := client.getIncomeStatement(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+		employee, err := client.updateEmployee(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, employee)
 		}
-		printIncomeStatement(a.stdout, report)
+		printEmployee(a.stdout, employee)
 		return nil
 
-	case "consolidated":
-		fs := flag.NewFlagSet("reports consolidated", flag.ContinueOnError)
+	case "set-salary":
+		fs := flag.NewFlagSet("employees set-salary", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
-		startDate := fs.String("start", "", "Income statement start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "Income statement end date in YYYY-MM-DD")
-		tenantIDs := fs.String("tenant-ids", "", "Comma-separated tenant ids to consolidate")
+		employeeID := fs.String("id", "", "Employee id")
+		amountFlag := fs.String("amount", "", "Base salary amount")
+		effectiveFrom := fs.String("effective-from", "", "Effective date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
+		}
+		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
+		if err != nil {
+			return err
+		}
+		effectiveFromValue, err := parseRequiredDate("effective-from", *effectiveFrom)
+		if err != nil {
+			return err
+		}
 
-		report, err := client.getConsolidatedReport(ctx, cfg.TenantID, strings.TrimSpace(*asOf), strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), strings.TrimSpace(*tenantIDs))
+		result, err := client.setBaseSalary(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), amount, effectiveFromValue)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, result)
 		}
-		printConsolidatedFinancialReport(a.stdout, report)
+		_, _ = fmt.Fprintf(a.stdout, "Set base salary for employee %s to %s\n", strings.TrimSpace(*employeeID), amount.String())
 		return nil
 
-	case "annual":
-		fs := flag.NewFlagSet("reports annual", flag.ContinueOnError)
+	case "salary-components":
+		fs := flag.NewFlagSet("employees salary-components", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		periodEnd := fs.String("period-end", "", "Fiscal year-end date in YYYY-MM-DD")
-		methodFlag := fs.String("cash-flow-method", reports.CashFlowMethodDirect, "Cash flow method: direct or indirect")
+		employeeID := fs.String("id", "", "Employee id")
+		activeOn := fs.String("active-on", "", "Filter components active on date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*periodEnd) == "" {
-			return errors.New("period-end is required")
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
 		}
-		method, err := reports.NormalizeCashFlowMethod(*methodFlag)
-		if err != nil {
-			return err
+		activeOnValue := strings.TrimSpace(*activeOn)
+		if activeOnValue != "" {
+			if _, err := parseRequiredDate("active-on", activeOnValue); err != nil {
+				return err
+			}
 		}
-		report, err := client.getAnnualReport(ctx, cfg.TenantID, strings.TrimSpace(*periodEnd), method)
+
+		components, err := client.listSalaryComponents(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), activeOnValue)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, components)
 		}
-		printAnnualReport(a.stdout, report)
+		printSalaryComponentsTable(a.stdout, components)
 		return nil
 
-	case "cash-flow":
-		fs := flag.NewFlagSet("reports cash-flow", flag.ContinueOnError)
+	case "average-earnings":
+		fs := flag.NewFlagSet("employees average-earnings", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
-		methodFlag := fs.String("method", reports.CashFlowMethodDirect, "Cash flow method: direct or indirect")
-		operatingAccounts := fs.String("operating-accounts", "", "Comma-separated account codes to force into operating cash flow")
-		investingAccounts := fs.String("investing-accounts", "", "Comma-separated account codes to force into investing cash flow")
-		financingAccounts := fs.String("financing-accounts", "", "Comma-separated account codes to force into financing cash flow")
+		employeeID := fs.String("id", "", "Employee id")
+		date := fs.String("date", "", "Leave start date in YYYY-MM-DD")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
-		}
-		if strings.TrimSpace(*startDate) == "" || strings.TrimSpace(*endDate) == "" {
-			return errors.New("start and end are required")
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
 		}
-		method, err := reports.NormalizeCashFlowMethod(*methodFlag)
-		if err != nil {
+		if _, err := parseRequiredDate("date", strings.TrimSpace(*date)); err != nil {
 			return err
 		}
-		mappingOverrides := reports.CashFlowMappingOverrides{
-			OperatingAccountCodes: splitCSVFlag(*operatingAccounts),
-			InvestingAccountCodes: splitCSVFlag(*investingAccounts),
-			FinancingAccountCodes: splitCSVFlag(*financingAccounts),
-		}
-		mappingOverrides, err = reports.NormalizeCashFlowMappingOverrides(mappingOverrides)
+
+		average, err := client.getAverageEarnings(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), strings.TrimSpace(*date))
 		if err != nil {
 			return err
 		}
-		if *asCSV {
-			content, err := client.exportCashFlowStatementCSV(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cash flow CSV")
+		if *asJSON {
+			return printJSON(a.stdout, average)
 		}
-		if *asXLSX {
-			content, err := client.exportCashFlowStatementXLSX(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cash flow XLSX")
+		printAverageEarnings(a.stdout, average)
+		return nil
+
+	case "employment-events":
+		fs := flag.NewFlagSet("employees employment-events", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		employeeID := fs.String("id", "", "Employee id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
 		}
-		if *asPDF {
-			content, err := client.exportCashFlowStatementPDF(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cash flow PDF")
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
 		}
 
-		report, err := client.getCashFlowStatement(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
+		events, err := client.listEmploymentEvents(ctx, cfg.TenantID, strings.TrimSpace(*employeeID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, events)
 		}
-		printCashFlowStatement(a.stdout, report)
+		printEmploymentEventsTable(a.stdout, events)
 		return nil
 
-	case "cash-flow-mapping":
-		return a.runCashFlowMapping(ctx, cfg, client, args[1:])
-
-	case "aging":
-		fs := flag.NewFlagSet("reports aging", flag.ContinueOnError)
+	case "add-employment-event":
+		fs := flag.NewFlagSet("employees add-employment-event", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		reportType := fs.String("type", "receivables", "Aging report type: receivables or payables")
+		employeeID := fs.String("id", "", "Employee id")
+		eventType := fs.String("type", "", "Event type: START, END, SUSPENSION_START, SUSPENSION_END, WORKING_TIME_CHANGE")
+		date := fs.String("date", "", "Event date in YYYY-MM-DD")
+		terminationCode := fs.String("termination-code", "", "Termination code for END events, for example TLS79")
+		suspensionReason := fs.String("suspension-reason", "", "Suspension reason: PARENTAL_LEAVE, MILITARY_SERVICE, UNPAID_LEAVE")
+		workingTimeRatio := fs.String("working-time-ratio", "", "Working time ratio, 1 is full time")
+		position := fs.String("position", "", "Position reported with the event")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
 		}
-		normalizedType := strings.ToLower(strings.TrimSpace(*reportType))
-		if normalizedType != "receivables" && normalizedType != "payables" {
-			return errors.New("type must be receivables or payables")
+		if strings.TrimSpace(*eventType) == "" {
+			return errors.New("type is required")
 		}
-
-		if *asCSV {
-			content, err := client.exportAgingReport(ctx, cfg.TenantID, normalizedType, "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, normalizedType+" aging CSV")
+		eventDate, err := parseRequiredDate("date", *date)
+		if err != nil {
+			return err
 		}
-		if *asXLSX {
-			content, err := client.exportAgingReport(ctx, cfg.TenantID, normalizedType, "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, normalizedType+" aging XLSX")
+		req := &payroll.CreateEmploymentEventRequest{
+			EventType:        payroll.EmploymentEventType(strings.ToUpper(strings.TrimSpace(*eventType))),
+			EventDate:        eventDate,
+			TerminationCode:  strings.TrimSpace(*terminationCode),
+			SuspensionReason: strings.TrimSpace(*suspensionReason),
+			Position:         strings.TrimSpace(*position),
+			Notes:            strings.TrimSpace(*notes),
 		}
-		if *asPDF {
-			content, err := client.exportAgingReport(ctx, cfg.TenantID, normalizedType, "pdf")
+		if trimmed := strings.TrimSpace(*workingTimeRatio); trimmed != "" {
+			req.WorkingTimeRatio, err = parseRequiredPositiveDecimal("working-time-ratio", trimmed)
 			if err != nil {
 				return err
 			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, normalizedType+" aging PDF")
 		}
 
-		report, err := client.getAgingReport(ctx, cfg.TenantID, normalizedType)
+		event, err := client.createEmploymentEvent(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), req)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, event)
 		}
-		printAgingReport(a.stdout, report)
+		_, _ = fmt.Fprintf(a.stdout, "Recorded %s employment event %s on %s\n", event.EventType, event.ID, formatDate(event.EventDate))
 		return nil
 
-	case "balance-confirmations":
-		fs := flag.NewFlagSet("reports balance-confirmations", flag.ContinueOnError)
+	case "add-salary-component":
+		fs := flag.NewFlagSet("employees add-salary-component", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		balanceType := fs.String("type", "", "Balance type: RECEIVABLE or PAYABLE")
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
-		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		employeeID := fs.String("id", "", "Employee id")
+		componentType := fs.String("type", payroll.SalaryComponentSecondaryEmployment, "Component type: SECONDARY_EMPLOYMENT, BONUS, COMMISSION, BENEFIT")
+		name := fs.String("name", "", "Component name")
+		amountFlag := fs.String("amount", "", "Component amount")
+		effectiveFrom := fs.String("effective-from", "", "Effective date in YYYY-MM-DD")
+		effectiveTo := fs.String("effective-to", "", "Optional end date in YYYY-MM-DD")
+		isTaxable := fs.Bool("taxable", true, "Component is taxable")
+		isRecurring := fs.Bool("recurring", true, "Component is recurring")
+		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("id is required")
+		}
+		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
+		if err != nil {
 			return err
 		}
-		normalizedType := strings.ToUpper(strings.TrimSpace(*balanceType))
-		if normalizedType != "RECEIVABLE" && normalizedType != "PAYABLE" {
-			return errors.New("type must be RECEIVABLE or PAYABLE")
+		effectiveFromValue, err := parseRequiredDate("effective-from", *effectiveFrom)
+		if err != nil {
+			return err
 		}
-		if strings.TrimSpace(*asOf) == "" {
-			return errors.New("as-of is required")
+		effectiveToValue, err := parseOptionalDate("effective-to", *effectiveTo)
+		if err != nil {
+			return err
 		}
+		taxableValue := *isTaxable
+		recurringValue := *isRecurring
 
-		if *asCSV {
-			content, err := client.exportBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf), "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmations CSV")
+		component, err := client.addSalaryComponent(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), &payroll.CreateSalaryComponentRequest{
+			ComponentType: strings.ToUpper(strings.TrimSpace(*componentType)),
+			Name:          strings.TrimSpace(*name),
+			Amount:        amount,
+			IsTaxable:     &taxableValue,
+			IsRecurring:   &recurringValue,
+			EffectiveFrom: effectiveFromValue,
+			EffectiveTo:   effectiveToValue,
+		})
+		if err != nil {
+			return err
 		}
-		if *asXLSX {
-			content, err := client.exportBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf), "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmations XLSX")
+		if *asJSON {
+			return printJSON(a.stdout, component)
 		}
-		if *asPDF {
-			content, err := client.exportBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf), "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmations PDF")
+		_, _ = fmt.Fprintf(a.stdout, "Added salary component %s (%s) for employee %s\n", component.Name, component.ID, strings.TrimSpace(*employeeID))
+		return nil
+
+	case "import":
+		fs := flag.NewFlagSet("employees import", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
 		}
 
-		report, err := client.getBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf))
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importEmployees(ctx, cfg.TenantID, &payroll.ImportEmployeesRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, result)
 		}
-		printBalanceConfirmationSummary(a.stdout, report)
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d employees, set %d salaries, skipped %d rows\n",
+			result.RowsProcessed,
+			result.EmployeesCreated,
+			result.SalariesCreated,
+			result.RowsSkipped,
+		)
 		return nil
 
-	case "balance-confirmation":
-		fs := flag.NewFlagSet("reports balance-confirmation", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown employees subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runLeave(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("leave subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "absence-types":
+		return a.runLeaveAbsenceTypes(ctx, cfg, client, args[1:])
+	case "balances":
+		return a.runLeaveBalances(ctx, cfg, client, args[1:])
+	case "records":
+		return a.runLeaveRecords(ctx, cfg, client, args[1:])
+	default:
+		return fmt.Errorf("unknown leave subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runLeaveAbsenceTypes(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("leave absence-types subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("leave absence-types list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		contactID := fs.String("contact-id", "", "Contact id")
-		balanceType := fs.String("type", "", "Balance type: RECEIVABLE or PAYABLE")
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		activeOnly := fs.Bool("active-only", false, "List only active absence types")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+
+		types, err := client.listAbsenceTypes(ctx, cfg.TenantID, *activeOnly)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*contactID) == "" {
-			return errors.New("contact-id is required")
-		}
-		normalizedType := strings.ToUpper(strings.TrimSpace(*balanceType))
-		if normalizedType != "RECEIVABLE" && normalizedType != "PAYABLE" {
-			return errors.New("type must be RECEIVABLE or PAYABLE")
-		}
-		if strings.TrimSpace(*asOf) == "" {
-			return errors.New("as-of is required")
+		if *asJSON {
+			return printJSON(a.stdout, types)
 		}
+		printAbsenceTypesTable(a.stdout, types)
+		return nil
 
-		if *asCSV {
-			content, err := client.exportBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf), "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmation CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf), "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmation XLSX")
+	case "get":
+		fs := flag.NewFlagSet("leave absence-types get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		typeID := fs.String("id", "", "Absence type id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
 		}
-		if *asPDF {
-			content, err := client.exportBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf), "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmation PDF")
+		if strings.TrimSpace(*typeID) == "" {
+			return errors.New("id is required")
 		}
 
-		report, err := client.getBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf))
+		absenceType, err := client.getAbsenceType(ctx, cfg.TenantID, strings.TrimSpace(*typeID))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, absenceType)
 		}
-		printBalanceConfirmation(a.stdout, report)
+		printAbsenceType(a.stdout, absenceType)
 		return nil
 
-	case "contact-statement":
-		fs := flag.NewFlagSet("reports contact-statement", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown leave absence-types subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runLeaveBalances(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("leave balances subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("leave balances list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		contactID := fs.String("contact-id", "", "Contact id")
-		balanceType := fs.String("type", "", "Statement type: RECEIVABLE or PAYABLE")
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		yearFlag := fs.String("year", "", "Balance year")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("employee-id is required")
+		}
+		year, err := parseOptionalInt(*yearFlag)
+		if err != nil {
 			return err
 		}
-		if strings.TrimSpace(*contactID) == "" {
-			return errors.New("contact-id is required")
+
+		balances, err := client.listLeaveBalances(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
+		if err != nil {
+			return err
 		}
-		normalizedType := strings.ToUpper(strings.TrimSpace(*balanceType))
-		if normalizedType != "RECEIVABLE" && normalizedType != "PAYABLE" {
-			return errors.New("type must be RECEIVABLE or PAYABLE")
+		if *asJSON {
+			return printJSON(a.stdout, balances)
 		}
-		startDateValue, err := parseRequiredDate("start", *startDate)
+		printLeaveBalancesTable(a.stdout, balances)
+		return nil
+
+	case "by-year":
+		fs := flag.NewFlagSet("leave balances by-year", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		employeeID := fs.String("employee-id", "", "Employee id")
+		yearFlag := fs.String("year", "", "Balance year")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("employee-id is required")
+		}
+		year, err := parseRequiredPositiveInt("year", *yearFlag)
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseRequiredDate("end", *endDate)
+
+		balances, err := client.getLeaveBalancesByYear(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
 		if err != nil {
 			return err
 		}
-		if endDateValue.Before(startDateValue) {
-			return errors.New("end must be on or after start")
+		if *asJSON {
+			return printJSON(a.stdout, balances)
 		}
-		start := startDateValue.Format("2006-01-02")
-		end := endDateValue.Format("2006-01-02")
-
-		if *asCSV {
-			content, err := client.exportContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end, "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "contact statement CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end, "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "contact statement XLSX")
-		}
-		if *asPDF {
-			content, err := client.exportContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end, "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "contact statement PDF")
-		}
-
-		report, err := client.getContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end)
-		if err != nil {
-			return err
-		}
-		if *asJSON {
-			return printJSON(a.stdout, report)
-		}
-		printContactStatement(a.stdout, report)
+		printLeaveBalancesTable(a.stdout, balances)
 		return nil
 
-	case "sales-margin":
-		fs := flag.NewFlagSet("reports sales-margin", flag.ContinueOnError)
+	case "update":
+		fs := flag.NewFlagSet("leave balances update", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		absenceTypeID := fs.String("absence-type-id", "", "Absence type id")
+		yearFlag := fs.String("year", "", "Balance year")
+		entitledDaysFlag := fs.String("entitled-days", "", "Entitled days")
+		carryoverDaysFlag := fs.String("carryover-days", "", "Carryover days")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("employee-id is required")
 		}
-		startDateValue, err := parseRequiredDate("start", *startDate)
+		if strings.TrimSpace(*absenceTypeID) == "" {
+			return errors.New("absence-type-id is required")
+		}
+		year, err := parseRequiredPositiveInt("year", *yearFlag)
 		if err != nil {
 			return err
 		}
-		endDateValue, err := parseRequiredDate("end", *endDate)
+		entitledDays, err := parseOptionalNonNegativeDecimalPtr("entitled-days", *entitledDaysFlag)
 		if err != nil {
 			return err
 		}
-		if endDateValue.Before(startDateValue) {
-			return errors.New("end must be on or after start")
-		}
-		start := startDateValue.Format("2006-01-02")
-		end := endDateValue.Format("2006-01-02")
-
-		if *asCSV {
-			content, err := client.exportSalesMarginReport(ctx, cfg.TenantID, start, end, "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "sales margin CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportSalesMarginReport(ctx, cfg.TenantID, start, end, "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "sales margin XLSX")
+		carryoverDays, err := parseOptionalNonNegativeDecimalPtr("carryover-days", *carryoverDaysFlag)
+		if err != nil {
+			return err
 		}
-		if *asPDF {
-			content, err := client.exportSalesMarginReport(ctx, cfg.TenantID, start, end, "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "sales margin PDF")
+		if entitledDays == nil && carryoverDays == nil && strings.TrimSpace(*notes) == "" {
+			return errors.New("entitled-days, carryover-days, or notes is required")
 		}
 
-		report, err := client.getSalesMarginReport(ctx, cfg.TenantID, start, end)
+		balance, err := client.updateLeaveBalance(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year, strings.TrimSpace(*absenceTypeID), &payroll.UpdateLeaveBalanceRequest{
+			EntitledDays:  entitledDays,
+			CarryoverDays: carryoverDays,
+			Notes:         strings.TrimSpace(*notes),
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, balance)
 		}
-		printSalesMarginReport(a.stdout, report)
+		printLeaveBalancesTable(a.stdout, []payroll.LeaveBalance{*balance})
 		return nil
 
-	case "customer-profitability":
-		fs := flag.NewFlagSet("reports customer-profitability", flag.ContinueOnError)
+	case "initialize":
+		fs := flag.NewFlagSet("leave balances initialize", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		yearFlag := fs.String("year", "", "Balance year")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
-		}
-		startDateValue, err := parseRequiredDate("start", *startDate)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("employee-id is required")
 		}
-		endDateValue, err := parseRequiredDate("end", *endDate)
+		year, err := parseRequiredPositiveInt("year", *yearFlag)
 		if err != nil {
 			return err
 		}
-		if endDateValue.Before(startDateValue) {
-			return errors.New("end must be on or after start")
-		}
-		start := startDateValue.Format("2006-01-02")
-		end := endDateValue.Format("2006-01-02")
-
-		if *asCSV {
-			content, err := client.exportCustomerProfitabilityReport(ctx, cfg.TenantID, start, end, "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "customer profitability CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportCustomerProfitabilityReport(ctx, cfg.TenantID, start, end, "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "customer profitability XLSX")
-		}
-		if *asPDF {
-			content, err := client.exportCustomerProfitabilityReport(ctx, cfg.TenantID, start, end, "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "customer profitability PDF")
-		}
 
-		report, err := client.getCustomerProfitabilityReport(ctx, cfg.TenantID, start, end)
+		balances, err := client.initializeLeaveBalances(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, balances)
 		}
-		printCustomerProfitabilityReport(a.stdout, report)
+		printLeaveBalancesTable(a.stdout, balances)
 		return nil
 
-	case "budget-vs-actual":
-		fs := flag.NewFlagSet("reports budget-vs-actual", flag.ContinueOnError)
+	case "import":
+		fs := flag.NewFlagSet("leave balances import", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
-		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		filePath := fs.String("file", "", "CSV file path")
 		asJSON := fs.Bool("json", false, "Output JSON")
-		asCSV := fs.Bool("csv", false, "Output CSV")
-		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
-		asPDF := fs.Bool("pdf", false, "Output PDF")
-		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
-			return err
-		}
-		startDateValue, err := parseOptionalDate("start", *startDate)
-		if err != nil {
-			return err
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
 		}
-		endDateValue, err := parseOptionalDate("end", *endDate)
+
+		content, fileName, err := readCSVInput(*filePath)
 		if err != nil {
 			return err
 		}
-		if startDateValue != nil && endDateValue != nil && endDateValue.Before(*startDateValue) {
-			return errors.New("end must be on or after start")
-		}
-
-		if *asCSV {
-			content, err := client.exportBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue, "csv")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "budget vs actual CSV")
-		}
-		if *asXLSX {
-			content, err := client.exportBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue, "xlsx")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "budget vs actual XLSX")
-		}
-		if *asPDF {
-			content, err := client.exportBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue, "pdf")
-			if err != nil {
-				return err
-			}
-			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "budget vs actual PDF")
-		}
-
-		report, err := client.getBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue)
+		result, err := client.importLeaveBalances(ctx, cfg.TenantID, &payroll.ImportLeaveBalancesRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, report)
+			return printJSON(a.stdout, result)
 		}
-		printBudgetVsActualReport(a.stdout, report)
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d leave balances, updated %d leave balances, skipped %d rows\n",
+			result.RowsProcessed,
+			result.LeaveBalancesCreated,
+			result.LeaveBalancesUpdated,
+			result.RowsSkipped,
+		)
 		return nil
 
 	default:
-		return fmt.Errorf("unknown reports subcommand %q", args[0])
+		return fmt.Errorf("unknown leave balances subcommand %q", args[0])
 	}
 }
 
-func (a *cliApp) runCashFlowMapping(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+func (a *cliApp) runEmploymentRegister(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("reports cash-flow-mapping subcommand required")
+		return errors.New("employment-register subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
 	}
 
 	switch args[0] {
-	case "get":
-		fs := flag.NewFlagSet("reports cash-flow-mapping get", flag.ContinueOnError)
+	case "pending":
+		fs := flag.NewFlagSet("employment-register pending", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
 
-		mapping, err := client.getCashFlowMapping(ctx, cfg.TenantID)
+		pending, err := client.listPendingEmploymentRegister(ctx, cfg.TenantID)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, mapping)
+			return printJSON(a.stdout, pending)
 		}
-		printCashFlowMapping(a.stdout, mapping)
+		printPendingEmploymentRegisterTable(a.stdout, pending)
 		return nil
-	case "update":
-		fs := flag.NewFlagSet("reports cash-flow-mapping update", flag.ContinueOnError)
+
+	case "export":
+		fs := flag.NewFlagSet("employment-register export", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		operatingAccounts := fs.String("operating-accounts", "", "Comma-separated account codes to force into operating cash flow")
-		investingAccounts := fs.String("investing-accounts", "", "Comma-separated account codes to force into investing cash flow")
-		financingAccounts := fs.String("financing-accounts", "", "Comma-separated account codes to force into financing cash flow")
-		asJSON := fs.Bool("json", false, "Output JSON")
+		fromDate := fs.String("from", "", "First event date in YYYY-MM-DD")
+		toDate := fs.String("to", "", "Last event date in YYYY-MM-DD")
+		includeExported := fs.Bool("include-exported", false, "Include events that were already exported")
+		outputPath := fs.String("output", "", "Optional output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-
-		mapping, err := reports.NormalizeCashFlowMappingOverrides(reports.CashFlowMappingOverrides{
-			OperatingAccountCodes: splitCSVFlag(*operatingAccounts),
-			InvestingAccountCodes: splitCSVFlag(*investingAccounts),
-			FinancingAccountCodes: splitCSVFlag(*financingAccounts),
-		})
+		from, err := parseRequiredDate("from", *fromDate)
 		if err != nil {
 			return err
 		}
-		updated, err := client.updateCashFlowMapping(ctx, cfg.TenantID, mapping)
+		to, err := parseRequiredDate("to", *toDate)
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, updated)
+
+		content, err := client.exportEmploymentRegister(ctx, cfg.TenantID, &payroll.ExportEmploymentRegisterRequest{
+			From:            from,
+			To:              to,
+			IncludeExported: *includeExported,
+		})
+		if err != nil {
+			return err
 		}
-		printCashFlowMapping(a.stdout, updated)
-		return nil
-	default:
-		return fmt.Errorf("unknown reports cash-flow-mapping subcommand %q", args[0])
-	}
-}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "employment register CSV")
 
-func validateReportOutputFlags(asJSON, asCSV, asXLSX, asPDF bool, outputPath string) error {
-	selected := 0
-	for _, value := range []bool{asJSON, asCSV, asXLSX, asPDF} {
-		if value {
-			selected++
-		}
-	}
-	if selected > 1 {
-		return errors.New("json, csv, xlsx, and pdf cannot be combined")
-	}
-	if strings.TrimSpace(outputPath) != "" && !asCSV && !asXLSX && !asPDF {
-		return errors.New("output requires csv, xlsx, or pdf")
+	default:
+		return fmt.Errorf("unknown employment-register subcommand %q", args[0])
 	}
-	return nil
 }
 
-func (a *cliApp) runDocuments(ctx context.Context, args []string) error {
+func (a *cliApp) runSelf(ctx context.Context, args []string) error {
 	if len(args) == 0 {
-		return errors.New("documents subcommand required")
+		return errors.New("self subcommand required")
 	}
 	cfg, client, err := a.loadAuthenticatedClient()
 	if err != nil {
@@ -12260,1150 +12872,4786 @@ func (a *cliApp) runDocuments(ctx context.Context, args []string) error {
 	}
 
 	switch args[0] {
-	case "list":
-		fs := flag.NewFlagSet("documents list", flag.ContinueOnError)
+	case "employee":
+		fs := flag.NewFlagSet("self employee", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
-		entityID := fs.String("entity-id", "", "Entity id")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*entityType) == "" || strings.TrimSpace(*entityID) == "" {
-			return errors.New("entity-type and entity-id are required")
-		}
 
-		docs, err := client.listDocuments(ctx, cfg.TenantID, strings.TrimSpace(*entityType), strings.TrimSpace(*entityID))
+		employee, err := client.getSelfEmployee(ctx, cfg.TenantID)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, docs)
+			return printJSON(a.stdout, employee)
 		}
-		printDocumentsTable(a.stdout, docs)
+		printEmployee(a.stdout, employee)
 		return nil
 
-	case "review-summary":
-		fs := flag.NewFlagSet("documents review-summary", flag.ContinueOnError)
+	case "payslips":
+		fs := flag.NewFlagSet("self payslips", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
-		entityIDs := stringListFlags{}
-		fs.Var(&entityIDs, "entity-id", "Entity id; repeatable")
+		yearFlag := fs.String("year", "", "Payroll period year")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*entityType) == "" {
-			return errors.New("entity-type is required")
-		}
-		if len(entityIDs) == 0 {
-			return errors.New("at least one entity-id is required")
+		year, err := parseOptionalInt(*yearFlag)
+		if err != nil {
+			return err
 		}
 
-		summaries, err := client.listDocumentReviewSummaries(ctx, cfg.TenantID, &documentReviewSummaryRequest{
-			EntityType: strings.TrimSpace(*entityType),
-			EntityIDs:  []string(entityIDs),
-		})
+		payslips, err := client.listSelfPayslips(ctx, cfg.TenantID, year)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, summaries)
+			return printJSON(a.stdout, payslips)
 		}
-		printDocumentReviewSummariesTable(a.stdout, summaries)
+		printEmployeePayslipsTable(a.stdout, payslips)
 		return nil
 
-	case "review-queue":
-		fs := flag.NewFlagSet("documents review-queue", flag.ContinueOnError)
+	case "payslip-pdf":
+		fs := flag.NewFlagSet("self payslip-pdf", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entityType := fs.String("entity-type", "", "Optional entity type filter")
-		documentType := fs.String("document-type", "", "Optional document type filter")
-		reviewStatus := fs.String("status", documents.ReviewStatusPending, "Review status filter: PENDING, REVIEWED, APPROVED, REJECTED, or all")
-		limit := fs.Int("limit", 50, "Maximum documents to return")
-		asJSON := fs.Bool("json", false, "Output JSON")
+		payslipID := fs.String("id", "", "Payslip id")
+		outputPath := fs.String("output", "", "Optional PDF output file path")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if *limit < 0 {
-			return errors.New("limit must be zero or greater")
+		if strings.TrimSpace(*payslipID) == "" {
+			return errors.New("id is required")
 		}
 
-		queue, err := client.getDocumentReviewQueue(ctx, cfg.TenantID, &documents.ReviewQueueFilter{
-			EntityType:   strings.TrimSpace(*entityType),
-			DocumentType: strings.TrimSpace(*documentType),
-			ReviewStatus: strings.TrimSpace(*reviewStatus),
-			Limit:        *limit,
-		})
+		content, err := client.downloadSelfPayslipPDF(ctx, cfg.TenantID, strings.TrimSpace(*payslipID))
 		if err != nil {
 			return err
 		}
-		if *asJSON {
-			return printJSON(a.stdout, queue)
-		}
-		printDocumentReviewQueue(a.stdout, queue)
-		return nil
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "payslip PDF")
 
-	case "evidence-policy":
-		fs := flag.NewFlagSet("documents evidence-policy", flag.ContinueOnError)
+	case "leave-balances":
+		fs := flag.NewFlagSet("self leave-balances", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
-		entityIDs := stringListFlags{}
-		documentTypes := stringListFlags{}
-		fs.Var(&entityIDs, "entity-id", "Entity id; repeatable")
-		fs.Var(&documentTypes, "document-type", "Document type that satisfies the policy; repeatable")
-		fs.Var(&documentTypes, "required-document-type", "Document type that satisfies the policy; repeatable")
-		minCount := fs.Int("min-count", 1, "Minimum number of matching documents")
-		requireApproved := fs.Bool("require-approved", false, "Only count approved matching documents")
+		yearFlag := fs.String("year", "", "Balance year, defaults to the current year")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*entityType) == "" {
-			return errors.New("entity-type is required")
-		}
-		if len(entityIDs) == 0 {
-			return errors.New("at least one entity-id is required")
-		}
-		if *minCount <= 0 {
-			return errors.New("min-count must be one or greater")
+		year, err := parseOptionalInt(*yearFlag)
+		if err != nil {
+			return err
 		}
 
-		results, err := client.evaluateDocumentEvidencePolicy(ctx, cfg.TenantID, &documents.EvidencePolicyRequest{
-			EntityType: strings.TrimSpace(*entityType),
-			EntityIDs:  []string(entityIDs),
-			Rules: []documents.EvidencePolicyRule{{
-				DocumentTypes:   []string(documentTypes),
-				MinCount:        *minCount,
-				RequireApproved: *requireApproved,
-			}},
-		})
+		balances, err := client.listSelfLeaveBalances(ctx, cfg.TenantID, year)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, results)
+			return printJSON(a.stdout, balances)
 		}
-		printDocumentEvidencePolicy(a.stdout, results)
+		printLeaveBalancesTable(a.stdout, balances)
 		return nil
 
-	case "retention":
-		fs := flag.NewFlagSet("documents retention", flag.ContinueOnError)
+	case "leave-records":
+		fs := flag.NewFlagSet("self leave-records", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD; defaults to today")
-		horizonDays := fs.Int("horizon-days", 30, "Include documents due within this many days")
-		includeMissing := fs.Bool("include-missing", false, "Include documents without retention_until")
+		yearFlag := fs.String("year", "", "Record year")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if *horizonDays < 0 {
-			return errors.New("horizon-days must be zero or greater")
+		year, err := parseOptionalInt(*yearFlag)
+		if err != nil {
+			return err
 		}
 
-		review, err := client.getDocumentRetentionReview(ctx, cfg.TenantID, strings.TrimSpace(*asOf), *horizonDays, *includeMissing)
+		records, err := client.listSelfLeaveRecords(ctx, cfg.TenantID, year)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, review)
+			return printJSON(a.stdout, records)
 		}
-		printDocumentRetentionReview(a.stdout, review)
+		printLeaveRecordsTable(a.stdout, records)
 		return nil
 
-	case "purge":
-		fs := flag.NewFlagSet("documents purge", flag.ContinueOnError)
+	case "request-leave":
+		fs := flag.NewFlagSet("self request-leave", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD; defaults to today")
-		limit := fs.Int("limit", 100, "Maximum eligible documents to purge or report")
-		execute := fs.Bool("execute", false, "Execute the purge; default is dry-run")
+		absenceTypeID := fs.String("absence-type-id", "", "Absence type id")
+		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
+		totalDaysFlag := fs.String("total-days", "", "Total calendar days")
+		workingDaysFlag := fs.String("working-days", "", "Working days")
+		documentNumber := fs.String("document-number", "", "Document number")
+		documentDate := fs.String("document-date", "", "Document date in YYYY-MM-DD")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if *limit < 0 {
-			return errors.New("limit must be zero or greater")
+		if strings.TrimSpace(*absenceTypeID) == "" {
+			return errors.New("absence-type-id is required")
 		}
-		if strings.TrimSpace(*asOf) != "" {
-			if _, err := time.Parse("2006-01-02", strings.TrimSpace(*asOf)); err != nil {
-				return fmt.Errorf("parse as-of: %w", err)
-			}
+		start, err := parseRequiredDate("start-date", *startDate)
+		if err != nil {
+			return err
+		}
+		end, err := parseRequiredDate("end-date", *endDate)
+		if err != nil {
+			return err
+		}
+		totalDays, err := parseRequiredPositiveDecimal("total-days", *totalDaysFlag)
+		if err != nil {
+			return err
+		}
+		workingDays, err := parseRequiredPositiveDecimal("working-days", *workingDaysFlag)
+		if err != nil {
+			return err
+		}
+		docDate, err := parseOptionalDate("document-date", *documentDate)
+		if err != nil {
+			return err
 		}
 
-		result, err := client.purgeExpiredDocuments(ctx, cfg.TenantID, documentPurgeRequest{
-			AsOf:   strings.TrimSpace(*asOf),
-			DryRun: !*execute,
-			Limit:  *limit,
+		record, err := client.createSelfLeaveRecord(ctx, cfg.TenantID, &payroll.CreateLeaveRecordRequest{
+			AbsenceTypeID:  strings.TrimSpace(*absenceTypeID),
+			StartDate:      start,
+			EndDate:        end,
+			TotalDays:      totalDays,
+			WorkingDays:    workingDays,
+			DocumentNumber: strings.TrimSpace(*documentNumber),
+			DocumentDate:   docDate,
+			Notes:          strings.TrimSpace(*notes),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, result)
+			return printJSON(a.stdout, record)
 		}
-		printDocumentPurgeResult(a.stdout, result)
+		_, _ = fmt.Fprintf(a.stdout, "Requested leave %s (%s)\n", record.ID, record.Status)
 		return nil
 
-	case "retention-set":
-		fs := flag.NewFlagSet("documents retention-set", flag.ContinueOnError)
+	case "expenses":
+		fs := flag.NewFlagSet("self expenses", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
-		retentionUntil := fs.String("retention-until", "", "Retention date in YYYY-MM-DD")
-		clearRetention := fs.Bool("clear", false, "Clear retention metadata")
+		status := fs.String("status", "", "Optional expense status filter")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" {
-			return errors.New("id is required")
-		}
-		trimmedRetention := strings.TrimSpace(*retentionUntil)
-		if *clearRetention && trimmedRetention != "" {
-			return errors.New("retention-until cannot be combined with clear")
-		}
-		if !*clearRetention && trimmedRetention == "" {
-			return errors.New("retention-until is required unless clear is set")
-		}
-		if trimmedRetention != "" {
-			if _, err := time.Parse("2006-01-02", trimmedRetention); err != nil {
-				return fmt.Errorf("parse retention-until: %w", err)
-			}
-		}
 
-		doc, err := client.updateDocumentRetention(ctx, cfg.TenantID, strings.TrimSpace(*documentID), trimmedRetention, *clearRetention)
+		expenseList, err := client.listSelfExpenses(ctx, cfg.TenantID, expenses.ExpenseStatus(strings.ToUpper(strings.TrimSpace(*status))))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, doc)
-		}
-		if *clearRetention {
-			_, _ = fmt.Fprintf(a.stdout, "Cleared retention for document %s\n", doc.ID)
-			return nil
-		}
-		retentionLabel := trimmedRetention
-		if doc.RetentionUntil != nil {
-			retentionLabel = doc.RetentionUntil.Format("2006-01-02")
+			return printJSON(a.stdout, expenseList)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Set retention for document %s to %s\n", doc.ID, retentionLabel)
+		printExpensesTable(a.stdout, expenseList)
 		return nil
 
-	case "lifecycle-set":
-		fs := flag.NewFlagSet("documents lifecycle-set", flag.ContinueOnError)
+	case "submit-expense":
+		fs := flag.NewFlagSet("self submit-expense", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
-		lifecycleStatus := fs.String("status", "", "Lifecycle status: ACTIVE, SUPERSEDED, ARCHIVED, or DISPOSED")
-		lifecycleNote := fs.String("note", "", "Lifecycle audit note; required for archived or disposed documents")
-		supersededBy := fs.String("superseded-by-document-id", "", "Replacement document id when marking the document superseded")
+		merchant := fs.String("merchant", "", "Merchant")
+		description := fs.String("description", "", "Description")
+		expenseDate := fs.String("expense-date", "", "Expense date in YYYY-MM-DD")
+		amountFlag := fs.String("amount", "", "Expense amount")
+		currency := fs.String("currency", "EUR", "Currency code")
+		exchangeRateFlag := fs.String("exchange-rate", "1", "Exchange rate to base currency")
+		requiresReceipt := fs.Bool("requires-receipt", true, "Require an approved receipt before approval/posting")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" || strings.TrimSpace(*lifecycleStatus) == "" {
-			return errors.New("id and status are required")
+		if strings.TrimSpace(*merchant) == "" {
+			return errors.New("merchant is required")
+		}
+		expenseDateValue, err := parseRequiredDate("expense-date", *expenseDate)
+		if err != nil {
+			return err
+		}
+		amount, err := parseRequiredPositiveDecimal("amount", *amountFlag)
+		if err != nil {
+			return err
+		}
+		exchangeRate, err := parseRequiredPositiveDecimal("exchange-rate", *exchangeRateFlag)
+		if err != nil {
+			return err
 		}
 
-		doc, err := client.updateDocumentLifecycle(ctx, cfg.TenantID, strings.TrimSpace(*documentID), &documents.DocumentLifecycleRequest{
-			LifecycleStatus:      strings.ToUpper(strings.TrimSpace(*lifecycleStatus)),
-			LifecycleNote:        strings.TrimSpace(*lifecycleNote),
-			SupersededByDocument: strings.TrimSpace(*supersededBy),
+		expense, err := client.submitSelfExpense(ctx, cfg.TenantID, &expenses.CreateExpenseRequest{
+			ExpenseDate:     expenseDateValue,
+			Merchant:        strings.TrimSpace(*merchant),
+			Description:     strings.TrimSpace(*description),
+			Amount:          amount,
+			Currency:        strings.ToUpper(strings.TrimSpace(*currency)),
+			ExchangeRate:    exchangeRate,
+			RequiresReceipt: requiresReceipt,
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, doc)
+			return printJSON(a.stdout, expense)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked document %s lifecycle as %s\n", doc.ID, doc.LifecycleStatus)
+		_, _ = fmt.Fprintf(a.stdout, "Submitted expense %s (%s)\n", expense.ExpenseNumber, expense.ID)
 		return nil
 
-	case "legal-hold-set":
-		fs := flag.NewFlagSet("documents legal-hold-set", flag.ContinueOnError)
-		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
-		enabled := fs.Bool("enabled", true, "Whether legal hold is active")
-		note := fs.String("note", "", "Legal hold audit note")
+	default:
+		return fmt.Errorf("unknown self subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runTimesheets(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("timesheets subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("timesheets list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		employeeID := fs.String("employee-id", "", "Employee id")
+		fromDate := fs.String("from", "", "First work date in YYYY-MM-DD")
+		toDate := fs.String("to", "", "Last work date in YYYY-MM-DD")
+		status := fs.String("status", "", "Status: PENDING or APPROVED")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" {
-			return errors.New("id is required")
+		if _, err := parseOptionalDate("from", *fromDate); err != nil {
+			return err
 		}
-		if strings.TrimSpace(*note) == "" {
-			return errors.New("note is required")
+		if _, err := parseOptionalDate("to", *toDate); err != nil {
+			return err
 		}
 
-		doc, err := client.updateDocumentLegalHold(ctx, cfg.TenantID, strings.TrimSpace(*documentID), &documents.DocumentLegalHoldRequest{
-			LegalHold: *enabled,
-			Note:      strings.TrimSpace(*note),
-		})
+		entries, err := client.listTimesheets(ctx, cfg.TenantID, *employeeID, *fromDate, *toDate, strings.ToUpper(strings.TrimSpace(*status)))
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, doc)
-		}
-		state := "released"
-		if doc.LegalHold {
-			state = "placed"
+			return printJSON(a.stdout, entries)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Legal hold %s for document %s\n", state, doc.ID)
+		printTimesheetsTable(a.stdout, entries)
 		return nil
 
-	case "upload":
-		fs := flag.NewFlagSet("documents upload", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("timesheets create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
-		entityID := fs.String("entity-id", "", "Entity id")
-		filePath := fs.String("file", "", "File path ('-' for stdin)")
-		documentType := fs.String("document-type", documents.DocumentTypeSupportingDocument, "Document type")
-		notes := fs.String("notes", "", "Optional notes")
-		retentionUntil := fs.String("retention-until", "", "Optional retention date in YYYY-MM-DD")
-		retentionYears := fs.Int("retention-years", 0, "Set retention date this many years after upload (1-100)")
-		replacesDocumentID := fs.String("replaces-document-id", "", "Existing document id this upload replaces")
-		replacementNote := fs.String("replacement-note", "", "Audit note for the replacement link")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		date := fs.String("date", "", "Work date in YYYY-MM-DD")
+		regularHours := fs.String("regular-hours", "", "Regular hours")
+		overtimeHours := fs.String("overtime-hours", "", "Overtime hours")
+		nightHours := fs.String("night-hours", "", "Night work hours")
+		holidayHours := fs.String("holiday-hours", "", "Public holiday hours")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*entityType) == "" || strings.TrimSpace(*entityID) == "" || strings.TrimSpace(*filePath) == "" {
-			return errors.New("entity-type, entity-id, and file are required")
-		}
-		trimmedRetention := strings.TrimSpace(*retentionUntil)
-		if trimmedRetention != "" && *retentionYears > 0 {
-			return errors.New("retention-until and retention-years cannot be combined")
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("employee-id is required")
 		}
-		if *retentionYears < 0 {
-			return errors.New("retention-years must be zero or greater")
+		workDate, err := parseRequiredDate("date", *date)
+		if err != nil {
+			return err
 		}
-		if *retentionYears > documents.MaxRetentionYears {
-			return fmt.Errorf("retention-years cannot exceed %d", documents.MaxRetentionYears)
+		req := &payroll.CreateTimesheetEntryRequest{
+			EmployeeID: strings.TrimSpace(*employeeID),
+			WorkDate:   workDate,
+			Notes:      strings.TrimSpace(*notes),
 		}
-		var retentionDate *time.Time
-		if trimmedRetention != "" {
-			parsed, err := time.Parse("2006-01-02", trimmedRetention)
+		for _, hours := range []struct {
+			name  string
+			value string
+			dest  *decimal.Decimal
+		}{
+			{"regular-hours", *regularHours, &req.RegularHours},
+			{"overtime-hours", *overtimeHours, &req.OvertimeHours},
+			{"night-hours", *nightHours, &req.NightHours},
+			{"holiday-hours", *holidayHours, &req.HolidayHours},
+		} {
+			parsed, err := parseOptionalNonNegativeDecimalPtr(hours.name, hours.value)
 			if err != nil {
-				return fmt.Errorf("parse retention-until: %w", err)
+				return err
+			}
+			if parsed != nil {
+				*hours.dest = *parsed
 			}
-			normalized := parsed.UTC()
-			retentionDate = &normalized
 		}
 
-		content, fileName, err := readFileInput(*filePath, "stdin.bin")
+		entry, err := client.saveTimesheet(ctx, cfg.TenantID, req)
 		if err != nil {
 			return err
 		}
+		if *asJSON {
+			return printJSON(a.stdout, entry)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Saved timesheet %s for %s (%s hours)\n", entry.ID, formatDate(entry.WorkDate), entry.TotalHours().String())
+		return nil
 
-		doc, err := client.uploadDocument(ctx, cfg.TenantID, &documents.UploadDocumentRequest{
-			EntityType:         strings.TrimSpace(*entityType),
-			EntityID:           strings.TrimSpace(*entityID),
-			DocumentType:       strings.TrimSpace(*documentType),
-			FileName:           fileName,
-			Notes:              strings.TrimSpace(*notes),
-			RetentionUntil:     retentionDate,
-			RetentionYears:     *retentionYears,
-			ReplacesDocumentID: strings.TrimSpace(*replacesDocumentID),
-			ReplacementNote:    strings.TrimSpace(*replacementNote),
-		}, content)
+	case "import":
+		fs := flag.NewFlagSet("timesheets import", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importTimesheets(ctx, cfg.TenantID, &payroll.ImportTimesheetsRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, doc)
+			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Uploaded %s (%s) to %s %s\n", doc.FileName, doc.ID, doc.EntityType, doc.EntityID)
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d entries, updated %d entries, skipped %d rows\n",
+			result.RowsProcessed,
+			result.EntriesCreated,
+			result.EntriesUpdated,
+			result.RowsSkipped,
+		)
 		return nil
 
-	case "download":
-		fs := flag.NewFlagSet("documents download", flag.ContinueOnError)
+	case "approve":
+		fs := flag.NewFlagSet("timesheets approve", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
-		outputPath := fs.String("output", "", "Output path; defaults to downloaded file name, '-' for stdout")
+		employeeID := fs.String("employee-id", "", "Optional employee id")
+		fromDate := fs.String("from", "", "First work date in YYYY-MM-DD")
+		toDate := fs.String("to", "", "Last work date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" {
-			return errors.New("id is required")
-		}
-
-		download, err := client.downloadDocument(ctx, cfg.TenantID, strings.TrimSpace(*documentID))
+		from, err := parseRequiredDate("from", *fromDate)
 		if err != nil {
 			return err
 		}
-		targetPath := strings.TrimSpace(*outputPath)
-		if targetPath == "" {
-			targetPath = download.FileName
+		to, err := parseRequiredDate("to", *toDate)
+		if err != nil {
+			return err
 		}
-		if targetPath == "-" {
-			_, err := a.stdout.Write(download.Content)
+
+		result, err := client.approveTimesheets(ctx, cfg.TenantID, &payroll.ApproveTimesheetsRequest{
+			EmployeeID: strings.TrimSpace(*employeeID),
+			From:       from,
+			To:         to,
+		})
+		if err != nil {
 			return err
 		}
-		if err := os.WriteFile(targetPath, download.Content, 0o600); err != nil {
-			return fmt.Errorf("write document: %w", err)
+		if *asJSON {
+			return printJSON(a.stdout, result)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Downloaded %s to %s (%d bytes)\n", strings.TrimSpace(*documentID), targetPath, len(download.Content))
+		_, _ = fmt.Fprintf(a.stdout, "Approved %d timesheet entries\n", result.Approved)
 		return nil
 
-	case "mark-reviewed":
-		fs := flag.NewFlagSet("documents mark-reviewed", flag.ContinueOnError)
+	default:
+		return fmt.Errorf("unknown timesheets subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runLeaveRecords(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("leave records subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("leave records list", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		yearFlag := fs.String("year", "", "Record year")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" {
-			return errors.New("id is required")
+		year, err := parseOptionalInt(*yearFlag)
+		if err != nil {
+			return err
 		}
 
-		doc, err := client.markDocumentReviewed(ctx, cfg.TenantID, strings.TrimSpace(*documentID))
+		records, err := client.listLeaveRecords(ctx, cfg.TenantID, strings.TrimSpace(*employeeID), year)
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, doc)
+			return printJSON(a.stdout, records)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Marked document %s as reviewed\n", doc.ID)
+		printLeaveRecordsTable(a.stdout, records)
 		return nil
 
-	case "review":
-		fs := flag.NewFlagSet("documents review", flag.ContinueOnError)
+	case "create":
+		fs := flag.NewFlagSet("leave records create", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
-		reviewStatus := fs.String("status", "", "Review status: REVIEWED, APPROVED, or REJECTED")
-		reviewNote := fs.String("note", "", "Optional review note; required for rejected documents")
+		employeeID := fs.String("employee-id", "", "Employee id")
+		absenceTypeID := fs.String("absence-type-id", "", "Absence type id")
+		startDate := fs.String("start-date", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end-date", "", "End date in YYYY-MM-DD")
+		totalDaysFlag := fs.String("total-days", "", "Total calendar days")
+		workingDaysFlag := fs.String("working-days", "", "Working days")
+		documentNumber := fs.String("document-number", "", "Document number")
+		documentDate := fs.String("document-date", "", "Document date in YYYY-MM-DD")
+		notes := fs.String("notes", "", "Notes")
 		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" || strings.TrimSpace(*reviewStatus) == "" {
-			return errors.New("id and status are required")
+		if strings.TrimSpace(*employeeID) == "" {
+			return errors.New("employee-id is required")
+		}
+		if strings.TrimSpace(*absenceTypeID) == "" {
+			return errors.New("absence-type-id is required")
+		}
+		start, err := parseRequiredDate("start-date", *startDate)
+		if err != nil {
+			return err
+		}
+		end, err := parseRequiredDate("end-date", *endDate)
+		if err != nil {
+			return err
+		}
+		totalDays, err := parseRequiredPositiveDecimal("total-days", *totalDaysFlag)
+		if err != nil {
+			return err
+		}
+		workingDays, err := parseRequiredPositiveDecimal("working-days", *workingDaysFlag)
+		if err != nil {
+			return err
+		}
+		docDate, err := parseOptionalDate("document-date", *documentDate)
+		if err != nil {
+			return err
 		}
 
-		doc, err := client.reviewDocument(ctx, cfg.TenantID, strings.TrimSpace(*documentID), &documents.ReviewDocumentRequest{
-			ReviewStatus: strings.ToUpper(strings.TrimSpace(*reviewStatus)),
-			ReviewNote:   strings.TrimSpace(*reviewNote),
+		record, err := client.createLeaveRecord(ctx, cfg.TenantID, &payroll.CreateLeaveRecordRequest{
+			EmployeeID:     strings.TrimSpace(*employeeID),
+			AbsenceTypeID:  strings.TrimSpace(*absenceTypeID),
+			StartDate:      start,
+			EndDate:        end,
+			TotalDays:      totalDays,
+			WorkingDays:    workingDays,
+			DocumentNumber: strings.TrimSpace(*documentNumber),
+			DocumentDate:   docDate,
+			Notes:          strings.TrimSpace(*notes),
 		})
 		if err != nil {
 			return err
 		}
 		if *asJSON {
-			return printJSON(a.stdout, doc)
+			return printJSON(a.stdout, record)
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Reviewed document %s as %s\n", doc.ID, doc.ReviewStatus)
+		_, _ = fmt.Fprintf(a.stdout, "Created leave record %s\n", record.ID)
 		return nil
 
-	case "delete":
-		fs := flag.NewFlagSet("documents delete", flag.ContinueOnError)
+	case "get":
+		fs := flag.NewFlagSet("leave records get", flag.ContinueOnError)
 		fs.SetOutput(a.stderr)
-		documentID := fs.String("id", "", "Document id")
+		recordID := fs.String("id", "", "Leave record id")
+		asJSON := fs.Bool("json", false, "Output JSON")
 		if err := fs.Parse(args[1:]); err != nil {
 			return err
 		}
-		if strings.TrimSpace(*documentID) == "" {
+		if strings.TrimSpace(*recordID) == "" {
 			return errors.New("id is required")
 		}
 
-		if err := client.deleteDocument(ctx, cfg.TenantID, strings.TrimSpace(*documentID)); err != nil {
+		record, err := client.getLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID))
+		if err != nil {
 			return err
 		}
-		_, _ = fmt.Fprintf(a.stdout, "Deleted document %s\n", strings.TrimSpace(*documentID))
+		if *asJSON {
+			return printJSON(a.stdout, record)
+		}
+		printLeaveRecord(a.stdout, record)
 		return nil
 
-	default:
-		return fmt.Errorf("unknown documents subcommand %q", args[0])
-	}
-}
-
-func (a *cliApp) loadAuthenticatedClient() (*cliConfig, *apiClient, error) {
-	cfg, client, err := a.loadTokenClient()
-	if err != nil {
-		return nil, nil, err
-	}
-	if strings.TrimSpace(cfg.TenantID) == "" {
+	case "approve":
+		fs := flag.NewFlagSet("leave records approve", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		recordID := fs.String("id", "", "Leave record id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*recordID) == "" {
+			return errors.New("id is required")
+		}
+
+		record, err := client.approveLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, record)
+		}
+		printLeaveRecord(a.stdout, record)
+		return nil
+
+	case "reject":
+		fs := flag.NewFlagSet("leave records reject", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		recordID := fs.String("id", "", "Leave record id")
+		reason := fs.String("reason", "", "Rejection reason")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*recordID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*reason) == "" {
+			return errors.New("reason is required")
+		}
+
+		record, err := client.rejectLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID), &payroll.RejectLeaveRequest{Reason: strings.TrimSpace(*reason)})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, record)
+		}
+		printLeaveRecord(a.stdout, record)
+		return nil
+
+	case "cancel":
+		fs := flag.NewFlagSet("leave records cancel", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		recordID := fs.String("id", "", "Leave record id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*recordID) == "" {
+			return errors.New("id is required")
+		}
+
+		record, err := client.cancelLeaveRecord(ctx, cfg.TenantID, strings.TrimSpace(*recordID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, record)
+		}
+		printLeaveRecord(a.stdout, record)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown leave records subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runJournal(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("journal subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "templates":
+		return a.runJournalTemplates(ctx, cfg, client, args[1:])
+
+	case "list":
+		fs := flag.NewFlagSet("journal list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		limitFlag := fs.String("limit", "50", "Maximum entries to return")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		limit, err := parseRequiredPositiveInt("limit", *limitFlag)
+		if err != nil {
+			return err
+		}
+		if limit > 200 {
+			return errors.New("limit must be between 1 and 200")
+		}
+
+		entries, err := client.listJournalEntries(ctx, cfg.TenantID, limit)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entries)
+		}
+		printJournalEntriesTable(a.stdout, entries)
+		return nil
+
+	case "get":
+		fs := flag.NewFlagSet("journal get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entryID := fs.String("id", "", "Journal entry id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entryID) == "" {
+			return errors.New("id is required")
+		}
+
+		entry, err := client.getJournalEntry(ctx, cfg.TenantID, strings.TrimSpace(*entryID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entry)
+		}
+		printJournalEntry(a.stdout, entry)
+		return nil
+
+	case "create":
+		fs := flag.NewFlagSet("journal create", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entryDate := fs.String("entry-date", "", "Entry date in YYYY-MM-DD")
+		description := fs.String("description", "", "Journal entry description")
+		reference := fs.String("reference", "", "Reference")
+		sourceType := fs.String("source-type", "", "Source type")
+		sourceID := fs.String("source-id", "", "Source id")
+		requiresEvidence := fs.Bool("requires-evidence", false, "Require approved evidence before posting")
+		lines := journalLineFlags{}
+		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		entryDateValue, err := parseRequiredDate("entry-date", *entryDate)
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(*description) == "" {
+			return errors.New("description is required")
+		}
+		if len(lines) < 2 {
+			return errors.New("at least two lines are required")
+		}
+		parsedSourceID, err := optionalUUIDStringPtr("source-id", *sourceID)
+		if err != nil {
+			return err
+		}
+
+		entry, err := client.createJournalEntry(ctx, cfg.TenantID, &accounting.CreateJournalEntryRequest{
+			EntryDate:        entryDateValue,
+			Description:      strings.TrimSpace(*description),
+			Reference:        strings.TrimSpace(*reference),
+			SourceType:       strings.TrimSpace(*sourceType),
+			SourceID:         parsedSourceID,
+			RequiresEvidence: *requiresEvidence,
+			Lines:            []accounting.CreateJournalEntryLineReq(lines),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entry)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Created journal entry %s (%s)\n", entry.EntryNumber, entry.ID)
+		if entry.RequiresEvidence {
+			_, _ = fmt.Fprintln(a.stdout, "Approved evidence required before posting")
+		}
+		return nil
+
+	case "post":
+		fs := flag.NewFlagSet("journal post", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entryID := fs.String("id", "", "Journal entry id")
+		reason := fs.String("reason", "", "Reason for posting")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entryID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*reason) == "" {
+			return errors.New("reason is required")
+		}
+
+		result, err := client.postJournalEntry(ctx, cfg.TenantID, strings.TrimSpace(*entryID), strings.TrimSpace(*reason))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Posted journal entry %s\n", strings.TrimSpace(*entryID))
+		return nil
+
+	case "void":
+		fs := flag.NewFlagSet("journal void", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entryID := fs.String("id", "", "Journal entry id")
+		reason := fs.String("reason", "", "Void reason")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entryID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*reason) == "" {
+			return errors.New("reason is required")
+		}
+
+		reversal, err := client.voidJournalEntry(ctx, cfg.TenantID, strings.TrimSpace(*entryID), strings.TrimSpace(*reason))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, reversal)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Voided journal entry %s with reversal %s\n", strings.TrimSpace(*entryID), reversal.EntryNumber)
+		return nil
+
+	case "import-opening-balances":
+		fs := flag.NewFlagSet("journal import-opening-balances", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		entryDate := fs.String("entry-date", "", "Entry date in YYYY-MM-DD")
+		description := fs.String("description", "Opening balances", "Journal entry description")
+		reference := fs.String("reference", fmt.Sprintf("OB-%d", time.Now().Year()), "Reference")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+		if strings.TrimSpace(*entryDate) == "" {
+			return errors.New("entry-date is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importOpeningBalances(ctx, cfg.TenantID, &accounting.ImportOpeningBalancesRequest{
+			FileName:    fileName,
+			EntryDate:   strings.TrimSpace(*entryDate),
+			Description: strings.TrimSpace(*description),
+			Reference:   strings.TrimSpace(*reference),
+			CSVContent:  content,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Created posted journal entry %s with %d lines, debit %s, credit %s\n",
+			result.JournalEntry.EntryNumber,
+			result.LinesImported,
+			result.TotalDebit.String(),
+			result.TotalCredit.String(),
+		)
+		return nil
+
+	case "import":
+		fs := flag.NewFlagSet("journal import", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		sourceType := fs.String("source-type", "", "Default source type")
+		postEntries := fs.Bool("post", false, "Post imported entries immediately")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importJournalEntries(ctx, cfg.TenantID, &accounting.ImportJournalEntriesRequest{
+			FileName:    fileName,
+			CSVContent:  content,
+			SourceType:  strings.TrimSpace(*sourceType),
+			PostEntries: *postEntries,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d journal entries, imported %d lines, skipped %d rows\n",
+			result.RowsProcessed,
+			result.EntriesCreated,
+			result.LinesImported,
+			result.RowsSkipped,
+		)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown journal subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runJournalTemplates(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("journal templates subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("journal templates list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		activeOnly := fs.Bool("active-only", false, "Only show active templates")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		templates, err := client.listJournalEntryTemplates(ctx, cfg.TenantID, *activeOnly)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, templates)
+		}
+		printJournalEntryTemplatesTable(a.stdout, templates)
+		return nil
+
+	case "create":
+		fs := flag.NewFlagSet("journal templates create", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		name := fs.String("name", "", "Template name")
+		description := fs.String("description", "", "Journal entry description")
+		reference := fs.String("reference", "", "Default reference")
+		requiresEvidence := fs.Bool("requires-evidence", false, "Require approved evidence before posting generated entries")
+		frequencyFlag := fs.String("frequency", "", "Recurring frequency: WEEKLY, BIWEEKLY, MONTHLY, QUARTERLY, YEARLY")
+		startDate := fs.String("start-date", "", "Recurring start date in YYYY-MM-DD")
+		endDate := fs.String("end-date", "", "Recurring end date in YYYY-MM-DD")
+		nextGenerationDate := fs.String("next-generation-date", "", "Next recurring generation date in YYYY-MM-DD")
+		lines := journalLineFlags{}
+		fs.Var(&lines, "line", "Line as comma-separated key=value pairs; repeatable")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*name) == "" {
+			return errors.New("name is required")
+		}
+		if strings.TrimSpace(*description) == "" {
+			return errors.New("description is required")
+		}
+		if len(lines) < 2 {
+			return errors.New("at least two lines are required")
+		}
+		frequency, err := parseOptionalJournalTemplateFrequency(*frequencyFlag)
+		if err != nil {
+			return err
+		}
+		startDateValue, err := parseOptionalDate("start-date", *startDate)
+		if err != nil {
+			return err
+		}
+		endDateValue, err := parseOptionalDate("end-date", *endDate)
+		if err != nil {
+			return err
+		}
+		nextGenerationDateValue, err := parseOptionalDate("next-generation-date", *nextGenerationDate)
+		if err != nil {
+			return err
+		}
+
+		template, err := client.createJournalEntryTemplate(ctx, cfg.TenantID, &accounting.CreateJournalEntryTemplateRequest{
+			Name:               strings.TrimSpace(*name),
+			Description:        strings.TrimSpace(*description),
+			Reference:          strings.TrimSpace(*reference),
+			RequiresEvidence:   *requiresEvidence,
+			Frequency:          frequency,
+			StartDate:          startDateValue,
+			EndDate:            endDateValue,
+			NextGenerationDate: nextGenerationDateValue,
+			Lines:              []accounting.CreateJournalEntryLineReq(lines),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, template)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Created journal entry template %s (%s)\n", template.Name, template.ID)
+		return nil
+
+	case "get":
+		fs := flag.NewFlagSet("journal templates get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		templateID := fs.String("id", "", "Journal entry template id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*templateID) == "" {
+			return errors.New("id is required")
+		}
+
+		template, err := client.getJournalEntryTemplate(ctx, cfg.TenantID, strings.TrimSpace(*templateID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, template)
+		}
+		printJournalEntryTemplate(a.stdout, template)
+		return nil
+
+	case "generate":
+		fs := flag.NewFlagSet("journal templates generate", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		templateID := fs.String("id", "", "Journal entry template id")
+		entryDate := fs.String("entry-date", "", "Override entry date in YYYY-MM-DD")
+		postEntry := fs.Bool("post", false, "Post the generated entry immediately")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*templateID) == "" {
+			return errors.New("id is required")
+		}
+		entryDateValue, err := parseOptionalDate("entry-date", *entryDate)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.generateJournalEntryTemplate(ctx, cfg.TenantID, strings.TrimSpace(*templateID), &accounting.GenerateJournalEntryTemplateRequest{
+			EntryDate: entryDateValue,
+			Post:      *postEntry,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		printJournalEntryTemplateGenerationResults(a.stdout, []accounting.JournalEntryTemplateGenerationResult{*result})
+		return nil
+
+	case "generate-due":
+		fs := flag.NewFlagSet("journal templates generate-due", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asOfDate := fs.String("as-of", "", "Generate templates due on or before YYYY-MM-DD")
+		postEntries := fs.Bool("post", false, "Post generated entries immediately")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		asOfDateValue, err := parseOptionalDate("as-of", *asOfDate)
+		if err != nil {
+			return err
+		}
+
+		results, err := client.generateDueJournalEntryTemplates(ctx, cfg.TenantID, &accounting.GenerateDueJournalEntryTemplatesRequest{
+			AsOfDate: asOfDateValue,
+			Post:     *postEntries,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, results)
+		}
+		printJournalEntryTemplateGenerationResults(a.stdout, results)
+		return nil
+
+	case "apply":
+		fs := flag.NewFlagSet("journal templates apply", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		templateID := fs.String("id", "", "Journal entry template id")
+		entryDate := fs.String("entry-date", "", "Entry date in YYYY-MM-DD")
+		description := fs.String("description", "", "Override generated entry description")
+		reference := fs.String("reference", "", "Override generated entry reference")
+		postEntry := fs.Bool("post", false, "Post the generated entry immediately")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*templateID) == "" {
+			return errors.New("id is required")
+		}
+		entryDateValue, err := parseRequiredDate("entry-date", *entryDate)
+		if err != nil {
+			return err
+		}
+
+		entry, err := client.applyJournalEntryTemplate(ctx, cfg.TenantID, strings.TrimSpace(*templateID), &accounting.ApplyJournalEntryTemplateRequest{
+			EntryDate:   entryDateValue,
+			Description: strings.TrimSpace(*description),
+			Reference:   strings.TrimSpace(*reference),
+			Post:        *postEntry,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, entry)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Created journal entry %s (%s) from template %s\n", entry.EntryNumber, entry.ID, strings.TrimSpace(*templateID))
+		if entry.Status == accounting.StatusPosted {
+			_, _ = fmt.Fprintln(a.stdout, "Generated entry was posted")
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown journal templates subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runPayroll(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("payroll subcommand required")
+	}
+
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "runs":
+		return a.runPayrollRuns(ctx, cfg, client, args[1:])
+
+	case "posting-accounts":
+		return a.runPayrollPostingAccounts(ctx, cfg, client, args[1:])
+
+	case "tax-preview":
+		fs := flag.NewFlagSet("payroll tax-preview", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		grossSalary := fs.String("gross-salary", "", "Gross salary")
+		applyBasicExemption := fs.Bool("apply-basic-exemption", true, "Apply basic exemption")
+		fundedPensionRate := fs.String("funded-pension-rate", "0.02", "Funded pension rate")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		grossSalaryValue, err := decimal.NewFromString(strings.TrimSpace(*grossSalary))
+		if err != nil || grossSalaryValue.LessThanOrEqual(decimal.Zero) {
+			return errors.New("gross-salary must be a positive decimal")
+		}
+		fundedPensionValue, err := decimal.NewFromString(strings.TrimSpace(*fundedPensionRate))
+		if err != nil {
+			return fmt.Errorf("parse funded-pension-rate: %w", err)
+		}
+
+		calculation, err := client.calculateTaxPreview(ctx, cfg.TenantID, grossSalaryValue, *applyBasicExemption, fundedPensionValue)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, calculation)
+		}
+		printTaxCalculation(a.stdout, calculation)
+		return nil
+
+	case "import-history":
+		fs := flag.NewFlagSet("payroll import-history", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importPayrollHistory(ctx, cfg.TenantID, &payroll.ImportPayrollHistoryRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d payroll runs, created %d payslips, skipped %d rows\n",
+			result.RowsProcessed,
+			result.PayrollRunsCreated,
+			result.PayslipsCreated,
+			result.RowsSkipped,
+		)
+		return nil
+	case "import-leave-balances":
+		fs := flag.NewFlagSet("payroll import-leave-balances", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importLeaveBalances(ctx, cfg.TenantID, &payroll.ImportLeaveBalancesRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d leave balances, updated %d leave balances, skipped %d rows\n",
+			result.RowsProcessed,
+			result.LeaveBalancesCreated,
+			result.LeaveBalancesUpdated,
+			result.RowsSkipped,
+		)
+		return nil
+	default:
+		return fmt.Errorf("unknown payroll subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runPayrollRuns(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("payroll runs subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("payroll runs list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Optional period year")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year := 0
+		if strings.TrimSpace(*yearFlag) != "" {
+			parsed, err := parseRequiredPositiveInt("year", *yearFlag)
+			if err != nil {
+				return err
+			}
+			year = parsed
+		}
+
+		runs, err := client.listPayrollRuns(ctx, cfg.TenantID, year)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, runs)
+		}
+		printPayrollRunsTable(a.stdout, runs)
+		return nil
+
+	case "create":
+		fs := flag.NewFlagSet("payroll runs create", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Period year")
+		monthFlag := fs.String("month", "", "Period month")
+		paymentDate := fs.String("payment-date", "", "Optional payment date in YYYY-MM-DD")
+		notes := fs.String("notes", "", "Optional notes")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+		var paymentDateValue *time.Time
+		if strings.TrimSpace(*paymentDate) != "" {
+			parsed, err := time.Parse("2006-01-02", strings.TrimSpace(*paymentDate))
+			if err != nil {
+				return fmt.Errorf("parse payment-date: %w", err)
+			}
+			paymentDateValue = &parsed
+		}
+
+		run, err := client.createPayrollRun(ctx, cfg.TenantID, &payroll.CreatePayrollRunRequest{
+			PeriodYear:  year,
+			PeriodMonth: month,
+			PaymentDate: paymentDateValue,
+			Notes:       strings.TrimSpace(*notes),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, run)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Created payroll run %04d-%02d (%s)\n", run.PeriodYear, run.PeriodMonth, run.ID)
+		return nil
+
+	case "get":
+		fs := flag.NewFlagSet("payroll runs get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+
+		run, err := client.getPayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, run)
+		}
+		printPayrollRun(a.stdout, run)
+		return nil
+
+	case "calculate":
+		fs := flag.NewFlagSet("payroll runs calculate", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+
+		run, err := client.calculatePayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, run)
+		}
+		printPayrollRun(a.stdout, run)
+		return nil
+
+	case "set-payment-date":
+		fs := flag.NewFlagSet("payroll runs set-payment-date", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		paymentDate := fs.String("payment-date", "", "Payment date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		trimmedRunID := strings.TrimSpace(*runID)
+		if trimmedRunID == "" {
+			return errors.New("id is required")
+		}
+		trimmedPaymentDate := strings.TrimSpace(*paymentDate)
+		if trimmedPaymentDate == "" {
+			return errors.New("payment-date is required")
+		}
+		parsedPaymentDate, err := time.Parse("2006-01-02", trimmedPaymentDate)
+		if err != nil {
+			return fmt.Errorf("parse payment-date: %w", err)
+		}
+
+		run, err := client.updatePayrollRunPaymentDate(ctx, cfg.TenantID, trimmedRunID, &payroll.UpdatePayrollRunPaymentDateRequest{
+			PaymentDate: parsedPaymentDate,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, run)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Updated payroll run %s payment date to %s\n", trimmedRunID, trimmedPaymentDate)
+		printPayrollRun(a.stdout, run)
+		return nil
+
+	case "process":
+		fs := flag.NewFlagSet("payroll runs process", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		approve := fs.Bool("approve", false, "Approve after calculation")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		trimmedRunID := strings.TrimSpace(*runID)
+		if trimmedRunID == "" {
+			return errors.New("id is required")
+		}
+
+		result, err := client.processPayrollRun(ctx, cfg.TenantID, trimmedRunID, &payroll.ProcessPayrollRunRequest{
+			Approve: *approve,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Processed payroll run %s with %d payslips\n", trimmedRunID, result.PayslipCount)
+		if result.Approved {
+			_, _ = fmt.Fprintln(a.stdout, "Payroll run was approved")
+		}
+		printPayrollRun(a.stdout, result.PayrollRun)
+		return nil
+
+	case "approve":
+		fs := flag.NewFlagSet("payroll runs approve", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+
+		result, err := client.approvePayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Approved payroll run %s\n", strings.TrimSpace(*runID))
+		return nil
+
+	case "reopen":
+		fs := flag.NewFlagSet("payroll runs reopen", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		reason := fs.String("reason", "", "Reason for reopening")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*reason) == "" {
+			return errors.New("reason is required")
+		}
+
+		run, err := client.reopenPayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID), strings.TrimSpace(*reason))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, run)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Reopened payroll run %s\n", run.ID)
+		printPayrollRun(a.stdout, run)
+		return nil
+
+	case "payslips":
+		fs := flag.NewFlagSet("payroll runs payslips", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+
+		payslips, err := client.listPayslips(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, payslips)
+		}
+		printPayslipsTable(a.stdout, payslips)
+		return nil
+
+	case "pay":
+		fs := flag.NewFlagSet("payroll runs pay", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		bankAccountID := fs.String("bank-account-id", "", "Paying bank account id")
+		includeTax := fs.Bool("include-tax", false, "Add the TSD tax transfer with the tenant prepayment reference")
+		taxIBAN := fs.String("tax-iban", "", "Tax and Customs Board IBAN for the tax transfer")
+		outputPath := fs.String("output", "", "Optional SEPA XML output file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*bankAccountID) == "" {
+			return errors.New("bank-account-id is required")
+		}
+
+		result, err := client.payPayrollRun(ctx, cfg.TenantID, strings.TrimSpace(*runID), &payroll.PayPayrollRunRequest{
+			BankAccountID:     strings.TrimSpace(*bankAccountID),
+			IncludeTaxPayment: *includeTax,
+			TaxAccountIBAN:    strings.TrimSpace(*taxIBAN),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		if strings.TrimSpace(*outputPath) != "" && result.SEPA != nil {
+			if err := writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), []byte(result.SEPA.XML), "payroll SEPA XML"); err != nil {
+				return err
+			}
+		}
+		printPayrollPaymentsTable(a.stdout, []payroll.PayrollPayment{*result.PayrollPayment})
+		return nil
+
+	case "payments":
+		fs := flag.NewFlagSet("payroll runs payments", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("id", "", "Payroll run id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("id is required")
+		}
+
+		paid, err := client.listPayrollPayments(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, paid)
+		}
+		printPayrollPaymentsTable(a.stdout, paid)
+		return nil
+
+	case "payslip-pdf":
+		fs := flag.NewFlagSet("payroll runs payslip-pdf", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("run-id", "", "Payroll run id")
+		payslipID := fs.String("payslip-id", "", "Payslip id")
+		outputPath := fs.String("output", "", "Optional PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("run-id is required")
+		}
+		if strings.TrimSpace(*payslipID) == "" {
+			return errors.New("payslip-id is required")
+		}
+		content, err := client.downloadPayslipPDF(ctx, cfg.TenantID, strings.TrimSpace(*runID), strings.TrimSpace(*payslipID))
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "payslip PDF")
+
+	default:
+		return fmt.Errorf("unknown payroll runs subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runPayrollPostingAccounts(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("payroll posting-accounts subcommand required")
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("payroll posting-accounts list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		accounts, err := client.listPayrollPostingAccounts(ctx, cfg.TenantID)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, accounts)
+		}
+		printPayrollPostingAccountsTable(a.stdout, accounts)
+		return nil
+
+	case "set":
+		fs := flag.NewFlagSet("payroll posting-accounts set", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		department := fs.String("department", "", "Department override; omit for the tenant default")
+		costCenterID := fs.String("cost-center-id", "", "Cost center for department payroll expenses")
+		salaryExpense := fs.String("salary-expense-account-id", "", "Gross salary expense account id")
+		employerTaxExpense := fs.String("employer-tax-expense-account-id", "", "Employer social tax and unemployment expense account id")
+		incomeTaxPayable := fs.String("income-tax-payable-account-id", "", "Income tax payable account id")
+		socialTaxPayable := fs.String("social-tax-payable-account-id", "", "Social tax payable account id")
+		unemploymentPayable := fs.String("unemployment-payable-account-id", "", "Unemployment insurance payable account id")
+		fundedPensionPayable := fs.String("funded-pension-payable-account-id", "", "Funded pension payable account id")
+		netPayPayable := fs.String("net-pay-payable-account-id", "", "Net salary payable account id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		accounts, err := client.setPayrollPostingAccounts(ctx, cfg.TenantID, &payroll.SetPayrollPostingAccountsRequest{
+			Department:                    strings.TrimSpace(*department),
+			CostCenterID:                  optionalStringPtr(*costCenterID),
+			SalaryExpenseAccountID:        strings.TrimSpace(*salaryExpense),
+			EmployerTaxExpenseAccountID:   strings.TrimSpace(*employerTaxExpense),
+			IncomeTaxPayableAccountID:     strings.TrimSpace(*incomeTaxPayable),
+			SocialTaxPayableAccountID:     strings.TrimSpace(*socialTaxPayable),
+			UnemploymentPayableAccountID:  strings.TrimSpace(*unemploymentPayable),
+			FundedPensionPayableAccountID: strings.TrimSpace(*fundedPensionPayable),
+			NetPayPayableAccountID:        strings.TrimSpace(*netPayPayable),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, accounts)
+		}
+		printPayrollPostingAccountsTable(a.stdout, []payroll.PayrollPostingAccounts{*accounts})
+		return nil
+
+	case "delete":
+		fs := flag.NewFlagSet("payroll posting-accounts delete", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		department := fs.String("department", "", "Department override; omit for the tenant default")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		trimmedDepartment := strings.TrimSpace(*department)
+		if err := client.deletePayrollPostingAccounts(ctx, cfg.TenantID, trimmedDepartment); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Deleted payroll posting accounts for %s\n", defaultString(trimmedDepartment, "tenant default"))
+		return nil
+
+	default:
+		return fmt.Errorf("unknown payroll posting-accounts subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runTSD(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("tsd subcommand required")
+	}
+
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("tsd list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		yearFlag := fs.String("year", "", "Filter by declaration year")
+		monthFlag := fs.String("month", "", "Filter by declaration month")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		year, err := parseOptionalPositiveInt("year", *yearFlag)
+		if err != nil {
+			return err
+		}
+		month, err := parseOptionalBoundedInt("month", *monthFlag, 1, 12)
+		if err != nil {
+			return err
+		}
+		declarations, err := client.listTSD(ctx, cfg.TenantID, payroll.TSDListFilter{
+			Year:  year,
+			Month: month,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, declarations)
+		}
+		printTSDDeclarationsTable(a.stdout, declarations)
+		return nil
+
+	case "get":
+		fs := flag.NewFlagSet("tsd get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		declaration, err := client.getTSD(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, declaration)
+		}
+		printTSDDeclaration(a.stdout, declaration)
+		return nil
+
+	case "generate":
+		fs := flag.NewFlagSet("tsd generate", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		runID := fs.String("run-id", "", "Payroll run id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*runID) == "" {
+			return errors.New("run-id is required")
+		}
+
+		declaration, err := client.generateTSD(ctx, cfg.TenantID, strings.TrimSpace(*runID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, declaration)
+		}
+		printTSDDeclaration(a.stdout, declaration)
+		return nil
+
+	case "export-xml":
+		fs := flag.NewFlagSet("tsd export-xml", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		outputPath := fs.String("output", "", "Optional output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		content, err := client.exportTSDXML(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "TSD XML")
+
+	case "export-csv":
+		fs := flag.NewFlagSet("tsd export-csv", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		outputPath := fs.String("output", "", "Optional output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		content, err := client.exportTSDCSV(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "TSD CSV")
+
+	case "import-history":
+		fs := flag.NewFlagSet("tsd import-history", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importTSDHistory(ctx, cfg.TenantID, &payroll.ImportTSDHistoryRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d TSD declarations, imported %d rows, skipped %d rows\n",
+			result.RowsProcessed,
+			result.DeclarationsCreated,
+			result.RowsImported,
+			result.RowsSkipped,
+		)
+		return nil
+
+	case "mark-submitted":
+		fs := flag.NewFlagSet("tsd mark-submitted", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		emtaReference := fs.String("emta-reference", "", "e-MTA submission reference")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.markTSDSubmitted(ctx, cfg.TenantID, year, month, strings.TrimSpace(*emtaReference))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked TSD %04d-%02d as submitted\n", year, month)
+		return nil
+
+	case "mark-accepted":
+		fs := flag.NewFlagSet("tsd mark-accepted", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.markTSDAccepted(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked TSD %04d-%02d as accepted\n", year, month)
+		return nil
+
+	case "mark-rejected":
+		fs := flag.NewFlagSet("tsd mark-rejected", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.markTSDRejected(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked TSD %04d-%02d as rejected\n", year, month)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown tsd subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runTax(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("tax subcommand required")
+	}
+	if args[0] == "oss" {
+		return a.runTaxOSS(ctx, args[1:])
+	}
+	if args[0] != "kmd" {
+		return fmt.Errorf("unknown tax subcommand %q", args[0])
+	}
+	if len(args) == 1 {
+		return errors.New("tax kmd subcommand required")
+	}
+
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[1] {
+	case "list":
+		fs := flag.NewFlagSet("tax kmd list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+
+		declarations, err := client.listKMD(ctx, cfg.TenantID)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, declarations)
+		}
+		printKMDDeclarationsTable(a.stdout, declarations)
+		return nil
+
+	case "generate":
+		fs := flag.NewFlagSet("tax kmd generate", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		declaration, err := client.generateKMD(ctx, cfg.TenantID, &tax.CreateKMDRequest{
+			Year:  year,
+			Month: month,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, declaration)
+		}
+		printKMDDeclaration(a.stdout, declaration)
+		return nil
+
+	case "inf":
+		fs := flag.NewFlagSet("tax kmd inf", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		thresholdFlag := fs.String("threshold", "", "Optional partner-period threshold excluding VAT")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+		threshold := decimal.Zero
+		if strings.TrimSpace(*thresholdFlag) != "" {
+			threshold, err = parseRequiredPositiveDecimal("threshold", *thresholdFlag)
+			if err != nil {
+				return err
+			}
+		}
+
+		report, err := client.generateKMDINF(ctx, cfg.TenantID, year, month, threshold)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printKMDINFReport(a.stdout, report)
+		return nil
+
+	case "import-history":
+		fs := flag.NewFlagSet("tax kmd import-history", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		filePath := fs.String("file", "", "CSV file path")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*filePath) == "" {
+			return errors.New("file is required")
+		}
+
+		content, fileName, err := readCSVInput(*filePath)
+		if err != nil {
+			return err
+		}
+		result, err := client.importKMDHistory(ctx, cfg.TenantID, &tax.ImportKMDHistoryRequest{
+			FileName:   fileName,
+			CSVContent: content,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(
+			a.stdout,
+			"Processed %d rows, created %d KMD declarations, imported %d rows, skipped %d rows\n",
+			result.RowsProcessed,
+			result.DeclarationsCreated,
+			result.RowsImported,
+			result.RowsSkipped,
+		)
+		return nil
+
+	case "export-xml":
+		fs := flag.NewFlagSet("tax kmd export-xml", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		outputPath := fs.String("output", "", "Optional output file path")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		content, err := client.exportKMDXML(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "KMD XML")
+
+	case "mark-submitted":
+		fs := flag.NewFlagSet("tax kmd mark-submitted", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.markKMDSubmitted(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked KMD %04d-%02d as submitted\n", year, month)
+		return nil
+
+	case "mark-accepted":
+		fs := flag.NewFlagSet("tax kmd mark-accepted", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		yearFlag := fs.String("year", "", "Declaration year")
+		monthFlag := fs.String("month", "", "Declaration month")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[2:]); err != nil {
+			return err
+		}
+		year, month, err := parseYearMonthFlags(*yearFlag, *monthFlag)
+		if err != nil {
+			return err
+		}
+
+		result, err := client.markKMDAccepted(ctx, cfg.TenantID, year, month)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked KMD %04d-%02d as accepted\n", year, month)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown tax kmd subcommand %q", args[1])
+	}
+}
+
+func (a *cliApp) runTaxOSS(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("tax oss subcommand required")
+	}
+	if args[0] != "report" {
+		return fmt.Errorf("unknown tax oss subcommand %q", args[0])
+	}
+
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	fs := flag.NewFlagSet("tax oss report", flag.ContinueOnError)
+	fs.SetOutput(a.stderr)
+	yearFlag := fs.String("year", "", "Report year")
+	quarterFlag := fs.String("quarter", "", "Report quarter")
+	includeB2B := fs.Bool("include-b2b", false, "Include contacts with VAT numbers")
+	asJSON := fs.Bool("json", false, "Output JSON")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	year, err := strconv.Atoi(strings.TrimSpace(*yearFlag))
+	if err != nil || year < 2020 || year > 2100 {
+		return errors.New("year must be between 2020 and 2100")
+	}
+	quarter, err := strconv.Atoi(strings.TrimSpace(*quarterFlag))
+	if err != nil || quarter < 1 || quarter > 4 {
+		return errors.New("quarter must be between 1 and 4")
+	}
+
+	report, err := client.generateEUVATOSS(ctx, cfg.TenantID, year, quarter, *includeB2B)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(a.stdout, report)
+	}
+	printEUVATOSSReport(a.stdout, report)
+	return nil
+}
+
+func (a *cliApp) runReports(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("reports subcommand required")
+	}
+
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "trial-balance":
+		fs := flag.NewFlagSet("reports trial-balance", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if *asCSV {
+			content, err := client.exportTrialBalanceCSV(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "trial balance CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportTrialBalanceXLSX(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "trial balance XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportTrialBalancePDF(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "trial balance PDF")
+		}
+
+		report, err := client.getTrialBalance(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printTrialBalance(a.stdout, report)
+		return nil
+
+	case "account-balance":
+		fs := flag.NewFlagSet("reports account-balance", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		accountID := fs.String("account-id", "", "Account id")
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*accountID) == "" {
+			return errors.New("account-id is required")
+		}
+		if *asCSV {
+			content, err := client.exportAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf), "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "account balance CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf), "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "account balance XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf), "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "account balance PDF")
+		}
+
+		report, err := client.getAccountBalanceReport(ctx, cfg.TenantID, strings.TrimSpace(*accountID), strings.TrimSpace(*asOf))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printAccountBalance(a.stdout, report)
+		return nil
+
+	case "balance-sheet":
+		fs := flag.NewFlagSet("reports balance-sheet", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if *asCSV {
+			content, err := client.exportBalanceSheetCSV(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance sheet CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportBalanceSheetXLSX(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance sheet XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportBalanceSheetPDF(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance sheet PDF")
+		}
+
+		report, err := client.getBalanceSheet(ctx, cfg.TenantID, strings.TrimSpace(*asOf))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printBalanceSheet(a.stdout, report)
+		return nil
+
+	case "income-statement":
+		fs := flag.NewFlagSet("reports income-statement", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*startDate) == "" || strings.TrimSpace(*endDate) == "" {
+			return errors.New("start and end are required")
+		}
+		if *asCSV {
+			content, err := client.exportIncomeStatementCSV(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "income statement CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportIncomeStatementXLSX(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "income statement XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportIncomeStatementPDF(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "income statement PDF")
+		}
+
+		report, err := client.getIncomeStatement(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printIncomeStatement(a.stdout, report)
+		return nil
+
+	case "consolidated":
+		fs := flag.NewFlagSet("reports consolidated", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		startDate := fs.String("start", "", "Income statement start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "Income statement end date in YYYY-MM-DD")
+		tenantIDs := fs.String("tenant-ids", "", "Comma-separated tenant ids to consolidate")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		report, err := client.getConsolidatedReport(ctx, cfg.TenantID, strings.TrimSpace(*asOf), strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), strings.TrimSpace(*tenantIDs))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printConsolidatedFinancialReport(a.stdout, report)
+		return nil
+
+	case "annual":
+		fs := flag.NewFlagSet("reports annual", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		periodEnd := fs.String("period-end", "", "Fiscal year-end date in YYYY-MM-DD")
+		methodFlag := fs.String("cash-flow-method", reports.CashFlowMethodDirect, "Cash flow method: direct or indirect")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*periodEnd) == "" {
+			return errors.New("period-end is required")
+		}
+		method, err := reports.NormalizeCashFlowMethod(*methodFlag)
+		if err != nil {
+			return err
+		}
+		report, err := client.getAnnualReport(ctx, cfg.TenantID, strings.TrimSpace(*periodEnd), method)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printAnnualReport(a.stdout, report)
+		return nil
+
+	case "cash-flow":
+		fs := flag.NewFlagSet("reports cash-flow", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		methodFlag := fs.String("method", reports.CashFlowMethodDirect, "Cash flow method: direct or indirect")
+		operatingAccounts := fs.String("operating-accounts", "", "Comma-separated account codes to force into operating cash flow")
+		investingAccounts := fs.String("investing-accounts", "", "Comma-separated account codes to force into investing cash flow")
+		financingAccounts := fs.String("financing-accounts", "", "Comma-separated account codes to force into financing cash flow")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*startDate) == "" || strings.TrimSpace(*endDate) == "" {
+			return errors.New("start and end are required")
+		}
+		method, err := reports.NormalizeCashFlowMethod(*methodFlag)
+		if err != nil {
+			return err
+		}
+		mappingOverrides := reports.CashFlowMappingOverrides{
+			OperatingAccountCodes: splitCSVFlag(*operatingAccounts),
+			InvestingAccountCodes: splitCSVFlag(*investingAccounts),
+			FinancingAccountCodes: splitCSVFlag(*financingAccounts),
+		}
+		mappingOverrides, err = reports.NormalizeCashFlowMappingOverrides(mappingOverrides)
+		if err != nil {
+			return err
+		}
+		if *asCSV {
+			content, err := client.exportCashFlowStatementCSV(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cash flow CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportCashFlowStatementXLSX(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cash flow XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportCashFlowStatementPDF(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "cash flow PDF")
+		}
+
+		report, err := client.getCashFlowStatement(ctx, cfg.TenantID, strings.TrimSpace(*startDate), strings.TrimSpace(*endDate), method, mappingOverrides)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printCashFlowStatement(a.stdout, report)
+		return nil
+
+	case "cash-flow-mapping":
+		return a.runCashFlowMapping(ctx, cfg, client, args[1:])
+
+	case "aging":
+		fs := flag.NewFlagSet("reports aging", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		reportType := fs.String("type", "receivables", "Aging report type: receivables or payables")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		normalizedType := strings.ToLower(strings.TrimSpace(*reportType))
+		if normalizedType != "receivables" && normalizedType != "payables" {
+			return errors.New("type must be receivables or payables")
+		}
+
+		if *asCSV {
+			content, err := client.exportAgingReport(ctx, cfg.TenantID, normalizedType, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, normalizedType+" aging CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportAgingReport(ctx, cfg.TenantID, normalizedType, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, normalizedType+" aging XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportAgingReport(ctx, cfg.TenantID, normalizedType, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, normalizedType+" aging PDF")
+		}
+
+		report, err := client.getAgingReport(ctx, cfg.TenantID, normalizedType)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printAgingReport(a.stdout, report)
+		return nil
+
+	case "balance-confirmations":
+		fs := flag.NewFlagSet("reports balance-confirmations", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		balanceType := fs.String("type", "", "Balance type: RECEIVABLE or PAYABLE")
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		normalizedType := strings.ToUpper(strings.TrimSpace(*balanceType))
+		if normalizedType != "RECEIVABLE" && normalizedType != "PAYABLE" {
+			return errors.New("type must be RECEIVABLE or PAYABLE")
+		}
+		if strings.TrimSpace(*asOf) == "" {
+			return errors.New("as-of is required")
+		}
+
+		if *asCSV {
+			content, err := client.exportBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf), "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmations CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf), "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmations XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf), "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmations PDF")
+		}
+
+		report, err := client.getBalanceConfirmationSummary(ctx, cfg.TenantID, normalizedType, strings.TrimSpace(*asOf))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printBalanceConfirmationSummary(a.stdout, report)
+		return nil
+
+	case "balance-confirmation":
+		fs := flag.NewFlagSet("reports balance-confirmation", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		contactID := fs.String("contact-id", "", "Contact id")
+		balanceType := fs.String("type", "", "Balance type: RECEIVABLE or PAYABLE")
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*contactID) == "" {
+			return errors.New("contact-id is required")
+		}
+		normalizedType := strings.ToUpper(strings.TrimSpace(*balanceType))
+		if normalizedType != "RECEIVABLE" && normalizedType != "PAYABLE" {
+			return errors.New("type must be RECEIVABLE or PAYABLE")
+		}
+		if strings.TrimSpace(*asOf) == "" {
+			return errors.New("as-of is required")
+		}
+
+		if *asCSV {
+			content, err := client.exportBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf), "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmation CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf), "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmation XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf), "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "balance confirmation PDF")
+		}
+
+		report, err := client.getBalanceConfirmation(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, strings.TrimSpace(*asOf))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printBalanceConfirmation(a.stdout, report)
+		return nil
+
+	case "contact-statement":
+		fs := flag.NewFlagSet("reports contact-statement", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		contactID := fs.String("contact-id", "", "Contact id")
+		balanceType := fs.String("type", "", "Statement type: RECEIVABLE or PAYABLE")
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*contactID) == "" {
+			return errors.New("contact-id is required")
+		}
+		normalizedType := strings.ToUpper(strings.TrimSpace(*balanceType))
+		if normalizedType != "RECEIVABLE" && normalizedType != "PAYABLE" {
+			return errors.New("type must be RECEIVABLE or PAYABLE")
+		}
+		startDateValue, err := parseRequiredDate("start", *startDate)
+		if err != nil {
+			return err
+		}
+		endDateValue, err := parseRequiredDate("end", *endDate)
+		if err != nil {
+			return err
+		}
+		if endDateValue.Before(startDateValue) {
+			return errors.New("end must be on or after start")
+		}
+		start := startDateValue.Format("2006-01-02")
+		end := endDateValue.Format("2006-01-02")
+
+		if *asCSV {
+			content, err := client.exportContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "contact statement CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "contact statement XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "contact statement PDF")
+		}
+
+		report, err := client.getContactStatement(ctx, cfg.TenantID, strings.TrimSpace(*contactID), normalizedType, start, end)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printContactStatement(a.stdout, report)
+		return nil
+
+	case "sales-margin":
+		fs := flag.NewFlagSet("reports sales-margin", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		startDateValue, err := parseRequiredDate("start", *startDate)
+		if err != nil {
+			return err
+		}
+		endDateValue, err := parseRequiredDate("end", *endDate)
+		if err != nil {
+			return err
+		}
+		if endDateValue.Before(startDateValue) {
+			return errors.New("end must be on or after start")
+		}
+		start := startDateValue.Format("2006-01-02")
+		end := endDateValue.Format("2006-01-02")
+
+		if *asCSV {
+			content, err := client.exportSalesMarginReport(ctx, cfg.TenantID, start, end, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "sales margin CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportSalesMarginReport(ctx, cfg.TenantID, start, end, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "sales margin XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportSalesMarginReport(ctx, cfg.TenantID, start, end, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "sales margin PDF")
+		}
+
+		report, err := client.getSalesMarginReport(ctx, cfg.TenantID, start, end)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printSalesMarginReport(a.stdout, report)
+		return nil
+
+	case "customer-profitability":
+		fs := flag.NewFlagSet("reports customer-profitability", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		startDateValue, err := parseRequiredDate("start", *startDate)
+		if err != nil {
+			return err
+		}
+		endDateValue, err := parseRequiredDate("end", *endDate)
+		if err != nil {
+			return err
+		}
+		if endDateValue.Before(startDateValue) {
+			return errors.New("end must be on or after start")
+		}
+		start := startDateValue.Format("2006-01-02")
+		end := endDateValue.Format("2006-01-02")
+
+		if *asCSV {
+			content, err := client.exportCustomerProfitabilityReport(ctx, cfg.TenantID, start, end, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "customer profitability CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportCustomerProfitabilityReport(ctx, cfg.TenantID, start, end, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "customer profitability XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportCustomerProfitabilityReport(ctx, cfg.TenantID, start, end, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "customer profitability PDF")
+		}
+
+		report, err := client.getCustomerProfitabilityReport(ctx, cfg.TenantID, start, end)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printCustomerProfitabilityReport(a.stdout, report)
+		return nil
+
+	case "budget-vs-actual":
+		fs := flag.NewFlagSet("reports budget-vs-actual", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		startDateValue, err := parseOptionalDate("start", *startDate)
+		if err != nil {
+			return err
+		}
+		endDateValue, err := parseOptionalDate("end", *endDate)
+		if err != nil {
+			return err
+		}
+		if startDateValue != nil && endDateValue != nil && endDateValue.Before(*startDateValue) {
+			return errors.New("end must be on or after start")
+		}
+
+		if *asCSV {
+			content, err := client.exportBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "budget vs actual CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "budget vs actual XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "budget vs actual PDF")
+		}
+
+		report, err := client.getBudgetVsActualReport(ctx, cfg.TenantID, startDateValue, endDateValue)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printBudgetVsActualReport(a.stdout, report)
+		return nil
+
+	case "fixed-asset-register":
+		fs := flag.NewFlagSet("reports fixed-asset-register", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		startDate := fs.String("start", "", "Start date in YYYY-MM-DD")
+		endDate := fs.String("end", "", "End date in YYYY-MM-DD")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		asCSV := fs.Bool("csv", false, "Output CSV")
+		asXLSX := fs.Bool("xlsx", false, "Output XLSX")
+		asPDF := fs.Bool("pdf", false, "Output PDF")
+		outputPath := fs.String("output", "", "Optional CSV/XLSX/PDF output file path")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if err := validateReportOutputFlags(*asJSON, *asCSV, *asXLSX, *asPDF, *outputPath); err != nil {
+			return err
+		}
+		startDateValue, err := parseRequiredDate("start", *startDate)
+		if err != nil {
+			return err
+		}
+		endDateValue, err := parseRequiredDate("end", *endDate)
+		if err != nil {
+			return err
+		}
+		if endDateValue.Before(startDateValue) {
+			return errors.New("end must be on or after start")
+		}
+		start := startDateValue.Format("2006-01-02")
+		end := endDateValue.Format("2006-01-02")
+
+		if *asCSV {
+			content, err := client.exportFixedAssetRegister(ctx, cfg.TenantID, start, end, "csv")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "fixed asset register CSV")
+		}
+		if *asXLSX {
+			content, err := client.exportFixedAssetRegister(ctx, cfg.TenantID, start, end, "xlsx")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "fixed asset register XLSX")
+		}
+		if *asPDF {
+			content, err := client.exportFixedAssetRegister(ctx, cfg.TenantID, start, end, "pdf")
+			if err != nil {
+				return err
+			}
+			return writeExportOutput(a.stdout, strings.TrimSpace(*outputPath), content, "fixed asset register PDF")
+		}
+
+		report, err := client.getFixedAssetRegister(ctx, cfg.TenantID, start, end)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, report)
+		}
+		printFixedAssetRegister(a.stdout, report)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown reports subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) runCashFlowMapping(ctx context.Context, cfg *cliConfig, client *apiClient, args []string) error {
+	if len(args) == 0 {
+		return errors.New("reports cash-flow-mapping subcommand required")
+	}
+
+	switch args[0] {
+	case "get":
+		fs := flag.NewFlagSet("reports cash-flow-mapping get", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		mapping, err := client.getCashFlowMapping(ctx, cfg.TenantID)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, mapping)
+		}
+		printCashFlowMapping(a.stdout, mapping)
+		return nil
+	case "update":
+		fs := flag.NewFlagSet("reports cash-flow-mapping update", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		operatingAccounts := fs.String("operating-accounts", "", "Comma-separated account codes to force into operating cash flow")
+		investingAccounts := fs.String("investing-accounts", "", "Comma-separated account codes to force into investing cash flow")
+		financingAccounts := fs.String("financing-accounts", "", "Comma-separated account codes to force into financing cash flow")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+
+		mapping, err := reports.NormalizeCashFlowMappingOverrides(reports.CashFlowMappingOverrides{
+			OperatingAccountCodes: splitCSVFlag(*operatingAccounts),
+			InvestingAccountCodes: splitCSVFlag(*investingAccounts),
+			FinancingAccountCodes: splitCSVFlag(*financingAccounts),
+		})
+		if err != nil {
+			return err
+		}
+		updated, err := client.updateCashFlowMapping(ctx, cfg.TenantID, mapping)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, updated)
+		}
+		printCashFlowMapping(a.stdout, updated)
+		return nil
+	default:
+		return fmt.Errorf("unknown reports cash-flow-mapping subcommand %q", args[0])
+	}
+}
+
+func validateReportOutputFlags(asJSON, asCSV, asXLSX, asPDF bool, outputPath string) error {
+	selected := 0
+	for _, value := range []bool{asJSON, asCSV, asXLSX, asPDF} {
+		if value {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return errors.New("json, csv, xlsx, and pdf cannot be combined")
+	}
+	if strings.TrimSpace(outputPath) != "" && !asCSV && !asXLSX && !asPDF {
+		return errors.New("output requires csv, xlsx, or pdf")
+	}
+	return nil
+}
+
+func (a *cliApp) runDocuments(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New("documents subcommand required")
+	}
+	cfg, client, err := a.loadAuthenticatedClient()
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		fs := flag.NewFlagSet("documents list", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
+		entityID := fs.String("entity-id", "", "Entity id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entityType) == "" || strings.TrimSpace(*entityID) == "" {
+			return errors.New("entity-type and entity-id are required")
+		}
+
+		docs, err := client.listDocuments(ctx, cfg.TenantID, strings.TrimSpace(*entityType), strings.TrimSpace(*entityID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, docs)
+		}
+		printDocumentsTable(a.stdout, docs)
+		return nil
+
+	case "review-summary":
+		fs := flag.NewFlagSet("documents review-summary", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
+		entityIDs := stringListFlags{}
+		fs.Var(&entityIDs, "entity-id", "Entity id; repeatable")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entityType) == "" {
+			return errors.New("entity-type is required")
+		}
+		if len(entityIDs) == 0 {
+			return errors.New("at least one entity-id is required")
+		}
+
+		summaries, err := client.listDocumentReviewSummaries(ctx, cfg.TenantID, &documentReviewSummaryRequest{
+			EntityType: strings.TrimSpace(*entityType),
+			EntityIDs:  []string(entityIDs),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, summaries)
+		}
+		printDocumentReviewSummariesTable(a.stdout, summaries)
+		return nil
+
+	case "review-queue":
+		fs := flag.NewFlagSet("documents review-queue", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entityType := fs.String("entity-type", "", "Optional entity type filter")
+		documentType := fs.String("document-type", "", "Optional document type filter")
+		reviewStatus := fs.String("status", documents.ReviewStatusPending, "Review status filter: PENDING, REVIEWED, APPROVED, REJECTED, or all")
+		limit := fs.Int("limit", 50, "Maximum documents to return")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *limit < 0 {
+			return errors.New("limit must be zero or greater")
+		}
+
+		queue, err := client.getDocumentReviewQueue(ctx, cfg.TenantID, &documents.ReviewQueueFilter{
+			EntityType:   strings.TrimSpace(*entityType),
+			DocumentType: strings.TrimSpace(*documentType),
+			ReviewStatus: strings.TrimSpace(*reviewStatus),
+			Limit:        *limit,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, queue)
+		}
+		printDocumentReviewQueue(a.stdout, queue)
+		return nil
+
+	case "evidence-policy":
+		fs := flag.NewFlagSet("documents evidence-policy", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
+		entityIDs := stringListFlags{}
+		documentTypes := stringListFlags{}
+		fs.Var(&entityIDs, "entity-id", "Entity id; repeatable")
+		fs.Var(&documentTypes, "document-type", "Document type that satisfies the policy; repeatable")
+		fs.Var(&documentTypes, "required-document-type", "Document type that satisfies the policy; repeatable")
+		minCount := fs.Int("min-count", 1, "Minimum number of matching documents")
+		requireApproved := fs.Bool("require-approved", false, "Only count approved matching documents")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entityType) == "" {
+			return errors.New("entity-type is required")
+		}
+		if len(entityIDs) == 0 {
+			return errors.New("at least one entity-id is required")
+		}
+		if *minCount <= 0 {
+			return errors.New("min-count must be one or greater")
+		}
+
+		results, err := client.evaluateDocumentEvidencePolicy(ctx, cfg.TenantID, &documents.EvidencePolicyRequest{
+			EntityType: strings.TrimSpace(*entityType),
+			EntityIDs:  []string(entityIDs),
+			Rules: []documents.EvidencePolicyRule{{
+				DocumentTypes:   []string(documentTypes),
+				MinCount:        *minCount,
+				RequireApproved: *requireApproved,
+			}},
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, results)
+		}
+		printDocumentEvidencePolicy(a.stdout, results)
+		return nil
+
+	case "retention":
+		fs := flag.NewFlagSet("documents retention", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD; defaults to today")
+		horizonDays := fs.Int("horizon-days", 30, "Include documents due within this many days")
+		includeMissing := fs.Bool("include-missing", false, "Include documents without retention_until")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *horizonDays < 0 {
+			return errors.New("horizon-days must be zero or greater")
+		}
+
+		review, err := client.getDocumentRetentionReview(ctx, cfg.TenantID, strings.TrimSpace(*asOf), *horizonDays, *includeMissing)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, review)
+		}
+		printDocumentRetentionReview(a.stdout, review)
+		return nil
+
+	case "purge":
+		fs := flag.NewFlagSet("documents purge", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		asOf := fs.String("as-of", "", "As-of date in YYYY-MM-DD; defaults to today")
+		limit := fs.Int("limit", 100, "Maximum eligible documents to purge or report")
+		execute := fs.Bool("execute", false, "Execute the purge; default is dry-run")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *limit < 0 {
+			return errors.New("limit must be zero or greater")
+		}
+		if strings.TrimSpace(*asOf) != "" {
+			if _, err := time.Parse("2006-01-02", strings.TrimSpace(*asOf)); err != nil {
+				return fmt.Errorf("parse as-of: %w", err)
+			}
+		}
+
+		result, err := client.purgeExpiredDocuments(ctx, cfg.TenantID, documentPurgeRequest{
+			AsOf:   strings.TrimSpace(*asOf),
+			DryRun: !*execute,
+			Limit:  *limit,
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, result)
+		}
+		printDocumentPurgeResult(a.stdout, result)
+		return nil
+
+	case "retention-set":
+		fs := flag.NewFlagSet("documents retention-set", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		retentionUntil := fs.String("retention-until", "", "Retention date in YYYY-MM-DD")
+		clearRetention := fs.Bool("clear", false, "Clear retention metadata")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" {
+			return errors.New("id is required")
+		}
+		trimmedRetention := strings.TrimSpace(*retentionUntil)
+		if *clearRetention && trimmedRetention != "" {
+			return errors.New("retention-until cannot be combined with clear")
+		}
+		if !*clearRetention && trimmedRetention == "" {
+			return errors.New("retention-until is required unless clear is set")
+		}
+		if trimmedRetention != "" {
+			if _, err := time.Parse("2006-01-02", trimmedRetention); err != nil {
+				return fmt.Errorf("parse retention-until: %w", err)
+			}
+		}
+
+		doc, err := client.updateDocumentRetention(ctx, cfg.TenantID, strings.TrimSpace(*documentID), trimmedRetention, *clearRetention)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, doc)
+		}
+		if *clearRetention {
+			_, _ = fmt.Fprintf(a.stdout, "Cleared retention for document %s\n", doc.ID)
+			return nil
+		}
+		retentionLabel := trimmedRetention
+		if doc.RetentionUntil != nil {
+			retentionLabel = doc.RetentionUntil.Format("2006-01-02")
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Set retention for document %s to %s\n", doc.ID, retentionLabel)
+		return nil
+
+	case "lifecycle-set":
+		fs := flag.NewFlagSet("documents lifecycle-set", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		lifecycleStatus := fs.String("status", "", "Lifecycle status: ACTIVE, SUPERSEDED, ARCHIVED, or DISPOSED")
+		lifecycleNote := fs.String("note", "", "Lifecycle audit note; required for archived or disposed documents")
+		supersededBy := fs.String("superseded-by-document-id", "", "Replacement document id when marking the document superseded")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" || strings.TrimSpace(*lifecycleStatus) == "" {
+			return errors.New("id and status are required")
+		}
+
+		doc, err := client.updateDocumentLifecycle(ctx, cfg.TenantID, strings.TrimSpace(*documentID), &documents.DocumentLifecycleRequest{
+			LifecycleStatus:      strings.ToUpper(strings.TrimSpace(*lifecycleStatus)),
+			LifecycleNote:        strings.TrimSpace(*lifecycleNote),
+			SupersededByDocument: strings.TrimSpace(*supersededBy),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, doc)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked document %s lifecycle as %s\n", doc.ID, doc.LifecycleStatus)
+		return nil
+
+	case "legal-hold-set":
+		fs := flag.NewFlagSet("documents legal-hold-set", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		enabled := fs.Bool("enabled", true, "Whether legal hold is active")
+		note := fs.String("note", "", "Legal hold audit note")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" {
+			return errors.New("id is required")
+		}
+		if strings.TrimSpace(*note) == "" {
+			return errors.New("note is required")
+		}
+
+		doc, err := client.updateDocumentLegalHold(ctx, cfg.TenantID, strings.TrimSpace(*documentID), &documents.DocumentLegalHoldRequest{
+			LegalHold: *enabled,
+			Note:      strings.TrimSpace(*note),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, doc)
+		}
+		state := "released"
+		if doc.LegalHold {
+			state = "placed"
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Legal hold %s for document %s\n", state, doc.ID)
+		return nil
+
+	case "upload":
+		fs := flag.NewFlagSet("documents upload", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		entityType := fs.String("entity-type", "", documentEntityTypeHelp)
+		entityID := fs.String("entity-id", "", "Entity id")
+		filePath := fs.String("file", "", "File path ('-' for stdin)")
+		documentType := fs.String("document-type", documents.DocumentTypeSupportingDocument, "Document type")
+		notes := fs.String("notes", "", "Optional notes")
+		retentionUntil := fs.String("retention-until", "", "Optional retention date in YYYY-MM-DD")
+		retentionYears := fs.Int("retention-years", 0, "Set retention date this many years after upload (1-100)")
+		replacesDocumentID := fs.String("replaces-document-id", "", "Existing document id this upload replaces")
+		replacementNote := fs.String("replacement-note", "", "Audit note for the replacement link")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*entityType) == "" || strings.TrimSpace(*entityID) == "" || strings.TrimSpace(*filePath) == "" {
+			return errors.New("entity-type, entity-id, and file are required")
+		}
+		trimmedRetention := strings.TrimSpace(*retentionUntil)
+		if trimmedRetention != "" && *retentionYears > 0 {
+			return errors.New("retention-until and retention-years cannot be combined")
+		}
+		if *retentionYears < 0 {
+			return errors.New("retention-years must be zero or greater")
+		}
+		if *retentionYears > documents.MaxRetentionYears {
+			return fmt.Errorf("retention-years cannot exceed %d", documents.MaxRetentionYears)
+		}
+		var retentionDate *time.Time
+		if trimmedRetention != "" {
+			parsed, err := time.Parse("2006-01-02", trimmedRetention)
+			if err != nil {
+				return fmt.Errorf("parse retention-until: %w", err)
+			}
+			normalized := parsed.UTC()
+			retentionDate = &normalized
+		}
+
+		content, fileName, err := readFileInput(*filePath, "stdin.bin")
+		if err != nil {
+			return err
+		}
+
+		doc, err := client.uploadDocument(ctx, cfg.TenantID, &documents.UploadDocumentRequest{
+			EntityType:         strings.TrimSpace(*entityType),
+			EntityID:           strings.TrimSpace(*entityID),
+			DocumentType:       strings.TrimSpace(*documentType),
+			FileName:           fileName,
+			Notes:              strings.TrimSpace(*notes),
+			RetentionUntil:     retentionDate,
+			RetentionYears:     *retentionYears,
+			ReplacesDocumentID: strings.TrimSpace(*replacesDocumentID),
+			ReplacementNote:    strings.TrimSpace(*replacementNote),
+		}, content)
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, doc)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Uploaded %s (%s) to %s %s\n", doc.FileName, doc.ID, doc.EntityType, doc.EntityID)
+		return nil
+
+	case "download":
+		fs := flag.NewFlagSet("documents download", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		outputPath := fs.String("output", "", "Output path; defaults to downloaded file name, '-' for stdout")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" {
+			return errors.New("id is required")
+		}
+
+		download, err := client.downloadDocument(ctx, cfg.TenantID, strings.TrimSpace(*documentID))
+		if err != nil {
+			return err
+		}
+		targetPath := strings.TrimSpace(*outputPath)
+		if targetPath == "" {
+			targetPath = download.FileName
+		}
+		if targetPath == "-" {
+			_, err := a.stdout.Write(download.Content)
+			return err
+		}
+		if err := os.WriteFile(targetPath, download.Content, 0o600); err != nil {
+			return fmt.Errorf("write document: %w", err)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Downloaded %s to %s (%d bytes)\n", strings.TrimSpace(*documentID), targetPath, len(download.Content))
+		return nil
+
+	case "mark-reviewed":
+		fs := flag.NewFlagSet("documents mark-reviewed", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" {
+			return errors.New("id is required")
+		}
+
+		doc, err := client.markDocumentReviewed(ctx, cfg.TenantID, strings.TrimSpace(*documentID))
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, doc)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Marked document %s as reviewed\n", doc.ID)
+		return nil
+
+	case "review":
+		fs := flag.NewFlagSet("documents review", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		reviewStatus := fs.String("status", "", "Review status: REVIEWED, APPROVED, or REJECTED")
+		reviewNote := fs.String("note", "", "Optional review note; required for rejected documents")
+		asJSON := fs.Bool("json", false, "Output JSON")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" || strings.TrimSpace(*reviewStatus) == "" {
+			return errors.New("id and status are required")
+		}
+
+		doc, err := client.reviewDocument(ctx, cfg.TenantID, strings.TrimSpace(*documentID), &documents.ReviewDocumentRequest{
+			ReviewStatus: strings.ToUpper(strings.TrimSpace(*reviewStatus)),
+			ReviewNote:   strings.TrimSpace(*reviewNote),
+		})
+		if err != nil {
+			return err
+		}
+		if *asJSON {
+			return printJSON(a.stdout, doc)
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Reviewed document %s as %s\n", doc.ID, doc.ReviewStatus)
+		return nil
+
+	case "delete":
+		fs := flag.NewFlagSet("documents delete", flag.ContinueOnError)
+		fs.SetOutput(a.stderr)
+		documentID := fs.String("id", "", "Document id")
+		if err := fs.Parse(args[1:]); err != nil {
+			return err
+		}
+		if strings.TrimSpace(*documentID) == "" {
+			return errors.New("id is required")
+		}
+
+		if err := client.deleteDocument(ctx, cfg.TenantID, strings.TrimSpace(*documentID)); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(a.stdout, "Deleted document %s\n", strings.TrimSpace(*documentID))
+		return nil
+
+	default:
+		return fmt.Errorf("unknown documents subcommand %q", args[0])
+	}
+}
+
+func (a *cliApp) loadAuthenticatedClient() (*cliConfig, *apiClient, error) {
+	cfg, client, err := a.loadTokenClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	if strings.TrimSpace(cfg.TenantID) == "" {
 		return nil, nil, errors.New("no tenant configured, run `oa auth init` first")
 	}
-	return cfg, client, nil
+	return cfg, client, nil
+}
+
+func (a *cliApp) loadTokenClient() (*cliConfig, *apiClient, error) {
+	cfg, err := loadRuntimeConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	if strings.TrimSpace(cfg.APIToken) == "" {
+		return nil, nil, errors.New("no API token configured, run `oa auth init` first")
+	}
+	return cfg, newAPIClient(cfg.BaseURL, cfg.APIToken), nil
+}
+
+func (a *cliApp) loadPublicClient(baseURL string) (*apiClient, error) {
+	if strings.TrimSpace(baseURL) != "" {
+		return newAPIClient(baseURL, ""), nil
+	}
+	cfg, err := loadRuntimeConfig()
+	if err != nil {
+		return nil, err
+	}
+	return newAPIClient(cfg.BaseURL, ""), nil
+}
+
+func resolvePassword(password string, passwordStdin bool) (string, error) {
+	if strings.TrimSpace(password) != "" {
+		return password, nil
+	}
+	if !passwordStdin {
+		return "", errors.New("password is required")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	value, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read password from stdin: %w", err)
+	}
+	value = strings.TrimRight(value, "\r\n")
+	if value == "" {
+		return "", errors.New("password from stdin is empty")
+	}
+	return value, nil
+}
+
+func resolvePasswordPair(currentPassword, newPassword string, passwordsStdin bool) (string, string, error) {
+	if strings.TrimSpace(currentPassword) != "" && strings.TrimSpace(newPassword) != "" {
+		return currentPassword, newPassword, nil
+	}
+	if !passwordsStdin {
+		return "", "", errors.New("current-password and new-password are required")
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	currentValue, err := readPasswordLine(reader, "current password")
+	if err != nil {
+		return "", "", err
+	}
+	newValue, err := readPasswordLine(reader, "new password")
+	if err != nil {
+		return "", "", err
+	}
+	return currentValue, newValue, nil
+}
+
+func readPasswordLine(reader *bufio.Reader, label string) (string, error) {
+	value, err := reader.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("read %s from stdin: %w", label, err)
+	}
+	value = strings.TrimRight(value, "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("%s from stdin is empty", label)
+	}
+	return value, nil
+}
+
+func parseTenantSettingsInput(settingsJSON, settingsFile string) (*tenant.TenantSettings, error) {
+	settingsJSON = strings.TrimSpace(settingsJSON)
+	settingsFile = strings.TrimSpace(settingsFile)
+	if settingsJSON == "" && settingsFile == "" {
+		return nil, nil
+	}
+	if settingsJSON != "" && settingsFile != "" {
+		return nil, errors.New("use either settings-json or settings-file, not both")
+	}
+
+	payload := []byte(settingsJSON)
+	if settingsFile != "" {
+		content, err := os.ReadFile(settingsFile)
+		if err != nil {
+			return nil, fmt.Errorf("read settings file: %w", err)
+		}
+		payload = content
+	}
+
+	var settings tenant.TenantSettings
+	if err := json.Unmarshal(payload, &settings); err != nil {
+		return nil, fmt.Errorf("parse tenant settings JSON: %w", err)
+	}
+	return &settings, nil
+}
+
+func parseRawJSONInput(inlineJSON, filePath, defaultJSON string) (json.RawMessage, error) {
+	inlineJSON = strings.TrimSpace(inlineJSON)
+	filePath = strings.TrimSpace(filePath)
+	if inlineJSON == "" && filePath == "" {
+		if strings.TrimSpace(defaultJSON) == "" {
+			return nil, nil
+		}
+		return json.RawMessage(defaultJSON), nil
+	}
+	if inlineJSON != "" && filePath != "" {
+		return nil, errors.New("use either settings-json or settings-file, not both")
+	}
+
+	payload := []byte(inlineJSON)
+	if filePath != "" {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("read settings file: %w", err)
+		}
+		payload = content
+	}
+
+	var value any
+	if err := json.Unmarshal(payload, &value); err != nil {
+		return nil, fmt.Errorf("parse JSON: %w", err)
+	}
+	return json.RawMessage(payload), nil
+}
+
+func parseYearMonthFlags(yearValue, monthValue string) (int, int, error) {
+	year, err := parseRequiredPositiveInt("year", yearValue)
+	if err != nil {
+		return 0, 0, err
+	}
+	month, err := parseRequiredPositiveInt("month", monthValue)
+	if err != nil {
+		return 0, 0, err
+	}
+	if month < 1 || month > 12 {
+		return 0, 0, errors.New("month must be between 1 and 12")
+	}
+	return year, month, nil
+}
+
+func parseOptionalDate(name, value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	return &parsed, nil
+}
+
+func parseRequiredDate(name, value string) (time.Time, error) {
+	parsed, err := parseOptionalDate(name, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if parsed == nil {
+		return time.Time{}, fmt.Errorf("%s is required", name)
+	}
+	return *parsed, nil
+}
+
+func parseRequiredPositiveInt(name, value string) (int, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, fmt.Errorf("%s is required", name)
+	}
+	parsed, err := strconv.Atoi(trimmed)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if parsed <= 0 {
+		return 0, fmt.Errorf("%s must be positive", name)
+	}
+	return parsed, nil
+}
+
+func parseOptionalPositiveInt(name, value string) (int, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, nil
+	}
+	return parseRequiredPositiveInt(name, trimmed)
+}
+
+func parseOptionalBoundedInt(name, value string, min, max int) (int, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, nil
+	}
+	return parseRequiredBoundedInt(name, trimmed, min, max)
+}
+
+func parseRequiredBoundedInt(name, value string, min, max int) (int, error) {
+	parsed, err := parseRequiredPositiveInt(name, value)
+	if err != nil {
+		return 0, err
+	}
+	if parsed < min || parsed > max {
+		return 0, fmt.Errorf("%s must be between %d and %d", name, min, max)
+	}
+	return parsed, nil
+}
+
+func parseRequiredNonNegativeInt(name, value string) (int, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, fmt.Errorf("%s is required", name)
+	}
+	parsed, err := strconv.Atoi(trimmed)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if parsed < 0 {
+		return 0, fmt.Errorf("%s must be non-negative", name)
+	}
+	return parsed, nil
+}
+
+func parseOptionalBoolPtr(name, value string) (*bool, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", name, err)
+	}
+	return &parsed, nil
+}
+
+func parseRequiredPositiveDecimal(name, value string) (decimal.Decimal, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return decimal.Zero, fmt.Errorf("%s is required", name)
+	}
+	parsed, err := decimal.NewFromString(trimmed)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if parsed.LessThanOrEqual(decimal.Zero) {
+		return decimal.Zero, fmt.Errorf("%s must be positive", name)
+	}
+	return parsed, nil
+}
+
+func parseRequiredDecimal(name, value string) (decimal.Decimal, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return decimal.Zero, fmt.Errorf("%s is required", name)
+	}
+	parsed, err := decimal.NewFromString(trimmed)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("parse %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+func parseRequiredNonNegativeDecimal(name, value string) (decimal.Decimal, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return decimal.Zero, fmt.Errorf("%s is required", name)
+	}
+	parsed, err := decimal.NewFromString(trimmed)
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("parse %s: %w", name, err)
+	}
+	if parsed.LessThan(decimal.Zero) {
+		return decimal.Zero, fmt.Errorf("%s must be non-negative", name)
+	}
+	return parsed, nil
+}
+
+func parseOptionalNonNegativeDecimalPtr(name, value string) (*decimal.Decimal, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	parsed, err := parseRequiredNonNegativeDecimal(name, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
 
-func (a *cliApp) loadTokenClient() (*cliConfig, *apiClient, error) {
-	cfg, err := loadRuntimeConfig()
-	if err != nil {
-		return nil, nil, err
+func decimalValueOrZero(value *decimal.Decimal) decimal.Decimal {
+	if value == nil {
+		return decimal.Zero
 	}
-	if strings.TrimSpace(cfg.APIToken) == "" {
-		return nil, nil, errors.New("no API token configured, run `oa auth init` first")
+	return *value
+}
+
+func parseOptionalPurchaseOrderStatus(value string) (purchasing.PurchaseOrderStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch purchasing.PurchaseOrderStatus(normalized) {
+	case purchasing.PurchaseOrderStatusDraft, purchasing.PurchaseOrderStatusApproved, purchasing.PurchaseOrderStatusPartiallyReceived,
+		purchasing.PurchaseOrderStatusReceived, purchasing.PurchaseOrderStatusClosed, purchasing.PurchaseOrderStatusCanceled:
+		return purchasing.PurchaseOrderStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid purchase order status %q", value)
 	}
-	return cfg, newAPIClient(cfg.BaseURL, cfg.APIToken), nil
 }
 
-func (a *cliApp) loadPublicClient(baseURL string) (*apiClient, error) {
-	if strings.TrimSpace(baseURL) != "" {
-		return newAPIClient(baseURL, ""), nil
+func parseOptionalStockCountStatus(value string) (stocktake.StockCountStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
 	}
-	cfg, err := loadRuntimeConfig()
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch stocktake.StockCountStatus(normalized) {
+	case stocktake.StockCountStatusOpen, stocktake.StockCountStatusSubmitted, stocktake.StockCountStatusApproved, stocktake.StockCountStatusCanceled:
+		return stocktake.StockCountStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid stock count status %q", value)
+	}
+}
+
+func parseOptionalBOMType(value string) (assembly.BOMType, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assembly.BOMType(normalized) {
+	case assembly.BOMTypeAssembly, assembly.BOMTypeKit:
+		return assembly.BOMType(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid bill of materials type %q", value)
+	}
+}
+
+func parseOptionalAssemblyOrderType(value string) (assembly.AssemblyOrderType, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assembly.AssemblyOrderType(normalized) {
+	case assembly.AssemblyOrderTypeAssembly, assembly.AssemblyOrderTypeDisassembly:
+		return assembly.AssemblyOrderType(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid assembly order type %q", value)
+	}
+}
+
+func parseOptionalAssemblyOrderStatus(value string) (assembly.AssemblyOrderStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assembly.AssemblyOrderStatus(normalized) {
+	case assembly.AssemblyOrderStatusDraft, assembly.AssemblyOrderStatusCompleted, assembly.AssemblyOrderStatusCanceled:
+		return assembly.AssemblyOrderStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid assembly order status %q", value)
+	}
+}
+
+func parseOptionalInvoiceType(value string) (invoicing.InvoiceType, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	return parseRequiredInvoiceType(value)
+}
+
+func parseRequiredInvoiceType(value string) (invoicing.InvoiceType, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch invoicing.InvoiceType(normalized) {
+	case invoicing.InvoiceTypeSales, invoicing.InvoiceTypePurchase, invoicing.InvoiceTypeCreditNote:
+		return invoicing.InvoiceType(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("type is required")
+		}
+		return "", fmt.Errorf("invalid invoice type %q", value)
+	}
+}
+
+func parseOptionalInvoiceStatus(value string) (invoicing.InvoiceStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch invoicing.InvoiceStatus(normalized) {
+	case invoicing.StatusDraft, invoicing.StatusSent, invoicing.StatusPartiallyPaid, invoicing.StatusPaid, invoicing.StatusOverdue, invoicing.StatusVoided:
+		return invoicing.InvoiceStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid invoice status %q", value)
+	}
+}
+
+func parseOptionalQuoteStatus(value string) (quotes.QuoteStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch quotes.QuoteStatus(normalized) {
+	case quotes.QuoteStatusDraft, quotes.QuoteStatusSent, quotes.QuoteStatusAccepted, quotes.QuoteStatusRejected, quotes.QuoteStatusExpired, quotes.QuoteStatusConverted:
+		return quotes.QuoteStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid quote status %q", value)
+	}
+}
+
+func parseOptionalOrderStatus(value string) (orders.OrderStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch orders.OrderStatus(normalized) {
+	case orders.OrderStatusPending, orders.OrderStatusConfirmed, orders.OrderStatusProcessing, orders.OrderStatusShipped, orders.OrderStatusDelivered, orders.OrderStatusCanceled:
+		return orders.OrderStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid order status %q", value)
+	}
+}
+
+func parseOptionalBankTransactionStatus(value string) (banking.TransactionStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch banking.TransactionStatus(normalized) {
+	case banking.StatusUnmatched, banking.StatusMatched, banking.StatusReconciled:
+		return banking.TransactionStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid bank transaction status %q", value)
+	}
+}
+
+func parseRequiredBankFollowUpStatus(value string) (banking.FollowUpStatus, error) {
+	status, err := banking.NormalizeFollowUpStatus(value)
 	if err != nil {
-		return nil, err
+		if strings.TrimSpace(value) == "" {
+			return "", errors.New("follow-up-status is required")
+		}
+		return "", fmt.Errorf("invalid bank follow-up status %q", value)
 	}
-	return newAPIClient(cfg.BaseURL, ""), nil
+	return status, nil
 }
 
-func resolvePassword(password string, passwordStdin bool) (string, error) {
-	if strings.TrimSpace(password) != "" {
-		return password, nil
+func parseBankMatchRuleConfidence(value string) (float64, error) {
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse min-confidence: %w", err)
 	}
-	if !passwordStdin {
-		return "", errors.New("password is required")
+	if parsed < 0 || parsed > 1 {
+		return 0, errors.New("min-confidence must be between 0 and 1")
 	}
+	return parsed, nil
+}
 
-	reader := bufio.NewReader(os.Stdin)
-	value, err := reader.ReadString('\n')
-	if err != nil && !errors.Is(err, io.EOF) {
-		return "", fmt.Errorf("read password from stdin: %w", err)
+func parseOptionalAssetStatus(value string) (assets.AssetStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
 	}
-	value = strings.TrimRight(value, "\r\n")
-	if value == "" {
-		return "", errors.New("password from stdin is empty")
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assets.AssetStatus(normalized) {
+	case assets.AssetStatusDraft, assets.AssetStatusActive, assets.AssetStatusDisposed, assets.AssetStatusSold:
+		return assets.AssetStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid asset status %q", value)
+	}
+}
+
+func parseOptionalExpenseStatus(value string) (expenses.ExpenseStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch expenses.ExpenseStatus(normalized) {
+	case expenses.StatusDraft, expenses.StatusSubmitted, expenses.StatusApproved, expenses.StatusRejected, expenses.StatusPosted:
+		return expenses.ExpenseStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid expense status %q", value)
+	}
+}
+
+func parseOptionalProductType(value string) (inventory.ProductType, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	return parseRequiredProductType(value)
+}
+
+func parseRequiredProductType(value string) (inventory.ProductType, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch inventory.ProductType(normalized) {
+	case inventory.ProductTypeGoods, inventory.ProductTypeService:
+		return inventory.ProductType(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("type is required")
+		}
+		return "", fmt.Errorf("invalid product type %q", value)
+	}
+}
+
+func parseOptionalProductStatus(value string) (inventory.ProductStatus, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch inventory.ProductStatus(normalized) {
+	case inventory.ProductStatusActive, inventory.ProductStatusInactive:
+		return inventory.ProductStatus(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid product status %q", value)
+	}
+}
+
+func parseRequiredRecurringFrequency(value string) (recurring.Frequency, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch recurring.Frequency(normalized) {
+	case recurring.FrequencyWeekly, recurring.FrequencyBiweekly, recurring.FrequencyMonthly, recurring.FrequencyQuarterly, recurring.FrequencyYearly:
+		return recurring.Frequency(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("frequency is required")
+		}
+		return "", fmt.Errorf("invalid recurring invoice frequency %q", value)
+	}
+}
+
+func parseOptionalJournalTemplateFrequency(value string) (accounting.JournalEntryTemplateFrequency, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch accounting.JournalEntryTemplateFrequency(normalized) {
+	case accounting.JournalEntryTemplateFrequencyWeekly, accounting.JournalEntryTemplateFrequencyBiweekly, accounting.JournalEntryTemplateFrequencyMonthly, accounting.JournalEntryTemplateFrequencyQuarterly, accounting.JournalEntryTemplateFrequencyYearly:
+		return accounting.JournalEntryTemplateFrequency(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid journal template frequency %q", value)
+	}
+}
+
+func parseOptionalDepreciationMethod(value string) (assets.DepreciationMethod, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assets.DepreciationMethod(normalized) {
+	case assets.DepreciationStraightLine, assets.DepreciationDecliningBalance, assets.DepreciationUnitsOfProd:
+		return assets.DepreciationMethod(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid depreciation method %q", value)
+	}
+}
+
+func parseRequiredDisposalMethod(value string) (assets.DisposalMethod, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assets.DisposalMethod(normalized) {
+	case assets.DisposalSold, assets.DisposalScrapped, assets.DisposalDonated, assets.DisposalLost:
+		return assets.DisposalMethod(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("method is required")
+		}
+		return "", fmt.Errorf("invalid disposal method %q", value)
 	}
-	return value, nil
 }
 
-func resolvePasswordPair(currentPassword, newPassword string, passwordsStdin bool) (string, string, error) {
-	if strings.TrimSpace(currentPassword) != "" && strings.TrimSpace(newPassword) != "" {
-		return currentPassword, newPassword, nil
-	}
-	if !passwordsStdin {
-		return "", "", errors.New("current-password and new-password are required")
+func parseRequiredAssetEventType(value string) (assets.AssetEventType, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch assets.AssetEventType(normalized) {
+	case assets.AssetEventImprovement, assets.AssetEventImpairment, assets.AssetEventEstimateChange:
+		return assets.AssetEventType(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("type is required")
+		}
+		return "", fmt.Errorf("invalid asset event type %q", value)
 	}
+}
 
-	reader := bufio.NewReader(os.Stdin)
-	currentValue, err := readPasswordLine(reader, "current password")
-	if err != nil {
-		return "", "", err
+func parseOptionalBudgetPeriod(value string) (accounting.BudgetPeriod, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
 	}
-	newValue, err := readPasswordLine(reader, "new password")
-	if err != nil {
-		return "", "", err
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch accounting.BudgetPeriod(normalized) {
+	case accounting.BudgetPeriodMonthly, accounting.BudgetPeriodQuarterly, accounting.BudgetPeriodAnnual:
+		return accounting.BudgetPeriod(normalized), nil
+	default:
+		return "", fmt.Errorf("invalid budget period %q", value)
 	}
-	return currentValue, newValue, nil
 }
 
-func readPasswordLine(reader *bufio.Reader, label string) (string, error) {
-	value, err := reader.ReadString('\n')
-	if err != nil && !errors.Is(err, io.EOF) {
-		return "", fmt.Errorf("read %s from stdin: %w", label, err)
-	}
-	value = strings.TrimRight(value, "\r\n")
-	if value == "" {
-		return "", fmt.Errorf("%s from stdin is empty", label)
+func parseOptionalPaymentType(value string) (payments.PaymentType, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
 	}
-	return value, nil
+	return parseRequiredPaymentType(value)
 }
 
-func parseTenantSettingsInput(settingsJSON, settingsFile string) (*tenant.TenantSettings, error) {
-	settingsJSON = strings.TrimSpace(settingsJSON)
-	settingsFile = strings.TrimSpace(settingsFile)
-	if settingsJSON == "" && settingsFile == "" {
-		return nil, nil
-	}
-	if settingsJSON != "" && settingsFile != "" {
-		return nil, errors.New("use either settings-json or settings-file, not both")
+func parseRequiredPaymentType(value string) (payments.PaymentType, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch payments.PaymentType(normalized) {
+	case payments.PaymentTypeReceived, payments.PaymentTypeMade:
+		return payments.PaymentType(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("type is required")
+		}
+		return "", fmt.Errorf("invalid payment type %q", value)
 	}
+}
 
-	payload := []byte(settingsJSON)
-	if settingsFile != "" {
-		content, err := os.ReadFile(settingsFile)
-		if err != nil {
-			return nil, fmt.Errorf("read settings file: %w", err)
+func parseRequiredReminderTriggerType(value string) (invoicing.TriggerType, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch invoicing.TriggerType(normalized) {
+	case invoicing.TriggerBeforeDue, invoicing.TriggerOnDue, invoicing.TriggerAfterDue:
+		return invoicing.TriggerType(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("trigger-type is required")
 		}
-		payload = content
+		return "", fmt.Errorf("invalid reminder trigger type %q", value)
 	}
+}
 
-	var settings tenant.TenantSettings
-	if err := json.Unmarshal(payload, &settings); err != nil {
-		return nil, fmt.Errorf("parse tenant settings JSON: %w", err)
+func parseRequiredDocumentType(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", errors.New("type is required")
 	}
-	return &settings, nil
+	return tenant.NormalizeDocumentType(value)
 }
 
-func parseRawJSONInput(inlineJSON, filePath, defaultJSON string) (json.RawMessage, error) {
-	inlineJSON = strings.TrimSpace(inlineJSON)
-	filePath = strings.TrimSpace(filePath)
-	if inlineJSON == "" && filePath == "" {
-		if strings.TrimSpace(defaultJSON) == "" {
-			return nil, nil
-		}
-		return json.RawMessage(defaultJSON), nil
+func readDocumentTemplateFile(filePath string) (*tenant.DocumentTemplate, error) {
+	data, _, err := readFileInput(strings.TrimSpace(filePath), "template.json")
+	if err != nil {
+		return nil, err
 	}
-	if inlineJSON != "" && filePath != "" {
-		return nil, errors.New("use either settings-json or settings-file, not both")
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var tmpl tenant.DocumentTemplate
+	if err := decoder.Decode(&tmpl); err != nil {
+		return nil, fmt.Errorf("parse template JSON: %w", err)
 	}
+	return &tmpl, nil
+}
 
-	payload := []byte(inlineJSON)
-	if filePath != "" {
-		content, err := os.ReadFile(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("read settings file: %w", err)
+func parseRequiredEmailTemplateType(value string) (email.TemplateType, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(value))
+	switch email.TemplateType(normalized) {
+	case email.TemplateInvoiceSend, email.TemplateQuoteSend, email.TemplateOrderConfirm, email.TemplatePaymentReceipt, email.TemplateOverdueReminder, email.TemplateDocumentRetentionReminder:
+		return email.TemplateType(normalized), nil
+	default:
+		if normalized == "" {
+			return "", errors.New("type is required")
 		}
-		payload = content
+		return "", fmt.Errorf("invalid email template type %q", value)
 	}
+}
 
-	var value any
-	if err := json.Unmarshal(payload, &value); err != nil {
-		return nil, fmt.Errorf("parse JSON: %w", err)
+func parseInterestRateFlags(rateValue, annualRateValue string) (float64, error) {
+	hasRate := strings.TrimSpace(rateValue) != ""
+	hasAnnualRate := strings.TrimSpace(annualRateValue) != ""
+	if hasRate && hasAnnualRate {
+		return 0, errors.New("rate and annual-rate cannot both be set")
+	}
+	if !hasRate && !hasAnnualRate {
+		return 0, errors.New("rate or annual-rate is required")
 	}
-	return json.RawMessage(payload), nil
-}
 
-func parseYearMonthFlags(yearValue, monthValue string) (int, int, error) {
-	year, err := parseRequiredPositiveInt("year", yearValue)
-	if err != nil {
-		return 0, 0, err
+	sourceName := "rate"
+	sourceValue := rateValue
+	divisor := 1.0
+	if hasAnnualRate {
+		sourceName = "annual-rate"
+		sourceValue = annualRateValue
+		divisor = 365
 	}
-	month, err := parseRequiredPositiveInt("month", monthValue)
+
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(sourceValue), 64)
 	if err != nil {
-		return 0, 0, err
+		return 0, fmt.Errorf("parse %s: %w", sourceName, err)
 	}
-	if month < 1 || month > 12 {
-		return 0, 0, errors.New("month must be between 1 and 12")
+	rate := parsed / divisor
+	req := &invoicing.UpdateInterestSettingsRequest{Rate: rate}
+	if err := req.Validate(); err != nil {
+		return 0, err
 	}
-	return year, month, nil
+	return rate, nil
 }
 
-func parseOptionalDate(name, value string) (*time.Time, error) {
-	if strings.TrimSpace(value) == "" {
+func optionalStringPtr(value string) *string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func optionalUUIDStringPtr(name, value string) (*string, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
 		return nil, nil
 	}
-	parsed, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	parsedID, err := uuid.Parse(trimmed)
 	if err != nil {
-		return nil, fmt.Errorf("parse %s: %w", name, err)
+		return nil, fmt.Errorf("%s must be a valid UUID", name)
 	}
-	return &parsed, nil
+	id := parsedID.String()
+	return &id, nil
 }
 
-func parseRequiredDate(name, value string) (time.Time, error) {
-	parsed, err := parseOptionalDate(name, value)
+func optionalUUIDStringValue(name, value string) (string, error) {
+	parsedID, err := optionalUUIDStringPtr(name, value)
 	if err != nil {
-		return time.Time{}, err
+		return "", err
 	}
-	if parsed == nil {
-		return time.Time{}, fmt.Errorf("%s is required", name)
+	if parsedID == nil {
+		return "", nil
 	}
-	return *parsed, nil
+	return *parsedID, nil
 }
 
-func parseRequiredPositiveInt(name, value string) (int, error) {
+func optionalUpperStringPtr(value string) *string {
 	trimmed := strings.TrimSpace(value)
 	if trimmed == "" {
-		return 0, fmt.Errorf("%s is required", name)
+		return nil
 	}
-	parsed, err := strconv.Atoi(trimmed)
-	if err != nil {
-		return 0, fmt.Errorf("parse %s: %w", name, err)
+	upper := strings.ToUpper(trimmed)
+	return &upper
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
 	}
-	if parsed <= 0 {
-		return 0, fmt.Errorf("%s must be positive", name)
+	return ""
+}
+
+func quoteActionPastTense(action string) string {
+	switch action {
+	case "send":
+		return "Sent"
+	case "accept":
+		return "Accepted"
+	case "reject":
+		return "Rejected"
+	default:
+		return titleLabel(action)
 	}
-	return parsed, nil
 }
 
-func parseOptionalPositiveInt(name, value string) (int, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return 0, nil
+func orderActionPastTense(action string) string {
+	switch action {
+	case "confirm":
+		return "Confirmed"
+	case "process":
+		return "Processed"
+	case "ship":
+		return "Shipped"
+	case "deliver":
+		return "Delivered"
+	case "cancel":
+		return "Canceled"
+	default:
+		return titleLabel(action)
 	}
-	return parseRequiredPositiveInt(name, trimmed)
 }
 
-func parseOptionalBoundedInt(name, value string, min, max int) (int, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return 0, nil
+func expenseActionPastTense(action string) string {
+	switch action {
+	case "submit":
+		return "Submitted"
+	case "approve":
+		return "Approved"
+	case "post":
+		return "Posted"
+	default:
+		return titleLabel(action)
 	}
-	return parseRequiredBoundedInt(name, trimmed, min, max)
 }
 
-func parseRequiredBoundedInt(name, value string, min, max int) (int, error) {
-	parsed, err := parseRequiredPositiveInt(name, value)
-	if err != nil {
-		return 0, err
+// parseSalesLineDescriptionAndPrice reads the description and unit price of a
+// sales document line. Product lines may omit both so the server resolves them
+// from the applicable price list; the unit price is nil then.
+func parseSalesLineDescriptionAndPrice(values map[string]string, hasProduct bool) (string, *decimal.Decimal, error) {
+	description := strings.TrimSpace(values["description"])
+	if description == "" && !hasProduct {
+		return "", nil, errors.New("line description is required")
 	}
-	if parsed < min || parsed > max {
-		return 0, fmt.Errorf("%s must be between %d and %d", name, min, max)
+	rawPrice := firstNonEmpty(values["unit_price"], values["price"])
+	if rawPrice == "" && hasProduct {
+		return description, nil, nil
 	}
-	return parsed, nil
+	unitPrice, err := parseRequiredNonNegativeDecimal("line unit_price", rawPrice)
+	if err != nil {
+		return "", nil, err
+	}
+	return description, &unitPrice, nil
 }
 
-func parseRequiredNonNegativeInt(name, value string) (int, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return 0, fmt.Errorf("%s is required", name)
-	}
-	parsed, err := strconv.Atoi(trimmed)
+type invoiceLineFlags []invoicing.CreateInvoiceLineRequest
+
+func (l *invoiceLineFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
 	if err != nil {
-		return 0, fmt.Errorf("parse %s: %w", name, err)
+		return fmt.Errorf("parse line: %w", err)
 	}
-	if parsed < 0 {
-		return 0, fmt.Errorf("%s must be non-negative", name)
+
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("line field %q must be key=value", field)
+		}
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
 	}
-	return parsed, nil
-}
 
-func parseOptionalBoolPtr(name, value string) (*bool, error) {
-	if strings.TrimSpace(value) == "" {
-		return nil, nil
+	productID := optionalStringPtr(firstNonEmpty(values["product_id"], values["product"]))
+	description, unitPrice, err := parseSalesLineDescriptionAndPrice(values, productID != nil)
+	if err != nil {
+		return err
 	}
-	parsed, err := strconv.ParseBool(strings.TrimSpace(value))
+	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
 	if err != nil {
-		return nil, fmt.Errorf("parse %s: %w", name, err)
+		return err
 	}
-	return &parsed, nil
-}
-
-func parseRequiredPositiveDecimal(name, value string) (decimal.Decimal, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return decimal.Zero, fmt.Errorf("%s is required", name)
+	vatRate, err := parseRequiredNonNegativeDecimal("line vat_rate", firstNonEmpty(values["vat_rate"], values["vat"]))
+	if err != nil {
+		return err
 	}
-	parsed, err := decimal.NewFromString(trimmed)
+	discountPercent := decimal.Zero
+	if rawDiscount := firstNonEmpty(values["discount_percent"], values["discount"]); rawDiscount != "" {
+		discountPercent, err = parseRequiredNonNegativeDecimal("line discount_percent", rawDiscount)
+		if err != nil {
+			return err
+		}
+	}
+	vatTreatment, err := parseInvoiceLineVATTreatment(values["vat_treatment"], values["vat_type"], values["reverse_charge"])
 	if err != nil {
-		return decimal.Zero, fmt.Errorf("parse %s: %w", name, err)
+		return err
 	}
-	if parsed.LessThanOrEqual(decimal.Zero) {
-		return decimal.Zero, fmt.Errorf("%s must be positive", name)
+	if vatTreatment == invoicing.VATTreatmentReverseCharge && vatRate.LessThanOrEqual(decimal.Zero) {
+		return errors.New("line reverse charge VAT rate must be positive")
 	}
-	return parsed, nil
+
+	*l = append(*l, invoicing.CreateInvoiceLineRequest{
+		Description:     description,
+		Quantity:        quantity,
+		Unit:            strings.TrimSpace(values["unit"]),
+		UnitPrice:       unitPrice,
+		DiscountPercent: discountPercent,
+		VATRate:         vatRate,
+		VATTreatment:    vatTreatment,
+		AccountID:       optionalStringPtr(firstNonEmpty(values["account_id"], values["account"])),
+		ProductID:       productID,
+	})
+	return nil
 }
 
-func parseRequiredDecimal(name, value string) (decimal.Decimal, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return decimal.Zero, fmt.Errorf("%s is required", name)
+func (l *invoiceLineFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	parsed, err := decimal.NewFromString(trimmed)
-	if err != nil {
-		return decimal.Zero, fmt.Errorf("parse %s: %w", name, err)
+	descriptions := make([]string, 0, len(*l))
+	for _, line := range *l {
+		descriptions = append(descriptions, line.Description)
 	}
-	return parsed, nil
+	return strings.Join(descriptions, ",")
 }
 
-func parseRequiredNonNegativeDecimal(name, value string) (decimal.Decimal, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return decimal.Zero, fmt.Errorf("%s is required", name)
+type creditNoteLineFlags []invoicing.CreateCreditNoteLineRequest
+
+func (l *creditNoteLineFlags) Set(value string) error {
+	lineID, rawQuantity, ok := strings.Cut(strings.TrimSpace(value), ":")
+	if !ok || strings.TrimSpace(lineID) == "" || strings.TrimSpace(rawQuantity) == "" {
+		return errors.New("line must be in original-line-id:quantity form")
 	}
-	parsed, err := decimal.NewFromString(trimmed)
+	quantity, err := parseRequiredPositiveDecimal("line quantity", rawQuantity)
 	if err != nil {
-		return decimal.Zero, fmt.Errorf("parse %s: %w", name, err)
-	}
-	if parsed.LessThan(decimal.Zero) {
-		return decimal.Zero, fmt.Errorf("%s must be non-negative", name)
+		return err
 	}
-	return parsed, nil
+	*l = append(*l, invoicing.CreateCreditNoteLineRequest{
+		OriginalLineID: strings.TrimSpace(lineID),
+		Quantity:       quantity,
+	})
+	return nil
 }
 
-func parseOptionalNonNegativeDecimalPtr(name, value string) (*decimal.Decimal, error) {
-	if strings.TrimSpace(value) == "" {
-		return nil, nil
+func (l *creditNoteLineFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	parsed, err := parseRequiredNonNegativeDecimal(name, value)
-	if err != nil {
-		return nil, err
+	values := make([]string, 0, len(*l))
+	for _, line := range *l {
+		values = append(values, line.OriginalLineID+":"+line.Quantity.String())
 	}
-	return &parsed, nil
+	return strings.Join(values, ",")
 }
 
-func parseOptionalInvoiceType(value string) (invoicing.InvoiceType, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+type orderLineFlags []orders.CreateOrderLineRequest
+
+func (l *orderLineFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse line: %w", err)
 	}
-	return parseRequiredInvoiceType(value)
-}
 
-func parseRequiredInvoiceType(value string) (invoicing.InvoiceType, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch invoicing.InvoiceType(normalized) {
-	case invoicing.InvoiceTypeSales, invoicing.InvoiceTypePurchase, invoicing.InvoiceTypeCreditNote:
-		return invoicing.InvoiceType(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("type is required")
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("line field %q must be key=value", field)
 		}
-		return "", fmt.Errorf("invalid invoice type %q", value)
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
 	}
-}
 
-func parseOptionalInvoiceStatus(value string) (invoicing.InvoiceStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+	productID := optionalStringPtr(firstNonEmpty(values["product_id"], values["product"]))
+	description, unitPrice, err := parseSalesLineDescriptionAndPrice(values, productID != nil)
+	if err != nil {
+		return err
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch invoicing.InvoiceStatus(normalized) {
-	case invoicing.StatusDraft, invoicing.StatusSent, invoicing.StatusPartiallyPaid, invoicing.StatusPaid, invoicing.StatusOverdue, invoicing.StatusVoided:
-		return invoicing.InvoiceStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid invoice status %q", value)
+	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
+	if err != nil {
+		return err
 	}
-}
-
-func parseOptionalQuoteStatus(value string) (quotes.QuoteStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+	vatRate, err := parseRequiredNonNegativeDecimal("line vat_rate", firstNonEmpty(values["vat_rate"], values["vat"]))
+	if err != nil {
+		return err
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch quotes.QuoteStatus(normalized) {
-	case quotes.QuoteStatusDraft, quotes.QuoteStatusSent, quotes.QuoteStatusAccepted, quotes.QuoteStatusRejected, quotes.QuoteStatusExpired, quotes.QuoteStatusConverted:
-		return quotes.QuoteStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid quote status %q", value)
+	discountPercent := decimal.Zero
+	if rawDiscount := firstNonEmpty(values["discount_percent"], values["discount"]); rawDiscount != "" {
+		discountPercent, err = parseRequiredNonNegativeDecimal("line discount_percent", rawDiscount)
+		if err != nil {
+			return err
+		}
 	}
-}
 
-func parseOptionalOrderStatus(value string) (orders.OrderStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
-	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch orders.OrderStatus(normalized) {
-	case orders.OrderStatusPending, orders.OrderStatusConfirmed, orders.OrderStatusProcessing, orders.OrderStatusShipped, orders.OrderStatusDelivered, orders.OrderStatusCanceled:
-		return orders.OrderStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid order status %q", value)
-	}
+	*l = append(*l, orders.CreateOrderLineRequest{
+		Description:     description,
+		Quantity:        quantity,
+		Unit:            strings.TrimSpace(values["unit"]),
+		UnitPrice:       unitPrice,
+		DiscountPercent: discountPercent,
+		VATRate:         vatRate,
+		ProductID:       productID,
+	})
+	return nil
 }
 
-func parseOptionalBankTransactionStatus(value string) (banking.TransactionStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+func (l *orderLineFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch banking.TransactionStatus(normalized) {
-	case banking.StatusUnmatched, banking.StatusMatched, banking.StatusReconciled:
-		return banking.TransactionStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid bank transaction status %q", value)
+	descriptions := make([]string, 0, len(*l))
+	for _, line := range *l {
+		descriptions = append(descriptions, line.Description)
 	}
+	return strings.Join(descriptions, ",")
 }
 
-func parseRequiredBankFollowUpStatus(value string) (banking.FollowUpStatus, error) {
-	status, err := banking.NormalizeFollowUpStatus(value)
+type purchaseOrderLineFlags []purchasing.CreatePurchaseOrderLineRequest
+
+func (l *purchaseOrderLineFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
 	if err != nil {
-		if strings.TrimSpace(value) == "" {
-			return "", errors.New("follow-up-status is required")
+		return fmt.Errorf("parse line: %w", err)
+	}
+
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("line field %q must be key=value", field)
 		}
-		return "", fmt.Errorf("invalid bank follow-up status %q", value)
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
 	}
-	return status, nil
-}
 
-func parseBankMatchRuleConfidence(value string) (float64, error) {
-	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	productID := firstNonEmpty(values["product_id"], values["product"])
+	if productID == "" {
+		return errors.New("line product_id is required")
+	}
+	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
 	if err != nil {
-		return 0, fmt.Errorf("parse min-confidence: %w", err)
+		return err
 	}
-	if parsed < 0 || parsed > 1 {
-		return 0, errors.New("min-confidence must be between 0 and 1")
+	unitPrice, err := parseRequiredNonNegativeDecimal("line unit_price", firstNonEmpty(values["unit_price"], values["price"]))
+	if err != nil {
+		return err
 	}
-	return parsed, nil
-}
-
-func parseOptionalAssetStatus(value string) (assets.AssetStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+	vatRate, err := parseRequiredNonNegativeDecimal("line vat_rate", firstNonEmpty(values["vat_rate"], values["vat"]))
+	if err != nil {
+		return err
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch assets.AssetStatus(normalized) {
-	case assets.AssetStatusDraft, assets.AssetStatusActive, assets.AssetStatusDisposed, assets.AssetStatusSold:
-		return assets.AssetStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid asset status %q", value)
+	discountPercent := decimal.Zero
+	if rawDiscount := firstNonEmpty(values["discount_percent"], values["discount"]); rawDiscount != "" {
+		discountPercent, err = parseRequiredNonNegativeDecimal("line discount_percent", rawDiscount)
+		if err != nil {
+			return err
+		}
 	}
+
+	*l = append(*l, purchasing.CreatePurchaseOrderLineRequest{
+		ProductID:       productID,
+		Description:     strings.TrimSpace(values["description"]),
+		Quantity:        quantity,
+		Unit:            strings.TrimSpace(values["unit"]),
+		UnitPrice:       unitPrice,
+		DiscountPercent: discountPercent,
+		VATRate:         vatRate,
+	})
+	return nil
 }
 
-func parseOptionalExpenseStatus(value string) (expenses.ExpenseStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+func (l *purchaseOrderLineFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch expenses.ExpenseStatus(normalized) {
-	case expenses.StatusDraft, expenses.StatusSubmitted, expenses.StatusApproved, expenses.StatusRejected, expenses.StatusPosted:
-		return expenses.ExpenseStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid expense status %q", value)
+	productIDs := make([]string, 0, len(*l))
+	for _, line := range *l {
+		productIDs = append(productIDs, line.ProductID)
 	}
+	return strings.Join(productIDs, ",")
 }
 
-func parseOptionalProductType(value string) (inventory.ProductType, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+type stockCountEntryFlags []stocktake.CountEntry
+
+func (l *stockCountEntryFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse entry: %w", err)
 	}
-	return parseRequiredProductType(value)
-}
 
-func parseRequiredProductType(value string) (inventory.ProductType, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch inventory.ProductType(normalized) {
-	case inventory.ProductTypeGoods, inventory.ProductTypeService:
-		return inventory.ProductType(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("type is required")
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("entry field %q must be key=value", field)
 		}
-		return "", fmt.Errorf("invalid product type %q", value)
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
 	}
-}
 
-func parseOptionalProductStatus(value string) (inventory.ProductStatus, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+	entry := stocktake.CountEntry{
+		LineID:       values["line_id"],
+		ProductID:    values["product_id"],
+		Barcode:      values["barcode"],
+		LotNumber:    firstNonEmpty(values["lot_number"], values["lot"]),
+		SerialNumber: firstNonEmpty(values["serial_number"], values["serial"]),
+		ExpiryDate:   firstNonEmpty(values["expiry_date"], values["expiry"]),
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch inventory.ProductStatus(normalized) {
-	case inventory.ProductStatusActive, inventory.ProductStatusInactive:
-		return inventory.ProductStatus(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid product status %q", value)
+	if entry.LineID == "" && entry.ProductID == "" && entry.Barcode == "" {
+		return errors.New("entry line_id, product_id or barcode is required")
 	}
-}
-
-func parseRequiredRecurringFrequency(value string) (recurring.Frequency, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch recurring.Frequency(normalized) {
-	case recurring.FrequencyWeekly, recurring.FrequencyBiweekly, recurring.FrequencyMonthly, recurring.FrequencyQuarterly, recurring.FrequencyYearly:
-		return recurring.Frequency(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("frequency is required")
+	entry.Quantity, err = parseRequiredNonNegativeDecimal("entry quantity", firstNonEmpty(values["quantity"], values["qty"]))
+	if err != nil {
+		return err
+	}
+	if entry.ExpiryDate != "" {
+		if _, err := parseRequiredDate("entry expiry_date", entry.ExpiryDate); err != nil {
+			return err
 		}
-		return "", fmt.Errorf("invalid recurring invoice frequency %q", value)
 	}
+
+	*l = append(*l, entry)
+	return nil
 }
 
-func parseOptionalJournalTemplateFrequency(value string) (accounting.JournalEntryTemplateFrequency, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+func (l *stockCountEntryFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch accounting.JournalEntryTemplateFrequency(normalized) {
-	case accounting.JournalEntryTemplateFrequencyWeekly, accounting.JournalEntryTemplateFrequencyBiweekly, accounting.JournalEntryTemplateFrequencyMonthly, accounting.JournalEntryTemplateFrequencyQuarterly, accounting.JournalEntryTemplateFrequencyYearly:
-		return accounting.JournalEntryTemplateFrequency(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid journal template frequency %q", value)
+	keys := make([]string, 0, len(*l))
+	for _, entry := range *l {
+		keys = append(keys, firstNonEmpty(entry.LineID, entry.ProductID, entry.Barcode))
 	}
+	return strings.Join(keys, ",")
 }
 
-func parseOptionalDepreciationMethod(value string) (assets.DepreciationMethod, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
-	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch assets.DepreciationMethod(normalized) {
-	case assets.DepreciationStraightLine, assets.DepreciationDecliningBalance, assets.DepreciationUnitsOfProd:
-		return assets.DepreciationMethod(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid depreciation method %q", value)
+type bomComponentFlags []assembly.CreateBOMLineRequest
+
+func (l *bomComponentFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse component: %w", err)
 	}
-}
 
-func parseRequiredDisposalMethod(value string) (assets.DisposalMethod, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch assets.DisposalMethod(normalized) {
-	case assets.DisposalSold, assets.DisposalScrapped, assets.DisposalDonated, assets.DisposalLost:
-		return assets.DisposalMethod(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("method is required")
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("component field %q must be key=value", field)
 		}
-		return "", fmt.Errorf("invalid disposal method %q", value)
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
 	}
-}
 
-func parseOptionalBudgetPeriod(value string) (accounting.BudgetPeriod, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+	productID := firstNonEmpty(values["component_product_id"], values["product_id"])
+	if productID == "" {
+		return errors.New("component product_id is required")
 	}
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch accounting.BudgetPeriod(normalized) {
-	case accounting.BudgetPeriodMonthly, accounting.BudgetPeriodQuarterly, accounting.BudgetPeriodAnnual:
-		return accounting.BudgetPeriod(normalized), nil
-	default:
-		return "", fmt.Errorf("invalid budget period %q", value)
+	quantity, err := parseRequiredPositiveDecimal("component quantity", firstNonEmpty(values["quantity"], values["qty"]))
+	if err != nil {
+		return err
 	}
+
+	*l = append(*l, assembly.CreateBOMLineRequest{
+		ComponentProductID: productID,
+		Quantity:           quantity,
+		Notes:              values["notes"],
+	})
+	return nil
 }
 
-func parseOptionalPaymentType(value string) (payments.PaymentType, error) {
-	if strings.TrimSpace(value) == "" {
-		return "", nil
+func (l *bomComponentFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	return parseRequiredPaymentType(value)
+	productIDs := make([]string, 0, len(*l))
+	for _, line := range *l {
+		productIDs = append(productIDs, line.ComponentProductID)
+	}
+	return strings.Join(productIDs, ",")
 }
 
-func parseRequiredPaymentType(value string) (payments.PaymentType, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch payments.PaymentType(normalized) {
-	case payments.PaymentTypeReceived, payments.PaymentTypeMade:
-		return payments.PaymentType(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("type is required")
-		}
-		return "", fmt.Errorf("invalid payment type %q", value)
+type goodsReceiptLineFlags []purchasing.ReceiveGoodsLineRequest
+
+func (l *goodsReceiptLineFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse line: %w", err)
 	}
-}
 
-func parseRequiredReminderTriggerType(value string) (invoicing.TriggerType, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch invoicing.TriggerType(normalized) {
-	case invoicing.TriggerBeforeDue, invoicing.TriggerOnDue, invoicing.TriggerAfterDue:
-		return invoicing.TriggerType(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("trigger-type is required")
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("line field %q must be key=value", field)
 		}
-		return "", fmt.Errorf("invalid reminder trigger type %q", value)
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
 	}
-}
 
-func parseRequiredEmailTemplateType(value string) (email.TemplateType, error) {
-	normalized := strings.ToUpper(strings.TrimSpace(value))
-	switch email.TemplateType(normalized) {
-	case email.TemplateInvoiceSend, email.TemplateQuoteSend, email.TemplateOrderConfirm, email.TemplatePaymentReceipt, email.TemplateOverdueReminder, email.TemplateDocumentRetentionReminder:
-		return email.TemplateType(normalized), nil
-	default:
-		if normalized == "" {
-			return "", errors.New("type is required")
+	lineID := firstNonEmpty(values["purchase_order_line_id"], values["line_id"])
+	if lineID == "" {
+		return errors.New("line line_id is required")
+	}
+	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
+	if err != nil {
+		return err
+	}
+	unitCost, err := parseOptionalNonNegativeDecimalPtr("line unit_cost", firstNonEmpty(values["unit_cost"], values["cost"]))
+	if err != nil {
+		return err
+	}
+	expiryDate := firstNonEmpty(values["expiry_date"], values["expiry"])
+	if expiryDate != "" {
+		if _, err := parseRequiredDate("line expiry_date", expiryDate); err != nil {
+			return err
 		}
-		return "", fmt.Errorf("invalid email template type %q", value)
 	}
+
+	*l = append(*l, purchasing.ReceiveGoodsLineRequest{
+		PurchaseOrderLineID: lineID,
+		Quantity:            quantity,
+		UnitCost:            unitCost,
+		LotNumber:           firstNonEmpty(values["lot_number"], values["lot"]),
+		SerialNumber:        firstNonEmpty(values["serial_number"], values["serial"]),
+		ExpiryDate:          expiryDate,
+	})
+	return nil
 }
 
-func parseInterestRateFlags(rateValue, annualRateValue string) (float64, error) {
-	hasRate := strings.TrimSpace(rateValue) != ""
-	hasAnnualRate := strings.TrimSpace(annualRateValue) != ""
-	if hasRate && hasAnnualRate {
-		return 0, errors.New("rate and annual-rate cannot both be set")
+func (l *goodsReceiptLineFlags) String() string {
+	if l == nil {
+		return ""
 	}
-	if !hasRate && !hasAnnualRate {
-		return 0, errors.New("rate or annual-rate is required")
+	lineIDs := make([]string, 0, len(*l))
+	for _, line := range *l {
+		lineIDs = append(lineIDs, line.PurchaseOrderLineID)
 	}
+	return strings.Join(lineIDs, ",")
+}
 
-	sourceName := "rate"
-	sourceValue := rateValue
-	divisor := 1.0
-	if hasAnnualRate {
-		sourceName = "annual-rate"
-		sourceValue = annualRateValue
-		divisor = 365
+func parseInvoiceLineVATTreatment(rawTreatment, rawType, rawReverseCharge string) (invoicing.VATTreatment, error) {
+	if strings.TrimSpace(rawReverseCharge) != "" {
+		reverseCharge, err := strconv.ParseBool(strings.TrimSpace(rawReverseCharge))
+		if err != nil {
+			return "", fmt.Errorf("parse reverse_charge: %w", err)
+		}
+		if reverseCharge {
+			return invoicing.VATTreatmentReverseCharge, nil
+		}
 	}
 
-	parsed, err := strconv.ParseFloat(strings.TrimSpace(sourceValue), 64)
-	if err != nil {
-		return 0, fmt.Errorf("parse %s: %w", sourceName, err)
-	}
-	rate := parsed / divisor
-	req := &invoicing.UpdateInterestSettingsRequest{Rate: rate}
-	if err := req.Validate(); err != nil {
-		return 0, err
+	value := firstNonEmpty(rawTreatment, rawType)
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	switch normalized {
+	case "", "standard", "normal":
+		return invoicing.VATTreatmentStandard, nil
+	case "reverse_charge", "reverse-charge", "reverse charge", "reversecharge", "rc":
+		return invoicing.VATTreatmentReverseCharge, nil
+	default:
+		return "", fmt.Errorf("invalid line vat_treatment %q", value)
 	}
-	return rate, nil
 }
 
-func optionalStringPtr(value string) *string {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return nil
+type inventoryAgingFlags struct {
+	warehouseID        *string
+	categoryID         *string
+	asOf               *string
+	slowMovingDays     *int
+	expiringWithinDays *int
+	sellingCost        *string
+	slowMovingDiscount *string
+}
+
+func bindInventoryAgingFlags(fs *flag.FlagSet) inventoryAgingFlags {
+	return inventoryAgingFlags{
+		warehouseID:        fs.String("warehouse-id", "", "Warehouse id"),
+		categoryID:         fs.String("category-id", "", "Product category id"),
+		asOf:               fs.String("as-of", "", "Aging date in YYYY-MM-DD (default today)"),
+		slowMovingDays:     fs.Int("slow-moving-days", 0, "Days without issues before a lot is slow-moving (default 180)"),
+		expiringWithinDays: fs.Int("expiring-within-days", 0, "Days to expiry flagged as expiring (default 90)"),
+		sellingCost:        fs.String("selling-cost-percent", "", "Costs to sell as a percentage of the sales price (default 0)"),
+		slowMovingDiscount: fs.String("slow-moving-discount-percent", "", "Price reduction for slow-moving lots in percent (default 50)"),
 	}
-	return &trimmed
 }
 
-func optionalUUIDStringPtr(name, value string) (*string, error) {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return nil, nil
+func (f inventoryAgingFlags) request() (*inventory.InventoryAgingRequest, error) {
+	if *f.slowMovingDays < 0 {
+		return nil, errors.New("slow-moving-days must not be negative")
 	}
-	parsedID, err := uuid.Parse(trimmed)
+	if *f.expiringWithinDays < 0 {
+		return nil, errors.New("expiring-within-days must not be negative")
+	}
+	asOf, err := parseOptionalDate("as-of", *f.asOf)
 	if err != nil {
-		return nil, fmt.Errorf("%s must be a valid UUID", name)
+		return nil, err
 	}
-	id := parsedID.String()
-	return &id, nil
-}
-
-func optionalUUIDStringValue(name, value string) (string, error) {
-	parsedID, err := optionalUUIDStringPtr(name, value)
+	sellingCost, err := parseOptionalNonNegativeDecimalPtr("selling-cost-percent", *f.sellingCost)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if parsedID == nil {
-		return "", nil
+	slowMovingDiscount, err := parseOptionalNonNegativeDecimalPtr("slow-moving-discount-percent", *f.slowMovingDiscount)
+	if err != nil {
+		return nil, err
 	}
-	return *parsedID, nil
-}
-
-func optionalUpperStringPtr(value string) *string {
-	trimmed := strings.TrimSpace(value)
-	if trimmed == "" {
-		return nil
+	req := &inventory.InventoryAgingRequest{
+		WarehouseID:               strings.TrimSpace(*f.warehouseID),
+		CategoryID:                strings.TrimSpace(*f.categoryID),
+		SlowMovingDays:            *f.slowMovingDays,
+		ExpiringWithinDays:        *f.expiringWithinDays,
+		SlowMovingDiscountPercent: slowMovingDiscount,
 	}
-	upper := strings.ToUpper(trimmed)
-	return &upper
-}
-
-func firstNonEmpty(values ...string) string {
-	for _, value := range values {
-		if strings.TrimSpace(value) != "" {
-			return strings.TrimSpace(value)
-		}
+	if sellingCost != nil {
+		req.SellingCostPercent = *sellingCost
 	}
-	return ""
+	if asOf != nil {
+		req.AsOfDate = *asOf
+	}
+	return req, nil
 }
 
-func quoteActionPastTense(action string) string {
-	switch action {
-	case "send":
-		return "Sent"
-	case "accept":
-		return "Accepted"
-	case "reject":
-		return "Rejected"
-	default:
-		return titleLabel(action)
-	}
+type replenishmentFlags struct {
+	warehouseID  *string
+	supplierID   *string
+	asOf         *string
+	velocityDays *int
+	coverageDays *int
 }
 
-func orderActionPastTense(action string) string {
-	switch action {
-	case "confirm":
-		return "Confirmed"
-	case "process":
-		return "Processed"
-	case "ship":
-		return "Shipped"
-	case "deliver":
-		return "Delivered"
-	case "cancel":
-		return "Canceled"
-	default:
-		return titleLabel(action)
+func bindReplenishmentFlags(fs *flag.FlagSet) replenishmentFlags {
+	return replenishmentFlags{
+		warehouseID:  fs.String("warehouse-id", "", "Warehouse id"),
+		supplierID:   fs.String("supplier-id", "", "Supplier contact id"),
+		asOf:         fs.String("as-of", "", "Planning date in YYYY-MM-DD (default today)"),
+		velocityDays: fs.Int("velocity-days", 0, "Consumption window in days (default 90)"),
+		coverageDays: fs.Int("coverage-days", 0, "Days of demand to cover beyond the reorder level (default 30)"),
 	}
 }
 
-func expenseActionPastTense(action string) string {
-	switch action {
-	case "submit":
-		return "Submitted"
-	case "approve":
-		return "Approved"
-	case "post":
-		return "Posted"
-	default:
-		return titleLabel(action)
+func (f replenishmentFlags) request() (*purchasing.ReplenishmentRequest, error) {
+	if *f.velocityDays < 0 {
+		return nil, errors.New("velocity-days must not be negative")
+	}
+	if *f.coverageDays < 0 {
+		return nil, errors.New("coverage-days must not be negative")
+	}
+	asOf, err := parseOptionalDate("as-of", *f.asOf)
+	if err != nil {
+		return nil, err
+	}
+	req := &purchasing.ReplenishmentRequest{
+		WarehouseID:  strings.TrimSpace(*f.warehouseID),
+		SupplierID:   strings.TrimSpace(*f.supplierID),
+		VelocityDays: *f.velocityDays,
+		CoverageDays: *f.coverageDays,
 	}
+	if asOf != nil {
+		req.AsOfDate = *asOf
+	}
+	return req, nil
 }
 
-type invoiceLineFlags []invoicing.CreateInvoiceLineRequest
+type shipmentLineFlags []orders.ShipOrderLineRequest
 
-func (l *invoiceLineFlags) Set(value string) error {
+func (l *shipmentLineFlags) Set(value string) error {
 	reader := csv.NewReader(strings.NewReader(value))
 	reader.TrimLeadingSpace = true
 	reader.FieldsPerRecord = -1
@@ -13422,65 +17670,45 @@ func (l *invoiceLineFlags) Set(value string) error {
 		values[normalizedKey] = strings.TrimSpace(val)
 	}
 
-	description := strings.TrimSpace(values["description"])
-	if description == "" {
-		return errors.New("line description is required")
+	lineID := firstNonEmpty(values["order_line_id"], values["line_id"])
+	if lineID == "" {
+		return errors.New("line line_id is required")
 	}
 	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
 	if err != nil {
 		return err
 	}
-	unitPrice, err := parseRequiredNonNegativeDecimal("line unit_price", firstNonEmpty(values["unit_price"], values["price"]))
-	if err != nil {
-		return err
-	}
-	vatRate, err := parseRequiredNonNegativeDecimal("line vat_rate", firstNonEmpty(values["vat_rate"], values["vat"]))
-	if err != nil {
-		return err
-	}
-	discountPercent := decimal.Zero
-	if rawDiscount := firstNonEmpty(values["discount_percent"], values["discount"]); rawDiscount != "" {
-		discountPercent, err = parseRequiredNonNegativeDecimal("line discount_percent", rawDiscount)
-		if err != nil {
+	expiryDate := firstNonEmpty(values["expiry_date"], values["expiry"])
+	if expiryDate != "" {
+		if _, err := parseRequiredDate("line expiry_date", expiryDate); err != nil {
 			return err
 		}
 	}
-	vatTreatment, err := parseInvoiceLineVATTreatment(values["vat_treatment"], values["vat_type"], values["reverse_charge"])
-	if err != nil {
-		return err
-	}
-	if vatTreatment == invoicing.VATTreatmentReverseCharge && vatRate.LessThanOrEqual(decimal.Zero) {
-		return errors.New("line reverse charge VAT rate must be positive")
-	}
 
-	*l = append(*l, invoicing.CreateInvoiceLineRequest{
-		Description:     description,
-		Quantity:        quantity,
-		Unit:            strings.TrimSpace(values["unit"]),
-		UnitPrice:       unitPrice,
-		DiscountPercent: discountPercent,
-		VATRate:         vatRate,
-		VATTreatment:    vatTreatment,
-		AccountID:       optionalStringPtr(firstNonEmpty(values["account_id"], values["account"])),
-		ProductID:       optionalStringPtr(firstNonEmpty(values["product_id"], values["product"])),
+	*l = append(*l, orders.ShipOrderLineRequest{
+		OrderLineID:  lineID,
+		Quantity:     quantity,
+		LotNumber:    firstNonEmpty(values["lot_number"], values["lot"]),
+		SerialNumber: firstNonEmpty(values["serial_number"], values["serial"]),
+		ExpiryDate:   expiryDate,
 	})
 	return nil
 }
 
-func (l *invoiceLineFlags) String() string {
+func (l *shipmentLineFlags) String() string {
 	if l == nil {
 		return ""
 	}
-	descriptions := make([]string, 0, len(*l))
+	lineIDs := make([]string, 0, len(*l))
 	for _, line := range *l {
-		descriptions = append(descriptions, line.Description)
+		lineIDs = append(lineIDs, line.OrderLineID)
 	}
-	return strings.Join(descriptions, ",")
+	return strings.Join(lineIDs, ",")
 }
 
-type orderLineFlags []orders.CreateOrderLineRequest
+type billingLineFlags []invoicing.PartialInvoiceLineRequest
 
-func (l *orderLineFlags) Set(value string) error {
+func (l *billingLineFlags) Set(value string) error {
 	reader := csv.NewReader(strings.NewReader(value))
 	reader.TrimLeadingSpace = true
 	reader.FieldsPerRecord = -1
@@ -13499,74 +17727,58 @@ func (l *orderLineFlags) Set(value string) error {
 		values[normalizedKey] = strings.TrimSpace(val)
 	}
 
-	description := strings.TrimSpace(values["description"])
-	if description == "" {
-		return errors.New("line description is required")
+	lineID := firstNonEmpty(values["line_id"], values["id"])
+	if lineID == "" {
+		return errors.New("line line_id is required")
 	}
 	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
 	if err != nil {
 		return err
 	}
-	unitPrice, err := parseRequiredNonNegativeDecimal("line unit_price", firstNonEmpty(values["unit_price"], values["price"]))
-	if err != nil {
-		return err
-	}
-	vatRate, err := parseRequiredNonNegativeDecimal("line vat_rate", firstNonEmpty(values["vat_rate"], values["vat"]))
-	if err != nil {
-		return err
-	}
-	discountPercent := decimal.Zero
-	if rawDiscount := firstNonEmpty(values["discount_percent"], values["discount"]); rawDiscount != "" {
-		discountPercent, err = parseRequiredNonNegativeDecimal("line discount_percent", rawDiscount)
-		if err != nil {
-			return err
-		}
-	}
 
-	*l = append(*l, orders.CreateOrderLineRequest{
-		Description:     description,
-		Quantity:        quantity,
-		Unit:            strings.TrimSpace(values["unit"]),
-		UnitPrice:       unitPrice,
-		DiscountPercent: discountPercent,
-		VATRate:         vatRate,
-		ProductID:       optionalStringPtr(firstNonEmpty(values["product_id"], values["product"])),
-	})
+	*l = append(*l, invoicing.PartialInvoiceLineRequest{LineID: lineID, Quantity: quantity})
 	return nil
 }
 
-func (l *orderLineFlags) String() string {
+func (l *billingLineFlags) String() string {
 	if l == nil {
 		return ""
 	}
-	descriptions := make([]string, 0, len(*l))
+	lineIDs := make([]string, 0, len(*l))
 	for _, line := range *l {
-		descriptions = append(descriptions, line.Description)
+		lineIDs = append(lineIDs, line.LineID)
 	}
-	return strings.Join(descriptions, ",")
+	return strings.Join(lineIDs, ",")
 }
 
-func parseInvoiceLineVATTreatment(rawTreatment, rawType, rawReverseCharge string) (invoicing.VATTreatment, error) {
-	if strings.TrimSpace(rawReverseCharge) != "" {
-		reverseCharge, err := strconv.ParseBool(strings.TrimSpace(rawReverseCharge))
-		if err != nil {
-			return "", fmt.Errorf("parse reverse_charge: %w", err)
+// parsePartialInvoiceRequest builds the billing selection shared by the
+// order and quote convert-to-invoice commands.
+func parsePartialInvoiceRequest(kind, percent, amount, vatRate string, lines billingLineFlags) (invoicing.PartialInvoiceRequest, error) {
+	req := invoicing.PartialInvoiceRequest{Lines: lines}
+	if strings.TrimSpace(kind) != "" {
+		normalized := invoicing.BillingKind(strings.ToUpper(strings.TrimSpace(kind)))
+		switch normalized {
+		case invoicing.BillingKindQuantity, invoicing.BillingKindPercentage, invoicing.BillingKindPrepayment:
+			req.Kind = normalized
+		default:
+			return req, fmt.Errorf("invalid billing kind %q", kind)
 		}
-		if reverseCharge {
-			return invoicing.VATTreatmentReverseCharge, nil
+	}
+	var err error
+	if strings.TrimSpace(percent) != "" {
+		if req.Percent, err = parseRequiredPositiveDecimal("percent", percent); err != nil {
+			return req, err
 		}
 	}
-
-	value := firstNonEmpty(rawTreatment, rawType)
-	normalized := strings.ToLower(strings.TrimSpace(value))
-	switch normalized {
-	case "", "standard", "normal":
-		return invoicing.VATTreatmentStandard, nil
-	case "reverse_charge", "reverse-charge", "reverse charge", "reversecharge", "rc":
-		return invoicing.VATTreatmentReverseCharge, nil
-	default:
-		return "", fmt.Errorf("invalid line vat_treatment %q", value)
+	if strings.TrimSpace(amount) != "" {
+		if req.Amount, err = parseRequiredPositiveDecimal("amount", amount); err != nil {
+			return req, err
+		}
 	}
+	if req.VATRate, err = parseOptionalNonNegativeDecimalPtr("vat-rate", vatRate); err != nil {
+		return req, err
+	}
+	return req, nil
 }
 
 type quoteLineFlags []quotes.CreateQuoteLineRequest
@@ -13590,15 +17802,12 @@ func (l *quoteLineFlags) Set(value string) error {
 		values[normalizedKey] = strings.TrimSpace(val)
 	}
 
-	description := strings.TrimSpace(values["description"])
-	if description == "" {
-		return errors.New("line description is required")
-	}
-	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
+	productID := optionalStringPtr(firstNonEmpty(values["product_id"], values["product"]))
+	description, unitPrice, err := parseSalesLineDescriptionAndPrice(values, productID != nil)
 	if err != nil {
 		return err
 	}
-	unitPrice, err := parseRequiredNonNegativeDecimal("line unit_price", firstNonEmpty(values["unit_price"], values["price"]))
+	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
 	if err != nil {
 		return err
 	}
@@ -13621,7 +17830,7 @@ func (l *quoteLineFlags) Set(value string) error {
 		UnitPrice:       unitPrice,
 		DiscountPercent: discountPercent,
 		VATRate:         vatRate,
-		ProductID:       optionalStringPtr(firstNonEmpty(values["product_id"], values["product"])),
+		ProductID:       productID,
 	})
 	return nil
 }
@@ -13658,15 +17867,12 @@ func (l *recurringLineFlags) Set(value string) error {
 		values[normalizedKey] = strings.TrimSpace(val)
 	}
 
-	description := strings.TrimSpace(values["description"])
-	if description == "" {
-		return errors.New("line description is required")
-	}
-	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
+	productID := optionalStringPtr(firstNonEmpty(values["product_id"], values["product"]))
+	description, unitPrice, err := parseSalesLineDescriptionAndPrice(values, productID != nil)
 	if err != nil {
 		return err
 	}
-	unitPrice, err := parseRequiredNonNegativeDecimal("line unit_price", firstNonEmpty(values["unit_price"], values["price"]))
+	quantity, err := parseRequiredPositiveDecimal("line quantity", firstNonEmpty(values["quantity"], values["qty"]))
 	if err != nil {
 		return err
 	}
@@ -13690,7 +17896,8 @@ func (l *recurringLineFlags) Set(value string) error {
 		DiscountPercent: discountPercent,
 		VATRate:         vatRate,
 		AccountID:       optionalStringPtr(firstNonEmpty(values["account_id"], values["account"])),
-		ProductID:       optionalStringPtr(firstNonEmpty(values["product_id"], values["product"])),
+		ProductID:       productID,
+		UsageMetric:     firstNonEmpty(values["usage_metric"], values["metric"]),
 	})
 	return nil
 }
@@ -13872,6 +18079,108 @@ func (l *sepaLineFlags) String() string {
 	return strings.Join(values, ",")
 }
 
+type landedCostTargetFlags []purchasing.LandedCostTargetRequest
+
+func (l *landedCostTargetFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse line: %w", err)
+	}
+
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("line field %q must be key=value", field)
+		}
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
+	}
+
+	target := purchasing.LandedCostTargetRequest{
+		GoodsReceiptLineID: firstNonEmpty(values["goods_receipt_line_id"], values["receipt_line_id"]),
+		ProductID:          values["product_id"],
+		LotNumber:          firstNonEmpty(values["lot_number"], values["lot"]),
+	}
+	if target.GoodsReceiptLineID == "" && (target.ProductID == "" || target.LotNumber == "") {
+		return errors.New("line receipt_line_id or product_id with lot is required")
+	}
+	target.Weight, err = parseOptionalNonNegativeDecimalPtr("line weight", values["weight"])
+	if err != nil {
+		return err
+	}
+
+	*l = append(*l, target)
+	return nil
+}
+
+func (l *landedCostTargetFlags) String() string {
+	if l == nil {
+		return ""
+	}
+	targets := make([]string, 0, len(*l))
+	for _, target := range *l {
+		targets = append(targets, firstNonEmpty(target.GoodsReceiptLineID, target.ProductID+":"+target.LotNumber))
+	}
+	return strings.Join(targets, ",")
+}
+
+type priceListItemFlags []pricing.PriceListItemRequest
+
+func (l *priceListItemFlags) Set(value string) error {
+	reader := csv.NewReader(strings.NewReader(value))
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+	fields, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("parse item: %w", err)
+	}
+
+	values := make(map[string]string)
+	for _, field := range fields {
+		key, val, ok := strings.Cut(field, "=")
+		if !ok {
+			return fmt.Errorf("item field %q must be key=value", field)
+		}
+		normalizedKey := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(key)), "-", "_")
+		values[normalizedKey] = strings.TrimSpace(val)
+	}
+
+	productID := firstNonEmpty(values["product_id"], values["product"])
+	if productID == "" {
+		return errors.New("item product_id is required")
+	}
+	minQuantity, err := parseOptionalNonNegativeDecimalPtr("item min_quantity", firstNonEmpty(values["min_quantity"], values["min_qty"]))
+	if err != nil {
+		return err
+	}
+	unitPrice, err := parseRequiredNonNegativeDecimal("item unit_price", firstNonEmpty(values["unit_price"], values["price"]))
+	if err != nil {
+		return err
+	}
+
+	*l = append(*l, pricing.PriceListItemRequest{
+		ProductID:   productID,
+		MinQuantity: decimalValueOrZero(minQuantity),
+		UnitPrice:   unitPrice,
+	})
+	return nil
+}
+
+func (l *priceListItemFlags) String() string {
+	if l == nil {
+		return ""
+	}
+	items := make([]string, 0, len(*l))
+	for _, item := range *l {
+		items = append(items, item.ProductID+"@"+item.MinQuantity.String())
+	}
+	return strings.Join(items, ",")
+}
+
 type stringListFlags []string
 
 func (f *stringListFlags) Set(value string) error {
@@ -15,6 +15,7 @@ import (
 	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/analytics"
 	"github.com/HMB-research/open-accounting/internal/apitoken"
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/auth"
 	"github.com/HMB-research/open-accounting/internal/banking"
@@ -29,9 +30,12 @@ import (
 	"github.com/HMB-research/open-accounting/internal/payments"
 	"github.com/HMB-research/open-accounting/internal/payroll"
 	"github.com/HMB-research/open-accounting/internal/plugin"
+	"github.com/HMB-research/open-accounting/internal/pricing"
+	"github.com/HMB-research/open-accounting/internal/purchasing"
 	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 	"github.com/HMB-research/open-accounting/internal/reports"
+	"github.com/HMB-research/open-accounting/internal/stocktake"
 	"github.com/HMB-research/open-accounting/internal/tax"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/HMB-research/open-accounting/internal/webhooks"
@@ -789,6 +793,13 @@ func formatRuntimePluginName(status *plugin.PluginRuntimeStatus) string {
 	return "-"
 }
 
+func defaultString(value, fallback string) string {
+	if strings.TrimSpace(value) == "" {
+		return fallback
+	}
+	return value
+}
+
 func emptyDash(value string) string {
 	if strings.TrimSpace(value) == "" {
 		return "-"
@@ -1067,6 +1078,66 @@ func printQuoteLinesTable(w io.Writer, lines []quotes.QuoteLine) {
 	_ = tw.Flush()
 }
 
+func printQuoteInvoices(w io.Writer, billings []quotes.QuoteInvoice) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "INVOICE\tKIND\tDATE\tSUBTOTAL\tDEDUCTED\tNETTED")
+	for _, billing := range billings {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			billing.InvoiceNumber,
+			billing.Kind,
+			formatDate(billing.CreatedAt),
+			billing.Subtotal.StringFixed(2),
+			billing.PrepaymentDeducted.StringFixed(2),
+			billing.NettedAmount.StringFixed(2),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printQuoteRevisions(w io.Writer, revisions []quotes.QuoteRevision) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "REVISION\tSENT\tVALID UNTIL\tTOTAL")
+	for _, revision := range revisions {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s %s\n",
+			revision.Revision,
+			formatDate(revision.SentAt),
+			formatDatePtr(revision.ValidUntil),
+			revision.Total.StringFixed(2),
+			revision.Currency,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPublicQuote(w io.Writer, quote *quotes.PublicQuote) {
+	_, _ = fmt.Fprintf(w, "Quote %s revision %d (%s)\n", quote.QuoteNumber, quote.Revision, quote.Status)
+	if strings.TrimSpace(quote.CompanyName) != "" {
+		_, _ = fmt.Fprintf(w, "From: %s\n", quote.CompanyName)
+	}
+	if strings.TrimSpace(quote.CustomerName) != "" {
+		_, _ = fmt.Fprintf(w, "To: %s\n", quote.CustomerName)
+	}
+	_, _ = fmt.Fprintf(w, "Quote date: %s\n", formatDate(quote.QuoteDate))
+	_, _ = fmt.Fprintf(w, "Valid until: %s\n", formatDatePtr(quote.ValidUntil))
+	_, _ = fmt.Fprintf(w, "Subtotal: %s %s\n", quote.Subtotal.String(), quote.Currency)
+	_, _ = fmt.Fprintf(w, "VAT: %s\n", quote.VATAmount.String())
+	_, _ = fmt.Fprintf(w, "Total: %s\n", quote.Total.String())
+	_, _ = fmt.Fprintf(w, "Link expires: %s\n", quote.LinkExpiresAt.Format(time.RFC3339))
+	if len(quote.Lines) == 0 {
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NO\tDESCRIPTION\tQTY\tUNIT\tUNIT PRICE\tVAT\tTOTAL")
+	for _, line := range quote.Lines {
+		_, _ = fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n", line.LineNumber, line.Description, line.Quantity.String(), line.Unit, line.UnitPrice.String(), line.VATRate.String(), line.LineTotal.String())
+	}
+	_ = tw.Flush()
+}
+
 func printOrdersTable(w io.Writer, ordersList []orders.Order) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 	_, _ = fmt.Fprintln(tw, "ID\tNUMBER\tSTATUS\tDATE\tEXPECTED\tTOTAL\tCONTACT")
@@ -1260,6 +1331,253 @@ func printOrderStockReservations(w io.Writer, reservations []orders.OrderStockRe
 	_ = tw.Flush()
 }
 
+func printOrderShipments(w io.Writer, shipments []orders.OrderShipment) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNUMBER\tDATE\tWAREHOUSE\tLINES\tCOST")
+	for _, shipment := range shipments {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%d\t%s\n",
+			shipment.ID,
+			shipment.ShipmentNumber,
+			formatDate(shipment.ShipmentDate),
+			shipment.WarehouseID,
+			len(shipment.Lines),
+			shipment.TotalCost.String(),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printOrderInvoices(w io.Writer, billings []orders.OrderInvoice) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "INVOICE\tKIND\tDATE\tSUBTOTAL\tDEDUCTED\tNETTED")
+	for _, billing := range billings {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			billing.InvoiceNumber,
+			billing.Kind,
+			formatDate(billing.CreatedAt),
+			billing.Subtotal.StringFixed(2),
+			billing.PrepaymentDeducted.StringFixed(2),
+			billing.NettedAmount.StringFixed(2),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPurchaseOrdersTable(w io.Writer, orderList []purchasing.PurchaseOrder) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNUMBER\tSTATUS\tDATE\tEXPECTED\tTOTAL\tSUPPLIER")
+	for _, po := range orderList {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s %s\t%s\n",
+			po.ID,
+			po.PONumber,
+			po.Status,
+			formatDate(po.OrderDate),
+			formatDatePtr(po.ExpectedDate),
+			po.Total.String(),
+			po.Currency,
+			po.ContactID,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPurchaseOrder(w io.Writer, po *purchasing.PurchaseOrder) {
+	_, _ = fmt.Fprintf(w, "Purchase order %s (%s)\n", po.PONumber, po.Status)
+	_, _ = fmt.Fprintf(w, "ID: %s\n", po.ID)
+	_, _ = fmt.Fprintf(w, "Supplier: %s\n", po.ContactID)
+	_, _ = fmt.Fprintf(w, "Warehouse: %s\n", po.WarehouseID)
+	_, _ = fmt.Fprintf(w, "Order date: %s\n", formatDate(po.OrderDate))
+	_, _ = fmt.Fprintf(w, "Expected delivery: %s\n", formatDatePtr(po.ExpectedDate))
+	_, _ = fmt.Fprintf(w, "Subtotal: %s %s\n", po.Subtotal.String(), po.Currency)
+	_, _ = fmt.Fprintf(w, "VAT: %s\n", po.VATAmount.String())
+	_, _ = fmt.Fprintf(w, "Total: %s\n", po.Total.String())
+	if strings.TrimSpace(po.Notes) != "" {
+		_, _ = fmt.Fprintf(w, "Notes: %s\n", po.Notes)
+	}
+	if len(po.Lines) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NO\tID\tDESCRIPTION\tQTY\tRECEIVED\tINVOICED\tUNIT PRICE\tTOTAL")
+	for _, line := range po.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.LineNumber,
+			line.ID,
+			line.Description,
+			line.Quantity.String(),
+			line.ReceivedQuantity.String(),
+			line.InvoicedQuantity.String(),
+			line.UnitPrice.String(),
+			line.LineTotal.String(),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPurchaseOrderMatching(w io.Writer, report *purchasing.PurchaseOrderMatching) {
+	if report == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Purchase order matching %s (%s)\n", report.PONumber, report.Status)
+	_, _ = fmt.Fprintf(w, "Ordered: %s %s\n", report.OrderedAmount.String(), report.Currency)
+	_, _ = fmt.Fprintf(w, "Received: %s\n", report.ReceivedAmount.String())
+	_, _ = fmt.Fprintf(w, "Invoiced: %s\n", report.InvoicedAmount.String())
+	_, _ = fmt.Fprintf(w, "Received not invoiced: %s\n", report.AccruedNotInvoiced.String())
+	_, _ = fmt.Fprintf(w, "Price variance: %s\n\n", report.PriceVariance.String())
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "LINE\tDESCRIPTION\tORDERED\tRECEIVED\tINVOICED\tACCRUED\tVARIANCE\tSTATUS")
+	for _, line := range report.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.LineNumber,
+			line.Description,
+			line.OrderedQuantity.String(),
+			line.ReceivedQuantity.String(),
+			line.InvoicedQuantity.String(),
+			line.AccruedNotInvoiced.String(),
+			line.PriceVariance.String(),
+			line.Status,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printLandedCostsTable(w io.Writer, landedCosts []purchasing.LandedCost) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNUMBER\tDATE\tINVOICE\tMETHOD\tAMOUNT\tINVENTORY\tCOGS")
+	for _, landedCost := range landedCosts {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			landedCost.ID,
+			landedCost.LandedCostNumber,
+			formatDate(landedCost.CostDate),
+			landedCost.InvoiceID,
+			landedCost.AllocationMethod,
+			landedCost.Amount.String(),
+			landedCost.InventoryAmount.String(),
+			landedCost.COGSAmount.String(),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printLandedCost(w io.Writer, landedCost *purchasing.LandedCost) {
+	_, _ = fmt.Fprintf(w, "Landed cost %s (%s)\n", landedCost.LandedCostNumber, landedCost.AllocationMethod)
+	_, _ = fmt.Fprintf(w, "ID: %s\n", landedCost.ID)
+	_, _ = fmt.Fprintf(w, "Invoice: %s\n", landedCost.InvoiceID)
+	_, _ = fmt.Fprintf(w, "Cost date: %s\n", formatDate(landedCost.CostDate))
+	_, _ = fmt.Fprintf(w, "Amount: %s\n", landedCost.Amount.String())
+	_, _ = fmt.Fprintf(w, "Inventory: %s\n", landedCost.InventoryAmount.String())
+	_, _ = fmt.Fprintf(w, "Cost of goods sold: %s\n", landedCost.COGSAmount.String())
+	if landedCost.JournalEntryID != nil {
+		_, _ = fmt.Fprintf(w, "Journal entry: %s\n", *landedCost.JournalEntryID)
+	}
+	if len(landedCost.Lines) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "RECEIPT LINE\tPRODUCT\tLOT\tQTY\tON HAND\tISSUED\tAMOUNT\tUNIT COST")
+	for _, line := range landedCost.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s -> %s\n",
+			line.GoodsReceiptLineID,
+			line.ProductID,
+			line.LotNumber,
+			line.Quantity.String(),
+			line.OnHandQuantity.String(),
+			line.IssuedQuantity.String(),
+			line.Amount.String(),
+			line.PreviousUnitCost.String(),
+			line.NewUnitCost.String(),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPriceListsTable(w io.Writer, priceLists []pricing.PriceList) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tCODE\tNAME\tCURRENCY\tVALID FROM\tVALID TO\tDEFAULT\tACTIVE")
+	for _, priceList := range priceLists {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%t\t%t\n",
+			priceList.ID,
+			priceList.Code,
+			priceList.Name,
+			priceList.Currency,
+			formatDatePtr(priceList.ValidFrom),
+			formatDatePtr(priceList.ValidTo),
+			priceList.IsDefault,
+			priceList.IsActive,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPriceList(w io.Writer, priceList *pricing.PriceList) {
+	_, _ = fmt.Fprintf(w, "Price list %s (%s)\n", priceList.Code, priceList.Name)
+	_, _ = fmt.Fprintf(w, "ID: %s\n", priceList.ID)
+	_, _ = fmt.Fprintf(w, "Currency: %s\n", priceList.Currency)
+	_, _ = fmt.Fprintf(w, "Valid: %s - %s\n", formatDatePtr(priceList.ValidFrom), formatDatePtr(priceList.ValidTo))
+	_, _ = fmt.Fprintf(w, "Default: %t\n", priceList.IsDefault)
+	_, _ = fmt.Fprintf(w, "Active: %t\n", priceList.IsActive)
+	if len(priceList.Items) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PRODUCT\tMIN QTY\tUNIT PRICE")
+	for _, item := range priceList.Items {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\n", item.ProductID, item.MinQuantity.String(), item.UnitPrice.String())
+	}
+	_ = tw.Flush()
+}
+
+func printCustomerGroupsTable(w io.Writer, groups []pricing.CustomerGroup) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tCODE\tNAME\tPRICE LIST\tDISCOUNT %")
+	for _, group := range groups {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", group.ID, group.Code, group.Name, stringValue(group.PriceListID), group.DiscountPercent.String())
+	}
+	_ = tw.Flush()
+}
+
+func printCustomerPricing(w io.Writer, terms *pricing.CustomerPricing) {
+	_, _ = fmt.Fprintf(w, "Contact: %s\n", terms.ContactID)
+	_, _ = fmt.Fprintf(w, "Customer group: %s\n", stringValue(terms.CustomerGroupID))
+	_, _ = fmt.Fprintf(w, "Price list: %s\n", stringValue(terms.PriceListID))
+	_, _ = fmt.Fprintf(w, "Discount percent: %s\n", terms.DiscountPercent.String())
+}
+
+func printResolvedPrice(w io.Writer, resolved *pricing.ResolvedPrice) {
+	_, _ = fmt.Fprintf(w, "Product: %s (%s)\n", resolved.ProductName, resolved.ProductID)
+	_, _ = fmt.Fprintf(w, "Quantity: %s\n", resolved.Quantity.String())
+	_, _ = fmt.Fprintf(w, "Unit price: %s %s\n", resolved.UnitPrice.String(), resolved.Currency)
+	if resolved.PriceListCode != "" {
+		_, _ = fmt.Fprintf(w, "Source: %s %s (from quantity %s)\n", resolved.Source, resolved.PriceListCode, resolved.MinQuantity.String())
+	} else {
+		_, _ = fmt.Fprintf(w, "Source: %s\n", resolved.Source)
+	}
+	if resolved.DiscountSource != "" {
+		_, _ = fmt.Fprintf(w, "Discount: %s%% (%s)\n", resolved.DiscountPercent.String(), resolved.DiscountSource)
+	}
+}
+
 func printRecurringInvoicesTable(w io.Writer, invoices []recurring.RecurringInvoice) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 	_, _ = fmt.Fprintln(tw, "ID\tNAME\tCONTACT\tFREQUENCY\tNEXT\tACTIVE\tGENERATED")
@@ -1288,6 +1606,12 @@ func printRecurringInvoice(w io.Writer, invoice *recurring.RecurringInvoice) {
 	_, _ = fmt.Fprintf(w, "Start date: %s\n", formatDate(invoice.StartDate))
 	_, _ = fmt.Fprintf(w, "End date: %s\n", formatDatePtr(invoice.EndDate))
 	_, _ = fmt.Fprintf(w, "Next generation: %s\n", formatDate(invoice.NextGenerationDate))
+	if invoice.BillingAnchorDate != nil {
+		_, _ = fmt.Fprintf(w, "Billing anchor: %s\n", formatDatePtr(invoice.BillingAnchorDate))
+	}
+	if invoice.LastPeriodStart != nil {
+		_, _ = fmt.Fprintf(w, "Last period start: %s\n", formatDatePtr(invoice.LastPeriodStart))
+	}
 	_, _ = fmt.Fprintf(w, "Payment terms: %d days\n", invoice.PaymentTermsDays)
 	_, _ = fmt.Fprintf(w, "Active: %t\n", invoice.IsActive)
 	_, _ = fmt.Fprintf(w, "Generated count: %d\n", invoice.GeneratedCount)
@@ -1308,21 +1632,79 @@ func printRecurringInvoice(w io.Writer, invoice *recurring.RecurringInvoice) {
 	if len(invoice.Lines) > 0 {
 		printRecurringInvoiceLinesTable(w, invoice.Lines)
 	}
+	if len(invoice.PriceChanges) > 0 {
+		printRecurringPriceChangesTable(w, invoice.PriceChanges)
+	}
 }
 
 func printRecurringInvoiceLinesTable(w io.Writer, lines []recurring.RecurringInvoiceLine) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "NO\tDESCRIPTION\tQTY\tUNIT\tUNIT PRICE\tVAT")
+	_, _ = fmt.Fprintln(tw, "NO\tDESCRIPTION\tQTY\tUNIT\tUNIT PRICE\tVAT\tUSAGE METRIC")
 	for _, line := range lines {
 		_, _ = fmt.Fprintf(
 			tw,
-			"%d\t%s\t%s\t%s\t%s\t%s\n",
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
 			line.LineNumber,
 			line.Description,
 			line.Quantity.String(),
 			line.Unit,
 			line.UnitPrice.String(),
 			line.VATRate.String(),
+			line.UsageMetric,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printRecurringPriceChangesTable(w io.Writer, changes []recurring.PriceChange) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tEFFECTIVE\tLINE\tCHANGE\tAPPLIED\tNOTE")
+	for _, change := range changes {
+		line := "all"
+		if change.LineNumber != nil {
+			line = strconv.Itoa(*change.LineNumber)
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			change.ID,
+			formatDate(change.EffectiveDate),
+			line,
+			recurringPriceChangeLabel(change),
+			formatDatePtr(change.AppliedAt),
+			change.Note,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func recurringPriceChangeLabel(change recurring.PriceChange) string {
+	if change.UnitPrice != nil {
+		return "price " + change.UnitPrice.String()
+	}
+	if change.UpliftPercent != nil {
+		return "uplift " + change.UpliftPercent.String() + "%"
+	}
+	return ""
+}
+
+func printRecurringUsageTable(w io.Writer, records []recurring.UsageRecord) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tDATE\tMETRIC\tQUANTITY\tREFERENCE\tINVOICE")
+	for _, record := range records {
+		invoiceID := ""
+		if record.InvoiceID != nil {
+			invoiceID = *record.InvoiceID
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			record.ID,
+			formatDate(record.UsageDate),
+			record.Metric,
+			record.Quantity.String(),
+			record.Reference,
+			invoiceID,
 		)
 	}
 	_ = tw.Flush()
@@ -1553,6 +1935,168 @@ func printDepreciationEntriesTable(w io.Writer, entries []assets.DepreciationEnt
 	_ = tw.Flush()
 }
 
+func printDepreciationSchedule(w io.Writer, schedule *assets.DepreciationSchedule) {
+	_, _ = fmt.Fprintf(w, "%s %s (%s, %s)\n", schedule.AssetNumber, schedule.AssetName, schedule.DepreciationMethod, schedule.Status)
+	_, _ = fmt.Fprintf(w, "End of life: %s\n", formatDate(schedule.EndOfLifeDate))
+	_, _ = fmt.Fprintf(w, "Book value: %s\n", schedule.BookValue.String())
+	_, _ = fmt.Fprintf(w, "Remaining depreciation: %s\n", schedule.RemainingDepreciation.String())
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PERIOD\tUNITS\tAMOUNT\tACCUMULATED\tBOOK VALUE")
+	for _, line := range schedule.Lines {
+		units := ""
+		if line.Units != nil {
+			units = line.Units.String()
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s..%s\t%s\t%s\t%s\t%s\n",
+			formatDate(line.PeriodStart),
+			formatDate(line.PeriodEnd),
+			units,
+			line.DepreciationAmount.String(),
+			line.AccumulatedTotal.String(),
+			line.BookValueAfter.String(),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printFixedAssetRegister(w io.Writer, report *assets.AssetRegisterReport) {
+	_, _ = fmt.Fprintf(w, "Fixed asset register from %s to %s\n", formatDate(report.StartDate), formatDate(report.EndDate))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CATEGORY\tASSETS\tOPENING COST\tADDITIONS\tDISPOSALS\tCLOSING COST\tDEPRECIATION\tIMPAIRMENTS\tOPENING NBV\tCLOSING NBV")
+	for _, category := range report.Categories {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			category.CategoryName,
+			category.AssetCount,
+			category.OpeningCost.String(),
+			category.Additions.String(),
+			category.Disposals.String(),
+			category.ClosingCost.String(),
+			category.DepreciationCharge.String(),
+			category.Impairments.String(),
+			category.OpeningNetBookValue.String(),
+			category.ClosingNetBookValue.String(),
+		)
+	}
+	_, _ = fmt.Fprintf(
+		tw,
+		"TOTAL\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		len(report.Assets),
+		report.Totals.OpeningCost.String(),
+		report.Totals.Additions.String(),
+		report.Totals.Disposals.String(),
+		report.Totals.ClosingCost.String(),
+		report.Totals.DepreciationCharge.String(),
+		report.Totals.Impairments.String(),
+		report.Totals.OpeningNetBookValue.String(),
+		report.Totals.ClosingNetBookValue.String(),
+	)
+	_ = tw.Flush()
+}
+
+func printAssetEventsTable(w io.Writer, events []assets.AssetEvent) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "DATE\tTYPE\tAMOUNT\tBOOK VALUE\tLIFE MONTHS\tRESIDUAL\tMONTHLY DEPRECIATION\tDESCRIPTION")
+	for _, event := range events {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s -> %s\t%d -> %d\t%s -> %s\t%s -> %s\t%s\n",
+			formatDate(event.EventDate),
+			event.EventType,
+			event.Amount.String(),
+			event.BookValueBefore.String(),
+			event.BookValueAfter.String(),
+			event.UsefulLifeBefore,
+			event.UsefulLifeAfter,
+			event.ResidualValueBefore.String(),
+			event.ResidualValueAfter.String(),
+			event.MonthlyDepreciationBefore.String(),
+			event.MonthlyDepreciationAfter.String(),
+			event.Description,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printAssetHistory(w io.Writer, history *assets.AssetHistory) {
+	_, _ = fmt.Fprintf(w, "%s %s (%s)\n", history.AssetNumber, history.AssetName, history.Status)
+	_, _ = fmt.Fprintf(w, "Book value: %s\n", history.BookValue.String())
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "DATE\tTYPE\tCOST\tACCUMULATED\tNBV CHANGE\tBOOK VALUE\tDESCRIPTION")
+	for _, line := range history.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			formatDate(line.Date),
+			line.Type,
+			line.CostAfter.String(),
+			line.AccumulatedAfter.String(),
+			line.BookValueChange.String(),
+			line.BookValueAfter.String(),
+			line.Description,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printDepreciationRunPreview(w io.Writer, preview *assets.DepreciationRunPreview) {
+	_, _ = fmt.Fprintf(w, "Period: %s..%s\n", formatDate(preview.PeriodStart), formatDate(preview.PeriodEnd))
+	if preview.PostedRun != nil {
+		_, _ = fmt.Fprintf(w, "Posted run: %s\n", preview.PostedRun.ID)
+	}
+	_, _ = fmt.Fprintf(w, "Assets: %d\n", preview.AssetCount)
+	_, _ = fmt.Fprintf(w, "Total: %s\n", preview.TotalAmount.String())
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CATEGORY\tASSETS\tAMOUNT")
+	for _, total := range preview.CategoryTotals {
+		_, _ = fmt.Fprintf(tw, "%s\t%d\t%s\n", total.CategoryName, total.AssetCount, total.Amount.String())
+	}
+	_ = tw.Flush()
+	for _, skipped := range preview.Skipped {
+		_, _ = fmt.Fprintf(w, "Skipped %s: %s\n", skipped.AssetNumber, skipped.Reason)
+	}
+	for _, issue := range preview.Issues {
+		_, _ = fmt.Fprintf(w, "Issue: %s\n", issue)
+	}
+}
+
+func printDepreciationRunsTable(w io.Writer, runs []assets.DepreciationRun) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tPERIOD\tMODE\tSTATUS\tASSETS\tTOTAL")
+	for _, run := range runs {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s..%s\t%s\t%s\t%d\t%s\n",
+			run.ID,
+			formatDate(run.PeriodStart),
+			formatDate(run.PeriodEnd),
+			run.PostingMode,
+			run.Status,
+			run.AssetCount,
+			run.TotalAmount.String(),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printDepreciationRun(w io.Writer, run *assets.DepreciationRun) {
+	_, _ = fmt.Fprintf(w, "ID: %s\n", run.ID)
+	_, _ = fmt.Fprintf(w, "Period: %s..%s\n", formatDate(run.PeriodStart), formatDate(run.PeriodEnd))
+	_, _ = fmt.Fprintf(w, "Posting mode: %s\n", run.PostingMode)
+	_, _ = fmt.Fprintf(w, "Status: %s\n", run.Status)
+	_, _ = fmt.Fprintf(w, "Assets: %d\n", run.AssetCount)
+	_, _ = fmt.Fprintf(w, "Total: %s\n", run.TotalAmount.String())
+	if len(run.JournalEntryIDs) > 0 {
+		_, _ = fmt.Fprintf(w, "Journal entries: %s\n", strings.Join(run.JournalEntryIDs, ", "))
+	}
+	if len(run.Entries) > 0 {
+		printDepreciationEntriesTable(w, run.Entries)
+	}
+}
+
 func printProductCategoriesTable(w io.Writer, categories []inventory.ProductCategory) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 	_, _ = fmt.Fprintln(tw, "ID\tNAME\tPARENT\tDESCRIPTION")
@@ -1834,125 +2378,515 @@ func printInventorySubledgerReconciliation(w io.Writer, report *inventory.Invent
 	_ = tw.Flush()
 }
 
-func inventorySubledgerAccountLabel(line inventory.InventorySubledgerReconciliationAccountLine) string {
-	if strings.TrimSpace(line.AccountCode) != "" && strings.TrimSpace(line.AccountName) != "" {
-		return line.AccountCode + " " + line.AccountName
+func inventorySubledgerAccountLabel(line inventory.InventorySubledgerReconciliationAccountLine) string {
+	if strings.TrimSpace(line.AccountCode) != "" && strings.TrimSpace(line.AccountName) != "" {
+		return line.AccountCode + " " + line.AccountName
+	}
+	if strings.TrimSpace(line.AccountCode) != "" {
+		return line.AccountCode
+	}
+	if strings.TrimSpace(line.AccountName) != "" {
+		return line.AccountName
+	}
+	return line.AccountID
+}
+
+func inventorySubledgerProductLabel(line inventory.InventorySubledgerReconciliationLine) string {
+	if strings.TrimSpace(line.ProductCode) != "" && strings.TrimSpace(line.ProductName) != "" {
+		return line.ProductCode + " " + line.ProductName
+	}
+	if strings.TrimSpace(line.ProductCode) != "" {
+		return line.ProductCode
+	}
+	if strings.TrimSpace(line.ProductName) != "" {
+		return line.ProductName
+	}
+	return line.ProductID
+}
+
+func inventorySubledgerWarehouseLabel(line inventory.InventorySubledgerReconciliationLine) string {
+	if strings.TrimSpace(line.WarehouseCode) != "" && strings.TrimSpace(line.WarehouseName) != "" {
+		return line.WarehouseCode + " " + line.WarehouseName
+	}
+	if strings.TrimSpace(line.WarehouseCode) != "" {
+		return line.WarehouseCode
+	}
+	if strings.TrimSpace(line.WarehouseName) != "" {
+		return line.WarehouseName
+	}
+	if strings.TrimSpace(line.WarehouseID) != "" {
+		return line.WarehouseID
+	}
+	return "All warehouses"
+}
+
+func inventorySubledgerLineAccountLabel(line inventory.InventorySubledgerReconciliationLine) string {
+	if strings.TrimSpace(line.AccountCode) != "" && strings.TrimSpace(line.AccountName) != "" {
+		return line.AccountCode + " " + line.AccountName
+	}
+	if strings.TrimSpace(line.InventoryAccountID) != "" {
+		return line.InventoryAccountID
+	}
+	return "-"
+}
+
+func printInventoryLotReport(w io.Writer, report *inventory.InventoryLotReport) {
+	if report == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "Inventory lots")
+	if strings.TrimSpace(report.ProductID) != "" {
+		_, _ = fmt.Fprintf(w, "Product: %s\n", report.ProductID)
+	}
+	if strings.TrimSpace(report.WarehouseID) != "" {
+		_, _ = fmt.Fprintf(w, "Warehouse: %s\n", report.WarehouseID)
+	}
+	if report.IncludeEmpty {
+		_, _ = fmt.Fprintln(w, "Including empty positions")
+	}
+	_, _ = fmt.Fprintf(w, "Generated: %s\n\n", formatTime(report.GeneratedAt))
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PRODUCT\tWAREHOUSE\tLOT\tSERIAL\tEXPIRY\tQUANTITY\tUNIT COST\tVALUE\tLAST MOVEMENT")
+	for _, line := range report.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			inventoryLotProductLabel(line),
+			inventoryLotWarehouseLabel(line),
+			formatOptionalString(line.LotNumber),
+			formatOptionalString(line.SerialNumber),
+			formatOptionalString(line.ExpiryDate),
+			line.Quantity.String(),
+			line.UnitCost.String(),
+			line.InventoryValue.String(),
+			formatTime(line.LastMovementDate),
+		)
+	}
+	_, _ = fmt.Fprintf(
+		tw,
+		"TOTAL\t\t\t\t\t%s\t\t%s\t\n",
+		report.TotalQuantity.String(),
+		report.TotalValue.String(),
+	)
+	_ = tw.Flush()
+}
+
+func inventoryLotProductLabel(line inventory.InventoryLotLine) string {
+	if strings.TrimSpace(line.ProductCode) != "" && strings.TrimSpace(line.ProductName) != "" {
+		return line.ProductCode + " " + line.ProductName
+	}
+	if strings.TrimSpace(line.ProductCode) != "" {
+		return line.ProductCode
+	}
+	if strings.TrimSpace(line.ProductName) != "" {
+		return line.ProductName
+	}
+	return line.ProductID
+}
+
+func inventoryLotWarehouseLabel(line inventory.InventoryLotLine) string {
+	if strings.TrimSpace(line.WarehouseCode) != "" && strings.TrimSpace(line.WarehouseName) != "" {
+		return line.WarehouseCode + " " + line.WarehouseName
+	}
+	if strings.TrimSpace(line.WarehouseCode) != "" {
+		return line.WarehouseCode
+	}
+	if strings.TrimSpace(line.WarehouseName) != "" {
+		return line.WarehouseName
+	}
+	return line.WarehouseID
+}
+
+func printInventoryAgingReport(w io.Writer, report *inventory.InventoryAgingReport) {
+	if report == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Inventory aging as of %s\n", formatDate(report.AsOfDate))
+	_, _ = fmt.Fprintf(w, "Slow-moving after %d days, expiring within %d days\n", report.SlowMovingDays, report.ExpiringWithinDays)
+	_, _ = fmt.Fprintf(w, "Selling costs: %s%%, slow-moving discount: %s%%\n\n", report.SellingCostPercent.String(), report.SlowMovingDiscountPercent.String())
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "WAREHOUSE\tCATEGORY\tVALUE\t0-30\t31-90\t91-180\t181-365\tOVER 365\tEXPIRED\tSLOW-MOVING\tWRITE-DOWN")
+	for _, group := range report.Groups {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", firstNonEmpty(group.WarehouseCode, group.WarehouseID), group.CategoryName, group.InventoryValue.String(), inventoryAgingBucketColumns(group.AgeBuckets), strings.Join([]string{group.ExpiredValue.String(), group.SlowMovingValue.String(), group.WriteDownAmount.String()}, "\t"))
+	}
+	_, _ = fmt.Fprintf(tw, "TOTAL\t\t%s\t%s\t%s\n", report.TotalValue.String(), inventoryAgingBucketColumns(report.AgeBuckets), strings.Join([]string{report.ExpiredValue.String(), report.SlowMovingValue.String(), report.WriteDownAmount.String()}, "\t"))
+	_ = tw.Flush()
+
+	if len(report.Lines) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w)
+	tw = tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PRODUCT\tWAREHOUSE\tLOT\tEXPIRY\tAGE\tDAYS TO EXPIRY\tQUANTITY\tVALUE\tNRV\tWRITE-DOWN\tSTATUS")
+	for _, line := range report.Lines {
+		daysToExpiry := "-"
+		if line.DaysToExpiry != nil {
+			daysToExpiry = strconv.Itoa(*line.DaysToExpiry)
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s %s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.ProductCode,
+			line.ProductName,
+			firstNonEmpty(line.WarehouseCode, line.WarehouseID),
+			formatOptionalString(firstNonEmpty(line.LotNumber, line.SerialNumber)),
+			formatOptionalString(line.ExpiryDate),
+			line.AgeDays,
+			daysToExpiry,
+			line.Quantity.String(),
+			line.InventoryValue.String(),
+			line.NetRealisableValue.String(),
+			line.WriteDownAmount.String(),
+			line.Status,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func inventoryAgingBucketColumns(buckets []inventory.InventoryAgingBucket) string {
+	values := make([]string, 0, len(buckets))
+	for _, bucket := range buckets {
+		values = append(values, bucket.Value.String())
+	}
+	return strings.Join(values, "\t")
+}
+
+func printInventoryWriteDownProposal(w io.Writer, proposal *inventory.InventoryWriteDownProposal) {
+	if proposal == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Inventory write-down as of %s\n", formatDate(proposal.AsOfDate))
+	_, _ = fmt.Fprintf(w, "Required allowance: %s\n", proposal.RequiredAllowance.String())
+	_, _ = fmt.Fprintf(w, "Existing allowance: %s\n", proposal.ExistingAllowance.String())
+	_, _ = fmt.Fprintf(w, "Adjustment: %s\n", proposal.Adjustment.String())
+	if proposal.JournalID == "" {
+		_, _ = fmt.Fprintln(w, "No adjustment needed")
+		return
+	}
+	_, _ = fmt.Fprintf(w, "Journal entry: %s (%s), post it to book the write-down\n", firstNonEmpty(proposal.JournalNo, proposal.JournalID), proposal.JournalStatus)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ROLE\tACCOUNT\tDEBIT\tCREDIT")
+	for _, line := range proposal.Lines {
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", line.Role, line.AccountID, line.DebitAmount.String(), line.CreditAmount.String())
+	}
+	_ = tw.Flush()
+}
+
+func printReplenishmentReport(w io.Writer, report *purchasing.ReplenishmentReport) {
+	if report == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Replenishment as of %s\n", formatDate(report.AsOfDate))
+	_, _ = fmt.Fprintf(w, "Velocity window: %d days, coverage: %d days\n", report.VelocityDays, report.CoverageDays)
+	if len(report.Proposals) == 0 {
+		_, _ = fmt.Fprintln(w, "No products need reordering")
+		return
+	}
+	for _, proposal := range report.Proposals {
+		supplier := proposal.SupplierID
+		if strings.TrimSpace(supplier) == "" {
+			supplier = "(no supplier)"
+		}
+		warehouse := proposal.WarehouseCode
+		if strings.TrimSpace(warehouse) == "" {
+			warehouse = proposal.WarehouseID
+		}
+		_, _ = fmt.Fprintf(w, "\nSupplier %s -> %s (lead time %d days, estimated %s)\n", supplier, warehouse, proposal.LeadTimeDays, proposal.EstimatedCost.String())
+		printReplenishmentLinesTable(w, proposal.Lines)
+	}
+	_, _ = fmt.Fprintf(w, "\nLines: %d, estimated cost: %s\n", report.LineCount, report.EstimatedCost.String())
+}
+
+func printReplenishmentLinesTable(w io.Writer, lines []purchasing.ReplenishmentLine) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "PRODUCT\tAVAILABLE\tINCOMING\tDAILY USAGE\tREORDER LEVEL\tSUGGESTED\tUNIT PRICE\tCOST\tBELOW MIN")
+	for _, line := range lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s %s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%t\n",
+			line.ProductCode,
+			line.ProductName,
+			line.Available.String(),
+			line.Incoming.String(),
+			line.DailyUsage.String(),
+			line.ReorderLevel.String(),
+			line.SuggestedQuantity.String(),
+			line.UnitPrice.String(),
+			line.EstimatedCost.String(),
+			line.BelowMinimum,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printReplenishmentOrdersResult(w io.Writer, result *purchasing.ReplenishmentOrdersResult) {
+	if result == nil {
+		return
 	}
-	if strings.TrimSpace(line.AccountCode) != "" {
-		return line.AccountCode
+
+	_, _ = fmt.Fprintf(w, "Created %d draft purchase orders\n", len(result.PurchaseOrders))
+	if len(result.PurchaseOrders) > 0 {
+		printPurchaseOrdersTable(w, result.PurchaseOrders)
 	}
-	if strings.TrimSpace(line.AccountName) != "" {
-		return line.AccountName
+	if len(result.UnassignedLines) > 0 {
+		_, _ = fmt.Fprintln(w, "\nProducts without a supplier:")
+		printReplenishmentLinesTable(w, result.UnassignedLines)
 	}
-	return line.AccountID
 }
 
-func inventorySubledgerProductLabel(line inventory.InventorySubledgerReconciliationLine) string {
-	if strings.TrimSpace(line.ProductCode) != "" && strings.TrimSpace(line.ProductName) != "" {
-		return line.ProductCode + " " + line.ProductName
+func printLowStockEvent(w io.Writer, result *inventoryLowStockEventResponse) {
+	if result == nil {
+		return
 	}
-	if strings.TrimSpace(line.ProductCode) != "" {
-		return line.ProductCode
+
+	_, _ = fmt.Fprintf(w, "Products below minimum stock as of %s: %d\n", formatDate(result.AsOfDate), len(result.Lines))
+	if len(result.Lines) > 0 {
+		printReplenishmentLinesTable(w, result.Lines)
 	}
-	if strings.TrimSpace(line.ProductName) != "" {
-		return line.ProductName
+	if result.Event == nil {
+		_, _ = fmt.Fprintln(w, "No event sent")
+		return
 	}
-	return line.ProductID
+	printWebhookDeliveryResult(w, result.Event)
 }
 
-func inventorySubledgerWarehouseLabel(line inventory.InventorySubledgerReconciliationLine) string {
-	if strings.TrimSpace(line.WarehouseCode) != "" && strings.TrimSpace(line.WarehouseName) != "" {
-		return line.WarehouseCode + " " + line.WarehouseName
+func printStockCountsTable(w io.Writer, counts []stocktake.StockCount) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNUMBER\tSTATUS\tDATE\tWAREHOUSE\tMETHOD")
+	for _, count := range counts {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			count.ID,
+			count.CountNumber,
+			count.Status,
+			formatDate(count.CountDate),
+			count.WarehouseID,
+			count.ValuationMethod,
+		)
 	}
-	if strings.TrimSpace(line.WarehouseCode) != "" {
-		return line.WarehouseCode
+	_ = tw.Flush()
+}
+
+func printStockCount(w io.Writer, count *stocktake.StockCount) {
+	_, _ = fmt.Fprintf(w, "Stock count %s (%s)\n", count.CountNumber, count.Status)
+	_, _ = fmt.Fprintf(w, "ID: %s\n", count.ID)
+	_, _ = fmt.Fprintf(w, "Warehouse: %s\n", count.WarehouseID)
+	_, _ = fmt.Fprintf(w, "Count date: %s\n", formatDate(count.CountDate))
+	_, _ = fmt.Fprintf(w, "Valuation method: %s\n", count.ValuationMethod)
+	_, _ = fmt.Fprintf(w, "Uncounted lines: %d of %d\n", count.UncountedLines(), len(count.Lines))
+	if count.JournalEntryID != nil {
+		_, _ = fmt.Fprintf(w, "Variance journal: %s\n", *count.JournalEntryID)
 	}
-	if strings.TrimSpace(line.WarehouseName) != "" {
-		return line.WarehouseName
+	if strings.TrimSpace(count.Notes) != "" {
+		_, _ = fmt.Fprintf(w, "Notes: %s\n", count.Notes)
 	}
-	if strings.TrimSpace(line.WarehouseID) != "" {
-		return line.WarehouseID
+	if len(count.Lines) == 0 {
+		return
 	}
-	return "All warehouses"
-}
 
-func inventorySubledgerLineAccountLabel(line inventory.InventorySubledgerReconciliationLine) string {
-	if strings.TrimSpace(line.AccountCode) != "" && strings.TrimSpace(line.AccountName) != "" {
-		return line.AccountCode + " " + line.AccountName
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NO\tID\tPRODUCT\tBARCODE\tLOT\tSERIAL\tEXPECTED\tCOUNTED\tUNIT COST")
+	for _, line := range count.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.LineNumber,
+			line.ID,
+			line.ProductCode,
+			formatOptionalString(line.Barcode),
+			formatOptionalString(line.LotNumber),
+			formatOptionalString(line.SerialNumber),
+			line.ExpectedQuantity.String(),
+			formatDecimalPtr(line.CountedQuantity),
+			line.UnitCost.String(),
+		)
 	}
-	if strings.TrimSpace(line.InventoryAccountID) != "" {
-		return line.InventoryAccountID
+	_ = tw.Flush()
+}
+
+func printStockCountImportResult(w io.Writer, result *stocktake.ImportCountsResult) {
+	_, _ = fmt.Fprintf(w, "Processed %d rows, imported %d counts, skipped %d rows\n", result.RowsProcessed, result.RowsImported, result.RowsSkipped)
+	for _, rowErr := range result.Errors {
+		_, _ = fmt.Fprintf(w, "Row %d (%s): %s\n", rowErr.Row, formatOptionalString(rowErr.Barcode), rowErr.Message)
 	}
-	return "-"
 }
 
-func printInventoryLotReport(w io.Writer, report *inventory.InventoryLotReport) {
-	if report == nil {
+func printStockCountVariance(w io.Writer, variance *stocktake.StockCountVariance) {
+	if variance == nil {
 		return
 	}
 
-	_, _ = fmt.Fprintln(w, "Inventory lots")
-	if strings.TrimSpace(report.ProductID) != "" {
-		_, _ = fmt.Fprintf(w, "Product: %s\n", report.ProductID)
+	_, _ = fmt.Fprintf(w, "Stock count variance %s (%s)\n", variance.CountNumber, variance.Status)
+	_, _ = fmt.Fprintf(w, "Count date: %s at %s\n", formatDate(variance.CountDate), variance.ValuationMethod)
+	_, _ = fmt.Fprintf(w, "Counted lines: %d, uncounted: %d\n", variance.CountedLines, variance.UncountedLines)
+	_, _ = fmt.Fprintf(w, "Expected value: %s\n", variance.ExpectedValue.String())
+	_, _ = fmt.Fprintf(w, "Counted value: %s\n", variance.CountedValue.String())
+	_, _ = fmt.Fprintf(w, "Surplus: %s\n", variance.SurplusValue.String())
+	_, _ = fmt.Fprintf(w, "Shortage: %s\n", variance.ShortageValue.String())
+	_, _ = fmt.Fprintf(w, "Net variance: %s\n\n", variance.NetVariance.String())
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NO\tPRODUCT\tLOT\tSERIAL\tEXPECTED\tCOUNTED\tVARIANCE\tUNIT COST\tVALUE")
+	for _, line := range variance.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.LineNumber,
+			line.ProductCode,
+			formatOptionalString(line.LotNumber),
+			formatOptionalString(line.SerialNumber),
+			line.ExpectedQuantity.String(),
+			formatDecimalPtr(line.CountedQuantity),
+			line.VarianceQuantity.String(),
+			line.UnitCost.String(),
+			line.VarianceValue.String(),
+		)
 	}
-	if strings.TrimSpace(report.WarehouseID) != "" {
-		_, _ = fmt.Fprintf(w, "Warehouse: %s\n", report.WarehouseID)
+	_ = tw.Flush()
+}
+
+func printBOMsTable(w io.Writer, boms []assembly.BillOfMaterials) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tPRODUCT\tTYPE\tOUTPUT\tCOMPONENTS\tLABOUR\tOVERHEAD\tACTIVE")
+	for _, bom := range boms {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%d\t%s\t%s\t%t\n",
+			bom.ID,
+			bom.ProductID,
+			bom.BOMType,
+			bom.OutputQuantity.String(),
+			len(bom.Lines),
+			bom.LabourCost.String(),
+			bom.OverheadCost.String(),
+			bom.IsActive,
+		)
 	}
-	if report.IncludeEmpty {
-		_, _ = fmt.Fprintln(w, "Including empty positions")
+	_ = tw.Flush()
+}
+
+func printBOM(w io.Writer, bom *assembly.BillOfMaterials) {
+	_, _ = fmt.Fprintf(w, "Bill of materials %s (%s)\n", bom.ID, bom.BOMType)
+	_, _ = fmt.Fprintf(w, "Product: %s\n", bom.ProductID)
+	_, _ = fmt.Fprintf(w, "Output quantity: %s\n", bom.OutputQuantity.String())
+	_, _ = fmt.Fprintf(w, "Labour: %s, overhead: %s\n", bom.LabourCost.String(), bom.OverheadCost.String())
+	_, _ = fmt.Fprintf(w, "Active: %t\n", bom.IsActive)
+	if strings.TrimSpace(bom.Notes) != "" {
+		_, _ = fmt.Fprintf(w, "Notes: %s\n", bom.Notes)
+	}
+	if len(bom.Lines) == 0 {
+		return
 	}
-	_, _ = fmt.Fprintf(w, "Generated: %s\n\n", formatTime(report.GeneratedAt))
 
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
-	_, _ = fmt.Fprintln(tw, "PRODUCT\tWAREHOUSE\tLOT\tSERIAL\tEXPIRY\tQUANTITY\tUNIT COST\tVALUE\tLAST MOVEMENT")
-	for _, line := range report.Lines {
+	_, _ = fmt.Fprintln(tw, "NO\tCOMPONENT\tQUANTITY\tNOTES")
+	for _, line := range bom.Lines {
 		_, _ = fmt.Fprintf(
 			tw,
-			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			inventoryLotProductLabel(line),
-			inventoryLotWarehouseLabel(line),
-			formatOptionalString(line.LotNumber),
-			formatOptionalString(line.SerialNumber),
-			formatOptionalString(line.ExpiryDate),
+			"%d\t%s\t%s\t%s\n",
+			line.LineNumber,
+			line.ComponentProductID,
 			line.Quantity.String(),
-			line.UnitCost.String(),
-			line.InventoryValue.String(),
-			formatTime(line.LastMovementDate),
+			formatOptionalString(line.Notes),
 		)
 	}
-	_, _ = fmt.Fprintf(
-		tw,
-		"TOTAL\t\t\t\t\t%s\t\t%s\t\n",
-		report.TotalQuantity.String(),
-		report.TotalValue.String(),
-	)
 	_ = tw.Flush()
 }
 
-func inventoryLotProductLabel(line inventory.InventoryLotLine) string {
-	if strings.TrimSpace(line.ProductCode) != "" && strings.TrimSpace(line.ProductName) != "" {
-		return line.ProductCode + " " + line.ProductName
+func printBOMExplosion(w io.Writer, explosion *assembly.BOMExplosion) {
+	if explosion == nil {
+		return
 	}
-	if strings.TrimSpace(line.ProductCode) != "" {
-		return line.ProductCode
+
+	_, _ = fmt.Fprintf(w, "Bill of materials explosion %s %s (%s)\n", explosion.ProductCode, explosion.ProductName, explosion.BOMType)
+	_, _ = fmt.Fprintf(w, "Quantity: %s at %s\n", explosion.Quantity.String(), explosion.ValuationMethod)
+	_, _ = fmt.Fprintf(w, "Material cost: %s\n", explosion.MaterialCost.String())
+	_, _ = fmt.Fprintf(w, "Labour cost: %s\n", explosion.LabourCost.String())
+	_, _ = fmt.Fprintf(w, "Overhead cost: %s\n", explosion.OverheadCost.String())
+	_, _ = fmt.Fprintf(w, "Total cost: %s\n", explosion.TotalCost.String())
+	_, _ = fmt.Fprintf(w, "Unit cost: %s\n\n", explosion.UnitCost.String())
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "LEVEL\tPRODUCT\tNAME\tTYPE\tQUANTITY\tUNIT COST\tTOTAL")
+	for _, line := range explosion.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.Level,
+			line.ProductCode,
+			line.ProductName,
+			formatOptionalString(string(line.BOMType)),
+			line.Quantity.String(),
+			line.UnitCost.String(),
+			line.TotalCost.String(),
+		)
 	}
-	if strings.TrimSpace(line.ProductName) != "" {
-		return line.ProductName
+	_ = tw.Flush()
+}
+
+func printAssemblyOrdersTable(w io.Writer, orders []assembly.AssemblyOrder) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tNUMBER\tTYPE\tSTATUS\tDATE\tPRODUCT\tWAREHOUSE\tQUANTITY\tTOTAL COST")
+	for _, order := range orders {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			order.ID,
+			order.OrderNumber,
+			order.OrderType,
+			order.Status,
+			formatDate(order.OrderDate),
+			order.ProductID,
+			order.WarehouseID,
+			order.Quantity.String(),
+			order.TotalCost.String(),
+		)
 	}
-	return line.ProductID
+	_ = tw.Flush()
 }
 
-func inventoryLotWarehouseLabel(line inventory.InventoryLotLine) string {
-	if strings.TrimSpace(line.WarehouseCode) != "" && strings.TrimSpace(line.WarehouseName) != "" {
-		return line.WarehouseCode + " " + line.WarehouseName
+func printAssemblyOrder(w io.Writer, order *assembly.AssemblyOrder) {
+	_, _ = fmt.Fprintf(w, "Assembly order %s (%s, %s)\n", order.OrderNumber, order.OrderType, order.Status)
+	_, _ = fmt.Fprintf(w, "ID: %s\n", order.ID)
+	_, _ = fmt.Fprintf(w, "Product: %s x %s\n", order.ProductID, order.Quantity.String())
+	_, _ = fmt.Fprintf(w, "Warehouse: %s\n", order.WarehouseID)
+	_, _ = fmt.Fprintf(w, "Order date: %s\n", formatDate(order.OrderDate))
+	_, _ = fmt.Fprintf(w, "Component cost: %s\n", order.ComponentCost.String())
+	_, _ = fmt.Fprintf(w, "Labour: %s, overhead: %s\n", order.LabourCost.String(), order.OverheadCost.String())
+	_, _ = fmt.Fprintf(w, "Total cost: %s, unit cost: %s\n", order.TotalCost.String(), order.UnitCost.String())
+	if order.JournalEntryID != nil {
+		_, _ = fmt.Fprintf(w, "Journal: %s\n", *order.JournalEntryID)
 	}
-	if strings.TrimSpace(line.WarehouseCode) != "" {
-		return line.WarehouseCode
+	if strings.TrimSpace(order.Notes) != "" {
+		_, _ = fmt.Fprintf(w, "Notes: %s\n", order.Notes)
 	}
-	if strings.TrimSpace(line.WarehouseName) != "" {
-		return line.WarehouseName
+	if len(order.Lines) == 0 {
+		return
 	}
-	return line.WarehouseID
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NO\tPRODUCT\tQUANTITY\tLOT\tSERIAL\tUNIT COST\tTOTAL")
+	for _, line := range order.Lines {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			line.LineNumber,
+			line.ProductID,
+			line.Quantity.String(),
+			formatOptionalString(line.LotNumber),
+			formatOptionalString(line.SerialNumber),
+			line.UnitCost.String(),
+			line.TotalCost.String(),
+		)
+	}
+	_ = tw.Flush()
 }
 
 func printCostCentersTable(w io.Writer, costCenters []accounting.CostCenter) {
@@ -2247,6 +3181,9 @@ func printEmployee(w io.Writer, employee *payroll.Employee) {
 	_, _ = fmt.Fprintf(w, "End date: %s\n", formatDatePtr(employee.EndDate))
 	_, _ = fmt.Fprintf(w, "Basic exemption: %t (%s)\n", employee.ApplyBasicExemption, employee.BasicExemptionAmount.String())
 	_, _ = fmt.Fprintf(w, "Funded pension rate: %s\n", employee.FundedPensionRate.String())
+	if employee.HourlyRate.IsPositive() {
+		_, _ = fmt.Fprintf(w, "Hourly rate: %s\n", employee.HourlyRate.String())
+	}
 	_, _ = fmt.Fprintf(w, "Active: %t\n", employee.IsActive)
 }
 
@@ -2270,6 +3207,81 @@ func printSalaryComponentsTable(w io.Writer, components []payroll.SalaryComponen
 	_ = tw.Flush()
 }
 
+func printAverageEarnings(w io.Writer, average *payroll.AverageEarnings) {
+	_, _ = fmt.Fprintf(w, "Employee: %s\n", average.EmployeeID)
+	_, _ = fmt.Fprintf(w, "Period: %s to %s\n", formatDate(average.PeriodStart), formatDate(average.PeriodEnd))
+	_, _ = fmt.Fprintf(w, "Source: %s (%d payslips)\n", average.Source, average.PayslipCount)
+	_, _ = fmt.Fprintf(w, "Earnings: %s over %d days\n", average.TotalEarnings.String(), average.CalendarDays)
+	_, _ = fmt.Fprintf(w, "Average daily earnings: %s\n", average.AverageDailyEarnings.String())
+}
+
+func printTimesheetsTable(w io.Writer, entries []payroll.TimesheetEntry) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tEMPLOYEE\tDATE\tREGULAR\tOVERTIME\tNIGHT\tHOLIDAY\tSTATUS")
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.ID,
+			entry.EmployeeID,
+			formatDate(entry.WorkDate),
+			entry.RegularHours.String(),
+			entry.OvertimeHours.String(),
+			entry.NightHours.String(),
+			entry.HolidayHours.String(),
+			entry.Status,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printEmploymentEventsTable(w io.Writer, events []payroll.EmploymentEvent) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tDATE\tEVENT\tRATIO\tCODE\tEXPORTED")
+	for _, event := range events {
+		code := event.TerminationCode
+		if code == "" {
+			code = event.SuspensionReason
+		}
+		exported := "pending"
+		if event.ExportedAt != nil {
+			exported = formatDate(*event.ExportedAt)
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			event.ID,
+			formatDate(event.EventDate),
+			event.EventType,
+			event.WorkingTimeRatio.String(),
+			code,
+			exported,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPendingEmploymentRegisterTable(w io.Writer, pending []payroll.EmploymentRegisterPendingEmployee) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "EMPLOYEE\tNUMBER\tNAME\tEVENTS\tFIRST DATE")
+	for _, employee := range pending {
+		firstDate := ""
+		if len(employee.Events) > 0 {
+			firstDate = formatDate(employee.Events[0].EventDate)
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%d\t%s\n",
+			employee.EmployeeID,
+			employee.EmployeeNumber,
+			employee.EmployeeName,
+			len(employee.Events),
+			firstDate,
+		)
+	}
+	_ = tw.Flush()
+}
+
 func printDocumentsTable(w io.Writer, docs []documents.Document) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 	_, _ = fmt.Fprintln(tw, "ID\tENTITY\tTYPE\tFILE\tREVIEW\tLIFECYCLE\tRETENTION\tCREATED")
@@ -2748,6 +3760,38 @@ func printEmailTemplate(w io.Writer, template *email.EmailTemplate) {
 	}
 }
 
+func printDocumentTemplatesTable(w io.Writer, entries []tenant.DocumentTemplateEntry) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TYPE\tCUSTOMIZED\tPAPER\tORIENTATION\tLOGO\tDISCOUNT COLUMN\tPAYMENT QR\tCUSTOM FIELDS")
+	for _, entry := range entries {
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%t\t%s\t%s\t%s\t%t\t%t\t%d\n",
+			entry.DocumentType,
+			entry.Customized,
+			defaultString(entry.Template.PaperSize, tenant.PaperSizeA4),
+			defaultString(entry.Template.Orientation, tenant.OrientationPortrait),
+			defaultString(entry.Template.LogoPosition, tenant.LogoPositionNone),
+			!entry.Template.HideDiscountColumn,
+			entry.Template.ShowPaymentQRCode,
+			len(entry.Template.CustomFields),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printDocumentTemplate(w io.Writer, entry *tenant.DocumentTemplateEntry) {
+	_, _ = fmt.Fprintf(w, "Document template %s\n", entry.DocumentType)
+	_, _ = fmt.Fprintf(w, "Customized: %t\n", entry.Customized)
+	_, _ = fmt.Fprintf(w, "Paper: %s %s\n", defaultString(entry.Template.PaperSize, tenant.PaperSizeA4), defaultString(entry.Template.Orientation, tenant.OrientationPortrait))
+	_, _ = fmt.Fprintf(w, "Logo: %s\n", defaultString(entry.Template.LogoPosition, tenant.LogoPositionNone))
+	_, _ = fmt.Fprintf(w, "Discount column: %t\n", !entry.Template.HideDiscountColumn)
+	_, _ = fmt.Fprintf(w, "Payment QR code: %t\n", entry.Template.ShowPaymentQRCode)
+	for _, field := range entry.Template.CustomFields {
+		_, _ = fmt.Fprintf(w, "Field %s: %s\n", field.Label, field.Value)
+	}
+}
+
 func printEmailLogsTable(w io.Writer, logs []email.EmailLog) {
 	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
 	_, _ = fmt.Fprintln(tw, "ID\tTYPE\tRECIPIENT\tSUBJECT\tSTATUS\tSENT\tERROR")
@@ -3473,6 +4517,82 @@ func printPayslipsTable(w io.Writer, payslips []payroll.Payslip) {
 	_ = tw.Flush()
 }
 
+func printEmployeePayslipsTable(w io.Writer, payslips []payroll.EmployeePayslip) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "ID\tPERIOD\tGROSS\tNET\tRUN STATUS\tPAYMENT DATE")
+	for _, payslip := range payslips {
+		paymentDate := ""
+		if payslip.PaymentDate != nil {
+			paymentDate = formatDate(*payslip.PaymentDate)
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%04d-%02d\t%s\t%s\t%s\t%s\n",
+			payslip.ID,
+			payslip.PeriodYear,
+			payslip.PeriodMonth,
+			payslip.GrossSalary.String(),
+			payslip.NetSalary.String(),
+			payslip.RunStatus,
+			paymentDate,
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPayrollPaymentsTable(w io.Writer, paid []payroll.PayrollPayment) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "MESSAGE ID\tEXECUTION DATE\tPAYSLIPS\tNET\tTAX\tTAX REFERENCE\tPAYMENT\tJOURNAL ENTRY")
+	for _, payment := range paid {
+		paymentID := ""
+		if payment.PaymentID != nil {
+			paymentID = *payment.PaymentID
+		}
+		journalEntryID := ""
+		if payment.JournalEntryID != nil {
+			journalEntryID = *payment.JournalEntryID
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\n",
+			payment.MessageID,
+			payment.ExecutionDate.Format("2006-01-02"),
+			payment.PayslipCount,
+			payment.NetAmount.StringFixed(2),
+			payment.TaxAmount.StringFixed(2),
+			emptyDash(payment.TaxReference),
+			emptyDash(paymentID),
+			emptyDash(journalEntryID),
+		)
+	}
+	_ = tw.Flush()
+}
+
+func printPayrollPostingAccountsTable(w io.Writer, accounts []payroll.PayrollPostingAccounts) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "DEPARTMENT\tCOST CENTER\tSALARY EXPENSE\tEMPLOYER TAX EXPENSE\tINCOME TAX\tSOCIAL TAX\tUNEMPLOYMENT\tFUNDED PENSION\tNET PAY")
+	for _, account := range accounts {
+		costCenter := ""
+		if account.CostCenterID != nil {
+			costCenter = *account.CostCenterID
+		}
+		_, _ = fmt.Fprintf(
+			tw,
+			"%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			defaultString(account.Department, "(default)"),
+			emptyDash(costCenter),
+			emptyDash(account.SalaryExpenseAccountID),
+			emptyDash(account.EmployerTaxExpenseAccountID),
+			emptyDash(account.IncomeTaxPayableAccountID),
+			emptyDash(account.SocialTaxPayableAccountID),
+			emptyDash(account.UnemploymentPayableAccountID),
+			emptyDash(account.FundedPensionPayableAccountID),
+			emptyDash(account.NetPayPayableAccountID),
+		)
+	}
+	_ = tw.Flush()
+}
+
 func printTaxCalculation(w io.Writer, calc *payroll.TaxCalculation) {
 	_, _ = fmt.Fprintf(w, "Gross salary: %s\n", calc.GrossSalary.String())
 	_, _ = fmt.Fprintf(w, "Basic exemption: %s\n", calc.BasicExemption.String())
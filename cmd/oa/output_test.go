@@ -2163,6 +2163,23 @@ func TestPrintRecurringInvoiceOutputs(t *testing.T) {
 	assert.Contains(t, detailBuf.String(), "Email recipient: billing@example.com")
 	assert.Contains(t, detailBuf.String(), "Consulting")
 
+	uplift := decimal.RequireFromString("3.5")
+	anchor := now.AddDate(0, 0, 10)
+	recurringInvoice.BillingAnchorDate = &anchor
+	recurringInvoice.Lines[0].UsageMetric = "hours"
+	recurringInvoice.PriceChanges = []recurring.PriceChange{{ID: "change-1", EffectiveDate: now.AddDate(1, 0, 0), UpliftPercent: &uplift, Note: "CPI"}}
+	detailBuf.Reset()
+	printRecurringInvoice(&detailBuf, &recurringInvoice)
+	assert.Contains(t, detailBuf.String(), "Billing anchor: "+formatDate(anchor))
+	assert.Contains(t, detailBuf.String(), "hours")
+	assert.Contains(t, detailBuf.String(), "uplift 3.5%")
+
+	invoiceID := "inv-1"
+	var usageBuf bytes.Buffer
+	printRecurringUsageTable(&usageBuf, []recurring.UsageRecord{{ID: "usage-1", Metric: "kwh", UsageDate: now, Quantity: decimal.NewFromInt(80), InvoiceID: &invoiceID}})
+	assert.Contains(t, usageBuf.String(), "kwh")
+	assert.Contains(t, usageBuf.String(), "inv-1")
+
 	var resultsBuf bytes.Buffer
 	printRecurringGenerationResultsTable(&resultsBuf, []recurring.GenerationResult{result})
 	assert.Contains(t, resultsBuf.String(), "INV-00001")
@@ -178,6 +178,7 @@ type CostCenterRepository interface {
 	GetExpensesByPeriod(ctx context.Context, schemaName, tenantID, costCenterID string, start, end time.Time) (decimal.Decimal, error)
 	CreateAllocation(ctx context.Context, schemaName string, allocation *CostAllocation) error
 	ListAllocations(ctx context.Context, schemaName, tenantID string, filters CostAllocationFilters) ([]CostAllocation, error)
+	DeleteAllocationsByJournalLines(ctx context.Context, schemaName, tenantID string, journalEntryLineIDs []string) error
 }
 
 // CostCenterGORMRepository implements CostCenterRepository with the shared ORM layer.
@@ -385,6 +386,21 @@ func (r *CostCenterGORMRepository) CreateAllocation(ctx context.Context, schemaN
 	return nil
 }
 
+// DeleteAllocationsByJournalLines removes the allocations attached to the given journal entry lines.
+func (r *CostCenterGORMRepository) DeleteAllocationsByJournalLines(ctx context.Context, schemaName, tenantID string, journalEntryLineIDs []string) error {
+	if len(journalEntryLineIDs) == 0 {
+		return nil
+	}
+	db, err := r.tenantTable(ctx, schemaName, "cost_allocations")
+	if err != nil {
+		return fmt.Errorf("qualify cost allocations table: %w", err)
+	}
+	if err := db.Where("tenant_id = ? AND journal_entry_line_id IN ?", tenantID, journalEntryLineIDs).Delete(&models.CostAllocation{}).Error; err != nil {
+		return fmt.Errorf("delete cost allocations: %w", err)
+	}
+	return nil
+}
+
 // ListAllocations lists cost allocations with optional cost center and date filters.
 func (r *CostCenterGORMRepository) ListAllocations(ctx context.Context, schemaName, tenantID string, filters CostAllocationFilters) ([]CostAllocation, error) {
 	allocationsTable, err := r.tenantTable(ctx, schemaName, "cost_allocations")
@@ -724,6 +740,12 @@ func (s *CostCenterService) CreateCostAllocation(ctx context.Context, schemaName
 	return allocation, nil
 }
 
+// DeleteCostAllocationsForJournalLines removes cost-center allocations for journal
+// entry lines that have been voided, so cost-center totals no longer include them.
+func (s *CostCenterService) DeleteCostAllocationsForJournalLines(ctx context.Context, schemaName, tenantID string, journalEntryLineIDs []string) error {
+	return s.repo.DeleteAllocationsByJournalLines(ctx, schemaName, tenantID, journalEntryLineIDs)
+}
+
 // ListCostAllocations returns cost-center allocations for review and automation.
 func (s *CostCenterService) ListCostAllocations(ctx context.Context, schemaName, tenantID string, filters CostAllocationFilters) ([]CostAllocation, error) {
 	if filters.StartDate != nil && filters.EndDate != nil && filters.EndDate.Before(*filters.StartDate) {
@@ -859,3 +881,21 @@ func (m *MockCostCenterRepository) ListAllocations(_ context.Context, _, tenantI
 	}
 	return result, nil
 }
+
+func (m *MockCostCenterRepository) DeleteAllocationsByJournalLines(_ context.Context, _, tenantID string, journalEntryLineIDs []string) error {
+	remove := make(map[string]bool, len(journalEntryLineIDs))
+	for _, id := range journalEntryLineIDs {
+		remove[id] = true
+	}
+	for costCenterID, allocations := range m.Allocations {
+		kept := allocations[:0]
+		for _, allocation := range allocations {
+			if allocation.TenantID == tenantID && remove[allocation.JournalEntryLineID] {
+				continue
+			}
+			kept = append(kept, allocation)
+		}
+		m.Allocations[costCenterID] = kept
+	}
+	return nil
+}
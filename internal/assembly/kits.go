@@ -0,0 +1,204 @@
+package assembly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+// IsKit reports whether a product has an active kit bill of materials
+func (s *Service) IsKit(ctx context.Context, tenantID, schemaName, productID string) (bool, error) {
+	bom, err := s.repo.GetBOMByProduct(ctx, schemaName, tenantID, strings.TrimSpace(productID))
+	if errors.Is(err, ErrBOMNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get bill of materials: %w", err)
+	}
+	return bom.IsActive && bom.BOMType == BOMTypeKit, nil
+}
+
+// IssueKit issues the components of a kit from a warehouse and posts their
+// cost to cost of goods sold. Nested kits are exploded into their own
+// components. Each component uses the requested cost of goods sold account,
+// falling back to its purchase account.
+func (s *Service) IssueKit(ctx context.Context, tenantID, schemaName string, req *IssueKitRequest) (*IssueKitResult, error) {
+	if req == nil {
+		return nil, fmt.Errorf("kit issue request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for kit issues")
+	}
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	productID := strings.TrimSpace(req.ProductID)
+	bom, err := s.repo.GetBOMByProduct(ctx, schemaName, tenantID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+	if !bom.IsActive || bom.BOMType != BOMTypeKit {
+		return nil, fmt.Errorf("product has no active kit bill of materials")
+	}
+	index, err := s.bomIndex(ctx, tenantID, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	components, err := explodeKits(index, bom, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IssueKitResult{
+		ProductID:   productID,
+		WarehouseID: req.WarehouseID,
+		Quantity:    req.Quantity,
+		TotalCost:   decimal.Zero,
+		Components:  make([]KitComponentIssue, 0, len(components)),
+	}
+	// Issue every component in one transaction so a component that cannot be
+	// issued leaves no stock movement or journal of the others behind.
+	err = s.withInventoryLedgerTransaction(ctx, func(tx *Service) error {
+		for _, component := range components {
+			cogsAccountID := strings.TrimSpace(req.CostOfGoodsSoldAccountID)
+			if cogsAccountID == "" {
+				product, err := tx.stock.GetProductByID(ctx, tenantID, schemaName, component.ProductID)
+				if err != nil {
+					return fmt.Errorf("get product: %w", err)
+				}
+				cogsAccountID = product.PurchaseAccountID
+			}
+			issued, err := tx.stock.IssueStock(ctx, tenantID, schemaName, &inventory.IssueStockRequest{
+				ProductID:                component.ProductID,
+				WarehouseID:              req.WarehouseID,
+				Quantity:                 component.Quantity.String(),
+				CostingMethod:            req.CostingMethod,
+				Reference:                req.Reference,
+				SourceType:               req.SourceType,
+				SourceID:                 req.SourceID,
+				Reason:                   req.Reason,
+				CostOfGoodsSoldAccountID: cogsAccountID,
+				InventoryAccountID:       req.InventoryAccountID,
+				PostToLedger:             true,
+				UserID:                   req.UserID,
+			})
+			if err != nil {
+				return fmt.Errorf("issue kit component %d: %w", component.LineNumber, err)
+			}
+			issue := KitComponentIssue{
+				ProductID: component.ProductID,
+				Quantity:  issued.Quantity,
+				UnitCost:  issued.UnitCost,
+				TotalCost: issued.TotalCost,
+			}
+			if issued.Accounting != nil {
+				issue.JournalEntryID = issued.Accounting.JournalID
+			}
+			result.CostingMethod = issued.CostingMethod
+			result.TotalCost = result.TotalCost.Add(issued.TotalCost)
+			result.Components = append(result.Components, issue)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.UnitCost = result.TotalCost.Div(req.Quantity).Round(8)
+	return result, nil
+}
+
+// IssueInvoiceKits issues the components of every kit line on a sales
+// invoice. Without a warehouse the tenant's default warehouse is used. Lines
+// for products that are not kits are skipped, and nothing is issued again for
+// an invoice whose kits were already issued.
+func (s *Service) IssueInvoiceKits(ctx context.Context, tenantID, schemaName string, req *IssueInvoiceKitsRequest) ([]IssueKitResult, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invoice kit request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for kit issues")
+	}
+	kitLines := make([]InvoiceProductLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		isKit, err := s.IsKit(ctx, tenantID, schemaName, line.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		if isKit && line.Quantity.IsPositive() {
+			kitLines = append(kitLines, line)
+		}
+	}
+	if len(kitLines) == 0 {
+		return []IssueKitResult{}, nil
+	}
+
+	warehouseID := strings.TrimSpace(req.WarehouseID)
+	if warehouseID == "" {
+		warehouses, err := s.stock.ListWarehouses(ctx, tenantID, schemaName, true)
+		if err != nil {
+			return nil, fmt.Errorf("list warehouses: %w", err)
+		}
+		for _, warehouse := range warehouses {
+			if warehouse.IsDefault {
+				warehouseID = warehouse.ID
+				break
+			}
+		}
+		if warehouseID == "" {
+			return nil, fmt.Errorf("a default warehouse is required to issue kit components")
+		}
+	}
+
+	results := make([]IssueKitResult, 0, len(kitLines))
+	err := s.withInventoryLedgerTransaction(ctx, func(tx *Service) error {
+		issued, err := tx.stock.HasSourceMovements(ctx, tenantID, schemaName, KitSaleSourceType, req.InvoiceID)
+		if err != nil {
+			return err
+		}
+		if issued {
+			return nil
+		}
+		for _, line := range kitLines {
+			result, err := tx.IssueKit(ctx, tenantID, schemaName, &IssueKitRequest{
+				ProductID:     line.ProductID,
+				WarehouseID:   warehouseID,
+				Quantity:      line.Quantity,
+				CostingMethod: req.CostingMethod,
+				Reference:     req.InvoiceNumber,
+				SourceType:    KitSaleSourceType,
+				SourceID:      req.InvoiceID,
+				Reason:        fmt.Sprintf("Invoice %s line %d", req.InvoiceNumber, line.LineNumber),
+				UserID:        req.UserID,
+			})
+			if err != nil {
+				return fmt.Errorf("line %d: %w", line.LineNumber, err)
+			}
+			results = append(results, *result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// withInventoryLedgerTransaction runs fn with a copy of the service bound to
+// one repository and inventory transaction when the repository supports it.
+func (s *Service) withInventoryLedgerTransaction(ctx context.Context, fn func(tx *Service) error) error {
+	transactioner, ok := s.repo.(InventoryLedgerTransactionRepository)
+	if !ok {
+		return fn(s)
+	}
+	return transactioner.WithInventoryLedgerTransaction(ctx, func(txRepo Repository, stock stockAssembler) error {
+		tx := *s
+		tx.repo = txRepo
+		tx.stock = stock
+		return fn(&tx)
+	})
+}
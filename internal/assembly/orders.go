@@ -0,0 +1,292 @@
+package assembly
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+// CreateOrder drafts an assembly or disassembly order from the active bill of
+// materials of a product. Component lines are the bill's components scaled to
+// the order quantity, with kit components exploded into their own
+// components. Sub-assemblies are consumed from stock as they are.
+func (s *Service) CreateOrder(ctx context.Context, tenantID, schemaName string, req *CreateAssemblyOrderRequest) (*AssemblyOrder, error) {
+	if req == nil {
+		return nil, fmt.Errorf("assembly order request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for assembly orders")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to create an assembly order")
+	}
+	orderType := req.OrderType
+	if orderType == "" {
+		orderType = AssemblyOrderTypeAssembly
+	}
+	if orderType != AssemblyOrderTypeAssembly && orderType != AssemblyOrderTypeDisassembly {
+		return nil, fmt.Errorf("invalid order_type: %s", orderType)
+	}
+	productID := strings.TrimSpace(req.ProductID)
+	if productID == "" {
+		return nil, fmt.Errorf("product_id is required")
+	}
+	warehouseID := strings.TrimSpace(req.WarehouseID)
+	if warehouseID == "" {
+		return nil, fmt.Errorf("warehouse_id is required")
+	}
+	if !req.Quantity.IsPositive() {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	if req.ExpiryDate != "" {
+		if _, err := time.Parse("2006-01-02", strings.TrimSpace(req.ExpiryDate)); err != nil {
+			return nil, fmt.Errorf("expiry_date must use YYYY-MM-DD")
+		}
+	}
+
+	bom, err := s.repo.GetBOMByProduct(ctx, schemaName, tenantID, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+	if !bom.IsActive {
+		return nil, fmt.Errorf("bill of materials is inactive")
+	}
+	if bom.BOMType != BOMTypeAssembly {
+		return nil, fmt.Errorf("kits are issued as components when sold and cannot be assembled")
+	}
+	if _, err := s.stock.GetWarehouseByID(ctx, tenantID, schemaName, warehouseID); err != nil {
+		return nil, fmt.Errorf("get warehouse: %w", err)
+	}
+	index, err := s.bomIndex(ctx, tenantID, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := explodeKits(index, bom, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+
+	factor := req.Quantity.Div(bom.OutputQuantity)
+	labourCost := bom.LabourCost.Mul(factor).Round(2)
+	overheadCost := bom.OverheadCost.Mul(factor).Round(2)
+	if req.LabourCost != nil {
+		labourCost = req.LabourCost.Round(2)
+	}
+	if req.OverheadCost != nil {
+		overheadCost = req.OverheadCost.Round(2)
+	}
+	if labourCost.IsNegative() || overheadCost.IsNegative() {
+		return nil, fmt.Errorf("labour_cost and overhead_cost cannot be negative")
+	}
+	if orderType == AssemblyOrderTypeDisassembly {
+		labourCost = decimal.Zero
+		overheadCost = decimal.Zero
+	}
+
+	now := time.Now()
+	order := &AssemblyOrder{
+		ID:           uuid.New().String(),
+		TenantID:     tenantID,
+		OrderType:    orderType,
+		BOMID:        bom.ID,
+		ProductID:    productID,
+		WarehouseID:  warehouseID,
+		Quantity:     req.Quantity,
+		OrderDate:    req.OrderDate,
+		Status:       AssemblyOrderStatusDraft,
+		LotNumber:    strings.TrimSpace(req.LotNumber),
+		SerialNumber: strings.TrimSpace(req.SerialNumber),
+		ExpiryDate:   strings.TrimSpace(req.ExpiryDate),
+		LabourCost:   labourCost,
+		OverheadCost: overheadCost,
+		Notes:        req.Notes,
+		Lines:        lines,
+		CreatedBy:    userID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if order.OrderDate.IsZero() {
+		order.OrderDate = now
+	}
+	for i := range order.Lines {
+		order.Lines[i].ID = uuid.New().String()
+		order.Lines[i].TenantID = tenantID
+		order.Lines[i].AssemblyOrderID = order.ID
+	}
+
+	orderNumber, err := s.repo.GenerateOrderNumber(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("generate assembly order number: %w", err)
+	}
+	order.OrderNumber = orderNumber
+	if err := s.repo.CreateOrder(ctx, schemaName, order); err != nil {
+		return nil, fmt.Errorf("create assembly order: %w", err)
+	}
+	return order, nil
+}
+
+// GetOrder retrieves an assembly order by ID with its lines
+func (s *Service) GetOrder(ctx context.Context, tenantID, schemaName, orderID string) (*AssemblyOrder, error) {
+	order, err := s.repo.GetOrder(ctx, schemaName, tenantID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get assembly order: %w", err)
+	}
+	return order, nil
+}
+
+// ListOrders retrieves assembly orders with optional filtering
+func (s *Service) ListOrders(ctx context.Context, tenantID, schemaName string, filter *AssemblyOrderFilter) ([]AssemblyOrder, error) {
+	orders, err := s.repo.ListOrders(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list assembly orders: %w", err)
+	}
+	return orders, nil
+}
+
+// CancelOrder cancels a draft assembly order
+func (s *Service) CancelOrder(ctx context.Context, tenantID, schemaName, orderID string) error {
+	order, err := s.repo.GetOrder(ctx, schemaName, tenantID, orderID)
+	if err != nil {
+		return fmt.Errorf("get assembly order: %w", err)
+	}
+	if order.Status != AssemblyOrderStatusDraft {
+		return fmt.Errorf("only draft assembly orders can be canceled")
+	}
+	if err := s.repo.UpdateOrderStatus(ctx, schemaName, tenantID, orderID, AssemblyOrderStatusCanceled); err != nil {
+		return fmt.Errorf("cancel assembly order: %w", err)
+	}
+	return nil
+}
+
+// CompleteOrder books a draft assembly order. An assembly issues the
+// components and receives the product at the issued cost plus labour and
+// overhead, which are credited to the absorption account. A disassembly
+// issues the product and receives the components, splitting the product's
+// cost by the components' rolled-up cost.
+func (s *Service) CompleteOrder(ctx context.Context, tenantID, schemaName, orderID string, req *CompleteAssemblyOrderRequest) (*AssemblyOrder, error) {
+	if req == nil {
+		return nil, fmt.Errorf("completion request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for assembly orders")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to complete an assembly order")
+	}
+
+	order, err := s.repo.GetOrder(ctx, schemaName, tenantID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("get assembly order: %w", err)
+	}
+	if order.Status != AssemblyOrderStatusDraft {
+		return nil, fmt.Errorf("only draft assembly orders can be completed")
+	}
+	if req.PeriodLockDate != nil && !order.OrderDate.After(*req.PeriodLockDate) {
+		return nil, fmt.Errorf("%w through %s; order date %s must be later", ErrPeriodLocked, req.PeriodLockDate.Format("2006-01-02"), order.OrderDate.Format("2006-01-02"))
+	}
+
+	finished := inventory.PostAssemblyLine{
+		ProductID:    order.ProductID,
+		Quantity:     order.Quantity,
+		LotNumber:    order.LotNumber,
+		SerialNumber: order.SerialNumber,
+		ExpiryDate:   order.ExpiryDate,
+	}
+	components := make([]inventory.PostAssemblyLine, len(order.Lines))
+	for i, line := range order.Lines {
+		components[i] = inventory.PostAssemblyLine{
+			ProductID:    line.ProductID,
+			Quantity:     line.Quantity,
+			LotNumber:    line.LotNumber,
+			SerialNumber: line.SerialNumber,
+			ExpiryDate:   line.ExpiryDate,
+		}
+	}
+	stockReq := &inventory.PostAssemblyRequest{
+		WarehouseID:         order.WarehouseID,
+		AssemblyDate:        order.OrderDate,
+		Reference:           order.OrderNumber,
+		SourceType:          AssemblyOrderSourceType,
+		SourceID:            order.ID,
+		CostingMethod:       req.CostingMethod,
+		AbsorptionAccountID: req.AbsorptionAccountID,
+		InventoryAccountID:  req.InventoryAccountID,
+		UserID:              userID,
+	}
+	if order.OrderType == AssemblyOrderTypeDisassembly {
+		if err := s.weightDisassembly(ctx, tenantID, schemaName, components); err != nil {
+			return nil, err
+		}
+		stockReq.Consumed = []inventory.PostAssemblyLine{finished}
+		stockReq.Produced = components
+	} else {
+		stockReq.AdditionalCost = order.LabourCost.Add(order.OverheadCost)
+		stockReq.Consumed = components
+		stockReq.Produced = []inventory.PostAssemblyLine{finished}
+	}
+
+	result, err := s.stock.PostAssembly(ctx, tenantID, schemaName, stockReq)
+	if err != nil {
+		return nil, fmt.Errorf("post assembly: %w", err)
+	}
+	componentCosts := result.Consumed
+	finishedCost := result.Produced[0]
+	if order.OrderType == AssemblyOrderTypeDisassembly {
+		componentCosts = result.Produced
+		finishedCost = result.Consumed[0]
+	}
+	order.ComponentCost = decimal.Zero
+	for i := range order.Lines {
+		order.Lines[i].UnitCost = componentCosts[i].UnitCost
+		order.Lines[i].TotalCost = componentCosts[i].TotalCost
+		order.ComponentCost = order.ComponentCost.Add(componentCosts[i].TotalCost)
+	}
+	order.TotalCost = result.TotalCost
+	order.UnitCost = finishedCost.UnitCost
+
+	now := time.Now()
+	order.Status = AssemblyOrderStatusCompleted
+	order.CostingMethod = result.CostingMethod
+	order.AbsorptionAccountID = strings.TrimSpace(req.AbsorptionAccountID)
+	if result.JournalID != "" {
+		journalID := result.JournalID
+		order.JournalEntryID = &journalID
+	}
+	order.CompletedAt = &now
+	order.CompletedBy = userID
+	order.UpdatedAt = now
+	if err := s.repo.CompleteOrder(ctx, schemaName, order); err != nil {
+		return nil, fmt.Errorf("complete assembly order: %w", err)
+	}
+	return order, nil
+}
+
+// weightDisassembly sets each component's cost weight to its quantity valued
+// at its rolled-up unit cost.
+func (s *Service) weightDisassembly(ctx context.Context, tenantID, schemaName string, components []inventory.PostAssemblyLine) error {
+	index, err := s.bomIndex(ctx, tenantID, schemaName)
+	if err != nil {
+		return err
+	}
+	valuation, err := s.stock.GetInventoryValuation(ctx, tenantID, schemaName, "", "")
+	if err != nil {
+		return fmt.Errorf("get inventory valuation: %w", err)
+	}
+	costs := newBOMCoster(ctx, s.stock, tenantID, schemaName, index, valuation)
+	for i := range components {
+		unitCost, err := costs.unitCost(components[i].ProductID, 0)
+		if err != nil {
+			return err
+		}
+		components[i].CostWeight = components[i].Quantity.Mul(unitCost)
+	}
+	return nil
+}
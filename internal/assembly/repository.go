@@ -0,0 +1,665 @@
+package assembly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gorm.io/gorm"
+)
+
+// Repository defines the contract for bill of materials and assembly order data access
+type Repository interface {
+	CreateBOM(ctx context.Context, schemaName string, bom *BillOfMaterials) error
+	UpdateBOM(ctx context.Context, schemaName string, bom *BillOfMaterials) error
+	GetBOM(ctx context.Context, schemaName, tenantID, bomID string) (*BillOfMaterials, error)
+	GetBOMByProduct(ctx context.Context, schemaName, tenantID, productID string) (*BillOfMaterials, error)
+	ListBOMs(ctx context.Context, schemaName, tenantID string, filter *BOMFilter) ([]BillOfMaterials, error)
+	DeleteBOM(ctx context.Context, schemaName, tenantID, bomID string) error
+	CreateOrder(ctx context.Context, schemaName string, order *AssemblyOrder) error
+	GetOrder(ctx context.Context, schemaName, tenantID, orderID string) (*AssemblyOrder, error)
+	ListOrders(ctx context.Context, schemaName, tenantID string, filter *AssemblyOrderFilter) ([]AssemblyOrder, error)
+	CompleteOrder(ctx context.Context, schemaName string, order *AssemblyOrder) error
+	UpdateOrderStatus(ctx context.Context, schemaName, tenantID, orderID string, status AssemblyOrderStatus) error
+	GenerateOrderNumber(ctx context.Context, schemaName, tenantID string) (string, error)
+}
+
+// InventoryLedgerTransactionRepository runs assembly reads, stock movements and
+// their journal postings in one database transaction. Repositories that do not
+// implement it issue stock one movement at a time.
+type InventoryLedgerTransactionRepository interface {
+	WithInventoryLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockAssembler) error) error
+}
+
+// ErrBOMNotFound is returned when a bill of materials is not found
+var ErrBOMNotFound = fmt.Errorf("bill of materials not found")
+
+// ErrAssemblyOrderNotFound is returned when an assembly order is not found
+var ErrAssemblyOrderNotFound = fmt.Errorf("assembly order not found")
+
+// ErrPeriodLocked is returned when a completion would post into a locked accounting period
+var ErrPeriodLocked = fmt.Errorf("period locked")
+
+var errAssemblyRepositoryDatabaseNotConfigured = errors.New("assembly repository database is not configured")
+
+var newGormDBFromPool = database.NewGormDBFromPool
+
+// GORMRepository implements Repository with the shared ORM layer.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *pgxpool.Pool) *GORMRepository {
+	if db == nil {
+		return &GORMRepository{}
+	}
+	gormDB, err := newGormDBFromPool(context.Background(), db)
+	if err != nil {
+		panic(fmt.Errorf("create assembly GORM repository: %w", err))
+	}
+	return NewGORMRepository(gormDB)
+}
+
+func NewGORMRepository(db *gorm.DB) *GORMRepository {
+	return &GORMRepository{db: db}
+}
+
+// WithInventoryLedgerTransaction runs fn inside a GORM-backed transaction
+// shared by the assembly repository, inventory and the general ledger.
+func (r *GORMRepository) WithInventoryLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockAssembler) error) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(&GORMRepository{db: tx}, inventory.NewServiceWithGORM(tx))
+	})
+}
+
+func (r *GORMRepository) dbWithContext(ctx context.Context) (*gorm.DB, error) {
+	if r == nil || r.db == nil {
+		return nil, errAssemblyRepositoryDatabaseNotConfigured
+	}
+	return r.db.WithContext(ctx), nil
+}
+
+func (r *GORMRepository) tenantTable(ctx context.Context, schemaName, tableName string) (*gorm.DB, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return database.TenantTable(db, schemaName, tableName)
+}
+
+// CreateBOM inserts a new bill of materials with its lines
+func (r *GORMRepository) CreateBOM(ctx context.Context, schemaName string, bom *BillOfMaterials) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		bomsTable, err := database.TenantTable(tx, schemaName, "bills_of_materials")
+		if err != nil {
+			return fmt.Errorf("qualify bills of materials table: %w", err)
+		}
+		if err := bomsTable.Create(bomToModel(bom)).Error; err != nil {
+			return fmt.Errorf("insert bill of materials: %w", err)
+		}
+		return insertBOMLinesTx(tx, schemaName, bom)
+	})
+}
+
+// UpdateBOM updates a bill of materials and replaces its lines
+func (r *GORMRepository) UpdateBOM(ctx context.Context, schemaName string, bom *BillOfMaterials) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		bomsTable, err := database.TenantTable(tx, schemaName, "bills_of_materials")
+		if err != nil {
+			return fmt.Errorf("qualify bills of materials table: %w", err)
+		}
+		model := bomToModel(bom)
+		result := bomsTable.Where("id = ? AND tenant_id = ?", bom.ID, bom.TenantID).
+			Updates(map[string]interface{}{
+				"bom_type":        model.BOMType,
+				"output_quantity": model.OutputQuantity,
+				"labour_cost":     model.LabourCost,
+				"overhead_cost":   model.OverheadCost,
+				"is_active":       model.IsActive,
+				"notes":           model.Notes,
+				"updated_at":      model.UpdatedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update bill of materials: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrBOMNotFound
+		}
+
+		linesTable, _ := database.TenantTable(tx, schemaName, "bill_of_material_lines")
+		if err := linesTable.Where("bom_id = ? AND tenant_id = ?", bom.ID, bom.TenantID).
+			Delete(&models.BillOfMaterialLine{}).Error; err != nil {
+			return fmt.Errorf("delete bill of materials lines: %w", err)
+		}
+		return insertBOMLinesTx(tx, schemaName, bom)
+	})
+}
+
+// GetBOM retrieves a bill of materials by ID with its lines
+func (r *GORMRepository) GetBOM(ctx context.Context, schemaName, tenantID, bomID string) (*BillOfMaterials, error) {
+	return r.getBOM(ctx, schemaName, tenantID, "id = ? AND tenant_id = ?", bomID)
+}
+
+// GetBOMByProduct retrieves the bill of materials of a product with its lines
+func (r *GORMRepository) GetBOMByProduct(ctx context.Context, schemaName, tenantID, productID string) (*BillOfMaterials, error) {
+	return r.getBOM(ctx, schemaName, tenantID, "product_id = ? AND tenant_id = ?", productID)
+}
+
+func (r *GORMRepository) getBOM(ctx context.Context, schemaName, tenantID, condition, value string) (*BillOfMaterials, error) {
+	db, err := r.tenantTable(ctx, schemaName, "bills_of_materials")
+	if err != nil {
+		return nil, fmt.Errorf("qualify bills of materials table: %w", err)
+	}
+
+	var bomModel models.BillOfMaterials
+	err = db.Where(condition, value, tenantID).First(&bomModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrBOMNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+
+	boms := []BillOfMaterials{*bomFromModel(&bomModel)}
+	if err := r.loadBOMLines(ctx, schemaName, tenantID, boms); err != nil {
+		return nil, err
+	}
+	return &boms[0], nil
+}
+
+// ListBOMs retrieves bills of materials with their lines
+func (r *GORMRepository) ListBOMs(ctx context.Context, schemaName, tenantID string, filter *BOMFilter) ([]BillOfMaterials, error) {
+	db, err := r.tenantTable(ctx, schemaName, "bills_of_materials")
+	if err != nil {
+		return nil, fmt.Errorf("qualify bills of materials table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter != nil {
+		if filter.BOMType != "" {
+			query = query.Where("bom_type = ?", string(filter.BOMType))
+		}
+		if filter.ProductID != "" {
+			query = query.Where("product_id = ?", filter.ProductID)
+		}
+		if filter.ActiveOnly {
+			query = query.Where("is_active = ?", true)
+		}
+	}
+
+	var bomModels []models.BillOfMaterials
+	if err := query.Order("created_at ASC").Find(&bomModels).Error; err != nil {
+		return nil, fmt.Errorf("list bills of materials: %w", err)
+	}
+
+	boms := make([]BillOfMaterials, len(bomModels))
+	for i := range bomModels {
+		boms[i] = *bomFromModel(&bomModels[i])
+	}
+	if err := r.loadBOMLines(ctx, schemaName, tenantID, boms); err != nil {
+		return nil, err
+	}
+	return boms, nil
+}
+
+func (r *GORMRepository) loadBOMLines(ctx context.Context, schemaName, tenantID string, boms []BillOfMaterials) error {
+	if len(boms) == 0 {
+		return nil
+	}
+	ids := make([]string, len(boms))
+	byID := make(map[string]*BillOfMaterials, len(boms))
+	for i := range boms {
+		ids[i] = boms[i].ID
+		boms[i].Lines = []BOMLine{}
+		byID[boms[i].ID] = &boms[i]
+	}
+
+	linesTable, err := r.tenantTable(ctx, schemaName, "bill_of_material_lines")
+	if err != nil {
+		return fmt.Errorf("qualify bill of materials lines table: %w", err)
+	}
+	var lineModels []models.BillOfMaterialLine
+	if err := linesTable.
+		Where("bom_id IN ? AND tenant_id = ?", ids, tenantID).
+		Order("line_number ASC").
+		Find(&lineModels).Error; err != nil {
+		return fmt.Errorf("get bill of materials lines: %w", err)
+	}
+	for i := range lineModels {
+		if bom, ok := byID[lineModels[i].BOMID]; ok {
+			bom.Lines = append(bom.Lines, *bomLineFromModel(&lineModels[i]))
+		}
+	}
+	return nil
+}
+
+// DeleteBOM deletes a bill of materials and its lines
+func (r *GORMRepository) DeleteBOM(ctx context.Context, schemaName, tenantID, bomID string) error {
+	db, err := r.tenantTable(ctx, schemaName, "bills_of_materials")
+	if err != nil {
+		return fmt.Errorf("qualify bills of materials table: %w", err)
+	}
+	result := db.Where("id = ? AND tenant_id = ?", bomID, tenantID).Delete(&models.BillOfMaterials{})
+	if result.Error != nil {
+		return fmt.Errorf("delete bill of materials: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBOMNotFound
+	}
+	return nil
+}
+
+// CreateOrder inserts a new assembly order with its lines
+func (r *GORMRepository) CreateOrder(ctx context.Context, schemaName string, order *AssemblyOrder) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		ordersTable, err := database.TenantTable(tx, schemaName, "assembly_orders")
+		if err != nil {
+			return fmt.Errorf("qualify assembly orders table: %w", err)
+		}
+		if err := ordersTable.Create(assemblyOrderToModel(order)).Error; err != nil {
+			return fmt.Errorf("insert assembly order: %w", err)
+		}
+
+		if len(order.Lines) == 0 {
+			return nil
+		}
+
+		linesTable, _ := database.TenantTable(tx, schemaName, "assembly_order_lines")
+		lineModels := make([]models.AssemblyOrderLine, len(order.Lines))
+		for i := range order.Lines {
+			order.Lines[i].AssemblyOrderID = order.ID
+			lineModels[i] = *assemblyOrderLineToModel(&order.Lines[i])
+		}
+		if err := linesTable.Create(&lineModels).Error; err != nil {
+			return fmt.Errorf("insert assembly order line: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetOrder retrieves an assembly order by ID with its lines
+func (r *GORMRepository) GetOrder(ctx context.Context, schemaName, tenantID, orderID string) (*AssemblyOrder, error) {
+	db, err := r.tenantTable(ctx, schemaName, "assembly_orders")
+	if err != nil {
+		return nil, fmt.Errorf("qualify assembly orders table: %w", err)
+	}
+
+	var orderModel models.AssemblyOrder
+	err = db.Where("id = ? AND tenant_id = ?", orderID, tenantID).First(&orderModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAssemblyOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get assembly order: %w", err)
+	}
+
+	linesTable, err := r.tenantTable(ctx, schemaName, "assembly_order_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify assembly order lines table: %w", err)
+	}
+	var lineModels []models.AssemblyOrderLine
+	if err := linesTable.
+		Where("assembly_order_id = ? AND tenant_id = ?", orderID, tenantID).
+		Order("line_number ASC").
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("get assembly order lines: %w", err)
+	}
+
+	order := assemblyOrderFromModel(&orderModel)
+	order.Lines = make([]AssemblyOrderLine, len(lineModels))
+	for i := range lineModels {
+		order.Lines[i] = *assemblyOrderLineFromModel(&lineModels[i])
+	}
+	return order, nil
+}
+
+// ListOrders retrieves assembly orders without lines, newest first
+func (r *GORMRepository) ListOrders(ctx context.Context, schemaName, tenantID string, filter *AssemblyOrderFilter) ([]AssemblyOrder, error) {
+	db, err := r.tenantTable(ctx, schemaName, "assembly_orders")
+	if err != nil {
+		return nil, fmt.Errorf("qualify assembly orders table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", string(filter.Status))
+		}
+		if filter.OrderType != "" {
+			query = query.Where("order_type = ?", string(filter.OrderType))
+		}
+		if filter.ProductID != "" {
+			query = query.Where("product_id = ?", filter.ProductID)
+		}
+		if filter.WarehouseID != "" {
+			query = query.Where("warehouse_id = ?", filter.WarehouseID)
+		}
+	}
+
+	var orderModels []models.AssemblyOrder
+	if err := query.
+		Order("order_date DESC").
+		Order("order_number DESC").
+		Find(&orderModels).Error; err != nil {
+		return nil, fmt.Errorf("list assembly orders: %w", err)
+	}
+
+	orders := make([]AssemblyOrder, len(orderModels))
+	for i := range orderModels {
+		orders[i] = *assemblyOrderFromModel(&orderModels[i])
+	}
+	return orders, nil
+}
+
+// CompleteOrder stores the completion and booked costs of an assembly order
+func (r *GORMRepository) CompleteOrder(ctx context.Context, schemaName string, order *AssemblyOrder) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		for i := range order.Lines {
+			linesTable, err := database.TenantTable(tx, schemaName, "assembly_order_lines")
+			if err != nil {
+				return fmt.Errorf("qualify assembly order lines table: %w", err)
+			}
+			model := assemblyOrderLineToModel(&order.Lines[i])
+			result := linesTable.Where("id = ? AND tenant_id = ? AND assembly_order_id = ?", order.Lines[i].ID, order.TenantID, order.ID).
+				Updates(map[string]interface{}{
+					"unit_cost":  model.UnitCost,
+					"total_cost": model.TotalCost,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("update assembly order line: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("assembly order line %s not found", order.Lines[i].ID)
+			}
+		}
+
+		ordersTable, err := database.TenantTable(tx, schemaName, "assembly_orders")
+		if err != nil {
+			return fmt.Errorf("qualify assembly orders table: %w", err)
+		}
+		model := assemblyOrderToModel(order)
+		result := ordersTable.Where("id = ? AND tenant_id = ?", order.ID, order.TenantID).
+			Updates(map[string]interface{}{
+				"status":                model.Status,
+				"component_cost":        model.ComponentCost,
+				"total_cost":            model.TotalCost,
+				"unit_cost":             model.UnitCost,
+				"costing_method":        model.CostingMethod,
+				"absorption_account_id": model.AbsorptionAccountID,
+				"journal_entry_id":      model.JournalEntryID,
+				"completed_at":          model.CompletedAt,
+				"completed_by":          model.CompletedBy,
+				"updated_at":            model.UpdatedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("complete assembly order: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrAssemblyOrderNotFound
+		}
+		return nil
+	})
+}
+
+// UpdateOrderStatus updates the status of an assembly order
+func (r *GORMRepository) UpdateOrderStatus(ctx context.Context, schemaName, tenantID, orderID string, status AssemblyOrderStatus) error {
+	db, err := r.tenantTable(ctx, schemaName, "assembly_orders")
+	if err != nil {
+		return fmt.Errorf("qualify assembly orders table: %w", err)
+	}
+	result := db.Where("id = ? AND tenant_id = ?", orderID, tenantID).Updates(map[string]interface{}{
+		"status":     string(status),
+		"updated_at": time.Now(),
+	})
+	if result.Error != nil {
+		return fmt.Errorf("update assembly order status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAssemblyOrderNotFound
+	}
+	return nil
+}
+
+// GenerateOrderNumber generates a new assembly order number
+func (r *GORMRepository) GenerateOrderNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	db, err := r.tenantTable(ctx, schemaName, "assembly_orders")
+	if err != nil {
+		return "", fmt.Errorf("qualify assembly orders table: %w", err)
+	}
+
+	var seq int
+	if err := db.
+		Select(`
+			COALESCE(MAX(
+				CASE
+					WHEN order_number ~ ? THEN CAST(SUBSTRING(order_number FROM ?) AS INTEGER)
+					ELSE 0
+				END
+			), 0) + 1
+		`, "AO-[0-9]+$", "AO-([0-9]+)$").
+		Where("tenant_id = ?", tenantID).
+		Scan(&seq).Error; err != nil {
+		return "", fmt.Errorf("generate assembly order number: %w", err)
+	}
+	return fmt.Sprintf("AO-%05d", seq), nil
+}
+
+func insertBOMLinesTx(tx *gorm.DB, schemaName string, bom *BillOfMaterials) error {
+	if len(bom.Lines) == 0 {
+		return nil
+	}
+	linesTable, err := database.TenantTable(tx, schemaName, "bill_of_material_lines")
+	if err != nil {
+		return fmt.Errorf("qualify bill of materials lines table: %w", err)
+	}
+	lineModels := make([]models.BillOfMaterialLine, len(bom.Lines))
+	for i := range bom.Lines {
+		bom.Lines[i].BOMID = bom.ID
+		lineModels[i] = *bomLineToModel(&bom.Lines[i])
+	}
+	if err := linesTable.Create(&lineModels).Error; err != nil {
+		return fmt.Errorf("insert bill of materials line: %w", err)
+	}
+	return nil
+}
+
+func bomToModel(bom *BillOfMaterials) *models.BillOfMaterials {
+	return &models.BillOfMaterials{
+		ID:             bom.ID,
+		TenantID:       bom.TenantID,
+		ProductID:      bom.ProductID,
+		BOMType:        string(bom.BOMType),
+		OutputQuantity: models.Decimal{Decimal: bom.OutputQuantity},
+		LabourCost:     models.Decimal{Decimal: bom.LabourCost},
+		OverheadCost:   models.Decimal{Decimal: bom.OverheadCost},
+		IsActive:       bom.IsActive,
+		Notes:          bom.Notes,
+		CreatedBy:      bom.CreatedBy,
+		CreatedAt:      bom.CreatedAt,
+		UpdatedAt:      bom.UpdatedAt,
+	}
+}
+
+func bomFromModel(bom *models.BillOfMaterials) *BillOfMaterials {
+	return &BillOfMaterials{
+		ID:             bom.ID,
+		TenantID:       bom.TenantID,
+		ProductID:      bom.ProductID,
+		BOMType:        BOMType(bom.BOMType),
+		OutputQuantity: bom.OutputQuantity.Decimal,
+		LabourCost:     bom.LabourCost.Decimal,
+		OverheadCost:   bom.OverheadCost.Decimal,
+		IsActive:       bom.IsActive,
+		Notes:          bom.Notes,
+		CreatedBy:      bom.CreatedBy,
+		CreatedAt:      bom.CreatedAt,
+		UpdatedAt:      bom.UpdatedAt,
+	}
+}
+
+func bomLineToModel(line *BOMLine) *models.BillOfMaterialLine {
+	return &models.BillOfMaterialLine{
+		ID:                 line.ID,
+		TenantID:           line.TenantID,
+		BOMID:              line.BOMID,
+		LineNumber:         line.LineNumber,
+		ComponentProductID: line.ComponentProductID,
+		Quantity:           models.Decimal{Decimal: line.Quantity},
+		Notes:              line.Notes,
+	}
+}
+
+func bomLineFromModel(line *models.BillOfMaterialLine) *BOMLine {
+	return &BOMLine{
+		ID:                 line.ID,
+		TenantID:           line.TenantID,
+		BOMID:              line.BOMID,
+		LineNumber:         line.LineNumber,
+		ComponentProductID: line.ComponentProductID,
+		Quantity:           line.Quantity.Decimal,
+		Notes:              line.Notes,
+	}
+}
+
+func assemblyOrderToModel(order *AssemblyOrder) *models.AssemblyOrder {
+	return &models.AssemblyOrder{
+		ID:                  order.ID,
+		TenantID:            order.TenantID,
+		OrderNumber:         order.OrderNumber,
+		OrderType:           string(order.OrderType),
+		BOMID:               order.BOMID,
+		ProductID:           order.ProductID,
+		WarehouseID:         order.WarehouseID,
+		Quantity:            models.Decimal{Decimal: order.Quantity},
+		OrderDate:           order.OrderDate,
+		Status:              string(order.Status),
+		LotNumber:           nilIfEmpty(order.LotNumber),
+		SerialNumber:        nilIfEmpty(order.SerialNumber),
+		ExpiryDate:          nilIfEmpty(order.ExpiryDate),
+		LabourCost:          models.Decimal{Decimal: order.LabourCost},
+		OverheadCost:        models.Decimal{Decimal: order.OverheadCost},
+		ComponentCost:       models.Decimal{Decimal: order.ComponentCost},
+		TotalCost:           models.Decimal{Decimal: order.TotalCost},
+		UnitCost:            models.Decimal{Decimal: order.UnitCost},
+		CostingMethod:       nilIfEmpty(order.CostingMethod),
+		AbsorptionAccountID: nilIfEmpty(order.AbsorptionAccountID),
+		JournalEntryID:      order.JournalEntryID,
+		Notes:               order.Notes,
+		CompletedAt:         order.CompletedAt,
+		CompletedBy:         nilIfEmpty(order.CompletedBy),
+		CreatedBy:           order.CreatedBy,
+		CreatedAt:           order.CreatedAt,
+		UpdatedAt:           order.UpdatedAt,
+	}
+}
+
+func assemblyOrderFromModel(order *models.AssemblyOrder) *AssemblyOrder {
+	return &AssemblyOrder{
+		ID:                  order.ID,
+		TenantID:            order.TenantID,
+		OrderNumber:         order.OrderNumber,
+		OrderType:           AssemblyOrderType(order.OrderType),
+		BOMID:               order.BOMID,
+		ProductID:           order.ProductID,
+		WarehouseID:         order.WarehouseID,
+		Quantity:            order.Quantity.Decimal,
+		OrderDate:           order.OrderDate,
+		Status:              AssemblyOrderStatus(order.Status),
+		LotNumber:           valueOrEmpty(order.LotNumber),
+		SerialNumber:        valueOrEmpty(order.SerialNumber),
+		ExpiryDate:          dateOrEmpty(order.ExpiryDate),
+		LabourCost:          order.LabourCost.Decimal,
+		OverheadCost:        order.OverheadCost.Decimal,
+		ComponentCost:       order.ComponentCost.Decimal,
+		TotalCost:           order.TotalCost.Decimal,
+		UnitCost:            order.UnitCost.Decimal,
+		CostingMethod:       valueOrEmpty(order.CostingMethod),
+		AbsorptionAccountID: valueOrEmpty(order.AbsorptionAccountID),
+		JournalEntryID:      order.JournalEntryID,
+		Notes:               order.Notes,
+		CompletedAt:         order.CompletedAt,
+		CompletedBy:         valueOrEmpty(order.CompletedBy),
+		CreatedBy:           order.CreatedBy,
+		CreatedAt:           order.CreatedAt,
+		UpdatedAt:           order.UpdatedAt,
+	}
+}
+
+func assemblyOrderLineToModel(line *AssemblyOrderLine) *models.AssemblyOrderLine {
+	return &models.AssemblyOrderLine{
+		ID:              line.ID,
+		TenantID:        line.TenantID,
+		AssemblyOrderID: line.AssemblyOrderID,
+		LineNumber:      line.LineNumber,
+		ProductID:       line.ProductID,
+		Quantity:        models.Decimal{Decimal: line.Quantity},
+		LotNumber:       nilIfEmpty(line.LotNumber),
+		SerialNumber:    nilIfEmpty(line.SerialNumber),
+		ExpiryDate:      nilIfEmpty(line.ExpiryDate),
+		UnitCost:        models.Decimal{Decimal: line.UnitCost},
+		TotalCost:       models.Decimal{Decimal: line.TotalCost},
+	}
+}
+
+func assemblyOrderLineFromModel(line *models.AssemblyOrderLine) *AssemblyOrderLine {
+	return &AssemblyOrderLine{
+		ID:              line.ID,
+		TenantID:        line.TenantID,
+		AssemblyOrderID: line.AssemblyOrderID,
+		LineNumber:      line.LineNumber,
+		ProductID:       line.ProductID,
+		Quantity:        line.Quantity.Decimal,
+		LotNumber:       valueOrEmpty(line.LotNumber),
+		SerialNumber:    valueOrEmpty(line.SerialNumber),
+		ExpiryDate:      dateOrEmpty(line.ExpiryDate),
+		UnitCost:        line.UnitCost.Decimal,
+		TotalCost:       line.TotalCost.Decimal,
+	}
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func dateOrEmpty(value *string) string {
+	date := valueOrEmpty(value)
+	if len(date) > len("2006-01-02") {
+		date = date[:len("2006-01-02")]
+	}
+	return date
+}
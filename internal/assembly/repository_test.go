@@ -0,0 +1,190 @@
+package assembly
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_test"
+	tenantID := "tenant-1"
+	bomID := "bom-1"
+	orderID := "order-1"
+
+	repositories := []struct {
+		name string
+		repo *GORMRepository
+	}{
+		{name: "nil receiver", repo: nil},
+		{name: "nil gorm database", repo: NewGORMRepository(nil)},
+		{name: "nil pool constructor", repo: NewRepository(nil)},
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo *GORMRepository) error
+	}{
+		{name: "WithInventoryLedgerTransaction", run: func(t *testing.T, repo *GORMRepository) error {
+			called := false
+			err := repo.WithInventoryLedgerTransaction(ctx, func(Repository, stockAssembler) error {
+				called = true
+				return nil
+			})
+			assert.False(t, called)
+			return err
+		}},
+		{name: "CreateBOM", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreateBOM(ctx, schemaName, &BillOfMaterials{ID: bomID, TenantID: tenantID})
+		}},
+		{name: "UpdateBOM", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.UpdateBOM(ctx, schemaName, &BillOfMaterials{ID: bomID, TenantID: tenantID})
+		}},
+		{name: "GetBOM", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetBOM(ctx, schemaName, tenantID, bomID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "GetBOMByProduct", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetBOMByProduct(ctx, schemaName, tenantID, "product-1")
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListBOMs", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListBOMs(ctx, schemaName, tenantID, &BOMFilter{BOMType: BOMTypeKit, ActiveOnly: true})
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "DeleteBOM", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.DeleteBOM(ctx, schemaName, tenantID, bomID)
+		}},
+		{name: "CreateOrder", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreateOrder(ctx, schemaName, &AssemblyOrder{ID: orderID, TenantID: tenantID})
+		}},
+		{name: "GetOrder", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetOrder(ctx, schemaName, tenantID, orderID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListOrders", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListOrders(ctx, schemaName, tenantID, &AssemblyOrderFilter{Status: AssemblyOrderStatusDraft})
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "CompleteOrder", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CompleteOrder(ctx, schemaName, &AssemblyOrder{ID: orderID, TenantID: tenantID})
+		}},
+		{name: "UpdateOrderStatus", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.UpdateOrderStatus(ctx, schemaName, tenantID, orderID, AssemblyOrderStatusCanceled)
+		}},
+		{name: "GenerateOrderNumber", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GenerateOrderNumber(ctx, schemaName, tenantID)
+			assert.Empty(t, got)
+			return err
+		}},
+	}
+
+	for _, repository := range repositories {
+		repository := repository
+		t.Run(repository.name, func(t *testing.T) {
+			for _, tt := range tests {
+				tt := tt
+				t.Run(tt.name, func(t *testing.T) {
+					err := tt.run(t, repository.repo)
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), "assembly repository database is not configured")
+				})
+			}
+		})
+	}
+}
+
+func TestAssemblyModelMappingRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC)
+	bom := &BillOfMaterials{
+		ID:             "bom-1",
+		TenantID:       "tenant-1",
+		ProductID:      "product-1",
+		BOMType:        BOMTypeKit,
+		OutputQuantity: decimal.NewFromInt(2),
+		LabourCost:     decimal.RequireFromString("1.5"),
+		OverheadCost:   decimal.RequireFromString("0.5"),
+		IsActive:       true,
+		Notes:          "Gift box",
+		CreatedBy:      "user-1",
+		CreatedAt:      createdAt,
+		UpdatedAt:      createdAt,
+	}
+	assert.Equal(t, bom, bomFromModel(bomToModel(bom)))
+
+	bomLine := &BOMLine{
+		ID:                 "line-1",
+		TenantID:           "tenant-1",
+		BOMID:              "bom-1",
+		LineNumber:         1,
+		ComponentProductID: "component-1",
+		Quantity:           decimal.RequireFromString("0.25"),
+		Notes:              "Cut to size",
+	}
+	assert.Equal(t, bomLine, bomLineFromModel(bomLineToModel(bomLine)))
+
+	completedAt := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.UTC)
+	journalID := "journal-1"
+	order := &AssemblyOrder{
+		ID:                  "order-1",
+		TenantID:            "tenant-1",
+		OrderNumber:         "AO-00001",
+		OrderType:           AssemblyOrderTypeAssembly,
+		BOMID:               "bom-1",
+		ProductID:           "product-1",
+		WarehouseID:         "warehouse-1",
+		Quantity:            decimal.NewFromInt(4),
+		OrderDate:           completedAt,
+		Status:              AssemblyOrderStatusCompleted,
+		LotNumber:           "LOT-1",
+		ExpiryDate:          "2027-06-30",
+		LabourCost:          decimal.NewFromInt(6),
+		OverheadCost:        decimal.NewFromInt(2),
+		ComponentCost:       decimal.NewFromInt(40),
+		TotalCost:           decimal.NewFromInt(48),
+		UnitCost:            decimal.NewFromInt(12),
+		CostingMethod:       "FIFO",
+		AbsorptionAccountID: "absorption",
+		JournalEntryID:      &journalID,
+		CompletedAt:         &completedAt,
+		CompletedBy:         "user-2",
+		CreatedBy:           "user-1",
+		CreatedAt:           createdAt,
+		UpdatedAt:           completedAt,
+	}
+	assert.Equal(t, order, assemblyOrderFromModel(assemblyOrderToModel(order)))
+
+	draft := assemblyOrderToModel(&AssemblyOrder{ID: "order-2"})
+	assert.Nil(t, draft.CostingMethod)
+	assert.Nil(t, draft.AbsorptionAccountID)
+	assert.Nil(t, draft.CompletedBy)
+
+	orderLine := &AssemblyOrderLine{
+		ID:              "order-line-1",
+		TenantID:        "tenant-1",
+		AssemblyOrderID: "order-1",
+		LineNumber:      1,
+		ProductID:       "component-1",
+		Quantity:        decimal.NewFromInt(8),
+		SerialNumber:    "SN-1",
+		UnitCost:        decimal.NewFromInt(5),
+		TotalCost:       decimal.NewFromInt(40),
+	}
+	assert.Equal(t, orderLine, assemblyOrderLineFromModel(assemblyOrderLineToModel(orderLine)))
+
+	model := assemblyOrderLineToModel(&AssemblyOrderLine{ID: "order-line-2"})
+	assert.Nil(t, model.LotNumber)
+	expiry := "2027-06-30T00:00:00Z"
+	model.ExpiryDate = &expiry
+	assert.Equal(t, "2027-06-30", assemblyOrderLineFromModel(model).ExpiryDate)
+}
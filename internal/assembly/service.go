@@ -0,0 +1,509 @@
+package assembly
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+type stockAssembler interface {
+	GetProductByID(ctx context.Context, tenantID, schemaName, productID string) (*inventory.Product, error)
+	GetWarehouseByID(ctx context.Context, tenantID, schemaName, warehouseID string) (*inventory.Warehouse, error)
+	ListWarehouses(ctx context.Context, tenantID, schemaName string, activeOnly bool) ([]inventory.Warehouse, error)
+	GetInventoryValuation(ctx context.Context, tenantID, schemaName, warehouseID, method string) (*inventory.InventoryValuationReport, error)
+	IssueStock(ctx context.Context, tenantID, schemaName string, req *inventory.IssueStockRequest) (*inventory.IssueStockResult, error)
+	PostAssembly(ctx context.Context, tenantID, schemaName string, req *inventory.PostAssemblyRequest) (*inventory.PostAssemblyResult, error)
+	HasSourceMovements(ctx context.Context, tenantID, schemaName, sourceType, sourceID string) (bool, error)
+}
+
+// Service provides bills of materials, assembly orders and kit issues
+type Service struct {
+	repo  Repository
+	stock stockAssembler
+}
+
+// NewService creates a new assembly service with an ORM-backed repository.
+func NewService(db *pgxpool.Pool, inventoryService *inventory.Service) *Service {
+	return &Service{
+		repo:  NewRepository(db),
+		stock: inventoryService,
+	}
+}
+
+// NewServiceWithGORM creates an assembly service whose repository and
+// inventory service share an existing GORM handle, so kit issues can join a
+// caller's transaction.
+func NewServiceWithGORM(db *gorm.DB) *Service {
+	return &Service{
+		repo:  NewGORMRepository(db),
+		stock: inventory.NewServiceWithGORM(db),
+	}
+}
+
+// NewServiceWithRepository creates a new assembly service with custom dependencies
+func NewServiceWithRepository(repo Repository, stock stockAssembler) *Service {
+	return &Service{
+		repo:  repo,
+		stock: stock,
+	}
+}
+
+// CreateBOM creates the bill of materials of a product. A product has at most
+// one bill of materials, and a component may itself have one, which makes the
+// bill multi-level. Bills that would make a product a component of itself are
+// rejected.
+func (s *Service) CreateBOM(ctx context.Context, tenantID, schemaName string, req *CreateBOMRequest) (*BillOfMaterials, error) {
+	if req == nil {
+		return nil, fmt.Errorf("bill of materials request is required")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to create a bill of materials")
+	}
+	productID := strings.TrimSpace(req.ProductID)
+	if productID == "" {
+		return nil, fmt.Errorf("product_id is required")
+	}
+	if _, err := s.repo.GetBOMByProduct(ctx, schemaName, tenantID, productID); err == nil {
+		return nil, fmt.Errorf("product already has a bill of materials")
+	} else if !errors.Is(err, ErrBOMNotFound) {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+
+	now := time.Now()
+	bom := &BillOfMaterials{
+		ID:             uuid.New().String(),
+		TenantID:       tenantID,
+		ProductID:      productID,
+		BOMType:        req.BOMType,
+		OutputQuantity: req.OutputQuantity,
+		LabourCost:     req.LabourCost,
+		OverheadCost:   req.OverheadCost,
+		IsActive:       true,
+		Notes:          req.Notes,
+		CreatedBy:      userID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if err := s.prepareBOM(ctx, tenantID, schemaName, bom, req.Lines); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateBOM(ctx, schemaName, bom); err != nil {
+		return nil, fmt.Errorf("create bill of materials: %w", err)
+	}
+	return bom, nil
+}
+
+// UpdateBOM replaces the components and costs of a bill of materials
+func (s *Service) UpdateBOM(ctx context.Context, tenantID, schemaName, bomID string, req *UpdateBOMRequest) (*BillOfMaterials, error) {
+	if req == nil {
+		return nil, fmt.Errorf("bill of materials request is required")
+	}
+	bom, err := s.repo.GetBOM(ctx, schemaName, tenantID, bomID)
+	if err != nil {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+	bom.BOMType = req.BOMType
+	bom.OutputQuantity = req.OutputQuantity
+	bom.LabourCost = req.LabourCost
+	bom.OverheadCost = req.OverheadCost
+	bom.Notes = req.Notes
+	if req.IsActive != nil {
+		bom.IsActive = *req.IsActive
+	}
+	bom.UpdatedAt = time.Now()
+	if err := s.prepareBOM(ctx, tenantID, schemaName, bom, req.Lines); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateBOM(ctx, schemaName, bom); err != nil {
+		return nil, fmt.Errorf("update bill of materials: %w", err)
+	}
+	return bom, nil
+}
+
+// GetBOM retrieves a bill of materials by ID with its lines
+func (s *Service) GetBOM(ctx context.Context, tenantID, schemaName, bomID string) (*BillOfMaterials, error) {
+	bom, err := s.repo.GetBOM(ctx, schemaName, tenantID, bomID)
+	if err != nil {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+	return bom, nil
+}
+
+// ListBOMs retrieves bills of materials with optional filtering
+func (s *Service) ListBOMs(ctx context.Context, tenantID, schemaName string, filter *BOMFilter) ([]BillOfMaterials, error) {
+	boms, err := s.repo.ListBOMs(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list bills of materials: %w", err)
+	}
+	return boms, nil
+}
+
+// DeleteBOM deletes a bill of materials that no assembly order uses yet.
+// Bills with assembly history can be deactivated instead.
+func (s *Service) DeleteBOM(ctx context.Context, tenantID, schemaName, bomID string) error {
+	bom, err := s.repo.GetBOM(ctx, schemaName, tenantID, bomID)
+	if err != nil {
+		return fmt.Errorf("get bill of materials: %w", err)
+	}
+	orders, err := s.repo.ListOrders(ctx, schemaName, tenantID, &AssemblyOrderFilter{ProductID: bom.ProductID})
+	if err != nil {
+		return fmt.Errorf("list assembly orders: %w", err)
+	}
+	for _, order := range orders {
+		if order.BOMID == bom.ID {
+			return fmt.Errorf("bill of materials is used by assembly order %s; deactivate it instead", order.OrderNumber)
+		}
+	}
+	if err := s.repo.DeleteBOM(ctx, schemaName, tenantID, bomID); err != nil {
+		return fmt.Errorf("delete bill of materials: %w", err)
+	}
+	return nil
+}
+
+// Explode breaks a bill of materials down through every level for a
+// quantity of its product and rolls up its cost. Leaf components are valued
+// at the valuation method, falling back to the purchase price, and
+// sub-assemblies and kits at the rolled-up cost of their own bills.
+func (s *Service) Explode(ctx context.Context, tenantID, schemaName, bomID string, quantity decimal.Decimal, valuationMethod string) (*BOMExplosion, error) {
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for bills of materials")
+	}
+	bom, err := s.repo.GetBOM(ctx, schemaName, tenantID, bomID)
+	if err != nil {
+		return nil, fmt.Errorf("get bill of materials: %w", err)
+	}
+	if quantity.IsZero() {
+		quantity = bom.OutputQuantity
+	}
+	if !quantity.IsPositive() {
+		return nil, fmt.Errorf("quantity must be positive")
+	}
+	index, err := s.bomIndex(ctx, tenantID, schemaName)
+	if err != nil {
+		return nil, err
+	}
+	index[bom.ProductID] = bom
+	valuation, err := s.stock.GetInventoryValuation(ctx, tenantID, schemaName, "", valuationMethod)
+	if err != nil {
+		return nil, fmt.Errorf("get inventory valuation: %w", err)
+	}
+	costs := newBOMCoster(ctx, s.stock, tenantID, schemaName, index, valuation)
+
+	product, err := costs.product(bom.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	explosion := &BOMExplosion{
+		BOMID:           bom.ID,
+		ProductID:       bom.ProductID,
+		ProductCode:     product.Code,
+		ProductName:     product.Name,
+		BOMType:         bom.BOMType,
+		Quantity:        quantity,
+		ValuationMethod: valuation.ValuationMethod,
+		Lines:           []BOMExplosionLine{},
+		MaterialCost:    decimal.Zero,
+	}
+	if err := costs.explode(explosion, bom, quantity, 1); err != nil {
+		return nil, err
+	}
+	factor := quantity.Div(bom.OutputQuantity)
+	for _, line := range explosion.Lines {
+		if line.Level == 1 {
+			explosion.MaterialCost = explosion.MaterialCost.Add(line.TotalCost)
+		}
+	}
+	explosion.LabourCost = bom.LabourCost.Mul(factor).Round(2)
+	explosion.OverheadCost = bom.OverheadCost.Mul(factor).Round(2)
+	explosion.TotalCost = explosion.MaterialCost.Add(explosion.LabourCost).Add(explosion.OverheadCost)
+	explosion.UnitCost = explosion.TotalCost.Div(quantity).Round(8)
+	return explosion, nil
+}
+
+// prepareBOM validates a new or updated bill of materials and replaces its lines.
+func (s *Service) prepareBOM(ctx context.Context, tenantID, schemaName string, bom *BillOfMaterials, lines []CreateBOMLineRequest) error {
+	if s.stock == nil {
+		return fmt.Errorf("inventory service is unavailable for bills of materials")
+	}
+	if bom.BOMType == "" {
+		bom.BOMType = BOMTypeAssembly
+	}
+	if bom.BOMType != BOMTypeAssembly && bom.BOMType != BOMTypeKit {
+		return fmt.Errorf("invalid bom_type: %s", bom.BOMType)
+	}
+	if bom.OutputQuantity.IsZero() {
+		bom.OutputQuantity = decimal.NewFromInt(1)
+	}
+	if bom.OutputQuantity.IsNegative() {
+		return fmt.Errorf("output_quantity must be positive")
+	}
+	if bom.LabourCost.IsNegative() || bom.OverheadCost.IsNegative() {
+		return fmt.Errorf("labour_cost and overhead_cost cannot be negative")
+	}
+	if bom.BOMType == BOMTypeKit && (bom.LabourCost.IsPositive() || bom.OverheadCost.IsPositive()) {
+		return fmt.Errorf("kits are not assembled and cannot carry labour or overhead cost")
+	}
+	if len(lines) == 0 {
+		return fmt.Errorf("at least one component is required")
+	}
+
+	product, err := s.stock.GetProductByID(ctx, tenantID, schemaName, bom.ProductID)
+	if err != nil {
+		return fmt.Errorf("get product: %w", err)
+	}
+	if product.ProductType != inventory.ProductTypeGoods {
+		return fmt.Errorf("product %s is not a goods product", product.Code)
+	}
+	if bom.BOMType == BOMTypeAssembly && !product.TrackInventory {
+		return fmt.Errorf("assembled product %s must track inventory", product.Code)
+	}
+	if bom.BOMType == BOMTypeKit && product.TrackInventory {
+		return fmt.Errorf("kit product %s must not track inventory; its components are issued instead", product.Code)
+	}
+
+	index, err := s.bomIndex(ctx, tenantID, schemaName)
+	if err != nil {
+		return err
+	}
+	bom.Lines = make([]BOMLine, 0, len(lines))
+	seen := make(map[string]bool, len(lines))
+	for i, line := range lines {
+		componentID := strings.TrimSpace(line.ComponentProductID)
+		if componentID == "" {
+			return fmt.Errorf("line %d: component_product_id is required", i+1)
+		}
+		if !line.Quantity.IsPositive() {
+			return fmt.Errorf("line %d: quantity must be positive", i+1)
+		}
+		if seen[componentID] {
+			return fmt.Errorf("line %d: component is listed more than once", i+1)
+		}
+		seen[componentID] = true
+		component, err := s.stock.GetProductByID(ctx, tenantID, schemaName, componentID)
+		if err != nil {
+			return fmt.Errorf("line %d: get product: %w", i+1, err)
+		}
+		if component.ProductType != inventory.ProductTypeGoods {
+			return fmt.Errorf("line %d: component %s is not a goods product", i+1, component.Code)
+		}
+		if !component.TrackInventory {
+			if sub, ok := index[componentID]; !ok || sub.BOMType != BOMTypeKit {
+				return fmt.Errorf("line %d: component %s must track inventory or be a kit", i+1, component.Code)
+			}
+		}
+		bom.Lines = append(bom.Lines, BOMLine{
+			ID:                 uuid.New().String(),
+			TenantID:           tenantID,
+			BOMID:              bom.ID,
+			LineNumber:         i + 1,
+			ComponentProductID: componentID,
+			Quantity:           line.Quantity,
+			Notes:              line.Notes,
+		})
+	}
+
+	index[bom.ProductID] = bom
+	if usesProduct(index, bom.ProductID, bom.ProductID, 0) {
+		return fmt.Errorf("bill of materials would make product %s a component of itself", product.Code)
+	}
+	return nil
+}
+
+// bomIndex returns the active bills of materials keyed by product.
+func (s *Service) bomIndex(ctx context.Context, tenantID, schemaName string) (map[string]*BillOfMaterials, error) {
+	boms, err := s.repo.ListBOMs(ctx, schemaName, tenantID, &BOMFilter{ActiveOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("list bills of materials: %w", err)
+	}
+	index := make(map[string]*BillOfMaterials, len(boms))
+	for i := range boms {
+		index[boms[i].ProductID] = &boms[i]
+	}
+	return index, nil
+}
+
+// usesProduct reports whether the bill of materials of parentID contains
+// productID at any level. Nesting deeper than maxBOMDepth counts as a cycle.
+func usesProduct(index map[string]*BillOfMaterials, parentID, productID string, depth int) bool {
+	if depth > maxBOMDepth {
+		return true
+	}
+	bom, ok := index[parentID]
+	if !ok {
+		return false
+	}
+	for _, line := range bom.Lines {
+		if line.ComponentProductID == productID || usesProduct(index, line.ComponentProductID, productID, depth+1) {
+			return true
+		}
+	}
+	return false
+}
+
+// explodeKits breaks the components of a bill of materials down into stocked
+// products, expanding kit components into their own components. Quantities
+// are summed per product in first-seen order.
+func explodeKits(index map[string]*BillOfMaterials, bom *BillOfMaterials, quantity decimal.Decimal) ([]AssemblyOrderLine, error) {
+	lines := []AssemblyOrderLine{}
+	positions := make(map[string]int)
+	var walk func(bom *BillOfMaterials, quantity decimal.Decimal, depth int) error
+	walk = func(bom *BillOfMaterials, quantity decimal.Decimal, depth int) error {
+		if depth > maxBOMDepth {
+			return fmt.Errorf("bill of materials is nested more than %d levels deep", maxBOMDepth)
+		}
+		factor := quantity.Div(bom.OutputQuantity)
+		for _, line := range bom.Lines {
+			needed := line.Quantity.Mul(factor)
+			if sub, ok := index[line.ComponentProductID]; ok && sub.BOMType == BOMTypeKit {
+				if err := walk(sub, needed, depth+1); err != nil {
+					return err
+				}
+				continue
+			}
+			if position, ok := positions[line.ComponentProductID]; ok {
+				lines[position].Quantity = lines[position].Quantity.Add(needed)
+				continue
+			}
+			positions[line.ComponentProductID] = len(lines)
+			lines = append(lines, AssemblyOrderLine{
+				LineNumber: len(lines) + 1,
+				ProductID:  line.ComponentProductID,
+				Quantity:   needed,
+			})
+		}
+		return nil
+	}
+	if err := walk(bom, quantity, 0); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// bomCoster values components for explosions and rolls up the cost of
+// sub-assemblies and kits, caching products and rolled-up unit costs.
+type bomCoster struct {
+	ctx        context.Context
+	stock      stockAssembler
+	tenantID   string
+	schemaName string
+	index      map[string]*BillOfMaterials
+	valued     map[string]decimal.Decimal
+	products   map[string]*inventory.Product
+	rolledUp   map[string]decimal.Decimal
+}
+
+func newBOMCoster(ctx context.Context, stock stockAssembler, tenantID, schemaName string, index map[string]*BillOfMaterials, valuation *inventory.InventoryValuationReport) *bomCoster {
+	valued := make(map[string]decimal.Decimal, len(valuation.Lines))
+	for _, line := range valuation.Lines {
+		if line.Quantity.IsPositive() {
+			valued[line.ProductID] = line.UnitCost
+		}
+	}
+	return &bomCoster{
+		ctx:        ctx,
+		stock:      stock,
+		tenantID:   tenantID,
+		schemaName: schemaName,
+		index:      index,
+		valued:     valued,
+		products:   make(map[string]*inventory.Product),
+		rolledUp:   make(map[string]decimal.Decimal),
+	}
+}
+
+func (c *bomCoster) product(productID string) (*inventory.Product, error) {
+	if product, ok := c.products[productID]; ok {
+		return product, nil
+	}
+	product, err := c.stock.GetProductByID(c.ctx, c.tenantID, c.schemaName, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product: %w", err)
+	}
+	c.products[productID] = product
+	return product, nil
+}
+
+// unitCost returns the rolled-up unit cost of a product with a bill of
+// materials and the valued unit cost of any other product.
+func (c *bomCoster) unitCost(productID string, depth int) (decimal.Decimal, error) {
+	if cost, ok := c.rolledUp[productID]; ok {
+		return cost, nil
+	}
+	bom, ok := c.index[productID]
+	if !ok {
+		if cost, ok := c.valued[productID]; ok {
+			return cost, nil
+		}
+		product, err := c.product(productID)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		return product.PurchasePrice, nil
+	}
+	if depth > maxBOMDepth {
+		return decimal.Zero, fmt.Errorf("bill of materials is nested more than %d levels deep", maxBOMDepth)
+	}
+	total := bom.LabourCost.Add(bom.OverheadCost)
+	for _, line := range bom.Lines {
+		cost, err := c.unitCost(line.ComponentProductID, depth+1)
+		if err != nil {
+			return decimal.Zero, err
+		}
+		total = total.Add(line.Quantity.Mul(cost))
+	}
+	cost := total.Div(bom.OutputQuantity).Round(8)
+	c.rolledUp[productID] = cost
+	return cost, nil
+}
+
+// explode appends the components of a bill of materials needed for quantity,
+// each followed by its own components when it has a bill of materials.
+func (c *bomCoster) explode(explosion *BOMExplosion, bom *BillOfMaterials, quantity decimal.Decimal, level int) error {
+	if level > maxBOMDepth {
+		return fmt.Errorf("bill of materials is nested more than %d levels deep", maxBOMDepth)
+	}
+	factor := quantity.Div(bom.OutputQuantity)
+	for _, line := range bom.Lines {
+		product, err := c.product(line.ComponentProductID)
+		if err != nil {
+			return err
+		}
+		unitCost, err := c.unitCost(line.ComponentProductID, level)
+		if err != nil {
+			return err
+		}
+		needed := line.Quantity.Mul(factor)
+		entry := BOMExplosionLine{
+			Level:           level,
+			ParentProductID: bom.ProductID,
+			ProductID:       product.ID,
+			ProductCode:     product.Code,
+			ProductName:     product.Name,
+			Unit:            product.Unit,
+			Quantity:        needed,
+			UnitCost:        unitCost,
+			TotalCost:       needed.Mul(unitCost).Round(2),
+		}
+		sub, hasBOM := c.index[line.ComponentProductID]
+		if hasBOM {
+			entry.BOMType = sub.BOMType
+		}
+		explosion.Lines = append(explosion.Lines, entry)
+		if hasBOM {
+			if err := c.explode(explosion, sub, needed, level+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
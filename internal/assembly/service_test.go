@@ -0,0 +1,785 @@
+package assembly
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+const (
+	testTenantID    = "tenant-1"
+	testSchemaName  = "tenant_test"
+	testWarehouseID = "22222222-2222-4222-8222-222222222222"
+	testFinishedID  = "finished"
+	testSubID       = "sub-assembly"
+	testKitID       = "kit"
+	testBoltID      = "bolt"
+	testPanelID     = "panel"
+	testServiceID   = "service"
+)
+
+type mockRepository struct {
+	boms   map[string]*BillOfMaterials
+	orders map[string]*AssemblyOrder
+	seq    int
+	stock  *fakeStock
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		boms:   make(map[string]*BillOfMaterials),
+		orders: make(map[string]*AssemblyOrder),
+	}
+}
+
+func (m *mockRepository) CreateBOM(_ context.Context, _ string, bom *BillOfMaterials) error {
+	m.boms[bom.ID] = cloneBOM(bom)
+	return nil
+}
+
+func (m *mockRepository) UpdateBOM(_ context.Context, _ string, bom *BillOfMaterials) error {
+	m.boms[bom.ID] = cloneBOM(bom)
+	return nil
+}
+
+func (m *mockRepository) GetBOM(_ context.Context, _, tenantID, bomID string) (*BillOfMaterials, error) {
+	bom, ok := m.boms[bomID]
+	if !ok || bom.TenantID != tenantID {
+		return nil, ErrBOMNotFound
+	}
+	return cloneBOM(bom), nil
+}
+
+func (m *mockRepository) GetBOMByProduct(_ context.Context, _, tenantID, productID string) (*BillOfMaterials, error) {
+	for _, bom := range m.boms {
+		if bom.TenantID == tenantID && bom.ProductID == productID {
+			return cloneBOM(bom), nil
+		}
+	}
+	return nil, ErrBOMNotFound
+}
+
+func (m *mockRepository) ListBOMs(_ context.Context, _, tenantID string, filter *BOMFilter) ([]BillOfMaterials, error) {
+	result := []BillOfMaterials{}
+	for _, bom := range m.boms {
+		if bom.TenantID != tenantID {
+			continue
+		}
+		if filter != nil && filter.ActiveOnly && !bom.IsActive {
+			continue
+		}
+		if filter != nil && filter.BOMType != "" && bom.BOMType != filter.BOMType {
+			continue
+		}
+		if filter != nil && filter.ProductID != "" && bom.ProductID != filter.ProductID {
+			continue
+		}
+		result = append(result, *cloneBOM(bom))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ProductID < result[j].ProductID })
+	return result, nil
+}
+
+func (m *mockRepository) DeleteBOM(_ context.Context, _, tenantID, bomID string) error {
+	bom, ok := m.boms[bomID]
+	if !ok || bom.TenantID != tenantID {
+		return ErrBOMNotFound
+	}
+	delete(m.boms, bomID)
+	return nil
+}
+
+func (m *mockRepository) CreateOrder(_ context.Context, _ string, order *AssemblyOrder) error {
+	m.orders[order.ID] = cloneOrder(order)
+	return nil
+}
+
+func (m *mockRepository) GetOrder(_ context.Context, _, tenantID, orderID string) (*AssemblyOrder, error) {
+	order, ok := m.orders[orderID]
+	if !ok || order.TenantID != tenantID {
+		return nil, ErrAssemblyOrderNotFound
+	}
+	return cloneOrder(order), nil
+}
+
+func (m *mockRepository) ListOrders(_ context.Context, _, tenantID string, filter *AssemblyOrderFilter) ([]AssemblyOrder, error) {
+	result := []AssemblyOrder{}
+	for _, order := range m.orders {
+		if order.TenantID != tenantID {
+			continue
+		}
+		if filter != nil && filter.ProductID != "" && order.ProductID != filter.ProductID {
+			continue
+		}
+		if filter != nil && filter.Status != "" && order.Status != filter.Status {
+			continue
+		}
+		result = append(result, *order)
+	}
+	return result, nil
+}
+
+func (m *mockRepository) CompleteOrder(_ context.Context, _ string, order *AssemblyOrder) error {
+	m.orders[order.ID] = cloneOrder(order)
+	return nil
+}
+
+func (m *mockRepository) UpdateOrderStatus(_ context.Context, _, tenantID, orderID string, status AssemblyOrderStatus) error {
+	order, ok := m.orders[orderID]
+	if !ok || order.TenantID != tenantID {
+		return ErrAssemblyOrderNotFound
+	}
+	order.Status = status
+	return nil
+}
+
+func (m *mockRepository) GenerateOrderNumber(context.Context, string, string) (string, error) {
+	m.seq++
+	return fmt.Sprintf("AO-%05d", m.seq), nil
+}
+
+// WithInventoryLedgerTransaction drops the stock issued by fn when it fails.
+func (m *mockRepository) WithInventoryLedgerTransaction(_ context.Context, fn func(txRepo Repository, stock stockAssembler) error) error {
+	issued := len(m.stock.issued)
+	if err := fn(m, m.stock); err != nil {
+		m.stock.issued = m.stock.issued[:issued]
+		return err
+	}
+	return nil
+}
+
+func cloneBOM(bom *BillOfMaterials) *BillOfMaterials {
+	copyBOM := *bom
+	copyBOM.Lines = append([]BOMLine(nil), bom.Lines...)
+	return &copyBOM
+}
+
+func cloneOrder(order *AssemblyOrder) *AssemblyOrder {
+	copyOrder := *order
+	copyOrder.Lines = append([]AssemblyOrderLine(nil), order.Lines...)
+	return &copyOrder
+}
+
+type fakeStock struct {
+	products       map[string]*inventory.Product
+	unitCosts      map[string]decimal.Decimal
+	issued         []inventory.IssueStockRequest
+	assembled      *inventory.PostAssemblyRequest
+	issueErr       error
+	issueErrFor    string
+	sourceCheckErr error
+}
+
+func newFakeStock() *fakeStock {
+	goods := func(id, code string, tracked bool, purchasePrice int64) *inventory.Product {
+		return &inventory.Product{
+			ID:                id,
+			Code:              code,
+			Name:              code + " product",
+			Unit:              "pcs",
+			ProductType:       inventory.ProductTypeGoods,
+			TrackInventory:    tracked,
+			PurchasePrice:     decimal.NewFromInt(purchasePrice),
+			PurchaseAccountID: "cogs-" + id,
+		}
+	}
+	return &fakeStock{
+		products: map[string]*inventory.Product{
+			testFinishedID: goods(testFinishedID, "FIN", true, 0),
+			testSubID:      goods(testSubID, "SUB", true, 0),
+			testKitID:      goods(testKitID, "KIT", false, 0),
+			testBoltID:     goods(testBoltID, "BOLT", true, 2),
+			testPanelID:    goods(testPanelID, "PANEL", true, 5),
+			testServiceID:  {ID: testServiceID, Code: "SRV", ProductType: inventory.ProductTypeService},
+		},
+		unitCosts: map[string]decimal.Decimal{
+			testBoltID: decimal.RequireFromString("2.5"),
+		},
+	}
+}
+
+func (f *fakeStock) GetProductByID(_ context.Context, _, _, productID string) (*inventory.Product, error) {
+	product, ok := f.products[productID]
+	if !ok {
+		return nil, fmt.Errorf("product not found")
+	}
+	return product, nil
+}
+
+func (f *fakeStock) GetWarehouseByID(_ context.Context, _, _, warehouseID string) (*inventory.Warehouse, error) {
+	if warehouseID != testWarehouseID {
+		return nil, fmt.Errorf("warehouse not found")
+	}
+	return &inventory.Warehouse{ID: warehouseID, Code: "MAIN"}, nil
+}
+
+func (f *fakeStock) ListWarehouses(context.Context, string, string, bool) ([]inventory.Warehouse, error) {
+	return []inventory.Warehouse{
+		{ID: "other", Code: "OTHER", IsActive: true},
+		{ID: testWarehouseID, Code: "MAIN", IsDefault: true, IsActive: true},
+	}, nil
+}
+
+func (f *fakeStock) GetInventoryValuation(_ context.Context, _, _, _, method string) (*inventory.InventoryValuationReport, error) {
+	if method == "" {
+		method = inventory.InventoryValuationMethodStandardCost
+	}
+	report := &inventory.InventoryValuationReport{ValuationMethod: method}
+	for productID, unitCost := range f.unitCosts {
+		report.Lines = append(report.Lines, inventory.InventoryValuationLine{ProductID: productID, Quantity: decimal.NewFromInt(10), UnitCost: unitCost})
+	}
+	return report, nil
+}
+
+func (f *fakeStock) IssueStock(_ context.Context, _, _ string, req *inventory.IssueStockRequest) (*inventory.IssueStockResult, error) {
+	if f.issueErr != nil && (f.issueErrFor == "" || f.issueErrFor == req.ProductID) {
+		return nil, f.issueErr
+	}
+	f.issued = append(f.issued, *req)
+	quantity := decimal.RequireFromString(req.Quantity)
+	unitCost := f.unitCost(req.ProductID)
+	return &inventory.IssueStockResult{
+		ProductID:     req.ProductID,
+		WarehouseID:   req.WarehouseID,
+		Quantity:      quantity,
+		CostingMethod: "FIFO",
+		UnitCost:      unitCost,
+		TotalCost:     quantity.Mul(unitCost),
+		Accounting:    &inventory.InventoryIssueAccounting{Posted: true, JournalID: "journal-" + req.ProductID},
+	}, nil
+}
+
+func (f *fakeStock) HasSourceMovements(_ context.Context, _, _, sourceType, sourceID string) (bool, error) {
+	if f.sourceCheckErr != nil {
+		return false, f.sourceCheckErr
+	}
+	for _, issued := range f.issued {
+		if issued.SourceType == sourceType && issued.SourceID == sourceID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PostAssembly issues consumed lines at their unit cost and splits the total
+// over the produced lines by weight, or evenly without weights.
+func (f *fakeStock) PostAssembly(_ context.Context, _, _ string, req *inventory.PostAssemblyRequest) (*inventory.PostAssemblyResult, error) {
+	f.assembled = req
+	result := &inventory.PostAssemblyResult{
+		WarehouseID:    req.WarehouseID,
+		CostingMethod:  "FIFO",
+		AdditionalCost: req.AdditionalCost,
+		JournalID:      "journal-assembly",
+	}
+	for _, line := range req.Consumed {
+		unitCost := f.unitCost(line.ProductID)
+		result.Consumed = append(result.Consumed, inventory.PostAssemblyLineCost{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  unitCost,
+			TotalCost: line.Quantity.Mul(unitCost),
+		})
+		result.ConsumedCost = result.ConsumedCost.Add(line.Quantity.Mul(unitCost))
+	}
+	result.TotalCost = result.ConsumedCost.Add(req.AdditionalCost)
+	totalWeight := decimal.Zero
+	for _, line := range req.Produced {
+		totalWeight = totalWeight.Add(line.CostWeight)
+	}
+	for _, line := range req.Produced {
+		share := decimal.NewFromInt(1).Div(decimal.NewFromInt(int64(len(req.Produced))))
+		if totalWeight.IsPositive() {
+			share = line.CostWeight.Div(totalWeight)
+		}
+		totalCost := result.TotalCost.Mul(share).Round(2)
+		result.Produced = append(result.Produced, inventory.PostAssemblyLineCost{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+			UnitCost:  totalCost.Div(line.Quantity),
+			TotalCost: totalCost,
+		})
+	}
+	return result, nil
+}
+
+func (f *fakeStock) unitCost(productID string) decimal.Decimal {
+	if unitCost, ok := f.unitCosts[productID]; ok {
+		return unitCost
+	}
+	return f.products[productID].PurchasePrice
+}
+
+func createTestBOM(t *testing.T, svc *Service, req *CreateBOMRequest) *BillOfMaterials {
+	t.Helper()
+	req.UserID = "user-1"
+	bom, err := svc.CreateBOM(context.Background(), testTenantID, testSchemaName, req)
+	require.NoError(t, err)
+	return bom
+}
+
+// newTestService sets up a finished product built from one sub-assembly, two
+// panels and one kit. The sub-assembly is two bolts plus 1.00 labour and the
+// kit is one panel and one bolt.
+func newTestService(t *testing.T) (*Service, *mockRepository, *fakeStock) {
+	t.Helper()
+	repo := newMockRepository()
+	stock := newFakeStock()
+	repo.stock = stock
+	svc := NewServiceWithRepository(repo, stock)
+	createTestBOM(t, svc, &CreateBOMRequest{
+		ProductID:  testSubID,
+		LabourCost: decimal.NewFromInt(1),
+		Lines:      []CreateBOMLineRequest{{ComponentProductID: testBoltID, Quantity: decimal.NewFromInt(2)}},
+	})
+	createTestBOM(t, svc, &CreateBOMRequest{
+		ProductID: testKitID,
+		BOMType:   BOMTypeKit,
+		Lines: []CreateBOMLineRequest{
+			{ComponentProductID: testPanelID, Quantity: decimal.NewFromInt(1)},
+			{ComponentProductID: testBoltID, Quantity: decimal.NewFromInt(1)},
+		},
+	})
+	createTestBOM(t, svc, &CreateBOMRequest{
+		ProductID:  testFinishedID,
+		LabourCost: decimal.NewFromInt(3),
+		Lines: []CreateBOMLineRequest{
+			{ComponentProductID: testSubID, Quantity: decimal.NewFromInt(1)},
+			{ComponentProductID: testPanelID, Quantity: decimal.NewFromInt(2)},
+			{ComponentProductID: testKitID, Quantity: decimal.NewFromInt(1)},
+		},
+	})
+	return svc, repo, stock
+}
+
+func bomForProduct(t *testing.T, repo *mockRepository, productID string) *BillOfMaterials {
+	t.Helper()
+	bom, err := repo.GetBOMByProduct(context.Background(), testSchemaName, testTenantID, productID)
+	require.NoError(t, err)
+	return bom
+}
+
+func TestNewServiceWithGORM(t *testing.T) {
+	db := &gorm.DB{}
+	svc := NewServiceWithGORM(db)
+	require.NotNil(t, svc)
+	repo, ok := svc.repo.(*GORMRepository)
+	require.True(t, ok)
+	assert.Same(t, db, repo.db)
+	assert.IsType(t, &inventory.Service{}, svc.stock)
+}
+
+func TestService_CreateBOM(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+
+	bom := bomForProduct(t, repo, testFinishedID)
+	assert.Equal(t, BOMTypeAssembly, bom.BOMType)
+	assert.True(t, bom.IsActive)
+	assert.True(t, bom.OutputQuantity.Equal(decimal.NewFromInt(1)))
+	require.Len(t, bom.Lines, 3)
+	assert.Equal(t, 3, bom.Lines[2].LineNumber)
+	assert.Equal(t, testKitID, bom.Lines[2].ComponentProductID)
+
+	list, err := svc.ListBOMs(context.Background(), testTenantID, testSchemaName, &BOMFilter{BOMType: BOMTypeKit})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, testKitID, list[0].ProductID)
+}
+
+func TestService_CreateBOMValidation(t *testing.T) {
+	line := func(productID string, quantity int64) []CreateBOMLineRequest {
+		return []CreateBOMLineRequest{{ComponentProductID: productID, Quantity: decimal.NewFromInt(quantity)}}
+	}
+	tests := []struct {
+		name    string
+		req     *CreateBOMRequest
+		wantErr string
+	}{
+		{name: "nil request", wantErr: "request is required"},
+		{name: "missing user", req: &CreateBOMRequest{ProductID: testPanelID}, wantErr: "user id is required"},
+		{name: "missing product", req: &CreateBOMRequest{UserID: "user-1"}, wantErr: "product_id is required"},
+		{name: "duplicate product", req: &CreateBOMRequest{ProductID: testSubID, UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "already has a bill of materials"},
+		{name: "invalid type", req: &CreateBOMRequest{ProductID: testPanelID, BOMType: "RECIPE", UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "invalid bom_type"},
+		{name: "negative output", req: &CreateBOMRequest{ProductID: testPanelID, OutputQuantity: decimal.NewFromInt(-1), UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "output_quantity must be positive"},
+		{name: "negative labour", req: &CreateBOMRequest{ProductID: testPanelID, LabourCost: decimal.NewFromInt(-1), UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "cannot be negative"},
+		{name: "kit with overhead", req: &CreateBOMRequest{ProductID: testPanelID, BOMType: BOMTypeKit, OverheadCost: decimal.NewFromInt(1), UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "cannot carry labour or overhead"},
+		{name: "no components", req: &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1"}, wantErr: "at least one component"},
+		{name: "service product", req: &CreateBOMRequest{ProductID: testServiceID, UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "not a goods product"},
+		{name: "tracked kit product", req: &CreateBOMRequest{ProductID: testPanelID, BOMType: BOMTypeKit, UserID: "user-1", Lines: line(testBoltID, 1)}, wantErr: "must not track inventory"},
+		{name: "missing component", req: &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1", Lines: []CreateBOMLineRequest{{Quantity: decimal.NewFromInt(1)}}}, wantErr: "line 1: component_product_id is required"},
+		{name: "nonpositive quantity", req: &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1", Lines: line(testBoltID, 0)}, wantErr: "line 1: quantity must be positive"},
+		{name: "duplicate component", req: &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1", Lines: append(line(testBoltID, 1), line(testBoltID, 2)...)}, wantErr: "line 2: component is listed more than once"},
+		{name: "service component", req: &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1", Lines: line(testServiceID, 1)}, wantErr: "line 1: component SRV is not a goods product"},
+		{name: "cycle through sub-assembly", req: &CreateBOMRequest{ProductID: testBoltID, UserID: "user-1", Lines: line(testSubID, 1)}, wantErr: "component of itself"},
+		{name: "cycle through kit", req: &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1", Lines: line(testKitID, 1)}, wantErr: "component of itself"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newTestService(t)
+
+			_, err := svc.CreateBOM(context.Background(), testTenantID, testSchemaName, tt.req)
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("untracked component without kit", func(t *testing.T) {
+		svc, _, stock := newTestService(t)
+		stock.products["loose"] = &inventory.Product{ID: "loose", Code: "LOOSE", ProductType: inventory.ProductTypeGoods}
+
+		_, err := svc.CreateBOM(context.Background(), testTenantID, testSchemaName, &CreateBOMRequest{ProductID: testPanelID, UserID: "user-1", Lines: line("loose", 1)})
+
+		require.ErrorContains(t, err, "must track inventory or be a kit")
+	})
+}
+
+func TestService_UpdateAndDeleteBOM(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _ := newTestService(t)
+	sub := bomForProduct(t, repo, testSubID)
+	inactive := false
+
+	updated, err := svc.UpdateBOM(ctx, testTenantID, testSchemaName, sub.ID, &UpdateBOMRequest{
+		OutputQuantity: decimal.NewFromInt(2),
+		IsActive:       &inactive,
+		Lines:          []CreateBOMLineRequest{{ComponentProductID: testPanelID, Quantity: decimal.NewFromInt(1)}},
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.IsActive)
+	assert.True(t, updated.LabourCost.IsZero())
+	require.Len(t, updated.Lines, 1)
+	assert.Equal(t, testPanelID, repo.boms[sub.ID].Lines[0].ComponentProductID)
+
+	_, err = svc.UpdateBOM(ctx, testTenantID, testSchemaName, "missing", &UpdateBOMRequest{})
+	require.ErrorIs(t, err, ErrBOMNotFound)
+
+	finished := bomForProduct(t, repo, testFinishedID)
+	repo.orders["order-1"] = &AssemblyOrder{ID: "order-1", TenantID: testTenantID, OrderNumber: "AO-00001", BOMID: finished.ID, ProductID: testFinishedID}
+	err = svc.DeleteBOM(ctx, testTenantID, testSchemaName, finished.ID)
+	require.ErrorContains(t, err, "used by assembly order AO-00001")
+
+	require.NoError(t, svc.DeleteBOM(ctx, testTenantID, testSchemaName, sub.ID))
+	_, err = svc.GetBOM(ctx, testTenantID, testSchemaName, sub.ID)
+	require.ErrorIs(t, err, ErrBOMNotFound)
+}
+
+func TestService_Explode(t *testing.T) {
+	svc, repo, _ := newTestService(t)
+	finished := bomForProduct(t, repo, testFinishedID)
+
+	explosion, err := svc.Explode(context.Background(), testTenantID, testSchemaName, finished.ID, decimal.NewFromInt(2), "FIFO")
+
+	require.NoError(t, err)
+	assert.Equal(t, "FIN", explosion.ProductCode)
+	assert.Equal(t, "FIFO", explosion.ValuationMethod)
+	type row struct {
+		level     int
+		productID string
+		quantity  string
+		unitCost  string
+		totalCost string
+	}
+	got := make([]row, len(explosion.Lines))
+	for i, line := range explosion.Lines {
+		got[i] = row{line.Level, line.ProductID, line.Quantity.String(), line.UnitCost.String(), line.TotalCost.String()}
+	}
+	assert.Equal(t, []row{
+		{1, testSubID, "2", "6", "12"},
+		{2, testBoltID, "4", "2.5", "10"},
+		{1, testPanelID, "4", "5", "20"},
+		{1, testKitID, "2", "7.5", "15"},
+		{2, testPanelID, "2", "5", "10"},
+		{2, testBoltID, "2", "2.5", "5"},
+	}, got)
+	assert.Equal(t, BOMTypeAssembly, explosion.Lines[0].BOMType)
+	assert.Equal(t, BOMTypeKit, explosion.Lines[3].BOMType)
+	assert.True(t, explosion.MaterialCost.Equal(decimal.NewFromInt(47)))
+	assert.True(t, explosion.LabourCost.Equal(decimal.NewFromInt(6)))
+	assert.True(t, explosion.TotalCost.Equal(decimal.NewFromInt(53)))
+	assert.True(t, explosion.UnitCost.Equal(decimal.RequireFromString("26.5")))
+
+	defaulted, err := svc.Explode(context.Background(), testTenantID, testSchemaName, finished.ID, decimal.Zero, "")
+	require.NoError(t, err)
+	assert.True(t, defaulted.Quantity.Equal(decimal.NewFromInt(1)))
+
+	_, err = svc.Explode(context.Background(), testTenantID, testSchemaName, finished.ID, decimal.NewFromInt(-1), "")
+	require.ErrorContains(t, err, "quantity must be positive")
+}
+
+func TestService_AssemblyOrder(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, stock := newTestService(t)
+
+	order, err := svc.CreateOrder(ctx, testTenantID, testSchemaName, &CreateAssemblyOrderRequest{
+		ProductID:   testFinishedID,
+		WarehouseID: testWarehouseID,
+		Quantity:    decimal.NewFromInt(2),
+		OrderDate:   time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		LotNumber:   "LOT-1",
+		UserID:      "user-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "AO-00001", order.OrderNumber)
+	assert.Equal(t, AssemblyOrderStatusDraft, order.Status)
+	assert.True(t, order.LabourCost.Equal(decimal.NewFromInt(6)))
+	lines := map[string]string{}
+	for _, line := range order.Lines {
+		lines[line.ProductID] = line.Quantity.String()
+	}
+	assert.Equal(t, map[string]string{testSubID: "2", testPanelID: "6", testBoltID: "2"}, lines)
+
+	lockDate := time.Date(2026, time.March, 31, 0, 0, 0, 0, time.UTC)
+	_, err = svc.CompleteOrder(ctx, testTenantID, testSchemaName, order.ID, &CompleteAssemblyOrderRequest{PeriodLockDate: &lockDate, UserID: "user-1"})
+	require.ErrorIs(t, err, ErrPeriodLocked)
+
+	stock.unitCosts[testSubID] = decimal.NewFromInt(6)
+	completed, err := svc.CompleteOrder(ctx, testTenantID, testSchemaName, order.ID, &CompleteAssemblyOrderRequest{
+		CostingMethod:       "FIFO",
+		AbsorptionAccountID: "absorption",
+		UserID:              "user-2",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, stock.assembled)
+	assert.Equal(t, AssemblyOrderSourceType, stock.assembled.SourceType)
+	assert.Equal(t, order.ID, stock.assembled.SourceID)
+	assert.Equal(t, "AO-00001", stock.assembled.Reference)
+	assert.True(t, stock.assembled.AdditionalCost.Equal(decimal.NewFromInt(6)))
+	require.Len(t, stock.assembled.Consumed, 3)
+	require.Len(t, stock.assembled.Produced, 1)
+	assert.Equal(t, "LOT-1", stock.assembled.Produced[0].LotNumber)
+
+	assert.Equal(t, AssemblyOrderStatusCompleted, completed.Status)
+	assert.True(t, completed.ComponentCost.Equal(decimal.NewFromInt(47)))
+	assert.True(t, completed.TotalCost.Equal(decimal.NewFromInt(53)))
+	assert.True(t, completed.UnitCost.Equal(decimal.RequireFromString("26.5")))
+	require.NotNil(t, completed.JournalEntryID)
+	assert.Equal(t, "journal-assembly", *completed.JournalEntryID)
+	assert.Equal(t, "user-2", repo.orders[order.ID].CompletedBy)
+	assert.Equal(t, AssemblyOrderStatusCompleted, repo.orders[order.ID].Status)
+	assert.True(t, repo.orders[order.ID].Lines[1].TotalCost.Equal(decimal.NewFromInt(30)))
+
+	_, err = svc.CompleteOrder(ctx, testTenantID, testSchemaName, order.ID, &CompleteAssemblyOrderRequest{UserID: "user-1"})
+	require.ErrorContains(t, err, "only draft assembly orders can be completed")
+	err = svc.CancelOrder(ctx, testTenantID, testSchemaName, order.ID)
+	require.ErrorContains(t, err, "only draft assembly orders can be canceled")
+}
+
+func TestService_DisassemblyOrder(t *testing.T) {
+	ctx := context.Background()
+	svc, _, stock := newTestService(t)
+	stock.unitCosts[testFinishedID] = decimal.RequireFromString("26.5")
+	labour := decimal.NewFromInt(9)
+
+	order, err := svc.CreateOrder(ctx, testTenantID, testSchemaName, &CreateAssemblyOrderRequest{
+		ProductID:   testFinishedID,
+		OrderType:   AssemblyOrderTypeDisassembly,
+		WarehouseID: testWarehouseID,
+		Quantity:    decimal.NewFromInt(1),
+		LabourCost:  &labour,
+		UserID:      "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, order.LabourCost.IsZero())
+
+	completed, err := svc.CompleteOrder(ctx, testTenantID, testSchemaName, order.ID, &CompleteAssemblyOrderRequest{UserID: "user-1"})
+	require.NoError(t, err)
+
+	require.Len(t, stock.assembled.Consumed, 1)
+	assert.Equal(t, testFinishedID, stock.assembled.Consumed[0].ProductID)
+	assert.True(t, stock.assembled.AdditionalCost.IsZero())
+	weights := map[string]string{}
+	for _, line := range stock.assembled.Produced {
+		weights[line.ProductID] = line.CostWeight.String()
+	}
+	assert.Equal(t, map[string]string{testSubID: "6", testPanelID: "15", testBoltID: "2.5"}, weights)
+	assert.True(t, completed.TotalCost.Equal(decimal.RequireFromString("26.5")))
+	assert.True(t, completed.UnitCost.Equal(decimal.RequireFromString("26.5")))
+	assert.True(t, completed.ComponentCost.Equal(decimal.RequireFromString("26.5")))
+}
+
+func TestService_AssemblyOrderValidation(t *testing.T) {
+	ctx := context.Background()
+	valid := func() *CreateAssemblyOrderRequest {
+		return &CreateAssemblyOrderRequest{ProductID: testFinishedID, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(1), UserID: "user-1"}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(req *CreateAssemblyOrderRequest)
+		wantErr string
+	}{
+		{name: "missing user", mutate: func(req *CreateAssemblyOrderRequest) { req.UserID = "" }, wantErr: "user id is required"},
+		{name: "invalid type", mutate: func(req *CreateAssemblyOrderRequest) { req.OrderType = "REPAIR" }, wantErr: "invalid order_type"},
+		{name: "missing product", mutate: func(req *CreateAssemblyOrderRequest) { req.ProductID = "" }, wantErr: "product_id is required"},
+		{name: "missing warehouse", mutate: func(req *CreateAssemblyOrderRequest) { req.WarehouseID = "" }, wantErr: "warehouse_id is required"},
+		{name: "nonpositive quantity", mutate: func(req *CreateAssemblyOrderRequest) { req.Quantity = decimal.Zero }, wantErr: "quantity must be positive"},
+		{name: "bad expiry", mutate: func(req *CreateAssemblyOrderRequest) { req.ExpiryDate = "31.12.2027" }, wantErr: "expiry_date must use YYYY-MM-DD"},
+		{name: "product without bill", mutate: func(req *CreateAssemblyOrderRequest) { req.ProductID = testPanelID }, wantErr: "bill of materials not found"},
+		{name: "kit product", mutate: func(req *CreateAssemblyOrderRequest) { req.ProductID = testKitID }, wantErr: "kits are issued as components"},
+		{name: "unknown warehouse", mutate: func(req *CreateAssemblyOrderRequest) { req.WarehouseID = "missing" }, wantErr: "get warehouse"},
+		{name: "negative labour", mutate: func(req *CreateAssemblyOrderRequest) {
+			labour := decimal.NewFromInt(-1)
+			req.LabourCost = &labour
+		}, wantErr: "cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newTestService(t)
+			req := valid()
+			tt.mutate(req)
+
+			_, err := svc.CreateOrder(ctx, testTenantID, testSchemaName, req)
+
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("inactive bill", func(t *testing.T) {
+		svc, repo, _ := newTestService(t)
+		repo.boms[bomForProduct(t, repo, testFinishedID).ID].IsActive = false
+
+		_, err := svc.CreateOrder(ctx, testTenantID, testSchemaName, valid())
+
+		require.ErrorContains(t, err, "bill of materials is inactive")
+	})
+
+	t.Run("cancels draft order", func(t *testing.T) {
+		svc, repo, _ := newTestService(t)
+		order, err := svc.CreateOrder(ctx, testTenantID, testSchemaName, valid())
+		require.NoError(t, err)
+
+		require.NoError(t, svc.CancelOrder(ctx, testTenantID, testSchemaName, order.ID))
+		assert.Equal(t, AssemblyOrderStatusCanceled, repo.orders[order.ID].Status)
+
+		_, err = svc.CompleteOrder(ctx, testTenantID, testSchemaName, order.ID, &CompleteAssemblyOrderRequest{UserID: "user-1"})
+		require.ErrorContains(t, err, "only draft")
+		_, err = svc.GetOrder(ctx, testTenantID, testSchemaName, "missing")
+		require.ErrorIs(t, err, ErrAssemblyOrderNotFound)
+	})
+}
+
+func TestService_IssueKit(t *testing.T) {
+	ctx := context.Background()
+	svc, _, stock := newTestService(t)
+
+	isKit, err := svc.IsKit(ctx, testTenantID, testSchemaName, testKitID)
+	require.NoError(t, err)
+	assert.True(t, isKit)
+	isKit, err = svc.IsKit(ctx, testTenantID, testSchemaName, testFinishedID)
+	require.NoError(t, err)
+	assert.False(t, isKit)
+	isKit, err = svc.IsKit(ctx, testTenantID, testSchemaName, testPanelID)
+	require.NoError(t, err)
+	assert.False(t, isKit)
+
+	result, err := svc.IssueKit(ctx, testTenantID, testSchemaName, &IssueKitRequest{
+		ProductID:   testKitID,
+		WarehouseID: testWarehouseID,
+		Quantity:    decimal.NewFromInt(2),
+		SourceType:  "ORDER_SHIPMENT",
+		SourceID:    "shipment-1",
+		UserID:      "user-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, stock.issued, 2)
+	assert.Equal(t, testPanelID, stock.issued[0].ProductID)
+	assert.Equal(t, "2", stock.issued[0].Quantity)
+	assert.Equal(t, "cogs-"+testPanelID, stock.issued[0].CostOfGoodsSoldAccountID)
+	assert.True(t, stock.issued[0].PostToLedger)
+	assert.Equal(t, "shipment-1", stock.issued[1].SourceID)
+	assert.True(t, result.TotalCost.Equal(decimal.NewFromInt(15)))
+	assert.True(t, result.UnitCost.Equal(decimal.RequireFromString("7.5")))
+	require.Len(t, result.Components, 2)
+	assert.Equal(t, "journal-"+testBoltID, result.Components[1].JournalEntryID)
+
+	_, err = svc.IssueKit(ctx, testTenantID, testSchemaName, &IssueKitRequest{ProductID: testFinishedID, Quantity: decimal.NewFromInt(1)})
+	require.ErrorContains(t, err, "no active kit bill of materials")
+	_, err = svc.IssueKit(ctx, testTenantID, testSchemaName, &IssueKitRequest{ProductID: testKitID})
+	require.ErrorContains(t, err, "quantity must be positive")
+
+	stock.issueErr = fmt.Errorf("insufficient stock")
+	_, err = svc.IssueKit(ctx, testTenantID, testSchemaName, &IssueKitRequest{ProductID: testKitID, Quantity: decimal.NewFromInt(1)})
+	require.ErrorContains(t, err, "issue kit component 1: insufficient stock")
+
+	stock.issueErrFor = testBoltID
+	_, err = svc.IssueKit(ctx, testTenantID, testSchemaName, &IssueKitRequest{ProductID: testKitID, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(1)})
+	require.ErrorContains(t, err, "issue kit component 2: insufficient stock")
+	assert.Len(t, stock.issued, 2, "the panel issue is rolled back with the failed bolt issue")
+}
+
+func TestService_IssueInvoiceKits(t *testing.T) {
+	ctx := context.Background()
+	svc, _, stock := newTestService(t)
+
+	results, err := svc.IssueInvoiceKits(ctx, testTenantID, testSchemaName, &IssueInvoiceKitsRequest{
+		InvoiceID:     "invoice-1",
+		InvoiceNumber: "INV-00001",
+		CostingMethod: "FIFO",
+		Lines: []InvoiceProductLine{
+			{LineNumber: 1, ProductID: testPanelID, Quantity: decimal.NewFromInt(1)},
+			{LineNumber: 2, ProductID: testKitID, Quantity: decimal.NewFromInt(3)},
+		},
+		UserID: "user-1",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, testWarehouseID, results[0].WarehouseID)
+	require.Len(t, stock.issued, 2)
+	for _, issued := range stock.issued {
+		assert.Equal(t, KitSaleSourceType, issued.SourceType)
+		assert.Equal(t, "invoice-1", issued.SourceID)
+		assert.Equal(t, "INV-00001", issued.Reference)
+		assert.Equal(t, "FIFO", issued.CostingMethod)
+		assert.Equal(t, "3", issued.Quantity)
+	}
+
+	results, err = svc.IssueInvoiceKits(ctx, testTenantID, testSchemaName, &IssueInvoiceKitsRequest{
+		Lines: []InvoiceProductLine{{LineNumber: 1, ProductID: testPanelID, Quantity: decimal.NewFromInt(1)}},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestService_IssueInvoiceKitsOnce(t *testing.T) {
+	ctx := context.Background()
+	svc, _, stock := newTestService(t)
+	req := &IssueInvoiceKitsRequest{
+		InvoiceID:     "invoice-1",
+		InvoiceNumber: "INV-00001",
+		Lines:         []InvoiceProductLine{{LineNumber: 1, ProductID: testKitID, Quantity: decimal.NewFromInt(2)}},
+		UserID:        "user-1",
+	}
+
+	stock.issueErr = fmt.Errorf("insufficient stock")
+	stock.issueErrFor = testBoltID
+	_, err := svc.IssueInvoiceKits(ctx, testTenantID, testSchemaName, req)
+	require.ErrorContains(t, err, "line 1: issue kit component 2: insufficient stock")
+	assert.Empty(t, stock.issued)
+
+	stock.issueErr = nil
+	results, err := svc.IssueInvoiceKits(ctx, testTenantID, testSchemaName, req)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Len(t, stock.issued, 2)
+
+	results, err = svc.IssueInvoiceKits(ctx, testTenantID, testSchemaName, req)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+	assert.Len(t, stock.issued, 2)
+
+	stock.sourceCheckErr = fmt.Errorf("check source movements: connection reset")
+	_, err = svc.IssueInvoiceKits(ctx, testTenantID, testSchemaName, &IssueInvoiceKitsRequest{
+		InvoiceID: "invoice-2",
+		Lines:     []InvoiceProductLine{{LineNumber: 1, ProductID: testKitID, Quantity: decimal.NewFromInt(1)}},
+	})
+	require.ErrorContains(t, err, "connection reset")
+	assert.Len(t, stock.issued, 2)
+}
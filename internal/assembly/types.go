@@ -0,0 +1,285 @@
+package assembly
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BOMType tells how a bill of materials is used
+type BOMType string
+
+const (
+	// BOMTypeAssembly products are built into stock by assembly orders
+	BOMTypeAssembly BOMType = "ASSEMBLY"
+	// BOMTypeKit products are not stocked; their components are issued when
+	// the kit is shipped or invoiced
+	BOMTypeKit BOMType = "KIT"
+)
+
+// AssemblyOrderType represents the direction of an assembly order
+type AssemblyOrderType string
+
+const (
+	AssemblyOrderTypeAssembly    AssemblyOrderType = "ASSEMBLY"
+	AssemblyOrderTypeDisassembly AssemblyOrderType = "DISASSEMBLY"
+)
+
+// AssemblyOrderStatus represents the status of an assembly order
+type AssemblyOrderStatus string
+
+const (
+	AssemblyOrderStatusDraft     AssemblyOrderStatus = "DRAFT"
+	AssemblyOrderStatusCompleted AssemblyOrderStatus = "COMPLETED"
+	AssemblyOrderStatusCanceled  AssemblyOrderStatus = "CANCELED"
+)
+
+const (
+	// AssemblyOrderSourceType marks inventory movements and journal entries created by assembly orders.
+	AssemblyOrderSourceType = "ASSEMBLY_ORDER"
+	// KitSaleSourceType marks component issues for kits sold on sales invoices.
+	KitSaleSourceType = "KIT_SALE"
+)
+
+// maxBOMDepth bounds multi-level explosions so a cycle stored outside the
+// service cannot recurse forever.
+const maxBOMDepth = 20
+
+// BillOfMaterials lists the components of one product. Component quantities
+// make OutputQuantity units of the product; labour and overhead are costs
+// per OutputQuantity absorbed into the product when it is assembled.
+type BillOfMaterials struct {
+	ID             string          `json:"id"`
+	TenantID       string          `json:"tenant_id"`
+	ProductID      string          `json:"product_id"`
+	BOMType        BOMType         `json:"bom_type"`
+	OutputQuantity decimal.Decimal `json:"output_quantity"`
+	LabourCost     decimal.Decimal `json:"labour_cost"`
+	OverheadCost   decimal.Decimal `json:"overhead_cost"`
+	IsActive       bool            `json:"is_active"`
+	Notes          string          `json:"notes,omitempty"`
+	Lines          []BOMLine       `json:"lines"`
+	CreatedBy      string          `json:"created_by"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// BOMLine is one component of a bill of materials
+type BOMLine struct {
+	ID                 string          `json:"id"`
+	TenantID           string          `json:"tenant_id"`
+	BOMID              string          `json:"bom_id"`
+	LineNumber         int             `json:"line_number"`
+	ComponentProductID string          `json:"component_product_id"`
+	Quantity           decimal.Decimal `json:"quantity"`
+	Notes              string          `json:"notes,omitempty"`
+}
+
+// CreateBOMRequest creates a bill of materials for a product
+type CreateBOMRequest struct {
+	ProductID      string                 `json:"product_id"`
+	BOMType        BOMType                `json:"bom_type,omitempty"`
+	OutputQuantity decimal.Decimal        `json:"output_quantity,omitempty"`
+	LabourCost     decimal.Decimal        `json:"labour_cost,omitempty"`
+	OverheadCost   decimal.Decimal        `json:"overhead_cost,omitempty"`
+	Notes          string                 `json:"notes,omitempty"`
+	Lines          []CreateBOMLineRequest `json:"lines"`
+	UserID         string                 `json:"-"`
+}
+
+// CreateBOMLineRequest is one component of a new or updated bill of materials
+type CreateBOMLineRequest struct {
+	ComponentProductID string          `json:"component_product_id"`
+	Quantity           decimal.Decimal `json:"quantity"`
+	Notes              string          `json:"notes,omitempty"`
+}
+
+// UpdateBOMRequest replaces the components and costs of a bill of materials
+type UpdateBOMRequest struct {
+	BOMType        BOMType                `json:"bom_type,omitempty"`
+	OutputQuantity decimal.Decimal        `json:"output_quantity,omitempty"`
+	LabourCost     decimal.Decimal        `json:"labour_cost,omitempty"`
+	OverheadCost   decimal.Decimal        `json:"overhead_cost,omitempty"`
+	IsActive       *bool                  `json:"is_active,omitempty"`
+	Notes          string                 `json:"notes,omitempty"`
+	Lines          []CreateBOMLineRequest `json:"lines"`
+}
+
+// BOMFilter provides filtering options for bills of materials
+type BOMFilter struct {
+	BOMType    BOMType
+	ProductID  string
+	ActiveOnly bool
+}
+
+// BOMExplosion is a multi-level breakdown of a bill of materials with its
+// rolled-up cost. Leaf components are valued at the valuation method and
+// sub-assemblies at the rolled-up cost of their own bills of materials.
+type BOMExplosion struct {
+	BOMID           string             `json:"bom_id"`
+	ProductID       string             `json:"product_id"`
+	ProductCode     string             `json:"product_code"`
+	ProductName     string             `json:"product_name"`
+	BOMType         BOMType            `json:"bom_type"`
+	Quantity        decimal.Decimal    `json:"quantity"`
+	ValuationMethod string             `json:"valuation_method"`
+	Lines           []BOMExplosionLine `json:"lines"`
+	MaterialCost    decimal.Decimal    `json:"material_cost"`
+	LabourCost      decimal.Decimal    `json:"labour_cost"`
+	OverheadCost    decimal.Decimal    `json:"overhead_cost"`
+	TotalCost       decimal.Decimal    `json:"total_cost"`
+	UnitCost        decimal.Decimal    `json:"unit_cost"`
+}
+
+// BOMExplosionLine is one component at one level of an explosion. Quantity is
+// the total needed for the exploded quantity of the top-level product.
+type BOMExplosionLine struct {
+	Level           int             `json:"level"`
+	ParentProductID string          `json:"parent_product_id"`
+	ProductID       string          `json:"product_id"`
+	ProductCode     string          `json:"product_code"`
+	ProductName     string          `json:"product_name"`
+	Unit            string          `json:"unit,omitempty"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	UnitCost        decimal.Decimal `json:"unit_cost"`
+	TotalCost       decimal.Decimal `json:"total_cost"`
+	BOMType         BOMType         `json:"bom_type,omitempty"`
+}
+
+// AssemblyOrder builds a product from its components or breaks it down into
+// them in one warehouse
+type AssemblyOrder struct {
+	ID                  string              `json:"id"`
+	TenantID            string              `json:"tenant_id"`
+	OrderNumber         string              `json:"order_number"`
+	OrderType           AssemblyOrderType   `json:"order_type"`
+	BOMID               string              `json:"bom_id"`
+	ProductID           string              `json:"product_id"`
+	WarehouseID         string              `json:"warehouse_id"`
+	Quantity            decimal.Decimal     `json:"quantity"`
+	OrderDate           time.Time           `json:"order_date"`
+	Status              AssemblyOrderStatus `json:"status"`
+	LotNumber           string              `json:"lot_number,omitempty"`
+	SerialNumber        string              `json:"serial_number,omitempty"`
+	ExpiryDate          string              `json:"expiry_date,omitempty"`
+	LabourCost          decimal.Decimal     `json:"labour_cost"`
+	OverheadCost        decimal.Decimal     `json:"overhead_cost"`
+	ComponentCost       decimal.Decimal     `json:"component_cost"`
+	TotalCost           decimal.Decimal     `json:"total_cost"`
+	UnitCost            decimal.Decimal     `json:"unit_cost"`
+	CostingMethod       string              `json:"costing_method,omitempty"`
+	AbsorptionAccountID string              `json:"absorption_account_id,omitempty"`
+	JournalEntryID      *string             `json:"journal_entry_id,omitempty"`
+	Notes               string              `json:"notes,omitempty"`
+	Lines               []AssemblyOrderLine `json:"lines"`
+	CompletedAt         *time.Time          `json:"completed_at,omitempty"`
+	CompletedBy         string              `json:"completed_by,omitempty"`
+	CreatedBy           string              `json:"created_by"`
+	CreatedAt           time.Time           `json:"created_at"`
+	UpdatedAt           time.Time           `json:"updated_at"`
+}
+
+// AssemblyOrderLine is one component consumed by an assembly or produced by a
+// disassembly. Kit components inside the bill of materials are exploded into
+// their own components.
+type AssemblyOrderLine struct {
+	ID              string          `json:"id"`
+	TenantID        string          `json:"tenant_id"`
+	AssemblyOrderID string          `json:"assembly_order_id"`
+	LineNumber      int             `json:"line_number"`
+	ProductID       string          `json:"product_id"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	LotNumber       string          `json:"lot_number,omitempty"`
+	SerialNumber    string          `json:"serial_number,omitempty"`
+	ExpiryDate      string          `json:"expiry_date,omitempty"`
+	UnitCost        decimal.Decimal `json:"unit_cost"`
+	TotalCost       decimal.Decimal `json:"total_cost"`
+}
+
+// CreateAssemblyOrderRequest drafts an assembly order from a product's bill
+// of materials. Labour and overhead default to the bill of materials costs
+// scaled to the order quantity and are ignored for disassembly.
+type CreateAssemblyOrderRequest struct {
+	ProductID    string            `json:"product_id"`
+	OrderType    AssemblyOrderType `json:"order_type,omitempty"`
+	WarehouseID  string            `json:"warehouse_id"`
+	Quantity     decimal.Decimal   `json:"quantity"`
+	OrderDate    time.Time         `json:"order_date,omitempty"`
+	LotNumber    string            `json:"lot_number,omitempty"`
+	SerialNumber string            `json:"serial_number,omitempty"`
+	ExpiryDate   string            `json:"expiry_date,omitempty"`
+	LabourCost   *decimal.Decimal  `json:"labour_cost,omitempty"`
+	OverheadCost *decimal.Decimal  `json:"overhead_cost,omitempty"`
+	Notes        string            `json:"notes,omitempty"`
+	UserID       string            `json:"-"`
+}
+
+// CompleteAssemblyOrderRequest books a draft assembly order to stock and the ledger
+type CompleteAssemblyOrderRequest struct {
+	CostingMethod       string     `json:"costing_method,omitempty"`
+	AbsorptionAccountID string     `json:"absorption_account_id,omitempty"`
+	InventoryAccountID  string     `json:"inventory_account_id,omitempty"`
+	PeriodLockDate      *time.Time `json:"-"`
+	UserID              string     `json:"-"`
+}
+
+// AssemblyOrderFilter provides filtering options for assembly orders
+type AssemblyOrderFilter struct {
+	Status      AssemblyOrderStatus
+	OrderType   AssemblyOrderType
+	ProductID   string
+	WarehouseID string
+}
+
+// IssueKitRequest issues the components of a kit sold in a quantity
+type IssueKitRequest struct {
+	ProductID                string
+	WarehouseID              string
+	Quantity                 decimal.Decimal
+	CostingMethod            string
+	Reference                string
+	SourceType               string
+	SourceID                 string
+	Reason                   string
+	CostOfGoodsSoldAccountID string
+	InventoryAccountID       string
+	UserID                   string
+}
+
+// IssueKitResult summarizes the component issues of a kit
+type IssueKitResult struct {
+	ProductID     string              `json:"product_id"`
+	WarehouseID   string              `json:"warehouse_id"`
+	Quantity      decimal.Decimal     `json:"quantity"`
+	CostingMethod string              `json:"costing_method,omitempty"`
+	UnitCost      decimal.Decimal     `json:"unit_cost"`
+	TotalCost     decimal.Decimal     `json:"total_cost"`
+	Components    []KitComponentIssue `json:"components"`
+}
+
+// KitComponentIssue is the stock issue of one kit component
+type KitComponentIssue struct {
+	ProductID      string          `json:"product_id"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	UnitCost       decimal.Decimal `json:"unit_cost"`
+	TotalCost      decimal.Decimal `json:"total_cost"`
+	JournalEntryID string          `json:"journal_entry_id,omitempty"`
+}
+
+// InvoiceProductLine is one product line of a sales invoice. Lines for
+// products that are not kits are skipped.
+type InvoiceProductLine struct {
+	LineNumber int
+	ProductID  string
+	Quantity   decimal.Decimal
+}
+
+// IssueInvoiceKitsRequest issues the components of the kits on a sales invoice
+type IssueInvoiceKitsRequest struct {
+	InvoiceID     string
+	InvoiceNumber string
+	WarehouseID   string
+	CostingMethod string
+	Lines         []InvoiceProductLine
+	UserID        string
+}
@@ -0,0 +1,644 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// SourceTypeAssetDepreciationRun marks aggregated journal entries posted by a depreciation run.
+const SourceTypeAssetDepreciationRun = "ASSET_DEPRECIATION_RUN"
+
+// DepreciationPostingMode controls how a depreciation run is journalised.
+type DepreciationPostingMode string
+
+const (
+	// DepreciationPostingAggregated posts one journal entry for the whole run.
+	DepreciationPostingAggregated DepreciationPostingMode = "AGGREGATED"
+	// DepreciationPostingPerAsset posts one journal entry per depreciated asset.
+	DepreciationPostingPerAsset DepreciationPostingMode = "PER_ASSET"
+)
+
+// DepreciationRunStatus represents the lifecycle of a depreciation run.
+type DepreciationRunStatus string
+
+const (
+	DepreciationRunPosted   DepreciationRunStatus = "POSTED"
+	DepreciationRunReversed DepreciationRunStatus = "REVERSED"
+)
+
+var (
+	// ErrDepreciationRunsUnavailable is returned when the repository cannot store depreciation runs.
+	ErrDepreciationRunsUnavailable = errors.New("depreciation runs are unavailable")
+	// ErrDepreciationRunNotFound is returned when a depreciation run is not found.
+	ErrDepreciationRunNotFound = errors.New("depreciation run not found")
+)
+
+// DepreciationCategoryTotal sums the depreciation of one asset category in a run.
+type DepreciationCategoryTotal struct {
+	CategoryID   *string         `json:"category_id,omitempty"`
+	CategoryName string          `json:"category_name"`
+	AssetCount   int             `json:"asset_count"`
+	Amount       decimal.Decimal `json:"amount"`
+}
+
+// DepreciationRunLine is the depreciation computed for one asset in a run.
+type DepreciationRunLine struct {
+	AssetID          string          `json:"asset_id"`
+	AssetNumber      string          `json:"asset_number"`
+	AssetName        string          `json:"asset_name"`
+	CategoryID       *string         `json:"category_id,omitempty"`
+	Amount           decimal.Decimal `json:"amount"`
+	AccumulatedAfter decimal.Decimal `json:"accumulated_after"`
+	BookValueAfter   decimal.Decimal `json:"book_value_after"`
+}
+
+// DepreciationRunSkip explains why an active asset is left out of a run.
+type DepreciationRunSkip struct {
+	AssetID     string `json:"asset_id"`
+	AssetNumber string `json:"asset_number"`
+	AssetName   string `json:"asset_name"`
+	Reason      string `json:"reason"`
+}
+
+// DepreciationRunPreview is the depreciation a run would post for a period.
+// Issues block the run; PostedRun is set when the period already has one.
+type DepreciationRunPreview struct {
+	PeriodStart    time.Time                   `json:"period_start"`
+	PeriodEnd      time.Time                   `json:"period_end"`
+	AssetCount     int                         `json:"asset_count"`
+	TotalAmount    decimal.Decimal             `json:"total_amount"`
+	CategoryTotals []DepreciationCategoryTotal `json:"category_totals"`
+	Lines          []DepreciationRunLine       `json:"lines"`
+	Skipped        []DepreciationRunSkip       `json:"skipped,omitempty"`
+	Issues         []string                    `json:"issues,omitempty"`
+	PostedRun      *DepreciationRun            `json:"posted_run,omitempty"`
+}
+
+// DepreciationRun records the depreciation posted for every active asset in one period.
+type DepreciationRun struct {
+	ID              string                      `json:"id"`
+	TenantID        string                      `json:"tenant_id"`
+	PeriodStart     time.Time                   `json:"period_start"`
+	PeriodEnd       time.Time                   `json:"period_end"`
+	PostingMode     DepreciationPostingMode     `json:"posting_mode"`
+	Status          DepreciationRunStatus       `json:"status"`
+	AssetCount      int                         `json:"asset_count"`
+	TotalAmount     decimal.Decimal             `json:"total_amount"`
+	CategoryTotals  []DepreciationCategoryTotal `json:"category_totals"`
+	JournalEntryIDs []string                    `json:"journal_entry_ids"`
+	ReversedAt      *time.Time                  `json:"reversed_at,omitempty"`
+	ReversedBy      *string                     `json:"reversed_by,omitempty"`
+	ReversalReason  string                      `json:"reversal_reason,omitempty"`
+	CreatedBy       string                      `json:"created_by"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	Entries         []DepreciationEntry         `json:"entries,omitempty"`
+}
+
+// CreateDepreciationRunRequest runs depreciation for one calendar month.
+type CreateDepreciationRunRequest struct {
+	Year        int                     `json:"year"`
+	Month       int                     `json:"month"`
+	PostingMode DepreciationPostingMode `json:"posting_mode,omitempty"`
+	UserID      string                  `json:"-"`
+}
+
+// ReverseDepreciationRunRequest reverses a posted depreciation run.
+type ReverseDepreciationRunRequest struct {
+	Reason string `json:"reason"`
+	UserID string `json:"-"`
+}
+
+type journalVoider interface {
+	VoidJournalEntry(ctx context.Context, schemaName, tenantID, entryID, userID, reason string) (*accounting.JournalEntry, error)
+}
+
+// DepreciationRunPeriod returns the first and last day of a depreciation run month.
+func DepreciationRunPeriod(year, month int) (time.Time, time.Time, error) {
+	if year < 1900 || month < 1 || month > 12 {
+		return time.Time{}, time.Time{}, fmt.Errorf("valid year and month are required")
+	}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, -1), nil
+}
+
+// PreviewDepreciationRun computes the depreciation for every active asset not
+// yet depreciated in the month, without posting anything.
+func (s *Service) PreviewDepreciationRun(ctx context.Context, tenantID, schemaName string, year, month int) (*DepreciationRunPreview, error) {
+	if s.runs == nil {
+		return nil, ErrDepreciationRunsUnavailable
+	}
+	periodStart, periodEnd, err := DepreciationRunPeriod(year, month)
+	if err != nil {
+		return nil, err
+	}
+	preview, _, err := s.buildDepreciationRunPreview(ctx, tenantID, schemaName, periodStart, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	posted, err := s.runs.GetPostedDepreciationRun(ctx, schemaName, tenantID, periodStart, periodEnd)
+	if err != nil && !errors.Is(err, ErrDepreciationRunNotFound) {
+		return nil, fmt.Errorf("get posted depreciation run: %w", err)
+	}
+	preview.PostedRun = posted
+	return preview, nil
+}
+
+// RunDepreciation posts depreciation for every asset in the month's preview.
+// A month already covered by a posted run returns that run unchanged, and the
+// boolean result reports whether a new run was created.
+func (s *Service) RunDepreciation(ctx context.Context, tenantID, schemaName string, req *CreateDepreciationRunRequest) (*DepreciationRun, bool, error) {
+	if s.runs == nil {
+		return nil, false, ErrDepreciationRunsUnavailable
+	}
+	if req == nil {
+		return nil, false, fmt.Errorf("depreciation run request is required")
+	}
+	mode := req.PostingMode
+	if mode == "" {
+		mode = DepreciationPostingAggregated
+	}
+	if mode != DepreciationPostingAggregated && mode != DepreciationPostingPerAsset {
+		return nil, false, fmt.Errorf("posting mode must be %s or %s", DepreciationPostingAggregated, DepreciationPostingPerAsset)
+	}
+	periodStart, periodEnd, err := DepreciationRunPeriod(req.Year, req.Month)
+	if err != nil {
+		return nil, false, err
+	}
+
+	posted, err := s.runs.GetPostedDepreciationRun(ctx, schemaName, tenantID, periodStart, periodEnd)
+	if err == nil {
+		return posted, false, nil
+	}
+	if !errors.Is(err, ErrDepreciationRunNotFound) {
+		return nil, false, fmt.Errorf("get posted depreciation run: %w", err)
+	}
+
+	preview, assetsByID, err := s.buildDepreciationRunPreview(ctx, tenantID, schemaName, periodStart, periodEnd)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(preview.Issues) > 0 {
+		return nil, false, fmt.Errorf("%w: %s", ErrAssetAccountingInvalid, strings.Join(preview.Issues, "; "))
+	}
+	if len(preview.Lines) > 0 && s.ledger == nil {
+		return nil, false, fmt.Errorf("%w: accounting service is unavailable", ErrAssetAccountingInvalid)
+	}
+
+	now := time.Now()
+	run := &DepreciationRun{
+		ID:              uuid.New().String(),
+		TenantID:        tenantID,
+		PeriodStart:     periodStart,
+		PeriodEnd:       periodEnd,
+		PostingMode:     mode,
+		Status:          DepreciationRunPosted,
+		AssetCount:      preview.AssetCount,
+		TotalAmount:     preview.TotalAmount,
+		CategoryTotals:  preview.CategoryTotals,
+		JournalEntryIDs: []string{},
+		CreatedBy:       req.UserID,
+		CreatedAt:       now,
+	}
+	entries := make([]DepreciationEntry, 0, len(preview.Lines))
+	for _, line := range preview.Lines {
+		runID := run.ID
+		entries = append(entries, DepreciationEntry{
+			ID:                 uuid.New().String(),
+			TenantID:           tenantID,
+			AssetID:            line.AssetID,
+			DepreciationDate:   periodEnd,
+			PeriodStart:        periodStart,
+			PeriodEnd:          periodEnd,
+			DepreciationAmount: line.Amount,
+			AccumulatedTotal:   line.AccumulatedAfter,
+			BookValueAfter:     line.BookValueAfter,
+			RunID:              &runID,
+			CreatedAt:          now,
+			CreatedBy:          req.UserID,
+		})
+	}
+
+	// The journals, the run (unique per posted period), its entries and the
+	// asset balances commit together, so a concurrent run for the same month
+	// cannot leave orphan journals behind. Assets are locked and re-checked
+	// first so that an event stored since the preview is not overwritten.
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		for i := range entries {
+			previewed := assetsByID[entries[i].AssetID]
+			current, err := tx.getAssetForUpdate(ctx, tenantID, schemaName, previewed.ID)
+			if err != nil {
+				return fmt.Errorf("get asset: %w", err)
+			}
+			if current.Status != previewed.Status ||
+				!current.PurchaseCost.Equal(previewed.PurchaseCost) ||
+				!current.AccumulatedDepreciation.Equal(previewed.AccumulatedDepreciation) ||
+				!current.ResidualValue.Equal(previewed.ResidualValue) ||
+				current.UsefulLifeMonths != previewed.UsefulLifeMonths {
+				return fmt.Errorf("asset %s changed while the depreciation run was prepared; run it again", previewed.AssetNumber)
+			}
+		}
+		if err := tx.postDepreciationRunJournals(ctx, schemaName, tenantID, run, entries, assetsByID); err != nil {
+			return err
+		}
+		if err := tx.runs.CreateDepreciationRun(ctx, schemaName, run); err != nil {
+			return fmt.Errorf("create depreciation run: %w", err)
+		}
+		for i := range entries {
+			entry := &entries[i]
+			if err := tx.repo.CreateDepreciationEntry(ctx, schemaName, entry); err != nil {
+				return fmt.Errorf("create depreciation entry: %w", err)
+			}
+			asset := *assetsByID[entry.AssetID]
+			asset.AccumulatedDepreciation = entry.AccumulatedTotal
+			asset.BookValue = entry.BookValueAfter
+			depreciatedThrough := periodEnd
+			asset.LastDepreciationDate = &depreciatedThrough
+			if err := tx.repo.UpdateAssetDepreciation(ctx, schemaName, &asset); err != nil {
+				return fmt.Errorf("update asset depreciation: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	run.Entries = entries
+	return run, true, nil
+}
+
+// ListDepreciationRuns returns depreciation runs, newest period first. A zero
+// year returns every run.
+func (s *Service) ListDepreciationRuns(ctx context.Context, tenantID, schemaName string, year int) ([]DepreciationRun, error) {
+	if s.runs == nil {
+		return nil, ErrDepreciationRunsUnavailable
+	}
+	runs, err := s.runs.ListDepreciationRuns(ctx, schemaName, tenantID, year)
+	if err != nil {
+		return nil, fmt.Errorf("list depreciation runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetDepreciationRun returns a depreciation run with its asset entries.
+func (s *Service) GetDepreciationRun(ctx context.Context, tenantID, schemaName, runID string) (*DepreciationRun, error) {
+	if s.runs == nil {
+		return nil, ErrDepreciationRunsUnavailable
+	}
+	run, err := s.runs.GetDepreciationRun(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.runs.ListDepreciationRunEntries(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, fmt.Errorf("list depreciation run entries: %w", err)
+	}
+	run.Entries = entries
+	return run, nil
+}
+
+// ReverseDepreciationRun voids the run's journal entries, removes its asset
+// entries and restores the assets' accumulated depreciation and book value.
+// Assets depreciated after the run, with events dated on or after its period
+// end, or no longer active, block the reversal.
+func (s *Service) ReverseDepreciationRun(ctx context.Context, tenantID, schemaName, runID string, req *ReverseDepreciationRunRequest) (*DepreciationRun, error) {
+	if s.runs == nil {
+		return nil, ErrDepreciationRunsUnavailable
+	}
+	if req == nil || strings.TrimSpace(req.Reason) == "" {
+		return nil, fmt.Errorf("reversal reason is required")
+	}
+	reason := strings.TrimSpace(req.Reason)
+
+	run, err := s.GetDepreciationRun(ctx, tenantID, schemaName, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != DepreciationRunPosted {
+		return nil, fmt.Errorf("only POSTED depreciation runs can be reversed, current status: %s", run.Status)
+	}
+
+	type restore struct {
+		asset       *FixedAsset
+		entry       DepreciationEntry
+		lastEntryAt *time.Time
+	}
+	restores := make([]restore, 0, len(run.Entries))
+	for _, entry := range run.Entries {
+		asset, err := s.repo.GetByID(ctx, schemaName, tenantID, entry.AssetID)
+		if err != nil {
+			return nil, fmt.Errorf("get asset: %w", err)
+		}
+		if asset.Status != AssetStatusActive {
+			return nil, fmt.Errorf("asset %s is %s and its depreciation cannot be reversed", asset.AssetNumber, asset.Status)
+		}
+		history, err := s.repo.ListDepreciationEntries(ctx, schemaName, tenantID, asset.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list depreciation entries: %w", err)
+		}
+		var lastEntryAt *time.Time
+		for _, other := range history {
+			if other.ID == entry.ID {
+				continue
+			}
+			if other.PeriodStart.After(run.PeriodEnd) {
+				return nil, fmt.Errorf("asset %s has depreciation after %s; reverse the later depreciation first", asset.AssetNumber, run.PeriodEnd.Format("2006-01-02"))
+			}
+			if lastEntryAt == nil || other.PeriodEnd.After(*lastEntryAt) {
+				periodEnd := other.PeriodEnd
+				lastEntryAt = &periodEnd
+			}
+		}
+		if s.events != nil {
+			// Events revised the depreciation basis from the run's book value
+			events, err := s.events.ListAssetEvents(ctx, schemaName, tenantID, asset.ID)
+			if err != nil {
+				return nil, fmt.Errorf("list asset events: %w", err)
+			}
+			for _, event := range events {
+				if !event.EventDate.Before(run.PeriodEnd) {
+					return nil, fmt.Errorf("asset %s has a %s event on %s; its depreciation for the run cannot be reversed", asset.AssetNumber, event.EventType, event.EventDate.Format("2006-01-02"))
+				}
+			}
+		}
+		restores = append(restores, restore{asset: asset, entry: entry, lastEntryAt: lastEntryAt})
+	}
+
+	if len(run.JournalEntryIDs) > 0 {
+		if _, ok := s.ledger.(journalVoider); !ok {
+			return nil, fmt.Errorf("%w: journal reversal is unavailable", ErrAssetAccountingInvalid)
+		}
+	}
+
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		if len(run.JournalEntryIDs) > 0 {
+			voider, ok := tx.ledger.(journalVoider)
+			if !ok {
+				return fmt.Errorf("%w: journal reversal is unavailable", ErrAssetAccountingInvalid)
+			}
+			for _, journalEntryID := range run.JournalEntryIDs {
+				if _, err := voider.VoidJournalEntry(ctx, schemaName, tenantID, journalEntryID, req.UserID, "Depreciation run reversed: "+reason); err != nil {
+					return fmt.Errorf("void depreciation journal: %w", err)
+				}
+			}
+		}
+
+		for _, item := range restores {
+			asset := *item.asset
+			asset.AccumulatedDepreciation = asset.AccumulatedDepreciation.Sub(item.entry.DepreciationAmount)
+			asset.BookValue = asset.PurchaseCost.Sub(asset.AccumulatedDepreciation)
+			asset.LastDepreciationDate = item.lastEntryAt
+			if err := tx.repo.UpdateAssetDepreciation(ctx, schemaName, &asset); err != nil {
+				return fmt.Errorf("restore asset depreciation: %w", err)
+			}
+		}
+		if err := tx.runs.DeleteDepreciationRunEntries(ctx, schemaName, tenantID, run.ID); err != nil {
+			return fmt.Errorf("delete depreciation run entries: %w", err)
+		}
+		if err := tx.runs.MarkDepreciationRunReversed(ctx, schemaName, tenantID, run.ID, req.UserID, reason, time.Now()); err != nil {
+			return fmt.Errorf("mark depreciation run reversed: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetDepreciationRun(ctx, tenantID, schemaName, run.ID)
+}
+
+// withLedgerTransaction runs fn with a copy of the service bound to one
+// repository and ledger transaction when the repository supports it.
+func (s *Service) withLedgerTransaction(ctx context.Context, fn func(tx *Service) error) error {
+	transactioner, ok := s.repo.(LedgerTransactionRepository)
+	if !ok {
+		return fn(s)
+	}
+	return transactioner.WithLedgerTransaction(ctx, func(txRepo Repository, ledger accountingPoster) error {
+		tx := *s
+		tx.repo = txRepo
+		if s.runs != nil {
+			tx.runs, _ = txRepo.(DepreciationRunRepository)
+		}
+		if s.events != nil {
+			tx.events, _ = txRepo.(AssetEventRepository)
+		}
+		if s.ledger != nil {
+			tx.ledger = ledger
+		}
+		return fn(&tx)
+	})
+}
+
+// buildDepreciationRunPreview returns the preview together with the assets it
+// depreciates, keyed by ID.
+func (s *Service) buildDepreciationRunPreview(ctx context.Context, tenantID, schemaName string, periodStart, periodEnd time.Time) (*DepreciationRunPreview, map[string]*FixedAsset, error) {
+	activeAssets, err := s.repo.List(ctx, schemaName, tenantID, &AssetFilter{Status: AssetStatusActive})
+	if err != nil {
+		return nil, nil, fmt.Errorf("list active assets: %w", err)
+	}
+	periodEntries, err := s.runs.ListPeriodDepreciationEntries(ctx, schemaName, tenantID, periodStart, periodEnd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list period depreciation entries: %w", err)
+	}
+	depreciated := make(map[string]bool, len(periodEntries))
+	for _, entry := range periodEntries {
+		depreciated[entry.AssetID] = true
+	}
+	categories, err := s.repo.ListCategories(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list categories: %w", err)
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+
+	sort.Slice(activeAssets, func(i, j int) bool {
+		return activeAssets[i].AssetNumber < activeAssets[j].AssetNumber
+	})
+
+	preview := &DepreciationRunPreview{
+		PeriodStart:    periodStart,
+		PeriodEnd:      periodEnd,
+		TotalAmount:    decimal.Zero,
+		CategoryTotals: []DepreciationCategoryTotal{},
+		Lines:          []DepreciationRunLine{},
+	}
+	assetsByID := make(map[string]*FixedAsset, len(activeAssets))
+	totals := make(map[string]*DepreciationCategoryTotal)
+	for i := range activeAssets {
+		asset := &activeAssets[i]
+		skip := func(reason string) {
+			preview.Skipped = append(preview.Skipped, DepreciationRunSkip{
+				AssetID:     asset.ID,
+				AssetNumber: asset.AssetNumber,
+				AssetName:   asset.Name,
+				Reason:      reason,
+			})
+		}
+
+		startDate := asset.PurchaseDate
+		if asset.DepreciationStartDate != nil {
+			startDate = *asset.DepreciationStartDate
+		}
+		if startDate.After(periodEnd) {
+			skip("depreciation starts after the period")
+			continue
+		}
+		if depreciated[asset.ID] {
+			skip("already depreciated for the period")
+			continue
+		}
+		if asset.LastDepreciationDate != nil && !asset.LastDepreciationDate.Before(periodStart) {
+			skip(fmt.Sprintf("already depreciated through %s", asset.LastDepreciationDate.Format("2006-01-02")))
+			continue
+		}
+		amount := asset.CalculateMonthlyDepreciation()
+		remaining := asset.PurchaseCost.Sub(asset.ResidualValue).Sub(asset.AccumulatedDepreciation)
+		if amount.GreaterThan(remaining) {
+			amount = remaining
+		}
+		if amount.LessThanOrEqual(decimal.Zero) {
+			skip("fully depreciated")
+			continue
+		}
+		if trimmedStringPtr(asset.DepreciationExpenseAccountID) == "" || trimmedStringPtr(asset.AccumulatedDepreciationAcctID) == "" {
+			preview.Issues = append(preview.Issues, fmt.Sprintf("asset %s has no depreciation expense or accumulated depreciation account", asset.AssetNumber))
+		}
+
+		accumulated := asset.AccumulatedDepreciation.Add(amount)
+		preview.Lines = append(preview.Lines, DepreciationRunLine{
+			AssetID:          asset.ID,
+			AssetNumber:      asset.AssetNumber,
+			AssetName:        asset.Name,
+			CategoryID:       asset.CategoryID,
+			Amount:           amount,
+			AccumulatedAfter: accumulated,
+			BookValueAfter:   asset.PurchaseCost.Sub(accumulated),
+		})
+		preview.AssetCount++
+		preview.TotalAmount = preview.TotalAmount.Add(amount)
+		assetsByID[asset.ID] = asset
+
+		categoryKey := trimmedStringPtr(asset.CategoryID)
+		total, ok := totals[categoryKey]
+		if !ok {
+			total = &DepreciationCategoryTotal{CategoryID: nonEmptyStringPtr(categoryKey), CategoryName: uncategorizedAssetCategoryName, Amount: decimal.Zero}
+			if name, found := categoryNames[categoryKey]; found {
+				total.CategoryName = name
+			}
+			totals[categoryKey] = total
+		}
+		total.AssetCount++
+		total.Amount = total.Amount.Add(amount)
+	}
+
+	for _, total := range totals {
+		preview.CategoryTotals = append(preview.CategoryTotals, *total)
+	}
+	sort.Slice(preview.CategoryTotals, func(i, j int) bool {
+		return preview.CategoryTotals[i].CategoryName < preview.CategoryTotals[j].CategoryName
+	})
+	return preview, assetsByID, nil
+}
+
+// postDepreciationRunJournals posts the run's journal entries and links them to
+// the run and its asset entries.
+func (s *Service) postDepreciationRunJournals(ctx context.Context, schemaName, tenantID string, run *DepreciationRun, entries []DepreciationEntry, assetsByID map[string]*FixedAsset) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	if run.PostingMode == DepreciationPostingPerAsset {
+		for i := range entries {
+			journalEntryID, err := s.recordDepreciationJournal(ctx, schemaName, tenantID, assetsByID[entries[i].AssetID], &entries[i], run.CreatedBy)
+			if err != nil {
+				return err
+			}
+			entries[i].JournalEntryID = journalEntryID
+			run.JournalEntryIDs = append(run.JournalEntryIDs, *journalEntryID)
+		}
+		return nil
+	}
+
+	type accountPair struct {
+		expense     string
+		accumulated string
+	}
+	checked := make(map[string]bool)
+	amounts := make(map[accountPair]decimal.Decimal)
+	pairs := make([]accountPair, 0)
+	for _, entry := range entries {
+		asset := assetsByID[entry.AssetID]
+		pair := accountPair{
+			expense:     trimmedStringPtr(asset.DepreciationExpenseAccountID),
+			accumulated: trimmedStringPtr(asset.AccumulatedDepreciationAcctID),
+		}
+		if !checked[pair.expense] {
+			if err := s.requireAccountType(ctx, schemaName, tenantID, pair.expense, "depreciation expense account", accounting.AccountTypeExpense); err != nil {
+				return err
+			}
+			checked[pair.expense] = true
+		}
+		if !checked[pair.accumulated] {
+			if err := s.requireAccountType(ctx, schemaName, tenantID, pair.accumulated, "accumulated depreciation account", accounting.AccountTypeAsset); err != nil {
+				return err
+			}
+			checked[pair.accumulated] = true
+		}
+		if _, ok := amounts[pair]; !ok {
+			pairs = append(pairs, pair)
+		}
+		amounts[pair] = amounts[pair].Add(entry.DepreciationAmount)
+	}
+
+	period := run.PeriodStart.Format("2006-01")
+	description := "Fixed asset depreciation " + period
+	lines := make([]accounting.CreateJournalEntryLineReq, 0, len(pairs)*2)
+	for _, pair := range pairs {
+		lines = append(lines,
+			accounting.CreateJournalEntryLineReq{
+				AccountID:    pair.expense,
+				Description:  description,
+				DebitAmount:  amounts[pair],
+				CreditAmount: decimal.Zero,
+			},
+			accounting.CreateJournalEntryLineReq{
+				AccountID:    pair.accumulated,
+				Description:  description,
+				DebitAmount:  decimal.Zero,
+				CreditAmount: amounts[pair],
+			},
+		)
+	}
+
+	sourceID := run.ID
+	journalEntry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   run.PeriodEnd,
+		Description: description,
+		Reference:   "DEPR-" + period,
+		SourceType:  SourceTypeAssetDepreciationRun,
+		SourceID:    &sourceID,
+		UserID:      run.CreatedBy,
+		Lines:       lines,
+	})
+	if err != nil {
+		return fmt.Errorf("create depreciation run journal: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, journalEntry.ID, run.CreatedBy, "Fixed asset depreciation run posting"); err != nil {
+		return fmt.Errorf("post depreciation run journal: %w", err)
+	}
+	for i := range entries {
+		journalEntryID := journalEntry.ID
+		entries[i].JournalEntryID = &journalEntryID
+	}
+	run.JournalEntryIDs = append(run.JournalEntryIDs, journalEntry.ID)
+	return nil
+}
@@ -0,0 +1,517 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type depreciationRunMockRepository struct {
+	*MockRepository
+	runs map[string]*DepreciationRun
+}
+
+func newDepreciationRunMockRepository() *depreciationRunMockRepository {
+	return &depreciationRunMockRepository{MockRepository: NewMockRepository(), runs: make(map[string]*DepreciationRun)}
+}
+
+func (r *depreciationRunMockRepository) CreateDepreciationRun(_ context.Context, _ string, run *DepreciationRun) error {
+	stored := *run
+	stored.Entries = nil
+	r.runs[run.ID] = &stored
+	return nil
+}
+
+func (r *depreciationRunMockRepository) GetDepreciationRun(_ context.Context, _, tenantID, runID string) (*DepreciationRun, error) {
+	run, ok := r.runs[runID]
+	if !ok || run.TenantID != tenantID {
+		return nil, ErrDepreciationRunNotFound
+	}
+	found := *run
+	return &found, nil
+}
+
+func (r *depreciationRunMockRepository) GetPostedDepreciationRun(_ context.Context, _, tenantID string, periodStart, periodEnd time.Time) (*DepreciationRun, error) {
+	for _, run := range r.runs {
+		if run.TenantID == tenantID && run.Status == DepreciationRunPosted && run.PeriodStart.Equal(periodStart) && run.PeriodEnd.Equal(periodEnd) {
+			found := *run
+			return &found, nil
+		}
+	}
+	return nil, ErrDepreciationRunNotFound
+}
+
+func (r *depreciationRunMockRepository) ListDepreciationRuns(_ context.Context, _, tenantID string, year int) ([]DepreciationRun, error) {
+	result := []DepreciationRun{}
+	for _, run := range r.runs {
+		if run.TenantID == tenantID && (year == 0 || run.PeriodStart.Year() == year) {
+			result = append(result, *run)
+		}
+	}
+	return result, nil
+}
+
+func (r *depreciationRunMockRepository) MarkDepreciationRunReversed(_ context.Context, _, tenantID, runID, userID, reason string, reversedAt time.Time) error {
+	run, ok := r.runs[runID]
+	if !ok || run.TenantID != tenantID || run.Status != DepreciationRunPosted {
+		return ErrDepreciationRunNotFound
+	}
+	run.Status = DepreciationRunReversed
+	run.ReversedAt = &reversedAt
+	run.ReversedBy = &userID
+	run.ReversalReason = reason
+	return nil
+}
+
+func (r *depreciationRunMockRepository) ListPeriodDepreciationEntries(_ context.Context, _, tenantID string, periodStart, periodEnd time.Time) ([]DepreciationEntry, error) {
+	result := []DepreciationEntry{}
+	for _, entries := range r.DepreciationEntries {
+		for _, entry := range entries {
+			if entry.TenantID == tenantID && !entry.PeriodStart.After(periodEnd) && !entry.PeriodEnd.Before(periodStart) {
+				result = append(result, entry)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *depreciationRunMockRepository) ListDepreciationRunEntries(_ context.Context, _, tenantID, runID string) ([]DepreciationEntry, error) {
+	result := []DepreciationEntry{}
+	for _, entries := range r.DepreciationEntries {
+		for _, entry := range entries {
+			if entry.TenantID == tenantID && entry.RunID != nil && *entry.RunID == runID {
+				result = append(result, entry)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *depreciationRunMockRepository) DeleteDepreciationRunEntries(_ context.Context, _, tenantID, runID string) error {
+	for assetID, entries := range r.DepreciationEntries {
+		kept := entries[:0]
+		for _, entry := range entries {
+			if entry.TenantID == tenantID && entry.RunID != nil && *entry.RunID == runID {
+				continue
+			}
+			kept = append(kept, entry)
+		}
+		r.DepreciationEntries[assetID] = kept
+	}
+	return nil
+}
+
+type depreciationRunLedger struct {
+	*fakeAssetAccountingPoster
+	requests []*accounting.CreateJournalEntryRequest
+	voided   []string
+	voidErr  error
+}
+
+func newDepreciationRunLedger() *depreciationRunLedger {
+	ledger := &depreciationRunLedger{fakeAssetAccountingPoster: newFakeAssetAccountingPoster()}
+	ledger.accounts["vehicle-expense"] = &accounting.Account{ID: "vehicle-expense", AccountType: accounting.AccountTypeExpense}
+	ledger.accounts["vehicle-accumulated"] = &accounting.Account{ID: "vehicle-accumulated", AccountType: accounting.AccountTypeAsset}
+	return ledger
+}
+
+func (l *depreciationRunLedger) CreateJournalEntry(_ context.Context, _, tenantID string, req *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error) {
+	l.requests = append(l.requests, req)
+	return &accounting.JournalEntry{ID: fmt.Sprintf("je-%d", len(l.requests)), TenantID: tenantID, Status: accounting.StatusDraft}, nil
+}
+
+func (l *depreciationRunLedger) VoidJournalEntry(_ context.Context, _, _, entryID, _, reason string) (*accounting.JournalEntry, error) {
+	if l.voidErr != nil {
+		return nil, l.voidErr
+	}
+	l.voided = append(l.voided, entryID)
+	return &accounting.JournalEntry{ID: "void-" + entryID, Description: reason}, nil
+}
+
+func seedDepreciationRunAssets(repo *depreciationRunMockRepository) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	categoryIT := "cat-it"
+	categoryVehicles := "cat-vehicles"
+	repo.Categories[categoryIT] = &AssetCategory{ID: categoryIT, TenantID: "tenant-1", Name: "IT equipment"}
+	repo.Categories[categoryVehicles] = &AssetCategory{ID: categoryVehicles, TenantID: "tenant-1", Name: "Vehicles"}
+	expense := "depreciation-expense"
+	accumulated := "accumulated-depreciation"
+	vehicleExpense := "vehicle-expense"
+	vehicleAccumulated := "vehicle-accumulated"
+
+	for _, asset := range []*FixedAsset{
+		{ID: "laptop", AssetNumber: "FA-00001", Name: "Laptop", CategoryID: &categoryIT, PurchaseCost: decimal.NewFromInt(1200), UsefulLifeMonths: 24, DepreciationExpenseAccountID: &expense, AccumulatedDepreciationAcctID: &accumulated},
+		{ID: "server", AssetNumber: "FA-00002", Name: "Server", CategoryID: &categoryIT, PurchaseCost: decimal.NewFromInt(3600), UsefulLifeMonths: 36, DepreciationExpenseAccountID: &expense, AccumulatedDepreciationAcctID: &accumulated},
+		{ID: "van", AssetNumber: "FA-00003", Name: "Van", CategoryID: &categoryVehicles, PurchaseCost: decimal.NewFromInt(24000), UsefulLifeMonths: 60, DepreciationExpenseAccountID: &vehicleExpense, AccumulatedDepreciationAcctID: &vehicleAccumulated},
+		{ID: "printer", AssetNumber: "FA-00004", Name: "Printer", CategoryID: &categoryIT, PurchaseCost: decimal.NewFromInt(600), UsefulLifeMonths: 12, AccumulatedDepreciation: decimal.NewFromInt(600), DepreciationExpenseAccountID: &expense, AccumulatedDepreciationAcctID: &accumulated},
+		{ID: "forklift", AssetNumber: "FA-00005", Name: "Forklift", CategoryID: &categoryVehicles, PurchaseCost: decimal.NewFromInt(12000), UsefulLifeMonths: 60, DepreciationStartDate: &later, DepreciationExpenseAccountID: &vehicleExpense, AccumulatedDepreciationAcctID: &vehicleAccumulated},
+	} {
+		asset.TenantID = "tenant-1"
+		asset.Status = AssetStatusActive
+		asset.PurchaseDate = start
+		asset.DepreciationMethod = DepreciationStraightLine
+		asset.BookValue = asset.PurchaseCost.Sub(asset.AccumulatedDepreciation)
+		repo.Assets[asset.ID] = asset
+	}
+	repo.Assets["draft"] = &FixedAsset{ID: "draft", TenantID: "tenant-1", AssetNumber: "FA-00006", Status: AssetStatusDraft, PurchaseCost: decimal.NewFromInt(100), UsefulLifeMonths: 10}
+}
+
+func TestDepreciationRunPreviewTotalsByCategory(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	service := NewServiceWithRepositoryAndAccounting(repo, newDepreciationRunLedger())
+
+	preview, err := service.PreviewDepreciationRun(context.Background(), "tenant-1", "tenant_acme", 2026, 1)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), preview.PeriodEnd)
+	assert.Equal(t, 3, preview.AssetCount)
+	assert.Equal(t, "550", preview.TotalAmount.String())
+	require.Len(t, preview.CategoryTotals, 2)
+	assert.Equal(t, "IT equipment", preview.CategoryTotals[0].CategoryName)
+	assert.Equal(t, 2, preview.CategoryTotals[0].AssetCount)
+	assert.Equal(t, "150", preview.CategoryTotals[0].Amount.String())
+	assert.Equal(t, "Vehicles", preview.CategoryTotals[1].CategoryName)
+	assert.Equal(t, "400", preview.CategoryTotals[1].Amount.String())
+	assert.Empty(t, preview.Issues)
+	assert.Nil(t, preview.PostedRun)
+
+	reasons := map[string]string{}
+	for _, skipped := range preview.Skipped {
+		reasons[skipped.AssetNumber] = skipped.Reason
+	}
+	assert.Equal(t, map[string]string{
+		"FA-00004": "fully depreciated",
+		"FA-00005": "depreciation starts after the period",
+	}, reasons)
+
+	_, err = service.PreviewDepreciationRun(context.Background(), "tenant-1", "tenant_acme", 2026, 13)
+	assert.EqualError(t, err, "valid year and month are required")
+}
+
+func TestRunDepreciationPostsAggregatedJournalAndIsIdempotent(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	ledger := newDepreciationRunLedger()
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+
+	run, created, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, DepreciationPostingAggregated, run.PostingMode)
+	assert.Equal(t, DepreciationRunPosted, run.Status)
+	assert.Equal(t, []string{"je-1"}, run.JournalEntryIDs)
+	require.Len(t, run.Entries, 3)
+
+	require.Len(t, ledger.requests, 1)
+	journal := ledger.requests[0]
+	assert.Equal(t, SourceTypeAssetDepreciationRun, journal.SourceType)
+	assert.Equal(t, "DEPR-2026-01", journal.Reference)
+	assert.Equal(t, run.PeriodEnd, journal.EntryDate)
+	require.Len(t, journal.Lines, 4)
+	debits := map[string]string{}
+	credits := map[string]string{}
+	for _, line := range journal.Lines {
+		if line.DebitAmount.IsPositive() {
+			debits[line.AccountID] = line.DebitAmount.String()
+		} else {
+			credits[line.AccountID] = line.CreditAmount.String()
+		}
+	}
+	assert.Equal(t, map[string]string{"depreciation-expense": "150", "vehicle-expense": "400"}, debits)
+	assert.Equal(t, map[string]string{"accumulated-depreciation": "150", "vehicle-accumulated": "400"}, credits)
+	assert.Equal(t, []string{"je-1"}, ledger.postedIDs)
+
+	laptop := repo.Assets["laptop"]
+	assert.Equal(t, "50", laptop.AccumulatedDepreciation.String())
+	assert.Equal(t, "1150", laptop.BookValue.String())
+	require.NotNil(t, laptop.LastDepreciationDate)
+	assert.Equal(t, run.PeriodEnd, *laptop.LastDepreciationDate)
+	require.NotNil(t, repo.DepreciationEntries["laptop"][0].JournalEntryID)
+	assert.Equal(t, "je-1", *repo.DepreciationEntries["laptop"][0].JournalEntryID)
+
+	again, created, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, run.ID, again.ID)
+	assert.Len(t, ledger.requests, 1)
+
+	preview, err := service.PreviewDepreciationRun(ctx, "tenant-1", "tenant_acme", 2026, 1)
+	require.NoError(t, err)
+	require.NotNil(t, preview.PostedRun)
+	assert.Equal(t, run.ID, preview.PostedRun.ID)
+	assert.Zero(t, preview.AssetCount)
+
+	runs, err := service.ListDepreciationRuns(ctx, "tenant-1", "tenant_acme", 2026)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+}
+
+func TestRunDepreciationSkipsAssetsDepreciatedManually(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	ledger := newDepreciationRunLedger()
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+
+	_, err := service.RecordDepreciation(ctx, "tenant-1", "tenant_acme", "van", "user-1",
+		time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	run, created, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{
+		Year: 2026, Month: 2, PostingMode: DepreciationPostingPerAsset, UserID: "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 2, run.AssetCount)
+	assert.Equal(t, "150", run.TotalAmount.String())
+	assert.Equal(t, []string{"je-2", "je-3"}, run.JournalEntryIDs)
+	for _, request := range ledger.requests[1:] {
+		assert.Equal(t, SourceTypeAssetDepreciation, request.SourceType)
+	}
+	assert.Len(t, repo.DepreciationEntries["van"], 1)
+	assert.Equal(t, "400", repo.Assets["van"].AccumulatedDepreciation.String())
+}
+
+func TestRunDepreciationSkipsMonthsBeforeTheLastDepreciation(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	ledger := newDepreciationRunLedger()
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+
+	march, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 3, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, march.AssetCount)
+
+	preview, err := service.PreviewDepreciationRun(ctx, "tenant-1", "tenant_acme", 2026, 2)
+	require.NoError(t, err)
+	assert.Zero(t, preview.AssetCount)
+	reasons := map[string]string{}
+	for _, skipped := range preview.Skipped {
+		reasons[skipped.AssetNumber] = skipped.Reason
+	}
+	assert.Equal(t, "already depreciated through 2026-03-31", reasons["FA-00001"])
+
+	empty, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 2, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Zero(t, empty.AssetCount)
+	assert.Empty(t, empty.JournalEntryIDs)
+	assert.Len(t, ledger.requests, 1)
+	assert.Equal(t, "50", repo.Assets["laptop"].AccumulatedDepreciation.String())
+	assert.Equal(t, march.PeriodEnd, *repo.Assets["laptop"].LastDepreciationDate)
+
+	for _, runID := range []string{march.ID, empty.ID} {
+		_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", runID, &ReverseDepreciationRunRequest{Reason: "Wrong month", UserID: "user-1"})
+		require.NoError(t, err)
+	}
+	february, created, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 2, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 3, february.AssetCount)
+	assert.Equal(t, february.PeriodEnd, *repo.Assets["laptop"].LastDepreciationDate)
+}
+
+func TestRunDepreciationRejectsInvalidRequests(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	repo.Assets["laptop"].DepreciationExpenseAccountID = nil
+	service := NewServiceWithRepositoryAndAccounting(repo, newDepreciationRunLedger())
+	ctx := context.Background()
+
+	_, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, PostingMode: "WEEKLY"})
+	assert.EqualError(t, err, "posting mode must be AGGREGATED or PER_ASSET")
+
+	_, _, err = service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.ErrorIs(t, err, ErrAssetAccountingInvalid)
+	assert.Contains(t, err.Error(), "asset FA-00001 has no depreciation expense or accumulated depreciation account")
+	assert.Empty(t, repo.runs)
+
+	_, _, err = NewServiceWithRepository(NewMockRepository()).RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1})
+	assert.ErrorIs(t, err, ErrDepreciationRunsUnavailable)
+}
+
+func TestReverseDepreciationRunRestoresAssets(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	ledger := newDepreciationRunLedger()
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+
+	january, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.NoError(t, err)
+	february, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 2, UserID: "user-1"})
+	require.NoError(t, err)
+
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", january.ID, &ReverseDepreciationRunRequest{UserID: "user-1"})
+	assert.EqualError(t, err, "reversal reason is required")
+
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", january.ID, &ReverseDepreciationRunRequest{Reason: "Wrong month", UserID: "user-1"})
+	assert.ErrorContains(t, err, "reverse the later depreciation first")
+
+	reversed, err := service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", february.ID, &ReverseDepreciationRunRequest{Reason: "Wrong month", UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, DepreciationRunReversed, reversed.Status)
+	assert.Equal(t, "Wrong month", reversed.ReversalReason)
+	assert.Empty(t, reversed.Entries)
+	assert.Equal(t, []string{"je-2"}, ledger.voided)
+
+	laptop := repo.Assets["laptop"]
+	assert.Equal(t, "50", laptop.AccumulatedDepreciation.String())
+	assert.Equal(t, "1150", laptop.BookValue.String())
+	require.NotNil(t, laptop.LastDepreciationDate)
+	assert.Equal(t, january.PeriodEnd, *laptop.LastDepreciationDate)
+
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", february.ID, &ReverseDepreciationRunRequest{Reason: "Again", UserID: "user-1"})
+	assert.EqualError(t, err, "only POSTED depreciation runs can be reversed, current status: REVERSED")
+
+	rerun, created, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 2, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.NotEqual(t, february.ID, rerun.ID)
+
+	repo.Assets["server"].Status = AssetStatusDisposed
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", rerun.ID, &ReverseDepreciationRunRequest{Reason: "Disposed", UserID: "user-1"})
+	assert.EqualError(t, err, "asset FA-00002 is DISPOSED and its depreciation cannot be reversed")
+
+	repo.Assets["server"].Status = AssetStatusActive
+	ledger.voidErr = errors.New("period locked")
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", rerun.ID, &ReverseDepreciationRunRequest{Reason: "Locked", UserID: "user-1"})
+	assert.EqualError(t, err, "void depreciation journal: period locked")
+
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", "missing", &ReverseDepreciationRunRequest{Reason: "Missing", UserID: "user-1"})
+	assert.ErrorIs(t, err, ErrDepreciationRunNotFound)
+}
+
+func TestReverseDepreciationRunRequiresJournalVoiding(t *testing.T) {
+	repo := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(repo)
+	ledger := newDepreciationRunLedger()
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger.fakeAssetAccountingPoster)
+	ctx := context.Background()
+
+	run, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.NoError(t, err)
+
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", run.ID, &ReverseDepreciationRunRequest{Reason: "Wrong", UserID: "user-1"})
+	require.ErrorIs(t, err, ErrAssetAccountingInvalid)
+	assert.Equal(t, DepreciationRunPosted, repo.runs[run.ID].Status)
+}
+
+// transactionalDepreciationRunRepository restores assets, entries, runs and
+// ledger postings when the transaction callback fails.
+type transactionalDepreciationRunRepository struct {
+	*depreciationRunMockRepository
+	ledger             *depreciationRunLedger
+	createRunErr       error
+	markReversedErr    error
+	transactionsOpened int
+}
+
+func (r *transactionalDepreciationRunRepository) CreateDepreciationRun(ctx context.Context, schemaName string, run *DepreciationRun) error {
+	if r.createRunErr != nil {
+		return r.createRunErr
+	}
+	return r.depreciationRunMockRepository.CreateDepreciationRun(ctx, schemaName, run)
+}
+
+func (r *transactionalDepreciationRunRepository) MarkDepreciationRunReversed(ctx context.Context, schemaName, tenantID, runID, userID, reason string, reversedAt time.Time) error {
+	if r.markReversedErr != nil {
+		return r.markReversedErr
+	}
+	return r.depreciationRunMockRepository.MarkDepreciationRunReversed(ctx, schemaName, tenantID, runID, userID, reason, reversedAt)
+}
+
+func (r *transactionalDepreciationRunRepository) WithLedgerTransaction(_ context.Context, fn func(txRepo Repository, ledger accountingPoster) error) error {
+	r.transactionsOpened++
+	assets := make(map[string]FixedAsset, len(r.Assets))
+	for id, asset := range r.Assets {
+		assets[id] = *asset
+	}
+	entries := make(map[string][]DepreciationEntry, len(r.DepreciationEntries))
+	for id, assetEntries := range r.DepreciationEntries {
+		entries[id] = append([]DepreciationEntry(nil), assetEntries...)
+	}
+	runs := make(map[string]DepreciationRun, len(r.runs))
+	for id, run := range r.runs {
+		runs[id] = *run
+	}
+	requests, posted, voided := len(r.ledger.requests), len(r.ledger.postedIDs), len(r.ledger.voided)
+
+	if err := fn(r, r.ledger); err != nil {
+		for id, asset := range assets {
+			*r.Assets[id] = asset
+		}
+		r.DepreciationEntries = entries
+		r.runs = make(map[string]*DepreciationRun, len(runs))
+		for id, run := range runs {
+			run := run
+			r.runs[id] = &run
+		}
+		r.ledger.requests = r.ledger.requests[:requests]
+		r.ledger.postedIDs = r.ledger.postedIDs[:posted]
+		r.ledger.voided = r.ledger.voided[:voided]
+		return err
+	}
+	return nil
+}
+
+func TestRunDepreciationRollsBackJournalsWhenRunCannotBeStored(t *testing.T) {
+	base := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(base)
+	ledger := newDepreciationRunLedger()
+	repo := &transactionalDepreciationRunRepository{
+		depreciationRunMockRepository: base,
+		ledger:                        ledger,
+		createRunErr:                  errors.New(`duplicate key value violates unique constraint "idx_depreciation_runs_posted_period"`),
+	}
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+
+	run, created, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.ErrorContains(t, err, "create depreciation run")
+	assert.Nil(t, run)
+	assert.False(t, created)
+	assert.Equal(t, 1, repo.transactionsOpened)
+	assert.Empty(t, ledger.requests)
+	assert.Empty(t, ledger.postedIDs)
+	assert.Empty(t, base.runs)
+	assert.Empty(t, base.DepreciationEntries)
+	assert.True(t, base.Assets["laptop"].AccumulatedDepreciation.IsZero())
+	assert.Nil(t, base.Assets["laptop"].LastDepreciationDate)
+
+	repo.createRunErr = nil
+	run, created, err = service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, []string{"je-1"}, run.JournalEntryIDs)
+	assert.Equal(t, "50", base.Assets["laptop"].AccumulatedDepreciation.String())
+}
+
+func TestReverseDepreciationRunRollsBackVoidsWhenRunCannotBeMarked(t *testing.T) {
+	base := newDepreciationRunMockRepository()
+	seedDepreciationRunAssets(base)
+	ledger := newDepreciationRunLedger()
+	repo := &transactionalDepreciationRunRepository{depreciationRunMockRepository: base, ledger: ledger}
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+
+	run, _, err := service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 1, UserID: "user-1"})
+	require.NoError(t, err)
+
+	repo.markReversedErr = errors.New("connection reset")
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", run.ID, &ReverseDepreciationRunRequest{Reason: "Wrong month", UserID: "user-1"})
+	require.EqualError(t, err, "mark depreciation run reversed: connection reset")
+	assert.Empty(t, ledger.voided)
+	assert.Equal(t, DepreciationRunPosted, base.runs[run.ID].Status)
+	assert.Len(t, base.DepreciationEntries["laptop"], 1)
+	assert.Equal(t, "50", base.Assets["laptop"].AccumulatedDepreciation.String())
+}
@@ -0,0 +1,543 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// AssetEventType identifies a change to an active asset's cost, accumulated
+// depreciation or depreciation estimates.
+type AssetEventType string
+
+const (
+	// AssetEventImprovement capitalises an improvement onto the asset's cost.
+	AssetEventImprovement AssetEventType = "IMPROVEMENT"
+	// AssetEventImpairment writes the asset's book value down.
+	AssetEventImpairment AssetEventType = "IMPAIRMENT"
+	// AssetEventEstimateChange revises the useful life or residual value.
+	AssetEventEstimateChange AssetEventType = "ESTIMATE_CHANGE"
+)
+
+const (
+	// SourceTypeAssetImprovement marks journal entries posted from capitalised asset improvements.
+	SourceTypeAssetImprovement = "ASSET_IMPROVEMENT"
+	// SourceTypeAssetImpairment marks journal entries posted from asset impairments.
+	SourceTypeAssetImpairment = "ASSET_IMPAIRMENT"
+)
+
+// Asset history line types that are not asset events.
+const (
+	AssetHistoryAcquisition  = "ACQUISITION"
+	AssetHistoryDepreciation = "DEPRECIATION"
+	AssetHistoryDisposal     = "DISPOSAL"
+)
+
+// ErrAssetEventsUnavailable is returned when the repository cannot store asset events.
+var ErrAssetEventsUnavailable = errors.New("asset events are unavailable")
+
+// AssetEvent records an improvement, impairment or estimate change together
+// with the asset's values before and after it.
+type AssetEvent struct {
+	ID                        string          `json:"id"`
+	TenantID                  string          `json:"tenant_id"`
+	AssetID                   string          `json:"asset_id"`
+	EventType                 AssetEventType  `json:"event_type"`
+	EventDate                 time.Time       `json:"event_date"`
+	Amount                    decimal.Decimal `json:"amount"`
+	Description               string          `json:"description,omitempty"`
+	CostBefore                decimal.Decimal `json:"cost_before"`
+	CostAfter                 decimal.Decimal `json:"cost_after"`
+	AccumulatedBefore         decimal.Decimal `json:"accumulated_before"`
+	AccumulatedAfter          decimal.Decimal `json:"accumulated_after"`
+	BookValueBefore           decimal.Decimal `json:"book_value_before"`
+	BookValueAfter            decimal.Decimal `json:"book_value_after"`
+	UsefulLifeBefore          int             `json:"useful_life_before"`
+	UsefulLifeAfter           int             `json:"useful_life_after"`
+	ResidualValueBefore       decimal.Decimal `json:"residual_value_before"`
+	ResidualValueAfter        decimal.Decimal `json:"residual_value_after"`
+	MonthlyDepreciationBefore decimal.Decimal `json:"monthly_depreciation_before"`
+	MonthlyDepreciationAfter  decimal.Decimal `json:"monthly_depreciation_after"`
+	OffsetAccountID           *string         `json:"offset_account_id,omitempty"`
+	JournalEntryID            *string         `json:"journal_entry_id,omitempty"`
+	CreatedBy                 string          `json:"created_by"`
+	CreatedAt                 time.Time       `json:"created_at"`
+}
+
+// CreateAssetEventRequest records an asset event. Improvements need the amount
+// capitalised and the account credited; impairments need the amount written
+// down and the expense account debited; estimate changes carry a new useful
+// life, residual value or both. Improvements and impairments may revise the
+// estimates too.
+type CreateAssetEventRequest struct {
+	EventType        AssetEventType   `json:"event_type"`
+	EventDate        time.Time        `json:"event_date"`
+	Amount           decimal.Decimal  `json:"amount"`
+	UsefulLifeMonths *int             `json:"useful_life_months,omitempty"`
+	ResidualValue    *decimal.Decimal `json:"residual_value,omitempty"`
+	OffsetAccountID  *string          `json:"offset_account_id,omitempty"`
+	Description      string           `json:"description,omitempty"`
+	UserID           string           `json:"-"`
+}
+
+// AssetHistoryLine is one change in an asset's net book value.
+type AssetHistoryLine struct {
+	Date              time.Time       `json:"date"`
+	Type              string          `json:"type"`
+	SourceID          string          `json:"source_id,omitempty"`
+	Description       string          `json:"description,omitempty"`
+	CostChange        decimal.Decimal `json:"cost_change"`
+	AccumulatedChange decimal.Decimal `json:"accumulated_change"`
+	BookValueChange   decimal.Decimal `json:"book_value_change"`
+	CostAfter         decimal.Decimal `json:"cost_after"`
+	AccumulatedAfter  decimal.Decimal `json:"accumulated_after"`
+	BookValueAfter    decimal.Decimal `json:"book_value_after"`
+	JournalEntryID    *string         `json:"journal_entry_id,omitempty"`
+	createdAt         time.Time
+}
+
+// AssetHistory explains an asset's net book value from acquisition through
+// depreciation, events and disposal.
+type AssetHistory struct {
+	AssetID                 string             `json:"asset_id"`
+	AssetNumber             string             `json:"asset_number"`
+	AssetName               string             `json:"asset_name"`
+	Status                  AssetStatus        `json:"status"`
+	PurchaseCost            decimal.Decimal    `json:"purchase_cost"`
+	AccumulatedDepreciation decimal.Decimal    `json:"accumulated_depreciation"`
+	BookValue               decimal.Decimal    `json:"book_value"`
+	Lines                   []AssetHistoryLine `json:"lines"`
+}
+
+// CreateAssetEvent records an improvement, impairment or estimate change on an
+// active asset, posts its journal entry and revises depreciation prospectively:
+// the new book value less the residual value is spread over the months of
+// useful life left from the event month. Posted depreciation is not restated.
+// The asset row stays locked while the journal, the event and the revised
+// asset are stored in one transaction.
+func (s *Service) CreateAssetEvent(ctx context.Context, tenantID, schemaName, assetID string, req *CreateAssetEventRequest) (*AssetEvent, error) {
+	if s.events == nil {
+		return nil, ErrAssetEventsUnavailable
+	}
+	if req == nil {
+		return nil, fmt.Errorf("asset event is required")
+	}
+	if !isValidAssetEventType(req.EventType) {
+		return nil, fmt.Errorf("invalid event type %q", req.EventType)
+	}
+	if req.EventDate.IsZero() {
+		return nil, fmt.Errorf("event date is required")
+	}
+
+	var event *AssetEvent
+	err := s.withLedgerTransaction(ctx, func(tx *Service) error {
+		var err error
+		event, err = tx.createAssetEvent(ctx, tenantID, schemaName, assetID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+func (s *Service) createAssetEvent(ctx context.Context, tenantID, schemaName, assetID string, req *CreateAssetEventRequest) (*AssetEvent, error) {
+	eventDate := dateOnly(req.EventDate)
+	asset, err := s.getAssetForUpdate(ctx, tenantID, schemaName, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("get asset: %w", err)
+	}
+	if asset.Status != AssetStatusActive {
+		return nil, fmt.Errorf("only active assets can have events")
+	}
+	if eventDate.Before(dateOnly(asset.PurchaseDate)) {
+		return nil, fmt.Errorf("event date cannot be before the purchase date")
+	}
+
+	entries, err := s.repo.ListDepreciationEntries(ctx, schemaName, tenantID, asset.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list depreciation entries: %w", err)
+	}
+	var lastPeriodEnd *time.Time
+	for _, entry := range entries {
+		periodEnd := dateOnly(entry.PeriodEnd)
+		if lastPeriodEnd == nil || periodEnd.After(*lastPeriodEnd) {
+			lastPeriodEnd = &periodEnd
+		}
+	}
+	if lastPeriodEnd != nil && eventDate.Before(*lastPeriodEnd) {
+		return nil, fmt.Errorf("asset is depreciated through %s; date the event on or after it", lastPeriodEnd.Format("2006-01-02"))
+	}
+
+	usefulLife := asset.UsefulLifeMonths
+	if req.UsefulLifeMonths != nil {
+		if *req.UsefulLifeMonths <= 0 {
+			return nil, fmt.Errorf("useful life must be positive")
+		}
+		usefulLife = *req.UsefulLifeMonths
+	}
+	residualValue := asset.ResidualValue
+	if req.ResidualValue != nil {
+		if req.ResidualValue.IsNegative() {
+			return nil, fmt.Errorf("residual value cannot be negative")
+		}
+		residualValue = *req.ResidualValue
+	}
+
+	cost := asset.PurchaseCost
+	accumulated := asset.AccumulatedDepreciation
+	switch req.EventType {
+	case AssetEventImprovement:
+		if !req.Amount.IsPositive() {
+			return nil, fmt.Errorf("improvement amount must be positive")
+		}
+		cost = cost.Add(req.Amount)
+	case AssetEventImpairment:
+		if !req.Amount.IsPositive() {
+			return nil, fmt.Errorf("impairment amount must be positive")
+		}
+		accumulated = accumulated.Add(req.Amount)
+	case AssetEventEstimateChange:
+		if !req.Amount.IsZero() {
+			return nil, fmt.Errorf("estimate changes do not take an amount")
+		}
+		if req.UsefulLifeMonths == nil && req.ResidualValue == nil {
+			return nil, fmt.Errorf("useful life or residual value is required for an estimate change")
+		}
+	}
+	bookValue := cost.Sub(accumulated)
+	if bookValue.LessThan(residualValue) {
+		return nil, fmt.Errorf("book value %s after the event would be below the residual value %s", bookValue.StringFixed(2), residualValue.StringFixed(2))
+	}
+
+	revised := *asset
+	revised.PurchaseCost = cost
+	revised.AccumulatedDepreciation = accumulated
+	revised.BookValue = bookValue
+	revised.UsefulLifeMonths = usefulLife
+	revised.ResidualValue = residualValue
+	remainingMonths := remainingLifeMonths(&revised, eventDate, lastPeriodEnd)
+	if remainingMonths <= 0 {
+		return nil, fmt.Errorf("asset has no useful life left after %s; extend the useful life", eventDate.Format("2006-01-02"))
+	}
+	revised.RevisedDepreciableAmount = bookValue.Sub(residualValue)
+	revised.RevisedLifeMonths = remainingMonths
+
+	now := time.Now()
+	event := &AssetEvent{
+		ID:                        uuid.New().String(),
+		TenantID:                  tenantID,
+		AssetID:                   asset.ID,
+		EventType:                 req.EventType,
+		EventDate:                 eventDate,
+		Amount:                    req.Amount,
+		Description:               strings.TrimSpace(req.Description),
+		CostBefore:                asset.PurchaseCost,
+		CostAfter:                 cost,
+		AccumulatedBefore:         asset.AccumulatedDepreciation,
+		AccumulatedAfter:          accumulated,
+		BookValueBefore:           asset.PurchaseCost.Sub(asset.AccumulatedDepreciation),
+		BookValueAfter:            bookValue,
+		UsefulLifeBefore:          asset.UsefulLifeMonths,
+		UsefulLifeAfter:           usefulLife,
+		ResidualValueBefore:       asset.ResidualValue,
+		ResidualValueAfter:        residualValue,
+		MonthlyDepreciationBefore: asset.CalculateMonthlyDepreciation(),
+		MonthlyDepreciationAfter:  revised.CalculateMonthlyDepreciation(),
+		OffsetAccountID:           nonEmptyStringPtr(trimmedStringPtr(req.OffsetAccountID)),
+		CreatedBy:                 req.UserID,
+		CreatedAt:                 now,
+	}
+
+	journalEntryID, err := s.recordAssetEventJournal(ctx, schemaName, tenantID, asset, event)
+	if err != nil {
+		return nil, err
+	}
+	event.JournalEntryID = journalEntryID
+
+	revised.UpdatedAt = now
+	if err := s.events.CreateAssetEvent(ctx, schemaName, event, &revised); err != nil {
+		return nil, fmt.Errorf("create asset event: %w", err)
+	}
+	return event, nil
+}
+
+// ListAssetEvents retrieves an asset's events in date order.
+func (s *Service) ListAssetEvents(ctx context.Context, tenantID, schemaName, assetID string) ([]AssetEvent, error) {
+	if s.events == nil {
+		return nil, ErrAssetEventsUnavailable
+	}
+	if _, err := s.repo.GetByID(ctx, schemaName, tenantID, assetID); err != nil {
+		return nil, fmt.Errorf("get asset: %w", err)
+	}
+	events, err := s.events.ListAssetEvents(ctx, schemaName, tenantID, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("list asset events: %w", err)
+	}
+	return events, nil
+}
+
+// GetAssetHistory explains every change in an asset's net book value: the
+// acquisition, each posted depreciation, improvements, impairments, estimate
+// changes and the disposal. Accumulated depreciation imported with the asset
+// is shown on the acquisition line.
+func (s *Service) GetAssetHistory(ctx context.Context, tenantID, schemaName, assetID string) (*AssetHistory, error) {
+	asset, err := s.repo.GetByID(ctx, schemaName, tenantID, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("get asset: %w", err)
+	}
+	entries, err := s.repo.ListDepreciationEntries(ctx, schemaName, tenantID, asset.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list depreciation entries: %w", err)
+	}
+	var events []AssetEvent
+	if s.events != nil {
+		if events, err = s.events.ListAssetEvents(ctx, schemaName, tenantID, asset.ID); err != nil {
+			return nil, fmt.Errorf("list asset events: %w", err)
+		}
+	}
+
+	originalCost := asset.PurchaseCost
+	openingAccumulated := asset.AccumulatedDepreciation
+	changes := make([]AssetHistoryLine, 0, len(entries)+len(events))
+	for _, entry := range entries {
+		openingAccumulated = openingAccumulated.Sub(entry.DepreciationAmount)
+		changes = append(changes, AssetHistoryLine{
+			Date:              dateOnly(entry.PeriodEnd),
+			Type:              AssetHistoryDepreciation,
+			SourceID:          entry.ID,
+			Description:       fmt.Sprintf("Depreciation %s to %s", entry.PeriodStart.Format("2006-01-02"), entry.PeriodEnd.Format("2006-01-02")),
+			CostChange:        decimal.Zero,
+			AccumulatedChange: entry.DepreciationAmount,
+			JournalEntryID:    entry.JournalEntryID,
+			createdAt:         entry.CreatedAt,
+		})
+	}
+	for _, event := range events {
+		costChange := event.CostAfter.Sub(event.CostBefore)
+		accumulatedChange := event.AccumulatedAfter.Sub(event.AccumulatedBefore)
+		originalCost = originalCost.Sub(costChange)
+		openingAccumulated = openingAccumulated.Sub(accumulatedChange)
+		changes = append(changes, AssetHistoryLine{
+			Date:              dateOnly(event.EventDate),
+			Type:              string(event.EventType),
+			SourceID:          event.ID,
+			Description:       assetEventHistoryDescription(&event),
+			CostChange:        costChange,
+			AccumulatedChange: accumulatedChange,
+			JournalEntryID:    event.JournalEntryID,
+			createdAt:         event.CreatedAt,
+		})
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if !changes[i].Date.Equal(changes[j].Date) {
+			return changes[i].Date.Before(changes[j].Date)
+		}
+		return changes[i].createdAt.Before(changes[j].createdAt)
+	})
+
+	history := &AssetHistory{
+		AssetID:                 asset.ID,
+		AssetNumber:             asset.AssetNumber,
+		AssetName:               asset.Name,
+		Status:                  asset.Status,
+		PurchaseCost:            asset.PurchaseCost,
+		AccumulatedDepreciation: asset.AccumulatedDepreciation,
+		BookValue:               asset.BookValue,
+		Lines:                   make([]AssetHistoryLine, 0, len(changes)+2),
+	}
+	acquisition := AssetHistoryLine{
+		Date:              dateOnly(asset.PurchaseDate),
+		Type:              AssetHistoryAcquisition,
+		SourceID:          asset.ID,
+		Description:       "Acquisition",
+		CostChange:        originalCost,
+		AccumulatedChange: openingAccumulated,
+	}
+	if !openingAccumulated.IsZero() {
+		acquisition.Description = "Acquisition with opening accumulated depreciation"
+	}
+	cost := decimal.Zero
+	accumulated := decimal.Zero
+	for _, line := range append([]AssetHistoryLine{acquisition}, changes...) {
+		cost = cost.Add(line.CostChange)
+		accumulated = accumulated.Add(line.AccumulatedChange)
+		line.BookValueChange = line.CostChange.Sub(line.AccumulatedChange)
+		line.CostAfter = cost
+		line.AccumulatedAfter = accumulated
+		line.BookValueAfter = cost.Sub(accumulated)
+		history.Lines = append(history.Lines, line)
+	}
+	if asset.DisposalDate != nil {
+		history.Lines = append(history.Lines, AssetHistoryLine{
+			Date:              dateOnly(*asset.DisposalDate),
+			Type:              AssetHistoryDisposal,
+			SourceID:          asset.ID,
+			Description:       strings.TrimSpace("Disposal " + disposalMethodLabel(asset.DisposalMethod)),
+			CostChange:        cost.Neg(),
+			AccumulatedChange: accumulated.Neg(),
+			BookValueChange:   cost.Sub(accumulated).Neg(),
+			CostAfter:         decimal.Zero,
+			AccumulatedAfter:  decimal.Zero,
+			BookValueAfter:    decimal.Zero,
+			JournalEntryID:    asset.DisposalJournalEntryID,
+		})
+	}
+	return history, nil
+}
+
+func (s *Service) recordAssetEventJournal(ctx context.Context, schemaName, tenantID string, asset *FixedAsset, event *AssetEvent) (*string, error) {
+	if event.EventType == AssetEventEstimateChange {
+		return nil, nil
+	}
+	offsetAccountID := trimmedStringPtr(event.OffsetAccountID)
+	if offsetAccountID == "" {
+		return nil, fmt.Errorf("%w: offset account is required for %s posting", ErrAssetAccountingInvalid, strings.ToLower(string(event.EventType)))
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("%w: accounting service is unavailable", ErrAssetAccountingInvalid)
+	}
+
+	var debitAccountID, creditAccountID, sourceType string
+	switch event.EventType {
+	case AssetEventImprovement:
+		debitAccountID = trimmedStringPtr(asset.AssetAccountID)
+		if debitAccountID == "" {
+			return nil, fmt.Errorf("%w: asset account is required for improvement posting", ErrAssetAccountingInvalid)
+		}
+		if err := s.requireAccountType(ctx, schemaName, tenantID, debitAccountID, "asset account", accounting.AccountTypeAsset); err != nil {
+			return nil, err
+		}
+		// Improvements are paid for or owed: credit a bank, clearing or payable account
+		offsetAccount, err := s.ledger.GetAccount(ctx, schemaName, tenantID, offsetAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: load improvement offset account: %v", ErrAssetAccountingInvalid, err)
+		}
+		if offsetAccount.AccountType != accounting.AccountTypeAsset && offsetAccount.AccountType != accounting.AccountTypeLiability {
+			return nil, fmt.Errorf("%w: improvement offset account must be ASSET or LIABILITY", ErrAssetAccountingInvalid)
+		}
+		creditAccountID = offsetAccountID
+		sourceType = SourceTypeAssetImprovement
+	case AssetEventImpairment:
+		creditAccountID = trimmedStringPtr(asset.AccumulatedDepreciationAcctID)
+		if creditAccountID == "" {
+			return nil, fmt.Errorf("%w: accumulated depreciation account is required for impairment posting", ErrAssetAccountingInvalid)
+		}
+		if err := s.requireAccountType(ctx, schemaName, tenantID, creditAccountID, "accumulated depreciation account", accounting.AccountTypeAsset); err != nil {
+			return nil, err
+		}
+		if err := s.requireAccountType(ctx, schemaName, tenantID, offsetAccountID, "impairment expense account", accounting.AccountTypeExpense); err != nil {
+			return nil, err
+		}
+		debitAccountID = offsetAccountID
+		sourceType = SourceTypeAssetImpairment
+	}
+
+	description := assetEventJournalDescription(asset, event)
+	sourceID := event.ID
+	journalEntry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   event.EventDate,
+		Description: description,
+		Reference:   disposalJournalReference(asset, event.EventDate),
+		SourceType:  sourceType,
+		SourceID:    &sourceID,
+		UserID:      event.CreatedBy,
+		Lines: []accounting.CreateJournalEntryLineReq{
+			{
+				AccountID:    debitAccountID,
+				Description:  description,
+				DebitAmount:  event.Amount,
+				CreditAmount: decimal.Zero,
+			},
+			{
+				AccountID:    creditAccountID,
+				Description:  description,
+				DebitAmount:  decimal.Zero,
+				CreditAmount: event.Amount,
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create %s journal: %w", strings.ToLower(string(event.EventType)), err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, journalEntry.ID, event.CreatedBy, "Fixed asset "+strings.ToLower(string(event.EventType))+" posting"); err != nil {
+		return nil, fmt.Errorf("post %s journal: %w", strings.ToLower(string(event.EventType)), err)
+	}
+	return &journalEntry.ID, nil
+}
+
+// remainingLifeMonths counts the months of useful life left from the first
+// month not yet depreciated on or after the event.
+func remainingLifeMonths(asset *FixedAsset, eventDate time.Time, lastPeriodEnd *time.Time) int {
+	startMonth := depreciationStartMonth(asset)
+	first := monthStart(eventDate)
+	if lastPeriodEnd != nil {
+		if afterLast := monthStart(*lastPeriodEnd).AddDate(0, 1, 0); afterLast.After(first) {
+			first = afterLast
+		}
+	}
+	if startMonth.After(first) {
+		first = startMonth
+	}
+	endOfLife := startMonth.AddDate(0, asset.UsefulLifeMonths, 0)
+	return (endOfLife.Year()-first.Year())*12 + int(endOfLife.Month()) - int(first.Month())
+}
+
+func isValidAssetEventType(eventType AssetEventType) bool {
+	switch eventType {
+	case AssetEventImprovement, AssetEventImpairment, AssetEventEstimateChange:
+		return true
+	default:
+		return false
+	}
+}
+
+func assetEventJournalDescription(asset *FixedAsset, event *AssetEvent) string {
+	label := "Improvement"
+	if event.EventType == AssetEventImpairment {
+		label = "Impairment"
+	}
+	assetNumber := strings.TrimSpace(asset.AssetNumber)
+	name := strings.TrimSpace(asset.Name)
+	switch {
+	case assetNumber != "" && name != "":
+		return fmt.Sprintf("%s %s - %s", label, assetNumber, name)
+	case assetNumber != "":
+		return fmt.Sprintf("%s %s", label, assetNumber)
+	case name != "":
+		return fmt.Sprintf("%s %s", label, name)
+	default:
+		return fmt.Sprintf("%s asset %s", label, asset.ID)
+	}
+}
+
+func assetEventHistoryDescription(event *AssetEvent) string {
+	var parts []string
+	if event.Description != "" {
+		parts = append(parts, event.Description)
+	}
+	if event.UsefulLifeAfter != event.UsefulLifeBefore {
+		parts = append(parts, fmt.Sprintf("useful life %d to %d months", event.UsefulLifeBefore, event.UsefulLifeAfter))
+	}
+	if !event.ResidualValueAfter.Equal(event.ResidualValueBefore) {
+		parts = append(parts, fmt.Sprintf("residual value %s to %s", event.ResidualValueBefore.StringFixed(2), event.ResidualValueAfter.StringFixed(2)))
+	}
+	if !event.MonthlyDepreciationAfter.Equal(event.MonthlyDepreciationBefore) {
+		parts = append(parts, fmt.Sprintf("monthly depreciation %s to %s", event.MonthlyDepreciationBefore.StringFixed(2), event.MonthlyDepreciationAfter.StringFixed(2)))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func disposalMethodLabel(method *DisposalMethod) string {
+	if method == nil {
+		return ""
+	}
+	return strings.ToLower(string(*method))
+}
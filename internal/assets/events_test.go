@@ -0,0 +1,378 @@
+package assets
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type assetEventMockRepository struct {
+	*depreciationRunMockRepository
+	events map[string][]AssetEvent
+}
+
+func newAssetEventMockRepository() *assetEventMockRepository {
+	return &assetEventMockRepository{depreciationRunMockRepository: newDepreciationRunMockRepository(), events: make(map[string][]AssetEvent)}
+}
+
+func (r *assetEventMockRepository) CreateAssetEvent(_ context.Context, _ string, event *AssetEvent, asset *FixedAsset) error {
+	stored, ok := r.Assets[asset.ID]
+	if !ok || stored.Status != AssetStatusActive {
+		return ErrAssetNotFound
+	}
+	r.events[event.AssetID] = append(r.events[event.AssetID], *event)
+	updated := *asset
+	r.Assets[asset.ID] = &updated
+	return nil
+}
+
+func (r *assetEventMockRepository) ListAssetEvents(_ context.Context, _, tenantID, assetID string) ([]AssetEvent, error) {
+	result := []AssetEvent{}
+	for _, event := range r.events[assetID] {
+		if event.TenantID == tenantID {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+// transactionalAssetEventRepository locks assets and restores assets, events
+// and ledger postings when the transaction callback fails.
+type transactionalAssetEventRepository struct {
+	*assetEventMockRepository
+	ledger         *depreciationRunLedger
+	createEventErr error
+	onLock         func(assetID string)
+	locked         []string
+}
+
+func (r *transactionalAssetEventRepository) GetByIDForUpdate(ctx context.Context, schemaName, tenantID, assetID string) (*FixedAsset, error) {
+	r.locked = append(r.locked, assetID)
+	if r.onLock != nil {
+		r.onLock(assetID)
+	}
+	return r.GetByID(ctx, schemaName, tenantID, assetID)
+}
+
+func (r *transactionalAssetEventRepository) CreateAssetEvent(ctx context.Context, schemaName string, event *AssetEvent, asset *FixedAsset) error {
+	if r.createEventErr != nil {
+		return r.createEventErr
+	}
+	return r.assetEventMockRepository.CreateAssetEvent(ctx, schemaName, event, asset)
+}
+
+func (r *transactionalAssetEventRepository) WithLedgerTransaction(_ context.Context, fn func(txRepo Repository, ledger accountingPoster) error) error {
+	assets := make(map[string]FixedAsset, len(r.Assets))
+	for id, asset := range r.Assets {
+		assets[id] = *asset
+	}
+	events := make(map[string][]AssetEvent, len(r.events))
+	for id, assetEvents := range r.events {
+		events[id] = append([]AssetEvent(nil), assetEvents...)
+	}
+	requests, posted := len(r.ledger.requests), len(r.ledger.postedIDs)
+
+	if err := fn(r, r.ledger); err != nil {
+		for id, asset := range assets {
+			*r.Assets[id] = asset
+		}
+		r.events = events
+		r.ledger.requests = r.ledger.requests[:requests]
+		r.ledger.postedIDs = r.ledger.postedIDs[:posted]
+		return err
+	}
+	return nil
+}
+
+// newAssetEventTestService returns a service with a server bought on 1 January
+// 2026 for 3600 over 36 months, depreciated 100 a month for January to March.
+func newAssetEventTestService(t *testing.T) (*Service, *assetEventMockRepository, *depreciationRunLedger) {
+	t.Helper()
+	repo := newAssetEventMockRepository()
+	assetAccount := "fixed-assets"
+	expense := "depreciation-expense"
+	accumulated := "accumulated-depreciation"
+	repo.Assets["server"] = &FixedAsset{
+		ID:                            "server",
+		TenantID:                      "tenant-1",
+		AssetNumber:                   "FA-00001",
+		Name:                          "Server",
+		Status:                        AssetStatusActive,
+		PurchaseDate:                  time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		PurchaseCost:                  decimal.NewFromInt(3600),
+		DepreciationMethod:            DepreciationStraightLine,
+		UsefulLifeMonths:              36,
+		BookValue:                     decimal.NewFromInt(3600),
+		AssetAccountID:                &assetAccount,
+		DepreciationExpenseAccountID:  &expense,
+		AccumulatedDepreciationAcctID: &accumulated,
+	}
+	ledger := newDepreciationRunLedger()
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	for month := 1; month <= 3; month++ {
+		_, _, err := service.RunDepreciation(context.Background(), "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: month, UserID: "user-1"})
+		require.NoError(t, err)
+	}
+	require.Equal(t, "300", repo.Assets["server"].AccumulatedDepreciation.String())
+	return service, repo, ledger
+}
+
+func TestCreateAssetEventImprovementRevisesDepreciation(t *testing.T) {
+	service, repo, ledger := newAssetEventTestService(t)
+	cash := "cash"
+
+	event, err := service.CreateAssetEvent(context.Background(), "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{
+		EventType:       AssetEventImprovement,
+		EventDate:       time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC),
+		Amount:          decimal.NewFromInt(1800),
+		OffsetAccountID: &cash,
+		Description:     "Memory upgrade",
+		UserID:          "user-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "3600", event.CostBefore.String())
+	assert.Equal(t, "5400", event.CostAfter.String())
+	assert.Equal(t, "3300", event.BookValueBefore.String())
+	assert.Equal(t, "5100", event.BookValueAfter.String())
+	assert.Equal(t, "100", event.MonthlyDepreciationBefore.String())
+	assert.Equal(t, "154.55", event.MonthlyDepreciationAfter.String())
+	require.NotNil(t, event.JournalEntryID)
+
+	request := ledger.requests[len(ledger.requests)-1]
+	assert.Equal(t, SourceTypeAssetImprovement, request.SourceType)
+	assert.Equal(t, "Improvement FA-00001 - Server", request.Description)
+	require.Len(t, request.Lines, 2)
+	assert.Equal(t, "fixed-assets", request.Lines[0].AccountID)
+	assert.Equal(t, "1800", request.Lines[0].DebitAmount.String())
+	assert.Equal(t, "cash", request.Lines[1].AccountID)
+	assert.Equal(t, "1800", request.Lines[1].CreditAmount.String())
+
+	server := repo.Assets["server"]
+	assert.Equal(t, "5400", server.PurchaseCost.String())
+	assert.Equal(t, "5100", server.BookValue.String())
+	assert.Equal(t, "5100", server.RevisedDepreciableAmount.String())
+	assert.Equal(t, 33, server.RevisedLifeMonths)
+
+	run, _, err := service.RunDepreciation(context.Background(), "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 4, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "154.55", run.TotalAmount.String())
+
+	schedule, err := service.GetDepreciationSchedule(context.Background(), "tenant-1", "tenant_acme", "server", nil)
+	require.NoError(t, err)
+	require.Len(t, schedule.Lines, 32)
+	assert.Equal(t, "0", schedule.Lines[len(schedule.Lines)-1].BookValueAfter.String())
+}
+
+func TestCreateAssetEventImpairmentAndEstimateChange(t *testing.T) {
+	service, repo, ledger := newAssetEventTestService(t)
+	loss := "asset-disposal-loss"
+	ctx := context.Background()
+
+	impairment, err := service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{
+		EventType:       AssetEventImpairment,
+		EventDate:       time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Amount:          decimal.NewFromInt(1000),
+		OffsetAccountID: &loss,
+		UserID:          "user-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1300", impairment.AccumulatedAfter.String())
+	assert.Equal(t, "2300", impairment.BookValueAfter.String())
+	assert.Equal(t, "69.7", impairment.MonthlyDepreciationAfter.String())
+	request := ledger.requests[len(ledger.requests)-1]
+	assert.Equal(t, SourceTypeAssetImpairment, request.SourceType)
+	assert.Equal(t, "asset-disposal-loss", request.Lines[0].AccountID)
+	assert.Equal(t, "accumulated-depreciation", request.Lines[1].AccountID)
+
+	journalCount := len(ledger.requests)
+	lifeMonths := 48
+	residual := decimal.NewFromInt(200)
+	change, err := service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{
+		EventType:        AssetEventEstimateChange,
+		EventDate:        time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+		UsefulLifeMonths: &lifeMonths,
+		ResidualValue:    &residual,
+		UserID:           "user-1",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, change.JournalEntryID)
+	assert.Len(t, ledger.requests, journalCount)
+	assert.Equal(t, 36, change.UsefulLifeBefore)
+	assert.Equal(t, 48, change.UsefulLifeAfter)
+	assert.Equal(t, "46.67", change.MonthlyDepreciationAfter.String())
+
+	server := repo.Assets["server"]
+	assert.Equal(t, 48, server.UsefulLifeMonths)
+	assert.Equal(t, "200", server.ResidualValue.String())
+	assert.Equal(t, "2100", server.RevisedDepreciableAmount.String())
+	assert.Equal(t, 45, server.RevisedLifeMonths)
+
+	events, err := service.ListAssetEvents(ctx, "tenant-1", "tenant_acme", "server")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, AssetEventImpairment, events[0].EventType)
+}
+
+func TestCreateAssetEventValidation(t *testing.T) {
+	service, repo, _ := newAssetEventTestService(t)
+	cash := "cash"
+	expense := "depreciation-expense"
+	lifeMonths := 3
+	april := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		req  *CreateAssetEventRequest
+		want string
+	}{
+		{name: "invalid type", req: &CreateAssetEventRequest{EventType: "REVALUATION", EventDate: april}, want: `invalid event type "REVALUATION"`},
+		{name: "missing date", req: &CreateAssetEventRequest{EventType: AssetEventImprovement}, want: "event date is required"},
+		{name: "before last depreciation", req: &CreateAssetEventRequest{EventType: AssetEventImprovement, EventDate: time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(10), OffsetAccountID: &cash}, want: "asset is depreciated through 2026-03-31; date the event on or after it"},
+		{name: "improvement without amount", req: &CreateAssetEventRequest{EventType: AssetEventImprovement, EventDate: april}, want: "improvement amount must be positive"},
+		{name: "impairment below residual", req: &CreateAssetEventRequest{EventType: AssetEventImpairment, EventDate: april, Amount: decimal.NewFromInt(3400), OffsetAccountID: &expense}, want: "book value -100.00 after the event would be below the residual value 0.00"},
+		{name: "estimate change with amount", req: &CreateAssetEventRequest{EventType: AssetEventEstimateChange, EventDate: april, Amount: decimal.NewFromInt(1)}, want: "estimate changes do not take an amount"},
+		{name: "estimate change without estimates", req: &CreateAssetEventRequest{EventType: AssetEventEstimateChange, EventDate: april}, want: "useful life or residual value is required for an estimate change"},
+		{name: "life already used", req: &CreateAssetEventRequest{EventType: AssetEventEstimateChange, EventDate: april, UsefulLifeMonths: &lifeMonths}, want: "asset has no useful life left after 2026-04-01; extend the useful life"},
+		{name: "missing offset account", req: &CreateAssetEventRequest{EventType: AssetEventImpairment, EventDate: april, Amount: decimal.NewFromInt(10)}, want: "asset accounting configuration is invalid: offset account is required for impairment posting"},
+		{name: "wrong improvement offset", req: &CreateAssetEventRequest{EventType: AssetEventImprovement, EventDate: april, Amount: decimal.NewFromInt(10), OffsetAccountID: &expense}, want: "asset accounting configuration is invalid: improvement offset account must be ASSET or LIABILITY"},
+		{name: "wrong impairment offset", req: &CreateAssetEventRequest{EventType: AssetEventImpairment, EventDate: april, Amount: decimal.NewFromInt(10), OffsetAccountID: &cash}, want: "asset accounting configuration is invalid: impairment expense account must be EXPENSE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreateAssetEvent(context.Background(), "tenant-1", "tenant_acme", "server", tt.req)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+	assert.Empty(t, repo.events)
+
+	repo.Assets["server"].Status = AssetStatusDisposed
+	_, err := service.CreateAssetEvent(context.Background(), "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{EventType: AssetEventImprovement, EventDate: april, Amount: decimal.NewFromInt(10), OffsetAccountID: &cash})
+	assert.EqualError(t, err, "only active assets can have events")
+
+	_, err = NewServiceWithRepository(NewMockRepository()).CreateAssetEvent(context.Background(), "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{})
+	assert.ErrorIs(t, err, ErrAssetEventsUnavailable)
+}
+
+func TestGetAssetHistoryExplainsBookValue(t *testing.T) {
+	service, repo, _ := newAssetEventTestService(t)
+	cash := "cash"
+	loss := "asset-disposal-loss"
+	ctx := context.Background()
+
+	_, err := service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{EventType: AssetEventImprovement, EventDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(1800), OffsetAccountID: &cash, UserID: "user-1"})
+	require.NoError(t, err)
+	_, _, err = service.RunDepreciation(ctx, "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 4, UserID: "user-1"})
+	require.NoError(t, err)
+	_, err = service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{EventType: AssetEventImpairment, EventDate: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), Amount: decimal.NewFromInt(500), OffsetAccountID: &loss, UserID: "user-1"})
+	require.NoError(t, err)
+
+	history, err := service.GetAssetHistory(ctx, "tenant-1", "tenant_acme", "server")
+	require.NoError(t, err)
+	types := make([]string, len(history.Lines))
+	for i, line := range history.Lines {
+		types[i] = line.Type
+	}
+	assert.Equal(t, []string{"ACQUISITION", "DEPRECIATION", "DEPRECIATION", "DEPRECIATION", "IMPROVEMENT", "DEPRECIATION", "IMPAIRMENT"}, types)
+	assert.Equal(t, "3600", history.Lines[0].BookValueAfter.String())
+	assert.Equal(t, "1800", history.Lines[4].BookValueChange.String())
+	assert.Equal(t, "-154.55", history.Lines[5].BookValueChange.String())
+	last := history.Lines[len(history.Lines)-1]
+	assert.Equal(t, "-500", last.BookValueChange.String())
+	assert.True(t, last.BookValueAfter.Equal(repo.Assets["server"].BookValue), last.BookValueAfter.String())
+
+	report, err := service.GetAssetRegister(ctx, "tenant-1", "tenant_acme", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "3600", report.Totals.OpeningCost.String())
+	assert.Equal(t, "1800", report.Totals.Additions.String())
+	assert.Equal(t, "300", report.Totals.OpeningAccumulatedDepreciation.String())
+	assert.Equal(t, "154.55", report.Totals.DepreciationCharge.String())
+	assert.Equal(t, "500", report.Totals.Impairments.String())
+	assert.True(t, report.Totals.ClosingNetBookValue.Equal(repo.Assets["server"].BookValue))
+
+	march, err := service.GetAssetRegister(ctx, "tenant-1", "tenant_acme", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, "3600", march.Totals.ClosingCost.String())
+	assert.Equal(t, "300", march.Totals.ClosingAccumulatedDepreciation.String())
+}
+
+func TestReverseDepreciationRunBlockedByLaterAssetEvent(t *testing.T) {
+	service, repo, _ := newAssetEventTestService(t)
+	lifeMonths := 48
+	ctx := context.Background()
+
+	runs, err := service.ListDepreciationRuns(ctx, "tenant-1", "tenant_acme", 2026)
+	require.NoError(t, err)
+	var march *DepreciationRun
+	for i := range runs {
+		if runs[i].PeriodStart.Month() == time.March {
+			march = &runs[i]
+		}
+	}
+	require.NotNil(t, march)
+
+	_, err = service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", &CreateAssetEventRequest{EventType: AssetEventEstimateChange, EventDate: time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), UsefulLifeMonths: &lifeMonths, UserID: "user-1"})
+	require.NoError(t, err)
+
+	_, err = service.ReverseDepreciationRun(ctx, "tenant-1", "tenant_acme", march.ID, &ReverseDepreciationRunRequest{Reason: "Wrong", UserID: "user-1"})
+	assert.EqualError(t, err, "asset FA-00001 has a ESTIMATE_CHANGE event on 2026-04-01; its depreciation for the run cannot be reversed")
+	assert.Equal(t, "300", repo.Assets["server"].AccumulatedDepreciation.String())
+}
+
+func TestCreateAssetEventRollsBackJournalWhenEventCannotBeStored(t *testing.T) {
+	_, base, ledger := newAssetEventTestService(t)
+	repo := &transactionalAssetEventRepository{
+		assetEventMockRepository: base,
+		ledger:                   ledger,
+		createEventErr:           errors.New("insert asset event: connection reset"),
+	}
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	ctx := context.Background()
+	cash := "cash"
+	req := &CreateAssetEventRequest{
+		EventType:       AssetEventImprovement,
+		EventDate:       time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC),
+		Amount:          decimal.NewFromInt(1800),
+		OffsetAccountID: &cash,
+		UserID:          "user-1",
+	}
+	requests := len(ledger.requests)
+
+	event, err := service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", req)
+	require.ErrorContains(t, err, "create asset event")
+	assert.Nil(t, event)
+	assert.Equal(t, []string{"server"}, repo.locked)
+	assert.Len(t, ledger.requests, requests)
+	assert.Empty(t, base.events["server"])
+	assert.Equal(t, "3600", base.Assets["server"].PurchaseCost.String())
+
+	repo.createEventErr = nil
+	event, err = service.CreateAssetEvent(ctx, "tenant-1", "tenant_acme", "server", req)
+	require.NoError(t, err)
+	require.NotNil(t, event.JournalEntryID)
+	assert.Len(t, ledger.requests, requests+1)
+	assert.Equal(t, "5400", base.Assets["server"].PurchaseCost.String())
+}
+
+func TestRunDepreciationRejectsAssetChangedSincePreview(t *testing.T) {
+	_, base, ledger := newAssetEventTestService(t)
+	repo := &transactionalAssetEventRepository{assetEventMockRepository: base, ledger: ledger}
+	repo.onLock = func(assetID string) {
+		// An impairment committed between the preview and the run's lock
+		base.Assets[assetID].AccumulatedDepreciation = decimal.NewFromInt(800)
+	}
+	service := NewServiceWithRepositoryAndAccounting(repo, ledger)
+	requests := len(ledger.requests)
+
+	run, created, err := service.RunDepreciation(context.Background(), "tenant-1", "tenant_acme", &CreateDepreciationRunRequest{Year: 2026, Month: 4, UserID: "user-1"})
+	require.ErrorContains(t, err, "asset FA-00001 changed while the depreciation run was prepared; run it again")
+	assert.Nil(t, run)
+	assert.False(t, created)
+	assert.Equal(t, []string{"server"}, repo.locked)
+	assert.Len(t, ledger.requests, requests)
+	assert.Equal(t, "300", base.Assets["server"].AccumulatedDepreciation.String())
+}
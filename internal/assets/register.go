@@ -0,0 +1,394 @@
+package assets
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// maxScheduleMonths bounds a projected depreciation schedule.
+const maxScheduleMonths = 1200
+
+const uncategorizedAssetCategoryName = "Uncategorized"
+
+// DepreciationScheduleRequest supplies planned usage for units-of-production
+// forecasts. Without planned units the schedule uses the monthly amount that
+// depreciation posting would record.
+type DepreciationScheduleRequest struct {
+	UnitsTotal    decimal.Decimal `json:"units_total"`
+	UnitsPerMonth decimal.Decimal `json:"units_per_month"`
+}
+
+// DepreciationScheduleLine is one projected month of depreciation.
+type DepreciationScheduleLine struct {
+	PeriodStart        time.Time        `json:"period_start"`
+	PeriodEnd          time.Time        `json:"period_end"`
+	Units              *decimal.Decimal `json:"units,omitempty"`
+	DepreciationAmount decimal.Decimal  `json:"depreciation_amount"`
+	AccumulatedTotal   decimal.Decimal  `json:"accumulated_total"`
+	BookValueAfter     decimal.Decimal  `json:"book_value_after"`
+}
+
+// DepreciationSchedule projects an asset's remaining depreciation through the
+// end of its useful life.
+type DepreciationSchedule struct {
+	AssetID                 string                     `json:"asset_id"`
+	AssetNumber             string                     `json:"asset_number"`
+	AssetName               string                     `json:"asset_name"`
+	Status                  AssetStatus                `json:"status"`
+	DepreciationMethod      DepreciationMethod         `json:"depreciation_method"`
+	UsesPlannedUnits        bool                       `json:"uses_planned_units"`
+	PurchaseCost            decimal.Decimal            `json:"purchase_cost"`
+	ResidualValue           decimal.Decimal            `json:"residual_value"`
+	UsefulLifeMonths        int                        `json:"useful_life_months"`
+	EndOfLifeDate           time.Time                  `json:"end_of_life_date"`
+	AccumulatedDepreciation decimal.Decimal            `json:"accumulated_depreciation"`
+	BookValue               decimal.Decimal            `json:"book_value"`
+	RemainingDepreciation   decimal.Decimal            `json:"remaining_depreciation"`
+	Lines                   []DepreciationScheduleLine `json:"lines"`
+}
+
+// AssetRegisterAmounts is the cost and depreciation roll-forward of a period.
+type AssetRegisterAmounts struct {
+	OpeningCost                    decimal.Decimal `json:"opening_cost"`
+	Additions                      decimal.Decimal `json:"additions"`
+	Disposals                      decimal.Decimal `json:"disposals"`
+	ClosingCost                    decimal.Decimal `json:"closing_cost"`
+	OpeningAccumulatedDepreciation decimal.Decimal `json:"opening_accumulated_depreciation"`
+	DepreciationCharge             decimal.Decimal `json:"depreciation_charge"`
+	Impairments                    decimal.Decimal `json:"impairments"`
+	DisposalDepreciation           decimal.Decimal `json:"disposal_depreciation"`
+	ClosingAccumulatedDepreciation decimal.Decimal `json:"closing_accumulated_depreciation"`
+	OpeningNetBookValue            decimal.Decimal `json:"opening_net_book_value"`
+	ClosingNetBookValue            decimal.Decimal `json:"closing_net_book_value"`
+}
+
+// AssetRegisterCategory is the register roll-forward of one asset category.
+type AssetRegisterCategory struct {
+	CategoryID   *string `json:"category_id,omitempty"`
+	CategoryName string  `json:"category_name"`
+	AssetCount   int     `json:"asset_count"`
+	AssetRegisterAmounts
+}
+
+// AssetRegisterAsset is the register roll-forward of one asset.
+type AssetRegisterAsset struct {
+	AssetID      string      `json:"asset_id"`
+	AssetNumber  string      `json:"asset_number"`
+	AssetName    string      `json:"asset_name"`
+	CategoryID   *string     `json:"category_id,omitempty"`
+	CategoryName string      `json:"category_name"`
+	Status       AssetStatus `json:"status"`
+	AssetRegisterAmounts
+}
+
+// AssetRegisterReport rolls fixed asset cost and accumulated depreciation
+// forward from the start to the end of a period.
+type AssetRegisterReport struct {
+	StartDate  time.Time               `json:"start_date"`
+	EndDate    time.Time               `json:"end_date"`
+	Categories []AssetRegisterCategory `json:"categories"`
+	Assets     []AssetRegisterAsset    `json:"assets"`
+	Totals     AssetRegisterAmounts    `json:"totals"`
+}
+
+// GetDepreciationSchedule projects an asset's monthly depreciation from the
+// month after its last depreciation through the end of its useful life.
+// Disposed and sold assets have no remaining schedule.
+func (s *Service) GetDepreciationSchedule(ctx context.Context, tenantID, schemaName, assetID string, req *DepreciationScheduleRequest) (*DepreciationSchedule, error) {
+	if req == nil {
+		req = &DepreciationScheduleRequest{}
+	}
+	usesUnits := !req.UnitsTotal.IsZero() || !req.UnitsPerMonth.IsZero()
+	if usesUnits && (!req.UnitsTotal.IsPositive() || !req.UnitsPerMonth.IsPositive()) {
+		return nil, fmt.Errorf("units_total and units_per_month must both be positive")
+	}
+
+	asset, err := s.repo.GetByID(ctx, schemaName, tenantID, assetID)
+	if err != nil {
+		return nil, fmt.Errorf("get asset: %w", err)
+	}
+	if usesUnits && asset.DepreciationMethod != DepreciationUnitsOfProd {
+		return nil, fmt.Errorf("planned units apply only to %s assets", DepreciationUnitsOfProd)
+	}
+
+	firstMonth := depreciationStartMonth(asset)
+	schedule := &DepreciationSchedule{
+		AssetID:                 asset.ID,
+		AssetNumber:             asset.AssetNumber,
+		AssetName:               asset.Name,
+		Status:                  asset.Status,
+		DepreciationMethod:      asset.DepreciationMethod,
+		UsesPlannedUnits:        usesUnits,
+		PurchaseCost:            asset.PurchaseCost,
+		ResidualValue:           asset.ResidualValue,
+		UsefulLifeMonths:        asset.UsefulLifeMonths,
+		EndOfLifeDate:           firstMonth.AddDate(0, asset.UsefulLifeMonths, -1),
+		AccumulatedDepreciation: asset.AccumulatedDepreciation,
+		BookValue:               asset.BookValue,
+		RemainingDepreciation:   decimal.Zero,
+		Lines:                   []DepreciationScheduleLine{},
+	}
+	if asset.Status == AssetStatusDisposed || asset.Status == AssetStatusSold {
+		return schedule, nil
+	}
+
+	next := firstMonth
+	if asset.LastDepreciationDate != nil {
+		if afterLast := monthStart(*asset.LastDepreciationDate).AddDate(0, 1, 0); afterLast.After(next) {
+			next = afterLast
+		}
+	}
+
+	projected := *asset
+	depreciable := asset.PurchaseCost.Sub(asset.ResidualValue)
+	for i := 0; i < maxScheduleMonths; i++ {
+		remaining := depreciable.Sub(projected.AccumulatedDepreciation)
+		if !remaining.IsPositive() {
+			break
+		}
+		line := DepreciationScheduleLine{PeriodStart: next, PeriodEnd: next.AddDate(0, 1, -1)}
+		var amount decimal.Decimal
+		switch {
+		case usesUnits:
+			units := req.UnitsPerMonth
+			line.Units = &units
+			amount = depreciable.Mul(req.UnitsPerMonth).Div(req.UnitsTotal).Round(2)
+		case !line.PeriodEnd.Before(schedule.EndOfLifeDate):
+			// The last month of useful life takes whatever is left above the residual value.
+			amount = remaining
+		default:
+			amount = projected.CalculateMonthlyDepreciation()
+		}
+		if amount.GreaterThan(remaining) {
+			amount = remaining
+		}
+		if !amount.IsPositive() {
+			break
+		}
+
+		projected.AccumulatedDepreciation = projected.AccumulatedDepreciation.Add(amount)
+		projected.BookValue = projected.PurchaseCost.Sub(projected.AccumulatedDepreciation)
+		line.DepreciationAmount = amount
+		line.AccumulatedTotal = projected.AccumulatedDepreciation
+		line.BookValueAfter = projected.BookValue
+		schedule.Lines = append(schedule.Lines, line)
+		schedule.RemainingDepreciation = schedule.RemainingDepreciation.Add(amount)
+		next = next.AddDate(0, 1, 0)
+	}
+	return schedule, nil
+}
+
+// GetAssetRegister builds the fixed asset register roll-forward for a period:
+// opening cost, additions, disposals, depreciation charge, impairments and net
+// book value per asset and per category. Capitalised improvements are additions.
+// Draft assets are not in service and are left out.
+func (s *Service) GetAssetRegister(ctx context.Context, tenantID, schemaName string, startDate, endDate time.Time) (*AssetRegisterReport, error) {
+	startDate = dateOnly(startDate)
+	endDate = dateOnly(endDate)
+	if startDate.IsZero() || endDate.IsZero() {
+		return nil, fmt.Errorf("start date and end date are required")
+	}
+	if endDate.Before(startDate) {
+		return nil, fmt.Errorf("end date must be on or after start date")
+	}
+
+	allAssets, err := s.repo.List(ctx, schemaName, tenantID, &AssetFilter{})
+	if err != nil {
+		return nil, fmt.Errorf("list assets: %w", err)
+	}
+	categories, err := s.repo.ListCategories(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+	sort.Slice(allAssets, func(i, j int) bool {
+		return allAssets[i].AssetNumber < allAssets[j].AssetNumber
+	})
+
+	report := &AssetRegisterReport{
+		StartDate:  startDate,
+		EndDate:    endDate,
+		Categories: []AssetRegisterCategory{},
+		Assets:     []AssetRegisterAsset{},
+		Totals:     zeroAssetRegisterAmounts(),
+	}
+	byCategory := make(map[string]*AssetRegisterCategory)
+	openingDate := startDate.AddDate(0, 0, -1)
+	for i := range allAssets {
+		asset := &allAssets[i]
+		if asset.Status == AssetStatusDraft {
+			continue
+		}
+		purchaseDate := dateOnly(asset.PurchaseDate)
+		var disposalDate *time.Time
+		if asset.DisposalDate != nil {
+			disposed := dateOnly(*asset.DisposalDate)
+			disposalDate = &disposed
+		}
+		ownedAtOpening := purchaseDate.Before(startDate) && (disposalDate == nil || !disposalDate.Before(startDate))
+		addedInPeriod := !purchaseDate.Before(startDate) && !purchaseDate.After(endDate)
+		if !ownedAtOpening && !addedInPeriod {
+			continue
+		}
+		disposedInPeriod := disposalDate != nil && !disposalDate.After(endDate)
+
+		entries, err := s.repo.ListDepreciationEntries(ctx, schemaName, tenantID, asset.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list depreciation entries for asset %s: %w", asset.AssetNumber, err)
+		}
+		var events []AssetEvent
+		if s.events != nil {
+			if events, err = s.events.ListAssetEvents(ctx, schemaName, tenantID, asset.ID); err != nil {
+				return nil, fmt.Errorf("list asset events for asset %s: %w", asset.AssetNumber, err)
+			}
+		}
+
+		amounts := zeroAssetRegisterAmounts()
+		closingCost := assetCostAt(asset, events, endDate)
+		closingAccumulated := accumulatedDepreciationAt(asset, entries, events, endDate)
+		if ownedAtOpening {
+			amounts.OpeningCost = assetCostAt(asset, events, openingDate)
+			amounts.OpeningAccumulatedDepreciation = accumulatedDepreciationAt(asset, entries, events, openingDate)
+		}
+		amounts.Additions = closingCost.Sub(amounts.OpeningCost)
+		for _, event := range events {
+			eventDate := dateOnly(event.EventDate)
+			if event.EventType == AssetEventImpairment && eventDate.After(openingDate) && !eventDate.After(endDate) {
+				amounts.Impairments = amounts.Impairments.Add(event.Amount)
+			}
+		}
+		amounts.DepreciationCharge = closingAccumulated.Sub(amounts.OpeningAccumulatedDepreciation).Sub(amounts.Impairments)
+		if disposedInPeriod {
+			amounts.Disposals = closingCost
+			amounts.DisposalDepreciation = closingAccumulated
+		}
+		amounts.ClosingCost = amounts.OpeningCost.Add(amounts.Additions).Sub(amounts.Disposals)
+		amounts.ClosingAccumulatedDepreciation = amounts.OpeningAccumulatedDepreciation.Add(amounts.DepreciationCharge).Add(amounts.Impairments).Sub(amounts.DisposalDepreciation)
+		amounts.OpeningNetBookValue = amounts.OpeningCost.Sub(amounts.OpeningAccumulatedDepreciation)
+		amounts.ClosingNetBookValue = amounts.ClosingCost.Sub(amounts.ClosingAccumulatedDepreciation)
+
+		categoryKey := trimmedStringPtr(asset.CategoryID)
+		categoryName := uncategorizedAssetCategoryName
+		if name, ok := categoryNames[categoryKey]; ok {
+			categoryName = name
+		}
+		report.Assets = append(report.Assets, AssetRegisterAsset{
+			AssetID:              asset.ID,
+			AssetNumber:          asset.AssetNumber,
+			AssetName:            asset.Name,
+			CategoryID:           asset.CategoryID,
+			CategoryName:         categoryName,
+			Status:               asset.Status,
+			AssetRegisterAmounts: amounts,
+		})
+
+		category, ok := byCategory[categoryKey]
+		if !ok {
+			category = &AssetRegisterCategory{
+				CategoryID:           nonEmptyStringPtr(categoryKey),
+				CategoryName:         categoryName,
+				AssetRegisterAmounts: zeroAssetRegisterAmounts(),
+			}
+			byCategory[categoryKey] = category
+		}
+		category.AssetCount++
+		category.AssetRegisterAmounts = category.AssetRegisterAmounts.add(amounts)
+		report.Totals = report.Totals.add(amounts)
+	}
+
+	for _, category := range byCategory {
+		report.Categories = append(report.Categories, *category)
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		return report.Categories[i].CategoryName < report.Categories[j].CategoryName
+	})
+	return report, nil
+}
+
+func (a AssetRegisterAmounts) add(other AssetRegisterAmounts) AssetRegisterAmounts {
+	return AssetRegisterAmounts{
+		OpeningCost:                    a.OpeningCost.Add(other.OpeningCost),
+		Additions:                      a.Additions.Add(other.Additions),
+		Disposals:                      a.Disposals.Add(other.Disposals),
+		ClosingCost:                    a.ClosingCost.Add(other.ClosingCost),
+		OpeningAccumulatedDepreciation: a.OpeningAccumulatedDepreciation.Add(other.OpeningAccumulatedDepreciation),
+		DepreciationCharge:             a.DepreciationCharge.Add(other.DepreciationCharge),
+		Impairments:                    a.Impairments.Add(other.Impairments),
+		DisposalDepreciation:           a.DisposalDepreciation.Add(other.DisposalDepreciation),
+		ClosingAccumulatedDepreciation: a.ClosingAccumulatedDepreciation.Add(other.ClosingAccumulatedDepreciation),
+		OpeningNetBookValue:            a.OpeningNetBookValue.Add(other.OpeningNetBookValue),
+		ClosingNetBookValue:            a.ClosingNetBookValue.Add(other.ClosingNetBookValue),
+	}
+}
+
+func zeroAssetRegisterAmounts() AssetRegisterAmounts {
+	return AssetRegisterAmounts{
+		OpeningCost:                    decimal.Zero,
+		Additions:                      decimal.Zero,
+		Disposals:                      decimal.Zero,
+		ClosingCost:                    decimal.Zero,
+		OpeningAccumulatedDepreciation: decimal.Zero,
+		DepreciationCharge:             decimal.Zero,
+		Impairments:                    decimal.Zero,
+		DisposalDepreciation:           decimal.Zero,
+		ClosingAccumulatedDepreciation: decimal.Zero,
+		OpeningNetBookValue:            decimal.Zero,
+		ClosingNetBookValue:            decimal.Zero,
+	}
+}
+
+// accumulatedDepreciationAt rewinds the asset's current accumulated
+// depreciation by the entries for periods ending after the date and the
+// impairments dated after it, so imported opening depreciation without
+// entries is kept.
+func accumulatedDepreciationAt(asset *FixedAsset, entries []DepreciationEntry, events []AssetEvent, date time.Time) decimal.Decimal {
+	accumulated := asset.AccumulatedDepreciation
+	for _, entry := range entries {
+		if dateOnly(entry.PeriodEnd).After(date) {
+			accumulated = accumulated.Sub(entry.DepreciationAmount)
+		}
+	}
+	for _, event := range events {
+		if dateOnly(event.EventDate).After(date) {
+			accumulated = accumulated.Sub(event.AccumulatedAfter.Sub(event.AccumulatedBefore))
+		}
+	}
+	return accumulated
+}
+
+// assetCostAt rewinds the asset's current cost by the improvements dated after
+// the date.
+func assetCostAt(asset *FixedAsset, events []AssetEvent, date time.Time) decimal.Decimal {
+	cost := asset.PurchaseCost
+	for _, event := range events {
+		if dateOnly(event.EventDate).After(date) {
+			cost = cost.Sub(event.CostAfter.Sub(event.CostBefore))
+		}
+	}
+	return cost
+}
+
+func depreciationStartMonth(asset *FixedAsset) time.Time {
+	if asset.DepreciationStartDate != nil {
+		return monthStart(*asset.DepreciationStartDate)
+	}
+	return monthStart(asset.PurchaseDate)
+}
+
+func monthStart(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func dateOnly(value time.Time) time.Time {
+	if value.IsZero() {
+		return value
+	}
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
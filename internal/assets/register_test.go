@@ -0,0 +1,188 @@
+package assets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerTestDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestGetDepreciationScheduleStraightLine(t *testing.T) {
+	repo := NewMockRepository()
+	lastDepreciation := registerTestDate(2026, 3, 31)
+	repo.Assets["laptop"] = &FixedAsset{
+		ID:                      "laptop",
+		TenantID:                "tenant-1",
+		AssetNumber:             "FA-00001",
+		Name:                    "Laptop",
+		Status:                  AssetStatusActive,
+		PurchaseDate:            registerTestDate(2026, 1, 10),
+		PurchaseCost:            decimal.NewFromInt(1000),
+		UsefulLifeMonths:        6,
+		DepreciationMethod:      DepreciationStraightLine,
+		AccumulatedDepreciation: decimal.RequireFromString("499.98"),
+		BookValue:               decimal.RequireFromString("500.02"),
+		LastDepreciationDate:    &lastDepreciation,
+	}
+	service := NewServiceWithRepository(repo)
+
+	schedule, err := service.GetDepreciationSchedule(context.Background(), "tenant-1", "tenant_test", "laptop", nil)
+	require.NoError(t, err)
+	assert.Equal(t, registerTestDate(2026, 6, 30), schedule.EndOfLifeDate)
+	require.Len(t, schedule.Lines, 3)
+	assert.Equal(t, registerTestDate(2026, 4, 1), schedule.Lines[0].PeriodStart)
+	assert.True(t, schedule.Lines[0].DepreciationAmount.Equal(decimal.RequireFromString("166.67")))
+	// The final month of useful life absorbs the rounding difference.
+	assert.True(t, schedule.Lines[2].DepreciationAmount.Equal(decimal.RequireFromString("166.68")))
+	assert.True(t, schedule.Lines[2].BookValueAfter.IsZero())
+	assert.True(t, schedule.RemainingDepreciation.Equal(decimal.RequireFromString("500.02")))
+}
+
+func TestGetDepreciationScheduleDecliningBalanceStopsAtResidual(t *testing.T) {
+	repo := NewMockRepository()
+	repo.Assets["van"] = &FixedAsset{
+		ID:                 "van",
+		TenantID:           "tenant-1",
+		AssetNumber:        "FA-00002",
+		Status:             AssetStatusActive,
+		PurchaseDate:       registerTestDate(2026, 1, 1),
+		PurchaseCost:       decimal.NewFromInt(24000),
+		ResidualValue:      decimal.NewFromInt(4000),
+		UsefulLifeMonths:   24,
+		DepreciationMethod: DepreciationDecliningBalance,
+		BookValue:          decimal.NewFromInt(24000),
+	}
+	service := NewServiceWithRepository(repo)
+
+	schedule, err := service.GetDepreciationSchedule(context.Background(), "tenant-1", "tenant_test", "van", nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, schedule.Lines)
+	assert.True(t, schedule.Lines[0].DepreciationAmount.Equal(decimal.NewFromInt(2000)))
+	assert.True(t, schedule.Lines[1].DepreciationAmount.Equal(decimal.RequireFromString("1833.33")))
+	last := schedule.Lines[len(schedule.Lines)-1]
+	assert.False(t, last.PeriodEnd.After(registerTestDate(2027, 12, 31)))
+	assert.True(t, last.BookValueAfter.Equal(decimal.NewFromInt(4000)))
+	assert.True(t, schedule.RemainingDepreciation.Equal(decimal.NewFromInt(20000)))
+}
+
+func TestGetDepreciationScheduleUnitsOfProduction(t *testing.T) {
+	repo := NewMockRepository()
+	repo.Assets["press"] = &FixedAsset{
+		ID:                 "press",
+		TenantID:           "tenant-1",
+		AssetNumber:        "FA-00003",
+		Status:             AssetStatusActive,
+		PurchaseDate:       registerTestDate(2026, 1, 1),
+		PurchaseCost:       decimal.NewFromInt(11000),
+		ResidualValue:      decimal.NewFromInt(1000),
+		UsefulLifeMonths:   60,
+		DepreciationMethod: DepreciationUnitsOfProd,
+		BookValue:          decimal.NewFromInt(11000),
+	}
+	repo.Assets["disposed"] = &FixedAsset{ID: "disposed", TenantID: "tenant-1", Status: AssetStatusSold, DepreciationMethod: DepreciationStraightLine}
+	service := NewServiceWithRepository(repo)
+	ctx := context.Background()
+
+	schedule, err := service.GetDepreciationSchedule(ctx, "tenant-1", "tenant_test", "press", &DepreciationScheduleRequest{
+		UnitsTotal:    decimal.NewFromInt(100000),
+		UnitsPerMonth: decimal.NewFromInt(4000),
+	})
+	require.NoError(t, err)
+	assert.True(t, schedule.UsesPlannedUnits)
+	require.Len(t, schedule.Lines, 25)
+	require.NotNil(t, schedule.Lines[0].Units)
+	assert.True(t, schedule.Lines[0].DepreciationAmount.Equal(decimal.NewFromInt(400)))
+	assert.True(t, schedule.Lines[24].BookValueAfter.Equal(decimal.NewFromInt(1000)))
+
+	schedule, err = service.GetDepreciationSchedule(ctx, "tenant-1", "tenant_test", "press", nil)
+	require.NoError(t, err)
+	assert.False(t, schedule.UsesPlannedUnits)
+	assert.Len(t, schedule.Lines, 60)
+
+	_, err = service.GetDepreciationSchedule(ctx, "tenant-1", "tenant_test", "press", &DepreciationScheduleRequest{UnitsTotal: decimal.NewFromInt(100)})
+	assert.EqualError(t, err, "units_total and units_per_month must both be positive")
+
+	schedule, err = service.GetDepreciationSchedule(ctx, "tenant-1", "tenant_test", "disposed", nil)
+	require.NoError(t, err)
+	assert.Empty(t, schedule.Lines)
+
+	_, err = service.GetDepreciationSchedule(ctx, "tenant-1", "tenant_test", "disposed", &DepreciationScheduleRequest{UnitsTotal: decimal.NewFromInt(10), UnitsPerMonth: decimal.NewFromInt(1)})
+	assert.EqualError(t, err, "planned units apply only to UNITS_OF_PRODUCTION assets")
+}
+
+func TestGetAssetRegisterRollForward(t *testing.T) {
+	repo := NewMockRepository()
+	itCategory := "cat-it"
+	repo.Categories[itCategory] = &AssetCategory{ID: itCategory, TenantID: "tenant-1", Name: "IT equipment"}
+	disposalDate := registerTestDate(2026, 9, 15)
+	repo.Assets["server"] = &FixedAsset{
+		ID: "server", TenantID: "tenant-1", AssetNumber: "FA-00001", CategoryID: &itCategory,
+		Status: AssetStatusActive, PurchaseDate: registerTestDate(2024, 1, 1),
+		PurchaseCost: decimal.NewFromInt(1200), AccumulatedDepreciation: decimal.NewFromInt(700),
+	}
+	repo.Assets["laptop"] = &FixedAsset{
+		ID: "laptop", TenantID: "tenant-1", AssetNumber: "FA-00002", CategoryID: &itCategory,
+		Status: AssetStatusActive, PurchaseDate: registerTestDate(2026, 4, 5),
+		PurchaseCost: decimal.NewFromInt(900), AccumulatedDepreciation: decimal.NewFromInt(50),
+	}
+	repo.Assets["car"] = &FixedAsset{
+		ID: "car", TenantID: "tenant-1", AssetNumber: "FA-00003",
+		Status: AssetStatusSold, PurchaseDate: registerTestDate(2023, 6, 1), DisposalDate: &disposalDate,
+		PurchaseCost: decimal.NewFromInt(20000), AccumulatedDepreciation: decimal.NewFromInt(9000),
+	}
+	repo.Assets["draft"] = &FixedAsset{ID: "draft", TenantID: "tenant-1", AssetNumber: "FA-00004", Status: AssetStatusDraft, PurchaseDate: registerTestDate(2026, 2, 1), PurchaseCost: decimal.NewFromInt(500)}
+	repo.Assets["future"] = &FixedAsset{ID: "future", TenantID: "tenant-1", AssetNumber: "FA-00005", Status: AssetStatusActive, PurchaseDate: registerTestDate(2027, 2, 1), PurchaseCost: decimal.NewFromInt(500)}
+
+	entry := func(assetID string, year int, month time.Month, amount int64) DepreciationEntry {
+		start := registerTestDate(year, month, 1)
+		return DepreciationEntry{TenantID: "tenant-1", AssetID: assetID, PeriodStart: start, PeriodEnd: start.AddDate(0, 1, -1), DepreciationAmount: decimal.NewFromInt(amount)}
+	}
+	// Server: 400 imported before any entries, 100 in 2025, 200 in 2026.
+	repo.DepreciationEntries["server"] = []DepreciationEntry{entry("server", 2025, 12, 100), entry("server", 2026, 3, 100), entry("server", 2026, 6, 100)}
+	repo.DepreciationEntries["laptop"] = []DepreciationEntry{entry("laptop", 2026, 5, 25), entry("laptop", 2026, 6, 25)}
+	repo.DepreciationEntries["car"] = []DepreciationEntry{entry("car", 2026, 8, 1000)}
+	service := NewServiceWithRepository(repo)
+
+	report, err := service.GetAssetRegister(context.Background(), "tenant-1", "tenant_test", registerTestDate(2026, 1, 1), registerTestDate(2026, 12, 31))
+	require.NoError(t, err)
+	require.Len(t, report.Assets, 3)
+	require.Len(t, report.Categories, 2)
+
+	server := report.Assets[0]
+	assert.Equal(t, "IT equipment", server.CategoryName)
+	assert.True(t, server.OpeningCost.Equal(decimal.NewFromInt(1200)))
+	assert.True(t, server.OpeningAccumulatedDepreciation.Equal(decimal.NewFromInt(500)))
+	assert.True(t, server.DepreciationCharge.Equal(decimal.NewFromInt(200)))
+	assert.True(t, server.ClosingNetBookValue.Equal(decimal.NewFromInt(500)))
+
+	laptop := report.Assets[1]
+	assert.True(t, laptop.OpeningCost.IsZero())
+	assert.True(t, laptop.Additions.Equal(decimal.NewFromInt(900)))
+	assert.True(t, laptop.DepreciationCharge.Equal(decimal.NewFromInt(50)))
+
+	car := report.Assets[2]
+	assert.Equal(t, uncategorizedAssetCategoryName, car.CategoryName)
+	assert.True(t, car.OpeningAccumulatedDepreciation.Equal(decimal.NewFromInt(8000)))
+	assert.True(t, car.Disposals.Equal(decimal.NewFromInt(20000)))
+	assert.True(t, car.DisposalDepreciation.Equal(decimal.NewFromInt(9000)))
+	assert.True(t, car.ClosingCost.IsZero())
+	assert.True(t, car.ClosingAccumulatedDepreciation.IsZero())
+
+	it := report.Categories[0]
+	assert.Equal(t, "IT equipment", it.CategoryName)
+	assert.Equal(t, 2, it.AssetCount)
+	assert.True(t, it.ClosingCost.Equal(decimal.NewFromInt(2100)))
+	assert.True(t, report.Totals.OpeningCost.Equal(decimal.NewFromInt(21200)))
+	assert.True(t, report.Totals.DepreciationCharge.Equal(decimal.NewFromInt(1250)))
+	assert.True(t, report.Totals.ClosingNetBookValue.Equal(decimal.NewFromInt(1350)))
+
+	_, err = service.GetAssetRegister(context.Background(), "tenant-1", "tenant_test", registerTestDate(2026, 12, 31), registerTestDate(2026, 1, 1))
+	assert.EqualError(t, err, "end date must be on or after start date")
+}
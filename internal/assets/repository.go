@@ -2,15 +2,19 @@ package assets
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/database"
 	"github.com/HMB-research/open-accounting/internal/models"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // Repository defines the contract for asset data access
@@ -38,6 +42,40 @@ type Repository interface {
 	UpdateAssetDepreciation(ctx context.Context, schemaName string, asset *FixedAsset) error
 }
 
+// DepreciationRunRepository stores batch depreciation runs. Repositories that do
+// not implement it disable depreciation runs.
+type DepreciationRunRepository interface {
+	CreateDepreciationRun(ctx context.Context, schemaName string, run *DepreciationRun) error
+	GetDepreciationRun(ctx context.Context, schemaName, tenantID, runID string) (*DepreciationRun, error)
+	GetPostedDepreciationRun(ctx context.Context, schemaName, tenantID string, periodStart, periodEnd time.Time) (*DepreciationRun, error)
+	ListDepreciationRuns(ctx context.Context, schemaName, tenantID string, year int) ([]DepreciationRun, error)
+	MarkDepreciationRunReversed(ctx context.Context, schemaName, tenantID, runID, userID, reason string, reversedAt time.Time) error
+	ListPeriodDepreciationEntries(ctx context.Context, schemaName, tenantID string, periodStart, periodEnd time.Time) ([]DepreciationEntry, error)
+	ListDepreciationRunEntries(ctx context.Context, schemaName, tenantID, runID string) ([]DepreciationEntry, error)
+	DeleteDepreciationRunEntries(ctx context.Context, schemaName, tenantID, runID string) error
+}
+
+// LedgerTransactionRepository runs asset writes and their journal postings in
+// one database transaction. Repositories that do not implement it write them
+// one at a time.
+type LedgerTransactionRepository interface {
+	WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, ledger accountingPoster) error) error
+}
+
+// AssetLockingRepository loads an asset with its row locked until the
+// surrounding transaction ends. Repositories that do not implement it load the
+// asset unlocked.
+type AssetLockingRepository interface {
+	GetByIDForUpdate(ctx context.Context, schemaName, tenantID, assetID string) (*FixedAsset, error)
+}
+
+// AssetEventRepository stores asset events. Repositories that do not implement
+// it disable improvements, impairments and estimate changes.
+type AssetEventRepository interface {
+	CreateAssetEvent(ctx context.Context, schemaName string, event *AssetEvent, asset *FixedAsset) error
+	ListAssetEvents(ctx context.Context, schemaName, tenantID, assetID string) ([]AssetEvent, error)
+}
+
 // ErrAssetNotFound is returned when an asset is not found
 var ErrAssetNotFound = fmt.Errorf("asset not found")
 
@@ -81,6 +119,17 @@ func NewGORMRepository(db *gorm.DB) *GORMRepository {
 	return &GORMRepository{db: db}
 }
 
+// WithLedgerTransaction runs fn inside a GORM-backed transaction shared by the
+// assets repository and the general ledger.
+func (r *GORMRepository) WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, ledger accountingPoster) error) error {
+	if r.db == nil {
+		return fmt.Errorf("assets repository database is not configured")
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&GORMRepository{db: tx}, accounting.NewServiceWithRepository(accounting.NewGORMRepository(tx)))
+	})
+}
+
 func (r *GORMRepository) tenantTable(ctx context.Context, schemaName, tableName string) (*gorm.DB, error) {
 	if r.db == nil {
 		return nil, fmt.Errorf("assets repository database is not configured")
@@ -196,13 +245,26 @@ func (r *GORMRepository) Create(ctx context.Context, schemaName string, asset *F
 
 // GetByID retrieves an asset by ID
 func (r *GORMRepository) GetByID(ctx context.Context, schemaName, tenantID, assetID string) (*FixedAsset, error) {
+	return r.getByID(ctx, schemaName, tenantID, assetID, false)
+}
+
+// GetByIDForUpdate retrieves an asset and locks its row until the transaction ends
+func (r *GORMRepository) GetByIDForUpdate(ctx context.Context, schemaName, tenantID, assetID string) (*FixedAsset, error) {
+	return r.getByID(ctx, schemaName, tenantID, assetID, true)
+}
+
+func (r *GORMRepository) getByID(ctx context.Context, schemaName, tenantID, assetID string, forUpdate bool) (*FixedAsset, error) {
 	db, err := r.tenantTable(ctx, schemaName, "fixed_assets")
 	if err != nil {
 		return nil, fmt.Errorf("qualify fixed assets table: %w", err)
 	}
 
 	var assetModel models.FixedAsset
-	err = db.Where("id = ? AND tenant_id = ?", assetID, tenantID).First(&assetModel).Error
+	query := db.Where("id = ? AND tenant_id = ?", assetID, tenantID)
+	if forUpdate {
+		query = query.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	err = query.First(&assetModel).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrAssetNotFound
 	}
@@ -417,6 +479,230 @@ func (r *GORMRepository) UpdateAssetDepreciation(ctx context.Context, schemaName
 	return nil
 }
 
+// CreateDepreciationRun inserts a new depreciation run
+func (r *GORMRepository) CreateDepreciationRun(ctx context.Context, schemaName string, run *DepreciationRun) error {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_runs")
+	if err != nil {
+		return fmt.Errorf("qualify depreciation runs table: %w", err)
+	}
+	runModel, err := depreciationRunToModel(run)
+	if err != nil {
+		return err
+	}
+	if err := db.Create(runModel).Error; err != nil {
+		return fmt.Errorf("insert depreciation run: %w", err)
+	}
+	return nil
+}
+
+// GetDepreciationRun retrieves a depreciation run by ID
+func (r *GORMRepository) GetDepreciationRun(ctx context.Context, schemaName, tenantID, runID string) (*DepreciationRun, error) {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_runs")
+	if err != nil {
+		return nil, fmt.Errorf("qualify depreciation runs table: %w", err)
+	}
+
+	var runModel models.DepreciationRun
+	err = db.Where("id = ? AND tenant_id = ?", runID, tenantID).First(&runModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDepreciationRunNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get depreciation run: %w", err)
+	}
+	return depreciationRunFromModel(&runModel)
+}
+
+// GetPostedDepreciationRun retrieves the posted depreciation run for a period
+func (r *GORMRepository) GetPostedDepreciationRun(ctx context.Context, schemaName, tenantID string, periodStart, periodEnd time.Time) (*DepreciationRun, error) {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_runs")
+	if err != nil {
+		return nil, fmt.Errorf("qualify depreciation runs table: %w", err)
+	}
+
+	var runModel models.DepreciationRun
+	err = db.
+		Where("tenant_id = ? AND period_start = ? AND period_end = ? AND status = ?", tenantID, periodStart, periodEnd, string(DepreciationRunPosted)).
+		First(&runModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrDepreciationRunNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get posted depreciation run: %w", err)
+	}
+	return depreciationRunFromModel(&runModel)
+}
+
+// ListDepreciationRuns retrieves depreciation runs, optionally for one year
+func (r *GORMRepository) ListDepreciationRuns(ctx context.Context, schemaName, tenantID string, year int) ([]DepreciationRun, error) {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_runs")
+	if err != nil {
+		return nil, fmt.Errorf("qualify depreciation runs table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if year > 0 {
+		query = query.Where("period_start >= ? AND period_start < ?",
+			time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(year+1, time.January, 1, 0, 0, 0, 0, time.UTC))
+	}
+	var runModels []models.DepreciationRun
+	if err := query.Order("period_start DESC, created_at DESC").Find(&runModels).Error; err != nil {
+		return nil, fmt.Errorf("list depreciation runs: %w", err)
+	}
+
+	runs := make([]DepreciationRun, 0, len(runModels))
+	for i := range runModels {
+		run, err := depreciationRunFromModel(&runModels[i])
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, *run)
+	}
+	return runs, nil
+}
+
+// MarkDepreciationRunReversed marks a posted depreciation run as reversed
+func (r *GORMRepository) MarkDepreciationRunReversed(ctx context.Context, schemaName, tenantID, runID, userID, reason string, reversedAt time.Time) error {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_runs")
+	if err != nil {
+		return fmt.Errorf("qualify depreciation runs table: %w", err)
+	}
+
+	result := db.Where("id = ? AND tenant_id = ? AND status = ?", runID, tenantID, string(DepreciationRunPosted)).
+		Updates(map[string]interface{}{
+			"status":          string(DepreciationRunReversed),
+			"reversed_at":     reversedAt,
+			"reversed_by":     nonEmptyStringPtr(userID),
+			"reversal_reason": reason,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("mark depreciation run reversed: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrDepreciationRunNotFound
+	}
+	return nil
+}
+
+// ListPeriodDepreciationEntries retrieves depreciation entries overlapping a period
+func (r *GORMRepository) ListPeriodDepreciationEntries(ctx context.Context, schemaName, tenantID string, periodStart, periodEnd time.Time) ([]DepreciationEntry, error) {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_entries")
+	if err != nil {
+		return nil, fmt.Errorf("qualify depreciation entries table: %w", err)
+	}
+
+	var entryModels []models.DepreciationEntry
+	if err := db.
+		Where("tenant_id = ? AND period_start <= ? AND period_end >= ?", tenantID, periodEnd, periodStart).
+		Find(&entryModels).Error; err != nil {
+		return nil, fmt.Errorf("list period depreciation entries: %w", err)
+	}
+
+	entries := make([]DepreciationEntry, len(entryModels))
+	for i := range entryModels {
+		entries[i] = *depreciationEntryFromModel(&entryModels[i])
+	}
+	return entries, nil
+}
+
+// ListDepreciationRunEntries retrieves the depreciation entries of a run
+func (r *GORMRepository) ListDepreciationRunEntries(ctx context.Context, schemaName, tenantID, runID string) ([]DepreciationEntry, error) {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_entries")
+	if err != nil {
+		return nil, fmt.Errorf("qualify depreciation entries table: %w", err)
+	}
+
+	var entryModels []models.DepreciationEntry
+	if err := db.
+		Where("run_id = ? AND tenant_id = ?", runID, tenantID).
+		Order("created_at ASC, id ASC").
+		Find(&entryModels).Error; err != nil {
+		return nil, fmt.Errorf("list depreciation run entries: %w", err)
+	}
+
+	entries := make([]DepreciationEntry, len(entryModels))
+	for i := range entryModels {
+		entries[i] = *depreciationEntryFromModel(&entryModels[i])
+	}
+	return entries, nil
+}
+
+// DeleteDepreciationRunEntries removes the depreciation entries of a run
+func (r *GORMRepository) DeleteDepreciationRunEntries(ctx context.Context, schemaName, tenantID, runID string) error {
+	db, err := r.tenantTable(ctx, schemaName, "depreciation_entries")
+	if err != nil {
+		return fmt.Errorf("qualify depreciation entries table: %w", err)
+	}
+	if err := db.Where("run_id = ? AND tenant_id = ?", runID, tenantID).Delete(&models.DepreciationEntry{}).Error; err != nil {
+		return fmt.Errorf("delete depreciation run entries: %w", err)
+	}
+	return nil
+}
+
+// CreateAssetEvent inserts an asset event and applies its revised values to
+// the active asset in one transaction
+func (r *GORMRepository) CreateAssetEvent(ctx context.Context, schemaName string, event *AssetEvent, asset *FixedAsset) error {
+	if r.db == nil {
+		return fmt.Errorf("assets repository database is not configured")
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		events, err := database.TenantTable(tx, schemaName, "asset_events")
+		if err != nil {
+			return fmt.Errorf("qualify asset events table: %w", err)
+		}
+		if err := events.Create(assetEventToModel(event)).Error; err != nil {
+			return fmt.Errorf("insert asset event: %w", err)
+		}
+
+		fixedAssets, err := database.TenantTable(tx, schemaName, "fixed_assets")
+		if err != nil {
+			return fmt.Errorf("qualify fixed assets table: %w", err)
+		}
+		result := fixedAssets.Where("id = ? AND tenant_id = ? AND status = ?", asset.ID, asset.TenantID, string(AssetStatusActive)).
+			Updates(map[string]interface{}{
+				"purchase_cost":              asset.PurchaseCost.String(),
+				"accumulated_depreciation":   asset.AccumulatedDepreciation.String(),
+				"book_value":                 asset.BookValue.String(),
+				"useful_life_months":         asset.UsefulLifeMonths,
+				"residual_value":             asset.ResidualValue.String(),
+				"revised_depreciable_amount": asset.RevisedDepreciableAmount.String(),
+				"revised_life_months":        asset.RevisedLifeMonths,
+				"updated_at":                 asset.UpdatedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update asset values: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrAssetNotFound
+		}
+		return nil
+	})
+}
+
+// ListAssetEvents retrieves an asset's events in date order
+func (r *GORMRepository) ListAssetEvents(ctx context.Context, schemaName, tenantID, assetID string) ([]AssetEvent, error) {
+	db, err := r.tenantTable(ctx, schemaName, "asset_events")
+	if err != nil {
+		return nil, fmt.Errorf("qualify asset events table: %w", err)
+	}
+
+	var eventModels []models.AssetEvent
+	if err := db.
+		Where("asset_id = ? AND tenant_id = ?", assetID, tenantID).
+		Order("event_date ASC, created_at ASC").
+		Find(&eventModels).Error; err != nil {
+		return nil, fmt.Errorf("list asset events: %w", err)
+	}
+
+	events := make([]AssetEvent, len(eventModels))
+	for i := range eventModels {
+		events[i] = *assetEventFromModel(&eventModels[i])
+	}
+	return events, nil
+}
+
 func assetCategoryToModel(category *AssetCategory) *models.AssetCategory {
 	return &models.AssetCategory{
 		ID:                            category.ID,
@@ -473,6 +759,8 @@ func fixedAssetToModel(asset *FixedAsset) *models.FixedAsset {
 		AccumulatedDepreciation:       models.Decimal{Decimal: asset.AccumulatedDepreciation},
 		BookValue:                     models.Decimal{Decimal: asset.BookValue},
 		LastDepreciationDate:          asset.LastDepreciationDate,
+		RevisedDepreciableAmount:      models.Decimal{Decimal: asset.RevisedDepreciableAmount},
+		RevisedLifeMonths:             asset.RevisedLifeMonths,
 		DisposalDate:                  asset.DisposalDate,
 		DisposalMethod:                disposalMethodString(asset.DisposalMethod),
 		DisposalProceeds:              models.Decimal{Decimal: asset.DisposalProceeds},
@@ -515,6 +803,8 @@ func fixedAssetFromModel(asset *models.FixedAsset) *FixedAsset {
 		AccumulatedDepreciation:       asset.AccumulatedDepreciation.Decimal,
 		BookValue:                     asset.BookValue.Decimal,
 		LastDepreciationDate:          asset.LastDepreciationDate,
+		RevisedDepreciableAmount:      asset.RevisedDepreciableAmount.Decimal,
+		RevisedLifeMonths:             asset.RevisedLifeMonths,
 		DisposalDate:                  asset.DisposalDate,
 		DisposalMethod:                disposalMethod,
 		DisposalProceeds:              asset.DisposalProceeds.Decimal,
@@ -541,6 +831,7 @@ func depreciationEntryToModel(entry *DepreciationEntry) *models.DepreciationEntr
 		AccumulatedTotal:   models.Decimal{Decimal: entry.AccumulatedTotal},
 		BookValueAfter:     models.Decimal{Decimal: entry.BookValueAfter},
 		JournalEntryID:     entry.JournalEntryID,
+		RunID:              entry.RunID,
 		Notes:              entry.Notes,
 		CreatedAt:          entry.CreatedAt,
 		CreatedBy:          entry.CreatedBy,
@@ -559,8 +850,125 @@ func depreciationEntryFromModel(entry *models.DepreciationEntry) *DepreciationEn
 		AccumulatedTotal:   entry.AccumulatedTotal.Decimal,
 		BookValueAfter:     entry.BookValueAfter.Decimal,
 		JournalEntryID:     entry.JournalEntryID,
+		RunID:              entry.RunID,
 		Notes:              entry.Notes,
 		CreatedAt:          entry.CreatedAt,
 		CreatedBy:          entry.CreatedBy,
 	}
 }
+
+func depreciationRunToModel(run *DepreciationRun) (*models.DepreciationRun, error) {
+	categoryTotals := run.CategoryTotals
+	if categoryTotals == nil {
+		categoryTotals = []DepreciationCategoryTotal{}
+	}
+	encodedTotals, err := json.Marshal(categoryTotals)
+	if err != nil {
+		return nil, fmt.Errorf("encode depreciation category totals: %w", err)
+	}
+	journalEntryIDs := run.JournalEntryIDs
+	if journalEntryIDs == nil {
+		journalEntryIDs = []string{}
+	}
+	return &models.DepreciationRun{
+		ID:              run.ID,
+		TenantID:        run.TenantID,
+		PeriodStart:     run.PeriodStart,
+		PeriodEnd:       run.PeriodEnd,
+		PostingMode:     string(run.PostingMode),
+		Status:          string(run.Status),
+		AssetCount:      run.AssetCount,
+		TotalAmount:     models.Decimal{Decimal: run.TotalAmount},
+		CategoryTotals:  encodedTotals,
+		JournalEntryIDs: pq.StringArray(journalEntryIDs),
+		ReversedAt:      run.ReversedAt,
+		ReversedBy:      run.ReversedBy,
+		ReversalReason:  run.ReversalReason,
+		CreatedBy:       run.CreatedBy,
+		CreatedAt:       run.CreatedAt,
+	}, nil
+}
+
+func depreciationRunFromModel(run *models.DepreciationRun) (*DepreciationRun, error) {
+	categoryTotals := []DepreciationCategoryTotal{}
+	if len(run.CategoryTotals) > 0 {
+		if err := json.Unmarshal(run.CategoryTotals, &categoryTotals); err != nil {
+			return nil, fmt.Errorf("decode depreciation category totals: %w", err)
+		}
+	}
+	journalEntryIDs := []string{}
+	journalEntryIDs = append(journalEntryIDs, run.JournalEntryIDs...)
+	return &DepreciationRun{
+		ID:              run.ID,
+		TenantID:        run.TenantID,
+		PeriodStart:     run.PeriodStart,
+		PeriodEnd:       run.PeriodEnd,
+		PostingMode:     DepreciationPostingMode(run.PostingMode),
+		Status:          DepreciationRunStatus(run.Status),
+		AssetCount:      run.AssetCount,
+		TotalAmount:     run.TotalAmount.Decimal,
+		CategoryTotals:  categoryTotals,
+		JournalEntryIDs: journalEntryIDs,
+		ReversedAt:      run.ReversedAt,
+		ReversedBy:      run.ReversedBy,
+		ReversalReason:  run.ReversalReason,
+		CreatedBy:       run.CreatedBy,
+		CreatedAt:       run.CreatedAt,
+	}, nil
+}
+
+func assetEventToModel(event *AssetEvent) *models.AssetEvent {
+	return &models.AssetEvent{
+		ID:                        event.ID,
+		TenantID:                  event.TenantID,
+		AssetID:                   event.AssetID,
+		EventType:                 string(event.EventType),
+		EventDate:                 event.EventDate,
+		Amount:                    models.Decimal{Decimal: event.Amount},
+		Description:               event.Description,
+		CostBefore:                models.Decimal{Decimal: event.CostBefore},
+		CostAfter:                 models.Decimal{Decimal: event.CostAfter},
+		AccumulatedBefore:         models.Decimal{Decimal: event.AccumulatedBefore},
+		AccumulatedAfter:          models.Decimal{Decimal: event.AccumulatedAfter},
+		BookValueBefore:           models.Decimal{Decimal: event.BookValueBefore},
+		BookValueAfter:            models.Decimal{Decimal: event.BookValueAfter},
+		UsefulLifeBefore:          event.UsefulLifeBefore,
+		UsefulLifeAfter:           event.UsefulLifeAfter,
+		ResidualValueBefore:       models.Decimal{Decimal: event.ResidualValueBefore},
+		ResidualValueAfter:        models.Decimal{Decimal: event.ResidualValueAfter},
+		MonthlyDepreciationBefore: models.Decimal{Decimal: event.MonthlyDepreciationBefore},
+		MonthlyDepreciationAfter:  models.Decimal{Decimal: event.MonthlyDepreciationAfter},
+		OffsetAccountID:           event.OffsetAccountID,
+		JournalEntryID:            event.JournalEntryID,
+		CreatedBy:                 event.CreatedBy,
+		CreatedAt:                 event.CreatedAt,
+	}
+}
+
+func assetEventFromModel(event *models.AssetEvent) *AssetEvent {
+	return &AssetEvent{
+		ID:                        event.ID,
+		TenantID:                  event.TenantID,
+		AssetID:                   event.AssetID,
+		EventType:                 AssetEventType(event.EventType),
+		EventDate:                 event.EventDate,
+		Amount:                    event.Amount.Decimal,
+		Description:               event.Description,
+		CostBefore:                event.CostBefore.Decimal,
+		CostAfter:                 event.CostAfter.Decimal,
+		AccumulatedBefore:         event.AccumulatedBefore.Decimal,
+		AccumulatedAfter:          event.AccumulatedAfter.Decimal,
+		BookValueBefore:           event.BookValueBefore.Decimal,
+		BookValueAfter:            event.BookValueAfter.Decimal,
+		UsefulLifeBefore:          event.UsefulLifeBefore,
+		UsefulLifeAfter:           event.UsefulLifeAfter,
+		ResidualValueBefore:       event.ResidualValueBefore.Decimal,
+		ResidualValueAfter:        event.ResidualValueAfter.Decimal,
+		MonthlyDepreciationBefore: event.MonthlyDepreciationBefore.Decimal,
+		MonthlyDepreciationAfter:  event.MonthlyDepreciationAfter.Decimal,
+		OffsetAccountID:           event.OffsetAccountID,
+		JournalEntryID:            event.JournalEntryID,
+		CreatedBy:                 event.CreatedBy,
+		CreatedAt:                 event.CreatedAt,
+	}
+}
@@ -233,6 +233,10 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, asset.ID, gotAsset.ID)
 
+	lockedAsset, err := repo.GetByIDForUpdate(ctx, schemaName, tenantID, asset.ID)
+	require.NoError(t, err)
+	assert.Equal(t, asset.ID, lockedAsset.ID)
+
 	assets, err := repo.List(ctx, schemaName, tenantID, &AssetFilter{
 		Status:     AssetStatusActive,
 		CategoryID: category.ID,
@@ -269,6 +273,31 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	asset.BookValue = decimal.NewFromInt(11000)
 	asset.LastDepreciationDate = &now
 	require.NoError(t, repo.UpdateAssetDepreciation(ctx, schemaName, asset))
+
+	asset.PurchaseCost = decimal.NewFromInt(13000)
+	asset.BookValue = decimal.NewFromInt(12000)
+	asset.RevisedDepreciableAmount = decimal.NewFromInt(12000)
+	asset.RevisedLifeMonths = 40
+	require.NoError(t, repo.CreateAssetEvent(ctx, schemaName, &AssetEvent{
+		ID:        "asset-event-1",
+		TenantID:  tenantID,
+		AssetID:   asset.ID,
+		EventType: AssetEventImprovement,
+		EventDate: now,
+		Amount:    decimal.NewFromInt(1000),
+		CreatedBy: "user-1",
+		CreatedAt: now,
+	}, asset))
+	_, err = repo.ListAssetEvents(ctx, schemaName, tenantID, asset.ID)
+	require.NoError(t, err)
+
+	called := false
+	require.NoError(t, repo.WithLedgerTransaction(ctx, func(txRepo Repository, ledger accountingPoster) error {
+		called = true
+		assert.NotNil(t, ledger)
+		return txRepo.UpdateAssetDepreciation(ctx, schemaName, asset)
+	}))
+	assert.True(t, called)
 }
 
 func TestNewRepositoryPanicsWhenPoolCannotPing(t *testing.T) {
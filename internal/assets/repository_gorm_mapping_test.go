@@ -71,6 +71,14 @@ func TestGORMRepository_NilDatabase(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "GetByIDForUpdate",
+			run: func(t *testing.T) error {
+				asset, err := repo.GetByIDForUpdate(ctx, schemaName, tenantID, "asset-1")
+				assert.Nil(t, asset)
+				return err
+			},
+		},
 		{
 			name: "List",
 			run: func(t *testing.T) error {
@@ -135,6 +143,18 @@ func TestGORMRepository_NilDatabase(t *testing.T) {
 				return repo.UpdateAssetDepreciation(ctx, schemaName, &FixedAsset{ID: "asset-1", TenantID: tenantID})
 			},
 		},
+		{
+			name: "WithLedgerTransaction",
+			run: func(t *testing.T) error {
+				called := false
+				err := repo.WithLedgerTransaction(ctx, func(txRepo Repository, ledger accountingPoster) error {
+					called = true
+					return nil
+				})
+				assert.False(t, called)
+				return err
+			},
+		},
 		{
 			name: "tenantTable",
 			run: func(t *testing.T) error {
@@ -276,3 +296,40 @@ func TestDepreciationEntryModelMappingRoundTrip(t *testing.T) {
 	roundTrip := depreciationEntryFromModel(model)
 	assert.Equal(t, entry, roundTrip)
 }
+
+func TestAssetEventModelMappingRoundTrip(t *testing.T) {
+	offsetAccountID := "cash-account-id"
+	journalEntryID := "journal-entry-id"
+	event := &AssetEvent{
+		ID:                        "asset-event-id",
+		TenantID:                  "tenant-id",
+		AssetID:                   "asset-id",
+		EventType:                 AssetEventImprovement,
+		EventDate:                 time.Date(2026, time.April, 10, 0, 0, 0, 0, time.UTC),
+		Amount:                    decimal.RequireFromString("1800"),
+		Description:               "Memory upgrade",
+		CostBefore:                decimal.RequireFromString("3600"),
+		CostAfter:                 decimal.RequireFromString("5400"),
+		AccumulatedBefore:         decimal.RequireFromString("300"),
+		AccumulatedAfter:          decimal.RequireFromString("300"),
+		BookValueBefore:           decimal.RequireFromString("3300"),
+		BookValueAfter:            decimal.RequireFromString("5100"),
+		UsefulLifeBefore:          36,
+		UsefulLifeAfter:           36,
+		ResidualValueBefore:       decimal.Zero,
+		ResidualValueAfter:        decimal.Zero,
+		MonthlyDepreciationBefore: decimal.RequireFromString("100"),
+		MonthlyDepreciationAfter:  decimal.RequireFromString("154.55"),
+		OffsetAccountID:           &offsetAccountID,
+		JournalEntryID:            &journalEntryID,
+		CreatedBy:                 "creator-id",
+		CreatedAt:                 time.Date(2026, time.April, 10, 8, 0, 0, 0, time.UTC),
+	}
+
+	model := assetEventToModel(event)
+	assert.Equal(t, "IMPROVEMENT", model.EventType)
+	assert.True(t, model.CostAfter.Decimal.Equal(event.CostAfter))
+
+	roundTrip := assetEventFromModel(model)
+	assert.Equal(t, event, roundTrip)
+}
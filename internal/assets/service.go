@@ -32,6 +32,8 @@ type assetInvoiceResolver interface {
 // Service provides fixed asset operations
 type Service struct {
 	repo      Repository
+	runs      DepreciationRunRepository
+	events    AssetEventRepository
 	ledger    accountingPoster
 	contacts  contactLister
 	invoicing assetInvoiceResolver
@@ -50,8 +52,11 @@ var (
 // NewService creates a new assets service with an ORM-backed repository.
 func NewService(db *pgxpool.Pool) *Service {
 	ledger := newAssetsAccountingService(db)
+	repo := NewRepository(db)
 	service := &Service{
-		repo:     NewRepository(db),
+		repo:     repo,
+		runs:     repo,
+		events:   repo,
 		ledger:   ledger,
 		contacts: newAssetsContactsService(db),
 	}
@@ -67,11 +72,20 @@ func NewServiceWithRepository(repo Repository) *Service {
 }
 
 // NewServiceWithRepositoryAndAccounting creates a new assets service with custom repository and ledger poster.
+// Depreciation runs and asset events are enabled when the repository also implements
+// DepreciationRunRepository and AssetEventRepository.
 func NewServiceWithRepositoryAndAccounting(repo Repository, ledger accountingPoster) *Service {
-	return &Service{
+	service := &Service{
 		repo:   repo,
 		ledger: ledger,
 	}
+	if runs, ok := repo.(DepreciationRunRepository); ok {
+		service.runs = runs
+	}
+	if events, ok := repo.(AssetEventRepository); ok {
+		service.events = events
+	}
+	return service
 }
 
 // CreateCategory creates a new asset category
@@ -232,6 +246,15 @@ func (s *Service) GetByID(ctx context.Context, tenantID, schemaName, assetID str
 	return asset, nil
 }
 
+// getAssetForUpdate loads an asset locked for the rest of the transaction when
+// the repository supports row locks.
+func (s *Service) getAssetForUpdate(ctx context.Context, tenantID, schemaName, assetID string) (*FixedAsset, error) {
+	if locker, ok := s.repo.(AssetLockingRepository); ok {
+		return locker.GetByIDForUpdate(ctx, schemaName, tenantID, assetID)
+	}
+	return s.repo.GetByID(ctx, schemaName, tenantID, assetID)
+}
+
 // List retrieves assets with optional filtering
 func (s *Service) List(ctx context.Context, tenantID, schemaName string, filter *AssetFilter) ([]FixedAsset, error) {
 	assets, err := s.repo.List(ctx, schemaName, tenantID, filter)
@@ -451,8 +474,7 @@ func (s *Service) RecordDepreciation(ctx context.Context, tenantID, schemaName,
 	// Update asset values
 	asset.AccumulatedDepreciation = newAccumulated
 	asset.BookValue = newBookValue
-	now := time.Now()
-	asset.LastDepreciationDate = &now
+	asset.LastDepreciationDate = &periodEnd
 
 	if err := s.repo.UpdateAssetDepreciation(ctx, schemaName, asset); err != nil {
 		return nil, fmt.Errorf("update asset depreciation: %w", err)
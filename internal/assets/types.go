@@ -93,6 +93,11 @@ type FixedAsset struct {
 	BookValue               decimal.Decimal `json:"book_value"`
 	LastDepreciationDate    *time.Time      `json:"last_depreciation_date,omitempty"`
 
+	// Revised Depreciation Basis, set by asset events: the amount still to
+	// depreciate and the months left when the estimate was last revised
+	RevisedDepreciableAmount decimal.Decimal `json:"revised_depreciable_amount"`
+	RevisedLifeMonths        int             `json:"revised_life_months,omitempty"`
+
 	// Disposal Information
 	DisposalDate           *time.Time      `json:"disposal_date,omitempty"`
 	DisposalMethod         *DisposalMethod `json:"disposal_method,omitempty"`
@@ -122,6 +127,7 @@ type DepreciationEntry struct {
 	AccumulatedTotal   decimal.Decimal `json:"accumulated_total"`
 	BookValueAfter     decimal.Decimal `json:"book_value_after"`
 	JournalEntryID     *string         `json:"journal_entry_id,omitempty"`
+	RunID              *string         `json:"run_id,omitempty"`
 	Notes              string          `json:"notes,omitempty"`
 	CreatedAt          time.Time       `json:"created_at"`
 	CreatedBy          string          `json:"created_by"`
@@ -150,20 +156,30 @@ func (a *FixedAsset) CalculateMonthlyDepreciation() decimal.Decimal {
 	}
 
 	depreciableAmount := a.PurchaseCost.Sub(a.ResidualValue)
+	lifeMonths := a.UsefulLifeMonths
+	if a.RevisedLifeMonths > 0 {
+		// Asset events revise depreciation prospectively over the remaining life
+		depreciableAmount = a.RevisedDepreciableAmount
+		lifeMonths = a.RevisedLifeMonths
+	}
 	if depreciableAmount.LessThanOrEqual(decimal.Zero) {
 		return decimal.Zero
 	}
 
 	switch a.DepreciationMethod {
 	case DepreciationStraightLine:
-		return depreciableAmount.Div(decimal.NewFromInt(int64(a.UsefulLifeMonths))).Round(2)
+		return depreciableAmount.Div(decimal.NewFromInt(int64(lifeMonths))).Round(2)
 	case DepreciationDecliningBalance:
-		// Double declining balance rate
-		yearlyRate := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(int64(a.UsefulLifeMonths / 12)))
+		// Double declining balance rate; lives under a year use a one-year rate
+		years := lifeMonths / 12
+		if years < 1 {
+			years = 1
+		}
+		yearlyRate := decimal.NewFromFloat(2.0).Div(decimal.NewFromInt(int64(years)))
 		monthlyRate := yearlyRate.Div(decimal.NewFromInt(12))
 		return a.BookValue.Mul(monthlyRate).Round(2)
 	default:
-		return depreciableAmount.Div(decimal.NewFromInt(int64(a.UsefulLifeMonths))).Round(2)
+		return depreciableAmount.Div(decimal.NewFromInt(int64(lifeMonths))).Round(2)
 	}
 }
 
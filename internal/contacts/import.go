@@ -61,6 +61,8 @@ var contactImportHeaderAliases = map[string]string{
 	"zip_code":           "postal_code",
 	"country":            "country_code",
 	"country_code":       "country_code",
+	"language":           "language",
+	"document_language":  "language",
 	"payment_terms_days": "payment_terms_days",
 	"payment_days":       "payment_terms_days",
 	"terms_days":         "payment_terms_days",
@@ -285,6 +287,7 @@ func buildCreateRequestFromImportRow(row importRow) (*CreateContactRequest, erro
 		City:             strings.TrimSpace(row.values["city"]),
 		PostalCode:       strings.TrimSpace(row.values["postal_code"]),
 		CountryCode:      countryCode,
+		Language:         strings.TrimSpace(row.values["language"]),
 		PaymentTermsDays: paymentTermsDays,
 		CreditLimit:      creditLimit,
 		Notes:            strings.TrimSpace(row.values["notes"]),
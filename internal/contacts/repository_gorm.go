@@ -113,6 +113,7 @@ func (r *GORMRepository) Update(ctx context.Context, schemaName string, contact
 			"city":               contact.City,
 			"postal_code":        contact.PostalCode,
 			"country_code":       contact.CountryCode,
+			"language":           contact.Language,
 			"payment_terms_days": contact.PaymentTermsDays,
 			"credit_limit":       contact.CreditLimit,
 			"default_account_id": contact.DefaultAccountID,
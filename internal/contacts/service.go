@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -62,6 +63,7 @@ func (s *Service) Create(ctx context.Context, tenantID string, schemaName string
 		City:             req.City,
 		PostalCode:       req.PostalCode,
 		CountryCode:      req.CountryCode,
+		Language:         strings.ToLower(strings.TrimSpace(req.Language)),
 		PaymentTermsDays: req.PaymentTermsDays,
 		CreditLimit:      req.CreditLimit,
 		DefaultAccountID: defaultAccountID,
@@ -107,6 +109,9 @@ func validateCreateRequestAndNormalizeDefaultAccount(req *CreateContactRequest)
 	if !isValidContactType(req.ContactType) {
 		return nil, fmt.Errorf("invalid contact type: %s", req.ContactType)
 	}
+	if _, err := tenant.NormalizeDocumentLanguage(req.Language); err != nil {
+		return nil, err
+	}
 	return normalizeOptionalContactUUIDPtr(req.DefaultAccountID, "default_account_id")
 }
 
@@ -205,6 +210,13 @@ func applyUpdates(contact *Contact, req *UpdateContactRequest) error {
 	if req.CountryCode != nil {
 		contact.CountryCode = *req.CountryCode
 	}
+	if req.Language != nil {
+		language, err := tenant.NormalizeDocumentLanguage(*req.Language)
+		if err != nil {
+			return err
+		}
+		contact.Language = language
+	}
 	if req.PaymentTermsDays != nil {
 		contact.PaymentTermsDays = *req.PaymentTermsDays
 	}
@@ -523,6 +523,7 @@ func TestService_Update_AllFields(t *testing.T) {
 	city := "Tallinn"
 	postalCode := "10111"
 	countryCode := "EE"
+	language := "EN"
 	paymentTerms := 45
 	creditLimit := decimal.NewFromFloat(5000.00)
 	accountID := "77777777-7777-7777-7777-777777777777"
@@ -540,6 +541,7 @@ func TestService_Update_AllFields(t *testing.T) {
 		City:             &city,
 		PostalCode:       &postalCode,
 		CountryCode:      &countryCode,
+		Language:         &language,
 		PaymentTermsDays: &paymentTerms,
 		CreditLimit:      &creditLimit,
 		DefaultAccountID: &accountID,
@@ -569,6 +571,9 @@ func TestService_Update_AllFields(t *testing.T) {
 	if updated.PostalCode != postalCode {
 		t.Errorf("PostalCode = %q, want %q", updated.PostalCode, postalCode)
 	}
+	if updated.Language != "en" {
+		t.Errorf("Language = %q, want %q", updated.Language, "en")
+	}
 	if *updated.DefaultAccountID != accountID {
 		t.Errorf("DefaultAccountID = %q, want %q", *updated.DefaultAccountID, accountID)
 	}
@@ -580,6 +585,38 @@ func TestService_Update_AllFields(t *testing.T) {
 	}
 }
 
+func TestService_ContactLanguage(t *testing.T) {
+	ctx := context.Background()
+	service := NewServiceWithRepository(NewMockRepository())
+
+	created, err := service.Create(ctx, "tenant-1", "public", &CreateContactRequest{
+		Name:        "Foreign Buyer",
+		ContactType: ContactTypeCustomer,
+		Language:    " EN ",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if created.Language != "en" {
+		t.Errorf("Language = %q, want %q", created.Language, "en")
+	}
+
+	_, err = service.Create(ctx, "tenant-1", "public", &CreateContactRequest{
+		Name:        "Finnish Buyer",
+		ContactType: ContactTypeCustomer,
+		Language:    "fi",
+	})
+	if err == nil || !contains(err.Error(), "invalid document language") {
+		t.Fatalf("expected invalid language error, got %v", err)
+	}
+
+	language := "xx"
+	_, err = service.Update(ctx, "tenant-1", "public", created.ID, &UpdateContactRequest{Language: &language})
+	if err == nil || !contains(err.Error(), "invalid document language") {
+		t.Fatalf("expected invalid language error, got %v", err)
+	}
+}
+
 func TestService_Update_InvalidDefaultAccountID(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockRepository()
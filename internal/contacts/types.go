@@ -35,6 +35,7 @@ type Contact struct {
 	City             string          `json:"city,omitempty"`
 	PostalCode       string          `json:"postal_code,omitempty"`
 	CountryCode      string          `json:"country_code"`
+	Language         string          `json:"language,omitempty"`
 	PaymentTermsDays int             `json:"payment_terms_days"`
 	CreditLimit      decimal.Decimal `json:"credit_limit,omitempty"`
 	DefaultAccountID *string         `json:"default_account_id,omitempty"`
@@ -59,6 +60,7 @@ type CreateContactRequest struct {
 	City             string          `json:"city,omitempty"`
 	PostalCode       string          `json:"postal_code,omitempty"`
 	CountryCode      string          `json:"country_code,omitempty"`
+	Language         string          `json:"language,omitempty"`
 	PaymentTermsDays int             `json:"payment_terms_days,omitempty"`
 	CreditLimit      decimal.Decimal `json:"credit_limit,omitempty"`
 	DefaultAccountID *string         `json:"default_account_id,omitempty"`
@@ -77,6 +79,7 @@ type UpdateContactRequest struct {
 	City             *string          `json:"city,omitempty"`
 	PostalCode       *string          `json:"postal_code,omitempty"`
 	CountryCode      *string          `json:"country_code,omitempty"`
+	Language         *string          `json:"language,omitempty"`
 	PaymentTermsDays *int             `json:"payment_terms_days,omitempty"`
 	CreditLimit      *decimal.Decimal `json:"credit_limit,omitempty"`
 	DefaultAccountID *string          `json:"default_account_id,omitempty"`
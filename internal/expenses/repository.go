@@ -87,6 +87,9 @@ func (r *GORMRepository) List(ctx context.Context, schemaName, tenantID string,
 	if filter.Status != "" {
 		query = query.Where("status = ?", string(filter.Status))
 	}
+	if filter.EmployeeID != "" {
+		query = query.Where("employee_id = ?", filter.EmployeeID)
+	}
 	limit := filter.Limit
 	if limit <= 0 {
 		limit = 100
@@ -118,8 +118,9 @@ type ImportExpensesRowError struct {
 }
 
 type ListExpensesFilter struct {
-	Status ExpenseStatus `json:"status,omitempty"`
-	Limit  int           `json:"limit,omitempty"`
+	Status     ExpenseStatus `json:"status,omitempty"`
+	EmployeeID string        `json:"employee_id,omitempty"`
+	Limit      int           `json:"limit,omitempty"`
 }
 
 type ExpenseActionRequest struct {
@@ -0,0 +1,660 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	defaultInventoryAgingSlowMovingDays     = 180
+	defaultInventoryAgingExpiringWithinDays = 90
+
+	inventoryWriteDownSourceType              = "INVENTORY_WRITE_DOWN"
+	inventoryWriteDownAccountingRoleExpense   = "WRITE_DOWN_EXPENSE"
+	inventoryWriteDownAccountingRoleAllowance = "INVENTORY_ALLOWANCE"
+
+	// InventoryAgingStatusOK marks a lot without aging findings.
+	InventoryAgingStatusOK = "OK"
+	// InventoryAgingStatusExpired marks a lot whose expiry date has passed.
+	InventoryAgingStatusExpired = "EXPIRED"
+	// InventoryAgingStatusExpiring marks a lot expiring within the warning window.
+	InventoryAgingStatusExpiring = "EXPIRING"
+	// InventoryAgingStatusSlowMoving marks a lot without issues for the slow-moving window.
+	InventoryAgingStatusSlowMoving = "SLOW_MOVING"
+
+	inventoryExpiryBucketExpired  = "EXPIRED"
+	inventoryExpiryBucketNoExpiry = "NO_EXPIRY"
+)
+
+var (
+	defaultInventoryAgingSlowMovingDiscount = decimal.NewFromInt(50)
+
+	inventoryAgeBuckets = []inventoryAgingBucketLimit{
+		{label: "0-30", maxDays: 30},
+		{label: "31-90", maxDays: 90},
+		{label: "91-180", maxDays: 180},
+		{label: "181-365", maxDays: 365},
+		{label: "OVER_365", maxDays: -1},
+	}
+	inventoryExpiryBuckets = []inventoryAgingBucketLimit{
+		{label: "0-30", maxDays: 30},
+		{label: "31-90", maxDays: 90},
+		{label: "91-180", maxDays: 180},
+		{label: "OVER_180", maxDays: -1},
+	}
+)
+
+type inventoryAgingBucketLimit struct {
+	label   string
+	maxDays int
+}
+
+// InventoryAgingRequest selects the stock and the assumptions used by the aging report.
+// Percentages are given as 0-100. SlowMovingDiscountPercent defaults to 50 when omitted.
+type InventoryAgingRequest struct {
+	AsOfDate                  time.Time        `json:"as_of_date,omitempty"`
+	WarehouseID               string           `json:"warehouse_id,omitempty"`
+	CategoryID                string           `json:"category_id,omitempty"`
+	SlowMovingDays            int              `json:"slow_moving_days,omitempty"`
+	ExpiringWithinDays        int              `json:"expiring_within_days,omitempty"`
+	SellingCostPercent        decimal.Decimal  `json:"selling_cost_percent,omitempty"`
+	SlowMovingDiscountPercent *decimal.Decimal `json:"slow_moving_discount_percent,omitempty"`
+}
+
+// InventoryAgingBucket is the quantity and value falling into one age or expiry band.
+type InventoryAgingBucket struct {
+	Bucket   string          `json:"bucket"`
+	Quantity decimal.Decimal `json:"quantity"`
+	Value    decimal.Decimal `json:"value"`
+}
+
+// InventoryAgingLine is one on-hand lot position with its age, expiry and
+// net realisable value. ReceivedDate is the date of the oldest inflow still
+// assumed on hand when stock is consumed first in, first out.
+type InventoryAgingLine struct {
+	ProductID              string                 `json:"product_id"`
+	ProductCode            string                 `json:"product_code"`
+	ProductName            string                 `json:"product_name"`
+	CategoryID             string                 `json:"category_id,omitempty"`
+	CategoryName           string                 `json:"category_name"`
+	WarehouseID            string                 `json:"warehouse_id,omitempty"`
+	WarehouseCode          string                 `json:"warehouse_code,omitempty"`
+	WarehouseName          string                 `json:"warehouse_name,omitempty"`
+	LotNumber              string                 `json:"lot_number,omitempty"`
+	SerialNumber           string                 `json:"serial_number,omitempty"`
+	ExpiryDate             string                 `json:"expiry_date,omitempty"`
+	Quantity               decimal.Decimal        `json:"quantity"`
+	UnitCost               decimal.Decimal        `json:"unit_cost"`
+	InventoryValue         decimal.Decimal        `json:"inventory_value"`
+	ReceivedDate           *time.Time             `json:"received_date,omitempty"`
+	AgeDays                int                    `json:"age_days"`
+	AgeBuckets             []InventoryAgingBucket `json:"age_buckets"`
+	DaysToExpiry           *int                   `json:"days_to_expiry,omitempty"`
+	ExpiryBucket           string                 `json:"expiry_bucket"`
+	LastIssueDate          *time.Time             `json:"last_issue_date,omitempty"`
+	Status                 string                 `json:"status"`
+	Flags                  []string               `json:"flags,omitempty"`
+	NetRealisableUnitValue decimal.Decimal        `json:"net_realisable_unit_value"`
+	NetRealisableValue     decimal.Decimal        `json:"net_realisable_value"`
+	WriteDownAmount        decimal.Decimal        `json:"write_down_amount"`
+}
+
+// InventoryAgingGroup totals the aging lines of one warehouse and product category.
+type InventoryAgingGroup struct {
+	WarehouseID        string                 `json:"warehouse_id,omitempty"`
+	WarehouseCode      string                 `json:"warehouse_code,omitempty"`
+	WarehouseName      string                 `json:"warehouse_name,omitempty"`
+	CategoryID         string                 `json:"category_id,omitempty"`
+	CategoryName       string                 `json:"category_name"`
+	Quantity           decimal.Decimal        `json:"quantity"`
+	InventoryValue     decimal.Decimal        `json:"inventory_value"`
+	AgeBuckets         []InventoryAgingBucket `json:"age_buckets"`
+	ExpiryBuckets      []InventoryAgingBucket `json:"expiry_buckets"`
+	ExpiredValue       decimal.Decimal        `json:"expired_value"`
+	SlowMovingValue    decimal.Decimal        `json:"slow_moving_value"`
+	NetRealisableValue decimal.Decimal        `json:"net_realisable_value"`
+	WriteDownAmount    decimal.Decimal        `json:"write_down_amount"`
+}
+
+// InventoryAgingReport buckets on-hand stock value by age since receipt and
+// by days to expiry, and proposes a net realisable value write-down.
+type InventoryAgingReport struct {
+	TenantID                  string                 `json:"tenant_id"`
+	AsOfDate                  time.Time              `json:"as_of_date"`
+	WarehouseID               string                 `json:"warehouse_id,omitempty"`
+	CategoryID                string                 `json:"category_id,omitempty"`
+	SlowMovingDays            int                    `json:"slow_moving_days"`
+	ExpiringWithinDays        int                    `json:"expiring_within_days"`
+	SellingCostPercent        decimal.Decimal        `json:"selling_cost_percent"`
+	SlowMovingDiscountPercent decimal.Decimal        `json:"slow_moving_discount_percent"`
+	Lines                     []InventoryAgingLine   `json:"lines"`
+	Groups                    []InventoryAgingGroup  `json:"groups"`
+	AgeBuckets                []InventoryAgingBucket `json:"age_buckets"`
+	ExpiryBuckets             []InventoryAgingBucket `json:"expiry_buckets"`
+	TotalQuantity             decimal.Decimal        `json:"total_quantity"`
+	TotalValue                decimal.Decimal        `json:"total_value"`
+	ExpiredValue              decimal.Decimal        `json:"expired_value"`
+	SlowMovingValue           decimal.Decimal        `json:"slow_moving_value"`
+	NetRealisableValue        decimal.Decimal        `json:"net_realisable_value"`
+	WriteDownAmount           decimal.Decimal        `json:"write_down_amount"`
+	GeneratedAt               time.Time              `json:"generated_at"`
+}
+
+// ProposeInventoryWriteDownRequest drafts a journal entry adjusting the
+// inventory allowance account to the write-down required by the aging report.
+type ProposeInventoryWriteDownRequest struct {
+	InventoryAgingRequest
+	WriteDownAccountID string `json:"write_down_account_id"`
+	AllowanceAccountID string `json:"allowance_account_id"`
+	UserID             string `json:"-"`
+}
+
+// InventoryWriteDownProposal is the drafted write-down entry. The journal
+// entry is left in DRAFT so the accountant can review and post it.
+type InventoryWriteDownProposal struct {
+	AsOfDate           time.Time                      `json:"as_of_date"`
+	WriteDownAccountID string                         `json:"write_down_account_id"`
+	AllowanceAccountID string                         `json:"allowance_account_id"`
+	RequiredAllowance  decimal.Decimal                `json:"required_allowance"`
+	ExistingAllowance  decimal.Decimal                `json:"existing_allowance"`
+	Adjustment         decimal.Decimal                `json:"adjustment"`
+	Lines              []InventoryIssueAccountingLine `json:"accounting_lines,omitempty"`
+	JournalID          string                         `json:"journal_entry_id,omitempty"`
+	JournalNo          string                         `json:"journal_entry_number,omitempty"`
+	JournalStatus      string                         `json:"journal_entry_status,omitempty"`
+	Report             *InventoryAgingReport          `json:"report"`
+}
+
+// GetInventoryAgingReport ages on-hand lot positions as of a date. Stock on
+// hand is matched to the newest inflows of each position, so the age of a
+// lot is the age of its oldest remaining receipt. Expired lots are valued at
+// zero, and slow-moving lots at the selling price less selling costs and the
+// slow-moving discount; the difference to cost is the proposed write-down.
+func (s *Service) GetInventoryAgingReport(ctx context.Context, tenantID, schemaName string, req *InventoryAgingRequest) (*InventoryAgingReport, error) {
+	if req == nil {
+		req = &InventoryAgingRequest{}
+	}
+	report, err := newInventoryAgingReport(tenantID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.WarehouseID != "" {
+		if _, err := s.repo.GetWarehouseByID(ctx, schemaName, tenantID, report.WarehouseID); err != nil {
+			return nil, fmt.Errorf("get warehouse: %w", err)
+		}
+	}
+	categories, err := s.repo.ListCategories(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list categories: %w", err)
+	}
+	categoryNames := make(map[string]string, len(categories))
+	for _, category := range categories {
+		categoryNames[category.ID] = category.Name
+	}
+	if report.CategoryID != "" {
+		if _, ok := categoryNames[report.CategoryID]; !ok {
+			return nil, fmt.Errorf("category not found")
+		}
+	}
+
+	products, err := s.inventoryLotReportProducts(ctx, tenantID, schemaName, "")
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := s.repo.ListWarehouses(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list warehouses: %w", err)
+	}
+	warehouseByID := make(map[string]Warehouse, len(warehouses))
+	for _, warehouse := range warehouses {
+		warehouseByID[warehouse.ID] = warehouse
+	}
+
+	cutoff := report.AsOfDate.AddDate(0, 0, 1)
+	positions := make(map[inventoryLotKey]*inventoryLotAccumulator)
+	for _, product := range products {
+		if product.ProductType != ProductTypeGoods || !product.TrackInventory {
+			continue
+		}
+		if report.CategoryID != "" && product.CategoryID != report.CategoryID {
+			continue
+		}
+		movements, err := s.repo.ListMovements(ctx, schemaName, tenantID, product.ID)
+		if err != nil {
+			return nil, fmt.Errorf("list movements for product %s: %w", product.ID, err)
+		}
+		for _, movement := range movements {
+			if movement.TenantID != tenantID || !inventoryLotMovementDate(movement).Before(cutoff) {
+				continue
+			}
+			addInventoryLotReportMovement(positions, product, warehouseByID, movement, report.WarehouseID)
+		}
+	}
+
+	for _, position := range positions {
+		if position.line.Quantity.LessThanOrEqual(decimal.Zero) {
+			continue
+		}
+		report.Lines = append(report.Lines, report.agingLine(position, categoryNames))
+	}
+	sort.SliceStable(report.Lines, func(i, j int) bool {
+		left := report.Lines[i]
+		right := report.Lines[j]
+		leftKey := strings.Join([]string{left.WarehouseCode, left.WarehouseID, left.CategoryName, left.ProductCode, left.ProductID, left.ExpiryDate, left.LotNumber, left.SerialNumber}, "\x00")
+		rightKey := strings.Join([]string{right.WarehouseCode, right.WarehouseID, right.CategoryName, right.ProductCode, right.ProductID, right.ExpiryDate, right.LotNumber, right.SerialNumber}, "\x00")
+		return leftKey < rightKey
+	})
+	for _, line := range report.Lines {
+		report.addAgingLine(line)
+	}
+	return report, nil
+}
+
+// ProposeInventoryWriteDown drafts a journal entry that brings the inventory
+// allowance account to the write-down required by the aging report: the
+// expense account is debited and the allowance credited for an increase, and
+// the reverse for a release. The entry is not posted.
+func (s *Service) ProposeInventoryWriteDown(ctx context.Context, tenantID, schemaName string, req *ProposeInventoryWriteDownRequest) (*InventoryWriteDownProposal, error) {
+	if req == nil {
+		return nil, fmt.Errorf("write-down request is required")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for inventory write-down")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to propose an inventory write-down")
+	}
+	writeDownAccountID, err := normalizeRequiredInventoryUUIDString(req.WriteDownAccountID, "write_down_account_id")
+	if err != nil {
+		return nil, err
+	}
+	allowanceAccountID, err := normalizeRequiredInventoryUUIDString(req.AllowanceAccountID, "allowance_account_id")
+	if err != nil {
+		return nil, err
+	}
+	if writeDownAccountID == allowanceAccountID {
+		return nil, fmt.Errorf("write_down_account_id and allowance_account_id must differ")
+	}
+	if err := s.validateInventoryWriteDownAccounts(ctx, schemaName, tenantID, writeDownAccountID, allowanceAccountID); err != nil {
+		return nil, err
+	}
+
+	report, err := s.GetInventoryAgingReport(ctx, tenantID, schemaName, &req.InventoryAgingRequest)
+	if err != nil {
+		return nil, err
+	}
+	proposal := &InventoryWriteDownProposal{
+		AsOfDate:           report.AsOfDate,
+		WriteDownAccountID: writeDownAccountID,
+		AllowanceAccountID: allowanceAccountID,
+		RequiredAllowance:  report.WriteDownAmount,
+		ExistingAllowance:  decimal.Zero,
+		Report:             report,
+	}
+	if balancer, ok := s.accounts.(accountingBalancer); ok && balancer != nil {
+		balance, err := balancer.GetAccountBalance(ctx, schemaName, tenantID, allowanceAccountID, report.AsOfDate)
+		if err != nil {
+			return nil, fmt.Errorf("get allowance account balance: %w", err)
+		}
+		// The allowance is a contra-asset account, so its credit balance is negative.
+		proposal.ExistingAllowance = balance.Neg()
+	}
+	proposal.Adjustment = proposal.RequiredAllowance.Sub(proposal.ExistingAllowance)
+	if proposal.Adjustment.IsZero() {
+		return proposal, nil
+	}
+
+	reference := "NRV " + report.AsOfDate.Format("2006-01-02")
+	description := fmt.Sprintf("Inventory write-down to net realisable value: %s", reference)
+	proposal.Lines = appendInventoryStockCountLine(nil, inventoryWriteDownAccountingRoleExpense, writeDownAccountID, description, proposal.Adjustment)
+	proposal.Lines = appendInventoryStockCountLine(proposal.Lines, inventoryWriteDownAccountingRoleAllowance, allowanceAccountID, description, proposal.Adjustment.Neg())
+
+	journalLines := make([]accounting.CreateJournalEntryLineReq, 0, len(proposal.Lines))
+	for _, line := range proposal.Lines {
+		journalLines = append(journalLines, accounting.CreateJournalEntryLineReq{
+			AccountID:    line.AccountID,
+			Description:  line.Description,
+			DebitAmount:  line.DebitAmount,
+			CreditAmount: line.CreditAmount,
+			Currency:     line.Currency,
+			ExchangeRate: decimal.NewFromInt(1),
+		})
+	}
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   report.AsOfDate,
+		Description: description,
+		Reference:   reference,
+		SourceType:  inventoryWriteDownSourceType,
+		UserID:      userID,
+		Lines:       journalLines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create inventory write-down journal entry: %w", err)
+	}
+	proposal.JournalID = entry.ID
+	proposal.JournalNo = entry.EntryNumber
+	proposal.JournalStatus = string(entry.Status)
+	return proposal, nil
+}
+
+func (s *Service) validateInventoryWriteDownAccounts(ctx context.Context, schemaName, tenantID, writeDownAccountID, allowanceAccountID string) error {
+	if s.accounts == nil {
+		return nil
+	}
+	accounts, err := s.accounts.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for inventory write-down: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	writeDownAccount, ok := byID[writeDownAccountID]
+	if !ok {
+		return fmt.Errorf("write_down_account_id was not found")
+	}
+	if writeDownAccount.AccountType != accounting.AccountTypeExpense {
+		return fmt.Errorf("write_down_account_id must reference an EXPENSE account")
+	}
+	allowanceAccount, ok := byID[allowanceAccountID]
+	if !ok {
+		return fmt.Errorf("allowance_account_id was not found")
+	}
+	if allowanceAccount.AccountType != accounting.AccountTypeAsset {
+		return fmt.Errorf("allowance_account_id must reference an ASSET account")
+	}
+	return nil
+}
+
+func newInventoryAgingReport(tenantID string, req *InventoryAgingRequest) (*InventoryAgingReport, error) {
+	warehouseID, err := normalizeOptionalInventoryUUIDString(req.WarehouseID, "warehouse_id")
+	if err != nil {
+		return nil, err
+	}
+	categoryID, err := normalizeOptionalInventoryUUIDString(req.CategoryID, "category_id")
+	if err != nil {
+		return nil, err
+	}
+	if req.SlowMovingDays < 0 {
+		return nil, fmt.Errorf("slow_moving_days cannot be negative")
+	}
+	if req.ExpiringWithinDays < 0 {
+		return nil, fmt.Errorf("expiring_within_days cannot be negative")
+	}
+	hundred := decimal.NewFromInt(100)
+	if req.SellingCostPercent.IsNegative() || req.SellingCostPercent.GreaterThan(hundred) {
+		return nil, fmt.Errorf("selling_cost_percent must be between 0 and 100")
+	}
+	discount := defaultInventoryAgingSlowMovingDiscount
+	if req.SlowMovingDiscountPercent != nil {
+		discount = *req.SlowMovingDiscountPercent
+	}
+	if discount.IsNegative() || discount.GreaterThan(hundred) {
+		return nil, fmt.Errorf("slow_moving_discount_percent must be between 0 and 100")
+	}
+
+	asOfDate := req.AsOfDate
+	if asOfDate.IsZero() {
+		asOfDate = time.Now()
+	}
+	report := &InventoryAgingReport{
+		TenantID:                  tenantID,
+		AsOfDate:                  time.Date(asOfDate.Year(), asOfDate.Month(), asOfDate.Day(), 0, 0, 0, 0, time.UTC),
+		WarehouseID:               warehouseID,
+		CategoryID:                categoryID,
+		SlowMovingDays:            req.SlowMovingDays,
+		ExpiringWithinDays:        req.ExpiringWithinDays,
+		SellingCostPercent:        req.SellingCostPercent,
+		SlowMovingDiscountPercent: discount,
+		Lines:                     []InventoryAgingLine{},
+		Groups:                    []InventoryAgingGroup{},
+		AgeBuckets:                newInventoryAgeBuckets(),
+		ExpiryBuckets:             newInventoryExpiryBuckets(),
+		TotalQuantity:             decimal.Zero,
+		TotalValue:                decimal.Zero,
+		ExpiredValue:              decimal.Zero,
+		SlowMovingValue:           decimal.Zero,
+		NetRealisableValue:        decimal.Zero,
+		WriteDownAmount:           decimal.Zero,
+		GeneratedAt:               time.Now(),
+	}
+	if report.SlowMovingDays == 0 {
+		report.SlowMovingDays = defaultInventoryAgingSlowMovingDays
+	}
+	if report.ExpiringWithinDays == 0 {
+		report.ExpiringWithinDays = defaultInventoryAgingExpiringWithinDays
+	}
+	return report, nil
+}
+
+func (r *InventoryAgingReport) agingLine(position *inventoryLotAccumulator, categoryNames map[string]string) InventoryAgingLine {
+	lot := position.line
+	product := position.product
+	unitCost := inventoryPositionUnitCost(product, position)
+	line := InventoryAgingLine{
+		ProductID:      lot.ProductID,
+		ProductCode:    lot.ProductCode,
+		ProductName:    lot.ProductName,
+		CategoryID:     product.CategoryID,
+		CategoryName:   inventoryAgingCategoryName(product.CategoryID, categoryNames),
+		WarehouseID:    lot.WarehouseID,
+		WarehouseCode:  lot.WarehouseCode,
+		WarehouseName:  lot.WarehouseName,
+		LotNumber:      lot.LotNumber,
+		SerialNumber:   lot.SerialNumber,
+		ExpiryDate:     lot.ExpiryDate,
+		Quantity:       lot.Quantity,
+		UnitCost:       unitCost,
+		InventoryValue: lot.Quantity.Mul(unitCost).Round(2),
+		AgeBuckets:     []InventoryAgingBucket{},
+		ExpiryBucket:   inventoryExpiryBucketNoExpiry,
+		Status:         InventoryAgingStatusOK,
+	}
+
+	// Stock leaves first in, first out, so what remains on hand is the newest inflows.
+	inflows := append([]inventoryLotInflow(nil), position.inflows...)
+	sort.SliceStable(inflows, func(i, j int) bool { return inflows[i].date.After(inflows[j].date) })
+	remaining := lot.Quantity
+	for i, inflow := range inflows {
+		if remaining.LessThanOrEqual(decimal.Zero) {
+			break
+		}
+		quantity := minDecimal(remaining, inflow.quantity)
+		if i == len(inflows)-1 {
+			quantity = remaining
+		}
+		remaining = remaining.Sub(quantity)
+		receivedDate := inflow.date
+		line.ReceivedDate = &receivedDate
+		line.AgeDays = inventoryAgingDays(inflow.date, r.AsOfDate)
+		line.AgeBuckets = addInventoryAgingBucket(line.AgeBuckets, inventoryAgeBucket(line.AgeDays), quantity, quantity.Mul(unitCost))
+	}
+	for i := range line.AgeBuckets {
+		line.AgeBuckets[i].Value = line.AgeBuckets[i].Value.Round(2)
+	}
+	if !position.lastIssueDate.IsZero() {
+		lastIssueDate := position.lastIssueDate
+		line.LastIssueDate = &lastIssueDate
+	}
+
+	expiryValue := lot.ExpiryDate
+	if len(expiryValue) > len("2006-01-02") {
+		expiryValue = expiryValue[:len("2006-01-02")]
+	}
+	if expiryDate, err := time.Parse("2006-01-02", expiryValue); err == nil {
+		days := inventoryAgingDays(r.AsOfDate, expiryDate)
+		line.DaysToExpiry = &days
+		line.ExpiryBucket = inventoryExpiryBucket(days)
+		if days < 0 {
+			line.Flags = append(line.Flags, InventoryAgingStatusExpired)
+		} else if days <= r.ExpiringWithinDays {
+			line.Flags = append(line.Flags, InventoryAgingStatusExpiring)
+		}
+	}
+	idleDays := line.AgeDays
+	if line.LastIssueDate != nil {
+		idleDays = inventoryAgingDays(*line.LastIssueDate, r.AsOfDate)
+	}
+	slowMoving := line.ReceivedDate != nil && line.AgeDays >= r.SlowMovingDays && idleDays >= r.SlowMovingDays
+	if slowMoving {
+		line.Flags = append(line.Flags, InventoryAgingStatusSlowMoving)
+	}
+	for _, status := range []string{InventoryAgingStatusExpired, InventoryAgingStatusSlowMoving, InventoryAgingStatusExpiring} {
+		if inventoryAgingHasFlag(line.Flags, status) {
+			line.Status = status
+			break
+		}
+	}
+
+	hundred := decimal.NewFromInt(100)
+	netUnitValue := decimal.Zero
+	if line.Status != InventoryAgingStatusExpired {
+		netUnitValue = product.SalesPrice
+		if netUnitValue.LessThanOrEqual(decimal.Zero) {
+			netUnitValue = unitCost
+		}
+		netUnitValue = netUnitValue.Mul(hundred.Sub(r.SellingCostPercent)).Div(hundred)
+		if slowMoving {
+			netUnitValue = netUnitValue.Mul(hundred.Sub(r.SlowMovingDiscountPercent)).Div(hundred)
+		}
+	}
+	line.NetRealisableUnitValue = netUnitValue.Round(4)
+	line.NetRealisableValue = decimal.Min(line.InventoryValue, line.Quantity.Mul(netUnitValue).Round(2))
+	line.WriteDownAmount = line.InventoryValue.Sub(line.NetRealisableValue)
+	return line
+}
+
+func (r *InventoryAgingReport) addAgingLine(line InventoryAgingLine) {
+	var group *InventoryAgingGroup
+	for i := range r.Groups {
+		if r.Groups[i].WarehouseID == line.WarehouseID && r.Groups[i].CategoryID == line.CategoryID {
+			group = &r.Groups[i]
+			break
+		}
+	}
+	if group == nil {
+		r.Groups = append(r.Groups, InventoryAgingGroup{
+			WarehouseID:        line.WarehouseID,
+			WarehouseCode:      line.WarehouseCode,
+			WarehouseName:      line.WarehouseName,
+			CategoryID:         line.CategoryID,
+			CategoryName:       line.CategoryName,
+			Quantity:           decimal.Zero,
+			InventoryValue:     decimal.Zero,
+			AgeBuckets:         newInventoryAgeBuckets(),
+			ExpiryBuckets:      newInventoryExpiryBuckets(),
+			ExpiredValue:       decimal.Zero,
+			SlowMovingValue:    decimal.Zero,
+			NetRealisableValue: decimal.Zero,
+			WriteDownAmount:    decimal.Zero,
+		})
+		group = &r.Groups[len(r.Groups)-1]
+	}
+
+	expiredValue := decimal.Zero
+	if inventoryAgingHasFlag(line.Flags, InventoryAgingStatusExpired) {
+		expiredValue = line.InventoryValue
+	}
+	slowMovingValue := decimal.Zero
+	if inventoryAgingHasFlag(line.Flags, InventoryAgingStatusSlowMoving) {
+		slowMovingValue = line.InventoryValue
+	}
+
+	group.Quantity = group.Quantity.Add(line.Quantity)
+	group.InventoryValue = group.InventoryValue.Add(line.InventoryValue)
+	group.ExpiredValue = group.ExpiredValue.Add(expiredValue)
+	group.SlowMovingValue = group.SlowMovingValue.Add(slowMovingValue)
+	group.NetRealisableValue = group.NetRealisableValue.Add(line.NetRealisableValue)
+	group.WriteDownAmount = group.WriteDownAmount.Add(line.WriteDownAmount)
+	for _, bucket := range line.AgeBuckets {
+		group.AgeBuckets = addInventoryAgingBucket(group.AgeBuckets, bucket.Bucket, bucket.Quantity, bucket.Value)
+		r.AgeBuckets = addInventoryAgingBucket(r.AgeBuckets, bucket.Bucket, bucket.Quantity, bucket.Value)
+	}
+	group.ExpiryBuckets = addInventoryAgingBucket(group.ExpiryBuckets, line.ExpiryBucket, line.Quantity, line.InventoryValue)
+	r.ExpiryBuckets = addInventoryAgingBucket(r.ExpiryBuckets, line.ExpiryBucket, line.Quantity, line.InventoryValue)
+
+	r.TotalQuantity = r.TotalQuantity.Add(line.Quantity)
+	r.TotalValue = r.TotalValue.Add(line.InventoryValue)
+	r.ExpiredValue = r.ExpiredValue.Add(expiredValue)
+	r.SlowMovingValue = r.SlowMovingValue.Add(slowMovingValue)
+	r.NetRealisableValue = r.NetRealisableValue.Add(line.NetRealisableValue)
+	r.WriteDownAmount = r.WriteDownAmount.Add(line.WriteDownAmount)
+}
+
+func newInventoryAgeBuckets() []InventoryAgingBucket {
+	buckets := make([]InventoryAgingBucket, 0, len(inventoryAgeBuckets))
+	for _, limit := range inventoryAgeBuckets {
+		buckets = append(buckets, InventoryAgingBucket{Bucket: limit.label, Quantity: decimal.Zero, Value: decimal.Zero})
+	}
+	return buckets
+}
+
+func newInventoryExpiryBuckets() []InventoryAgingBucket {
+	buckets := []InventoryAgingBucket{{Bucket: inventoryExpiryBucketExpired, Quantity: decimal.Zero, Value: decimal.Zero}}
+	for _, limit := range inventoryExpiryBuckets {
+		buckets = append(buckets, InventoryAgingBucket{Bucket: limit.label, Quantity: decimal.Zero, Value: decimal.Zero})
+	}
+	return append(buckets, InventoryAgingBucket{Bucket: inventoryExpiryBucketNoExpiry, Quantity: decimal.Zero, Value: decimal.Zero})
+}
+
+func addInventoryAgingBucket(buckets []InventoryAgingBucket, label string, quantity, value decimal.Decimal) []InventoryAgingBucket {
+	for i := range buckets {
+		if buckets[i].Bucket == label {
+			buckets[i].Quantity = buckets[i].Quantity.Add(quantity)
+			buckets[i].Value = buckets[i].Value.Add(value)
+			return buckets
+		}
+	}
+	return append(buckets, InventoryAgingBucket{Bucket: label, Quantity: quantity, Value: value})
+}
+
+func inventoryAgeBucket(days int) string {
+	return inventoryAgingBucketLabel(inventoryAgeBuckets, days)
+}
+
+func inventoryExpiryBucket(days int) string {
+	if days < 0 {
+		return inventoryExpiryBucketExpired
+	}
+	return inventoryAgingBucketLabel(inventoryExpiryBuckets, days)
+}
+
+func inventoryAgingBucketLabel(limits []inventoryAgingBucketLimit, days int) string {
+	for _, limit := range limits {
+		if limit.maxDays < 0 || days <= limit.maxDays {
+			return limit.label
+		}
+	}
+	return limits[len(limits)-1].label
+}
+
+// inventoryAgingDays counts whole calendar days from one date to another.
+func inventoryAgingDays(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
+
+func inventoryAgingCategoryName(categoryID string, categoryNames map[string]string) string {
+	if name, ok := categoryNames[categoryID]; ok && strings.TrimSpace(name) != "" {
+		return name
+	}
+	return "Uncategorized"
+}
+
+func inventoryAgingHasFlag(flags []string, flag string) bool {
+	for _, value := range flags {
+		if value == flag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+)
+
+const (
+	agingCategoryID         = "33333333-3333-4333-8333-333333333333"
+	agingWriteDownAccountID = "77777777-7777-4777-8777-777777777777"
+	agingAllowanceAccountID = "88888888-8888-4888-8888-888888888888"
+)
+
+type fakeInventoryAgingLedger struct {
+	*fakeInventoryLedger
+	allowance decimal.Decimal
+}
+
+func (f *fakeInventoryAgingLedger) GetAccountBalance(_ context.Context, _, _, accountID string, _ time.Time) (decimal.Decimal, error) {
+	if accountID == agingAllowanceAccountID {
+		return f.allowance, nil
+	}
+	return decimal.Zero, nil
+}
+
+func agingDate(month time.Month, day int, year ...int) time.Time {
+	y := 2026
+	if len(year) > 0 {
+		y = year[0]
+	}
+	return time.Date(y, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// newAgingTestService holds three lots as of 30 June 2026: an old slow-moving
+// lot, an expired lot and a fresh lot that expires in 46 days.
+func newAgingTestService() (*Service, *MockRepository, *fakeInventoryAgingLedger) {
+	svc, repo, ledger := newReceiptTestService(nil)
+	agingLedger := &fakeInventoryAgingLedger{fakeInventoryLedger: ledger, allowance: decimal.Zero}
+	agingLedger.accounts = append(agingLedger.accounts,
+		accounting.Account{ID: agingWriteDownAccountID, AccountType: accounting.AccountTypeExpense},
+		accounting.Account{ID: agingAllowanceAccountID, AccountType: accounting.AccountTypeAsset},
+	)
+	svc = NewServiceWithRepositoryAndAccounting(repo, agingLedger)
+
+	product := repo.Products[inventoryStockProductID]
+	product.CategoryID = agingCategoryID
+	product.SalesPrice = decimal.RequireFromString("6")
+	repo.Warehouses[inventoryStockWarehouseID].Code = "MAIN"
+	repo.Categories[agingCategoryID] = &ProductCategory{ID: agingCategoryID, TenantID: "tenant-1", Name: "Food"}
+
+	movement := func(id string, movementType MovementType, quantity, unitCost, lotNumber, expiryDate string, date time.Time) InventoryMovement {
+		return InventoryMovement{
+			ID:           id,
+			TenantID:     "tenant-1",
+			ProductID:    inventoryStockProductID,
+			WarehouseID:  inventoryStockWarehouseID,
+			MovementType: movementType,
+			Quantity:     decimal.RequireFromString(quantity),
+			UnitCost:     decimal.RequireFromString(unitCost),
+			LotNumber:    lotNumber,
+			ExpiryDate:   expiryDate,
+			MovementDate: date,
+		}
+	}
+	repo.Movements[inventoryStockProductID] = []InventoryMovement{
+		movement("old-in", MovementTypeIn, "10", "4", "LOT-OLD", "2026-12-01", agingDate(time.January, 10, 2025)),
+		movement("exp-in", MovementTypeIn, "5", "2", "LOT-EXP", "2026-06-01", agingDate(time.March, 1)),
+		movement("exp-out", MovementTypeOut, "1", "0", "LOT-EXP", "2026-06-01", agingDate(time.April, 1)),
+		movement("new-in-1", MovementTypeIn, "4", "3", "LOT-NEW", "2026-08-15", agingDate(time.May, 1)),
+		movement("new-in-2", MovementTypeIn, "6", "3", "LOT-NEW", "2026-08-15", agingDate(time.June, 20)),
+		movement("new-out", MovementTypeOut, "5", "0", "LOT-NEW", "2026-08-15", agingDate(time.June, 25)),
+		movement("future-in", MovementTypeIn, "100", "3", "LOT-NEW", "2026-08-15", agingDate(time.July, 5)),
+	}
+	return svc, repo, agingLedger
+}
+
+func agingTestRequest() InventoryAgingRequest {
+	return InventoryAgingRequest{
+		AsOfDate:           agingDate(time.June, 30),
+		SellingCostPercent: decimal.NewFromInt(10),
+	}
+}
+
+func agingBucketValue(buckets []InventoryAgingBucket, label string) decimal.Decimal {
+	for _, bucket := range buckets {
+		if bucket.Bucket == label {
+			return bucket.Value
+		}
+	}
+	return decimal.Zero
+}
+
+func TestService_GetInventoryAgingReportBucketsAndFlagsLots(t *testing.T) {
+	svc, _, _ := newAgingTestService()
+	req := agingTestRequest()
+
+	report, err := svc.GetInventoryAgingReport(context.Background(), "tenant-1", "test_schema", &req)
+	require.NoError(t, err)
+	assert.Equal(t, 180, report.SlowMovingDays)
+	assert.Equal(t, 90, report.ExpiringWithinDays)
+	assert.True(t, report.SlowMovingDiscountPercent.Equal(decimal.NewFromInt(50)))
+	require.Len(t, report.Lines, 3)
+
+	expired := report.Lines[0]
+	assert.Equal(t, "LOT-EXP", expired.LotNumber)
+	assert.Equal(t, "Food", expired.CategoryName)
+	assert.Equal(t, InventoryAgingStatusExpired, expired.Status)
+	assert.Equal(t, 121, expired.AgeDays)
+	require.NotNil(t, expired.DaysToExpiry)
+	assert.Equal(t, -29, *expired.DaysToExpiry)
+	require.NotNil(t, expired.LastIssueDate)
+	assert.True(t, expired.NetRealisableValue.IsZero())
+	assert.True(t, expired.WriteDownAmount.Equal(decimal.NewFromInt(8)))
+
+	fresh := report.Lines[1]
+	assert.Equal(t, "LOT-NEW", fresh.LotNumber)
+	assert.True(t, fresh.Quantity.Equal(decimal.NewFromInt(5)))
+	assert.Equal(t, 10, fresh.AgeDays)
+	assert.Equal(t, InventoryAgingStatusExpiring, fresh.Status)
+	assert.Equal(t, "31-90", fresh.ExpiryBucket)
+	assert.True(t, fresh.NetRealisableValue.Equal(decimal.NewFromInt(15)))
+	assert.True(t, fresh.WriteDownAmount.IsZero())
+
+	old := report.Lines[2]
+	assert.Equal(t, "LOT-OLD", old.LotNumber)
+	assert.Equal(t, InventoryAgingStatusSlowMoving, old.Status)
+	assert.Nil(t, old.LastIssueDate)
+	assert.True(t, old.NetRealisableUnitValue.Equal(decimal.RequireFromString("2.7")))
+	assert.True(t, old.WriteDownAmount.Equal(decimal.NewFromInt(13)))
+
+	assert.True(t, report.TotalValue.Equal(decimal.NewFromInt(63)))
+	assert.True(t, report.ExpiredValue.Equal(decimal.NewFromInt(8)))
+	assert.True(t, report.SlowMovingValue.Equal(decimal.NewFromInt(40)))
+	assert.True(t, report.WriteDownAmount.Equal(decimal.NewFromInt(21)))
+	assert.True(t, agingBucketValue(report.AgeBuckets, "0-30").Equal(decimal.NewFromInt(15)))
+	assert.True(t, agingBucketValue(report.AgeBuckets, "91-180").Equal(decimal.NewFromInt(8)))
+	assert.True(t, agingBucketValue(report.AgeBuckets, "OVER_365").Equal(decimal.NewFromInt(40)))
+	assert.True(t, agingBucketValue(report.ExpiryBuckets, "EXPIRED").Equal(decimal.NewFromInt(8)))
+	assert.True(t, agingBucketValue(report.ExpiryBuckets, "91-180").Equal(decimal.NewFromInt(40)))
+
+	require.Len(t, report.Groups, 1)
+	assert.Equal(t, "MAIN", report.Groups[0].WarehouseCode)
+	assert.Equal(t, agingCategoryID, report.Groups[0].CategoryID)
+	assert.True(t, report.Groups[0].WriteDownAmount.Equal(decimal.NewFromInt(21)))
+}
+
+func TestService_GetInventoryAgingReportSplitsLayersAndFilters(t *testing.T) {
+	svc, repo, _ := newAgingTestService()
+	repo.Movements[inventoryStockProductID][5].Quantity = decimal.NewFromInt(1)
+	req := agingTestRequest()
+	discount := decimal.Zero
+	req.SlowMovingDiscountPercent = &discount
+
+	report, err := svc.GetInventoryAgingReport(context.Background(), "tenant-1", "test_schema", &req)
+	require.NoError(t, err)
+	fresh := report.Lines[1]
+	assert.True(t, fresh.Quantity.Equal(decimal.NewFromInt(9)))
+	assert.Equal(t, 60, fresh.AgeDays)
+	assert.True(t, agingBucketValue(fresh.AgeBuckets, "0-30").Equal(decimal.NewFromInt(18)))
+	assert.True(t, agingBucketValue(fresh.AgeBuckets, "31-90").Equal(decimal.NewFromInt(9)))
+	assert.True(t, report.Lines[2].WriteDownAmount.IsZero())
+
+	req.CategoryID = agingWriteDownAccountID
+	_, err = svc.GetInventoryAgingReport(context.Background(), "tenant-1", "test_schema", &req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "category not found")
+
+	req = agingTestRequest()
+	req.SellingCostPercent = decimal.NewFromInt(101)
+	_, err = svc.GetInventoryAgingReport(context.Background(), "tenant-1", "test_schema", &req)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "selling_cost_percent must be between 0 and 100")
+}
+
+func TestService_ProposeInventoryWriteDownDraftsAllowanceAdjustment(t *testing.T) {
+	svc, _, ledger := newAgingTestService()
+	ledger.allowance = decimal.NewFromInt(-5)
+
+	proposal, err := svc.ProposeInventoryWriteDown(context.Background(), "tenant-1", "test_schema", &ProposeInventoryWriteDownRequest{
+		InventoryAgingRequest: agingTestRequest(),
+		WriteDownAccountID:    agingWriteDownAccountID,
+		AllowanceAccountID:    agingAllowanceAccountID,
+		UserID:                "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, proposal.RequiredAllowance.Equal(decimal.NewFromInt(21)))
+	assert.True(t, proposal.ExistingAllowance.Equal(decimal.NewFromInt(5)))
+	assert.True(t, proposal.Adjustment.Equal(decimal.NewFromInt(16)))
+	assert.Equal(t, "journal-1", proposal.JournalID)
+	assert.Equal(t, string(accounting.StatusDraft), proposal.JournalStatus)
+	assert.Empty(t, ledger.postedIDs)
+
+	require.NotNil(t, ledger.createdRequest)
+	assert.Equal(t, "INVENTORY_WRITE_DOWN", ledger.createdRequest.SourceType)
+	assert.True(t, ledger.createdRequest.EntryDate.Equal(agingDate(time.June, 30)))
+	require.Len(t, ledger.createdRequest.Lines, 2)
+	assert.Equal(t, agingWriteDownAccountID, ledger.createdRequest.Lines[0].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[0].DebitAmount.Equal(decimal.NewFromInt(16)))
+	assert.Equal(t, agingAllowanceAccountID, ledger.createdRequest.Lines[1].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[1].CreditAmount.Equal(decimal.NewFromInt(16)))
+
+	ledger.allowance = decimal.NewFromInt(-30)
+	proposal, err = svc.ProposeInventoryWriteDown(context.Background(), "tenant-1", "test_schema", &ProposeInventoryWriteDownRequest{
+		InventoryAgingRequest: agingTestRequest(),
+		WriteDownAccountID:    agingWriteDownAccountID,
+		AllowanceAccountID:    agingAllowanceAccountID,
+		UserID:                "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, proposal.Adjustment.Equal(decimal.NewFromInt(-9)))
+	assert.Equal(t, inventoryWriteDownAccountingRoleExpense, proposal.Lines[0].Role)
+	assert.True(t, proposal.Lines[0].CreditAmount.Equal(decimal.NewFromInt(9)))
+	assert.True(t, proposal.Lines[1].DebitAmount.Equal(decimal.NewFromInt(9)))
+
+	ledger.createdRequest = nil
+	ledger.allowance = decimal.NewFromInt(-21)
+	proposal, err = svc.ProposeInventoryWriteDown(context.Background(), "tenant-1", "test_schema", &ProposeInventoryWriteDownRequest{
+		InventoryAgingRequest: agingTestRequest(),
+		WriteDownAccountID:    agingWriteDownAccountID,
+		AllowanceAccountID:    agingAllowanceAccountID,
+		UserID:                "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, proposal.Adjustment.IsZero())
+	assert.Empty(t, proposal.JournalID)
+	assert.Nil(t, ledger.createdRequest)
+}
+
+func TestService_ProposeInventoryWriteDownValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ProposeInventoryWriteDownRequest)
+		want   string
+	}{
+		{name: "missing user", mutate: func(req *ProposeInventoryWriteDownRequest) { req.UserID = "" }, want: "user id is required"},
+		{name: "missing expense account", mutate: func(req *ProposeInventoryWriteDownRequest) { req.WriteDownAccountID = "" }, want: "write_down_account_id is required"},
+		{name: "same accounts", mutate: func(req *ProposeInventoryWriteDownRequest) { req.AllowanceAccountID = agingWriteDownAccountID }, want: "must differ"},
+		{name: "expense type", mutate: func(req *ProposeInventoryWriteDownRequest) { req.WriteDownAccountID = receiptInventoryAccountID }, want: "write_down_account_id must reference an EXPENSE account"},
+		{name: "allowance type", mutate: func(req *ProposeInventoryWriteDownRequest) { req.AllowanceAccountID = receiptAccrualAccountID }, want: "allowance_account_id must reference an ASSET account"},
+		{name: "negative window", mutate: func(req *ProposeInventoryWriteDownRequest) { req.SlowMovingDays = -1 }, want: "slow_moving_days cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, ledger := newAgingTestService()
+			req := &ProposeInventoryWriteDownRequest{
+				InventoryAgingRequest: agingTestRequest(),
+				WriteDownAccountID:    agingWriteDownAccountID,
+				AllowanceAccountID:    agingAllowanceAccountID,
+				UserID:                "user-1",
+			}
+			tt.mutate(req)
+
+			_, err := svc.ProposeInventoryWriteDown(context.Background(), "tenant-1", "test_schema", req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Nil(t, ledger.createdRequest)
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	inventoryAssemblySourceTypeDefault        = "ASSEMBLY"
+	inventoryAssemblyAccountingRoleAbsorption = "ABSORPTION"
+)
+
+// PostAssemblyRequest converts stock in one warehouse: the consumed products
+// are issued at their costing method and the produced products are received
+// at the consumed cost plus any absorbed labour and overhead. Assembly
+// consumes components and produces the finished good; disassembly does the
+// reverse.
+type PostAssemblyRequest struct {
+	WarehouseID         string
+	AssemblyDate        time.Time
+	Reference           string
+	SourceType          string
+	SourceID            string
+	CostingMethod       string
+	AdditionalCost      decimal.Decimal
+	AbsorptionAccountID string
+	InventoryAccountID  string
+	Consumed            []PostAssemblyLine
+	Produced            []PostAssemblyLine
+	UserID              string
+}
+
+// PostAssemblyLine is one consumed or produced product. CostWeight splits the
+// total cost between produced lines; produced lines without weights share it
+// by quantity. CostWeight is ignored on consumed lines.
+type PostAssemblyLine struct {
+	ProductID    string
+	Quantity     decimal.Decimal
+	CostWeight   decimal.Decimal
+	LotNumber    string
+	SerialNumber string
+	ExpiryDate   string
+}
+
+// PostAssemblyLineCost is the cost booked for one consumed or produced line.
+type PostAssemblyLineCost struct {
+	ProductID string          `json:"product_id"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	UnitCost  decimal.Decimal `json:"unit_cost"`
+	TotalCost decimal.Decimal `json:"total_cost"`
+}
+
+// PostAssemblyResult summarizes the assembly movements and journal entry.
+type PostAssemblyResult struct {
+	WarehouseID    string                         `json:"warehouse_id"`
+	CostingMethod  string                         `json:"costing_method"`
+	ConsumedCost   decimal.Decimal                `json:"consumed_cost"`
+	AdditionalCost decimal.Decimal                `json:"additional_cost"`
+	TotalCost      decimal.Decimal                `json:"total_cost"`
+	Consumed       []PostAssemblyLineCost         `json:"consumed"`
+	Produced       []PostAssemblyLineCost         `json:"produced"`
+	Movements      []InventoryMovement            `json:"movements"`
+	Lines          []InventoryIssueAccountingLine `json:"accounting_lines,omitempty"`
+	JournalID      string                         `json:"journal_entry_id,omitempty"`
+	JournalNo      string                         `json:"journal_entry_number,omitempty"`
+}
+
+// PostAssembly issues the consumed products and receives the produced
+// products of an assembly or disassembly. The value moved between inventory
+// accounts and the absorbed labour and overhead are posted in one journal
+// entry, and movements and the journal entry are written in one transaction
+// when the repository supports it.
+func (s *Service) PostAssembly(ctx context.Context, tenantID, schemaName string, req *PostAssemblyRequest) (*PostAssemblyResult, error) {
+	if transactioner, ok := s.repo.(inventoryLedgerTransactioner); ok {
+		var result *PostAssemblyResult
+		err := transactioner.WithInventoryLedgerTransaction(ctx, s.ledger, func(txRepo Repository, txLedger accountingPoster) error {
+			if txLedger == nil {
+				return fmt.Errorf("accounting transaction is unavailable for assembly ledger posting")
+			}
+			txService := *s
+			txService.repo = txRepo
+			txService.accounts = txLedger
+			txService.ledger = txLedger
+			var err error
+			result, err = txService.postAssembly(ctx, tenantID, schemaName, req)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return s.postAssembly(ctx, tenantID, schemaName, req)
+}
+
+func (s *Service) postAssembly(ctx context.Context, tenantID, schemaName string, req *PostAssemblyRequest) (*PostAssemblyResult, error) {
+	if req == nil || len(req.Consumed) == 0 || len(req.Produced) == 0 {
+		return nil, fmt.Errorf("at least one consumed and one produced line are required")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for assembly ledger posting")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to post assembly accounting")
+	}
+	warehouseID, err := normalizeRequiredInventoryUUIDString(req.WarehouseID, "warehouse_id")
+	if err != nil {
+		return nil, err
+	}
+	if req.AdditionalCost.IsNegative() {
+		return nil, fmt.Errorf("additional cost cannot be negative")
+	}
+	absorptionAccountID, err := normalizeOptionalInventoryUUIDString(req.AbsorptionAccountID, "absorption_account_id")
+	if err != nil {
+		return nil, err
+	}
+	if req.AdditionalCost.IsPositive() && absorptionAccountID == "" {
+		return nil, fmt.Errorf("absorption_account_id is required to absorb labour and overhead")
+	}
+	defaultInventoryAccountID, err := normalizeOptionalInventoryUUIDString(req.InventoryAccountID, "inventory_account_id")
+	if err != nil {
+		return nil, err
+	}
+	sourceID, err := normalizeOptionalInventoryUUIDString(req.SourceID, "source_id")
+	if err != nil {
+		return nil, err
+	}
+	if sourceID == "" {
+		sourceID = uuid.New().String()
+	}
+	costingMethod, err := normalizeIssueCostingMethod(req.CostingMethod)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetWarehouseByID(ctx, schemaName, tenantID, warehouseID); err != nil {
+		return nil, fmt.Errorf("get warehouse: %w", err)
+	}
+
+	assemblyDate := req.AssemblyDate
+	if assemblyDate.IsZero() {
+		assemblyDate = time.Now()
+	}
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" {
+		reference = "Assembly"
+	}
+	sourceType := strings.TrimSpace(req.SourceType)
+	if sourceType == "" {
+		sourceType = inventoryAssemblySourceTypeDefault
+	}
+
+	// Validate every product and account before stock moves so a bad line
+	// cannot leave the assembly half booked.
+	inventoryAccounts := make(map[string]string)
+	trackedProduct := func(productID string) (*Product, error) {
+		product, err := s.repo.GetProductByID(ctx, schemaName, tenantID, productID)
+		if err != nil {
+			return nil, fmt.Errorf("get product: %w", err)
+		}
+		if product.ProductType != ProductTypeGoods || !product.TrackInventory {
+			return nil, fmt.Errorf("product %s does not track inventory", product.Code)
+		}
+		inventoryAccountID := firstInventoryNonEmpty(product.InventoryAccountID, defaultInventoryAccountID)
+		if inventoryAccountID == "" {
+			return nil, fmt.Errorf("product %s has no inventory account; set inventory_account_id", product.Code)
+		}
+		inventoryAccounts[productID] = inventoryAccountID
+		return product, nil
+	}
+	consumedIDs := make([]string, len(req.Consumed))
+	for i, line := range req.Consumed {
+		productID, err := normalizeRequiredInventoryUUIDString(line.ProductID, "product_id")
+		if err != nil {
+			return nil, fmt.Errorf("consumed line %d: %w", i+1, err)
+		}
+		if line.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("consumed line %d: quantity must be positive", i+1)
+		}
+		if _, err := trackedProduct(productID); err != nil {
+			return nil, fmt.Errorf("consumed line %d: %w", i+1, err)
+		}
+		consumedIDs[i] = productID
+	}
+	producedIDs := make([]string, len(req.Produced))
+	totalWeight := decimal.Zero
+	for i, line := range req.Produced {
+		productID, err := normalizeRequiredInventoryUUIDString(line.ProductID, "product_id")
+		if err != nil {
+			return nil, fmt.Errorf("produced line %d: %w", i+1, err)
+		}
+		if line.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("produced line %d: quantity must be positive", i+1)
+		}
+		if line.CostWeight.IsNegative() {
+			return nil, fmt.Errorf("produced line %d: cost weight cannot be negative", i+1)
+		}
+		if _, _, _, err := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate); err != nil {
+			return nil, fmt.Errorf("produced line %d: %w", i+1, err)
+		}
+		if _, err := trackedProduct(productID); err != nil {
+			return nil, fmt.Errorf("produced line %d: %w", i+1, err)
+		}
+		producedIDs[i] = productID
+		totalWeight = totalWeight.Add(line.CostWeight)
+	}
+	if err := s.validateInventoryAssemblyAccounts(ctx, schemaName, tenantID, absorptionAccountID, req.AdditionalCost, inventoryAccounts); err != nil {
+		return nil, err
+	}
+
+	result := &PostAssemblyResult{
+		WarehouseID:    warehouseID,
+		CostingMethod:  costingMethod,
+		ConsumedCost:   decimal.Zero,
+		AdditionalCost: req.AdditionalCost.Round(2),
+		Consumed:       make([]PostAssemblyLineCost, 0, len(req.Consumed)),
+		Produced:       make([]PostAssemblyLineCost, 0, len(req.Produced)),
+	}
+	inventoryValue := make(map[string]decimal.Decimal)
+	for i, line := range req.Consumed {
+		issued, err := s.issueStock(ctx, tenantID, schemaName, &IssueStockRequest{
+			ProductID:     consumedIDs[i],
+			WarehouseID:   warehouseID,
+			Quantity:      line.Quantity.String(),
+			CostingMethod: costingMethod,
+			LotNumber:     line.LotNumber,
+			SerialNumber:  line.SerialNumber,
+			ExpiryDate:    line.ExpiryDate,
+			Reference:     reference,
+			SourceType:    sourceType,
+			SourceID:      sourceID,
+			UserID:        userID,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("consumed line %d: %w", i+1, err)
+		}
+		cost := issued.TotalCost.Round(2)
+		accountID := inventoryAccounts[consumedIDs[i]]
+		inventoryValue[accountID] = inventoryValue[accountID].Sub(cost)
+		result.ConsumedCost = result.ConsumedCost.Add(cost)
+		result.Consumed = append(result.Consumed, PostAssemblyLineCost{
+			ProductID: consumedIDs[i],
+			Quantity:  issued.Quantity,
+			UnitCost:  issued.UnitCost,
+			TotalCost: cost,
+		})
+		result.Movements = append(result.Movements, issued.Movements...)
+	}
+	result.TotalCost = result.ConsumedCost.Add(result.AdditionalCost)
+
+	now := time.Now()
+	allocated := decimal.Zero
+	for i, line := range req.Produced {
+		productID := producedIDs[i]
+		cost := result.TotalCost.Sub(allocated)
+		if i < len(req.Produced)-1 {
+			cost = inventoryAssemblyCostShare(result.TotalCost, line, req.Produced, totalWeight)
+		}
+		allocated = allocated.Add(cost)
+		unitCost := cost.Div(line.Quantity).Round(8)
+
+		product, err := s.repo.GetProductByID(ctx, schemaName, tenantID, productID)
+		if err != nil {
+			return nil, fmt.Errorf("get product: %w", err)
+		}
+		level, err := s.stockLevelForWarehouse(ctx, tenantID, schemaName, productID, warehouseID)
+		if err != nil {
+			return nil, fmt.Errorf("get stock level: %w", err)
+		}
+		lotNumber, serialNumber, expiryDate, _ := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate)
+		movement := &InventoryMovement{
+			ID:           uuid.New().String(),
+			TenantID:     tenantID,
+			ProductID:    productID,
+			WarehouseID:  warehouseID,
+			MovementType: MovementTypeIn,
+			Quantity:     line.Quantity,
+			UnitCost:     unitCost,
+			TotalCost:    cost,
+			LotNumber:    lotNumber,
+			SerialNumber: serialNumber,
+			ExpiryDate:   expiryDate,
+			Reference:    reference,
+			SourceType:   sourceType,
+			SourceID:     sourceID,
+			MovementDate: assemblyDate,
+			CreatedAt:    now,
+			CreatedBy:    userID,
+		}
+		if err := s.repo.CreateMovement(ctx, schemaName, movement); err != nil {
+			return nil, fmt.Errorf("create assembly movement: %w", err)
+		}
+		if err := s.repo.UpdateProductStock(ctx, schemaName, tenantID, productID, product.CurrentStock.Add(line.Quantity)); err != nil {
+			return nil, fmt.Errorf("update product stock: %w", err)
+		}
+		level.Quantity = level.Quantity.Add(line.Quantity)
+		level.AvailableQty = level.Quantity.Sub(level.ReservedQty)
+		level.LastUpdated = now
+		if err := s.repo.UpsertStockLevel(ctx, schemaName, level); err != nil {
+			return nil, fmt.Errorf("update stock level: %w", err)
+		}
+
+		accountID := inventoryAccounts[productID]
+		inventoryValue[accountID] = inventoryValue[accountID].Add(cost)
+		result.Produced = append(result.Produced, PostAssemblyLineCost{
+			ProductID: productID,
+			Quantity:  line.Quantity,
+			UnitCost:  unitCost,
+			TotalCost: cost,
+		})
+		result.Movements = append(result.Movements, *movement)
+	}
+
+	result.Lines = inventoryAssemblyAccountingLines(reference, absorptionAccountID, inventoryValue, result.AdditionalCost)
+	if len(result.Lines) == 0 {
+		return result, nil
+	}
+
+	journalLines := make([]accounting.CreateJournalEntryLineReq, 0, len(result.Lines))
+	for _, line := range result.Lines {
+		journalLines = append(journalLines, accounting.CreateJournalEntryLineReq{
+			AccountID:    line.AccountID,
+			Description:  line.Description,
+			DebitAmount:  line.DebitAmount,
+			CreditAmount: line.CreditAmount,
+			Currency:     line.Currency,
+			ExchangeRate: decimal.NewFromInt(1),
+		})
+	}
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   assemblyDate,
+		Description: fmt.Sprintf("Assembly: %s", reference),
+		Reference:   reference,
+		SourceType:  sourceType,
+		SourceID:    &sourceID,
+		UserID:      userID,
+		Lines:       journalLines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create assembly journal entry: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Assembly ledger posting"); err != nil {
+		return nil, fmt.Errorf("post assembly journal entry: %w", err)
+	}
+	result.JournalID = entry.ID
+	result.JournalNo = entry.EntryNumber
+	return result, nil
+}
+
+// inventoryAssemblyCostShare returns the part of the total cost carried by one
+// produced line, by cost weight when weights are given and by quantity
+// otherwise.
+func inventoryAssemblyCostShare(totalCost decimal.Decimal, line PostAssemblyLine, produced []PostAssemblyLine, totalWeight decimal.Decimal) decimal.Decimal {
+	if totalWeight.IsPositive() {
+		return totalCost.Mul(line.CostWeight).Div(totalWeight).Round(2)
+	}
+	totalQuantity := decimal.Zero
+	for _, other := range produced {
+		totalQuantity = totalQuantity.Add(other.Quantity)
+	}
+	return totalCost.Mul(line.Quantity).Div(totalQuantity).Round(2)
+}
+
+func inventoryAssemblyAccountingLines(reference, absorptionAccountID string, inventoryValue map[string]decimal.Decimal, additionalCost decimal.Decimal) []InventoryIssueAccountingLine {
+	accountIDs := make([]string, 0, len(inventoryValue))
+	for accountID := range inventoryValue {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+
+	description := fmt.Sprintf("Assembly: %s", reference)
+	lines := make([]InventoryIssueAccountingLine, 0, len(accountIDs)+1)
+	for _, accountID := range accountIDs {
+		lines = appendInventoryStockCountLine(lines, inventoryIssueAccountingRoleAsset, accountID, description, inventoryValue[accountID])
+	}
+	return appendInventoryStockCountLine(lines, inventoryAssemblyAccountingRoleAbsorption, absorptionAccountID, description, additionalCost.Neg())
+}
+
+func (s *Service) validateInventoryAssemblyAccounts(ctx context.Context, schemaName, tenantID, absorptionAccountID string, additionalCost decimal.Decimal, inventoryAccounts map[string]string) error {
+	if s.accounts == nil {
+		return nil
+	}
+	accounts, err := s.accounts.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for assembly accounting: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	if additionalCost.IsPositive() {
+		absorptionAccount, ok := byID[absorptionAccountID]
+		if !ok {
+			return fmt.Errorf("absorption_account_id was not found")
+		}
+		if absorptionAccount.AccountType != accounting.AccountTypeExpense {
+			return fmt.Errorf("absorption_account_id must reference an EXPENSE account")
+		}
+	}
+	for _, accountID := range inventoryAccounts {
+		inventoryAccount, ok := byID[accountID]
+		if !ok {
+			return fmt.Errorf("inventory account %s was not found", accountID)
+		}
+		if inventoryAccount.AccountType != accounting.AccountTypeAsset {
+			return fmt.Errorf("inventory account %s must reference an ASSET account", accountID)
+		}
+	}
+	return nil
+}
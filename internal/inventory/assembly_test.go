@@ -0,0 +1,157 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+)
+
+const (
+	assemblyFinishedProductID   = "99999999-9999-4999-8999-999999999999"
+	assemblyFinishedAccountID   = "44444444-4444-4444-8444-444444444444"
+	assemblyAbsorptionAccountID = "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"
+)
+
+func newAssemblyTestService(postErr error) (*Service, *MockRepository, *fakeInventoryLedger) {
+	svc, repo, ledger := newReceiptTestService(postErr)
+	ledger.accounts = append(ledger.accounts,
+		accounting.Account{ID: assemblyFinishedAccountID, AccountType: accounting.AccountTypeAsset},
+		accounting.Account{ID: assemblyAbsorptionAccountID, AccountType: accounting.AccountTypeExpense},
+	)
+	repo.Movements[inventoryStockProductID] = []InventoryMovement{{
+		ID:           "lot-in",
+		TenantID:     "tenant-1",
+		ProductID:    inventoryStockProductID,
+		WarehouseID:  inventoryStockWarehouseID,
+		MovementType: MovementTypeIn,
+		Quantity:     decimal.RequireFromString("5"),
+		UnitCost:     decimal.RequireFromString("4"),
+		TotalCost:    decimal.RequireFromString("20"),
+		LotNumber:    "LOT-A",
+	}}
+	repo.Products[assemblyFinishedProductID] = &Product{
+		ID:                 assemblyFinishedProductID,
+		TenantID:           "tenant-1",
+		Code:               "KIT-001",
+		Name:               "Widget set",
+		ProductType:        ProductTypeGoods,
+		TrackInventory:     true,
+		InventoryAccountID: assemblyFinishedAccountID,
+	}
+	return svc, repo, ledger
+}
+
+func assemblyTestRequest() *PostAssemblyRequest {
+	return &PostAssemblyRequest{
+		WarehouseID:         inventoryStockWarehouseID,
+		Reference:           "AO-00001",
+		SourceType:          "ASSEMBLY_ORDER",
+		SourceID:            receiptSourceID,
+		AdditionalCost:      decimal.RequireFromString("3"),
+		AbsorptionAccountID: assemblyAbsorptionAccountID,
+		UserID:              "user-1",
+		Consumed: []PostAssemblyLine{
+			{ProductID: inventoryStockProductID, Quantity: decimal.RequireFromString("2")},
+		},
+		Produced: []PostAssemblyLine{
+			{ProductID: assemblyFinishedProductID, Quantity: decimal.RequireFromString("1"), LotNumber: "BATCH-1"},
+		},
+	}
+}
+
+func TestService_PostAssemblyReceivesRolledUpCost(t *testing.T) {
+	svc, repo, ledger := newAssemblyTestService(nil)
+
+	result, err := svc.PostAssembly(context.Background(), "tenant-1", "test_schema", assemblyTestRequest())
+	require.NoError(t, err)
+	assert.True(t, result.ConsumedCost.Equal(decimal.RequireFromString("8")))
+	assert.True(t, result.TotalCost.Equal(decimal.RequireFromString("11")))
+	require.Len(t, result.Produced, 1)
+	assert.True(t, result.Produced[0].UnitCost.Equal(decimal.RequireFromString("11")))
+	require.Len(t, result.Movements, 2)
+	assert.Equal(t, MovementTypeOut, result.Movements[0].MovementType)
+	assert.Equal(t, MovementTypeIn, result.Movements[1].MovementType)
+	assert.Equal(t, "BATCH-1", result.Movements[1].LotNumber)
+	assert.Equal(t, "ASSEMBLY_ORDER", result.Movements[1].SourceType)
+	assert.Equal(t, receiptSourceID, result.Movements[0].SourceID)
+
+	assert.True(t, repo.Products[inventoryStockProductID].CurrentStock.Equal(decimal.RequireFromString("3")))
+	assert.True(t, repo.Products[assemblyFinishedProductID].CurrentStock.Equal(decimal.RequireFromString("1")))
+	level := repo.StockLevels[inventoryStockLevelKey(assemblyFinishedProductID, inventoryStockWarehouseID)]
+	require.NotNil(t, level)
+	assert.True(t, level.AvailableQty.Equal(decimal.RequireFromString("1")))
+
+	require.NotNil(t, ledger.createdRequest)
+	require.Len(t, ledger.createdRequest.Lines, 3)
+	assert.Equal(t, assemblyFinishedAccountID, ledger.createdRequest.Lines[0].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[0].DebitAmount.Equal(decimal.RequireFromString("11")))
+	assert.Equal(t, receiptInventoryAccountID, ledger.createdRequest.Lines[1].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[1].CreditAmount.Equal(decimal.RequireFromString("8")))
+	assert.Equal(t, assemblyAbsorptionAccountID, ledger.createdRequest.Lines[2].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[2].CreditAmount.Equal(decimal.RequireFromString("3")))
+	assert.Equal(t, "journal-1", result.JournalID)
+}
+
+func TestService_PostAssemblySplitsCostByWeight(t *testing.T) {
+	svc, _, _ := newAssemblyTestService(nil)
+	req := assemblyTestRequest()
+	req.AdditionalCost = decimal.Zero
+	req.Consumed[0].Quantity = decimal.RequireFromString("3")
+	req.Produced = []PostAssemblyLine{
+		{ProductID: assemblyFinishedProductID, Quantity: decimal.RequireFromString("1"), CostWeight: decimal.RequireFromString("1")},
+		{ProductID: assemblyFinishedProductID, Quantity: decimal.RequireFromString("1"), CostWeight: decimal.RequireFromString("2")},
+	}
+
+	result, err := svc.PostAssembly(context.Background(), "tenant-1", "test_schema", req)
+	require.NoError(t, err)
+	require.Len(t, result.Produced, 2)
+	assert.True(t, result.Produced[0].TotalCost.Equal(decimal.RequireFromString("4")))
+	assert.True(t, result.Produced[1].TotalCost.Equal(decimal.RequireFromString("8")))
+}
+
+func TestService_PostAssemblyRollsBackWhenPostingFails(t *testing.T) {
+	svc, repo, _ := newAssemblyTestService(fmt.Errorf("ledger unavailable"))
+
+	_, err := svc.PostAssembly(context.Background(), "tenant-1", "test_schema", assemblyTestRequest())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "post assembly journal entry")
+	assert.True(t, repo.Products[inventoryStockProductID].CurrentStock.Equal(decimal.RequireFromString("5")))
+	assert.True(t, repo.Products[assemblyFinishedProductID].CurrentStock.IsZero())
+	assert.Empty(t, repo.Movements[assemblyFinishedProductID])
+}
+
+func TestService_PostAssemblyValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*PostAssemblyRequest)
+		want   string
+	}{
+		{name: "no produced lines", mutate: func(req *PostAssemblyRequest) { req.Produced = nil }, want: "at least one consumed and one produced line"},
+		{name: "missing absorption account", mutate: func(req *PostAssemblyRequest) { req.AbsorptionAccountID = "" }, want: "absorption_account_id is required"},
+		{name: "absorption account type", mutate: func(req *PostAssemblyRequest) { req.AbsorptionAccountID = receiptAccrualAccountID }, want: "must reference an EXPENSE account"},
+		{name: "negative additional cost", mutate: func(req *PostAssemblyRequest) { req.AdditionalCost = decimal.RequireFromString("-1") }, want: "additional cost cannot be negative"},
+		{name: "zero consumed quantity", mutate: func(req *PostAssemblyRequest) { req.Consumed[0].Quantity = decimal.Zero }, want: "consumed line 1: quantity must be positive"},
+		{name: "unknown produced product", mutate: func(req *PostAssemblyRequest) {
+			req.Produced[0].ProductID = inventoryStockWarehouseID2
+		}, want: "produced line 1: get product"},
+		{name: "insufficient stock", mutate: func(req *PostAssemblyRequest) { req.Consumed[0].Quantity = decimal.RequireFromString("5") }, want: "insufficient available stock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newAssemblyTestService(nil)
+			req := assemblyTestRequest()
+			tt.mutate(req)
+
+			_, err := svc.PostAssembly(context.Background(), "tenant-1", "test_schema", req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, repo.Movements[assemblyFinishedProductID])
+		})
+	}
+}
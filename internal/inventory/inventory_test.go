@@ -14,6 +14,7 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 const (
@@ -492,6 +493,37 @@ func (r *MockRepository) ListMovements(ctx context.Context, schemaName, tenantID
 	return result, nil
 }
 
+func (r *MockRepository) HasSourceMovements(ctx context.Context, schemaName, tenantID, sourceType, sourceID string) (bool, error) {
+	if r.ErrOnListMovements {
+		return false, fmt.Errorf("mock error on list movements")
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, movements := range r.Movements {
+		for _, m := range movements {
+			if m.TenantID == tenantID && m.SourceType == sourceType && m.SourceID == sourceID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func (r *MockRepository) UpdateMovementCost(ctx context.Context, schemaName, tenantID, movementID string, unitCost, totalCost decimal.Decimal) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for productID, movements := range r.Movements {
+		for i := range movements {
+			if movements[i].ID == movementID && movements[i].TenantID == tenantID {
+				r.Movements[productID][i].UnitCost = unitCost
+				r.Movements[productID][i].TotalCost = totalCost
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("inventory movement not found")
+}
+
 // Stock updates
 func (r *MockRepository) UpdateProductStock(ctx context.Context, schemaName, tenantID, productID string, newStock decimal.Decimal) error {
 	if r.ErrOnUpdateProductStock {
@@ -3527,6 +3559,27 @@ func TestService_GetMovements(t *testing.T) {
 	assert.Len(t, movements, 2)
 }
 
+func TestService_HasSourceMovements(t *testing.T) {
+	ts := newTestService()
+	ctx := context.Background()
+
+	ts.repo.Movements["p1"] = []InventoryMovement{
+		{ID: "m1", TenantID: "tenant-1", ProductID: "p1", MovementType: MovementTypeOut, Quantity: decimal.NewFromInt(2), SourceType: "KIT_SALE", SourceID: "invoice-1"},
+	}
+
+	found, err := ts.svc.HasSourceMovements(ctx, "tenant-1", "test_schema", "KIT_SALE", "invoice-1")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = ts.svc.HasSourceMovements(ctx, "tenant-1", "test_schema", "KIT_SALE", "invoice-2")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	ts.repo.ErrOnListMovements = true
+	_, err = ts.svc.HasSourceMovements(ctx, "tenant-1", "test_schema", "KIT_SALE", "invoice-1")
+	require.ErrorContains(t, err, "check source movements")
+}
+
 func TestService_GetInventoryValuation(t *testing.T) {
 	ts := newTestService()
 	ctx := context.Background()
@@ -4064,6 +4117,17 @@ func TestNewService(t *testing.T) {
 	assert.NotNil(t, svc.repo)
 }
 
+func TestNewServiceWithGORM(t *testing.T) {
+	db := &gorm.DB{}
+	svc := NewServiceWithGORM(db)
+	require.NotNil(t, svc)
+	repo, ok := svc.repo.(*GORMRepository)
+	require.True(t, ok)
+	assert.Same(t, db, repo.db)
+	assert.NotNil(t, svc.ledger)
+	assert.NotNil(t, svc.accounts)
+}
+
 func TestNewServiceWithRepository(t *testing.T) {
 	repo := NewMockRepository()
 	svc := NewServiceWithRepository(repo)
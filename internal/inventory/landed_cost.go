@@ -0,0 +1,448 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/shopspring/decimal"
+)
+
+const inventoryLandedCostSourceTypeDefault = "LANDED_COST"
+
+// ApplyLandedCostRequest adds freight, duty and other acquisition costs to
+// stock that was already received. Each line names the receipt movement by
+// its source document, product, warehouse and lot. OffsetLines carry the
+// other side of the posting, such as the supplier payable and input VAT.
+type ApplyLandedCostRequest struct {
+	CostDate                 time.Time
+	Reference                string
+	SourceType               string
+	SourceID                 string
+	CostOfGoodsSoldAccountID string
+	InventoryAccountID       string
+	Lines                    []ApplyLandedCostLine
+	OffsetLines              []InventoryIssueAccountingLine
+	UserID                   string
+}
+
+// ApplyLandedCostLine allocates an amount to one received stock line.
+type ApplyLandedCostLine struct {
+	ReceiptSourceType string
+	ReceiptSourceID   string
+	ProductID         string
+	WarehouseID       string
+	Quantity          decimal.Decimal
+	LotNumber         string
+	SerialNumber      string
+	ExpiryDate        string
+	Amount            decimal.Decimal
+}
+
+// AppliedLandedCostLine is the cost added to one receipt movement and how it
+// was split between stock still on hand and stock already issued.
+type AppliedLandedCostLine struct {
+	MovementID               string          `json:"movement_id"`
+	ProductID                string          `json:"product_id"`
+	WarehouseID              string          `json:"warehouse_id"`
+	Quantity                 decimal.Decimal `json:"quantity"`
+	OnHandQuantity           decimal.Decimal `json:"on_hand_quantity"`
+	IssuedQuantity           decimal.Decimal `json:"issued_quantity"`
+	Amount                   decimal.Decimal `json:"amount"`
+	InventoryAmount          decimal.Decimal `json:"inventory_amount"`
+	COGSAmount               decimal.Decimal `json:"cogs_amount"`
+	PreviousUnitCost         decimal.Decimal `json:"previous_unit_cost"`
+	NewUnitCost              decimal.Decimal `json:"new_unit_cost"`
+	InventoryAccountID       string          `json:"inventory_account_id"`
+	CostOfGoodsSoldAccountID string          `json:"cost_of_goods_sold_account_id,omitempty"`
+}
+
+// ApplyLandedCostResult summarizes the revalued receipts and the journal entry.
+type ApplyLandedCostResult struct {
+	TotalAmount     decimal.Decimal                `json:"total_amount"`
+	InventoryAmount decimal.Decimal                `json:"inventory_amount"`
+	COGSAmount      decimal.Decimal                `json:"cogs_amount"`
+	Lines           []AppliedLandedCostLine        `json:"lines"`
+	AccountingLines []InventoryIssueAccountingLine `json:"accounting_lines,omitempty"`
+	JournalID       string                         `json:"journal_entry_id,omitempty"`
+	JournalNo       string                         `json:"journal_entry_number,omitempty"`
+}
+
+// ApplyLandedCost raises the cost of received stock by the allocated amounts.
+// The receipt movement cost is increased, so FIFO, weighted-average and lot
+// valuation all pick up the landed cost. The part of each amount that belongs
+// to units of the receipt still on hand is debited to inventory; the part for
+// units already issued is debited to cost of goods sold. Issued units are
+// found first-in-first-out: stock of the same product and lot received later
+// is assumed to still be on hand. Movement updates and the journal entry are
+// written in one transaction when the repository supports it.
+func (s *Service) ApplyLandedCost(ctx context.Context, tenantID, schemaName string, req *ApplyLandedCostRequest) (*ApplyLandedCostResult, error) {
+	if transactioner, ok := s.repo.(inventoryLedgerTransactioner); ok {
+		var result *ApplyLandedCostResult
+		err := transactioner.WithInventoryLedgerTransaction(ctx, s.ledger, func(txRepo Repository, txLedger accountingPoster) error {
+			if txLedger == nil {
+				return fmt.Errorf("accounting transaction is unavailable for landed cost ledger posting")
+			}
+			txService := *s
+			txService.repo = txRepo
+			txService.accounts = txLedger
+			txService.ledger = txLedger
+			var err error
+			result, err = txService.applyLandedCost(ctx, tenantID, schemaName, req)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return s.applyLandedCost(ctx, tenantID, schemaName, req)
+}
+
+func (s *Service) applyLandedCost(ctx context.Context, tenantID, schemaName string, req *ApplyLandedCostRequest) (*ApplyLandedCostResult, error) {
+	if req == nil || len(req.Lines) == 0 {
+		return nil, fmt.Errorf("at least one landed cost line is required")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for landed cost ledger posting")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to post landed cost accounting")
+	}
+	defaultCOGSAccountID, err := normalizeOptionalInventoryUUIDString(req.CostOfGoodsSoldAccountID, "cost_of_goods_sold_account_id")
+	if err != nil {
+		return nil, err
+	}
+	defaultInventoryAccountID, err := normalizeOptionalInventoryUUIDString(req.InventoryAccountID, "inventory_account_id")
+	if err != nil {
+		return nil, err
+	}
+	sourceID, err := normalizeOptionalInventoryUUIDString(req.SourceID, "source_id")
+	if err != nil {
+		return nil, err
+	}
+	costDate := req.CostDate
+	if costDate.IsZero() {
+		costDate = time.Now()
+	}
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" {
+		reference = "Landed Cost"
+	}
+	sourceType := strings.TrimSpace(req.SourceType)
+	if sourceType == "" {
+		sourceType = inventoryLandedCostSourceTypeDefault
+	}
+
+	products := make(map[string]*Product)
+	movementsByProduct := make(map[string][]InventoryMovement)
+	used := make(map[string]bool)
+	result := &ApplyLandedCostResult{Lines: make([]AppliedLandedCostLine, 0, len(req.Lines))}
+	for i, line := range req.Lines {
+		productID, err := normalizeRequiredInventoryUUIDString(line.ProductID, "product_id")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if line.Amount.IsNegative() {
+			return nil, fmt.Errorf("line %d: amount cannot be negative", i+1)
+		}
+		product, ok := products[productID]
+		if !ok {
+			product, err = s.repo.GetProductByID(ctx, schemaName, tenantID, productID)
+			if err != nil {
+				return nil, fmt.Errorf("get product: %w", err)
+			}
+			products[productID] = product
+			movements, err := s.repo.ListMovements(ctx, schemaName, tenantID, productID)
+			if err != nil {
+				return nil, fmt.Errorf("list movements for product %s: %w", product.Code, err)
+			}
+			movementsByProduct[productID] = movements
+		}
+		movements := movementsByProduct[productID]
+		index := findLandedCostReceiptMovement(movements, tenantID, line, used)
+		if index < 0 {
+			return nil, fmt.Errorf("line %d: no receipt movement found for product %s in %s %s", i+1, product.Code, line.ReceiptSourceType, line.ReceiptSourceID)
+		}
+		movement := &movements[index]
+		used[movement.ID] = true
+
+		inventoryAccountID := firstInventoryNonEmpty(product.InventoryAccountID, defaultInventoryAccountID)
+		if inventoryAccountID == "" {
+			return nil, fmt.Errorf("product %s has no inventory account; set inventory_account_id", product.Code)
+		}
+		onHand := landedCostOnHandQuantity(movements, tenantID, *movement)
+		applied := AppliedLandedCostLine{
+			MovementID:         movement.ID,
+			ProductID:          productID,
+			WarehouseID:        movement.WarehouseID,
+			Quantity:           movement.Quantity,
+			OnHandQuantity:     onHand,
+			IssuedQuantity:     movement.Quantity.Sub(onHand),
+			Amount:             line.Amount,
+			InventoryAmount:    line.Amount,
+			COGSAmount:         decimal.Zero,
+			PreviousUnitCost:   movement.UnitCost,
+			NewUnitCost:        movement.UnitCost,
+			InventoryAccountID: inventoryAccountID,
+		}
+		if applied.IssuedQuantity.IsPositive() && line.Amount.IsPositive() {
+			applied.COGSAmount = line.Amount.Mul(applied.IssuedQuantity).Div(movement.Quantity).Round(2)
+			applied.InventoryAmount = line.Amount.Sub(applied.COGSAmount)
+			applied.CostOfGoodsSoldAccountID = firstInventoryNonEmpty(defaultCOGSAccountID, product.PurchaseAccountID)
+			if applied.CostOfGoodsSoldAccountID == "" {
+				return nil, fmt.Errorf("product %s has issued stock and no cost of goods sold account; set cost_of_goods_sold_account_id", product.Code)
+			}
+		}
+		if line.Amount.IsPositive() {
+			totalCost := movement.TotalCost
+			if totalCost.IsZero() {
+				totalCost = movement.Quantity.Mul(movement.UnitCost)
+			}
+			totalCost = totalCost.Add(line.Amount)
+			applied.NewUnitCost = totalCost.Div(movement.Quantity).Round(8)
+			movement.UnitCost = applied.NewUnitCost
+			movement.TotalCost = totalCost
+		}
+		result.TotalAmount = result.TotalAmount.Add(applied.Amount)
+		result.InventoryAmount = result.InventoryAmount.Add(applied.InventoryAmount)
+		result.COGSAmount = result.COGSAmount.Add(applied.COGSAmount)
+		result.Lines = append(result.Lines, applied)
+	}
+
+	description := fmt.Sprintf("Landed cost: %s", reference)
+	result.AccountingLines = landedCostAccountingLines(description, result.Lines, req.OffsetLines)
+	if err := s.validateLandedCostAccounts(ctx, schemaName, tenantID, result.Lines); err != nil {
+		return nil, err
+	}
+
+	for _, line := range result.Lines {
+		if !line.Amount.IsPositive() {
+			continue
+		}
+		movements := movementsByProduct[line.ProductID]
+		for i := range movements {
+			if movements[i].ID != line.MovementID {
+				continue
+			}
+			if err := s.repo.UpdateMovementCost(ctx, schemaName, tenantID, line.MovementID, movements[i].UnitCost, movements[i].TotalCost); err != nil {
+				return nil, fmt.Errorf("update receipt movement cost: %w", err)
+			}
+		}
+	}
+	if result.TotalAmount.IsZero() {
+		return result, nil
+	}
+
+	journalLines := make([]accounting.CreateJournalEntryLineReq, 0, len(result.AccountingLines))
+	for _, line := range result.AccountingLines {
+		journalLines = append(journalLines, accounting.CreateJournalEntryLineReq{
+			AccountID:    line.AccountID,
+			Description:  line.Description,
+			DebitAmount:  line.DebitAmount,
+			CreditAmount: line.CreditAmount,
+			Currency:     line.Currency,
+			ExchangeRate: decimal.NewFromInt(1),
+		})
+	}
+	var sourceIDPtr *string
+	if sourceID != "" {
+		sourceIDPtr = &sourceID
+	}
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   costDate,
+		Description: description,
+		Reference:   reference,
+		SourceType:  sourceType,
+		SourceID:    sourceIDPtr,
+		UserID:      userID,
+		Lines:       journalLines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create landed cost journal entry: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Landed cost ledger posting"); err != nil {
+		return nil, fmt.Errorf("post landed cost journal entry: %w", err)
+	}
+	result.JournalID = entry.ID
+	result.JournalNo = entry.EntryNumber
+	return result, nil
+}
+
+// findLandedCostReceiptMovement returns the index of the first inbound
+// movement created by the receipt line that has not been used yet, preferring
+// one with the same quantity.
+func findLandedCostReceiptMovement(movements []InventoryMovement, tenantID string, line ApplyLandedCostLine, used map[string]bool) int {
+	lotNumber, serialNumber, expiryDate, _ := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate)
+	fallback := -1
+	for i, movement := range movements {
+		if movement.TenantID != tenantID || used[movement.ID] {
+			continue
+		}
+		if movement.MovementType != MovementTypeIn || !movement.Quantity.IsPositive() {
+			continue
+		}
+		if movement.SourceType != strings.TrimSpace(line.ReceiptSourceType) || movement.SourceID != strings.TrimSpace(line.ReceiptSourceID) {
+			continue
+		}
+		if line.WarehouseID != "" && movement.WarehouseID != strings.TrimSpace(line.WarehouseID) {
+			continue
+		}
+		if strings.TrimSpace(movement.LotNumber) != lotNumber ||
+			strings.TrimSpace(movement.SerialNumber) != serialNumber ||
+			strings.TrimSpace(movement.ExpiryDate) != expiryDate {
+			continue
+		}
+		if movement.Quantity.Equal(line.Quantity) {
+			return i
+		}
+		if fallback < 0 {
+			fallback = i
+		}
+	}
+	return fallback
+}
+
+// landedCostOnHandQuantity estimates how much of a receipt is still in stock.
+// Stock of the same product and lot is counted across all warehouses, so
+// transfers do not count as issues, and units received after the receipt are
+// assumed to be the ones still on hand.
+func landedCostOnHandQuantity(movements []InventoryMovement, tenantID string, receipt InventoryMovement) decimal.Decimal {
+	onHand := decimal.Zero
+	receivedLater := decimal.Zero
+	receiptDate := inventoryLotMovementDate(receipt)
+	for _, movement := range movements {
+		if movement.TenantID != tenantID {
+			continue
+		}
+		if strings.TrimSpace(movement.LotNumber) != strings.TrimSpace(receipt.LotNumber) ||
+			strings.TrimSpace(movement.SerialNumber) != strings.TrimSpace(receipt.SerialNumber) ||
+			strings.TrimSpace(movement.ExpiryDate) != strings.TrimSpace(receipt.ExpiryDate) {
+			continue
+		}
+		switch movement.MovementType {
+		case MovementTypeIn:
+			onHand = onHand.Add(movement.Quantity.Abs())
+		case MovementTypeAdjustment:
+			onHand = onHand.Add(movement.Quantity)
+		case MovementTypeOut:
+			onHand = onHand.Sub(movement.Quantity.Abs())
+		case MovementTypeTransfer:
+			if strings.TrimSpace(movement.ToWarehouseID) == "" {
+				onHand = onHand.Add(movement.Quantity.Abs())
+			}
+		}
+		if movement.ID == receipt.ID || !movement.Quantity.IsPositive() {
+			continue
+		}
+		if movement.MovementType != MovementTypeIn && movement.MovementType != MovementTypeAdjustment {
+			continue
+		}
+		movementDate := inventoryLotMovementDate(movement)
+		if movementDate.After(receiptDate) || (movementDate.Equal(receiptDate) && movement.CreatedAt.After(receipt.CreatedAt)) {
+			receivedLater = receivedLater.Add(movement.Quantity)
+		}
+	}
+	remaining := onHand.Sub(receivedLater)
+	if remaining.IsNegative() {
+		return decimal.Zero
+	}
+	if remaining.GreaterThan(receipt.Quantity) {
+		return receipt.Quantity
+	}
+	return remaining
+}
+
+func landedCostAccountingLines(description string, lines []AppliedLandedCostLine, offsets []InventoryIssueAccountingLine) []InventoryIssueAccountingLine {
+	inventoryAmounts := make(map[string]decimal.Decimal)
+	cogsAmounts := make(map[string]decimal.Decimal)
+	for _, line := range lines {
+		if !line.InventoryAmount.IsZero() {
+			inventoryAmounts[line.InventoryAccountID] = inventoryAmounts[line.InventoryAccountID].Add(line.InventoryAmount)
+		}
+		if !line.COGSAmount.IsZero() {
+			cogsAmounts[line.CostOfGoodsSoldAccountID] = cogsAmounts[line.CostOfGoodsSoldAccountID].Add(line.COGSAmount)
+		}
+	}
+
+	accountingLines := make([]InventoryIssueAccountingLine, 0, len(inventoryAmounts)+len(cogsAmounts)+len(offsets))
+	for _, accountID := range sortedLandedCostAccountIDs(inventoryAmounts) {
+		accountingLines = append(accountingLines, InventoryIssueAccountingLine{
+			Role:        inventoryIssueAccountingRoleAsset,
+			AccountID:   accountID,
+			Description: description,
+			DebitAmount: inventoryAmounts[accountID],
+			Currency:    inventoryIssueAccountingCurrencyEUR,
+		})
+	}
+	for _, accountID := range sortedLandedCostAccountIDs(cogsAmounts) {
+		accountingLines = append(accountingLines, InventoryIssueAccountingLine{
+			Role:        inventoryIssueAccountingRoleCOGS,
+			AccountID:   accountID,
+			Description: description,
+			DebitAmount: cogsAmounts[accountID],
+			Currency:    inventoryIssueAccountingCurrencyEUR,
+		})
+	}
+	for _, offset := range offsets {
+		if offset.DebitAmount.IsZero() && offset.CreditAmount.IsZero() {
+			continue
+		}
+		if offset.Description == "" {
+			offset.Description = description
+		}
+		if offset.Currency == "" {
+			offset.Currency = inventoryIssueAccountingCurrencyEUR
+		}
+		accountingLines = append(accountingLines, offset)
+	}
+	return accountingLines
+}
+
+func sortedLandedCostAccountIDs(amounts map[string]decimal.Decimal) []string {
+	accountIDs := make([]string, 0, len(amounts))
+	for accountID := range amounts {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+	return accountIDs
+}
+
+func (s *Service) validateLandedCostAccounts(ctx context.Context, schemaName, tenantID string, lines []AppliedLandedCostLine) error {
+	if s.accounts == nil {
+		return nil
+	}
+	accounts, err := s.accounts.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for landed cost accounting: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	for _, line := range lines {
+		if !line.InventoryAmount.IsZero() {
+			inventoryAccount, ok := byID[line.InventoryAccountID]
+			if !ok {
+				return fmt.Errorf("inventory account %s was not found", line.InventoryAccountID)
+			}
+			if inventoryAccount.AccountType != accounting.AccountTypeAsset {
+				return fmt.Errorf("inventory account %s must reference an ASSET account", line.InventoryAccountID)
+			}
+		}
+		if !line.COGSAmount.IsZero() {
+			cogsAccount, ok := byID[line.CostOfGoodsSoldAccountID]
+			if !ok {
+				return fmt.Errorf("cost of goods sold account %s was not found", line.CostOfGoodsSoldAccountID)
+			}
+			if cogsAccount.AccountType != accounting.AccountTypeExpense {
+				return fmt.Errorf("cost of goods sold account %s must reference an EXPENSE account", line.CostOfGoodsSoldAccountID)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+)
+
+const (
+	landedCostCOGSAccountID    = "88888888-8888-4888-8888-888888888888"
+	landedCostPayableAccountID = "99999999-9999-4999-8999-999999999999"
+	landedCostSourceID         = "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"
+)
+
+func newLandedCostTestService(t *testing.T, postErr error) (*Service, *MockRepository, *fakeInventoryLedger) {
+	t.Helper()
+	svc, repo, ledger := newReceiptTestService(postErr)
+	ledger.accounts = append(ledger.accounts,
+		accounting.Account{ID: landedCostCOGSAccountID, AccountType: accounting.AccountTypeExpense},
+		accounting.Account{ID: landedCostPayableAccountID, AccountType: accounting.AccountTypeLiability},
+	)
+	ledger.postErr = nil
+	req := receiptTestRequest()
+	req.ReceiptDate = time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	_, err := svc.ReceiveStock(context.Background(), "tenant-1", "test_schema", req)
+	require.NoError(t, err)
+	ledger.postErr = postErr
+
+	// One unit of LOT-A has been sold since the receipt.
+	repo.Movements[inventoryStockProductID] = append(repo.Movements[inventoryStockProductID], InventoryMovement{
+		ID:           "out-1",
+		TenantID:     "tenant-1",
+		ProductID:    inventoryStockProductID,
+		WarehouseID:  inventoryStockWarehouseID,
+		MovementType: MovementTypeOut,
+		Quantity:     decimal.NewFromInt(1),
+		LotNumber:    "LOT-A",
+		ExpiryDate:   "2027-06-30",
+		MovementDate: time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC),
+	})
+	return svc, repo, ledger
+}
+
+func landedCostTestRequest() *ApplyLandedCostRequest {
+	return &ApplyLandedCostRequest{
+		CostDate:                 time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Reference:                "LC-00001",
+		SourceType:               "LANDED_COST",
+		SourceID:                 landedCostSourceID,
+		CostOfGoodsSoldAccountID: landedCostCOGSAccountID,
+		UserID:                   "user-1",
+		Lines: []ApplyLandedCostLine{
+			{ReceiptSourceType: "GOODS_RECEIPT", ReceiptSourceID: receiptSourceID, ProductID: inventoryStockProductID, WarehouseID: inventoryStockWarehouseID, Quantity: decimal.NewFromInt(3), LotNumber: "LOT-A", ExpiryDate: "2027-06-30", Amount: decimal.RequireFromString("6.00")},
+			{ReceiptSourceType: "GOODS_RECEIPT", ReceiptSourceID: receiptSourceID, ProductID: inventoryStockProductID, WarehouseID: inventoryStockWarehouseID, Quantity: decimal.NewFromInt(2), LotNumber: "LOT-B", Amount: decimal.RequireFromString("4.00")},
+		},
+		OffsetLines: []InventoryIssueAccountingLine{
+			{Role: "PAYABLE", AccountID: landedCostPayableAccountID, CreditAmount: decimal.RequireFromString("10.00")},
+		},
+	}
+}
+
+func TestService_ApplyLandedCostRevaluesReceiptAndSplitsIssuedCost(t *testing.T) {
+	svc, repo, ledger := newLandedCostTestService(t, nil)
+
+	result, err := svc.ApplyLandedCost(context.Background(), "tenant-1", "test_schema", landedCostTestRequest())
+	require.NoError(t, err)
+	assert.True(t, result.TotalAmount.Equal(decimal.RequireFromString("10")))
+	assert.True(t, result.InventoryAmount.Equal(decimal.RequireFromString("8")))
+	assert.True(t, result.COGSAmount.Equal(decimal.RequireFromString("2")))
+	require.Len(t, result.Lines, 2)
+
+	lotA := result.Lines[0]
+	assert.True(t, lotA.OnHandQuantity.Equal(decimal.NewFromInt(2)))
+	assert.True(t, lotA.IssuedQuantity.Equal(decimal.NewFromInt(1)))
+	assert.True(t, lotA.PreviousUnitCost.Equal(decimal.RequireFromString("4.10")))
+	assert.True(t, lotA.NewUnitCost.Equal(decimal.RequireFromString("6.10")))
+	assert.Equal(t, landedCostCOGSAccountID, lotA.CostOfGoodsSoldAccountID)
+	lotB := result.Lines[1]
+	assert.True(t, lotB.IssuedQuantity.IsZero())
+	assert.True(t, lotB.COGSAmount.IsZero())
+	assert.True(t, lotB.NewUnitCost.Equal(decimal.RequireFromString("6.10")))
+
+	for _, movement := range repo.Movements[inventoryStockProductID] {
+		if movement.ID == lotA.MovementID {
+			assert.True(t, movement.UnitCost.Equal(decimal.RequireFromString("6.10")))
+			assert.True(t, movement.TotalCost.Equal(decimal.RequireFromString("18.30")))
+		}
+	}
+	product := *repo.Products[inventoryStockProductID]
+	movements, err := repo.ListMovements(context.Background(), "test_schema", "tenant-1", inventoryStockProductID)
+	require.NoError(t, err)
+	assert.True(t, weightedAverageInventoryUnitCost(product, movements, "tenant-1").Equal(decimal.RequireFromString("6.1")))
+	lotPosition := inventoryLotPositionFromMovements(product, movements, "tenant-1", inventoryStockWarehouseID, "LOT-A", "", "2027-06-30")
+	assert.True(t, inventoryPositionUnitCost(product, lotPosition).Equal(decimal.RequireFromString("6.1")))
+
+	require.NotNil(t, ledger.createdRequest)
+	assert.Equal(t, "LANDED_COST", ledger.createdRequest.SourceType)
+	require.Len(t, ledger.createdRequest.Lines, 3)
+	assert.Equal(t, receiptInventoryAccountID, ledger.createdRequest.Lines[0].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[0].DebitAmount.Equal(decimal.RequireFromString("8")))
+	assert.Equal(t, landedCostCOGSAccountID, ledger.createdRequest.Lines[1].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[1].DebitAmount.Equal(decimal.RequireFromString("2")))
+	assert.Equal(t, landedCostPayableAccountID, ledger.createdRequest.Lines[2].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[2].CreditAmount.Equal(decimal.RequireFromString("10")))
+	assert.Equal(t, "journal-1", result.JournalID)
+}
+
+func TestService_ApplyLandedCostTreatsLaterReceiptsAsOnHand(t *testing.T) {
+	svc, repo, _ := newLandedCostTestService(t, nil)
+	repo.Movements[inventoryStockProductID] = append(repo.Movements[inventoryStockProductID], InventoryMovement{
+		ID:           "in-later",
+		TenantID:     "tenant-1",
+		ProductID:    inventoryStockProductID,
+		WarehouseID:  inventoryStockWarehouseID,
+		MovementType: MovementTypeIn,
+		Quantity:     decimal.NewFromInt(1),
+		UnitCost:     decimal.RequireFromString("4.10"),
+		LotNumber:    "LOT-A",
+		ExpiryDate:   "2027-06-30",
+		MovementDate: time.Date(2026, time.March, 8, 0, 0, 0, 0, time.UTC),
+	})
+	req := landedCostTestRequest()
+	req.Lines = req.Lines[:1]
+	req.OffsetLines[0].CreditAmount = decimal.RequireFromString("6.00")
+
+	result, err := svc.ApplyLandedCost(context.Background(), "tenant-1", "test_schema", req)
+	require.NoError(t, err)
+	assert.True(t, result.Lines[0].OnHandQuantity.Equal(decimal.NewFromInt(2)))
+	assert.True(t, result.Lines[0].COGSAmount.Equal(decimal.RequireFromString("2")))
+}
+
+func TestService_ApplyLandedCostRollsBackWhenPostingFails(t *testing.T) {
+	svc, repo, _ := newLandedCostTestService(t, fmt.Errorf("ledger unavailable"))
+
+	_, err := svc.ApplyLandedCost(context.Background(), "tenant-1", "test_schema", landedCostTestRequest())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "post landed cost journal entry")
+	for _, movement := range repo.Movements[inventoryStockProductID] {
+		if movement.MovementType == MovementTypeIn {
+			assert.True(t, movement.UnitCost.Equal(decimal.RequireFromString("4.10")))
+		}
+	}
+}
+
+func TestService_ApplyLandedCostValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ApplyLandedCostRequest, *MockRepository)
+		want   string
+	}{
+		{name: "no lines", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) { req.Lines = nil }, want: "at least one landed cost line"},
+		{name: "missing user", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) { req.UserID = "" }, want: "user id is required"},
+		{name: "negative amount", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) {
+			req.Lines[1].Amount = decimal.NewFromInt(-1)
+		}, want: "line 2: amount cannot be negative"},
+		{name: "unknown receipt", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) {
+			req.Lines[0].ReceiptSourceID = landedCostSourceID
+		}, want: "line 1: no receipt movement found"},
+		{name: "lot already used", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) {
+			req.Lines = append(req.Lines, req.Lines[1])
+		}, want: "line 3: no receipt movement found"},
+		{name: "missing cogs account", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) {
+			req.CostOfGoodsSoldAccountID = ""
+		}, want: "has issued stock and no cost of goods sold account"},
+		{name: "cogs account type", mutate: func(req *ApplyLandedCostRequest, _ *MockRepository) {
+			req.CostOfGoodsSoldAccountID = receiptAccrualAccountID
+		}, want: "must reference an EXPENSE account"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, ledger := newLandedCostTestService(t, nil)
+			ledger.createdRequest = nil
+			req := landedCostTestRequest()
+			tt.mutate(req, repo)
+
+			_, err := svc.ApplyLandedCost(context.Background(), "tenant-1", "test_schema", req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Nil(t, ledger.createdRequest)
+		})
+	}
+}
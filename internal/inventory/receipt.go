@@ -0,0 +1,328 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	inventoryReceiptSourceTypeDefault     = "INVENTORY_RECEIPT"
+	inventoryReceiptAccountingRoleAccrual = "ACCRUAL"
+)
+
+// ReceiveStockRequest receives purchased stock into one warehouse and posts
+// its cost to inventory against an accrual account until the supplier invoice
+// is booked.
+type ReceiveStockRequest struct {
+	WarehouseID        string
+	ReceiptDate        time.Time
+	Reference          string
+	SourceType         string
+	SourceID           string
+	AccrualAccountID   string
+	InventoryAccountID string
+	Lines              []ReceiveStockLine
+	UserID             string
+}
+
+// ReceiveStockLine is one product received at a unit cost.
+type ReceiveStockLine struct {
+	ProductID    string
+	Quantity     decimal.Decimal
+	UnitCost     decimal.Decimal
+	LotNumber    string
+	SerialNumber string
+	ExpiryDate   string
+}
+
+// ReceiveStockResult summarizes the created stock movements and the receipt journal entry.
+type ReceiveStockResult struct {
+	WarehouseID string                         `json:"warehouse_id"`
+	TotalCost   decimal.Decimal                `json:"total_cost"`
+	Movements   []InventoryMovement            `json:"movements"`
+	Lines       []InventoryIssueAccountingLine `json:"accounting_lines,omitempty"`
+	JournalID   string                         `json:"journal_entry_id,omitempty"`
+	JournalNo   string                         `json:"journal_entry_number,omitempty"`
+}
+
+// ReceiveStock books inbound stock for tracked goods. Every line creates an IN
+// movement carrying the source document, and the received cost is debited to
+// each product's inventory account and credited to the accrual account in one
+// posted journal entry. Movements and the journal entry are written in one
+// transaction when the repository supports it.
+func (s *Service) ReceiveStock(ctx context.Context, tenantID, schemaName string, req *ReceiveStockRequest) (*ReceiveStockResult, error) {
+	if transactioner, ok := s.repo.(inventoryLedgerTransactioner); ok {
+		var result *ReceiveStockResult
+		err := transactioner.WithInventoryLedgerTransaction(ctx, s.ledger, func(txRepo Repository, txLedger accountingPoster) error {
+			if txLedger == nil {
+				return fmt.Errorf("accounting transaction is unavailable for receipt ledger posting")
+			}
+			txService := *s
+			txService.repo = txRepo
+			txService.accounts = txLedger
+			txService.ledger = txLedger
+			var err error
+			result, err = txService.receiveStock(ctx, tenantID, schemaName, req)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return s.receiveStock(ctx, tenantID, schemaName, req)
+}
+
+func (s *Service) receiveStock(ctx context.Context, tenantID, schemaName string, req *ReceiveStockRequest) (*ReceiveStockResult, error) {
+	if req == nil || len(req.Lines) == 0 {
+		return nil, fmt.Errorf("at least one receipt line is required")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for receipt ledger posting")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to post receipt accounting")
+	}
+	warehouseID, err := normalizeRequiredInventoryUUIDString(req.WarehouseID, "warehouse_id")
+	if err != nil {
+		return nil, err
+	}
+	accrualAccountID, err := normalizeRequiredInventoryUUIDString(req.AccrualAccountID, "accrual_account_id")
+	if err != nil {
+		return nil, err
+	}
+	defaultInventoryAccountID, err := normalizeOptionalInventoryUUIDString(req.InventoryAccountID, "inventory_account_id")
+	if err != nil {
+		return nil, err
+	}
+	sourceID, err := normalizeOptionalInventoryUUIDString(req.SourceID, "source_id")
+	if err != nil {
+		return nil, err
+	}
+	if sourceID == "" {
+		sourceID = uuid.New().String()
+	}
+	if _, err := s.repo.GetWarehouseByID(ctx, schemaName, tenantID, warehouseID); err != nil {
+		return nil, fmt.Errorf("get warehouse: %w", err)
+	}
+
+	receiptDate := req.ReceiptDate
+	if receiptDate.IsZero() {
+		receiptDate = time.Now()
+	}
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" {
+		reference = "Inventory Receipt"
+	}
+	sourceType := strings.TrimSpace(req.SourceType)
+	if sourceType == "" {
+		sourceType = inventoryReceiptSourceTypeDefault
+	}
+
+	products := make(map[string]*Product)
+	productIDs := make([]string, len(req.Lines))
+	inventoryCost := make(map[string]decimal.Decimal)
+	totalCost := decimal.Zero
+	for i, line := range req.Lines {
+		productID, err := normalizeRequiredInventoryUUIDString(line.ProductID, "product_id")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		productIDs[i] = productID
+		if line.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("line %d: quantity must be positive", i+1)
+		}
+		if line.UnitCost.IsNegative() {
+			return nil, fmt.Errorf("line %d: unit cost cannot be negative", i+1)
+		}
+		if _, _, _, err := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		product, ok := products[productID]
+		if !ok {
+			product, err = s.repo.GetProductByID(ctx, schemaName, tenantID, productID)
+			if err != nil {
+				return nil, fmt.Errorf("get product: %w", err)
+			}
+			if product.ProductType != ProductTypeGoods || !product.TrackInventory {
+				return nil, fmt.Errorf("product %s does not track inventory", product.Code)
+			}
+			products[productID] = product
+		}
+		inventoryAccountID := firstInventoryNonEmpty(product.InventoryAccountID, defaultInventoryAccountID)
+		if inventoryAccountID == "" {
+			return nil, fmt.Errorf("product %s has no inventory account; set inventory_account_id", product.Code)
+		}
+		cost := line.Quantity.Mul(line.UnitCost).Round(2)
+		inventoryCost[inventoryAccountID] = inventoryCost[inventoryAccountID].Add(cost)
+		totalCost = totalCost.Add(cost)
+	}
+	if err := s.validateInventoryReceiptAccounts(ctx, schemaName, tenantID, accrualAccountID, inventoryCost); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	movements := make([]InventoryMovement, 0, len(req.Lines))
+	levels := make(map[string]*StockLevel)
+	for i, line := range req.Lines {
+		productID := productIDs[i]
+		product := products[productID]
+		lotNumber, serialNumber, expiryDate, _ := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate)
+		movement := &InventoryMovement{
+			ID:           uuid.New().String(),
+			TenantID:     tenantID,
+			ProductID:    productID,
+			WarehouseID:  warehouseID,
+			MovementType: MovementTypeIn,
+			Quantity:     line.Quantity,
+			UnitCost:     line.UnitCost,
+			TotalCost:    line.Quantity.Mul(line.UnitCost).Round(2),
+			LotNumber:    lotNumber,
+			SerialNumber: serialNumber,
+			ExpiryDate:   expiryDate,
+			Reference:    reference,
+			SourceType:   sourceType,
+			SourceID:     sourceID,
+			MovementDate: receiptDate,
+			CreatedAt:    now,
+			CreatedBy:    userID,
+		}
+		if err := s.repo.CreateMovement(ctx, schemaName, movement); err != nil {
+			return nil, fmt.Errorf("create receipt movement: %w", err)
+		}
+		movements = append(movements, *movement)
+
+		product.CurrentStock = product.CurrentStock.Add(line.Quantity)
+		level, ok := levels[productID]
+		if !ok {
+			level, err = s.stockLevelForWarehouse(ctx, tenantID, schemaName, productID, warehouseID)
+			if err != nil {
+				return nil, fmt.Errorf("get stock level: %w", err)
+			}
+			levels[productID] = level
+		}
+		level.Quantity = level.Quantity.Add(line.Quantity)
+		level.AvailableQty = level.Quantity.Sub(level.ReservedQty)
+		level.LastUpdated = now
+	}
+	for productID, product := range products {
+		if err := s.repo.UpdateProductStock(ctx, schemaName, tenantID, productID, product.CurrentStock); err != nil {
+			return nil, fmt.Errorf("update product stock: %w", err)
+		}
+		if err := s.repo.UpsertStockLevel(ctx, schemaName, levels[productID]); err != nil {
+			return nil, fmt.Errorf("update stock level: %w", err)
+		}
+	}
+
+	result := &ReceiveStockResult{
+		WarehouseID: warehouseID,
+		TotalCost:   totalCost,
+		Movements:   movements,
+		Lines:       inventoryReceiptAccountingLines(reference, accrualAccountID, inventoryCost, totalCost),
+	}
+	if totalCost.IsZero() {
+		return result, nil
+	}
+
+	journalLines := make([]accounting.CreateJournalEntryLineReq, 0, len(result.Lines))
+	for _, line := range result.Lines {
+		journalLines = append(journalLines, accounting.CreateJournalEntryLineReq{
+			AccountID:    line.AccountID,
+			Description:  line.Description,
+			DebitAmount:  line.DebitAmount,
+			CreditAmount: line.CreditAmount,
+			Currency:     line.Currency,
+			ExchangeRate: decimal.NewFromInt(1),
+		})
+	}
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   receiptDate,
+		Description: fmt.Sprintf("Stock received: %s", reference),
+		Reference:   reference,
+		SourceType:  sourceType,
+		SourceID:    &sourceID,
+		UserID:      userID,
+		Lines:       journalLines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create inventory receipt journal entry: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Inventory receipt ledger posting"); err != nil {
+		return nil, fmt.Errorf("post inventory receipt journal entry: %w", err)
+	}
+	result.JournalID = entry.ID
+	result.JournalNo = entry.EntryNumber
+	return result, nil
+}
+
+func inventoryReceiptAccountingLines(reference, accrualAccountID string, inventoryCost map[string]decimal.Decimal, totalCost decimal.Decimal) []InventoryIssueAccountingLine {
+	accountIDs := make([]string, 0, len(inventoryCost))
+	for accountID := range inventoryCost {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+
+	description := fmt.Sprintf("Stock received: %s", reference)
+	lines := make([]InventoryIssueAccountingLine, 0, len(accountIDs)+1)
+	for _, accountID := range accountIDs {
+		if inventoryCost[accountID].IsZero() {
+			continue
+		}
+		lines = append(lines, InventoryIssueAccountingLine{
+			Role:        inventoryIssueAccountingRoleAsset,
+			AccountID:   accountID,
+			Description: description,
+			DebitAmount: inventoryCost[accountID],
+			Currency:    inventoryIssueAccountingCurrencyEUR,
+		})
+	}
+	if totalCost.IsZero() {
+		return lines
+	}
+	return append(lines, InventoryIssueAccountingLine{
+		Role:         inventoryReceiptAccountingRoleAccrual,
+		AccountID:    accrualAccountID,
+		Description:  description,
+		CreditAmount: totalCost,
+		Currency:     inventoryIssueAccountingCurrencyEUR,
+	})
+}
+
+func (s *Service) validateInventoryReceiptAccounts(ctx context.Context, schemaName, tenantID, accrualAccountID string, inventoryCost map[string]decimal.Decimal) error {
+	if s.accounts == nil {
+		return nil
+	}
+	accounts, err := s.accounts.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for receipt accounting: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	accrualAccount, ok := byID[accrualAccountID]
+	if !ok {
+		return fmt.Errorf("accrual_account_id was not found")
+	}
+	if accrualAccount.AccountType != accounting.AccountTypeLiability {
+		return fmt.Errorf("accrual_account_id must reference a LIABILITY account")
+	}
+	for accountID := range inventoryCost {
+		inventoryAccount, ok := byID[accountID]
+		if !ok {
+			return fmt.Errorf("inventory account %s was not found", accountID)
+		}
+		if inventoryAccount.AccountType != accounting.AccountTypeAsset {
+			return fmt.Errorf("inventory account %s must reference an ASSET account", accountID)
+		}
+	}
+	return nil
+}
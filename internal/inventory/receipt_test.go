@@ -0,0 +1,139 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+)
+
+const (
+	receiptAccrualAccountID   = "66666666-6666-4666-8666-666666666666"
+	receiptInventoryAccountID = "55555555-5555-4555-8555-555555555555"
+	receiptSourceID           = "77777777-7777-4777-8777-777777777777"
+)
+
+func newReceiptTestService(postErr error) (*Service, *MockRepository, *fakeInventoryLedger) {
+	repo := NewMockRepository()
+	ledger := &fakeInventoryLedger{
+		accounts: []accounting.Account{
+			{ID: receiptAccrualAccountID, AccountType: accounting.AccountTypeLiability},
+			{ID: receiptInventoryAccountID, AccountType: accounting.AccountTypeAsset},
+		},
+		postErr: postErr,
+	}
+	repo.Products[inventoryStockProductID] = &Product{
+		ID:                 inventoryStockProductID,
+		TenantID:           "tenant-1",
+		Code:               "SKU-001",
+		Name:               "Widget",
+		ProductType:        ProductTypeGoods,
+		CurrentStock:       decimal.RequireFromString("5"),
+		TrackInventory:     true,
+		InventoryAccountID: receiptInventoryAccountID,
+	}
+	repo.Warehouses[inventoryStockWarehouseID] = &Warehouse{ID: inventoryStockWarehouseID, TenantID: "tenant-1", Name: "Main", IsActive: true}
+	repo.StockLevels[inventoryStockLevelKey(inventoryStockProductID, inventoryStockWarehouseID)] = &StockLevel{
+		ID:           "sl-1",
+		TenantID:     "tenant-1",
+		ProductID:    inventoryStockProductID,
+		WarehouseID:  inventoryStockWarehouseID,
+		Quantity:     decimal.RequireFromString("5"),
+		ReservedQty:  decimal.RequireFromString("1"),
+		AvailableQty: decimal.RequireFromString("4"),
+	}
+	return NewServiceWithRepositoryAndAccounting(repo, ledger), repo, ledger
+}
+
+func receiptTestRequest() *ReceiveStockRequest {
+	return &ReceiveStockRequest{
+		WarehouseID:      inventoryStockWarehouseID,
+		Reference:        "GRN-00001",
+		SourceType:       "GOODS_RECEIPT",
+		SourceID:         receiptSourceID,
+		AccrualAccountID: receiptAccrualAccountID,
+		UserID:           "user-1",
+		Lines: []ReceiveStockLine{
+			{ProductID: inventoryStockProductID, Quantity: decimal.RequireFromString("3"), UnitCost: decimal.RequireFromString("4.10"), LotNumber: "LOT-A", ExpiryDate: "2027-06-30"},
+			{ProductID: inventoryStockProductID, Quantity: decimal.RequireFromString("2"), UnitCost: decimal.RequireFromString("4.10"), LotNumber: "LOT-B"},
+		},
+	}
+}
+
+func TestService_ReceiveStockPostsInventoryAgainstAccrual(t *testing.T) {
+	svc, repo, ledger := newReceiptTestService(nil)
+
+	result, err := svc.ReceiveStock(context.Background(), "tenant-1", "test_schema", receiptTestRequest())
+	require.NoError(t, err)
+	assert.True(t, result.TotalCost.Equal(decimal.RequireFromString("20.50")))
+	assert.Equal(t, "journal-1", result.JournalID)
+	require.Len(t, result.Movements, 2)
+	assert.Equal(t, MovementTypeIn, result.Movements[0].MovementType)
+	assert.Equal(t, "GOODS_RECEIPT", result.Movements[0].SourceType)
+	assert.Equal(t, receiptSourceID, result.Movements[0].SourceID)
+	assert.Equal(t, "LOT-A", result.Movements[0].LotNumber)
+	assert.Equal(t, "2027-06-30", result.Movements[0].ExpiryDate)
+	assert.True(t, result.Movements[0].TotalCost.Equal(decimal.RequireFromString("12.30")))
+
+	assert.True(t, repo.Products[inventoryStockProductID].CurrentStock.Equal(decimal.RequireFromString("10")))
+	level := repo.StockLevels[inventoryStockLevelKey(inventoryStockProductID, inventoryStockWarehouseID)]
+	assert.True(t, level.Quantity.Equal(decimal.RequireFromString("10")))
+	assert.True(t, level.AvailableQty.Equal(decimal.RequireFromString("9")))
+
+	require.NotNil(t, ledger.createdRequest)
+	assert.Equal(t, "GOODS_RECEIPT", ledger.createdRequest.SourceType)
+	require.Len(t, ledger.createdRequest.Lines, 2)
+	assert.Equal(t, receiptInventoryAccountID, ledger.createdRequest.Lines[0].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[0].DebitAmount.Equal(decimal.RequireFromString("20.50")))
+	assert.Equal(t, receiptAccrualAccountID, ledger.createdRequest.Lines[1].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[1].CreditAmount.Equal(decimal.RequireFromString("20.50")))
+	assert.Equal(t, []string{"journal-1"}, ledger.postedIDs)
+}
+
+func TestService_ReceiveStockRollsBackWhenPostingFails(t *testing.T) {
+	svc, repo, _ := newReceiptTestService(fmt.Errorf("ledger unavailable"))
+
+	_, err := svc.ReceiveStock(context.Background(), "tenant-1", "test_schema", receiptTestRequest())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "post inventory receipt journal entry")
+	assert.True(t, repo.Products[inventoryStockProductID].CurrentStock.Equal(decimal.RequireFromString("5")))
+	assert.Empty(t, repo.Movements[inventoryStockProductID])
+}
+
+func TestService_ReceiveStockValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ReceiveStockRequest, *MockRepository)
+		want   string
+	}{
+		{name: "no lines", mutate: func(req *ReceiveStockRequest, _ *MockRepository) { req.Lines = nil }, want: "at least one receipt line"},
+		{name: "missing user", mutate: func(req *ReceiveStockRequest, _ *MockRepository) { req.UserID = "" }, want: "user id is required"},
+		{name: "missing accrual", mutate: func(req *ReceiveStockRequest, _ *MockRepository) { req.AccrualAccountID = "" }, want: "accrual_account_id is required"},
+		{name: "accrual type", mutate: func(req *ReceiveStockRequest, _ *MockRepository) { req.AccrualAccountID = receiptInventoryAccountID }, want: "must reference a LIABILITY account"},
+		{name: "zero quantity", mutate: func(req *ReceiveStockRequest, _ *MockRepository) { req.Lines[1].Quantity = decimal.Zero }, want: "line 2: quantity must be positive"},
+		{name: "bad expiry", mutate: func(req *ReceiveStockRequest, _ *MockRepository) { req.Lines[0].ExpiryDate = "30.06.2027" }, want: "expiry_date must use YYYY-MM-DD"},
+		{name: "untracked product", mutate: func(_ *ReceiveStockRequest, repo *MockRepository) {
+			repo.Products[inventoryStockProductID].TrackInventory = false
+		}, want: "does not track inventory"},
+		{name: "missing inventory account", mutate: func(_ *ReceiveStockRequest, repo *MockRepository) {
+			repo.Products[inventoryStockProductID].InventoryAccountID = ""
+		}, want: "has no inventory account"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newReceiptTestService(nil)
+			req := receiptTestRequest()
+			tt.mutate(req, repo)
+
+			_, err := svc.ReceiveStock(context.Background(), "tenant-1", "test_schema", req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, repo.Movements[inventoryStockProductID])
+		})
+	}
+}
@@ -49,6 +49,8 @@ type Repository interface {
 	// Movements
 	CreateMovement(ctx context.Context, schemaName string, movement *InventoryMovement) error
 	ListMovements(ctx context.Context, schemaName, tenantID, productID string) ([]InventoryMovement, error)
+	HasSourceMovements(ctx context.Context, schemaName, tenantID, sourceType, sourceID string) (bool, error)
+	UpdateMovementCost(ctx context.Context, schemaName, tenantID, movementID string, unitCost, totalCost decimal.Decimal) error
 
 	// Stock updates
 	UpdateProductStock(ctx context.Context, schemaName, tenantID, productID string, newStock decimal.Decimal) error
@@ -612,6 +614,41 @@ func (r *GORMRepository) ListMovements(ctx context.Context, schemaName, tenantID
 	return movements, nil
 }
 
+// HasSourceMovements reports whether any inventory movement was recorded for a source document
+func (r *GORMRepository) HasSourceMovements(ctx context.Context, schemaName, tenantID, sourceType, sourceID string) (bool, error) {
+	db, err := r.tenantTable(ctx, schemaName, "inventory_movements")
+	if err != nil {
+		return false, err
+	}
+
+	var count int64
+	if err := db.Where("tenant_id = ? AND source_type = ? AND source_id = ?", tenantID, sourceType, sourceID).
+		Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// UpdateMovementCost replaces the unit and total cost of an inventory movement
+func (r *GORMRepository) UpdateMovementCost(ctx context.Context, schemaName, tenantID, movementID string, unitCost, totalCost decimal.Decimal) error {
+	db, err := r.tenantTable(ctx, schemaName, "inventory_movements")
+	if err != nil {
+		return err
+	}
+	result := db.Where("id = ? AND tenant_id = ?", movementID, tenantID).
+		Updates(map[string]interface{}{
+			"unit_cost":  unitCost,
+			"total_cost": totalCost,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("inventory movement not found")
+	}
+	return nil
+}
+
 // UpdateProductStock updates the current stock of a product
 func (r *GORMRepository) UpdateProductStock(ctx context.Context, schemaName, tenantID, productID string, newStock decimal.Decimal) error {
 	db, err := r.tenantTable(ctx, schemaName, "products")
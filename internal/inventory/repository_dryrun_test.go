@@ -437,6 +437,10 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "dry run mode unsupported")
 
+	found, err := repo.HasSourceMovements(ctx, schemaName, tenantID, movement.SourceType, movement.SourceID)
+	require.NoError(t, err)
+	assert.False(t, found)
+
 	require.NoError(t, repo.UpdateProductStock(ctx, schemaName, tenantID, product.ID, decimal.NewFromInt(12)))
 
 	require.NoError(t, repo.WithInventoryLedgerTransaction(ctx, nil, func(txRepo Repository, ledger accountingPoster) error {
@@ -637,6 +641,14 @@ func TestGORMRepositoryDryRunInvalidSchema(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "HasSourceMovements",
+			run: func(t *testing.T) error {
+				got, err := repo.HasSourceMovements(ctx, invalidSchema, tenantID, movement.SourceType, movement.SourceID)
+				assert.False(t, got)
+				return err
+			},
+		},
 		{
 			name: "UpdateProductStock",
 			run: func(t *testing.T) error {
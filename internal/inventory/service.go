@@ -12,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
 )
 
 type accountingLister interface {
@@ -68,6 +69,18 @@ func NewService(db *pgxpool.Pool) *Service {
 	}
 }
 
+// NewServiceWithGORM creates an inventory service backed by an existing GORM
+// handle that also posts its journals through that handle, so stock movements
+// can join a caller's transaction.
+func NewServiceWithGORM(db *gorm.DB) *Service {
+	ledger := accounting.NewServiceWithRepository(accounting.NewGORMRepository(db))
+	return &Service{
+		repo:     &GORMRepository{db: db},
+		accounts: ledger,
+		ledger:   ledger,
+	}
+}
+
 // NewServiceWithRepository creates a new inventory service with a custom repository
 func NewServiceWithRepository(repo Repository) *Service {
 	return NewServiceWithRepositoryAndAccounting(repo, nil)
@@ -970,10 +983,19 @@ type inventoryLotKey struct {
 }
 
 type inventoryLotAccumulator struct {
-	product      Product
-	line         InventoryLotLine
-	costQuantity decimal.Decimal
-	costTotal    decimal.Decimal
+	product       Product
+	line          InventoryLotLine
+	costQuantity  decimal.Decimal
+	costTotal     decimal.Decimal
+	inflows       []inventoryLotInflow
+	lastIssueDate time.Time
+}
+
+// inventoryLotInflow is one quantity that entered a lot position, kept so
+// the aging report can date the stock still on hand.
+type inventoryLotInflow struct {
+	date     time.Time
+	quantity decimal.Decimal
 }
 
 func addInventoryLotReportMovement(positions map[inventoryLotKey]*inventoryLotAccumulator, product Product, warehouseByID map[string]Warehouse, movement InventoryMovement, warehouseIDFilter string) {
@@ -1048,8 +1070,12 @@ func addInventoryLotReportQuantity(positions map[inventoryLotKey]*inventoryLotAc
 	}
 
 	if quantity.LessThanOrEqual(decimal.Zero) {
+		if movement.MovementType == MovementTypeOut && movementDate.After(position.lastIssueDate) {
+			position.lastIssueDate = movementDate
+		}
 		return
 	}
+	position.inflows = append(position.inflows, inventoryLotInflow{date: movementDate, quantity: quantity})
 
 	movementCost := movement.TotalCost
 	if movementCost.IsZero() && movement.UnitCost.GreaterThan(decimal.Zero) {
@@ -2145,3 +2171,13 @@ func (s *Service) GetMovements(ctx context.Context, tenantID, schemaName, produc
 	}
 	return movements, nil
 }
+
+// HasSourceMovements reports whether stock was already moved for a source
+// document, such as the kit components issued for a sales invoice.
+func (s *Service) HasSourceMovements(ctx context.Context, tenantID, schemaName, sourceType, sourceID string) (bool, error) {
+	found, err := s.repo.HasSourceMovements(ctx, schemaName, tenantID, sourceType, sourceID)
+	if err != nil {
+		return false, fmt.Errorf("check source movements: %w", err)
+	}
+	return found, nil
+}
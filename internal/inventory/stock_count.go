@@ -0,0 +1,348 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	inventoryStockCountSourceTypeDefault      = "STOCK_COUNT"
+	inventoryStockCountAccountingRoleVariance = "STOCK_VARIANCE"
+)
+
+// PostStockCountRequest books the differences found by a stock count in one
+// warehouse and posts their value against a stock variance account.
+type PostStockCountRequest struct {
+	WarehouseID        string
+	CountDate          time.Time
+	Reference          string
+	SourceType         string
+	SourceID           string
+	VarianceAccountID  string
+	InventoryAccountID string
+	Lines              []PostStockCountLine
+	UserID             string
+}
+
+// PostStockCountLine is one counted difference. Quantity is the counted
+// quantity minus the expected quantity, so shortages are negative.
+type PostStockCountLine struct {
+	ProductID    string
+	Quantity     decimal.Decimal
+	UnitCost     decimal.Decimal
+	LotNumber    string
+	SerialNumber string
+	ExpiryDate   string
+}
+
+// PostStockCountResult summarizes the stock count movements and the variance journal entry.
+type PostStockCountResult struct {
+	WarehouseID   string                         `json:"warehouse_id"`
+	SurplusValue  decimal.Decimal                `json:"surplus_value"`
+	ShortageValue decimal.Decimal                `json:"shortage_value"`
+	NetVariance   decimal.Decimal                `json:"net_variance"`
+	Movements     []InventoryMovement            `json:"movements"`
+	Lines         []InventoryIssueAccountingLine `json:"accounting_lines,omitempty"`
+	JournalID     string                         `json:"journal_entry_id,omitempty"`
+	JournalNo     string                         `json:"journal_entry_number,omitempty"`
+}
+
+// PostStockCountVariances books stock count differences for tracked goods.
+// Surpluses create IN movements and shortages create OUT movements, and the
+// net value per inventory account is posted against the variance account in
+// one journal entry. Movements and the journal entry are written in one
+// transaction when the repository supports it.
+func (s *Service) PostStockCountVariances(ctx context.Context, tenantID, schemaName string, req *PostStockCountRequest) (*PostStockCountResult, error) {
+	if transactioner, ok := s.repo.(inventoryLedgerTransactioner); ok {
+		var result *PostStockCountResult
+		err := transactioner.WithInventoryLedgerTransaction(ctx, s.ledger, func(txRepo Repository, txLedger accountingPoster) error {
+			if txLedger == nil {
+				return fmt.Errorf("accounting transaction is unavailable for stock count ledger posting")
+			}
+			txService := *s
+			txService.repo = txRepo
+			txService.accounts = txLedger
+			txService.ledger = txLedger
+			var err error
+			result, err = txService.postStockCountVariances(ctx, tenantID, schemaName, req)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+	return s.postStockCountVariances(ctx, tenantID, schemaName, req)
+}
+
+func (s *Service) postStockCountVariances(ctx context.Context, tenantID, schemaName string, req *PostStockCountRequest) (*PostStockCountResult, error) {
+	if req == nil || len(req.Lines) == 0 {
+		return nil, fmt.Errorf("at least one stock count line is required")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for stock count ledger posting")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to post stock count accounting")
+	}
+	warehouseID, err := normalizeRequiredInventoryUUIDString(req.WarehouseID, "warehouse_id")
+	if err != nil {
+		return nil, err
+	}
+	varianceAccountID, err := normalizeRequiredInventoryUUIDString(req.VarianceAccountID, "variance_account_id")
+	if err != nil {
+		return nil, err
+	}
+	defaultInventoryAccountID, err := normalizeOptionalInventoryUUIDString(req.InventoryAccountID, "inventory_account_id")
+	if err != nil {
+		return nil, err
+	}
+	sourceID, err := normalizeOptionalInventoryUUIDString(req.SourceID, "source_id")
+	if err != nil {
+		return nil, err
+	}
+	if sourceID == "" {
+		sourceID = uuid.New().String()
+	}
+	if _, err := s.repo.GetWarehouseByID(ctx, schemaName, tenantID, warehouseID); err != nil {
+		return nil, fmt.Errorf("get warehouse: %w", err)
+	}
+
+	countDate := req.CountDate
+	if countDate.IsZero() {
+		countDate = time.Now()
+	}
+	reference := strings.TrimSpace(req.Reference)
+	if reference == "" {
+		reference = "Stock Count"
+	}
+	sourceType := strings.TrimSpace(req.SourceType)
+	if sourceType == "" {
+		sourceType = inventoryStockCountSourceTypeDefault
+	}
+
+	products := make(map[string]*Product)
+	productIDs := make([]string, len(req.Lines))
+	inventoryValue := make(map[string]decimal.Decimal)
+	result := &PostStockCountResult{
+		WarehouseID:   warehouseID,
+		SurplusValue:  decimal.Zero,
+		ShortageValue: decimal.Zero,
+		NetVariance:   decimal.Zero,
+	}
+	for i, line := range req.Lines {
+		productID, err := normalizeRequiredInventoryUUIDString(line.ProductID, "product_id")
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		productIDs[i] = productID
+		if line.Quantity.IsZero() {
+			return nil, fmt.Errorf("line %d: quantity difference cannot be zero", i+1)
+		}
+		if line.UnitCost.IsNegative() {
+			return nil, fmt.Errorf("line %d: unit cost cannot be negative", i+1)
+		}
+		if _, _, _, err := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate); err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		product, ok := products[productID]
+		if !ok {
+			product, err = s.repo.GetProductByID(ctx, schemaName, tenantID, productID)
+			if err != nil {
+				return nil, fmt.Errorf("get product: %w", err)
+			}
+			if product.ProductType != ProductTypeGoods || !product.TrackInventory {
+				return nil, fmt.Errorf("product %s does not track inventory", product.Code)
+			}
+			products[productID] = product
+		}
+		inventoryAccountID := firstInventoryNonEmpty(product.InventoryAccountID, defaultInventoryAccountID)
+		if inventoryAccountID == "" {
+			return nil, fmt.Errorf("product %s has no inventory account; set inventory_account_id", product.Code)
+		}
+		value := line.Quantity.Mul(line.UnitCost).Round(2)
+		inventoryValue[inventoryAccountID] = inventoryValue[inventoryAccountID].Add(value)
+		if value.IsNegative() {
+			result.ShortageValue = result.ShortageValue.Add(value.Neg())
+		} else {
+			result.SurplusValue = result.SurplusValue.Add(value)
+		}
+		result.NetVariance = result.NetVariance.Add(value)
+	}
+	if err := s.validateInventoryStockCountAccounts(ctx, schemaName, tenantID, varianceAccountID, inventoryValue); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	result.Movements = make([]InventoryMovement, 0, len(req.Lines))
+	levels := make(map[string]*StockLevel)
+	for i, line := range req.Lines {
+		productID := productIDs[i]
+		product := products[productID]
+		level, ok := levels[productID]
+		if !ok {
+			level, err = s.stockLevelForWarehouse(ctx, tenantID, schemaName, productID, warehouseID)
+			if err != nil {
+				return nil, fmt.Errorf("get stock level: %w", err)
+			}
+			levels[productID] = level
+		}
+		level.Quantity = level.Quantity.Add(line.Quantity)
+		if level.Quantity.IsNegative() {
+			return nil, fmt.Errorf("line %d: stock count would make warehouse stock of product %s negative", i+1, product.Code)
+		}
+		if level.Quantity.LessThan(level.ReservedQty) {
+			return nil, fmt.Errorf("line %d: stock count would reduce warehouse stock of product %s below reserved quantity", i+1, product.Code)
+		}
+		level.AvailableQty = level.Quantity.Sub(level.ReservedQty)
+		level.LastUpdated = now
+		product.CurrentStock = product.CurrentStock.Add(line.Quantity)
+		if product.CurrentStock.IsNegative() {
+			return nil, fmt.Errorf("line %d: stock count would make product %s stock negative", i+1, product.Code)
+		}
+
+		movementType := MovementTypeIn
+		if line.Quantity.IsNegative() {
+			movementType = MovementTypeOut
+		}
+		lotNumber, serialNumber, expiryDate, _ := normalizeMovementTrackingMetadataValues(line.LotNumber, line.SerialNumber, line.ExpiryDate)
+		movement := &InventoryMovement{
+			ID:           uuid.New().String(),
+			TenantID:     tenantID,
+			ProductID:    productID,
+			WarehouseID:  warehouseID,
+			MovementType: movementType,
+			Quantity:     line.Quantity.Abs(),
+			UnitCost:     line.UnitCost,
+			TotalCost:    line.Quantity.Abs().Mul(line.UnitCost).Round(2),
+			LotNumber:    lotNumber,
+			SerialNumber: serialNumber,
+			ExpiryDate:   expiryDate,
+			Reference:    reference,
+			SourceType:   sourceType,
+			SourceID:     sourceID,
+			MovementDate: countDate,
+			CreatedAt:    now,
+			CreatedBy:    userID,
+		}
+		if err := s.repo.CreateMovement(ctx, schemaName, movement); err != nil {
+			return nil, fmt.Errorf("create stock count movement: %w", err)
+		}
+		result.Movements = append(result.Movements, *movement)
+	}
+	for productID, product := range products {
+		if err := s.repo.UpdateProductStock(ctx, schemaName, tenantID, productID, product.CurrentStock); err != nil {
+			return nil, fmt.Errorf("update product stock: %w", err)
+		}
+		if err := s.repo.UpsertStockLevel(ctx, schemaName, levels[productID]); err != nil {
+			return nil, fmt.Errorf("update stock level: %w", err)
+		}
+	}
+
+	result.Lines = inventoryStockCountAccountingLines(reference, varianceAccountID, inventoryValue, result.NetVariance)
+	if len(result.Lines) == 0 {
+		return result, nil
+	}
+
+	journalLines := make([]accounting.CreateJournalEntryLineReq, 0, len(result.Lines))
+	for _, line := range result.Lines {
+		journalLines = append(journalLines, accounting.CreateJournalEntryLineReq{
+			AccountID:    line.AccountID,
+			Description:  line.Description,
+			DebitAmount:  line.DebitAmount,
+			CreditAmount: line.CreditAmount,
+			Currency:     line.Currency,
+			ExchangeRate: decimal.NewFromInt(1),
+		})
+	}
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   countDate,
+		Description: fmt.Sprintf("Stock count variance: %s", reference),
+		Reference:   reference,
+		SourceType:  sourceType,
+		SourceID:    &sourceID,
+		UserID:      userID,
+		Lines:       journalLines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create stock count journal entry: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Stock count variance ledger posting"); err != nil {
+		return nil, fmt.Errorf("post stock count journal entry: %w", err)
+	}
+	result.JournalID = entry.ID
+	result.JournalNo = entry.EntryNumber
+	return result, nil
+}
+
+func inventoryStockCountAccountingLines(reference, varianceAccountID string, inventoryValue map[string]decimal.Decimal, netVariance decimal.Decimal) []InventoryIssueAccountingLine {
+	accountIDs := make([]string, 0, len(inventoryValue))
+	for accountID := range inventoryValue {
+		accountIDs = append(accountIDs, accountID)
+	}
+	sort.Strings(accountIDs)
+
+	description := fmt.Sprintf("Stock count variance: %s", reference)
+	lines := make([]InventoryIssueAccountingLine, 0, len(accountIDs)+1)
+	for _, accountID := range accountIDs {
+		lines = appendInventoryStockCountLine(lines, inventoryIssueAccountingRoleAsset, accountID, description, inventoryValue[accountID])
+	}
+	return appendInventoryStockCountLine(lines, inventoryStockCountAccountingRoleVariance, varianceAccountID, description, netVariance.Neg())
+}
+
+func appendInventoryStockCountLine(lines []InventoryIssueAccountingLine, role, accountID, description string, amount decimal.Decimal) []InventoryIssueAccountingLine {
+	if amount.IsZero() {
+		return lines
+	}
+	line := InventoryIssueAccountingLine{
+		Role:        role,
+		AccountID:   accountID,
+		Description: description,
+		Currency:    inventoryIssueAccountingCurrencyEUR,
+	}
+	if amount.IsPositive() {
+		line.DebitAmount = amount
+	} else {
+		line.CreditAmount = amount.Neg()
+	}
+	return append(lines, line)
+}
+
+func (s *Service) validateInventoryStockCountAccounts(ctx context.Context, schemaName, tenantID, varianceAccountID string, inventoryValue map[string]decimal.Decimal) error {
+	if s.accounts == nil {
+		return nil
+	}
+	accounts, err := s.accounts.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for stock count accounting: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	varianceAccount, ok := byID[varianceAccountID]
+	if !ok {
+		return fmt.Errorf("variance_account_id was not found")
+	}
+	if varianceAccount.AccountType != accounting.AccountTypeExpense {
+		return fmt.Errorf("variance_account_id must reference an EXPENSE account")
+	}
+	for accountID := range inventoryValue {
+		inventoryAccount, ok := byID[accountID]
+		if !ok {
+			return fmt.Errorf("inventory account %s was not found", accountID)
+		}
+		if inventoryAccount.AccountType != accounting.AccountTypeAsset {
+			return fmt.Errorf("inventory account %s must reference an ASSET account", accountID)
+		}
+	}
+	return nil
+}
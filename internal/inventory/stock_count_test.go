@@ -0,0 +1,104 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+)
+
+const stockCountVarianceAccountID = "88888888-8888-4888-8888-888888888888"
+
+func newStockCountTestService(postErr error) (*Service, *MockRepository, *fakeInventoryLedger) {
+	svc, repo, ledger := newReceiptTestService(postErr)
+	ledger.accounts = append(ledger.accounts, accounting.Account{ID: stockCountVarianceAccountID, AccountType: accounting.AccountTypeExpense})
+	return svc, repo, ledger
+}
+
+func stockCountTestRequest() *PostStockCountRequest {
+	return &PostStockCountRequest{
+		WarehouseID:       inventoryStockWarehouseID,
+		Reference:         "SC-00001",
+		SourceType:        "STOCK_COUNT",
+		SourceID:          receiptSourceID,
+		VarianceAccountID: stockCountVarianceAccountID,
+		UserID:            "user-1",
+		Lines: []PostStockCountLine{
+			{ProductID: inventoryStockProductID, Quantity: decimal.RequireFromString("-3"), UnitCost: decimal.RequireFromString("4"), LotNumber: "LOT-A"},
+			{ProductID: inventoryStockProductID, Quantity: decimal.RequireFromString("1"), UnitCost: decimal.RequireFromString("4"), LotNumber: "LOT-B"},
+		},
+	}
+}
+
+func TestService_PostStockCountVariancesPostsNetDifference(t *testing.T) {
+	svc, repo, ledger := newStockCountTestService(nil)
+
+	result, err := svc.PostStockCountVariances(context.Background(), "tenant-1", "test_schema", stockCountTestRequest())
+	require.NoError(t, err)
+	assert.True(t, result.ShortageValue.Equal(decimal.RequireFromString("12")))
+	assert.True(t, result.SurplusValue.Equal(decimal.RequireFromString("4")))
+	assert.True(t, result.NetVariance.Equal(decimal.RequireFromString("-8")))
+	require.Len(t, result.Movements, 2)
+	assert.Equal(t, MovementTypeOut, result.Movements[0].MovementType)
+	assert.True(t, result.Movements[0].Quantity.Equal(decimal.RequireFromString("3")))
+	assert.Equal(t, MovementTypeIn, result.Movements[1].MovementType)
+	assert.Equal(t, "STOCK_COUNT", result.Movements[1].SourceType)
+
+	assert.True(t, repo.Products[inventoryStockProductID].CurrentStock.Equal(decimal.RequireFromString("3")))
+	level := repo.StockLevels[inventoryStockLevelKey(inventoryStockProductID, inventoryStockWarehouseID)]
+	assert.True(t, level.Quantity.Equal(decimal.RequireFromString("3")))
+	assert.True(t, level.AvailableQty.Equal(decimal.RequireFromString("2")))
+
+	require.NotNil(t, ledger.createdRequest)
+	require.Len(t, ledger.createdRequest.Lines, 2)
+	assert.Equal(t, receiptInventoryAccountID, ledger.createdRequest.Lines[0].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[0].CreditAmount.Equal(decimal.RequireFromString("8")))
+	assert.Equal(t, stockCountVarianceAccountID, ledger.createdRequest.Lines[1].AccountID)
+	assert.True(t, ledger.createdRequest.Lines[1].DebitAmount.Equal(decimal.RequireFromString("8")))
+	assert.Equal(t, "journal-1", result.JournalID)
+}
+
+func TestService_PostStockCountVariancesRollsBackWhenPostingFails(t *testing.T) {
+	svc, repo, _ := newStockCountTestService(fmt.Errorf("ledger unavailable"))
+
+	_, err := svc.PostStockCountVariances(context.Background(), "tenant-1", "test_schema", stockCountTestRequest())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "post stock count journal entry")
+	assert.True(t, repo.Products[inventoryStockProductID].CurrentStock.Equal(decimal.RequireFromString("5")))
+	assert.Empty(t, repo.Movements[inventoryStockProductID])
+}
+
+func TestService_PostStockCountVariancesValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*PostStockCountRequest)
+		want   string
+	}{
+		{name: "no lines", mutate: func(req *PostStockCountRequest) { req.Lines = nil }, want: "at least one stock count line"},
+		{name: "missing variance account", mutate: func(req *PostStockCountRequest) { req.VarianceAccountID = "" }, want: "variance_account_id is required"},
+		{name: "variance account type", mutate: func(req *PostStockCountRequest) { req.VarianceAccountID = receiptAccrualAccountID }, want: "must reference an EXPENSE account"},
+		{name: "zero difference", mutate: func(req *PostStockCountRequest) { req.Lines[0].Quantity = decimal.Zero }, want: "line 1: quantity difference cannot be zero"},
+		{name: "below reserved", mutate: func(req *PostStockCountRequest) { req.Lines[0].Quantity = decimal.RequireFromString("-5") }, want: "below reserved quantity"},
+		{name: "negative stock", mutate: func(req *PostStockCountRequest) {
+			req.Lines = req.Lines[:1]
+			req.Lines[0].Quantity = decimal.RequireFromString("-6")
+		}, want: "negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo, _ := newStockCountTestService(nil)
+			req := stockCountTestRequest()
+			tt.mutate(req)
+
+			_, err := svc.PostStockCountVariances(context.Background(), "tenant-1", "test_schema", req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, repo.Movements[inventoryStockProductID])
+		})
+	}
+}
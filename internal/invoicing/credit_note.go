@@ -0,0 +1,200 @@
+package invoicing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// ErrCreditExceedsRemaining is returned when a credit note would credit more
+// of an invoice line than is left after earlier credit notes.
+var ErrCreditExceedsRemaining = errors.New("credited quantity exceeds remaining quantity")
+
+type creditNoteCreator interface {
+	CreateCreditNote(ctx context.Context, schemaName, tenantID string, creditNote *Invoice, offset decimal.Decimal) error
+}
+
+// CreateCreditNote credits selected lines and quantities of an issued invoice.
+// Credit note lines copy prices, discounts and VAT settings from the original
+// lines so the credit is reported in the same VAT rows, and the credit amount
+// is offset against the original invoice's open balance.
+func (s *Service) CreateCreditNote(ctx context.Context, tenantID, schemaName, invoiceID string, req *CreateCreditNoteRequest) (*Invoice, error) {
+	original, err := s.repo.GetByID(ctx, schemaName, tenantID, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("get invoice: %w", err)
+	}
+	switch original.InvoiceType {
+	case InvoiceTypeSales, InvoiceTypePurchase:
+	default:
+		return nil, fmt.Errorf("only sales and purchase invoices can be credited")
+	}
+	if original.Status == StatusDraft || original.Status == StatusVoided {
+		return nil, fmt.Errorf("cannot credit invoice in %s status", original.Status)
+	}
+
+	credited, err := s.creditedQuantities(ctx, tenantID, schemaName, original.ID)
+	if err != nil {
+		return nil, err
+	}
+	remaining := make(map[string]decimal.Decimal, len(original.Lines))
+	for _, line := range original.Lines {
+		remaining[line.ID] = line.Quantity.Sub(credited[line.ID])
+	}
+
+	selections := req.Lines
+	if len(selections) == 0 {
+		for _, line := range original.Lines {
+			if remaining[line.ID].IsPositive() {
+				selections = append(selections, CreateCreditNoteLineRequest{OriginalLineID: line.ID, Quantity: remaining[line.ID]})
+			}
+		}
+		if len(selections) == 0 {
+			return nil, fmt.Errorf("invoice is already fully credited")
+		}
+	}
+
+	issueDate := req.IssueDate
+	if issueDate.IsZero() {
+		issueDate = time.Now()
+	}
+	originalID := original.ID
+	creditNote := &Invoice{
+		ID:                    uuid.New().String(),
+		TenantID:              tenantID,
+		InvoiceType:           InvoiceTypeCreditNote,
+		ContactID:             original.ContactID,
+		IssueDate:             issueDate,
+		DueDate:               issueDate,
+		Currency:              original.Currency,
+		ExchangeRate:          original.ExchangeRate,
+		Status:                StatusSent,
+		Reference:             req.Reference,
+		Notes:                 req.Notes,
+		AmountPaid:            decimal.Zero,
+		OriginalInvoiceID:     &originalID,
+		OriginalInvoiceNumber: original.InvoiceNumber,
+		CreatedAt:             time.Now(),
+		CreatedBy:             req.UserID,
+		UpdatedAt:             time.Now(),
+	}
+	if creditNote.Reference == "" {
+		creditNote.Reference = original.InvoiceNumber
+	}
+
+	for i, selection := range selections {
+		originalLine := findInvoiceLine(original.Lines, strings.TrimSpace(selection.OriginalLineID))
+		if originalLine == nil {
+			return nil, fmt.Errorf("line %d: original line %q not found on invoice", i+1, selection.OriginalLineID)
+		}
+		if !selection.Quantity.IsPositive() {
+			return nil, fmt.Errorf("line %d: credited quantity must be positive", i+1)
+		}
+		if selection.Quantity.GreaterThan(remaining[originalLine.ID]) {
+			return nil, fmt.Errorf("line %d: credited quantity %s exceeds remaining quantity %s", i+1, selection.Quantity, remaining[originalLine.ID])
+		}
+		remaining[originalLine.ID] = remaining[originalLine.ID].Sub(selection.Quantity)
+
+		originalLineID := originalLine.ID
+		creditNote.Lines = append(creditNote.Lines, InvoiceLine{
+			ID:                  uuid.New().String(),
+			TenantID:            tenantID,
+			LineNumber:          i + 1,
+			Description:         originalLine.Description,
+			Quantity:            selection.Quantity,
+			Unit:                originalLine.Unit,
+			UnitPrice:           originalLine.UnitPrice,
+			DiscountPercent:     originalLine.DiscountPercent,
+			VATRate:             originalLine.VATRate,
+			VATTreatment:        originalLine.VATTreatment,
+			AccountID:           originalLine.AccountID,
+			ProductID:           originalLine.ProductID,
+			OriginalLineID:      &originalLineID,
+			PrepaymentInvoiceID: originalLine.PrepaymentInvoiceID,
+		})
+	}
+
+	creditNote.Calculate()
+	if err := creditNote.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	// Only the open balance can be offset; any excess stays on the credit
+	// note as an amount owed back to the counterparty.
+	offset := decimal.Min(creditNote.Total, decimal.Max(original.AmountDue(), decimal.Zero))
+	creditNote.AmountPaid = offset
+	if offset.GreaterThanOrEqual(creditNote.Total) {
+		creditNote.Status = StatusPaid
+	} else if offset.IsPositive() {
+		creditNote.Status = StatusPartiallyPaid
+	}
+
+	number, err := s.repo.GenerateNumber(ctx, schemaName, tenantID, InvoiceTypeCreditNote)
+	if err != nil {
+		return nil, fmt.Errorf("generate invoice number: %w", err)
+	}
+	creditNote.InvoiceNumber = number
+
+	if creator, ok := s.repo.(creditNoteCreator); ok {
+		if err := creator.CreateCreditNote(ctx, schemaName, tenantID, creditNote, offset); err != nil {
+			return nil, fmt.Errorf("create credit note: %w", err)
+		}
+		return creditNote, nil
+	}
+
+	if err := s.repo.Create(ctx, schemaName, creditNote); err != nil {
+		return nil, fmt.Errorf("create credit note: %w", err)
+	}
+	if offset.IsPositive() {
+		if err := s.RecordPayment(ctx, tenantID, schemaName, original.ID, offset); err != nil {
+			return nil, fmt.Errorf("offset original invoice: %w", err)
+		}
+	}
+	return creditNote, nil
+}
+
+// creditedQuantities sums quantities already credited per original line by
+// non-voided credit notes.
+func (s *Service) creditedQuantities(ctx context.Context, tenantID, schemaName, invoiceID string) (map[string]decimal.Decimal, error) {
+	creditNotes, err := s.repo.List(ctx, schemaName, tenantID, &InvoiceFilter{
+		InvoiceType:       InvoiceTypeCreditNote,
+		OriginalInvoiceID: invoiceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list credit notes: %w", err)
+	}
+
+	credited := make(map[string]decimal.Decimal)
+	for _, creditNote := range creditNotes {
+		if creditNote.Status == StatusVoided || creditNote.OriginalInvoiceID == nil || *creditNote.OriginalInvoiceID != invoiceID {
+			continue
+		}
+		lines := creditNote.Lines
+		if len(lines) == 0 {
+			loaded, err := s.repo.GetByID(ctx, schemaName, tenantID, creditNote.ID)
+			if err != nil {
+				return nil, fmt.Errorf("get credit note: %w", err)
+			}
+			lines = loaded.Lines
+		}
+		for _, line := range lines {
+			if line.OriginalLineID != nil {
+				credited[*line.OriginalLineID] = credited[*line.OriginalLineID].Add(line.Quantity)
+			}
+		}
+	}
+	return credited, nil
+}
+
+func findInvoiceLine(lines []InvoiceLine, lineID string) *InvoiceLine {
+	for i := range lines {
+		if lines[i].ID == lineID {
+			return &lines[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package invoicing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func creditNoteTestInvoice() *Invoice {
+	invoice := &Invoice{
+		ID:            "inv-1",
+		TenantID:      "tenant-1",
+		InvoiceNumber: "INV-00001",
+		InvoiceType:   InvoiceTypeSales,
+		ContactID:     "contact-1",
+		IssueDate:     time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:       time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+		Currency:      "EUR",
+		ExchangeRate:  decimal.NewFromInt(1),
+		Status:        StatusSent,
+		Lines: []InvoiceLine{
+			{ID: "line-1", TenantID: "tenant-1", LineNumber: 1, Description: "Consulting", Quantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(100), VATRate: decimal.NewFromInt(24)},
+			{ID: "line-2", TenantID: "tenant-1", LineNumber: 2, Description: "Import service", Quantity: decimal.NewFromInt(2), UnitPrice: decimal.NewFromInt(50), VATRate: decimal.NewFromInt(24), VATTreatment: VATTreatmentReverseCharge},
+		},
+	}
+	invoice.Calculate()
+	return invoice
+}
+
+func TestServiceCreateCreditNotePartialQuantities(t *testing.T) {
+	repo := NewMockRepository()
+	original := creditNoteTestInvoice()
+	repo.invoices[original.ID] = original
+	service := NewServiceWithRepository(repo, nil)
+
+	creditNote, err := service.CreateCreditNote(context.Background(), "tenant-1", "tenant_schema", "inv-1", &CreateCreditNoteRequest{
+		IssueDate: time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		Notes:     "Returned hours",
+		Lines:     []CreateCreditNoteLineRequest{{OriginalLineID: "line-1", Quantity: decimal.NewFromInt(3)}},
+		UserID:    "user-1",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, InvoiceTypeCreditNote, creditNote.InvoiceType)
+	assert.Equal(t, "CN-00001", creditNote.InvoiceNumber)
+	require.NotNil(t, creditNote.OriginalInvoiceID)
+	assert.Equal(t, "inv-1", *creditNote.OriginalInvoiceID)
+	assert.Equal(t, "INV-00001", creditNote.OriginalInvoiceNumber)
+	assert.Equal(t, "INV-00001", creditNote.Reference)
+	require.Len(t, creditNote.Lines, 1)
+	require.NotNil(t, creditNote.Lines[0].OriginalLineID)
+	assert.Equal(t, "line-1", *creditNote.Lines[0].OriginalLineID)
+	assert.True(t, creditNote.Lines[0].VATRate.Equal(decimal.NewFromInt(24)))
+	assert.True(t, creditNote.Total.Equal(decimal.NewFromInt(372)))
+	assert.True(t, creditNote.AmountPaid.Equal(creditNote.Total))
+	assert.Equal(t, StatusPaid, creditNote.Status)
+
+	assert.True(t, original.AmountPaid.Equal(decimal.NewFromInt(372)))
+	assert.Equal(t, StatusPartiallyPaid, original.Status)
+}
+
+func TestServiceCreateCreditNoteRejectsOverCrediting(t *testing.T) {
+	repo := NewMockRepository()
+	original := creditNoteTestInvoice()
+	repo.invoices[original.ID] = original
+	lineID := "line-1"
+	originalID := original.ID
+	repo.invoices["cn-1"] = &Invoice{
+		ID:                "cn-1",
+		TenantID:          "tenant-1",
+		InvoiceType:       InvoiceTypeCreditNote,
+		Status:            StatusPaid,
+		OriginalInvoiceID: &originalID,
+		Lines:             []InvoiceLine{{OriginalLineID: &lineID, Quantity: decimal.NewFromInt(8)}},
+	}
+	repo.invoices["cn-voided"] = &Invoice{
+		ID:                "cn-voided",
+		TenantID:          "tenant-1",
+		InvoiceType:       InvoiceTypeCreditNote,
+		Status:            StatusVoided,
+		OriginalInvoiceID: &originalID,
+		Lines:             []InvoiceLine{{OriginalLineID: &lineID, Quantity: decimal.NewFromInt(2)}},
+	}
+	service := NewServiceWithRepository(repo, nil)
+
+	_, err := service.CreateCreditNote(context.Background(), "tenant-1", "tenant_schema", "inv-1", &CreateCreditNoteRequest{
+		Lines: []CreateCreditNoteLineRequest{{OriginalLineID: "line-1", Quantity: decimal.NewFromInt(3)}},
+	})
+	require.ErrorContains(t, err, "exceeds remaining quantity 2")
+
+	_, err = service.CreateCreditNote(context.Background(), "tenant-1", "tenant_schema", "inv-1", &CreateCreditNoteRequest{
+		Lines: []CreateCreditNoteLineRequest{{OriginalLineID: "missing", Quantity: decimal.NewFromInt(1)}},
+	})
+	require.ErrorContains(t, err, "not found on invoice")
+
+	creditNote, err := service.CreateCreditNote(context.Background(), "tenant-1", "tenant_schema", "inv-1", &CreateCreditNoteRequest{})
+	require.NoError(t, err)
+	require.Len(t, creditNote.Lines, 2)
+	assert.True(t, creditNote.Lines[0].Quantity.Equal(decimal.NewFromInt(2)))
+	assert.True(t, creditNote.Lines[1].Quantity.Equal(decimal.NewFromInt(2)))
+	assert.Equal(t, VATTreatmentReverseCharge, creditNote.Lines[1].VATTreatment)
+}
+
+func TestServiceCreateCreditNoteOffsetsOnlyOpenBalance(t *testing.T) {
+	repo := NewMockRepository()
+	original := creditNoteTestInvoice()
+	original.AmountPaid = original.Total.Sub(decimal.NewFromInt(100))
+	original.Status = StatusPartiallyPaid
+	repo.invoices[original.ID] = original
+	service := NewServiceWithRepository(repo, nil)
+
+	creditNote, err := service.CreateCreditNote(context.Background(), "tenant-1", "tenant_schema", "inv-1", &CreateCreditNoteRequest{
+		Lines: []CreateCreditNoteLineRequest{{OriginalLineID: "line-1", Quantity: decimal.NewFromInt(1)}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, creditNote.AmountPaid.Equal(decimal.NewFromInt(100)))
+	assert.Equal(t, StatusPartiallyPaid, creditNote.Status)
+	assert.Equal(t, StatusPaid, original.Status)
+	assert.True(t, original.AmountPaid.Equal(original.Total))
+}
+
+func TestServiceCreateCreditNoteRejectsIneligibleInvoices(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Invoice)
+		want   string
+	}{
+		{name: "draft", mutate: func(inv *Invoice) { inv.Status = StatusDraft }, want: "cannot credit invoice in DRAFT status"},
+		{name: "voided", mutate: func(inv *Invoice) { inv.Status = StatusVoided }, want: "cannot credit invoice in VOIDED status"},
+		{name: "credit note", mutate: func(inv *Invoice) { inv.InvoiceType = InvoiceTypeCreditNote }, want: "only sales and purchase invoices"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			original := creditNoteTestInvoice()
+			tt.mutate(original)
+			repo.invoices[original.ID] = original
+
+			_, err := NewServiceWithRepository(repo, nil).CreateCreditNote(context.Background(), "tenant-1", "tenant_schema", "inv-1", &CreateCreditNoteRequest{})
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package invoicing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+	ublmapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/ubl"
+)
+
+// E-invoice export formats.
+const (
+	EInvoiceFormatEstonian = "EVS923"
+	EInvoiceFormatPeppol   = "UBL"
+)
+
+// ExportEInvoiceRequest selects issued sales invoices and credit notes for an
+// outbound e-invoice file. Format defaults to the Estonian e-invoice; Peppol
+// UBL documents hold exactly one invoice.
+type ExportEInvoiceRequest struct {
+	InvoiceIDs []string `json:"invoice_ids"`
+	Format     string   `json:"format,omitempty"`
+	IncludePDF bool     `json:"include_pdf,omitempty"`
+	PayToIBAN  string   `json:"pay_to_iban,omitempty"`
+	PayToBIC   string   `json:"pay_to_bic,omitempty"`
+}
+
+// EInvoiceSeller holds the issuing company details printed on outbound
+// e-invoices.
+type EInvoiceSeller struct {
+	Name      string
+	RegCode   string
+	VATNumber string
+	Email     string
+	Address   string
+	Country   string
+	IBAN      string
+	BIC       string
+}
+
+// EInvoiceExportOptions supplies tenant data the invoicing service does not
+// own. LoadContact resolves buyers; RenderPDF is only used with IncludePDF.
+type EInvoiceExportOptions struct {
+	Seller      EInvoiceSeller
+	LoadContact func(ctx context.Context, contactID string) (*contacts.Contact, error)
+	RenderPDF   func(invoice *Invoice) ([]byte, error)
+}
+
+// EInvoiceExport is a rendered outbound e-invoice file.
+type EInvoiceExport struct {
+	FileID   string    `json:"file_id"`
+	FileName string    `json:"file_name"`
+	XML      []byte    `json:"-"`
+	Invoices []Invoice `json:"invoices"`
+}
+
+type eInvoiceExportRecorder interface {
+	MarkEInvoiceExported(ctx context.Context, schemaName, tenantID, invoiceID, eInvoiceID string, exportedAt time.Time) error
+}
+
+var eInvoiceExportNow = time.Now
+
+// ExportEInvoiceXML renders issued sales invoices and credit notes into one
+// Estonian e-invoice 1.2 file, or a single invoice into a Peppol UBL 2.1
+// document, and records the export on every invoice.
+func (s *Service) ExportEInvoiceXML(ctx context.Context, tenantID, schemaName string, req *ExportEInvoiceRequest, opts EInvoiceExportOptions) (*EInvoiceExport, error) {
+	if req == nil || len(req.InvoiceIDs) == 0 {
+		return nil, fmt.Errorf("at least one invoice id is required")
+	}
+	if strings.TrimSpace(opts.Seller.RegCode) == "" {
+		return nil, fmt.Errorf("company registry code is required for e-invoice export")
+	}
+	if req.IncludePDF && opts.RenderPDF == nil {
+		return nil, fmt.Errorf("PDF rendering is not available")
+	}
+	format := strings.ToUpper(strings.TrimSpace(req.Format))
+	switch format {
+	case "":
+		format = EInvoiceFormatEstonian
+	case EInvoiceFormatEstonian, EInvoiceFormatPeppol:
+	default:
+		return nil, fmt.Errorf("invalid format %q: use %s or %s", req.Format, EInvoiceFormatEstonian, EInvoiceFormatPeppol)
+	}
+
+	now := eInvoiceExportNow()
+	fileID := fmt.Sprintf("EINV-%s", now.UTC().Format("20060102150405"))
+	payment := einvoicemapper.PaymentDetails{
+		PayToName:    strings.TrimSpace(opts.Seller.Name),
+		PayToAccount: firstNonEmptyImportValue(req.PayToIBAN, opts.Seller.IBAN),
+		PayToBIC:     firstNonEmptyImportValue(req.PayToBIC, opts.Seller.BIC),
+	}
+
+	seen := make(map[string]struct{}, len(req.InvoiceIDs))
+	invoices := make([]Invoice, 0, len(req.InvoiceIDs))
+	outbound := make([]einvoicemapper.OutboundInvoice, 0, len(req.InvoiceIDs))
+	for _, rawID := range req.InvoiceIDs {
+		invoiceID := strings.TrimSpace(rawID)
+		if invoiceID == "" {
+			continue
+		}
+		if _, ok := seen[invoiceID]; ok {
+			continue
+		}
+		seen[invoiceID] = struct{}{}
+
+		invoice, err := s.repo.GetByID(ctx, schemaName, tenantID, invoiceID)
+		if err != nil {
+			return nil, fmt.Errorf("get invoice %s: %w", invoiceID, err)
+		}
+		mapped, err := s.outboundEInvoice(ctx, tenantID, schemaName, invoice, opts)
+		if err != nil {
+			return nil, err
+		}
+		mapped.Payment = payment
+		if req.IncludePDF {
+			content, err := opts.RenderPDF(invoice)
+			if err != nil {
+				return nil, fmt.Errorf("invoice %s: render PDF: %w", invoice.InvoiceNumber, err)
+			}
+			mapped.Attachment = &einvoicemapper.Attachment{FileName: "invoice-" + invoice.InvoiceNumber + ".pdf", Content: content}
+		}
+		outbound = append(outbound, mapped)
+		invoices = append(invoices, *invoice)
+	}
+	if len(outbound) == 0 {
+		return nil, fmt.Errorf("at least one invoice id is required")
+	}
+
+	var payload []byte
+	var err error
+	if format == EInvoiceFormatPeppol {
+		if len(outbound) != 1 {
+			return nil, fmt.Errorf("UBL export holds exactly one invoice")
+		}
+		payload, err = ublmapper.Render(outbound[0])
+	} else {
+		payload, err = einvoicemapper.Render(einvoicemapper.Export{FileID: fileID, Date: now, Invoices: outbound})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	recorder, canRecord := s.repo.(eInvoiceExportRecorder)
+	for i := range invoices {
+		if canRecord {
+			if err := recorder.MarkEInvoiceExported(ctx, schemaName, tenantID, invoices[i].ID, fileID, now); err != nil {
+				return nil, fmt.Errorf("record e-invoice export: %w", err)
+			}
+		}
+		exportedAt := now
+		eInvoiceID := fileID
+		invoices[i].EInvoiceSentAt = &exportedAt
+		invoices[i].EInvoiceID = &eInvoiceID
+	}
+
+	fileName := fmt.Sprintf("einvoice-%s.xml", now.Format("2006-01-02"))
+	if format == EInvoiceFormatPeppol {
+		fileName = fmt.Sprintf("ubl-%s.xml", invoices[0].InvoiceNumber)
+	} else if len(invoices) == 1 {
+		fileName = fmt.Sprintf("einvoice-%s.xml", invoices[0].InvoiceNumber)
+	}
+	return &EInvoiceExport{
+		FileID:   fileID,
+		FileName: fileName,
+		XML:      payload,
+		Invoices: invoices,
+	}, nil
+}
+
+func (s *Service) outboundEInvoice(ctx context.Context, tenantID, schemaName string, invoice *Invoice, opts EInvoiceExportOptions) (einvoicemapper.OutboundInvoice, error) {
+	if invoice.Status == StatusDraft || invoice.Status == StatusVoided {
+		return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: cannot export invoice in %s status", invoice.InvoiceNumber, invoice.Status)
+	}
+
+	mapped := einvoicemapper.OutboundInvoice{
+		GlobalID:  invoice.ID,
+		Number:    invoice.InvoiceNumber,
+		Type:      einvoicemapper.TypeDebit,
+		IssueDate: invoice.IssueDate,
+		DueDate:   invoice.DueDate,
+		Currency:  invoice.Currency,
+		Reference: eInvoicePaymentReference(invoice),
+		Notes:     invoice.Notes,
+		Subtotal:  invoice.Subtotal,
+		VATAmount: invoice.VATAmount,
+		Total:     invoice.Total,
+		AmountDue: decimal.Max(invoice.AmountDue(), decimal.Zero),
+	}
+
+	switch invoice.InvoiceType {
+	case InvoiceTypeSales:
+	case InvoiceTypeCreditNote:
+		if invoice.OriginalInvoiceID == nil {
+			return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: credit note is not linked to an original invoice", invoice.InvoiceNumber)
+		}
+		original, err := s.repo.GetByID(ctx, schemaName, tenantID, *invoice.OriginalInvoiceID)
+		if err != nil {
+			return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: get original invoice: %w", invoice.InvoiceNumber, err)
+		}
+		if original.InvoiceType != InvoiceTypeSales {
+			return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: only credit notes of sales invoices can be exported", invoice.InvoiceNumber)
+		}
+		mapped.Type = einvoicemapper.TypeCredit
+		mapped.SourceInvoice = original.InvoiceNumber
+		mapped.AmountDue = decimal.Zero
+	default:
+		return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: only sales invoices and credit notes can be exported", invoice.InvoiceNumber)
+	}
+
+	mapped.Seller = einvoicemapper.OutboundParty{
+		Name:         opts.Seller.Name,
+		RegNumber:    opts.Seller.RegCode,
+		VATRegNumber: opts.Seller.VATNumber,
+		Email:        opts.Seller.Email,
+		Address:      opts.Seller.Address,
+		Country:      opts.Seller.Country,
+	}
+
+	contact := invoice.Contact
+	if contact == nil && opts.LoadContact != nil {
+		loaded, err := opts.LoadContact(ctx, invoice.ContactID)
+		if err != nil {
+			return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: get buyer: %w", invoice.InvoiceNumber, err)
+		}
+		contact = loaded
+	}
+	if contact == nil {
+		return einvoicemapper.OutboundInvoice{}, fmt.Errorf("invoice %s: buyer contact is required", invoice.InvoiceNumber)
+	}
+	mapped.Buyer = einvoicemapper.OutboundParty{
+		Name:         contact.Name,
+		RegNumber:    contact.RegCode,
+		VATRegNumber: contact.VATNumber,
+		Email:        contact.Email,
+		Address:      strings.TrimSpace(strings.Join([]string{contact.AddressLine1, contact.AddressLine2}, " ")),
+		City:         contact.City,
+		PostalCode:   contact.PostalCode,
+		Country:      contact.CountryCode,
+	}
+
+	for _, line := range invoice.Lines {
+		mapped.Lines = append(mapped.Lines, einvoicemapper.OutboundLine{
+			Description:     line.Description,
+			Unit:            line.Unit,
+			Quantity:        line.Quantity,
+			UnitPrice:       line.UnitPrice,
+			DiscountPercent: line.DiscountPercent,
+			VATRate:         line.VATRate,
+			ReverseCharge:   normalizeVATTreatmentOrDefault(line.VATTreatment) == VATTreatmentReverseCharge,
+			Subtotal:        line.LineSubtotal,
+			VAT:             line.LineVAT,
+			Total:           line.LineTotal,
+		})
+	}
+	return mapped, nil
+}
+
+// eInvoicePaymentReference returns the invoice reference when it is a valid
+// Estonian or RF creditor reference, otherwise an Estonian 7-3-1 reference
+// derived from the digits of the invoice number.
+func eInvoicePaymentReference(invoice *Invoice) string {
+	reference := strings.ReplaceAll(strings.TrimSpace(invoice.Reference), " ", "")
+	if strings.HasPrefix(strings.ToUpper(reference), "RF") || isEstonianReferenceNumber(reference) {
+		return reference
+	}
+	return EstonianReferenceNumber(invoice.InvoiceNumber)
+}
+
+// EstonianReferenceNumber builds an Estonian payment reference number from the
+// digits of base by appending the 7-3-1 check digit. It returns an empty
+// string when base contains no digits.
+func EstonianReferenceNumber(base string) string {
+	digits := strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, base)
+	digits = strings.TrimLeft(digits, "0")
+	if digits == "" {
+		return ""
+	}
+	if len(digits) > 19 {
+		digits = digits[len(digits)-19:]
+	}
+	return digits + string(rune('0'+estonianReferenceCheckDigit(digits)))
+}
+
+func isEstonianReferenceNumber(value string) bool {
+	if len(value) < 2 || len(value) > 20 {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	body := value[:len(value)-1]
+	return int(value[len(value)-1]-'0') == estonianReferenceCheckDigit(body)
+}
+
+func estonianReferenceCheckDigit(digits string) int {
+	weights := []int{7, 3, 1}
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		digit := int(digits[len(digits)-1-i] - '0')
+		sum += digit * weights[i%3]
+	}
+	return (10 - sum%10) % 10
+}
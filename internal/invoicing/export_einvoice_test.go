@@ -0,0 +1,169 @@
+package invoicing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+	ublmapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/ubl"
+)
+
+type eInvoiceRecordingRepository struct {
+	*MockRepository
+	exported map[string]string
+}
+
+func (r *eInvoiceRecordingRepository) MarkEInvoiceExported(_ context.Context, _, _, invoiceID, eInvoiceID string, _ time.Time) error {
+	r.exported[invoiceID] = eInvoiceID
+	return nil
+}
+
+func eInvoiceTestOptions() EInvoiceExportOptions {
+	return EInvoiceExportOptions{
+		Seller: EInvoiceSeller{Name: "Seller OÜ", RegCode: "12345678", VATNumber: "EE123456789", IBAN: "EE382200221020145685", BIC: "HABAEE2X"},
+		LoadContact: func(_ context.Context, contactID string) (*contacts.Contact, error) {
+			return &contacts.Contact{ID: contactID, Name: "Buyer AS", RegCode: "87654321", CountryCode: "EE"}, nil
+		},
+	}
+}
+
+func TestServiceExportEInvoiceXML(t *testing.T) {
+	now := time.Date(2026, time.March, 20, 10, 0, 0, 0, time.UTC)
+	previousNow := eInvoiceExportNow
+	eInvoiceExportNow = func() time.Time { return now }
+	t.Cleanup(func() { eInvoiceExportNow = previousNow })
+
+	repo := &eInvoiceRecordingRepository{MockRepository: NewMockRepository(), exported: map[string]string{}}
+	original := creditNoteTestInvoice()
+	repo.invoices[original.ID] = original
+	originalID := original.ID
+	creditNote := creditNoteTestInvoice()
+	creditNote.ID = "cn-1"
+	creditNote.InvoiceNumber = "CN-00001"
+	creditNote.InvoiceType = InvoiceTypeCreditNote
+	creditNote.OriginalInvoiceID = &originalID
+	creditNote.Lines = creditNote.Lines[:1]
+	creditNote.Calculate()
+	repo.invoices[creditNote.ID] = creditNote
+
+	opts := eInvoiceTestOptions()
+	opts.RenderPDF = func(invoice *Invoice) ([]byte, error) { return []byte("%PDF " + invoice.InvoiceNumber), nil }
+	export, err := NewServiceWithRepository(repo, nil).ExportEInvoiceXML(context.Background(), "tenant-1", "tenant_schema", &ExportEInvoiceRequest{
+		InvoiceIDs: []string{"inv-1", "cn-1", "inv-1"},
+		IncludePDF: true,
+	}, opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, "EINV-20260320100000", export.FileID)
+	assert.Equal(t, "einvoice-2026-03-20.xml", export.FileName)
+	require.Len(t, export.Invoices, 2)
+	assert.Equal(t, map[string]string{"inv-1": export.FileID, "cn-1": export.FileID}, repo.exported)
+	require.NotNil(t, export.Invoices[0].EInvoiceSentAt)
+	assert.Equal(t, now, *export.Invoices[0].EInvoiceSentAt)
+
+	xml := string(export.XML)
+	assert.Contains(t, xml, "<RegNumber>12345678</RegNumber>")
+	assert.Contains(t, xml, "<RegNumber>87654321</RegNumber>")
+	assert.Contains(t, xml, "<PaymentRefId>"+EstonianReferenceNumber("INV-00001")+"</PaymentRefId>")
+	assert.Contains(t, xml, "<PayToAccount>EE382200221020145685</PayToAccount>")
+	assert.Contains(t, xml, "<SourceInvoice>INV-00001</SourceInvoice>")
+	assert.Equal(t, 2, strings.Count(xml, "<AttachmentFile>"))
+
+	parsed, err := einvoicemapper.Parse(xml)
+	require.NoError(t, err)
+	require.Len(t, parsed, 2)
+	assert.Equal(t, einvoicemapper.TypeCredit, parsed[1].Type)
+}
+
+func TestServiceExportEInvoiceXMLPeppolUBL(t *testing.T) {
+	repo := &eInvoiceRecordingRepository{MockRepository: NewMockRepository(), exported: map[string]string{}}
+	invoice := creditNoteTestInvoice()
+	repo.invoices[invoice.ID] = invoice
+	second := creditNoteTestInvoice()
+	second.ID = "inv-2"
+	repo.invoices[second.ID] = second
+	service := NewServiceWithRepository(repo, nil)
+	opts := eInvoiceTestOptions()
+	opts.Seller.Country = "EE"
+
+	export, err := service.ExportEInvoiceXML(context.Background(), "tenant-1", "tenant_schema", &ExportEInvoiceRequest{
+		InvoiceIDs: []string{"inv-1"},
+		Format:     "ubl",
+	}, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "ubl-INV-00001.xml", export.FileName)
+	assert.Contains(t, repo.exported, "inv-1")
+	xml := string(export.XML)
+	assert.True(t, ublmapper.IsUBL(xml))
+	assert.Contains(t, xml, `<cbc:EndpointID schemeID="0191">12345678</cbc:EndpointID>`)
+	assert.Contains(t, xml, "<cbc:ID>AE</cbc:ID>")
+
+	_, err = service.ExportEInvoiceXML(context.Background(), "tenant-1", "tenant_schema", &ExportEInvoiceRequest{
+		InvoiceIDs: []string{"inv-1", "inv-2"},
+		Format:     EInvoiceFormatPeppol,
+	}, opts)
+	require.ErrorContains(t, err, "exactly one invoice")
+
+	_, err = service.ExportEInvoiceXML(context.Background(), "tenant-1", "tenant_schema", &ExportEInvoiceRequest{
+		InvoiceIDs: []string{"inv-1"},
+		Format:     "pdf",
+	}, opts)
+	require.ErrorContains(t, err, `invalid format "pdf"`)
+}
+
+func TestServiceExportEInvoiceXMLRejectsIneligibleInvoices(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Invoice)
+		opts   func(*EInvoiceExportOptions)
+		want   string
+	}{
+		{name: "draft", mutate: func(inv *Invoice) { inv.Status = StatusDraft }, want: "cannot export invoice in DRAFT status"},
+		{name: "purchase", mutate: func(inv *Invoice) { inv.InvoiceType = InvoiceTypePurchase }, want: "only sales invoices and credit notes"},
+		{name: "unlinked credit note", mutate: func(inv *Invoice) { inv.InvoiceType = InvoiceTypeCreditNote }, want: "not linked to an original invoice"},
+		{name: "missing seller reg code", opts: func(opts *EInvoiceExportOptions) { opts.Seller.RegCode = "" }, want: "registry code is required"},
+		{name: "buyer lookup", opts: func(opts *EInvoiceExportOptions) {
+			opts.LoadContact = func(context.Context, string) (*contacts.Contact, error) { return nil, errors.New("boom") }
+		}, want: "get buyer: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockRepository()
+			invoice := creditNoteTestInvoice()
+			if tt.mutate != nil {
+				tt.mutate(invoice)
+			}
+			repo.invoices[invoice.ID] = invoice
+			opts := eInvoiceTestOptions()
+			if tt.opts != nil {
+				tt.opts(&opts)
+			}
+
+			_, err := NewServiceWithRepository(repo, nil).ExportEInvoiceXML(context.Background(), "tenant-1", "tenant_schema", &ExportEInvoiceRequest{InvoiceIDs: []string{invoice.ID}}, opts)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	_, err := NewServiceWithRepository(NewMockRepository(), nil).ExportEInvoiceXML(context.Background(), "tenant-1", "tenant_schema", &ExportEInvoiceRequest{}, eInvoiceTestOptions())
+	require.ErrorContains(t, err, "at least one invoice id")
+}
+
+func TestEstonianReferenceNumber(t *testing.T) {
+	assert.Equal(t, "1234561", EstonianReferenceNumber("INV-123456"))
+	assert.Equal(t, "", EstonianReferenceNumber("INV-"))
+	assert.True(t, isEstonianReferenceNumber("1234561"))
+	assert.False(t, isEstonianReferenceNumber("1234562"))
+
+	invoice := &Invoice{InvoiceNumber: "INV-00042", Reference: "RF18 5390 0754 7034"}
+	assert.Equal(t, "RF18539007547034", eInvoicePaymentReference(invoice))
+	invoice.Reference = "order 77"
+	assert.Equal(t, EstonianReferenceNumber("42"), eInvoicePaymentReference(invoice))
+}
@@ -3,6 +3,7 @@ package invoicing
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,11 +11,13 @@ import (
 
 	"github.com/HMB-research/open-accounting/internal/contacts"
 	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+	ublmapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/ubl"
 )
 
 var buildEInvoiceImportedInvoice = buildImportedInvoice
 
-// ImportEInvoiceXML imports invoices from Estonian e-invoice XML.
+// ImportEInvoiceXML imports invoices from Estonian e-invoice XML or Peppol
+// UBL 2.1 Invoice and CreditNote documents.
 func (s *Service) ImportEInvoiceXML(
 	ctx context.Context,
 	tenantID, schemaName string,
@@ -26,7 +29,7 @@ func (s *Service) ImportEInvoiceXML(
 		return nil, fmt.Errorf("xml_content is required")
 	}
 
-	mappedInvoices, err := einvoicemapper.Parse(req.XMLContent)
+	mappedInvoices, err := parseInboundEInvoice(req.XMLContent)
 	if err != nil {
 		return nil, err
 	}
@@ -161,6 +164,13 @@ func mappedEInvoiceToImportGroup(mapped einvoicemapper.Invoice, requestedType In
 	}
 
 	for _, line := range mapped.Lines {
+		// Reverse charge lines carry a zero rate in UBL, while reverse charge
+		// treatment here needs the self-assessed rate, so they import as
+		// standard lines and are flagged in the notes instead.
+		vatTreatment := VATTreatmentStandard
+		if line.ReverseCharge && line.VATRate.IsPositive() {
+			vatTreatment = VATTreatmentReverseCharge
+		}
 		group.lines = append(group.lines, invoiceImportLine{
 			description:     line.Description,
 			quantity:        line.Quantity,
@@ -168,12 +178,21 @@ func mappedEInvoiceToImportGroup(mapped einvoicemapper.Invoice, requestedType In
 			unitPrice:       line.UnitPrice,
 			discountPercent: line.DiscountPercent,
 			vatRate:         line.VATRate,
-			vatTreatment:    VATTreatmentStandard,
+			vatTreatment:    vatTreatment,
 		})
 	}
 	return group, nil
 }
 
+// parseInboundEInvoice parses Peppol UBL 2.1 Invoice and CreditNote documents
+// as well as Estonian E_Invoice files into the same normalized payload.
+func parseInboundEInvoice(content string) ([]einvoicemapper.Invoice, error) {
+	if ublmapper.IsUBL(content) {
+		return ublmapper.Parse(content)
+	}
+	return einvoicemapper.Parse(content)
+}
+
 func resolveEInvoiceImportType(rawType string, requestedType InvoiceType) (InvoiceType, error) {
 	if requestedType != "" {
 		switch requestedType {
@@ -193,23 +212,36 @@ func resolveEInvoiceImportType(rawType string, requestedType InvoiceType) (Invoi
 }
 
 func eInvoicePartyContactRef(party einvoicemapper.Party) invoiceImportContactRef {
+	// Foreign Peppol parties often carry only a VAT identifier, which is
+	// matched against contact VAT numbers rather than registry codes.
+	if strings.TrimSpace(party.RegNumber) == "" && strings.TrimSpace(party.VATRegNumber) != "" {
+		return invoiceImportContactRef{vatNumber: party.VATRegNumber}
+	}
 	return invoiceImportContactRef{
-		regCode: firstNonEmptyImportValue(party.RegNumber, party.VATRegNumber),
+		regCode: party.RegNumber,
 		email:   party.Email,
 		name:    party.Name,
 	}
 }
 
 func eInvoiceNotes(mapped einvoicemapper.Invoice) string {
-	notes := strings.TrimSpace(mapped.Notes)
+	parts := []string{}
+	if notes := strings.TrimSpace(mapped.Notes); notes != "" {
+		parts = append(parts, notes)
+	}
 	if mapped.SourceInvoice != "" {
-		sourceNote := "Source invoice: " + mapped.SourceInvoice
-		if notes == "" {
-			return sourceNote
+		parts = append(parts, "Source invoice: "+mapped.SourceInvoice)
+	}
+	reverseChargeLines := []string{}
+	for index, line := range mapped.Lines {
+		if line.ReverseCharge && !line.VATRate.IsPositive() {
+			reverseChargeLines = append(reverseChargeLines, strconv.Itoa(index+1))
 		}
-		return notes + "\n" + sourceNote
 	}
-	return notes
+	if len(reverseChargeLines) > 0 {
+		parts = append(parts, "Reverse charge: self-assess VAT on lines "+strings.Join(reverseChargeLines, ", "))
+	}
+	return strings.Join(parts, "\n")
 }
 
 func firstNonEmptyImportValue(values ...string) string {
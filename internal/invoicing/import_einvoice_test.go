@@ -13,6 +13,7 @@ import (
 
 	"github.com/HMB-research/open-accounting/internal/contacts"
 	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+	ublmapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/ubl"
 )
 
 func TestService_ImportEInvoiceXML(t *testing.T) {
@@ -336,3 +337,56 @@ func sampleEInvoiceXML() string {
   </Invoice>
 </E_Invoice>`
 }
+
+func TestService_ImportEInvoiceXMLPeppolUBL(t *testing.T) {
+	ctx := context.Background()
+	content, err := ublmapper.Render(einvoicemapper.OutboundInvoice{
+		Number:    "SUP-2026-17",
+		Type:      einvoicemapper.TypeDebit,
+		Seller:    einvoicemapper.OutboundParty{Name: "Supplier BV", VATRegNumber: "BE0123456789", Email: "billing@supplier.example", Country: "BE"},
+		Buyer:     einvoicemapper.OutboundParty{Name: "Buyer OÜ", RegNumber: "87654321", Country: "EE"},
+		IssueDate: time.Date(2026, time.April, 2, 0, 0, 0, 0, time.UTC),
+		DueDate:   time.Date(2026, time.April, 30, 0, 0, 0, 0, time.UTC),
+		Currency:  "EUR",
+		Reference: "PO-77",
+		AmountDue: decimal.NewFromInt(250),
+		Lines: []einvoicemapper.OutboundLine{{
+			Description:   "Support hours",
+			Quantity:      decimal.NewFromInt(10),
+			UnitPrice:     decimal.NewFromInt(25),
+			VATRate:       decimal.NewFromInt(24),
+			ReverseCharge: true,
+			Subtotal:      decimal.NewFromInt(250),
+		}},
+	})
+	require.NoError(t, err)
+
+	repo := NewMockRepository()
+	result, err := NewServiceWithRepository(repo, nil).ImportEInvoiceXML(ctx, "tenant-1", "tenant_test", []contacts.Contact{{
+		ID:        "supplier-1",
+		TenantID:  "tenant-1",
+		Name:      "Supplier BV",
+		VATNumber: "BE0123456789",
+		IsActive:  true,
+	}}, &ImportEInvoiceRequest{FileName: "peppol.xml", UserID: "user-1", XMLContent: string(content)}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.InvoicesCreated)
+	assert.Empty(t, result.Errors)
+
+	require.Len(t, repo.invoices, 1)
+	for _, invoice := range repo.invoices {
+		assert.Equal(t, "SUP-2026-17", invoice.InvoiceNumber)
+		assert.Equal(t, InvoiceTypePurchase, invoice.InvoiceType)
+		assert.Equal(t, "supplier-1", invoice.ContactID)
+		require.Len(t, invoice.Lines, 1)
+		assert.Equal(t, VATTreatmentStandard, invoice.Lines[0].VATTreatment)
+		assert.True(t, invoice.Lines[0].VATRate.IsZero())
+		assert.True(t, invoice.Total.Equal(decimal.NewFromInt(250)))
+		assert.Equal(t, "Reverse charge: self-assess VAT on lines 1", invoice.Notes)
+	}
+
+	_, err = NewServiceWithRepository(NewMockRepository(), nil).ImportEInvoiceXML(ctx, "tenant-1", "tenant_test", nil, &ImportEInvoiceRequest{
+		XMLContent: `<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"><cbc:ID xmlns:cbc="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">X-1</cbc:ID></Invoice>`,
+	}, nil)
+	require.ErrorContains(t, err, "BR-01: An Invoice shall have a Specification identifier")
+}
@@ -29,7 +29,7 @@ func TestInvoicingWave8CreateRejectsInvalidVATTreatment(t *testing.T) {
 		Lines: []CreateInvoiceLineRequest{{
 			Description:  "Consulting",
 			Quantity:     decimal.NewFromInt(1),
-			UnitPrice:    decimal.NewFromInt(100),
+			UnitPrice:    new(decimal.NewFromInt(100)),
 			VATRate:      decimal.NewFromInt(20),
 			VATTreatment: VATTreatment("unsupported"),
 		}},
@@ -41,6 +41,8 @@ type Line struct {
 	UnitPrice       decimal.Decimal
 	DiscountPercent decimal.Decimal
 	VATRate         decimal.Decimal
+	// ReverseCharge is set for lines the buyer self-assesses VAT on.
+	ReverseCharge bool
 }
 
 // Parse parses Estonian e-invoice XML.
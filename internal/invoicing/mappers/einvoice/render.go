@@ -0,0 +1,485 @@
+package einvoice
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// Version is the Estonian e-invoice standard version rendered by Render.
+	Version = "1.2"
+
+	// TypeDebit marks a regular invoice and TypeCredit a credit invoice.
+	TypeDebit  = "DEB"
+	TypeCredit = "CRE"
+)
+
+var marshalEInvoiceXML = xml.MarshalIndent
+
+// Export is an outbound e-invoice file containing one or more invoices.
+type Export struct {
+	FileID   string
+	Date     time.Time
+	Invoices []OutboundInvoice
+}
+
+// OutboundInvoice is an issued invoice or credit note ready for rendering.
+type OutboundInvoice struct {
+	GlobalID      string
+	Number        string
+	Type          string
+	SourceInvoice string
+	Seller        OutboundParty
+	Buyer         OutboundParty
+	IssueDate     time.Time
+	DueDate       time.Time
+	Currency      string
+	Reference     string
+	Notes         string
+	Subtotal      decimal.Decimal
+	VATAmount     decimal.Decimal
+	Total         decimal.Decimal
+	AmountDue     decimal.Decimal
+	Lines         []OutboundLine
+	Payment       PaymentDetails
+	Attachment    *Attachment
+}
+
+// OutboundParty is the seller or buyer block of an outbound e-invoice.
+type OutboundParty struct {
+	Name         string
+	RegNumber    string
+	VATRegNumber string
+	Email        string
+	Address      string
+	City         string
+	PostalCode   string
+	Country      string
+}
+
+// OutboundLine is one invoice row with precalculated amounts.
+type OutboundLine struct {
+	Description     string
+	Unit            string
+	Quantity        decimal.Decimal
+	UnitPrice       decimal.Decimal
+	DiscountPercent decimal.Decimal
+	VATRate         decimal.Decimal
+	ReverseCharge   bool
+	Subtotal        decimal.Decimal
+	VAT             decimal.Decimal
+	Total           decimal.Decimal
+}
+
+// PaymentDetails describes where the buyer should pay.
+type PaymentDetails struct {
+	PayToName    string
+	PayToAccount string
+	PayToBIC     string
+}
+
+// Attachment is an optional file embedded into the e-invoice, usually the
+// invoice PDF.
+type Attachment struct {
+	FileName string
+	Content  []byte
+}
+
+// Render validates and renders an Estonian e-invoice 1.2 XML document.
+func Render(export Export) ([]byte, error) {
+	if len(export.Invoices) == 0 {
+		return nil, fmt.Errorf("at least one invoice is required")
+	}
+	fileID := strings.TrimSpace(export.FileID)
+	if fileID == "" {
+		return nil, fmt.Errorf("file id is required")
+	}
+	date := export.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	doc := outDocumentXML{
+		XSI:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "e-invoice_ver1.2.xsd",
+		Header: outHeaderXML{
+			Date:    date.Format("2006-01-02"),
+			FileID:  fileID,
+			AppID:   "EARVE",
+			Version: Version,
+		},
+	}
+
+	totalAmount := decimal.Zero
+	for _, invoice := range export.Invoices {
+		rendered, err := renderInvoice(invoice)
+		if err != nil {
+			return nil, err
+		}
+		doc.Invoices = append(doc.Invoices, rendered)
+		totalAmount = totalAmount.Add(invoice.Total)
+	}
+	doc.Footer = outFooterXML{
+		TotalNumberInvoices: len(doc.Invoices),
+		TotalAmount:         formatAmount(totalAmount),
+	}
+
+	payload, err := marshalEInvoiceXML(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshal e-invoice XML: %w", err)
+	}
+	return append([]byte(xml.Header), payload...), nil
+}
+
+func renderInvoice(invoice OutboundInvoice) (outInvoiceXML, error) {
+	number := strings.TrimSpace(invoice.Number)
+	if number == "" {
+		return outInvoiceXML{}, fmt.Errorf("invoice number is required")
+	}
+	if strings.TrimSpace(invoice.Seller.Name) == "" || strings.TrimSpace(invoice.Seller.RegNumber) == "" {
+		return outInvoiceXML{}, fmt.Errorf("invoice %s: seller name and registry code are required", number)
+	}
+	if strings.TrimSpace(invoice.Buyer.Name) == "" {
+		return outInvoiceXML{}, fmt.Errorf("invoice %s: buyer name is required", number)
+	}
+	if len(invoice.Lines) == 0 {
+		return outInvoiceXML{}, fmt.Errorf("invoice %s: at least one line is required", number)
+	}
+	invoiceType := strings.ToUpper(strings.TrimSpace(invoice.Type))
+	if invoiceType == "" {
+		invoiceType = TypeDebit
+	}
+	if invoiceType != TypeDebit && invoiceType != TypeCredit {
+		return outInvoiceXML{}, fmt.Errorf("invoice %s: type must be %s or %s", number, TypeDebit, TypeCredit)
+	}
+	if invoiceType == TypeCredit && strings.TrimSpace(invoice.SourceInvoice) == "" {
+		return outInvoiceXML{}, fmt.Errorf("invoice %s: credit invoices must reference the source invoice", number)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(invoice.Currency))
+	if currency == "" {
+		currency = "EUR"
+	}
+
+	documentName := "Arve"
+	if invoiceType == TypeCredit {
+		documentName = "Kreeditarve"
+	}
+	rendered := outInvoiceXML{
+		InvoiceID:       number,
+		RegNumber:       strings.TrimSpace(invoice.Buyer.RegNumber),
+		SellerRegNumber: strings.TrimSpace(invoice.Seller.RegNumber),
+		GlobalID:        strings.TrimSpace(invoice.GlobalID),
+		Parties: outPartiesXML{
+			Seller: renderParty(invoice.Seller),
+			Buyer:  renderParty(invoice.Buyer),
+		},
+		Information: outInformationXML{
+			Type:                   outTypeXML{Type: invoiceType},
+			DocumentName:           documentName,
+			Number:                 number,
+			PaymentReferenceNumber: strings.TrimSpace(invoice.Reference),
+			ContentText:            strings.TrimSpace(invoice.Notes),
+			InvoiceDate:            invoice.IssueDate.Format("2006-01-02"),
+			DueDate:                optionalDate(invoice.DueDate),
+		},
+		SumGroup: outSumGroupXML{
+			InvoiceSum:  formatAmount(invoice.Subtotal),
+			TotalVATSum: formatAmount(invoice.VATAmount),
+			TotalSum:    formatAmount(invoice.Total),
+			TotalToPay:  formatAmount(invoice.AmountDue),
+			Currency:    currency,
+		},
+	}
+	if invoiceType == TypeCredit {
+		rendered.Information.SourceInvoice = strings.TrimSpace(invoice.SourceInvoice)
+	}
+
+	vatGroups := make(map[string]*outVATXML)
+	var vatOrder []string
+	reverseCharge := false
+	for i, line := range invoice.Lines {
+		if strings.TrimSpace(line.Description) == "" {
+			return outInvoiceXML{}, fmt.Errorf("invoice %s: line %d description is required", number, i+1)
+		}
+		vat := renderVAT(line.Subtotal, line.VATRate, line.VAT, line.ReverseCharge)
+		entry := outItemEntryXML{
+			RowNo:       i + 1,
+			Description: strings.TrimSpace(line.Description),
+			Details: &outDetailInfoXML{
+				Unit:   strings.TrimSpace(line.Unit),
+				Amount: line.Quantity.String(),
+				Price:  line.UnitPrice.String(),
+			},
+			ItemSum:   formatAmount(line.Subtotal),
+			VAT:       vat,
+			ItemTotal: formatAmount(line.Total),
+		}
+		if line.DiscountPercent.IsPositive() {
+			gross := line.Quantity.Mul(line.UnitPrice).Round(2)
+			entry.Additions = []outAdditionXML{{
+				Code:    "DSC",
+				Content: "Allahindlus",
+				Rate:    line.DiscountPercent.String(),
+				Sum:     formatAmount(gross.Sub(line.Subtotal)),
+			}}
+		}
+		rendered.Items.Group.Entries = append(rendered.Items.Group.Entries, entry)
+
+		key := fmt.Sprintf("%s|%t", line.VATRate.String(), line.ReverseCharge)
+		group, ok := vatGroups[key]
+		if !ok {
+			group = &outVATXML{VATID: vat.VATID, VATRate: vat.VATRate}
+			vatGroups[key] = group
+			vatOrder = append(vatOrder, key)
+		}
+		group.sumBeforeVAT = group.sumBeforeVAT.Add(line.Subtotal)
+		group.vatSum = group.vatSum.Add(line.VAT)
+		reverseCharge = reverseCharge || line.ReverseCharge
+	}
+	for _, key := range vatOrder {
+		group := vatGroups[key]
+		group.SumBeforeVAT = formatAmount(group.sumBeforeVAT)
+		group.VATSum = formatAmount(group.vatSum)
+		rendered.SumGroup.VAT = append(rendered.SumGroup.VAT, *group)
+	}
+	if reverseCharge {
+		rendered.AdditionalInfos = append(rendered.AdditionalInfos, outExtensionXML{
+			Name:    "Pöördmaksustamine",
+			Content: "Käibemaksu pöördmaksustamine, KMS § 41¹",
+		})
+	}
+
+	if invoice.Attachment != nil && len(invoice.Attachment.Content) > 0 {
+		fileName := strings.TrimSpace(invoice.Attachment.FileName)
+		if fileName == "" {
+			fileName = number + ".pdf"
+		}
+		rendered.Attachment = &outAttachmentXML{
+			FileName:   fileName,
+			FileBase64: base64.StdEncoding.EncodeToString(invoice.Attachment.Content),
+		}
+	}
+
+	payToName := strings.TrimSpace(invoice.Payment.PayToName)
+	if payToName == "" {
+		payToName = strings.TrimSpace(invoice.Seller.Name)
+	}
+	payable := "NO"
+	if invoiceType == TypeDebit && invoice.AmountDue.IsPositive() && strings.TrimSpace(invoice.Payment.PayToAccount) != "" {
+		payable = "YES"
+	}
+	rendered.PaymentInfo = outPaymentInfoXML{
+		Currency:     currency,
+		Description:  number,
+		RefID:        strings.TrimSpace(invoice.Reference),
+		Payable:      payable,
+		DueDate:      optionalDate(invoice.DueDate),
+		PaymentTotal: formatAmount(invoice.AmountDue),
+		PayerName:    strings.TrimSpace(invoice.Buyer.Name),
+		PaymentID:    number,
+		PayToAccount: strings.ReplaceAll(strings.TrimSpace(invoice.Payment.PayToAccount), " ", ""),
+		PayToName:    payToName,
+		PayToBIC:     strings.TrimSpace(invoice.Payment.PayToBIC),
+	}
+	return rendered, nil
+}
+
+func renderParty(party OutboundParty) outPartyXML {
+	rendered := outPartyXML{
+		Name:         strings.TrimSpace(party.Name),
+		RegNumber:    strings.TrimSpace(party.RegNumber),
+		VATRegNumber: strings.TrimSpace(party.VATRegNumber),
+	}
+	email := strings.TrimSpace(party.Email)
+	address := strings.TrimSpace(party.Address)
+	if email == "" && address == "" {
+		return rendered
+	}
+	rendered.ContactData = &outContactDataXML{Email: email}
+	if address != "" {
+		rendered.ContactData.LegalAddress = &outAddressXML{
+			PostalAddress1: address,
+			City:           strings.TrimSpace(party.City),
+			PostalCode:     strings.TrimSpace(party.PostalCode),
+			Country:        strings.TrimSpace(party.Country),
+		}
+	}
+	return rendered
+}
+
+func renderVAT(subtotal, rate, vat decimal.Decimal, reverseCharge bool) outVATXML {
+	vatID := "TAX"
+	if reverseCharge {
+		vatID = "NOTTAX"
+	}
+	return outVATXML{
+		VATID:        vatID,
+		SumBeforeVAT: formatAmount(subtotal),
+		VATRate:      rate.String(),
+		VATSum:       formatAmount(vat),
+	}
+}
+
+func formatAmount(value decimal.Decimal) string {
+	return value.StringFixed(2)
+}
+
+func optionalDate(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+	return value.Format("2006-01-02")
+}
+
+type outDocumentXML struct {
+	XMLName        xml.Name        `xml:"E_Invoice"`
+	XSI            string          `xml:"xmlns:xsi,attr"`
+	SchemaLocation string          `xml:"xsi:noNamespaceSchemaLocation,attr"`
+	Header         outHeaderXML    `xml:"Header"`
+	Invoices       []outInvoiceXML `xml:"Invoice"`
+	Footer         outFooterXML    `xml:"Footer"`
+}
+
+type outHeaderXML struct {
+	Date    string `xml:"Date"`
+	FileID  string `xml:"FileId"`
+	AppID   string `xml:"AppId"`
+	Version string `xml:"Version"`
+}
+
+type outFooterXML struct {
+	TotalNumberInvoices int    `xml:"TotalNumberInvoices"`
+	TotalAmount         string `xml:"TotalAmount"`
+}
+
+type outInvoiceXML struct {
+	InvoiceID       string            `xml:"invoiceId,attr"`
+	RegNumber       string            `xml:"regNumber,attr,omitempty"`
+	SellerRegNumber string            `xml:"sellerRegnumber,attr"`
+	GlobalID        string            `xml:"invoiceGlobUniqId,attr,omitempty"`
+	Parties         outPartiesXML     `xml:"InvoiceParties"`
+	Information     outInformationXML `xml:"InvoiceInformation"`
+	SumGroup        outSumGroupXML    `xml:"InvoiceSumGroup"`
+	Items           outInvoiceItemXML `xml:"InvoiceItem"`
+	AdditionalInfos []outExtensionXML `xml:"AdditionalInformation,omitempty"`
+	Attachment      *outAttachmentXML `xml:"AttachmentFile,omitempty"`
+	PaymentInfo     outPaymentInfoXML `xml:"PaymentInfo"`
+}
+
+type outPartiesXML struct {
+	Seller outPartyXML `xml:"SellerParty"`
+	Buyer  outPartyXML `xml:"BuyerParty"`
+}
+
+type outPartyXML struct {
+	Name         string             `xml:"Name"`
+	RegNumber    string             `xml:"RegNumber,omitempty"`
+	VATRegNumber string             `xml:"VATRegNumber,omitempty"`
+	ContactData  *outContactDataXML `xml:"ContactData,omitempty"`
+}
+
+type outContactDataXML struct {
+	Email        string         `xml:"E-mailAddress,omitempty"`
+	LegalAddress *outAddressXML `xml:"LegalAddress,omitempty"`
+}
+
+type outAddressXML struct {
+	PostalAddress1 string `xml:"PostalAddress1"`
+	City           string `xml:"City,omitempty"`
+	PostalCode     string `xml:"PostalCode,omitempty"`
+	Country        string `xml:"Country,omitempty"`
+}
+
+type outInformationXML struct {
+	Type                   outTypeXML `xml:"Type"`
+	DocumentName           string     `xml:"DocumentName"`
+	Number                 string     `xml:"InvoiceNumber"`
+	SourceInvoice          string     `xml:"SourceInvoice,omitempty"`
+	PaymentReferenceNumber string     `xml:"PaymentReferenceNumber,omitempty"`
+	ContentText            string     `xml:"InvoiceContentText,omitempty"`
+	InvoiceDate            string     `xml:"InvoiceDate"`
+	DueDate                string     `xml:"DueDate,omitempty"`
+}
+
+type outTypeXML struct {
+	Type string `xml:"type,attr"`
+}
+
+type outSumGroupXML struct {
+	InvoiceSum  string      `xml:"InvoiceSum"`
+	VAT         []outVATXML `xml:"VAT"`
+	TotalVATSum string      `xml:"TotalVATSum"`
+	TotalSum    string      `xml:"TotalSum"`
+	TotalToPay  string      `xml:"TotalToPay"`
+	Currency    string      `xml:"Currency"`
+}
+
+type outVATXML struct {
+	VATID        string `xml:"vatId,attr"`
+	SumBeforeVAT string `xml:"SumBeforeVAT"`
+	VATRate      string `xml:"VATRate"`
+	VATSum       string `xml:"VATSum"`
+
+	sumBeforeVAT decimal.Decimal
+	vatSum       decimal.Decimal
+}
+
+type outInvoiceItemXML struct {
+	Group outItemGroupXML `xml:"InvoiceItemGroup"`
+}
+
+type outItemGroupXML struct {
+	Entries []outItemEntryXML `xml:"ItemEntry"`
+}
+
+type outItemEntryXML struct {
+	RowNo       int               `xml:"RowNo"`
+	Description string            `xml:"Description"`
+	Details     *outDetailInfoXML `xml:"ItemDetailInfo,omitempty"`
+	ItemSum     string            `xml:"ItemSum"`
+	Additions   []outAdditionXML  `xml:"Addition,omitempty"`
+	VAT         outVATXML         `xml:"VAT"`
+	ItemTotal   string            `xml:"ItemTotal"`
+}
+
+type outDetailInfoXML struct {
+	Unit   string `xml:"ItemUnit,omitempty"`
+	Amount string `xml:"ItemAmount"`
+	Price  string `xml:"ItemPrice"`
+}
+
+type outAdditionXML struct {
+	Code    string `xml:"addCode,attr"`
+	Content string `xml:"AddContent"`
+	Rate    string `xml:"AddRate"`
+	Sum     string `xml:"AddSum"`
+}
+
+type outExtensionXML struct {
+	Name    string `xml:"InformationName"`
+	Content string `xml:"InformationContent"`
+}
+
+type outAttachmentXML struct {
+	FileName   string `xml:"FileName"`
+	FileBase64 string `xml:"FileBase64"`
+}
+
+type outPaymentInfoXML struct {
+	Currency     string `xml:"Currency"`
+	Description  string `xml:"PaymentDescription"`
+	RefID        string `xml:"PaymentRefId,omitempty"`
+	Payable      string `xml:"Payable"`
+	DueDate      string `xml:"PayDueDate,omitempty"`
+	PaymentTotal string `xml:"PaymentTotalSum"`
+	PayerName    string `xml:"PayerName"`
+	PaymentID    string `xml:"PaymentId"`
+	PayToAccount string `xml:"PayToAccount,omitempty"`
+	PayToName    string `xml:"PayToName"`
+	PayToBIC     string `xml:"PayToBIC,omitempty"`
+}
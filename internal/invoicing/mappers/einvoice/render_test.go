@@ -0,0 +1,172 @@
+package einvoice
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testOutboundInvoice() OutboundInvoice {
+	return OutboundInvoice{
+		GlobalID:  "inv-uuid-1",
+		Number:    "INV-00001",
+		Type:      TypeDebit,
+		Seller:    OutboundParty{Name: "Seller OÜ", RegNumber: "12345678", VATRegNumber: "EE123456789", Email: "billing@seller.example", Address: "Tartu mnt 1, Tallinn"},
+		Buyer:     OutboundParty{Name: "Buyer AS", RegNumber: "87654321", Email: "ap@buyer.example", Address: "Pikk 2", City: "Tartu", PostalCode: "51003", Country: "EE"},
+		IssueDate: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+		DueDate:   time.Date(2026, time.March, 29, 0, 0, 0, 0, time.UTC),
+		Currency:  "eur",
+		Reference: "1234561",
+		Notes:     "March services",
+		Subtotal:  decimal.RequireFromString("280.00"),
+		VATAmount: decimal.RequireFromString("39.60"),
+		Total:     decimal.RequireFromString("319.60"),
+		AmountDue: decimal.RequireFromString("319.60"),
+		Lines: []OutboundLine{
+			{
+				Description:     "Consulting",
+				Unit:            "h",
+				Quantity:        decimal.NewFromInt(2),
+				UnitPrice:       decimal.NewFromInt(100),
+				DiscountPercent: decimal.NewFromInt(10),
+				VATRate:         decimal.NewFromInt(22),
+				Subtotal:        decimal.RequireFromString("180.00"),
+				VAT:             decimal.RequireFromString("39.60"),
+				Total:           decimal.RequireFromString("219.60"),
+			},
+			{
+				Description:   "EU service",
+				Quantity:      decimal.NewFromInt(1),
+				UnitPrice:     decimal.NewFromInt(100),
+				VATRate:       decimal.NewFromInt(24),
+				ReverseCharge: true,
+				Subtotal:      decimal.RequireFromString("100.00"),
+				VAT:           decimal.Zero,
+				Total:         decimal.RequireFromString("100.00"),
+			},
+		},
+		Payment:    PaymentDetails{PayToAccount: "EE38 2200 2210 2014 5685", PayToBIC: "HABAEE2X"},
+		Attachment: &Attachment{Content: []byte("%PDF-1.4")},
+	}
+}
+
+func TestRender(t *testing.T) {
+	payload, err := Render(Export{
+		FileID:   "EINV-1",
+		Date:     time.Date(2026, time.March, 16, 0, 0, 0, 0, time.UTC),
+		Invoices: []OutboundInvoice{testOutboundInvoice()},
+	})
+	require.NoError(t, err)
+
+	content := string(payload)
+	assert.True(t, strings.HasPrefix(content, `<?xml version="1.0" encoding="UTF-8"?>`))
+	assert.Contains(t, content, `<E_Invoice xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:noNamespaceSchemaLocation="e-invoice_ver1.2.xsd">`)
+	assert.Contains(t, content, `<Version>1.2</Version>`)
+	assert.Contains(t, content, `<Invoice invoiceId="INV-00001" regNumber="87654321" sellerRegnumber="12345678" invoiceGlobUniqId="inv-uuid-1">`)
+	assert.Contains(t, content, `<Type type="DEB"></Type>`)
+	assert.Contains(t, content, `<PaymentReferenceNumber>1234561</PaymentReferenceNumber>`)
+	assert.Contains(t, content, `<VAT vatId="TAX">`)
+	assert.Contains(t, content, `<VAT vatId="NOTTAX">`)
+	assert.Contains(t, content, `<Addition addCode="DSC">`)
+	assert.Contains(t, content, `<AddSum>20.00</AddSum>`)
+	assert.Contains(t, content, `<PayToAccount>EE382200221020145685</PayToAccount>`)
+	assert.Contains(t, content, `<Payable>YES</Payable>`)
+	assert.Contains(t, content, `<FileBase64>JVBERi0xLjQ=</FileBase64>`)
+	assert.Contains(t, content, `<FileName>INV-00001.pdf</FileName>`)
+	assert.Contains(t, content, `<TotalNumberInvoices>1</TotalNumberInvoices>`)
+	assert.Contains(t, content, `<TotalAmount>319.60</TotalAmount>`)
+
+	parsed, err := Parse(content)
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	assert.Equal(t, "INV-00001", parsed[0].Number)
+	assert.Equal(t, "12345678", parsed[0].Seller.RegNumber)
+	assert.Equal(t, "87654321", parsed[0].Buyer.RegNumber)
+	assert.Equal(t, "EUR", parsed[0].Currency)
+	assert.Equal(t, "1234561", parsed[0].Reference)
+	require.Len(t, parsed[0].Lines, 2)
+	assert.True(t, parsed[0].Lines[0].DiscountPercent.Equal(decimal.NewFromInt(10)))
+	assert.True(t, parsed[0].Lines[1].VATRate.Equal(decimal.NewFromInt(24)))
+}
+
+func TestRenderCreditInvoice(t *testing.T) {
+	invoice := testOutboundInvoice()
+	invoice.Number = "CN-00001"
+	invoice.Type = TypeCredit
+	invoice.SourceInvoice = "INV-00001"
+	invoice.AmountDue = decimal.Zero
+	invoice.Attachment = nil
+
+	payload, err := Render(Export{FileID: "EINV-2", Invoices: []OutboundInvoice{invoice}})
+	require.NoError(t, err)
+	content := string(payload)
+	assert.Contains(t, content, `<Type type="CRE"></Type>`)
+	assert.Contains(t, content, `<DocumentName>Kreeditarve</DocumentName>`)
+	assert.Contains(t, content, `<SourceInvoice>INV-00001</SourceInvoice>`)
+	assert.Contains(t, content, `<Payable>NO</Payable>`)
+	assert.NotContains(t, content, `AttachmentFile`)
+
+	parsed, err := Parse(content)
+	require.NoError(t, err)
+	assert.Equal(t, "CRE", parsed[0].Type)
+	assert.Equal(t, "INV-00001", parsed[0].SourceInvoice)
+}
+
+func TestRenderValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		export Export
+		want   string
+	}{
+		{name: "no invoices", export: Export{FileID: "F"}, want: "at least one invoice"},
+		{name: "no file id", export: Export{Invoices: []OutboundInvoice{testOutboundInvoice()}}, want: "file id is required"},
+		{name: "missing seller registry code", export: Export{FileID: "F", Invoices: []OutboundInvoice{func() OutboundInvoice {
+			invoice := testOutboundInvoice()
+			invoice.Seller.RegNumber = ""
+			return invoice
+		}()}}, want: "seller name and registry code"},
+		{name: "missing buyer", export: Export{FileID: "F", Invoices: []OutboundInvoice{func() OutboundInvoice {
+			invoice := testOutboundInvoice()
+			invoice.Buyer.Name = ""
+			return invoice
+		}()}}, want: "buyer name is required"},
+		{name: "credit without source", export: Export{FileID: "F", Invoices: []OutboundInvoice{func() OutboundInvoice {
+			invoice := testOutboundInvoice()
+			invoice.Type = TypeCredit
+			return invoice
+		}()}}, want: "must reference the source invoice"},
+		{name: "invalid type", export: Export{FileID: "F", Invoices: []OutboundInvoice{func() OutboundInvoice {
+			invoice := testOutboundInvoice()
+			invoice.Type = "XYZ"
+			return invoice
+		}()}}, want: "type must be DEB or CRE"},
+		{name: "no lines", export: Export{FileID: "F", Invoices: []OutboundInvoice{func() OutboundInvoice {
+			invoice := testOutboundInvoice()
+			invoice.Lines = nil
+			return invoice
+		}()}}, want: "at least one line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(tt.export)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestRenderMarshalError(t *testing.T) {
+	original := marshalEInvoiceXML
+	marshalEInvoiceXML = func(any, string, string) ([]byte, error) {
+		return nil, errors.New("boom")
+	}
+	t.Cleanup(func() { marshalEInvoiceXML = original })
+
+	_, err := Render(Export{FileID: "F", Invoices: []OutboundInvoice{testOutboundInvoice()}})
+	require.ErrorContains(t, err, "marshal e-invoice XML: boom")
+}
@@ -0,0 +1,457 @@
+package ubl
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+)
+
+var marshalUBLXML = xml.MarshalIndent
+
+// unitCodes maps common free-text invoice units to UN/ECE Recommendation 20
+// codes. Unknown units fall back to C62 ("one").
+var unitCodes = map[string]string{
+	"":      "C62",
+	"pcs":   "H87",
+	"pc":    "H87",
+	"tk":    "H87",
+	"h":     "HUR",
+	"hour":  "HUR",
+	"hours": "HUR",
+	"tund":  "HUR",
+	"day":   "DAY",
+	"days":  "DAY",
+	"päev":  "DAY",
+	"kg":    "KGM",
+	"m":     "MTR",
+	"l":     "LTR",
+	"month": "MON",
+	"kuu":   "MON",
+}
+
+// Render renders an issued invoice or credit note as a Peppol BIS Billing 3.0
+// UBL 2.1 document and validates the result against the EN 16931 rules.
+func Render(invoice einvoicemapper.OutboundInvoice) ([]byte, error) {
+	if len(invoice.Lines) == 0 {
+		return nil, fmt.Errorf("invoice %s: at least one line is required", invoice.Number)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(invoice.Currency))
+	if currency == "" {
+		currency = "EUR"
+	}
+	money := func(value decimal.Decimal) amountOutXML {
+		return amountOutXML{CurrencyID: currency, Value: value.StringFixed(2)}
+	}
+	credit := invoice.Type == einvoicemapper.TypeCredit
+
+	doc := documentOutXML{
+		XMLNS:           invoiceNamespace,
+		CAC:             "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2",
+		CBC:             "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2",
+		CustomizationID: CustomizationID,
+		ProfileID:       ProfileID,
+		ID:              invoice.Number,
+		IssueDate:       invoice.IssueDate.Format("2006-01-02"),
+		Note:            strings.TrimSpace(invoice.Notes),
+		Currency:        currency,
+		BuyerReference:  firstNonEmpty(invoice.Reference, invoice.Number),
+		Supplier:        partyWrapperOutXML{Party: renderParty(invoice.Seller)},
+		Customer:        partyWrapperOutXML{Party: renderParty(invoice.Buyer)},
+	}
+	doc.XMLName = xml.Name{Local: "Invoice"}
+	if credit {
+		doc.XMLName = xml.Name{Local: "CreditNote"}
+		doc.XMLNS = creditNoteNamespace
+		doc.CreditNoteTypeCode = "381"
+	} else {
+		doc.DueDate = invoice.DueDate.Format("2006-01-02")
+		doc.InvoiceTypeCode = "380"
+	}
+	if source := strings.TrimSpace(invoice.SourceInvoice); source != "" {
+		doc.BillingReference = &billingReferenceOutXML{ID: source}
+	}
+	if invoice.Attachment != nil && len(invoice.Attachment.Content) > 0 {
+		doc.Attachment = &documentReferenceOutXML{
+			ID: invoice.Number,
+			Attachment: attachmentOutXML{Object: binaryObjectOutXML{
+				MimeCode: "application/pdf",
+				FileName: invoice.Attachment.FileName,
+				Value:    base64.StdEncoding.EncodeToString(invoice.Attachment.Content),
+			}},
+		}
+	}
+	if account := strings.TrimSpace(invoice.Payment.PayToAccount); account != "" {
+		means := &paymentMeansOutXML{
+			Code:      "58",
+			PaymentID: strings.TrimSpace(invoice.Reference),
+			Account: financialAccountOutXML{
+				ID:   strings.ReplaceAll(account, " ", ""),
+				Name: strings.TrimSpace(invoice.Payment.PayToName),
+			},
+		}
+		if credit {
+			means.Code = "30"
+		}
+		if bic := strings.TrimSpace(invoice.Payment.PayToBIC); bic != "" {
+			means.Account.Branch = &branchOutXML{ID: bic}
+		}
+		doc.PaymentMeans = means
+	}
+
+	type breakdownKey struct {
+		category string
+		rate     string
+	}
+	breakdowns := map[breakdownKey]*taxSubtotalOutXML{}
+	lineTotal := decimal.Zero
+	vatTotal := decimal.Zero
+	for index, line := range invoice.Lines {
+		category, rate := lineCategory(line)
+		quantity := quantityOutXML{UnitCode: unitCode(line.Unit), Value: line.Quantity.String()}
+		rendered := lineOutXML{
+			ID:                  strconv.Itoa(index + 1),
+			LineExtensionAmount: money(line.Subtotal),
+			Item: itemOutXML{
+				Name:        line.Description,
+				TaxCategory: taxCategoryOutXML{ID: category, Percent: rate.StringFixed(2), TaxScheme: taxSchemeOutXML{ID: "VAT"}},
+			},
+			Price: priceOutXML{Amount: amountOutXML{CurrencyID: currency, Value: line.UnitPrice.String()}},
+		}
+		if credit {
+			rendered.CreditedQuantity = &quantity
+		} else {
+			rendered.InvoicedQuantity = &quantity
+		}
+		gross := line.Quantity.Mul(line.UnitPrice)
+		if discount := gross.Sub(line.Subtotal).Round(2); line.DiscountPercent.IsPositive() && !discount.IsZero() {
+			rendered.AllowanceCharge = &allowanceChargeOutXML{
+				ChargeIndicator:  "false",
+				ReasonCode:       "95",
+				Reason:           "Discount",
+				MultiplierFactor: line.DiscountPercent.String(),
+				Amount:           money(discount),
+				BaseAmount:       &amountOutXML{CurrencyID: currency, Value: gross.String()},
+			}
+		}
+		if credit {
+			doc.CreditNoteLines = append(doc.CreditNoteLines, rendered)
+		} else {
+			doc.InvoiceLines = append(doc.InvoiceLines, rendered)
+		}
+
+		key := breakdownKey{category: category, rate: rate.StringFixed(2)}
+		breakdown, ok := breakdowns[key]
+		if !ok {
+			breakdown = &taxSubtotalOutXML{
+				taxable:  decimal.Zero,
+				tax:      decimal.Zero,
+				Category: taxCategoryOutXML{ID: category, Percent: rate.StringFixed(2), TaxScheme: taxSchemeOutXML{ID: "VAT"}},
+			}
+			if category == categoryReverseCharge {
+				breakdown.Category.ExemptionCode = "VATEX-EU-AE"
+				breakdown.Category.ExemptionReason = "Reverse charge"
+			}
+			breakdowns[key] = breakdown
+		}
+		breakdown.taxable = breakdown.taxable.Add(line.Subtotal)
+		breakdown.tax = breakdown.tax.Add(line.VAT)
+		lineTotal = lineTotal.Add(line.Subtotal)
+		vatTotal = vatTotal.Add(line.VAT)
+	}
+
+	keys := make([]breakdownKey, 0, len(breakdowns))
+	for key := range breakdowns {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].category != keys[j].category {
+			return keys[i].category > keys[j].category
+		}
+		return keys[i].rate > keys[j].rate
+	})
+	taxTotal := taxTotalOutXML{TaxAmount: money(vatTotal)}
+	for _, key := range keys {
+		breakdown := breakdowns[key]
+		breakdown.TaxableAmount = money(breakdown.taxable)
+		breakdown.TaxAmount = money(breakdown.tax)
+		taxTotal.Subtotals = append(taxTotal.Subtotals, *breakdown)
+	}
+	doc.TaxTotal = taxTotal
+
+	taxInclusive := lineTotal.Add(vatTotal)
+	payable := decimal.Min(decimal.Max(invoice.AmountDue, decimal.Zero), taxInclusive)
+	doc.MonetaryTotal = monetaryTotalOutXML{
+		LineExtensionAmount: money(lineTotal),
+		TaxExclusiveAmount:  money(lineTotal),
+		TaxInclusiveAmount:  money(taxInclusive),
+		PayableAmount:       money(payable),
+	}
+	if credit {
+		// A credit note is payable in full by the seller; any offset against
+		// the original invoice is settled outside the document.
+		doc.MonetaryTotal.PayableAmount = money(taxInclusive)
+	} else if prepaid := taxInclusive.Sub(payable); prepaid.IsPositive() {
+		amount := money(prepaid)
+		doc.MonetaryTotal.PrepaidAmount = &amount
+	}
+
+	body, err := marshalUBLXML(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("render UBL XML: %w", err)
+	}
+	payload := append([]byte(xml.Header), body...)
+
+	violations, err := Validate(string(payload))
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		return nil, &ValidationError{Violations: violations}
+	}
+	return payload, nil
+}
+
+func lineCategory(line einvoicemapper.OutboundLine) (string, decimal.Decimal) {
+	switch {
+	case line.ReverseCharge:
+		return categoryReverseCharge, decimal.Zero
+	case line.VATRate.IsPositive():
+		return categoryStandard, line.VATRate
+	default:
+		return categoryZero, decimal.Zero
+	}
+}
+
+func unitCode(unit string) string {
+	normalized := strings.ToLower(strings.TrimSpace(unit))
+	if code, ok := unitCodes[normalized]; ok {
+		return code
+	}
+	if len(normalized) == 3 && strings.ToUpper(unit) == strings.TrimSpace(unit) {
+		return strings.TrimSpace(unit)
+	}
+	return "C62"
+}
+
+// endpointID derives the Peppol electronic address of a party: the Estonian
+// registry code (scheme 0191), an Estonian VAT number (9931), or e-mail.
+func endpointID(party einvoicemapper.OutboundParty) *identifierXML {
+	country := strings.ToUpper(strings.TrimSpace(party.Country))
+	regCode := strings.TrimSpace(party.RegNumber)
+	vat := strings.ToUpper(strings.ReplaceAll(party.VATRegNumber, " ", ""))
+	switch {
+	case regCode != "" && (country == "" || country == "EE"):
+		return &identifierXML{SchemeID: "0191", Value: regCode}
+	case strings.HasPrefix(vat, "EE"):
+		return &identifierXML{SchemeID: "9931", Value: vat}
+	case strings.TrimSpace(party.Email) != "":
+		return &identifierXML{SchemeID: "EM", Value: strings.TrimSpace(party.Email)}
+	default:
+		return nil
+	}
+}
+
+func renderParty(party einvoicemapper.OutboundParty) partyOutXML {
+	rendered := partyOutXML{
+		EndpointID: endpointID(party),
+		Name:       &partyNameOutXML{Name: party.Name},
+		Address: addressOutXML{
+			StreetName: strings.TrimSpace(party.Address),
+			CityName:   strings.TrimSpace(party.City),
+			PostalZone: strings.TrimSpace(party.PostalCode),
+			Country:    countryOutXML{Code: strings.ToUpper(strings.TrimSpace(party.Country))},
+		},
+		LegalEntity: legalEntityOutXML{RegistrationName: party.Name},
+	}
+	if regCode := strings.TrimSpace(party.RegNumber); regCode != "" {
+		rendered.LegalEntity.CompanyID = &identifierXML{Value: regCode}
+	}
+	if vat := strings.TrimSpace(party.VATRegNumber); vat != "" {
+		rendered.TaxScheme = &partyTaxSchemeOutXML{CompanyID: vat, TaxScheme: taxSchemeOutXML{ID: "VAT"}}
+	}
+	if email := strings.TrimSpace(party.Email); email != "" {
+		rendered.Contact = &contactOutXML{Email: email}
+	}
+	return rendered
+}
+
+type documentOutXML struct {
+	XMLName            xml.Name
+	XMLNS              string                   `xml:"xmlns,attr"`
+	CAC                string                   `xml:"xmlns:cac,attr"`
+	CBC                string                   `xml:"xmlns:cbc,attr"`
+	CustomizationID    string                   `xml:"cbc:CustomizationID"`
+	ProfileID          string                   `xml:"cbc:ProfileID"`
+	ID                 string                   `xml:"cbc:ID"`
+	IssueDate          string                   `xml:"cbc:IssueDate"`
+	DueDate            string                   `xml:"cbc:DueDate,omitempty"`
+	InvoiceTypeCode    string                   `xml:"cbc:InvoiceTypeCode,omitempty"`
+	CreditNoteTypeCode string                   `xml:"cbc:CreditNoteTypeCode,omitempty"`
+	Note               string                   `xml:"cbc:Note,omitempty"`
+	Currency           string                   `xml:"cbc:DocumentCurrencyCode"`
+	BuyerReference     string                   `xml:"cbc:BuyerReference,omitempty"`
+	BillingReference   *billingReferenceOutXML  `xml:"cac:BillingReference>cac:InvoiceDocumentReference,omitempty"`
+	Attachment         *documentReferenceOutXML `xml:"cac:AdditionalDocumentReference,omitempty"`
+	Supplier           partyWrapperOutXML       `xml:"cac:AccountingSupplierParty"`
+	Customer           partyWrapperOutXML       `xml:"cac:AccountingCustomerParty"`
+	PaymentMeans       *paymentMeansOutXML      `xml:"cac:PaymentMeans,omitempty"`
+	TaxTotal           taxTotalOutXML           `xml:"cac:TaxTotal"`
+	MonetaryTotal      monetaryTotalOutXML      `xml:"cac:LegalMonetaryTotal"`
+	InvoiceLines       []lineOutXML             `xml:"cac:InvoiceLine,omitempty"`
+	CreditNoteLines    []lineOutXML             `xml:"cac:CreditNoteLine,omitempty"`
+}
+
+type billingReferenceOutXML struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type documentReferenceOutXML struct {
+	ID         string           `xml:"cbc:ID"`
+	Attachment attachmentOutXML `xml:"cac:Attachment"`
+}
+
+type attachmentOutXML struct {
+	Object binaryObjectOutXML `xml:"cbc:EmbeddedDocumentBinaryObject"`
+}
+
+type binaryObjectOutXML struct {
+	MimeCode string `xml:"mimeCode,attr"`
+	FileName string `xml:"filename,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type partyWrapperOutXML struct {
+	Party partyOutXML `xml:"cac:Party"`
+}
+
+type partyOutXML struct {
+	EndpointID  *identifierXML        `xml:"cbc:EndpointID,omitempty"`
+	Name        *partyNameOutXML      `xml:"cac:PartyName,omitempty"`
+	Address     addressOutXML         `xml:"cac:PostalAddress"`
+	TaxScheme   *partyTaxSchemeOutXML `xml:"cac:PartyTaxScheme,omitempty"`
+	LegalEntity legalEntityOutXML     `xml:"cac:PartyLegalEntity"`
+	Contact     *contactOutXML        `xml:"cac:Contact,omitempty"`
+}
+
+type partyNameOutXML struct {
+	Name string `xml:"cbc:Name"`
+}
+
+type addressOutXML struct {
+	StreetName string        `xml:"cbc:StreetName,omitempty"`
+	CityName   string        `xml:"cbc:CityName,omitempty"`
+	PostalZone string        `xml:"cbc:PostalZone,omitempty"`
+	Country    countryOutXML `xml:"cac:Country"`
+}
+
+type countryOutXML struct {
+	Code string `xml:"cbc:IdentificationCode"`
+}
+
+type partyTaxSchemeOutXML struct {
+	CompanyID string          `xml:"cbc:CompanyID"`
+	TaxScheme taxSchemeOutXML `xml:"cac:TaxScheme"`
+}
+
+type taxSchemeOutXML struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type legalEntityOutXML struct {
+	RegistrationName string         `xml:"cbc:RegistrationName"`
+	CompanyID        *identifierXML `xml:"cbc:CompanyID,omitempty"`
+}
+
+type contactOutXML struct {
+	Email string `xml:"cbc:ElectronicMail"`
+}
+
+type paymentMeansOutXML struct {
+	Code      string                 `xml:"cbc:PaymentMeansCode"`
+	PaymentID string                 `xml:"cbc:PaymentID,omitempty"`
+	Account   financialAccountOutXML `xml:"cac:PayeeFinancialAccount"`
+}
+
+type financialAccountOutXML struct {
+	ID     string        `xml:"cbc:ID"`
+	Name   string        `xml:"cbc:Name,omitempty"`
+	Branch *branchOutXML `xml:"cac:FinancialInstitutionBranch,omitempty"`
+}
+
+type branchOutXML struct {
+	ID string `xml:"cbc:ID"`
+}
+
+type amountOutXML struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type quantityOutXML struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type taxTotalOutXML struct {
+	TaxAmount amountOutXML        `xml:"cbc:TaxAmount"`
+	Subtotals []taxSubtotalOutXML `xml:"cac:TaxSubtotal"`
+}
+
+type taxSubtotalOutXML struct {
+	taxable       decimal.Decimal
+	tax           decimal.Decimal
+	TaxableAmount amountOutXML      `xml:"cbc:TaxableAmount"`
+	TaxAmount     amountOutXML      `xml:"cbc:TaxAmount"`
+	Category      taxCategoryOutXML `xml:"cac:TaxCategory"`
+}
+
+type taxCategoryOutXML struct {
+	ID              string          `xml:"cbc:ID"`
+	Percent         string          `xml:"cbc:Percent"`
+	ExemptionCode   string          `xml:"cbc:TaxExemptionReasonCode,omitempty"`
+	ExemptionReason string          `xml:"cbc:TaxExemptionReason,omitempty"`
+	TaxScheme       taxSchemeOutXML `xml:"cac:TaxScheme"`
+}
+
+type monetaryTotalOutXML struct {
+	LineExtensionAmount amountOutXML  `xml:"cbc:LineExtensionAmount"`
+	TaxExclusiveAmount  amountOutXML  `xml:"cbc:TaxExclusiveAmount"`
+	TaxInclusiveAmount  amountOutXML  `xml:"cbc:TaxInclusiveAmount"`
+	PrepaidAmount       *amountOutXML `xml:"cbc:PrepaidAmount,omitempty"`
+	PayableAmount       amountOutXML  `xml:"cbc:PayableAmount"`
+}
+
+type lineOutXML struct {
+	ID                  string                 `xml:"cbc:ID"`
+	InvoicedQuantity    *quantityOutXML        `xml:"cbc:InvoicedQuantity,omitempty"`
+	CreditedQuantity    *quantityOutXML        `xml:"cbc:CreditedQuantity,omitempty"`
+	LineExtensionAmount amountOutXML           `xml:"cbc:LineExtensionAmount"`
+	AllowanceCharge     *allowanceChargeOutXML `xml:"cac:AllowanceCharge,omitempty"`
+	Item                itemOutXML             `xml:"cac:Item"`
+	Price               priceOutXML            `xml:"cac:Price"`
+}
+
+type allowanceChargeOutXML struct {
+	ChargeIndicator  string        `xml:"cbc:ChargeIndicator"`
+	ReasonCode       string        `xml:"cbc:AllowanceChargeReasonCode,omitempty"`
+	Reason           string        `xml:"cbc:AllowanceChargeReason,omitempty"`
+	MultiplierFactor string        `xml:"cbc:MultiplierFactorNumeric,omitempty"`
+	Amount           amountOutXML  `xml:"cbc:Amount"`
+	BaseAmount       *amountOutXML `xml:"cbc:BaseAmount,omitempty"`
+}
+
+type itemOutXML struct {
+	Name        string            `xml:"cbc:Name"`
+	TaxCategory taxCategoryOutXML `xml:"cac:ClassifiedTaxCategory"`
+}
+
+type priceOutXML struct {
+	Amount amountOutXML `xml:"cbc:PriceAmount"`
+}
@@ -0,0 +1,149 @@
+package ubl
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+)
+
+func outboundTestInvoice() einvoicemapper.OutboundInvoice {
+	return einvoicemapper.OutboundInvoice{
+		GlobalID:  "inv-1",
+		Number:    "INV-00012",
+		Type:      einvoicemapper.TypeDebit,
+		Seller:    einvoicemapper.OutboundParty{Name: "Seller OÜ", RegNumber: "12345678", VATRegNumber: "EE123456789", Country: "EE", Address: "Narva mnt 5"},
+		Buyer:     einvoicemapper.OutboundParty{Name: "Buyer GmbH", VATRegNumber: "DE123456789", Email: "ap@buyer.example", Country: "DE", City: "Berlin"},
+		IssueDate: time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC),
+		DueDate:   time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC),
+		Currency:  "EUR",
+		Reference: "1234561",
+		Notes:     "Thank you",
+		AmountDue: decimal.RequireFromString("200.40"),
+		Lines: []einvoicemapper.OutboundLine{
+			{
+				Description:     "Consulting",
+				Unit:            "h",
+				Quantity:        decimal.NewFromInt(3),
+				UnitPrice:       decimal.RequireFromString("33.33"),
+				DiscountPercent: decimal.NewFromInt(10),
+				VATRate:         decimal.NewFromInt(24),
+				Subtotal:        decimal.RequireFromString("89.99"),
+				VAT:             decimal.RequireFromString("21.60"),
+			},
+			{
+				Description:   "Cross-border service",
+				Quantity:      decimal.NewFromInt(1),
+				UnitPrice:     decimal.NewFromInt(100),
+				VATRate:       decimal.NewFromInt(24),
+				ReverseCharge: true,
+				Subtotal:      decimal.NewFromInt(100),
+				VAT:           decimal.Zero,
+			},
+		},
+		Payment:    einvoicemapper.PaymentDetails{PayToName: "Seller OÜ", PayToAccount: "EE38 2200 2210 2014 5685", PayToBIC: "HABAEE2X"},
+		Attachment: &einvoicemapper.Attachment{FileName: "invoice-INV-00012.pdf", Content: []byte("%PDF")},
+	}
+}
+
+func TestRender(t *testing.T) {
+	payload, err := Render(outboundTestInvoice())
+	require.NoError(t, err)
+
+	xml := string(payload)
+	assert.True(t, IsUBL(xml))
+	assert.Contains(t, xml, `<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"`)
+	assert.Contains(t, xml, "<cbc:CustomizationID>"+CustomizationID+"</cbc:CustomizationID>")
+	assert.Contains(t, xml, "<cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>")
+	assert.Contains(t, xml, `<cbc:EndpointID schemeID="0191">12345678</cbc:EndpointID>`)
+	assert.Contains(t, xml, `<cbc:EndpointID schemeID="EM">ap@buyer.example</cbc:EndpointID>`)
+	assert.Contains(t, xml, `<cbc:InvoicedQuantity unitCode="HUR">3</cbc:InvoicedQuantity>`)
+	assert.Contains(t, xml, "<cbc:TaxExemptionReasonCode>VATEX-EU-AE</cbc:TaxExemptionReasonCode>")
+	assert.Contains(t, xml, "<cbc:PaymentID>1234561</cbc:PaymentID>")
+	assert.Contains(t, xml, "<cbc:ID>EE382200221020145685</cbc:ID>")
+	assert.Contains(t, xml, `<cbc:PayableAmount currencyID="EUR">200.40</cbc:PayableAmount>`)
+	assert.Contains(t, xml, `<cbc:PrepaidAmount currencyID="EUR">11.19</cbc:PrepaidAmount>`)
+	assert.Contains(t, xml, `mimeCode="application/pdf"`)
+
+	parsed, err := Parse(xml)
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+	invoice := parsed[0]
+	assert.Equal(t, "INV-00012", invoice.Number)
+	assert.Equal(t, einvoicemapper.TypeDebit, invoice.Type)
+	assert.Equal(t, "12345678", invoice.Seller.RegNumber)
+	assert.Equal(t, "DE123456789", invoice.Buyer.VATRegNumber)
+	assert.Equal(t, "1234561", invoice.Reference)
+	require.Len(t, invoice.Lines, 2)
+	assert.True(t, invoice.Lines[0].DiscountPercent.Equal(decimal.NewFromInt(10)))
+	assert.True(t, invoice.Lines[0].VATRate.Equal(decimal.NewFromInt(24)))
+	assert.True(t, invoice.Lines[1].ReverseCharge)
+}
+
+func TestRenderCreditNote(t *testing.T) {
+	creditNote := outboundTestInvoice()
+	creditNote.Number = "CN-00001"
+	creditNote.Type = einvoicemapper.TypeCredit
+	creditNote.SourceInvoice = "INV-00012"
+	creditNote.AmountDue = decimal.Zero
+	creditNote.Attachment = nil
+
+	payload, err := Render(creditNote)
+	require.NoError(t, err)
+
+	xml := string(payload)
+	assert.Contains(t, xml, `<CreditNote xmlns="urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"`)
+	assert.Contains(t, xml, "<cbc:CreditNoteTypeCode>381</cbc:CreditNoteTypeCode>")
+	assert.Contains(t, xml, "<cac:CreditNoteLine>")
+	assert.Contains(t, xml, "<cac:InvoiceDocumentReference>")
+	assert.NotContains(t, xml, "<cbc:DueDate>")
+
+	parsed, err := Parse(xml)
+	require.NoError(t, err)
+	assert.Equal(t, einvoicemapper.TypeCredit, parsed[0].Type)
+	assert.Equal(t, "INV-00012", parsed[0].SourceInvoice)
+}
+
+func TestRenderValidatesOutput(t *testing.T) {
+	invoice := outboundTestInvoice()
+	invoice.Buyer.Country = ""
+	invoice.Buyer.Email = ""
+
+	_, err := Render(invoice)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr), "error: %v", err)
+	rules := make([]string, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		rules = append(rules, violation.Rule)
+	}
+	assert.Contains(t, rules, "BR-11")
+	assert.Contains(t, rules, "PEPPOL-EN16931-R010")
+	assert.True(t, strings.HasPrefix(err.Error(), "UBL document fails EN 16931 validation: "))
+
+	invoice = outboundTestInvoice()
+	invoice.Lines = nil
+	_, err = Render(invoice)
+	require.ErrorContains(t, err, "at least one line is required")
+}
+
+func TestRenderMarshalError(t *testing.T) {
+	previous := marshalUBLXML
+	marshalUBLXML = func(any, string, string) ([]byte, error) { return nil, errors.New("boom") }
+	t.Cleanup(func() { marshalUBLXML = previous })
+
+	_, err := Render(outboundTestInvoice())
+	require.ErrorContains(t, err, "render UBL XML: boom")
+}
+
+func TestUnitCode(t *testing.T) {
+	assert.Equal(t, "C62", unitCode(""))
+	assert.Equal(t, "H87", unitCode("tk"))
+	assert.Equal(t, "KGM", unitCode("KGM"))
+	assert.Equal(t, "C62", unitCode("bundle"))
+}
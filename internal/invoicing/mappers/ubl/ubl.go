@@ -0,0 +1,379 @@
+// Package ubl maps Peppol BIS Billing 3.0 (UBL 2.1) invoices and credit notes
+// to and from the normalized e-invoice types used by the invoicing service.
+package ubl
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+)
+
+const (
+	// CustomizationID is the Peppol BIS Billing 3.0 specification identifier.
+	CustomizationID = "urn:cen.eu:en16931:2017#compliant#urn:fdc:peppol.eu:2017:poacc:billing:3.0"
+	// ProfileID is the Peppol BIS Billing 3.0 business process identifier.
+	ProfileID = "urn:fdc:peppol.eu:2017:poacc:billing:01:1.0"
+
+	invoiceNamespace    = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	creditNoteNamespace = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+
+	// VAT category codes from UNCL5305 used by Render.
+	categoryStandard      = "S"
+	categoryZero          = "Z"
+	categoryReverseCharge = "AE"
+)
+
+// IsUBL reports whether content is a UBL 2.1 Invoice or CreditNote document.
+func IsUBL(content string) bool {
+	decoder := xml.NewDecoder(strings.NewReader(strings.TrimPrefix(strings.TrimSpace(content), "\ufeff")))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			return start.Name.Space == invoiceNamespace || start.Name.Space == creditNoteNamespace
+		}
+	}
+}
+
+// Parse validates a UBL 2.1 Invoice or CreditNote against the EN 16931
+// business rules and maps it to the normalized e-invoice payload. Credit notes
+// are returned with the e-invoice credit type so they import as credit notes.
+func Parse(content string) ([]einvoicemapper.Invoice, error) {
+	doc, err := decodeDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	if violations := validateDocument(doc); len(violations) > 0 {
+		return nil, &ValidationError{Violations: violations}
+	}
+
+	number := strings.TrimSpace(doc.ID)
+	issueDate, err := parseDate(doc.IssueDate)
+	if err != nil {
+		return nil, fmt.Errorf("invoice %s: IssueDate must use YYYY-MM-DD", number)
+	}
+	dueDate, err := parseOptionalDate(firstNonEmpty(doc.DueDate, doc.paymentDueDate()))
+	if err != nil {
+		return nil, fmt.Errorf("invoice %s: DueDate must use YYYY-MM-DD", number)
+	}
+	if dueDate.IsZero() {
+		dueDate = issueDate.AddDate(0, 0, 14)
+	}
+
+	invoiceType := einvoicemapper.TypeDebit
+	if doc.isCreditNote() {
+		invoiceType = einvoicemapper.TypeCredit
+	}
+
+	lines := make([]einvoicemapper.Line, 0, len(doc.lines()))
+	for _, raw := range doc.lines() {
+		line, err := normalizeLine(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invoice %s: line %s: %w", number, raw.ID, err)
+		}
+		lines = append(lines, line)
+	}
+
+	notes := make([]string, 0, len(doc.Notes))
+	for _, note := range doc.Notes {
+		if trimmed := strings.TrimSpace(note); trimmed != "" {
+			notes = append(notes, trimmed)
+		}
+	}
+
+	return []einvoicemapper.Invoice{{
+		ID:            number,
+		Number:        number,
+		Type:          invoiceType,
+		SourceInvoice: strings.TrimSpace(doc.BillingReference.InvoiceDocumentReference.ID),
+		Seller:        normalizeParty(doc.Supplier.Party),
+		Buyer:         normalizeParty(doc.Customer.Party),
+		IssueDate:     issueDate,
+		DueDate:       dueDate,
+		Currency:      strings.ToUpper(strings.TrimSpace(doc.DocumentCurrencyCode)),
+		Reference:     firstNonEmpty(doc.paymentID(), doc.BuyerReference),
+		Notes:         strings.Join(notes, "\n"),
+		Lines:         lines,
+	}}, nil
+}
+
+func decodeDocument(content string) (*documentXML, error) {
+	trimmed := strings.TrimSpace(strings.TrimPrefix(content, "\ufeff"))
+	if trimmed == "" {
+		return nil, fmt.Errorf("xml_content is required")
+	}
+	var doc documentXML
+	if err := xml.NewDecoder(bytes.NewReader([]byte(trimmed))).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse UBL XML: %w", err)
+	}
+	switch doc.XMLName.Local {
+	case "Invoice", "CreditNote":
+	default:
+		return nil, fmt.Errorf("root element must be Invoice or CreditNote")
+	}
+	return &doc, nil
+}
+
+func normalizeParty(raw partyXML) einvoicemapper.Party {
+	return einvoicemapper.Party{
+		Name:         firstNonEmpty(raw.LegalEntity.RegistrationName, raw.Name.Name),
+		RegNumber:    strings.TrimSpace(raw.LegalEntity.CompanyID.Value),
+		VATRegNumber: strings.TrimSpace(raw.TaxScheme.CompanyID),
+		Email:        strings.TrimSpace(raw.Contact.Email),
+	}
+}
+
+func normalizeLine(raw lineXML) (einvoicemapper.Line, error) {
+	quantity, err := parseDecimal(raw.quantity().Value)
+	if err != nil {
+		return einvoicemapper.Line{}, fmt.Errorf("invalid quantity")
+	}
+	price, err := parseDecimal(raw.Price.Amount.Value)
+	if err != nil {
+		return einvoicemapper.Line{}, fmt.Errorf("invalid PriceAmount")
+	}
+	if base := strings.TrimSpace(raw.Price.BaseQuantity.Value); base != "" {
+		baseQuantity, err := parseDecimal(base)
+		if err != nil || !baseQuantity.IsPositive() {
+			return einvoicemapper.Line{}, fmt.Errorf("invalid BaseQuantity")
+		}
+		price = price.Div(baseQuantity)
+	}
+	vatRate, err := parseOptionalDecimal(raw.Item.TaxCategory.Percent)
+	if err != nil {
+		return einvoicemapper.Line{}, fmt.Errorf("invalid VAT Percent")
+	}
+
+	discount := decimal.Zero
+	gross := quantity.Mul(price)
+	for _, allowance := range raw.AllowanceCharges {
+		if allowance.isCharge() {
+			continue
+		}
+		if factor := strings.TrimSpace(allowance.MultiplierFactor); factor != "" {
+			percent, err := parseDecimal(factor)
+			if err != nil {
+				return einvoicemapper.Line{}, fmt.Errorf("invalid MultiplierFactorNumeric")
+			}
+			discount = discount.Add(percent)
+			continue
+		}
+		amount, err := parseDecimal(allowance.Amount.Value)
+		if err != nil {
+			return einvoicemapper.Line{}, fmt.Errorf("invalid allowance Amount")
+		}
+		if gross.IsPositive() {
+			discount = discount.Add(amount.Div(gross).Mul(decimal.NewFromInt(100)).Round(2))
+		}
+	}
+
+	return einvoicemapper.Line{
+		Description:     firstNonEmpty(raw.Item.Name, raw.Item.Description),
+		Quantity:        quantity,
+		Unit:            strings.TrimSpace(raw.quantity().UnitCode),
+		UnitPrice:       price,
+		DiscountPercent: discount,
+		VATRate:         vatRate,
+		ReverseCharge:   strings.EqualFold(strings.TrimSpace(raw.Item.TaxCategory.ID), categoryReverseCharge),
+	}, nil
+}
+
+type documentXML struct {
+	XMLName              xml.Name
+	CustomizationID      string   `xml:"CustomizationID"`
+	ProfileID            string   `xml:"ProfileID"`
+	ID                   string   `xml:"ID"`
+	IssueDate            string   `xml:"IssueDate"`
+	DueDate              string   `xml:"DueDate"`
+	InvoiceTypeCode      string   `xml:"InvoiceTypeCode"`
+	CreditNoteTypeCode   string   `xml:"CreditNoteTypeCode"`
+	Notes                []string `xml:"Note"`
+	DocumentCurrencyCode string   `xml:"DocumentCurrencyCode"`
+	BuyerReference       string   `xml:"BuyerReference"`
+	OrderReference       struct {
+		ID string `xml:"ID"`
+	} `xml:"OrderReference"`
+	BillingReference billingReferenceXML `xml:"BillingReference"`
+	Supplier         partyWrapperXML     `xml:"AccountingSupplierParty"`
+	Customer         partyWrapperXML     `xml:"AccountingCustomerParty"`
+	PaymentMeans     []paymentMeansXML   `xml:"PaymentMeans"`
+	TaxTotals        []taxTotalXML       `xml:"TaxTotal"`
+	MonetaryTotal    monetaryTotalXML    `xml:"LegalMonetaryTotal"`
+	InvoiceLines     []lineXML           `xml:"InvoiceLine"`
+	CreditNoteLines  []lineXML           `xml:"CreditNoteLine"`
+}
+
+func (d *documentXML) isCreditNote() bool {
+	return d.XMLName.Local == "CreditNote" || strings.TrimSpace(d.InvoiceTypeCode) == "381"
+}
+
+func (d *documentXML) typeCode() string {
+	return firstNonEmpty(d.InvoiceTypeCode, d.CreditNoteTypeCode)
+}
+
+func (d *documentXML) lines() []lineXML {
+	if d.XMLName.Local == "CreditNote" {
+		return d.CreditNoteLines
+	}
+	return d.InvoiceLines
+}
+
+func (d *documentXML) paymentID() string {
+	for _, means := range d.PaymentMeans {
+		if value := strings.TrimSpace(means.PaymentID); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (d *documentXML) paymentDueDate() string {
+	for _, means := range d.PaymentMeans {
+		if value := strings.TrimSpace(means.PaymentDueDate); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+type billingReferenceXML struct {
+	InvoiceDocumentReference struct {
+		ID string `xml:"ID"`
+	} `xml:"InvoiceDocumentReference"`
+}
+
+type partyWrapperXML struct {
+	Party partyXML `xml:"Party"`
+}
+
+type partyXML struct {
+	EndpointID identifierXML `xml:"EndpointID"`
+	Name       struct {
+		Name string `xml:"Name"`
+	} `xml:"PartyName"`
+	Address struct {
+		StreetName string `xml:"StreetName"`
+		CityName   string `xml:"CityName"`
+		PostalZone string `xml:"PostalZone"`
+		Country    struct {
+			Code string `xml:"IdentificationCode"`
+		} `xml:"Country"`
+	} `xml:"PostalAddress"`
+	TaxScheme struct {
+		CompanyID string `xml:"CompanyID"`
+	} `xml:"PartyTaxScheme"`
+	LegalEntity struct {
+		RegistrationName string        `xml:"RegistrationName"`
+		CompanyID        identifierXML `xml:"CompanyID"`
+	} `xml:"PartyLegalEntity"`
+	Contact struct {
+		Email string `xml:"ElectronicMail"`
+	} `xml:"Contact"`
+}
+
+func (p partyXML) hasAddress() bool {
+	return strings.TrimSpace(p.Address.Country.Code) != "" ||
+		strings.TrimSpace(p.Address.StreetName) != "" ||
+		strings.TrimSpace(p.Address.CityName) != ""
+}
+
+type identifierXML struct {
+	SchemeID string `xml:"schemeID,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type amountXML struct {
+	CurrencyID string `xml:"currencyID,attr"`
+	Value      string `xml:",chardata"`
+}
+
+type quantityXML struct {
+	UnitCode string `xml:"unitCode,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type paymentMeansXML struct {
+	Code           string `xml:"PaymentMeansCode"`
+	PaymentDueDate string `xml:"PaymentDueDate"`
+	PaymentID      string `xml:"PaymentID"`
+}
+
+type taxTotalXML struct {
+	TaxAmount amountXML        `xml:"TaxAmount"`
+	Subtotals []taxSubtotalXML `xml:"TaxSubtotal"`
+}
+
+type taxSubtotalXML struct {
+	TaxableAmount amountXML      `xml:"TaxableAmount"`
+	TaxAmount     amountXML      `xml:"TaxAmount"`
+	Category      taxCategoryXML `xml:"TaxCategory"`
+}
+
+type taxCategoryXML struct {
+	ID              string `xml:"ID"`
+	Percent         string `xml:"Percent"`
+	ExemptionReason string `xml:"TaxExemptionReason"`
+	ExemptionCode   string `xml:"TaxExemptionReasonCode"`
+	TaxSchemeID     string `xml:"TaxScheme>ID"`
+}
+
+type monetaryTotalXML struct {
+	LineExtensionAmount   amountXML `xml:"LineExtensionAmount"`
+	TaxExclusiveAmount    amountXML `xml:"TaxExclusiveAmount"`
+	TaxInclusiveAmount    amountXML `xml:"TaxInclusiveAmount"`
+	AllowanceTotalAmount  amountXML `xml:"AllowanceTotalAmount"`
+	ChargeTotalAmount     amountXML `xml:"ChargeTotalAmount"`
+	PrepaidAmount         amountXML `xml:"PrepaidAmount"`
+	PayableRoundingAmount amountXML `xml:"PayableRoundingAmount"`
+	PayableAmount         amountXML `xml:"PayableAmount"`
+}
+
+type lineXML struct {
+	ID                  string               `xml:"ID"`
+	InvoicedQuantity    quantityXML          `xml:"InvoicedQuantity"`
+	CreditedQuantity    quantityXML          `xml:"CreditedQuantity"`
+	LineExtensionAmount amountXML            `xml:"LineExtensionAmount"`
+	AllowanceCharges    []allowanceChargeXML `xml:"AllowanceCharge"`
+	Item                struct {
+		Description string         `xml:"Description"`
+		Name        string         `xml:"Name"`
+		TaxCategory taxCategoryXML `xml:"ClassifiedTaxCategory"`
+	} `xml:"Item"`
+	Price struct {
+		Amount       amountXML   `xml:"PriceAmount"`
+		BaseQuantity quantityXML `xml:"BaseQuantity"`
+	} `xml:"Price"`
+}
+
+func (l lineXML) quantity() quantityXML {
+	if strings.TrimSpace(l.CreditedQuantity.Value) != "" {
+		return l.CreditedQuantity
+	}
+	return l.InvoicedQuantity
+}
+
+type allowanceChargeXML struct {
+	ChargeIndicator  string    `xml:"ChargeIndicator"`
+	MultiplierFactor string    `xml:"MultiplierFactorNumeric"`
+	Amount           amountXML `xml:"Amount"`
+}
+
+func (a allowanceChargeXML) isCharge() bool {
+	return strings.EqualFold(strings.TrimSpace(a.ChargeIndicator), "true")
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return trimmed
+		}
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+package ubl
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	einvoicemapper "github.com/HMB-research/open-accounting/internal/invoicing/mappers/einvoice"
+)
+
+const peppolInvoiceXML = `<?xml version="1.0" encoding="UTF-8"?>
+<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+  xmlns:cac="urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+  xmlns:cbc="urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2">
+  <cbc:CustomizationID>urn:cen.eu:en16931:2017#compliant#urn:fdc:peppol.eu:2017:poacc:billing:3.0</cbc:CustomizationID>
+  <cbc:ProfileID>urn:fdc:peppol.eu:2017:poacc:billing:01:1.0</cbc:ProfileID>
+  <cbc:ID>SUP-2026-17</cbc:ID>
+  <cbc:IssueDate>2026-04-02</cbc:IssueDate>
+  <cbc:InvoiceTypeCode>380</cbc:InvoiceTypeCode>
+  <cbc:Note>April services</cbc:Note>
+  <cbc:DocumentCurrencyCode>EUR</cbc:DocumentCurrencyCode>
+  <cbc:BuyerReference>PO-77</cbc:BuyerReference>
+  <cac:AccountingSupplierParty>
+    <cac:Party>
+      <cbc:EndpointID schemeID="0208">0123456789</cbc:EndpointID>
+      <cac:PostalAddress><cac:Country><cbc:IdentificationCode>BE</cbc:IdentificationCode></cac:Country></cac:PostalAddress>
+      <cac:PartyTaxScheme><cbc:CompanyID>BE0123456789</cbc:CompanyID><cac:TaxScheme><cbc:ID>VAT</cbc:ID></cac:TaxScheme></cac:PartyTaxScheme>
+      <cac:PartyLegalEntity><cbc:RegistrationName>Supplier BV</cbc:RegistrationName></cac:PartyLegalEntity>
+      <cac:Contact><cbc:ElectronicMail>billing@supplier.example</cbc:ElectronicMail></cac:Contact>
+    </cac:Party>
+  </cac:AccountingSupplierParty>
+  <cac:AccountingCustomerParty>
+    <cac:Party>
+      <cbc:EndpointID schemeID="0191">12345678</cbc:EndpointID>
+      <cac:PostalAddress><cac:Country><cbc:IdentificationCode>EE</cbc:IdentificationCode></cac:Country></cac:PostalAddress>
+      <cac:PartyLegalEntity><cbc:RegistrationName>Buyer OÜ</cbc:RegistrationName><cbc:CompanyID>12345678</cbc:CompanyID></cac:PartyLegalEntity>
+    </cac:Party>
+  </cac:AccountingCustomerParty>
+  <cac:PaymentMeans>
+    <cbc:PaymentMeansCode>58</cbc:PaymentMeansCode>
+    <cbc:PaymentDueDate>2026-04-30</cbc:PaymentDueDate>
+    <cbc:PaymentID>RF18539007547034</cbc:PaymentID>
+  </cac:PaymentMeans>
+  <cac:TaxTotal>
+    <cbc:TaxAmount currencyID="EUR">0.00</cbc:TaxAmount>
+    <cac:TaxSubtotal>
+      <cbc:TaxableAmount currencyID="EUR">250.00</cbc:TaxableAmount>
+      <cbc:TaxAmount currencyID="EUR">0.00</cbc:TaxAmount>
+      <cac:TaxCategory><cbc:ID>AE</cbc:ID><cbc:Percent>0</cbc:Percent><cbc:TaxExemptionReasonCode>VATEX-EU-AE</cbc:TaxExemptionReasonCode><cac:TaxScheme><cbc:ID>VAT</cbc:ID></cac:TaxScheme></cac:TaxCategory>
+    </cac:TaxSubtotal>
+  </cac:TaxTotal>
+  <cac:LegalMonetaryTotal>
+    <cbc:LineExtensionAmount currencyID="EUR">250.00</cbc:LineExtensionAmount>
+    <cbc:TaxExclusiveAmount currencyID="EUR">250.00</cbc:TaxExclusiveAmount>
+    <cbc:TaxInclusiveAmount currencyID="EUR">250.00</cbc:TaxInclusiveAmount>
+    <cbc:PayableAmount currencyID="EUR">250.00</cbc:PayableAmount>
+  </cac:LegalMonetaryTotal>
+  <cac:InvoiceLine>
+    <cbc:ID>1</cbc:ID>
+    <cbc:InvoicedQuantity unitCode="HUR">10</cbc:InvoicedQuantity>
+    <cbc:LineExtensionAmount currencyID="EUR">250.00</cbc:LineExtensionAmount>
+    <cac:Item>
+      <cbc:Name>Support hours</cbc:Name>
+      <cac:ClassifiedTaxCategory><cbc:ID>AE</cbc:ID><cbc:Percent>0</cbc:Percent><cac:TaxScheme><cbc:ID>VAT</cbc:ID></cac:TaxScheme></cac:ClassifiedTaxCategory>
+    </cac:Item>
+    <cac:Price><cbc:PriceAmount currencyID="EUR">50.00</cbc:PriceAmount><cbc:BaseQuantity unitCode="HUR">2</cbc:BaseQuantity></cac:Price>
+  </cac:InvoiceLine>
+</Invoice>`
+
+func TestParse(t *testing.T) {
+	require.True(t, IsUBL(peppolInvoiceXML))
+
+	invoices, err := Parse(peppolInvoiceXML)
+	require.NoError(t, err)
+	require.Len(t, invoices, 1)
+
+	invoice := invoices[0]
+	assert.Equal(t, "SUP-2026-17", invoice.Number)
+	assert.Equal(t, einvoicemapper.TypeDebit, invoice.Type)
+	assert.Equal(t, "Supplier BV", invoice.Seller.Name)
+	assert.Equal(t, "BE0123456789", invoice.Seller.VATRegNumber)
+	assert.Equal(t, "billing@supplier.example", invoice.Seller.Email)
+	assert.Equal(t, "12345678", invoice.Buyer.RegNumber)
+	assert.Equal(t, "2026-04-30", invoice.DueDate.Format("2006-01-02"))
+	assert.Equal(t, "RF18539007547034", invoice.Reference)
+	assert.Equal(t, "April services", invoice.Notes)
+	require.Len(t, invoice.Lines, 1)
+	line := invoice.Lines[0]
+	assert.Equal(t, "Support hours", line.Description)
+	assert.Equal(t, "HUR", line.Unit)
+	assert.True(t, line.Quantity.Equal(decimal.NewFromInt(10)))
+	assert.True(t, line.UnitPrice.Equal(decimal.NewFromInt(25)))
+	assert.True(t, line.ReverseCharge)
+}
+
+func TestParseRejectsInvalidDocuments(t *testing.T) {
+	_, err := Parse("")
+	require.ErrorContains(t, err, "xml_content is required")
+
+	_, err = Parse("<Invoice>")
+	require.ErrorContains(t, err, "parse UBL XML")
+
+	_, err = Parse(`<Order xmlns="urn:oasis:names:specification:ubl:schema:xsd:Order-2"/>`)
+	require.ErrorContains(t, err, "root element must be Invoice or CreditNote")
+
+	_, err = Parse(`<Invoice xmlns="urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"/>`)
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "BR-01", validationErr.Violations[0].Rule)
+
+	assert.False(t, IsUBL(`<E_Invoice><Invoice/></E_Invoice>`))
+	assert.False(t, IsUBL("not xml"))
+}
@@ -0,0 +1,271 @@
+package ubl
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Violation is a failed EN 16931 or Peppol business rule.
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	return v.Rule + ": " + v.Message
+}
+
+// ValidationError lists every business rule a document fails.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		parts = append(parts, violation.String())
+	}
+	return "UBL document fails EN 16931 validation: " + strings.Join(parts, "; ")
+}
+
+// Validate checks a UBL 2.1 Invoice or CreditNote against the EN 16931 core
+// business rules supported offline. An error is returned only when the
+// document cannot be parsed; rule failures are returned as violations.
+func Validate(content string) ([]Violation, error) {
+	doc, err := decodeDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	return validateDocument(doc), nil
+}
+
+type ruleSet struct {
+	violations []Violation
+}
+
+func (r *ruleSet) check(ok bool, rule, format string, args ...any) {
+	if !ok {
+		r.violations = append(r.violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+// amount parses a monetary amount for a rule. Missing amounts are reported by
+// the presence rules, so they only produce a format violation when non-empty.
+func (r *ruleSet) amount(raw amountXML, name string) (decimal.Decimal, bool) {
+	value := strings.TrimSpace(raw.Value)
+	if value == "" {
+		return decimal.Zero, false
+	}
+	parsed, err := parseDecimal(value)
+	if err != nil {
+		r.check(false, "UBL-DT-01", "%s %q is not a valid amount", name, value)
+		return decimal.Zero, false
+	}
+	return parsed, true
+}
+
+func validateDocument(doc *documentXML) []Violation {
+	rules := &ruleSet{}
+	present := func(value string) bool { return strings.TrimSpace(value) != "" }
+
+	rules.check(present(doc.CustomizationID), "BR-01", "An Invoice shall have a Specification identifier (CustomizationID)")
+	rules.check(present(doc.ID), "BR-02", "An Invoice shall have an Invoice number (ID)")
+	rules.check(present(doc.IssueDate), "BR-03", "An Invoice shall have an Invoice issue date (IssueDate)")
+	if present(doc.IssueDate) {
+		_, err := time.Parse("2006-01-02", strings.TrimSpace(doc.IssueDate))
+		rules.check(err == nil, "UBL-DT-02", "IssueDate %q must use YYYY-MM-DD", strings.TrimSpace(doc.IssueDate))
+	}
+	rules.check(present(doc.typeCode()), "BR-04", "An Invoice shall have an Invoice type code")
+	rules.check(present(doc.DocumentCurrencyCode), "BR-05", "An Invoice shall have an Invoice currency code (DocumentCurrencyCode)")
+
+	seller := doc.Supplier.Party
+	buyer := doc.Customer.Party
+	rules.check(present(seller.LegalEntity.RegistrationName), "BR-06", "An Invoice shall contain the Seller name (PartyLegalEntity/RegistrationName)")
+	rules.check(present(buyer.LegalEntity.RegistrationName), "BR-07", "An Invoice shall contain the Buyer name (PartyLegalEntity/RegistrationName)")
+	rules.check(seller.hasAddress(), "BR-08", "An Invoice shall contain the Seller postal address")
+	rules.check(present(seller.Address.Country.Code), "BR-09", "The Seller postal address shall contain a Seller country code")
+	rules.check(buyer.hasAddress(), "BR-10", "An Invoice shall contain the Buyer postal address")
+	rules.check(present(buyer.Address.Country.Code), "BR-11", "The Buyer postal address shall contain a Buyer country code")
+	rules.check(present(seller.LegalEntity.CompanyID.Value) || present(seller.TaxScheme.CompanyID), "BR-CO-26",
+		"The Seller legal registration identifier or Seller VAT identifier shall be present")
+	rules.check(present(seller.EndpointID.Value), "PEPPOL-EN16931-R020", "Seller electronic address (EndpointID) MUST be provided")
+	rules.check(present(buyer.EndpointID.Value), "PEPPOL-EN16931-R010", "Buyer electronic address (EndpointID) MUST be provided")
+	rules.check(present(doc.BuyerReference) || present(doc.OrderReference.ID), "PEPPOL-EN16931-R003",
+		"A buyer reference or purchase order reference MUST be provided")
+	for _, party := range []struct {
+		name     string
+		endpoint identifierXML
+	}{{"Seller", seller.EndpointID}, {"Buyer", buyer.EndpointID}} {
+		if present(party.endpoint.Value) {
+			rules.check(present(party.endpoint.SchemeID), "BR-62", "The %s electronic address shall have a Scheme identifier", party.name)
+		}
+	}
+
+	total := doc.MonetaryTotal
+	rules.check(present(total.LineExtensionAmount.Value), "BR-12", "An Invoice shall have the Sum of Invoice line net amount (LineExtensionAmount)")
+	rules.check(present(total.TaxExclusiveAmount.Value), "BR-13", "An Invoice shall have the Invoice total amount without VAT (TaxExclusiveAmount)")
+	rules.check(present(total.TaxInclusiveAmount.Value), "BR-14", "An Invoice shall have the Invoice total amount with VAT (TaxInclusiveAmount)")
+	rules.check(present(total.PayableAmount.Value), "BR-15", "An Invoice shall have the Amount due for payment (PayableAmount)")
+
+	lines := doc.lines()
+	rules.check(len(lines) > 0, "BR-16", "An Invoice shall have at least one Invoice line")
+
+	lineSum := decimal.Zero
+	for index, line := range lines {
+		label := strings.TrimSpace(line.ID)
+		if label == "" {
+			label = fmt.Sprintf("#%d", index+1)
+		}
+		rules.check(present(line.ID), "BR-21", "Each Invoice line shall have an Invoice line identifier (line %s)", label)
+		rules.check(present(line.quantity().Value), "BR-22", "Each Invoice line shall have an Invoiced quantity (line %s)", label)
+		rules.check(present(line.LineExtensionAmount.Value), "BR-24", "Each Invoice line shall have an Invoice line net amount (line %s)", label)
+		rules.check(present(line.Item.Name), "BR-25", "Each Invoice line shall contain the Item name (line %s)", label)
+		rules.check(present(line.Price.Amount.Value), "BR-26", "Each Invoice line shall contain the Item net price (line %s)", label)
+		category := strings.ToUpper(strings.TrimSpace(line.Item.TaxCategory.ID))
+		rules.check(category != "", "BR-CO-04", "Each Invoice line shall be categorized with an Invoiced item VAT category code (line %s)", label)
+
+		price, hasPrice := rules.amount(line.Price.Amount, "PriceAmount")
+		if hasPrice {
+			rules.check(!price.IsNegative(), "BR-27", "The Item net price shall NOT be negative (line %s)", label)
+		}
+		rate, err := parseOptionalDecimal(line.Item.TaxCategory.Percent)
+		if err != nil {
+			rules.check(false, "UBL-DT-01", "VAT Percent %q is not a valid number (line %s)", line.Item.TaxCategory.Percent, label)
+		}
+		switch category {
+		case categoryStandard:
+			rules.check(rate.IsPositive(), "BR-S-05", "A Standard rated line shall have a VAT rate greater than zero (line %s)", label)
+		case categoryReverseCharge:
+			rules.check(rate.IsZero(), "BR-AE-05", "A Reverse charge line shall have a VAT rate of zero (line %s)", label)
+		case categoryZero:
+			rules.check(rate.IsZero(), "BR-Z-05", "A Zero rated line shall have a VAT rate of zero (line %s)", label)
+		}
+
+		net, hasNet := rules.amount(line.LineExtensionAmount, "LineExtensionAmount")
+		if !hasNet {
+			continue
+		}
+		lineSum = lineSum.Add(net)
+		quantity, qtyErr := parseDecimal(line.quantity().Value)
+		if !hasPrice || qtyErr != nil {
+			continue
+		}
+		if base := strings.TrimSpace(line.Price.BaseQuantity.Value); base != "" {
+			baseQuantity, err := parseDecimal(base)
+			if err != nil || !baseQuantity.IsPositive() {
+				continue
+			}
+			price = price.Div(baseQuantity)
+		}
+		expected := quantity.Mul(price)
+		for _, allowance := range line.AllowanceCharges {
+			amount, ok := rules.amount(allowance.Amount, "AllowanceCharge Amount")
+			if !ok {
+				continue
+			}
+			if allowance.isCharge() {
+				expected = expected.Add(amount)
+			} else {
+				expected = expected.Sub(amount)
+			}
+		}
+		rules.check(roundedEqual(expected, net), "PEPPOL-EN16931-R120",
+			"Invoice line net amount %s must equal quantity x net price + charges - allowances = %s (line %s)", net.StringFixed(2), expected.StringFixed(2), label)
+	}
+
+	lineTotal, hasLineTotal := rules.amount(total.LineExtensionAmount, "LineExtensionAmount")
+	if hasLineTotal {
+		rules.check(roundedEqual(lineTotal, lineSum), "BR-CO-10",
+			"Sum of Invoice line net amount %s must equal the sum of line net amounts %s", lineTotal.StringFixed(2), lineSum.StringFixed(2))
+	}
+	allowances, _ := rules.amount(total.AllowanceTotalAmount, "AllowanceTotalAmount")
+	charges, _ := rules.amount(total.ChargeTotalAmount, "ChargeTotalAmount")
+	taxExclusive, hasTaxExclusive := rules.amount(total.TaxExclusiveAmount, "TaxExclusiveAmount")
+	if hasLineTotal && hasTaxExclusive {
+		expected := lineTotal.Sub(allowances).Add(charges)
+		rules.check(roundedEqual(taxExclusive, expected), "BR-CO-13",
+			"Invoice total amount without VAT %s must equal line total - allowances + charges = %s", taxExclusive.StringFixed(2), expected.StringFixed(2))
+	}
+
+	vatTotal := decimal.Zero
+	var breakdownCount int
+	if len(doc.TaxTotals) > 0 {
+		documentTotal := doc.TaxTotals[0]
+		vatTotal, _ = rules.amount(documentTotal.TaxAmount, "TaxAmount")
+		breakdownSum := decimal.Zero
+		for _, subtotal := range documentTotal.Subtotals {
+			breakdownCount++
+			tax, _ := rules.amount(subtotal.TaxAmount, "TaxSubtotal TaxAmount")
+			breakdownSum = breakdownSum.Add(tax)
+			category := strings.ToUpper(strings.TrimSpace(subtotal.Category.ID))
+			rules.check(present(subtotal.TaxableAmount.Value), "BR-45", "Each VAT breakdown shall have a VAT category taxable amount")
+			rules.check(present(subtotal.TaxAmount.Value), "BR-46", "Each VAT breakdown shall have a VAT category tax amount")
+			rules.check(category != "", "BR-47", "Each VAT breakdown shall be defined through a VAT category code")
+			if category == categoryReverseCharge {
+				rules.check(present(subtotal.Category.ExemptionReason) || present(subtotal.Category.ExemptionCode), "BR-AE-10",
+					"A Reverse charge VAT breakdown shall have a VAT exemption reason code or text")
+			}
+		}
+		rules.check(roundedEqual(vatTotal, breakdownSum), "BR-CO-14",
+			"Invoice total VAT amount %s must equal the sum of VAT category tax amounts %s", vatTotal.StringFixed(2), breakdownSum.StringFixed(2))
+	}
+	rules.check(breakdownCount > 0, "BR-CO-18", "An Invoice shall at least have one VAT breakdown group")
+
+	taxInclusive, hasTaxInclusive := rules.amount(total.TaxInclusiveAmount, "TaxInclusiveAmount")
+	if hasTaxExclusive && hasTaxInclusive {
+		expected := taxExclusive.Add(vatTotal)
+		rules.check(roundedEqual(taxInclusive, expected), "BR-CO-15",
+			"Invoice total amount with VAT %s must equal total without VAT + total VAT = %s", taxInclusive.StringFixed(2), expected.StringFixed(2))
+	}
+	prepaid, _ := rules.amount(total.PrepaidAmount, "PrepaidAmount")
+	rounding, _ := rules.amount(total.PayableRoundingAmount, "PayableRoundingAmount")
+	payable, hasPayable := rules.amount(total.PayableAmount, "PayableAmount")
+	if hasTaxInclusive && hasPayable {
+		expected := taxInclusive.Sub(prepaid).Add(rounding)
+		rules.check(roundedEqual(payable, expected), "BR-CO-16",
+			"Amount due for payment %s must equal total with VAT - paid amount + rounding = %s", payable.StringFixed(2), expected.StringFixed(2))
+	}
+
+	return rules.violations
+}
+
+func roundedEqual(a, b decimal.Decimal) bool {
+	return a.Round(2).Equal(b.Round(2))
+}
+
+func parseDate(value string) (time.Time, error) {
+	parsed, err := parseOptionalDate(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if parsed.IsZero() {
+		return time.Time{}, fmt.Errorf("missing date")
+	}
+	return parsed, nil
+}
+
+func parseOptionalDate(value string) (time.Time, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return time.Time{}, nil
+	}
+	parsed, err := time.Parse("2006-01-02", trimmed)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(parsed.Year(), parsed.Month(), parsed.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+func parseOptionalDecimal(value string) (decimal.Decimal, error) {
+	if strings.TrimSpace(value) == "" {
+		return decimal.Zero, nil
+	}
+	return parseDecimal(value)
+}
+
+func parseDecimal(value string) (decimal.Decimal, error) {
+	return decimal.NewFromString(strings.TrimSpace(value))
+}
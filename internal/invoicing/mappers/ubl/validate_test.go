@@ -0,0 +1,78 @@
+package ubl
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func violatedRules(t *testing.T, content string) []string {
+	t.Helper()
+	violations, err := Validate(content)
+	require.NoError(t, err)
+	rules := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		rules = append(rules, violation.Rule)
+	}
+	return rules
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, violatedRules(t, peppolInvoiceXML))
+
+	tests := []struct {
+		name    string
+		replace [2]string
+		rule    string
+	}{
+		{name: "missing invoice number", replace: [2]string{"<cbc:ID>SUP-2026-17</cbc:ID>", ""}, rule: "BR-02"},
+		{name: "missing currency", replace: [2]string{"<cbc:DocumentCurrencyCode>EUR</cbc:DocumentCurrencyCode>", ""}, rule: "BR-05"},
+		{name: "missing seller name", replace: [2]string{"<cbc:RegistrationName>Supplier BV</cbc:RegistrationName>", ""}, rule: "BR-06"},
+		{name: "missing buyer country", replace: [2]string{"<cbc:IdentificationCode>EE</cbc:IdentificationCode>", ""}, rule: "BR-11"},
+		{name: "missing endpoint scheme", replace: [2]string{`<cbc:EndpointID schemeID="0191">`, "<cbc:EndpointID>"}, rule: "BR-62"},
+		{name: "missing buyer reference", replace: [2]string{"<cbc:BuyerReference>PO-77</cbc:BuyerReference>", ""}, rule: "PEPPOL-EN16931-R003"},
+		{name: "missing item name", replace: [2]string{"<cbc:Name>Support hours</cbc:Name>", ""}, rule: "BR-25"},
+		{name: "negative price", replace: [2]string{`<cbc:PriceAmount currencyID="EUR">50.00`, `<cbc:PriceAmount currencyID="EUR">-50.00`}, rule: "BR-27"},
+		{name: "line net mismatch", replace: [2]string{`<cbc:InvoicedQuantity unitCode="HUR">10`, `<cbc:InvoicedQuantity unitCode="HUR">11`}, rule: "PEPPOL-EN16931-R120"},
+		{name: "line total mismatch", replace: [2]string{`<cbc:LineExtensionAmount currencyID="EUR">250.00</cbc:LineExtensionAmount>
+    <cbc:TaxExclusiveAmount`, `<cbc:LineExtensionAmount currencyID="EUR">260.00</cbc:LineExtensionAmount>
+    <cbc:TaxExclusiveAmount`}, rule: "BR-CO-10"},
+		{name: "tax inclusive mismatch", replace: [2]string{`<cbc:TaxInclusiveAmount currencyID="EUR">250.00`, `<cbc:TaxInclusiveAmount currencyID="EUR">255.00`}, rule: "BR-CO-15"},
+		{name: "payable mismatch", replace: [2]string{`<cbc:PayableAmount currencyID="EUR">250.00`, `<cbc:PayableAmount currencyID="EUR">240.00`}, rule: "BR-CO-16"},
+		{name: "reverse charge needs exemption reason", replace: [2]string{"<cbc:TaxExemptionReasonCode>VATEX-EU-AE</cbc:TaxExemptionReasonCode>", ""}, rule: "BR-AE-10"},
+		{name: "reverse charge rate", replace: [2]string{"<cbc:ID>AE</cbc:ID><cbc:Percent>0</cbc:Percent><cac:TaxScheme>", "<cbc:ID>AE</cbc:ID><cbc:Percent>24</cbc:Percent><cac:TaxScheme>"}, rule: "BR-AE-05"},
+		{name: "invalid amount", replace: [2]string{`<cbc:TaxAmount currencyID="EUR">0.00</cbc:TaxAmount>
+    <cac:TaxSubtotal>`, `<cbc:TaxAmount currencyID="EUR">zero</cbc:TaxAmount>
+    <cac:TaxSubtotal>`}, rule: "UBL-DT-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Contains(t, peppolInvoiceXML, tt.replace[0])
+			content := strings.Replace(peppolInvoiceXML, tt.replace[0], tt.replace[1], 1)
+			assert.Contains(t, violatedRules(t, content), tt.rule)
+		})
+	}
+}
+
+func TestValidateReportsMissingVATBreakdown(t *testing.T) {
+	start := strings.Index(peppolInvoiceXML, "<cac:TaxTotal>")
+	end := strings.Index(peppolInvoiceXML, "</cac:TaxTotal>") + len("</cac:TaxTotal>")
+	content := peppolInvoiceXML[:start] + peppolInvoiceXML[end:]
+
+	rules := violatedRules(t, content)
+	assert.Contains(t, rules, "BR-CO-18")
+
+	_, err := Validate("<Invoice>")
+	require.ErrorContains(t, err, "parse UBL XML")
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Violations: []Violation{
+		{Rule: "BR-02", Message: "An Invoice shall have an Invoice number (ID)"},
+		{Rule: "BR-16", Message: "An Invoice shall have at least one Invoice line"},
+	}}
+	assert.Equal(t, "UBL document fails EN 16931 validation: BR-02: An Invoice shall have an Invoice number (ID); BR-16: An Invoice shall have at least one Invoice line", err.Error())
+}
@@ -0,0 +1,323 @@
+package invoicing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// BillingKind selects how an invoice bills part of an order or quote
+type BillingKind string
+
+const (
+	// BillingKindQuantity bills chosen line quantities, or every due quantity
+	BillingKindQuantity BillingKind = "QUANTITY"
+	// BillingKindPercentage bills a percentage of every line's ordered quantity
+	BillingKindPercentage BillingKind = "PERCENTAGE"
+	// BillingKindPrepayment bills an advance that later invoices net off
+	BillingKindPrepayment BillingKind = "PREPAYMENT"
+)
+
+// ErrNothingToInvoice is returned when a document has no quantity left to bill
+var ErrNothingToInvoice = errors.New("nothing left to invoice")
+
+// BillableLine is an order or quote line as seen by partial invoicing.
+// DueQuantity is the quantity a default conversion bills up to and that must
+// be invoiced before the document counts as fully invoiced.
+type BillableLine struct {
+	ID               string
+	LineNumber       int
+	Description      string
+	Unit             string
+	ProductID        *string
+	Quantity         decimal.Decimal
+	InvoicedQuantity decimal.Decimal
+	DueQuantity      decimal.Decimal
+	UnitPrice        decimal.Decimal
+	DiscountPercent  decimal.Decimal
+	VATRate          decimal.Decimal
+}
+
+// uninvoiced returns the ordered quantity not yet invoiced
+func (l *BillableLine) uninvoiced() decimal.Decimal {
+	return nonNegative(l.Quantity.Sub(l.InvoicedQuantity))
+}
+
+// subtotal returns the net amount of a quantity of the line, rounded like invoice lines
+func (l *BillableLine) subtotal(quantity decimal.Decimal) decimal.Decimal {
+	gross := quantity.Mul(l.UnitPrice)
+	discount := gross.Mul(l.DiscountPercent).Div(decimal.NewFromInt(100))
+	return gross.Sub(discount).Round(2)
+}
+
+// Prepayment is an earlier prepayment invoice of a document. Amount is the net
+// amount billed in advance and Netted the part already deducted.
+type Prepayment struct {
+	BillingID     string
+	InvoiceID     string
+	InvoiceNumber string
+	VATRate       decimal.Decimal
+	Amount        decimal.Decimal
+	Netted        decimal.Decimal
+}
+
+// Remaining returns the prepaid amount not yet netted off
+func (p *Prepayment) Remaining() decimal.Decimal {
+	return nonNegative(p.Amount.Sub(p.Netted))
+}
+
+// PartialInvoiceRequest selects what an invoice bills from an order or quote.
+// Without lines, percent or a prepayment kind every due quantity is billed.
+type PartialInvoiceRequest struct {
+	Kind    BillingKind                 `json:"kind,omitempty"`
+	Lines   []PartialInvoiceLineRequest `json:"lines,omitempty"`
+	Percent decimal.Decimal             `json:"percent,omitempty"`
+	// Amount is the net prepayment amount; with percent instead, the
+	// prepayment is that share of the document subtotal.
+	Amount decimal.Decimal `json:"amount,omitempty"`
+	// VATRate is required for prepayments of documents with mixed VAT rates
+	VATRate *decimal.Decimal `json:"vat_rate,omitempty"`
+}
+
+// PartialInvoiceLineRequest bills a quantity of one document line
+type PartialInvoiceLineRequest struct {
+	LineID   string          `json:"line_id"`
+	Quantity decimal.Decimal `json:"quantity"`
+}
+
+// IsPartial reports whether the request bills less than the due quantities
+func (r *PartialInvoiceRequest) IsPartial() bool {
+	return (r.Kind != "" && r.Kind != BillingKindQuantity) || len(r.Lines) > 0 || !r.Percent.IsZero() || !r.Amount.IsZero()
+}
+
+// PartialInvoicePlan is the invoice content planned for a document together
+// with the bookkeeping to record once the invoice exists.
+type PartialInvoicePlan struct {
+	Kind    BillingKind
+	Percent decimal.Decimal
+	Lines   []CreateInvoiceLineRequest
+	// Quantities holds the quantity billed per document line ID
+	Quantities map[string]decimal.Decimal
+	// Deductions holds the amount netted per prepayment billing ID
+	Deductions         map[string]decimal.Decimal
+	Subtotal           decimal.Decimal
+	PrepaymentDeducted decimal.Decimal
+	VATRate            decimal.Decimal
+	// Completes is true when the invoice bills every remaining due quantity
+	Completes bool
+}
+
+// PlanPartialInvoice plans the next invoice of a document. Label names the
+// document on prepayment lines, e.g. "order ORD-00001". Quantity and
+// percentage invoices net off open prepayments in proportion to what they
+// bill, and the invoice completing the document nets off the rest.
+func PlanPartialInvoice(label string, lines []BillableLine, prepayments []Prepayment, req *PartialInvoiceRequest) (*PartialInvoicePlan, error) {
+	if req == nil {
+		req = &PartialInvoiceRequest{}
+	}
+	kind := req.Kind
+	if kind == "" {
+		kind = BillingKindQuantity
+		if !req.Percent.IsZero() && len(req.Lines) == 0 {
+			kind = BillingKindPercentage
+		}
+	}
+	hundred := decimal.NewFromInt(100)
+	if req.Percent.IsNegative() || req.Percent.GreaterThan(hundred) {
+		return nil, errors.New("percent must be between 0 and 100")
+	}
+
+	plan := &PartialInvoicePlan{
+		Kind:       kind,
+		Percent:    req.Percent,
+		Quantities: map[string]decimal.Decimal{},
+		Deductions: map[string]decimal.Decimal{},
+	}
+	switch kind {
+	case BillingKindPrepayment:
+		if len(req.Lines) > 0 {
+			return nil, errors.New("prepayment invoices cannot bill lines")
+		}
+		if err := planPrepayment(plan, label, lines, prepayments, req); err != nil {
+			return nil, err
+		}
+		return plan, nil
+	case BillingKindPercentage:
+		if len(req.Lines) > 0 {
+			return nil, errors.New("percentage invoices cannot select lines")
+		}
+		if !req.Percent.IsPositive() {
+			return nil, errors.New("percent is required for percentage invoices")
+		}
+		for i := range lines {
+			quantity := decimal.Min(lines[i].Quantity.Mul(req.Percent).Div(hundred).Round(6), lines[i].uninvoiced())
+			if quantity.IsPositive() {
+				plan.Quantities[lines[i].ID] = quantity
+			}
+		}
+	case BillingKindQuantity:
+		if !req.Percent.IsZero() || !req.Amount.IsZero() {
+			return nil, errors.New("percent and amount apply only to percentage and prepayment invoices")
+		}
+		if len(req.Lines) == 0 {
+			for i := range lines {
+				quantity := nonNegative(lines[i].DueQuantity.Sub(lines[i].InvoicedQuantity))
+				if quantity.IsPositive() {
+					plan.Quantities[lines[i].ID] = quantity
+				}
+			}
+			break
+		}
+		for i, selection := range req.Lines {
+			line := findBillableLine(lines, strings.TrimSpace(selection.LineID))
+			if line == nil {
+				return nil, fmt.Errorf("line %d: line %q not found", i+1, selection.LineID)
+			}
+			if _, ok := plan.Quantities[line.ID]; ok {
+				return nil, fmt.Errorf("line %d: line %q selected more than once", i+1, selection.LineID)
+			}
+			if !selection.Quantity.IsPositive() {
+				return nil, fmt.Errorf("line %d: quantity must be positive", i+1)
+			}
+			if selection.Quantity.GreaterThan(line.uninvoiced()) {
+				return nil, fmt.Errorf("line %d: quantity %s exceeds uninvoiced quantity %s", i+1, selection.Quantity, line.uninvoiced())
+			}
+			plan.Quantities[line.ID] = selection.Quantity
+		}
+	default:
+		return nil, fmt.Errorf("kind must be %s, %s or %s", BillingKindQuantity, BillingKindPercentage, BillingKindPrepayment)
+	}
+	if len(plan.Quantities) == 0 {
+		return nil, ErrNothingToInvoice
+	}
+
+	documentSubtotal := decimal.Zero
+	plan.Completes = true
+	for i := range lines {
+		line := &lines[i]
+		documentSubtotal = documentSubtotal.Add(line.subtotal(line.Quantity))
+		quantity, ok := plan.Quantities[line.ID]
+		if line.InvoicedQuantity.Add(quantity).LessThan(line.DueQuantity) {
+			plan.Completes = false
+		}
+		if !ok {
+			continue
+		}
+		plan.Subtotal = plan.Subtotal.Add(line.subtotal(quantity))
+		plan.Lines = append(plan.Lines, CreateInvoiceLineRequest{
+			Description:     line.Description,
+			Quantity:        quantity,
+			Unit:            line.Unit,
+			UnitPrice:       new(line.UnitPrice),
+			DiscountPercent: line.DiscountPercent,
+			VATRate:         line.VATRate,
+			ProductID:       line.ProductID,
+		})
+	}
+
+	// Net off prepayments, never more than this invoice bills
+	available := plan.Subtotal
+	for i := range prepayments {
+		prepayment := &prepayments[i]
+		deduction := prepayment.Remaining()
+		if !plan.Completes && documentSubtotal.IsPositive() {
+			deduction = decimal.Min(deduction, prepayment.Amount.Mul(plan.Subtotal).Div(documentSubtotal).Round(2))
+		}
+		deduction = decimal.Min(deduction, available)
+		if !deduction.IsPositive() {
+			continue
+		}
+		available = available.Sub(deduction)
+		plan.Deductions[prepayment.BillingID] = deduction
+		plan.PrepaymentDeducted = plan.PrepaymentDeducted.Add(deduction)
+		invoiceID := prepayment.InvoiceID
+		plan.Lines = append(plan.Lines, CreateInvoiceLineRequest{
+			Description:         "Less prepayment " + prepayment.InvoiceNumber,
+			Quantity:            decimal.NewFromInt(1),
+			UnitPrice:           new(deduction.Neg()),
+			VATRate:             prepayment.VATRate,
+			PrepaymentInvoiceID: &invoiceID,
+		})
+	}
+	return plan, nil
+}
+
+// planPrepayment fills a plan with a single advance line, capped so that open
+// prepayments never exceed what is left to invoice
+func planPrepayment(plan *PartialInvoicePlan, label string, lines []BillableLine, prepayments []Prepayment, req *PartialInvoiceRequest) error {
+	documentSubtotal := decimal.Zero
+	uninvoiced := decimal.Zero
+	var rates []decimal.Decimal
+	for i := range lines {
+		documentSubtotal = documentSubtotal.Add(lines[i].subtotal(lines[i].Quantity))
+		uninvoiced = uninvoiced.Add(lines[i].subtotal(lines[i].uninvoiced()))
+		if !containsDecimal(rates, lines[i].VATRate) {
+			rates = append(rates, lines[i].VATRate)
+		}
+	}
+	for i := range prepayments {
+		uninvoiced = uninvoiced.Sub(prepayments[i].Remaining())
+	}
+
+	amount := req.Amount
+	switch {
+	case !amount.IsZero() && !req.Percent.IsZero():
+		return errors.New("give either a prepayment amount or a percent, not both")
+	case !req.Percent.IsZero():
+		amount = documentSubtotal.Mul(req.Percent).Div(decimal.NewFromInt(100)).Round(2)
+	}
+	if !amount.IsPositive() {
+		return errors.New("prepayment amount must be positive")
+	}
+	if amount.GreaterThan(uninvoiced) {
+		return fmt.Errorf("prepayment %s exceeds the %s left to invoice", amount.StringFixed(2), nonNegative(uninvoiced).StringFixed(2))
+	}
+
+	switch {
+	case req.VATRate != nil:
+		if req.VATRate.IsNegative() {
+			return errors.New("vat_rate cannot be negative")
+		}
+		plan.VATRate = *req.VATRate
+	case len(rates) == 1:
+		plan.VATRate = rates[0]
+	default:
+		return errors.New("vat_rate is required when lines have different VAT rates")
+	}
+
+	plan.Subtotal = amount
+	plan.Lines = []CreateInvoiceLineRequest{{
+		Description: "Prepayment for " + label,
+		Quantity:    decimal.NewFromInt(1),
+		UnitPrice:   new(amount),
+		VATRate:     plan.VATRate,
+	}}
+	return nil
+}
+
+func findBillableLine(lines []BillableLine, id string) *BillableLine {
+	for i := range lines {
+		if lines[i].ID == id {
+			return &lines[i]
+		}
+	}
+	return nil
+}
+
+func containsDecimal(values []decimal.Decimal, value decimal.Decimal) bool {
+	for _, v := range values {
+		if v.Equal(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func nonNegative(value decimal.Decimal) decimal.Decimal {
+	if value.IsNegative() {
+		return decimal.Zero
+	}
+	return value
+}
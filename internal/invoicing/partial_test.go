@@ -0,0 +1,123 @@
+package invoicing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func partialTestLines() []BillableLine {
+	return []BillableLine{
+		{ID: "line-1", LineNumber: 1, Description: "Hardware", Quantity: decimal.NewFromInt(3), DueQuantity: decimal.NewFromInt(2), UnitPrice: decimal.NewFromInt(100), VATRate: decimal.NewFromInt(22)},
+		{ID: "line-2", LineNumber: 2, Description: "Installation", Quantity: decimal.NewFromInt(1), DueQuantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(200), DiscountPercent: decimal.NewFromInt(10), VATRate: decimal.NewFromInt(9)},
+	}
+}
+
+func TestPlanPartialInvoiceDefaultBillsDueQuantities(t *testing.T) {
+	plan, err := PlanPartialInvoice("order ORD-1", partialTestLines(), nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, BillingKindQuantity, plan.Kind)
+	require.Len(t, plan.Lines, 2)
+	assert.True(t, plan.Lines[0].Quantity.Equal(decimal.NewFromInt(2)))
+	assert.True(t, plan.Subtotal.Equal(decimal.NewFromInt(380)))
+	assert.True(t, plan.Completes)
+
+	lines := partialTestLines()
+	lines[0].InvoicedQuantity = decimal.NewFromInt(2)
+	lines[1].InvoicedQuantity = decimal.NewFromInt(1)
+	_, err = PlanPartialInvoice("order ORD-1", lines, nil, nil)
+	assert.ErrorIs(t, err, ErrNothingToInvoice)
+}
+
+func TestPlanPartialInvoicePrepaymentNetting(t *testing.T) {
+	lines := partialTestLines()
+	rate := decimal.NewFromInt(22)
+
+	_, err := PlanPartialInvoice("order ORD-1", lines, nil, &PartialInvoiceRequest{Kind: BillingKindPrepayment, Amount: decimal.NewFromInt(100)})
+	assert.EqualError(t, err, "vat_rate is required when lines have different VAT rates")
+
+	plan, err := PlanPartialInvoice("order ORD-1", lines, nil, &PartialInvoiceRequest{Kind: BillingKindPrepayment, Amount: decimal.NewFromInt(240), VATRate: &rate})
+	require.NoError(t, err)
+	require.Len(t, plan.Lines, 1)
+	assert.Equal(t, "Prepayment for order ORD-1", plan.Lines[0].Description)
+	assert.Empty(t, plan.Quantities)
+	assert.False(t, plan.Completes)
+
+	prepayments := []Prepayment{{BillingID: "billing-1", InvoiceID: "invoice-1", InvoiceNumber: "INV-1", VATRate: rate, Amount: decimal.NewFromInt(240)}}
+	_, err = PlanPartialInvoice("order ORD-1", lines, prepayments, &PartialInvoiceRequest{Kind: BillingKindPrepayment, Amount: decimal.NewFromInt(241), VATRate: &rate})
+	assert.EqualError(t, err, "prepayment 241.00 exceeds the 240.00 left to invoice")
+
+	// One of three hardware units bills a fifth of the document subtotal
+	plan, err = PlanPartialInvoice("order ORD-1", lines, prepayments, &PartialInvoiceRequest{
+		Lines: []PartialInvoiceLineRequest{{LineID: "line-1", Quantity: decimal.NewFromInt(1)}},
+	})
+	require.NoError(t, err)
+	require.Len(t, plan.Lines, 2)
+	assert.True(t, plan.Deductions["billing-1"].Equal(decimal.NewFromInt(50)))
+	assert.True(t, plan.Lines[1].UnitPrice.Equal(decimal.NewFromInt(-50)))
+	require.NotNil(t, plan.Lines[1].PrepaymentInvoiceID)
+	assert.Equal(t, "invoice-1", *plan.Lines[1].PrepaymentInvoiceID)
+
+	invoice := &Invoice{ContactID: "contact-1", IssueDate: time.Now(), DueDate: time.Now()}
+	for i, line := range plan.Lines {
+		invoice.Lines = append(invoice.Lines, InvoiceLine{LineNumber: i + 1, Description: line.Description, Quantity: line.Quantity, UnitPrice: line.unitPrice(), VATRate: line.VATRate, PrepaymentInvoiceID: line.PrepaymentInvoiceID})
+	}
+	invoice.Calculate()
+	require.NoError(t, invoice.Validate())
+	assert.True(t, invoice.Subtotal.Equal(decimal.NewFromInt(50)))
+
+	// The invoice completing the document nets off the whole remainder
+	prepayments[0].Netted = decimal.NewFromInt(50)
+	lines[0].InvoicedQuantity = decimal.NewFromInt(1)
+	plan, err = PlanPartialInvoice("order ORD-1", lines, prepayments, nil)
+	require.NoError(t, err)
+	assert.True(t, plan.Completes)
+	assert.True(t, plan.PrepaymentDeducted.Equal(decimal.NewFromInt(190)))
+}
+
+func TestPlanPartialInvoicePercentage(t *testing.T) {
+	plan, err := PlanPartialInvoice("quote Q-1", partialTestLines(), nil, &PartialInvoiceRequest{Percent: decimal.NewFromInt(50)})
+	require.NoError(t, err)
+
+	assert.Equal(t, BillingKindPercentage, plan.Kind)
+	assert.True(t, plan.Quantities["line-1"].Equal(decimal.NewFromFloat(1.5)))
+	assert.True(t, plan.Quantities["line-2"].Equal(decimal.NewFromFloat(0.5)))
+	assert.False(t, plan.Completes)
+}
+
+func TestPlanPartialInvoiceValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		req  PartialInvoiceRequest
+		want string
+	}{
+		{name: "unknown kind", req: PartialInvoiceRequest{Kind: "MILESTONE"}, want: "kind must be QUANTITY, PERCENTAGE or PREPAYMENT"},
+		{name: "percent out of range", req: PartialInvoiceRequest{Percent: decimal.NewFromInt(120)}, want: "percent must be between 0 and 100"},
+		{name: "unknown line", req: PartialInvoiceRequest{Lines: []PartialInvoiceLineRequest{{LineID: "missing", Quantity: decimal.NewFromInt(1)}}}, want: `line 1: line "missing" not found`},
+		{name: "duplicate line", req: PartialInvoiceRequest{Lines: []PartialInvoiceLineRequest{{LineID: "line-2", Quantity: decimal.NewFromFloat(0.5)}, {LineID: "line-2", Quantity: decimal.NewFromFloat(0.5)}}}, want: `line 2: line "line-2" selected more than once`},
+		{name: "zero quantity", req: PartialInvoiceRequest{Lines: []PartialInvoiceLineRequest{{LineID: "line-1"}}}, want: "line 1: quantity must be positive"},
+		{name: "percentage with lines", req: PartialInvoiceRequest{Kind: BillingKindPercentage, Percent: decimal.NewFromInt(10), Lines: []PartialInvoiceLineRequest{{LineID: "line-1", Quantity: decimal.NewFromInt(1)}}}, want: "percentage invoices cannot select lines"},
+		{name: "prepayment without amount", req: PartialInvoiceRequest{Kind: BillingKindPrepayment}, want: "prepayment amount must be positive"},
+		{name: "prepayment amount and percent", req: PartialInvoiceRequest{Kind: BillingKindPrepayment, Amount: decimal.NewFromInt(10), Percent: decimal.NewFromInt(10)}, want: "give either a prepayment amount or a percent, not both"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := PlanPartialInvoice("order ORD-1", partialTestLines(), nil, &tt.req)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+}
+
+func TestInvoiceValidateRejectsNegativePriceWithoutPrepayment(t *testing.T) {
+	invoice := &Invoice{
+		ContactID: "contact-1",
+		IssueDate: time.Now(),
+		DueDate:   time.Now(),
+		Lines:     []InvoiceLine{{LineNumber: 1, Description: "Discount", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(-5)}},
+	}
+	assert.EqualError(t, invoice.Validate(), "line unit price cannot be negative")
+}
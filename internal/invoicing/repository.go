@@ -16,6 +16,7 @@ type Repository interface {
 	UpdatePayment(ctx context.Context, schemaName, tenantID, invoiceID string, amountPaid decimal.Decimal, status InvoiceStatus) error
 	GenerateNumber(ctx context.Context, schemaName, tenantID string, invoiceType InvoiceType) (string, error)
 	UpdateOverdueStatus(ctx context.Context, schemaName, tenantID string) (int, error)
+	DeleteDraft(ctx context.Context, schemaName, tenantID, invoiceID string) error
 }
 
 // ErrInvoiceNotFound is returned when an invoice is not found
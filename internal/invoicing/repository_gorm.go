@@ -93,9 +93,118 @@ func (r *GORMRepository) GetByID(ctx context.Context, schemaName, tenantID, invo
 		inv.Lines[i] = *modelToInvoiceLine(&lm)
 	}
 
+	if inv.OriginalInvoiceID != nil {
+		originalDB, _ := r.tenantTable(ctx, schemaName, "invoices")
+		var originalNumber string
+		if err := originalDB.
+			Select("invoice_number").
+			Where("id = ? AND tenant_id = ?", *inv.OriginalInvoiceID, tenantID).
+			Scan(&originalNumber).Error; err != nil {
+			return nil, fmt.Errorf("get original invoice number: %w", err)
+		}
+		inv.OriginalInvoiceNumber = originalNumber
+	}
+
 	return inv, nil
 }
 
+// CreateCreditNote inserts a credit note and offsets the original invoice's
+// open balance in the same transaction. The original invoice row is locked
+// and the credited quantities re-checked first, so concurrent credit notes
+// cannot over-credit a line.
+func (r *GORMRepository) CreateCreditNote(ctx context.Context, schemaName, tenantID string, creditNote *Invoice, offset decimal.Decimal) error {
+	db, err := r.tenantTable(ctx, schemaName, "invoices")
+	if err != nil {
+		return err
+	}
+	if creditNote.OriginalInvoiceID == nil {
+		return fmt.Errorf("credit note has no original invoice")
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		txRepo := NewGORMRepository(tx)
+		if err := txRepo.checkCreditableQuantities(ctx, schemaName, tenantID, creditNote); err != nil {
+			return err
+		}
+		if err := txRepo.Create(ctx, schemaName, creditNote); err != nil {
+			return err
+		}
+		if offset.IsPositive() {
+			if err := txRepo.applyPayment(ctx, schemaName, tenantID, *creditNote.OriginalInvoiceID, offset); err != nil {
+				return fmt.Errorf("offset original invoice: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// checkCreditableQuantities locks the original invoice and verifies that the
+// credit note's lines, together with those of the non-voided credit notes
+// already stored, do not exceed the original line quantities.
+func (r *GORMRepository) checkCreditableQuantities(ctx context.Context, schemaName, tenantID string, creditNote *Invoice) error {
+	invoicesDB, err := r.tenantTable(ctx, schemaName, "invoices")
+	if err != nil {
+		return err
+	}
+	originalID := *creditNote.OriginalInvoiceID
+
+	var original models.Invoice
+	if err := invoicesDB.
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ? AND tenant_id = ?", originalID, tenantID).
+		First(&original).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvoiceNotFound
+		}
+		return fmt.Errorf("lock original invoice: %w", err)
+	}
+
+	linesDB, _ := database.TenantTable(r.db.WithContext(ctx), schemaName, "invoice_lines")
+	var originalLines []models.InvoiceLine
+	if err := linesDB.
+		Where("tenant_id = ? AND invoice_id = ?", tenantID, originalID).
+		Find(&originalLines).Error; err != nil {
+		return fmt.Errorf("get original invoice lines: %w", err)
+	}
+	remaining := make(map[string]decimal.Decimal, len(originalLines))
+	for _, line := range originalLines {
+		remaining[line.ID] = line.Quantity.Decimal
+	}
+
+	qualifiedInvoicesTable, _ := database.QualifiedTable(schemaName, "invoices")
+	creditNoteIDs := r.db.WithContext(ctx).Table(qualifiedInvoicesTable).
+		Select("id").
+		Where("tenant_id = ? AND invoice_type = ? AND original_invoice_id = ? AND status <> ?",
+			tenantID, InvoiceTypeCreditNote, originalID, StatusVoided)
+	var credited []struct {
+		OriginalLineID string
+		Quantity       decimal.Decimal
+	}
+	creditedLinesDB, _ := database.TenantTable(r.db.WithContext(ctx), schemaName, "invoice_lines")
+	if err := creditedLinesDB.
+		Select("original_line_id, SUM(quantity) AS quantity").
+		Where("tenant_id = ? AND original_line_id IS NOT NULL AND invoice_id IN (?)", tenantID, creditNoteIDs).
+		Group("original_line_id").
+		Find(&credited).Error; err != nil {
+		return fmt.Errorf("sum credited quantities: %w", err)
+	}
+	for _, row := range credited {
+		remaining[row.OriginalLineID] = remaining[row.OriginalLineID].Sub(row.Quantity)
+	}
+
+	for _, line := range creditNote.Lines {
+		if line.OriginalLineID == nil {
+			continue
+		}
+		lineID := *line.OriginalLineID
+		if line.Quantity.GreaterThan(remaining[lineID]) {
+			return fmt.Errorf("%w: line %d credits %s of remaining %s", ErrCreditExceedsRemaining, line.LineNumber, line.Quantity, decimal.Max(remaining[lineID], decimal.Zero))
+		}
+		remaining[lineID] = remaining[lineID].Sub(line.Quantity)
+	}
+	return nil
+}
+
 // List retrieves invoices with optional filtering
 func (r *GORMRepository) List(ctx context.Context, schemaName, tenantID string, filter *InvoiceFilter) ([]Invoice, error) {
 	db, err := r.tenantTable(ctx, schemaName, "invoices")
@@ -115,6 +224,9 @@ func (r *GORMRepository) List(ctx context.Context, schemaName, tenantID string,
 		if filter.ContactID != "" {
 			query = query.Where("contact_id = ?", filter.ContactID)
 		}
+		if filter.OriginalInvoiceID != "" {
+			query = query.Where("original_invoice_id = ?", filter.OriginalInvoiceID)
+		}
 		if filter.FromDate != nil {
 			query = query.Where("issue_date >= ?", filter.FromDate)
 		}
@@ -163,6 +275,48 @@ func (r *GORMRepository) UpdateStatus(ctx context.Context, schemaName, tenantID,
 	return nil
 }
 
+// DeleteDraft deletes a draft invoice together with its lines. Invoices that
+// have left DRAFT are kept and ErrInvoiceNotFound is returned.
+func (r *GORMRepository) DeleteDraft(ctx context.Context, schemaName, tenantID, invoiceID string) error {
+	db, err := r.tenantTable(ctx, schemaName, "invoices")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ? AND status = ?", invoiceID, tenantID, StatusDraft).
+		Delete(&models.Invoice{})
+	if result.Error != nil {
+		return fmt.Errorf("delete draft invoice: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvoiceNotFound
+	}
+	return nil
+}
+
+// MarkEInvoiceExported records when an invoice was included in an outbound
+// e-invoice file and the file identifier it was sent under.
+func (r *GORMRepository) MarkEInvoiceExported(ctx context.Context, schemaName, tenantID, invoiceID, eInvoiceID string, exportedAt time.Time) error {
+	db, err := r.tenantTable(ctx, schemaName, "invoices")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ?", invoiceID, tenantID).
+		Updates(map[string]interface{}{
+			"einvoice_sent_at": exportedAt,
+			"einvoice_id":      eInvoiceID,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("mark e-invoice exported: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrInvoiceNotFound
+	}
+	return nil
+}
+
 // VoidInvoice atomically voids an unpaid invoice. The payment and status
 // predicates are part of the update so a concurrent payment cannot be
 // followed by a stale void operation.
@@ -335,102 +489,108 @@ func (r *GORMRepository) UpdateOverdueStatus(ctx context.Context, schemaName, te
 
 func modelToInvoice(m *models.Invoice) *Invoice {
 	return &Invoice{
-		ID:             m.ID,
-		TenantID:       m.TenantID,
-		InvoiceNumber:  m.InvoiceNumber,
-		InvoiceType:    InvoiceType(m.InvoiceType),
-		ContactID:      m.ContactID,
-		IssueDate:      m.IssueDate,
-		DueDate:        m.DueDate,
-		Currency:       m.Currency,
-		ExchangeRate:   m.ExchangeRate.Decimal,
-		Subtotal:       m.Subtotal.Decimal,
-		VATAmount:      m.VATAmount.Decimal,
-		Total:          m.Total.Decimal,
-		BaseSubtotal:   m.BaseSubtotal.Decimal,
-		BaseVATAmount:  m.BaseVATAmount.Decimal,
-		BaseTotal:      m.BaseTotal.Decimal,
-		AmountPaid:     m.AmountPaid.Decimal,
-		Status:         InvoiceStatus(m.Status),
-		Reference:      m.Reference,
-		Notes:          m.Notes,
-		JournalEntryID: m.JournalEntryID,
-		EInvoiceSentAt: m.EInvoiceSentAt,
-		EInvoiceID:     m.EInvoiceID,
-		CreatedAt:      m.CreatedAt,
-		CreatedBy:      m.CreatedBy,
-		UpdatedAt:      m.UpdatedAt,
+		ID:                m.ID,
+		TenantID:          m.TenantID,
+		InvoiceNumber:     m.InvoiceNumber,
+		InvoiceType:       InvoiceType(m.InvoiceType),
+		ContactID:         m.ContactID,
+		IssueDate:         m.IssueDate,
+		DueDate:           m.DueDate,
+		Currency:          m.Currency,
+		ExchangeRate:      m.ExchangeRate.Decimal,
+		Subtotal:          m.Subtotal.Decimal,
+		VATAmount:         m.VATAmount.Decimal,
+		Total:             m.Total.Decimal,
+		BaseSubtotal:      m.BaseSubtotal.Decimal,
+		BaseVATAmount:     m.BaseVATAmount.Decimal,
+		BaseTotal:         m.BaseTotal.Decimal,
+		AmountPaid:        m.AmountPaid.Decimal,
+		Status:            InvoiceStatus(m.Status),
+		Reference:         m.Reference,
+		Notes:             m.Notes,
+		JournalEntryID:    m.JournalEntryID,
+		EInvoiceSentAt:    m.EInvoiceSentAt,
+		EInvoiceID:        m.EInvoiceID,
+		OriginalInvoiceID: m.OriginalInvoiceID,
+		CreatedAt:         m.CreatedAt,
+		CreatedBy:         m.CreatedBy,
+		UpdatedAt:         m.UpdatedAt,
 	}
 }
 
 func invoiceToModel(inv *Invoice) *models.Invoice {
 	return &models.Invoice{
-		ID:             inv.ID,
-		TenantID:       inv.TenantID,
-		InvoiceNumber:  inv.InvoiceNumber,
-		InvoiceType:    models.InvoiceType(inv.InvoiceType),
-		ContactID:      inv.ContactID,
-		IssueDate:      inv.IssueDate,
-		DueDate:        inv.DueDate,
-		Currency:       inv.Currency,
-		ExchangeRate:   models.Decimal{Decimal: inv.ExchangeRate},
-		Subtotal:       models.Decimal{Decimal: inv.Subtotal},
-		VATAmount:      models.Decimal{Decimal: inv.VATAmount},
-		Total:          models.Decimal{Decimal: inv.Total},
-		BaseSubtotal:   models.Decimal{Decimal: inv.BaseSubtotal},
-		BaseVATAmount:  models.Decimal{Decimal: inv.BaseVATAmount},
-		BaseTotal:      models.Decimal{Decimal: inv.BaseTotal},
-		AmountPaid:     models.Decimal{Decimal: inv.AmountPaid},
-		Status:         models.InvoiceStatus(inv.Status),
-		Reference:      inv.Reference,
-		Notes:          inv.Notes,
-		JournalEntryID: inv.JournalEntryID,
-		EInvoiceSentAt: inv.EInvoiceSentAt,
-		EInvoiceID:     inv.EInvoiceID,
-		CreatedAt:      inv.CreatedAt,
-		CreatedBy:      inv.CreatedBy,
-		UpdatedAt:      inv.UpdatedAt,
+		ID:                inv.ID,
+		TenantID:          inv.TenantID,
+		InvoiceNumber:     inv.InvoiceNumber,
+		InvoiceType:       models.InvoiceType(inv.InvoiceType),
+		ContactID:         inv.ContactID,
+		IssueDate:         inv.IssueDate,
+		DueDate:           inv.DueDate,
+		Currency:          inv.Currency,
+		ExchangeRate:      models.Decimal{Decimal: inv.ExchangeRate},
+		Subtotal:          models.Decimal{Decimal: inv.Subtotal},
+		VATAmount:         models.Decimal{Decimal: inv.VATAmount},
+		Total:             models.Decimal{Decimal: inv.Total},
+		BaseSubtotal:      models.Decimal{Decimal: inv.BaseSubtotal},
+		BaseVATAmount:     models.Decimal{Decimal: inv.BaseVATAmount},
+		BaseTotal:         models.Decimal{Decimal: inv.BaseTotal},
+		AmountPaid:        models.Decimal{Decimal: inv.AmountPaid},
+		Status:            models.InvoiceStatus(inv.Status),
+		Reference:         inv.Reference,
+		Notes:             inv.Notes,
+		JournalEntryID:    inv.JournalEntryID,
+		EInvoiceSentAt:    inv.EInvoiceSentAt,
+		EInvoiceID:        inv.EInvoiceID,
+		OriginalInvoiceID: inv.OriginalInvoiceID,
+		CreatedAt:         inv.CreatedAt,
+		CreatedBy:         inv.CreatedBy,
+		UpdatedAt:         inv.UpdatedAt,
 	}
 }
 
 func modelToInvoiceLine(m *models.InvoiceLine) *InvoiceLine {
 	return &InvoiceLine{
-		ID:              m.ID,
-		TenantID:        m.TenantID,
-		InvoiceID:       m.InvoiceID,
-		LineNumber:      m.LineNumber,
-		Description:     m.Description,
-		Quantity:        m.Quantity.Decimal,
-		Unit:            m.Unit,
-		UnitPrice:       m.UnitPrice.Decimal,
-		DiscountPercent: m.DiscountPercent.Decimal,
-		VATRate:         m.VATRate.Decimal,
-		VATTreatment:    normalizeVATTreatmentOrDefault(VATTreatment(m.VATTreatment)),
-		LineSubtotal:    m.LineSubtotal.Decimal,
-		LineVAT:         m.LineVAT.Decimal,
-		LineTotal:       m.LineTotal.Decimal,
-		AccountID:       m.AccountID,
-		ProductID:       m.ProductID,
+		ID:                  m.ID,
+		TenantID:            m.TenantID,
+		InvoiceID:           m.InvoiceID,
+		LineNumber:          m.LineNumber,
+		Description:         m.Description,
+		Quantity:            m.Quantity.Decimal,
+		Unit:                m.Unit,
+		UnitPrice:           m.UnitPrice.Decimal,
+		DiscountPercent:     m.DiscountPercent.Decimal,
+		VATRate:             m.VATRate.Decimal,
+		VATTreatment:        normalizeVATTreatmentOrDefault(VATTreatment(m.VATTreatment)),
+		LineSubtotal:        m.LineSubtotal.Decimal,
+		LineVAT:             m.LineVAT.Decimal,
+		LineTotal:           m.LineTotal.Decimal,
+		AccountID:           m.AccountID,
+		ProductID:           m.ProductID,
+		OriginalLineID:      m.OriginalLineID,
+		PrepaymentInvoiceID: m.PrepaymentInvoiceID,
 	}
 }
 
 func invoiceLineToModel(l *InvoiceLine) *models.InvoiceLine {
 	return &models.InvoiceLine{
-		ID:              l.ID,
-		TenantID:        l.TenantID,
-		InvoiceID:       l.InvoiceID,
-		LineNumber:      l.LineNumber,
-		Description:     l.Description,
-		Quantity:        models.Decimal{Decimal: l.Quantity},
-		Unit:            l.Unit,
-		UnitPrice:       models.Decimal{Decimal: l.UnitPrice},
-		DiscountPercent: models.Decimal{Decimal: l.DiscountPercent},
-		VATRate:         models.Decimal{Decimal: l.VATRate},
-		VATTreatment:    string(normalizeVATTreatmentOrDefault(l.VATTreatment)),
-		LineSubtotal:    models.Decimal{Decimal: l.LineSubtotal},
-		LineVAT:         models.Decimal{Decimal: l.LineVAT},
-		LineTotal:       models.Decimal{Decimal: l.LineTotal},
-		AccountID:       l.AccountID,
-		ProductID:       l.ProductID,
+		ID:                  l.ID,
+		TenantID:            l.TenantID,
+		InvoiceID:           l.InvoiceID,
+		LineNumber:          l.LineNumber,
+		Description:         l.Description,
+		Quantity:            models.Decimal{Decimal: l.Quantity},
+		Unit:                l.Unit,
+		UnitPrice:           models.Decimal{Decimal: l.UnitPrice},
+		DiscountPercent:     models.Decimal{Decimal: l.DiscountPercent},
+		VATRate:             models.Decimal{Decimal: l.VATRate},
+		VATTreatment:        string(normalizeVATTreatmentOrDefault(l.VATTreatment)),
+		LineSubtotal:        models.Decimal{Decimal: l.LineSubtotal},
+		LineVAT:             models.Decimal{Decimal: l.LineVAT},
+		LineTotal:           models.Decimal{Decimal: l.LineTotal},
+		AccountID:           l.AccountID,
+		ProductID:           l.ProductID,
+		OriginalLineID:      l.OriginalLineID,
+		PrepaymentInvoiceID: l.PrepaymentInvoiceID,
 	}
 }
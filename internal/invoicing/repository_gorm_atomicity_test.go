@@ -159,6 +159,26 @@ func TestService_VoidUsesAtomicRepositoryCapability(t *testing.T) {
 	require.NoError(t, service.Void(context.Background(), "tenant-1", "tenant_invoicing", invoice.ID))
 }
 
+func TestGORMRepositoryDeleteDraftOnlyDeletesDrafts(t *testing.T) {
+	ctx := context.Background()
+	capture := &invoicingDryRunSQLCapture{}
+	repo := NewGORMRepository(newInvoicingDryRunDB(t, withInvoicingDryRunDeleteRows(1), withInvoicingDryRunSQLCapture(capture)))
+	require.NoError(t, repo.DeleteDraft(ctx, "tenant_invoicing", "tenant-1", "invoice-1"))
+	assert.Contains(t, strings.Join(capture.statements, "\n"), "AND status = ")
+
+	repo = NewGORMRepository(newInvoicingDryRunDB(t, withInvoicingDryRunDeleteRows(0)))
+	err := repo.DeleteDraft(ctx, "tenant_invoicing", "tenant-1", "invoice-1")
+	assert.ErrorIs(t, err, ErrInvoiceNotFound)
+
+	expectedErr := errors.New("delete failed")
+	repo = NewGORMRepository(newInvoicingDryRunDB(t, withInvoicingDryRunDeleteError(expectedErr)))
+	err = repo.DeleteDraft(ctx, "tenant_invoicing", "tenant-1", "invoice-1")
+	assert.ErrorIs(t, err, expectedErr)
+
+	repo = NewGORMRepository(newInvoicingDryRunDB(t))
+	assert.Error(t, repo.DeleteDraft(ctx, "tenant-invalid", "tenant-1", "invoice-1"))
+}
+
 func TestGORMRepositoryApplyPaymentRejectsInvalidInvoiceState(t *testing.T) {
 	now := time.Date(2026, time.June, 25, 12, 0, 0, 0, time.UTC)
 	ctx := context.Background()
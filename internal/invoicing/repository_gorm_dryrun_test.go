@@ -436,6 +436,76 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	capture.assertContains(t, `line_number`)
 }
 
+func TestGORMRepositoryDryRunCreateCreditNote(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_invoicing"
+	tenantID := "11111111-1111-1111-1111-111111111111"
+	now := time.Date(2026, time.June, 25, 9, 0, 0, 0, time.UTC)
+	original := invoicingDryRunInvoice(tenantID, now)
+	original.Status = StatusSent
+	capture := &invoicingDryRunSQLCapture{}
+	repo := NewGORMRepository(newInvoicingDryRunDB(t,
+		withInvoicingDryRunFixtures(invoicingDryRunFixtures{
+			invoice:      invoiceToModel(original),
+			invoices:     []models.Invoice{*invoiceToModel(original)},
+			invoiceLines: []models.InvoiceLine{*invoiceLineToModel(&original.Lines[0])},
+		}),
+		withInvoicingDryRunUpdateRows(1),
+		withInvoicingDryRunSQLCapture(capture),
+	))
+
+	creditNote := invoicingDryRunInvoice(tenantID, now)
+	creditNote.ID = "22222222-2222-2222-2222-222222222222"
+	creditNote.InvoiceType = InvoiceTypeCreditNote
+	creditNote.OriginalInvoiceID = &original.ID
+	creditNote.Lines[0].OriginalLineID = &original.Lines[0].ID
+
+	require.NoError(t, repo.CreateCreditNote(ctx, schemaName, tenantID, creditNote, decimal.NewFromInt(10)))
+	_, err := repo.List(ctx, schemaName, tenantID, &InvoiceFilter{OriginalInvoiceID: original.ID})
+	require.NoError(t, err)
+
+	capture.assertContains(t, `original_invoice_id`)
+	capture.assertContains(t, `original_line_id`)
+	capture.assertContains(t, `FOR UPDATE`)
+	capture.assertContains(t, `SUM(quantity)`)
+	capture.assertContains(t, `status <> `)
+
+	// A credit note committed concurrently has already credited the line
+	alreadyCredited := NewGORMRepository(newInvoicingDryRunDB(t,
+		withInvoicingDryRunFixtures(invoicingDryRunFixtures{
+			invoice:      invoiceToModel(original),
+			invoiceLines: []models.InvoiceLine{*invoiceLineToModel(&original.Lines[0])},
+			scanRows: []map[string]interface{}{
+				{"OriginalLineID": original.Lines[0].ID, "Quantity": decimal.NewFromInt(1)},
+			},
+		}),
+	))
+	err = alreadyCredited.CreateCreditNote(ctx, schemaName, tenantID, creditNote, decimal.Zero)
+	require.ErrorIs(t, err, ErrCreditExceedsRemaining)
+
+	creditNote.OriginalInvoiceID = nil
+	require.ErrorContains(t, repo.CreateCreditNote(ctx, schemaName, tenantID, creditNote, decimal.Zero), "no original invoice")
+}
+
+func TestGORMRepositoryDryRunMarkEInvoiceExported(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_invoicing"
+	tenantID := "11111111-1111-1111-1111-111111111111"
+	exportedAt := time.Date(2026, time.June, 25, 9, 0, 0, 0, time.UTC)
+	capture := &invoicingDryRunSQLCapture{}
+	repo := NewGORMRepository(newInvoicingDryRunDB(t,
+		withInvoicingDryRunUpdateRows(1),
+		withInvoicingDryRunSQLCapture(capture),
+	))
+
+	require.NoError(t, repo.MarkEInvoiceExported(ctx, schemaName, tenantID, "invoice-1", "EINV-20260625090000", exportedAt))
+	capture.assertContains(t, `einvoice_sent_at`)
+	capture.assertContains(t, `einvoice_id`)
+
+	missing := NewGORMRepository(newInvoicingDryRunDB(t, withInvoicingDryRunUpdateRows(0)))
+	require.ErrorIs(t, missing.MarkEInvoiceExported(ctx, schemaName, tenantID, "invoice-1", "EINV-1", exportedAt), ErrInvoiceNotFound)
+}
+
 func TestGORMRepositoryDryRunInvalidSchema(t *testing.T) {
 	ctx := context.Background()
 	invalidSchema := "tenant-invoicing"
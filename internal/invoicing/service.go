@@ -12,12 +12,14 @@ import (
 	"github.com/shopspring/decimal"
 
 	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/pricing"
 )
 
 // Service provides invoicing operations
 type Service struct {
 	repo       Repository
 	accounting *accounting.Service
+	pricing    pricing.Resolver
 }
 
 var newGormDBFromPool = database.NewGormDBFromPool
@@ -48,7 +50,14 @@ func NewServiceWithRepository(repo Repository, accountingService *accounting.Ser
 // WithRepository returns a service that keeps this service's domain
 // dependencies while using a repository bound to another transaction.
 func (s *Service) WithRepository(repo Repository) *Service {
-	return NewServiceWithRepository(repo, s.accounting)
+	return NewServiceWithRepository(repo, s.accounting).WithPricing(s.pricing)
+}
+
+// WithPricing resolves unit prices and discounts from price lists for
+// product lines sent without a unit price.
+func (s *Service) WithPricing(prices pricing.Resolver) *Service {
+	s.pricing = prices
+	return s
 }
 
 // Create creates a new invoice
@@ -86,23 +95,29 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 
 	// Convert request lines to invoice lines
 	for i, reqLine := range req.Lines {
+		if invoice.InvoiceType == InvoiceTypeSales {
+			if err := s.applyProductPrice(ctx, tenantID, schemaName, pricing.Document{ContactID: invoice.ContactID, Currency: invoice.Currency, Date: invoice.IssueDate}, i+1, &reqLine); err != nil {
+				return nil, err
+			}
+		}
 		vatTreatment, err := NormalizeVATTreatment(string(reqLine.VATTreatment))
 		if err != nil {
 			return nil, err
 		}
 		line := InvoiceLine{
-			ID:              uuid.New().String(),
-			TenantID:        tenantID,
-			LineNumber:      i + 1,
-			Description:     reqLine.Description,
-			Quantity:        reqLine.Quantity,
-			Unit:            reqLine.Unit,
-			UnitPrice:       reqLine.UnitPrice,
-			DiscountPercent: reqLine.DiscountPercent,
-			VATRate:         reqLine.VATRate,
-			VATTreatment:    vatTreatment,
-			AccountID:       reqLine.AccountID,
-			ProductID:       reqLine.ProductID,
+			ID:                  uuid.New().String(),
+			TenantID:            tenantID,
+			LineNumber:          i + 1,
+			Description:         reqLine.Description,
+			Quantity:            reqLine.Quantity,
+			Unit:                reqLine.Unit,
+			UnitPrice:           reqLine.unitPrice(),
+			DiscountPercent:     reqLine.DiscountPercent,
+			VATRate:             reqLine.VATRate,
+			VATTreatment:        vatTreatment,
+			AccountID:           reqLine.AccountID,
+			ProductID:           reqLine.ProductID,
+			PrepaymentInvoiceID: reqLine.PrepaymentInvoiceID,
 		}
 		line.Calculate()
 		invoice.Lines = append(invoice.Lines, line)
@@ -131,6 +146,27 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 	return invoice, nil
 }
 
+// applyProductPrice fills a product line sent without a unit price from the
+// customer's price lists.
+func (s *Service) applyProductPrice(ctx context.Context, tenantID, schemaName string, doc pricing.Document, lineNumber int, line *CreateInvoiceLineRequest) error {
+	priced := pricing.DocumentLine{
+		ProductID:       line.ProductID,
+		Quantity:        line.Quantity,
+		UnitPrice:       line.UnitPrice,
+		DiscountPercent: line.DiscountPercent,
+		Description:     line.Description,
+		Unit:            line.Unit,
+	}
+	if err := pricing.ApplyProductPrice(ctx, s.pricing, tenantID, schemaName, doc, &priced); err != nil {
+		return fmt.Errorf("resolve price for line %d: %w", lineNumber, err)
+	}
+	line.UnitPrice = priced.UnitPrice
+	line.DiscountPercent = priced.DiscountPercent
+	line.Description = priced.Description
+	line.Unit = priced.Unit
+	return nil
+}
+
 // GetByID retrieves an invoice by ID
 func (s *Service) GetByID(ctx context.Context, tenantID, schemaName, invoiceID string) (*Invoice, error) {
 	invoice, err := s.repo.GetByID(ctx, schemaName, tenantID, invoiceID)
@@ -293,6 +329,15 @@ func (s *Service) Void(ctx context.Context, tenantID, schemaName, invoiceID stri
 	return nil
 }
 
+// DeleteDraft deletes a draft invoice that was never sent, such as one whose
+// source document could not record it.
+func (s *Service) DeleteDraft(ctx context.Context, tenantID, schemaName, invoiceID string) error {
+	if err := s.repo.DeleteDraft(ctx, schemaName, tenantID, invoiceID); err != nil {
+		return fmt.Errorf("delete draft invoice: %w", err)
+	}
+	return nil
+}
+
 type invoiceVoider interface {
 	VoidInvoice(ctx context.Context, schemaName, tenantID, invoiceID string) error
 }
@@ -32,7 +32,7 @@ func TestService_RecordPaymentSerializesConcurrentUpdates(t *testing.T) {
 		Lines: []CreateInvoiceLineRequest{{
 			Description: "Concurrent payment test",
 			Quantity:    decimal.NewFromInt(1),
-			UnitPrice:   decimal.NewFromInt(100),
+			UnitPrice:   new(decimal.NewFromInt(100)),
 			VATRate:     decimal.NewFromInt(22),
 		}},
 	})
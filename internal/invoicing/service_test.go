@@ -8,6 +8,8 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/pricing"
 )
 
 // MockRepository is a mock implementation of Repository for testing
@@ -125,6 +127,15 @@ func (m *MockRepository) UpdateOverdueStatus(ctx context.Context, schemaName, te
 	return count, nil
 }
 
+func (m *MockRepository) DeleteDraft(ctx context.Context, schemaName, tenantID, invoiceID string) error {
+	inv, ok := m.invoices[invoiceID]
+	if !ok || inv.TenantID != tenantID || inv.Status != StatusDraft {
+		return ErrInvoiceNotFound
+	}
+	delete(m.invoices, invoiceID)
+	return nil
+}
+
 func TestNewServiceWithRepository(t *testing.T) {
 	repo := NewMockRepository()
 	service := NewServiceWithRepository(repo, nil)
@@ -157,7 +168,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromInt(22),
 					},
 				},
@@ -176,7 +187,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Materials",
 						Quantity:    decimal.NewFromFloat(5),
-						UnitPrice:   decimal.NewFromFloat(50.00),
+						UnitPrice:   new(decimal.NewFromFloat(50.00)),
 						VATRate:     decimal.NewFromInt(22),
 					},
 				},
@@ -195,7 +206,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description:  "EU service",
 						Quantity:     decimal.NewFromInt(1),
-						UnitPrice:    decimal.NewFromFloat(100.00),
+						UnitPrice:    new(decimal.NewFromFloat(100.00)),
 						VATRate:      decimal.NewFromInt(22),
 						VATTreatment: VATTreatmentReverseCharge,
 					},
@@ -215,7 +226,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromInt(22),
 					},
 				},
@@ -278,6 +289,50 @@ func TestService_Create(t *testing.T) {
 	}
 }
 
+type fakePriceResolver struct {
+	calls int
+}
+
+func (f *fakePriceResolver) ResolvePrice(_ context.Context, _, _ string, req *pricing.ResolvePriceRequest) (*pricing.ResolvedPrice, error) {
+	f.calls++
+	return &pricing.ResolvedPrice{ProductID: req.ProductID, ProductName: "Widget", UnitPrice: decimal.NewFromInt(16)}, nil
+}
+
+func TestService_CreateResolvesSalesProductPrices(t *testing.T) {
+	ctx := context.Background()
+	prices := &fakePriceResolver{}
+	service := NewServiceWithRepository(NewMockRepository(), nil).WithPricing(prices)
+	productID := "product-1"
+
+	sales, err := service.Create(ctx, "tenant-1", "tenant_test", &CreateInvoiceRequest{
+		InvoiceType: InvoiceTypeSales,
+		ContactID:   "contact-1",
+		Lines:       []CreateInvoiceLineRequest{{Quantity: decimal.NewFromInt(2), ProductID: &productID}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, prices.calls)
+	require.Equal(t, "Widget", sales.Lines[0].Description)
+	require.True(t, sales.Subtotal.Equal(decimal.NewFromInt(32)))
+
+	free, err := service.WithRepository(NewMockRepository()).Create(ctx, "tenant-1", "tenant_test", &CreateInvoiceRequest{
+		InvoiceType: InvoiceTypeSales,
+		ContactID:   "contact-1",
+		Lines:       []CreateInvoiceLineRequest{{Description: "Free sample", Quantity: decimal.NewFromInt(2), UnitPrice: new(decimal.Zero), ProductID: &productID}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, prices.calls)
+	require.True(t, free.Lines[0].UnitPrice.IsZero())
+
+	purchase, err := service.WithRepository(NewMockRepository()).Create(ctx, "tenant-1", "tenant_test", &CreateInvoiceRequest{
+		InvoiceType: InvoiceTypePurchase,
+		ContactID:   "contact-2",
+		Lines:       []CreateInvoiceLineRequest{{Description: "Widget", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.Zero), ProductID: &productID}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, prices.calls)
+	require.True(t, purchase.Lines[0].UnitPrice.IsZero())
+}
+
 func TestService_Create_DefaultValues(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockRepository()
@@ -290,7 +345,7 @@ func TestService_Create_DefaultValues(t *testing.T) {
 			{
 				Description: "Service",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromFloat(100.00),
+				UnitPrice:   new(decimal.NewFromFloat(100.00)),
 				VATRate:     decimal.NewFromInt(22),
 			},
 		},
@@ -330,13 +385,13 @@ func TestService_Create_CalculatesTotals(t *testing.T) {
 			{
 				Description: "Service A",
 				Quantity:    decimal.NewFromInt(2),
-				UnitPrice:   decimal.NewFromFloat(100.00),
+				UnitPrice:   new(decimal.NewFromFloat(100.00)),
 				VATRate:     decimal.NewFromInt(22),
 			},
 			{
 				Description: "Service B",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromFloat(50.00),
+				UnitPrice:   new(decimal.NewFromFloat(50.00)),
 				VATRate:     decimal.NewFromInt(22),
 			},
 		},
@@ -383,7 +438,7 @@ func TestService_Create_RepositoryError(t *testing.T) {
 			{
 				Description: "Service",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromFloat(100.00),
+				UnitPrice:   new(decimal.NewFromFloat(100.00)),
 				VATRate:     decimal.NewFromInt(22),
 			},
 		},
@@ -407,7 +462,7 @@ func TestService_GetByID(t *testing.T) {
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
 		Lines: []CreateInvoiceLineRequest{
-			{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)},
+			{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)},
 		},
 	})
 
@@ -468,21 +523,21 @@ func TestService_List(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "A", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "A", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypePurchase,
 		ContactID:   "contact-2",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 30),
-		Lines:       []CreateInvoiceLineRequest{{Description: "B", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(200), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "B", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(200)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Create(ctx, "tenant-2", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypeSales,
 		ContactID:   "contact-3",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "C", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(300), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "C", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(300)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	invoices, err := service.List(ctx, "tenant-1", "public", nil)
@@ -580,7 +635,7 @@ func TestService_Send(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	err := service.Send(ctx, "tenant-1", "public", created.ID)
@@ -606,7 +661,7 @@ func TestService_Send_NotDraft(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 
@@ -639,7 +694,7 @@ func TestService_RecordPayment(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 
@@ -668,7 +723,7 @@ func TestService_RecordPayment_FullPayment(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 
@@ -694,7 +749,7 @@ func TestService_RecordPayment_ReversalRestoresUnpaidStatus(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 
@@ -722,7 +777,7 @@ func TestService_RecordPayment_Voided(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	// Void the invoice
@@ -756,7 +811,7 @@ func TestService_Void(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	err := service.Void(ctx, "tenant-1", "public", created.ID)
@@ -770,6 +825,33 @@ func TestService_Void(t *testing.T) {
 	}
 }
 
+func TestService_DeleteDraft(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	service := NewServiceWithRepository(repo, nil)
+
+	created, err := service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
+		InvoiceType: InvoiceTypeSales,
+		ContactID:   "contact-1",
+		IssueDate:   time.Now(),
+		DueDate:     time.Now().AddDate(0, 0, 14),
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := service.DeleteDraft(ctx, "tenant-1", "public", created.ID); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := service.GetByID(ctx, "tenant-1", "public", created.ID); err == nil {
+		t.Error("Expected the draft invoice to be deleted")
+	}
+	if err := service.DeleteDraft(ctx, "tenant-1", "public", created.ID); !errors.Is(err, ErrInvoiceNotFound) {
+		t.Errorf("DeleteDraft() error = %v, want %v", err, ErrInvoiceNotFound)
+	}
+}
+
 func TestService_Void_AlreadyVoided(t *testing.T) {
 	ctx := context.Background()
 	repo := NewMockRepository()
@@ -780,7 +862,7 @@ func TestService_Void_AlreadyVoided(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Void(ctx, "tenant-1", "public", created.ID)
 
@@ -801,7 +883,7 @@ func TestService_Void_WithPayments(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 	service.RecordPayment(ctx, "tenant-1", "public", created.ID, decimal.NewFromFloat(50.00))
@@ -871,7 +953,7 @@ func TestService_Create_GenerateNumberError(t *testing.T) {
 		ContactID:   "contact-1",
 		IssueDate:   time.Now(),
 		DueDate:     time.Now().AddDate(0, 0, 14),
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	if err == nil {
 		t.Error("Expected error when GenerateNumber fails")
@@ -889,7 +971,7 @@ func TestService_Send_UpdateStatusError(t *testing.T) {
 	created, _ := service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypeSales,
 		ContactID:   "contact-1",
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	err := service.Send(ctx, "tenant-1", "public", created.ID)
@@ -909,7 +991,7 @@ func TestService_RecordPayment_UpdatePaymentError(t *testing.T) {
 	created, _ := service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypeSales,
 		ContactID:   "contact-1",
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	err := service.RecordPayment(ctx, "tenant-1", "public", created.ID, decimal.NewFromFloat(50.00))
@@ -929,7 +1011,7 @@ func TestService_Void_UpdateStatusError(t *testing.T) {
 	created, _ := service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypeSales,
 		ContactID:   "contact-1",
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 
 	err := service.Void(ctx, "tenant-1", "public", created.ID)
@@ -994,7 +1076,7 @@ func TestService_RecordPayment_OverpaymentCapped(t *testing.T) {
 	created, _ := service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypeSales,
 		ContactID:   "contact-1",
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 
@@ -1021,7 +1103,7 @@ func TestService_RecordPayment_ZeroAmount(t *testing.T) {
 	created, _ := service.Create(ctx, "tenant-1", "public", &CreateInvoiceRequest{
 		InvoiceType: InvoiceTypeSales,
 		ContactID:   "contact-1",
-		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(100), VATRate: decimal.NewFromInt(22)}},
+		Lines:       []CreateInvoiceLineRequest{{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(100)), VATRate: decimal.NewFromInt(22)}},
 	})
 	service.Send(ctx, "tenant-1", "public", created.ID)
 
@@ -66,9 +66,13 @@ type Invoice struct {
 	JournalEntryID *string           `json:"journal_entry_id,omitempty"`
 	EInvoiceSentAt *time.Time        `json:"einvoice_sent_at,omitempty"`
 	EInvoiceID     *string           `json:"einvoice_id,omitempty"`
-	CreatedAt      time.Time         `json:"created_at"`
-	CreatedBy      string            `json:"created_by"`
-	UpdatedAt      time.Time         `json:"updated_at"`
+	// OriginalInvoiceID and OriginalInvoiceNumber identify the invoice a
+	// credit note credits.
+	OriginalInvoiceID     *string   `json:"original_invoice_id,omitempty"`
+	OriginalInvoiceNumber string    `json:"original_invoice_number,omitempty"`
+	CreatedAt             time.Time `json:"created_at"`
+	CreatedBy             string    `json:"created_by"`
+	UpdatedAt             time.Time `json:"updated_at"`
 }
 
 // InvoiceLine represents a line item on an invoice
@@ -89,6 +93,10 @@ type InvoiceLine struct {
 	LineTotal       decimal.Decimal `json:"line_total"`
 	AccountID       *string         `json:"account_id,omitempty"`
 	ProductID       *string         `json:"product_id,omitempty"`
+	OriginalLineID  *string         `json:"original_line_id,omitempty"`
+	// PrepaymentInvoiceID marks a line netting off an earlier prepayment
+	// invoice; only such lines may carry a negative unit price.
+	PrepaymentInvoiceID *string `json:"prepayment_invoice_id,omitempty"`
 }
 
 // Calculate computes the line totals
@@ -164,7 +172,7 @@ func (inv *Invoice) Validate() error {
 		if line.Quantity.LessThanOrEqual(decimal.Zero) {
 			return errors.New("line quantity must be positive")
 		}
-		if line.UnitPrice.LessThan(decimal.Zero) {
+		if line.UnitPrice.LessThan(decimal.Zero) && line.PrepaymentInvoiceID == nil {
 			return errors.New("line unit price cannot be negative")
 		}
 		if line.VATRate.LessThan(decimal.Zero) {
@@ -221,15 +229,43 @@ type CreateInvoiceRequest struct {
 
 // CreateInvoiceLineRequest is a line in the create invoice request
 type CreateInvoiceLineRequest struct {
-	Description     string          `json:"description"`
-	Quantity        decimal.Decimal `json:"quantity"`
-	Unit            string          `json:"unit,omitempty"`
-	UnitPrice       decimal.Decimal `json:"unit_price"`
-	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
-	VATRate         decimal.Decimal `json:"vat_rate"`
-	VATTreatment    VATTreatment    `json:"vat_treatment,omitempty"`
-	AccountID       *string         `json:"account_id,omitempty"`
-	ProductID       *string         `json:"product_id,omitempty"`
+	Description     string           `json:"description"`
+	Quantity        decimal.Decimal  `json:"quantity"`
+	Unit            string           `json:"unit,omitempty"`
+	UnitPrice       *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountPercent decimal.Decimal  `json:"discount_percent,omitempty"`
+	VATRate         decimal.Decimal  `json:"vat_rate"`
+	VATTreatment    VATTreatment     `json:"vat_treatment,omitempty"`
+	AccountID       *string          `json:"account_id,omitempty"`
+	ProductID       *string          `json:"product_id,omitempty"`
+	// PrepaymentInvoiceID is set on lines deducting an earlier prepayment invoice.
+	PrepaymentInvoiceID *string `json:"prepayment_invoice_id,omitempty"`
+}
+
+// unitPrice returns the unit price sent on the line, zero when none was sent
+// and none was resolved.
+func (l *CreateInvoiceLineRequest) unitPrice() decimal.Decimal {
+	if l.UnitPrice == nil {
+		return decimal.Zero
+	}
+	return *l.UnitPrice
+}
+
+// CreateCreditNoteRequest is the request to credit an issued invoice. When
+// Lines is empty every remaining uncredited quantity is credited.
+type CreateCreditNoteRequest struct {
+	IssueDate time.Time                     `json:"issue_date"`
+	Reference string                        `json:"reference,omitempty"`
+	Notes     string                        `json:"notes,omitempty"`
+	Lines     []CreateCreditNoteLineRequest `json:"lines,omitempty"`
+	UserID    string                        `json:"-"`
+}
+
+// CreateCreditNoteLineRequest selects an original invoice line and the
+// quantity to credit from it.
+type CreateCreditNoteLineRequest struct {
+	OriginalLineID string          `json:"original_line_id"`
+	Quantity       decimal.Decimal `json:"quantity"`
 }
 
 // ImportInvoicesRequest contains CSV payload for bulk invoice import.
@@ -269,9 +305,11 @@ type InvoiceFilter struct {
 	InvoiceType InvoiceType
 	Status      InvoiceStatus
 	ContactID   string
-	FromDate    *time.Time
-	ToDate      *time.Time
-	Search      string
+	// OriginalInvoiceID limits results to credit notes of the given invoice.
+	OriginalInvoiceID string
+	FromDate          *time.Time
+	ToDate            *time.Time
+	Search            string
 }
 
 // NormalizeVATTreatment validates and normalizes VAT treatment values.
@@ -486,7 +486,7 @@ func TestCreateInvoiceRequest_Defaults(t *testing.T) {
 			{
 				Description: "Service",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromFloat(500),
+				UnitPrice:   new(decimal.NewFromFloat(500)),
 				VATRate:     decimal.NewFromInt(22),
 			},
 		},
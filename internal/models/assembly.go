@@ -0,0 +1,99 @@
+package models
+
+import "time"
+
+// BillOfMaterials represents the components that make up one product, either
+// built by assembly orders or exploded into components when sold as a kit.
+type BillOfMaterials struct {
+	ID             string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID       string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	ProductID      string    `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	BOMType        string    `gorm:"column:bom_type;size:20;not null;default:'ASSEMBLY'" json:"bom_type"`
+	OutputQuantity Decimal   `gorm:"column:output_quantity;type:numeric(18,6);not null;default:1" json:"output_quantity"`
+	LabourCost     Decimal   `gorm:"column:labour_cost;type:numeric(18,2);not null;default:0" json:"labour_cost"`
+	OverheadCost   Decimal   `gorm:"column:overhead_cost;type:numeric(18,2);not null;default:0" json:"overhead_cost"`
+	IsActive       bool      `gorm:"column:is_active;not null;default:true" json:"is_active"`
+	Notes          string    `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy      string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt      time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (BillOfMaterials) TableName() string {
+	return "bills_of_materials"
+}
+
+// BillOfMaterialLine represents one component quantity in a bill of materials.
+type BillOfMaterialLine struct {
+	ID                 string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID           string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	BOMID              string  `gorm:"column:bom_id;type:uuid;not null;index" json:"bom_id"`
+	LineNumber         int     `gorm:"column:line_number;not null" json:"line_number"`
+	ComponentProductID string  `gorm:"column:component_product_id;type:uuid;not null" json:"component_product_id"`
+	Quantity           Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	Notes              string  `gorm:"type:text" json:"notes,omitempty"`
+}
+
+// TableName returns the table name for GORM.
+func (BillOfMaterialLine) TableName() string {
+	return "bill_of_material_lines"
+}
+
+// AssemblyOrder represents an assembly or disassembly of a product in one
+// warehouse.
+type AssemblyOrder struct {
+	ID                  string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID            string     `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	OrderNumber         string     `gorm:"column:order_number;size:50;not null" json:"order_number"`
+	OrderType           string     `gorm:"column:order_type;size:20;not null;default:'ASSEMBLY'" json:"order_type"`
+	BOMID               string     `gorm:"column:bom_id;type:uuid;not null" json:"bom_id"`
+	ProductID           string     `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	WarehouseID         string     `gorm:"column:warehouse_id;type:uuid;not null" json:"warehouse_id"`
+	Quantity            Decimal    `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	OrderDate           time.Time  `gorm:"column:order_date;type:date;not null" json:"order_date"`
+	Status              string     `gorm:"size:20;not null;default:'DRAFT'" json:"status"`
+	LotNumber           *string    `gorm:"column:lot_number;size:100" json:"lot_number,omitempty"`
+	SerialNumber        *string    `gorm:"column:serial_number;size:100" json:"serial_number,omitempty"`
+	ExpiryDate          *string    `gorm:"column:expiry_date;type:date" json:"expiry_date,omitempty"`
+	LabourCost          Decimal    `gorm:"column:labour_cost;type:numeric(18,2);not null;default:0" json:"labour_cost"`
+	OverheadCost        Decimal    `gorm:"column:overhead_cost;type:numeric(18,2);not null;default:0" json:"overhead_cost"`
+	ComponentCost       Decimal    `gorm:"column:component_cost;type:numeric(18,2);not null;default:0" json:"component_cost"`
+	TotalCost           Decimal    `gorm:"column:total_cost;type:numeric(18,2);not null;default:0" json:"total_cost"`
+	UnitCost            Decimal    `gorm:"column:unit_cost;type:numeric(28,8);not null;default:0" json:"unit_cost"`
+	CostingMethod       *string    `gorm:"column:costing_method;size:30" json:"costing_method,omitempty"`
+	AbsorptionAccountID *string    `gorm:"column:absorption_account_id;type:uuid" json:"absorption_account_id,omitempty"`
+	JournalEntryID      *string    `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	Notes               string     `gorm:"type:text" json:"notes,omitempty"`
+	CompletedAt         *time.Time `gorm:"column:completed_at" json:"completed_at,omitempty"`
+	CompletedBy         *string    `gorm:"column:completed_by;type:uuid" json:"completed_by,omitempty"`
+	CreatedBy           string     `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt           time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt           time.Time  `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (AssemblyOrder) TableName() string {
+	return "assembly_orders"
+}
+
+// AssemblyOrderLine represents one component consumed by an assembly or
+// produced by a disassembly, with its booked cost.
+type AssemblyOrderLine struct {
+	ID              string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID        string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	AssemblyOrderID string  `gorm:"column:assembly_order_id;type:uuid;not null;index" json:"assembly_order_id"`
+	LineNumber      int     `gorm:"column:line_number;not null" json:"line_number"`
+	ProductID       string  `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	Quantity        Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	LotNumber       *string `gorm:"column:lot_number;size:100" json:"lot_number,omitempty"`
+	SerialNumber    *string `gorm:"column:serial_number;size:100" json:"serial_number,omitempty"`
+	ExpiryDate      *string `gorm:"column:expiry_date;type:date" json:"expiry_date,omitempty"`
+	UnitCost        Decimal `gorm:"column:unit_cost;type:numeric(28,8);not null;default:0" json:"unit_cost"`
+	TotalCost       Decimal `gorm:"column:total_cost;type:numeric(18,2);not null;default:0" json:"total_cost"`
+}
+
+// TableName returns the table name for GORM.
+func (AssemblyOrderLine) TableName() string {
+	return "assembly_order_lines"
+}
@@ -1,6 +1,11 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lib/pq"
+)
 
 // AssetCategory represents a fixed-asset category for a tenant.
 type AssetCategory struct {
@@ -45,6 +50,8 @@ type FixedAsset struct {
 	AccumulatedDepreciation       Decimal    `gorm:"column:accumulated_depreciation;type:numeric(28,8);not null;default:0" json:"accumulated_depreciation"`
 	BookValue                     Decimal    `gorm:"column:book_value;type:numeric(28,8);not null" json:"book_value"`
 	LastDepreciationDate          *time.Time `gorm:"column:last_depreciation_date;type:date" json:"last_depreciation_date,omitempty"`
+	RevisedDepreciableAmount      Decimal    `gorm:"column:revised_depreciable_amount;type:numeric(28,8);not null;default:0" json:"revised_depreciable_amount"`
+	RevisedLifeMonths             int        `gorm:"column:revised_life_months;not null;default:0" json:"revised_life_months"`
 	DisposalDate                  *time.Time `gorm:"column:disposal_date;type:date" json:"disposal_date,omitempty"`
 	DisposalMethod                *string    `gorm:"column:disposal_method;size:20" json:"disposal_method,omitempty"`
 	DisposalProceeds              Decimal    `gorm:"column:disposal_proceeds;type:numeric(28,8);default:0" json:"disposal_proceeds"`
@@ -75,6 +82,7 @@ type DepreciationEntry struct {
 	AccumulatedTotal   Decimal   `gorm:"column:accumulated_total;type:numeric(28,8);not null" json:"accumulated_total"`
 	BookValueAfter     Decimal   `gorm:"column:book_value_after;type:numeric(28,8);not null" json:"book_value_after"`
 	JournalEntryID     *string   `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	RunID              *string   `gorm:"column:run_id;type:uuid;index" json:"run_id,omitempty"`
 	Notes              string    `gorm:"type:text" json:"notes,omitempty"`
 	CreatedAt          time.Time `gorm:"not null;default:now()" json:"created_at"`
 	CreatedBy          string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
@@ -84,3 +92,59 @@ type DepreciationEntry struct {
 func (DepreciationEntry) TableName() string {
 	return "depreciation_entries"
 }
+
+// DepreciationRun represents one batch depreciation run for a period.
+type DepreciationRun struct {
+	ID              string          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID        string          `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	PeriodStart     time.Time       `gorm:"column:period_start;type:date;not null" json:"period_start"`
+	PeriodEnd       time.Time       `gorm:"column:period_end;type:date;not null" json:"period_end"`
+	PostingMode     string          `gorm:"column:posting_mode;size:20;not null;default:'AGGREGATED'" json:"posting_mode"`
+	Status          string          `gorm:"size:20;not null;default:'POSTED'" json:"status"`
+	AssetCount      int             `gorm:"column:asset_count;not null;default:0" json:"asset_count"`
+	TotalAmount     Decimal         `gorm:"column:total_amount;type:numeric(28,8);not null;default:0" json:"total_amount"`
+	CategoryTotals  json.RawMessage `gorm:"column:category_totals;type:jsonb;not null;default:'[]'" json:"category_totals"`
+	JournalEntryIDs pq.StringArray  `gorm:"column:journal_entry_ids;type:text[];not null" json:"journal_entry_ids"`
+	ReversedAt      *time.Time      `gorm:"column:reversed_at" json:"reversed_at,omitempty"`
+	ReversedBy      *string         `gorm:"column:reversed_by;type:uuid" json:"reversed_by,omitempty"`
+	ReversalReason  string          `gorm:"column:reversal_reason;type:text" json:"reversal_reason,omitempty"`
+	CreatedBy       string          `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt       time.Time       `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (DepreciationRun) TableName() string {
+	return "depreciation_runs"
+}
+
+// AssetEvent records an improvement, impairment or estimate change on a fixed asset.
+type AssetEvent struct {
+	ID                        string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID                  string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	AssetID                   string    `gorm:"column:asset_id;type:uuid;not null;index" json:"asset_id"`
+	EventType                 string    `gorm:"column:event_type;size:20;not null" json:"event_type"`
+	EventDate                 time.Time `gorm:"column:event_date;type:date;not null" json:"event_date"`
+	Amount                    Decimal   `gorm:"type:numeric(28,8);not null;default:0" json:"amount"`
+	Description               string    `gorm:"type:text" json:"description,omitempty"`
+	CostBefore                Decimal   `gorm:"column:cost_before;type:numeric(28,8);not null" json:"cost_before"`
+	CostAfter                 Decimal   `gorm:"column:cost_after;type:numeric(28,8);not null" json:"cost_after"`
+	AccumulatedBefore         Decimal   `gorm:"column:accumulated_before;type:numeric(28,8);not null" json:"accumulated_before"`
+	AccumulatedAfter          Decimal   `gorm:"column:accumulated_after;type:numeric(28,8);not null" json:"accumulated_after"`
+	BookValueBefore           Decimal   `gorm:"column:book_value_before;type:numeric(28,8);not null" json:"book_value_before"`
+	BookValueAfter            Decimal   `gorm:"column:book_value_after;type:numeric(28,8);not null" json:"book_value_after"`
+	UsefulLifeBefore          int       `gorm:"column:useful_life_before;not null" json:"useful_life_before"`
+	UsefulLifeAfter           int       `gorm:"column:useful_life_after;not null" json:"useful_life_after"`
+	ResidualValueBefore       Decimal   `gorm:"column:residual_value_before;type:numeric(28,8);not null" json:"residual_value_before"`
+	ResidualValueAfter        Decimal   `gorm:"column:residual_value_after;type:numeric(28,8);not null" json:"residual_value_after"`
+	MonthlyDepreciationBefore Decimal   `gorm:"column:monthly_depreciation_before;type:numeric(28,8);not null;default:0" json:"monthly_depreciation_before"`
+	MonthlyDepreciationAfter  Decimal   `gorm:"column:monthly_depreciation_after;type:numeric(28,8);not null;default:0" json:"monthly_depreciation_after"`
+	OffsetAccountID           *string   `gorm:"column:offset_account_id;type:uuid" json:"offset_account_id,omitempty"`
+	JournalEntryID            *string   `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	CreatedBy                 string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt                 time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (AssetEvent) TableName() string {
+	return "asset_events"
+}
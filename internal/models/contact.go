@@ -29,6 +29,7 @@ type Contact struct {
 	City             string      `gorm:"size:100" json:"city,omitempty"`
 	PostalCode       string      `gorm:"size:20" json:"postal_code,omitempty"`
 	CountryCode      string      `gorm:"size:2;not null;default:'EE'" json:"country_code"`
+	Language         string      `gorm:"size:5;not null;default:''" json:"language,omitempty"`
 	PaymentTermsDays int         `gorm:"not null;default:14" json:"payment_terms_days"`
 	CreditLimit      Decimal     `gorm:"type:numeric(28,8);not null;default:0" json:"credit_limit"`
 	DefaultAccountID *string     `gorm:"type:uuid" json:"default_account_id,omitempty"`
@@ -49,9 +49,11 @@ type Invoice struct {
 	JournalEntryID *string       `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
 	EInvoiceSentAt *time.Time    `gorm:"column:einvoice_sent_at" json:"einvoice_sent_at,omitempty"`
 	EInvoiceID     *string       `gorm:"column:einvoice_id;size:255" json:"einvoice_id,omitempty"`
-	CreatedAt      time.Time     `gorm:"not null;default:now()" json:"created_at"`
-	CreatedBy      string        `gorm:"type:uuid;not null" json:"created_by"`
-	UpdatedAt      time.Time     `gorm:"not null;default:now()" json:"updated_at"`
+	// OriginalInvoiceID links a credit note to the invoice it credits.
+	OriginalInvoiceID *string   `gorm:"column:original_invoice_id;type:uuid" json:"original_invoice_id,omitempty"`
+	CreatedAt         time.Time `gorm:"not null;default:now()" json:"created_at"`
+	CreatedBy         string    `gorm:"type:uuid;not null" json:"created_by"`
+	UpdatedAt         time.Time `gorm:"not null;default:now()" json:"updated_at"`
 
 	// Relations
 	Lines   []InvoiceLine `gorm:"foreignKey:InvoiceID" json:"lines,omitempty"`
@@ -81,6 +83,10 @@ type InvoiceLine struct {
 	LineTotal       Decimal `gorm:"column:line_total;type:numeric(28,8);not null;default:0" json:"line_total"`
 	AccountID       *string `gorm:"column:account_id;type:uuid" json:"account_id,omitempty"`
 	ProductID       *string `gorm:"column:product_id;type:uuid" json:"product_id,omitempty"`
+	// OriginalLineID links a credit note line to the invoice line it credits.
+	OriginalLineID *string `gorm:"column:original_line_id;type:uuid" json:"original_line_id,omitempty"`
+	// PrepaymentInvoiceID links a deduction line to the prepayment invoice it nets off.
+	PrepaymentInvoiceID *string `gorm:"column:prepayment_invoice_id;type:uuid" json:"prepayment_invoice_id,omitempty"`
 
 	// Relations
 	Invoice *Invoice `gorm:"foreignKey:InvoiceID" json:"invoice,omitempty"`
@@ -338,6 +338,20 @@ func TestAdditionalModelTableNames(t *testing.T) {
 		{name: "asset category", model: AssetCategory{}, want: "asset_categories"},
 		{name: "fixed asset", model: FixedAsset{}, want: "fixed_assets"},
 		{name: "depreciation entry", model: DepreciationEntry{}, want: "depreciation_entries"},
+		{name: "depreciation run", model: DepreciationRun{}, want: "depreciation_runs"},
+		{name: "asset event", model: AssetEvent{}, want: "asset_events"},
+		{name: "purchase order", model: PurchaseOrder{}, want: "purchase_orders"},
+		{name: "purchase order line", model: PurchaseOrderLine{}, want: "purchase_order_lines"},
+		{name: "goods receipt", model: GoodsReceipt{}, want: "goods_receipts"},
+		{name: "goods receipt line", model: GoodsReceiptLine{}, want: "goods_receipt_lines"},
+		{name: "purchase invoice match", model: PurchaseInvoiceMatch{}, want: "purchase_invoice_matches"},
+		{name: "purchase invoice match line", model: PurchaseInvoiceMatchLine{}, want: "purchase_invoice_match_lines"},
+		{name: "landed cost", model: LandedCost{}, want: "landed_costs"},
+		{name: "landed cost line", model: LandedCostLine{}, want: "landed_cost_lines"},
+		{name: "price list", model: PriceList{}, want: "price_lists"},
+		{name: "price list item", model: PriceListItem{}, want: "price_list_items"},
+		{name: "customer group", model: CustomerGroup{}, want: "customer_groups"},
+		{name: "customer pricing", model: CustomerPricing{}, want: "customer_pricing"},
 		{name: "refresh session", model: RefreshSession{}, want: "refresh_sessions"},
 		{name: "password reset token", model: PasswordResetToken{}, want: "password_reset_tokens"},
 		{name: "security audit event", model: SecurityAuditEvent{}, want: "security_audit_events"},
@@ -349,10 +363,23 @@ func TestAdditionalModelTableNames(t *testing.T) {
 		{name: "order", model: Order{}, want: "orders"},
 		{name: "order line", model: OrderLine{}, want: "order_lines"},
 		{name: "order stock reservation", model: OrderStockReservation{}, want: "order_stock_reservations"},
+		{name: "order invoice", model: OrderInvoice{}, want: "order_invoices"},
+		{name: "order shipment", model: OrderShipment{}, want: "order_shipments"},
+		{name: "order shipment line", model: OrderShipmentLine{}, want: "order_shipment_lines"},
+		{name: "stock count", model: StockCount{}, want: "stock_counts"},
+		{name: "stock count line", model: StockCountLine{}, want: "stock_count_lines"},
+		{name: "bill of materials", model: BillOfMaterials{}, want: "bills_of_materials"},
+		{name: "bill of material line", model: BillOfMaterialLine{}, want: "bill_of_material_lines"},
+		{name: "assembly order", model: AssemblyOrder{}, want: "assembly_orders"},
+		{name: "assembly order line", model: AssemblyOrderLine{}, want: "assembly_order_lines"},
 		{name: "tsd declaration", model: TSDDeclaration{}, want: "tsd_declarations"},
 		{name: "tsd row", model: TSDRow{}, want: "tsd_rows"},
 		{name: "quote", model: Quote{}, want: "quotes"},
 		{name: "quote line", model: QuoteLine{}, want: "quote_lines"},
+		{name: "quote invoice", model: QuoteInvoice{}, want: "quote_invoices"},
+		{name: "quote revision", model: QuoteRevision{}, want: "quote_revisions"},
+		{name: "recurring invoice usage", model: RecurringInvoiceUsage{}, want: "recurring_invoice_usage"},
+		{name: "recurring invoice price change", model: RecurringInvoicePriceChange{}, want: "recurring_invoice_price_changes"},
 		{name: "reminder rule", model: ReminderRule{}, want: "reminder_rules"},
 		{name: "payment reminder", model: PaymentReminder{}, want: "payment_reminders"},
 		{name: "tenant audit event", model: TenantAuditEvent{}, want: "tenant_audit_events"},
@@ -1224,11 +1251,41 @@ func TestRecurringInvoiceLine_Fields(t *testing.T) {
 		UnitPrice:          NewDecimalFromFloat(99.00),
 		DiscountPercent:    DecimalZero(),
 		VATRate:            NewDecimalFromFloat(22.00),
+		UsageMetric:        "api_calls",
 	}
 
 	if ril.Description != "Monthly service fee" {
 		t.Errorf("expected Monthly service fee, got %s", ril.Description)
 	}
+	if ril.UsageMetric != "api_calls" {
+		t.Errorf("expected api_calls, got %s", ril.UsageMetric)
+	}
+}
+
+func TestRecurringInvoiceUsageAndPriceChange_Fields(t *testing.T) {
+	invoiceID := "inv-1"
+	usage := RecurringInvoiceUsage{
+		ID:                 "usage-1",
+		RecurringInvoiceID: "ri-1",
+		Metric:             "kwh",
+		UsageDate:          time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		Quantity:           NewDecimalFromFloat(1250.5),
+		InvoiceID:          &invoiceID,
+	}
+	if usage.Metric != "kwh" || usage.InvoiceID == nil {
+		t.Errorf("unexpected usage record %+v", usage)
+	}
+
+	uplift := NewDecimalFromFloat(3.5)
+	change := RecurringInvoicePriceChange{
+		ID:                 "change-1",
+		RecurringInvoiceID: "ri-1",
+		EffectiveDate:      time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpliftPercent:      &uplift,
+	}
+	if change.UnitPrice != nil || change.UpliftPercent == nil || change.LineNumber != nil {
+		t.Errorf("unexpected price change %+v", change)
+	}
 }
 
 func TestKMDRow_Fields(t *testing.T) {
@@ -45,6 +45,9 @@ type OrderLine struct {
 	LineVAT         Decimal `gorm:"column:line_vat;type:numeric(28,8);not null" json:"line_vat"`
 	LineTotal       Decimal `gorm:"column:line_total;type:numeric(28,8);not null" json:"line_total"`
 	ProductID       *string `gorm:"column:product_id;type:uuid" json:"product_id,omitempty"`
+	ShippedQuantity Decimal `gorm:"column:shipped_quantity;type:numeric(18,6);not null;default:0" json:"shipped_quantity"`
+	// InvoicedQuantity is the part of the line already billed on invoices.
+	InvoicedQuantity Decimal `gorm:"column:invoiced_quantity;type:numeric(18,6);not null;default:0" json:"invoiced_quantity"`
 }
 
 // TableName returns the table name for GORM.
@@ -73,3 +76,69 @@ type OrderStockReservation struct {
 func (OrderStockReservation) TableName() string {
 	return "order_stock_reservations"
 }
+
+// OrderInvoice records one invoice billed from a sales order.
+type OrderInvoice struct {
+	ID                 string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID           string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	OrderID            string    `gorm:"column:order_id;type:uuid;not null;index" json:"order_id"`
+	InvoiceID          string    `gorm:"column:invoice_id;type:uuid;not null" json:"invoice_id"`
+	InvoiceNumber      string    `gorm:"column:invoice_number;size:50;not null" json:"invoice_number"`
+	Kind               string    `gorm:"size:20;not null;default:'QUANTITY'" json:"kind"`
+	Percent            Decimal   `gorm:"type:numeric(9,4);not null;default:0" json:"percent"`
+	Subtotal           Decimal   `gorm:"type:numeric(28,8);not null;default:0" json:"subtotal"`
+	VATRate            Decimal   `gorm:"column:vat_rate;type:numeric(5,2);not null;default:0" json:"vat_rate"`
+	PrepaymentDeducted Decimal   `gorm:"column:prepayment_deducted;type:numeric(28,8);not null;default:0" json:"prepayment_deducted"`
+	NettedAmount       Decimal   `gorm:"column:netted_amount;type:numeric(28,8);not null;default:0" json:"netted_amount"`
+	CreatedBy          *string   `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
+	CreatedAt          time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (OrderInvoice) TableName() string {
+	return "order_invoices"
+}
+
+// OrderShipment records a full or partial shipment of a sales order.
+type OrderShipment struct {
+	ID             string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID       string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	ShipmentNumber string    `gorm:"column:shipment_number;size:50;not null" json:"shipment_number"`
+	OrderID        string    `gorm:"column:order_id;type:uuid;not null;index" json:"order_id"`
+	WarehouseID    *string   `gorm:"column:warehouse_id;type:uuid" json:"warehouse_id,omitempty"`
+	ShipmentDate   time.Time `gorm:"column:shipment_date;type:date;not null" json:"shipment_date"`
+	CostingMethod  *string   `gorm:"column:costing_method;size:20" json:"costing_method,omitempty"`
+	TotalCost      Decimal   `gorm:"column:total_cost;type:numeric(28,8);not null;default:0" json:"total_cost"`
+	Notes          string    `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy      string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt      time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (OrderShipment) TableName() string {
+	return "order_shipments"
+}
+
+// OrderShipmentLine records the quantity shipped for one order line.
+type OrderShipmentLine struct {
+	ID             string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID       string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	ShipmentID     string  `gorm:"column:shipment_id;type:uuid;not null;index" json:"shipment_id"`
+	OrderLineID    string  `gorm:"column:order_line_id;type:uuid;not null" json:"order_line_id"`
+	LineNumber     int     `gorm:"column:line_number;not null" json:"line_number"`
+	ProductID      *string `gorm:"column:product_id;type:uuid" json:"product_id,omitempty"`
+	Description    string  `gorm:"type:text;not null" json:"description"`
+	Quantity       Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	Unit           string  `gorm:"size:20" json:"unit,omitempty"`
+	UnitCost       Decimal `gorm:"column:unit_cost;type:numeric(28,8);not null;default:0" json:"unit_cost"`
+	TotalCost      Decimal `gorm:"column:total_cost;type:numeric(28,8);not null;default:0" json:"total_cost"`
+	LotNumber      *string `gorm:"column:lot_number;size:100" json:"lot_number,omitempty"`
+	SerialNumber   *string `gorm:"column:serial_number;size:100" json:"serial_number,omitempty"`
+	ExpiryDate     *string `gorm:"column:expiry_date;type:date" json:"expiry_date,omitempty"`
+	JournalEntryID *string `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+}
+
+// TableName returns the table name for GORM.
+func (OrderShipmentLine) TableName() string {
+	return "order_shipment_lines"
+}
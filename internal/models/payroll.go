@@ -47,6 +47,7 @@ type Employee struct {
 	ApplyBasicExemption  bool    `gorm:"column:apply_basic_exemption;not null;default:true" json:"apply_basic_exemption"`
 	BasicExemptionAmount Decimal `gorm:"column:basic_exemption_amount;type:numeric(28,8);not null;default:0" json:"basic_exemption_amount"`
 	FundedPensionRate    Decimal `gorm:"column:funded_pension_rate;type:numeric(5,4);not null;default:0.02" json:"funded_pension_rate"`
+	HourlyRate           Decimal `gorm:"column:hourly_rate;type:numeric(15,4);not null;default:0" json:"hourly_rate"`
 
 	IsActive  bool      `gorm:"column:is_active;not null;default:true" json:"is_active"`
 	CreatedAt time.Time `gorm:"not null;default:now()" json:"created_at"`
@@ -96,6 +97,7 @@ type PayrollRun struct {
 	CreatedBy         *string       `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
 	ApprovedBy        *string       `gorm:"column:approved_by;type:uuid" json:"approved_by,omitempty"`
 	ApprovedAt        *time.Time    `gorm:"column:approved_at" json:"approved_at,omitempty"`
+	JournalEntryID    *string       `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
 	CreatedAt         time.Time     `gorm:"not null;default:now()" json:"created_at"`
 	UpdatedAt         time.Time     `gorm:"not null;default:now()" json:"updated_at"`
 
@@ -108,6 +110,52 @@ func (PayrollRun) TableName() string {
 	return "payroll_runs"
 }
 
+// PayrollPostingAccounts maps payroll amounts to ledger accounts, either as the
+// tenant default (empty department) or as a department override (GORM model).
+type PayrollPostingAccounts struct {
+	ID                            string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID                      string    `gorm:"type:uuid;not null;uniqueIndex:payroll_posting_accounts_department_unique" json:"tenant_id"`
+	Department                    string    `gorm:"size:100;not null;default:'';uniqueIndex:payroll_posting_accounts_department_unique" json:"department"`
+	CostCenterID                  *string   `gorm:"column:cost_center_id;type:uuid" json:"cost_center_id,omitempty"`
+	SalaryExpenseAccountID        *string   `gorm:"column:salary_expense_account_id;type:uuid" json:"salary_expense_account_id,omitempty"`
+	EmployerTaxExpenseAccountID   *string   `gorm:"column:employer_tax_expense_account_id;type:uuid" json:"employer_tax_expense_account_id,omitempty"`
+	IncomeTaxPayableAccountID     *string   `gorm:"column:income_tax_payable_account_id;type:uuid" json:"income_tax_payable_account_id,omitempty"`
+	SocialTaxPayableAccountID     *string   `gorm:"column:social_tax_payable_account_id;type:uuid" json:"social_tax_payable_account_id,omitempty"`
+	UnemploymentPayableAccountID  *string   `gorm:"column:unemployment_payable_account_id;type:uuid" json:"unemployment_payable_account_id,omitempty"`
+	FundedPensionPayableAccountID *string   `gorm:"column:funded_pension_payable_account_id;type:uuid" json:"funded_pension_payable_account_id,omitempty"`
+	NetPayPayableAccountID        *string   `gorm:"column:net_pay_payable_account_id;type:uuid" json:"net_pay_payable_account_id,omitempty"`
+	CreatedAt                     time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt                     time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (PayrollPostingAccounts) TableName() string {
+	return "payroll_posting_accounts"
+}
+
+// PayrollPayment records a net salary payment file generated from a payroll run (GORM model)
+type PayrollPayment struct {
+	ID             string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID       string    `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	PayrollRunID   string    `gorm:"column:payroll_run_id;type:uuid;not null;index" json:"payroll_run_id"`
+	BankAccountID  *string   `gorm:"column:bank_account_id;type:uuid" json:"bank_account_id,omitempty"`
+	PaymentID      *string   `gorm:"column:payment_id;type:uuid" json:"payment_id,omitempty"`
+	JournalEntryID *string   `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	ExecutionDate  time.Time `gorm:"column:execution_date;type:date;not null" json:"execution_date"`
+	MessageID      string    `gorm:"column:message_id;size:35;not null" json:"message_id"`
+	NetAmount      Decimal   `gorm:"column:net_amount;type:numeric(15,2);not null;default:0" json:"net_amount"`
+	TaxAmount      Decimal   `gorm:"column:tax_amount;type:numeric(15,2);not null;default:0" json:"tax_amount"`
+	TaxReference   string    `gorm:"column:tax_reference;size:35;not null;default:''" json:"tax_reference"`
+	PayslipCount   int       `gorm:"column:payslip_count;not null;default:0" json:"payslip_count"`
+	CreatedBy      *string   `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
+	CreatedAt      time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (PayrollPayment) TableName() string {
+	return "payroll_payments"
+}
+
 // Payslip represents an individual employee's payslip (GORM model)
 type Payslip struct {
 	ID           string `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
@@ -127,9 +175,10 @@ type Payslip struct {
 	TotalEmployerCost       Decimal `gorm:"column:total_employer_cost;type:numeric(28,8);not null;default:0" json:"total_employer_cost"`
 	BasicExemptionApplied   Decimal `gorm:"column:basic_exemption_applied;type:numeric(28,8);not null;default:0" json:"basic_exemption_applied"`
 
-	PaymentStatus string     `gorm:"column:payment_status;size:20;default:'PENDING'" json:"payment_status"`
-	PaidAt        *time.Time `gorm:"column:paid_at" json:"paid_at,omitempty"`
-	CreatedAt     time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	PaymentStatus    string     `gorm:"column:payment_status;size:20;default:'PENDING'" json:"payment_status"`
+	PaidAt           *time.Time `gorm:"column:paid_at" json:"paid_at,omitempty"`
+	PayrollPaymentID *string    `gorm:"column:payroll_payment_id;type:uuid" json:"payroll_payment_id,omitempty"`
+	CreatedAt        time.Time  `gorm:"not null;default:now()" json:"created_at"`
 
 	// Relations
 	PayrollRun *PayrollRun `gorm:"foreignKey:PayrollRunID" json:"payroll_run,omitempty"`
@@ -141,6 +190,75 @@ func (Payslip) TableName() string {
 	return "payslips"
 }
 
+// PayslipComponent is one pay line of a payslip, such as vacation pay (GORM model)
+type PayslipComponent struct {
+	ID            string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID      string    `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	PayslipID     string    `gorm:"column:payslip_id;type:uuid;not null;index" json:"payslip_id"`
+	ComponentType string    `gorm:"column:component_type;size:30;not null" json:"component_type"`
+	Name          string    `gorm:"size:100;not null" json:"name"`
+	PaymentType   string    `gorm:"column:payment_type;size:4;not null" json:"payment_type"`
+	LeaveRecordID *string   `gorm:"column:leave_record_id;type:uuid" json:"leave_record_id,omitempty"`
+	Days          Decimal   `gorm:"type:numeric(10,2);not null;default:0" json:"days"`
+	DailyRate     Decimal   `gorm:"column:daily_rate;type:numeric(15,4);not null;default:0" json:"daily_rate"`
+	Hours         Decimal   `gorm:"type:numeric(10,2);not null;default:0" json:"hours"`
+	HourlyRate    Decimal   `gorm:"column:hourly_rate;type:numeric(15,4);not null;default:0" json:"hourly_rate"`
+	Amount        Decimal   `gorm:"type:numeric(15,2);not null;default:0" json:"amount"`
+	SortOrder     int       `gorm:"column:sort_order;not null;default:0" json:"sort_order"`
+	CreatedAt     time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (PayslipComponent) TableName() string {
+	return "payslip_components"
+}
+
+// TimesheetEntry represents the hours an employee worked on one day (GORM model)
+type TimesheetEntry struct {
+	ID            string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID      string     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	EmployeeID    string     `gorm:"column:employee_id;type:uuid;not null;index" json:"employee_id"`
+	WorkDate      time.Time  `gorm:"column:work_date;type:date;not null" json:"work_date"`
+	RegularHours  Decimal    `gorm:"column:regular_hours;type:numeric(6,2);not null;default:0" json:"regular_hours"`
+	OvertimeHours Decimal    `gorm:"column:overtime_hours;type:numeric(6,2);not null;default:0" json:"overtime_hours"`
+	NightHours    Decimal    `gorm:"column:night_hours;type:numeric(6,2);not null;default:0" json:"night_hours"`
+	HolidayHours  Decimal    `gorm:"column:holiday_hours;type:numeric(6,2);not null;default:0" json:"holiday_hours"`
+	Status        string     `gorm:"size:20;not null;default:'PENDING'" json:"status"`
+	Notes         string     `gorm:"type:text" json:"notes,omitempty"`
+	ApprovedBy    *string    `gorm:"column:approved_by;type:uuid" json:"approved_by,omitempty"`
+	ApprovedAt    *time.Time `gorm:"column:approved_at" json:"approved_at,omitempty"`
+	CreatedAt     time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt     time.Time  `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (TimesheetEntry) TableName() string {
+	return "timesheet_entries"
+}
+
+// EmploymentEvent records one change to an employee's employment register
+// (TÖR) state: start, end, suspension or working-time change.
+type EmploymentEvent struct {
+	ID               string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID         string     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	EmployeeID       string     `gorm:"column:employee_id;type:uuid;not null;index" json:"employee_id"`
+	EventType        string     `gorm:"column:event_type;size:30;not null" json:"event_type"`
+	EventDate        time.Time  `gorm:"column:event_date;type:date;not null" json:"event_date"`
+	TerminationCode  string     `gorm:"column:termination_code;size:20" json:"termination_code,omitempty"`
+	SuspensionReason string     `gorm:"column:suspension_reason;size:30" json:"suspension_reason,omitempty"`
+	WorkingTimeRatio Decimal    `gorm:"column:working_time_ratio;type:numeric(5,4);not null;default:1" json:"working_time_ratio"`
+	Position         string     `gorm:"size:200" json:"position,omitempty"`
+	Notes            string     `gorm:"type:text" json:"notes,omitempty"`
+	ExportedAt       *time.Time `gorm:"column:exported_at" json:"exported_at,omitempty"`
+	CreatedBy        *string    `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
+	CreatedAt        time.Time  `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (EmploymentEvent) TableName() string {
+	return "employment_events"
+}
+
 // TSDDeclaration represents an Estonian TSD tax declaration.
 type TSDDeclaration struct {
 	ID           string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
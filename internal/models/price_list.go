@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// PriceList represents a named sales price list in one currency.
+type PriceList struct {
+	ID        string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID  string     `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	Code      string     `gorm:"size:50;not null" json:"code"`
+	Name      string     `gorm:"size:255;not null" json:"name"`
+	Currency  string     `gorm:"size:3;not null;default:'EUR'" json:"currency"`
+	ValidFrom *time.Time `gorm:"column:valid_from;type:date" json:"valid_from,omitempty"`
+	ValidTo   *time.Time `gorm:"column:valid_to;type:date" json:"valid_to,omitempty"`
+	IsDefault bool       `gorm:"column:is_default;not null;default:false" json:"is_default"`
+	IsActive  bool       `gorm:"column:is_active;not null;default:true" json:"is_active"`
+	Notes     string     `gorm:"type:text" json:"notes,omitempty"`
+	CreatedAt time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time  `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (PriceList) TableName() string {
+	return "price_lists"
+}
+
+// PriceListItem represents a product price from a minimum quantity on a price list.
+type PriceListItem struct {
+	ID          string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID    string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	PriceListID string  `gorm:"column:price_list_id;type:uuid;not null;index" json:"price_list_id"`
+	ProductID   string  `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	MinQuantity Decimal `gorm:"column:min_quantity;type:numeric(18,6);not null;default:0" json:"min_quantity"`
+	UnitPrice   Decimal `gorm:"column:unit_price;type:numeric(28,8);not null" json:"unit_price"`
+}
+
+// TableName returns the table name for GORM.
+func (PriceListItem) TableName() string {
+	return "price_list_items"
+}
+
+// CustomerGroup represents a group of customers sharing a price list and discount.
+type CustomerGroup struct {
+	ID              string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID        string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	Code            string    `gorm:"size:50;not null" json:"code"`
+	Name            string    `gorm:"size:255;not null" json:"name"`
+	PriceListID     *string   `gorm:"column:price_list_id;type:uuid" json:"price_list_id,omitempty"`
+	DiscountPercent Decimal   `gorm:"column:discount_percent;type:numeric(5,2);not null;default:0" json:"discount_percent"`
+	CreatedAt       time.Time `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt       time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (CustomerGroup) TableName() string {
+	return "customer_groups"
+}
+
+// CustomerPricing represents the pricing terms of one contact.
+type CustomerPricing struct {
+	ContactID       string    `gorm:"column:contact_id;type:uuid;primaryKey" json:"contact_id"`
+	TenantID        string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	CustomerGroupID *string   `gorm:"column:customer_group_id;type:uuid" json:"customer_group_id,omitempty"`
+	PriceListID     *string   `gorm:"column:price_list_id;type:uuid" json:"price_list_id,omitempty"`
+	DiscountPercent Decimal   `gorm:"column:discount_percent;type:numeric(5,2);not null;default:0" json:"discount_percent"`
+	UpdatedAt       time.Time `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (CustomerPricing) TableName() string {
+	return "customer_pricing"
+}
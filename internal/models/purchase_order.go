@@ -0,0 +1,203 @@
+package models
+
+import "time"
+
+// PurchaseOrder represents a tenant purchase order to a supplier.
+type PurchaseOrder struct {
+	ID           string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID     string     `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	PONumber     string     `gorm:"column:po_number;size:50;not null" json:"po_number"`
+	ContactID    string     `gorm:"column:contact_id;type:uuid;not null;index" json:"contact_id"`
+	WarehouseID  string     `gorm:"column:warehouse_id;type:uuid;not null" json:"warehouse_id"`
+	OrderDate    time.Time  `gorm:"column:order_date;type:date;not null" json:"order_date"`
+	ExpectedDate *time.Time `gorm:"column:expected_date;type:date" json:"expected_date,omitempty"`
+	Status       string     `gorm:"size:20;not null;default:'DRAFT'" json:"status"`
+	Currency     string     `gorm:"size:3;not null;default:'EUR'" json:"currency"`
+	ExchangeRate Decimal    `gorm:"column:exchange_rate;type:numeric(18,10);not null;default:1" json:"exchange_rate"`
+	Subtotal     Decimal    `gorm:"type:numeric(28,8);not null;default:0" json:"subtotal"`
+	VATAmount    Decimal    `gorm:"column:vat_amount;type:numeric(28,8);not null;default:0" json:"vat_amount"`
+	Total        Decimal    `gorm:"type:numeric(28,8);not null;default:0" json:"total"`
+	Notes        string     `gorm:"type:text" json:"notes,omitempty"`
+	ApprovedAt   *time.Time `gorm:"column:approved_at" json:"approved_at,omitempty"`
+	ApprovedBy   *string    `gorm:"column:approved_by;type:uuid" json:"approved_by,omitempty"`
+	CreatedAt    time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	CreatedBy    string     `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	UpdatedAt    time.Time  `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// PurchaseOrderLine represents a product line on a purchase order with its
+// received and invoiced progress.
+type PurchaseOrderLine struct {
+	ID               string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID         string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	PurchaseOrderID  string  `gorm:"column:purchase_order_id;type:uuid;not null;index" json:"purchase_order_id"`
+	LineNumber       int     `gorm:"column:line_number;not null" json:"line_number"`
+	ProductID        string  `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	Description      string  `gorm:"type:text;not null" json:"description"`
+	Quantity         Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	Unit             string  `gorm:"size:20" json:"unit,omitempty"`
+	UnitPrice        Decimal `gorm:"column:unit_price;type:numeric(28,8);not null" json:"unit_price"`
+	DiscountPercent  Decimal `gorm:"column:discount_percent;type:numeric(5,2);not null;default:0" json:"discount_percent"`
+	VATRate          Decimal `gorm:"column:vat_rate;type:numeric(5,2);not null;default:0" json:"vat_rate"`
+	LineSubtotal     Decimal `gorm:"column:line_subtotal;type:numeric(28,8);not null" json:"line_subtotal"`
+	LineVAT          Decimal `gorm:"column:line_vat;type:numeric(28,8);not null" json:"line_vat"`
+	LineTotal        Decimal `gorm:"column:line_total;type:numeric(28,8);not null" json:"line_total"`
+	ReceivedQuantity Decimal `gorm:"column:received_quantity;type:numeric(18,6);not null;default:0" json:"received_quantity"`
+	ReceivedAmount   Decimal `gorm:"column:received_amount;type:numeric(28,8);not null;default:0" json:"received_amount"`
+	InvoicedQuantity Decimal `gorm:"column:invoiced_quantity;type:numeric(18,6);not null;default:0" json:"invoiced_quantity"`
+	InvoicedAmount   Decimal `gorm:"column:invoiced_amount;type:numeric(28,8);not null;default:0" json:"invoiced_amount"`
+	AccrualCleared   Decimal `gorm:"column:accrual_cleared;type:numeric(28,8);not null;default:0" json:"accrual_cleared"`
+}
+
+// TableName returns the table name for GORM.
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
+
+// GoodsReceipt records goods received against a purchase order.
+type GoodsReceipt struct {
+	ID                string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID          string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	ReceiptNumber     string    `gorm:"column:receipt_number;size:50;not null" json:"receipt_number"`
+	PurchaseOrderID   string    `gorm:"column:purchase_order_id;type:uuid;not null;index" json:"purchase_order_id"`
+	WarehouseID       string    `gorm:"column:warehouse_id;type:uuid;not null" json:"warehouse_id"`
+	ReceiptDate       time.Time `gorm:"column:receipt_date;type:date;not null" json:"receipt_date"`
+	SupplierReference string    `gorm:"column:supplier_reference;size:100" json:"supplier_reference,omitempty"`
+	Notes             string    `gorm:"type:text" json:"notes,omitempty"`
+	TotalCost         Decimal   `gorm:"column:total_cost;type:numeric(28,8);not null;default:0" json:"total_cost"`
+	AccrualAccountID  string    `gorm:"column:accrual_account_id;type:uuid;not null" json:"accrual_account_id"`
+	JournalEntryID    *string   `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	CreatedBy         string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt         time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (GoodsReceipt) TableName() string {
+	return "goods_receipts"
+}
+
+// GoodsReceiptLine records one received purchase order line.
+type GoodsReceiptLine struct {
+	ID                  string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID            string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	GoodsReceiptID      string  `gorm:"column:goods_receipt_id;type:uuid;not null;index" json:"goods_receipt_id"`
+	PurchaseOrderLineID string  `gorm:"column:purchase_order_line_id;type:uuid;not null" json:"purchase_order_line_id"`
+	ProductID           string  `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	Quantity            Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	UnitCost            Decimal `gorm:"column:unit_cost;type:numeric(28,8);not null" json:"unit_cost"`
+	TotalCost           Decimal `gorm:"column:total_cost;type:numeric(28,8);not null" json:"total_cost"`
+	LotNumber           *string `gorm:"column:lot_number;size:100" json:"lot_number,omitempty"`
+	SerialNumber        *string `gorm:"column:serial_number;size:100" json:"serial_number,omitempty"`
+	ExpiryDate          *string `gorm:"column:expiry_date;type:date" json:"expiry_date,omitempty"`
+}
+
+// TableName returns the table name for GORM.
+func (GoodsReceiptLine) TableName() string {
+	return "goods_receipt_lines"
+}
+
+// PurchaseInvoiceMatch records a supplier invoice matched to a purchase order
+// and its receipts.
+type PurchaseInvoiceMatch struct {
+	ID                     string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID               string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	PurchaseOrderID        string    `gorm:"column:purchase_order_id;type:uuid;not null;index" json:"purchase_order_id"`
+	InvoiceID              string    `gorm:"column:invoice_id;type:uuid;not null" json:"invoice_id"`
+	MatchDate              time.Time `gorm:"column:match_date;type:date;not null" json:"match_date"`
+	AccrualCleared         Decimal   `gorm:"column:accrual_cleared;type:numeric(28,8);not null;default:0" json:"accrual_cleared"`
+	InvoicedAmount         Decimal   `gorm:"column:invoiced_amount;type:numeric(28,8);not null;default:0" json:"invoiced_amount"`
+	VATAmount              Decimal   `gorm:"column:vat_amount;type:numeric(28,8);not null;default:0" json:"vat_amount"`
+	PriceVariance          Decimal   `gorm:"column:price_variance;type:numeric(28,8);not null;default:0" json:"price_variance"`
+	PayableAmount          Decimal   `gorm:"column:payable_amount;type:numeric(28,8);not null;default:0" json:"payable_amount"`
+	AccrualAccountID       string    `gorm:"column:accrual_account_id;type:uuid;not null" json:"accrual_account_id"`
+	PayableAccountID       string    `gorm:"column:payable_account_id;type:uuid;not null" json:"payable_account_id"`
+	VATAccountID           *string   `gorm:"column:vat_account_id;type:uuid" json:"vat_account_id,omitempty"`
+	PriceVarianceAccountID *string   `gorm:"column:price_variance_account_id;type:uuid" json:"price_variance_account_id,omitempty"`
+	JournalEntryID         *string   `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	CreatedBy              string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt              time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (PurchaseInvoiceMatch) TableName() string {
+	return "purchase_invoice_matches"
+}
+
+// PurchaseInvoiceMatchLine records one invoice line matched to a purchase order line.
+type PurchaseInvoiceMatchLine struct {
+	ID                  string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID            string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	MatchID             string  `gorm:"column:match_id;type:uuid;not null;index" json:"match_id"`
+	PurchaseOrderLineID string  `gorm:"column:purchase_order_line_id;type:uuid;not null" json:"purchase_order_line_id"`
+	InvoiceLineID       string  `gorm:"column:invoice_line_id;type:uuid;not null" json:"invoice_line_id"`
+	Quantity            Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	AccrualCleared      Decimal `gorm:"column:accrual_cleared;type:numeric(28,8);not null" json:"accrual_cleared"`
+	InvoicedAmount      Decimal `gorm:"column:invoiced_amount;type:numeric(28,8);not null" json:"invoiced_amount"`
+	PriceVariance       Decimal `gorm:"column:price_variance;type:numeric(28,8);not null" json:"price_variance"`
+}
+
+// TableName returns the table name for GORM.
+func (PurchaseInvoiceMatchLine) TableName() string {
+	return "purchase_invoice_match_lines"
+}
+
+// LandedCost records a freight, duty or broker invoice allocated onto received stock.
+type LandedCost struct {
+	ID               string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID         string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	LandedCostNumber string    `gorm:"column:landed_cost_number;size:50;not null" json:"landed_cost_number"`
+	InvoiceID        string    `gorm:"column:invoice_id;type:uuid;not null" json:"invoice_id"`
+	CostDate         time.Time `gorm:"column:cost_date;type:date;not null" json:"cost_date"`
+	AllocationMethod string    `gorm:"column:allocation_method;size:20;not null;default:VALUE" json:"allocation_method"`
+	Amount           Decimal   `gorm:"type:numeric(28,8);not null;default:0" json:"amount"`
+	VATAmount        Decimal   `gorm:"column:vat_amount;type:numeric(28,8);not null;default:0" json:"vat_amount"`
+	PayableAmount    Decimal   `gorm:"column:payable_amount;type:numeric(28,8);not null;default:0" json:"payable_amount"`
+	InventoryAmount  Decimal   `gorm:"column:inventory_amount;type:numeric(28,8);not null;default:0" json:"inventory_amount"`
+	COGSAmount       Decimal   `gorm:"column:cogs_amount;type:numeric(28,8);not null;default:0" json:"cogs_amount"`
+	PayableAccountID string    `gorm:"column:payable_account_id;type:uuid;not null" json:"payable_account_id"`
+	VATAccountID     *string   `gorm:"column:vat_account_id;type:uuid" json:"vat_account_id,omitempty"`
+	COGSAccountID    *string   `gorm:"column:cogs_account_id;type:uuid" json:"cogs_account_id,omitempty"`
+	JournalEntryID   *string   `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	Notes            string    `gorm:"type:text" json:"notes,omitempty"`
+	CreatedBy        string    `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt        time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (LandedCost) TableName() string {
+	return "landed_costs"
+}
+
+// LandedCostLine records the landed cost allocated to one goods receipt line.
+type LandedCostLine struct {
+	ID                 string  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID           string  `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	LandedCostID       string  `gorm:"column:landed_cost_id;type:uuid;not null;index" json:"landed_cost_id"`
+	GoodsReceiptID     string  `gorm:"column:goods_receipt_id;type:uuid;not null;index" json:"goods_receipt_id"`
+	GoodsReceiptLineID string  `gorm:"column:goods_receipt_line_id;type:uuid;not null" json:"goods_receipt_line_id"`
+	MovementID         *string `gorm:"column:movement_id;type:uuid" json:"movement_id,omitempty"`
+	ProductID          string  `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	WarehouseID        string  `gorm:"column:warehouse_id;type:uuid;not null" json:"warehouse_id"`
+	LotNumber          *string `gorm:"column:lot_number;size:100" json:"lot_number,omitempty"`
+	SerialNumber       *string `gorm:"column:serial_number;size:100" json:"serial_number,omitempty"`
+	ExpiryDate         *string `gorm:"column:expiry_date;type:date" json:"expiry_date,omitempty"`
+	Quantity           Decimal `gorm:"type:numeric(18,6);not null" json:"quantity"`
+	Basis              Decimal `gorm:"type:numeric(28,8);not null;default:0" json:"basis"`
+	Amount             Decimal `gorm:"type:numeric(28,8);not null;default:0" json:"amount"`
+	OnHandQuantity     Decimal `gorm:"column:on_hand_quantity;type:numeric(18,6);not null;default:0" json:"on_hand_quantity"`
+	IssuedQuantity     Decimal `gorm:"column:issued_quantity;type:numeric(18,6);not null;default:0" json:"issued_quantity"`
+	InventoryAmount    Decimal `gorm:"column:inventory_amount;type:numeric(28,8);not null;default:0" json:"inventory_amount"`
+	COGSAmount         Decimal `gorm:"column:cogs_amount;type:numeric(28,8);not null;default:0" json:"cogs_amount"`
+	PreviousUnitCost   Decimal `gorm:"column:previous_unit_cost;type:numeric(28,8);not null;default:0" json:"previous_unit_cost"`
+	NewUnitCost        Decimal `gorm:"column:new_unit_cost;type:numeric(28,8);not null;default:0" json:"new_unit_cost"`
+}
+
+// TableName returns the table name for GORM.
+func (LandedCostLine) TableName() string {
+	return "landed_cost_lines"
+}
@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Quote represents a tenant sales quote.
 type Quote struct {
@@ -11,6 +14,7 @@ type Quote struct {
 	QuoteDate            time.Time  `gorm:"column:quote_date;type:date;not null" json:"quote_date"`
 	ValidUntil           *time.Time `gorm:"column:valid_until;type:date" json:"valid_until,omitempty"`
 	Status               string     `gorm:"size:20;not null;default:'DRAFT'" json:"status"`
+	Revision             int        `gorm:"not null;default:1" json:"revision"`
 	Currency             string     `gorm:"size:3;not null;default:'EUR'" json:"currency"`
 	ExchangeRate         Decimal    `gorm:"column:exchange_rate;type:numeric(18,10);not null;default:1" json:"exchange_rate"`
 	Subtotal             Decimal    `gorm:"type:numeric(28,8);not null;default:0" json:"subtotal"`
@@ -45,9 +49,51 @@ type QuoteLine struct {
 	LineVAT         Decimal `gorm:"column:line_vat;type:numeric(28,8);not null" json:"line_vat"`
 	LineTotal       Decimal `gorm:"column:line_total;type:numeric(28,8);not null" json:"line_total"`
 	ProductID       *string `gorm:"column:product_id;type:uuid" json:"product_id,omitempty"`
+	// InvoicedQuantity is the part of the line already billed on invoices.
+	InvoicedQuantity Decimal `gorm:"column:invoiced_quantity;type:numeric(18,6);not null;default:0" json:"invoiced_quantity"`
 }
 
 // TableName returns the table name for GORM.
 func (QuoteLine) TableName() string {
 	return "quote_lines"
 }
+
+// QuoteInvoice records one invoice billed from a quote.
+type QuoteInvoice struct {
+	ID                 string    `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID           string    `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	QuoteID            string    `gorm:"column:quote_id;type:uuid;not null;index" json:"quote_id"`
+	InvoiceID          string    `gorm:"column:invoice_id;type:uuid;not null" json:"invoice_id"`
+	InvoiceNumber      string    `gorm:"column:invoice_number;size:50;not null" json:"invoice_number"`
+	Kind               string    `gorm:"size:20;not null;default:'QUANTITY'" json:"kind"`
+	Percent            Decimal   `gorm:"type:numeric(9,4);not null;default:0" json:"percent"`
+	Subtotal           Decimal   `gorm:"type:numeric(28,8);not null;default:0" json:"subtotal"`
+	VATRate            Decimal   `gorm:"column:vat_rate;type:numeric(5,2);not null;default:0" json:"vat_rate"`
+	PrepaymentDeducted Decimal   `gorm:"column:prepayment_deducted;type:numeric(28,8);not null;default:0" json:"prepayment_deducted"`
+	NettedAmount       Decimal   `gorm:"column:netted_amount;type:numeric(28,8);not null;default:0" json:"netted_amount"`
+	CreatedBy          *string   `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
+	CreatedAt          time.Time `gorm:"not null;default:now()" json:"created_at"`
+}
+
+// TableName returns the table name for GORM.
+func (QuoteInvoice) TableName() string {
+	return "quote_invoices"
+}
+
+// QuoteRevision is the frozen content of a quote revision as it was sent.
+type QuoteRevision struct {
+	ID         string          `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID   string          `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	QuoteID    string          `gorm:"column:quote_id;type:uuid;not null;index" json:"quote_id"`
+	Revision   int             `gorm:"not null" json:"revision"`
+	ValidUntil *time.Time      `gorm:"column:valid_until;type:date" json:"valid_until,omitempty"`
+	Currency   string          `gorm:"size:3;not null;default:'EUR'" json:"currency"`
+	Total      Decimal         `gorm:"type:numeric(28,8);not null;default:0" json:"total"`
+	Snapshot   json.RawMessage `gorm:"type:jsonb;not null;default:'{}'" json:"snapshot"`
+	SentAt     time.Time       `gorm:"not null;default:now()" json:"sent_at"`
+}
+
+// TableName returns the table name for GORM.
+func (QuoteRevision) TableName() string {
+	return "quote_revisions"
+}
@@ -27,6 +27,8 @@ type RecurringInvoice struct {
 	StartDate          time.Time  `gorm:"column:start_date;type:date;not null" json:"start_date"`
 	EndDate            *time.Time `gorm:"column:end_date;type:date" json:"end_date,omitempty"`
 	NextGenerationDate time.Time  `gorm:"column:next_generation_date;type:date;not null" json:"next_generation_date"`
+	BillingAnchorDate  *time.Time `gorm:"column:billing_anchor_date;type:date" json:"billing_anchor_date,omitempty"`
+	LastPeriodStart    *time.Time `gorm:"column:last_period_start;type:date" json:"last_period_start,omitempty"`
 	PaymentTermsDays   int        `gorm:"column:payment_terms_days;not null;default:14" json:"payment_terms_days"`
 	Reference          string     `gorm:"type:text" json:"reference,omitempty"`
 	Notes              string     `gorm:"type:text" json:"notes,omitempty"`
@@ -67,6 +69,7 @@ type RecurringInvoiceLine struct {
 	VATRate            Decimal `gorm:"column:vat_rate;type:numeric(5,2);not null;default:0" json:"vat_rate"`
 	AccountID          *string `gorm:"column:account_id;type:uuid" json:"account_id,omitempty"`
 	ProductID          *string `gorm:"column:product_id;type:uuid" json:"product_id,omitempty"`
+	UsageMetric        string  `gorm:"column:usage_metric;size:50;not null;default:''" json:"usage_metric,omitempty"`
 
 	// Relations
 	RecurringInvoice *RecurringInvoice `gorm:"foreignKey:RecurringInvoiceID" json:"recurring_invoice,omitempty"`
@@ -76,3 +79,44 @@ type RecurringInvoiceLine struct {
 func (RecurringInvoiceLine) TableName() string {
 	return "recurring_invoice_lines"
 }
+
+// RecurringInvoiceUsage is a metered quantity reported for a usage-based
+// recurring invoice line (GORM model)
+type RecurringInvoiceUsage struct {
+	ID                 string    `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID           string    `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	RecurringInvoiceID string    `gorm:"column:recurring_invoice_id;type:uuid;not null;index" json:"recurring_invoice_id"`
+	Metric             string    `gorm:"size:50;not null" json:"metric"`
+	UsageDate          time.Time `gorm:"column:usage_date;type:date;not null" json:"usage_date"`
+	Quantity           Decimal   `gorm:"type:numeric(18,6);not null;default:0" json:"quantity"`
+	Reference          string    `gorm:"type:text;not null;default:''" json:"reference,omitempty"`
+	InvoiceID          *string   `gorm:"column:invoice_id;type:uuid" json:"invoice_id,omitempty"`
+	CreatedAt          time.Time `gorm:"not null;default:now()" json:"created_at"`
+	CreatedBy          *string   `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (RecurringInvoiceUsage) TableName() string {
+	return "recurring_invoice_usage"
+}
+
+// RecurringInvoicePriceChange schedules a new unit price or a percentage
+// uplift for recurring invoice lines from an effective date (GORM model)
+type RecurringInvoicePriceChange struct {
+	ID                 string     `gorm:"type:uuid;primaryKey" json:"id"`
+	TenantID           string     `gorm:"type:uuid;not null;index" json:"tenant_id"`
+	RecurringInvoiceID string     `gorm:"column:recurring_invoice_id;type:uuid;not null;index" json:"recurring_invoice_id"`
+	LineNumber         *int       `gorm:"column:line_number" json:"line_number,omitempty"`
+	EffectiveDate      time.Time  `gorm:"column:effective_date;type:date;not null" json:"effective_date"`
+	UnitPrice          *Decimal   `gorm:"column:unit_price;type:numeric(28,8)" json:"unit_price,omitempty"`
+	UpliftPercent      *Decimal   `gorm:"column:uplift_percent;type:numeric(9,4)" json:"uplift_percent,omitempty"`
+	Note               string     `gorm:"type:text;not null;default:''" json:"note,omitempty"`
+	AppliedAt          *time.Time `gorm:"column:applied_at" json:"applied_at,omitempty"`
+	CreatedAt          time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	CreatedBy          *string    `gorm:"column:created_by;type:uuid" json:"created_by,omitempty"`
+}
+
+// TableName returns the table name for GORM
+func (RecurringInvoicePriceChange) TableName() string {
+	return "recurring_invoice_price_changes"
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// StockCount represents a stock count session for one warehouse.
+type StockCount struct {
+	ID                string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID          string     `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	CountNumber       string     `gorm:"column:count_number;size:50;not null" json:"count_number"`
+	WarehouseID       string     `gorm:"column:warehouse_id;type:uuid;not null" json:"warehouse_id"`
+	CountDate         time.Time  `gorm:"column:count_date;type:date;not null" json:"count_date"`
+	Status            string     `gorm:"size:20;not null;default:'OPEN'" json:"status"`
+	ValuationMethod   string     `gorm:"column:valuation_method;size:30;not null" json:"valuation_method"`
+	Notes             string     `gorm:"type:text" json:"notes,omitempty"`
+	VarianceAccountID *string    `gorm:"column:variance_account_id;type:uuid" json:"variance_account_id,omitempty"`
+	JournalEntryID    *string    `gorm:"column:journal_entry_id;type:uuid" json:"journal_entry_id,omitempty"`
+	SubmittedAt       *time.Time `gorm:"column:submitted_at" json:"submitted_at,omitempty"`
+	SubmittedBy       *string    `gorm:"column:submitted_by;type:uuid" json:"submitted_by,omitempty"`
+	ApprovedAt        *time.Time `gorm:"column:approved_at" json:"approved_at,omitempty"`
+	ApprovedBy        *string    `gorm:"column:approved_by;type:uuid" json:"approved_by,omitempty"`
+	CreatedBy         string     `gorm:"column:created_by;type:uuid;not null" json:"created_by"`
+	CreatedAt         time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	UpdatedAt         time.Time  `gorm:"not null;default:now()" json:"updated_at"`
+}
+
+// TableName returns the table name for GORM.
+func (StockCount) TableName() string {
+	return "stock_counts"
+}
+
+// StockCountLine represents one product, lot and serial position in a stock
+// count with its frozen expected quantity and the counted quantity.
+type StockCountLine struct {
+	ID               string     `gorm:"type:uuid;primaryKey;default:gen_random_uuid()" json:"id"`
+	TenantID         string     `gorm:"column:tenant_id;type:uuid;not null;index" json:"tenant_id"`
+	StockCountID     string     `gorm:"column:stock_count_id;type:uuid;not null;index" json:"stock_count_id"`
+	LineNumber       int        `gorm:"column:line_number;not null" json:"line_number"`
+	ProductID        string     `gorm:"column:product_id;type:uuid;not null" json:"product_id"`
+	ProductCode      string     `gorm:"column:product_code;size:50;not null" json:"product_code"`
+	ProductName      string     `gorm:"column:product_name;size:255;not null" json:"product_name"`
+	Barcode          *string    `gorm:"size:100" json:"barcode,omitempty"`
+	Unit             string     `gorm:"size:20" json:"unit,omitempty"`
+	LotNumber        *string    `gorm:"column:lot_number;size:100" json:"lot_number,omitempty"`
+	SerialNumber     *string    `gorm:"column:serial_number;size:100" json:"serial_number,omitempty"`
+	ExpiryDate       *string    `gorm:"column:expiry_date;type:date" json:"expiry_date,omitempty"`
+	ExpectedQuantity Decimal    `gorm:"column:expected_quantity;type:numeric(18,6);not null;default:0" json:"expected_quantity"`
+	CountedQuantity  *Decimal   `gorm:"column:counted_quantity;type:numeric(18,6)" json:"counted_quantity,omitempty"`
+	UnitCost         Decimal    `gorm:"column:unit_cost;type:numeric(28,8);not null;default:0" json:"unit_cost"`
+	CountedBy        *string    `gorm:"column:counted_by;type:uuid" json:"counted_by,omitempty"`
+	CountedAt        *time.Time `gorm:"column:counted_at" json:"counted_at,omitempty"`
+}
+
+// TableName returns the table name for GORM.
+func (StockCountLine) TableName() string {
+	return "stock_count_lines"
+}
@@ -48,14 +48,15 @@ func (User) TableName() string {
 // TenantUserModel represents a user's membership in a tenant (GORM model)
 // Named TenantUserModel to avoid conflict with domain type
 type TenantUserModel struct {
-	TenantID  string     `gorm:"column:tenant_id;type:uuid;primaryKey" json:"tenant_id"`
-	UserID    string     `gorm:"column:user_id;type:uuid;primaryKey" json:"user_id"`
-	Role      string     `gorm:"size:50;not null" json:"role"`
-	IsDefault bool       `gorm:"column:is_default;not null;default:false" json:"is_default"`
-	IsActive  bool       `gorm:"column:is_active;not null;default:true" json:"is_active"`
-	InvitedBy *string    `gorm:"column:invited_by;type:uuid" json:"invited_by,omitempty"`
-	InvitedAt *time.Time `gorm:"column:invited_at" json:"invited_at,omitempty"`
-	CreatedAt time.Time  `gorm:"not null;default:now()" json:"created_at"`
+	TenantID   string     `gorm:"column:tenant_id;type:uuid;primaryKey" json:"tenant_id"`
+	UserID     string     `gorm:"column:user_id;type:uuid;primaryKey" json:"user_id"`
+	Role       string     `gorm:"size:50;not null" json:"role"`
+	EmployeeID *string    `gorm:"column:employee_id;type:uuid" json:"employee_id,omitempty"`
+	IsDefault  bool       `gorm:"column:is_default;not null;default:false" json:"is_default"`
+	IsActive   bool       `gorm:"column:is_active;not null;default:true" json:"is_active"`
+	InvitedBy  *string    `gorm:"column:invited_by;type:uuid" json:"invited_by,omitempty"`
+	InvitedAt  *time.Time `gorm:"column:invited_at" json:"invited_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"not null;default:now()" json:"created_at"`
 
 	// Relations
 	Tenant *Tenant `gorm:"foreignKey:TenantID" json:"tenant,omitempty"`
@@ -73,6 +74,7 @@ type UserInvitation struct {
 	TenantID   string     `gorm:"column:tenant_id;type:uuid;not null;index;uniqueIndex:idx_user_invitations_tenant_email" json:"tenant_id"`
 	Email      string     `gorm:"size:255;not null;uniqueIndex:idx_user_invitations_tenant_email" json:"email"`
 	Role       string     `gorm:"size:50;not null" json:"role"`
+	EmployeeID *string    `gorm:"column:employee_id;type:uuid" json:"employee_id,omitempty"`
 	InvitedBy  string     `gorm:"column:invited_by;type:uuid;not null" json:"invited_by"`
 	Token      string     `gorm:"size:255;not null;uniqueIndex" json:"-"`
 	ExpiresAt  time.Time  `gorm:"column:expires_at;not null" json:"expires_at"`
@@ -7,7 +7,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
 	"github.com/HMB-research/open-accounting/internal/models"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
@@ -29,6 +32,19 @@ type Repository interface {
 	GetStockReservation(ctx context.Context, schemaName, tenantID, orderID, productID, warehouseID string) (*OrderStockReservation, error)
 	UpsertStockReservation(ctx context.Context, schemaName string, reservation *OrderStockReservation) error
 	ReleaseStockReservation(ctx context.Context, schemaName, tenantID, orderID, productID, warehouseID string, quantity decimal.Decimal, reason, releasedBy string) (*OrderStockReservation, error)
+	GenerateShipmentNumber(ctx context.Context, schemaName, tenantID string) (string, error)
+	CreateShipment(ctx context.Context, schemaName string, shipment *OrderShipment, status OrderStatus) error
+	ListShipments(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderShipment, error)
+	RecordInvoice(ctx context.Context, schemaName string, billing *OrderInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error
+	ListInvoices(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderInvoice, error)
+	GetInvoiceByInvoiceID(ctx context.Context, schemaName, tenantID, invoiceID string) (*OrderInvoice, error)
+}
+
+// InventoryLedgerTransactionRepository runs order writes, stock movements and
+// their journal postings in one database transaction. Repositories that do not
+// implement it write them one at a time.
+type InventoryLedgerTransactionRepository interface {
+	WithInventoryLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockIssuer, kits kitIssuer) error) error
 }
 
 // ErrOrderNotFound is returned when an order is not found
@@ -37,6 +53,12 @@ var ErrOrderNotFound = fmt.Errorf("order not found")
 // ErrOrderStockReservationNotFound is returned when an order stock reservation is not found.
 var ErrOrderStockReservationNotFound = fmt.Errorf("order stock reservation not found")
 
+// ErrOrderShipmentNotFound is returned when an order shipment is not found.
+var ErrOrderShipmentNotFound = fmt.Errorf("order shipment not found")
+
+// ErrOrderInvoiceNotFound is returned when an invoice was not billed from an order.
+var ErrOrderInvoiceNotFound = fmt.Errorf("order invoice not found")
+
 var errOrdersRepositoryDatabaseNotConfigured = errors.New("orders repository database is not configured")
 
 var newGormDBFromPool = database.NewGormDBFromPool
@@ -61,6 +83,18 @@ func NewGORMRepository(db *gorm.DB) *GORMRepository {
 	return &GORMRepository{db: db}
 }
 
+// WithInventoryLedgerTransaction runs fn inside a GORM-backed transaction
+// shared by the orders repository, inventory, kit issues and the general ledger.
+func (r *GORMRepository) WithInventoryLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockIssuer, kits kitIssuer) error) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(&GORMRepository{db: tx}, inventory.NewServiceWithGORM(tx), assembly.NewServiceWithGORM(tx))
+	})
+}
+
 func (r *GORMRepository) dbWithContext(ctx context.Context) (*gorm.DB, error) {
 	if r == nil || r.db == nil {
 		return nil, errOrdersRepositoryDatabaseNotConfigured
@@ -414,6 +448,242 @@ func (r *GORMRepository) ReleaseStockReservation(
 	return r.GetStockReservation(ctx, schemaName, tenantID, orderID, productID, warehouseID)
 }
 
+// GenerateShipmentNumber generates a new delivery note number
+func (r *GORMRepository) GenerateShipmentNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	db, err := r.tenantTable(ctx, schemaName, "order_shipments")
+	if err != nil {
+		return "", fmt.Errorf("qualify order shipments table: %w", err)
+	}
+
+	var seq int
+	if err := db.
+		Select(`
+			COALESCE(MAX(
+				CASE
+					WHEN shipment_number ~ ? THEN CAST(SUBSTRING(shipment_number FROM ?) AS INTEGER)
+					ELSE 0
+				END
+			), 0) + 1
+		`, "DN-[0-9]+$", "DN-([0-9]+)$").
+		Where("tenant_id = ?", tenantID).
+		Scan(&seq).Error; err != nil {
+		return "", fmt.Errorf("generate shipment number: %w", err)
+	}
+	return fmt.Sprintf("DN-%05d", seq), nil
+}
+
+// CreateShipment stores a shipment with its lines, adds the shipped
+// quantities to the order lines and moves the order to the given status.
+func (r *GORMRepository) CreateShipment(ctx context.Context, schemaName string, shipment *OrderShipment, status OrderStatus) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		shipmentsTable, err := database.TenantTable(tx, schemaName, "order_shipments")
+		if err != nil {
+			return fmt.Errorf("qualify order shipments table: %w", err)
+		}
+		if err := shipmentsTable.Create(shipmentToModel(shipment)).Error; err != nil {
+			return fmt.Errorf("insert order shipment: %w", err)
+		}
+
+		lineModels := make([]models.OrderShipmentLine, len(shipment.Lines))
+		for i := range shipment.Lines {
+			shipment.Lines[i].ShipmentID = shipment.ID
+			lineModels[i] = *shipmentLineToModel(&shipment.Lines[i])
+		}
+		if len(lineModels) > 0 {
+			linesTable, _ := database.TenantTable(tx, schemaName, "order_shipment_lines")
+			if err := linesTable.Create(&lineModels).Error; err != nil {
+				return fmt.Errorf("insert order shipment line: %w", err)
+			}
+		}
+
+		for _, line := range shipment.Lines {
+			orderLinesTable, _ := database.TenantTable(tx, schemaName, "order_lines")
+			result := orderLinesTable.
+				Where("id = ? AND tenant_id = ? AND order_id = ? AND shipped_quantity + ? <= quantity", line.OrderLineID, shipment.TenantID, shipment.OrderID, line.Quantity).
+				Update("shipped_quantity", gorm.Expr("shipped_quantity + ?", line.Quantity))
+			if result.Error != nil {
+				return fmt.Errorf("update order line shipped quantity: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("order line %s has less open quantity than shipped", line.OrderLineID)
+			}
+		}
+
+		ordersTable, _ := database.TenantTable(tx, schemaName, "orders")
+		result := ordersTable.
+			Where("id = ? AND tenant_id = ? AND status IN ?", shipment.OrderID, shipment.TenantID, []string{string(OrderStatusConfirmed), string(OrderStatusProcessing)}).
+			Updates(map[string]interface{}{
+				"status":     string(status),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update order status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrOrderNotFound
+		}
+		return nil
+	})
+}
+
+// ListShipments retrieves the shipments of an order with their lines
+func (r *GORMRepository) ListShipments(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderShipment, error) {
+	db, err := r.tenantTable(ctx, schemaName, "order_shipments")
+	if err != nil {
+		return nil, fmt.Errorf("qualify order shipments table: %w", err)
+	}
+
+	var shipmentModels []models.OrderShipment
+	if err := db.
+		Where("tenant_id = ? AND order_id = ?", tenantID, orderID).
+		Order("shipment_date ASC").
+		Order("shipment_number ASC").
+		Find(&shipmentModels).Error; err != nil {
+		return nil, fmt.Errorf("list order shipments: %w", err)
+	}
+	if len(shipmentModels) == 0 {
+		return []OrderShipment{}, nil
+	}
+
+	shipmentIDs := make([]string, len(shipmentModels))
+	for i := range shipmentModels {
+		shipmentIDs[i] = shipmentModels[i].ID
+	}
+	linesTable, err := r.tenantTable(ctx, schemaName, "order_shipment_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify order shipment lines table: %w", err)
+	}
+	var lineModels []models.OrderShipmentLine
+	if err := linesTable.
+		Where("tenant_id = ? AND shipment_id IN ?", tenantID, shipmentIDs).
+		Order("line_number ASC").
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("list order shipment lines: %w", err)
+	}
+	linesByShipment := make(map[string][]OrderShipmentLine, len(shipmentModels))
+	for i := range lineModels {
+		line := shipmentLineFromModel(&lineModels[i])
+		linesByShipment[line.ShipmentID] = append(linesByShipment[line.ShipmentID], *line)
+	}
+
+	shipments := make([]OrderShipment, len(shipmentModels))
+	for i := range shipmentModels {
+		shipments[i] = *shipmentFromModel(&shipmentModels[i])
+		shipments[i].Lines = linesByShipment[shipments[i].ID]
+	}
+	return shipments, nil
+}
+
+// RecordInvoice stores the billing record of an invoice raised from an order,
+// adds the billed quantities to the order lines and the netted amounts to the
+// prepayments they deduct. The invoice completing the order also marks it
+// converted, failing when another invoice already did.
+func (r *GORMRepository) RecordInvoice(ctx context.Context, schemaName string, billing *OrderInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		invoicesTable, err := database.TenantTable(tx, schemaName, "order_invoices")
+		if err != nil {
+			return fmt.Errorf("qualify order invoices table: %w", err)
+		}
+		if err := invoicesTable.Create(orderInvoiceToModel(billing)).Error; err != nil {
+			return fmt.Errorf("insert order invoice: %w", err)
+		}
+
+		for lineID, quantity := range quantities {
+			orderLinesTable, _ := database.TenantTable(tx, schemaName, "order_lines")
+			result := orderLinesTable.
+				Where("id = ? AND tenant_id = ? AND order_id = ? AND invoiced_quantity + ? <= quantity", lineID, billing.TenantID, billing.OrderID, quantity).
+				Update("invoiced_quantity", gorm.Expr("invoiced_quantity + ?", quantity))
+			if result.Error != nil {
+				return fmt.Errorf("update order line invoiced quantity: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("order line %s has less uninvoiced quantity than billed", lineID)
+			}
+		}
+
+		for billingID, amount := range deductions {
+			prepaymentsTable, _ := database.TenantTable(tx, schemaName, "order_invoices")
+			result := prepaymentsTable.
+				Where("id = ? AND tenant_id = ? AND order_id = ? AND kind = ? AND netted_amount + ? <= subtotal", billingID, billing.TenantID, billing.OrderID, string(invoicing.BillingKindPrepayment), amount).
+				Update("netted_amount", gorm.Expr("netted_amount + ?", amount))
+			if result.Error != nil {
+				return fmt.Errorf("update order prepayment netted amount: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("order prepayment %s has less open amount than deducted", billingID)
+			}
+		}
+
+		if completes {
+			ordersTable, _ := database.TenantTable(tx, schemaName, "orders")
+			result := ordersTable.
+				Where("id = ? AND tenant_id = ? AND converted_to_invoice_id IS NULL", billing.OrderID, billing.TenantID).
+				Updates(map[string]interface{}{
+					"converted_to_invoice_id": billing.InvoiceID,
+					"updated_at":              time.Now(),
+				})
+			if result.Error != nil {
+				return fmt.Errorf("set converted to invoice: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("order %s has already been converted to an invoice", billing.OrderID)
+			}
+		}
+		return nil
+	})
+}
+
+// ListInvoices retrieves the billing records of an order, oldest first
+func (r *GORMRepository) ListInvoices(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderInvoice, error) {
+	db, err := r.tenantTable(ctx, schemaName, "order_invoices")
+	if err != nil {
+		return nil, fmt.Errorf("qualify order invoices table: %w", err)
+	}
+
+	var billingModels []models.OrderInvoice
+	if err := db.
+		Where("tenant_id = ? AND order_id = ?", tenantID, orderID).
+		Order("created_at ASC").
+		Order("invoice_number ASC").
+		Find(&billingModels).Error; err != nil {
+		return nil, fmt.Errorf("list order invoices: %w", err)
+	}
+
+	billings := make([]OrderInvoice, len(billingModels))
+	for i := range billingModels {
+		billings[i] = *orderInvoiceFromModel(&billingModels[i])
+	}
+	return billings, nil
+}
+
+// GetInvoiceByInvoiceID retrieves the billing record of an invoice raised from an order
+func (r *GORMRepository) GetInvoiceByInvoiceID(ctx context.Context, schemaName, tenantID, invoiceID string) (*OrderInvoice, error) {
+	db, err := r.tenantTable(ctx, schemaName, "order_invoices")
+	if err != nil {
+		return nil, fmt.Errorf("qualify order invoices table: %w", err)
+	}
+
+	var billingModel models.OrderInvoice
+	err = db.
+		Where("tenant_id = ? AND invoice_id = ?", tenantID, invoiceID).
+		First(&billingModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrOrderInvoiceNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get order invoice: %w", err)
+	}
+	return orderInvoiceFromModel(&billingModel), nil
+}
+
 func (r *GORMRepository) listOrderLines(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderLine, error) {
 	db, err := r.tenantTable(ctx, schemaName, "order_lines")
 	if err != nil {
@@ -483,39 +753,79 @@ func orderFromModel(order *models.Order) *Order {
 
 func orderLineToModel(line *OrderLine) *models.OrderLine {
 	return &models.OrderLine{
-		ID:              line.ID,
-		TenantID:        line.TenantID,
-		OrderID:         line.OrderID,
-		LineNumber:      line.LineNumber,
-		Description:     line.Description,
-		Quantity:        models.Decimal{Decimal: line.Quantity},
-		Unit:            line.Unit,
-		UnitPrice:       models.Decimal{Decimal: line.UnitPrice},
-		DiscountPercent: models.Decimal{Decimal: line.DiscountPercent},
-		VATRate:         models.Decimal{Decimal: line.VATRate},
-		LineSubtotal:    models.Decimal{Decimal: line.LineSubtotal},
-		LineVAT:         models.Decimal{Decimal: line.LineVAT},
-		LineTotal:       models.Decimal{Decimal: line.LineTotal},
-		ProductID:       line.ProductID,
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		OrderID:          line.OrderID,
+		LineNumber:       line.LineNumber,
+		Description:      line.Description,
+		Quantity:         models.Decimal{Decimal: line.Quantity},
+		Unit:             line.Unit,
+		UnitPrice:        models.Decimal{Decimal: line.UnitPrice},
+		DiscountPercent:  models.Decimal{Decimal: line.DiscountPercent},
+		VATRate:          models.Decimal{Decimal: line.VATRate},
+		LineSubtotal:     models.Decimal{Decimal: line.LineSubtotal},
+		LineVAT:          models.Decimal{Decimal: line.LineVAT},
+		LineTotal:        models.Decimal{Decimal: line.LineTotal},
+		ProductID:        line.ProductID,
+		ShippedQuantity:  models.Decimal{Decimal: line.ShippedQuantity},
+		InvoicedQuantity: models.Decimal{Decimal: line.InvoicedQuantity},
 	}
 }
 
 func orderLineFromModel(line *models.OrderLine) *OrderLine {
 	return &OrderLine{
-		ID:              line.ID,
-		TenantID:        line.TenantID,
-		OrderID:         line.OrderID,
-		LineNumber:      line.LineNumber,
-		Description:     line.Description,
-		Quantity:        line.Quantity.Decimal,
-		Unit:            line.Unit,
-		UnitPrice:       line.UnitPrice.Decimal,
-		DiscountPercent: line.DiscountPercent.Decimal,
-		VATRate:         line.VATRate.Decimal,
-		LineSubtotal:    line.LineSubtotal.Decimal,
-		LineVAT:         line.LineVAT.Decimal,
-		LineTotal:       line.LineTotal.Decimal,
-		ProductID:       line.ProductID,
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		OrderID:          line.OrderID,
+		LineNumber:       line.LineNumber,
+		Description:      line.Description,
+		Quantity:         line.Quantity.Decimal,
+		Unit:             line.Unit,
+		UnitPrice:        line.UnitPrice.Decimal,
+		DiscountPercent:  line.DiscountPercent.Decimal,
+		VATRate:          line.VATRate.Decimal,
+		LineSubtotal:     line.LineSubtotal.Decimal,
+		LineVAT:          line.LineVAT.Decimal,
+		LineTotal:        line.LineTotal.Decimal,
+		ProductID:        line.ProductID,
+		ShippedQuantity:  line.ShippedQuantity.Decimal,
+		InvoicedQuantity: line.InvoicedQuantity.Decimal,
+	}
+}
+
+func orderInvoiceToModel(billing *OrderInvoice) *models.OrderInvoice {
+	return &models.OrderInvoice{
+		ID:                 billing.ID,
+		TenantID:           billing.TenantID,
+		OrderID:            billing.OrderID,
+		InvoiceID:          billing.InvoiceID,
+		InvoiceNumber:      billing.InvoiceNumber,
+		Kind:               string(billing.Kind),
+		Percent:            models.Decimal{Decimal: billing.Percent},
+		Subtotal:           models.Decimal{Decimal: billing.Subtotal},
+		VATRate:            models.Decimal{Decimal: billing.VATRate},
+		PrepaymentDeducted: models.Decimal{Decimal: billing.PrepaymentDeducted},
+		NettedAmount:       models.Decimal{Decimal: billing.NettedAmount},
+		CreatedBy:          nilIfEmpty(billing.CreatedBy),
+		CreatedAt:          billing.CreatedAt,
+	}
+}
+
+func orderInvoiceFromModel(billing *models.OrderInvoice) *OrderInvoice {
+	return &OrderInvoice{
+		ID:                 billing.ID,
+		TenantID:           billing.TenantID,
+		OrderID:            billing.OrderID,
+		InvoiceID:          billing.InvoiceID,
+		InvoiceNumber:      billing.InvoiceNumber,
+		Kind:               invoicing.BillingKind(billing.Kind),
+		Percent:            billing.Percent.Decimal,
+		Subtotal:           billing.Subtotal.Decimal,
+		VATRate:            billing.VATRate.Decimal,
+		PrepaymentDeducted: billing.PrepaymentDeducted.Decimal,
+		NettedAmount:       billing.NettedAmount.Decimal,
+		CreatedBy:          valueOrEmpty(billing.CreatedBy),
+		CreatedAt:          billing.CreatedAt,
 	}
 }
 
@@ -563,6 +873,82 @@ func stockReservationsFromModels(reservationModels []models.OrderStockReservatio
 	return reservations
 }
 
+func shipmentToModel(shipment *OrderShipment) *models.OrderShipment {
+	return &models.OrderShipment{
+		ID:             shipment.ID,
+		TenantID:       shipment.TenantID,
+		ShipmentNumber: shipment.ShipmentNumber,
+		OrderID:        shipment.OrderID,
+		WarehouseID:    nilIfEmpty(shipment.WarehouseID),
+		ShipmentDate:   shipment.ShipmentDate,
+		CostingMethod:  nilIfEmpty(shipment.CostingMethod),
+		TotalCost:      models.Decimal{Decimal: shipment.TotalCost},
+		Notes:          shipment.Notes,
+		CreatedBy:      shipment.CreatedBy,
+		CreatedAt:      shipment.CreatedAt,
+	}
+}
+
+func shipmentFromModel(shipment *models.OrderShipment) *OrderShipment {
+	return &OrderShipment{
+		ID:             shipment.ID,
+		TenantID:       shipment.TenantID,
+		ShipmentNumber: shipment.ShipmentNumber,
+		OrderID:        shipment.OrderID,
+		WarehouseID:    valueOrEmpty(shipment.WarehouseID),
+		ShipmentDate:   shipment.ShipmentDate,
+		CostingMethod:  valueOrEmpty(shipment.CostingMethod),
+		TotalCost:      shipment.TotalCost.Decimal,
+		Notes:          shipment.Notes,
+		CreatedBy:      shipment.CreatedBy,
+		CreatedAt:      shipment.CreatedAt,
+	}
+}
+
+func shipmentLineToModel(line *OrderShipmentLine) *models.OrderShipmentLine {
+	return &models.OrderShipmentLine{
+		ID:             line.ID,
+		TenantID:       line.TenantID,
+		ShipmentID:     line.ShipmentID,
+		OrderLineID:    line.OrderLineID,
+		LineNumber:     line.LineNumber,
+		ProductID:      line.ProductID,
+		Description:    line.Description,
+		Quantity:       models.Decimal{Decimal: line.Quantity},
+		Unit:           line.Unit,
+		UnitCost:       models.Decimal{Decimal: line.UnitCost},
+		TotalCost:      models.Decimal{Decimal: line.TotalCost},
+		LotNumber:      nilIfEmpty(line.LotNumber),
+		SerialNumber:   nilIfEmpty(line.SerialNumber),
+		ExpiryDate:     nilIfEmpty(line.ExpiryDate),
+		JournalEntryID: line.JournalEntryID,
+	}
+}
+
+func shipmentLineFromModel(line *models.OrderShipmentLine) *OrderShipmentLine {
+	expiryDate := valueOrEmpty(line.ExpiryDate)
+	if len(expiryDate) > len("2006-01-02") {
+		expiryDate = expiryDate[:len("2006-01-02")]
+	}
+	return &OrderShipmentLine{
+		ID:             line.ID,
+		TenantID:       line.TenantID,
+		ShipmentID:     line.ShipmentID,
+		OrderLineID:    line.OrderLineID,
+		LineNumber:     line.LineNumber,
+		ProductID:      line.ProductID,
+		Description:    line.Description,
+		Quantity:       line.Quantity.Decimal,
+		Unit:           line.Unit,
+		UnitCost:       line.UnitCost.Decimal,
+		TotalCost:      line.TotalCost.Decimal,
+		LotNumber:      valueOrEmpty(line.LotNumber),
+		SerialNumber:   valueOrEmpty(line.SerialNumber),
+		ExpiryDate:     expiryDate,
+		JournalEntryID: line.JournalEntryID,
+	}
+}
+
 func nilIfEmpty(value string) *string {
 	if value == "" {
 		return nil
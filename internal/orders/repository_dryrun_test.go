@@ -321,6 +321,15 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, reservation.ID, releasedReservation.ID)
 
+	called := false
+	require.NoError(t, repo.WithInventoryLedgerTransaction(ctx, func(txRepo Repository, stock stockIssuer, kits kitIssuer) error {
+		called = true
+		assert.NotNil(t, stock)
+		assert.NotNil(t, kits)
+		return txRepo.UpdateStatus(ctx, schemaName, tenantID, order.ID, OrderStatusProcessing)
+	}))
+	assert.True(t, called)
+
 	capture.assertContains(t, `"tenant_orders"."orders"`)
 	capture.assertContains(t, `"tenant_orders"."order_lines"`)
 	capture.assertContains(t, `"tenant_orders"."order_stock_reservations"`)
@@ -426,6 +435,42 @@ func TestGORMRepositoryDryRunInvalidSchema(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "CreateShipment",
+			run: func(t *testing.T) error {
+				return repo.CreateShipment(ctx, invalidSchema, &OrderShipment{TenantID: tenantID, OrderID: order.ID}, OrderStatusShipped)
+			},
+		},
+		{
+			name: "ListShipments",
+			run: func(t *testing.T) error {
+				got, err := repo.ListShipments(ctx, invalidSchema, tenantID, order.ID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "RecordInvoice",
+			run: func(t *testing.T) error {
+				return repo.RecordInvoice(ctx, invalidSchema, &OrderInvoice{TenantID: tenantID, OrderID: order.ID}, nil, nil, false)
+			},
+		},
+		{
+			name: "ListInvoices",
+			run: func(t *testing.T) error {
+				got, err := repo.ListInvoices(ctx, invalidSchema, tenantID, order.ID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "GetInvoiceByInvoiceID",
+			run: func(t *testing.T) error {
+				got, err := repo.GetInvoiceByInvoiceID(ctx, invalidSchema, tenantID, order.ID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
 		{
 			name: "listOrderLines",
 			run: func(t *testing.T) error {
@@ -505,6 +550,19 @@ func TestGORMRepositoryDryRunErrors(t *testing.T) {
 		assert.ErrorIs(t, err, expectedErr)
 		assert.Contains(t, err.Error(), "insert order line")
 	})
+
+	t.Run("RecordInvoice marks the order converted with the completing invoice", func(t *testing.T) {
+		billing := &OrderInvoice{ID: "billing-1", TenantID: tenantID, OrderID: order.ID, InvoiceID: "invoice-1"}
+		capture := &orderDryRunSQLCapture{}
+		repo := NewGORMRepository(newOrderDryRunDB(t, withOrderDryRunUpdateRows(1), withOrderDryRunSQLCapture(capture)))
+
+		require.NoError(t, repo.RecordInvoice(ctx, schemaName, billing, nil, nil, true))
+		assert.Contains(t, strings.Join(capture.statements, "\n"), "converted_to_invoice_id IS NULL")
+
+		repo = NewGORMRepository(newOrderDryRunDB(t, withOrderDryRunUpdateRows(0)))
+		err := repo.RecordInvoice(ctx, schemaName, billing, nil, nil, true)
+		assert.ErrorContains(t, err, "has already been converted to an invoice")
+	})
 }
 
 type orderWave11RowSet struct {
@@ -129,6 +129,62 @@ func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "GenerateShipmentNumber",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.GenerateShipmentNumber(ctx, schemaName, tenantID)
+				assert.Empty(t, got)
+				return err
+			},
+		},
+		{
+			name: "CreateShipment",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.CreateShipment(ctx, schemaName, &OrderShipment{TenantID: tenantID, OrderID: orderID}, OrderStatusShipped)
+			},
+		},
+		{
+			name: "ListShipments",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.ListShipments(ctx, schemaName, tenantID, orderID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "RecordInvoice",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.RecordInvoice(ctx, schemaName, &OrderInvoice{TenantID: tenantID, OrderID: orderID}, nil, nil, false)
+			},
+		},
+		{
+			name: "ListInvoices",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.ListInvoices(ctx, schemaName, tenantID, orderID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "GetInvoiceByInvoiceID",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.GetInvoiceByInvoiceID(ctx, schemaName, tenantID, "invoice-1")
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "WithInventoryLedgerTransaction",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				called := false
+				err := repo.WithInventoryLedgerTransaction(ctx, func(Repository, stockIssuer, kitIssuer) error {
+					called = true
+					return nil
+				})
+				assert.False(t, called)
+				return err
+			},
+		},
 		{
 			name: "tenantTable",
 			run: func(t *testing.T, repo *GORMRepository) error {
@@ -209,6 +265,7 @@ func TestOrderLineModelMappingRoundTrip(t *testing.T) {
 		LineVAT:         decimal.RequireFromString("14.55"),
 		LineTotal:       decimal.RequireFromString("80.67"),
 		ProductID:       &productID,
+		ShippedQuantity: decimal.RequireFromString("1.500"),
 	}
 
 	model := orderLineToModel(line)
@@ -223,6 +280,48 @@ func TestOrderLineModelMappingRoundTrip(t *testing.T) {
 	assert.Equal(t, line, orderLineFromModel(model))
 }
 
+func TestShipmentModelMappingRoundTrip(t *testing.T) {
+	shipmentDate := time.Date(2026, time.March, 3, 0, 0, 0, 0, time.UTC)
+	shipment := &OrderShipment{
+		ID:             "shipment-1",
+		TenantID:       "tenant-1",
+		ShipmentNumber: "DN-00001",
+		OrderID:        "order-1",
+		WarehouseID:    "warehouse-1",
+		ShipmentDate:   shipmentDate,
+		CostingMethod:  "FIFO",
+		TotalCost:      decimal.RequireFromString("45"),
+		Notes:          "Leave at reception",
+		CreatedBy:      "user-1",
+	}
+	assert.Equal(t, shipment, shipmentFromModel(shipmentToModel(shipment)))
+
+	unstocked := shipmentToModel(&OrderShipment{ID: "shipment-2"})
+	assert.Nil(t, unstocked.WarehouseID)
+	assert.Nil(t, unstocked.CostingMethod)
+
+	line := &OrderShipmentLine{
+		ID:          "shipment-line-1",
+		ShipmentID:  "shipment-1",
+		OrderLineID: "line-1",
+		LineNumber:  1,
+		Description: "Widget",
+		Quantity:    decimal.NewFromInt(10),
+		UnitCost:    decimal.RequireFromString("4.5"),
+		TotalCost:   decimal.RequireFromString("45"),
+		LotNumber:   "LOT-1",
+	}
+	model := shipmentLineToModel(line)
+	require.NotNil(t, model.LotNumber)
+	assert.Nil(t, model.SerialNumber)
+	assert.Nil(t, model.ExpiryDate)
+	assert.Equal(t, line, shipmentLineFromModel(model))
+
+	expiry := "2027-06-30T00:00:00Z"
+	model.ExpiryDate = &expiry
+	assert.Equal(t, "2027-06-30", shipmentLineFromModel(model).ExpiryDate)
+}
+
 func TestStockReservationModelMappingHelpers(t *testing.T) {
 	createdAt := time.Date(2026, time.February, 4, 9, 15, 0, 0, time.UTC)
 	updatedAt := time.Date(2026, time.February, 5, 10, 20, 0, 0, time.UTC)
@@ -3,16 +3,37 @@ package orders
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/assembly"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/pricing"
 )
 
+type stockIssuer interface {
+	GetProductByID(ctx context.Context, tenantID, schemaName, productID string) (*inventory.Product, error)
+	ReserveStock(ctx context.Context, tenantID, schemaName string, req *inventory.StockReservationRequest) (*inventory.StockLevel, error)
+	ReleaseStock(ctx context.Context, tenantID, schemaName string, req *inventory.StockReservationRequest) (*inventory.StockLevel, error)
+	IssueStock(ctx context.Context, tenantID, schemaName string, req *inventory.IssueStockRequest) (*inventory.IssueStockResult, error)
+}
+
+type kitIssuer interface {
+	IsKit(ctx context.Context, tenantID, schemaName, productID string) (bool, error)
+	IssueKit(ctx context.Context, tenantID, schemaName string, req *assembly.IssueKitRequest) (*assembly.IssueKitResult, error)
+}
+
 // Service provides order operations
 type Service struct {
-	repo Repository
+	repo    Repository
+	stock   stockIssuer
+	kits    kitIssuer
+	pricing pricing.Resolver
 }
 
 // NewService creates a new orders service with an ORM-backed repository.
@@ -29,6 +50,26 @@ func NewServiceWithRepository(repo Repository) *Service {
 	}
 }
 
+// WithInventory enables stock issues and cost of goods sold postings when
+// orders with stock-tracked product lines are shipped.
+func (s *Service) WithInventory(stock stockIssuer) *Service {
+	s.stock = stock
+	return s
+}
+
+// WithKits enables shipping kit products by issuing their components.
+func (s *Service) WithKits(kits kitIssuer) *Service {
+	s.kits = kits
+	return s
+}
+
+// WithPricing resolves unit prices and discounts from price lists for
+// product lines sent without a unit price.
+func (s *Service) WithPricing(prices pricing.Resolver) *Service {
+	s.pricing = prices
+	return s
+}
+
 // Create creates a new order
 func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *CreateOrderRequest) (*Order, error) {
 	order := &Order{
@@ -59,6 +100,9 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 
 	// Convert request lines to order lines
 	for i, reqLine := range req.Lines {
+		if err := s.applyProductPrice(ctx, tenantID, schemaName, pricing.Document{ContactID: order.ContactID, Currency: order.Currency, Date: order.OrderDate}, i+1, &reqLine); err != nil {
+			return nil, err
+		}
 		line := OrderLine{
 			ID:              uuid.New().String(),
 			TenantID:        tenantID,
@@ -66,7 +110,7 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 			Description:     reqLine.Description,
 			Quantity:        reqLine.Quantity,
 			Unit:            reqLine.Unit,
-			UnitPrice:       reqLine.UnitPrice,
+			UnitPrice:       reqLine.unitPrice(),
 			DiscountPercent: reqLine.DiscountPercent,
 			VATRate:         reqLine.VATRate,
 			ProductID:       reqLine.ProductID,
@@ -127,6 +171,9 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, orderID stri
 	if existing.Status != OrderStatusPending && existing.Status != OrderStatusConfirmed {
 		return nil, fmt.Errorf("only pending or confirmed orders can be updated")
 	}
+	if existing.IsInvoiced() {
+		return nil, fmt.Errorf("invoiced orders cannot be updated")
+	}
 
 	// Update fields
 	existing.ContactID = req.ContactID
@@ -147,6 +194,9 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, orderID stri
 	// Replace lines
 	existing.Lines = nil
 	for i, reqLine := range req.Lines {
+		if err := s.applyProductPrice(ctx, tenantID, schemaName, pricing.Document{ContactID: existing.ContactID, Currency: existing.Currency, Date: existing.OrderDate}, i+1, &reqLine); err != nil {
+			return nil, err
+		}
 		line := OrderLine{
 			ID:              uuid.New().String(),
 			TenantID:        tenantID,
@@ -155,7 +205,7 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, orderID stri
 			Description:     reqLine.Description,
 			Quantity:        reqLine.Quantity,
 			Unit:            reqLine.Unit,
-			UnitPrice:       reqLine.UnitPrice,
+			UnitPrice:       reqLine.unitPrice(),
 			DiscountPercent: reqLine.DiscountPercent,
 			VATRate:         reqLine.VATRate,
 			ProductID:       reqLine.ProductID,
@@ -212,20 +262,355 @@ func (s *Service) Process(ctx context.Context, tenantID, schemaName, orderID str
 	return nil
 }
 
-// Ship marks an order as shipped
-func (s *Service) Ship(ctx context.Context, tenantID, schemaName, orderID string) error {
+// Ship books a full or partial shipment of a confirmed or processing order.
+// Stock-tracked product lines are issued from the shipping warehouse with the
+// requested costing method, consuming the order's stock reservations first,
+// and the issued cost is posted to cost of goods sold. Kit product lines issue
+// their components instead. The order becomes
+// SHIPPED once every line is shipped in full and PROCESSING until then.
+func (s *Service) Ship(ctx context.Context, tenantID, schemaName, orderID string, req *ShipOrderRequest) (*OrderShipment, error) {
+	if req == nil {
+		req = &ShipOrderRequest{}
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to ship orders")
+	}
+
 	order, err := s.repo.GetByID(ctx, schemaName, tenantID, orderID)
 	if err != nil {
-		return fmt.Errorf("get order: %w", err)
+		return nil, fmt.Errorf("get order: %w", err)
 	}
 	if order.Status != OrderStatusProcessing && order.Status != OrderStatusConfirmed {
-		return fmt.Errorf("order cannot be shipped in current status")
+		return nil, fmt.Errorf("order cannot be shipped in current status")
 	}
 
-	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, orderID, OrderStatusShipped); err != nil {
-		return fmt.Errorf("ship order: %w", err)
+	lineRequests, err := shipmentLineRequests(order, req.Lines)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	linesByID := make(map[string]*OrderLine, len(order.Lines))
+	for i := range order.Lines {
+		linesByID[order.Lines[i].ID] = &order.Lines[i]
+	}
+
+	// Resolve products before any stock moves so a bad line cannot leave a
+	// shipment half issued.
+	trackedProducts := make(map[string]*inventory.Product)
+	kitProducts := make(map[string]*inventory.Product)
+	for _, lineReq := range lineRequests {
+		orderLine := linesByID[lineReq.OrderLineID]
+		if orderLine.ProductID == nil || strings.TrimSpace(*orderLine.ProductID) == "" {
+			continue
+		}
+		if s.stock == nil {
+			return nil, fmt.Errorf("inventory service is unavailable for order shipments")
+		}
+		productID := strings.TrimSpace(*orderLine.ProductID)
+		product, err := s.stock.GetProductByID(ctx, tenantID, schemaName, productID)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: get product: %w", orderLine.LineNumber, err)
+		}
+		if product.ProductType == inventory.ProductTypeGoods && product.TrackInventory {
+			trackedProducts[productID] = product
+			continue
+		}
+		if product.ProductType == inventory.ProductTypeGoods && s.kits != nil {
+			isKit, err := s.kits.IsKit(ctx, tenantID, schemaName, productID)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", orderLine.LineNumber, err)
+			}
+			if isKit {
+				kitProducts[productID] = product
+			}
+		}
+	}
+
+	reservations, err := s.repo.ListStockReservations(ctx, schemaName, tenantID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list order stock reservations: %w", err)
+	}
+	warehouseID := shipmentWarehouseID(strings.TrimSpace(req.WarehouseID), reservations)
+	if len(trackedProducts)+len(kitProducts) > 0 && warehouseID == "" {
+		return nil, fmt.Errorf("warehouse_id is required to ship stock-tracked lines")
+	}
+	reservedByProduct := make(map[string]decimal.Decimal)
+	for _, reservation := range reservations {
+		if reservation.WarehouseID == warehouseID && reservation.Status == OrderStockReservationStatusReserved {
+			reservedByProduct[reservation.ProductID] = reservedByProduct[reservation.ProductID].Add(reservation.Quantity)
+		}
+	}
+
+	// Number the shipment, issue its stock and store it in one transaction so a
+	// failed line or shipment write leaves no stock movement or journal behind.
+	var shipment *OrderShipment
+	err = s.withInventoryLedgerTransaction(ctx, func(tx *Service) error {
+		shipmentNumber, err := tx.repo.GenerateShipmentNumber(ctx, schemaName, tenantID)
+		if err != nil {
+			return fmt.Errorf("generate shipment number: %w", err)
+		}
+		now := time.Now()
+		shipment = &OrderShipment{
+			ID:             uuid.New().String(),
+			TenantID:       tenantID,
+			ShipmentNumber: shipmentNumber,
+			OrderID:        order.ID,
+			WarehouseID:    warehouseID,
+			ShipmentDate:   now,
+			Notes:          req.Notes,
+			CreatedBy:      userID,
+			CreatedAt:      now,
+		}
+		reason := fmt.Sprintf("Order %s shipment %s", order.OrderNumber, shipmentNumber)
+
+		for _, lineReq := range lineRequests {
+			orderLine := linesByID[lineReq.OrderLineID]
+			line := OrderShipmentLine{
+				ID:           uuid.New().String(),
+				TenantID:     tenantID,
+				ShipmentID:   shipment.ID,
+				OrderLineID:  orderLine.ID,
+				LineNumber:   orderLine.LineNumber,
+				ProductID:    orderLine.ProductID,
+				Description:  orderLine.Description,
+				Quantity:     lineReq.Quantity,
+				Unit:         orderLine.Unit,
+				LotNumber:    strings.TrimSpace(lineReq.LotNumber),
+				SerialNumber: strings.TrimSpace(lineReq.SerialNumber),
+				ExpiryDate:   strings.TrimSpace(lineReq.ExpiryDate),
+			}
+			if orderLine.ProductID != nil {
+				if product, ok := trackedProducts[strings.TrimSpace(*orderLine.ProductID)]; ok {
+					result, err := tx.issueShipmentLine(ctx, tenantID, schemaName, order.ID, warehouseID, product, &line, reservedByProduct, req, reason, shipmentNumber+" / "+order.OrderNumber)
+					if err != nil {
+						return fmt.Errorf("line %d: %w", orderLine.LineNumber, err)
+					}
+					line.UnitCost = result.UnitCost
+					line.TotalCost = result.TotalCost
+					if result.Accounting != nil && result.Accounting.JournalID != "" {
+						journalID := result.Accounting.JournalID
+						line.JournalEntryID = &journalID
+					}
+					shipment.CostingMethod = result.CostingMethod
+					shipment.TotalCost = shipment.TotalCost.Add(result.TotalCost)
+				} else if product, ok := kitProducts[strings.TrimSpace(*orderLine.ProductID)]; ok {
+					cogsAccountID := strings.TrimSpace(req.CostOfGoodsSoldAccountID)
+					if cogsAccountID == "" {
+						cogsAccountID = product.PurchaseAccountID
+					}
+					result, err := tx.kits.IssueKit(ctx, tenantID, schemaName, &assembly.IssueKitRequest{
+						ProductID:                product.ID,
+						WarehouseID:              warehouseID,
+						Quantity:                 line.Quantity,
+						CostingMethod:            req.CostingMethod,
+						Reference:                shipmentNumber + " / " + order.OrderNumber,
+						SourceType:               OrderShipmentSourceType,
+						SourceID:                 shipment.ID,
+						Reason:                   reason,
+						CostOfGoodsSoldAccountID: cogsAccountID,
+						InventoryAccountID:       req.InventoryAccountID,
+						UserID:                   userID,
+					})
+					if err != nil {
+						return fmt.Errorf("line %d: issue kit: %w", orderLine.LineNumber, err)
+					}
+					line.UnitCost = result.UnitCost
+					line.TotalCost = result.TotalCost
+					shipment.CostingMethod = result.CostingMethod
+					shipment.TotalCost = shipment.TotalCost.Add(result.TotalCost)
+				}
+			}
+			shipment.Lines = append(shipment.Lines, line)
+		}
+
+		status := OrderStatusProcessing
+		if shipmentCompletesOrder(order, shipment) {
+			status = OrderStatusShipped
+		}
+		if err := tx.repo.CreateShipment(ctx, schemaName, shipment, status); err != nil {
+			return fmt.Errorf("ship order: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return shipment, nil
+}
+
+// issueShipmentLine issues one shipped line from stock. Reserved order stock
+// is released first so it becomes available to the issue.
+func (s *Service) issueShipmentLine(
+	ctx context.Context,
+	tenantID, schemaName, orderID, warehouseID string,
+	product *inventory.Product,
+	line *OrderShipmentLine,
+	reservedByProduct map[string]decimal.Decimal,
+	req *ShipOrderRequest,
+	reason, reference string,
+) (*inventory.IssueStockResult, error) {
+	userID := strings.TrimSpace(req.UserID)
+	consumed := decimal.Min(line.Quantity, reservedByProduct[product.ID])
+	if consumed.IsPositive() {
+		if _, err := s.stock.ReleaseStock(ctx, tenantID, schemaName, &inventory.StockReservationRequest{
+			ProductID:   product.ID,
+			WarehouseID: warehouseID,
+			Quantity:    consumed.String(),
+			Reason:      reason,
+			UserID:      userID,
+		}); err != nil {
+			return nil, fmt.Errorf("release reserved stock: %w", err)
+		}
+		if _, err := s.repo.ReleaseStockReservation(ctx, schemaName, tenantID, orderID, product.ID, warehouseID, consumed, reason, userID); err != nil {
+			return nil, fmt.Errorf("release order stock reservation: %w", err)
+		}
+		reservedByProduct[product.ID] = reservedByProduct[product.ID].Sub(consumed)
+	}
+
+	cogsAccountID := strings.TrimSpace(req.CostOfGoodsSoldAccountID)
+	if cogsAccountID == "" {
+		cogsAccountID = product.PurchaseAccountID
+	}
+	result, err := s.stock.IssueStock(ctx, tenantID, schemaName, &inventory.IssueStockRequest{
+		ProductID:                product.ID,
+		WarehouseID:              warehouseID,
+		Quantity:                 line.Quantity.String(),
+		CostingMethod:            req.CostingMethod,
+		LotNumber:                line.LotNumber,
+		SerialNumber:             line.SerialNumber,
+		ExpiryDate:               line.ExpiryDate,
+		Reference:                reference,
+		SourceType:               OrderShipmentSourceType,
+		SourceID:                 line.ShipmentID,
+		Reason:                   reason,
+		CostOfGoodsSoldAccountID: cogsAccountID,
+		InventoryAccountID:       req.InventoryAccountID,
+		PostToLedger:             true,
+		UserID:                   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("issue stock: %w", err)
+	}
+	return result, nil
+}
+
+// withInventoryLedgerTransaction runs fn with a copy of the service bound to
+// one repository, inventory and kit transaction when the repository supports it.
+func (s *Service) withInventoryLedgerTransaction(ctx context.Context, fn func(tx *Service) error) error {
+	transactioner, ok := s.repo.(InventoryLedgerTransactionRepository)
+	if !ok {
+		return fn(s)
+	}
+	return transactioner.WithInventoryLedgerTransaction(ctx, func(txRepo Repository, stock stockIssuer, kits kitIssuer) error {
+		tx := *s
+		tx.repo = txRepo
+		if s.stock != nil {
+			tx.stock = stock
+		}
+		if s.kits != nil {
+			tx.kits = kits
+		}
+		return fn(&tx)
+	})
+}
+
+// shipmentLineRequests validates the requested shipment lines against the
+// open quantities of the order. Without requested lines every open quantity
+// is shipped.
+func shipmentLineRequests(order *Order, requested []ShipOrderLineRequest) ([]ShipOrderLineRequest, error) {
+	if len(requested) == 0 {
+		lines := make([]ShipOrderLineRequest, 0, len(order.Lines))
+		for _, line := range order.Lines {
+			if open := line.OpenShipQuantity(); open.IsPositive() {
+				lines = append(lines, ShipOrderLineRequest{OrderLineID: line.ID, Quantity: open})
+			}
+		}
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("order has no open quantities to ship")
+		}
+		return lines, nil
+	}
+
+	linesByID := make(map[string]*OrderLine, len(order.Lines))
+	for i := range order.Lines {
+		linesByID[order.Lines[i].ID] = &order.Lines[i]
+	}
+	pending := make(map[string]decimal.Decimal, len(requested))
+	lines := make([]ShipOrderLineRequest, 0, len(requested))
+	for i, reqLine := range requested {
+		reqLine.OrderLineID = strings.TrimSpace(reqLine.OrderLineID)
+		orderLine, ok := linesByID[reqLine.OrderLineID]
+		if !ok {
+			return nil, fmt.Errorf("line %d: order line %s not found", i+1, reqLine.OrderLineID)
+		}
+		if reqLine.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("line %d: quantity must be positive", i+1)
+		}
+		open := orderLine.OpenShipQuantity().Sub(pending[orderLine.ID])
+		if reqLine.Quantity.GreaterThan(open) {
+			return nil, fmt.Errorf("line %d: quantity %s exceeds open quantity %s on order line %d", i+1, reqLine.Quantity.String(), open.String(), orderLine.LineNumber)
+		}
+		pending[orderLine.ID] = pending[orderLine.ID].Add(reqLine.Quantity)
+		lines = append(lines, reqLine)
+	}
+	return lines, nil
+}
+
+// shipmentCompletesOrder reports whether the shipment ships every quantity
+// still open on the order.
+func shipmentCompletesOrder(order *Order, shipment *OrderShipment) bool {
+	shipped := make(map[string]decimal.Decimal, len(shipment.Lines))
+	for _, line := range shipment.Lines {
+		shipped[line.OrderLineID] = shipped[line.OrderLineID].Add(line.Quantity)
+	}
+	for i := range order.Lines {
+		if order.Lines[i].OpenShipQuantity().GreaterThan(shipped[order.Lines[i].ID]) {
+			return false
+		}
+	}
+	return true
+}
+
+// shipmentWarehouseID returns the requested warehouse, or the warehouse
+// holding the order's stock reservations when they are all in one warehouse.
+func shipmentWarehouseID(requested string, reservations []OrderStockReservation) string {
+	if requested != "" {
+		return requested
+	}
+	warehouseID := ""
+	for _, reservation := range reservations {
+		if reservation.Status != OrderStockReservationStatusReserved || !reservation.Quantity.IsPositive() {
+			continue
+		}
+		if warehouseID != "" && reservation.WarehouseID != warehouseID {
+			return ""
+		}
+		warehouseID = reservation.WarehouseID
+	}
+	return warehouseID
+}
+
+// ListShipments retrieves the shipments of an order
+func (s *Service) ListShipments(ctx context.Context, tenantID, schemaName, orderID string) ([]OrderShipment, error) {
+	shipments, err := s.repo.ListShipments(ctx, schemaName, tenantID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list order shipments: %w", err)
+	}
+	return shipments, nil
+}
+
+// GetShipment retrieves one shipment of an order
+func (s *Service) GetShipment(ctx context.Context, tenantID, schemaName, orderID, shipmentID string) (*OrderShipment, error) {
+	shipments, err := s.ListShipments(ctx, tenantID, schemaName, orderID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range shipments {
+		if shipments[i].ID == shipmentID {
+			return &shipments[i], nil
+		}
+	}
+	return nil, ErrOrderShipmentNotFound
 }
 
 // Deliver marks an order as delivered
@@ -253,6 +638,9 @@ func (s *Service) Cancel(ctx context.Context, tenantID, schemaName, orderID stri
 	if order.Status == OrderStatusDelivered || order.Status == OrderStatusCanceled {
 		return fmt.Errorf("order cannot be canceled in current status")
 	}
+	if order.IsInvoiced() {
+		return fmt.Errorf("invoiced orders cannot be canceled")
+	}
 
 	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, orderID, OrderStatusCanceled); err != nil {
 		return fmt.Errorf("cancel order: %w", err)
@@ -276,6 +664,116 @@ func (s *Service) ConvertToInvoice(ctx context.Context, tenantID, schemaName, or
 	return nil
 }
 
+// PlanInvoice builds the next invoice of an order and the bookkeeping to
+// record once it exists. Quantity and percentage invoices net off open
+// prepayments; invoicing.ErrNothingToInvoice is returned when nothing is due.
+func (s *Service) PlanInvoice(ctx context.Context, tenantID, schemaName string, order *Order, req *ConvertOrderToInvoiceRequest) (*invoicing.CreateInvoiceRequest, *invoicing.PartialInvoicePlan, error) {
+	billings, err := s.repo.ListInvoices(ctx, schemaName, tenantID, order.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list order invoices: %w", err)
+	}
+	var prepayments []invoicing.Prepayment
+	for _, billing := range billings {
+		if billing.Kind != invoicing.BillingKindPrepayment {
+			continue
+		}
+		prepayments = append(prepayments, invoicing.Prepayment{
+			BillingID:     billing.ID,
+			InvoiceID:     billing.InvoiceID,
+			InvoiceNumber: billing.InvoiceNumber,
+			VATRate:       billing.VATRate,
+			Amount:        billing.Subtotal,
+			Netted:        billing.NettedAmount,
+		})
+	}
+	lines := make([]invoicing.BillableLine, len(order.Lines))
+	for i, line := range order.Lines {
+		lines[i] = invoicing.BillableLine{
+			ID:               line.ID,
+			LineNumber:       line.LineNumber,
+			Description:      line.Description,
+			Unit:             line.Unit,
+			ProductID:        line.ProductID,
+			Quantity:         line.Quantity,
+			InvoicedQuantity: line.InvoicedQuantity,
+			DueQuantity:      line.DueQuantity(order.Status),
+			UnitPrice:        line.UnitPrice,
+			DiscountPercent:  line.DiscountPercent,
+			VATRate:          line.VATRate,
+		}
+	}
+
+	plan, err := invoicing.PlanPartialInvoice("order "+order.OrderNumber, lines, prepayments, &req.PartialInvoiceRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		notes = order.Notes
+	}
+	return &invoicing.CreateInvoiceRequest{
+		InvoiceType:  invoicing.InvoiceTypeSales,
+		ContactID:    order.ContactID,
+		IssueDate:    req.IssueDate,
+		DueDate:      req.DueDate,
+		Currency:     order.Currency,
+		ExchangeRate: order.ExchangeRate,
+		Reference:    order.OrderNumber,
+		Notes:        notes,
+		Lines:        plan.Lines,
+		UserID:       req.UserID,
+	}, plan, nil
+}
+
+// RecordInvoice records an invoice created from a plan against the order and
+// closes the order once it is fully invoiced. The order is updated in place.
+func (s *Service) RecordInvoice(ctx context.Context, tenantID, schemaName string, order *Order, plan *invoicing.PartialInvoicePlan, invoice *invoicing.Invoice, userID string) (*OrderInvoice, error) {
+	billing := &OrderInvoice{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		OrderID:            order.ID,
+		InvoiceID:          invoice.ID,
+		InvoiceNumber:      invoice.InvoiceNumber,
+		Kind:               plan.Kind,
+		Percent:            plan.Percent,
+		Subtotal:           plan.Subtotal,
+		VATRate:            plan.VATRate,
+		PrepaymentDeducted: plan.PrepaymentDeducted,
+		CreatedBy:          userID,
+		CreatedAt:          time.Now(),
+	}
+	if err := s.repo.RecordInvoice(ctx, schemaName, billing, plan.Quantities, plan.Deductions, plan.Completes); err != nil {
+		return nil, fmt.Errorf("record order invoice: %w", err)
+	}
+	for i := range order.Lines {
+		order.Lines[i].InvoicedQuantity = order.Lines[i].InvoicedQuantity.Add(plan.Quantities[order.Lines[i].ID])
+	}
+	if plan.Completes {
+		order.ConvertedToInvoiceID = &invoice.ID
+	}
+	return billing, nil
+}
+
+// ListInvoices retrieves the invoices billed from an order
+func (s *Service) ListInvoices(ctx context.Context, tenantID, schemaName, orderID string) ([]OrderInvoice, error) {
+	billings, err := s.repo.ListInvoices(ctx, schemaName, tenantID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("list order invoices: %w", err)
+	}
+	return billings, nil
+}
+
+// GetInvoiceByInvoiceID retrieves the billing record of an invoice raised
+// from an order. ErrOrderInvoiceNotFound is returned for other invoices.
+func (s *Service) GetInvoiceByInvoiceID(ctx context.Context, tenantID, schemaName, invoiceID string) (*OrderInvoice, error) {
+	billing, err := s.repo.GetInvoiceByInvoiceID(ctx, schemaName, tenantID, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("get order invoice: %w", err)
+	}
+	return billing, nil
+}
+
 // ListStockReservations retrieves current stock reservations for an order.
 func (s *Service) ListStockReservations(ctx context.Context, tenantID, schemaName, orderID string) ([]OrderStockReservation, error) {
 	reservations, err := s.repo.ListStockReservations(ctx, schemaName, tenantID, orderID)
@@ -325,3 +823,24 @@ func (s *Service) ReleaseStockReservation(ctx context.Context, tenantID, schemaN
 	}
 	return reservation, nil
 }
+
+// applyProductPrice fills a product line sent without a unit price from the
+// customer's price lists.
+func (s *Service) applyProductPrice(ctx context.Context, tenantID, schemaName string, doc pricing.Document, lineNumber int, line *CreateOrderLineRequest) error {
+	priced := pricing.DocumentLine{
+		ProductID:       line.ProductID,
+		Quantity:        line.Quantity,
+		UnitPrice:       line.UnitPrice,
+		DiscountPercent: line.DiscountPercent,
+		Description:     line.Description,
+		Unit:            line.Unit,
+	}
+	if err := pricing.ApplyProductPrice(ctx, s.pricing, tenantID, schemaName, doc, &priced); err != nil {
+		return fmt.Errorf("resolve price for line %d: %w", lineNumber, err)
+	}
+	line.UnitPrice = priced.UnitPrice
+	line.DiscountPercent = priced.DiscountPercent
+	line.Description = priced.Description
+	line.Unit = priced.Unit
+	return nil
+}
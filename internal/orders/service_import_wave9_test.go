@@ -28,7 +28,7 @@ func TestOrdersWave9CreateDefaultsOrderDate(t *testing.T) {
 		Lines: []CreateOrderLineRequest{{
 			Description: "Consulting",
 			Quantity:    decimal.NewFromInt(2),
-			UnitPrice:   decimal.NewFromInt(100),
+			UnitPrice:   new(decimal.NewFromInt(100)),
 			VATRate:     decimal.NewFromInt(22),
 		}},
 	})
@@ -70,7 +70,7 @@ func TestOrdersWave9UpdateErrorBranches(t *testing.T) {
 			Lines: []CreateOrderLineRequest{{
 				Description: "",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromInt(10),
+				UnitPrice:   new(decimal.NewFromInt(10)),
 			}},
 		})
 
@@ -97,7 +97,7 @@ func TestOrdersWave9UpdateErrorBranches(t *testing.T) {
 			Lines: []CreateOrderLineRequest{{
 				Description: "Consulting",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromInt(10),
+				UnitPrice:   new(decimal.NewFromInt(10)),
 			}},
 		})
 
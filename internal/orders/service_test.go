@@ -3,6 +3,7 @@ package orders
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -11,8 +12,10 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/HMB-research/open-accounting/internal/assembly"
 	"github.com/HMB-research/open-accounting/internal/contacts"
 	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
 )
 
 // MockRepository implements Repository for testing
@@ -32,6 +35,10 @@ type MockRepository struct {
 	StockGetErr       error
 	StockUpsertErr    error
 	StockReleaseErr   error
+	Shipments         []OrderShipment
+	ShipmentErr       error
+	Billings          []OrderInvoice
+	BillingErr        error
 }
 
 func NewMockRepository() *MockRepository {
@@ -189,6 +196,81 @@ func (m *MockRepository) ReleaseStockReservation(ctx context.Context, schemaName
 	return reservation, nil
 }
 
+func (m *MockRepository) GenerateShipmentNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	return fmt.Sprintf("DN-%05d", len(m.Shipments)+1), nil
+}
+
+func (m *MockRepository) CreateShipment(ctx context.Context, schemaName string, shipment *OrderShipment, status OrderStatus) error {
+	if m.ShipmentErr != nil {
+		return m.ShipmentErr
+	}
+	order, ok := m.Orders[shipment.OrderID]
+	if !ok {
+		return ErrOrderNotFound
+	}
+	for _, shipped := range shipment.Lines {
+		for i := range order.Lines {
+			if order.Lines[i].ID == shipped.OrderLineID {
+				order.Lines[i].ShippedQuantity = order.Lines[i].ShippedQuantity.Add(shipped.Quantity)
+			}
+		}
+	}
+	order.Status = status
+	m.Shipments = append(m.Shipments, *shipment)
+	return nil
+}
+
+func (m *MockRepository) ListShipments(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderShipment, error) {
+	shipments := []OrderShipment{}
+	for _, shipment := range m.Shipments {
+		if shipment.TenantID == tenantID && shipment.OrderID == orderID {
+			shipments = append(shipments, shipment)
+		}
+	}
+	return shipments, nil
+}
+
+func (m *MockRepository) RecordInvoice(ctx context.Context, schemaName string, billing *OrderInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	if m.BillingErr != nil {
+		return m.BillingErr
+	}
+	if completes {
+		if err := m.SetConvertedToInvoice(ctx, schemaName, billing.TenantID, billing.OrderID, billing.InvoiceID); err != nil {
+			return err
+		}
+	}
+	for i := range m.Billings {
+		m.Billings[i].NettedAmount = m.Billings[i].NettedAmount.Add(deductions[m.Billings[i].ID])
+	}
+	m.Billings = append(m.Billings, *billing)
+	return nil
+}
+
+func (m *MockRepository) ListInvoices(ctx context.Context, schemaName, tenantID, orderID string) ([]OrderInvoice, error) {
+	if m.BillingErr != nil {
+		return nil, m.BillingErr
+	}
+	billings := []OrderInvoice{}
+	for _, billing := range m.Billings {
+		if billing.TenantID == tenantID && billing.OrderID == orderID {
+			billings = append(billings, billing)
+		}
+	}
+	return billings, nil
+}
+
+func (m *MockRepository) GetInvoiceByInvoiceID(ctx context.Context, schemaName, tenantID, invoiceID string) (*OrderInvoice, error) {
+	if m.BillingErr != nil {
+		return nil, m.BillingErr
+	}
+	for i := range m.Billings {
+		if m.Billings[i].TenantID == tenantID && m.Billings[i].InvoiceID == invoiceID {
+			return &m.Billings[i], nil
+		}
+	}
+	return nil, ErrOrderInvoiceNotFound
+}
+
 func orderStockReservationKey(orderID, productID, warehouseID string) string {
 	return orderID + "|" + productID + "|" + warehouseID
 }
@@ -222,7 +304,7 @@ func TestService_Create(t *testing.T) {
 				{
 					Description: "Test product",
 					Quantity:    decimal.NewFromInt(2),
-					UnitPrice:   decimal.NewFromFloat(100.00),
+					UnitPrice:   new(decimal.NewFromFloat(100.00)),
 					VATRate:     decimal.NewFromInt(20),
 				},
 			},
@@ -251,7 +333,7 @@ func TestService_Create(t *testing.T) {
 			Currency:  "", // empty
 			UserID:    "user-1",
 			Lines: []CreateOrderLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -270,7 +352,7 @@ func TestService_Create(t *testing.T) {
 			OrderDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateOrderLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -289,7 +371,7 @@ func TestService_Create(t *testing.T) {
 			OrderDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateOrderLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -309,7 +391,7 @@ func TestService_Create(t *testing.T) {
 			OrderDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateOrderLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -329,7 +411,7 @@ func TestService_Create(t *testing.T) {
 			OrderDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateOrderLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -613,7 +695,7 @@ func TestService_Update(t *testing.T) {
 			ContactID: "contact-2",
 			OrderDate: time.Now(),
 			Lines: []CreateOrderLineRequest{
-				{Description: "Updated", Quantity: decimal.NewFromInt(3), UnitPrice: decimal.NewFromFloat(50)},
+				{Description: "Updated", Quantity: decimal.NewFromInt(3), UnitPrice: new(decimal.NewFromFloat(50))},
 			},
 		}
 
@@ -637,7 +719,7 @@ func TestService_Update(t *testing.T) {
 			ContactID: "contact-2",
 			OrderDate: time.Now(),
 			Lines: []CreateOrderLineRequest{
-				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -659,7 +741,7 @@ func TestService_Update(t *testing.T) {
 			ContactID: "contact-2",
 			OrderDate: time.Now(),
 			Lines: []CreateOrderLineRequest{
-				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -718,38 +800,461 @@ func TestService_Process(t *testing.T) {
 	})
 }
 
+// fakeStockIssuer records stock released, reserved and issued by shipments
+type fakeStockIssuer struct {
+	products map[string]*inventory.Product
+	released []inventory.StockReservationRequest
+	reserved []inventory.StockReservationRequest
+	issued   []inventory.IssueStockRequest
+	issueErr error
+}
+
+func (f *fakeStockIssuer) GetProductByID(ctx context.Context, tenantID, schemaName, productID string) (*inventory.Product, error) {
+	product, ok := f.products[productID]
+	if !ok {
+		return nil, fmt.Errorf("product not found: %s", productID)
+	}
+	return product, nil
+}
+
+func (f *fakeStockIssuer) ReserveStock(ctx context.Context, tenantID, schemaName string, req *inventory.StockReservationRequest) (*inventory.StockLevel, error) {
+	f.reserved = append(f.reserved, *req)
+	return &inventory.StockLevel{}, nil
+}
+
+func (f *fakeStockIssuer) ReleaseStock(ctx context.Context, tenantID, schemaName string, req *inventory.StockReservationRequest) (*inventory.StockLevel, error) {
+	f.released = append(f.released, *req)
+	return &inventory.StockLevel{}, nil
+}
+
+func (f *fakeStockIssuer) IssueStock(ctx context.Context, tenantID, schemaName string, req *inventory.IssueStockRequest) (*inventory.IssueStockResult, error) {
+	if f.issueErr != nil {
+		return nil, f.issueErr
+	}
+	f.issued = append(f.issued, *req)
+	quantity := decimal.RequireFromString(req.Quantity)
+	unitCost := decimal.RequireFromString("4.5")
+	return &inventory.IssueStockResult{
+		ProductID:     req.ProductID,
+		WarehouseID:   req.WarehouseID,
+		Quantity:      quantity,
+		CostingMethod: req.CostingMethod,
+		UnitCost:      unitCost,
+		TotalCost:     quantity.Mul(unitCost),
+		Accounting:    &inventory.InventoryIssueAccounting{Posted: true, JournalID: "journal-" + req.ProductID},
+	}, nil
+}
+
+// fakeKitIssuer records kits issued by shipments
+type fakeKitIssuer struct {
+	kits     map[string]bool
+	issued   []assembly.IssueKitRequest
+	issueErr error
+}
+
+func (f *fakeKitIssuer) IsKit(ctx context.Context, tenantID, schemaName, productID string) (bool, error) {
+	return f.kits[productID], nil
+}
+
+func (f *fakeKitIssuer) IssueKit(ctx context.Context, tenantID, schemaName string, req *assembly.IssueKitRequest) (*assembly.IssueKitResult, error) {
+	if f.issueErr != nil {
+		return nil, f.issueErr
+	}
+	f.issued = append(f.issued, *req)
+	unitCost := decimal.NewFromInt(7)
+	return &assembly.IssueKitResult{
+		ProductID:     req.ProductID,
+		WarehouseID:   req.WarehouseID,
+		Quantity:      req.Quantity,
+		CostingMethod: req.CostingMethod,
+		UnitCost:      unitCost,
+		TotalCost:     req.Quantity.Mul(unitCost),
+	}, nil
+}
+
+// transactionalShippingRepository restores reservations, shipments and the
+// stock and kit issues recorded by the fakes when the transaction callback
+// fails.
+type transactionalShippingRepository struct {
+	*MockRepository
+	stock              *fakeStockIssuer
+	kits               *fakeKitIssuer
+	transactionsOpened int
+}
+
+func newTransactionalShippingRepository() *transactionalShippingRepository {
+	return &transactionalShippingRepository{MockRepository: NewMockRepository()}
+}
+
+func (r *transactionalShippingRepository) WithInventoryLedgerTransaction(_ context.Context, fn func(txRepo Repository, stock stockIssuer, kits kitIssuer) error) error {
+	r.transactionsOpened++
+	reservations := make(map[string]OrderStockReservation, len(r.StockReservations))
+	for key, reservation := range r.StockReservations {
+		reservations[key] = *reservation
+	}
+	shipments := len(r.Shipments)
+	var stock stockIssuer
+	var released, reserved, issued, kitsIssued int
+	if r.stock != nil {
+		stock = r.stock
+		released, reserved, issued = len(r.stock.released), len(r.stock.reserved), len(r.stock.issued)
+	}
+	var kits kitIssuer
+	if r.kits != nil {
+		kits = r.kits
+		kitsIssued = len(r.kits.issued)
+	}
+
+	if err := fn(r, stock, kits); err != nil {
+		r.StockReservations = make(map[string]*OrderStockReservation, len(reservations))
+		for key, reservation := range reservations {
+			reservation := reservation
+			r.StockReservations[key] = &reservation
+		}
+		r.Shipments = r.Shipments[:shipments]
+		if r.stock != nil {
+			r.stock.released = r.stock.released[:released]
+			r.stock.reserved = r.stock.reserved[:reserved]
+			r.stock.issued = r.stock.issued[:issued]
+		}
+		if r.kits != nil {
+			r.kits.issued = r.kits.issued[:kitsIssued]
+		}
+		return err
+	}
+	return nil
+}
+
+func shippableOrder(status OrderStatus) *Order {
+	productID := "product-1"
+	serviceID := "service-1"
+	return &Order{
+		ID:          "order-1",
+		TenantID:    "tenant-1",
+		OrderNumber: "ORD-00001",
+		Status:      status,
+		Lines: []OrderLine{
+			{ID: "line-1", LineNumber: 1, Description: "Widget", Quantity: decimal.NewFromInt(10), Unit: "pcs", ProductID: &productID},
+			{ID: "line-2", LineNumber: 2, Description: "Installation", Quantity: decimal.NewFromInt(1), ProductID: &serviceID},
+			{ID: "line-3", LineNumber: 3, Description: "Freight", Quantity: decimal.NewFromInt(1)},
+		},
+	}
+}
+
+func newShippingService(repo *MockRepository) (*Service, *fakeStockIssuer) {
+	stock := &fakeStockIssuer{products: map[string]*inventory.Product{
+		"product-1": {ID: "product-1", Name: "Widget", ProductType: inventory.ProductTypeGoods, TrackInventory: true, PurchaseAccountID: "cogs-1"},
+		"service-1": {ID: "service-1", Name: "Installation", ProductType: inventory.ProductTypeService},
+	}}
+	return NewServiceWithRepository(repo).WithInventory(stock), stock
+}
+
 func TestService_Ship(t *testing.T) {
-	t.Run("ships processing order", func(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ships all open quantities from reserved stock", func(t *testing.T) {
 		repo := NewMockRepository()
-		repo.Orders["order-1"] = &Order{ID: "order-1", Status: OrderStatusProcessing}
-		svc := NewServiceWithRepository(repo)
+		repo.Orders["order-1"] = shippableOrder(OrderStatusProcessing)
+		repo.StockReservations[orderStockReservationKey("order-1", "product-1", "warehouse-1")] = &OrderStockReservation{
+			TenantID:    "tenant-1",
+			OrderID:     "order-1",
+			ProductID:   "product-1",
+			WarehouseID: "warehouse-1",
+			Quantity:    decimal.NewFromInt(10),
+			Status:      OrderStockReservationStatusReserved,
+		}
+		svc, stock := newShippingService(repo)
 
-		err := svc.Ship(context.Background(), "tenant-1", "test_schema", "order-1")
+		shipment, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{CostingMethod: "FIFO", UserID: "user-1"})
 
 		require.NoError(t, err)
+		assert.Equal(t, "DN-00001", shipment.ShipmentNumber)
+		assert.Equal(t, "warehouse-1", shipment.WarehouseID)
+		assert.Equal(t, "FIFO", shipment.CostingMethod)
+		assert.True(t, shipment.TotalCost.Equal(decimal.NewFromInt(45)))
+		require.Len(t, shipment.Lines, 3)
+		require.NotNil(t, shipment.Lines[0].JournalEntryID)
+		assert.Equal(t, "journal-product-1", *shipment.Lines[0].JournalEntryID)
+		assert.True(t, shipment.Lines[1].TotalCost.IsZero())
+		assert.Nil(t, shipment.Lines[2].JournalEntryID)
+
+		require.Len(t, stock.released, 1)
+		assert.Equal(t, "10", stock.released[0].Quantity)
+		require.Len(t, stock.issued, 1)
+		issued := stock.issued[0]
+		assert.Equal(t, "warehouse-1", issued.WarehouseID)
+		assert.Equal(t, "10", issued.Quantity)
+		assert.Equal(t, "FIFO", issued.CostingMethod)
+		assert.Equal(t, OrderShipmentSourceType, issued.SourceType)
+		assert.Equal(t, shipment.ID, issued.SourceID)
+		assert.Equal(t, "cogs-1", issued.CostOfGoodsSoldAccountID)
+		assert.True(t, issued.PostToLedger)
+
+		reservation := repo.StockReservations[orderStockReservationKey("order-1", "product-1", "warehouse-1")]
+		assert.True(t, reservation.Quantity.IsZero())
+		assert.Equal(t, OrderStockReservationStatusReleased, reservation.Status)
 		assert.Equal(t, OrderStatusShipped, repo.Orders["order-1"].Status)
+		assert.True(t, repo.Orders["order-1"].Lines[0].ShippedQuantity.Equal(decimal.NewFromInt(10)))
 	})
 
-	t.Run("ships confirmed order", func(t *testing.T) {
+	t.Run("partial shipment keeps order processing", func(t *testing.T) {
 		repo := NewMockRepository()
-		repo.Orders["order-1"] = &Order{ID: "order-1", Status: OrderStatusConfirmed}
+		repo.Orders["order-1"] = shippableOrder(OrderStatusConfirmed)
+		repo.StockReservations[orderStockReservationKey("order-1", "product-1", "warehouse-1")] = &OrderStockReservation{
+			TenantID:    "tenant-1",
+			OrderID:     "order-1",
+			ProductID:   "product-1",
+			WarehouseID: "warehouse-1",
+			Quantity:    decimal.NewFromInt(3),
+			Status:      OrderStockReservationStatusReserved,
+		}
+		svc, stock := newShippingService(repo)
+
+		shipment, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{
+			WarehouseID:              "warehouse-1",
+			CostOfGoodsSoldAccountID: "cogs-2",
+			Lines:                    []ShipOrderLineRequest{{OrderLineID: "line-1", Quantity: decimal.NewFromInt(4), LotNumber: "LOT-1"}},
+			UserID:                   "user-1",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, shipment.Lines, 1)
+		assert.Equal(t, "LOT-1", shipment.Lines[0].LotNumber)
+		require.Len(t, stock.released, 1)
+		assert.Equal(t, "3", stock.released[0].Quantity)
+		require.Len(t, stock.issued, 1)
+		assert.Equal(t, "4", stock.issued[0].Quantity)
+		assert.Equal(t, "LOT-1", stock.issued[0].LotNumber)
+		assert.Equal(t, "cogs-2", stock.issued[0].CostOfGoodsSoldAccountID)
+		assert.Equal(t, OrderStatusProcessing, repo.Orders["order-1"].Status)
+		assert.True(t, repo.Orders["order-1"].Lines[0].ShippedQuantity.Equal(decimal.NewFromInt(4)))
+
+		_, err = svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{
+			WarehouseID: "warehouse-1",
+			Lines:       []ShipOrderLineRequest{{OrderLineID: "line-1", Quantity: decimal.NewFromInt(7)}},
+			UserID:      "user-1",
+		})
+		require.ErrorContains(t, err, "exceeds open quantity 6")
+	})
+
+	t.Run("rolls back reservation release when issue fails", func(t *testing.T) {
+		repo := newTransactionalShippingRepository()
+		repo.Orders["order-1"] = shippableOrder(OrderStatusProcessing)
+		repo.StockReservations[orderStockReservationKey("order-1", "product-1", "warehouse-1")] = &OrderStockReservation{
+			TenantID:    "tenant-1",
+			OrderID:     "order-1",
+			ProductID:   "product-1",
+			WarehouseID: "warehouse-1",
+			Quantity:    decimal.NewFromInt(10),
+			Status:      OrderStockReservationStatusReserved,
+		}
+		svc, stock := newShippingService(repo.MockRepository)
+		svc.repo = repo
+		repo.stock = stock
+		stock.issueErr = errors.New("insufficient available stock to issue")
+
+		_, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{UserID: "user-1"})
+
+		require.ErrorContains(t, err, "line 1: issue stock: insufficient available stock to issue")
+		assert.Equal(t, 1, repo.transactionsOpened)
+		assert.Empty(t, stock.released)
+		reservation := repo.StockReservations[orderStockReservationKey("order-1", "product-1", "warehouse-1")]
+		assert.True(t, reservation.Quantity.Equal(decimal.NewFromInt(10)))
+		assert.Equal(t, OrderStockReservationStatusReserved, reservation.Status)
+		assert.Empty(t, repo.Shipments)
+		assert.Equal(t, OrderStatusProcessing, repo.Orders["order-1"].Status)
+	})
+
+	t.Run("rolls back issued stock when shipment cannot be stored", func(t *testing.T) {
+		kitID := "kit-1"
+		productID := "product-1"
+		repo := newTransactionalShippingRepository()
+		repo.Orders["order-1"] = &Order{
+			ID:          "order-1",
+			TenantID:    "tenant-1",
+			OrderNumber: "ORD-00001",
+			Status:      OrderStatusConfirmed,
+			Lines: []OrderLine{
+				{ID: "line-1", LineNumber: 1, Description: "Widget", Quantity: decimal.NewFromInt(2), ProductID: &productID},
+				{ID: "line-2", LineNumber: 2, Description: "Gift box", Quantity: decimal.NewFromInt(1), ProductID: &kitID},
+			},
+		}
+		repo.StockReservations[orderStockReservationKey("order-1", productID, "warehouse-1")] = &OrderStockReservation{
+			TenantID:    "tenant-1",
+			OrderID:     "order-1",
+			ProductID:   productID,
+			WarehouseID: "warehouse-1",
+			Quantity:    decimal.NewFromInt(2),
+			Status:      OrderStockReservationStatusReserved,
+		}
+		repo.ShipmentErr = errors.New("insert failed")
+		svc, stock := newShippingService(repo.MockRepository)
+		stock.products[kitID] = &inventory.Product{ID: kitID, Name: "Gift box", ProductType: inventory.ProductTypeGoods}
+		kits := &fakeKitIssuer{kits: map[string]bool{kitID: true}}
+		svc.WithKits(kits)
+		svc.repo = repo
+		repo.stock = stock
+		repo.kits = kits
+
+		_, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{UserID: "user-1"})
+
+		require.ErrorContains(t, err, "ship order: insert failed")
+		assert.Equal(t, 1, repo.transactionsOpened)
+		assert.Empty(t, stock.released)
+		assert.Empty(t, stock.issued)
+		assert.Empty(t, kits.issued)
+		reservation := repo.StockReservations[orderStockReservationKey("order-1", productID, "warehouse-1")]
+		assert.True(t, reservation.Quantity.Equal(decimal.NewFromInt(2)))
+		assert.Equal(t, OrderStockReservationStatusReserved, reservation.Status)
+
+		repo.ShipmentErr = nil
+		shipment, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{UserID: "user-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "DN-00001", shipment.ShipmentNumber)
+		require.Len(t, stock.issued, 1)
+		require.Len(t, kits.issued, 1)
+		assert.Equal(t, OrderStatusShipped, repo.Orders["order-1"].Status)
+	})
+
+	t.Run("ships lines without products without inventory", func(t *testing.T) {
+		repo := NewMockRepository()
+		repo.Orders["order-1"] = &Order{
+			ID:       "order-1",
+			TenantID: "tenant-1",
+			Status:   OrderStatusConfirmed,
+			Lines:    []OrderLine{{ID: "line-1", LineNumber: 1, Description: "Consulting", Quantity: decimal.NewFromInt(2)}},
+		}
 		svc := NewServiceWithRepository(repo)
 
-		err := svc.Ship(context.Background(), "tenant-1", "test_schema", "order-1")
+		shipment, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{UserID: "user-1"})
 
 		require.NoError(t, err)
+		assert.True(t, shipment.TotalCost.IsZero())
 		assert.Equal(t, OrderStatusShipped, repo.Orders["order-1"].Status)
 	})
 
-	t.Run("returns error when pending", func(t *testing.T) {
+	t.Run("ships kit lines by issuing components", func(t *testing.T) {
+		kitID := "kit-1"
 		repo := NewMockRepository()
-		repo.Orders["order-1"] = &Order{ID: "order-1", Status: OrderStatusPending}
+		repo.Orders["order-1"] = &Order{
+			ID:          "order-1",
+			TenantID:    "tenant-1",
+			OrderNumber: "ORD-00001",
+			Status:      OrderStatusConfirmed,
+			Lines:       []OrderLine{{ID: "line-1", LineNumber: 1, Description: "Gift box", Quantity: decimal.NewFromInt(3), ProductID: &kitID}},
+		}
+		svc, stock := newShippingService(repo)
+		stock.products[kitID] = &inventory.Product{ID: kitID, Name: "Gift box", ProductType: inventory.ProductTypeGoods, PurchaseAccountID: "cogs-kit"}
+		kits := &fakeKitIssuer{kits: map[string]bool{kitID: true}}
+		svc.WithKits(kits)
+
+		shipment, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{WarehouseID: "warehouse-1", CostingMethod: "FIFO", UserID: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, kits.issued, 1)
+		issued := kits.issued[0]
+		assert.Equal(t, kitID, issued.ProductID)
+		assert.Equal(t, "warehouse-1", issued.WarehouseID)
+		assert.True(t, issued.Quantity.Equal(decimal.NewFromInt(3)))
+		assert.Equal(t, "cogs-kit", issued.CostOfGoodsSoldAccountID)
+		assert.Equal(t, OrderShipmentSourceType, issued.SourceType)
+		assert.Equal(t, shipment.ID, issued.SourceID)
+		assert.Empty(t, stock.issued)
+		require.Len(t, shipment.Lines, 1)
+		assert.True(t, shipment.Lines[0].TotalCost.Equal(decimal.NewFromInt(21)))
+		assert.Nil(t, shipment.Lines[0].JournalEntryID)
+		assert.True(t, shipment.TotalCost.Equal(decimal.NewFromInt(21)))
+		assert.Equal(t, OrderStatusShipped, repo.Orders["order-1"].Status)
+	})
+
+	t.Run("wraps kit issue errors", func(t *testing.T) {
+		kitID := "kit-1"
+		repo := NewMockRepository()
+		repo.Orders["order-1"] = &Order{
+			ID:       "order-1",
+			TenantID: "tenant-1",
+			Status:   OrderStatusConfirmed,
+			Lines:    []OrderLine{{ID: "line-1", LineNumber: 1, Description: "Gift box", Quantity: decimal.NewFromInt(1), ProductID: &kitID}},
+		}
+		svc, stock := newShippingService(repo)
+		stock.products[kitID] = &inventory.Product{ID: kitID, Name: "Gift box", ProductType: inventory.ProductTypeGoods}
+		svc.WithKits(&fakeKitIssuer{kits: map[string]bool{kitID: true}, issueErr: errors.New("insufficient stock")})
+
+		_, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{UserID: "user-1"})
+		require.ErrorContains(t, err, "warehouse_id is required")
+
+		_, err = svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{WarehouseID: "warehouse-1", UserID: "user-1"})
+		require.ErrorContains(t, err, "line 1: issue kit: insufficient stock")
+		assert.Empty(t, repo.Shipments)
+	})
+
+	t.Run("validates shipment", func(t *testing.T) {
+		tests := []struct {
+			name    string
+			order   *Order
+			withInv bool
+			req     *ShipOrderRequest
+			wantErr string
+		}{
+			{name: "requires user", order: shippableOrder(OrderStatusProcessing), req: &ShipOrderRequest{}, wantErr: "user id is required"},
+			{name: "rejects pending order", order: shippableOrder(OrderStatusPending), req: &ShipOrderRequest{UserID: "user-1"}, wantErr: "cannot be shipped"},
+			{name: "requires inventory for product lines", order: shippableOrder(OrderStatusProcessing), req: &ShipOrderRequest{UserID: "user-1"}, wantErr: "inventory service is unavailable"},
+			{name: "requires warehouse for tracked lines", order: shippableOrder(OrderStatusProcessing), withInv: true, req: &ShipOrderRequest{UserID: "user-1"}, wantErr: "warehouse_id is required"},
+			{name: "rejects unknown line", order: shippableOrder(OrderStatusProcessing), withInv: true, req: &ShipOrderRequest{UserID: "user-1", Lines: []ShipOrderLineRequest{{OrderLineID: "line-9", Quantity: decimal.NewFromInt(1)}}}, wantErr: "order line line-9 not found"},
+			{name: "rejects nonpositive quantity", order: shippableOrder(OrderStatusProcessing), withInv: true, req: &ShipOrderRequest{UserID: "user-1", Lines: []ShipOrderLineRequest{{OrderLineID: "line-1"}}}, wantErr: "quantity must be positive"},
+			{
+				name: "rejects fully shipped order",
+				order: &Order{ID: "order-1", TenantID: "tenant-1", Status: OrderStatusProcessing, Lines: []OrderLine{{
+					ID: "line-1", LineNumber: 1, Description: "Consulting", Quantity: decimal.NewFromInt(1), ShippedQuantity: decimal.NewFromInt(1),
+				}}},
+				req:     &ShipOrderRequest{UserID: "user-1"},
+				wantErr: "no open quantities to ship",
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				repo := NewMockRepository()
+				repo.Orders["order-1"] = tt.order
+				svc := NewServiceWithRepository(repo)
+				if tt.withInv {
+					svc, _ = newShippingService(repo)
+				}
+
+				_, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", tt.req)
+
+				require.ErrorContains(t, err, tt.wantErr)
+			})
+		}
+	})
+
+	t.Run("wraps shipment errors", func(t *testing.T) {
+		repo := NewMockRepository()
+		repo.Orders["order-1"] = &Order{
+			ID:       "order-1",
+			TenantID: "tenant-1",
+			Status:   OrderStatusProcessing,
+			Lines:    []OrderLine{{ID: "line-1", LineNumber: 1, Description: "Consulting", Quantity: decimal.NewFromInt(1)}},
+		}
+		repo.ShipmentErr = errors.New("insert failed")
 		svc := NewServiceWithRepository(repo)
 
-		err := svc.Ship(context.Background(), "tenant-1", "test_schema", "order-1")
+		_, err := svc.Ship(ctx, "tenant-1", "test_schema", "order-1", &ShipOrderRequest{UserID: "user-1"})
 
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "cannot be shipped")
+		require.ErrorContains(t, err, "ship order: insert failed")
+	})
+
+	t.Run("gets shipment", func(t *testing.T) {
+		repo := NewMockRepository()
+		repo.Shipments = []OrderShipment{{ID: "shipment-1", TenantID: "tenant-1", OrderID: "order-1"}}
+		svc := NewServiceWithRepository(repo)
+
+		shipment, err := svc.GetShipment(ctx, "tenant-1", "test_schema", "order-1", "shipment-1")
+		require.NoError(t, err)
+		assert.Equal(t, "shipment-1", shipment.ID)
+
+		_, err = svc.GetShipment(ctx, "tenant-1", "test_schema", "order-1", "shipment-2")
+		assert.ErrorIs(t, err, ErrOrderShipmentNotFound)
 	})
 }
 
@@ -846,12 +1351,6 @@ func TestService_StatusTransitionRepositoryErrors(t *testing.T) {
 			},
 			wantPrefix: "process order",
 		},
-		{
-			name:       "ship",
-			status:     OrderStatusProcessing,
-			call:       func(svc *Service) error { return svc.Ship(context.Background(), "tenant-1", "test_schema", "order-1") },
-			wantPrefix: "ship order",
-		},
 		{
 			name:   "deliver",
 			status: OrderStatusShipped,
@@ -940,6 +1439,90 @@ func TestService_ConvertToInvoice(t *testing.T) {
 	})
 }
 
+func TestService_PartialInvoicing(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	order := &Order{
+		ID:          "order-1",
+		TenantID:    "tenant-1",
+		OrderNumber: "ORD-00001",
+		ContactID:   "contact-1",
+		Status:      OrderStatusConfirmed,
+		Currency:    "EUR",
+		Lines: []OrderLine{
+			{ID: "line-1", LineNumber: 1, Description: "Design", Quantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(100), VATRate: decimal.NewFromInt(22)},
+			{ID: "line-2", LineNumber: 2, Description: "Build", Quantity: decimal.NewFromInt(4), UnitPrice: decimal.NewFromInt(50), VATRate: decimal.NewFromInt(22)},
+		},
+	}
+	repo.Orders[order.ID] = order
+	svc := NewServiceWithRepository(repo)
+
+	bill := func(req ConvertOrderToInvoiceRequest, invoiceID string) *invoicing.CreateInvoiceRequest {
+		t.Helper()
+		invoiceReq, plan, err := svc.PlanInvoice(ctx, "tenant-1", "test_schema", order, &req)
+		require.NoError(t, err)
+		_, err = svc.RecordInvoice(ctx, "tenant-1", "test_schema", order, plan, &invoicing.Invoice{ID: invoiceID, InvoiceNumber: "INV-" + invoiceID}, "user-1")
+		require.NoError(t, err)
+		return invoiceReq
+	}
+
+	prepayment := bill(ConvertOrderToInvoiceRequest{PartialInvoiceRequest: invoicing.PartialInvoiceRequest{
+		Kind:    invoicing.BillingKindPrepayment,
+		Percent: decimal.NewFromInt(30),
+	}}, "1")
+	require.Len(t, prepayment.Lines, 1)
+	assert.Equal(t, "Prepayment for order ORD-00001", prepayment.Lines[0].Description)
+	assert.True(t, prepayment.Lines[0].UnitPrice.Equal(decimal.NewFromInt(360)))
+	assert.True(t, prepayment.Lines[0].VATRate.Equal(decimal.NewFromInt(22)))
+	assert.False(t, order.IsInvoiced())
+
+	milestone := bill(ConvertOrderToInvoiceRequest{PartialInvoiceRequest: invoicing.PartialInvoiceRequest{
+		Lines: []invoicing.PartialInvoiceLineRequest{{LineID: "line-1", Quantity: decimal.NewFromInt(5)}},
+	}}, "2")
+	require.Len(t, milestone.Lines, 2)
+	assert.True(t, milestone.Lines[0].Quantity.Equal(decimal.NewFromInt(5)))
+	assert.Equal(t, "Less prepayment INV-1", milestone.Lines[1].Description)
+	assert.True(t, milestone.Lines[1].UnitPrice.Equal(decimal.NewFromInt(-150)))
+	assert.Nil(t, order.ConvertedToInvoiceID)
+	assert.True(t, order.Lines[0].InvoicedQuantity.Equal(decimal.NewFromInt(5)))
+
+	_, err := svc.Update(ctx, "tenant-1", "test_schema", order.ID, &UpdateOrderRequest{ContactID: "contact-1"})
+	assert.EqualError(t, err, "invoiced orders cannot be updated")
+	assert.EqualError(t, svc.Cancel(ctx, "tenant-1", "test_schema", order.ID), "invoiced orders cannot be canceled")
+
+	_, _, err = svc.PlanInvoice(ctx, "tenant-1", "test_schema", order, &ConvertOrderToInvoiceRequest{PartialInvoiceRequest: invoicing.PartialInvoiceRequest{
+		Lines: []invoicing.PartialInvoiceLineRequest{{LineID: "line-1", Quantity: decimal.NewFromInt(6)}},
+	}})
+	assert.EqualError(t, err, "line 1: quantity 6 exceeds uninvoiced quantity 5")
+
+	final := bill(ConvertOrderToInvoiceRequest{}, "3")
+	require.Len(t, final.Lines, 3)
+	assert.True(t, final.Lines[2].UnitPrice.Equal(decimal.NewFromInt(-210)))
+	require.NotNil(t, order.ConvertedToInvoiceID)
+	assert.Equal(t, "3", *order.ConvertedToInvoiceID)
+
+	billings, err := svc.ListInvoices(ctx, "tenant-1", "test_schema", order.ID)
+	require.NoError(t, err)
+	require.Len(t, billings, 3)
+	assert.True(t, billings[0].NettedAmount.Equal(decimal.NewFromInt(360)))
+	assert.True(t, billings[2].PrepaymentDeducted.Equal(decimal.NewFromInt(210)))
+
+	_, _, err = svc.PlanInvoice(ctx, "tenant-1", "test_schema", order, &ConvertOrderToInvoiceRequest{})
+	assert.ErrorIs(t, err, invoicing.ErrNothingToInvoice)
+
+	billing, err := svc.GetInvoiceByInvoiceID(ctx, "tenant-1", "test_schema", "2")
+	require.NoError(t, err)
+	assert.Equal(t, order.ID, billing.OrderID)
+	_, err = svc.GetInvoiceByInvoiceID(ctx, "tenant-1", "test_schema", "other")
+	assert.ErrorIs(t, err, ErrOrderInvoiceNotFound)
+
+	repo.BillingErr = errors.New("db error")
+	_, err = svc.ListInvoices(ctx, "tenant-1", "test_schema", order.ID)
+	assert.Error(t, err)
+	_, err = svc.GetInvoiceByInvoiceID(ctx, "tenant-1", "test_schema", "2")
+	assert.Error(t, err)
+}
+
 func TestService_StockReservations(t *testing.T) {
 	ctx := context.Background()
 
@@ -62,6 +62,27 @@ type OrderLine struct {
 	LineVAT         decimal.Decimal `json:"line_vat"`
 	LineTotal       decimal.Decimal `json:"line_total"`
 	ProductID       *string         `json:"product_id,omitempty"`
+	ShippedQuantity decimal.Decimal `json:"shipped_quantity"`
+	// InvoicedQuantity is the part of the line already billed on invoices
+	InvoicedQuantity decimal.Decimal `json:"invoiced_quantity"`
+}
+
+// OpenShipQuantity returns the ordered quantity not yet shipped
+func (l *OrderLine) OpenShipQuantity() decimal.Decimal {
+	open := l.Quantity.Sub(l.ShippedQuantity)
+	if open.IsNegative() {
+		return decimal.Zero
+	}
+	return open
+}
+
+// DueQuantity returns the quantity the order must invoice for the line. Once
+// the order is delivered only the shipped quantity is billed.
+func (l *OrderLine) DueQuantity(status OrderStatus) decimal.Decimal {
+	if status == OrderStatusDelivered {
+		return l.ShippedQuantity
+	}
+	return l.Quantity
 }
 
 // Calculate computes the line totals
@@ -92,6 +113,16 @@ func (o *Order) Calculate() {
 	}
 }
 
+// IsInvoiced reports whether any line has been billed
+func (o *Order) IsInvoiced() bool {
+	for i := range o.Lines {
+		if o.Lines[i].InvoicedQuantity.IsPositive() {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate validates the order
 func (o *Order) Validate() error {
 	if len(o.Lines) == 0 {
@@ -146,13 +177,22 @@ type CreateOrderRequest struct {
 
 // CreateOrderLineRequest is a line in the create order request
 type CreateOrderLineRequest struct {
-	Description     string          `json:"description"`
-	Quantity        decimal.Decimal `json:"quantity"`
-	Unit            string          `json:"unit,omitempty"`
-	UnitPrice       decimal.Decimal `json:"unit_price"`
-	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
-	VATRate         decimal.Decimal `json:"vat_rate"`
-	ProductID       *string         `json:"product_id,omitempty"`
+	Description     string           `json:"description"`
+	Quantity        decimal.Decimal  `json:"quantity"`
+	Unit            string           `json:"unit,omitempty"`
+	UnitPrice       *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountPercent decimal.Decimal  `json:"discount_percent,omitempty"`
+	VATRate         decimal.Decimal  `json:"vat_rate"`
+	ProductID       *string          `json:"product_id,omitempty"`
+}
+
+// unitPrice returns the unit price sent on the line, zero when none was sent
+// and none was resolved.
+func (l *CreateOrderLineRequest) unitPrice() decimal.Decimal {
+	if l.UnitPrice == nil {
+		return decimal.Zero
+	}
+	return *l.UnitPrice
 }
 
 // ImportOrdersRequest contains CSV payload for order migration.
@@ -196,18 +236,105 @@ type UpdateOrderRequest struct {
 	Lines            []CreateOrderLineRequest `json:"lines"`
 }
 
-// ConvertOrderToInvoiceRequest requests conversion of a delivered order into a sales invoice.
+// ConvertOrderToInvoiceRequest requests a sales invoice for an order. Without
+// lines, percent or a prepayment kind a delivered order is billed for every
+// shipped quantity not yet invoiced; partial invoices can be raised from
+// confirmation onwards.
 type ConvertOrderToInvoiceRequest struct {
 	IssueDate time.Time `json:"issue_date,omitempty"`
 	DueDate   time.Time `json:"due_date,omitempty"`
 	Notes     string    `json:"notes,omitempty"`
-	UserID    string    `json:"-"`
+	invoicing.PartialInvoiceRequest
+	UserID string `json:"-"`
 }
 
-// OrderInvoiceConversionResult returns the converted order and created invoice.
+// OrderInvoiceConversionResult returns the order, the created invoice and its billing record.
 type OrderInvoiceConversionResult struct {
 	Order   *Order             `json:"order"`
 	Invoice *invoicing.Invoice `json:"invoice"`
+	Billing *OrderInvoice      `json:"billing,omitempty"`
+}
+
+// OrderInvoice records one invoice billed from an order. For prepayments,
+// Subtotal is the amount paid in advance and NettedAmount the part later
+// invoices have deducted.
+type OrderInvoice struct {
+	ID                 string                `json:"id"`
+	TenantID           string                `json:"tenant_id"`
+	OrderID            string                `json:"order_id"`
+	InvoiceID          string                `json:"invoice_id"`
+	InvoiceNumber      string                `json:"invoice_number"`
+	Kind               invoicing.BillingKind `json:"kind"`
+	Percent            decimal.Decimal       `json:"percent"`
+	Subtotal           decimal.Decimal       `json:"subtotal"`
+	VATRate            decimal.Decimal       `json:"vat_rate"`
+	PrepaymentDeducted decimal.Decimal       `json:"prepayment_deducted"`
+	NettedAmount       decimal.Decimal       `json:"netted_amount"`
+	CreatedBy          string                `json:"created_by,omitempty"`
+	CreatedAt          time.Time             `json:"created_at"`
+}
+
+// OrderShipmentSourceType marks inventory movements and COGS journal entries created by order shipments.
+const OrderShipmentSourceType = "ORDER_SHIPMENT"
+
+// OrderShipment records a full or partial shipment of a sales order
+type OrderShipment struct {
+	ID             string              `json:"id"`
+	TenantID       string              `json:"tenant_id"`
+	ShipmentNumber string              `json:"shipment_number"`
+	OrderID        string              `json:"order_id"`
+	WarehouseID    string              `json:"warehouse_id,omitempty"`
+	ShipmentDate   time.Time           `json:"shipment_date"`
+	CostingMethod  string              `json:"costing_method,omitempty"`
+	TotalCost      decimal.Decimal     `json:"total_cost"`
+	Notes          string              `json:"notes,omitempty"`
+	Lines          []OrderShipmentLine `json:"lines"`
+	CreatedBy      string              `json:"created_by"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// OrderShipmentLine records the quantity shipped for one order line and,
+// for stock-tracked products, the cost of goods issued from the warehouse
+type OrderShipmentLine struct {
+	ID             string          `json:"id"`
+	TenantID       string          `json:"tenant_id"`
+	ShipmentID     string          `json:"shipment_id"`
+	OrderLineID    string          `json:"order_line_id"`
+	LineNumber     int             `json:"line_number"`
+	ProductID      *string         `json:"product_id,omitempty"`
+	Description    string          `json:"description"`
+	Quantity       decimal.Decimal `json:"quantity"`
+	Unit           string          `json:"unit,omitempty"`
+	UnitCost       decimal.Decimal `json:"unit_cost"`
+	TotalCost      decimal.Decimal `json:"total_cost"`
+	LotNumber      string          `json:"lot_number,omitempty"`
+	SerialNumber   string          `json:"serial_number,omitempty"`
+	ExpiryDate     string          `json:"expiry_date,omitempty"`
+	JournalEntryID *string         `json:"journal_entry_id,omitempty"`
+}
+
+// ShipOrderRequest ships a confirmed or processing order. Without lines every
+// open quantity is shipped. Stock-tracked product lines are issued from the
+// warehouse, consuming the order's stock reservations first, and their cost is
+// posted from inventory to cost of goods sold.
+type ShipOrderRequest struct {
+	WarehouseID              string                 `json:"warehouse_id,omitempty"`
+	CostOfGoodsSoldAccountID string                 `json:"cost_of_goods_sold_account_id,omitempty"`
+	InventoryAccountID       string                 `json:"inventory_account_id,omitempty"`
+	Notes                    string                 `json:"notes,omitempty"`
+	Lines                    []ShipOrderLineRequest `json:"lines,omitempty"`
+	CostingMethod            string                 `json:"-"`
+	UserID                   string                 `json:"-"`
+}
+
+// ShipOrderLineRequest ships a quantity of one order line. Lot, serial and
+// expiry select the tracked stock to issue.
+type ShipOrderLineRequest struct {
+	OrderLineID  string          `json:"order_line_id"`
+	Quantity     decimal.Decimal `json:"quantity"`
+	LotNumber    string          `json:"lot_number,omitempty"`
+	SerialNumber string          `json:"serial_number,omitempty"`
+	ExpiryDate   string          `json:"expiry_date,omitempty"`
 }
 
 // OrderFilter provides filtering options
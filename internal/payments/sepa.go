@@ -22,16 +22,19 @@ var marshalSEPAXML = xml.MarshalIndent
 
 // SEPACreditTransferLine is one outgoing SEPA credit transfer in a payment file.
 type SEPACreditTransferLine struct {
-	EndToEndID    string          `json:"end_to_end_id,omitempty"`
-	CreditorName  string          `json:"creditor_name"`
-	CreditorIBAN  string          `json:"creditor_iban"`
-	CreditorBIC   string          `json:"creditor_bic,omitempty"`
-	Amount        decimal.Decimal `json:"amount"`
-	Currency      string          `json:"currency,omitempty"`
-	Remittance    string          `json:"remittance,omitempty"`
-	InvoiceID     string          `json:"invoice_id,omitempty"`
-	PaymentID     string          `json:"payment_id,omitempty"`
-	PaymentNumber string          `json:"payment_number,omitempty"`
+	EndToEndID   string          `json:"end_to_end_id,omitempty"`
+	CreditorName string          `json:"creditor_name"`
+	CreditorIBAN string          `json:"creditor_iban"`
+	CreditorBIC  string          `json:"creditor_bic,omitempty"`
+	Amount       decimal.Decimal `json:"amount"`
+	Currency     string          `json:"currency,omitempty"`
+	Remittance   string          `json:"remittance,omitempty"`
+	// CreditorReference is a structured creditor reference, such as an Estonian
+	// reference number. When set it replaces the unstructured remittance text.
+	CreditorReference string `json:"creditor_reference,omitempty"`
+	InvoiceID         string `json:"invoice_id,omitempty"`
+	PaymentID         string `json:"payment_id,omitempty"`
+	PaymentNumber     string `json:"payment_number,omitempty"`
 }
 
 // SEPAExportRequest describes a SEPA pain.001 XML export request.
@@ -219,7 +222,17 @@ func sepaTransactionFromLine(index int, line SEPACreditTransferLine) (sepaCredit
 		agent := sepaAgentForBIC(creditorBIC)
 		tx.CreditorAgent = &agent
 	}
-	if remittance := strings.TrimSpace(line.Remittance); remittance != "" {
+	if reference := strings.TrimSpace(line.CreditorReference); reference != "" {
+		if len(reference) > 35 {
+			return sepaCreditTransferTransaction{}, decimal.Zero, fmt.Errorf("line %d creditor_reference must be at most 35 characters", index+1)
+		}
+		tx.RemittanceInfo = &sepaRemittanceInfo{Structured: &sepaStructuredRemittance{
+			CreditorReference: sepaCreditorReference{
+				Type:      sepaCreditorReferenceType{CodeOrProprietary: sepaCode{Code: "SCOR"}},
+				Reference: reference,
+			},
+		}}
+	} else if remittance := strings.TrimSpace(line.Remittance); remittance != "" {
 		tx.RemittanceInfo = &sepaRemittanceInfo{Unstructured: remittance}
 	}
 	return tx, line.Amount.Round(2), nil
@@ -376,5 +389,19 @@ type sepaInstructedAmount struct {
 }
 
 type sepaRemittanceInfo struct {
-	Unstructured string `xml:"Ustrd"`
+	Unstructured string                    `xml:"Ustrd,omitempty"`
+	Structured   *sepaStructuredRemittance `xml:"Strd,omitempty"`
+}
+
+type sepaStructuredRemittance struct {
+	CreditorReference sepaCreditorReference `xml:"CdtrRefInf"`
+}
+
+type sepaCreditorReference struct {
+	Type      sepaCreditorReferenceType `xml:"Tp"`
+	Reference string                    `xml:"Ref"`
+}
+
+type sepaCreditorReferenceType struct {
+	CodeOrProprietary sepaCode `xml:"CdOrPrtry"`
 }
@@ -47,6 +47,38 @@ func TestBuildSEPAExport(t *testing.T) {
 	assert.Contains(t, result.XML, `xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"`)
 }
 
+func TestBuildSEPAExportCreditorReference(t *testing.T) {
+	result, err := BuildSEPAExport(&SEPAExportRequest{
+		DebtorName:    "Example OU",
+		DebtorIBAN:    "EE382200221020145685",
+		ExecutionDate: "2026-04-10",
+		Lines: []SEPACreditTransferLine{{
+			CreditorName:      "Maksu- ja Tolliamet",
+			CreditorIBAN:      "EE932200221023778606",
+			Amount:            decimal.RequireFromString("1020.00"),
+			Remittance:        "Ignored when a reference is set",
+			CreditorReference: "10123456781",
+		}},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, result.XML, `<Cd>SCOR</Cd>`)
+	assert.Contains(t, result.XML, `<Ref>10123456781</Ref>`)
+	assert.NotContains(t, result.XML, `<Ustrd>`)
+
+	_, err = BuildSEPAExport(&SEPAExportRequest{
+		DebtorName:    "Example OU",
+		DebtorIBAN:    "EE382200221020145685",
+		ExecutionDate: "2026-04-10",
+		Lines: []SEPACreditTransferLine{{
+			CreditorName:      "Maksu- ja Tolliamet",
+			CreditorIBAN:      "EE932200221023778606",
+			Amount:            decimal.RequireFromString("1.00"),
+			CreditorReference: strings.Repeat("1", 36),
+		}},
+	})
+	require.EqualError(t, err, "line 1 creditor_reference must be at most 35 characters")
+}
+
 func TestBuildSEPAExportDefaultBranches(t *testing.T) {
 	batchBooking := false
 
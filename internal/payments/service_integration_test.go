@@ -138,7 +138,7 @@ func createAtomicPaymentInvoice(t *testing.T, service *invoicing.Service, tenant
 		Lines: []invoicing.CreateInvoiceLineRequest{{
 			Description: "Atomic payment test",
 			Quantity:    decimal.NewFromInt(1),
-			UnitPrice:   decimal.NewFromInt(100),
+			UnitPrice:   new(decimal.NewFromInt(100)),
 			VATRate:     decimal.NewFromInt(22),
 		}},
 	})
@@ -0,0 +1,499 @@
+package payroll
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrEmploymentEventsUnavailable is returned when the repository does not store
+// employment register events.
+var ErrEmploymentEventsUnavailable = errors.New("employment register events are unavailable")
+
+// EmploymentEventType identifies a change reported to the Tax Board employment
+// register (töötamise register, TÖR).
+type EmploymentEventType string
+
+const (
+	EmploymentEventStart             EmploymentEventType = "START"
+	EmploymentEventEnd               EmploymentEventType = "END"
+	EmploymentEventSuspensionStart   EmploymentEventType = "SUSPENSION_START"
+	EmploymentEventSuspensionEnd     EmploymentEventType = "SUSPENSION_END"
+	EmploymentEventWorkingTimeChange EmploymentEventType = "WORKING_TIME_CHANGE"
+)
+
+// EmploymentTerminationCodes lists the Employment Contracts Act (TLS) grounds
+// accepted as the termination code of an END event.
+var EmploymentTerminationCodes = map[string]string{
+	"TLS79": "Agreement of the parties (§ 79)",
+	"TLS80": "Expiry of a fixed-term contract (§ 80)",
+	"TLS85": "Ordinary cancellation by the employee (§ 85)",
+	"TLS86": "Cancellation during the probationary period (§ 86)",
+	"TLS88": "Extraordinary cancellation by the employer for reasons relating to the employee (§ 88)",
+	"TLS89": "Extraordinary cancellation by the employer for economic reasons (§ 89)",
+	"TLS91": "Extraordinary cancellation by the employee (§ 91)",
+}
+
+// EmploymentSuspensionReasons lists the reasons accepted for a SUSPENSION_START event.
+var EmploymentSuspensionReasons = map[string]string{
+	"PARENTAL_LEAVE":   "Parental leave",
+	"MILITARY_SERVICE": "Compulsory military or alternative service",
+	"UNPAID_LEAVE":     "Unpaid leave",
+}
+
+// EmploymentEvent is one entry in an employee's employment register history.
+// Events are append-only; ExportedAt is set once the event has been included
+// in a TÖR upload file.
+type EmploymentEvent struct {
+	ID               string              `json:"id"`
+	TenantID         string              `json:"tenant_id"`
+	EmployeeID       string              `json:"employee_id"`
+	EventType        EmploymentEventType `json:"event_type"`
+	EventDate        time.Time           `json:"event_date"`
+	TerminationCode  string              `json:"termination_code,omitempty"`
+	SuspensionReason string              `json:"suspension_reason,omitempty"`
+	WorkingTimeRatio decimal.Decimal     `json:"working_time_ratio"`
+	Position         string              `json:"position,omitempty"`
+	Notes            string              `json:"notes,omitempty"`
+	ExportedAt       *time.Time          `json:"exported_at,omitempty"`
+	CreatedBy        string              `json:"created_by,omitempty"`
+	CreatedAt        time.Time           `json:"created_at"`
+}
+
+// EmploymentEventFilter contains optional filters for listing employment events.
+type EmploymentEventFilter struct {
+	EmployeeID  string
+	From        *time.Time
+	To          *time.Time
+	PendingOnly bool
+}
+
+// CreateEmploymentEventRequest records an employment register event for an employee.
+type CreateEmploymentEventRequest struct {
+	EventType        EmploymentEventType `json:"event_type"`
+	EventDate        time.Time           `json:"event_date"`
+	TerminationCode  string              `json:"termination_code,omitempty"`
+	SuspensionReason string              `json:"suspension_reason,omitempty"`
+	WorkingTimeRatio decimal.Decimal     `json:"working_time_ratio,omitempty"`
+	Position         string              `json:"position,omitempty"`
+	Notes            string              `json:"notes,omitempty"`
+}
+
+// ExportEmploymentRegisterRequest selects the events for a TÖR bulk-upload file.
+type ExportEmploymentRegisterRequest struct {
+	From            time.Time `json:"from"`
+	To              time.Time `json:"to"`
+	IncludeExported bool      `json:"include_exported,omitempty"`
+}
+
+// EmploymentRegisterExport is a generated TÖR bulk-upload file.
+type EmploymentRegisterExport struct {
+	FileName   string
+	Content    []byte
+	EventCount int
+}
+
+// EmploymentRegisterPendingEmployee lists an employee's events that have not
+// been exported to the employment register yet.
+type EmploymentRegisterPendingEmployee struct {
+	EmployeeID     string            `json:"employee_id"`
+	EmployeeNumber string            `json:"employee_number,omitempty"`
+	EmployeeName   string            `json:"employee_name"`
+	Events         []EmploymentEvent `json:"events"`
+}
+
+// employmentRegisterState is an employee's register state derived from history.
+type employmentRegisterState struct {
+	recorded         bool
+	employed         bool
+	suspended        bool
+	workingTimeRatio decimal.Decimal
+	lastEventDate    time.Time
+}
+
+func (s *Service) employmentEventRepository() (EmploymentEventRepository, error) {
+	events, ok := s.repo.(EmploymentEventRepository)
+	if !ok {
+		return nil, ErrEmploymentEventsUnavailable
+	}
+	return events, nil
+}
+
+// ListEmploymentEvents returns an employee's employment register history in date order.
+func (s *Service) ListEmploymentEvents(ctx context.Context, schemaName, tenantID, employeeID string) ([]EmploymentEvent, error) {
+	events, err := s.employmentEventRepository()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetEmployee(ctx, schemaName, tenantID, employeeID); err != nil {
+		return nil, err
+	}
+	history, err := events.ListEmploymentEvents(ctx, schemaName, tenantID, EmploymentEventFilter{EmployeeID: employeeID})
+	if err != nil {
+		return nil, fmt.Errorf("list employment events: %w", err)
+	}
+	return history, nil
+}
+
+// RecordEmploymentEvent appends an event to an employee's employment register
+// history. The event must follow the latest recorded event and be valid for
+// the state that history implies; END events also close the employee record.
+func (s *Service) RecordEmploymentEvent(ctx context.Context, schemaName, tenantID, employeeID, userID string, req *CreateEmploymentEventRequest) (*EmploymentEvent, error) {
+	if _, err := s.employmentEventRepository(); err != nil {
+		return nil, err
+	}
+	if req == nil {
+		return nil, fmt.Errorf("event type is required")
+	}
+	if req.EventDate.IsZero() {
+		return nil, fmt.Errorf("event date is required")
+	}
+
+	var event *EmploymentEvent
+	err := s.repo.WithTransaction(ctx, func(txRepo Repository) error {
+		events, ok := txRepo.(EmploymentEventRepository)
+		if !ok {
+			return ErrEmploymentEventsUnavailable
+		}
+		txService := &Service{repo: txRepo, uuid: s.uuid}
+
+		emp, err := txService.GetEmployee(ctx, schemaName, tenantID, employeeID)
+		if err != nil {
+			return err
+		}
+		history, err := events.ListEmploymentEvents(ctx, schemaName, tenantID, EmploymentEventFilter{EmployeeID: employeeID})
+		if err != nil {
+			return fmt.Errorf("list employment events: %w", err)
+		}
+
+		event, err = txService.buildEmploymentEvent(emp, history, req)
+		if err != nil {
+			return err
+		}
+		event.CreatedBy = userID
+		if err := events.CreateEmploymentEvent(ctx, schemaName, event); err != nil {
+			return fmt.Errorf("create employment event: %w", err)
+		}
+
+		if event.EventType == EmploymentEventEnd {
+			endDate := event.EventDate
+			isActive := false
+			if _, err := txService.UpdateEmployee(ctx, schemaName, tenantID, emp.ID, &UpdateEmployeeRequest{
+				EndDate:  &endDate,
+				IsActive: &isActive,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// ListPendingEmploymentRegisterEmployees returns the employees whose register
+// events have not been exported yet.
+func (s *Service) ListPendingEmploymentRegisterEmployees(ctx context.Context, schemaName, tenantID string) ([]EmploymentRegisterPendingEmployee, error) {
+	events, err := s.employmentEventRepository()
+	if err != nil {
+		return nil, err
+	}
+	pending, err := events.ListEmploymentEvents(ctx, schemaName, tenantID, EmploymentEventFilter{PendingOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("list employment events: %w", err)
+	}
+	employees, err := s.repo.ListEmployees(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list employees: %w", err)
+	}
+	return groupPendingEmploymentEvents(pending, employees), nil
+}
+
+// ExportEmploymentRegisterCSV generates the TÖR bulk-upload file for the events
+// dated within the range and marks them exported. Events that were already
+// exported are only included when requested.
+func (s *Service) ExportEmploymentRegisterCSV(ctx context.Context, schemaName, tenantID string, req *ExportEmploymentRegisterRequest) (*EmploymentRegisterExport, error) {
+	if _, err := s.employmentEventRepository(); err != nil {
+		return nil, err
+	}
+	if req == nil || req.From.IsZero() || req.To.IsZero() {
+		return nil, fmt.Errorf("from and to dates are required")
+	}
+	from := dateOnly(req.From)
+	to := dateOnly(req.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must be on or after from date")
+	}
+
+	var export *EmploymentRegisterExport
+	err := s.repo.WithTransaction(ctx, func(txRepo Repository) error {
+		events, ok := txRepo.(EmploymentEventRepository)
+		if !ok {
+			return ErrEmploymentEventsUnavailable
+		}
+		selected, err := events.ListEmploymentEvents(ctx, schemaName, tenantID, EmploymentEventFilter{
+			From:        &from,
+			To:          &to,
+			PendingOnly: !req.IncludeExported,
+		})
+		if err != nil {
+			return fmt.Errorf("list employment events: %w", err)
+		}
+		if len(selected) == 0 {
+			return fmt.Errorf("no employment register events to export between %s and %s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+		}
+
+		employees, err := txRepo.ListEmployees(ctx, schemaName, tenantID, false)
+		if err != nil {
+			return fmt.Errorf("list employees: %w", err)
+		}
+		employeesByID := make(map[string]*Employee, len(employees))
+		for i := range employees {
+			employeesByID[employees[i].ID] = &employees[i]
+		}
+
+		content, err := buildEmploymentRegisterCSV(selected, employeesByID)
+		if err != nil {
+			return err
+		}
+
+		pendingIDs := make([]string, 0, len(selected))
+		for _, event := range selected {
+			if event.ExportedAt == nil {
+				pendingIDs = append(pendingIDs, event.ID)
+			}
+		}
+		if len(pendingIDs) > 0 {
+			if err := events.MarkEmploymentEventsExported(ctx, schemaName, tenantID, pendingIDs, time.Now()); err != nil {
+				return fmt.Errorf("mark employment events exported: %w", err)
+			}
+		}
+
+		export = &EmploymentRegisterExport{
+			FileName:   fmt.Sprintf("TOR_%s_%s.csv", from.Format("20060102"), to.Format("20060102")),
+			Content:    content,
+			EventCount: len(selected),
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+func (s *Service) buildEmploymentEvent(emp *Employee, history []EmploymentEvent, req *CreateEmploymentEventRequest) (*EmploymentEvent, error) {
+	eventType := EmploymentEventType(strings.ToUpper(strings.TrimSpace(string(req.EventType))))
+	eventDate := dateOnly(req.EventDate)
+	state := deriveEmploymentRegisterState(emp, history)
+	if !state.lastEventDate.IsZero() && eventDate.Before(state.lastEventDate) {
+		return nil, fmt.Errorf("event date %s is before the latest employment event on %s", eventDate.Format("2006-01-02"), state.lastEventDate.Format("2006-01-02"))
+	}
+
+	event := &EmploymentEvent{
+		ID:               s.uuid.New(),
+		TenantID:         emp.TenantID,
+		EmployeeID:       emp.ID,
+		EventType:        eventType,
+		EventDate:        eventDate,
+		WorkingTimeRatio: state.workingTimeRatio,
+		Position:         strings.TrimSpace(req.Position),
+		Notes:            strings.TrimSpace(req.Notes),
+		CreatedAt:        time.Now(),
+	}
+	if event.Position == "" {
+		event.Position = emp.Position
+	}
+
+	switch eventType {
+	case EmploymentEventStart:
+		if state.recorded && state.employed {
+			return nil, fmt.Errorf("employee is already registered as employed")
+		}
+		if emp.EndDate != nil {
+			return nil, fmt.Errorf("employee record has an end date; create a new employee record for a rehire")
+		}
+		ratio, err := employmentWorkingTimeRatio(req.WorkingTimeRatio, decimal.NewFromInt(1))
+		if err != nil {
+			return nil, err
+		}
+		event.WorkingTimeRatio = ratio
+	case EmploymentEventEnd:
+		if !state.employed {
+			return nil, fmt.Errorf("employee is not registered as employed")
+		}
+		code := strings.ToUpper(strings.TrimSpace(req.TerminationCode))
+		if code == "" {
+			return nil, fmt.Errorf("termination code is required")
+		}
+		if _, ok := EmploymentTerminationCodes[code]; !ok {
+			return nil, fmt.Errorf("unknown termination code %q", req.TerminationCode)
+		}
+		event.TerminationCode = code
+	case EmploymentEventSuspensionStart:
+		if !state.employed {
+			return nil, fmt.Errorf("employee is not registered as employed")
+		}
+		if state.suspended {
+			return nil, fmt.Errorf("employment is already suspended")
+		}
+		reason := strings.ToUpper(strings.TrimSpace(req.SuspensionReason))
+		if reason == "" {
+			return nil, fmt.Errorf("suspension reason is required")
+		}
+		if _, ok := EmploymentSuspensionReasons[reason]; !ok {
+			return nil, fmt.Errorf("unknown suspension reason %q", req.SuspensionReason)
+		}
+		event.SuspensionReason = reason
+	case EmploymentEventSuspensionEnd:
+		if !state.suspended {
+			return nil, fmt.Errorf("employment is not suspended")
+		}
+	case EmploymentEventWorkingTimeChange:
+		if !state.employed {
+			return nil, fmt.Errorf("employee is not registered as employed")
+		}
+		if req.WorkingTimeRatio.IsZero() {
+			return nil, fmt.Errorf("working time ratio is required")
+		}
+		ratio, err := employmentWorkingTimeRatio(req.WorkingTimeRatio, decimal.Zero)
+		if err != nil {
+			return nil, err
+		}
+		event.WorkingTimeRatio = ratio
+	case "":
+		return nil, fmt.Errorf("event type is required")
+	default:
+		return nil, fmt.Errorf("unknown employment event type %q", req.EventType)
+	}
+
+	return event, nil
+}
+
+// deriveEmploymentRegisterState replays an employee's event history. Employees
+// without history were registered before events were tracked, so they count as
+// employed until their end date and may still have their START back-filled.
+func deriveEmploymentRegisterState(emp *Employee, history []EmploymentEvent) employmentRegisterState {
+	state := employmentRegisterState{
+		employed:         emp.EndDate == nil,
+		workingTimeRatio: decimal.NewFromInt(1),
+	}
+	for _, event := range history {
+		state.recorded = true
+		switch event.EventType {
+		case EmploymentEventStart:
+			state.employed = true
+			state.suspended = false
+			state.workingTimeRatio = event.WorkingTimeRatio
+		case EmploymentEventEnd:
+			state.employed = false
+			state.suspended = false
+		case EmploymentEventSuspensionStart:
+			state.suspended = true
+		case EmploymentEventSuspensionEnd:
+			state.suspended = false
+		case EmploymentEventWorkingTimeChange:
+			state.workingTimeRatio = event.WorkingTimeRatio
+		}
+		if event.EventDate.After(state.lastEventDate) {
+			state.lastEventDate = dateOnly(event.EventDate)
+		}
+	}
+	return state
+}
+
+func employmentWorkingTimeRatio(ratio, fallback decimal.Decimal) (decimal.Decimal, error) {
+	if ratio.IsZero() && fallback.IsPositive() {
+		return fallback, nil
+	}
+	if !ratio.IsPositive() || ratio.GreaterThan(decimal.NewFromInt(1)) {
+		return decimal.Zero, fmt.Errorf("working time ratio must be greater than 0 and at most 1")
+	}
+	return ratio, nil
+}
+
+func buildEmploymentRegisterCSV(events []EmploymentEvent, employeesByID map[string]*Employee) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("row_number;personal_code;first_name;last_name;action;event_date;employment_type;position;working_time_ratio;termination_code;suspension_reason\n")
+
+	for i, event := range events {
+		emp, ok := employeesByID[event.EmployeeID]
+		if !ok {
+			return nil, fmt.Errorf("employee %s not found for employment event %s", event.EmployeeID, event.ID)
+		}
+		if strings.TrimSpace(emp.PersonalCode) == "" {
+			return nil, fmt.Errorf("employee %s has no personal code", emp.FullName())
+		}
+		fmt.Fprintf(&buf, "%d;%s;%s;%s;%s;%s;%s;%s;%s;%s;%s\n",
+			i+1,
+			emp.PersonalCode,
+			emp.FirstName,
+			emp.LastName,
+			event.EventType,
+			event.EventDate.Format("2006-01-02"),
+			emp.EmploymentType,
+			event.Position,
+			event.WorkingTimeRatio.StringFixed(2),
+			event.TerminationCode,
+			event.SuspensionReason,
+		)
+	}
+	return buf.Bytes(), nil
+}
+
+func groupPendingEmploymentEvents(events []EmploymentEvent, employees []Employee) []EmploymentRegisterPendingEmployee {
+	employeesByID := make(map[string]*Employee, len(employees))
+	for i := range employees {
+		employeesByID[employees[i].ID] = &employees[i]
+	}
+
+	indexes := make(map[string]int)
+	result := make([]EmploymentRegisterPendingEmployee, 0)
+	for _, event := range events {
+		idx, ok := indexes[event.EmployeeID]
+		if !ok {
+			pending := EmploymentRegisterPendingEmployee{EmployeeID: event.EmployeeID}
+			if emp, found := employeesByID[event.EmployeeID]; found {
+				pending.EmployeeNumber = emp.EmployeeNumber
+				pending.EmployeeName = emp.FullName()
+			}
+			idx = len(result)
+			indexes[event.EmployeeID] = idx
+			result = append(result, pending)
+		}
+		result[idx].Events = append(result[idx].Events, event)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].EmployeeName < result[j].EmployeeName
+	})
+	return result
+}
+
+// payrollRunRemediationActions builds a run's follow-up actions, adding one per
+// employee whose register events up to the period end have not been exported.
+func payrollRunRemediationActions(run *PayrollRun, pending []EmploymentRegisterPendingEmployee) []PayrollRunRemediationAction {
+	actions := BuildPayrollRunRemediationActions(run)
+	return append(actions, BuildEmploymentRegisterRemediationActions(run, pending)...)
+}
+
+// pendingEmploymentRegisterEmployees looks up unexported register events for
+// remediation actions. Lookup failures are ignored because the actions are advisory.
+func (s *Service) pendingEmploymentRegisterEmployees(ctx context.Context, schemaName, tenantID string) []EmploymentRegisterPendingEmployee {
+	if _, ok := s.repo.(EmploymentEventRepository); !ok {
+		return nil
+	}
+	pending, err := s.ListPendingEmploymentRegisterEmployees(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil
+	}
+	return pending
+}
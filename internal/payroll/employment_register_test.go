@@ -0,0 +1,285 @@
+package payroll
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type employmentEventMockRepository struct {
+	*MockRepository
+	events []EmploymentEvent
+}
+
+func newEmploymentEventMockRepository() *employmentEventMockRepository {
+	return &employmentEventMockRepository{MockRepository: NewMockRepository()}
+}
+
+func (m *employmentEventMockRepository) WithTransaction(ctx context.Context, fn func(txRepo Repository) error) error {
+	return fn(m)
+}
+
+func (m *employmentEventMockRepository) ListEmploymentEvents(ctx context.Context, schemaName, tenantID string, filter EmploymentEventFilter) ([]EmploymentEvent, error) {
+	result := []EmploymentEvent{}
+	for _, event := range m.events {
+		if event.TenantID != tenantID || (filter.EmployeeID != "" && event.EmployeeID != filter.EmployeeID) {
+			continue
+		}
+		if filter.From != nil && event.EventDate.Before(*filter.From) {
+			continue
+		}
+		if filter.To != nil && event.EventDate.After(*filter.To) {
+			continue
+		}
+		if filter.PendingOnly && event.ExportedAt != nil {
+			continue
+		}
+		result = append(result, event)
+	}
+	return result, nil
+}
+
+func (m *employmentEventMockRepository) CreateEmploymentEvent(ctx context.Context, schemaName string, event *EmploymentEvent) error {
+	m.events = append(m.events, *event)
+	return nil
+}
+
+func (m *employmentEventMockRepository) MarkEmploymentEventsExported(ctx context.Context, schemaName, tenantID string, eventIDs []string, exportedAt time.Time) error {
+	for i := range m.events {
+		for _, id := range eventIDs {
+			if m.events[i].ID == id {
+				m.events[i].ExportedAt = &exportedAt
+			}
+		}
+	}
+	return nil
+}
+
+func setupEmploymentRegisterService(t *testing.T) (*Service, *employmentEventMockRepository, *Employee) {
+	t.Helper()
+	repo := newEmploymentEventMockRepository()
+	service := NewServiceWithRepository(repo, &DefaultUUIDGenerator{})
+	emp, err := service.CreateEmployee(context.Background(), "tenant_test", "tenant-1", &CreateEmployeeRequest{
+		FirstName:        "Mari",
+		LastName:         "Maasikas",
+		PersonalCode:     "49001010001",
+		StartDate:        leavePayDate(2026, time.January, 5),
+		Position:         "Accountant",
+		EmploymentType:   EmploymentPartTime,
+		WorkingTimeRatio: decimal.NewFromFloat(0.5),
+	})
+	require.NoError(t, err)
+	return service, repo, emp
+}
+
+func TestCreateEmployeeRecordsStartEvent(t *testing.T) {
+	_, repo, emp := setupEmploymentRegisterService(t)
+
+	require.Len(t, repo.events, 1)
+	event := repo.events[0]
+	assert.Equal(t, EmploymentEventStart, event.EventType)
+	assert.Equal(t, emp.ID, event.EmployeeID)
+	assert.Equal(t, leavePayDate(2026, time.January, 5), event.EventDate)
+	assert.Equal(t, "0.5", event.WorkingTimeRatio.String())
+	assert.Equal(t, "Accountant", event.Position)
+	assert.Nil(t, event.ExportedAt)
+
+	service := NewServiceWithRepository(repo, &DefaultUUIDGenerator{})
+	_, err := service.CreateEmployee(context.Background(), "tenant_test", "tenant-1", &CreateEmployeeRequest{
+		FirstName:        "Jaan",
+		LastName:         "Tamm",
+		StartDate:        leavePayDate(2026, time.January, 5),
+		WorkingTimeRatio: decimal.NewFromFloat(1.5),
+	})
+	assert.EqualError(t, err, "working time ratio must be greater than 0 and at most 1")
+
+	plain, err := NewServiceWithRepository(NewMockRepository(), &DefaultUUIDGenerator{}).CreateEmployee(context.Background(), "tenant_test", "tenant-1", &CreateEmployeeRequest{
+		FirstName: "Jaan",
+		LastName:  "Tamm",
+		StartDate: leavePayDate(2026, time.January, 5),
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, plain.ID)
+}
+
+func TestRecordEmploymentEvent(t *testing.T) {
+	service, repo, emp := setupEmploymentRegisterService(t)
+	ctx := context.Background()
+	record := func(req CreateEmploymentEventRequest) (*EmploymentEvent, error) {
+		return service.RecordEmploymentEvent(ctx, "tenant_test", "tenant-1", emp.ID, "user-1", &req)
+	}
+
+	changed, err := record(CreateEmploymentEventRequest{EventType: "working_time_change", EventDate: leavePayDate(2026, time.March, 1), WorkingTimeRatio: decimal.NewFromFloat(0.75)})
+	require.NoError(t, err)
+	assert.Equal(t, EmploymentEventWorkingTimeChange, changed.EventType)
+	assert.Equal(t, "user-1", changed.CreatedBy)
+
+	suspended, err := record(CreateEmploymentEventRequest{EventType: EmploymentEventSuspensionStart, EventDate: leavePayDate(2026, time.April, 1), SuspensionReason: "parental_leave"})
+	require.NoError(t, err)
+	assert.Equal(t, "PARENTAL_LEAVE", suspended.SuspensionReason)
+	assert.Equal(t, "0.75", suspended.WorkingTimeRatio.String())
+
+	for _, tc := range []struct {
+		name string
+		req  CreateEmploymentEventRequest
+		want string
+	}{
+		{"missing date", CreateEmploymentEventRequest{EventType: EmploymentEventEnd}, "event date is required"},
+		{"missing type", CreateEmploymentEventRequest{EventDate: leavePayDate(2026, time.May, 1)}, "event type is required"},
+		{"unknown type", CreateEmploymentEventRequest{EventType: "TRANSFER", EventDate: leavePayDate(2026, time.May, 1)}, `unknown employment event type "TRANSFER"`},
+		{"before latest event", CreateEmploymentEventRequest{EventType: EmploymentEventSuspensionEnd, EventDate: leavePayDate(2026, time.March, 31)}, "event date 2026-03-31 is before the latest employment event on 2026-04-01"},
+		{"start while employed", CreateEmploymentEventRequest{EventType: EmploymentEventStart, EventDate: leavePayDate(2026, time.May, 1)}, "employee is already registered as employed"},
+		{"already suspended", CreateEmploymentEventRequest{EventType: EmploymentEventSuspensionStart, EventDate: leavePayDate(2026, time.May, 1), SuspensionReason: "UNPAID_LEAVE"}, "employment is already suspended"},
+		{"missing termination code", CreateEmploymentEventRequest{EventType: EmploymentEventEnd, EventDate: leavePayDate(2026, time.May, 1)}, "termination code is required"},
+		{"unknown termination code", CreateEmploymentEventRequest{EventType: EmploymentEventEnd, EventDate: leavePayDate(2026, time.May, 1), TerminationCode: "TLS999"}, `unknown termination code "TLS999"`},
+		{"missing ratio", CreateEmploymentEventRequest{EventType: EmploymentEventWorkingTimeChange, EventDate: leavePayDate(2026, time.May, 1)}, "working time ratio is required"},
+		{"ratio too high", CreateEmploymentEventRequest{EventType: EmploymentEventWorkingTimeChange, EventDate: leavePayDate(2026, time.May, 1), WorkingTimeRatio: decimal.NewFromInt(2)}, "working time ratio must be greater than 0 and at most 1"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := record(tc.req)
+			assert.EqualError(t, err, tc.want)
+		})
+	}
+
+	_, err = record(CreateEmploymentEventRequest{EventType: EmploymentEventSuspensionEnd, EventDate: leavePayDate(2026, time.October, 1)})
+	require.NoError(t, err)
+	_, err = record(CreateEmploymentEventRequest{EventType: EmploymentEventSuspensionEnd, EventDate: leavePayDate(2026, time.October, 2)})
+	assert.EqualError(t, err, "employment is not suspended")
+
+	ended, err := record(CreateEmploymentEventRequest{EventType: EmploymentEventEnd, EventDate: leavePayDate(2026, time.November, 30), TerminationCode: "tls79"})
+	require.NoError(t, err)
+	assert.Equal(t, "TLS79", ended.TerminationCode)
+	updated := repo.Employees[emp.ID]
+	require.NotNil(t, updated.EndDate)
+	assert.Equal(t, leavePayDate(2026, time.November, 30), *updated.EndDate)
+	assert.False(t, updated.IsActive)
+
+	_, err = record(CreateEmploymentEventRequest{EventType: EmploymentEventWorkingTimeChange, EventDate: leavePayDate(2026, time.December, 1), WorkingTimeRatio: decimal.NewFromInt(1)})
+	assert.EqualError(t, err, "employee is not registered as employed")
+	_, err = record(CreateEmploymentEventRequest{EventType: EmploymentEventStart, EventDate: leavePayDate(2026, time.December, 1)})
+	assert.EqualError(t, err, "employee record has an end date; create a new employee record for a rehire")
+
+	history, err := service.ListEmploymentEvents(ctx, "tenant_test", "tenant-1", emp.ID)
+	require.NoError(t, err)
+	assert.Len(t, history, 5)
+
+	_, err = service.ListEmploymentEvents(ctx, "tenant_test", "tenant-1", "missing")
+	assert.EqualError(t, err, "employee not found")
+}
+
+func TestRecordEmploymentEventBackfillsLegacyStart(t *testing.T) {
+	repo := newEmploymentEventMockRepository()
+	repo.Employees["emp-legacy"] = &Employee{ID: "emp-legacy", TenantID: "tenant-1", FirstName: "Jaan", LastName: "Tamm", StartDate: leavePayDate(2024, time.June, 1), IsActive: true}
+	service := NewServiceWithRepository(repo, &DefaultUUIDGenerator{})
+
+	_, err := service.RecordEmploymentEvent(context.Background(), "tenant_test", "tenant-1", "emp-legacy", "", &CreateEmploymentEventRequest{
+		EventType: EmploymentEventStart,
+		EventDate: leavePayDate(2024, time.June, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.events, 1)
+	assert.Equal(t, "1", repo.events[0].WorkingTimeRatio.String())
+
+	_, err = NewServiceWithRepository(NewMockRepository(), &DefaultUUIDGenerator{}).RecordEmploymentEvent(context.Background(), "tenant_test", "tenant-1", "emp-legacy", "", &CreateEmploymentEventRequest{})
+	assert.ErrorIs(t, err, ErrEmploymentEventsUnavailable)
+}
+
+func TestExportEmploymentRegisterCSV(t *testing.T) {
+	service, repo, emp := setupEmploymentRegisterService(t)
+	ctx := context.Background()
+	_, err := service.RecordEmploymentEvent(ctx, "tenant_test", "tenant-1", emp.ID, "", &CreateEmploymentEventRequest{
+		EventType:       EmploymentEventEnd,
+		EventDate:       leavePayDate(2026, time.February, 27),
+		TerminationCode: "TLS85",
+	})
+	require.NoError(t, err)
+
+	pending, err := service.ListPendingEmploymentRegisterEmployees(ctx, "tenant_test", "tenant-1")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "Mari Maasikas", pending[0].EmployeeName)
+	assert.Len(t, pending[0].Events, 2)
+
+	export, err := service.ExportEmploymentRegisterCSV(ctx, "tenant_test", "tenant-1", &ExportEmploymentRegisterRequest{
+		From: leavePayDate(2026, time.January, 1),
+		To:   leavePayDate(2026, time.January, 31),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "TOR_20260101_20260131.csv", export.FileName)
+	assert.Equal(t, 1, export.EventCount)
+	lines := strings.Split(strings.TrimSpace(string(export.Content)), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "row_number;personal_code;first_name;last_name;action;event_date;employment_type;position;working_time_ratio;termination_code;suspension_reason", lines[0])
+	assert.Equal(t, "1;49001010001;Mari;Maasikas;START;2026-01-05;PART_TIME;Accountant;0.50;;", lines[1])
+	assert.NotNil(t, repo.events[0].ExportedAt)
+	assert.Nil(t, repo.events[1].ExportedAt)
+
+	_, err = service.ExportEmploymentRegisterCSV(ctx, "tenant_test", "tenant-1", &ExportEmploymentRegisterRequest{
+		From: leavePayDate(2026, time.January, 1),
+		To:   leavePayDate(2026, time.January, 31),
+	})
+	assert.EqualError(t, err, "no employment register events to export between 2026-01-01 and 2026-01-31")
+
+	again, err := service.ExportEmploymentRegisterCSV(ctx, "tenant_test", "tenant-1", &ExportEmploymentRegisterRequest{
+		From:            leavePayDate(2026, time.January, 1),
+		To:              leavePayDate(2026, time.February, 28),
+		IncludeExported: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, again.EventCount)
+	assert.Contains(t, string(again.Content), "2;49001010001;Mari;Maasikas;END;2026-02-27;PART_TIME;Accountant;0.50;TLS85;")
+
+	_, err = service.ExportEmploymentRegisterCSV(ctx, "tenant_test", "tenant-1", &ExportEmploymentRegisterRequest{From: leavePayDate(2026, time.February, 1)})
+	assert.EqualError(t, err, "from and to dates are required")
+	_, err = service.ExportEmploymentRegisterCSV(ctx, "tenant_test", "tenant-1", &ExportEmploymentRegisterRequest{From: leavePayDate(2026, time.February, 1), To: leavePayDate(2026, time.January, 1)})
+	assert.EqualError(t, err, "to date must be on or after from date")
+
+	repo.Employees[emp.ID].PersonalCode = ""
+	_, err = service.ExportEmploymentRegisterCSV(ctx, "tenant_test", "tenant-1", &ExportEmploymentRegisterRequest{
+		From:            leavePayDate(2026, time.January, 1),
+		To:              leavePayDate(2026, time.January, 31),
+		IncludeExported: true,
+	})
+	assert.EqualError(t, err, "employee Mari Maasikas has no personal code")
+}
+
+func TestEmploymentRegisterRemediationActions(t *testing.T) {
+	service, repo, emp := setupEmploymentRegisterService(t)
+	ctx := context.Background()
+
+	run, err := service.CreatePayrollRun(ctx, "tenant_test", "tenant-1", "user-1", &CreatePayrollRunRequest{PeriodYear: 2026, PeriodMonth: 1})
+	require.NoError(t, err)
+	var pendingAction *PayrollRunRemediationAction
+	for i := range run.RemediationActions {
+		if run.RemediationActions[i].Code == "employment_register_export_pending" {
+			pendingAction = &run.RemediationActions[i]
+		}
+	}
+	require.NotNil(t, pendingAction)
+	assert.Equal(t, "ACTION", pendingAction.Severity)
+	assert.Equal(t, "employee", pendingAction.EntityType)
+	assert.Equal(t, emp.ID, pendingAction.EntityID)
+	assert.Equal(t, "2026-01", pendingAction.Period)
+	assert.Equal(t, "oa employment-register export --from 2026-01-05 --to 2026-01-31 --output ./tor-2026-01.csv", pendingAction.CLICommand)
+
+	earlier := &PayrollRun{ID: "run-dec", PeriodYear: 2025, PeriodMonth: 12, Status: PayrollDraft}
+	pending, err := service.ListPendingEmploymentRegisterEmployees(ctx, "tenant_test", "tenant-1")
+	require.NoError(t, err)
+	assert.Empty(t, BuildEmploymentRegisterRemediationActions(earlier, pending))
+	declared := &PayrollRun{ID: "run-jan", PeriodYear: 2026, PeriodMonth: 1, Status: PayrollDeclared}
+	assert.Empty(t, BuildEmploymentRegisterRemediationActions(declared, pending))
+
+	now := time.Now()
+	repo.events[0].ExportedAt = &now
+	runs, err := service.ListPayrollRuns(ctx, "tenant_test", "tenant-1", 2026)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	for _, action := range runs[0].RemediationActions {
+		assert.NotEqual(t, "employment_register_export_pending", action.Code)
+	}
+}
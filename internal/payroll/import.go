@@ -72,6 +72,9 @@ var employeeImportHeaderAliases = map[string]string{
 	"base_salary":            "base_salary",
 	"salary":                 "base_salary",
 	"gross_salary":           "base_salary",
+	"hourly_rate":            "hourly_rate",
+	"hourly_wage":            "hourly_rate",
+	"tunnitasu":              "hourly_rate",
 	"salary_effective_from":  "salary_effective_from",
 	"effective_from":         "salary_effective_from",
 	"is_active":              "is_active",
@@ -165,7 +168,7 @@ func (s *Service) ImportEmployeesCSV(ctx context.Context, schemaName, tenantID s
 				uuid: s.uuid,
 			}
 
-			employee, err := txService.CreateEmployee(ctx, schemaName, tenantID, &record.createRequest)
+			employee, err := txService.createEmployee(ctx, schemaName, tenantID, &record.createRequest)
 			if err != nil {
 				return err
 			}
@@ -385,6 +388,18 @@ func buildEmployeeImportRecord(row employeeImportRow) (*employeeImportRecord, er
 		baseSalary = &parsed
 	}
 
+	hourlyRate := decimal.Zero
+	if value := strings.TrimSpace(row.values["hourly_rate"]); value != "" {
+		parsed, err := parseEmployeeImportDecimal(value, "hourly_rate")
+		if err != nil {
+			return nil, err
+		}
+		if parsed.IsNegative() {
+			return nil, fmt.Errorf("hourly_rate must be zero or greater")
+		}
+		hourlyRate = parsed
+	}
+
 	var salaryEffectiveFrom *time.Time
 	if value := strings.TrimSpace(row.values["salary_effective_from"]); value != "" {
 		if baseSalary == nil {
@@ -414,6 +429,7 @@ func buildEmployeeImportRecord(row employeeImportRow) (*employeeImportRecord, er
 			ApplyBasicExemption:  applyBasicExemption,
 			BasicExemptionAmount: basicExemptionAmount,
 			FundedPensionRate:    fundedPensionRate,
+			HourlyRate:           hourlyRate,
 		},
 		employeeName:   employeeImportDisplayName(firstName, lastName),
 		employeeNumber: strings.TrimSpace(row.values["employee_number"]),
@@ -21,9 +21,9 @@ func TestImportEmployeesCSV_Success(t *testing.T) {
 
 	result, err := service.ImportEmployeesCSV(ctx, "tenant_schema", "tenant-1", &ImportEmployeesRequest{
 		FileName: "employees.csv",
-		CSVContent: "employee_number,first_name,last_name,personal_code,email,start_date,employment_type,base_salary,salary_effective_from\n" +
-			"EMP-001,Mari,Maasikas,49001010001,mari@example.com,2026-01-15,FULL_TIME,3200.00,2026-01-15\n" +
-			"EMP-002,Juhan,Tamm,49001010002,juhan@example.com,2026-02-01,PART_TIME,,\n",
+		CSVContent: "employee_number,first_name,last_name,personal_code,email,start_date,employment_type,base_salary,salary_effective_from,hourly_rate\n" +
+			"EMP-001,Mari,Maasikas,49001010001,mari@example.com,2026-01-15,FULL_TIME,3200.00,2026-01-15,\n" +
+			"EMP-002,Juhan,Tamm,49001010002,juhan@example.com,2026-02-01,PART_TIME,,,14.50\n",
 	})
 	require.NoError(t, err)
 
@@ -40,6 +40,7 @@ func TestImportEmployeesCSV_Success(t *testing.T) {
 	assert.True(t, repo.Employees["emp-1"].ApplyBasicExemption)
 	assert.True(t, repo.Salaries["emp-1"].Equal(decimal.RequireFromString("3200.00")))
 	assert.Equal(t, EmploymentPartTime, repo.Employees["emp-3"].EmploymentType)
+	assert.Equal(t, "14.5", repo.Employees["emp-3"].HourlyRate.String())
 }
 
 func TestImportEmployeesCSV_SkipsDuplicatesAndInvalidRows(t *testing.T) {
@@ -163,27 +164,28 @@ func TestImportEmployeesCSV_ReportsExtendedValidationErrors(t *testing.T) {
 
 	result, err := service.ImportEmployeesCSV(ctx, "tenant_schema", "tenant-1", &ImportEmployeesRequest{
 		CSVContent: strings.Join([]string{
-			"first_name,last_name,start_date,employment_type,basic_exemption_amount,funded_pension_rate,end_date,is_active,base_salary,salary_effective_from",
-			",Missing,2026-01-01,FULL_TIME,,,,,,",
-			"Bad,Start,not-a-date,FULL_TIME,,,,,,",
-			"Bad,Type,2026-01-01,intern,,,,,,",
-			"Bad,BasicParse,2026-01-01,,not-a-decimal,,,,,",
-			"Bad,BasicNegative,2026-01-01,,-1,,,,,",
-			"Bad,PensionParse,2026-01-01,,,not-a-decimal,,,,",
-			"Bad,PensionNegative,2026-01-01,,,-0.01,,,,",
-			"Bad,EndParse,2026-01-01,,,,not-a-date,,,",
-			"Bad,Active,2026-01-01,,,,,maybe,,",
-			"Bad,SalaryParse,2026-01-01,,,,,,not-a-decimal,",
-			"Bad,SalaryEffectiveParse,2026-01-01,,,,,,1000,not-a-date",
+			"first_name,last_name,start_date,employment_type,basic_exemption_amount,funded_pension_rate,end_date,is_active,base_salary,salary_effective_from,hourly_rate",
+			",Missing,2026-01-01,FULL_TIME,,,,,,,",
+			"Bad,Start,not-a-date,FULL_TIME,,,,,,,",
+			"Bad,Type,2026-01-01,intern,,,,,,,",
+			"Bad,BasicParse,2026-01-01,,not-a-decimal,,,,,,",
+			"Bad,BasicNegative,2026-01-01,,-1,,,,,,",
+			"Bad,PensionParse,2026-01-01,,,not-a-decimal,,,,,",
+			"Bad,PensionNegative,2026-01-01,,,-0.01,,,,,",
+			"Bad,EndParse,2026-01-01,,,,not-a-date,,,,",
+			"Bad,Active,2026-01-01,,,,,maybe,,,",
+			"Bad,SalaryParse,2026-01-01,,,,,,not-a-decimal,,",
+			"Bad,SalaryEffectiveParse,2026-01-01,,,,,,1000,not-a-date,",
+			"Bad,HourlyNegative,2026-01-01,,,,,,,,-1",
 		}, "\n") + "\n",
 	})
 	require.NoError(t, err)
 
-	assert.Equal(t, 11, result.RowsProcessed)
+	assert.Equal(t, 12, result.RowsProcessed)
 	assert.Zero(t, result.EmployeesCreated)
 	assert.Zero(t, result.SalariesCreated)
-	assert.Equal(t, 11, result.RowsSkipped)
-	require.Len(t, result.Errors, 11)
+	assert.Equal(t, 12, result.RowsSkipped)
+	require.Len(t, result.Errors, 12)
 
 	expectedMessages := []string{
 		"first_name and last_name are required",
@@ -197,6 +199,7 @@ func TestImportEmployeesCSV_ReportsExtendedValidationErrors(t *testing.T) {
 		`invalid is_active "maybe"`,
 		"invalid base_salary",
 		"salary_effective_from must be in YYYY-MM-DD format",
+		"hourly_rate must be zero or greater",
 	}
 	for i, expected := range expectedMessages {
 		assert.Contains(t, result.Errors[i].Message, expected)
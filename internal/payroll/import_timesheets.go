@@ -0,0 +1,270 @@
+package payroll
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type timesheetImportRow struct {
+	rowNumber int
+	values    map[string]string
+}
+
+type timesheetImportRecord struct {
+	employee       *Employee
+	employeeName   string
+	employeeNumber string
+	request        CreateTimesheetEntryRequest
+}
+
+var timesheetImportHeaderAliases = map[string]string{
+	"employee_number":      "employee_number",
+	"employee_no":          "employee_number",
+	"employee_id":          "employee_number",
+	"personal_code":        "personal_code",
+	"isikukood":            "personal_code",
+	"email":                "email",
+	"name":                 "name",
+	"employee":             "name",
+	"first_name":           "first_name",
+	"last_name":            "last_name",
+	"work_date":            "work_date",
+	"date":                 "work_date",
+	"day":                  "work_date",
+	"kuupaev":              "work_date",
+	"regular_hours":        "regular_hours",
+	"hours":                "regular_hours",
+	"normal_hours":         "regular_hours",
+	"overtime_hours":       "overtime_hours",
+	"overtime":             "overtime_hours",
+	"ot_hours":             "overtime_hours",
+	"night_hours":          "night_hours",
+	"night":                "night_hours",
+	"holiday_hours":        "holiday_hours",
+	"holiday":              "holiday_hours",
+	"public_holiday_hours": "holiday_hours",
+	"notes":                "notes",
+	"description":          "notes",
+}
+
+var timesheetImportHourColumns = []string{"regular_hours", "overtime_hours", "night_hours", "holiday_hours"}
+
+// ImportTimesheetsCSV imports daily timesheet entries from CSV. Rows for days
+// that already have a pending entry replace it; approved days are rejected.
+func (s *Service) ImportTimesheetsCSV(ctx context.Context, schemaName, tenantID string, req *ImportTimesheetsRequest) (*ImportTimesheetsResult, error) {
+	timesheets, err := s.timesheetRepository()
+	if err != nil {
+		return nil, err
+	}
+	if req == nil || strings.TrimSpace(req.CSVContent) == "" {
+		return nil, fmt.Errorf("csv_content is required")
+	}
+
+	rows, err := parseTimesheetImportRows(req.CSVContent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no timesheet rows found in CSV")
+	}
+
+	employees, err := s.repo.ListEmployees(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list existing employees: %w", err)
+	}
+	employeeIndexes := buildPayrollHistoryEmployeeIndexes(employees)
+
+	result := &ImportTimesheetsResult{
+		FileName: req.FileName,
+		Errors:   []ImportTimesheetRowError{},
+	}
+	seen := make(map[string]int)
+
+	for _, row := range rows {
+		result.RowsProcessed++
+
+		record, err := buildTimesheetImportRecord(row, employeeIndexes)
+		if err != nil {
+			appendTimesheetRowError(result, row, nil, err.Error())
+			continue
+		}
+
+		key := record.employee.ID + "|" + record.request.WorkDate.Format("2006-01-02")
+		if previous, ok := seen[key]; ok {
+			appendTimesheetRowError(result, row, record, fmt.Sprintf("duplicate timesheet row; %s on %s is already on row %d", record.employeeName, record.request.WorkDate.Format("2006-01-02"), previous))
+			continue
+		}
+		seen[key] = row.rowNumber
+
+		_, created, err := s.saveTimesheetEntry(ctx, timesheets, schemaName, tenantID, record.employee, &record.request)
+		if err != nil {
+			appendTimesheetRowError(result, row, record, err.Error())
+			continue
+		}
+		if created {
+			result.EntriesCreated++
+		} else {
+			result.EntriesUpdated++
+		}
+	}
+
+	if len(result.Errors) == 0 {
+		result.Errors = nil
+	}
+
+	return result, nil
+}
+
+func parseTimesheetImportRows(content string) ([]timesheetImportRow, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(content), "\ufeff")
+	if trimmed == "" {
+		return nil, fmt.Errorf("csv_content is required")
+	}
+
+	reader := csv.NewReader(strings.NewReader(trimmed))
+	reader.Comma = detectEmployeeImportDelimiter(trimmed)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("parse csv header: %w", err)
+	}
+
+	canonicalHeaders := make([]string, len(headers))
+	hasWorkDate := false
+	hasHours := false
+	for i, header := range headers {
+		canonicalHeaders[i] = canonicalTimesheetImportHeader(header)
+		switch canonicalHeaders[i] {
+		case "work_date":
+			hasWorkDate = true
+		case "regular_hours", "overtime_hours", "night_hours", "holiday_hours":
+			hasHours = true
+		}
+	}
+
+	if !hasWorkDate {
+		return nil, fmt.Errorf("missing required work_date column")
+	}
+	if !hasHours {
+		return nil, fmt.Errorf("missing required regular_hours, overtime_hours, night_hours, or holiday_hours column")
+	}
+
+	rows := make([]timesheetImportRow, 0)
+	rowNumber := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("parse csv row %d: %w", rowNumber+1, err)
+		}
+
+		rowNumber++
+		rowValues := make(map[string]string, len(canonicalHeaders))
+		isBlank := true
+		for i, header := range canonicalHeaders {
+			if header == "" {
+				continue
+			}
+			value := ""
+			if i < len(record) {
+				value = strings.TrimSpace(record[i])
+			}
+			if value != "" {
+				isBlank = false
+			}
+			rowValues[header] = value
+		}
+		if isBlank {
+			continue
+		}
+		rows = append(rows, timesheetImportRow{
+			rowNumber: rowNumber,
+			values:    rowValues,
+		})
+	}
+
+	return rows, nil
+}
+
+func buildTimesheetImportRecord(row timesheetImportRow, employeeIndexes *payrollHistoryEmployeeIndexes) (*timesheetImportRecord, error) {
+	employee, employeeName, err := findPayrollHistoryEmployee(row.values, employeeIndexes)
+	if err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(row.values["work_date"]) == "" {
+		return nil, fmt.Errorf("work_date is required")
+	}
+	workDate, err := parseEmployeeImportDate(row.values["work_date"], "work_date")
+	if err != nil {
+		return nil, err
+	}
+
+	hours := make(map[string]decimal.Decimal, len(timesheetImportHourColumns))
+	for _, column := range timesheetImportHourColumns {
+		value, err := parseOptionalPayrollHistoryDecimal(row.values[column], column)
+		if err != nil {
+			return nil, err
+		}
+		hours[column] = value
+	}
+
+	request := CreateTimesheetEntryRequest{
+		EmployeeID:    employee.ID,
+		WorkDate:      workDate,
+		RegularHours:  hours["regular_hours"],
+		OvertimeHours: hours["overtime_hours"],
+		NightHours:    hours["night_hours"],
+		HolidayHours:  hours["holiday_hours"],
+		Notes:         strings.TrimSpace(row.values["notes"]),
+	}
+	if err := validateTimesheetHours(&request); err != nil {
+		return nil, err
+	}
+
+	employeeNumber := strings.TrimSpace(row.values["employee_number"])
+	if employeeNumber == "" {
+		employeeNumber = employee.EmployeeNumber
+	}
+
+	return &timesheetImportRecord{
+		employee:       employee,
+		employeeName:   employeeName,
+		employeeNumber: employeeNumber,
+		request:        request,
+	}, nil
+}
+
+func appendTimesheetRowError(result *ImportTimesheetsResult, row timesheetImportRow, record *timesheetImportRecord, message string) {
+	result.RowsSkipped++
+	rowError := ImportTimesheetRowError{
+		Row:            row.rowNumber,
+		WorkDate:       strings.TrimSpace(row.values["work_date"]),
+		EmployeeName:   payrollHistoryImportEmployeeName(row.values),
+		EmployeeNumber: strings.TrimSpace(row.values["employee_number"]),
+		Message:        message,
+	}
+	if record != nil {
+		rowError.WorkDate = record.request.WorkDate.Format("2006-01-02")
+		rowError.EmployeeName = record.employeeName
+		rowError.EmployeeNumber = record.employeeNumber
+	}
+	result.Errors = append(result.Errors, rowError)
+}
+
+func canonicalTimesheetImportHeader(header string) string {
+	normalized := strings.ToLower(strings.TrimSpace(header))
+	if canonical, ok := timesheetImportHeaderAliases[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
@@ -0,0 +1,354 @@
+package payroll
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Leave pay follows the Estonian average earnings rules: the daily rate is the
+// pay of the six calendar months before the month the leave starts, divided by
+// the calendar days of those months excluding public holidays.
+const averageEarningsMonths = 6
+
+// Employer-paid sick days are calendar days 4 to 8 of an illness, compensated
+// at 70% of average earnings. The Health Insurance Fund pays from day 9.
+var SickPayRate = decimal.NewFromFloat(0.70)
+
+const (
+	sickPayFirstDay = 4
+	sickPayLastDay  = 8
+)
+
+// Average earnings sources
+const (
+	AverageEarningsSourcePayslips = "PAYSLIPS"
+	AverageEarningsSourceSalary   = "AGREED_SALARY"
+)
+
+// PayslipComponent is one pay line of a payslip. PaymentType is the TSD
+// payment type code the line is declared under.
+type PayslipComponent struct {
+	ID            string          `json:"id"`
+	TenantID      string          `json:"tenant_id"`
+	PayslipID     string          `json:"payslip_id"`
+	ComponentType string          `json:"component_type"`
+	Name          string          `json:"name"`
+	PaymentType   string          `json:"payment_type"`
+	LeaveRecordID *string         `json:"leave_record_id,omitempty"`
+	Days          decimal.Decimal `json:"days"`
+	DailyRate     decimal.Decimal `json:"daily_rate"`
+	Hours         decimal.Decimal `json:"hours"`
+	HourlyRate    decimal.Decimal `json:"hourly_rate"`
+	Amount        decimal.Decimal `json:"amount"`
+	SortOrder     int             `json:"sort_order"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// EarningsHistoryEntry is one finalized historical payslip. AverageBasedPay is
+// the part of the gross that was itself paid from average earnings and is
+// left out of later averages.
+type EarningsHistoryEntry struct {
+	PayrollRunID    string          `json:"payroll_run_id"`
+	PeriodYear      int             `json:"period_year"`
+	PeriodMonth     int             `json:"period_month"`
+	GrossSalary     decimal.Decimal `json:"gross_salary"`
+	AverageBasedPay decimal.Decimal `json:"average_based_pay"`
+}
+
+// AverageEarnings is an employee's average daily earnings for leave starting
+// on ReferenceDate. Without finalized payslips in the period the agreed
+// monthly salary is used.
+type AverageEarnings struct {
+	EmployeeID           string                 `json:"employee_id"`
+	ReferenceDate        time.Time              `json:"reference_date"`
+	PeriodStart          time.Time              `json:"period_start"`
+	PeriodEnd            time.Time              `json:"period_end"`
+	Source               string                 `json:"source"`
+	PayslipCount         int                    `json:"payslip_count"`
+	TotalEarnings        decimal.Decimal        `json:"total_earnings"`
+	CalendarDays         int                    `json:"calendar_days"`
+	AverageDailyEarnings decimal.Decimal        `json:"average_daily_earnings"`
+	History              []EarningsHistoryEntry `json:"history,omitempty"`
+}
+
+type leavePayRule struct {
+	componentType string
+	paymentType   string
+	rate          decimal.Decimal
+	deductSalary  bool
+}
+
+// leavePayRuleFor maps an absence type to its pay treatment. Annual and study
+// leave replace salary with average earnings, sick leave pays employer sick
+// days, and other unpaid or salary-affecting absences only reduce salary.
+func leavePayRuleFor(absenceType *AbsenceType) leavePayRule {
+	if absenceType == nil {
+		return leavePayRule{}
+	}
+	switch absenceType.Code {
+	case "ANNUAL_LEAVE", "STUDY_LEAVE":
+		return leavePayRule{componentType: SalaryComponentVacationPay, paymentType: PaymentTypeVacationPay, rate: decimal.NewFromInt(1), deductSalary: true}
+	case "SICK_LEAVE":
+		return leavePayRule{componentType: SalaryComponentSickPay, paymentType: PaymentTypeSickPay, rate: SickPayRate, deductSalary: true}
+	case "COMP_TIME":
+		return leavePayRule{}
+	}
+	return leavePayRule{deductSalary: !absenceType.IsPaid || absenceType.AffectsSalary}
+}
+
+// CalculateAverageEarnings returns the employee's average daily earnings for
+// leave starting on referenceDate, using finalized payslips including imported
+// payroll history.
+func (s *Service) CalculateAverageEarnings(ctx context.Context, schemaName, tenantID, employeeID string, referenceDate time.Time) (*AverageEarnings, error) {
+	leavePay, ok := s.repo.(LeavePayRepository)
+	if !ok {
+		return nil, fmt.Errorf("average earnings are unavailable")
+	}
+	if referenceDate.IsZero() {
+		return nil, fmt.Errorf("reference date is required")
+	}
+	emp, err := s.GetEmployee(ctx, schemaName, tenantID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+	return averageEarnings(ctx, s.repo, leavePay, schemaName, tenantID, emp, referenceDate)
+}
+
+func averageEarnings(ctx context.Context, repo Repository, leavePay LeavePayRepository, schemaName, tenantID string, emp *Employee, referenceDate time.Time) (*AverageEarnings, error) {
+	reference := dateOnly(referenceDate)
+	periodEnd := time.Date(reference.Year(), reference.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodStart := periodEnd.AddDate(0, -averageEarningsMonths, 0)
+
+	history, err := leavePay.ListEarningsHistory(ctx, schemaName, tenantID, emp.ID, periodStart, periodEnd)
+	if err != nil {
+		return nil, fmt.Errorf("list earnings history: %w", err)
+	}
+
+	result := &AverageEarnings{
+		EmployeeID:    emp.ID,
+		ReferenceDate: reference,
+		PeriodStart:   periodStart,
+		PeriodEnd:     periodEnd.AddDate(0, 0, -1),
+		Source:        AverageEarningsSourcePayslips,
+		History:       history,
+	}
+	for _, entry := range history {
+		result.TotalEarnings = result.TotalEarnings.Add(entry.GrossSalary.Sub(entry.AverageBasedPay))
+	}
+	result.PayslipCount = len(history)
+
+	countFrom := periodStart
+	if start := dateOnly(emp.StartDate); start.After(countFrom) {
+		countFrom = start
+	}
+	if len(history) == 0 || !countFrom.Before(periodEnd) {
+		salary, err := repo.GetCurrentSalary(ctx, schemaName, tenantID, emp.ID)
+		if err != nil {
+			return nil, fmt.Errorf("get current salary: %w", err)
+		}
+		result.Source = AverageEarningsSourceSalary
+		result.PayslipCount = 0
+		result.History = nil
+		result.TotalEarnings = salary.Mul(decimal.NewFromInt(averageEarningsMonths))
+		countFrom = periodStart
+	}
+
+	result.CalendarDays = calendarDaysExcludingHolidays(countFrom, periodEnd.AddDate(0, 0, -1))
+	if result.CalendarDays > 0 {
+		result.AverageDailyEarnings = result.TotalEarnings.Div(decimal.NewFromInt(int64(result.CalendarDays))).Round(4)
+	}
+	return result, nil
+}
+
+// buildLeavePayComponents returns the pay lines for one employee's payroll
+// period: the salary, deductions for absent working days, and leave pay from
+// average earnings. periodEnd is exclusive.
+func buildLeavePayComponents(salary decimal.Decimal, periodStart, periodEnd time.Time, leaves []LeaveRecord, average func(LeaveRecord) (*AverageEarnings, error)) ([]PayslipComponent, error) {
+	components := []PayslipComponent{}
+	if salary.IsPositive() {
+		components = append(components, baseSalaryPayslipComponent(salary))
+	}
+
+	lastDay := periodEnd.AddDate(0, 0, -1)
+	monthWorkingDays := workingDays(periodStart, lastDay)
+	dailySalary := decimal.Zero
+	if monthWorkingDays > 0 {
+		dailySalary = salary.Div(decimal.NewFromInt(int64(monthWorkingDays)))
+	}
+
+	sorted := append([]LeaveRecord(nil), leaves...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].StartDate.Before(sorted[j].StartDate)
+	})
+
+	deducted := decimal.Zero
+	for _, leave := range sorted {
+		if leave.AbsenceType == nil {
+			continue
+		}
+		rule := leavePayRuleFor(leave.AbsenceType)
+		from := laterDate(dateOnly(leave.StartDate), periodStart)
+		to := earlierDate(dateOnly(leave.EndDate), lastDay)
+		if to.Before(from) {
+			continue
+		}
+		leaveID := leave.ID
+		name := leave.AbsenceType.Name
+
+		if rule.deductSalary {
+			days := workingDays(from, to)
+			amount := dailySalary.Mul(decimal.NewFromInt(int64(days))).Round(2)
+			if remaining := salary.Sub(deducted); amount.GreaterThan(remaining) {
+				amount = remaining
+			}
+			if amount.IsPositive() {
+				deducted = deducted.Add(amount)
+				components = append(components, PayslipComponent{
+					ComponentType: SalaryComponentAbsenceDeduction,
+					Name:          name,
+					PaymentType:   PaymentTypeSalary,
+					LeaveRecordID: &leaveID,
+					Days:          decimal.NewFromInt(int64(days)),
+					DailyRate:     dailySalary.Round(4),
+					Amount:        amount.Neg(),
+				})
+			}
+		}
+
+		if rule.componentType == "" {
+			continue
+		}
+		days := 0
+		if rule.componentType == SalaryComponentSickPay {
+			days = sickPayDays(dateOnly(leave.StartDate), from, to)
+		} else {
+			days = calendarDaysExcludingHolidays(from, to)
+		}
+		if days == 0 {
+			continue
+		}
+		avg, err := average(leave)
+		if err != nil {
+			return nil, err
+		}
+		dailyRate := avg.AverageDailyEarnings.Mul(rule.rate).Round(4)
+		components = append(components, PayslipComponent{
+			ComponentType: rule.componentType,
+			Name:          name,
+			PaymentType:   rule.paymentType,
+			LeaveRecordID: &leaveID,
+			Days:          decimal.NewFromInt(int64(days)),
+			DailyRate:     dailyRate,
+			Amount:        dailyRate.Mul(decimal.NewFromInt(int64(days))).Round(2),
+		})
+	}
+	return components, nil
+}
+
+func baseSalaryPayslipComponent(salary decimal.Decimal) PayslipComponent {
+	return PayslipComponent{
+		ComponentType: SalaryComponentBaseSalary,
+		Name:          defaultSalaryComponentName(SalaryComponentBaseSalary),
+		PaymentType:   PaymentTypeSalary,
+		Amount:        salary,
+	}
+}
+
+// sickPayDays counts the employer-paid illness days (days 4 to 8 counted from
+// illnessStart) that fall between from and to inclusive.
+func sickPayDays(illnessStart, from, to time.Time) int {
+	count := 0
+	for day := sickPayFirstDay; day <= sickPayLastDay; day++ {
+		date := illnessStart.AddDate(0, 0, day-1)
+		if !date.Before(from) && !date.After(to) {
+			count++
+		}
+	}
+	return count
+}
+
+func sumPayslipComponents(components []PayslipComponent) decimal.Decimal {
+	total := decimal.Zero
+	for _, component := range components {
+		total = total.Add(component.Amount)
+	}
+	return total
+}
+
+func calendarDaysExcludingHolidays(from, to time.Time) int {
+	count := 0
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if !isEstonianPublicHoliday(date) {
+			count++
+		}
+	}
+	return count
+}
+
+func workingDays(from, to time.Time) int {
+	count := 0
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		if date.Weekday() != time.Saturday && date.Weekday() != time.Sunday && !isEstonianPublicHoliday(date) {
+			count++
+		}
+	}
+	return count
+}
+
+// isEstonianPublicHoliday reports whether date is a public holiday (riigipüha)
+// under the Public Holidays and Days of National Importance Act.
+func isEstonianPublicHoliday(date time.Time) bool {
+	switch {
+	case date.Month() == time.January && date.Day() == 1,
+		date.Month() == time.February && date.Day() == 24,
+		date.Month() == time.May && date.Day() == 1,
+		date.Month() == time.June && (date.Day() == 23 || date.Day() == 24),
+		date.Month() == time.August && date.Day() == 20,
+		date.Month() == time.December && date.Day() >= 24 && date.Day() <= 26:
+		return true
+	}
+	easter := easterSunday(date.Year())
+	day := dateOnly(date)
+	return day.Equal(easter.AddDate(0, 0, -2)) || day.Equal(easter) || day.Equal(easter.AddDate(0, 0, 49))
+}
+
+// easterSunday returns Western Easter Sunday using the anonymous Gregorian algorithm.
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+func dateOnly(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func laterDate(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlierDate(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,234 @@
+package payroll
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type leavePayMockRepository struct {
+	*MockRepository
+	history    map[string][]EarningsHistoryEntry
+	leaves     []LeaveRecord
+	components []PayslipComponent
+}
+
+func newLeavePayMockRepository() *leavePayMockRepository {
+	return &leavePayMockRepository{
+		MockRepository: NewMockRepository(),
+		history:        make(map[string][]EarningsHistoryEntry),
+	}
+}
+
+func (m *leavePayMockRepository) WithTransaction(ctx context.Context, fn func(txRepo Repository) error) error {
+	return fn(m)
+}
+
+func (m *leavePayMockRepository) ListEarningsHistory(ctx context.Context, schemaName, tenantID, employeeID string, from, to time.Time) ([]EarningsHistoryEntry, error) {
+	result := []EarningsHistoryEntry{}
+	for _, entry := range m.history[employeeID] {
+		period := time.Date(entry.PeriodYear, time.Month(entry.PeriodMonth), 1, 0, 0, 0, 0, time.UTC)
+		if !period.Before(from) && period.Before(to) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (m *leavePayMockRepository) ListApprovedLeave(ctx context.Context, schemaName, tenantID string, from, to time.Time) ([]LeaveRecord, error) {
+	result := []LeaveRecord{}
+	for _, leave := range m.leaves {
+		if leave.TenantID == tenantID && leave.Status == LeaveApproved && leave.StartDate.Before(to) && !leave.EndDate.Before(from) {
+			result = append(result, leave)
+		}
+	}
+	return result, nil
+}
+
+func (m *leavePayMockRepository) CreatePayslipComponents(ctx context.Context, schemaName string, components []PayslipComponent) error {
+	m.components = append(m.components, components...)
+	return nil
+}
+
+func (m *leavePayMockRepository) ListPayslipComponents(ctx context.Context, schemaName, tenantID, runID string) ([]PayslipComponent, error) {
+	payslipIDs := make(map[string]bool)
+	for _, payslip := range m.Payslips {
+		if payslip.PayrollRunID == runID {
+			payslipIDs[payslip.ID] = true
+		}
+	}
+	result := []PayslipComponent{}
+	for _, component := range m.components {
+		if component.TenantID == tenantID && payslipIDs[component.PayslipID] {
+			result = append(result, component)
+		}
+	}
+	return result, nil
+}
+
+func leavePayDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func setupLeavePayService(t *testing.T) (*Service, *leavePayMockRepository) {
+	t.Helper()
+	repo := newLeavePayMockRepository()
+	repo.Employees["emp-1"] = &Employee{
+		ID:             "emp-1",
+		TenantID:       "tenant-1",
+		FirstName:      "Mari",
+		LastName:       "Maasikas",
+		PersonalCode:   "48001010000",
+		StartDate:      leavePayDate(2024, time.January, 1),
+		EmploymentType: EmploymentFullTime,
+		IsActive:       true,
+	}
+	repo.Salaries["emp-1"] = decimal.NewFromInt(2000)
+	for month := 9; month <= 14; month++ {
+		year, periodMonth := 2025, month
+		if month > 12 {
+			year, periodMonth = 2026, month-12
+		}
+		repo.history["emp-1"] = append(repo.history["emp-1"], EarningsHistoryEntry{
+			PeriodYear:  year,
+			PeriodMonth: periodMonth,
+			GrossSalary: decimal.NewFromInt(2000),
+		})
+	}
+	// A previous vacation pay line is excluded from the average.
+	repo.history["emp-1"][2].GrossSalary = decimal.NewFromInt(2300)
+	repo.history["emp-1"][2].AverageBasedPay = decimal.NewFromInt(300)
+
+	return NewServiceWithRepository(repo, &DefaultUUIDGenerator{}), repo
+}
+
+func TestIsEstonianPublicHoliday(t *testing.T) {
+	assert.True(t, isEstonianPublicHoliday(leavePayDate(2026, time.February, 24)))
+	assert.True(t, isEstonianPublicHoliday(leavePayDate(2026, time.April, 3)), "Good Friday")
+	assert.True(t, isEstonianPublicHoliday(leavePayDate(2026, time.April, 5)), "Easter Sunday")
+	assert.True(t, isEstonianPublicHoliday(leavePayDate(2026, time.May, 24)), "Pentecost")
+	assert.True(t, isEstonianPublicHoliday(leavePayDate(2025, time.December, 24)))
+	assert.False(t, isEstonianPublicHoliday(leavePayDate(2026, time.April, 6)))
+	assert.Equal(t, leavePayDate(2025, time.April, 20), easterSunday(2025))
+	assert.Equal(t, 22, workingDays(leavePayDate(2026, time.March, 1), leavePayDate(2026, time.March, 31)))
+}
+
+func TestCalculateAverageEarnings(t *testing.T) {
+	service, repo := setupLeavePayService(t)
+
+	avg, err := service.CalculateAverageEarnings(context.Background(), "tenant_test", "tenant-1", "emp-1", leavePayDate(2026, time.March, 9))
+	require.NoError(t, err)
+	assert.Equal(t, AverageEarningsSourcePayslips, avg.Source)
+	assert.Equal(t, leavePayDate(2025, time.September, 1), avg.PeriodStart)
+	assert.Equal(t, leavePayDate(2026, time.February, 28), avg.PeriodEnd)
+	assert.Equal(t, 6, avg.PayslipCount)
+	assert.Equal(t, "12000", avg.TotalEarnings.String())
+	// 181 calendar days minus Dec 24-26, Jan 1 and Feb 24.
+	assert.Equal(t, 176, avg.CalendarDays)
+	assert.Equal(t, "68.1818", avg.AverageDailyEarnings.String())
+
+	repo.history["emp-1"] = nil
+	avg, err = service.CalculateAverageEarnings(context.Background(), "tenant_test", "tenant-1", "emp-1", leavePayDate(2026, time.March, 9))
+	require.NoError(t, err)
+	assert.Equal(t, AverageEarningsSourceSalary, avg.Source)
+	assert.Equal(t, "12000", avg.TotalEarnings.String())
+
+	_, err = service.CalculateAverageEarnings(context.Background(), "tenant_test", "tenant-1", "emp-1", time.Time{})
+	assert.EqualError(t, err, "reference date is required")
+
+	_, err = NewServiceWithRepository(NewMockRepository(), &DefaultUUIDGenerator{}).CalculateAverageEarnings(context.Background(), "tenant_test", "tenant-1", "emp-1", leavePayDate(2026, time.March, 9))
+	assert.EqualError(t, err, "average earnings are unavailable")
+}
+
+func TestSickPayDays(t *testing.T) {
+	start := leavePayDate(2026, time.March, 28)
+	// Days 4-8 are March 31 to April 4.
+	assert.Equal(t, 1, sickPayDays(start, start, leavePayDate(2026, time.March, 31)))
+	assert.Equal(t, 4, sickPayDays(start, leavePayDate(2026, time.April, 1), leavePayDate(2026, time.April, 10)))
+	assert.Equal(t, 0, sickPayDays(start, start, leavePayDate(2026, time.March, 29)))
+}
+
+func TestCalculatePayrollWithLeavePay(t *testing.T) {
+	service, repo := setupLeavePayService(t)
+	ctx := context.Background()
+	repo.PayrollRuns["run-1"] = &PayrollRun{ID: "run-1", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 3, Status: PayrollDraft}
+	annualLeave := &AbsenceType{ID: "type-annual", Code: "ANNUAL_LEAVE", Name: "Annual Leave", IsPaid: true}
+	sickLeave := &AbsenceType{ID: "type-sick", Code: "SICK_LEAVE", Name: "Sick Leave", IsPaid: true, AffectsSalary: true}
+	repo.leaves = []LeaveRecord{
+		{ID: "leave-1", TenantID: "tenant-1", EmployeeID: "emp-1", StartDate: leavePayDate(2026, time.March, 9), EndDate: leavePayDate(2026, time.March, 15), Status: LeaveApproved, AbsenceType: annualLeave},
+		{ID: "leave-2", TenantID: "tenant-1", EmployeeID: "emp-1", StartDate: leavePayDate(2026, time.March, 20), EndDate: leavePayDate(2026, time.March, 31), Status: LeaveApproved, AbsenceType: sickLeave},
+		{ID: "leave-3", TenantID: "tenant-1", EmployeeID: "emp-1", StartDate: leavePayDate(2026, time.March, 2), EndDate: leavePayDate(2026, time.March, 3), Status: LeavePending, AbsenceType: annualLeave},
+	}
+
+	run, err := service.CalculatePayroll(ctx, "tenant_test", "tenant-1", "run-1")
+	require.NoError(t, err)
+	require.Len(t, run.Payslips, 1)
+	payslip := run.Payslips[0]
+
+	require.Len(t, payslip.Components, 5)
+	byType := make(map[string][]PayslipComponent)
+	for _, component := range payslip.Components {
+		byType[component.ComponentType] = append(byType[component.ComponentType], component)
+	}
+	assert.Equal(t, "2000", byType[SalaryComponentBaseSalary][0].Amount.String())
+	require.Len(t, byType[SalaryComponentAbsenceDeduction], 2)
+	// 5 and 8 of 22 March working days.
+	assert.Equal(t, "-454.55", byType[SalaryComponentAbsenceDeduction][0].Amount.String())
+	assert.Equal(t, "-727.27", byType[SalaryComponentAbsenceDeduction][1].Amount.String())
+
+	vacation := byType[SalaryComponentVacationPay][0]
+	assert.Equal(t, PaymentTypeVacationPay, vacation.PaymentType)
+	assert.Equal(t, "7", vacation.Days.String())
+	assert.Equal(t, "68.1818", vacation.DailyRate.String())
+	assert.Equal(t, "477.27", vacation.Amount.String())
+	require.NotNil(t, vacation.LeaveRecordID)
+	assert.Equal(t, "leave-1", *vacation.LeaveRecordID)
+
+	sick := byType[SalaryComponentSickPay][0]
+	assert.Equal(t, PaymentTypeSickPay, sick.PaymentType)
+	assert.Equal(t, "5", sick.Days.String())
+	assert.Equal(t, "47.7273", sick.DailyRate.String())
+	assert.Equal(t, "238.64", sick.Amount.String())
+
+	assert.Equal(t, "1534.09", payslip.GrossSalary.String())
+	assert.Len(t, repo.components, 5)
+
+	repo.PayrollRuns["run-1"].Status = PayrollApproved
+	tsd, err := service.GenerateTSD(ctx, "tenant_test", "tenant-1", "run-1")
+	require.NoError(t, err)
+	require.Len(t, tsd.Rows, 3)
+	gross := make(map[string]string)
+	incomeTax := decimal.Zero
+	for _, row := range tsd.Rows {
+		gross[row.PaymentType] = row.GrossPayment.String()
+		incomeTax = incomeTax.Add(row.IncomeTax)
+	}
+	assert.Equal(t, map[string]string{
+		PaymentTypeSalary:      "818.18",
+		PaymentTypeVacationPay: "477.27",
+		PaymentTypeSickPay:     "238.64",
+	}, gross)
+	assert.True(t, payslip.IncomeTax.Equal(incomeTax))
+	assert.True(t, payslip.GrossSalary.Equal(tsd.TotalPayments))
+}
+
+func TestBuildLeavePayComponentsUnpaidLeave(t *testing.T) {
+	unpaid := &AbsenceType{Code: "UNPAID", Name: "Unpaid Leave"}
+	leaves := []LeaveRecord{{
+		ID:          "leave-1",
+		StartDate:   leavePayDate(2026, time.February, 20),
+		EndDate:     leavePayDate(2026, time.April, 30),
+		AbsenceType: unpaid,
+	}}
+	components, err := buildLeavePayComponents(decimal.NewFromInt(2000), leavePayDate(2026, time.March, 1), leavePayDate(2026, time.April, 1), leaves, func(LeaveRecord) (*AverageEarnings, error) {
+		t.Fatal("unpaid leave must not use average earnings")
+		return nil, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, components, 2)
+	assert.True(t, sumPayslipComponents(components).IsZero())
+}
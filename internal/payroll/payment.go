@@ -0,0 +1,368 @@
+package payroll
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/banking"
+	"github.com/HMB-research/open-accounting/internal/payments"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+	"github.com/shopspring/decimal"
+)
+
+// SourceTypePayrollPayment marks journal entries posted for payroll payment files.
+const SourceTypePayrollPayment = "PAYROLL_PAYMENT"
+
+// Default creditor for payroll tax transfers: the Tax and Customs Board
+// prepayment account at Swedbank. Other EMTA bank accounts can be requested.
+const (
+	TaxBoardCreditorName = "Maksu- ja Tolliamet"
+	TaxBoardIBAN         = "EE932200221023778606"
+)
+
+type payrollBankAccounts interface {
+	GetBankAccount(ctx context.Context, schemaName, tenantID, accountID string) (*banking.BankAccount, error)
+}
+
+type payrollPaymentRecorder interface {
+	Create(ctx context.Context, tenantID, schemaName string, req *payments.CreatePaymentRequest) (*payments.Payment, error)
+}
+
+type payrollTenantLookup interface {
+	GetTenant(ctx context.Context, tenantID string) (*tenant.Tenant, error)
+}
+
+// PayrollPayment records one salary payment file generated from a payroll run.
+// PaymentID is the outgoing payment that the bank debit is matched against.
+type PayrollPayment struct {
+	ID             string          `json:"id"`
+	TenantID       string          `json:"tenant_id"`
+	PayrollRunID   string          `json:"payroll_run_id"`
+	BankAccountID  *string         `json:"bank_account_id,omitempty"`
+	PaymentID      *string         `json:"payment_id,omitempty"`
+	JournalEntryID *string         `json:"journal_entry_id,omitempty"`
+	ExecutionDate  time.Time       `json:"execution_date"`
+	MessageID      string          `json:"message_id"`
+	NetAmount      decimal.Decimal `json:"net_amount"`
+	TaxAmount      decimal.Decimal `json:"tax_amount"`
+	TaxReference   string          `json:"tax_reference,omitempty"`
+	PayslipCount   int             `json:"payslip_count"`
+	CreatedBy      *string         `json:"created_by,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// TotalAmount returns the amount debited from the paying bank account.
+func (p *PayrollPayment) TotalAmount() decimal.Decimal {
+	return p.NetAmount.Add(p.TaxAmount)
+}
+
+// PayPayrollRunRequest selects the paying bank account and whether the TSD tax
+// total is transferred in the same file.
+type PayPayrollRunRequest struct {
+	BankAccountID     string `json:"bank_account_id"`
+	IncludeTaxPayment bool   `json:"include_tax_payment,omitempty"`
+	TaxAccountIBAN    string `json:"tax_account_iban,omitempty"`
+}
+
+// PayPayrollRunResult returns the recorded payroll payment, the outgoing
+// payment to reconcile against and the SEPA pain.001 file for bank upload.
+type PayPayrollRunResult struct {
+	PayrollPayment *PayrollPayment            `json:"payroll_payment"`
+	Payment        *payments.Payment          `json:"payment"`
+	SEPA           *payments.SEPAExportResult `json:"sepa"`
+}
+
+type payrollPaymentAmounts struct {
+	accountID string
+	amount    decimal.Decimal
+}
+
+// ListPayrollPayments returns the payment files generated for a payroll run.
+func (s *Service) ListPayrollPayments(ctx context.Context, schemaName, tenantID, runID string) ([]PayrollPayment, error) {
+	if s.payrollPayments == nil {
+		return nil, fmt.Errorf("payroll payments are unavailable")
+	}
+	if _, err := s.GetPayrollRun(ctx, schemaName, tenantID, runID); err != nil {
+		return nil, err
+	}
+	paid, err := s.payrollPayments.ListPayrollPayments(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, fmt.Errorf("list payroll payments: %w", err)
+	}
+	return paid, nil
+}
+
+// PayPayrollRun builds a SEPA credit-transfer file for the unpaid net salaries
+// of an approved payroll run on the run's payment date, optionally adding the
+// TSD tax total transfer to the Tax and Customs Board with the tenant's
+// prepayment reference. The paid payslips are linked to the recorded payment and
+// an outgoing payment is created for bank reconciliation. When payroll posting
+// accounts are configured, the net-pay and tax liabilities are cleared against
+// the bank account's ledger account. The journal, the outgoing payment and the
+// payroll payment are recorded in one transaction.
+func (s *Service) PayPayrollRun(ctx context.Context, schemaName, tenantID, runID, userID string, req *PayPayrollRunRequest) (*PayPayrollRunResult, error) {
+	if s.payrollPayments == nil || s.banks == nil || s.paymentRecorder == nil || s.tenants == nil {
+		return nil, fmt.Errorf("payroll payments are unavailable")
+	}
+	if req == nil || strings.TrimSpace(req.BankAccountID) == "" {
+		return nil, fmt.Errorf("bank account is required")
+	}
+
+	run, err := s.GetPayrollRun(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != PayrollApproved && run.Status != PayrollPaid {
+		return nil, fmt.Errorf("only APPROVED payroll runs can be paid, current status: %s", run.Status)
+	}
+	if run.PaymentDate == nil {
+		return nil, fmt.Errorf("payroll run payment date is required")
+	}
+
+	bankAccount, err := s.banks.GetBankAccount(ctx, schemaName, tenantID, strings.TrimSpace(req.BankAccountID))
+	if err != nil {
+		return nil, fmt.Errorf("load bank account: %w", err)
+	}
+	if !bankAccount.IsActive {
+		return nil, fmt.Errorf("bank account %s is inactive", bankAccount.Name)
+	}
+	tenantRecord, err := s.tenants.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load tenant: %w", err)
+	}
+
+	existing, err := s.payrollPayments.ListPayrollPayments(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, fmt.Errorf("list payroll payments: %w", err)
+	}
+	payslips, err := s.repo.GetPayslipsWithEmployees(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, fmt.Errorf("load payslips: %w", err)
+	}
+	defaults, departments, err := s.loadPostingAccounts(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	period := fmt.Sprintf("%04d-%02d", run.PeriodYear, run.PeriodMonth)
+	messageID := fmt.Sprintf("PAYROLL-%04d%02d-%d", run.PeriodYear, run.PeriodMonth, len(existing)+1)
+	payment := &PayrollPayment{
+		ID:            s.uuid.New(),
+		TenantID:      tenantID,
+		PayrollRunID:  runID,
+		BankAccountID: &bankAccount.ID,
+		ExecutionDate: *run.PaymentDate,
+		MessageID:     messageID,
+		CreatedAt:     time.Now(),
+	}
+	if userID != "" {
+		payment.CreatedBy = &userID
+	}
+
+	var lines []payments.SEPACreditTransferLine
+	var payslipIDs []string
+	liabilities := make(map[string]decimal.Decimal)
+	remaining := 0
+	for i := range payslips {
+		payslip := &payslips[i]
+		if payslip.PayrollPaymentID != nil || payslip.PaymentStatus == "PAID" || payslip.PaymentStatus == "CANCELLED" { //nolint:misspell // Existing API/database spelling.
+			continue
+		}
+		net := payslip.NetSalary.Round(2)
+		if !net.IsPositive() {
+			continue
+		}
+		remaining++
+		name := payslip.EmployeeID
+		iban := ""
+		if payslip.Employee != nil {
+			name = payslip.Employee.FullName()
+			iban = strings.TrimSpace(payslip.Employee.BankAccount)
+		}
+		if iban == "" {
+			return nil, fmt.Errorf("employee %s has no bank account", name)
+		}
+		lines = append(lines, payments.SEPACreditTransferLine{
+			EndToEndID:   fmt.Sprintf("%s-%d", messageID, len(lines)+1),
+			CreditorName: name,
+			CreditorIBAN: iban,
+			Amount:       net,
+			Currency:     bankAccount.Currency,
+			Remittance:   "Salary " + period,
+		})
+		payslipIDs = append(payslipIDs, payslip.ID)
+		payment.NetAmount = payment.NetAmount.Add(net)
+		if defaults != nil {
+			_, accounts := postingAccountsForPayslip(payslip, defaults, departments)
+			liabilities[accounts.NetPayPayableAccountID] = liabilities[accounts.NetPayPayableAccountID].Add(net)
+		}
+	}
+	payment.PayslipCount = len(payslipIDs)
+
+	if req.IncludeTaxPayment {
+		for _, previous := range existing {
+			if previous.TaxAmount.IsPositive() {
+				return nil, fmt.Errorf("payroll run taxes were already paid in payment file %s", previous.MessageID)
+			}
+		}
+		reference := strings.TrimSpace(tenantRecord.Settings.TaxPrepaymentReference)
+		if reference == "" {
+			return nil, fmt.Errorf("tax prepayment reference is not set in tenant settings")
+		}
+		// Each tax is rounded per payslip so the transferred total and the
+		// liabilities it clears add up to the same cents
+		for i := range payslips {
+			payslip := &payslips[i]
+			var accounts PayrollPostingAccounts
+			if defaults != nil {
+				_, accounts = postingAccountsForPayslip(payslip, defaults, departments)
+			}
+			for _, part := range []payrollPaymentAmounts{
+				{accountID: accounts.IncomeTaxPayableAccountID, amount: payslip.IncomeTax},
+				{accountID: accounts.SocialTaxPayableAccountID, amount: payslip.SocialTax},
+				{accountID: accounts.UnemploymentPayableAccountID, amount: payslip.UnemploymentInsuranceEE.Add(payslip.UnemploymentInsuranceER)},
+				{accountID: accounts.FundedPensionPayableAccountID, amount: payslip.FundedPension},
+			} {
+				amount := part.amount.Round(2)
+				payment.TaxAmount = payment.TaxAmount.Add(amount)
+				if defaults != nil {
+					liabilities[part.accountID] = liabilities[part.accountID].Add(amount)
+				}
+			}
+		}
+		if payment.TaxAmount.IsPositive() {
+			taxIBAN := strings.TrimSpace(req.TaxAccountIBAN)
+			if taxIBAN == "" {
+				taxIBAN = TaxBoardIBAN
+			}
+			payment.TaxReference = reference
+			lines = append(lines, payments.SEPACreditTransferLine{
+				EndToEndID:        fmt.Sprintf("%s-%d", messageID, len(lines)+1),
+				CreditorName:      TaxBoardCreditorName,
+				CreditorIBAN:      taxIBAN,
+				Amount:            payment.TaxAmount,
+				Currency:          bankAccount.Currency,
+				CreditorReference: reference,
+			})
+		}
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("payroll run has no unpaid payslips")
+	}
+
+	sepa, err := payments.BuildSEPAExport(&payments.SEPAExportRequest{
+		MessageID:     messageID,
+		DebtorName:    tenantRecord.Name,
+		DebtorIBAN:    bankAccount.AccountNumber,
+		DebtorBIC:     bankAccount.SwiftCode,
+		ExecutionDate: run.PaymentDate.Format("2006-01-02"),
+		Lines:         lines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("build payroll payment file: %w", err)
+	}
+
+	var outgoing *payments.Payment
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		if defaults != nil {
+			entry, err := tx.postPayrollPaymentJournal(ctx, schemaName, tenantID, payment, bankAccount, liabilities, "Payroll payment "+period, userID)
+			if err != nil {
+				return err
+			}
+			payment.JournalEntryID = &entry.ID
+		}
+
+		var err error
+		outgoing, err = tx.paymentRecorder.Create(ctx, tenantID, schemaName, &payments.CreatePaymentRequest{
+			PaymentType:   payments.PaymentTypeMade,
+			PaymentDate:   *run.PaymentDate,
+			Amount:        payment.TotalAmount(),
+			Currency:      bankAccount.Currency,
+			PaymentMethod: "BANK_TRANSFER",
+			BankAccount:   bankAccount.AccountNumber,
+			Reference:     messageID,
+			Notes:         "Payroll payment " + period,
+			UserID:        userID,
+		})
+		if err != nil {
+			return fmt.Errorf("record payroll payment: %w", err)
+		}
+		payment.PaymentID = &outgoing.ID
+
+		if err := tx.payrollPayments.CreatePayrollPayment(ctx, schemaName, payment, payslipIDs, remaining == len(payslipIDs)); err != nil {
+			return fmt.Errorf("save payroll payment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &PayPayrollRunResult{PayrollPayment: payment, Payment: outgoing, SEPA: sepa}, nil
+}
+
+// postPayrollPaymentJournal clears the paid payroll liabilities against the
+// ledger account of the paying bank account.
+func (s *Service) postPayrollPaymentJournal(ctx context.Context, schemaName, tenantID string, payment *PayrollPayment, bankAccount *banking.BankAccount, liabilities map[string]decimal.Decimal, description, userID string) (*accounting.JournalEntry, error) {
+	if s.ledger == nil {
+		return nil, fmt.Errorf("%w: accounting service is unavailable", ErrPayrollPostingInvalid)
+	}
+	if bankAccount.GLAccountID == nil || *bankAccount.GLAccountID == "" {
+		return nil, fmt.Errorf("%w: bank account %s has no ledger account", ErrPayrollPostingInvalid, bankAccount.Name)
+	}
+	if err := s.requirePostingAccountType(ctx, schemaName, tenantID, *bankAccount.GLAccountID, "bank ledger account", accounting.AccountTypeAsset); err != nil {
+		return nil, err
+	}
+
+	accountIDs := make([]string, 0, len(liabilities))
+	for accountID, amount := range liabilities {
+		if !amount.IsZero() {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	sort.Strings(accountIDs)
+
+	// The bank is credited with the payment file total; the liabilities are
+	// sums of amounts already rounded to cents, so they clear exactly that
+	lines := make([]accounting.CreateJournalEntryLineReq, 0, len(accountIDs)+1)
+	credit := payment.TotalAmount()
+	debits := decimal.Zero
+	for _, accountID := range accountIDs {
+		debits = debits.Add(liabilities[accountID])
+		lines = append(lines, accounting.CreateJournalEntryLineReq{
+			AccountID:   accountID,
+			Description: description,
+			DebitAmount: liabilities[accountID],
+		})
+	}
+	if !debits.Equal(credit) {
+		return nil, fmt.Errorf("%w: payroll liabilities of %s do not match the payment total %s", ErrPayrollPostingInvalid, debits.StringFixed(2), credit.StringFixed(2))
+	}
+	lines = append(lines, accounting.CreateJournalEntryLineReq{
+		AccountID:    *bankAccount.GLAccountID,
+		Description:  description,
+		CreditAmount: credit,
+	})
+
+	sourceID := payment.ID
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+		EntryDate:   payment.ExecutionDate,
+		Description: description,
+		Reference:   payment.MessageID,
+		SourceType:  SourceTypePayrollPayment,
+		SourceID:    &sourceID,
+		UserID:      userID,
+		Lines:       lines,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create payroll payment journal: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Payroll payment file"); err != nil {
+		return nil, fmt.Errorf("post payroll payment journal: %w", err)
+	}
+	return entry, nil
+}
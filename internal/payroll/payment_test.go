@@ -0,0 +1,395 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/banking"
+	"github.com/HMB-research/open-accounting/internal/payments"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paymentMockRepository struct {
+	*postingMockRepository
+	recorder          *fakePayrollPaymentRecorder
+	payments          []PayrollPayment
+	createPaymentErr  error
+	lastPaidPayslips  []string
+	lastMarkedRunPaid bool
+}
+
+// WithLedgerTransaction also undoes recorded payroll payments, paid payslips
+// and outgoing payments when fn fails.
+func (m *paymentMockRepository) WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error) error {
+	paid := len(m.payments)
+	payslips := append([]Payslip(nil), m.Payslips...)
+	requests := len(m.recorder.requests)
+	err := m.withLedgerRollback(func(ledger payrollLedger, costCenters payrollCostAllocator) error {
+		return fn(m, ledger, costCenters, m.recorder)
+	})
+	if err != nil {
+		m.payments = m.payments[:paid]
+		m.Payslips = payslips
+		m.recorder.requests = m.recorder.requests[:requests]
+	}
+	return err
+}
+
+func (m *paymentMockRepository) CreatePayrollPayment(ctx context.Context, schemaName string, payment *PayrollPayment, payslipIDs []string, markRunPaid bool) error {
+	if m.createPaymentErr != nil {
+		return m.createPaymentErr
+	}
+	m.payments = append(m.payments, *payment)
+	m.lastPaidPayslips = payslipIDs
+	m.lastMarkedRunPaid = markRunPaid
+	for _, id := range payslipIDs {
+		for i := range m.Payslips {
+			if m.Payslips[i].ID == id {
+				paymentID := payment.ID
+				paidAt := payment.ExecutionDate
+				m.Payslips[i].PayrollPaymentID = &paymentID
+				m.Payslips[i].PaymentStatus = "PAID"
+				m.Payslips[i].PaidAt = &paidAt
+			}
+		}
+	}
+	if markRunPaid {
+		m.PayrollRuns[payment.PayrollRunID].Status = PayrollPaid
+	}
+	return nil
+}
+
+func (m *paymentMockRepository) ListPayrollPayments(ctx context.Context, schemaName, tenantID, runID string) ([]PayrollPayment, error) {
+	result := []PayrollPayment{}
+	for _, payment := range m.payments {
+		if payment.TenantID == tenantID && payment.PayrollRunID == runID {
+			result = append(result, payment)
+		}
+	}
+	return result, nil
+}
+
+type fakePayrollBankAccounts map[string]*banking.BankAccount
+
+func (f fakePayrollBankAccounts) GetBankAccount(ctx context.Context, schemaName, tenantID, accountID string) (*banking.BankAccount, error) {
+	account, ok := f[accountID]
+	if !ok {
+		return nil, fmt.Errorf("bank account not found")
+	}
+	return account, nil
+}
+
+type fakePayrollPaymentRecorder struct {
+	requests []payments.CreatePaymentRequest
+	err      error
+}
+
+func (f *fakePayrollPaymentRecorder) Create(ctx context.Context, tenantID, schemaName string, req *payments.CreatePaymentRequest) (*payments.Payment, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.requests = append(f.requests, *req)
+	return &payments.Payment{
+		ID:            fmt.Sprintf("payment-%d", len(f.requests)),
+		TenantID:      tenantID,
+		PaymentNumber: fmt.Sprintf("PAY-%05d", len(f.requests)),
+		PaymentType:   req.PaymentType,
+		PaymentDate:   req.PaymentDate,
+		Amount:        req.Amount,
+		Reference:     req.Reference,
+	}, nil
+}
+
+type fakePayrollTenants map[string]*tenant.Tenant
+
+func (f fakePayrollTenants) GetTenant(ctx context.Context, tenantID string) (*tenant.Tenant, error) {
+	record, ok := f[tenantID]
+	if !ok {
+		return nil, fmt.Errorf("tenant not found")
+	}
+	return record, nil
+}
+
+func setupPaymentService(t *testing.T) (*Service, *paymentMockRepository, *fakePayrollLedger, *fakePayrollPaymentRecorder, fakePayrollTenants) {
+	t.Helper()
+	postingRepo := newPostingMockRepository()
+	ledger := newFakePayrollLedger()
+	recorder := &fakePayrollPaymentRecorder{}
+	postingRepo.ledger = ledger
+	repo := &paymentMockRepository{postingMockRepository: postingRepo, recorder: recorder}
+	bankLedgerAccount := "bank"
+	banks := fakePayrollBankAccounts{
+		"bank-1": {ID: "bank-1", Name: "Main", AccountNumber: "EE382200221020145685", Currency: "EUR", GLAccountID: &bankLedgerAccount, IsActive: true},
+		"bank-2": {ID: "bank-2", Name: "Closed", AccountNumber: "EE382200221020145685", Currency: "EUR", IsActive: false},
+	}
+	settings := tenant.DefaultSettings()
+	settings.TaxPrepaymentReference = "10123456781"
+	tenants := fakePayrollTenants{"tenant-1": {ID: "tenant-1", Name: "Example OU", Settings: settings}}
+	service := NewServiceWithRepositoryAndAccounting(repo, &MockUUIDGenerator{prefix: "payment"}, ledger, nil).
+		WithPaymentServices(banks, recorder, tenants)
+
+	paymentDate := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	repo.Employees["emp-ops"] = &Employee{ID: "emp-ops", TenantID: "tenant-1", FirstName: "Mari", LastName: "Maasikas", Department: "Operations", BankAccount: "EE471000001020145685"}
+	repo.Employees["emp-sales"] = &Employee{ID: "emp-sales", TenantID: "tenant-1", FirstName: "Jaan", LastName: "Tamm", Department: "Sales", BankAccount: "EE457700771000676899"}
+	repo.PayrollRuns["run-1"] = &PayrollRun{ID: "run-1", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 2, Status: PayrollApproved, PaymentDate: &paymentDate}
+	for _, employeeID := range []string{"emp-ops", "emp-sales"} {
+		calc := CalculateEstonianTaxes(decimal.NewFromInt(2000), DefaultBasicExemption, FundedPensionRateDefault)
+		repo.Payslips = append(repo.Payslips, Payslip{
+			ID:                      "payslip-" + employeeID,
+			TenantID:                "tenant-1",
+			PayrollRunID:            "run-1",
+			EmployeeID:              employeeID,
+			GrossSalary:             calc.GrossSalary,
+			IncomeTax:               calc.IncomeTax,
+			UnemploymentInsuranceEE: calc.UnemploymentEE,
+			FundedPension:           calc.FundedPension,
+			NetSalary:               calc.NetSalary,
+			SocialTax:               calc.SocialTax,
+			UnemploymentInsuranceER: calc.UnemploymentER,
+			TotalEmployerCost:       calc.TotalEmployerCost,
+			PaymentStatus:           "PENDING",
+		})
+	}
+	return service, repo, ledger, recorder, tenants
+}
+
+func TestPayPayrollRun(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, recorder, _ := setupPaymentService(t)
+	_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+	require.NoError(t, err)
+
+	result, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{
+		BankAccountID:     "bank-1",
+		IncludeTaxPayment: true,
+	})
+	require.NoError(t, err)
+
+	calc := CalculateEstonianTaxes(decimal.NewFromInt(2000), DefaultBasicExemption, FundedPensionRateDefault)
+	two := decimal.NewFromInt(2)
+	expectedNet := calc.NetSalary.Mul(two)
+	expectedTax := calc.IncomeTax.Add(calc.SocialTax).Add(calc.UnemploymentEE).Add(calc.UnemploymentER).Add(calc.FundedPension).Mul(two)
+
+	paid := result.PayrollPayment
+	assert.Equal(t, "PAYROLL-202602-1", paid.MessageID)
+	assert.True(t, paid.NetAmount.Equal(expectedNet), paid.NetAmount.String())
+	assert.True(t, paid.TaxAmount.Equal(expectedTax), paid.TaxAmount.String())
+	assert.Equal(t, "10123456781", paid.TaxReference)
+	assert.Equal(t, 2, paid.PayslipCount)
+	assert.Equal(t, time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC), paid.ExecutionDate)
+
+	assert.Equal(t, "2026-03-10", result.SEPA.ExecutionDate)
+	assert.Equal(t, 3, result.SEPA.TransactionCount)
+	assert.True(t, result.SEPA.ControlSum.Equal(expectedNet.Add(expectedTax)))
+	assert.Contains(t, result.SEPA.XML, "<Nm>Mari Maasikas</Nm>")
+	assert.Contains(t, result.SEPA.XML, "<IBAN>"+TaxBoardIBAN+"</IBAN>")
+	assert.Contains(t, result.SEPA.XML, "<Ref>10123456781</Ref>")
+	assert.Contains(t, result.SEPA.XML, "<Ustrd>Salary 2026-02</Ustrd>")
+
+	require.Len(t, recorder.requests, 1)
+	assert.Equal(t, payments.PaymentTypeMade, recorder.requests[0].PaymentType)
+	assert.True(t, recorder.requests[0].Amount.Equal(paid.TotalAmount()))
+	assert.Equal(t, "PAYROLL-202602-1", recorder.requests[0].Reference)
+	require.NotNil(t, paid.PaymentID)
+	assert.Equal(t, "payment-1", *paid.PaymentID)
+
+	require.NotNil(t, paid.JournalEntryID)
+	entry := ledger.entries[*paid.JournalEntryID]
+	require.NotNil(t, entry)
+	assert.Equal(t, accounting.StatusPosted, entry.Status)
+	assert.Equal(t, SourceTypePayrollPayment, entry.SourceType)
+	totals := map[string]decimal.Decimal{}
+	for _, line := range entry.Lines {
+		totals[line.AccountID] = totals[line.AccountID].Add(line.DebitAmount).Sub(line.CreditAmount)
+	}
+	assert.True(t, totals["net-pay"].Equal(expectedNet), totals["net-pay"].String())
+	assert.True(t, totals["income-tax"].Equal(calc.IncomeTax.Mul(two)))
+	assert.True(t, totals["bank"].Equal(expectedNet.Add(expectedTax).Neg()))
+
+	assert.ElementsMatch(t, []string{"payslip-emp-ops", "payslip-emp-sales"}, repo.lastPaidPayslips)
+	assert.True(t, repo.lastMarkedRunPaid)
+	assert.Equal(t, PayrollPaid, repo.PayrollRuns["run-1"].Status)
+
+	listed, err := service.ListPayrollPayments(ctx, "tenant_test", "tenant-1", "run-1")
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+
+	_, err = service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+	require.EqualError(t, err, "payroll run has no unpaid payslips")
+	_, err = service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1", IncludeTaxPayment: true})
+	require.EqualError(t, err, "payroll run taxes were already paid in payment file PAYROLL-202602-1")
+}
+
+func TestPayPayrollRunBalancesRoundedLiabilities(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, _, _ := setupPaymentService(t)
+	_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+	require.NoError(t, err)
+	// Each tax account rounds up by a cent while the tax total rounds up once
+	fraction := decimal.RequireFromString("0.003")
+	for i := range repo.Payslips {
+		repo.Payslips[i].IncomeTax = repo.Payslips[i].IncomeTax.Add(fraction)
+		repo.Payslips[i].SocialTax = repo.Payslips[i].SocialTax.Add(fraction)
+	}
+
+	result, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{
+		BankAccountID:     "bank-1",
+		IncludeTaxPayment: true,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.PayrollPayment.JournalEntryID)
+	entry := ledger.entries[*result.PayrollPayment.JournalEntryID]
+	require.NotNil(t, entry)
+	require.NoError(t, entry.Validate())
+}
+
+func TestPayPayrollRunJournalMatchesPaymentFileTotal(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, recorder, _ := setupPaymentService(t)
+	_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+	require.NoError(t, err)
+	// Per payslip the income tax rounds up, the social tax and net pay round
+	// down; summed first, the income tax would round down and the social tax up
+	for i := range repo.Payslips {
+		repo.Payslips[i].NetSalary = repo.Payslips[i].NetSalary.Add(decimal.RequireFromString("0.004"))
+		repo.Payslips[i].IncomeTax = repo.Payslips[i].IncomeTax.Add(decimal.RequireFromString("0.005"))
+		repo.Payslips[i].SocialTax = repo.Payslips[i].SocialTax.Add(decimal.RequireFromString("0.004"))
+	}
+
+	result, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{
+		BankAccountID:     "bank-1",
+		IncludeTaxPayment: true,
+	})
+	require.NoError(t, err)
+
+	calc := CalculateEstonianTaxes(decimal.NewFromInt(2000), DefaultBasicExemption, FundedPensionRateDefault)
+	two := decimal.NewFromInt(2)
+	expectedNet := calc.NetSalary.Mul(two)
+	expectedTax := calc.IncomeTax.Add(calc.SocialTax).Add(calc.UnemploymentEE).Add(calc.UnemploymentER).Add(calc.FundedPension).Mul(two).Add(decimal.RequireFromString("0.02"))
+	paid := result.PayrollPayment
+	assert.True(t, paid.NetAmount.Equal(expectedNet), paid.NetAmount.String())
+	assert.True(t, paid.TaxAmount.Equal(expectedTax), paid.TaxAmount.String())
+	assert.True(t, result.SEPA.ControlSum.Equal(paid.TotalAmount()), result.SEPA.ControlSum.String())
+	assert.Contains(t, result.SEPA.XML, "<CtrlSum>"+paid.TotalAmount().StringFixed(2)+"</CtrlSum>")
+	require.Len(t, recorder.requests, 1)
+	assert.True(t, recorder.requests[0].Amount.Equal(paid.TotalAmount()))
+
+	require.NotNil(t, paid.JournalEntryID)
+	entry := ledger.entries[*paid.JournalEntryID]
+	require.NotNil(t, entry)
+	require.NoError(t, entry.Validate())
+	totals := map[string]decimal.Decimal{}
+	for _, line := range entry.Lines {
+		totals[line.AccountID] = totals[line.AccountID].Add(line.DebitAmount).Sub(line.CreditAmount)
+	}
+	assert.True(t, totals["bank"].Neg().Equal(paid.TotalAmount()), totals["bank"].String())
+	assert.True(t, totals["net-pay"].Equal(paid.NetAmount), totals["net-pay"].String())
+	assert.True(t, totals["income-tax"].Equal(calc.IncomeTax.Mul(two).Add(decimal.RequireFromString("0.02"))), totals["income-tax"].String())
+	assert.True(t, totals["social-tax"].Equal(calc.SocialTax.Mul(two)), totals["social-tax"].String())
+}
+
+func TestPayPayrollRunWithoutPostingAccounts(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, recorder, _ := setupPaymentService(t)
+	repo.Payslips[1].PaymentStatus = "PAID"
+
+	result, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+	require.NoError(t, err)
+	assert.Nil(t, result.PayrollPayment.JournalEntryID)
+	assert.True(t, result.PayrollPayment.TaxAmount.IsZero())
+	assert.Equal(t, 1, result.SEPA.TransactionCount)
+	assert.NotContains(t, result.SEPA.XML, TaxBoardCreditorName)
+	assert.Empty(t, ledger.entries)
+	require.Len(t, recorder.requests, 1)
+	assert.Equal(t, []string{"payslip-emp-ops"}, repo.lastPaidPayslips)
+	assert.True(t, repo.lastMarkedRunPaid)
+}
+
+func TestPayPayrollRunValidation(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("run must be approved", func(t *testing.T) {
+		service, repo, _, _, _ := setupPaymentService(t)
+		repo.PayrollRuns["run-1"].Status = PayrollCalculated
+		_, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.EqualError(t, err, "only APPROVED payroll runs can be paid, current status: CALCULATED")
+	})
+
+	t.Run("payment date is required", func(t *testing.T) {
+		service, repo, _, _, _ := setupPaymentService(t)
+		repo.PayrollRuns["run-1"].PaymentDate = nil
+		_, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.EqualError(t, err, "payroll run payment date is required")
+	})
+
+	t.Run("bank account is required and active", func(t *testing.T) {
+		service, _, _, _, _ := setupPaymentService(t)
+		_, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{})
+		require.EqualError(t, err, "bank account is required")
+		_, err = service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-2"})
+		require.EqualError(t, err, "bank account Closed is inactive")
+	})
+
+	t.Run("employee needs an IBAN", func(t *testing.T) {
+		service, repo, _, recorder, _ := setupPaymentService(t)
+		repo.Employees["emp-sales"].BankAccount = ""
+		_, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.EqualError(t, err, "employee Jaan Tamm has no bank account")
+		assert.Empty(t, recorder.requests)
+	})
+
+	t.Run("tax payment needs a prepayment reference", func(t *testing.T) {
+		service, _, _, _, tenants := setupPaymentService(t)
+		tenants["tenant-1"].Settings.TaxPrepaymentReference = ""
+		_, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1", IncludeTaxPayment: true})
+		require.EqualError(t, err, "tax prepayment reference is not set in tenant settings")
+	})
+
+	t.Run("posting needs a bank ledger account", func(t *testing.T) {
+		service, _, _, recorder, _ := setupPaymentService(t)
+		_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+		require.NoError(t, err)
+		service.banks.(fakePayrollBankAccounts)["bank-1"].GLAccountID = nil
+		_, err = service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.ErrorIs(t, err, ErrPayrollPostingInvalid)
+		assert.Empty(t, recorder.requests)
+	})
+
+	t.Run("failed save rolls back the payment journal", func(t *testing.T) {
+		service, repo, ledger, recorder, _ := setupPaymentService(t)
+		_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+		require.NoError(t, err)
+		repo.createPaymentErr = errors.New("database unavailable")
+		_, err = service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.EqualError(t, err, "save payroll payment: database unavailable")
+		assert.Empty(t, ledger.entries)
+		assert.Empty(t, ledger.voided)
+		assert.Empty(t, recorder.requests)
+	})
+
+	t.Run("failed outgoing payment rolls back the payment journal", func(t *testing.T) {
+		service, repo, ledger, recorder, _ := setupPaymentService(t)
+		_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+		require.NoError(t, err)
+		recorder.err = errors.New("payment numbering failed")
+		_, err = service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.EqualError(t, err, "record payroll payment: payment numbering failed")
+		assert.Empty(t, ledger.entries)
+		assert.Empty(t, repo.payments)
+	})
+
+	t.Run("payments unavailable without dependencies", func(t *testing.T) {
+		service := NewServiceWithRepository(NewMockRepository(), &MockUUIDGenerator{prefix: "payment"})
+		_, err := service.PayPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "user-1", &PayPayrollRunRequest{BankAccountID: "bank-1"})
+		require.EqualError(t, err, "payroll payments are unavailable")
+		_, err = service.ListPayrollPayments(ctx, "tenant_test", "tenant-1", "run-1")
+		require.EqualError(t, err, "payroll payments are unavailable")
+	})
+}
@@ -0,0 +1,480 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/shopspring/decimal"
+)
+
+// SourceTypePayrollRun marks journal entries posted from approved payroll runs.
+const SourceTypePayrollRun = "PAYROLL_RUN"
+
+// ErrPayrollPostingInvalid is returned when payroll posting accounts cannot produce a journal entry.
+var ErrPayrollPostingInvalid = errors.New("invalid payroll posting configuration")
+
+type payrollLedger interface {
+	GetAccount(ctx context.Context, schemaName, tenantID, accountID string) (*accounting.Account, error)
+	CreateJournalEntry(ctx context.Context, schemaName, tenantID string, req *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error)
+	PostJournalEntry(ctx context.Context, schemaName, tenantID, entryID, userID, reason string) error
+	VoidJournalEntry(ctx context.Context, schemaName, tenantID, entryID, userID, reason string) (*accounting.JournalEntry, error)
+	GetJournalEntry(ctx context.Context, schemaName, tenantID, entryID string) (*accounting.JournalEntry, error)
+}
+
+type payrollCostAllocator interface {
+	CreateCostAllocation(ctx context.Context, schemaName, tenantID string, req *accounting.CreateCostAllocationRequest) (*accounting.CostAllocation, error)
+	DeleteCostAllocationsForJournalLines(ctx context.Context, schemaName, tenantID string, journalEntryLineIDs []string) error
+}
+
+// PayrollPostingAccounts maps payroll amounts to ledger accounts. The entry with
+// an empty Department is the tenant default and must set every account;
+// department entries override it field by field and may allocate the salary and
+// employer tax expense lines to a cost center.
+type PayrollPostingAccounts struct {
+	ID                            string    `json:"id"`
+	TenantID                      string    `json:"tenant_id"`
+	Department                    string    `json:"department,omitempty"`
+	CostCenterID                  *string   `json:"cost_center_id,omitempty"`
+	SalaryExpenseAccountID        string    `json:"salary_expense_account_id,omitempty"`
+	EmployerTaxExpenseAccountID   string    `json:"employer_tax_expense_account_id,omitempty"`
+	IncomeTaxPayableAccountID     string    `json:"income_tax_payable_account_id,omitempty"`
+	SocialTaxPayableAccountID     string    `json:"social_tax_payable_account_id,omitempty"`
+	UnemploymentPayableAccountID  string    `json:"unemployment_payable_account_id,omitempty"`
+	FundedPensionPayableAccountID string    `json:"funded_pension_payable_account_id,omitempty"`
+	NetPayPayableAccountID        string    `json:"net_pay_payable_account_id,omitempty"`
+	CreatedAt                     time.Time `json:"created_at"`
+	UpdatedAt                     time.Time `json:"updated_at"`
+}
+
+// SetPayrollPostingAccountsRequest creates or replaces the posting accounts for
+// the tenant default (empty department) or one department.
+type SetPayrollPostingAccountsRequest struct {
+	Department                    string  `json:"department,omitempty"`
+	CostCenterID                  *string `json:"cost_center_id,omitempty"`
+	SalaryExpenseAccountID        string  `json:"salary_expense_account_id,omitempty"`
+	EmployerTaxExpenseAccountID   string  `json:"employer_tax_expense_account_id,omitempty"`
+	IncomeTaxPayableAccountID     string  `json:"income_tax_payable_account_id,omitempty"`
+	SocialTaxPayableAccountID     string  `json:"social_tax_payable_account_id,omitempty"`
+	UnemploymentPayableAccountID  string  `json:"unemployment_payable_account_id,omitempty"`
+	FundedPensionPayableAccountID string  `json:"funded_pension_payable_account_id,omitempty"`
+	NetPayPayableAccountID        string  `json:"net_pay_payable_account_id,omitempty"`
+}
+
+type postingAccountField struct {
+	name        string
+	value       *string
+	accountType accounting.AccountType
+}
+
+func (a *PayrollPostingAccounts) fields() []postingAccountField {
+	return []postingAccountField{
+		{name: "salary expense account", value: &a.SalaryExpenseAccountID, accountType: accounting.AccountTypeExpense},
+		{name: "employer tax expense account", value: &a.EmployerTaxExpenseAccountID, accountType: accounting.AccountTypeExpense},
+		{name: "income tax payable account", value: &a.IncomeTaxPayableAccountID, accountType: accounting.AccountTypeLiability},
+		{name: "social tax payable account", value: &a.SocialTaxPayableAccountID, accountType: accounting.AccountTypeLiability},
+		{name: "unemployment insurance payable account", value: &a.UnemploymentPayableAccountID, accountType: accounting.AccountTypeLiability},
+		{name: "funded pension payable account", value: &a.FundedPensionPayableAccountID, accountType: accounting.AccountTypeLiability},
+		{name: "net pay payable account", value: &a.NetPayPayableAccountID, accountType: accounting.AccountTypeLiability},
+	}
+}
+
+// withDefaults fills blank department accounts from the tenant default.
+func (a PayrollPostingAccounts) withDefaults(defaults PayrollPostingAccounts) PayrollPostingAccounts {
+	resolved := a
+	defaultFields := defaults.fields()
+	for i, field := range resolved.fields() {
+		if strings.TrimSpace(*field.value) == "" {
+			*field.value = *defaultFields[i].value
+		}
+	}
+	return resolved
+}
+
+// ListPayrollPostingAccounts returns the tenant default and department posting accounts.
+func (s *Service) ListPayrollPostingAccounts(ctx context.Context, schemaName, tenantID string) ([]PayrollPostingAccounts, error) {
+	if s.posting == nil {
+		return nil, fmt.Errorf("payroll posting is unavailable")
+	}
+	accounts, err := s.posting.ListPostingAccounts(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list payroll posting accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// SetPayrollPostingAccounts validates and stores posting accounts for the tenant
+// default or a department override.
+func (s *Service) SetPayrollPostingAccounts(ctx context.Context, schemaName, tenantID string, req *SetPayrollPostingAccountsRequest) (*PayrollPostingAccounts, error) {
+	if s.posting == nil {
+		return nil, fmt.Errorf("payroll posting is unavailable")
+	}
+	if req == nil {
+		return nil, fmt.Errorf("posting accounts are required")
+	}
+
+	now := time.Now()
+	accounts := &PayrollPostingAccounts{
+		ID:                            s.uuid.New(),
+		TenantID:                      tenantID,
+		Department:                    strings.TrimSpace(req.Department),
+		CostCenterID:                  trimmedOptionalString(req.CostCenterID),
+		SalaryExpenseAccountID:        strings.TrimSpace(req.SalaryExpenseAccountID),
+		EmployerTaxExpenseAccountID:   strings.TrimSpace(req.EmployerTaxExpenseAccountID),
+		IncomeTaxPayableAccountID:     strings.TrimSpace(req.IncomeTaxPayableAccountID),
+		SocialTaxPayableAccountID:     strings.TrimSpace(req.SocialTaxPayableAccountID),
+		UnemploymentPayableAccountID:  strings.TrimSpace(req.UnemploymentPayableAccountID),
+		FundedPensionPayableAccountID: strings.TrimSpace(req.FundedPensionPayableAccountID),
+		NetPayPayableAccountID:        strings.TrimSpace(req.NetPayPayableAccountID),
+		CreatedAt:                     now,
+		UpdatedAt:                     now,
+	}
+	if accounts.Department == "" && accounts.CostCenterID != nil {
+		return nil, fmt.Errorf("cost center can only be set for a department")
+	}
+
+	configured := accounts.CostCenterID != nil
+	for _, field := range accounts.fields() {
+		if *field.value == "" {
+			if accounts.Department == "" {
+				return nil, fmt.Errorf("%s is required for the default posting accounts", field.name)
+			}
+			continue
+		}
+		configured = true
+		if err := s.requirePostingAccountType(ctx, schemaName, tenantID, *field.value, field.name, field.accountType); err != nil {
+			return nil, err
+		}
+	}
+	if !configured {
+		return nil, fmt.Errorf("department posting accounts must set at least one account or a cost center")
+	}
+
+	if err := s.posting.UpsertPostingAccounts(ctx, schemaName, accounts); err != nil {
+		return nil, fmt.Errorf("save payroll posting accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// DeletePayrollPostingAccounts removes the posting accounts for the tenant default
+// or a department override.
+func (s *Service) DeletePayrollPostingAccounts(ctx context.Context, schemaName, tenantID, department string) error {
+	if s.posting == nil {
+		return fmt.Errorf("payroll posting is unavailable")
+	}
+	if err := s.posting.DeletePostingAccounts(ctx, schemaName, tenantID, strings.TrimSpace(department)); err != nil {
+		if errors.Is(err, ErrPostingAccountsNotFound) {
+			return ErrPostingAccountsNotFound
+		}
+		return fmt.Errorf("delete payroll posting accounts: %w", err)
+	}
+	return nil
+}
+
+// ReopenPayrollRun returns an approved payroll run to DRAFT so it can be
+// recalculated. A posted payroll journal entry is voided with a reversal.
+func (s *Service) ReopenPayrollRun(ctx context.Context, schemaName, tenantID, runID, userID, reason string) (*PayrollRun, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("reopen reason is required")
+	}
+	if s.posting == nil {
+		return nil, fmt.Errorf("payroll run reopening is unavailable")
+	}
+
+	run, err := s.GetPayrollRun(ctx, schemaName, tenantID, runID)
+	if err != nil {
+		return nil, err
+	}
+	if run.Status != PayrollApproved {
+		return nil, fmt.Errorf("only APPROVED payroll runs can be reopened, current status: %s", run.Status)
+	}
+
+	journalEntryID := ""
+	if run.JournalEntryID != nil {
+		if s.ledger == nil {
+			return nil, fmt.Errorf("%w: accounting service is unavailable", ErrPayrollPostingInvalid)
+		}
+		journalEntryID = *run.JournalEntryID
+	}
+
+	// The status reset only matches an APPROVED run, so it goes first: a
+	// concurrent reopen fails there before voiding the journal a second time.
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		if err := tx.posting.ReopenPayrollRun(ctx, schemaName, tenantID, runID); err != nil {
+			if errors.Is(err, ErrPayrollRunNotFound) {
+				return fmt.Errorf("payroll run not found or not in APPROVED status")
+			}
+			return fmt.Errorf("reopen payroll run: %w", err)
+		}
+		if journalEntryID == "" {
+			return nil
+		}
+
+		entry, err := tx.ledger.GetJournalEntry(ctx, schemaName, tenantID, journalEntryID)
+		if err != nil {
+			return fmt.Errorf("load payroll journal entry: %w", err)
+		}
+		if entry.Status == accounting.StatusPosted {
+			if _, err := tx.ledger.VoidJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Payroll run reopened: "+reason); err != nil {
+				return fmt.Errorf("void payroll journal entry: %w", err)
+			}
+		}
+		if tx.costCenters != nil {
+			lineIDs := make([]string, 0, len(entry.Lines))
+			for _, line := range entry.Lines {
+				lineIDs = append(lineIDs, line.ID)
+			}
+			if err := tx.costCenters.DeleteCostAllocationsForJournalLines(ctx, schemaName, tenantID, lineIDs); err != nil {
+				return fmt.Errorf("remove payroll cost allocations: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.GetPayrollRun(ctx, schemaName, tenantID, runID)
+}
+
+// loadPostingAccounts returns the tenant default posting accounts, or nil when
+// posting is not configured, and the department overrides keyed by lower-case name.
+func (s *Service) loadPostingAccounts(ctx context.Context, schemaName, tenantID string) (*PayrollPostingAccounts, map[string]PayrollPostingAccounts, error) {
+	if s.posting == nil {
+		return nil, nil, nil
+	}
+	configured, err := s.posting.ListPostingAccounts(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("load payroll posting accounts: %w", err)
+	}
+	var defaults *PayrollPostingAccounts
+	departments := make(map[string]PayrollPostingAccounts, len(configured))
+	for _, accounts := range configured {
+		if accounts.Department == "" {
+			found := accounts
+			defaults = &found
+			continue
+		}
+		departments[strings.ToLower(accounts.Department)] = accounts
+	}
+	return defaults, departments, nil
+}
+
+// postingAccountsForPayslip resolves the posting accounts for the payslip's
+// employee department and returns the department key they are grouped under.
+func postingAccountsForPayslip(payslip *Payslip, defaults *PayrollPostingAccounts, departments map[string]PayrollPostingAccounts) (string, PayrollPostingAccounts) {
+	key := ""
+	if payslip.Employee != nil {
+		key = strings.ToLower(strings.TrimSpace(payslip.Employee.Department))
+	}
+	accounts, ok := departments[key]
+	if !ok {
+		return "", *defaults
+	}
+	return key, accounts.withDefaults(*defaults)
+}
+
+// PayrollRunPostingDate is the ledger date of a payroll run: the last day of its period.
+func PayrollRunPostingDate(run *PayrollRun) time.Time {
+	return time.Date(run.PeriodYear, time.Month(run.PeriodMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+}
+
+type payrollPostingGroup struct {
+	accounts      PayrollPostingAccounts
+	gross         decimal.Decimal
+	employerTaxes decimal.Decimal
+	incomeTax     decimal.Decimal
+	socialTax     decimal.Decimal
+	unemployment  decimal.Decimal
+	fundedPension decimal.Decimal
+	netPay        decimal.Decimal
+}
+
+type payrollJournal struct {
+	request     *accounting.CreateJournalEntryRequest
+	allocations map[int]string // request line index -> cost center ID
+}
+
+// buildPayrollJournal groups payslips by department posting accounts and
+// returns nil when the tenant has no default posting accounts configured.
+func (s *Service) buildPayrollJournal(ctx context.Context, schemaName, tenantID string, run *PayrollRun, userID string) (*payrollJournal, error) {
+	defaults, departments, err := s.loadPostingAccounts(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	if defaults == nil {
+		return nil, nil
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("%w: accounting service is unavailable", ErrPayrollPostingInvalid)
+	}
+
+	payslips, err := s.repo.GetPayslipsWithEmployees(ctx, schemaName, tenantID, run.ID)
+	if err != nil {
+		return nil, fmt.Errorf("load payslips: %w", err)
+	}
+
+	groups := make(map[string]*payrollPostingGroup)
+	for _, payslip := range payslips {
+		key, accounts := postingAccountsForPayslip(&payslip, defaults, departments)
+		group, ok := groups[key]
+		if !ok {
+			group = &payrollPostingGroup{accounts: accounts}
+			groups[key] = group
+		}
+		group.gross = group.gross.Add(payslip.GrossSalary)
+		group.employerTaxes = group.employerTaxes.Add(payslip.SocialTax).Add(payslip.UnemploymentInsuranceER)
+		group.incomeTax = group.incomeTax.Add(payslip.IncomeTax)
+		group.socialTax = group.socialTax.Add(payslip.SocialTax)
+		group.unemployment = group.unemployment.Add(payslip.UnemploymentInsuranceEE).Add(payslip.UnemploymentInsuranceER)
+		group.fundedPension = group.fundedPension.Add(payslip.FundedPension)
+		// Other deductions stay payable by the employer until paid to the
+		// third party, so they are carried with net pay.
+		group.netPay = group.netPay.Add(payslip.NetSalary).Add(payslip.OtherDeductions)
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("%w: payroll run has no payslips to post", ErrPayrollPostingInvalid)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	period := fmt.Sprintf("%04d-%02d", run.PeriodYear, run.PeriodMonth)
+	description := "Payroll " + period
+	journal := &payrollJournal{allocations: make(map[int]string)}
+	lines := make([]accounting.CreateJournalEntryLineReq, 0, len(groups)*7)
+	checked := make(map[string]bool)
+	for _, key := range keys {
+		group := groups[key]
+		for _, field := range group.accounts.fields() {
+			if checked[*field.value] {
+				continue
+			}
+			if err := s.requirePostingAccountType(ctx, schemaName, tenantID, *field.value, field.name, field.accountType); err != nil {
+				return nil, err
+			}
+			checked[*field.value] = true
+		}
+
+		lineDescription := description
+		if group.accounts.Department != "" {
+			lineDescription = description + " - " + group.accounts.Department
+		}
+		for _, line := range []struct {
+			accountID string
+			debit     decimal.Decimal
+			credit    decimal.Decimal
+			expense   bool
+		}{
+			{accountID: group.accounts.SalaryExpenseAccountID, debit: group.gross, expense: true},
+			{accountID: group.accounts.EmployerTaxExpenseAccountID, debit: group.employerTaxes, expense: true},
+			{accountID: group.accounts.IncomeTaxPayableAccountID, credit: group.incomeTax},
+			{accountID: group.accounts.SocialTaxPayableAccountID, credit: group.socialTax},
+			{accountID: group.accounts.UnemploymentPayableAccountID, credit: group.unemployment},
+			{accountID: group.accounts.FundedPensionPayableAccountID, credit: group.fundedPension},
+			{accountID: group.accounts.NetPayPayableAccountID, credit: group.netPay},
+		} {
+			if line.debit.IsZero() && line.credit.IsZero() {
+				continue
+			}
+			if line.expense && group.accounts.CostCenterID != nil {
+				journal.allocations[len(lines)] = *group.accounts.CostCenterID
+			}
+			lines = append(lines, accounting.CreateJournalEntryLineReq{
+				AccountID:    line.accountID,
+				Description:  lineDescription,
+				DebitAmount:  line.debit,
+				CreditAmount: line.credit,
+			})
+		}
+	}
+
+	sourceID := run.ID
+	journal.request = &accounting.CreateJournalEntryRequest{
+		EntryDate:   PayrollRunPostingDate(run),
+		Description: description,
+		Reference:   "PAYROLL-" + period,
+		SourceType:  SourceTypePayrollRun,
+		SourceID:    &sourceID,
+		UserID:      userID,
+		Lines:       lines,
+	}
+	return journal, nil
+}
+
+// postPayrollJournal creates and posts the payroll journal entry.
+func (s *Service) postPayrollJournal(ctx context.Context, schemaName, tenantID string, journal *payrollJournal, userID string) (*accounting.JournalEntry, error) {
+	entry, err := s.ledger.CreateJournalEntry(ctx, schemaName, tenantID, journal.request)
+	if err != nil {
+		return nil, fmt.Errorf("create payroll journal: %w", err)
+	}
+	if err := s.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Payroll run approval posting"); err != nil {
+		return nil, fmt.Errorf("post payroll journal: %w", err)
+	}
+	return entry, nil
+}
+
+func (s *Service) allocatePayrollJournal(ctx context.Context, schemaName, tenantID string, journal *payrollJournal, entry *accounting.JournalEntry) error {
+	if len(journal.allocations) == 0 {
+		return nil
+	}
+	if s.costCenters == nil {
+		return fmt.Errorf("%w: cost center service is unavailable", ErrPayrollPostingInvalid)
+	}
+	indexes := make([]int, 0, len(journal.allocations))
+	for index := range journal.allocations {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		if index >= len(entry.Lines) {
+			continue
+		}
+		line := entry.Lines[index]
+		if _, err := s.costCenters.CreateCostAllocation(ctx, schemaName, tenantID, &accounting.CreateCostAllocationRequest{
+			CostCenterID:       journal.allocations[index],
+			JournalEntryLineID: line.ID,
+			Amount:             line.DebitAmount,
+			AllocationDate:     entry.EntryDate,
+			Notes:              journal.request.Description,
+		}); err != nil {
+			return fmt.Errorf("allocate payroll cost: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) requirePostingAccountType(ctx context.Context, schemaName, tenantID, accountID, label string, accountType accounting.AccountType) error {
+	if s.ledger == nil {
+		return fmt.Errorf("%w: accounting service is unavailable", ErrPayrollPostingInvalid)
+	}
+	account, err := s.ledger.GetAccount(ctx, schemaName, tenantID, accountID)
+	if err != nil {
+		return fmt.Errorf("%w: load %s: %v", ErrPayrollPostingInvalid, label, err)
+	}
+	if account.AccountType != accountType {
+		return fmt.Errorf("%w: %s must be %s", ErrPayrollPostingInvalid, label, accountType)
+	}
+	if !account.IsActive {
+		return fmt.Errorf("%w: %s is inactive", ErrPayrollPostingInvalid, label)
+	}
+	return nil
+}
+
+func trimmedOptionalString(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,492 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type postingMockRepository struct {
+	*MockRepository
+	accounts  map[string]PayrollPostingAccounts
+	ledger    *fakePayrollLedger
+	allocator *fakePayrollCostAllocator
+	linkErr   error
+}
+
+func newPostingMockRepository() *postingMockRepository {
+	return &postingMockRepository{
+		MockRepository: NewMockRepository(),
+		accounts:       make(map[string]PayrollPostingAccounts),
+	}
+}
+
+func (m *postingMockRepository) ListPostingAccounts(ctx context.Context, schemaName, tenantID string) ([]PayrollPostingAccounts, error) {
+	result := []PayrollPostingAccounts{}
+	for _, accounts := range m.accounts {
+		if accounts.TenantID == tenantID {
+			result = append(result, accounts)
+		}
+	}
+	return result, nil
+}
+
+func (m *postingMockRepository) UpsertPostingAccounts(ctx context.Context, schemaName string, accounts *PayrollPostingAccounts) error {
+	m.accounts[accounts.Department] = *accounts
+	return nil
+}
+
+func (m *postingMockRepository) DeletePostingAccounts(ctx context.Context, schemaName, tenantID, department string) error {
+	if _, ok := m.accounts[department]; !ok {
+		return ErrPostingAccountsNotFound
+	}
+	delete(m.accounts, department)
+	return nil
+}
+
+// WithLedgerTransaction undoes the payroll run, journal entry and cost
+// allocation changes made by fn when it fails, like a rolled back transaction.
+func (m *postingMockRepository) WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error) error {
+	return m.withLedgerRollback(func(ledger payrollLedger, costCenters payrollCostAllocator) error {
+		return fn(m, ledger, costCenters, nil)
+	})
+}
+
+func (m *postingMockRepository) withLedgerRollback(fn func(ledger payrollLedger, costCenters payrollCostAllocator) error) error {
+	runs := make(map[string]PayrollRun, len(m.PayrollRuns))
+	for id, run := range m.PayrollRuns {
+		runs[id] = *run
+	}
+	var ledger payrollLedger
+	entries := make(map[string]accounting.JournalEntry)
+	var voided []string
+	if m.ledger != nil {
+		ledger = m.ledger
+		for id, entry := range m.ledger.entries {
+			entries[id] = *entry
+		}
+		voided = append(voided, m.ledger.voided...)
+	}
+	var costCenters payrollCostAllocator
+	allocations := make(map[string]accounting.CreateCostAllocationRequest)
+	if m.allocator != nil {
+		costCenters = m.allocator
+		for id, allocation := range m.allocator.allocations {
+			allocations[id] = allocation
+		}
+	}
+
+	err := fn(ledger, costCenters)
+	if err == nil {
+		return nil
+	}
+	for id := range m.PayrollRuns {
+		run := runs[id]
+		*m.PayrollRuns[id] = run
+	}
+	if m.ledger != nil {
+		for id := range m.ledger.entries {
+			if entry, ok := entries[id]; ok {
+				*m.ledger.entries[id] = entry
+			} else {
+				delete(m.ledger.entries, id)
+			}
+		}
+		m.ledger.voided = voided
+	}
+	if m.allocator != nil {
+		m.allocator.allocations = allocations
+	}
+	return err
+}
+
+func (m *postingMockRepository) SetPayrollRunJournalEntry(ctx context.Context, schemaName, tenantID, runID string, journalEntryID *string) error {
+	if m.linkErr != nil {
+		return m.linkErr
+	}
+	run, ok := m.PayrollRuns[runID]
+	if !ok {
+		return ErrPayrollRunNotFound
+	}
+	run.JournalEntryID = journalEntryID
+	return nil
+}
+
+func (m *postingMockRepository) ReopenPayrollRun(ctx context.Context, schemaName, tenantID, runID string) error {
+	run, ok := m.PayrollRuns[runID]
+	if !ok || run.Status != PayrollApproved {
+		return ErrPayrollRunNotFound
+	}
+	run.Status = PayrollDraft
+	run.ApprovedBy = ""
+	run.ApprovedAt = nil
+	run.JournalEntryID = nil
+	return nil
+}
+
+type fakePayrollLedger struct {
+	accounts map[string]accounting.Account
+	entries  map[string]*accounting.JournalEntry
+	voided   []string
+	nextID   int
+}
+
+func newFakePayrollLedger() *fakePayrollLedger {
+	ledger := &fakePayrollLedger{
+		accounts: make(map[string]accounting.Account),
+		entries:  make(map[string]*accounting.JournalEntry),
+	}
+	for id, accountType := range map[string]accounting.AccountType{
+		"salary":          accounting.AccountTypeExpense,
+		"employer-tax":    accounting.AccountTypeExpense,
+		"sales-salary":    accounting.AccountTypeExpense,
+		"income-tax":      accounting.AccountTypeLiability,
+		"social-tax":      accounting.AccountTypeLiability,
+		"unemployment":    accounting.AccountTypeLiability,
+		"funded-pension":  accounting.AccountTypeLiability,
+		"net-pay":         accounting.AccountTypeLiability,
+		"bank":            accounting.AccountTypeAsset,
+		"archived-salary": accounting.AccountTypeExpense,
+	} {
+		ledger.accounts[id] = accounting.Account{ID: id, AccountType: accountType, IsActive: id != "archived-salary"}
+	}
+	return ledger
+}
+
+func (l *fakePayrollLedger) GetAccount(ctx context.Context, schemaName, tenantID, accountID string) (*accounting.Account, error) {
+	account, ok := l.accounts[accountID]
+	if !ok {
+		return nil, fmt.Errorf("account not found")
+	}
+	return &account, nil
+}
+
+func (l *fakePayrollLedger) CreateJournalEntry(ctx context.Context, schemaName, tenantID string, req *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error) {
+	l.nextID++
+	entry := &accounting.JournalEntry{
+		ID:          fmt.Sprintf("je-%d", l.nextID),
+		TenantID:    tenantID,
+		EntryDate:   req.EntryDate,
+		Description: req.Description,
+		Reference:   req.Reference,
+		SourceType:  req.SourceType,
+		SourceID:    req.SourceID,
+		Status:      accounting.StatusDraft,
+	}
+	for i, line := range req.Lines {
+		entry.Lines = append(entry.Lines, accounting.JournalEntryLine{
+			ID:           fmt.Sprintf("%s-line-%d", entry.ID, i+1),
+			AccountID:    line.AccountID,
+			Description:  line.Description,
+			DebitAmount:  line.DebitAmount,
+			CreditAmount: line.CreditAmount,
+			BaseDebit:    line.DebitAmount,
+			BaseCredit:   line.CreditAmount,
+		})
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+	l.entries[entry.ID] = entry
+	return entry, nil
+}
+
+func (l *fakePayrollLedger) PostJournalEntry(ctx context.Context, schemaName, tenantID, entryID, userID, reason string) error {
+	l.entries[entryID].Status = accounting.StatusPosted
+	return nil
+}
+
+func (l *fakePayrollLedger) VoidJournalEntry(ctx context.Context, schemaName, tenantID, entryID, userID, reason string) (*accounting.JournalEntry, error) {
+	l.entries[entryID].Status = accounting.StatusVoided
+	l.voided = append(l.voided, entryID)
+	return &accounting.JournalEntry{ID: entryID + "-reversal"}, nil
+}
+
+func (l *fakePayrollLedger) GetJournalEntry(ctx context.Context, schemaName, tenantID, entryID string) (*accounting.JournalEntry, error) {
+	entry, ok := l.entries[entryID]
+	if !ok {
+		return nil, fmt.Errorf("journal entry not found")
+	}
+	return entry, nil
+}
+
+type fakePayrollCostAllocator struct {
+	allocations map[string]accounting.CreateCostAllocationRequest
+	deleteErr   error
+}
+
+func (a *fakePayrollCostAllocator) CreateCostAllocation(ctx context.Context, schemaName, tenantID string, req *accounting.CreateCostAllocationRequest) (*accounting.CostAllocation, error) {
+	a.allocations[req.JournalEntryLineID] = *req
+	return &accounting.CostAllocation{CostCenterID: req.CostCenterID, JournalEntryLineID: req.JournalEntryLineID, Amount: req.Amount}, nil
+}
+
+func (a *fakePayrollCostAllocator) DeleteCostAllocationsForJournalLines(ctx context.Context, schemaName, tenantID string, journalEntryLineIDs []string) error {
+	if a.deleteErr != nil {
+		return a.deleteErr
+	}
+	for _, id := range journalEntryLineIDs {
+		delete(a.allocations, id)
+	}
+	return nil
+}
+
+func defaultPostingAccountsRequest() *SetPayrollPostingAccountsRequest {
+	return &SetPayrollPostingAccountsRequest{
+		SalaryExpenseAccountID:        "salary",
+		EmployerTaxExpenseAccountID:   "employer-tax",
+		IncomeTaxPayableAccountID:     "income-tax",
+		SocialTaxPayableAccountID:     "social-tax",
+		UnemploymentPayableAccountID:  "unemployment",
+		FundedPensionPayableAccountID: "funded-pension",
+		NetPayPayableAccountID:        "net-pay",
+	}
+}
+
+func setupPostingService(t *testing.T) (*Service, *postingMockRepository, *fakePayrollLedger, *fakePayrollCostAllocator) {
+	t.Helper()
+	repo := newPostingMockRepository()
+	ledger := newFakePayrollLedger()
+	allocator := &fakePayrollCostAllocator{allocations: make(map[string]accounting.CreateCostAllocationRequest)}
+	repo.ledger, repo.allocator = ledger, allocator
+	service := NewServiceWithRepositoryAndAccounting(repo, &MockUUIDGenerator{prefix: "posting"}, ledger, allocator)
+
+	repo.Employees["emp-ops"] = &Employee{ID: "emp-ops", TenantID: "tenant-1", FirstName: "Mari", LastName: "Maasikas", Department: "Operations"}
+	repo.Employees["emp-sales"] = &Employee{ID: "emp-sales", TenantID: "tenant-1", FirstName: "Jaan", LastName: "Tamm", Department: "Sales"}
+	repo.PayrollRuns["run-1"] = &PayrollRun{ID: "run-1", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 2, Status: PayrollCalculated}
+	for _, employeeID := range []string{"emp-ops", "emp-sales"} {
+		calc := CalculateEstonianTaxes(decimal.NewFromInt(2000), DefaultBasicExemption, FundedPensionRateDefault)
+		repo.Payslips = append(repo.Payslips, Payslip{
+			ID:                      "payslip-" + employeeID,
+			TenantID:                "tenant-1",
+			PayrollRunID:            "run-1",
+			EmployeeID:              employeeID,
+			GrossSalary:             calc.GrossSalary,
+			IncomeTax:               calc.IncomeTax,
+			UnemploymentInsuranceEE: calc.UnemploymentEE,
+			FundedPension:           calc.FundedPension,
+			NetSalary:               calc.NetSalary,
+			SocialTax:               calc.SocialTax,
+			UnemploymentInsuranceER: calc.UnemploymentER,
+			TotalEmployerCost:       calc.TotalEmployerCost,
+		})
+	}
+	return service, repo, ledger, allocator
+}
+
+func TestSetPayrollPostingAccounts(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _, _ := setupPostingService(t)
+
+	incomplete := defaultPostingAccountsRequest()
+	incomplete.NetPayPayableAccountID = ""
+	_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", incomplete)
+	require.ErrorContains(t, err, "net pay payable account is required")
+
+	wrongType := defaultPostingAccountsRequest()
+	wrongType.NetPayPayableAccountID = "bank"
+	_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", wrongType)
+	require.ErrorIs(t, err, ErrPayrollPostingInvalid)
+	assert.ErrorContains(t, err, "net pay payable account must be LIABILITY")
+
+	inactive := defaultPostingAccountsRequest()
+	inactive.SalaryExpenseAccountID = "archived-salary"
+	_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", inactive)
+	require.ErrorContains(t, err, "salary expense account is inactive")
+
+	costCenter := "cc-1"
+	withCostCenter := defaultPostingAccountsRequest()
+	withCostCenter.CostCenterID = &costCenter
+	_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", withCostCenter)
+	require.ErrorContains(t, err, "cost center can only be set for a department")
+
+	_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", &SetPayrollPostingAccountsRequest{Department: "Sales"})
+	require.ErrorContains(t, err, "must set at least one account or a cost center")
+
+	saved, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+	require.NoError(t, err)
+	assert.Equal(t, "", saved.Department)
+	assert.Equal(t, "net-pay", saved.NetPayPayableAccountID)
+
+	department, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", &SetPayrollPostingAccountsRequest{
+		Department:             " Sales ",
+		CostCenterID:           &costCenter,
+		SalaryExpenseAccountID: "sales-salary",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", department.Department)
+
+	accounts, err := service.ListPayrollPostingAccounts(ctx, "tenant_test", "tenant-1")
+	require.NoError(t, err)
+	assert.Len(t, accounts, 2)
+
+	require.NoError(t, service.DeletePayrollPostingAccounts(ctx, "tenant_test", "tenant-1", "Sales"))
+	assert.ErrorIs(t, service.DeletePayrollPostingAccounts(ctx, "tenant_test", "tenant-1", "Sales"), ErrPostingAccountsNotFound)
+	assert.Len(t, repo.accounts, 1)
+}
+
+func TestApprovePayrollRunPostsJournalEntry(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, allocator := setupPostingService(t)
+
+	_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+	require.NoError(t, err)
+	costCenter := "cc-sales"
+	_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", &SetPayrollPostingAccountsRequest{
+		Department:             "sales",
+		CostCenterID:           &costCenter,
+		SalaryExpenseAccountID: "sales-salary",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1"))
+
+	run := repo.PayrollRuns["run-1"]
+	assert.Equal(t, PayrollApproved, run.Status)
+	require.NotNil(t, run.JournalEntryID)
+	entry := ledger.entries[*run.JournalEntryID]
+	require.NotNil(t, entry)
+	assert.Equal(t, accounting.StatusPosted, entry.Status)
+	assert.Equal(t, SourceTypePayrollRun, entry.SourceType)
+	require.NotNil(t, entry.SourceID)
+	assert.Equal(t, "run-1", *entry.SourceID)
+	assert.Equal(t, time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC), entry.EntryDate)
+	assert.Equal(t, "PAYROLL-2026-02", entry.Reference)
+	require.NoError(t, entry.Validate())
+
+	totals := map[string]decimal.Decimal{}
+	for _, line := range entry.Lines {
+		totals[line.AccountID] = totals[line.AccountID].Add(line.DebitAmount).Sub(line.CreditAmount)
+	}
+	calc := CalculateEstonianTaxes(decimal.NewFromInt(2000), DefaultBasicExemption, FundedPensionRateDefault)
+	assert.True(t, totals["salary"].Equal(calc.GrossSalary), totals["salary"].String())
+	assert.True(t, totals["sales-salary"].Equal(calc.GrossSalary), totals["sales-salary"].String())
+	assert.True(t, totals["employer-tax"].Equal(calc.SocialTax.Add(calc.UnemploymentER).Mul(decimal.NewFromInt(2))))
+	assert.True(t, totals["net-pay"].Equal(calc.NetSalary.Mul(decimal.NewFromInt(-2))))
+	assert.True(t, totals["income-tax"].Equal(calc.IncomeTax.Mul(decimal.NewFromInt(-2))))
+
+	require.Len(t, allocator.allocations, 2)
+	for _, allocation := range allocator.allocations {
+		assert.Equal(t, "cc-sales", allocation.CostCenterID)
+	}
+
+	reopened, err := service.ReopenPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1", "Missed overtime")
+	require.NoError(t, err)
+	assert.Equal(t, PayrollDraft, reopened.Status)
+	assert.Nil(t, reopened.JournalEntryID)
+	assert.Equal(t, []string{entry.ID}, ledger.voided)
+	assert.Empty(t, allocator.allocations)
+
+	_, err = service.ReopenPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1", "Again")
+	require.ErrorContains(t, err, "only APPROVED payroll runs can be reopened")
+}
+
+func TestReopenPayrollRunRollsBackWhenAllocationsCannotBeRemoved(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, allocator := setupPostingService(t)
+
+	_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+	require.NoError(t, err)
+	costCenter := "cc-sales"
+	_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", &SetPayrollPostingAccountsRequest{Department: "sales", CostCenterID: &costCenter})
+	require.NoError(t, err)
+	require.NoError(t, service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1"))
+	entryID := *repo.PayrollRuns["run-1"].JournalEntryID
+	allocator.deleteErr = errors.New("database unavailable")
+
+	_, err = service.ReopenPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1", "Missed overtime")
+	require.ErrorContains(t, err, "remove payroll cost allocations: database unavailable")
+	run := repo.PayrollRuns["run-1"]
+	assert.Equal(t, PayrollApproved, run.Status)
+	require.NotNil(t, run.JournalEntryID)
+	assert.Equal(t, entryID, *run.JournalEntryID)
+	assert.Equal(t, accounting.StatusPosted, ledger.entries[entryID].Status)
+	assert.Empty(t, ledger.voided)
+	assert.Len(t, allocator.allocations, 2)
+
+	allocator.deleteErr = nil
+	reopened, err := service.ReopenPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1", "Missed overtime")
+	require.NoError(t, err)
+	assert.Equal(t, PayrollDraft, reopened.Status)
+	assert.Equal(t, []string{entryID}, ledger.voided)
+	assert.Empty(t, allocator.allocations)
+}
+
+func TestApprovePayrollRunWithoutPostingAccounts(t *testing.T) {
+	ctx := context.Background()
+	service, repo, ledger, _ := setupPostingService(t)
+
+	require.NoError(t, service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1"))
+	assert.Equal(t, PayrollApproved, repo.PayrollRuns["run-1"].Status)
+	assert.Nil(t, repo.PayrollRuns["run-1"].JournalEntryID)
+	assert.Empty(t, ledger.entries)
+
+	_, err := service.ReopenPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1", " ")
+	require.ErrorContains(t, err, "reopen reason is required")
+	reopened, err := service.ReopenPayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1", "Wrong period")
+	require.NoError(t, err)
+	assert.Equal(t, PayrollDraft, reopened.Status)
+}
+
+func TestApprovePayrollRunPostingFailures(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("invalid account blocks approval", func(t *testing.T) {
+		service, repo, ledger, _ := setupPostingService(t)
+		_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+		require.NoError(t, err)
+		delete(ledger.accounts, "net-pay")
+
+		err = service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1")
+		require.ErrorIs(t, err, ErrPayrollPostingInvalid)
+		assert.Equal(t, PayrollCalculated, repo.PayrollRuns["run-1"].Status)
+		assert.Empty(t, ledger.entries)
+	})
+
+	t.Run("failed approval rolls back the posted entry", func(t *testing.T) {
+		service, repo, ledger, _ := setupPostingService(t)
+		_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+		require.NoError(t, err)
+		repo.ApprovePayrollRunErr = errors.New("database unavailable")
+
+		err = service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1")
+		require.ErrorContains(t, err, "approve payroll run: database unavailable")
+		assert.Empty(t, ledger.entries)
+		assert.Empty(t, ledger.voided)
+	})
+
+	t.Run("failed link rolls back the approval and allocations", func(t *testing.T) {
+		service, repo, ledger, allocator := setupPostingService(t)
+		_, err := service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", defaultPostingAccountsRequest())
+		require.NoError(t, err)
+		costCenter := "cc-sales"
+		_, err = service.SetPayrollPostingAccounts(ctx, "tenant_test", "tenant-1", &SetPayrollPostingAccountsRequest{Department: "sales", CostCenterID: &costCenter})
+		require.NoError(t, err)
+		repo.linkErr = errors.New("database unavailable")
+
+		err = service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1")
+		require.ErrorContains(t, err, "link payroll journal entry: database unavailable")
+		assert.Equal(t, PayrollCalculated, repo.PayrollRuns["run-1"].Status)
+		assert.Nil(t, repo.PayrollRuns["run-1"].JournalEntryID)
+		assert.Empty(t, ledger.entries)
+		assert.Empty(t, allocator.allocations)
+
+		repo.linkErr = nil
+		require.NoError(t, service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1"))
+		assert.Len(t, ledger.entries, 1)
+		assert.Len(t, allocator.allocations, 2)
+	})
+
+	t.Run("run must be calculated", func(t *testing.T) {
+		service, repo, _, _ := setupPostingService(t)
+		repo.PayrollRuns["run-1"].Status = PayrollDraft
+		err := service.ApprovePayrollRun(ctx, "tenant_test", "tenant-1", "run-1", "approver-1")
+		require.EqualError(t, err, "payroll run not found or not in CALCULATED status")
+	})
+}
@@ -3,6 +3,7 @@ package payroll
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/HMB-research/open-accounting/internal/workspace"
 )
@@ -121,3 +122,69 @@ func BuildPayrollRunRemediationActions(run *PayrollRun) []PayrollRunRemediationA
 
 	return actions
 }
+
+// BuildEmploymentRegisterRemediationActions adds a follow-up action for each
+// employee whose employment register events dated up to the end of the run
+// period have not been exported to TÖR yet.
+func BuildEmploymentRegisterRemediationActions(run *PayrollRun, pending []EmploymentRegisterPendingEmployee) []PayrollRunRemediationAction {
+	if run == nil || len(pending) == 0 {
+		return nil
+	}
+	if PayrollStatus(strings.ToUpper(strings.TrimSpace(string(run.Status)))) == PayrollDeclared {
+		return nil
+	}
+
+	period := fmt.Sprintf("%04d-%02d", run.PeriodYear, run.PeriodMonth)
+	periodEnd := time.Date(run.PeriodYear, time.Month(run.PeriodMonth)+1, 0, 0, 0, 0, 0, time.UTC)
+
+	actions := make([]PayrollRunRemediationAction, 0, len(pending))
+	for _, employee := range pending {
+		var earliest time.Time
+		count := 0
+		for _, event := range employee.Events {
+			if dateOnly(event.EventDate).After(periodEnd) {
+				continue
+			}
+			if count == 0 || event.EventDate.Before(earliest) {
+				earliest = dateOnly(event.EventDate)
+			}
+			count++
+		}
+		if count == 0 {
+			continue
+		}
+
+		const code = "employment_register_export_pending"
+		meta := workspace.RemediationAssignment(
+			"payroll_runs",
+			code,
+			"ACTION",
+			"employee",
+			employee.EmployeeID,
+			period,
+		)
+		name := employee.EmployeeName
+		if name == "" {
+			name = employee.EmployeeID
+		}
+		actions = append(actions, PayrollRunRemediationAction{
+			Code:           code,
+			Severity:       "ACTION",
+			Scope:          "payroll",
+			OwnerRole:      "accountant",
+			WorkspaceQueue: meta.WorkspaceQueue,
+			AssignmentKey:  meta.AssignmentKey,
+			Priority:       meta.Priority,
+			DueInDays:      meta.DueInDays,
+			Message:        fmt.Sprintf("%s has %d employment register change(s) not yet exported to TÖR.", name, count),
+			Action:         "Export the employment register file for the period and upload it to the Tax Board before paying salaries.",
+			Period:         period,
+			EntityType:     "employee",
+			EntityID:       employee.EmployeeID,
+			UIPath:         fmt.Sprintf("/employees?employee_id=%s", employee.EmployeeID),
+			CLICommand: fmt.Sprintf("oa employment-register export --from %s --to %s --output ./tor-%s.csv",
+				earliest.Format("2006-01-02"), periodEnd.Format("2006-01-02"), period),
+		})
+	}
+	return actions
+}
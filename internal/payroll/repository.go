@@ -10,9 +10,10 @@ import (
 )
 
 var (
-	ErrEmployeeNotFound       = errors.New("employee not found")
-	ErrPayrollRunNotFound     = errors.New("payroll run not found")
-	ErrTSDDeclarationNotFound = errors.New("TSD declaration not found")
+	ErrEmployeeNotFound        = errors.New("employee not found")
+	ErrPayrollRunNotFound      = errors.New("payroll run not found")
+	ErrTSDDeclarationNotFound  = errors.New("TSD declaration not found")
+	ErrPostingAccountsNotFound = errors.New("payroll posting accounts not found")
 )
 
 // UUIDGenerator provides IDs for payroll services.
@@ -66,3 +67,54 @@ type Repository interface {
 	// Transaction support
 	WithTransaction(ctx context.Context, fn func(txRepo Repository) error) error
 }
+
+// PostingRepository stores payroll general-ledger posting configuration and
+// run journal links. Repositories that do not implement it disable posting.
+type PostingRepository interface {
+	ListPostingAccounts(ctx context.Context, schemaName, tenantID string) ([]PayrollPostingAccounts, error)
+	UpsertPostingAccounts(ctx context.Context, schemaName string, accounts *PayrollPostingAccounts) error
+	DeletePostingAccounts(ctx context.Context, schemaName, tenantID, department string) error
+	SetPayrollRunJournalEntry(ctx context.Context, schemaName, tenantID, runID string, journalEntryID *string) error
+	ReopenPayrollRun(ctx context.Context, schemaName, tenantID, runID string) error
+}
+
+// LedgerTransactionRepository runs payroll writes together with general
+// ledger, cost allocation and outgoing payment writes in one database
+// transaction.
+type LedgerTransactionRepository interface {
+	WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error) error
+}
+
+// PaymentRepository records payroll salary payment files and the payslips they
+// paid. Repositories that do not implement it disable payroll payments.
+type PaymentRepository interface {
+	CreatePayrollPayment(ctx context.Context, schemaName string, payment *PayrollPayment, payslipIDs []string, markRunPaid bool) error
+	ListPayrollPayments(ctx context.Context, schemaName, tenantID, runID string) ([]PayrollPayment, error)
+}
+
+// LeavePayRepository supplies earnings history and approved leave for leave pay
+// and stores payslip pay lines. Repositories that do not implement it calculate
+// payslips from salary components only.
+type LeavePayRepository interface {
+	ListEarningsHistory(ctx context.Context, schemaName, tenantID, employeeID string, from, to time.Time) ([]EarningsHistoryEntry, error)
+	ListApprovedLeave(ctx context.Context, schemaName, tenantID string, from, to time.Time) ([]LeaveRecord, error)
+	CreatePayslipComponents(ctx context.Context, schemaName string, components []PayslipComponent) error
+	ListPayslipComponents(ctx context.Context, schemaName, tenantID, runID string) ([]PayslipComponent, error)
+}
+
+// TimesheetRepository stores daily timesheet entries for hourly pay.
+// Repositories that do not implement it disable timesheets.
+type TimesheetRepository interface {
+	ListTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter) ([]TimesheetEntry, error)
+	CreateTimesheetEntry(ctx context.Context, schemaName string, entry *TimesheetEntry) error
+	UpdateTimesheetEntry(ctx context.Context, schemaName string, entry *TimesheetEntry) error
+	ApproveTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter, approverID string, approvedAt time.Time) (int, error)
+}
+
+// EmploymentEventRepository stores the employment register history of
+// employees. Repositories that do not implement it disable TÖR tracking.
+type EmploymentEventRepository interface {
+	ListEmploymentEvents(ctx context.Context, schemaName, tenantID string, filter EmploymentEventFilter) ([]EmploymentEvent, error)
+	CreateEmploymentEvent(ctx context.Context, schemaName string, event *EmploymentEvent) error
+	MarkEmploymentEventsExported(ctx context.Context, schemaName, tenantID string, eventIDs []string, exportedAt time.Time) error
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/HMB-research/open-accounting/internal/accounting"
 	"github.com/HMB-research/open-accounting/internal/database"
 	"github.com/HMB-research/open-accounting/internal/models"
+	"github.com/HMB-research/open-accounting/internal/payments"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
@@ -48,6 +50,21 @@ func (r *GORMRepository) WithTransaction(ctx context.Context, fn func(txRepo Rep
 	})
 }
 
+// WithLedgerTransaction runs fn inside a GORM-backed transaction shared by the
+// payroll repository, the general ledger, cost allocations and payments.
+func (r *GORMRepository) WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(&GORMRepository{db: tx},
+			accounting.NewServiceWithRepository(accounting.NewGORMRepository(tx)),
+			accounting.NewCostCenterServiceWithRepository(accounting.NewCostCenterGORMRepository(tx)),
+			payments.NewServiceWithRepository(payments.NewGORMRepository(tx), nil))
+	})
+}
+
 // CreateEmployee inserts a new employee
 func (r *GORMRepository) CreateEmployee(ctx context.Context, schemaName string, emp *Employee) error {
 	db, err := r.tenantTable(ctx, schemaName, "employees")
@@ -130,6 +147,7 @@ func (r *GORMRepository) UpdateEmployee(ctx context.Context, schemaName string,
 			"apply_basic_exemption":  emp.ApplyBasicExemption,
 			"basic_exemption_amount": emp.BasicExemptionAmount.String(),
 			"funded_pension_rate":    emp.FundedPensionRate.String(),
+			"hourly_rate":            emp.HourlyRate.String(),
 			"is_active":              emp.IsActive,
 			"updated_at":             emp.UpdatedAt,
 		})
@@ -353,6 +371,8 @@ func (r *GORMRepository) GetPayslipsWithEmployees(ctx context.Context, schemaNam
 		EmployeeLastName     string
 		EmployeePersonalCode string
 		EmployeeEmail        string
+		EmployeeBankAccount  string
+		EmployeeDepartment   string
 	}
 	if err := db.Table(payslipsTable+" AS p").
 		Select(`
@@ -360,7 +380,9 @@ func (r *GORMRepository) GetPayslipsWithEmployees(ctx context.Context, schemaNam
 			e.first_name AS employee_first_name,
 			e.last_name AS employee_last_name,
 			e.personal_code AS employee_personal_code,
-			e.email AS employee_email
+			e.email AS employee_email,
+			COALESCE(e.bank_account, '') AS employee_bank_account,
+			COALESCE(e.department, '') AS employee_department
 		`).
 		Joins("JOIN "+employeesTable+" AS e ON e.id = p.employee_id").
 		Where("p.tenant_id = ? AND p.payroll_run_id = ?", tenantID, payrollRunID).
@@ -378,6 +400,8 @@ func (r *GORMRepository) GetPayslipsWithEmployees(ctx context.Context, schemaNam
 			LastName:     rows[i].EmployeeLastName,
 			PersonalCode: rows[i].EmployeePersonalCode,
 			Email:        rows[i].EmployeeEmail,
+			BankAccount:  rows[i].EmployeeBankAccount,
+			Department:   rows[i].EmployeeDepartment,
 		}
 		payslips[i] = payslip
 	}
@@ -562,6 +586,7 @@ func modelToEmployee(m *models.Employee) *Employee {
 		ApplyBasicExemption:  m.ApplyBasicExemption,
 		BasicExemptionAmount: m.BasicExemptionAmount.Decimal,
 		FundedPensionRate:    m.FundedPensionRate.Decimal,
+		HourlyRate:           m.HourlyRate.Decimal,
 		IsActive:             m.IsActive,
 		CreatedAt:            m.CreatedAt,
 		UpdatedAt:            m.UpdatedAt,
@@ -589,6 +614,7 @@ func employeeToModel(e *Employee) *models.Employee {
 		ApplyBasicExemption:  e.ApplyBasicExemption,
 		BasicExemptionAmount: models.Decimal{Decimal: e.BasicExemptionAmount},
 		FundedPensionRate:    models.Decimal{Decimal: e.FundedPensionRate},
+		HourlyRate:           models.Decimal{Decimal: e.HourlyRate},
 		IsActive:             e.IsActive,
 		CreatedAt:            e.CreatedAt,
 		UpdatedAt:            e.UpdatedAt,
@@ -617,6 +643,7 @@ func employeeCreateValues(e *Employee) map[string]interface{} {
 		"apply_basic_exemption":  m.ApplyBasicExemption,
 		"basic_exemption_amount": m.BasicExemptionAmount,
 		"funded_pension_rate":    m.FundedPensionRate,
+		"hourly_rate":            m.HourlyRate,
 		"is_active":              m.IsActive,
 		"created_at":             m.CreatedAt,
 		"updated_at":             m.UpdatedAt,
@@ -687,6 +714,7 @@ func modelToPayrollRun(m *models.PayrollRun) *PayrollRun {
 		CreatedBy:         stringValue(m.CreatedBy),
 		ApprovedBy:        stringValue(m.ApprovedBy),
 		ApprovedAt:        m.ApprovedAt,
+		JournalEntryID:    m.JournalEntryID,
 		CreatedAt:         m.CreatedAt,
 		UpdatedAt:         m.UpdatedAt,
 	}
@@ -707,6 +735,7 @@ func payrollRunToModel(r *PayrollRun) *models.PayrollRun {
 		CreatedBy:         stringPtrIfNotBlank(r.CreatedBy),
 		ApprovedBy:        stringPtrIfNotBlank(r.ApprovedBy),
 		ApprovedAt:        r.ApprovedAt,
+		JournalEntryID:    r.JournalEntryID,
 		CreatedAt:         r.CreatedAt,
 		UpdatedAt:         r.UpdatedAt,
 	}
@@ -799,6 +828,7 @@ func modelToPayslip(m *models.Payslip) *Payslip {
 		BasicExemptionApplied:   m.BasicExemptionApplied.Decimal,
 		PaymentStatus:           m.PaymentStatus,
 		PaidAt:                  m.PaidAt,
+		PayrollPaymentID:        m.PayrollPaymentID,
 		CreatedAt:               m.CreatedAt,
 	}
 }
@@ -947,3 +977,696 @@ func modelToTSDRow(m *models.TSDRow) *TSDRow {
 		CreatedAt:      m.CreatedAt,
 	}
 }
+
+// ListPostingAccounts returns the tenant default and department posting accounts.
+func (r *GORMRepository) ListPostingAccounts(ctx context.Context, schemaName, tenantID string) ([]PayrollPostingAccounts, error) {
+	db, err := r.tenantTable(ctx, schemaName, "payroll_posting_accounts")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.PayrollPostingAccounts
+	if err := db.Where("tenant_id = ?", tenantID).Order("department").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list payroll posting accounts: %w", err)
+	}
+	result := make([]PayrollPostingAccounts, 0, len(rows))
+	for i := range rows {
+		result = append(result, *modelToPostingAccounts(&rows[i]))
+	}
+	return result, nil
+}
+
+// UpsertPostingAccounts creates or replaces the posting accounts for a department.
+func (r *GORMRepository) UpsertPostingAccounts(ctx context.Context, schemaName string, accounts *PayrollPostingAccounts) error {
+	db, err := r.tenantTable(ctx, schemaName, "payroll_posting_accounts")
+	if err != nil {
+		return err
+	}
+
+	m := postingAccountsToModel(accounts)
+	result := db.Where("tenant_id = ? AND department = ?", m.TenantID, m.Department).
+		Updates(map[string]interface{}{
+			"cost_center_id":                    m.CostCenterID,
+			"salary_expense_account_id":         m.SalaryExpenseAccountID,
+			"employer_tax_expense_account_id":   m.EmployerTaxExpenseAccountID,
+			"income_tax_payable_account_id":     m.IncomeTaxPayableAccountID,
+			"social_tax_payable_account_id":     m.SocialTaxPayableAccountID,
+			"unemployment_payable_account_id":   m.UnemploymentPayableAccountID,
+			"funded_pension_payable_account_id": m.FundedPensionPayableAccountID,
+			"net_pay_payable_account_id":        m.NetPayPayableAccountID,
+			"updated_at":                        m.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("update payroll posting accounts: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	db, err = r.tenantTable(ctx, schemaName, "payroll_posting_accounts")
+	if err != nil {
+		return err
+	}
+	if err := db.Create(m).Error; err != nil {
+		return fmt.Errorf("create payroll posting accounts: %w", err)
+	}
+	return nil
+}
+
+// DeletePostingAccounts removes the posting accounts for a department.
+func (r *GORMRepository) DeletePostingAccounts(ctx context.Context, schemaName, tenantID, department string) error {
+	db, err := r.tenantTable(ctx, schemaName, "payroll_posting_accounts")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("tenant_id = ? AND department = ?", tenantID, department).Delete(&models.PayrollPostingAccounts{})
+	if result.Error != nil {
+		return fmt.Errorf("delete payroll posting accounts: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPostingAccountsNotFound
+	}
+	return nil
+}
+
+// SetPayrollRunJournalEntry links a payroll run to its general-ledger journal entry.
+func (r *GORMRepository) SetPayrollRunJournalEntry(ctx context.Context, schemaName, tenantID, runID string, journalEntryID *string) error {
+	db, err := r.tenantTable(ctx, schemaName, "payroll_runs")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("tenant_id = ? AND id = ?", tenantID, runID).
+		Updates(map[string]interface{}{
+			"journal_entry_id": journalEntryID,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("link payroll run journal entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPayrollRunNotFound
+	}
+	return nil
+}
+
+// ReopenPayrollRun returns an approved payroll run to DRAFT and clears its approval.
+func (r *GORMRepository) ReopenPayrollRun(ctx context.Context, schemaName, tenantID, runID string) error {
+	db, err := r.tenantTable(ctx, schemaName, "payroll_runs")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("tenant_id = ? AND id = ? AND status = ?", tenantID, runID, PayrollApproved).
+		Updates(map[string]interface{}{
+			"status":           PayrollDraft,
+			"approved_by":      nil,
+			"approved_at":      nil,
+			"journal_entry_id": nil,
+			"updated_at":       time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("reopen payroll run: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPayrollRunNotFound
+	}
+	return nil
+}
+
+func modelToPostingAccounts(m *models.PayrollPostingAccounts) *PayrollPostingAccounts {
+	return &PayrollPostingAccounts{
+		ID:                            m.ID,
+		TenantID:                      m.TenantID,
+		Department:                    m.Department,
+		CostCenterID:                  m.CostCenterID,
+		SalaryExpenseAccountID:        stringValue(m.SalaryExpenseAccountID),
+		EmployerTaxExpenseAccountID:   stringValue(m.EmployerTaxExpenseAccountID),
+		IncomeTaxPayableAccountID:     stringValue(m.IncomeTaxPayableAccountID),
+		SocialTaxPayableAccountID:     stringValue(m.SocialTaxPayableAccountID),
+		UnemploymentPayableAccountID:  stringValue(m.UnemploymentPayableAccountID),
+		FundedPensionPayableAccountID: stringValue(m.FundedPensionPayableAccountID),
+		NetPayPayableAccountID:        stringValue(m.NetPayPayableAccountID),
+		CreatedAt:                     m.CreatedAt,
+		UpdatedAt:                     m.UpdatedAt,
+	}
+}
+
+func postingAccountsToModel(a *PayrollPostingAccounts) *models.PayrollPostingAccounts {
+	return &models.PayrollPostingAccounts{
+		ID:                            a.ID,
+		TenantID:                      a.TenantID,
+		Department:                    a.Department,
+		CostCenterID:                  a.CostCenterID,
+		SalaryExpenseAccountID:        stringPtrIfNotBlank(a.SalaryExpenseAccountID),
+		EmployerTaxExpenseAccountID:   stringPtrIfNotBlank(a.EmployerTaxExpenseAccountID),
+		IncomeTaxPayableAccountID:     stringPtrIfNotBlank(a.IncomeTaxPayableAccountID),
+		SocialTaxPayableAccountID:     stringPtrIfNotBlank(a.SocialTaxPayableAccountID),
+		UnemploymentPayableAccountID:  stringPtrIfNotBlank(a.UnemploymentPayableAccountID),
+		FundedPensionPayableAccountID: stringPtrIfNotBlank(a.FundedPensionPayableAccountID),
+		NetPayPayableAccountID:        stringPtrIfNotBlank(a.NetPayPayableAccountID),
+		CreatedAt:                     a.CreatedAt,
+		UpdatedAt:                     a.UpdatedAt,
+	}
+}
+
+// CreatePayrollPayment stores a payroll payment file, links the paid payslips to
+// it and optionally marks the payroll run PAID, all in one transaction.
+func (r *GORMRepository) CreatePayrollPayment(ctx context.Context, schemaName string, payment *PayrollPayment, payslipIDs []string, markRunPaid bool) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	paymentsTable, err := database.QualifiedTable(schemaName, "payroll_payments")
+	if err != nil {
+		return err
+	}
+	payslipsTable, _ := database.QualifiedTable(schemaName, "payslips")
+	runsTable, _ := database.QualifiedTable(schemaName, "payroll_runs")
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Table(paymentsTable).Create(payrollPaymentToModel(payment)).Error; err != nil {
+			return fmt.Errorf("create payroll payment: %w", err)
+		}
+		if len(payslipIDs) > 0 {
+			result := tx.Table(payslipsTable).
+				Where("tenant_id = ? AND payroll_run_id = ? AND id IN ? AND payroll_payment_id IS NULL", payment.TenantID, payment.PayrollRunID, payslipIDs).
+				Updates(map[string]interface{}{
+					"payment_status":     "PAID",
+					"paid_at":            payment.ExecutionDate,
+					"payroll_payment_id": payment.ID,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("mark payslips paid: %w", result.Error)
+			}
+			if result.RowsAffected != int64(len(payslipIDs)) {
+				return fmt.Errorf("mark payslips paid: %d of %d payslips were already paid", int64(len(payslipIDs))-result.RowsAffected, len(payslipIDs))
+			}
+		}
+		if markRunPaid {
+			if err := tx.Table(runsTable).
+				Where("tenant_id = ? AND id = ?", payment.TenantID, payment.PayrollRunID).
+				Updates(map[string]interface{}{
+					"status":     PayrollPaid,
+					"updated_at": time.Now(),
+				}).Error; err != nil {
+				return fmt.Errorf("mark payroll run paid: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// ListPayrollPayments returns the payment files generated for a payroll run.
+func (r *GORMRepository) ListPayrollPayments(ctx context.Context, schemaName, tenantID, runID string) ([]PayrollPayment, error) {
+	db, err := r.tenantTable(ctx, schemaName, "payroll_payments")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.PayrollPayment
+	if err := db.Where("tenant_id = ? AND payroll_run_id = ?", tenantID, runID).Order("created_at").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list payroll payments: %w", err)
+	}
+	result := make([]PayrollPayment, 0, len(rows))
+	for i := range rows {
+		result = append(result, *modelToPayrollPayment(&rows[i]))
+	}
+	return result, nil
+}
+
+func modelToPayrollPayment(m *models.PayrollPayment) *PayrollPayment {
+	return &PayrollPayment{
+		ID:             m.ID,
+		TenantID:       m.TenantID,
+		PayrollRunID:   m.PayrollRunID,
+		BankAccountID:  m.BankAccountID,
+		PaymentID:      m.PaymentID,
+		JournalEntryID: m.JournalEntryID,
+		ExecutionDate:  m.ExecutionDate,
+		MessageID:      m.MessageID,
+		NetAmount:      m.NetAmount.Decimal,
+		TaxAmount:      m.TaxAmount.Decimal,
+		TaxReference:   m.TaxReference,
+		PayslipCount:   m.PayslipCount,
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+	}
+}
+
+func payrollPaymentToModel(p *PayrollPayment) *models.PayrollPayment {
+	return &models.PayrollPayment{
+		ID:             p.ID,
+		TenantID:       p.TenantID,
+		PayrollRunID:   p.PayrollRunID,
+		BankAccountID:  p.BankAccountID,
+		PaymentID:      p.PaymentID,
+		JournalEntryID: p.JournalEntryID,
+		ExecutionDate:  p.ExecutionDate,
+		MessageID:      p.MessageID,
+		NetAmount:      models.Decimal{Decimal: p.NetAmount},
+		TaxAmount:      models.Decimal{Decimal: p.TaxAmount},
+		TaxReference:   p.TaxReference,
+		PayslipCount:   p.PayslipCount,
+		CreatedBy:      p.CreatedBy,
+		CreatedAt:      p.CreatedAt,
+	}
+}
+
+// ListEarningsHistory returns an employee's finalized payslips, including
+// imported payroll history, for runs with a period in [from, to).
+func (r *GORMRepository) ListEarningsHistory(ctx context.Context, schemaName, tenantID, employeeID string, from, to time.Time) ([]EarningsHistoryEntry, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	payslipsTable, err := database.QualifiedTable(schemaName, "payslips")
+	if err != nil {
+		return nil, err
+	}
+	runsTable, _ := database.QualifiedTable(schemaName, "payroll_runs")
+	componentsTable, _ := database.QualifiedTable(schemaName, "payslip_components")
+
+	var rows []struct {
+		PayrollRunID    string
+		PeriodYear      int
+		PeriodMonth     int
+		GrossSalary     models.Decimal
+		AverageBasedPay models.Decimal
+	}
+	if err := db.Table(payslipsTable+" AS p").
+		Select(`
+			pr.id AS payroll_run_id,
+			pr.period_year,
+			pr.period_month,
+			p.gross_salary,
+			COALESCE((
+				SELECT SUM(pc.amount) FROM `+componentsTable+` AS pc
+				WHERE pc.payslip_id = p.id AND pc.component_type IN (?, ?)
+			), 0) AS average_based_pay
+		`, SalaryComponentVacationPay, SalaryComponentSickPay).
+		Joins("JOIN "+runsTable+" AS pr ON pr.id = p.payroll_run_id").
+		Where("p.tenant_id = ? AND p.employee_id = ?", tenantID, employeeID).
+		Where("pr.status IN ?", []string{string(PayrollApproved), string(PayrollPaid), string(PayrollDeclared)}).
+		Where("COALESCE(p.payment_status, '') <> ?", "CANCELLED"). //nolint:misspell // Payment status values use existing API/database spelling.
+		Where("make_date(pr.period_year, pr.period_month, 1) >= ? AND make_date(pr.period_year, pr.period_month, 1) < ?", from, to).
+		Order("pr.period_year, pr.period_month").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list earnings history: %w", err)
+	}
+
+	history := make([]EarningsHistoryEntry, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, EarningsHistoryEntry{
+			PayrollRunID:    row.PayrollRunID,
+			PeriodYear:      row.PeriodYear,
+			PeriodMonth:     row.PeriodMonth,
+			GrossSalary:     row.GrossSalary.Decimal,
+			AverageBasedPay: row.AverageBasedPay.Decimal,
+		})
+	}
+	return history, nil
+}
+
+// ListApprovedLeave returns approved leave records overlapping [from, to) with
+// their absence type pay settings.
+func (r *GORMRepository) ListApprovedLeave(ctx context.Context, schemaName, tenantID string, from, to time.Time) ([]LeaveRecord, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	leaveRecordsTable, err := database.QualifiedTable(schemaName, "leave_records")
+	if err != nil {
+		return nil, err
+	}
+	absenceTypesTable, _ := database.QualifiedTable(schemaName, "absence_types")
+
+	var rows []struct {
+		models.LeaveRecord
+		AbsenceTypeCode          string `gorm:"column:absence_type_code"`
+		AbsenceTypeName          string `gorm:"column:absence_type_name"`
+		AbsenceTypeNameET        string `gorm:"column:absence_type_name_et"`
+		AbsenceTypeIsPaid        bool   `gorm:"column:absence_type_is_paid"`
+		AbsenceTypeAffectsSalary bool   `gorm:"column:absence_type_affects_salary"`
+	}
+	if err := db.Table(leaveRecordsTable+" AS lr").
+		Select(`
+			lr.*,
+			at.code AS absence_type_code,
+			at.name AS absence_type_name,
+			at.name_et AS absence_type_name_et,
+			at.is_paid AS absence_type_is_paid,
+			at.affects_salary AS absence_type_affects_salary
+		`).
+		Joins("JOIN "+absenceTypesTable+" AS at ON at.id = lr.absence_type_id").
+		Where("lr.tenant_id = ? AND lr.status = ?", tenantID, string(LeaveApproved)).
+		Where("lr.start_date < ? AND lr.end_date >= ?", to, from).
+		Order("lr.employee_id, lr.start_date").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list approved leave: %w", err)
+	}
+
+	records := make([]LeaveRecord, len(rows))
+	for i := range rows {
+		record := *modelToLeaveRecord(&rows[i].LeaveRecord)
+		record.AbsenceType = &AbsenceType{
+			ID:            record.AbsenceTypeID,
+			Code:          rows[i].AbsenceTypeCode,
+			Name:          rows[i].AbsenceTypeName,
+			NameET:        rows[i].AbsenceTypeNameET,
+			IsPaid:        rows[i].AbsenceTypeIsPaid,
+			AffectsSalary: rows[i].AbsenceTypeAffectsSalary,
+		}
+		records[i] = record
+	}
+	return records, nil
+}
+
+// CreatePayslipComponents inserts payslip pay lines.
+func (r *GORMRepository) CreatePayslipComponents(ctx context.Context, schemaName string, components []PayslipComponent) error {
+	if len(components) == 0 {
+		return nil
+	}
+	db, err := r.tenantTable(ctx, schemaName, "payslip_components")
+	if err != nil {
+		return err
+	}
+	rows := make([]models.PayslipComponent, 0, len(components))
+	for i := range components {
+		rows = append(rows, *payslipComponentToModel(&components[i]))
+	}
+	if err := db.Create(&rows).Error; err != nil {
+		return fmt.Errorf("create payslip components: %w", err)
+	}
+	return nil
+}
+
+// ListPayslipComponents returns the pay lines of every payslip in a payroll run.
+func (r *GORMRepository) ListPayslipComponents(ctx context.Context, schemaName, tenantID, runID string) ([]PayslipComponent, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	componentsTable, err := database.QualifiedTable(schemaName, "payslip_components")
+	if err != nil {
+		return nil, err
+	}
+	payslipsTable, _ := database.QualifiedTable(schemaName, "payslips")
+
+	var rows []models.PayslipComponent
+	if err := db.Table(componentsTable+" AS pc").
+		Select("pc.*").
+		Joins("JOIN "+payslipsTable+" AS p ON p.id = pc.payslip_id").
+		Where("pc.tenant_id = ? AND p.payroll_run_id = ?", tenantID, runID).
+		Order("pc.payslip_id, pc.sort_order").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list payslip components: %w", err)
+	}
+	components := make([]PayslipComponent, 0, len(rows))
+	for i := range rows {
+		components = append(components, *modelToPayslipComponent(&rows[i]))
+	}
+	return components, nil
+}
+
+func modelToPayslipComponent(m *models.PayslipComponent) *PayslipComponent {
+	return &PayslipComponent{
+		ID:            m.ID,
+		TenantID:      m.TenantID,
+		PayslipID:     m.PayslipID,
+		ComponentType: m.ComponentType,
+		Name:          m.Name,
+		PaymentType:   m.PaymentType,
+		LeaveRecordID: m.LeaveRecordID,
+		Days:          m.Days.Decimal,
+		DailyRate:     m.DailyRate.Decimal,
+		Hours:         m.Hours.Decimal,
+		HourlyRate:    m.HourlyRate.Decimal,
+		Amount:        m.Amount.Decimal,
+		SortOrder:     m.SortOrder,
+		CreatedAt:     m.CreatedAt,
+	}
+}
+
+func payslipComponentToModel(c *PayslipComponent) *models.PayslipComponent {
+	return &models.PayslipComponent{
+		ID:            c.ID,
+		TenantID:      c.TenantID,
+		PayslipID:     c.PayslipID,
+		ComponentType: c.ComponentType,
+		Name:          c.Name,
+		PaymentType:   c.PaymentType,
+		LeaveRecordID: c.LeaveRecordID,
+		Days:          models.Decimal{Decimal: c.Days},
+		DailyRate:     models.Decimal{Decimal: c.DailyRate},
+		Hours:         models.Decimal{Decimal: c.Hours},
+		HourlyRate:    models.Decimal{Decimal: c.HourlyRate},
+		Amount:        models.Decimal{Decimal: c.Amount},
+		SortOrder:     c.SortOrder,
+		CreatedAt:     c.CreatedAt,
+	}
+}
+
+// ListTimesheetEntries returns timesheet entries matching the filter.
+func (r *GORMRepository) ListTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter) ([]TimesheetEntry, error) {
+	db, err := r.tenantTable(ctx, schemaName, "timesheet_entries")
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []models.TimesheetEntry
+	if err := applyTimesheetFilter(db.Where("tenant_id = ?", tenantID), filter).
+		Order("work_date, employee_id").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list timesheet entries: %w", err)
+	}
+	entries := make([]TimesheetEntry, 0, len(rows))
+	for i := range rows {
+		entries = append(entries, *modelToTimesheetEntry(&rows[i]))
+	}
+	return entries, nil
+}
+
+// CreateTimesheetEntry inserts a timesheet entry.
+func (r *GORMRepository) CreateTimesheetEntry(ctx context.Context, schemaName string, entry *TimesheetEntry) error {
+	db, err := r.tenantTable(ctx, schemaName, "timesheet_entries")
+	if err != nil {
+		return err
+	}
+	if err := db.Create(timesheetEntryToModel(entry)).Error; err != nil {
+		return fmt.Errorf("create timesheet entry: %w", err)
+	}
+	return nil
+}
+
+// UpdateTimesheetEntry updates the hours and notes of a pending timesheet entry.
+func (r *GORMRepository) UpdateTimesheetEntry(ctx context.Context, schemaName string, entry *TimesheetEntry) error {
+	db, err := r.tenantTable(ctx, schemaName, "timesheet_entries")
+	if err != nil {
+		return err
+	}
+	result := db.Where("tenant_id = ? AND id = ? AND status = ?", entry.TenantID, entry.ID, string(TimesheetPending)).
+		Updates(map[string]interface{}{
+			"regular_hours":  entry.RegularHours.String(),
+			"overtime_hours": entry.OvertimeHours.String(),
+			"night_hours":    entry.NightHours.String(),
+			"holiday_hours":  entry.HolidayHours.String(),
+			"notes":          entry.Notes,
+			"updated_at":     entry.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("update timesheet entry: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("timesheet entry %s is not pending", entry.ID)
+	}
+	return nil
+}
+
+// ApproveTimesheetEntries marks the timesheet entries matching the filter as approved.
+func (r *GORMRepository) ApproveTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter, approverID string, approvedAt time.Time) (int, error) {
+	db, err := r.tenantTable(ctx, schemaName, "timesheet_entries")
+	if err != nil {
+		return 0, err
+	}
+	var approvedBy *string
+	if approverID != "" {
+		approvedBy = &approverID
+	}
+	result := applyTimesheetFilter(db.Where("tenant_id = ?", tenantID), filter).
+		Updates(map[string]interface{}{
+			"status":      string(TimesheetApproved),
+			"approved_by": approvedBy,
+			"approved_at": approvedAt,
+			"updated_at":  approvedAt,
+		})
+	if result.Error != nil {
+		return 0, fmt.Errorf("approve timesheet entries: %w", result.Error)
+	}
+	return int(result.RowsAffected), nil
+}
+
+func applyTimesheetFilter(query *gorm.DB, filter TimesheetFilter) *gorm.DB {
+	if filter.EmployeeID != "" {
+		query = query.Where("employee_id = ?", filter.EmployeeID)
+	}
+	if filter.From != nil {
+		query = query.Where("work_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("work_date <= ?", *filter.To)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", string(filter.Status))
+	}
+	return query
+}
+
+func modelToTimesheetEntry(m *models.TimesheetEntry) *TimesheetEntry {
+	entry := &TimesheetEntry{
+		ID:            m.ID,
+		TenantID:      m.TenantID,
+		EmployeeID:    m.EmployeeID,
+		WorkDate:      m.WorkDate,
+		RegularHours:  m.RegularHours.Decimal,
+		OvertimeHours: m.OvertimeHours.Decimal,
+		NightHours:    m.NightHours.Decimal,
+		HolidayHours:  m.HolidayHours.Decimal,
+		Status:        TimesheetStatus(m.Status),
+		Notes:         m.Notes,
+		ApprovedAt:    m.ApprovedAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+	if m.ApprovedBy != nil {
+		entry.ApprovedBy = *m.ApprovedBy
+	}
+	return entry
+}
+
+func timesheetEntryToModel(e *TimesheetEntry) *models.TimesheetEntry {
+	m := &models.TimesheetEntry{
+		ID:            e.ID,
+		TenantID:      e.TenantID,
+		EmployeeID:    e.EmployeeID,
+		WorkDate:      e.WorkDate,
+		RegularHours:  models.Decimal{Decimal: e.RegularHours},
+		OvertimeHours: models.Decimal{Decimal: e.OvertimeHours},
+		NightHours:    models.Decimal{Decimal: e.NightHours},
+		HolidayHours:  models.Decimal{Decimal: e.HolidayHours},
+		Status:        string(e.Status),
+		Notes:         e.Notes,
+		ApprovedAt:    e.ApprovedAt,
+		CreatedAt:     e.CreatedAt,
+		UpdatedAt:     e.UpdatedAt,
+	}
+	if e.ApprovedBy != "" {
+		approvedBy := e.ApprovedBy
+		m.ApprovedBy = &approvedBy
+	}
+	return m
+}
+
+// ListEmploymentEvents returns employment register events matching the filter in date order.
+func (r *GORMRepository) ListEmploymentEvents(ctx context.Context, schemaName, tenantID string, filter EmploymentEventFilter) ([]EmploymentEvent, error) {
+	db, err := r.tenantTable(ctx, schemaName, "employment_events")
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter.EmployeeID != "" {
+		query = query.Where("employee_id = ?", filter.EmployeeID)
+	}
+	if filter.From != nil {
+		query = query.Where("event_date >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("event_date <= ?", *filter.To)
+	}
+	if filter.PendingOnly {
+		query = query.Where("exported_at IS NULL")
+	}
+
+	var rows []models.EmploymentEvent
+	if err := query.Order("event_date, created_at").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list employment events: %w", err)
+	}
+	events := make([]EmploymentEvent, 0, len(rows))
+	for i := range rows {
+		events = append(events, *modelToEmploymentEvent(&rows[i]))
+	}
+	return events, nil
+}
+
+// CreateEmploymentEvent inserts an employment register event.
+func (r *GORMRepository) CreateEmploymentEvent(ctx context.Context, schemaName string, event *EmploymentEvent) error {
+	db, err := r.tenantTable(ctx, schemaName, "employment_events")
+	if err != nil {
+		return err
+	}
+	if err := db.Create(employmentEventToModel(event)).Error; err != nil {
+		return fmt.Errorf("create employment event: %w", err)
+	}
+	return nil
+}
+
+// MarkEmploymentEventsExported records that the events were included in a TÖR upload file.
+func (r *GORMRepository) MarkEmploymentEventsExported(ctx context.Context, schemaName, tenantID string, eventIDs []string, exportedAt time.Time) error {
+	if len(eventIDs) == 0 {
+		return nil
+	}
+	db, err := r.tenantTable(ctx, schemaName, "employment_events")
+	if err != nil {
+		return err
+	}
+	if err := db.Where("tenant_id = ? AND id IN ?", tenantID, eventIDs).
+		Update("exported_at", exportedAt).Error; err != nil {
+		return fmt.Errorf("mark employment events exported: %w", err)
+	}
+	return nil
+}
+
+func modelToEmploymentEvent(m *models.EmploymentEvent) *EmploymentEvent {
+	event := &EmploymentEvent{
+		ID:               m.ID,
+		TenantID:         m.TenantID,
+		EmployeeID:       m.EmployeeID,
+		EventType:        EmploymentEventType(m.EventType),
+		EventDate:        m.EventDate,
+		TerminationCode:  m.TerminationCode,
+		SuspensionReason: m.SuspensionReason,
+		WorkingTimeRatio: m.WorkingTimeRatio.Decimal,
+		Position:         m.Position,
+		Notes:            m.Notes,
+		ExportedAt:       m.ExportedAt,
+		CreatedAt:        m.CreatedAt,
+	}
+	if m.CreatedBy != nil {
+		event.CreatedBy = *m.CreatedBy
+	}
+	return event
+}
+
+func employmentEventToModel(e *EmploymentEvent) *models.EmploymentEvent {
+	m := &models.EmploymentEvent{
+		ID:               e.ID,
+		TenantID:         e.TenantID,
+		EmployeeID:       e.EmployeeID,
+		EventType:        string(e.EventType),
+		EventDate:        e.EventDate,
+		TerminationCode:  e.TerminationCode,
+		SuspensionReason: e.SuspensionReason,
+		WorkingTimeRatio: models.Decimal{Decimal: e.WorkingTimeRatio},
+		Position:         e.Position,
+		Notes:            e.Notes,
+		ExportedAt:       e.ExportedAt,
+		CreatedAt:        e.CreatedAt,
+	}
+	if e.CreatedBy != "" {
+		createdBy := e.CreatedBy
+		m.CreatedBy = &createdBy
+	}
+	return m
+}
@@ -434,6 +434,16 @@ func TestPayrollGORMRepositoryDryRunCoreOperations(t *testing.T) {
 	}))
 	assert.True(t, called)
 
+	called = false
+	require.NoError(t, repo.WithLedgerTransaction(ctx, func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error {
+		called = true
+		assert.NotNil(t, ledger)
+		assert.NotNil(t, costCenters)
+		assert.NotNil(t, recorder)
+		return txRepo.CreateEmployee(ctx, schemaName, modelToEmployee(&employee))
+	}))
+	assert.True(t, called)
+
 	require.NoError(t, repo.CreateEmployee(ctx, schemaName, modelToEmployee(&employee)))
 	gotEmployee, err := repo.GetEmployee(ctx, schemaName, tenantID, employee.ID)
 	require.NoError(t, err)
@@ -107,6 +107,18 @@ func TestGORMRepositoryNilDatabase(t *testing.T) {
 				return err
 			},
 		},
+		{
+			name: "WithLedgerTransaction",
+			run: func(t *testing.T) error {
+				called := false
+				err := repo.WithLedgerTransaction(ctx, func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error {
+					called = true
+					return nil
+				})
+				assert.False(t, called)
+				return err
+			},
+		},
 		{
 			name: "CreateEmployee",
 			run: func(t *testing.T) error {
@@ -305,6 +317,7 @@ func TestEmployeeModelMappings(t *testing.T) {
 		ApplyBasicExemption:  true,
 		BasicExemptionAmount: decimal.NewFromInt(500),
 		FundedPensionRate:    decimal.NewFromFloat(0.04),
+		HourlyRate:           decimal.RequireFromString("14.5"),
 		IsActive:             true,
 		CreatedAt:            now,
 		UpdatedAt:            now.Add(time.Hour),
@@ -331,6 +344,7 @@ func TestEmployeeModelMappings(t *testing.T) {
 	assert.Equal(t, employee.ApplyBasicExemption, model.ApplyBasicExemption)
 	requireDecimalEqual(t, model.BasicExemptionAmount.Decimal, employee.BasicExemptionAmount)
 	requireDecimalEqual(t, model.FundedPensionRate.Decimal, employee.FundedPensionRate)
+	requireDecimalEqual(t, model.HourlyRate.Decimal, employee.HourlyRate)
 	assert.Equal(t, employee.IsActive, model.IsActive)
 	assert.Equal(t, employee.CreatedAt, model.CreatedAt)
 	assert.Equal(t, employee.UpdatedAt, model.UpdatedAt)
@@ -344,10 +358,11 @@ func TestEmployeeModelMappings(t *testing.T) {
 	assert.Equal(t, employee.EmploymentType, roundTrip.EmploymentType)
 	requireDecimalEqual(t, roundTrip.BasicExemptionAmount, employee.BasicExemptionAmount)
 	requireDecimalEqual(t, roundTrip.FundedPensionRate, employee.FundedPensionRate)
+	requireDecimalEqual(t, roundTrip.HourlyRate, employee.HourlyRate)
 	assert.Equal(t, employee.IsActive, roundTrip.IsActive)
 
 	values := employeeCreateValues(employee)
-	require.Len(t, values, 22)
+	require.Len(t, values, 23)
 	assert.Equal(t, employee.ID, values["id"])
 	assert.Equal(t, employee.TenantID, values["tenant_id"])
 	assert.Equal(t, employee.EmployeeNumber, values["employee_number"])
@@ -356,6 +371,7 @@ func TestEmployeeModelMappings(t *testing.T) {
 	assert.Equal(t, employee.EndDate, values["end_date"])
 	requireDecimalEqual(t, values["basic_exemption_amount"].(models.Decimal).Decimal, employee.BasicExemptionAmount)
 	requireDecimalEqual(t, values["funded_pension_rate"].(models.Decimal).Decimal, employee.FundedPensionRate)
+	requireDecimalEqual(t, values["hourly_rate"].(models.Decimal).Decimal, employee.HourlyRate)
 	assert.Equal(t, employee.UpdatedAt, values["updated_at"])
 }
 
@@ -645,6 +661,43 @@ func TestTSDRowModelMappings(t *testing.T) {
 	requireDecimalEqual(t, values["funded_pension"].(models.Decimal).Decimal, row.FundedPension)
 }
 
+func TestEmploymentEventModelMappings(t *testing.T) {
+	now := time.Date(2026, 6, 7, 8, 9, 10, 0, time.UTC)
+	event := &EmploymentEvent{
+		ID:               uuid.NewString(),
+		TenantID:         uuid.NewString(),
+		EmployeeID:       uuid.NewString(),
+		EventType:        EmploymentEventEnd,
+		EventDate:        time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC),
+		TerminationCode:  "TLS79",
+		WorkingTimeRatio: decimal.NewFromFloat(0.75),
+		Position:         "Accountant",
+		Notes:            "Mutual agreement",
+		ExportedAt:       &now,
+		CreatedBy:        uuid.NewString(),
+		CreatedAt:        now,
+	}
+
+	model := employmentEventToModel(event)
+
+	assert.Equal(t, event.ID, model.ID)
+	assert.Equal(t, string(event.EventType), model.EventType)
+	assert.Equal(t, event.TerminationCode, model.TerminationCode)
+	requireDecimalEqual(t, model.WorkingTimeRatio.Decimal, event.WorkingTimeRatio)
+	require.NotNil(t, model.CreatedBy)
+	assert.Equal(t, event.CreatedBy, *model.CreatedBy)
+
+	roundTrip := modelToEmploymentEvent(model)
+	assert.Equal(t, event.EventType, roundTrip.EventType)
+	assert.Equal(t, event.EventDate, roundTrip.EventDate)
+	assert.Equal(t, event.ExportedAt, roundTrip.ExportedAt)
+	assert.Equal(t, event.CreatedBy, roundTrip.CreatedBy)
+	requireDecimalEqual(t, roundTrip.WorkingTimeRatio, event.WorkingTimeRatio)
+
+	event.CreatedBy = ""
+	assert.Nil(t, employmentEventToModel(event).CreatedBy)
+}
+
 func TestPayrollStringPointerHelpers(t *testing.T) {
 	require.Nil(t, stringPtrIfNotBlank(""))
 
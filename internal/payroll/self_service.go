@@ -0,0 +1,92 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrPayslipNotFound is returned when a payslip is missing or not visible to the employee.
+var ErrPayslipNotFound = errors.New("payslip not found")
+
+// EmployeePayslip is one released payslip in an employee's self-service history.
+type EmployeePayslip struct {
+	Payslip
+	PeriodYear  int           `json:"period_year"`
+	PeriodMonth int           `json:"period_month"`
+	RunStatus   PayrollStatus `json:"run_status"`
+	PaymentDate *time.Time    `json:"payment_date,omitempty"`
+}
+
+// IsPayslipReleased reports whether payslips of a run may be shown to employees.
+// Draft and calculated runs can still change, so only approved runs and later
+// are released.
+func IsPayslipReleased(status PayrollStatus) bool {
+	switch status {
+	case PayrollApproved, PayrollPaid, PayrollDeclared:
+		return true
+	default:
+		return false
+	}
+}
+
+// ListEmployeePayslips returns an employee's payslips from released payroll
+// runs, newest period first. A zero year returns every period.
+func (s *Service) ListEmployeePayslips(ctx context.Context, schemaName, tenantID, employeeID string, year int) ([]EmployeePayslip, error) {
+	employeeID = strings.TrimSpace(employeeID)
+	if employeeID == "" {
+		return nil, fmt.Errorf("employee id is required")
+	}
+
+	runs, err := s.repo.ListPayrollRuns(ctx, schemaName, tenantID, year)
+	if err != nil {
+		return nil, fmt.Errorf("list payroll runs: %w", err)
+	}
+
+	result := []EmployeePayslip{}
+	for i := range runs {
+		run := &runs[i]
+		if !IsPayslipReleased(run.Status) {
+			continue
+		}
+		payslips, err := s.GetPayslipsWithEmployees(ctx, schemaName, tenantID, run.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, payslip := range payslips {
+			if payslip.EmployeeID != employeeID {
+				continue
+			}
+			result = append(result, EmployeePayslip{
+				Payslip:     payslip,
+				PeriodYear:  run.PeriodYear,
+				PeriodMonth: run.PeriodMonth,
+				RunStatus:   run.Status,
+				PaymentDate: run.PaymentDate,
+			})
+		}
+	}
+	return result, nil
+}
+
+// GetEmployeePayslip returns one released payslip together with its payroll run
+// when it belongs to the employee. Any other payslip is reported as not found.
+func (s *Service) GetEmployeePayslip(ctx context.Context, schemaName, tenantID, employeeID, payslipID string) (*Payslip, *PayrollRun, error) {
+	payslips, err := s.ListEmployeePayslips(ctx, schemaName, tenantID, employeeID, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range payslips {
+		if payslips[i].ID != payslipID {
+			continue
+		}
+		run, err := s.GetPayrollRun(ctx, schemaName, tenantID, payslips[i].PayrollRunID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &payslips[i].Payslip, run, nil
+	}
+	return nil, nil, ErrPayslipNotFound
+}
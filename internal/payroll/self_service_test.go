@@ -0,0 +1,95 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSelfServiceTestService() (*Service, *MockRepository) {
+	repo := NewMockRepository()
+	paymentDate := time.Date(2026, time.February, 10, 0, 0, 0, 0, time.UTC)
+	for _, run := range []*PayrollRun{
+		{ID: "run-dec", TenantID: "tenant-1", PeriodYear: 2025, PeriodMonth: 12, Status: PayrollDeclared},
+		{ID: "run-jan", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 1, Status: PayrollPaid, PaymentDate: &paymentDate},
+		{ID: "run-feb", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 2, Status: PayrollCalculated},
+	} {
+		repo.PayrollRuns[run.ID] = run
+	}
+	for _, runID := range []string{"run-dec", "run-jan", "run-feb"} {
+		for _, employeeID := range []string{"emp-1", "emp-2"} {
+			repo.Payslips = append(repo.Payslips, Payslip{
+				ID:           runID + "-" + employeeID,
+				TenantID:     "tenant-1",
+				PayrollRunID: runID,
+				EmployeeID:   employeeID,
+				GrossSalary:  decimal.NewFromInt(2000),
+			})
+		}
+	}
+	return NewServiceWithRepository(repo, &DefaultUUIDGenerator{}), repo
+}
+
+func TestListEmployeePayslipsReturnsOnlyReleasedOwnPayslips(t *testing.T) {
+	service, _ := newSelfServiceTestService()
+	ctx := context.Background()
+
+	payslips, err := service.ListEmployeePayslips(ctx, "tenant_acme", "tenant-1", "emp-1", 0)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(payslips))
+	for _, payslip := range payslips {
+		assert.Equal(t, "emp-1", payslip.EmployeeID)
+		ids = append(ids, payslip.ID)
+		if payslip.PayrollRunID == "run-jan" {
+			assert.Equal(t, 2026, payslip.PeriodYear)
+			assert.Equal(t, 1, payslip.PeriodMonth)
+			assert.Equal(t, PayrollPaid, payslip.RunStatus)
+			require.NotNil(t, payslip.PaymentDate)
+		}
+	}
+	assert.ElementsMatch(t, []string{"run-dec-emp-1", "run-jan-emp-1"}, ids)
+
+	payslips, err = service.ListEmployeePayslips(ctx, "tenant_acme", "tenant-1", "emp-1", 2026)
+	require.NoError(t, err)
+	require.Len(t, payslips, 1)
+	assert.Equal(t, "run-jan-emp-1", payslips[0].ID)
+
+	_, err = service.ListEmployeePayslips(ctx, "tenant_acme", "tenant-1", " ", 0)
+	assert.EqualError(t, err, "employee id is required")
+}
+
+func TestGetEmployeePayslipHidesOtherEmployeesAndUnreleasedRuns(t *testing.T) {
+	service, repo := newSelfServiceTestService()
+	ctx := context.Background()
+
+	payslip, run, err := service.GetEmployeePayslip(ctx, "tenant_acme", "tenant-1", "emp-1", "run-jan-emp-1")
+	require.NoError(t, err)
+	assert.Equal(t, "run-jan-emp-1", payslip.ID)
+	assert.Equal(t, "run-jan", run.ID)
+
+	for _, payslipID := range []string{"run-jan-emp-2", "run-feb-emp-1", "missing"} {
+		_, _, err = service.GetEmployeePayslip(ctx, "tenant_acme", "tenant-1", "emp-1", payslipID)
+		assert.ErrorIs(t, err, ErrPayslipNotFound, payslipID)
+	}
+
+	repo.GetPayslipsErr = errors.New("boom")
+	_, _, err = service.GetEmployeePayslip(ctx, "tenant_acme", "tenant-1", "emp-1", "run-jan-emp-1")
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestIsPayslipReleased(t *testing.T) {
+	for status, released := range map[PayrollStatus]bool{
+		PayrollDraft:      false,
+		PayrollCalculated: false,
+		PayrollApproved:   true,
+		PayrollPaid:       true,
+		PayrollDeclared:   true,
+	} {
+		assert.Equal(t, released, IsPayslipReleased(status), status)
+	}
+}
@@ -6,15 +6,26 @@ import (
 	"strings"
 	"time"
 
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/banking"
 	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/payments"
+	"github.com/HMB-research/open-accounting/internal/tenant"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
 )
 
 // Service provides payroll operations
 type Service struct {
-	repo Repository
-	uuid UUIDGenerator
+	repo            Repository
+	uuid            UUIDGenerator
+	posting         PostingRepository
+	ledger          payrollLedger
+	costCenters     payrollCostAllocator
+	payrollPayments PaymentRepository
+	banks           payrollBankAccounts
+	paymentRecorder payrollPaymentRecorder
+	tenants         payrollTenantLookup
 }
 
 var newGormDBFromPool = database.NewGormDBFromPool
@@ -30,32 +41,114 @@ func NewService(db *pgxpool.Pool) *Service {
 	if err != nil {
 		panic(fmt.Errorf("create payroll GORM repository: %w", err))
 	}
+	repo := NewGORMRepository(gormDB)
 	return &Service{
-		repo: NewGORMRepository(gormDB),
-		uuid: &DefaultUUIDGenerator{},
+		repo:            repo,
+		uuid:            &DefaultUUIDGenerator{},
+		posting:         repo,
+		ledger:          accounting.NewServiceWithRepository(accounting.NewGORMRepository(gormDB)),
+		costCenters:     accounting.NewCostCenterServiceWithRepository(accounting.NewCostCenterGORMRepository(gormDB)),
+		payrollPayments: repo,
+		banks:           banking.NewServiceWithGORM(gormDB),
+		paymentRecorder: payments.NewServiceWithRepository(payments.NewGORMRepository(gormDB), nil),
+		tenants:         tenant.NewServiceWithRepository(tenant.NewGORMRepository(gormDB)),
 	}
 }
 
 // NewServiceWithRepository creates a new payroll service with a custom repository (for testing)
 func NewServiceWithRepository(repo Repository, uuidGen UUIDGenerator) *Service {
-	return &Service{
-		repo: repo,
-		uuid: uuidGen,
+	return NewServiceWithRepositoryAndAccounting(repo, uuidGen, nil, nil)
+}
+
+// NewServiceWithRepositoryAndAccounting creates a payroll service with a custom
+// repository, ledger and cost-center allocator. General-ledger posting is
+// enabled when the repository also implements PostingRepository.
+func NewServiceWithRepositoryAndAccounting(repo Repository, uuidGen UUIDGenerator, ledger payrollLedger, costCenters payrollCostAllocator) *Service {
+	service := &Service{
+		repo:        repo,
+		uuid:        uuidGen,
+		ledger:      ledger,
+		costCenters: costCenters,
+	}
+	if posting, ok := repo.(PostingRepository); ok {
+		service.posting = posting
 	}
+	if payrollPayments, ok := repo.(PaymentRepository); ok {
+		service.payrollPayments = payrollPayments
+	}
+	return service
+}
+
+// WithPaymentServices enables payroll payment files using the given bank
+// account lookup, outgoing payment recorder and tenant lookup. Payments also
+// require a repository that implements PaymentRepository.
+func (s *Service) WithPaymentServices(banks payrollBankAccounts, recorder payrollPaymentRecorder, tenants payrollTenantLookup) *Service {
+	s.banks = banks
+	s.paymentRecorder = recorder
+	s.tenants = tenants
+	return s
 }
 
 // =============================================================================
 // EMPLOYEE OPERATIONS
 // =============================================================================
 
-// CreateEmployee creates a new employee
+// CreateEmployee creates a new employee. When employment register events are
+// stored, the hire is recorded as a START event pending TÖR export.
 func (s *Service) CreateEmployee(ctx context.Context, schemaName, tenantID string, req *CreateEmployeeRequest) (*Employee, error) {
+	if _, ok := s.repo.(EmploymentEventRepository); !ok {
+		return s.createEmployee(ctx, schemaName, tenantID, req)
+	}
+	ratio, err := employmentWorkingTimeRatio(req.WorkingTimeRatio, decimal.NewFromInt(1))
+	if err != nil {
+		return nil, err
+	}
+
+	var emp *Employee
+	err = s.repo.WithTransaction(ctx, func(txRepo Repository) error {
+		events, ok := txRepo.(EmploymentEventRepository)
+		if !ok {
+			return ErrEmploymentEventsUnavailable
+		}
+		txService := &Service{repo: txRepo, uuid: s.uuid}
+
+		created, err := txService.createEmployee(ctx, schemaName, tenantID, req)
+		if err != nil {
+			return err
+		}
+		if err := events.CreateEmploymentEvent(ctx, schemaName, &EmploymentEvent{
+			ID:               s.uuid.New(),
+			TenantID:         tenantID,
+			EmployeeID:       created.ID,
+			EventType:        EmploymentEventStart,
+			EventDate:        dateOnly(created.StartDate),
+			WorkingTimeRatio: ratio,
+			Position:         created.Position,
+			CreatedAt:        created.CreatedAt,
+		}); err != nil {
+			return fmt.Errorf("create employment event: %w", err)
+		}
+		emp = created
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return emp, nil
+}
+
+// createEmployee creates an employee record without register events. Imports
+// use it directly because imported employees are already registered.
+func (s *Service) createEmployee(ctx context.Context, schemaName, tenantID string, req *CreateEmployeeRequest) (*Employee, error) {
 	if req.FirstName == "" || req.LastName == "" {
 		return nil, fmt.Errorf("first name and last name are required")
 	}
 	if req.StartDate.IsZero() {
 		return nil, fmt.Errorf("start date is required")
 	}
+	if req.HourlyRate.IsNegative() {
+		return nil, fmt.Errorf("hourly rate must be zero or greater")
+	}
 
 	// Set defaults
 	if req.EmploymentType == "" {
@@ -84,6 +177,7 @@ func (s *Service) CreateEmployee(ctx context.Context, schemaName, tenantID strin
 		ApplyBasicExemption:  req.ApplyBasicExemption,
 		BasicExemptionAmount: req.BasicExemptionAmount,
 		FundedPensionRate:    req.FundedPensionRate,
+		HourlyRate:           req.HourlyRate,
 		IsActive:             true,
 		CreatedAt:            time.Now(),
 		UpdatedAt:            time.Now(),
@@ -171,6 +265,12 @@ func (s *Service) UpdateEmployee(ctx context.Context, schemaName, tenantID, empl
 	if req.FundedPensionRate != nil {
 		emp.FundedPensionRate = *req.FundedPensionRate
 	}
+	if req.HourlyRate != nil {
+		if req.HourlyRate.IsNegative() {
+			return nil, fmt.Errorf("hourly rate must be zero or greater")
+		}
+		emp.HourlyRate = *req.HourlyRate
+	}
 	if req.IsActive != nil {
 		emp.IsActive = *req.IsActive
 	}
@@ -354,7 +454,7 @@ func (s *Service) CreatePayrollRun(ctx context.Context, schemaName, tenantID, us
 		return nil, fmt.Errorf("create payroll run: %w", err)
 	}
 
-	run.RemediationActions = BuildPayrollRunRemediationActions(run)
+	run.RemediationActions = payrollRunRemediationActions(run, s.pendingEmploymentRegisterEmployees(ctx, schemaName, tenantID))
 	return run, nil
 }
 
@@ -376,6 +476,39 @@ func (s *Service) CalculatePayroll(ctx context.Context, schemaName, tenantID, pa
 		return nil, err
 	}
 
+	// Approved leave in the period turns into pay lines when the repository
+	// supports leave pay.
+	leavePay, _ := s.repo.(LeavePayRepository)
+	periodStart := time.Date(run.PeriodYear, time.Month(run.PeriodMonth), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+	leaveByEmployee := make(map[string][]LeaveRecord)
+	if leavePay != nil {
+		leaves, err := leavePay.ListApprovedLeave(ctx, schemaName, tenantID, periodStart, periodEnd)
+		if err != nil {
+			return nil, fmt.Errorf("list approved leave: %w", err)
+		}
+		for _, leave := range leaves {
+			leaveByEmployee[leave.EmployeeID] = append(leaveByEmployee[leave.EmployeeID], leave)
+		}
+	}
+
+	// Approved timesheet hours in the period are paid at the hourly rate.
+	timesheetsByEmployee := make(map[string][]TimesheetEntry)
+	if timesheets, ok := s.repo.(TimesheetRepository); ok {
+		lastDay := periodEnd.AddDate(0, 0, -1)
+		entries, err := timesheets.ListTimesheetEntries(ctx, schemaName, tenantID, TimesheetFilter{
+			From:   &periodStart,
+			To:     &lastDay,
+			Status: TimesheetApproved,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("list approved timesheets: %w", err)
+		}
+		for _, entry := range entries {
+			timesheetsByEmployee[entry.EmployeeID] = append(timesheetsByEmployee[entry.EmployeeID], entry)
+		}
+	}
+
 	var totalGross, totalNet, totalEmployerCost decimal.Decimal
 	payslips := make([]Payslip, 0, len(employees))
 
@@ -386,8 +519,34 @@ func (s *Service) CalculatePayroll(ctx context.Context, schemaName, tenantID, pa
 		for _, emp := range employees {
 			// Get current salary
 			salary, err := txRepo.GetCurrentSalary(ctx, schemaName, tenantID, emp.ID)
-			if err != nil || salary.IsZero() {
-				continue // Skip employees without salary
+			timesheetEntries := timesheetsByEmployee[emp.ID]
+			if err != nil || (salary.IsZero() && len(timesheetEntries) == 0) {
+				continue // Skip employees without salary or approved hours
+			}
+
+			gross := salary
+			var components []PayslipComponent
+			if leavePay != nil {
+				employee := emp
+				components, err = buildLeavePayComponents(salary, periodStart, periodEnd, leaveByEmployee[emp.ID], func(leave LeaveRecord) (*AverageEarnings, error) {
+					return averageEarnings(ctx, txRepo, leavePay, schemaName, tenantID, &employee, leave.StartDate)
+				})
+				if err != nil {
+					return fmt.Errorf("calculate leave pay for %s: %w", emp.FullName(), err)
+				}
+			} else if len(timesheetEntries) > 0 && salary.IsPositive() {
+				components = []PayslipComponent{baseSalaryPayslipComponent(salary)}
+			}
+			hourlyComponents, err := buildTimesheetPayComponents(&emp, timesheetEntries)
+			if err != nil {
+				return err
+			}
+			components = append(components, hourlyComponents...)
+			if components != nil {
+				gross = sumPayslipComponents(components)
+			}
+			if !gross.IsPositive() {
+				continue // Skip employees absent without pay for the whole period
 			}
 
 			// Calculate taxes
@@ -395,7 +554,7 @@ func (s *Service) CalculatePayroll(ctx context.Context, schemaName, tenantID, pa
 			if emp.ApplyBasicExemption {
 				basicExemption = emp.BasicExemptionAmount
 			}
-			calc := CalculateEstonianTaxes(salary, basicExemption, emp.FundedPensionRate)
+			calc := CalculateEstonianTaxes(gross, basicExemption, emp.FundedPensionRate)
 
 			// Create payslip
 			payslip := Payslip{
@@ -420,6 +579,19 @@ func (s *Service) CalculatePayroll(ctx context.Context, schemaName, tenantID, pa
 			if err := txRepo.CreatePayslip(ctx, schemaName, &payslip); err != nil {
 				return fmt.Errorf("insert payslip: %w", err)
 			}
+			if txLeavePay, ok := txRepo.(LeavePayRepository); ok && len(components) > 0 {
+				for i := range components {
+					components[i].ID = s.uuid.New()
+					components[i].TenantID = tenantID
+					components[i].PayslipID = payslip.ID
+					components[i].SortOrder = i
+					components[i].CreatedAt = payslip.CreatedAt
+				}
+				if err := txLeavePay.CreatePayslipComponents(ctx, schemaName, components); err != nil {
+					return fmt.Errorf("insert payslip components: %w", err)
+				}
+				payslip.Components = components
+			}
 
 			totalGross = totalGross.Add(calc.GrossSalary)
 			totalNet = totalNet.Add(calc.NetSalary)
@@ -442,7 +614,7 @@ func (s *Service) CalculatePayroll(ctx context.Context, schemaName, tenantID, pa
 	}
 
 	run.Payslips = payslips
-	run.RemediationActions = BuildPayrollRunRemediationActions(run)
+	run.RemediationActions = payrollRunRemediationActions(run, s.pendingEmploymentRegisterEmployees(ctx, schemaName, tenantID))
 
 	return run, nil
 }
@@ -473,9 +645,12 @@ func (s *Service) ProcessPayrollRun(ctx context.Context, schemaName, tenantID, r
 		run.ApprovedBy = approverID
 		run.ApprovedAt = &now
 		run.UpdatedAt = now
+		if approved, err := s.repo.GetPayrollRun(ctx, schemaName, tenantID, runID); err == nil {
+			run.JournalEntryID = approved.JournalEntryID
+		}
 		result.Approved = true
 	}
-	run.RemediationActions = BuildPayrollRunRemediationActions(run)
+	run.RemediationActions = payrollRunRemediationActions(run, s.pendingEmploymentRegisterEmployees(ctx, schemaName, tenantID))
 
 	return result, nil
 }
@@ -489,7 +664,7 @@ func (s *Service) GetPayrollRun(ctx context.Context, schemaName, tenantID, runID
 	if err != nil {
 		return nil, fmt.Errorf("get payroll run: %w", err)
 	}
-	run.RemediationActions = BuildPayrollRunRemediationActions(run)
+	run.RemediationActions = payrollRunRemediationActions(run, s.pendingEmploymentRegisterEmployees(ctx, schemaName, tenantID))
 	return run, nil
 }
 
@@ -523,19 +698,82 @@ func (s *Service) ListPayrollRuns(ctx context.Context, schemaName, tenantID stri
 	if err != nil {
 		return nil, fmt.Errorf("list payroll runs: %w", err)
 	}
+	pending := s.pendingEmploymentRegisterEmployees(ctx, schemaName, tenantID)
 	for i := range runs {
-		runs[i].RemediationActions = BuildPayrollRunRemediationActions(&runs[i])
+		runs[i].RemediationActions = payrollRunRemediationActions(&runs[i], pending)
 	}
 	return runs, nil
 }
 
-// ApprovePayrollRun approves a calculated payroll run
+// ApprovePayrollRun approves a calculated payroll run. When payroll posting
+// accounts are configured, a balanced journal entry is posted for the run and
+// linked to it in the same transaction as the approval.
 func (s *Service) ApprovePayrollRun(ctx context.Context, schemaName, tenantID, runID, approverID string) error {
-	if err := s.repo.ApprovePayrollRun(ctx, schemaName, tenantID, runID, approverID); err != nil {
-		if err == ErrPayrollRunNotFound {
+	var journal *payrollJournal
+	if s.posting != nil {
+		run, err := s.repo.GetPayrollRun(ctx, schemaName, tenantID, runID)
+		if err != nil && err != ErrPayrollRunNotFound {
+			return fmt.Errorf("get payroll run: %w", err)
+		}
+		if err != nil || run.Status != PayrollCalculated {
 			return fmt.Errorf("payroll run not found or not in CALCULATED status")
 		}
-		return fmt.Errorf("approve payroll run: %w", err)
+		if journal, err = s.buildPayrollJournal(ctx, schemaName, tenantID, run, approverID); err != nil {
+			return err
+		}
 	}
-	return nil
+
+	return s.withLedgerTransaction(ctx, func(tx *Service) error {
+		var entry *accounting.JournalEntry
+		if journal != nil {
+			var err error
+			if entry, err = tx.postPayrollJournal(ctx, schemaName, tenantID, journal, approverID); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.repo.ApprovePayrollRun(ctx, schemaName, tenantID, runID, approverID); err != nil {
+			if err == ErrPayrollRunNotFound {
+				return fmt.Errorf("payroll run not found or not in CALCULATED status")
+			}
+			return fmt.Errorf("approve payroll run: %w", err)
+		}
+
+		if entry != nil {
+			if err := tx.posting.SetPayrollRunJournalEntry(ctx, schemaName, tenantID, runID, &entry.ID); err != nil {
+				return fmt.Errorf("link payroll journal entry: %w", err)
+			}
+			if err := tx.allocatePayrollJournal(ctx, schemaName, tenantID, journal, entry); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// withLedgerTransaction runs fn with a copy of the service whose repository,
+// ledger, cost allocations and payment recorder share one database
+// transaction, so a failed step leaves no journal entry behind. Repositories
+// without ledger transactions run fn on the service itself.
+func (s *Service) withLedgerTransaction(ctx context.Context, fn func(tx *Service) error) error {
+	transactioner, ok := s.repo.(LedgerTransactionRepository)
+	if !ok {
+		return fn(s)
+	}
+	return transactioner.WithLedgerTransaction(ctx, func(txRepo Repository, ledger payrollLedger, costCenters payrollCostAllocator, recorder payrollPaymentRecorder) error {
+		tx := *s
+		tx.repo = txRepo
+		if s.posting != nil {
+			tx.posting, _ = txRepo.(PostingRepository)
+		}
+		if s.payrollPayments != nil {
+			tx.payrollPayments, _ = txRepo.(PaymentRepository)
+		}
+		tx.ledger = ledger
+		tx.costCenters = costCenters
+		if s.paymentRecorder != nil {
+			tx.paymentRecorder = recorder
+		}
+		return fn(&tx)
+	})
 }
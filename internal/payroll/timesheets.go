@@ -0,0 +1,317 @@
+package payroll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Hours worked are paid at the employee's hourly rate with the statutory
+// premiums of the Employment Contracts Act: overtime at 1.5 times the rate
+// (§ 44), night work at 1.25 times and public holiday work at twice the rate
+// (§ 45).
+var (
+	OvertimePayRate = decimal.NewFromFloat(1.5)
+	NightPayRate    = decimal.NewFromFloat(1.25)
+	HolidayPayRate  = decimal.NewFromInt(2)
+)
+
+var maxTimesheetHoursPerDay = decimal.NewFromInt(24)
+
+// ErrTimesheetsUnavailable is returned when the repository does not store timesheets.
+var ErrTimesheetsUnavailable = errors.New("timesheets are unavailable")
+
+// TimesheetStatus represents the approval status of a timesheet entry
+type TimesheetStatus string
+
+const (
+	TimesheetPending  TimesheetStatus = "PENDING"
+	TimesheetApproved TimesheetStatus = "APPROVED"
+)
+
+// TimesheetEntry holds the hours an employee worked on one day. Every hour is
+// reported in exactly one category, so night and public holiday hours are not
+// also counted as regular hours.
+type TimesheetEntry struct {
+	ID            string          `json:"id"`
+	TenantID      string          `json:"tenant_id"`
+	EmployeeID    string          `json:"employee_id"`
+	WorkDate      time.Time       `json:"work_date"`
+	RegularHours  decimal.Decimal `json:"regular_hours"`
+	OvertimeHours decimal.Decimal `json:"overtime_hours"`
+	NightHours    decimal.Decimal `json:"night_hours"`
+	HolidayHours  decimal.Decimal `json:"holiday_hours"`
+	Status        TimesheetStatus `json:"status"`
+	Notes         string          `json:"notes,omitempty"`
+	ApprovedBy    string          `json:"approved_by,omitempty"`
+	ApprovedAt    *time.Time      `json:"approved_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// TotalHours returns all hours worked on the day
+func (e *TimesheetEntry) TotalHours() decimal.Decimal {
+	return e.RegularHours.Add(e.OvertimeHours).Add(e.NightHours).Add(e.HolidayHours)
+}
+
+// TimesheetFilter contains optional filters for listing and approving timesheet entries.
+type TimesheetFilter struct {
+	EmployeeID string
+	From       *time.Time
+	To         *time.Time
+	Status     TimesheetStatus
+}
+
+// CreateTimesheetEntryRequest records the hours an employee worked on one day.
+type CreateTimesheetEntryRequest struct {
+	EmployeeID    string          `json:"employee_id"`
+	WorkDate      time.Time       `json:"work_date"`
+	RegularHours  decimal.Decimal `json:"regular_hours"`
+	OvertimeHours decimal.Decimal `json:"overtime_hours"`
+	NightHours    decimal.Decimal `json:"night_hours"`
+	HolidayHours  decimal.Decimal `json:"holiday_hours"`
+	Notes         string          `json:"notes,omitempty"`
+}
+
+// ApproveTimesheetsRequest approves the pending timesheet entries of a date range.
+type ApproveTimesheetsRequest struct {
+	EmployeeID string    `json:"employee_id,omitempty"`
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+}
+
+// ApproveTimesheetsResult reports how many timesheet entries were approved.
+type ApproveTimesheetsResult struct {
+	Approved int `json:"approved"`
+}
+
+// ImportTimesheetsRequest contains CSV payload for timesheet import.
+type ImportTimesheetsRequest struct {
+	CSVContent string `json:"csv_content"`
+	FileName   string `json:"file_name,omitempty"`
+}
+
+// ImportTimesheetsResult summarizes a timesheet import.
+type ImportTimesheetsResult struct {
+	FileName       string                    `json:"file_name,omitempty"`
+	RowsProcessed  int                       `json:"rows_processed"`
+	EntriesCreated int                       `json:"entries_created"`
+	EntriesUpdated int                       `json:"entries_updated"`
+	RowsSkipped    int                       `json:"rows_skipped"`
+	Errors         []ImportTimesheetRowError `json:"errors,omitempty"`
+}
+
+// ImportTimesheetRowError describes a row-level timesheet import failure.
+type ImportTimesheetRowError struct {
+	Row            int    `json:"row"`
+	WorkDate       string `json:"work_date,omitempty"`
+	EmployeeName   string `json:"employee_name,omitempty"`
+	EmployeeNumber string `json:"employee_number,omitempty"`
+	Message        string `json:"message"`
+}
+
+// timesheetPayCategory maps an hour category to its pay line and premium.
+type timesheetPayCategory struct {
+	componentType string
+	name          string
+	multiplier    decimal.Decimal
+	hours         func(*TimesheetEntry) decimal.Decimal
+}
+
+var timesheetPayCategories = []timesheetPayCategory{
+	{SalaryComponentHourlyPay, "Regular hours", decimal.NewFromInt(1), func(e *TimesheetEntry) decimal.Decimal { return e.RegularHours }},
+	{SalaryComponentOvertimePay, "Overtime", OvertimePayRate, func(e *TimesheetEntry) decimal.Decimal { return e.OvertimeHours }},
+	{SalaryComponentNightPay, "Night work", NightPayRate, func(e *TimesheetEntry) decimal.Decimal { return e.NightHours }},
+	{SalaryComponentHolidayPay, "Public holiday work", HolidayPayRate, func(e *TimesheetEntry) decimal.Decimal { return e.HolidayHours }},
+}
+
+func (s *Service) timesheetRepository() (TimesheetRepository, error) {
+	timesheets, ok := s.repo.(TimesheetRepository)
+	if !ok {
+		return nil, ErrTimesheetsUnavailable
+	}
+	return timesheets, nil
+}
+
+// ListTimesheetEntries returns timesheet entries matching the filter
+func (s *Service) ListTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter) ([]TimesheetEntry, error) {
+	timesheets, err := s.timesheetRepository()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := timesheets.ListTimesheetEntries(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list timesheet entries: %w", err)
+	}
+	return entries, nil
+}
+
+// SaveTimesheetEntry records the hours an employee worked on a day. A pending
+// entry for the same day is replaced; approved entries cannot be changed.
+func (s *Service) SaveTimesheetEntry(ctx context.Context, schemaName, tenantID string, req *CreateTimesheetEntryRequest) (*TimesheetEntry, bool, error) {
+	timesheets, err := s.timesheetRepository()
+	if err != nil {
+		return nil, false, err
+	}
+	if strings.TrimSpace(req.EmployeeID) == "" {
+		return nil, false, fmt.Errorf("employee ID is required")
+	}
+	if req.WorkDate.IsZero() {
+		return nil, false, fmt.Errorf("work date is required")
+	}
+	if err := validateTimesheetHours(req); err != nil {
+		return nil, false, err
+	}
+
+	emp, err := s.GetEmployee(ctx, schemaName, tenantID, req.EmployeeID)
+	if err != nil {
+		return nil, false, err
+	}
+	return s.saveTimesheetEntry(ctx, timesheets, schemaName, tenantID, emp, req)
+}
+
+func (s *Service) saveTimesheetEntry(ctx context.Context, timesheets TimesheetRepository, schemaName, tenantID string, emp *Employee, req *CreateTimesheetEntryRequest) (*TimesheetEntry, bool, error) {
+	workDate := dateOnly(req.WorkDate)
+	if workDate.Before(dateOnly(emp.StartDate)) || (emp.EndDate != nil && workDate.After(dateOnly(*emp.EndDate))) {
+		return nil, false, fmt.Errorf("work date %s is outside the employment period", workDate.Format("2006-01-02"))
+	}
+
+	existing, err := timesheets.ListTimesheetEntries(ctx, schemaName, tenantID, TimesheetFilter{
+		EmployeeID: emp.ID,
+		From:       &workDate,
+		To:         &workDate,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("list timesheet entries: %w", err)
+	}
+
+	now := time.Now()
+	if len(existing) > 0 {
+		entry := existing[0]
+		if entry.Status == TimesheetApproved {
+			return nil, false, fmt.Errorf("timesheet for %s is already approved", workDate.Format("2006-01-02"))
+		}
+		entry.RegularHours = req.RegularHours
+		entry.OvertimeHours = req.OvertimeHours
+		entry.NightHours = req.NightHours
+		entry.HolidayHours = req.HolidayHours
+		entry.Notes = strings.TrimSpace(req.Notes)
+		entry.UpdatedAt = now
+		if err := timesheets.UpdateTimesheetEntry(ctx, schemaName, &entry); err != nil {
+			return nil, false, fmt.Errorf("update timesheet entry: %w", err)
+		}
+		return &entry, false, nil
+	}
+
+	entry := &TimesheetEntry{
+		ID:            s.uuid.New(),
+		TenantID:      tenantID,
+		EmployeeID:    emp.ID,
+		WorkDate:      workDate,
+		RegularHours:  req.RegularHours,
+		OvertimeHours: req.OvertimeHours,
+		NightHours:    req.NightHours,
+		HolidayHours:  req.HolidayHours,
+		Status:        TimesheetPending,
+		Notes:         strings.TrimSpace(req.Notes),
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	if err := timesheets.CreateTimesheetEntry(ctx, schemaName, entry); err != nil {
+		return nil, false, fmt.Errorf("create timesheet entry: %w", err)
+	}
+	return entry, true, nil
+}
+
+// ApproveTimesheets approves the pending timesheet entries in a date range,
+// optionally for one employee. Only approved hours are paid.
+func (s *Service) ApproveTimesheets(ctx context.Context, schemaName, tenantID, approverID string, req *ApproveTimesheetsRequest) (*ApproveTimesheetsResult, error) {
+	timesheets, err := s.timesheetRepository()
+	if err != nil {
+		return nil, err
+	}
+	if req.From.IsZero() || req.To.IsZero() {
+		return nil, fmt.Errorf("from and to dates are required")
+	}
+	from, to := dateOnly(req.From), dateOnly(req.To)
+	if to.Before(from) {
+		return nil, fmt.Errorf("to date must not be before from date")
+	}
+
+	approved, err := timesheets.ApproveTimesheetEntries(ctx, schemaName, tenantID, TimesheetFilter{
+		EmployeeID: strings.TrimSpace(req.EmployeeID),
+		From:       &from,
+		To:         &to,
+		Status:     TimesheetPending,
+	}, approverID, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("approve timesheet entries: %w", err)
+	}
+	return &ApproveTimesheetsResult{Approved: approved}, nil
+}
+
+func validateTimesheetHours(req *CreateTimesheetEntryRequest) error {
+	for _, hours := range []struct {
+		field string
+		value decimal.Decimal
+	}{
+		{"regular hours", req.RegularHours},
+		{"overtime hours", req.OvertimeHours},
+		{"night hours", req.NightHours},
+		{"holiday hours", req.HolidayHours},
+	} {
+		if hours.value.IsNegative() {
+			return fmt.Errorf("%s must be zero or greater", hours.field)
+		}
+	}
+	total := req.RegularHours.Add(req.OvertimeHours).Add(req.NightHours).Add(req.HolidayHours)
+	if !total.IsPositive() {
+		return fmt.Errorf("at least one hour must be recorded")
+	}
+	if total.GreaterThan(maxTimesheetHoursPerDay) {
+		return fmt.Errorf("total hours must not exceed %s per day", maxTimesheetHoursPerDay.String())
+	}
+	return nil
+}
+
+// buildTimesheetPayComponents turns approved timesheet entries into one pay
+// line per hour category at the employee's hourly rate and statutory premium.
+func buildTimesheetPayComponents(emp *Employee, entries []TimesheetEntry) ([]PayslipComponent, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if !emp.HourlyRate.IsPositive() {
+		return nil, fmt.Errorf("hourly rate is not set for %s", emp.FullName())
+	}
+
+	sorted := append([]TimesheetEntry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].WorkDate.Before(sorted[j].WorkDate)
+	})
+
+	components := make([]PayslipComponent, 0, len(timesheetPayCategories))
+	for _, category := range timesheetPayCategories {
+		hours := decimal.Zero
+		for i := range sorted {
+			hours = hours.Add(category.hours(&sorted[i]))
+		}
+		if !hours.IsPositive() {
+			continue
+		}
+		rate := emp.HourlyRate.Mul(category.multiplier)
+		components = append(components, PayslipComponent{
+			ComponentType: category.componentType,
+			Name:          category.name,
+			PaymentType:   PaymentTypeSalary,
+			Hours:         hours,
+			HourlyRate:    rate.Round(4),
+			Amount:        hours.Mul(rate).Round(2),
+		})
+	}
+	return components, nil
+}
@@ -0,0 +1,294 @@
+package payroll
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type timesheetMockRepository struct {
+	*leavePayMockRepository
+	entries []TimesheetEntry
+}
+
+func newTimesheetMockRepository() *timesheetMockRepository {
+	return &timesheetMockRepository{leavePayMockRepository: newLeavePayMockRepository()}
+}
+
+func (m *timesheetMockRepository) WithTransaction(ctx context.Context, fn func(txRepo Repository) error) error {
+	return fn(m)
+}
+
+func (m *timesheetMockRepository) matches(entry TimesheetEntry, tenantID string, filter TimesheetFilter) bool {
+	if entry.TenantID != tenantID {
+		return false
+	}
+	if filter.EmployeeID != "" && entry.EmployeeID != filter.EmployeeID {
+		return false
+	}
+	if filter.From != nil && entry.WorkDate.Before(*filter.From) {
+		return false
+	}
+	if filter.To != nil && entry.WorkDate.After(*filter.To) {
+		return false
+	}
+	return filter.Status == "" || entry.Status == filter.Status
+}
+
+func (m *timesheetMockRepository) ListTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter) ([]TimesheetEntry, error) {
+	result := []TimesheetEntry{}
+	for _, entry := range m.entries {
+		if m.matches(entry, tenantID, filter) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
+func (m *timesheetMockRepository) CreateTimesheetEntry(ctx context.Context, schemaName string, entry *TimesheetEntry) error {
+	m.entries = append(m.entries, *entry)
+	return nil
+}
+
+func (m *timesheetMockRepository) UpdateTimesheetEntry(ctx context.Context, schemaName string, entry *TimesheetEntry) error {
+	for i := range m.entries {
+		if m.entries[i].ID == entry.ID {
+			m.entries[i] = *entry
+		}
+	}
+	return nil
+}
+
+func (m *timesheetMockRepository) ApproveTimesheetEntries(ctx context.Context, schemaName, tenantID string, filter TimesheetFilter, approverID string, approvedAt time.Time) (int, error) {
+	approved := 0
+	for i := range m.entries {
+		if m.matches(m.entries[i], tenantID, filter) {
+			m.entries[i].Status = TimesheetApproved
+			m.entries[i].ApprovedBy = approverID
+			m.entries[i].ApprovedAt = &approvedAt
+			approved++
+		}
+	}
+	return approved, nil
+}
+
+func setupTimesheetService(t *testing.T) (*Service, *timesheetMockRepository) {
+	t.Helper()
+	repo := newTimesheetMockRepository()
+	repo.Employees["emp-1"] = &Employee{
+		ID:             "emp-1",
+		TenantID:       "tenant-1",
+		EmployeeNumber: "E001",
+		FirstName:      "Jaan",
+		LastName:       "Tamm",
+		StartDate:      leavePayDate(2026, time.January, 1),
+		EmploymentType: EmploymentPartTime,
+		HourlyRate:     decimal.NewFromInt(12),
+		IsActive:       true,
+	}
+	return NewServiceWithRepository(repo, &DefaultUUIDGenerator{}), repo
+}
+
+func timesheetRequest(date time.Time, regular, overtime, night, holiday int64) *CreateTimesheetEntryRequest {
+	return &CreateTimesheetEntryRequest{
+		EmployeeID:    "emp-1",
+		WorkDate:      date,
+		RegularHours:  decimal.NewFromInt(regular),
+		OvertimeHours: decimal.NewFromInt(overtime),
+		NightHours:    decimal.NewFromInt(night),
+		HolidayHours:  decimal.NewFromInt(holiday),
+	}
+}
+
+func TestBuildTimesheetPayComponents(t *testing.T) {
+	emp := &Employee{FirstName: "Jaan", LastName: "Tamm", HourlyRate: decimal.NewFromInt(12)}
+	entries := []TimesheetEntry{
+		{WorkDate: leavePayDate(2026, time.March, 3), RegularHours: decimal.NewFromInt(8), OvertimeHours: decimal.NewFromInt(2)},
+		{WorkDate: leavePayDate(2026, time.March, 2), RegularHours: decimal.NewFromInt(8), NightHours: decimal.NewFromInt(4)},
+		{WorkDate: leavePayDate(2026, time.April, 3), HolidayHours: decimal.RequireFromString("6.5")},
+	}
+
+	components, err := buildTimesheetPayComponents(emp, entries)
+	require.NoError(t, err)
+	require.Len(t, components, 4)
+
+	assert.Equal(t, SalaryComponentHourlyPay, components[0].ComponentType)
+	assert.Equal(t, "16", components[0].Hours.String())
+	assert.Equal(t, "12", components[0].HourlyRate.String())
+	assert.Equal(t, "192", components[0].Amount.String())
+
+	assert.Equal(t, SalaryComponentOvertimePay, components[1].ComponentType)
+	assert.Equal(t, "18", components[1].HourlyRate.String())
+	assert.Equal(t, "36", components[1].Amount.String())
+
+	assert.Equal(t, SalaryComponentNightPay, components[2].ComponentType)
+	assert.Equal(t, "15", components[2].HourlyRate.String())
+	assert.Equal(t, "60", components[2].Amount.String())
+
+	assert.Equal(t, SalaryComponentHolidayPay, components[3].ComponentType)
+	assert.Equal(t, "24", components[3].HourlyRate.String())
+	assert.Equal(t, "156", components[3].Amount.String())
+	for _, component := range components {
+		assert.Equal(t, PaymentTypeSalary, component.PaymentType)
+	}
+
+	_, err = buildTimesheetPayComponents(&Employee{FirstName: "Jaan", LastName: "Tamm"}, entries)
+	assert.EqualError(t, err, "hourly rate is not set for Jaan Tamm")
+
+	components, err = buildTimesheetPayComponents(emp, nil)
+	require.NoError(t, err)
+	assert.Empty(t, components)
+}
+
+func TestSaveTimesheetEntry(t *testing.T) {
+	service, repo := setupTimesheetService(t)
+	ctx := context.Background()
+
+	entry, created, err := service.SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", timesheetRequest(leavePayDate(2026, time.March, 2), 8, 0, 0, 0))
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, TimesheetPending, entry.Status)
+
+	entry, created, err = service.SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", timesheetRequest(leavePayDate(2026, time.March, 2), 6, 2, 0, 0))
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, "8", entry.TotalHours().String())
+	require.Len(t, repo.entries, 1)
+	assert.Equal(t, "2", repo.entries[0].OvertimeHours.String())
+
+	repo.entries[0].Status = TimesheetApproved
+	_, _, err = service.SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", timesheetRequest(leavePayDate(2026, time.March, 2), 8, 0, 0, 0))
+	assert.EqualError(t, err, "timesheet for 2026-03-02 is already approved")
+
+	for _, tc := range []struct {
+		name string
+		req  *CreateTimesheetEntryRequest
+		want string
+	}{
+		{"missing employee", &CreateTimesheetEntryRequest{WorkDate: leavePayDate(2026, time.March, 3), RegularHours: decimal.NewFromInt(8)}, "employee ID is required"},
+		{"missing date", &CreateTimesheetEntryRequest{EmployeeID: "emp-1", RegularHours: decimal.NewFromInt(8)}, "work date is required"},
+		{"negative hours", timesheetRequest(leavePayDate(2026, time.March, 3), 8, -1, 0, 0), "overtime hours must be zero or greater"},
+		{"no hours", timesheetRequest(leavePayDate(2026, time.March, 3), 0, 0, 0, 0), "at least one hour must be recorded"},
+		{"too many hours", timesheetRequest(leavePayDate(2026, time.March, 3), 12, 8, 6, 0), "total hours must not exceed 24 per day"},
+		{"before employment", timesheetRequest(leavePayDate(2025, time.December, 31), 8, 0, 0, 0), "work date 2025-12-31 is outside the employment period"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := service.SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", tc.req)
+			assert.EqualError(t, err, tc.want)
+		})
+	}
+
+	_, _, err = NewServiceWithRepository(NewMockRepository(), &DefaultUUIDGenerator{}).SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", timesheetRequest(leavePayDate(2026, time.March, 2), 8, 0, 0, 0))
+	assert.ErrorIs(t, err, ErrTimesheetsUnavailable)
+}
+
+func TestApproveTimesheets(t *testing.T) {
+	service, repo := setupTimesheetService(t)
+	ctx := context.Background()
+	for day := 2; day <= 4; day++ {
+		_, _, err := service.SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", timesheetRequest(leavePayDate(2026, time.March, day), 8, 0, 0, 0))
+		require.NoError(t, err)
+	}
+
+	_, err := service.ApproveTimesheets(ctx, "tenant_test", "tenant-1", "user-1", &ApproveTimesheetsRequest{From: leavePayDate(2026, time.March, 3)})
+	assert.EqualError(t, err, "from and to dates are required")
+	_, err = service.ApproveTimesheets(ctx, "tenant_test", "tenant-1", "user-1", &ApproveTimesheetsRequest{From: leavePayDate(2026, time.March, 3), To: leavePayDate(2026, time.March, 2)})
+	assert.EqualError(t, err, "to date must not be before from date")
+
+	result, err := service.ApproveTimesheets(ctx, "tenant_test", "tenant-1", "user-1", &ApproveTimesheetsRequest{
+		EmployeeID: "emp-1",
+		From:       leavePayDate(2026, time.March, 3),
+		To:         leavePayDate(2026, time.March, 31),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Approved)
+	assert.Equal(t, TimesheetPending, repo.entries[0].Status)
+	assert.Equal(t, "user-1", repo.entries[1].ApprovedBy)
+
+	entries, err := service.ListTimesheetEntries(ctx, "tenant_test", "tenant-1", TimesheetFilter{Status: TimesheetApproved})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestImportTimesheetsCSV(t *testing.T) {
+	service, repo := setupTimesheetService(t)
+	ctx := context.Background()
+	_, _, err := service.SaveTimesheetEntry(ctx, "tenant_test", "tenant-1", timesheetRequest(leavePayDate(2026, time.March, 2), 4, 0, 0, 0))
+	require.NoError(t, err)
+
+	result, err := service.ImportTimesheetsCSV(ctx, "tenant_test", "tenant-1", &ImportTimesheetsRequest{
+		FileName: "timesheets.csv",
+		CSVContent: "employee_no;date;hours;overtime;night;public_holiday_hours;notes\n" +
+			"E001;2026-03-02;8;0;0;0;Corrected\n" +
+			"E001;03.03.2026;6;0;2;0;\n" +
+			"E001;2026-03-03;8;0;0;0;\n" +
+			"E999;2026-03-04;8;0;0;0;\n" +
+			"E001;2026-03-05;8,5;0;0;0;\n" +
+			"E001;2026-03-06;0;0;0;0;\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 6, result.RowsProcessed)
+	assert.Equal(t, 2, result.EntriesCreated)
+	assert.Equal(t, 1, result.EntriesUpdated)
+	assert.Equal(t, 3, result.RowsSkipped)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, 4, result.Errors[0].Row)
+	assert.Contains(t, result.Errors[0].Message, "duplicate timesheet row")
+	assert.Equal(t, `employee_number "E999" not found`, result.Errors[1].Message)
+	assert.Equal(t, "at least one hour must be recorded", result.Errors[2].Message)
+	require.Len(t, repo.entries, 3)
+	assert.Equal(t, "Corrected", repo.entries[0].Notes)
+	assert.Equal(t, "2", repo.entries[1].NightHours.String())
+	assert.Equal(t, "8.5", repo.entries[2].RegularHours.String())
+
+	_, err = service.ImportTimesheetsCSV(ctx, "tenant_test", "tenant-1", &ImportTimesheetsRequest{CSVContent: "employee_number,hours\nE001,8\n"})
+	assert.EqualError(t, err, "missing required work_date column")
+	_, err = service.ImportTimesheetsCSV(ctx, "tenant_test", "tenant-1", &ImportTimesheetsRequest{CSVContent: "employee_number,work_date\nE001,2026-03-02\n"})
+	assert.EqualError(t, err, "missing required regular_hours, overtime_hours, night_hours, or holiday_hours column")
+	_, err = service.ImportTimesheetsCSV(ctx, "tenant_test", "tenant-1", &ImportTimesheetsRequest{})
+	assert.EqualError(t, err, "csv_content is required")
+}
+
+func TestCalculatePayrollWithTimesheets(t *testing.T) {
+	service, repo := setupTimesheetService(t)
+	ctx := context.Background()
+	repo.PayrollRuns["run-1"] = &PayrollRun{ID: "run-1", TenantID: "tenant-1", PeriodYear: 2026, PeriodMonth: 3, Status: PayrollDraft}
+	repo.entries = []TimesheetEntry{
+		{ID: "ts-1", TenantID: "tenant-1", EmployeeID: "emp-1", WorkDate: leavePayDate(2026, time.March, 2), RegularHours: decimal.NewFromInt(100), Status: TimesheetApproved},
+		{ID: "ts-2", TenantID: "tenant-1", EmployeeID: "emp-1", WorkDate: leavePayDate(2026, time.March, 3), OvertimeHours: decimal.NewFromInt(10), NightHours: decimal.NewFromInt(8), Status: TimesheetApproved},
+		{ID: "ts-3", TenantID: "tenant-1", EmployeeID: "emp-1", WorkDate: leavePayDate(2026, time.March, 4), RegularHours: decimal.NewFromInt(8), Status: TimesheetPending},
+		{ID: "ts-4", TenantID: "tenant-1", EmployeeID: "emp-1", WorkDate: leavePayDate(2026, time.April, 1), RegularHours: decimal.NewFromInt(8), Status: TimesheetApproved},
+	}
+
+	run, err := service.CalculatePayroll(ctx, "tenant_test", "tenant-1", "run-1")
+	require.NoError(t, err)
+	require.Len(t, run.Payslips, 1)
+	payslip := run.Payslips[0]
+	// 100 h x 12 + 10 h x 18 + 8 h x 15
+	assert.Equal(t, "1500", payslip.GrossSalary.String())
+	require.Len(t, payslip.Components, 3)
+	assert.Equal(t, SalaryComponentHourlyPay, payslip.Components[0].ComponentType)
+	assert.Equal(t, SalaryComponentOvertimePay, payslip.Components[1].ComponentType)
+	assert.Equal(t, SalaryComponentNightPay, payslip.Components[2].ComponentType)
+	assert.Len(t, repo.components, 3)
+
+	// A monthly salary and hourly pay add up on the same payslip.
+	repo.PayrollRuns["run-1"].Status = PayrollDraft
+	repo.Payslips = nil
+	repo.components = nil
+	repo.Salaries["emp-1"] = decimal.NewFromInt(500)
+	run, err = service.CalculatePayroll(ctx, "tenant_test", "tenant-1", "run-1")
+	require.NoError(t, err)
+	require.Len(t, run.Payslips, 1)
+	assert.Equal(t, "2000", run.Payslips[0].GrossSalary.String())
+	assert.Equal(t, SalaryComponentBaseSalary, run.Payslips[0].Components[0].ComponentType)
+
+	repo.PayrollRuns["run-1"].Status = PayrollDraft
+	repo.Employees["emp-1"].HourlyRate = decimal.Zero
+	_, err = service.CalculatePayroll(ctx, "tenant_test", "tenant-1", "run-1")
+	assert.EqualError(t, err, "hourly rate is not set for Jaan Tamm")
+}
@@ -3,6 +3,7 @@ package payroll
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -50,35 +51,21 @@ func (s *Service) GenerateTSD(ctx context.Context, schemaName, tenantID, payroll
 			continue
 		}
 
-		row := TSDRow{
-			ID:             uuid.New().String(),
-			TenantID:       tenantID,
-			DeclarationID:  tsd.ID,
-			EmployeeID:     ps.EmployeeID,
-			PersonalCode:   ps.Employee.PersonalCode,
-			FirstName:      ps.Employee.FirstName,
-			LastName:       ps.Employee.LastName,
-			PaymentType:    "10", // Regular salary
-			GrossPayment:   ps.GrossSalary,
-			BasicExemption: ps.BasicExemptionApplied,
-			TaxableAmount:  ps.TaxableIncome,
-			IncomeTax:      ps.IncomeTax,
-			SocialTax:      ps.SocialTax,
-			UnemploymentER: ps.UnemploymentInsuranceER,
-			UnemploymentEE: ps.UnemploymentInsuranceEE,
-			FundedPension:  ps.FundedPension,
-			CreatedAt:      time.Now(),
+		for _, row := range tsdRowsForPayslip(ps) {
+			row.ID = uuid.New().String()
+			row.TenantID = tenantID
+			row.DeclarationID = tsd.ID
+			row.CreatedAt = time.Now()
+			rows = append(rows, row)
+
+			// Accumulate totals
+			tsd.TotalPayments = tsd.TotalPayments.Add(row.GrossPayment)
+			tsd.TotalIncomeTax = tsd.TotalIncomeTax.Add(row.IncomeTax)
+			tsd.TotalSocialTax = tsd.TotalSocialTax.Add(row.SocialTax)
+			tsd.TotalUnemploymentER = tsd.TotalUnemploymentER.Add(row.UnemploymentER)
+			tsd.TotalUnemploymentEE = tsd.TotalUnemploymentEE.Add(row.UnemploymentEE)
+			tsd.TotalFundedPension = tsd.TotalFundedPension.Add(row.FundedPension)
 		}
-
-		rows = append(rows, row)
-
-		// Accumulate totals
-		tsd.TotalPayments = tsd.TotalPayments.Add(row.GrossPayment)
-		tsd.TotalIncomeTax = tsd.TotalIncomeTax.Add(row.IncomeTax)
-		tsd.TotalSocialTax = tsd.TotalSocialTax.Add(row.SocialTax)
-		tsd.TotalUnemploymentER = tsd.TotalUnemploymentER.Add(row.UnemploymentER)
-		tsd.TotalUnemploymentEE = tsd.TotalUnemploymentEE.Add(row.UnemploymentEE)
-		tsd.TotalFundedPension = tsd.TotalFundedPension.Add(row.FundedPension)
 	}
 
 	if err := s.repo.WithTransaction(ctx, func(txRepo Repository) error {
@@ -141,6 +128,19 @@ func (s *Service) GetPayslipsWithEmployees(ctx context.Context, schemaName, tena
 	if err != nil {
 		return nil, fmt.Errorf("get payslips: %w", err)
 	}
+	if leavePay, ok := s.repo.(LeavePayRepository); ok {
+		components, err := leavePay.ListPayslipComponents(ctx, schemaName, tenantID, payrollRunID)
+		if err != nil {
+			return nil, fmt.Errorf("get payslip components: %w", err)
+		}
+		byPayslip := make(map[string][]PayslipComponent)
+		for _, component := range components {
+			byPayslip[component.PayslipID] = append(byPayslip[component.PayslipID], component)
+		}
+		for i := range payslips {
+			payslips[i].Components = byPayslip[payslips[i].ID]
+		}
+	}
 	return payslips, nil
 }
 
@@ -152,3 +152,77 @@ func CalculateTaxPreview(grossSalary decimal.Decimal, applyBasicExemption bool,
 	}
 	return CalculateEstonianTaxes(grossSalary, basicExemption, fundedPensionRate)
 }
+
+// tsdRowsForPayslip declares a payslip on one TSD row per payment type code of
+// its pay lines, such as vacation or sick pay, sharing the taxes in proportion
+// to the gross amounts. Payslips without pay lines are declared as salary.
+func tsdRowsForPayslip(ps Payslip) []TSDRow {
+	base := TSDRow{
+		EmployeeID:     ps.EmployeeID,
+		PersonalCode:   ps.Employee.PersonalCode,
+		FirstName:      ps.Employee.FirstName,
+		LastName:       ps.Employee.LastName,
+		PaymentType:    PaymentTypeSalary,
+		GrossPayment:   ps.GrossSalary,
+		BasicExemption: ps.BasicExemptionApplied,
+		TaxableAmount:  ps.TaxableIncome,
+		IncomeTax:      ps.IncomeTax,
+		SocialTax:      ps.SocialTax,
+		UnemploymentER: ps.UnemploymentInsuranceER,
+		UnemploymentEE: ps.UnemploymentInsuranceEE,
+		FundedPension:  ps.FundedPension,
+	}
+
+	grossByType := make(map[string]decimal.Decimal)
+	for _, component := range ps.Components {
+		grossByType[component.PaymentType] = grossByType[component.PaymentType].Add(component.Amount)
+	}
+	paymentTypes := make([]string, 0, len(grossByType))
+	for paymentType, gross := range grossByType {
+		if !gross.IsZero() {
+			paymentTypes = append(paymentTypes, paymentType)
+		}
+	}
+	if len(paymentTypes) <= 1 || !ps.GrossSalary.IsPositive() {
+		if len(paymentTypes) == 1 {
+			base.PaymentType = paymentTypes[0]
+		}
+		return []TSDRow{base}
+	}
+	sort.Strings(paymentTypes)
+
+	rows := make([]TSDRow, 0, len(paymentTypes))
+	remaining := base
+	for i, paymentType := range paymentTypes {
+		if i == len(paymentTypes)-1 {
+			remaining.PaymentType = paymentType
+			rows = append(rows, remaining)
+			break
+		}
+		share := grossByType[paymentType].Div(ps.GrossSalary)
+		part := func(total decimal.Decimal) decimal.Decimal {
+			return total.Mul(share).Round(2)
+		}
+		row := base
+		row.PaymentType = paymentType
+		row.GrossPayment = grossByType[paymentType]
+		row.BasicExemption = part(base.BasicExemption)
+		row.TaxableAmount = part(base.TaxableAmount)
+		row.IncomeTax = part(base.IncomeTax)
+		row.SocialTax = part(base.SocialTax)
+		row.UnemploymentER = part(base.UnemploymentER)
+		row.UnemploymentEE = part(base.UnemploymentEE)
+		row.FundedPension = part(base.FundedPension)
+		rows = append(rows, row)
+
+		remaining.GrossPayment = remaining.GrossPayment.Sub(row.GrossPayment)
+		remaining.BasicExemption = remaining.BasicExemption.Sub(row.BasicExemption)
+		remaining.TaxableAmount = remaining.TaxableAmount.Sub(row.TaxableAmount)
+		remaining.IncomeTax = remaining.IncomeTax.Sub(row.IncomeTax)
+		remaining.SocialTax = remaining.SocialTax.Sub(row.SocialTax)
+		remaining.UnemploymentER = remaining.UnemploymentER.Sub(row.UnemploymentER)
+		remaining.UnemploymentEE = remaining.UnemploymentEE.Sub(row.UnemploymentEE)
+		remaining.FundedPension = remaining.FundedPension.Sub(row.FundedPension)
+	}
+	return rows
+}
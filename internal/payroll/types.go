@@ -75,6 +75,9 @@ type Employee struct {
 	BasicExemptionAmount decimal.Decimal `json:"basic_exemption_amount"`
 	FundedPensionRate    decimal.Decimal `json:"funded_pension_rate"`
 
+	// Hourly rate for pay derived from approved timesheets
+	HourlyRate decimal.Decimal `json:"hourly_rate"`
+
 	IsActive  bool      `json:"is_active"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -107,6 +110,13 @@ const (
 	SalaryComponentCommission          = "COMMISSION"
 	SalaryComponentBenefit             = "BENEFIT"
 	SalaryComponentDeduction           = "DEDUCTION"
+	SalaryComponentAbsenceDeduction    = "ABSENCE_DEDUCTION"
+	SalaryComponentVacationPay         = "VACATION_PAY"
+	SalaryComponentSickPay             = "SICK_PAY"
+	SalaryComponentHourlyPay           = "HOURLY_PAY"
+	SalaryComponentOvertimePay         = "OVERTIME_PAY"
+	SalaryComponentNightPay            = "NIGHT_PAY"
+	SalaryComponentHolidayPay          = "HOLIDAY_PAY"
 )
 
 // PayrollRun represents a monthly payroll calculation
@@ -125,6 +135,7 @@ type PayrollRun struct {
 	CreatedBy          string                        `json:"created_by,omitempty"`
 	ApprovedBy         string                        `json:"approved_by,omitempty"`
 	ApprovedAt         *time.Time                    `json:"approved_at,omitempty"`
+	JournalEntryID     *string                       `json:"journal_entry_id,omitempty"`
 	CreatedAt          time.Time                     `json:"created_at"`
 	UpdatedAt          time.Time                     `json:"updated_at"`
 
@@ -181,12 +192,14 @@ type Payslip struct {
 	// Tax calculation details
 	BasicExemptionApplied decimal.Decimal `json:"basic_exemption_applied"`
 
-	PaymentStatus string     `json:"payment_status"`
-	PaidAt        *time.Time `json:"paid_at,omitempty"`
-	CreatedAt     time.Time  `json:"created_at"`
+	PaymentStatus    string     `json:"payment_status"`
+	PaidAt           *time.Time `json:"paid_at,omitempty"`
+	PayrollPaymentID *string    `json:"payroll_payment_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
 
 	// Loaded relations
-	Employee *Employee `json:"employee,omitempty"`
+	Employee   *Employee          `json:"employee,omitempty"`
+	Components []PayslipComponent `json:"components,omitempty"`
 }
 
 // TSDDeclaration represents a TSD tax declaration for a period
@@ -289,6 +302,9 @@ type CreateEmployeeRequest struct {
 	ApplyBasicExemption  bool            `json:"apply_basic_exemption"`
 	BasicExemptionAmount decimal.Decimal `json:"basic_exemption_amount,omitempty"`
 	FundedPensionRate    decimal.Decimal `json:"funded_pension_rate,omitempty"`
+	HourlyRate           decimal.Decimal `json:"hourly_rate,omitempty"`
+	// WorkingTimeRatio is reported on the employment register START event; 1 is full time.
+	WorkingTimeRatio decimal.Decimal `json:"working_time_ratio,omitempty"`
 }
 
 // CreatePayrollRunRequest is the request to create a payroll run
@@ -344,6 +360,7 @@ type UpdateEmployeeRequest struct {
 	ApplyBasicExemption  *bool            `json:"apply_basic_exemption,omitempty"`
 	BasicExemptionAmount *decimal.Decimal `json:"basic_exemption_amount,omitempty"`
 	FundedPensionRate    *decimal.Decimal `json:"funded_pension_rate,omitempty"`
+	HourlyRate           *decimal.Decimal `json:"hourly_rate,omitempty"`
 	IsActive             *bool            `json:"is_active,omitempty"`
 }
 
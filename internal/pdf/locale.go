@@ -0,0 +1,368 @@
+package pdf
+
+import (
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+// labelSet holds the translated labels used across generated documents
+type labelSet struct {
+	PagePattern string
+	VATNumber   string
+	RegCode     string
+
+	// Invoices and credit notes
+	Invoice         string
+	CreditNote      string
+	PurchaseInvoice string
+	InvoiceNumber   string
+	IssueDate       string
+	DueDate         string
+	Status          string
+	Reference       string
+	CreditNoteFor   string
+	BillTo          string
+	Paid            string
+	AmountDue       string
+	PaymentDetails  string
+
+	// Quotes and orders
+	Quote             string
+	QuoteNumber       string
+	QuoteDate         string
+	ValidUntil        string
+	QuoteID           string
+	OrderConfirmation string
+	OrderNumber       string
+	OrderDate         string
+	ExpectedDelivery  string
+	Customer          string
+
+	// Delivery notes
+	DeliveryNote       string
+	DeliveryNoteNumber string
+	ShipmentDate       string
+	DeliverTo          string
+	Unit               string
+	LotSerial          string
+
+	// Line items and totals
+	Description string
+	Quantity    string
+	UnitPrice   string
+	VATPercent  string
+	Discount    string
+	LineTotal   string
+	Subtotal    string
+	VAT         string
+	Total       string
+	Terms       string
+	Notes       string
+
+	// Payslips
+	Payslip                 string
+	PayslipID               string
+	PaymentStatus           string
+	PaidAt                  string
+	Employee                string
+	EmployeeID              string
+	Amount                  string
+	GrossSalary             string
+	TaxableIncome           string
+	IncomeTax               string
+	UnemploymentInsuranceEE string
+	FundedPension           string
+	OtherDeductions         string
+	NetSalary               string
+	SocialTax               string
+	UnemploymentInsuranceER string
+	TotalEmployerCost       string
+	BasicExemptionApplied   string
+	PayLines                string
+	Hours                   string
+	Days                    string
+	Rate                    string
+
+	// Payment reminders
+	PaymentReminder string
+	ReminderIntro   string
+	ReminderClosing string
+	DaysOverdue     string
+	Outstanding     string
+	ScanToPay       string
+
+	// Defaults used when the tenant has not customised the footer or terms
+	DefaultFooterText   string
+	DefaultInvoiceTerms string
+}
+
+var labelSets = map[string]labelSet{
+	tenant.DocumentLanguageEnglish: {
+		PagePattern: "Page {current} of {total}",
+		VATNumber:   "VAT",
+		RegCode:     "Reg",
+
+		Invoice:         "INVOICE",
+		CreditNote:      "CREDIT NOTE",
+		PurchaseInvoice: "PURCHASE INVOICE",
+		InvoiceNumber:   "No.",
+		IssueDate:       "Issue Date",
+		DueDate:         "Due Date",
+		Status:          "Status",
+		Reference:       "Reference",
+		CreditNoteFor:   "Credit note for invoice %s",
+		BillTo:          "Bill To:",
+		Paid:            "Paid:",
+		AmountDue:       "Amount Due:",
+		PaymentDetails:  "Payment Details:",
+
+		Quote:             "QUOTE",
+		QuoteNumber:       "Quote No.",
+		QuoteDate:         "Quote Date",
+		ValidUntil:        "Valid Until",
+		QuoteID:           "Quote ID",
+		OrderConfirmation: "ORDER CONFIRMATION",
+		OrderNumber:       "Order No.",
+		OrderDate:         "Order Date",
+		ExpectedDelivery:  "Expected Delivery",
+		Customer:          "Customer:",
+
+		DeliveryNote:       "DELIVERY NOTE",
+		DeliveryNoteNumber: "Delivery Note No.",
+		ShipmentDate:       "Shipment Date",
+		DeliverTo:          "Deliver To:",
+		Unit:               "Unit",
+		LotSerial:          "Lot / Serial",
+
+		Description: "Description",
+		Quantity:    "Qty",
+		UnitPrice:   "Unit Price",
+		VATPercent:  "VAT %",
+		Discount:    "Discount",
+		LineTotal:   "Total",
+		Subtotal:    "Subtotal:",
+		VAT:         "VAT:",
+		Total:       "TOTAL:",
+		Terms:       "Terms & Conditions:",
+		Notes:       "Notes:",
+
+		Payslip:                 "PAYSLIP",
+		PayslipID:               "Payslip ID",
+		PaymentStatus:           "Payment status",
+		PaidAt:                  "Paid at",
+		Employee:                "Employee",
+		EmployeeID:              "Employee ID",
+		Amount:                  "Amount",
+		GrossSalary:             "Gross salary",
+		TaxableIncome:           "Taxable income",
+		IncomeTax:               "Income tax",
+		UnemploymentInsuranceEE: "Unemployment insurance employee",
+		FundedPension:           "Funded pension",
+		OtherDeductions:         "Other deductions",
+		NetSalary:               "Net salary",
+		SocialTax:               "Social tax",
+		UnemploymentInsuranceER: "Unemployment insurance employer",
+		TotalEmployerCost:       "Total employer cost",
+		BasicExemptionApplied:   "Basic exemption applied",
+		PayLines:                "Pay lines",
+		Hours:                   "h",
+		Days:                    "d",
+		Rate:                    "Rate",
+
+		PaymentReminder: "PAYMENT REMINDER",
+		ReminderIntro:   "According to our records, the invoice below has not been paid by its due date.",
+		ReminderClosing: "Please pay the outstanding amount at your earliest convenience. If you have already paid, please disregard this reminder.",
+		DaysOverdue:     "Days overdue",
+		Outstanding:     "Outstanding:",
+		ScanToPay:       "Scan to pay",
+
+		DefaultFooterText:   "Thank you for your business",
+		DefaultInvoiceTerms: "Payment due within 14 days of invoice date.",
+	},
+	tenant.DocumentLanguageEstonian: {
+		PagePattern: "Lehekülg {current} / {total}",
+		VATNumber:   "KMKR",
+		RegCode:     "Registrikood",
+
+		Invoice:         "ARVE",
+		CreditNote:      "KREEDITARVE",
+		PurchaseInvoice: "OSTUARVE",
+		InvoiceNumber:   "Nr.",
+		IssueDate:       "Arve kuupäev",
+		DueDate:         "Maksetähtaeg",
+		Status:          "Staatus",
+		Reference:       "Viitenumber",
+		CreditNoteFor:   "Kreeditarve arvele %s",
+		BillTo:          "Maksja:",
+		Paid:            "Tasutud:",
+		AmountDue:       "Tasuda:",
+		PaymentDetails:  "Makseandmed:",
+
+		Quote:             "HINNAPAKKUMINE",
+		QuoteNumber:       "Pakkumise nr.",
+		QuoteDate:         "Pakkumise kuupäev",
+		ValidUntil:        "Kehtib kuni",
+		QuoteID:           "Pakkumise ID",
+		OrderConfirmation: "TELLIMUSE KINNITUS",
+		OrderNumber:       "Tellimuse nr.",
+		OrderDate:         "Tellimuse kuupäev",
+		ExpectedDelivery:  "Eeldatav tarne",
+		Customer:          "Klient:",
+
+		DeliveryNote:       "SAATELEHT",
+		DeliveryNoteNumber: "Saatelehe nr.",
+		ShipmentDate:       "Saatmise kuupäev",
+		DeliverTo:          "Kaubasaaja:",
+		Unit:               "Ühik",
+		LotSerial:          "Partii / seerianr.",
+
+		Description: "Kirjeldus",
+		Quantity:    "Kogus",
+		UnitPrice:   "Ühiku hind",
+		VATPercent:  "KM %",
+		Discount:    "Allahindlus",
+		LineTotal:   "Summa",
+		Subtotal:    "Summa km-ta:",
+		VAT:         "Käibemaks:",
+		Total:       "KOKKU:",
+		Terms:       "Tingimused:",
+		Notes:       "Märkused:",
+
+		Payslip:                 "PALGATEATIS",
+		PayslipID:               "Palgateatise ID",
+		PaymentStatus:           "Makse staatus",
+		PaidAt:                  "Makstud",
+		Employee:                "Töötaja",
+		EmployeeID:              "Töötaja ID",
+		Amount:                  "Summa",
+		GrossSalary:             "Brutopalk",
+		TaxableIncome:           "Maksustatav tulu",
+		IncomeTax:               "Tulumaks",
+		UnemploymentInsuranceEE: "Töötuskindlustusmakse (töötaja)",
+		FundedPension:           "Kogumispension",
+		OtherDeductions:         "Muud kinnipidamised",
+		NetSalary:               "Netopalk",
+		SocialTax:               "Sotsiaalmaks",
+		UnemploymentInsuranceER: "Töötuskindlustusmakse (tööandja)",
+		TotalEmployerCost:       "Tööandja kulu kokku",
+		BasicExemptionApplied:   "Rakendatud maksuvaba tulu",
+		PayLines:                "Tasu read",
+		Hours:                   "t",
+		Days:                    "p",
+		Rate:                    "Määr",
+
+		PaymentReminder: "MAKSEMEELDETULETUS",
+		ReminderIntro:   "Meie andmetel ei ole allolevat arvet maksetähtajaks tasutud.",
+		ReminderClosing: "Palume tasuda võlgnevus esimesel võimalusel. Kui olete arve juba tasunud, palume meeldetuletust mitte arvestada.",
+		DaysOverdue:     "Päevi üle tähtaja",
+		Outstanding:     "Tasumata:",
+		ScanToPay:       "Maksa QR-koodiga",
+
+		DefaultFooterText:   "Täname koostöö eest",
+		DefaultInvoiceTerms: "Maksetähtaeg 14 päeva arve kuupäevast.",
+	},
+}
+
+// documentLocale combines the label set with the tenant's number and date formatting
+type documentLocale struct {
+	labels       labelSet
+	decimalSep   string
+	thousandsSep string
+	dateLayout   string
+}
+
+// localeFor resolves the document language from the contact, falling back to the
+// tenant default and then English. Formatting always follows the tenant settings.
+func localeFor(t *tenant.Tenant, contact *contacts.Contact) documentLocale {
+	language := ""
+	if contact != nil {
+		language = strings.ToLower(strings.TrimSpace(contact.Language))
+	}
+	if language == "" && t != nil {
+		language = strings.ToLower(strings.TrimSpace(t.Settings.DocumentLanguage))
+	}
+	labels, ok := labelSets[language]
+	if !ok {
+		labels = labelSets[tenant.DocumentLanguageEnglish]
+	}
+
+	loc := documentLocale{
+		labels:     labels,
+		decimalSep: ".",
+		dateLayout: "02.01.2006",
+	}
+	if t != nil {
+		if t.Settings.DecimalSep != "" {
+			loc.decimalSep = t.Settings.DecimalSep
+		}
+		loc.thousandsSep = t.Settings.ThousandsSep
+		if layout := goDateLayout(t.Settings.DateFormat); layout != "" {
+			loc.dateLayout = layout
+		}
+	}
+	return loc
+}
+
+// goDateLayout converts a tenant date format such as DD.MM.YYYY into a Go time layout.
+// Formats that do not contain a day, month and year are ignored.
+func goDateLayout(format string) string {
+	format = strings.ToUpper(strings.TrimSpace(format))
+	if !strings.Contains(format, "DD") || !strings.Contains(format, "MM") || !strings.Contains(format, "YY") {
+		return ""
+	}
+	return strings.NewReplacer("YYYY", "2006", "YY", "06", "MM", "01", "DD", "02").Replace(format)
+}
+
+func (l documentLocale) date(value time.Time) string {
+	return value.Format(l.dateLayout)
+}
+
+func (l documentLocale) number(d decimal.Decimal, precision int32) string {
+	formatted := formatDecimal(d, precision)
+	sign := ""
+	if strings.HasPrefix(formatted, "-") {
+		sign = "-"
+		formatted = formatted[1:]
+	}
+	integer, fraction, hasFraction := strings.Cut(formatted, ".")
+	if l.thousandsSep != "" && len(integer) > 3 {
+		var grouped strings.Builder
+		head := len(integer) % 3
+		if head > 0 {
+			grouped.WriteString(integer[:head])
+		}
+		for i := head; i < len(integer); i += 3 {
+			if grouped.Len() > 0 {
+				grouped.WriteString(l.thousandsSep)
+			}
+			grouped.WriteString(integer[i : i+3])
+		}
+		integer = grouped.String()
+	}
+	if hasFraction {
+		return sign + integer + l.decimalSep + fraction
+	}
+	return sign + integer
+}
+
+func (l documentLocale) money(amount decimal.Decimal, currency string) string {
+	return currency + " " + l.number(amount, 2)
+}
+
+// localizedSettings swaps the built-in English footer and terms for the
+// translated defaults; tenant-provided texts are left untouched.
+func (l documentLocale) localizedSettings(settings PDFSettings) PDFSettings {
+	defaults := DefaultPDFSettings()
+	if settings.FooterText == defaults.FooterText {
+		settings.FooterText = l.labels.DefaultFooterText
+	}
+	if settings.InvoiceTerms == defaults.InvoiceTerms {
+		settings.InvoiceTerms = l.labels.DefaultInvoiceTerms
+	}
+	return settings
+}
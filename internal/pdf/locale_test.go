@@ -0,0 +1,77 @@
+package pdf
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+// plainLocale formats numbers without grouping and with a decimal point.
+var plainLocale = localeFor(nil, nil)
+
+func TestLocaleForResolvesLanguage(t *testing.T) {
+	estonianTenant := &tenant.Tenant{Settings: tenant.TenantSettings{DocumentLanguage: tenant.DocumentLanguageEstonian}}
+
+	assert.Equal(t, "ARVE", localeFor(estonianTenant, nil).labels.Invoice)
+	assert.Equal(t, "ARVE", localeFor(estonianTenant, &contacts.Contact{}).labels.Invoice)
+	assert.Equal(t, "INVOICE", localeFor(estonianTenant, &contacts.Contact{Language: "EN"}).labels.Invoice)
+	assert.Equal(t, "KREEDITARVE", localeFor(&tenant.Tenant{}, &contacts.Contact{Language: "et"}).labels.CreditNote)
+	assert.Equal(t, "INVOICE", localeFor(&tenant.Tenant{}, nil).labels.Invoice)
+	assert.Equal(t, "INVOICE", localeFor(&tenant.Tenant{Settings: tenant.TenantSettings{DocumentLanguage: "fi"}}, nil).labels.Invoice)
+}
+
+func TestLabelSetsAreComplete(t *testing.T) {
+	english := labelSets[tenant.DocumentLanguageEnglish]
+	estonian := labelSets[tenant.DocumentLanguageEstonian]
+	assert.NotEqual(t, english, estonian)
+	assert.NotContains(t, []string{
+		estonian.PagePattern, estonian.Invoice, estonian.Quote, estonian.OrderConfirmation,
+		estonian.Payslip, estonian.PaymentReminder, estonian.ReminderIntro, estonian.DefaultFooterText,
+		estonian.CreditNote, estonian.CreditNoteFor,
+	}, "")
+	assert.Equal(t, "Kreeditarve arvele INV-1", fmt.Sprintf(estonian.CreditNoteFor, "INV-1"))
+	assert.Equal(t, "Credit note for invoice INV-1", fmt.Sprintf(english.CreditNoteFor, "INV-1"))
+}
+
+func TestDocumentLocaleFormatting(t *testing.T) {
+	loc := localeFor(&tenant.Tenant{Settings: tenant.DefaultSettings()}, nil)
+
+	assert.Equal(t, "EUR 1 234 567,89", loc.money(decimal.RequireFromString("1234567.891"), "EUR"))
+	assert.Equal(t, "EUR -1 000,00", loc.money(decimal.NewFromInt(-1000), "EUR"))
+	assert.Equal(t, "999,50", loc.number(decimal.RequireFromString("999.5"), 2))
+	assert.Equal(t, "24", loc.number(decimal.NewFromInt(24), 0))
+	assert.Equal(t, "05.03.2026", loc.date(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)))
+
+	us := localeFor(&tenant.Tenant{Settings: tenant.TenantSettings{DecimalSep: ".", ThousandsSep: ",", DateFormat: "MM/DD/YYYY"}}, nil)
+	assert.Equal(t, "USD 12,345.60", us.money(decimal.RequireFromString("12345.6"), "USD"))
+	assert.Equal(t, "03/05/2026", us.date(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, "EUR 1234.56", plainLocale.money(decimal.RequireFromString("1234.56"), "EUR"))
+	assert.Equal(t, "05.03.2026", plainLocale.date(time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestGoDateLayout(t *testing.T) {
+	assert.Equal(t, "02.01.2006", goDateLayout("DD.MM.YYYY"))
+	assert.Equal(t, "2006-01-02", goDateLayout("yyyy-mm-dd"))
+	assert.Equal(t, "02/01/06", goDateLayout("DD/MM/YY"))
+	assert.Equal(t, "", goDateLayout(""))
+	assert.Equal(t, "", goDateLayout("YYYY"))
+}
+
+func TestLocalizedSettings(t *testing.T) {
+	loc := localeFor(&tenant.Tenant{Settings: tenant.TenantSettings{DocumentLanguage: tenant.DocumentLanguageEstonian}}, nil)
+
+	settings := loc.localizedSettings(DefaultPDFSettings())
+	assert.Equal(t, "Täname koostöö eest", settings.FooterText)
+	assert.Equal(t, "Maksetähtaeg 14 päeva arve kuupäevast.", settings.InvoiceTerms)
+
+	custom := DefaultPDFSettings()
+	custom.FooterText = "Aitäh!"
+	assert.Equal(t, "Aitäh!", loc.localizedSettings(custom).FooterText)
+}
@@ -39,6 +39,11 @@ func TestWave11PDFGenerationErrors(t *testing.T) {
 	require.ErrorIs(t, err, expectedErr)
 	require.Contains(t, err.Error(), "failed to generate PDF")
 
+	reminderBytes, err := service.GenerateReminderPDF(createTestInvoice(), tnant, DefaultPDFSettings(), createTestInvoice().DueDate)
+	require.Nil(t, reminderBytes)
+	require.ErrorIs(t, err, expectedErr)
+	require.Contains(t, err.Error(), "failed to generate reminder PDF")
+
 	payslipBytes, err := service.GeneratePayslipPDF(&payroll.Payslip{
 		ID:            "payslip-1",
 		TenantID:      "tenant-1",
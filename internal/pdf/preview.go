@@ -0,0 +1,180 @@
+package pdf
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/orders"
+	"github.com/HMB-research/open-accounting/internal/quotes"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+var (
+	sampleIssueDate = time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	sampleDueDate   = sampleIssueDate.AddDate(0, 0, 14)
+)
+
+// GenerateTemplatePreviewPDF renders a document template against a sample
+// document so a layout can be checked before it is saved. The tenant's
+// company details, logo and saved settings are used as-is.
+func (s *Service) GenerateTemplatePreviewPDF(documentType string, tmpl tenant.DocumentTemplate, t *tenant.Tenant, pdfSettings PDFSettings) ([]byte, error) {
+	documentType, err := tenant.NormalizeDocumentType(documentType)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := tenant.NormalizeDocumentTemplate(tmpl)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := sampleTenant()
+	if t != nil {
+		preview = *t
+	}
+	templates := make(map[string]tenant.DocumentTemplate, len(preview.Settings.DocumentTemplates)+1)
+	for key, value := range preview.Settings.DocumentTemplates {
+		templates[key] = value
+	}
+	templates[documentType] = normalized
+	preview.Settings.DocumentTemplates = templates
+
+	invoice := sampleInvoice()
+	switch documentType {
+	case tenant.DocumentTypeQuote:
+		return s.GenerateQuotePDF(sampleQuote(invoice), &preview, pdfSettings)
+	case tenant.DocumentTypeOrder:
+		return s.GenerateOrderPDF(sampleOrder(invoice), &preview, pdfSettings)
+	case tenant.DocumentTypeReminder:
+		return s.GenerateReminderPDF(invoice, &preview, pdfSettings, sampleDueDate.AddDate(0, 0, 10))
+	default:
+		return s.GenerateInvoicePDF(invoice, &preview, pdfSettings)
+	}
+}
+
+func sampleTenant() tenant.Tenant {
+	return tenant.Tenant{
+		Name: "Sample Company OÜ",
+		Settings: tenant.TenantSettings{
+			RegCode:   "12345678",
+			VATNumber: "EE123456789",
+			Address:   "Narva mnt 5, 10117 Tallinn",
+			Email:     "billing@example.com",
+		},
+	}
+}
+
+func sampleInvoice() *invoicing.Invoice {
+	lines := []invoicing.InvoiceLine{
+		sampleInvoiceLine(1, "Consulting services", decimal.NewFromInt(10), decimal.NewFromInt(80), decimal.Zero),
+		sampleInvoiceLine(2, "Software licence", decimal.NewFromInt(1), decimal.NewFromInt(250), decimal.NewFromInt(10)),
+	}
+	invoice := &invoicing.Invoice{
+		InvoiceNumber: "INV-00001",
+		InvoiceType:   invoicing.InvoiceTypeSales,
+		Contact: &contacts.Contact{
+			Name:         "Sample Customer AS",
+			RegCode:      "87654321",
+			VATNumber:    "EE987654321",
+			Email:        "accounts@customer.example",
+			AddressLine1: "Ülikooli 1",
+			City:         "Tartu",
+			PostalCode:   "51003",
+			CountryCode:  "EE",
+		},
+		IssueDate:  sampleIssueDate,
+		DueDate:    sampleDueDate,
+		Currency:   "EUR",
+		Status:     invoicing.StatusSent,
+		Reference:  "1234561",
+		Notes:      fmt.Sprintf("Sample document generated for template preview on %s", sampleIssueDate.Format("2006-01-02")),
+		Lines:      lines,
+		AmountPaid: decimal.Zero,
+	}
+	for _, line := range lines {
+		invoice.Subtotal = invoice.Subtotal.Add(line.LineSubtotal)
+		invoice.VATAmount = invoice.VATAmount.Add(line.LineVAT)
+		invoice.Total = invoice.Total.Add(line.LineTotal)
+	}
+	return invoice
+}
+
+func sampleInvoiceLine(number int, description string, quantity, unitPrice, discount decimal.Decimal) invoicing.InvoiceLine {
+	vatRate := decimal.NewFromInt(24)
+	subtotal := quantity.Mul(unitPrice).Mul(decimal.NewFromInt(100).Sub(discount)).Div(decimal.NewFromInt(100)).Round(2)
+	vat := subtotal.Mul(vatRate).Div(decimal.NewFromInt(100)).Round(2)
+	return invoicing.InvoiceLine{
+		LineNumber:      number,
+		Description:     description,
+		Quantity:        quantity,
+		UnitPrice:       unitPrice,
+		DiscountPercent: discount,
+		VATRate:         vatRate,
+		LineSubtotal:    subtotal,
+		LineVAT:         vat,
+		LineTotal:       subtotal.Add(vat),
+	}
+}
+
+func sampleQuote(invoice *invoicing.Invoice) *quotes.Quote {
+	validUntil := invoice.DueDate
+	quote := &quotes.Quote{
+		QuoteNumber: "QUO-00001",
+		Contact:     invoice.Contact,
+		QuoteDate:   invoice.IssueDate,
+		ValidUntil:  &validUntil,
+		Status:      quotes.QuoteStatusSent,
+		Currency:    invoice.Currency,
+		Subtotal:    invoice.Subtotal,
+		VATAmount:   invoice.VATAmount,
+		Total:       invoice.Total,
+		Notes:       invoice.Notes,
+	}
+	for _, line := range invoice.Lines {
+		quote.Lines = append(quote.Lines, quotes.QuoteLine{
+			LineNumber:      line.LineNumber,
+			Description:     line.Description,
+			Quantity:        line.Quantity,
+			UnitPrice:       line.UnitPrice,
+			DiscountPercent: line.DiscountPercent,
+			VATRate:         line.VATRate,
+			LineSubtotal:    line.LineSubtotal,
+			LineVAT:         line.LineVAT,
+			LineTotal:       line.LineTotal,
+		})
+	}
+	return quote
+}
+
+func sampleOrder(invoice *invoicing.Invoice) *orders.Order {
+	expected := invoice.DueDate
+	order := &orders.Order{
+		OrderNumber:      "ORD-00001",
+		Contact:          invoice.Contact,
+		OrderDate:        invoice.IssueDate,
+		ExpectedDelivery: &expected,
+		Status:           orders.OrderStatusConfirmed,
+		Currency:         invoice.Currency,
+		Subtotal:         invoice.Subtotal,
+		VATAmount:        invoice.VATAmount,
+		Total:            invoice.Total,
+		Notes:            invoice.Notes,
+	}
+	for _, line := range invoice.Lines {
+		order.Lines = append(order.Lines, orders.OrderLine{
+			LineNumber:      line.LineNumber,
+			Description:     line.Description,
+			Quantity:        line.Quantity,
+			UnitPrice:       line.UnitPrice,
+			DiscountPercent: line.DiscountPercent,
+			VATRate:         line.VATRate,
+			LineSubtotal:    line.LineSubtotal,
+			LineVAT:         line.LineVAT,
+			LineTotal:       line.LineTotal,
+		})
+	}
+	return order
+}
@@ -3,12 +3,11 @@ package pdf
 import (
 	"fmt"
 	"strings"
+	"time"
 
-	"github.com/johnfercher/maroto/v2"
 	"github.com/johnfercher/maroto/v2/pkg/components/col"
 	"github.com/johnfercher/maroto/v2/pkg/components/line"
 	"github.com/johnfercher/maroto/v2/pkg/components/text"
-	"github.com/johnfercher/maroto/v2/pkg/config"
 	"github.com/johnfercher/maroto/v2/pkg/consts/align"
 	"github.com/johnfercher/maroto/v2/pkg/consts/border"
 	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
@@ -33,6 +32,7 @@ type PDFSettings struct {
 }
 
 type commercialDocumentPDF struct {
+	DocumentType       string
 	Title              string
 	NumberLabel        string
 	Number             string
@@ -107,36 +107,39 @@ func NewService() *Service {
 
 // GenerateInvoicePDF generates a PDF for the given invoice
 func (s *Service) GenerateInvoicePDF(invoice *invoicing.Invoice, t *tenant.Tenant, pdfSettings PDFSettings) ([]byte, error) {
-	cfg := config.NewBuilder().
-		WithPageNumber(props.PageNumber{
-			Pattern: "Page {current} of {total}",
-			Place:   props.RightBottom,
-			Size:    8,
-		}).
-		WithLeftMargin(15).
-		WithTopMargin(15).
-		WithRightMargin(15).
-		Build()
-
-	m := maroto.New(cfg)
+	loc := localeFor(t, invoice.Contact)
+	pdfSettings = loc.localizedSettings(pdfSettings)
+	layout, err := layoutFor(t, tenant.DocumentTypeInvoice, invoiceTemplateData(invoice, t, tenant.DocumentTypeInvoice, invoiceTitle(invoice, loc), loc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	if layout.footerText != "" {
+		pdfSettings.FooterText = layout.footerText
+	}
+
+	m := newDocument(loc, layout.template)
 
 	// Header with company info
-	s.addHeader(m, t)
+	s.addHeader(m, t, loc, layout)
 
 	// Invoice title and details
-	s.addInvoiceTitle(m, invoice)
+	s.addInvoiceTitle(m, invoice, loc)
+	s.addTemplateFields(m, layout)
 
 	// Bill to section
-	s.addBillTo(m, invoice)
+	s.addBillTo(m, invoice.Contact, loc.labels.BillTo, loc)
 
 	// Line items table
-	s.addLineItems(m, invoice, t)
+	s.addLineItems(m, invoiceDocumentLines(invoice), invoice.Currency, loc, layout.template.HideDiscountColumn)
 
 	// Totals
-	s.addTotals(m, invoice, t)
+	s.addTotals(m, invoice, loc)
+	if invoice.InvoiceType != invoicing.InvoiceTypePurchase {
+		s.addPaymentQRCode(m, layout, t, pdfSettings, invoice.AmountDue(), invoice.Currency, invoiceRemittance(invoice), loc)
+	}
 
 	// Payment details and notes
-	s.addFooter(m, invoice, pdfSettings)
+	s.addFooter(m, invoice.Notes, pdfSettings, loc)
 
 	doc, err := generateMarotoPDF(m)
 	if err != nil {
@@ -148,15 +151,17 @@ func (s *Service) GenerateInvoicePDF(invoice *invoicing.Invoice, t *tenant.Tenan
 
 // GenerateQuotePDF generates a customer-facing PDF for a sales quote.
 func (s *Service) GenerateQuotePDF(quote *quotes.Quote, t *tenant.Tenant, pdfSettings PDFSettings) ([]byte, error) {
+	loc := localeFor(t, quote.Contact)
 	doc := commercialDocumentPDF{
-		Title:              "QUOTE",
-		NumberLabel:        "Quote No.",
+		DocumentType:       tenant.DocumentTypeQuote,
+		Title:              loc.labels.Quote,
+		NumberLabel:        loc.labels.QuoteNumber,
 		Number:             quote.QuoteNumber,
 		Status:             string(quote.Status),
-		PrimaryDateLabel:   "Quote Date",
-		PrimaryDate:        quote.QuoteDate.Format("02.01.2006"),
-		SecondaryDateLabel: "Valid Until",
-		RecipientLabel:     "Customer:",
+		PrimaryDateLabel:   loc.labels.QuoteDate,
+		PrimaryDate:        loc.date(quote.QuoteDate),
+		SecondaryDateLabel: loc.labels.ValidUntil,
+		RecipientLabel:     loc.labels.Customer,
 		Contact:            quote.Contact,
 		Currency:           quote.Currency,
 		Subtotal:           quote.Subtotal,
@@ -166,7 +171,7 @@ func (s *Service) GenerateQuotePDF(quote *quotes.Quote, t *tenant.Tenant, pdfSet
 		Lines:              make([]commercialDocumentLine, 0, len(quote.Lines)),
 	}
 	if quote.ValidUntil != nil {
-		doc.SecondaryDate = quote.ValidUntil.Format("02.01.2006")
+		doc.SecondaryDate = loc.date(*quote.ValidUntil)
 	}
 	for _, line := range quote.Lines {
 		doc.Lines = append(doc.Lines, commercialDocumentLine{
@@ -180,20 +185,22 @@ func (s *Service) GenerateQuotePDF(quote *quotes.Quote, t *tenant.Tenant, pdfSet
 		})
 	}
 
-	return s.generateCommercialDocumentPDF(doc, t, pdfSettings)
+	return s.generateCommercialDocumentPDF(doc, t, pdfSettings, loc)
 }
 
 // GenerateOrderPDF generates a customer-facing PDF for a sales order.
 func (s *Service) GenerateOrderPDF(order *orders.Order, t *tenant.Tenant, pdfSettings PDFSettings) ([]byte, error) {
+	loc := localeFor(t, order.Contact)
 	doc := commercialDocumentPDF{
-		Title:              "ORDER CONFIRMATION",
-		NumberLabel:        "Order No.",
+		DocumentType:       tenant.DocumentTypeOrder,
+		Title:              loc.labels.OrderConfirmation,
+		NumberLabel:        loc.labels.OrderNumber,
 		Number:             order.OrderNumber,
 		Status:             string(order.Status),
-		PrimaryDateLabel:   "Order Date",
-		PrimaryDate:        order.OrderDate.Format("02.01.2006"),
-		SecondaryDateLabel: "Expected Delivery",
-		RecipientLabel:     "Customer:",
+		PrimaryDateLabel:   loc.labels.OrderDate,
+		PrimaryDate:        loc.date(order.OrderDate),
+		SecondaryDateLabel: loc.labels.ExpectedDelivery,
+		RecipientLabel:     loc.labels.Customer,
 		Contact:            order.Contact,
 		Currency:           order.Currency,
 		Subtotal:           order.Subtotal,
@@ -203,10 +210,10 @@ func (s *Service) GenerateOrderPDF(order *orders.Order, t *tenant.Tenant, pdfSet
 		Lines:              make([]commercialDocumentLine, 0, len(order.Lines)),
 	}
 	if order.ExpectedDelivery != nil {
-		doc.SecondaryDate = order.ExpectedDelivery.Format("02.01.2006")
+		doc.SecondaryDate = loc.date(*order.ExpectedDelivery)
 	}
 	if order.QuoteID != nil && strings.TrimSpace(*order.QuoteID) != "" {
-		doc.ReferenceLabel = "Quote ID"
+		doc.ReferenceLabel = loc.labels.QuoteID
 		doc.Reference = strings.TrimSpace(*order.QuoteID)
 	}
 	for _, line := range order.Lines {
@@ -221,28 +228,71 @@ func (s *Service) GenerateOrderPDF(order *orders.Order, t *tenant.Tenant, pdfSet
 		})
 	}
 
-	return s.generateCommercialDocumentPDF(doc, t, pdfSettings)
+	return s.generateCommercialDocumentPDF(doc, t, pdfSettings, loc)
 }
 
-func (s *Service) generateCommercialDocumentPDF(doc commercialDocumentPDF, t *tenant.Tenant, pdfSettings PDFSettings) ([]byte, error) {
-	cfg := config.NewBuilder().
-		WithPageNumber(props.PageNumber{
-			Pattern: "Page {current} of {total}",
-			Place:   props.RightBottom,
-			Size:    8,
-		}).
-		WithLeftMargin(15).
-		WithTopMargin(15).
-		WithRightMargin(15).
-		Build()
-
-	m := maroto.New(cfg)
-	s.addHeader(m, t)
-	s.addCommercialDocumentTitle(m, doc)
-	s.addCommercialDocumentRecipient(m, doc)
-	s.addCommercialDocumentLineItems(m, doc)
-	s.addCommercialDocumentTotals(m, doc)
-	s.addCommercialDocumentFooter(m, doc, pdfSettings)
+// GenerateDeliveryNotePDF generates a delivery note listing the quantities of
+// one order shipment. It uses the order template but shows no prices.
+func (s *Service) GenerateDeliveryNotePDF(order *orders.Order, shipment *orders.OrderShipment, t *tenant.Tenant, pdfSettings PDFSettings) ([]byte, error) {
+	loc := localeFor(t, order.Contact)
+	pdfSettings = loc.localizedSettings(pdfSettings)
+	pdfSettings.InvoiceTerms = ""
+	doc := commercialDocumentPDF{
+		DocumentType:     tenant.DocumentTypeOrder,
+		Title:            loc.labels.DeliveryNote,
+		NumberLabel:      loc.labels.DeliveryNoteNumber,
+		Number:           shipment.ShipmentNumber,
+		Status:           string(order.Status),
+		PrimaryDateLabel: loc.labels.ShipmentDate,
+		PrimaryDate:      loc.date(shipment.ShipmentDate),
+		ReferenceLabel:   loc.labels.OrderNumber,
+		Reference:        order.OrderNumber,
+		RecipientLabel:   loc.labels.DeliverTo,
+		Contact:          order.Contact,
+		Currency:         order.Currency,
+		Notes:            shipment.Notes,
+	}
+	layout, err := layoutFor(t, doc.DocumentType, commercialDocumentTemplateData(doc, t, loc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery note PDF: %w", err)
+	}
+	if layout.footerText != "" {
+		pdfSettings.FooterText = layout.footerText
+	}
+
+	m := newDocument(loc, layout.template)
+	s.addHeader(m, t, loc, layout)
+	s.addCommercialDocumentTitle(m, doc, loc)
+	s.addTemplateFields(m, layout)
+	s.addBillTo(m, doc.Contact, doc.RecipientLabel, loc)
+	s.addDeliveryNoteLines(m, shipment.Lines, loc)
+	s.addCommercialDocumentFooter(m, doc, pdfSettings, loc)
+
+	generated, err := generateMarotoPDF(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delivery note PDF: %w", err)
+	}
+	return generated.GetBytes(), nil
+}
+
+func (s *Service) generateCommercialDocumentPDF(doc commercialDocumentPDF, t *tenant.Tenant, pdfSettings PDFSettings, loc documentLocale) ([]byte, error) {
+	pdfSettings = loc.localizedSettings(pdfSettings)
+	layout, err := layoutFor(t, doc.DocumentType, commercialDocumentTemplateData(doc, t, loc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+	if layout.footerText != "" {
+		pdfSettings.FooterText = layout.footerText
+	}
+
+	m := newDocument(loc, layout.template)
+	s.addHeader(m, t, loc, layout)
+	s.addCommercialDocumentTitle(m, doc, loc)
+	s.addTemplateFields(m, layout)
+	s.addBillTo(m, doc.Contact, doc.RecipientLabel, loc)
+	s.addLineItems(m, doc.Lines, doc.Currency, loc, layout.template.HideDiscountColumn)
+	s.addCommercialDocumentTotals(m, doc, loc)
+	s.addCommercialDocumentFooter(m, doc, pdfSettings, loc)
 
 	generated, err := generateMarotoPDF(m)
 	if err != nil {
@@ -251,24 +301,44 @@ func (s *Service) generateCommercialDocumentPDF(doc commercialDocumentPDF, t *te
 	return generated.GetBytes(), nil
 }
 
+// GenerateReminderPDF generates a payment reminder letter for an overdue invoice.
+func (s *Service) GenerateReminderPDF(invoice *invoicing.Invoice, t *tenant.Tenant, pdfSettings PDFSettings, asOf time.Time) ([]byte, error) {
+	loc := localeFor(t, invoice.Contact)
+	pdfSettings = loc.localizedSettings(pdfSettings)
+	layout, err := layoutFor(t, tenant.DocumentTypeReminder, invoiceTemplateData(invoice, t, tenant.DocumentTypeReminder, loc.labels.PaymentReminder, loc))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reminder PDF: %w", err)
+	}
+	if layout.footerText != "" {
+		pdfSettings.FooterText = layout.footerText
+	}
+
+	m := newDocument(loc, layout.template)
+	s.addHeader(m, t, loc, layout)
+	s.addReminderSummary(m, invoice, asOf, loc)
+	s.addTemplateFields(m, layout)
+	s.addBillTo(m, invoice.Contact, loc.labels.BillTo, loc)
+	s.addLineItems(m, invoiceDocumentLines(invoice), invoice.Currency, loc, layout.template.HideDiscountColumn)
+	s.addReminderTotals(m, invoice, loc)
+	s.addPaymentQRCode(m, layout, t, pdfSettings, invoice.AmountDue(), invoice.Currency, invoiceRemittance(invoice), loc)
+	s.addFooter(m, "", pdfSettings, loc)
+
+	doc, err := generateMarotoPDF(m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate reminder PDF: %w", err)
+	}
+	return doc.GetBytes(), nil
+}
+
 // GeneratePayslipPDF generates a PDF for an employee payslip.
 func (s *Service) GeneratePayslipPDF(payslip *payroll.Payslip, run *payroll.PayrollRun, t *tenant.Tenant) ([]byte, error) {
-	cfg := config.NewBuilder().
-		WithPageNumber(props.PageNumber{
-			Pattern: "Page {current} of {total}",
-			Place:   props.RightBottom,
-			Size:    8,
-		}).
-		WithLeftMargin(15).
-		WithTopMargin(15).
-		WithRightMargin(15).
-		Build()
-
-	m := maroto.New(cfg)
-	s.addHeader(m, t)
-	s.addPayslipTitle(m, payslip, run)
-	s.addPayslipEmployee(m, payslip)
-	s.addPayslipAmounts(m, payslip)
+	loc := localeFor(t, nil)
+	m := newDocument(loc, tenant.DocumentTemplate{})
+	s.addHeader(m, t, loc, documentLayout{})
+	s.addPayslipTitle(m, payslip, run, loc)
+	s.addPayslipEmployee(m, payslip, loc)
+	s.addPayslipComponents(m, payslip, loc)
+	s.addPayslipAmounts(m, payslip, loc)
 
 	doc, err := generateMarotoPDF(m)
 	if err != nil {
@@ -277,7 +347,69 @@ func (s *Service) GeneratePayslipPDF(payslip *payroll.Payslip, run *payroll.Payr
 	return doc.GetBytes(), nil
 }
 
-func (s *Service) addHeader(m core.Maroto, t *tenant.Tenant) {
+func (s *Service) addReminderSummary(m core.Maroto, invoice *invoicing.Invoice, asOf time.Time, loc documentLocale) {
+	daysOverdue := 0
+	if asOf.After(invoice.DueDate) {
+		daysOverdue = int(asOf.Sub(invoice.DueDate).Hours() / 24)
+	}
+
+	m.AddRow(12,
+		col.New(6).Add(text.New(loc.labels.PaymentReminder, props.Text{Size: 20, Style: fontstyle.Bold, Align: align.Left})),
+		col.New(6).Add(text.New(loc.date(asOf), props.Text{Size: 12, Align: align.Right})),
+	)
+	m.AddRow(8,
+		col.New(12).Add(text.New(loc.labels.ReminderIntro, props.Text{Size: 10, Align: align.Left})),
+	)
+	m.AddRow(6,
+		col.New(6).Add(text.New(fmt.Sprintf("%s %s %s", loc.labels.Invoice, loc.labels.InvoiceNumber, invoice.InvoiceNumber), props.Text{Size: 10, Style: fontstyle.Bold, Align: align.Left})),
+		col.New(6).Add(text.New(fmt.Sprintf("%s: %d", loc.labels.DaysOverdue, daysOverdue), props.Text{Size: 10, Style: fontstyle.Bold, Align: align.Right})),
+	)
+	m.AddRow(6,
+		col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.IssueDate, loc.date(invoice.IssueDate)), props.Text{Size: 9, Align: align.Left})),
+		col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.DueDate, loc.date(invoice.DueDate)), props.Text{Size: 9, Align: align.Right})),
+	)
+	if invoice.Reference != "" {
+		m.AddRow(6,
+			col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.Reference, invoice.Reference), props.Text{Size: 9, Align: align.Left})),
+		)
+	}
+	m.AddRow(8)
+}
+
+func (s *Service) addReminderTotals(m core.Maroto, invoice *invoicing.Invoice, loc documentLocale) {
+	labelStyle := props.Text{Size: 10, Align: align.Left}
+	totalStyle := props.Text{Size: 10, Align: align.Right}
+	labelBoldStyle := props.Text{Size: 11, Style: fontstyle.Bold, Align: align.Left}
+	totalBoldStyle := props.Text{Size: 11, Style: fontstyle.Bold, Align: align.Right}
+
+	m.AddRow(6,
+		col.New(8),
+		col.New(2).Add(text.New(loc.labels.Total, labelStyle)),
+		col.New(2).Add(text.New(loc.money(invoice.Total, invoice.Currency), totalStyle)),
+	)
+	m.AddRow(6,
+		col.New(8),
+		col.New(2).Add(text.New(loc.labels.Paid, labelStyle)),
+		col.New(2).Add(text.New(loc.money(invoice.AmountPaid, invoice.Currency), totalStyle)),
+	)
+	m.AddRow(1,
+		col.New(8),
+		col.New(4).Add(line.New(props.Line{Thickness: 0.5})),
+	)
+	m.AddRow(8,
+		col.New(8),
+		col.New(2).Add(text.New(loc.labels.Outstanding, labelBoldStyle)),
+		col.New(2).Add(text.New(loc.money(invoice.AmountDue(), invoice.Currency), totalBoldStyle)),
+	)
+	m.AddRow(6)
+	m.AddRow(10,
+		col.New(12).Add(text.New(loc.labels.ReminderClosing, props.Text{Size: 10, Align: align.Left})),
+	)
+	m.AddRow(6)
+}
+
+func (s *Service) addHeader(m core.Maroto, t *tenant.Tenant, loc documentLocale, layout documentLayout) {
+	s.addLogo(m, layout)
 	m.AddRow(20,
 		col.New(8).Add(
 			text.New(t.Name, props.Text{
@@ -300,10 +432,10 @@ func (s *Service) addHeader(m core.Maroto, t *tenant.Tenant) {
 		companyDetails = append(companyDetails, t.Settings.Phone)
 	}
 	if t.Settings.VATNumber != "" {
-		companyDetails = append(companyDetails, fmt.Sprintf("VAT: %s", t.Settings.VATNumber))
+		companyDetails = append(companyDetails, fmt.Sprintf("%s: %s", loc.labels.VATNumber, t.Settings.VATNumber))
 	}
 	if t.Settings.RegCode != "" {
-		companyDetails = append(companyDetails, fmt.Sprintf("Reg: %s", t.Settings.RegCode))
+		companyDetails = append(companyDetails, fmt.Sprintf("%s: %s", loc.labels.RegCode, t.Settings.RegCode))
 	}
 
 	for _, detail := range companyDetails {
@@ -317,6 +449,14 @@ func (s *Service) addHeader(m core.Maroto, t *tenant.Tenant) {
 		)
 	}
 
+	// Template header text
+	if layout.headerText != "" {
+		m.AddRow(3)
+		for _, line := range strings.Split(layout.headerText, "\n") {
+			m.AddRow(5, col.New(12).Add(text.New(line, props.Text{Size: 9, Align: align.Left})))
+		}
+	}
+
 	// Separator line
 	m.AddRow(5)
 	m.AddRow(1,
@@ -329,14 +469,14 @@ func (s *Service) addHeader(m core.Maroto, t *tenant.Tenant) {
 	m.AddRow(5)
 }
 
-func (s *Service) addPayslipTitle(m core.Maroto, payslip *payroll.Payslip, run *payroll.PayrollRun) {
+func (s *Service) addPayslipTitle(m core.Maroto, payslip *payroll.Payslip, run *payroll.PayrollRun, loc documentLocale) {
 	period := ""
 	if run != nil {
 		period = fmt.Sprintf("%04d-%02d", run.PeriodYear, run.PeriodMonth)
 	}
 	m.AddRow(12,
 		col.New(6).Add(
-			text.New("PAYSLIP", props.Text{
+			text.New(loc.labels.Payslip, props.Text{
 				Size:  20,
 				Style: fontstyle.Bold,
 				Align: align.Left,
@@ -351,18 +491,18 @@ func (s *Service) addPayslipTitle(m core.Maroto, payslip *payroll.Payslip, run *
 		),
 	)
 	m.AddRow(6,
-		col.New(6).Add(text.New(fmt.Sprintf("Payslip ID: %s", payslip.ID), props.Text{Size: 9, Align: align.Left})),
-		col.New(6).Add(text.New(fmt.Sprintf("Payment status: %s", payslip.PaymentStatus), props.Text{Size: 9, Align: align.Right})),
+		col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.PayslipID, payslip.ID), props.Text{Size: 9, Align: align.Left})),
+		col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.PaymentStatus, payslip.PaymentStatus), props.Text{Size: 9, Align: align.Right})),
 	)
 	if payslip.PaidAt != nil {
 		m.AddRow(6,
-			col.New(6).Add(text.New(fmt.Sprintf("Paid at: %s", payslip.PaidAt.Format("02.01.2006")), props.Text{Size: 9, Align: align.Left})),
+			col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.PaidAt, loc.date(*payslip.PaidAt)), props.Text{Size: 9, Align: align.Left})),
 		)
 	}
 	m.AddRow(8)
 }
 
-func (s *Service) addPayslipEmployee(m core.Maroto, payslip *payroll.Payslip) {
+func (s *Service) addPayslipEmployee(m core.Maroto, payslip *payroll.Payslip, loc documentLocale) {
 	name := payslip.EmployeeID
 	personalCode := ""
 	email := ""
@@ -376,11 +516,11 @@ func (s *Service) addPayslipEmployee(m core.Maroto, payslip *payroll.Payslip) {
 	}
 
 	m.AddRow(6,
-		col.New(12).Add(text.New("Employee", props.Text{Size: 10, Style: fontstyle.Bold, Align: align.Left})),
+		col.New(12).Add(text.New(loc.labels.Employee, props.Text{Size: 10, Style: fontstyle.Bold, Align: align.Left})),
 	)
 	m.AddRow(5,
 		col.New(6).Add(text.New(name, props.Text{Size: 10, Style: fontstyle.Bold, Align: align.Left})),
-		col.New(6).Add(text.New(fmt.Sprintf("Employee ID: %s", payslip.EmployeeID), props.Text{Size: 9, Align: align.Right})),
+		col.New(6).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.EmployeeID, payslip.EmployeeID), props.Text{Size: 9, Align: align.Right})),
 	)
 	if personalCode != "" || email != "" {
 		m.AddRow(5,
@@ -391,15 +531,77 @@ func (s *Service) addPayslipEmployee(m core.Maroto, payslip *payroll.Payslip) {
 	m.AddRow(8)
 }
 
-func (s *Service) addPayslipAmounts(m core.Maroto, payslip *payroll.Payslip) {
+// payslipComponentRow is one pay line with its quantity (hours or days) and
+// rate formatted for display. Fixed amounts leave both blank.
+type payslipComponentRow struct {
+	name     string
+	quantity string
+	rate     string
+	amount   string
+}
+
+func payslipComponentRows(components []payroll.PayslipComponent, loc documentLocale) []payslipComponentRow {
+	rows := make([]payslipComponentRow, 0, len(components))
+	for _, component := range components {
+		row := payslipComponentRow{
+			name:   component.Name,
+			amount: loc.money(component.Amount, "EUR"),
+		}
+		switch {
+		case component.Hours.IsPositive():
+			row.quantity = loc.number(component.Hours, 2) + " " + loc.labels.Hours
+			row.rate = loc.money(component.HourlyRate, "EUR")
+		case component.Days.IsPositive():
+			row.quantity = loc.number(component.Days, 0) + " " + loc.labels.Days
+			row.rate = loc.money(component.DailyRate, "EUR")
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (s *Service) addPayslipComponents(m core.Maroto, payslip *payroll.Payslip, loc documentLocale) {
+	if len(payslip.Components) == 0 {
+		return
+	}
+	headerStyle := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Left}
+	headerStyleRight := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Right}
+	cellStyle := props.Text{Size: 9, Align: align.Left}
+	cellStyleRight := props.Text{Size: 9, Align: align.Right}
+
+	m.AddRow(7,
+		col.New(6).Add(text.New(loc.labels.PayLines, headerStyle)),
+		col.New(2).Add(text.New(loc.labels.Quantity, headerStyleRight)),
+		col.New(2).Add(text.New(loc.labels.Rate, headerStyleRight)),
+		col.New(2).Add(text.New(loc.labels.Amount, headerStyleRight)),
+	).WithStyle(&props.Cell{
+		BackgroundColor: &props.Color{Red: 240, Green: 240, Blue: 240},
+		BorderType:      border.Bottom,
+		BorderThickness: 0.5,
+	})
+	for _, row := range payslipComponentRows(payslip.Components, loc) {
+		m.AddRow(6,
+			col.New(6).Add(text.New(row.name, cellStyle)),
+			col.New(2).Add(text.New(row.quantity, cellStyleRight)),
+			col.New(2).Add(text.New(row.rate, cellStyleRight)),
+			col.New(2).Add(text.New(row.amount, cellStyleRight)),
+		).WithStyle(&props.Cell{
+			BorderType:      border.Bottom,
+			BorderThickness: 0.2,
+		})
+	}
+	m.AddRow(6)
+}
+
+func (s *Service) addPayslipAmounts(m core.Maroto, payslip *payroll.Payslip, loc documentLocale) {
 	headerStyle := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Left}
 	headerStyleRight := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Right}
 	cellStyle := props.Text{Size: 9, Align: align.Left}
 	cellStyleRight := props.Text{Size: 9, Align: align.Right}
 
 	m.AddRow(7,
-		col.New(8).Add(text.New("Description", headerStyle)),
-		col.New(4).Add(text.New("Amount", headerStyleRight)),
+		col.New(8).Add(text.New(loc.labels.Description, headerStyle)),
+		col.New(4).Add(text.New(loc.labels.Amount, headerStyleRight)),
 	).WithStyle(&props.Cell{
 		BackgroundColor: &props.Color{Red: 240, Green: 240, Blue: 240},
 		BorderType:      border.Bottom,
@@ -410,22 +612,22 @@ func (s *Service) addPayslipAmounts(m core.Maroto, payslip *payroll.Payslip) {
 		label  string
 		amount decimal.Decimal
 	}{
-		{"Gross salary", payslip.GrossSalary},
-		{"Taxable income", payslip.TaxableIncome},
-		{"Income tax", payslip.IncomeTax.Neg()},
-		{"Unemployment insurance employee", payslip.UnemploymentInsuranceEE.Neg()},
-		{"Funded pension", payslip.FundedPension.Neg()},
-		{"Other deductions", payslip.OtherDeductions.Neg()},
-		{"Net salary", payslip.NetSalary},
-		{"Social tax", payslip.SocialTax},
-		{"Unemployment insurance employer", payslip.UnemploymentInsuranceER},
-		{"Total employer cost", payslip.TotalEmployerCost},
-		{"Basic exemption applied", payslip.BasicExemptionApplied},
+		{loc.labels.GrossSalary, payslip.GrossSalary},
+		{loc.labels.TaxableIncome, payslip.TaxableIncome},
+		{loc.labels.IncomeTax, payslip.IncomeTax.Neg()},
+		{loc.labels.UnemploymentInsuranceEE, payslip.UnemploymentInsuranceEE.Neg()},
+		{loc.labels.FundedPension, payslip.FundedPension.Neg()},
+		{loc.labels.OtherDeductions, payslip.OtherDeductions.Neg()},
+		{loc.labels.NetSalary, payslip.NetSalary},
+		{loc.labels.SocialTax, payslip.SocialTax},
+		{loc.labels.UnemploymentInsuranceER, payslip.UnemploymentInsuranceER},
+		{loc.labels.TotalEmployerCost, payslip.TotalEmployerCost},
+		{loc.labels.BasicExemptionApplied, payslip.BasicExemptionApplied},
 	}
 	for _, row := range rows {
 		m.AddRow(6,
 			col.New(8).Add(text.New(row.label, cellStyle)),
-			col.New(4).Add(text.New(formatMoney(row.amount, "EUR"), cellStyleRight)),
+			col.New(4).Add(text.New(loc.money(row.amount, "EUR"), cellStyleRight)),
 		).WithStyle(&props.Cell{
 			BorderType:      border.Bottom,
 			BorderThickness: 0.2,
@@ -434,28 +636,74 @@ func (s *Service) addPayslipAmounts(m core.Maroto, payslip *payroll.Payslip) {
 	m.AddRow(8)
 }
 
-func (s *Service) addInvoiceTitle(m core.Maroto, invoice *invoicing.Invoice) {
-	// Invoice title
-	var title string
+func invoiceTitle(invoice *invoicing.Invoice, loc documentLocale) string {
 	switch invoice.InvoiceType {
 	case invoicing.InvoiceTypeCreditNote:
-		title = "CREDIT NOTE"
+		return loc.labels.CreditNote
 	case invoicing.InvoiceTypePurchase:
-		title = "PURCHASE INVOICE"
+		return loc.labels.PurchaseInvoice
 	default:
-		title = "INVOICE"
+		return loc.labels.Invoice
 	}
+}
+
+func invoiceRemittance(invoice *invoicing.Invoice) string {
+	return strings.TrimSpace(invoice.InvoiceNumber + " " + invoice.Reference)
+}
 
+func invoiceTemplateData(invoice *invoicing.Invoice, t *tenant.Tenant, documentType, title string, loc documentLocale) TemplateData {
+	return TemplateData{
+		DocumentType: documentType,
+		Title:        title,
+		Number:       invoice.InvoiceNumber,
+		Status:       string(invoice.Status),
+		IssueDate:    loc.date(invoice.IssueDate),
+		DueDate:      loc.date(invoice.DueDate),
+		Reference:    invoice.Reference,
+		Currency:     invoice.Currency,
+		Subtotal:     loc.money(invoice.Subtotal, invoice.Currency),
+		VATAmount:    loc.money(invoice.VATAmount, invoice.Currency),
+		Total:        loc.money(invoice.Total, invoice.Currency),
+		AmountPaid:   loc.money(invoice.AmountPaid, invoice.Currency),
+		AmountDue:    loc.money(invoice.AmountDue(), invoice.Currency),
+		Notes:        invoice.Notes,
+		Company:      partyFromTenant(t),
+		Customer:     partyFromContact(invoice.Contact),
+	}
+}
+
+func commercialDocumentTemplateData(doc commercialDocumentPDF, t *tenant.Tenant, loc documentLocale) TemplateData {
+	return TemplateData{
+		DocumentType: doc.DocumentType,
+		Title:        doc.Title,
+		Number:       doc.Number,
+		Status:       doc.Status,
+		IssueDate:    doc.PrimaryDate,
+		DueDate:      doc.SecondaryDate,
+		Reference:    doc.Reference,
+		Currency:     doc.Currency,
+		Subtotal:     loc.money(doc.Subtotal, doc.Currency),
+		VATAmount:    loc.money(doc.VATAmount, doc.Currency),
+		Total:        loc.money(doc.Total, doc.Currency),
+		AmountPaid:   loc.money(decimal.Zero, doc.Currency),
+		AmountDue:    loc.money(doc.Total, doc.Currency),
+		Notes:        doc.Notes,
+		Company:      partyFromTenant(t),
+		Customer:     partyFromContact(doc.Contact),
+	}
+}
+
+func (s *Service) addInvoiceTitle(m core.Maroto, invoice *invoicing.Invoice, loc documentLocale) {
 	m.AddRow(12,
 		col.New(6).Add(
-			text.New(title, props.Text{
+			text.New(invoiceTitle(invoice, loc), props.Text{
 				Size:  20,
 				Style: fontstyle.Bold,
 				Align: align.Left,
 			}),
 		),
 		col.New(6).Add(
-			text.New(fmt.Sprintf("No. %s", invoice.InvoiceNumber), props.Text{
+			text.New(fmt.Sprintf("%s %s", loc.labels.InvoiceNumber, invoice.InvoiceNumber), props.Text{
 				Size:  14,
 				Style: fontstyle.Bold,
 				Align: align.Right,
@@ -466,13 +714,13 @@ func (s *Service) addInvoiceTitle(m core.Maroto, invoice *invoicing.Invoice) {
 	// Dates
 	m.AddRow(6,
 		col.New(6).Add(
-			text.New(fmt.Sprintf("Issue Date: %s", invoice.IssueDate.Format("02.01.2006")), props.Text{
+			text.New(fmt.Sprintf("%s: %s", loc.labels.IssueDate, loc.date(invoice.IssueDate)), props.Text{
 				Size:  9,
 				Align: align.Left,
 			}),
 		),
 		col.New(6).Add(
-			text.New(fmt.Sprintf("Status: %s", string(invoice.Status)), props.Text{
+			text.New(fmt.Sprintf("%s: %s", loc.labels.Status, string(invoice.Status)), props.Text{
 				Size:  9,
 				Align: align.Right,
 			}),
@@ -481,17 +729,29 @@ func (s *Service) addInvoiceTitle(m core.Maroto, invoice *invoicing.Invoice) {
 
 	m.AddRow(6,
 		col.New(6).Add(
-			text.New(fmt.Sprintf("Due Date: %s", invoice.DueDate.Format("02.01.2006")), props.Text{
+			text.New(fmt.Sprintf("%s: %s", loc.labels.DueDate, loc.date(invoice.DueDate)), props.Text{
 				Size:  9,
 				Align: align.Left,
 			}),
 		),
 	)
 
+	if invoice.InvoiceType == invoicing.InvoiceTypeCreditNote && invoice.OriginalInvoiceNumber != "" {
+		m.AddRow(6,
+			col.New(12).Add(
+				text.New(fmt.Sprintf(loc.labels.CreditNoteFor, invoice.OriginalInvoiceNumber), props.Text{
+					Size:  9,
+					Style: fontstyle.Bold,
+					Align: align.Left,
+				}),
+			),
+		)
+	}
+
 	if invoice.Reference != "" {
 		m.AddRow(6,
 			col.New(6).Add(
-				text.New(fmt.Sprintf("Reference: %s", invoice.Reference), props.Text{
+				text.New(fmt.Sprintf("%s: %s", loc.labels.Reference, invoice.Reference), props.Text{
 					Size:  9,
 					Align: align.Left,
 				}),
@@ -502,10 +762,10 @@ func (s *Service) addInvoiceTitle(m core.Maroto, invoice *invoicing.Invoice) {
 	m.AddRow(8)
 }
 
-func (s *Service) addBillTo(m core.Maroto, invoice *invoicing.Invoice) {
+func (s *Service) addBillTo(m core.Maroto, c *contacts.Contact, label string, loc documentLocale) {
 	m.AddRow(6,
 		col.New(12).Add(
-			text.New("Bill To:", props.Text{
+			text.New(label, props.Text{
 				Size:  10,
 				Style: fontstyle.Bold,
 				Align: align.Left,
@@ -513,8 +773,7 @@ func (s *Service) addBillTo(m core.Maroto, invoice *invoicing.Invoice) {
 		),
 	)
 
-	if invoice.Contact != nil {
-		c := invoice.Contact
+	if c != nil {
 		m.AddRow(5,
 			col.New(12).Add(
 				text.New(c.Name, props.Text{
@@ -526,25 +785,10 @@ func (s *Service) addBillTo(m core.Maroto, invoice *invoicing.Invoice) {
 		)
 
 		if c.AddressLine1 != "" {
-			m.AddRow(5,
-				col.New(12).Add(
-					text.New(c.AddressLine1, props.Text{
-						Size:  9,
-						Align: align.Left,
-					}),
-				),
-			)
+			m.AddRow(5, col.New(12).Add(text.New(c.AddressLine1, props.Text{Size: 9, Align: align.Left})))
 		}
-
 		if c.AddressLine2 != "" {
-			m.AddRow(5,
-				col.New(12).Add(
-					text.New(c.AddressLine2, props.Text{
-						Size:  9,
-						Align: align.Left,
-					}),
-				),
-			)
+			m.AddRow(5, col.New(12).Add(text.New(c.AddressLine2, props.Text{Size: 9, Align: align.Left})))
 		}
 
 		cityLine := ""
@@ -564,98 +808,135 @@ func (s *Service) addBillTo(m core.Maroto, invoice *invoicing.Invoice) {
 			cityLine += c.CountryCode
 		}
 		if cityLine != "" {
-			m.AddRow(5,
-				col.New(12).Add(
-					text.New(cityLine, props.Text{
-						Size:  9,
-						Align: align.Left,
-					}),
-				),
-			)
+			m.AddRow(5, col.New(12).Add(text.New(cityLine, props.Text{Size: 9, Align: align.Left})))
 		}
-
 		if c.VATNumber != "" {
-			m.AddRow(5,
-				col.New(12).Add(
-					text.New(fmt.Sprintf("VAT: %s", c.VATNumber), props.Text{
-						Size:  9,
-						Align: align.Left,
-					}),
-				),
-			)
+			m.AddRow(5, col.New(12).Add(text.New(fmt.Sprintf("%s: %s", loc.labels.VATNumber, c.VATNumber), props.Text{Size: 9, Align: align.Left})))
 		}
-
 		if c.Email != "" {
-			m.AddRow(5,
-				col.New(12).Add(
-					text.New(c.Email, props.Text{
-						Size:  9,
-						Align: align.Left,
-					}),
-				),
-			)
+			m.AddRow(5, col.New(12).Add(text.New(c.Email, props.Text{Size: 9, Align: align.Left})))
 		}
 	}
 
 	m.AddRow(8)
 }
 
-func (s *Service) addLineItems(m core.Maroto, invoice *invoicing.Invoice, t *tenant.Tenant) {
-	// Table header
-	headerStyle := props.Text{
-		Size:  9,
-		Style: fontstyle.Bold,
-		Align: align.Left,
+func invoiceDocumentLines(invoice *invoicing.Invoice) []commercialDocumentLine {
+	lines := make([]commercialDocumentLine, 0, len(invoice.Lines))
+	for _, line := range invoice.Lines {
+		lines = append(lines, commercialDocumentLine{
+			LineNumber:      line.LineNumber,
+			Description:     line.Description,
+			Quantity:        line.Quantity,
+			UnitPrice:       line.UnitPrice,
+			VATRate:         line.VATRate,
+			DiscountPercent: line.DiscountPercent,
+			LineTotal:       line.LineTotal,
+		})
 	}
-	headerStyleRight := props.Text{
-		Size:  9,
-		Style: fontstyle.Bold,
-		Align: align.Right,
+	return lines
+}
+
+func (s *Service) addLineItems(m core.Maroto, lines []commercialDocumentLine, currency string, loc documentLocale, hideDiscount bool) {
+	headerStyle := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Left}
+	headerStyleRight := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Right}
+	descriptionWidth := 4
+	if hideDiscount {
+		descriptionWidth = 5
 	}
 
+	headerCols := []core.Col{
+		col.New(1).Add(text.New("#", headerStyle)),
+		col.New(descriptionWidth).Add(text.New(loc.labels.Description, headerStyle)),
+		col.New(1).Add(text.New(loc.labels.Quantity, headerStyleRight)),
+		col.New(2).Add(text.New(loc.labels.UnitPrice, headerStyleRight)),
+		col.New(1).Add(text.New(loc.labels.VATPercent, headerStyleRight)),
+	}
+	if !hideDiscount {
+		headerCols = append(headerCols, col.New(1).Add(text.New(loc.labels.Discount, headerStyleRight)))
+	}
+	headerCols = append(headerCols, col.New(2).Add(text.New(loc.labels.LineTotal, headerStyleRight)))
+	m.AddRow(7, headerCols...).WithStyle(&props.Cell{
+		BackgroundColor: &props.Color{Red: 240, Green: 240, Blue: 240},
+		BorderType:      border.Bottom,
+		BorderThickness: 0.5,
+	})
+
+	for i, line := range lines {
+		cellStyle := props.Text{Size: 9, Align: align.Left}
+		cellStyleRight := props.Text{Size: 9, Align: align.Right}
+		lineNumber := line.LineNumber
+		if lineNumber == 0 {
+			lineNumber = i + 1
+		}
+
+		cols := []core.Col{
+			col.New(1).Add(text.New(fmt.Sprintf("%d", lineNumber), cellStyle)),
+			col.New(descriptionWidth).Add(text.New(truncateText(line.Description, 50), cellStyle)),
+			col.New(1).Add(text.New(loc.number(line.Quantity, 2), cellStyleRight)),
+			col.New(2).Add(text.New(loc.money(line.UnitPrice, currency), cellStyleRight)),
+			col.New(1).Add(text.New(loc.number(line.VATRate, 0)+"%", cellStyleRight)),
+		}
+		if !hideDiscount {
+			cols = append(cols, col.New(1).Add(text.New(formatDiscount(line.DiscountPercent), cellStyleRight)))
+		}
+		cols = append(cols, col.New(2).Add(text.New(loc.money(line.LineTotal, currency), cellStyleRight)))
+		m.AddRow(6, cols...).WithStyle(&props.Cell{
+			BorderType:      border.Bottom,
+			BorderThickness: 0.2,
+		})
+	}
+
+	m.AddRow(5)
+}
+
+func (s *Service) addDeliveryNoteLines(m core.Maroto, lines []orders.OrderShipmentLine, loc documentLocale) {
+	headerStyle := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Left}
+	headerStyleRight := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Right}
+
 	m.AddRow(7,
 		col.New(1).Add(text.New("#", headerStyle)),
-		col.New(4).Add(text.New("Description", headerStyle)),
-		col.New(1).Add(text.New("Qty", headerStyleRight)),
-		col.New(2).Add(text.New("Unit Price", headerStyleRight)),
-		col.New(1).Add(text.New("VAT %", headerStyleRight)),
-		col.New(1).Add(text.New("Discount", headerStyleRight)),
-		col.New(2).Add(text.New("Total", headerStyleRight)),
+		col.New(6).Add(text.New(loc.labels.Description, headerStyle)),
+		col.New(3).Add(text.New(loc.labels.LotSerial, headerStyle)),
+		col.New(1).Add(text.New(loc.labels.Quantity, headerStyleRight)),
+		col.New(1).Add(text.New(loc.labels.Unit, headerStyleRight)),
 	).WithStyle(&props.Cell{
 		BackgroundColor: &props.Color{Red: 240, Green: 240, Blue: 240},
 		BorderType:      border.Bottom,
 		BorderThickness: 0.5,
 	})
 
-	// Table rows
-	for _, line := range invoice.Lines {
-		cellStyle := props.Text{
-			Size:  9,
-			Align: align.Left,
+	for i, line := range lines {
+		cellStyle := props.Text{Size: 9, Align: align.Left}
+		cellStyleRight := props.Text{Size: 9, Align: align.Right}
+		lineNumber := line.LineNumber
+		if lineNumber == 0 {
+			lineNumber = i + 1
 		}
-		cellStyleRight := props.Text{
-			Size:  9,
-			Align: align.Right,
+		tracking := line.LotNumber
+		if line.SerialNumber != "" {
+			if tracking != "" {
+				tracking += " / "
+			}
+			tracking += line.SerialNumber
 		}
 
 		m.AddRow(6,
-			col.New(1).Add(text.New(fmt.Sprintf("%d", line.LineNumber), cellStyle)),
-			col.New(4).Add(text.New(truncateText(line.Description, 50), cellStyle)),
-			col.New(1).Add(text.New(formatDecimal(line.Quantity, 2), cellStyleRight)),
-			col.New(2).Add(text.New(formatMoney(line.UnitPrice, invoice.Currency), cellStyleRight)),
-			col.New(1).Add(text.New(formatDecimal(line.VATRate, 0)+"%", cellStyleRight)),
-			col.New(1).Add(text.New(formatDiscount(line.DiscountPercent), cellStyleRight)),
-			col.New(2).Add(text.New(formatMoney(line.LineTotal, invoice.Currency), cellStyleRight)),
+			col.New(1).Add(text.New(fmt.Sprintf("%d", lineNumber), cellStyle)),
+			col.New(6).Add(text.New(truncateText(line.Description, 70), cellStyle)),
+			col.New(3).Add(text.New(tracking, cellStyle)),
+			col.New(1).Add(text.New(loc.number(line.Quantity, 2), cellStyleRight)),
+			col.New(1).Add(text.New(line.Unit, cellStyleRight)),
 		).WithStyle(&props.Cell{
 			BorderType:      border.Bottom,
 			BorderThickness: 0.2,
 		})
 	}
 
-	m.AddRow(5)
+	m.AddRow(10)
 }
 
-func (s *Service) addTotals(m core.Maroto, invoice *invoicing.Invoice, t *tenant.Tenant) {
+func (s *Service) addTotals(m core.Maroto, invoice *invoicing.Invoice, loc documentLocale) {
 	totalStyle := props.Text{
 		Size:  10,
 		Align: align.Right,
@@ -678,15 +959,15 @@ func (s *Service) addTotals(m core.Maroto, invoice *invoicing.Invoice, t *tenant
 	// Subtotal
 	m.AddRow(6,
 		col.New(8),
-		col.New(2).Add(text.New("Subtotal:", labelStyle)),
-		col.New(2).Add(text.New(formatMoney(invoice.Subtotal, invoice.Currency), totalStyle)),
+		col.New(2).Add(text.New(loc.labels.Subtotal, labelStyle)),
+		col.New(2).Add(text.New(loc.money(invoice.Subtotal, invoice.Currency), totalStyle)),
 	)
 
 	// VAT
 	m.AddRow(6,
 		col.New(8),
-		col.New(2).Add(text.New("VAT:", labelStyle)),
-		col.New(2).Add(text.New(formatMoney(invoice.VATAmount, invoice.Currency), totalStyle)),
+		col.New(2).Add(text.New(loc.labels.VAT, labelStyle)),
+		col.New(2).Add(text.New(loc.money(invoice.VATAmount, invoice.Currency), totalStyle)),
 	)
 
 	// Total
@@ -701,35 +982,35 @@ func (s *Service) addTotals(m core.Maroto, invoice *invoicing.Invoice, t *tenant
 
 	m.AddRow(8,
 		col.New(8),
-		col.New(2).Add(text.New("TOTAL:", labelBoldStyle)),
-		col.New(2).Add(text.New(formatMoney(invoice.Total, invoice.Currency), totalBoldStyle)),
+		col.New(2).Add(text.New(loc.labels.Total, labelBoldStyle)),
+		col.New(2).Add(text.New(loc.money(invoice.Total, invoice.Currency), totalBoldStyle)),
 	)
 
 	// Amount paid and due (if applicable)
 	if invoice.AmountPaid.GreaterThan(decimal.Zero) {
 		m.AddRow(6,
 			col.New(8),
-			col.New(2).Add(text.New("Paid:", labelStyle)),
-			col.New(2).Add(text.New(formatMoney(invoice.AmountPaid, invoice.Currency), totalStyle)),
+			col.New(2).Add(text.New(loc.labels.Paid, labelStyle)),
+			col.New(2).Add(text.New(loc.money(invoice.AmountPaid, invoice.Currency), totalStyle)),
 		)
 
 		amountDue := invoice.AmountDue()
 		m.AddRow(6,
 			col.New(8),
-			col.New(2).Add(text.New("Amount Due:", labelBoldStyle)),
-			col.New(2).Add(text.New(formatMoney(amountDue, invoice.Currency), totalBoldStyle)),
+			col.New(2).Add(text.New(loc.labels.AmountDue, labelBoldStyle)),
+			col.New(2).Add(text.New(loc.money(amountDue, invoice.Currency), totalBoldStyle)),
 		)
 	}
 
 	m.AddRow(10)
 }
 
-func (s *Service) addFooter(m core.Maroto, invoice *invoicing.Invoice, settings PDFSettings) {
+func (s *Service) addFooter(m core.Maroto, notes string, settings PDFSettings, loc documentLocale) {
 	// Bank details
 	if settings.BankDetails != "" {
 		m.AddRow(6,
 			col.New(12).Add(
-				text.New("Payment Details:", props.Text{
+				text.New(loc.labels.PaymentDetails, props.Text{
 					Size:  10,
 					Style: fontstyle.Bold,
 					Align: align.Left,
@@ -753,7 +1034,7 @@ func (s *Service) addFooter(m core.Maroto, invoice *invoicing.Invoice, settings
 	if settings.InvoiceTerms != "" {
 		m.AddRow(6,
 			col.New(12).Add(
-				text.New("Terms & Conditions:", props.Text{
+				text.New(loc.labels.Terms, props.Text{
 					Size:  10,
 					Style: fontstyle.Bold,
 					Align: align.Left,
@@ -772,10 +1053,10 @@ func (s *Service) addFooter(m core.Maroto, invoice *invoicing.Invoice, settings
 	}
 
 	// Notes
-	if invoice.Notes != "" {
+	if notes != "" {
 		m.AddRow(6,
 			col.New(12).Add(
-				text.New("Notes:", props.Text{
+				text.New(loc.labels.Notes, props.Text{
 					Size:  10,
 					Style: fontstyle.Bold,
 					Align: align.Left,
@@ -784,7 +1065,7 @@ func (s *Service) addFooter(m core.Maroto, invoice *invoicing.Invoice, settings
 		)
 		m.AddRow(5,
 			col.New(12).Add(
-				text.New(invoice.Notes, props.Text{
+				text.New(notes, props.Text{
 					Size:  9,
 					Align: align.Left,
 				}),
@@ -808,7 +1089,7 @@ func (s *Service) addFooter(m core.Maroto, invoice *invoicing.Invoice, settings
 	}
 }
 
-func (s *Service) addCommercialDocumentTitle(m core.Maroto, doc commercialDocumentPDF) {
+func (s *Service) addCommercialDocumentTitle(m core.Maroto, doc commercialDocumentPDF, loc documentLocale) {
 	m.AddRow(12,
 		col.New(6).Add(
 			text.New(doc.Title, props.Text{
@@ -834,7 +1115,7 @@ func (s *Service) addCommercialDocumentTitle(m core.Maroto, doc commercialDocume
 			}),
 		),
 		col.New(6).Add(
-			text.New(fmt.Sprintf("Status: %s", doc.Status), props.Text{
+			text.New(fmt.Sprintf("%s: %s", loc.labels.Status, doc.Status), props.Text{
 				Size:  9,
 				Align: align.Right,
 			}),
@@ -855,7 +1136,7 @@ func (s *Service) addCommercialDocumentTitle(m core.Maroto, doc commercialDocume
 	if doc.Reference != "" {
 		label := doc.ReferenceLabel
 		if label == "" {
-			label = "Reference"
+			label = loc.labels.Reference
 		}
 		m.AddRow(6,
 			col.New(6).Add(
@@ -870,110 +1151,7 @@ func (s *Service) addCommercialDocumentTitle(m core.Maroto, doc commercialDocume
 	m.AddRow(8)
 }
 
-func (s *Service) addCommercialDocumentRecipient(m core.Maroto, doc commercialDocumentPDF) {
-	m.AddRow(6,
-		col.New(12).Add(
-			text.New(doc.RecipientLabel, props.Text{
-				Size:  10,
-				Style: fontstyle.Bold,
-				Align: align.Left,
-			}),
-		),
-	)
-
-	if doc.Contact != nil {
-		c := doc.Contact
-		m.AddRow(5,
-			col.New(12).Add(
-				text.New(c.Name, props.Text{
-					Size:  10,
-					Style: fontstyle.Bold,
-					Align: align.Left,
-				}),
-			),
-		)
-
-		if c.AddressLine1 != "" {
-			m.AddRow(5, col.New(12).Add(text.New(c.AddressLine1, props.Text{Size: 9, Align: align.Left})))
-		}
-		if c.AddressLine2 != "" {
-			m.AddRow(5, col.New(12).Add(text.New(c.AddressLine2, props.Text{Size: 9, Align: align.Left})))
-		}
-
-		cityLine := ""
-		if c.City != "" {
-			cityLine = c.City
-		}
-		if c.PostalCode != "" {
-			if cityLine != "" {
-				cityLine += ", "
-			}
-			cityLine += c.PostalCode
-		}
-		if c.CountryCode != "" {
-			if cityLine != "" {
-				cityLine += ", "
-			}
-			cityLine += c.CountryCode
-		}
-		if cityLine != "" {
-			m.AddRow(5, col.New(12).Add(text.New(cityLine, props.Text{Size: 9, Align: align.Left})))
-		}
-		if c.VATNumber != "" {
-			m.AddRow(5, col.New(12).Add(text.New(fmt.Sprintf("VAT: %s", c.VATNumber), props.Text{Size: 9, Align: align.Left})))
-		}
-		if c.Email != "" {
-			m.AddRow(5, col.New(12).Add(text.New(c.Email, props.Text{Size: 9, Align: align.Left})))
-		}
-	}
-
-	m.AddRow(8)
-}
-
-func (s *Service) addCommercialDocumentLineItems(m core.Maroto, doc commercialDocumentPDF) {
-	headerStyle := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Left}
-	headerStyleRight := props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Right}
-
-	m.AddRow(7,
-		col.New(1).Add(text.New("#", headerStyle)),
-		col.New(4).Add(text.New("Description", headerStyle)),
-		col.New(1).Add(text.New("Qty", headerStyleRight)),
-		col.New(2).Add(text.New("Unit Price", headerStyleRight)),
-		col.New(1).Add(text.New("VAT %", headerStyleRight)),
-		col.New(1).Add(text.New("Discount", headerStyleRight)),
-		col.New(2).Add(text.New("Total", headerStyleRight)),
-	).WithStyle(&props.Cell{
-		BackgroundColor: &props.Color{Red: 240, Green: 240, Blue: 240},
-		BorderType:      border.Bottom,
-		BorderThickness: 0.5,
-	})
-
-	for i, line := range doc.Lines {
-		cellStyle := props.Text{Size: 9, Align: align.Left}
-		cellStyleRight := props.Text{Size: 9, Align: align.Right}
-		lineNumber := line.LineNumber
-		if lineNumber == 0 {
-			lineNumber = i + 1
-		}
-
-		m.AddRow(6,
-			col.New(1).Add(text.New(fmt.Sprintf("%d", lineNumber), cellStyle)),
-			col.New(4).Add(text.New(truncateText(line.Description, 50), cellStyle)),
-			col.New(1).Add(text.New(formatDecimal(line.Quantity, 2), cellStyleRight)),
-			col.New(2).Add(text.New(formatMoney(line.UnitPrice, doc.Currency), cellStyleRight)),
-			col.New(1).Add(text.New(formatDecimal(line.VATRate, 0)+"%", cellStyleRight)),
-			col.New(1).Add(text.New(formatDiscount(line.DiscountPercent), cellStyleRight)),
-			col.New(2).Add(text.New(formatMoney(line.LineTotal, doc.Currency), cellStyleRight)),
-		).WithStyle(&props.Cell{
-			BorderType:      border.Bottom,
-			BorderThickness: 0.2,
-		})
-	}
-
-	m.AddRow(5)
-}
-
-func (s *Service) addCommercialDocumentTotals(m core.Maroto, doc commercialDocumentPDF) {
+func (s *Service) addCommercialDocumentTotals(m core.Maroto, doc commercialDocumentPDF, loc documentLocale) {
 	labelStyle := props.Text{Size: 10, Align: align.Left}
 	totalStyle := props.Text{Size: 10, Align: align.Right}
 	labelBoldStyle := props.Text{Size: 11, Style: fontstyle.Bold, Align: align.Left}
@@ -981,13 +1159,13 @@ func (s *Service) addCommercialDocumentTotals(m core.Maroto, doc commercialDocum
 
 	m.AddRow(6,
 		col.New(8),
-		col.New(2).Add(text.New("Subtotal:", labelStyle)),
-		col.New(2).Add(text.New(formatMoney(doc.Subtotal, doc.Currency), totalStyle)),
+		col.New(2).Add(text.New(loc.labels.Subtotal, labelStyle)),
+		col.New(2).Add(text.New(loc.money(doc.Subtotal, doc.Currency), totalStyle)),
 	)
 	m.AddRow(6,
 		col.New(8),
-		col.New(2).Add(text.New("VAT:", labelStyle)),
-		col.New(2).Add(text.New(formatMoney(doc.VATAmount, doc.Currency), totalStyle)),
+		col.New(2).Add(text.New(loc.labels.VAT, labelStyle)),
+		col.New(2).Add(text.New(loc.money(doc.VATAmount, doc.Currency), totalStyle)),
 	)
 	m.AddRow(1,
 		col.New(8),
@@ -995,17 +1173,17 @@ func (s *Service) addCommercialDocumentTotals(m core.Maroto, doc commercialDocum
 	)
 	m.AddRow(8,
 		col.New(8),
-		col.New(2).Add(text.New("TOTAL:", labelBoldStyle)),
-		col.New(2).Add(text.New(formatMoney(doc.Total, doc.Currency), totalBoldStyle)),
+		col.New(2).Add(text.New(loc.labels.Total, labelBoldStyle)),
+		col.New(2).Add(text.New(loc.money(doc.Total, doc.Currency), totalBoldStyle)),
 	)
 	m.AddRow(10)
 }
 
-func (s *Service) addCommercialDocumentFooter(m core.Maroto, doc commercialDocumentPDF, settings PDFSettings) {
+func (s *Service) addCommercialDocumentFooter(m core.Maroto, doc commercialDocumentPDF, settings PDFSettings, loc documentLocale) {
 	if settings.InvoiceTerms != "" {
 		m.AddRow(6,
 			col.New(12).Add(
-				text.New("Terms & Conditions:", props.Text{
+				text.New(loc.labels.Terms, props.Text{
 					Size:  10,
 					Style: fontstyle.Bold,
 					Align: align.Left,
@@ -1021,7 +1199,7 @@ func (s *Service) addCommercialDocumentFooter(m core.Maroto, doc commercialDocum
 	if doc.Notes != "" {
 		m.AddRow(6,
 			col.New(12).Add(
-				text.New("Notes:", props.Text{
+				text.New(loc.labels.Notes, props.Text{
 					Size:  10,
 					Style: fontstyle.Bold,
 					Align: align.Left,
@@ -1050,10 +1228,6 @@ func (s *Service) addCommercialDocumentFooter(m core.Maroto, doc commercialDocum
 
 // Helper functions
 
-func formatMoney(amount decimal.Decimal, currency string) string {
-	return fmt.Sprintf("%s %s", currency, amount.StringFixed(2))
-}
-
 func formatDecimal(d decimal.Decimal, precision int32) string {
 	return d.StringFixed(precision)
 }
@@ -68,9 +68,9 @@ func TestFormatMoney(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := formatMoney(tt.amount, tt.currency)
+			result := plainLocale.money(tt.amount, tt.currency)
 			if result != tt.expected {
-				t.Errorf("formatMoney(%v, %q) = %q, want %q", tt.amount, tt.currency, result, tt.expected)
+				t.Errorf("money(%v, %q) = %q, want %q", tt.amount, tt.currency, result, tt.expected)
 			}
 		})
 	}
@@ -202,13 +202,13 @@ func TestFormatMoney_Currencies(t *testing.T) {
 
 	for _, currency := range currencies {
 		t.Run(currency, func(t *testing.T) {
-			result := formatMoney(amount, currency)
+			result := plainLocale.money(amount, currency)
 			if len(result) < len(currency)+1 {
-				t.Errorf("formatMoney result %q too short for currency %q", result, currency)
+				t.Errorf("money result %q too short for currency %q", result, currency)
 			}
 			// Should start with currency code
 			if result[:len(currency)] != currency {
-				t.Errorf("formatMoney result %q should start with %q", result, currency)
+				t.Errorf("money result %q should start with %q", result, currency)
 			}
 		})
 	}
@@ -309,6 +309,7 @@ func TestGenerateInvoicePDF(t *testing.T) {
 	t.Run("generates PDF for credit note", func(t *testing.T) {
 		invoice := createTestInvoice()
 		invoice.InvoiceType = invoicing.InvoiceTypeCreditNote
+		invoice.OriginalInvoiceNumber = "INV-2024-001"
 		tnant := createTestTenant()
 		settings := DefaultPDFSettings()
 
@@ -460,6 +461,39 @@ func TestGenerateInvoicePDF(t *testing.T) {
 	})
 }
 
+func TestGenerateLocalizedPDFs(t *testing.T) {
+	svc := NewService()
+	tnant := createTestTenant()
+	tnant.Settings = tenant.DefaultSettings()
+
+	invoice := createTestInvoice()
+	invoice.Contact = &contacts.Contact{Name: "Klient OÜ", Language: tenant.DocumentLanguageEstonian}
+	invoice.AmountPaid = decimal.NewFromInt(10)
+	pdfBytes, err := svc.GenerateInvoicePDF(invoice, tnant, DefaultPDFSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+
+	quote := createTestQuote()
+	quote.Contact = &contacts.Contact{Name: "Foreign Ltd", Language: tenant.DocumentLanguageEnglish}
+	pdfBytes, err = svc.GenerateQuotePDF(quote, tnant, DefaultPDFSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+}
+
+func TestGenerateReminderPDF(t *testing.T) {
+	svc := NewService()
+	tnant := createTestTenant()
+	invoice := createTestInvoice()
+	invoice.Reference = "1234561"
+	invoice.AmountPaid = decimal.NewFromInt(20)
+	settings := DefaultPDFSettings()
+	settings.BankDetails = "IBAN: EE382200221020145685"
+
+	pdfBytes, err := svc.GenerateReminderPDF(invoice, tnant, settings, invoice.DueDate.AddDate(0, 0, 12))
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+}
+
 func TestGenerateCommercialDocumentPDFs(t *testing.T) {
 	svc := NewService()
 	tnant := createTestTenant()
@@ -487,6 +521,26 @@ func TestGenerateCommercialDocumentPDFs(t *testing.T) {
 		assert.Equal(t, "%PDF", string(pdfBytes[:4]))
 	})
 
+	t.Run("generates delivery note PDF", func(t *testing.T) {
+		order := createTestOrder()
+		shipment := &orders.OrderShipment{
+			ShipmentNumber: "DN-00001",
+			OrderID:        order.ID,
+			ShipmentDate:   time.Date(2026, time.March, 20, 0, 0, 0, 0, time.UTC),
+			Notes:          "Leave at reception",
+			Lines: []orders.OrderShipmentLine{
+				{LineNumber: 1, Description: "Widget", Quantity: decimal.NewFromInt(4), Unit: "pcs", LotNumber: "LOT-1", SerialNumber: "SN-1"},
+				{Description: "Installation", Quantity: decimal.NewFromInt(1), SerialNumber: "SN-2"},
+			},
+		}
+
+		pdfBytes, err := svc.GenerateDeliveryNotePDF(order, shipment, tnant, settings)
+
+		require.NoError(t, err)
+		require.NotEmpty(t, pdfBytes)
+		assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+	})
+
 	t.Run("generates document with default reference label and sparse contact", func(t *testing.T) {
 		doc := commercialDocumentPDF{
 			Title:            "CUSTOM DOCUMENT",
@@ -511,7 +565,7 @@ func TestGenerateCommercialDocumentPDFs(t *testing.T) {
 			}},
 		}
 
-		pdfBytes, err := svc.generateCommercialDocumentPDF(doc, tnant, PDFSettings{})
+		pdfBytes, err := svc.generateCommercialDocumentPDF(doc, tnant, PDFSettings{}, localeFor(tnant, nil))
 
 		require.NoError(t, err)
 		require.NotEmpty(t, pdfBytes)
@@ -577,6 +631,38 @@ func TestGeneratePayslipPDF(t *testing.T) {
 		require.NotEmpty(t, pdfBytes)
 		assert.Equal(t, "%PDF", string(pdfBytes[:4]))
 	})
+
+	t.Run("lists pay lines with hours and days", func(t *testing.T) {
+		edgePayslip := *payslip
+		edgePayslip.Components = []payroll.PayslipComponent{
+			{Name: "Base salary", Amount: decimal.RequireFromString("1000.00")},
+			{Name: "Overtime", Hours: decimal.RequireFromString("10"), HourlyRate: decimal.RequireFromString("18.75"), Amount: decimal.RequireFromString("187.50")},
+			{Name: "Annual leave", Days: decimal.NewFromInt(5), DailyRate: decimal.RequireFromString("120.50"), Amount: decimal.RequireFromString("602.50")},
+		}
+
+		pdfBytes, err := svc.GeneratePayslipPDF(&edgePayslip, run, tnant)
+
+		require.NoError(t, err)
+		assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+	})
+}
+
+func TestPayslipComponentRows(t *testing.T) {
+	components := []payroll.PayslipComponent{
+		{Name: "Base salary", Amount: decimal.RequireFromString("1000")},
+		{Name: "Overtime", Hours: decimal.RequireFromString("10.5"), HourlyRate: decimal.RequireFromString("18"), Amount: decimal.RequireFromString("189")},
+		{Name: "Annual leave", Days: decimal.NewFromInt(5), DailyRate: decimal.RequireFromString("120.5"), Amount: decimal.RequireFromString("602.5")},
+	}
+
+	rows := payslipComponentRows(components, localeFor(&tenant.Tenant{Settings: tenant.TenantSettings{DocumentLanguage: tenant.DocumentLanguageEstonian, DecimalSep: ","}}, nil))
+
+	require.Len(t, rows, 3)
+	assert.Equal(t, "", rows[0].quantity)
+	assert.Equal(t, "", rows[0].rate)
+	assert.Equal(t, "10,50 t", rows[1].quantity)
+	assert.Contains(t, rows[1].rate, "18,00")
+	assert.Equal(t, "5 p", rows[2].quantity)
+	assert.Contains(t, rows[2].amount, "602,50")
 }
 
 func createTestInvoice() *invoicing.Invoice {
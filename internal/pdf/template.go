@@ -0,0 +1,326 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	_ "image/jpeg" // register JPEG logo decoding
+	_ "image/png"  // register PNG logo decoding
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/johnfercher/maroto/v2"
+	"github.com/johnfercher/maroto/v2/pkg/components/code"
+	"github.com/johnfercher/maroto/v2/pkg/components/col"
+	imagecomponent "github.com/johnfercher/maroto/v2/pkg/components/image"
+	"github.com/johnfercher/maroto/v2/pkg/components/text"
+	"github.com/johnfercher/maroto/v2/pkg/config"
+	"github.com/johnfercher/maroto/v2/pkg/consts/align"
+	"github.com/johnfercher/maroto/v2/pkg/consts/extension"
+	"github.com/johnfercher/maroto/v2/pkg/consts/fontstyle"
+	"github.com/johnfercher/maroto/v2/pkg/consts/orientation"
+	"github.com/johnfercher/maroto/v2/pkg/consts/pagesize"
+	"github.com/johnfercher/maroto/v2/pkg/core"
+	"github.com/johnfercher/maroto/v2/pkg/props"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+// TemplateData is the data model document template text is rendered against.
+// Amounts and dates are preformatted with the document locale.
+type TemplateData struct {
+	DocumentType string
+	Title        string
+	Number       string
+	Status       string
+	IssueDate    string
+	DueDate      string
+	Reference    string
+	Currency     string
+	Subtotal     string
+	VATAmount    string
+	Total        string
+	AmountPaid   string
+	AmountDue    string
+	Notes        string
+	Company      TemplateParty
+	Customer     TemplateParty
+}
+
+// TemplateParty describes the issuing company or the customer in TemplateData.
+type TemplateParty struct {
+	Name      string
+	RegCode   string
+	VATNumber string
+	Email     string
+	Phone     string
+	Address   string
+}
+
+type templateField struct {
+	Label string
+	Value string
+}
+
+// documentLayout is a tenant document template with its text already rendered.
+type documentLayout struct {
+	template   tenant.DocumentTemplate
+	headerText string
+	footerText string
+	fields     []templateField
+	logo       []byte
+	logoFormat extension.Type
+}
+
+// ValidateDocumentTemplate renders a template against sample data so that
+// unknown fields are rejected before the template is saved.
+func ValidateDocumentTemplate(documentType string, tmpl tenant.DocumentTemplate) error {
+	normalized, err := tenant.NormalizeDocumentTemplate(tmpl)
+	if err != nil {
+		return err
+	}
+	sample := sampleInvoice()
+	company := sampleTenant()
+	loc := localeFor(nil, sample.Contact)
+	_, err = renderDocumentLayout(normalized, invoiceTemplateData(sample, &company, documentType, loc.labels.Invoice, loc), "")
+	return err
+}
+
+func documentTemplateFor(t *tenant.Tenant, documentType string) tenant.DocumentTemplate {
+	if t == nil {
+		return tenant.DocumentTemplate{}
+	}
+	return t.Settings.DocumentTemplates[documentType]
+}
+
+func layoutFor(t *tenant.Tenant, documentType string, data TemplateData) (documentLayout, error) {
+	logo := ""
+	if t != nil {
+		logo = t.Settings.Logo
+	}
+	return renderDocumentLayout(documentTemplateFor(t, documentType), data, logo)
+}
+
+func renderDocumentLayout(tmpl tenant.DocumentTemplate, data TemplateData, logo string) (documentLayout, error) {
+	layout := documentLayout{template: tmpl}
+	var err error
+	if layout.headerText, err = executeTemplateText("header_text", tmpl.HeaderText, data); err != nil {
+		return documentLayout{}, err
+	}
+	if layout.footerText, err = executeTemplateText("footer_text", tmpl.FooterText, data); err != nil {
+		return documentLayout{}, err
+	}
+	for _, field := range tmpl.CustomFields {
+		value, err := executeTemplateText(field.Label, field.Value, data)
+		if err != nil {
+			return documentLayout{}, err
+		}
+		layout.fields = append(layout.fields, templateField{Label: field.Label, Value: value})
+	}
+	switch tmpl.LogoPosition {
+	case tenant.LogoPositionLeft, tenant.LogoPositionRight, tenant.LogoPositionCenter:
+		layout.logo, layout.logoFormat = decodeLogo(logo)
+	}
+	return layout, nil
+}
+
+func executeTemplateText(name, value string, data TemplateData) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	parsed, err := template.New(name).Option("missingkey=error").Parse(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid template in %s: %w", name, err)
+	}
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render template %s: %w", name, err)
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
+
+// decodeLogo reads a PNG or JPEG data URL as uploaded in company settings.
+// Unsupported formats such as SVG are skipped.
+func decodeLogo(value string) ([]byte, extension.Type) {
+	header, payload, ok := strings.Cut(strings.TrimSpace(value), ",")
+	if !ok || !strings.HasPrefix(header, "data:") || !strings.HasSuffix(header, ";base64") {
+		return nil, ""
+	}
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ""
+	}
+	_, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ""
+	}
+	switch format {
+	case "png":
+		return data, extension.Png
+	case "jpeg":
+		return data, extension.Jpeg
+	default:
+		return nil, ""
+	}
+}
+
+func newDocument(loc documentLocale, tmpl tenant.DocumentTemplate) core.Maroto {
+	builder := config.NewBuilder().
+		WithPageNumber(props.PageNumber{
+			Pattern: loc.labels.PagePattern,
+			Place:   props.RightBottom,
+			Size:    8,
+		}).
+		WithLeftMargin(15).
+		WithTopMargin(15).
+		WithRightMargin(15)
+
+	switch tmpl.PaperSize {
+	case tenant.PaperSizeA5:
+		builder = builder.WithPageSize(pagesize.A5)
+	case tenant.PaperSizeLetter:
+		builder = builder.WithPageSize(pagesize.Letter)
+	case tenant.PaperSizeLegal:
+		builder = builder.WithPageSize(pagesize.Legal)
+	default:
+		builder = builder.WithPageSize(pagesize.A4)
+	}
+	if tmpl.Orientation == tenant.OrientationLandscape {
+		builder = builder.WithOrientation(orientation.Horizontal)
+	}
+
+	return maroto.New(builder.Build())
+}
+
+func (s *Service) addLogo(m core.Maroto, layout documentLayout) {
+	if len(layout.logo) == 0 {
+		return
+	}
+	logo := imagecomponent.NewFromBytesCol(4, layout.logo, layout.logoFormat, props.Rect{Center: true, Percent: 100})
+	switch layout.template.LogoPosition {
+	case tenant.LogoPositionRight:
+		m.AddRow(20, col.New(8), logo)
+	case tenant.LogoPositionCenter:
+		m.AddRow(20, col.New(4), logo, col.New(4))
+	default:
+		m.AddRow(20, logo, col.New(8))
+	}
+	m.AddRow(3)
+}
+
+func (s *Service) addTemplateFields(m core.Maroto, layout documentLayout) {
+	if len(layout.fields) == 0 {
+		return
+	}
+	for _, field := range layout.fields {
+		m.AddRow(5,
+			col.New(12).Add(text.New(fmt.Sprintf("%s: %s", field.Label, field.Value), props.Text{Size: 9, Align: align.Left})),
+		)
+	}
+	m.AddRow(5)
+}
+
+func (s *Service) addPaymentQRCode(m core.Maroto, layout documentLayout, t *tenant.Tenant, settings PDFSettings, amount decimal.Decimal, currency, remittance string, loc documentLocale) {
+	if !layout.template.ShowPaymentQRCode || t == nil {
+		return
+	}
+	payload := epcPaymentPayload(t.Name, findIBAN(settings.BankDetails), amount, currency, remittance)
+	if payload == "" {
+		return
+	}
+	m.AddRow(30,
+		col.New(9).Add(text.New(loc.labels.ScanToPay, props.Text{Size: 9, Style: fontstyle.Bold, Align: align.Right, Top: 12})),
+		code.NewQrCol(3, payload, props.Rect{Center: true, Percent: 100}),
+	)
+	m.AddRow(5)
+}
+
+var ibanCandidatePattern = regexp.MustCompile(`[A-Z]{2}[0-9]{2}(?: ?[A-Z0-9]{1,4}){3,8}`)
+
+// findIBAN returns the first valid IBAN in free-form bank details.
+func findIBAN(value string) string {
+	for _, candidate := range ibanCandidatePattern.FindAllString(strings.ToUpper(value), -1) {
+		iban := strings.ReplaceAll(candidate, " ", "")
+		if len(iban) >= 15 && len(iban) <= 34 && ibanChecksumValid(iban) {
+			return iban
+		}
+	}
+	return ""
+}
+
+func ibanChecksumValid(iban string) bool {
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			value := int(r-'A') + 10
+			remainder = (remainder*10 + value/10) % 97
+			remainder = (remainder*10 + value%10) % 97
+		default:
+			return false
+		}
+	}
+	return remainder == 1
+}
+
+// epcPaymentPayload builds an EPC069-12 SEPA credit transfer QR payload. Only
+// positive EUR amounts to a valid IBAN can be encoded.
+func epcPaymentPayload(beneficiary, iban string, amount decimal.Decimal, currency, remittance string) string {
+	if iban == "" || !amount.IsPositive() || !strings.EqualFold(currency, "EUR") {
+		return ""
+	}
+	return strings.Join([]string{
+		"BCD",
+		"002",
+		"1",
+		"SCT",
+		"",
+		truncateText(strings.TrimSpace(beneficiary), 70),
+		iban,
+		"EUR" + amount.StringFixed(2),
+		"",
+		"",
+		truncateText(strings.TrimSpace(remittance), 140),
+	}, "\n")
+}
+
+func partyFromTenant(t *tenant.Tenant) TemplateParty {
+	if t == nil {
+		return TemplateParty{}
+	}
+	return TemplateParty{
+		Name:      t.Name,
+		RegCode:   t.Settings.RegCode,
+		VATNumber: t.Settings.VATNumber,
+		Email:     t.Settings.Email,
+		Phone:     t.Settings.Phone,
+		Address:   t.Settings.Address,
+	}
+}
+
+func partyFromContact(c *contacts.Contact) TemplateParty {
+	if c == nil {
+		return TemplateParty{}
+	}
+	address := make([]string, 0, 4)
+	for _, part := range []string{c.AddressLine1, c.AddressLine2, strings.TrimSpace(c.PostalCode + " " + c.City), c.CountryCode} {
+		if strings.TrimSpace(part) != "" {
+			address = append(address, strings.TrimSpace(part))
+		}
+	}
+	return TemplateParty{
+		Name:      c.Name,
+		RegCode:   c.RegCode,
+		VATNumber: c.VATNumber,
+		Email:     c.Email,
+		Phone:     c.Phone,
+		Address:   strings.Join(address, ", "),
+	}
+}
@@ -0,0 +1,162 @@
+package pdf
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+func testLogoDataURL(t *testing.T) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+	img.Set(0, 0, color.RGBA{R: 29, G: 78, B: 216, A: 255})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes())
+}
+
+func fullDocumentTemplate() tenant.DocumentTemplate {
+	return tenant.DocumentTemplate{
+		PaperSize:          "a5",
+		Orientation:        "landscape",
+		LogoPosition:       "right",
+		HideDiscountColumn: true,
+		ShowPaymentQRCode:  true,
+		HeaderText:         "{{.Company.Name}} · {{.Company.Email}}",
+		FooterText:         "Questions about {{.Number}}? Reply to {{.Company.Email}}",
+		CustomFields: []tenant.DocumentTemplateField{
+			{Label: "Customer reg code", Value: "{{.Customer.RegCode}}"},
+			{Label: "Amount due", Value: "{{.AmountDue}}"},
+		},
+	}
+}
+
+func TestGenerateTemplatePreviewPDF(t *testing.T) {
+	service := NewService()
+	tnant := createTestTenant()
+	tnant.Settings.Logo = testLogoDataURL(t)
+	settings := DefaultPDFSettings()
+	settings.BankDetails = "Bank: LHV\nIBAN: EE38 2200 2210 2014 5685"
+
+	for _, documentType := range tenant.DocumentTypes {
+		t.Run(documentType, func(t *testing.T) {
+			pdfBytes, err := service.GenerateTemplatePreviewPDF(strings.ToLower(documentType), fullDocumentTemplate(), tnant, settings)
+			require.NoError(t, err)
+			assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+		})
+	}
+
+	pdfBytes, err := service.GenerateTemplatePreviewPDF(tenant.DocumentTypeInvoice, tenant.DocumentTemplate{}, nil, DefaultPDFSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+	assert.Empty(t, tnant.Settings.DocumentTemplates, "preview must not change the tenant settings")
+
+	_, err = service.GenerateTemplatePreviewPDF("PAYSLIP", tenant.DocumentTemplate{}, tnant, settings)
+	require.ErrorContains(t, err, "invalid document type")
+	_, err = service.GenerateTemplatePreviewPDF(tenant.DocumentTypeInvoice, tenant.DocumentTemplate{PaperSize: "A0"}, tnant, settings)
+	require.ErrorContains(t, err, "invalid paper size")
+	_, err = service.GenerateTemplatePreviewPDF(tenant.DocumentTypeQuote, tenant.DocumentTemplate{HeaderText: "{{.Missing}}"}, tnant, settings)
+	require.ErrorContains(t, err, "render template header_text")
+}
+
+func TestGenerateInvoicePDFUsesSavedTemplate(t *testing.T) {
+	service := NewService()
+	tnant := createTestTenant()
+	tnant.Settings.DocumentTemplates = map[string]tenant.DocumentTemplate{
+		tenant.DocumentTypeInvoice: {
+			CustomFields: []tenant.DocumentTemplateField{{Label: "Project", Value: "{{.Reference}}"}},
+		},
+	}
+
+	pdfBytes, err := service.GenerateInvoicePDF(createTestInvoice(), tnant, DefaultPDFSettings())
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdfBytes[:4]))
+
+	tnant.Settings.DocumentTemplates[tenant.DocumentTypeInvoice] = tenant.DocumentTemplate{FooterText: "{{.Unknown}}"}
+	_, err = service.GenerateInvoicePDF(createTestInvoice(), tnant, DefaultPDFSettings())
+	require.ErrorContains(t, err, "failed to generate PDF")
+}
+
+func TestValidateDocumentTemplate(t *testing.T) {
+	require.NoError(t, ValidateDocumentTemplate(tenant.DocumentTypeInvoice, fullDocumentTemplate()))
+
+	err := ValidateDocumentTemplate(tenant.DocumentTypeInvoice, tenant.DocumentTemplate{FooterText: "{{.Company.Fax}}"})
+	require.ErrorContains(t, err, "render template footer_text")
+
+	err = ValidateDocumentTemplate(tenant.DocumentTypeInvoice, tenant.DocumentTemplate{HeaderText: "{{.Number"})
+	require.ErrorContains(t, err, "invalid template in header_text")
+}
+
+func TestRenderDocumentLayout(t *testing.T) {
+	data := TemplateData{Number: "INV-7", Customer: TemplateParty{Name: "Acme"}}
+	layout, err := renderDocumentLayout(tenant.DocumentTemplate{
+		LogoPosition: tenant.LogoPositionLeft,
+		HeaderText:   "Invoice {{.Number}}",
+		CustomFields: []tenant.DocumentTemplateField{{Label: "Client", Value: "{{.Customer.Name}}"}},
+	}, data, testLogoDataURL(t))
+	require.NoError(t, err)
+	assert.Equal(t, "Invoice INV-7", layout.headerText)
+	assert.Equal(t, []templateField{{Label: "Client", Value: "Acme"}}, layout.fields)
+	assert.NotEmpty(t, layout.logo)
+
+	layout, err = renderDocumentLayout(tenant.DocumentTemplate{}, data, testLogoDataURL(t))
+	require.NoError(t, err)
+	assert.Empty(t, layout.logo, "logo is only drawn when the template positions it")
+}
+
+func TestDecodeLogo(t *testing.T) {
+	logo, format := decodeLogo(testLogoDataURL(t))
+	assert.NotEmpty(t, logo)
+	assert.Equal(t, "png", string(format))
+
+	for _, value := range []string{
+		"",
+		"https://example.com/logo.png",
+		"data:image/png;base64,not-base64!",
+		"data:image/svg+xml;base64," + base64.StdEncoding.EncodeToString([]byte("<svg/>")),
+	} {
+		logo, _ := decodeLogo(value)
+		assert.Empty(t, logo, value)
+	}
+}
+
+func TestFindIBAN(t *testing.T) {
+	assert.Equal(t, "EE382200221020145685", findIBAN("Bank: LHV\nIBAN: EE38 2200 2210 2014 5685"))
+	assert.Equal(t, "EE382200221020145685", findIBAN("iban ee382200221020145685"))
+	assert.Empty(t, findIBAN("IBAN: EE00 2200 2210 2014 5685"))
+	assert.Empty(t, findIBAN("Pay by card"))
+}
+
+func TestEPCPaymentPayload(t *testing.T) {
+	payload := epcPaymentPayload("Test Company OÜ", "EE382200221020145685", decimal.RequireFromString("1240.5"), "eur", "INV-1 1234561")
+	assert.Equal(t, "BCD\n002\n1\nSCT\n\nTest Company OÜ\nEE382200221020145685\nEUR1240.50\n\n\nINV-1 1234561", payload)
+
+	assert.Empty(t, epcPaymentPayload("Test", "", decimal.NewFromInt(10), "EUR", ""))
+	assert.Empty(t, epcPaymentPayload("Test", "EE382200221020145685", decimal.Zero, "EUR", ""))
+	assert.Empty(t, epcPaymentPayload("Test", "EE382200221020145685", decimal.NewFromInt(10), "USD", ""))
+}
+
+func TestPartyFromContact(t *testing.T) {
+	party := partyFromContact(&contacts.Contact{
+		Name:         "Acme OÜ",
+		AddressLine1: "Main 1",
+		City:         "Tartu",
+		PostalCode:   "51003",
+		CountryCode:  "EE",
+	})
+	assert.Equal(t, "Acme OÜ", party.Name)
+	assert.Equal(t, "Main 1, 51003 Tartu, EE", party.Address)
+	assert.Equal(t, TemplateParty{}, partyFromContact(nil))
+	assert.Equal(t, TemplateParty{}, partyFromTenant(nil))
+}
@@ -59,6 +59,13 @@ const (
 	EventPayrollApproved   = "payroll.approved"
 	EventEmployeeCreated   = "employee.created"
 
+	// Inventory events
+	EventInventoryLowStock = "inventory.low_stock"
+
+	// Quote events
+	EventQuoteAccepted = "quote.accepted"
+	EventQuoteRejected = "quote.rejected"
+
 	// Tenant events
 	EventTenantCreated = "tenant.created"
 	EventTenantUpdated = "tenant.updated"
@@ -294,6 +301,9 @@ var AllEventTypes = []string{
 	EventPayrollCalculated,
 	EventPayrollApproved,
 	EventEmployeeCreated,
+	EventInventoryLowStock,
+	EventQuoteAccepted,
+	EventQuoteRejected,
 	EventTenantCreated,
 	EventTenantUpdated,
 	EventEmailSent,
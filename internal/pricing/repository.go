@@ -0,0 +1,421 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Repository defines the contract for price list and customer pricing data access
+type Repository interface {
+	CreatePriceList(ctx context.Context, schemaName string, list *PriceList) error
+	UpdatePriceList(ctx context.Context, schemaName string, list *PriceList) error
+	GetPriceList(ctx context.Context, schemaName, tenantID, priceListID string) (*PriceList, error)
+	ListPriceLists(ctx context.Context, schemaName, tenantID string, filter *PriceListFilter) ([]PriceList, error)
+	CreateCustomerGroup(ctx context.Context, schemaName string, group *CustomerGroup) error
+	UpdateCustomerGroup(ctx context.Context, schemaName string, group *CustomerGroup) error
+	GetCustomerGroup(ctx context.Context, schemaName, tenantID, groupID string) (*CustomerGroup, error)
+	ListCustomerGroups(ctx context.Context, schemaName, tenantID string) ([]CustomerGroup, error)
+	GetCustomerPricing(ctx context.Context, schemaName, tenantID, contactID string) (*CustomerPricing, error)
+	SaveCustomerPricing(ctx context.Context, schemaName string, terms *CustomerPricing) error
+}
+
+var errPricingRepositoryDatabaseNotConfigured = errors.New("pricing repository database is not configured")
+
+var newGormDBFromPool = database.NewGormDBFromPool
+
+// GORMRepository implements Repository with the shared ORM layer.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *pgxpool.Pool) *GORMRepository {
+	if db == nil {
+		return &GORMRepository{}
+	}
+	gormDB, err := newGormDBFromPool(context.Background(), db)
+	if err != nil {
+		panic(fmt.Errorf("create pricing GORM repository: %w", err))
+	}
+	return NewGORMRepository(gormDB)
+}
+
+func NewGORMRepository(db *gorm.DB) *GORMRepository {
+	return &GORMRepository{db: db}
+}
+
+func (r *GORMRepository) dbWithContext(ctx context.Context) (*gorm.DB, error) {
+	if r == nil || r.db == nil {
+		return nil, errPricingRepositoryDatabaseNotConfigured
+	}
+	return r.db.WithContext(ctx), nil
+}
+
+func (r *GORMRepository) tenantTable(ctx context.Context, schemaName, tableName string) (*gorm.DB, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return database.TenantTable(db, schemaName, tableName)
+}
+
+// CreatePriceList inserts a new price list with its items
+func (r *GORMRepository) CreatePriceList(ctx context.Context, schemaName string, list *PriceList) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		listsTable, err := database.TenantTable(tx, schemaName, "price_lists")
+		if err != nil {
+			return fmt.Errorf("qualify price lists table: %w", err)
+		}
+		if err := listsTable.Create(priceListToModel(list)).Error; err != nil {
+			return fmt.Errorf("insert price list: %w", err)
+		}
+		return insertPriceListItemsTx(tx, schemaName, list)
+	})
+}
+
+// UpdatePriceList stores the price list header and replaces its items
+func (r *GORMRepository) UpdatePriceList(ctx context.Context, schemaName string, list *PriceList) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		listsTable, err := database.TenantTable(tx, schemaName, "price_lists")
+		if err != nil {
+			return fmt.Errorf("qualify price lists table: %w", err)
+		}
+		result := listsTable.Where("id = ? AND tenant_id = ?", list.ID, list.TenantID).
+			Updates(map[string]interface{}{
+				"name":       list.Name,
+				"currency":   list.Currency,
+				"valid_from": list.ValidFrom,
+				"valid_to":   list.ValidTo,
+				"is_default": list.IsDefault,
+				"is_active":  list.IsActive,
+				"notes":      list.Notes,
+				"updated_at": list.UpdatedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update price list: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrPriceListNotFound
+		}
+
+		itemsTable, err := database.TenantTable(tx, schemaName, "price_list_items")
+		if err != nil {
+			return fmt.Errorf("qualify price list items table: %w", err)
+		}
+		if err := itemsTable.Where("price_list_id = ? AND tenant_id = ?", list.ID, list.TenantID).
+			Delete(&models.PriceListItem{}).Error; err != nil {
+			return fmt.Errorf("delete price list items: %w", err)
+		}
+		return insertPriceListItemsTx(tx, schemaName, list)
+	})
+}
+
+// GetPriceList retrieves a price list by ID with its items
+func (r *GORMRepository) GetPriceList(ctx context.Context, schemaName, tenantID, priceListID string) (*PriceList, error) {
+	db, err := r.tenantTable(ctx, schemaName, "price_lists")
+	if err != nil {
+		return nil, fmt.Errorf("qualify price lists table: %w", err)
+	}
+
+	var listModel models.PriceList
+	err = db.Where("id = ? AND tenant_id = ?", priceListID, tenantID).First(&listModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPriceListNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get price list: %w", err)
+	}
+
+	itemsTable, err := r.tenantTable(ctx, schemaName, "price_list_items")
+	if err != nil {
+		return nil, fmt.Errorf("qualify price list items table: %w", err)
+	}
+	var itemModels []models.PriceListItem
+	if err := itemsTable.
+		Where("price_list_id = ? AND tenant_id = ?", priceListID, tenantID).
+		Order("product_id ASC").
+		Order("min_quantity ASC").
+		Find(&itemModels).Error; err != nil {
+		return nil, fmt.Errorf("get price list items: %w", err)
+	}
+
+	list := priceListFromModel(&listModel)
+	list.Items = make([]PriceListItem, len(itemModels))
+	for i := range itemModels {
+		list.Items[i] = *priceListItemFromModel(&itemModels[i])
+	}
+	return list, nil
+}
+
+// ListPriceLists retrieves price lists without items ordered by code
+func (r *GORMRepository) ListPriceLists(ctx context.Context, schemaName, tenantID string, filter *PriceListFilter) ([]PriceList, error) {
+	db, err := r.tenantTable(ctx, schemaName, "price_lists")
+	if err != nil {
+		return nil, fmt.Errorf("qualify price lists table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter != nil {
+		if filter.Currency != "" {
+			query = query.Where("currency = ?", filter.Currency)
+		}
+		if filter.ActiveOnly {
+			query = query.Where("is_active = ?", true)
+		}
+	}
+
+	var listModels []models.PriceList
+	if err := query.Order("code ASC").Find(&listModels).Error; err != nil {
+		return nil, fmt.Errorf("list price lists: %w", err)
+	}
+
+	lists := make([]PriceList, len(listModels))
+	for i := range listModels {
+		lists[i] = *priceListFromModel(&listModels[i])
+	}
+	return lists, nil
+}
+
+// CreateCustomerGroup inserts a new customer group
+func (r *GORMRepository) CreateCustomerGroup(ctx context.Context, schemaName string, group *CustomerGroup) error {
+	db, err := r.tenantTable(ctx, schemaName, "customer_groups")
+	if err != nil {
+		return fmt.Errorf("qualify customer groups table: %w", err)
+	}
+	if err := db.Create(customerGroupToModel(group)).Error; err != nil {
+		return fmt.Errorf("insert customer group: %w", err)
+	}
+	return nil
+}
+
+// UpdateCustomerGroup stores the code, name, price list and discount of a customer group
+func (r *GORMRepository) UpdateCustomerGroup(ctx context.Context, schemaName string, group *CustomerGroup) error {
+	db, err := r.tenantTable(ctx, schemaName, "customer_groups")
+	if err != nil {
+		return fmt.Errorf("qualify customer groups table: %w", err)
+	}
+	model := customerGroupToModel(group)
+	result := db.Where("id = ? AND tenant_id = ?", group.ID, group.TenantID).
+		Updates(map[string]interface{}{
+			"code":             model.Code,
+			"name":             model.Name,
+			"price_list_id":    model.PriceListID,
+			"discount_percent": model.DiscountPercent,
+			"updated_at":       model.UpdatedAt,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("update customer group: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrCustomerGroupNotFound
+	}
+	return nil
+}
+
+// GetCustomerGroup retrieves a customer group by ID
+func (r *GORMRepository) GetCustomerGroup(ctx context.Context, schemaName, tenantID, groupID string) (*CustomerGroup, error) {
+	db, err := r.tenantTable(ctx, schemaName, "customer_groups")
+	if err != nil {
+		return nil, fmt.Errorf("qualify customer groups table: %w", err)
+	}
+
+	var model models.CustomerGroup
+	err = db.Where("id = ? AND tenant_id = ?", groupID, tenantID).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrCustomerGroupNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get customer group: %w", err)
+	}
+	return customerGroupFromModel(&model), nil
+}
+
+// ListCustomerGroups retrieves customer groups ordered by code
+func (r *GORMRepository) ListCustomerGroups(ctx context.Context, schemaName, tenantID string) ([]CustomerGroup, error) {
+	db, err := r.tenantTable(ctx, schemaName, "customer_groups")
+	if err != nil {
+		return nil, fmt.Errorf("qualify customer groups table: %w", err)
+	}
+
+	var groupModels []models.CustomerGroup
+	if err := db.Where("tenant_id = ?", tenantID).Order("code ASC").Find(&groupModels).Error; err != nil {
+		return nil, fmt.Errorf("list customer groups: %w", err)
+	}
+
+	groups := make([]CustomerGroup, len(groupModels))
+	for i := range groupModels {
+		groups[i] = *customerGroupFromModel(&groupModels[i])
+	}
+	return groups, nil
+}
+
+// GetCustomerPricing retrieves the pricing terms of a contact. Contacts
+// without terms return nil without an error.
+func (r *GORMRepository) GetCustomerPricing(ctx context.Context, schemaName, tenantID, contactID string) (*CustomerPricing, error) {
+	db, err := r.tenantTable(ctx, schemaName, "customer_pricing")
+	if err != nil {
+		return nil, fmt.Errorf("qualify customer pricing table: %w", err)
+	}
+
+	var model models.CustomerPricing
+	err = db.Where("contact_id = ? AND tenant_id = ?", contactID, tenantID).First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get customer pricing: %w", err)
+	}
+	return customerPricingFromModel(&model), nil
+}
+
+// SaveCustomerPricing inserts or replaces the pricing terms of a contact
+func (r *GORMRepository) SaveCustomerPricing(ctx context.Context, schemaName string, terms *CustomerPricing) error {
+	db, err := r.tenantTable(ctx, schemaName, "customer_pricing")
+	if err != nil {
+		return fmt.Errorf("qualify customer pricing table: %w", err)
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "contact_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"customer_group_id", "price_list_id", "discount_percent", "updated_at"}),
+	}).Create(customerPricingToModel(terms)).Error; err != nil {
+		return fmt.Errorf("save customer pricing: %w", err)
+	}
+	return nil
+}
+
+func insertPriceListItemsTx(tx *gorm.DB, schemaName string, list *PriceList) error {
+	if len(list.Items) == 0 {
+		return nil
+	}
+	itemsTable, err := database.TenantTable(tx, schemaName, "price_list_items")
+	if err != nil {
+		return fmt.Errorf("qualify price list items table: %w", err)
+	}
+	itemModels := make([]models.PriceListItem, len(list.Items))
+	for i := range list.Items {
+		list.Items[i].PriceListID = list.ID
+		itemModels[i] = *priceListItemToModel(&list.Items[i])
+	}
+	if err := itemsTable.Create(&itemModels).Error; err != nil {
+		return fmt.Errorf("insert price list items: %w", err)
+	}
+	return nil
+}
+
+func priceListToModel(list *PriceList) *models.PriceList {
+	return &models.PriceList{
+		ID:        list.ID,
+		TenantID:  list.TenantID,
+		Code:      list.Code,
+		Name:      list.Name,
+		Currency:  list.Currency,
+		ValidFrom: list.ValidFrom,
+		ValidTo:   list.ValidTo,
+		IsDefault: list.IsDefault,
+		IsActive:  list.IsActive,
+		Notes:     list.Notes,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func priceListFromModel(list *models.PriceList) *PriceList {
+	return &PriceList{
+		ID:        list.ID,
+		TenantID:  list.TenantID,
+		Code:      list.Code,
+		Name:      list.Name,
+		Currency:  list.Currency,
+		ValidFrom: list.ValidFrom,
+		ValidTo:   list.ValidTo,
+		IsDefault: list.IsDefault,
+		IsActive:  list.IsActive,
+		Notes:     list.Notes,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func priceListItemToModel(item *PriceListItem) *models.PriceListItem {
+	return &models.PriceListItem{
+		ID:          item.ID,
+		TenantID:    item.TenantID,
+		PriceListID: item.PriceListID,
+		ProductID:   item.ProductID,
+		MinQuantity: models.Decimal{Decimal: item.MinQuantity},
+		UnitPrice:   models.Decimal{Decimal: item.UnitPrice},
+	}
+}
+
+func priceListItemFromModel(item *models.PriceListItem) *PriceListItem {
+	return &PriceListItem{
+		ID:          item.ID,
+		TenantID:    item.TenantID,
+		PriceListID: item.PriceListID,
+		ProductID:   item.ProductID,
+		MinQuantity: item.MinQuantity.Decimal,
+		UnitPrice:   item.UnitPrice.Decimal,
+	}
+}
+
+func customerGroupToModel(group *CustomerGroup) *models.CustomerGroup {
+	return &models.CustomerGroup{
+		ID:              group.ID,
+		TenantID:        group.TenantID,
+		Code:            group.Code,
+		Name:            group.Name,
+		PriceListID:     group.PriceListID,
+		DiscountPercent: models.Decimal{Decimal: group.DiscountPercent},
+		CreatedAt:       group.CreatedAt,
+		UpdatedAt:       group.UpdatedAt,
+	}
+}
+
+func customerGroupFromModel(group *models.CustomerGroup) *CustomerGroup {
+	return &CustomerGroup{
+		ID:              group.ID,
+		TenantID:        group.TenantID,
+		Code:            group.Code,
+		Name:            group.Name,
+		PriceListID:     group.PriceListID,
+		DiscountPercent: group.DiscountPercent.Decimal,
+		CreatedAt:       group.CreatedAt,
+		UpdatedAt:       group.UpdatedAt,
+	}
+}
+
+func customerPricingToModel(terms *CustomerPricing) *models.CustomerPricing {
+	return &models.CustomerPricing{
+		ContactID:       terms.ContactID,
+		TenantID:        terms.TenantID,
+		CustomerGroupID: terms.CustomerGroupID,
+		PriceListID:     terms.PriceListID,
+		DiscountPercent: models.Decimal{Decimal: terms.DiscountPercent},
+		UpdatedAt:       terms.UpdatedAt,
+	}
+}
+
+func customerPricingFromModel(terms *models.CustomerPricing) *CustomerPricing {
+	return &CustomerPricing{
+		ContactID:       terms.ContactID,
+		TenantID:        terms.TenantID,
+		CustomerGroupID: terms.CustomerGroupID,
+		PriceListID:     terms.PriceListID,
+		DiscountPercent: terms.DiscountPercent.Decimal,
+		UpdatedAt:       terms.UpdatedAt,
+	}
+}
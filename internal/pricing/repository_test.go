@@ -0,0 +1,120 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_test"
+	tenantID := "tenant-1"
+
+	repositories := []struct {
+		name string
+		repo *GORMRepository
+	}{
+		{name: "nil receiver", repo: nil},
+		{name: "nil gorm database", repo: NewGORMRepository(nil)},
+		{name: "nil pool constructor", repo: NewRepository(nil)},
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo *GORMRepository) error
+	}{
+		{name: "CreatePriceList", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreatePriceList(ctx, schemaName, &PriceList{ID: "list-1", TenantID: tenantID})
+		}},
+		{name: "UpdatePriceList", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.UpdatePriceList(ctx, schemaName, &PriceList{ID: "list-1", TenantID: tenantID})
+		}},
+		{name: "GetPriceList", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetPriceList(ctx, schemaName, tenantID, "list-1")
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListPriceLists", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListPriceLists(ctx, schemaName, tenantID, &PriceListFilter{Currency: "EUR", ActiveOnly: true})
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "CreateCustomerGroup", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreateCustomerGroup(ctx, schemaName, &CustomerGroup{ID: "group-1", TenantID: tenantID})
+		}},
+		{name: "UpdateCustomerGroup", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.UpdateCustomerGroup(ctx, schemaName, &CustomerGroup{ID: "group-1", TenantID: tenantID})
+		}},
+		{name: "GetCustomerGroup", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetCustomerGroup(ctx, schemaName, tenantID, "group-1")
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListCustomerGroups", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListCustomerGroups(ctx, schemaName, tenantID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "GetCustomerPricing", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetCustomerPricing(ctx, schemaName, tenantID, "contact-1")
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "SaveCustomerPricing", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.SaveCustomerPricing(ctx, schemaName, &CustomerPricing{ContactID: "contact-1", TenantID: tenantID})
+		}},
+	}
+
+	for _, repository := range repositories {
+		repository := repository
+		t.Run(repository.name, func(t *testing.T) {
+			for _, tt := range tests {
+				tt := tt
+				t.Run(tt.name, func(t *testing.T) {
+					err := tt.run(t, repository.repo)
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), "pricing repository database is not configured")
+				})
+			}
+		})
+	}
+}
+
+func TestPricingModelMappingRoundTrip(t *testing.T) {
+	validFrom := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)
+	list := &PriceList{
+		ID:        "list-1",
+		TenantID:  "tenant-1",
+		Code:      "RETAIL",
+		Name:      "Retail",
+		Currency:  "EUR",
+		ValidFrom: &validFrom,
+		IsDefault: true,
+		IsActive:  true,
+		Notes:     "Public prices",
+	}
+	assert.Equal(t, list, priceListFromModel(priceListToModel(list)))
+
+	item := &PriceListItem{
+		ID:          "item-1",
+		TenantID:    "tenant-1",
+		PriceListID: "list-1",
+		ProductID:   "product-1",
+		MinQuantity: decimal.NewFromInt(10),
+		UnitPrice:   decimal.RequireFromString("16.5"),
+	}
+	assert.Equal(t, item, priceListItemFromModel(priceListItemToModel(item)))
+
+	priceListID := "list-1"
+	group := &CustomerGroup{ID: "group-1", TenantID: "tenant-1", Code: "DEALERS", Name: "Dealers", PriceListID: &priceListID, DiscountPercent: decimal.NewFromInt(5)}
+	assert.Equal(t, group, customerGroupFromModel(customerGroupToModel(group)))
+
+	groupID := "group-1"
+	terms := &CustomerPricing{ContactID: "contact-1", TenantID: "tenant-1", CustomerGroupID: &groupID, DiscountPercent: decimal.RequireFromString("7.5")}
+	assert.Equal(t, terms, customerPricingFromModel(customerPricingToModel(terms)))
+}
@@ -0,0 +1,563 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+type productReader interface {
+	GetProductByID(ctx context.Context, tenantID, schemaName, productID string) (*inventory.Product, error)
+}
+
+type contactReader interface {
+	GetByID(ctx context.Context, tenantID, schemaName, contactID string) (*contacts.Contact, error)
+}
+
+type tenantReader interface {
+	GetTenant(ctx context.Context, tenantID string) (*tenant.Tenant, error)
+}
+
+// Resolver resolves the sales price of a product sold to a contact
+type Resolver interface {
+	ResolvePrice(ctx context.Context, tenantID, schemaName string, req *ResolvePriceRequest) (*ResolvedPrice, error)
+}
+
+// Service provides price lists, customer pricing terms and sales price resolution
+type Service struct {
+	repo     Repository
+	products productReader
+	contacts contactReader
+	tenants  tenantReader
+}
+
+// NewService creates a new pricing service with an ORM-backed repository.
+func NewService(db *pgxpool.Pool, inventoryService *inventory.Service, contactsService *contacts.Service, tenantService *tenant.Service) *Service {
+	return &Service{
+		repo:     NewRepository(db),
+		products: inventoryService,
+		contacts: contactsService,
+		tenants:  tenantService,
+	}
+}
+
+// NewServiceWithRepository creates a new pricing service with custom dependencies
+func NewServiceWithRepository(repo Repository, products productReader, contactsReader contactReader, tenants tenantReader) *Service {
+	return &Service{
+		repo:     repo,
+		products: products,
+		contacts: contactsReader,
+		tenants:  tenants,
+	}
+}
+
+// CreatePriceList creates a price list with its product prices
+func (s *Service) CreatePriceList(ctx context.Context, tenantID, schemaName string, req *CreatePriceListRequest) (*PriceList, error) {
+	if req == nil {
+		return nil, fmt.Errorf("price list request is required")
+	}
+	now := time.Now()
+	list := &PriceList{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Code:      strings.TrimSpace(req.Code),
+		Name:      strings.TrimSpace(req.Name),
+		Currency:  req.Currency,
+		ValidFrom: req.ValidFrom,
+		ValidTo:   req.ValidTo,
+		IsDefault: req.IsDefault,
+		IsActive:  true,
+		Notes:     req.Notes,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if list.Code == "" {
+		return nil, fmt.Errorf("code is required")
+	}
+	if err := s.preparePriceList(ctx, tenantID, schemaName, list, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreatePriceList(ctx, schemaName, list); err != nil {
+		return nil, fmt.Errorf("create price list: %w", err)
+	}
+	return list, nil
+}
+
+// UpdatePriceList replaces the header and product prices of a price list
+func (s *Service) UpdatePriceList(ctx context.Context, tenantID, schemaName, priceListID string, req *UpdatePriceListRequest) (*PriceList, error) {
+	if req == nil {
+		return nil, fmt.Errorf("price list request is required")
+	}
+	list, err := s.repo.GetPriceList(ctx, schemaName, tenantID, priceListID)
+	if err != nil {
+		return nil, fmt.Errorf("get price list: %w", err)
+	}
+	list.Name = strings.TrimSpace(req.Name)
+	list.Currency = req.Currency
+	list.ValidFrom = req.ValidFrom
+	list.ValidTo = req.ValidTo
+	list.IsDefault = req.IsDefault
+	if req.IsActive != nil {
+		list.IsActive = *req.IsActive
+	}
+	list.Notes = req.Notes
+	list.UpdatedAt = time.Now()
+	if err := s.preparePriceList(ctx, tenantID, schemaName, list, req.Items); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdatePriceList(ctx, schemaName, list); err != nil {
+		return nil, fmt.Errorf("update price list: %w", err)
+	}
+	return list, nil
+}
+
+// GetPriceList retrieves a price list with its product prices
+func (s *Service) GetPriceList(ctx context.Context, tenantID, schemaName, priceListID string) (*PriceList, error) {
+	list, err := s.repo.GetPriceList(ctx, schemaName, tenantID, priceListID)
+	if err != nil {
+		return nil, fmt.Errorf("get price list: %w", err)
+	}
+	return list, nil
+}
+
+// ListPriceLists retrieves price lists without their product prices
+func (s *Service) ListPriceLists(ctx context.Context, tenantID, schemaName string, filter *PriceListFilter) ([]PriceList, error) {
+	if filter != nil {
+		filter.Currency = strings.ToUpper(strings.TrimSpace(filter.Currency))
+	}
+	lists, err := s.repo.ListPriceLists(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list price lists: %w", err)
+	}
+	return lists, nil
+}
+
+// preparePriceList validates the header, builds the items and makes sure an
+// active default list does not overlap another default list in the currency.
+func (s *Service) preparePriceList(ctx context.Context, tenantID, schemaName string, list *PriceList, items []PriceListItemRequest) error {
+	if list.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	currency, err := normalizeCurrency(list.Currency)
+	if err != nil {
+		return err
+	}
+	list.Currency = currency
+	if list.ValidFrom != nil && list.ValidTo != nil && list.ValidTo.Before(*list.ValidFrom) {
+		return fmt.Errorf("valid_to cannot be before valid_from")
+	}
+
+	seen := make(map[string]bool, len(items))
+	list.Items = make([]PriceListItem, 0, len(items))
+	for i, reqItem := range items {
+		productID := strings.TrimSpace(reqItem.ProductID)
+		if productID == "" {
+			return fmt.Errorf("item %d: product_id is required", i+1)
+		}
+		if reqItem.MinQuantity.IsNegative() {
+			return fmt.Errorf("item %d: min_quantity cannot be negative", i+1)
+		}
+		if reqItem.UnitPrice.IsNegative() {
+			return fmt.Errorf("item %d: unit_price cannot be negative", i+1)
+		}
+		key := productID + "|" + reqItem.MinQuantity.String()
+		if seen[key] {
+			return fmt.Errorf("item %d: product %s already has a price from quantity %s", i+1, productID, reqItem.MinQuantity.String())
+		}
+		seen[key] = true
+		if s.products != nil {
+			if _, err := s.products.GetProductByID(ctx, tenantID, schemaName, productID); err != nil {
+				return fmt.Errorf("item %d: get product: %w", i+1, err)
+			}
+		}
+		list.Items = append(list.Items, PriceListItem{
+			ID:          uuid.New().String(),
+			TenantID:    tenantID,
+			PriceListID: list.ID,
+			ProductID:   productID,
+			MinQuantity: reqItem.MinQuantity,
+			UnitPrice:   reqItem.UnitPrice,
+		})
+	}
+
+	if !list.IsDefault || !list.IsActive {
+		return nil
+	}
+	others, err := s.repo.ListPriceLists(ctx, schemaName, tenantID, &PriceListFilter{Currency: list.Currency, ActiveOnly: true})
+	if err != nil {
+		return fmt.Errorf("list price lists: %w", err)
+	}
+	for _, other := range others {
+		if other.ID != list.ID && other.IsDefault && validityOverlaps(list, &other) {
+			return fmt.Errorf("default price list %s for %s already covers these dates", other.Code, list.Currency)
+		}
+	}
+	return nil
+}
+
+// CreateCustomerGroup creates a customer group
+func (s *Service) CreateCustomerGroup(ctx context.Context, tenantID, schemaName string, req *CustomerGroupRequest) (*CustomerGroup, error) {
+	if req == nil {
+		return nil, fmt.Errorf("customer group request is required")
+	}
+	now := time.Now()
+	group := &CustomerGroup{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.applyCustomerGroupRequest(ctx, tenantID, schemaName, group, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateCustomerGroup(ctx, schemaName, group); err != nil {
+		return nil, fmt.Errorf("create customer group: %w", err)
+	}
+	return group, nil
+}
+
+// UpdateCustomerGroup updates the code, name, price list and discount of a customer group
+func (s *Service) UpdateCustomerGroup(ctx context.Context, tenantID, schemaName, groupID string, req *CustomerGroupRequest) (*CustomerGroup, error) {
+	if req == nil {
+		return nil, fmt.Errorf("customer group request is required")
+	}
+	group, err := s.repo.GetCustomerGroup(ctx, schemaName, tenantID, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("get customer group: %w", err)
+	}
+	group.UpdatedAt = time.Now()
+	if err := s.applyCustomerGroupRequest(ctx, tenantID, schemaName, group, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateCustomerGroup(ctx, schemaName, group); err != nil {
+		return nil, fmt.Errorf("update customer group: %w", err)
+	}
+	return group, nil
+}
+
+// ListCustomerGroups retrieves customer groups
+func (s *Service) ListCustomerGroups(ctx context.Context, tenantID, schemaName string) ([]CustomerGroup, error) {
+	groups, err := s.repo.ListCustomerGroups(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list customer groups: %w", err)
+	}
+	return groups, nil
+}
+
+func (s *Service) applyCustomerGroupRequest(ctx context.Context, tenantID, schemaName string, group *CustomerGroup, req *CustomerGroupRequest) error {
+	group.Code = strings.TrimSpace(req.Code)
+	group.Name = strings.TrimSpace(req.Name)
+	if group.Code == "" {
+		return fmt.Errorf("code is required")
+	}
+	if group.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if err := validateDiscountPercent(req.DiscountPercent); err != nil {
+		return err
+	}
+	group.DiscountPercent = req.DiscountPercent
+	priceListID, err := s.optionalPriceListID(ctx, tenantID, schemaName, req.PriceListID)
+	if err != nil {
+		return err
+	}
+	group.PriceListID = priceListID
+	return nil
+}
+
+// GetCustomerPricing returns the pricing terms of a contact. Contacts without
+// terms get empty terms.
+func (s *Service) GetCustomerPricing(ctx context.Context, tenantID, schemaName, contactID string) (*CustomerPricing, error) {
+	if err := s.requireContact(ctx, tenantID, schemaName, contactID); err != nil {
+		return nil, err
+	}
+	terms, err := s.repo.GetCustomerPricing(ctx, schemaName, tenantID, contactID)
+	if err != nil {
+		return nil, fmt.Errorf("get customer pricing: %w", err)
+	}
+	if terms == nil {
+		terms = &CustomerPricing{ContactID: contactID, TenantID: tenantID}
+	}
+	return terms, nil
+}
+
+// SetCustomerPricing links a contact to a customer group, price list and discount
+func (s *Service) SetCustomerPricing(ctx context.Context, tenantID, schemaName, contactID string, req *SetCustomerPricingRequest) (*CustomerPricing, error) {
+	if req == nil {
+		return nil, fmt.Errorf("customer pricing request is required")
+	}
+	if err := s.requireContact(ctx, tenantID, schemaName, contactID); err != nil {
+		return nil, err
+	}
+	if err := validateDiscountPercent(req.DiscountPercent); err != nil {
+		return nil, err
+	}
+	terms := &CustomerPricing{
+		ContactID:       contactID,
+		TenantID:        tenantID,
+		DiscountPercent: req.DiscountPercent,
+		UpdatedAt:       time.Now(),
+	}
+	if groupID := optionalID(req.CustomerGroupID); groupID != nil {
+		if _, err := s.repo.GetCustomerGroup(ctx, schemaName, tenantID, *groupID); err != nil {
+			return nil, fmt.Errorf("get customer group: %w", err)
+		}
+		terms.CustomerGroupID = groupID
+	}
+	priceListID, err := s.optionalPriceListID(ctx, tenantID, schemaName, req.PriceListID)
+	if err != nil {
+		return nil, err
+	}
+	terms.PriceListID = priceListID
+
+	if err := s.repo.SaveCustomerPricing(ctx, schemaName, terms); err != nil {
+		return nil, fmt.Errorf("save customer pricing: %w", err)
+	}
+	return terms, nil
+}
+
+// ResolvePrice returns the unit price and discount of a product sold to a
+// contact. The first price list in the currency that is valid on the date
+// and prices the product wins, in this order: the customer's own list, the
+// customer group's list, then the default list. Within a list the quantity
+// break with the highest minimum quantity not above the line quantity
+// applies. Without a matching list the product sales price is used, which is
+// in the tenant's default currency; other currencies return
+// ErrNoPriceInCurrency. The customer discount replaces the customer group
+// discount.
+func (s *Service) ResolvePrice(ctx context.Context, tenantID, schemaName string, req *ResolvePriceRequest) (*ResolvedPrice, error) {
+	if req == nil {
+		return nil, fmt.Errorf("price request is required")
+	}
+	if s.products == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for price resolution")
+	}
+	productID := strings.TrimSpace(req.ProductID)
+	if productID == "" {
+		return nil, fmt.Errorf("product_id is required")
+	}
+	currency, err := normalizeCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	quantity := req.Quantity
+	if quantity.LessThanOrEqual(decimal.Zero) {
+		quantity = decimal.NewFromInt(1)
+	}
+	date := req.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	product, err := s.products.GetProductByID(ctx, tenantID, schemaName, productID)
+	if err != nil {
+		return nil, fmt.Errorf("get product: %w", err)
+	}
+	resolved := &ResolvedPrice{
+		ProductID:   product.ID,
+		ProductName: product.Name,
+		Unit:        product.Unit,
+		Currency:    currency,
+		Quantity:    quantity,
+		UnitPrice:   product.SalesPrice,
+		Source:      PriceSourceProduct,
+	}
+
+	type candidate struct {
+		priceListID string
+		source      PriceSource
+	}
+	var candidates []candidate
+	contactID := strings.TrimSpace(req.ContactID)
+	if contactID != "" {
+		terms, err := s.repo.GetCustomerPricing(ctx, schemaName, tenantID, contactID)
+		if err != nil {
+			return nil, fmt.Errorf("get customer pricing: %w", err)
+		}
+		if terms != nil {
+			if terms.PriceListID != nil {
+				candidates = append(candidates, candidate{priceListID: *terms.PriceListID, source: PriceSourceCustomerPriceList})
+			}
+			var group *CustomerGroup
+			if terms.CustomerGroupID != nil {
+				group, err = s.repo.GetCustomerGroup(ctx, schemaName, tenantID, *terms.CustomerGroupID)
+				if err != nil {
+					return nil, fmt.Errorf("get customer group: %w", err)
+				}
+				if group.PriceListID != nil {
+					candidates = append(candidates, candidate{priceListID: *group.PriceListID, source: PriceSourceGroupPriceList})
+				}
+			}
+			switch {
+			case terms.DiscountPercent.IsPositive():
+				resolved.DiscountPercent = terms.DiscountPercent
+				resolved.DiscountSource = DiscountSourceCustomer
+			case group != nil && group.DiscountPercent.IsPositive():
+				resolved.DiscountPercent = group.DiscountPercent
+				resolved.DiscountSource = DiscountSourceGroup
+			}
+		}
+	}
+	defaults, err := s.repo.ListPriceLists(ctx, schemaName, tenantID, &PriceListFilter{Currency: currency, ActiveOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("list price lists: %w", err)
+	}
+	for _, list := range defaults {
+		if list.IsDefault && list.ValidOn(date) {
+			candidates = append(candidates, candidate{priceListID: list.ID, source: PriceSourceDefaultPriceList})
+		}
+	}
+
+	for _, next := range candidates {
+		list, err := s.repo.GetPriceList(ctx, schemaName, tenantID, next.priceListID)
+		if err != nil {
+			return nil, fmt.Errorf("get price list: %w", err)
+		}
+		if list.Currency != currency || !list.ValidOn(date) {
+			continue
+		}
+		item := list.PriceFor(product.ID, quantity)
+		if item == nil {
+			continue
+		}
+		resolved.UnitPrice = item.UnitPrice
+		resolved.Source = next.source
+		resolved.PriceListID = list.ID
+		resolved.PriceListCode = list.Code
+		resolved.MinQuantity = item.MinQuantity
+		break
+	}
+	if resolved.Source == PriceSourceProduct {
+		baseCurrency, err := s.baseCurrency(ctx, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		if currency != baseCurrency {
+			return nil, fmt.Errorf("%w: product %s has a sales price in %s and no price list in %s", ErrNoPriceInCurrency, product.Name, baseCurrency, currency)
+		}
+	}
+	return resolved, nil
+}
+
+// baseCurrency returns the tenant's default currency, which product sales
+// prices are in.
+func (s *Service) baseCurrency(ctx context.Context, tenantID string) (string, error) {
+	if s.tenants == nil {
+		return "EUR", nil
+	}
+	tenantRecord, err := s.tenants.GetTenant(ctx, tenantID)
+	if err != nil {
+		return "", fmt.Errorf("get tenant: %w", err)
+	}
+	return normalizeCurrency(tenantRecord.Settings.DefaultCurrency)
+}
+
+// ApplyProductPrice fills a document line that has a product but no unit
+// price with the unit price and discount resolved from the customer's price
+// lists, and with the product name and unit when those are missing. Lines
+// with a unit price, including zero, and a nil resolver leave the line as it
+// is.
+func ApplyProductPrice(ctx context.Context, prices Resolver, tenantID, schemaName string, doc Document, line *DocumentLine) error {
+	if prices == nil || line.ProductID == nil || *line.ProductID == "" || line.UnitPrice != nil {
+		return nil
+	}
+	resolved, err := prices.ResolvePrice(ctx, tenantID, schemaName, &ResolvePriceRequest{
+		ContactID: doc.ContactID,
+		ProductID: *line.ProductID,
+		Currency:  doc.Currency,
+		Quantity:  line.Quantity,
+		Date:      doc.Date,
+	})
+	if err != nil {
+		return err
+	}
+	unitPrice := resolved.UnitPrice
+	line.UnitPrice = &unitPrice
+	if line.DiscountPercent.IsZero() {
+		line.DiscountPercent = resolved.DiscountPercent
+	}
+	if line.Description == "" {
+		line.Description = resolved.ProductName
+	}
+	if line.Unit == "" {
+		line.Unit = resolved.Unit
+	}
+	return nil
+}
+
+func (s *Service) requireContact(ctx context.Context, tenantID, schemaName, contactID string) error {
+	if strings.TrimSpace(contactID) == "" {
+		return fmt.Errorf("contact id is required")
+	}
+	if s.contacts == nil {
+		return nil
+	}
+	if _, err := s.contacts.GetByID(ctx, tenantID, schemaName, contactID); err != nil {
+		return fmt.Errorf("get contact: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) optionalPriceListID(ctx context.Context, tenantID, schemaName string, value *string) (*string, error) {
+	priceListID := optionalID(value)
+	if priceListID == nil {
+		return nil, nil
+	}
+	if _, err := s.repo.GetPriceList(ctx, schemaName, tenantID, *priceListID); err != nil {
+		return nil, fmt.Errorf("get price list: %w", err)
+	}
+	return priceListID, nil
+}
+
+func optionalID(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func normalizeCurrency(value string) (string, error) {
+	currency := strings.ToUpper(strings.TrimSpace(value))
+	if currency == "" {
+		return "EUR", nil
+	}
+	if len(currency) != 3 {
+		return "", fmt.Errorf("currency must be a 3-letter code")
+	}
+	return currency, nil
+}
+
+func validateDiscountPercent(value decimal.Decimal) error {
+	if value.IsNegative() || value.GreaterThan(decimal.NewFromInt(100)) {
+		return fmt.Errorf("discount_percent must be between 0 and 100")
+	}
+	return nil
+}
+
+// validityOverlaps reports whether two price lists share at least one valid day
+func validityOverlaps(a, b *PriceList) bool {
+	if a.ValidTo != nil && b.ValidFrom != nil && a.ValidTo.Format("2006-01-02") < b.ValidFrom.Format("2006-01-02") {
+		return false
+	}
+	if b.ValidTo != nil && a.ValidFrom != nil && b.ValidTo.Format("2006-01-02") < a.ValidFrom.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,397 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/contacts"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/tenant"
+)
+
+const (
+	testProductID = "33333333-3333-4333-8333-333333333333"
+	testContactID = "55555555-5555-4555-8555-555555555555"
+	testOtherID   = "66666666-6666-4666-8666-666666666666"
+)
+
+type mockRepository struct {
+	lists  map[string]*PriceList
+	groups map[string]*CustomerGroup
+	terms  map[string]*CustomerPricing
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{
+		lists:  make(map[string]*PriceList),
+		groups: make(map[string]*CustomerGroup),
+		terms:  make(map[string]*CustomerPricing),
+	}
+}
+
+func (m *mockRepository) CreatePriceList(_ context.Context, _ string, list *PriceList) error {
+	copied := *list
+	m.lists[list.ID] = &copied
+	return nil
+}
+
+func (m *mockRepository) UpdatePriceList(ctx context.Context, schemaName string, list *PriceList) error {
+	if _, ok := m.lists[list.ID]; !ok {
+		return ErrPriceListNotFound
+	}
+	return m.CreatePriceList(ctx, schemaName, list)
+}
+
+func (m *mockRepository) GetPriceList(_ context.Context, _, tenantID, priceListID string) (*PriceList, error) {
+	list, ok := m.lists[priceListID]
+	if !ok || list.TenantID != tenantID {
+		return nil, ErrPriceListNotFound
+	}
+	copied := *list
+	return &copied, nil
+}
+
+func (m *mockRepository) ListPriceLists(_ context.Context, _, tenantID string, filter *PriceListFilter) ([]PriceList, error) {
+	var lists []PriceList
+	for _, list := range m.lists {
+		if list.TenantID != tenantID {
+			continue
+		}
+		if filter != nil && filter.Currency != "" && list.Currency != filter.Currency {
+			continue
+		}
+		if filter != nil && filter.ActiveOnly && !list.IsActive {
+			continue
+		}
+		copied := *list
+		copied.Items = nil
+		lists = append(lists, copied)
+	}
+	return lists, nil
+}
+
+func (m *mockRepository) CreateCustomerGroup(_ context.Context, _ string, group *CustomerGroup) error {
+	copied := *group
+	m.groups[group.ID] = &copied
+	return nil
+}
+
+func (m *mockRepository) UpdateCustomerGroup(ctx context.Context, schemaName string, group *CustomerGroup) error {
+	if _, ok := m.groups[group.ID]; !ok {
+		return ErrCustomerGroupNotFound
+	}
+	return m.CreateCustomerGroup(ctx, schemaName, group)
+}
+
+func (m *mockRepository) GetCustomerGroup(_ context.Context, _, tenantID, groupID string) (*CustomerGroup, error) {
+	group, ok := m.groups[groupID]
+	if !ok || group.TenantID != tenantID {
+		return nil, ErrCustomerGroupNotFound
+	}
+	copied := *group
+	return &copied, nil
+}
+
+func (m *mockRepository) ListCustomerGroups(context.Context, string, string) ([]CustomerGroup, error) {
+	var groups []CustomerGroup
+	for _, group := range m.groups {
+		groups = append(groups, *group)
+	}
+	return groups, nil
+}
+
+func (m *mockRepository) GetCustomerPricing(_ context.Context, _, _, contactID string) (*CustomerPricing, error) {
+	return m.terms[contactID], nil
+}
+
+func (m *mockRepository) SaveCustomerPricing(_ context.Context, _ string, terms *CustomerPricing) error {
+	copied := *terms
+	m.terms[terms.ContactID] = &copied
+	return nil
+}
+
+type fakeCatalog struct {
+	currency string
+}
+
+func (fakeCatalog) GetProductByID(_ context.Context, _, _, productID string) (*inventory.Product, error) {
+	if productID != testProductID {
+		return nil, fmt.Errorf("product not found")
+	}
+	return &inventory.Product{ID: productID, Code: "SKU-1", Name: "Widget", Unit: "pcs", SalesPrice: decimal.NewFromInt(20)}, nil
+}
+
+func (fakeCatalog) GetByID(_ context.Context, _, _, contactID string) (*contacts.Contact, error) {
+	if contactID != testContactID && contactID != testOtherID {
+		return nil, contacts.ErrContactNotFound
+	}
+	return &contacts.Contact{ID: contactID, Name: "Customer"}, nil
+}
+
+func (c fakeCatalog) GetTenant(_ context.Context, tenantID string) (*tenant.Tenant, error) {
+	return &tenant.Tenant{ID: tenantID, Settings: tenant.TenantSettings{DefaultCurrency: c.currency}}, nil
+}
+
+func newTestService() (*Service, *mockRepository) {
+	repo := newMockRepository()
+	catalog := fakeCatalog{currency: "EUR"}
+	return NewServiceWithRepository(repo, catalog, catalog, catalog), repo
+}
+
+func day(value string) *time.Time {
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return &parsed
+}
+
+func price(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func createList(t *testing.T, svc *Service, req *CreatePriceListRequest) *PriceList {
+	t.Helper()
+	list, err := svc.CreatePriceList(context.Background(), "tenant-1", "tenant_test", req)
+	require.NoError(t, err)
+	return list
+}
+
+func resolve(t *testing.T, svc *Service, contactID, currency, quantity, date string) *ResolvedPrice {
+	t.Helper()
+	resolved, err := svc.ResolvePrice(context.Background(), "tenant-1", "tenant_test", &ResolvePriceRequest{
+		ContactID: contactID,
+		ProductID: testProductID,
+		Currency:  currency,
+		Quantity:  price(quantity),
+		Date:      *day(date),
+	})
+	require.NoError(t, err)
+	return resolved
+}
+
+func TestResolvePricePrefersCustomerThenGroupThenDefaultList(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	defaultList := createList(t, svc, &CreatePriceListRequest{
+		Code:      "RETAIL",
+		Name:      "Retail",
+		IsDefault: true,
+		Items: []PriceListItemRequest{
+			{ProductID: testProductID, UnitPrice: price("18")},
+			{ProductID: testProductID, MinQuantity: price("10"), UnitPrice: price("16")},
+			{ProductID: testProductID, MinQuantity: price("50"), UnitPrice: price("15")},
+		},
+	})
+	groupList := createList(t, svc, &CreatePriceListRequest{
+		Code:      "WHOLESALE",
+		Name:      "Wholesale",
+		ValidFrom: day("2026-01-01"),
+		ValidTo:   day("2026-06-30"),
+		Items:     []PriceListItemRequest{{ProductID: testProductID, UnitPrice: price("14")}},
+	})
+	customerList := createList(t, svc, &CreatePriceListRequest{
+		Code:     "ACME-USD",
+		Name:     "Acme USD",
+		Currency: "usd",
+		Items:    []PriceListItemRequest{{ProductID: testProductID, UnitPrice: price("21")}},
+	})
+	group, err := svc.CreateCustomerGroup(ctx, "tenant-1", "tenant_test", &CustomerGroupRequest{
+		Code:            "DEALERS",
+		Name:            "Dealers",
+		PriceListID:     &groupList.ID,
+		DiscountPercent: price("5"),
+	})
+	require.NoError(t, err)
+	_, err = svc.SetCustomerPricing(ctx, "tenant-1", "tenant_test", testContactID, &SetCustomerPricingRequest{
+		CustomerGroupID: &group.ID,
+		PriceListID:     &customerList.ID,
+	})
+	require.NoError(t, err)
+
+	resolved := resolve(t, svc, testContactID, "USD", "1", "2026-03-01")
+	assert.Equal(t, PriceSourceCustomerPriceList, resolved.Source)
+	assert.Equal(t, "ACME-USD", resolved.PriceListCode)
+	assert.True(t, resolved.UnitPrice.Equal(price("21")))
+	assert.True(t, resolved.DiscountPercent.Equal(price("5")))
+	assert.Equal(t, DiscountSourceGroup, resolved.DiscountSource)
+
+	resolved = resolve(t, svc, testContactID, "EUR", "1", "2026-03-01")
+	assert.Equal(t, PriceSourceGroupPriceList, resolved.Source)
+	assert.True(t, resolved.UnitPrice.Equal(price("14")))
+	assert.Equal(t, "Widget", resolved.ProductName)
+	assert.Equal(t, "pcs", resolved.Unit)
+
+	resolved = resolve(t, svc, testContactID, "EUR", "12", "2026-07-01")
+	assert.Equal(t, PriceSourceDefaultPriceList, resolved.Source)
+	assert.Equal(t, defaultList.ID, resolved.PriceListID)
+	assert.True(t, resolved.UnitPrice.Equal(price("16")))
+	assert.True(t, resolved.MinQuantity.Equal(price("10")))
+
+	resolved = resolve(t, svc, testOtherID, "", "50", "2026-07-01")
+	assert.True(t, resolved.UnitPrice.Equal(price("15")))
+	assert.True(t, resolved.DiscountPercent.IsZero())
+
+	_, err = svc.SetCustomerPricing(ctx, "tenant-1", "tenant_test", testContactID, &SetCustomerPricingRequest{
+		CustomerGroupID: &group.ID,
+		DiscountPercent: price("7.5"),
+	})
+	require.NoError(t, err)
+	resolved = resolve(t, svc, testContactID, "EUR", "1", "2026-03-01")
+	assert.True(t, resolved.DiscountPercent.Equal(price("7.5")))
+	assert.Equal(t, DiscountSourceCustomer, resolved.DiscountSource)
+}
+
+func TestResolvePriceFallsBackToProductSalesPrice(t *testing.T) {
+	svc, _ := newTestService()
+	createList(t, svc, &CreatePriceListRequest{
+		Code:      "RETAIL-2025",
+		Name:      "Retail 2025",
+		IsDefault: true,
+		ValidTo:   day("2025-12-31"),
+		Items:     []PriceListItemRequest{{ProductID: testProductID, UnitPrice: price("18")}},
+	})
+
+	resolved := resolve(t, svc, "", "EUR", "0", "2026-01-01")
+	assert.Equal(t, PriceSourceProduct, resolved.Source)
+	assert.True(t, resolved.UnitPrice.Equal(price("20")))
+	assert.True(t, resolved.Quantity.Equal(price("1")))
+
+	_, err := svc.ResolvePrice(context.Background(), "tenant-1", "tenant_test", &ResolvePriceRequest{ProductID: "missing"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "product not found")
+}
+
+func TestResolvePriceRejectsSalesPriceInAnotherCurrency(t *testing.T) {
+	svc, repo := newTestService()
+	createList(t, svc, &CreatePriceListRequest{
+		Code:      "RETAIL",
+		Name:      "Retail",
+		IsDefault: true,
+		Items:     []PriceListItemRequest{{ProductID: testProductID, UnitPrice: price("18")}},
+	})
+
+	_, err := svc.ResolvePrice(context.Background(), "tenant-1", "tenant_test", &ResolvePriceRequest{
+		ProductID: testProductID,
+		Currency:  "USD",
+		Date:      *day("2026-03-01"),
+	})
+	require.ErrorIs(t, err, ErrNoPriceInCurrency)
+	assert.Contains(t, err.Error(), "sales price in EUR and no price list in USD")
+
+	catalog := fakeCatalog{currency: "SEK"}
+	svc = NewServiceWithRepository(repo, catalog, catalog, catalog)
+	resolved := resolve(t, svc, "", "SEK", "1", "2026-03-01")
+	assert.Equal(t, PriceSourceProduct, resolved.Source)
+	assert.True(t, resolved.UnitPrice.Equal(price("20")))
+	_, err = svc.ResolvePrice(context.Background(), "tenant-1", "tenant_test", &ResolvePriceRequest{ProductID: testProductID, Currency: "USD"})
+	require.ErrorIs(t, err, ErrNoPriceInCurrency)
+}
+
+func TestApplyProductPrice(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	productID := testProductID
+	doc := Document{Currency: "EUR", Date: *day("2026-03-01")}
+
+	line := DocumentLine{ProductID: &productID, Quantity: price("2")}
+	require.NoError(t, ApplyProductPrice(ctx, svc, "tenant-1", "tenant_test", doc, &line))
+	require.NotNil(t, line.UnitPrice)
+	assert.True(t, line.UnitPrice.Equal(price("20")))
+	assert.Equal(t, "Widget", line.Description)
+	assert.Equal(t, "pcs", line.Unit)
+
+	free := decimal.Zero
+	line = DocumentLine{ProductID: &productID, Quantity: price("2"), UnitPrice: &free, Description: "Free sample"}
+	require.NoError(t, ApplyProductPrice(ctx, svc, "tenant-1", "tenant_test", doc, &line))
+	assert.True(t, line.UnitPrice.IsZero())
+	assert.Equal(t, "Free sample", line.Description)
+
+	line = DocumentLine{ProductID: &productID, Quantity: price("2")}
+	require.NoError(t, ApplyProductPrice(ctx, nil, "tenant-1", "tenant_test", doc, &line))
+	assert.Nil(t, line.UnitPrice)
+
+	doc.Currency = "USD"
+	err := ApplyProductPrice(ctx, svc, "tenant-1", "tenant_test", doc, &line)
+	require.ErrorIs(t, err, ErrNoPriceInCurrency)
+	assert.Nil(t, line.UnitPrice)
+}
+
+func TestPriceListValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+	createList(t, svc, &CreatePriceListRequest{Code: "RETAIL", Name: "Retail", IsDefault: true, ValidFrom: day("2026-01-01")})
+
+	tests := []struct {
+		name string
+		req  *CreatePriceListRequest
+		want string
+	}{
+		{name: "code", req: &CreatePriceListRequest{Name: "X"}, want: "code is required"},
+		{name: "name", req: &CreatePriceListRequest{Code: "X"}, want: "name is required"},
+		{name: "currency", req: &CreatePriceListRequest{Code: "X", Name: "X", Currency: "EURO"}, want: "3-letter code"},
+		{name: "validity", req: &CreatePriceListRequest{Code: "X", Name: "X", ValidFrom: day("2026-02-01"), ValidTo: day("2026-01-01")}, want: "valid_to cannot be before valid_from"},
+		{name: "product", req: &CreatePriceListRequest{Code: "X", Name: "X", Items: []PriceListItemRequest{{ProductID: "missing"}}}, want: "item 1: get product"},
+		{name: "negative price", req: &CreatePriceListRequest{Code: "X", Name: "X", Items: []PriceListItemRequest{{ProductID: testProductID, UnitPrice: price("-1")}}}, want: "unit_price cannot be negative"},
+		{name: "duplicate break", req: &CreatePriceListRequest{Code: "X", Name: "X", Items: []PriceListItemRequest{
+			{ProductID: testProductID, MinQuantity: price("5"), UnitPrice: price("1")},
+			{ProductID: testProductID, MinQuantity: price("5.0"), UnitPrice: price("2")},
+		}}, want: "already has a price from quantity 5"},
+		{name: "overlapping default", req: &CreatePriceListRequest{Code: "X", Name: "X", IsDefault: true, ValidTo: day("2026-01-01")}, want: "default price list RETAIL for EUR already covers these dates"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := svc.CreatePriceList(ctx, "tenant-1", "tenant_test", tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+
+	earlier := createList(t, svc, &CreatePriceListRequest{Code: "RETAIL-2025", Name: "Retail 2025", IsDefault: true, ValidTo: day("2025-12-31")})
+	inactive := false
+	updated, err := svc.UpdatePriceList(ctx, "tenant-1", "tenant_test", earlier.ID, &UpdatePriceListRequest{
+		Name:      "Retail 2025",
+		IsDefault: true,
+		IsActive:  &inactive,
+		Items:     []PriceListItemRequest{{ProductID: testProductID, UnitPrice: price("17")}},
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.IsActive)
+	require.Len(t, updated.Items, 1)
+	assert.Equal(t, earlier.ID, updated.Items[0].PriceListID)
+}
+
+func TestCustomerPricingValidation(t *testing.T) {
+	svc, _ := newTestService()
+	ctx := context.Background()
+
+	terms, err := svc.GetCustomerPricing(ctx, "tenant-1", "tenant_test", testContactID)
+	require.NoError(t, err)
+	assert.Equal(t, testContactID, terms.ContactID)
+	assert.Nil(t, terms.PriceListID)
+
+	_, err = svc.GetCustomerPricing(ctx, "tenant-1", "tenant_test", "missing")
+	assert.ErrorIs(t, err, contacts.ErrContactNotFound)
+
+	missing := "missing"
+	_, err = svc.SetCustomerPricing(ctx, "tenant-1", "tenant_test", testContactID, &SetCustomerPricingRequest{CustomerGroupID: &missing})
+	assert.ErrorIs(t, err, ErrCustomerGroupNotFound)
+	_, err = svc.SetCustomerPricing(ctx, "tenant-1", "tenant_test", testContactID, &SetCustomerPricingRequest{PriceListID: &missing})
+	assert.ErrorIs(t, err, ErrPriceListNotFound)
+	_, err = svc.SetCustomerPricing(ctx, "tenant-1", "tenant_test", testContactID, &SetCustomerPricingRequest{DiscountPercent: price("101")})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "discount_percent must be between 0 and 100")
+
+	_, err = svc.CreateCustomerGroup(ctx, "tenant-1", "tenant_test", &CustomerGroupRequest{Code: "VIP"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "name is required")
+	_, err = svc.UpdateCustomerGroup(ctx, "tenant-1", "tenant_test", "missing", &CustomerGroupRequest{Code: "VIP", Name: "VIP"})
+	assert.ErrorIs(t, err, ErrCustomerGroupNotFound)
+}
@@ -0,0 +1,217 @@
+package pricing
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PriceSource tells where a resolved price came from
+type PriceSource string
+
+const (
+	PriceSourceCustomerPriceList PriceSource = "CUSTOMER_PRICE_LIST"
+	PriceSourceGroupPriceList    PriceSource = "GROUP_PRICE_LIST"
+	PriceSourceDefaultPriceList  PriceSource = "DEFAULT_PRICE_LIST"
+	PriceSourceProduct           PriceSource = "PRODUCT"
+)
+
+// DiscountSource tells where a resolved discount came from
+type DiscountSource string
+
+const (
+	DiscountSourceCustomer DiscountSource = "CUSTOMER"
+	DiscountSourceGroup    DiscountSource = "CUSTOMER_GROUP"
+)
+
+// ErrPriceListNotFound is returned when a price list is not found
+var ErrPriceListNotFound = errors.New("price list not found")
+
+// ErrCustomerGroupNotFound is returned when a customer group is not found
+var ErrCustomerGroupNotFound = errors.New("customer group not found")
+
+// ErrNoPriceInCurrency is returned when no price list prices a product in a
+// currency other than the tenant's default currency, which the product sales
+// price is in.
+var ErrNoPriceInCurrency = errors.New("no price in currency")
+
+// PriceList is a named set of product prices in one currency. Validity dates
+// are inclusive; a missing date leaves that side open. Default lists apply to
+// every customer without a customer or group price list for the currency.
+type PriceList struct {
+	ID        string          `json:"id"`
+	TenantID  string          `json:"tenant_id"`
+	Code      string          `json:"code"`
+	Name      string          `json:"name"`
+	Currency  string          `json:"currency"`
+	ValidFrom *time.Time      `json:"valid_from,omitempty"`
+	ValidTo   *time.Time      `json:"valid_to,omitempty"`
+	IsDefault bool            `json:"is_default"`
+	IsActive  bool            `json:"is_active"`
+	Notes     string          `json:"notes,omitempty"`
+	Items     []PriceListItem `json:"items"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// PriceListItem is the unit price of a product from a minimum quantity.
+// Several items for one product form quantity breaks.
+type PriceListItem struct {
+	ID          string          `json:"id"`
+	TenantID    string          `json:"tenant_id"`
+	PriceListID string          `json:"price_list_id"`
+	ProductID   string          `json:"product_id"`
+	MinQuantity decimal.Decimal `json:"min_quantity"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+}
+
+// ValidOn reports whether the price list is active and valid on the date
+func (p *PriceList) ValidOn(date time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	day := date.Format("2006-01-02")
+	if p.ValidFrom != nil && day < p.ValidFrom.Format("2006-01-02") {
+		return false
+	}
+	if p.ValidTo != nil && day > p.ValidTo.Format("2006-01-02") {
+		return false
+	}
+	return true
+}
+
+// PriceFor returns the quantity break item of a product that applies to the
+// quantity: the item with the highest minimum quantity not above it.
+func (p *PriceList) PriceFor(productID string, quantity decimal.Decimal) *PriceListItem {
+	var best *PriceListItem
+	for i := range p.Items {
+		item := &p.Items[i]
+		if item.ProductID != productID || item.MinQuantity.GreaterThan(quantity) {
+			continue
+		}
+		if best == nil || item.MinQuantity.GreaterThan(best.MinQuantity) {
+			best = item
+		}
+	}
+	return best
+}
+
+// CustomerGroup groups customers that share a price list and a discount
+type CustomerGroup struct {
+	ID              string          `json:"id"`
+	TenantID        string          `json:"tenant_id"`
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	PriceListID     *string         `json:"price_list_id,omitempty"`
+	DiscountPercent decimal.Decimal `json:"discount_percent"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// CustomerPricing links a contact to a customer group, a customer-specific
+// price list and discount. A customer discount replaces the group discount.
+type CustomerPricing struct {
+	ContactID       string          `json:"contact_id"`
+	TenantID        string          `json:"tenant_id"`
+	CustomerGroupID *string         `json:"customer_group_id,omitempty"`
+	PriceListID     *string         `json:"price_list_id,omitempty"`
+	DiscountPercent decimal.Decimal `json:"discount_percent"`
+	UpdatedAt       time.Time       `json:"updated_at"`
+}
+
+// PriceListFilter provides filtering options for listing price lists
+type PriceListFilter struct {
+	Currency   string
+	ActiveOnly bool
+}
+
+// CreatePriceListRequest is the request to create a price list
+type CreatePriceListRequest struct {
+	Code      string                 `json:"code"`
+	Name      string                 `json:"name"`
+	Currency  string                 `json:"currency,omitempty"`
+	ValidFrom *time.Time             `json:"valid_from,omitempty"`
+	ValidTo   *time.Time             `json:"valid_to,omitempty"`
+	IsDefault bool                   `json:"is_default"`
+	Notes     string                 `json:"notes,omitempty"`
+	Items     []PriceListItemRequest `json:"items"`
+}
+
+// UpdatePriceListRequest replaces the header and items of a price list
+type UpdatePriceListRequest struct {
+	Name      string                 `json:"name"`
+	Currency  string                 `json:"currency,omitempty"`
+	ValidFrom *time.Time             `json:"valid_from,omitempty"`
+	ValidTo   *time.Time             `json:"valid_to,omitempty"`
+	IsDefault bool                   `json:"is_default"`
+	IsActive  *bool                  `json:"is_active,omitempty"`
+	Notes     string                 `json:"notes,omitempty"`
+	Items     []PriceListItemRequest `json:"items"`
+}
+
+// PriceListItemRequest is a product price in a price list request
+type PriceListItemRequest struct {
+	ProductID   string          `json:"product_id"`
+	MinQuantity decimal.Decimal `json:"min_quantity,omitempty"`
+	UnitPrice   decimal.Decimal `json:"unit_price"`
+}
+
+// CustomerGroupRequest is the request to create or update a customer group
+type CustomerGroupRequest struct {
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	PriceListID     *string         `json:"price_list_id,omitempty"`
+	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
+}
+
+// SetCustomerPricingRequest is the request to set the pricing terms of a contact
+type SetCustomerPricingRequest struct {
+	CustomerGroupID *string         `json:"customer_group_id,omitempty"`
+	PriceListID     *string         `json:"price_list_id,omitempty"`
+	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
+}
+
+// ResolvePriceRequest asks for the price of a product sold to a contact
+type ResolvePriceRequest struct {
+	ContactID string          `json:"contact_id,omitempty"`
+	ProductID string          `json:"product_id"`
+	Currency  string          `json:"currency,omitempty"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Date      time.Time       `json:"date"`
+}
+
+// ResolvedPrice is the unit price and discount that apply to a sales line
+type ResolvedPrice struct {
+	ProductID       string          `json:"product_id"`
+	ProductName     string          `json:"product_name"`
+	Unit            string          `json:"unit,omitempty"`
+	Currency        string          `json:"currency"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	UnitPrice       decimal.Decimal `json:"unit_price"`
+	Source          PriceSource     `json:"source"`
+	PriceListID     string          `json:"price_list_id,omitempty"`
+	PriceListCode   string          `json:"price_list_code,omitempty"`
+	MinQuantity     decimal.Decimal `json:"min_quantity"`
+	DiscountPercent decimal.Decimal `json:"discount_percent"`
+	DiscountSource  DiscountSource  `json:"discount_source,omitempty"`
+}
+
+// Document is the customer, currency and date a sales document line is priced for
+type Document struct {
+	ContactID string
+	Currency  string
+	Date      time.Time
+}
+
+// DocumentLine holds the fields of a quote, order or invoice line that price
+// resolution fills. A nil UnitPrice asks for the price to be resolved; an
+// explicit price, zero included, is kept.
+type DocumentLine struct {
+	ProductID       *string
+	Quantity        decimal.Decimal
+	UnitPrice       *decimal.Decimal
+	DiscountPercent decimal.Decimal
+	Description     string
+	Unit            string
+}
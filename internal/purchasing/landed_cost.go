@@ -0,0 +1,389 @@
+package purchasing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+)
+
+type landedCostTarget struct {
+	line   GoodsReceiptLine
+	weight *decimal.Decimal
+}
+
+// AllocateLandedCost allocates a purchase invoice for freight, customs duty or
+// broker fees onto received stock instead of expensing it. The invoice base
+// subtotal is spread over the selected goods receipt lines by received value,
+// quantity or weight and added to the cost of the receipt movements, so
+// FIFO, weighted-average and lot valuation use the landed cost. The share of
+// units still on hand is debited to inventory and the share of units already
+// issued to cost of goods sold, against accounts payable in one posted journal
+// entry linked to the invoice.
+func (s *Service) AllocateLandedCost(ctx context.Context, tenantID, schemaName string, req *AllocateLandedCostRequest) (*LandedCost, error) {
+	if req == nil {
+		return nil, fmt.Errorf("landed cost request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for landed costs")
+	}
+	if s.invoices == nil {
+		return nil, fmt.Errorf("invoicing service is unavailable for landed costs")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for landed costs")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to allocate landed costs")
+	}
+	invoiceID := strings.TrimSpace(req.InvoiceID)
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice_id is required")
+	}
+	payableAccountID := strings.TrimSpace(req.PayableAccountID)
+	if payableAccountID == "" {
+		return nil, fmt.Errorf("payable_account_id is required")
+	}
+	method := LandedCostAllocationMethod(strings.ToUpper(strings.TrimSpace(string(req.AllocationMethod))))
+	switch method {
+	case "":
+		method = LandedCostAllocationByValue
+	case LandedCostAllocationByValue, LandedCostAllocationByQuantity, LandedCostAllocationByWeight:
+	default:
+		return nil, fmt.Errorf("allocation_method must be VALUE, QUANTITY or WEIGHT")
+	}
+	if len(req.GoodsReceiptIDs) == 0 && len(req.Lines) == 0 {
+		return nil, fmt.Errorf("at least one goods receipt or receipt line is required")
+	}
+
+	invoice, err := s.invoices.GetByID(ctx, tenantID, schemaName, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("get invoice: %w", err)
+	}
+	if err := validateLandedCostInvoice(invoice); err != nil {
+		return nil, err
+	}
+
+	landedCost := &LandedCost{
+		ID:                       uuid.New().String(),
+		TenantID:                 tenantID,
+		InvoiceID:                invoice.ID,
+		CostDate:                 invoice.IssueDate,
+		AllocationMethod:         method,
+		Amount:                   invoice.BaseSubtotal,
+		VATAmount:                invoice.BaseVATAmount,
+		PayableAmount:            invoice.BaseSubtotal.Add(invoice.BaseVATAmount),
+		PayableAccountID:         payableAccountID,
+		VATAccountID:             strings.TrimSpace(req.VATAccountID),
+		CostOfGoodsSoldAccountID: strings.TrimSpace(req.CostOfGoodsSoldAccountID),
+		Notes:                    req.Notes,
+		CreatedBy:                userID,
+		CreatedAt:                time.Now(),
+	}
+	if landedCost.CostDate.IsZero() {
+		landedCost.CostDate = time.Now()
+	}
+	if req.PeriodLockDate != nil && !landedCost.CostDate.After(*req.PeriodLockDate) {
+		return nil, fmt.Errorf("%w through %s; invoice date %s must be later", ErrPeriodLocked, req.PeriodLockDate.Format("2006-01-02"), landedCost.CostDate.Format("2006-01-02"))
+	}
+	if !landedCost.VATAmount.IsZero() && landedCost.VATAccountID == "" {
+		return nil, fmt.Errorf("vat_account_id is required when the invoice has VAT")
+	}
+
+	targets, err := s.landedCostTargets(ctx, tenantID, schemaName, req)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := allocateLandedCostLines(method, targets, landedCost.Amount)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.validateLandedCostAccounts(ctx, schemaName, tenantID, landedCost); err != nil {
+		return nil, err
+	}
+
+	receipts := make(map[string]*GoodsReceipt)
+	stockLines := make([]inventory.ApplyLandedCostLine, 0, len(lines))
+	for i := range lines {
+		line := &lines[i]
+		receipt, ok := receipts[line.GoodsReceiptID]
+		if !ok {
+			receipt, err = s.repo.GetReceipt(ctx, schemaName, tenantID, line.GoodsReceiptID)
+			if err != nil {
+				return nil, fmt.Errorf("get goods receipt: %w", err)
+			}
+			receipts[line.GoodsReceiptID] = receipt
+		}
+		line.ID = uuid.New().String()
+		line.TenantID = tenantID
+		line.LandedCostID = landedCost.ID
+		line.WarehouseID = receipt.WarehouseID
+		stockLines = append(stockLines, inventory.ApplyLandedCostLine{
+			ReceiptSourceType: GoodsReceiptSourceType,
+			ReceiptSourceID:   receipt.ID,
+			ProductID:         line.ProductID,
+			WarehouseID:       receipt.WarehouseID,
+			Quantity:          line.Quantity,
+			LotNumber:         line.LotNumber,
+			SerialNumber:      line.SerialNumber,
+			ExpiryDate:        line.ExpiryDate,
+			Amount:            line.Amount,
+		})
+	}
+
+	// Apply the cost to stock, post its journal and store the landed cost in
+	// one transaction so a failed write leaves no revaluation behind.
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		number, err := tx.repo.GenerateLandedCostNumber(ctx, schemaName, tenantID)
+		if err != nil {
+			return fmt.Errorf("generate landed cost number: %w", err)
+		}
+		landedCost.LandedCostNumber = number
+
+		applied, err := tx.stock.ApplyLandedCost(ctx, tenantID, schemaName, &inventory.ApplyLandedCostRequest{
+			CostDate:                 landedCost.CostDate,
+			Reference:                number + " / " + invoice.InvoiceNumber,
+			SourceType:               LandedCostSourceType,
+			SourceID:                 landedCost.ID,
+			CostOfGoodsSoldAccountID: landedCost.CostOfGoodsSoldAccountID,
+			InventoryAccountID:       req.InventoryAccountID,
+			Lines:                    stockLines,
+			OffsetLines:              landedCostOffsetLines(invoice, landedCost),
+			UserID:                   userID,
+		})
+		if err != nil {
+			return fmt.Errorf("apply landed cost: %w", err)
+		}
+		for i, result := range applied.Lines {
+			lines[i].MovementID = result.MovementID
+			lines[i].OnHandQuantity = result.OnHandQuantity
+			lines[i].IssuedQuantity = result.IssuedQuantity
+			lines[i].InventoryAmount = result.InventoryAmount
+			lines[i].COGSAmount = result.COGSAmount
+			lines[i].PreviousUnitCost = result.PreviousUnitCost
+			lines[i].NewUnitCost = result.NewUnitCost
+		}
+		landedCost.Lines = lines
+		landedCost.InventoryAmount = applied.InventoryAmount
+		landedCost.COGSAmount = applied.COGSAmount
+		if applied.JournalID != "" {
+			journalID := applied.JournalID
+			landedCost.JournalEntryID = &journalID
+		}
+
+		if err := tx.repo.CreateLandedCost(ctx, schemaName, landedCost); err != nil {
+			return fmt.Errorf("create landed cost: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return landedCost, nil
+}
+
+// GetLandedCost retrieves a landed cost by ID
+func (s *Service) GetLandedCost(ctx context.Context, tenantID, schemaName, landedCostID string) (*LandedCost, error) {
+	landedCost, err := s.repo.GetLandedCost(ctx, schemaName, tenantID, landedCostID)
+	if err != nil {
+		return nil, fmt.Errorf("get landed cost: %w", err)
+	}
+	return landedCost, nil
+}
+
+// ListLandedCosts retrieves landed costs with optional filtering
+func (s *Service) ListLandedCosts(ctx context.Context, tenantID, schemaName string, filter *LandedCostFilter) ([]LandedCost, error) {
+	landedCosts, err := s.repo.ListLandedCosts(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list landed costs: %w", err)
+	}
+	return landedCosts, nil
+}
+
+func validateLandedCostInvoice(invoice *invoicing.Invoice) error {
+	if invoice.InvoiceType != invoicing.InvoiceTypePurchase {
+		return fmt.Errorf("invoice %s is not a purchase invoice", invoice.InvoiceNumber)
+	}
+	if invoice.Status == invoicing.StatusVoided {
+		return fmt.Errorf("invoice %s is voided", invoice.InvoiceNumber)
+	}
+	if invoice.JournalEntryID != nil {
+		return fmt.Errorf("invoice %s is already posted to the ledger", invoice.InvoiceNumber)
+	}
+	if !invoice.BaseSubtotal.IsPositive() {
+		return fmt.Errorf("invoice %s has no amount to allocate", invoice.InvoiceNumber)
+	}
+	return nil
+}
+
+// landedCostTargets resolves whole receipts, receipt lines and product lots
+// to goods receipt lines. A line selected more than once is allocated once.
+func (s *Service) landedCostTargets(ctx context.Context, tenantID, schemaName string, req *AllocateLandedCostRequest) ([]landedCostTarget, error) {
+	targets := make([]landedCostTarget, 0)
+	seen := make(map[string]bool)
+	add := func(line GoodsReceiptLine, weight *decimal.Decimal) {
+		if seen[line.ID] {
+			return
+		}
+		seen[line.ID] = true
+		targets = append(targets, landedCostTarget{line: line, weight: weight})
+	}
+
+	for _, receiptID := range req.GoodsReceiptIDs {
+		receiptID = strings.TrimSpace(receiptID)
+		receipt, err := s.repo.GetReceipt(ctx, schemaName, tenantID, receiptID)
+		if err != nil {
+			return nil, fmt.Errorf("get goods receipt %s: %w", receiptID, err)
+		}
+		for _, line := range receipt.Lines {
+			add(line, nil)
+		}
+	}
+
+	for i, target := range req.Lines {
+		if target.Weight != nil && target.Weight.IsNegative() {
+			return nil, fmt.Errorf("line %d: weight cannot be negative", i+1)
+		}
+		filter := &GoodsReceiptLineFilter{}
+		switch {
+		case strings.TrimSpace(target.GoodsReceiptLineID) != "":
+			filter.LineIDs = []string{strings.TrimSpace(target.GoodsReceiptLineID)}
+		case strings.TrimSpace(target.ProductID) != "" && strings.TrimSpace(target.LotNumber) != "":
+			filter.ProductID = strings.TrimSpace(target.ProductID)
+			filter.LotNumber = strings.TrimSpace(target.LotNumber)
+		default:
+			return nil, fmt.Errorf("line %d: goods_receipt_line_id or product_id with lot_number is required", i+1)
+		}
+		receiptLines, err := s.repo.ListReceiptLines(ctx, schemaName, tenantID, filter)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		if len(receiptLines) == 0 {
+			return nil, fmt.Errorf("line %d: no received stock matches the target", i+1)
+		}
+
+		// A lot weight covers every receipt of the lot; split it by quantity.
+		totalQuantity := decimal.Zero
+		for _, line := range receiptLines {
+			totalQuantity = totalQuantity.Add(line.Quantity)
+		}
+		for _, line := range receiptLines {
+			var weight *decimal.Decimal
+			if target.Weight != nil {
+				lineWeight := *target.Weight
+				if len(receiptLines) > 1 && totalQuantity.IsPositive() {
+					lineWeight = target.Weight.Mul(line.Quantity).Div(totalQuantity)
+				}
+				weight = &lineWeight
+			}
+			add(line, weight)
+		}
+	}
+	return targets, nil
+}
+
+// allocateLandedCostLines spreads the amount over the targets in proportion
+// to their basis. Amounts are rounded to cents and the rounding difference is
+// booked on the last line so the lines add up to the amount.
+func allocateLandedCostLines(method LandedCostAllocationMethod, targets []landedCostTarget, amount decimal.Decimal) ([]LandedCostLine, error) {
+	lines := make([]LandedCostLine, 0, len(targets))
+	totalBasis := decimal.Zero
+	for _, target := range targets {
+		basis := target.line.TotalCost
+		switch method {
+		case LandedCostAllocationByQuantity:
+			basis = target.line.Quantity
+		case LandedCostAllocationByWeight:
+			if target.weight == nil {
+				return nil, fmt.Errorf("weight is required for every receipt line with WEIGHT allocation")
+			}
+			basis = *target.weight
+		}
+		totalBasis = totalBasis.Add(basis)
+		lines = append(lines, LandedCostLine{
+			GoodsReceiptID:     target.line.GoodsReceiptID,
+			GoodsReceiptLineID: target.line.ID,
+			ProductID:          target.line.ProductID,
+			LotNumber:          target.line.LotNumber,
+			SerialNumber:       target.line.SerialNumber,
+			ExpiryDate:         target.line.ExpiryDate,
+			Quantity:           target.line.Quantity,
+			Basis:              basis,
+		})
+	}
+	if !totalBasis.IsPositive() {
+		return nil, fmt.Errorf("the selected receipt lines have no %s to allocate by", strings.ToLower(string(method)))
+	}
+
+	allocated := decimal.Zero
+	for i := range lines {
+		if i == len(lines)-1 {
+			lines[i].Amount = amount.Sub(allocated)
+			break
+		}
+		lines[i].Amount = amount.Mul(lines[i].Basis).Div(totalBasis).Round(2)
+		allocated = allocated.Add(lines[i].Amount)
+	}
+	return lines, nil
+}
+
+func landedCostOffsetLines(invoice *invoicing.Invoice, landedCost *LandedCost) []inventory.InventoryIssueAccountingLine {
+	description := fmt.Sprintf("Landed cost invoice %s", invoice.InvoiceNumber)
+	lines := make([]inventory.InventoryIssueAccountingLine, 0, 2)
+	if !landedCost.VATAmount.IsZero() {
+		lines = append(lines, inventory.InventoryIssueAccountingLine{
+			Role:        "VAT",
+			AccountID:   landedCost.VATAccountID,
+			Description: description + " input VAT",
+			DebitAmount: landedCost.VATAmount,
+			Currency:    "EUR",
+		})
+	}
+	return append(lines, inventory.InventoryIssueAccountingLine{
+		Role:         "PAYABLE",
+		AccountID:    landedCost.PayableAccountID,
+		Description:  description + " payable",
+		CreditAmount: landedCost.PayableAmount,
+		Currency:     "EUR",
+	})
+}
+
+func (s *Service) validateLandedCostAccounts(ctx context.Context, schemaName, tenantID string, landedCost *LandedCost) error {
+	accounts, err := s.ledger.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for landed costs: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	checks := []struct {
+		field     string
+		accountID string
+		want      accounting.AccountType
+	}{
+		{field: "payable_account_id", accountID: landedCost.PayableAccountID, want: accounting.AccountTypeLiability},
+		{field: "vat_account_id", accountID: landedCost.VATAccountID, want: accounting.AccountTypeAsset},
+	}
+	for _, check := range checks {
+		if check.accountID == "" {
+			continue
+		}
+		account, ok := byID[check.accountID]
+		if !ok {
+			return fmt.Errorf("%s was not found", check.field)
+		}
+		if account.AccountType != check.want {
+			return fmt.Errorf("%s must reference a %s account", check.field, check.want)
+		}
+	}
+	return nil
+}
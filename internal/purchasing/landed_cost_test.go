@@ -0,0 +1,198 @@
+package purchasing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+)
+
+// landedCostFixture receives the same lot twice (4 and 6 units at 5.00) and
+// books a freight invoice of 10.00 net with 22% VAT.
+func landedCostFixture(t *testing.T) (*purchasingFixture, *GoodsReceipt, *GoodsReceipt) {
+	t.Helper()
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+	first := f.receive(t, po, "4")
+	second := f.receive(t, po, "6")
+	invoice := f.addInvoice("1", "10.00")
+	invoice.InvoiceNumber = "FREIGHT-1"
+	invoice.Lines[0].ProductID = nil
+	return f, first, second
+}
+
+func TestService_AllocateLandedCostByValue(t *testing.T) {
+	f, first, second := landedCostFixture(t)
+
+	landedCost, err := f.svc.AllocateLandedCost(context.Background(), "tenant-1", "tenant_schema", &AllocateLandedCostRequest{
+		InvoiceID:                testInvoiceID,
+		GoodsReceiptIDs:          []string{first.ID, second.ID},
+		PayableAccountID:         testPayableAccountID,
+		VATAccountID:             testVATAccountID,
+		CostOfGoodsSoldAccountID: testVarianceAccountID,
+		UserID:                   "user-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "LC-00001", landedCost.LandedCostNumber)
+	assert.Equal(t, LandedCostAllocationByValue, landedCost.AllocationMethod)
+	assert.True(t, landedCost.Amount.Equal(decimal.RequireFromString("10")))
+	assert.True(t, landedCost.VATAmount.Equal(decimal.RequireFromString("2.2")))
+	assert.True(t, landedCost.PayableAmount.Equal(decimal.RequireFromString("12.2")))
+	assert.True(t, landedCost.COGSAmount.Equal(decimal.RequireFromString("2")))
+	assert.True(t, landedCost.InventoryAmount.Equal(decimal.RequireFromString("8")))
+	require.NotNil(t, landedCost.JournalEntryID)
+	assert.Equal(t, "landed-journal-1", *landedCost.JournalEntryID)
+
+	require.Len(t, landedCost.Lines, 2)
+	assert.Equal(t, first.ID, landedCost.Lines[0].GoodsReceiptID)
+	assert.Equal(t, testWarehouseID, landedCost.Lines[0].WarehouseID)
+	assert.True(t, landedCost.Lines[0].Basis.Equal(decimal.RequireFromString("20")))
+	assert.True(t, landedCost.Lines[0].Amount.Equal(decimal.RequireFromString("4")))
+	assert.True(t, landedCost.Lines[1].Amount.Equal(decimal.RequireFromString("6")))
+	assert.Equal(t, "movement-1", landedCost.Lines[0].MovementID)
+	assert.True(t, landedCost.Lines[0].IssuedQuantity.Equal(decimal.NewFromInt(1)))
+
+	require.Len(t, f.stock.landedRequests, 1)
+	stockReq := f.stock.landedRequests[0]
+	assert.Equal(t, LandedCostSourceType, stockReq.SourceType)
+	assert.Equal(t, landedCost.ID, stockReq.SourceID)
+	assert.Equal(t, "LC-00001 / FREIGHT-1", stockReq.Reference)
+	assert.Equal(t, testVarianceAccountID, stockReq.CostOfGoodsSoldAccountID)
+	assert.Equal(t, GoodsReceiptSourceType, stockReq.Lines[0].ReceiptSourceType)
+	assert.Equal(t, second.ID, stockReq.Lines[1].ReceiptSourceID)
+	assert.Equal(t, "LOT-1", stockReq.Lines[1].LotNumber)
+	require.Len(t, stockReq.OffsetLines, 2)
+	assert.Equal(t, testVATAccountID, stockReq.OffsetLines[0].AccountID)
+	assert.True(t, stockReq.OffsetLines[0].DebitAmount.Equal(decimal.RequireFromString("2.2")))
+	assert.Equal(t, testPayableAccountID, stockReq.OffsetLines[1].AccountID)
+	assert.True(t, stockReq.OffsetLines[1].CreditAmount.Equal(decimal.RequireFromString("12.2")))
+
+	require.Len(t, f.repo.landedCosts, 1)
+	listed, err := f.svc.ListLandedCosts(context.Background(), "tenant-1", "tenant_schema", &LandedCostFilter{GoodsReceiptID: second.ID})
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+	got, err := f.svc.GetLandedCost(context.Background(), "tenant-1", "tenant_schema", landedCost.ID)
+	require.NoError(t, err)
+	assert.Equal(t, landedCost.LandedCostNumber, got.LandedCostNumber)
+}
+
+func TestService_AllocateLandedCostRollsBackRevaluationWhenCostCannotBeStored(t *testing.T) {
+	f, first, second := landedCostFixture(t)
+	f.repo.landedErr = fmt.Errorf(`duplicate key value violates unique constraint "landed_costs_tenant_id_invoice_id_key"`)
+	req := &AllocateLandedCostRequest{
+		InvoiceID:                testInvoiceID,
+		GoodsReceiptIDs:          []string{first.ID, second.ID},
+		PayableAccountID:         testPayableAccountID,
+		VATAccountID:             testVATAccountID,
+		CostOfGoodsSoldAccountID: testVarianceAccountID,
+		UserID:                   "user-1",
+	}
+
+	_, err := f.svc.AllocateLandedCost(context.Background(), "tenant-1", "tenant_schema", req)
+	require.ErrorContains(t, err, "create landed cost")
+	assert.Empty(t, f.stock.landedRequests)
+	assert.Empty(t, f.repo.landedCosts)
+
+	f.repo.landedErr = nil
+	landedCost, err := f.svc.AllocateLandedCost(context.Background(), "tenant-1", "tenant_schema", req)
+	require.NoError(t, err)
+	require.Len(t, f.stock.landedRequests, 1)
+	assert.Equal(t, landedCost.ID, f.stock.landedRequests[0].SourceID)
+	require.Len(t, f.repo.landedCosts, 1)
+}
+
+func TestService_AllocateLandedCostByQuantityAndWeight(t *testing.T) {
+	f, first, second := landedCostFixture(t)
+	f.invoices.invoices[testInvoiceID].BaseSubtotal = decimal.RequireFromString("10.01")
+	f.invoices.invoices[testInvoiceID].BaseVATAmount = decimal.Zero
+
+	landedCost, err := f.svc.AllocateLandedCost(context.Background(), "tenant-1", "tenant_schema", &AllocateLandedCostRequest{
+		InvoiceID:                testInvoiceID,
+		AllocationMethod:         "quantity",
+		Lines:                    []LandedCostTargetRequest{{ProductID: testProductID, LotNumber: "LOT-1"}},
+		PayableAccountID:         testPayableAccountID,
+		CostOfGoodsSoldAccountID: testVarianceAccountID,
+		UserID:                   "user-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, landedCost.Lines, 2)
+	assert.True(t, landedCost.Lines[0].Basis.Equal(decimal.NewFromInt(4)))
+	assert.True(t, landedCost.Lines[0].Amount.Equal(decimal.RequireFromString("4")))
+	assert.True(t, landedCost.Lines[1].Amount.Equal(decimal.RequireFromString("6.01")))
+	assert.Len(t, f.stock.landedRequests[0].OffsetLines, 1)
+
+	weight := decimal.NewFromInt(30)
+	otherWeight := decimal.NewFromInt(10)
+	landedCost, err = f.svc.AllocateLandedCost(context.Background(), "tenant-1", "tenant_schema", &AllocateLandedCostRequest{
+		InvoiceID:        testInvoiceID,
+		AllocationMethod: LandedCostAllocationByWeight,
+		Lines: []LandedCostTargetRequest{
+			{GoodsReceiptLineID: first.Lines[0].ID, Weight: &weight},
+			{GoodsReceiptLineID: second.Lines[0].ID, Weight: &otherWeight},
+		},
+		PayableAccountID:         testPayableAccountID,
+		CostOfGoodsSoldAccountID: testVarianceAccountID,
+		UserID:                   "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, landedCost.Lines[0].Amount.Equal(decimal.RequireFromString("7.51")))
+	assert.True(t, landedCost.Lines[1].Amount.Equal(decimal.RequireFromString("2.5")))
+}
+
+func TestService_AllocateLandedCostValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*invoicing.Invoice, *AllocateLandedCostRequest)
+		want   string
+	}{
+		{name: "missing user", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.UserID = "" }, want: "user id is required"},
+		{name: "missing payable", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.PayableAccountID = "" }, want: "payable_account_id is required"},
+		{name: "method", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.AllocationMethod = "VOLUME" }, want: "allocation_method must be"},
+		{name: "no targets", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.GoodsReceiptIDs = nil }, want: "at least one goods receipt"},
+		{name: "sales invoice", mutate: func(inv *invoicing.Invoice, _ *AllocateLandedCostRequest) {
+			inv.InvoiceType = invoicing.InvoiceTypeSales
+		}, want: "is not a purchase invoice"},
+		{name: "already posted", mutate: func(inv *invoicing.Invoice, _ *AllocateLandedCostRequest) {
+			journalID := "journal-1"
+			inv.JournalEntryID = &journalID
+		}, want: "already posted"},
+		{name: "locked period", mutate: func(inv *invoicing.Invoice, req *AllocateLandedCostRequest) {
+			lockDate := inv.IssueDate
+			req.PeriodLockDate = &lockDate
+		}, want: "period locked through"},
+		{name: "missing VAT account", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.VATAccountID = "" }, want: "vat_account_id is required"},
+		{name: "unknown receipt", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.GoodsReceiptIDs = []string{"missing"} }, want: "goods receipt not found"},
+		{name: "unmatched lot", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) {
+			req.GoodsReceiptIDs = nil
+			req.Lines = []LandedCostTargetRequest{{ProductID: testProductID, LotNumber: "LOT-9"}}
+		}, want: "no received stock matches"},
+		{name: "weight required", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) {
+			req.AllocationMethod = LandedCostAllocationByWeight
+		}, want: "weight is required"},
+		{name: "payable type", mutate: func(_ *invoicing.Invoice, req *AllocateLandedCostRequest) { req.PayableAccountID = testVATAccountID }, want: "payable_account_id must reference a LIABILITY account"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, first, _ := landedCostFixture(t)
+			req := &AllocateLandedCostRequest{
+				InvoiceID:        testInvoiceID,
+				GoodsReceiptIDs:  []string{first.ID},
+				PayableAccountID: testPayableAccountID,
+				VATAccountID:     testVATAccountID,
+				UserID:           "user-1",
+			}
+			tt.mutate(f.invoices.invoices[testInvoiceID], req)
+
+			_, err := f.svc.AllocateLandedCost(context.Background(), "tenant-1", "tenant_schema", req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, f.stock.landedRequests)
+			assert.Empty(t, f.repo.landedCosts)
+		})
+	}
+}
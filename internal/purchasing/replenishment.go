@@ -0,0 +1,471 @@
+package purchasing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+const (
+	defaultReplenishmentVelocityDays = 90
+	defaultReplenishmentCoverageDays = 30
+	maxReplenishmentDays             = 730
+)
+
+type stockPlanner interface {
+	ListProducts(ctx context.Context, tenantID, schemaName string, filter *inventory.ProductFilter) ([]inventory.Product, error)
+	ListWarehouses(ctx context.Context, tenantID, schemaName string, activeOnly bool) ([]inventory.Warehouse, error)
+	GetStockLevels(ctx context.Context, tenantID, schemaName, productID string) ([]inventory.StockLevel, error)
+	GetMovements(ctx context.Context, tenantID, schemaName, productID string) ([]inventory.InventoryMovement, error)
+}
+
+// ReplenishmentRequest selects the stock positions to plan and the windows
+// used for consumption velocity and order coverage.
+type ReplenishmentRequest struct {
+	WarehouseID  string    `json:"warehouse_id,omitempty"`
+	SupplierID   string    `json:"supplier_id,omitempty"`
+	AsOfDate     time.Time `json:"as_of_date,omitempty"`
+	VelocityDays int       `json:"velocity_days,omitempty"`
+	CoverageDays int       `json:"coverage_days,omitempty"`
+}
+
+// ReplenishmentReport proposes purchase quantities for stock positions that
+// are projected to fall below their reorder level, grouped by supplier and
+// warehouse.
+type ReplenishmentReport struct {
+	AsOfDate      time.Time               `json:"as_of_date"`
+	WarehouseID   string                  `json:"warehouse_id,omitempty"`
+	SupplierID    string                  `json:"supplier_id,omitempty"`
+	VelocityDays  int                     `json:"velocity_days"`
+	CoverageDays  int                     `json:"coverage_days"`
+	Proposals     []ReplenishmentProposal `json:"proposals"`
+	LineCount     int                     `json:"line_count"`
+	EstimatedCost decimal.Decimal         `json:"estimated_cost"`
+}
+
+// ReplenishmentProposal is one supplier's proposed order into one warehouse.
+// Products without a supplier are grouped under an empty supplier ID.
+type ReplenishmentProposal struct {
+	SupplierID    string              `json:"supplier_id,omitempty"`
+	WarehouseID   string              `json:"warehouse_id"`
+	WarehouseCode string              `json:"warehouse_code,omitempty"`
+	WarehouseName string              `json:"warehouse_name,omitempty"`
+	LeadTimeDays  int                 `json:"lead_time_days"`
+	Lines         []ReplenishmentLine `json:"lines"`
+	EstimatedCost decimal.Decimal     `json:"estimated_cost"`
+}
+
+// ReplenishmentLine explains the proposed order quantity for one product in
+// one warehouse. Available stock is on hand minus reservations; projected
+// stock adds quantities still open on purchase orders.
+type ReplenishmentLine struct {
+	ProductID         string          `json:"product_id"`
+	ProductCode       string          `json:"product_code"`
+	ProductName       string          `json:"product_name"`
+	Unit              string          `json:"unit,omitempty"`
+	SupplierID        string          `json:"supplier_id,omitempty"`
+	WarehouseID       string          `json:"warehouse_id"`
+	OnHand            decimal.Decimal `json:"on_hand"`
+	Reserved          decimal.Decimal `json:"reserved"`
+	Available         decimal.Decimal `json:"available"`
+	Incoming          decimal.Decimal `json:"incoming"`
+	Projected         decimal.Decimal `json:"projected"`
+	MinStockLevel     decimal.Decimal `json:"min_stock_level"`
+	ReorderPoint      decimal.Decimal `json:"reorder_point"`
+	LeadTimeDays      int             `json:"lead_time_days"`
+	Consumption       decimal.Decimal `json:"consumption"`
+	DailyUsage        decimal.Decimal `json:"daily_usage"`
+	ReorderLevel      decimal.Decimal `json:"reorder_level"`
+	TargetLevel       decimal.Decimal `json:"target_level"`
+	SuggestedQuantity decimal.Decimal `json:"suggested_quantity"`
+	UnitPrice         decimal.Decimal `json:"unit_price"`
+	VATRate           decimal.Decimal `json:"vat_rate"`
+	EstimatedCost     decimal.Decimal `json:"estimated_cost"`
+	BelowMinimum      bool            `json:"below_minimum"`
+}
+
+// CreateReplenishmentOrdersRequest turns a replenishment report into draft
+// purchase orders, one per supplier and warehouse.
+type CreateReplenishmentOrdersRequest struct {
+	ReplenishmentRequest
+	OrderDate time.Time `json:"order_date,omitempty"`
+	UserID    string    `json:"-"`
+}
+
+// ReplenishmentOrdersResult lists the draft purchase orders created from a
+// replenishment report and the lines left out because the product has no supplier.
+type ReplenishmentOrdersResult struct {
+	PurchaseOrders  []PurchaseOrder     `json:"purchase_orders"`
+	UnassignedLines []ReplenishmentLine `json:"unassigned_lines"`
+}
+
+// LowStockLines returns the lines whose available stock is below the
+// product's minimum stock level.
+func (r *ReplenishmentReport) LowStockLines() []ReplenishmentLine {
+	lines := make([]ReplenishmentLine, 0)
+	for _, proposal := range r.Proposals {
+		for _, line := range proposal.Lines {
+			if line.BelowMinimum {
+				lines = append(lines, line)
+			}
+		}
+	}
+	return lines
+}
+
+// WithStockPlanner sets the inventory reader used for replenishment reports.
+func (s *Service) WithStockPlanner(planner stockPlanner) *Service {
+	s.planner = planner
+	return s
+}
+
+// GetReplenishmentReport compares each tracked product's available and
+// incoming stock per warehouse with its reorder level and proposes an order
+// quantity when the projected stock falls below it. The reorder level is the
+// product's reorder point, or its minimum stock level plus the demand expected
+// during the supplier lead time when that is higher. Demand is the average
+// daily quantity issued from the warehouse over the velocity window, and the
+// proposed quantity restores stock to the reorder level plus coverage-window
+// demand, rounded up to whole units.
+func (s *Service) GetReplenishmentReport(ctx context.Context, tenantID, schemaName string, req *ReplenishmentRequest) (*ReplenishmentReport, error) {
+	if s.planner == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for replenishment planning")
+	}
+	if req == nil {
+		req = &ReplenishmentRequest{}
+	}
+	velocityDays, err := replenishmentDays(req.VelocityDays, defaultReplenishmentVelocityDays, "velocity_days")
+	if err != nil {
+		return nil, err
+	}
+	coverageDays, err := replenishmentDays(req.CoverageDays, defaultReplenishmentCoverageDays, "coverage_days")
+	if err != nil {
+		return nil, err
+	}
+	asOfDate := req.AsOfDate
+	if asOfDate.IsZero() {
+		asOfDate = time.Now()
+	}
+	asOfDate = time.Date(asOfDate.Year(), asOfDate.Month(), asOfDate.Day(), 0, 0, 0, 0, time.UTC)
+	windowEnd := asOfDate.AddDate(0, 0, 1)
+	windowStart := windowEnd.AddDate(0, 0, -velocityDays)
+
+	report := &ReplenishmentReport{
+		AsOfDate:      asOfDate,
+		WarehouseID:   strings.TrimSpace(req.WarehouseID),
+		SupplierID:    strings.TrimSpace(req.SupplierID),
+		VelocityDays:  velocityDays,
+		CoverageDays:  coverageDays,
+		Proposals:     []ReplenishmentProposal{},
+		EstimatedCost: decimal.Zero,
+	}
+
+	warehouses, err := s.planner.ListWarehouses(ctx, tenantID, schemaName, false)
+	if err != nil {
+		return nil, fmt.Errorf("list warehouses: %w", err)
+	}
+	warehousesByID := make(map[string]inventory.Warehouse, len(warehouses))
+	for _, warehouse := range warehouses {
+		warehousesByID[warehouse.ID] = warehouse
+	}
+	if report.WarehouseID != "" {
+		if _, ok := warehousesByID[report.WarehouseID]; !ok {
+			return nil, fmt.Errorf("warehouse %s not found", report.WarehouseID)
+		}
+	}
+
+	incoming, err := s.incomingStock(ctx, tenantID, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := s.planner.ListProducts(ctx, tenantID, schemaName, &inventory.ProductFilter{ProductType: inventory.ProductTypeGoods})
+	if err != nil {
+		return nil, fmt.Errorf("list products: %w", err)
+	}
+
+	proposals := make(map[string]*ReplenishmentProposal)
+	for _, product := range products {
+		if !product.IsActive || !product.TrackInventory {
+			continue
+		}
+		supplierID := strings.TrimSpace(product.SupplierID)
+		if report.SupplierID != "" && supplierID != report.SupplierID {
+			continue
+		}
+
+		positions, err := s.replenishmentPositions(ctx, tenantID, schemaName, product, report.WarehouseID, incoming, windowStart, windowEnd)
+		if err != nil {
+			return nil, err
+		}
+		for _, position := range positions {
+			line, ok := replenishmentLine(product, position, velocityDays, coverageDays)
+			if !ok {
+				continue
+			}
+			key := supplierID + "|" + line.WarehouseID
+			proposal, exists := proposals[key]
+			if !exists {
+				warehouse := warehousesByID[line.WarehouseID]
+				proposal = &ReplenishmentProposal{
+					SupplierID:    supplierID,
+					WarehouseID:   line.WarehouseID,
+					WarehouseCode: warehouse.Code,
+					WarehouseName: warehouse.Name,
+					EstimatedCost: decimal.Zero,
+				}
+				proposals[key] = proposal
+			}
+			proposal.Lines = append(proposal.Lines, line)
+			proposal.EstimatedCost = proposal.EstimatedCost.Add(line.EstimatedCost)
+			if line.LeadTimeDays > proposal.LeadTimeDays {
+				proposal.LeadTimeDays = line.LeadTimeDays
+			}
+		}
+	}
+
+	for _, proposal := range proposals {
+		sort.Slice(proposal.Lines, func(i, j int) bool {
+			return proposal.Lines[i].ProductCode < proposal.Lines[j].ProductCode
+		})
+		report.Proposals = append(report.Proposals, *proposal)
+		report.LineCount += len(proposal.Lines)
+		report.EstimatedCost = report.EstimatedCost.Add(proposal.EstimatedCost)
+	}
+	sort.Slice(report.Proposals, func(i, j int) bool {
+		left, right := report.Proposals[i], report.Proposals[j]
+		if left.WarehouseCode != right.WarehouseCode {
+			return left.WarehouseCode < right.WarehouseCode
+		}
+		if left.WarehouseID != right.WarehouseID {
+			return left.WarehouseID < right.WarehouseID
+		}
+		if (left.SupplierID == "") != (right.SupplierID == "") {
+			return right.SupplierID == ""
+		}
+		return left.SupplierID < right.SupplierID
+	})
+	return report, nil
+}
+
+// CreateReplenishmentOrders creates a draft purchase order for every supplier
+// proposal in the replenishment report. Lines of products without a supplier
+// are returned unassigned.
+func (s *Service) CreateReplenishmentOrders(ctx context.Context, tenantID, schemaName string, req *CreateReplenishmentOrdersRequest) (*ReplenishmentOrdersResult, error) {
+	if req == nil {
+		req = &CreateReplenishmentOrdersRequest{}
+	}
+	report, err := s.GetReplenishmentReport(ctx, tenantID, schemaName, &req.ReplenishmentRequest)
+	if err != nil {
+		return nil, err
+	}
+	orderDate := req.OrderDate
+	if orderDate.IsZero() {
+		orderDate = report.AsOfDate
+	}
+
+	result := &ReplenishmentOrdersResult{
+		PurchaseOrders:  []PurchaseOrder{},
+		UnassignedLines: []ReplenishmentLine{},
+	}
+	for _, proposal := range report.Proposals {
+		if proposal.SupplierID == "" {
+			result.UnassignedLines = append(result.UnassignedLines, proposal.Lines...)
+			continue
+		}
+		poReq := &CreatePurchaseOrderRequest{
+			ContactID:   proposal.SupplierID,
+			WarehouseID: proposal.WarehouseID,
+			OrderDate:   orderDate,
+			Notes:       fmt.Sprintf("Replenishment proposal as of %s", report.AsOfDate.Format("2006-01-02")),
+			UserID:      req.UserID,
+		}
+		if proposal.LeadTimeDays > 0 {
+			expectedDate := orderDate.AddDate(0, 0, proposal.LeadTimeDays)
+			poReq.ExpectedDate = &expectedDate
+		}
+		for _, line := range proposal.Lines {
+			poReq.Lines = append(poReq.Lines, CreatePurchaseOrderLineRequest{
+				ProductID: line.ProductID,
+				Quantity:  line.SuggestedQuantity,
+				Unit:      line.Unit,
+				UnitPrice: line.UnitPrice,
+				VATRate:   line.VATRate,
+			})
+		}
+		po, err := s.Create(ctx, tenantID, schemaName, poReq)
+		if err != nil {
+			return nil, fmt.Errorf("supplier %s warehouse %s: %w", proposal.SupplierID, proposal.WarehouseID, err)
+		}
+		result.PurchaseOrders = append(result.PurchaseOrders, *po)
+	}
+	return result, nil
+}
+
+type replenishmentPosition struct {
+	warehouseID string
+	onHand      decimal.Decimal
+	reserved    decimal.Decimal
+	incoming    decimal.Decimal
+	consumption decimal.Decimal
+}
+
+// incomingStock sums the quantities still open on draft, approved and
+// partially received purchase orders per product and warehouse.
+func (s *Service) incomingStock(ctx context.Context, tenantID, schemaName string) (map[string]decimal.Decimal, error) {
+	openOrders, err := s.repo.ListOpen(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("list open purchase orders: %w", err)
+	}
+	incoming := make(map[string]decimal.Decimal)
+	for _, po := range openOrders {
+		for i := range po.Lines {
+			open := po.Lines[i].OpenReceiptQuantity()
+			if !open.IsPositive() {
+				continue
+			}
+			key := replenishmentPositionKey(po.Lines[i].ProductID, po.WarehouseID)
+			incoming[key] = incoming[key].Add(open)
+		}
+	}
+	return incoming, nil
+}
+
+// replenishmentPositions collects a product's stock, incoming quantity and
+// consumption per warehouse. Without a warehouse filter only warehouses that
+// hold, expect or issued the product are returned.
+func (s *Service) replenishmentPositions(
+	ctx context.Context,
+	tenantID, schemaName string,
+	product inventory.Product,
+	warehouseID string,
+	incoming map[string]decimal.Decimal,
+	windowStart, windowEnd time.Time,
+) ([]replenishmentPosition, error) {
+	positions := make(map[string]*replenishmentPosition)
+	position := func(id string) *replenishmentPosition {
+		if existing, ok := positions[id]; ok {
+			return existing
+		}
+		created := &replenishmentPosition{warehouseID: id}
+		positions[id] = created
+		return created
+	}
+	if warehouseID != "" {
+		position(warehouseID)
+	}
+
+	levels, err := s.planner.GetStockLevels(ctx, tenantID, schemaName, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("product %s: %w", product.Code, err)
+	}
+	for _, level := range levels {
+		if warehouseID != "" && level.WarehouseID != warehouseID {
+			continue
+		}
+		current := position(level.WarehouseID)
+		current.onHand = current.onHand.Add(level.Quantity)
+		current.reserved = current.reserved.Add(level.ReservedQty)
+	}
+
+	movements, err := s.planner.GetMovements(ctx, tenantID, schemaName, product.ID)
+	if err != nil {
+		return nil, fmt.Errorf("product %s: %w", product.Code, err)
+	}
+	for _, movement := range movements {
+		if movement.MovementType != inventory.MovementTypeOut || strings.TrimSpace(movement.ToWarehouseID) != "" {
+			continue
+		}
+		if movement.MovementDate.Before(windowStart) || !movement.MovementDate.Before(windowEnd) {
+			continue
+		}
+		if warehouseID != "" && movement.WarehouseID != warehouseID {
+			continue
+		}
+		current := position(movement.WarehouseID)
+		current.consumption = current.consumption.Add(movement.Quantity.Abs())
+	}
+
+	for key, quantity := range incoming {
+		productID, positionWarehouseID, _ := strings.Cut(key, "|")
+		if productID != product.ID || (warehouseID != "" && positionWarehouseID != warehouseID) {
+			continue
+		}
+		current := position(positionWarehouseID)
+		current.incoming = current.incoming.Add(quantity)
+	}
+
+	result := make([]replenishmentPosition, 0, len(positions))
+	for _, current := range positions {
+		result = append(result, *current)
+	}
+	return result, nil
+}
+
+// replenishmentLine evaluates one stock position and reports whether an order
+// is proposed for it.
+func replenishmentLine(product inventory.Product, position replenishmentPosition, velocityDays, coverageDays int) (ReplenishmentLine, bool) {
+	available := position.onHand.Sub(position.reserved)
+	projected := available.Add(position.incoming)
+	dailyUsage := position.consumption.Div(decimal.NewFromInt(int64(velocityDays))).Round(6)
+	leadTimeDays := product.LeadTimeDays
+	if leadTimeDays < 0 {
+		leadTimeDays = 0
+	}
+
+	reorderLevel := decimal.Max(product.ReorderPoint, product.MinStockLevel.Add(dailyUsage.Mul(decimal.NewFromInt(int64(leadTimeDays)))))
+	if !reorderLevel.IsPositive() || !projected.LessThan(reorderLevel) {
+		return ReplenishmentLine{}, false
+	}
+	targetLevel := reorderLevel.Add(dailyUsage.Mul(decimal.NewFromInt(int64(coverageDays))))
+	suggested := targetLevel.Sub(projected).Ceil()
+	if !suggested.IsPositive() {
+		return ReplenishmentLine{}, false
+	}
+
+	return ReplenishmentLine{
+		ProductID:         product.ID,
+		ProductCode:       product.Code,
+		ProductName:       product.Name,
+		Unit:              product.Unit,
+		SupplierID:        strings.TrimSpace(product.SupplierID),
+		WarehouseID:       position.warehouseID,
+		OnHand:            position.onHand,
+		Reserved:          position.reserved,
+		Available:         available,
+		Incoming:          position.incoming,
+		Projected:         projected,
+		MinStockLevel:     product.MinStockLevel,
+		ReorderPoint:      product.ReorderPoint,
+		LeadTimeDays:      leadTimeDays,
+		Consumption:       position.consumption,
+		DailyUsage:        dailyUsage,
+		ReorderLevel:      reorderLevel,
+		TargetLevel:       targetLevel,
+		SuggestedQuantity: suggested,
+		UnitPrice:         product.PurchasePrice,
+		VATRate:           product.VATRate,
+		EstimatedCost:     suggested.Mul(product.PurchasePrice).Round(2),
+		BelowMinimum:      available.LessThan(product.MinStockLevel),
+	}, true
+}
+
+func replenishmentPositionKey(productID, warehouseID string) string {
+	return productID + "|" + warehouseID
+}
+
+func replenishmentDays(value, defaultValue int, field string) (int, error) {
+	if value == 0 {
+		return defaultValue, nil
+	}
+	if value < 0 || value > maxReplenishmentDays {
+		return 0, fmt.Errorf("%s must be between 1 and %d", field, maxReplenishmentDays)
+	}
+	return value, nil
+}
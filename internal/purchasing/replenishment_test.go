@@ -0,0 +1,195 @@
+package purchasing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+const (
+	testSecondProductID   = "99999999-9999-4999-8999-999999999999"
+	testSecondWarehouseID = "aaaaaaaa-aaaa-4aaa-8aaa-aaaaaaaaaaaa"
+)
+
+type fakePlanner struct {
+	products   []inventory.Product
+	warehouses []inventory.Warehouse
+	levels     map[string][]inventory.StockLevel
+	movements  map[string][]inventory.InventoryMovement
+}
+
+func (f *fakePlanner) ListProducts(context.Context, string, string, *inventory.ProductFilter) ([]inventory.Product, error) {
+	return f.products, nil
+}
+
+func (f *fakePlanner) ListWarehouses(context.Context, string, string, bool) ([]inventory.Warehouse, error) {
+	return f.warehouses, nil
+}
+
+func (f *fakePlanner) GetStockLevels(_ context.Context, _, _, productID string) ([]inventory.StockLevel, error) {
+	return f.levels[productID], nil
+}
+
+func (f *fakePlanner) GetMovements(_ context.Context, _, _, productID string) ([]inventory.InventoryMovement, error) {
+	return f.movements[productID], nil
+}
+
+var replenishmentAsOf = time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+
+func newReplenishmentFixture() (*purchasingFixture, *fakePlanner) {
+	f := newPurchasingFixture()
+	widget := f.stock.products[testProductID]
+	widget.IsActive = true
+	widget.SupplierID = testSupplierID
+	widget.MinStockLevel = decimal.NewFromInt(5)
+	widget.ReorderPoint = decimal.NewFromInt(10)
+	widget.LeadTimeDays = 10
+	widget.PurchasePrice = decimal.RequireFromString("4.50")
+	widget.VATRate = decimal.NewFromInt(22)
+	gadget := &inventory.Product{
+		ID:             testSecondProductID,
+		Code:           "SKU-2",
+		Name:           "Gadget",
+		Unit:           "pcs",
+		ProductType:    inventory.ProductTypeGoods,
+		TrackInventory: true,
+		IsActive:       true,
+		ReorderPoint:   decimal.NewFromInt(3),
+	}
+	f.stock.products[testSecondProductID] = gadget
+
+	planner := &fakePlanner{
+		products: []inventory.Product{*widget, *gadget},
+		warehouses: []inventory.Warehouse{
+			{ID: testWarehouseID, Code: "MAIN", Name: "Main"},
+			{ID: testSecondWarehouseID, Code: "SIDE", Name: "Side"},
+		},
+		levels: map[string][]inventory.StockLevel{
+			testProductID: {
+				{ProductID: testProductID, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(8), ReservedQty: decimal.NewFromInt(4)},
+				{ProductID: testProductID, WarehouseID: testSecondWarehouseID, Quantity: decimal.NewFromInt(50)},
+			},
+			testSecondProductID: {
+				{ProductID: testSecondProductID, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(1)},
+			},
+		},
+		movements: map[string][]inventory.InventoryMovement{
+			testProductID: {
+				{MovementType: inventory.MovementTypeOut, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(60), MovementDate: replenishmentAsOf.AddDate(0, 0, -10)},
+				{MovementType: inventory.MovementTypeOut, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(30), MovementDate: replenishmentAsOf.AddDate(0, 0, -89)},
+				{MovementType: inventory.MovementTypeOut, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(500), MovementDate: replenishmentAsOf.AddDate(0, 0, -120)},
+				{MovementType: inventory.MovementTypeOut, WarehouseID: testWarehouseID, ToWarehouseID: testSecondWarehouseID, Quantity: decimal.NewFromInt(40), MovementDate: replenishmentAsOf.AddDate(0, 0, -5)},
+				{MovementType: inventory.MovementTypeIn, WarehouseID: testWarehouseID, Quantity: decimal.NewFromInt(100), MovementDate: replenishmentAsOf.AddDate(0, 0, -5)},
+			},
+		},
+	}
+	f.svc.WithStockPlanner(planner)
+	return f, planner
+}
+
+func TestService_GetReplenishmentReportProposesOrdersBySupplier(t *testing.T) {
+	f, _ := newReplenishmentFixture()
+	po := f.approvedOrder(t)
+	f.receive(t, po, "4")
+
+	report, err := f.svc.GetReplenishmentReport(context.Background(), "tenant-1", "tenant_schema", &ReplenishmentRequest{AsOfDate: replenishmentAsOf})
+	require.NoError(t, err)
+
+	assert.Equal(t, 90, report.VelocityDays)
+	assert.Equal(t, 30, report.CoverageDays)
+	require.Len(t, report.Proposals, 2)
+	assert.Equal(t, 2, report.LineCount)
+
+	supplierProposal := report.Proposals[0]
+	assert.Equal(t, testSupplierID, supplierProposal.SupplierID)
+	assert.Equal(t, "MAIN", supplierProposal.WarehouseCode)
+	assert.Equal(t, 10, supplierProposal.LeadTimeDays)
+	require.Len(t, supplierProposal.Lines, 1)
+	widget := supplierProposal.Lines[0]
+	// 90 issued in the 90-day window, transfers and older issues excluded.
+	assert.True(t, widget.Consumption.Equal(decimal.NewFromInt(90)), widget.Consumption.String())
+	assert.True(t, widget.DailyUsage.Equal(decimal.NewFromInt(1)))
+	assert.True(t, widget.Available.Equal(decimal.NewFromInt(4)))
+	assert.True(t, widget.Incoming.Equal(decimal.NewFromInt(6)))
+	assert.True(t, widget.Projected.Equal(decimal.NewFromInt(10)))
+	// Reorder level is min stock 5 plus 10 days of lead-time demand.
+	assert.True(t, widget.ReorderLevel.Equal(decimal.NewFromInt(15)))
+	assert.True(t, widget.TargetLevel.Equal(decimal.NewFromInt(45)))
+	assert.True(t, widget.SuggestedQuantity.Equal(decimal.NewFromInt(35)))
+	assert.True(t, widget.EstimatedCost.Equal(decimal.RequireFromString("157.50")))
+	assert.True(t, widget.BelowMinimum)
+
+	unassigned := report.Proposals[1]
+	assert.Empty(t, unassigned.SupplierID)
+	require.Len(t, unassigned.Lines, 1)
+	assert.Equal(t, "SKU-2", unassigned.Lines[0].ProductCode)
+	assert.True(t, unassigned.Lines[0].SuggestedQuantity.Equal(decimal.NewFromInt(2)))
+	assert.False(t, unassigned.Lines[0].BelowMinimum)
+
+	assert.Len(t, report.LowStockLines(), 1)
+}
+
+func TestService_GetReplenishmentReportFilters(t *testing.T) {
+	f, _ := newReplenishmentFixture()
+	ctx := context.Background()
+
+	// An explicit warehouse is planned even for products it has never held.
+	report, err := f.svc.GetReplenishmentReport(ctx, "tenant-1", "tenant_schema", &ReplenishmentRequest{AsOfDate: replenishmentAsOf, WarehouseID: testSecondWarehouseID})
+	require.NoError(t, err)
+	require.Len(t, report.Proposals, 1)
+	require.Len(t, report.Proposals[0].Lines, 1)
+	assert.Equal(t, "SKU-2", report.Proposals[0].Lines[0].ProductCode)
+	assert.Equal(t, "SIDE", report.Proposals[0].WarehouseCode)
+	assert.True(t, report.Proposals[0].Lines[0].SuggestedQuantity.Equal(decimal.NewFromInt(3)))
+
+	report, err = f.svc.GetReplenishmentReport(ctx, "tenant-1", "tenant_schema", &ReplenishmentRequest{AsOfDate: replenishmentAsOf, SupplierID: testSupplierID})
+	require.NoError(t, err)
+	require.Len(t, report.Proposals, 1)
+	assert.Equal(t, testSupplierID, report.Proposals[0].SupplierID)
+
+	_, err = f.svc.GetReplenishmentReport(ctx, "tenant-1", "tenant_schema", &ReplenishmentRequest{WarehouseID: "missing"})
+	assert.ErrorContains(t, err, "warehouse missing not found")
+
+	_, err = f.svc.GetReplenishmentReport(ctx, "tenant-1", "tenant_schema", &ReplenishmentRequest{VelocityDays: -1})
+	assert.ErrorContains(t, err, "velocity_days must be between 1 and 730")
+
+	_, err = NewServiceWithRepository(newMockRepository(), nil, nil, nil).GetReplenishmentReport(ctx, "tenant-1", "tenant_schema", nil)
+	assert.ErrorContains(t, err, "inventory service is unavailable")
+}
+
+func TestService_CreateReplenishmentOrders(t *testing.T) {
+	f, _ := newReplenishmentFixture()
+	orderDate := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	result, err := f.svc.CreateReplenishmentOrders(context.Background(), "tenant-1", "tenant_schema", &CreateReplenishmentOrdersRequest{
+		ReplenishmentRequest: ReplenishmentRequest{AsOfDate: replenishmentAsOf},
+		OrderDate:            orderDate,
+		UserID:               "user-1",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, result.PurchaseOrders, 1)
+	po := result.PurchaseOrders[0]
+	assert.Equal(t, PurchaseOrderStatusDraft, po.Status)
+	assert.Equal(t, testSupplierID, po.ContactID)
+	assert.Equal(t, testWarehouseID, po.WarehouseID)
+	require.NotNil(t, po.ExpectedDate)
+	assert.Equal(t, orderDate.AddDate(0, 0, 10), *po.ExpectedDate)
+	require.Len(t, po.Lines, 1)
+	assert.True(t, po.Lines[0].Quantity.Equal(decimal.NewFromInt(41)), po.Lines[0].Quantity.String())
+	assert.True(t, po.Lines[0].UnitPrice.Equal(decimal.RequireFromString("4.50")))
+
+	require.Len(t, result.UnassignedLines, 1)
+	assert.Equal(t, testSecondProductID, result.UnassignedLines[0].ProductID)
+
+	// The draft order now counts as incoming stock.
+	report, err := f.svc.GetReplenishmentReport(context.Background(), "tenant-1", "tenant_schema", &ReplenishmentRequest{AsOfDate: replenishmentAsOf, SupplierID: testSupplierID})
+	require.NoError(t, err)
+	assert.Empty(t, report.Proposals)
+}
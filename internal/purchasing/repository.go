@@ -0,0 +1,1128 @@
+package purchasing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gorm.io/gorm"
+)
+
+// Repository defines the contract for purchase order data access
+type Repository interface {
+	Create(ctx context.Context, schemaName string, po *PurchaseOrder) error
+	GetByID(ctx context.Context, schemaName, tenantID, poID string) (*PurchaseOrder, error)
+	List(ctx context.Context, schemaName, tenantID string, filter *PurchaseOrderFilter) ([]PurchaseOrder, error)
+	ListOpen(ctx context.Context, schemaName, tenantID string) ([]PurchaseOrder, error)
+	UpdateStatus(ctx context.Context, schemaName, tenantID, poID string, status PurchaseOrderStatus, userID string) error
+	GenerateNumber(ctx context.Context, schemaName, tenantID string) (string, error)
+	GenerateReceiptNumber(ctx context.Context, schemaName, tenantID string) (string, error)
+	CreateReceipt(ctx context.Context, schemaName string, receipt *GoodsReceipt, status PurchaseOrderStatus) error
+	ListReceipts(ctx context.Context, schemaName, tenantID, poID string) ([]GoodsReceipt, error)
+	CreateInvoiceMatch(ctx context.Context, schemaName string, match *PurchaseInvoiceMatch, status PurchaseOrderStatus) error
+	ListInvoiceMatches(ctx context.Context, schemaName, tenantID, poID string) ([]PurchaseInvoiceMatch, error)
+	GetReceipt(ctx context.Context, schemaName, tenantID, receiptID string) (*GoodsReceipt, error)
+	ListReceiptLines(ctx context.Context, schemaName, tenantID string, filter *GoodsReceiptLineFilter) ([]GoodsReceiptLine, error)
+	GenerateLandedCostNumber(ctx context.Context, schemaName, tenantID string) (string, error)
+	CreateLandedCost(ctx context.Context, schemaName string, landedCost *LandedCost) error
+	GetLandedCost(ctx context.Context, schemaName, tenantID, landedCostID string) (*LandedCost, error)
+	ListLandedCosts(ctx context.Context, schemaName, tenantID string, filter *LandedCostFilter) ([]LandedCost, error)
+}
+
+// LedgerTransactionRepository runs purchasing writes, stock movements and their
+// journal postings in one database transaction. Repositories that do not
+// implement it write them one at a time.
+type LedgerTransactionRepository interface {
+	WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockReceiver, ledger accountingPoster) error) error
+}
+
+// ErrPurchaseOrderNotFound is returned when a purchase order is not found
+var ErrPurchaseOrderNotFound = fmt.Errorf("purchase order not found")
+
+// ErrGoodsReceiptNotFound is returned when a goods receipt is not found
+var ErrGoodsReceiptNotFound = fmt.Errorf("goods receipt not found")
+
+// ErrLandedCostNotFound is returned when a landed cost is not found
+var ErrLandedCostNotFound = fmt.Errorf("landed cost not found")
+
+// ErrInvoiceAlreadyPosted is returned when a matched invoice already has a journal entry
+var ErrInvoiceAlreadyPosted = fmt.Errorf("invoice is already posted to the ledger")
+
+// ErrPeriodLocked is returned when an invoice match or landed cost would post into a locked accounting period
+var ErrPeriodLocked = fmt.Errorf("period locked")
+
+var errPurchasingRepositoryDatabaseNotConfigured = errors.New("purchasing repository database is not configured")
+
+var newGormDBFromPool = database.NewGormDBFromPool
+
+// GORMRepository implements Repository with the shared ORM layer.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *pgxpool.Pool) *GORMRepository {
+	if db == nil {
+		return &GORMRepository{}
+	}
+	gormDB, err := newGormDBFromPool(context.Background(), db)
+	if err != nil {
+		panic(fmt.Errorf("create purchasing GORM repository: %w", err))
+	}
+	return NewGORMRepository(gormDB)
+}
+
+func NewGORMRepository(db *gorm.DB) *GORMRepository {
+	return &GORMRepository{db: db}
+}
+
+// WithLedgerTransaction runs fn inside a GORM-backed transaction shared by the
+// purchasing repository, inventory and the general ledger.
+func (r *GORMRepository) WithLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockReceiver, ledger accountingPoster) error) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(&GORMRepository{db: tx},
+			inventory.NewServiceWithGORM(tx),
+			accounting.NewServiceWithRepository(accounting.NewGORMRepository(tx)))
+	})
+}
+
+func (r *GORMRepository) dbWithContext(ctx context.Context) (*gorm.DB, error) {
+	if r == nil || r.db == nil {
+		return nil, errPurchasingRepositoryDatabaseNotConfigured
+	}
+	return r.db.WithContext(ctx), nil
+}
+
+func (r *GORMRepository) tenantTable(ctx context.Context, schemaName, tableName string) (*gorm.DB, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return database.TenantTable(db, schemaName, tableName)
+}
+
+// Create inserts a new purchase order with its lines
+func (r *GORMRepository) Create(ctx context.Context, schemaName string, po *PurchaseOrder) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		ordersTable, err := database.TenantTable(tx, schemaName, "purchase_orders")
+		if err != nil {
+			return fmt.Errorf("qualify purchase orders table: %w", err)
+		}
+		if err := ordersTable.Create(purchaseOrderToModel(po)).Error; err != nil {
+			return fmt.Errorf("insert purchase order: %w", err)
+		}
+
+		if len(po.Lines) == 0 {
+			return nil
+		}
+
+		linesTable, _ := database.TenantTable(tx, schemaName, "purchase_order_lines")
+		lineModels := make([]models.PurchaseOrderLine, len(po.Lines))
+		for i := range po.Lines {
+			po.Lines[i].PurchaseOrderID = po.ID
+			lineModels[i] = *purchaseOrderLineToModel(&po.Lines[i])
+		}
+		if err := linesTable.Create(&lineModels).Error; err != nil {
+			return fmt.Errorf("insert purchase order line: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID retrieves a purchase order by ID with its lines
+func (r *GORMRepository) GetByID(ctx context.Context, schemaName, tenantID, poID string) (*PurchaseOrder, error) {
+	db, err := r.tenantTable(ctx, schemaName, "purchase_orders")
+	if err != nil {
+		return nil, fmt.Errorf("qualify purchase orders table: %w", err)
+	}
+
+	var poModel models.PurchaseOrder
+	err = db.Where("id = ? AND tenant_id = ?", poID, tenantID).First(&poModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get purchase order: %w", err)
+	}
+
+	po := purchaseOrderFromModel(&poModel)
+	lines, err := r.listPurchaseOrderLines(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, err
+	}
+	po.Lines = lines
+	return po, nil
+}
+
+// List retrieves purchase orders with optional filtering
+func (r *GORMRepository) List(ctx context.Context, schemaName, tenantID string, filter *PurchaseOrderFilter) ([]PurchaseOrder, error) {
+	db, err := r.tenantTable(ctx, schemaName, "purchase_orders")
+	if err != nil {
+		return nil, fmt.Errorf("qualify purchase orders table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", string(filter.Status))
+		}
+		if filter.ContactID != "" {
+			query = query.Where("contact_id = ?", filter.ContactID)
+		}
+		if filter.FromDate != nil {
+			query = query.Where("order_date >= ?", filter.FromDate)
+		}
+		if filter.ToDate != nil {
+			query = query.Where("order_date <= ?", filter.ToDate)
+		}
+		if strings.TrimSpace(filter.Search) != "" {
+			query = query.Where("po_number ILIKE ?", "%"+strings.TrimSpace(filter.Search)+"%")
+		}
+	}
+
+	var poModels []models.PurchaseOrder
+	if err := query.
+		Order("order_date DESC").
+		Order("po_number DESC").
+		Find(&poModels).Error; err != nil {
+		return nil, fmt.Errorf("list purchase orders: %w", err)
+	}
+
+	orders := make([]PurchaseOrder, len(poModels))
+	for i := range poModels {
+		orders[i] = *purchaseOrderFromModel(&poModels[i])
+	}
+	return orders, nil
+}
+
+// ListOpen retrieves draft, approved and partially received purchase orders
+// with their lines
+func (r *GORMRepository) ListOpen(ctx context.Context, schemaName, tenantID string) ([]PurchaseOrder, error) {
+	db, err := r.tenantTable(ctx, schemaName, "purchase_orders")
+	if err != nil {
+		return nil, fmt.Errorf("qualify purchase orders table: %w", err)
+	}
+
+	var poModels []models.PurchaseOrder
+	if err := db.
+		Where("tenant_id = ? AND status IN ?", tenantID, []string{
+			string(PurchaseOrderStatusDraft),
+			string(PurchaseOrderStatusApproved),
+			string(PurchaseOrderStatusPartiallyReceived),
+		}).
+		Order("order_date ASC").
+		Order("po_number ASC").
+		Find(&poModels).Error; err != nil {
+		return nil, fmt.Errorf("list open purchase orders: %w", err)
+	}
+
+	orders := make([]PurchaseOrder, len(poModels))
+	for i := range poModels {
+		orders[i] = *purchaseOrderFromModel(&poModels[i])
+		lines, err := r.listPurchaseOrderLines(ctx, schemaName, tenantID, orders[i].ID)
+		if err != nil {
+			return nil, err
+		}
+		orders[i].Lines = lines
+	}
+	return orders, nil
+}
+
+// UpdateStatus updates the status of a purchase order. Approvals also record
+// who approved the order and when.
+func (r *GORMRepository) UpdateStatus(ctx context.Context, schemaName, tenantID, poID string, status PurchaseOrderStatus, userID string) error {
+	db, err := r.tenantTable(ctx, schemaName, "purchase_orders")
+	if err != nil {
+		return fmt.Errorf("qualify purchase orders table: %w", err)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     string(status),
+		"updated_at": now,
+	}
+	if status == PurchaseOrderStatusApproved {
+		updates["approved_at"] = now
+		updates["approved_by"] = nilIfEmpty(userID)
+	}
+	result := db.Where("id = ? AND tenant_id = ?", poID, tenantID).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("update purchase order status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPurchaseOrderNotFound
+	}
+	return nil
+}
+
+// GenerateNumber generates a new purchase order number
+func (r *GORMRepository) GenerateNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	return r.generateSequenceNumber(ctx, schemaName, tenantID, "purchase_orders", "po_number", "PO")
+}
+
+// GenerateReceiptNumber generates a new goods receipt note number
+func (r *GORMRepository) GenerateReceiptNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	return r.generateSequenceNumber(ctx, schemaName, tenantID, "goods_receipts", "receipt_number", "GRN")
+}
+
+func (r *GORMRepository) generateSequenceNumber(ctx context.Context, schemaName, tenantID, tableName, column, prefix string) (string, error) {
+	db, err := r.tenantTable(ctx, schemaName, tableName)
+	if err != nil {
+		return "", fmt.Errorf("qualify %s table: %w", strings.ReplaceAll(tableName, "_", " "), err)
+	}
+
+	var seq int
+	if err := db.
+		Select(fmt.Sprintf(`
+			COALESCE(MAX(
+				CASE
+					WHEN %[1]s ~ ? THEN CAST(SUBSTRING(%[1]s FROM ?) AS INTEGER)
+					ELSE 0
+				END
+			), 0) + 1
+		`, column), prefix+"-[0-9]+$", prefix+"-([0-9]+)$").
+		Where("tenant_id = ?", tenantID).
+		Scan(&seq).Error; err != nil {
+		return "", fmt.Errorf("generate %s: %w", strings.ReplaceAll(column, "_", " "), err)
+	}
+	return fmt.Sprintf("%s-%05d", prefix, seq), nil
+}
+
+// CreateReceipt inserts a goods receipt, adds its quantities and costs to the
+// purchase order lines and moves the purchase order to the given status.
+func (r *GORMRepository) CreateReceipt(ctx context.Context, schemaName string, receipt *GoodsReceipt, status PurchaseOrderStatus) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		receiptsTable, err := database.TenantTable(tx, schemaName, "goods_receipts")
+		if err != nil {
+			return fmt.Errorf("qualify goods receipts table: %w", err)
+		}
+		if err := receiptsTable.Create(goodsReceiptToModel(receipt)).Error; err != nil {
+			return fmt.Errorf("insert goods receipt: %w", err)
+		}
+
+		lineModels := make([]models.GoodsReceiptLine, len(receipt.Lines))
+		for i := range receipt.Lines {
+			receipt.Lines[i].GoodsReceiptID = receipt.ID
+			lineModels[i] = *goodsReceiptLineToModel(&receipt.Lines[i])
+		}
+		if len(lineModels) > 0 {
+			linesTable, _ := database.TenantTable(tx, schemaName, "goods_receipt_lines")
+			if err := linesTable.Create(&lineModels).Error; err != nil {
+				return fmt.Errorf("insert goods receipt line: %w", err)
+			}
+		}
+
+		for _, line := range receipt.Lines {
+			poLinesTable, _ := database.TenantTable(tx, schemaName, "purchase_order_lines")
+			result := poLinesTable.Where("id = ? AND tenant_id = ? AND purchase_order_id = ?", line.PurchaseOrderLineID, receipt.TenantID, receipt.PurchaseOrderID).
+				Updates(map[string]interface{}{
+					"received_quantity": gorm.Expr("received_quantity + ?", line.Quantity),
+					"received_amount":   gorm.Expr("received_amount + ?", line.TotalCost),
+				})
+			if result.Error != nil {
+				return fmt.Errorf("update purchase order line receipt: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("purchase order line %s not found", line.PurchaseOrderLineID)
+			}
+		}
+
+		return updatePurchaseOrderStatusTx(tx, schemaName, receipt.TenantID, receipt.PurchaseOrderID, status)
+	})
+}
+
+// ListReceipts retrieves goods receipts for a purchase order with their lines
+func (r *GORMRepository) ListReceipts(ctx context.Context, schemaName, tenantID, poID string) ([]GoodsReceipt, error) {
+	db, err := r.tenantTable(ctx, schemaName, "goods_receipts")
+	if err != nil {
+		return nil, fmt.Errorf("qualify goods receipts table: %w", err)
+	}
+
+	var receiptModels []models.GoodsReceipt
+	if err := db.
+		Where("tenant_id = ? AND purchase_order_id = ?", tenantID, poID).
+		Order("receipt_date ASC").
+		Order("receipt_number ASC").
+		Find(&receiptModels).Error; err != nil {
+		return nil, fmt.Errorf("list goods receipts: %w", err)
+	}
+	if len(receiptModels) == 0 {
+		return []GoodsReceipt{}, nil
+	}
+
+	receiptIDs := make([]string, len(receiptModels))
+	for i := range receiptModels {
+		receiptIDs[i] = receiptModels[i].ID
+	}
+	linesTable, err := r.tenantTable(ctx, schemaName, "goods_receipt_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify goods receipt lines table: %w", err)
+	}
+	var lineModels []models.GoodsReceiptLine
+	if err := linesTable.
+		Where("tenant_id = ? AND goods_receipt_id IN ?", tenantID, receiptIDs).
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("list goods receipt lines: %w", err)
+	}
+	linesByReceipt := make(map[string][]GoodsReceiptLine, len(receiptModels))
+	for i := range lineModels {
+		line := goodsReceiptLineFromModel(&lineModels[i])
+		linesByReceipt[line.GoodsReceiptID] = append(linesByReceipt[line.GoodsReceiptID], *line)
+	}
+
+	receipts := make([]GoodsReceipt, len(receiptModels))
+	for i := range receiptModels {
+		receipts[i] = *goodsReceiptFromModel(&receiptModels[i])
+		receipts[i].Lines = linesByReceipt[receipts[i].ID]
+	}
+	return receipts, nil
+}
+
+// CreateInvoiceMatch inserts a purchase invoice match, adds the matched
+// quantities and amounts to the purchase order lines, links the journal entry
+// to the invoice and moves the purchase order to the given status.
+func (r *GORMRepository) CreateInvoiceMatch(ctx context.Context, schemaName string, match *PurchaseInvoiceMatch, status PurchaseOrderStatus) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		matchesTable, err := database.TenantTable(tx, schemaName, "purchase_invoice_matches")
+		if err != nil {
+			return fmt.Errorf("qualify purchase invoice matches table: %w", err)
+		}
+		if err := matchesTable.Create(invoiceMatchToModel(match)).Error; err != nil {
+			return fmt.Errorf("insert purchase invoice match: %w", err)
+		}
+
+		lineModels := make([]models.PurchaseInvoiceMatchLine, len(match.Lines))
+		for i := range match.Lines {
+			match.Lines[i].MatchID = match.ID
+			lineModels[i] = *invoiceMatchLineToModel(&match.Lines[i])
+		}
+		if len(lineModels) > 0 {
+			linesTable, _ := database.TenantTable(tx, schemaName, "purchase_invoice_match_lines")
+			if err := linesTable.Create(&lineModels).Error; err != nil {
+				return fmt.Errorf("insert purchase invoice match line: %w", err)
+			}
+		}
+
+		for _, line := range match.Lines {
+			poLinesTable, _ := database.TenantTable(tx, schemaName, "purchase_order_lines")
+			result := poLinesTable.Where("id = ? AND tenant_id = ? AND purchase_order_id = ?", line.PurchaseOrderLineID, match.TenantID, match.PurchaseOrderID).
+				Updates(map[string]interface{}{
+					"invoiced_quantity": gorm.Expr("invoiced_quantity + ?", line.Quantity),
+					"invoiced_amount":   gorm.Expr("invoiced_amount + ?", line.InvoicedAmount),
+					"accrual_cleared":   gorm.Expr("accrual_cleared + ?", line.AccrualCleared),
+				})
+			if result.Error != nil {
+				return fmt.Errorf("update purchase order line invoicing: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("purchase order line %s not found", line.PurchaseOrderLineID)
+			}
+		}
+
+		if match.JournalEntryID != nil {
+			invoicesTable, err := database.TenantTable(tx, schemaName, "invoices")
+			if err != nil {
+				return fmt.Errorf("qualify invoices table: %w", err)
+			}
+			result := invoicesTable.Where("id = ? AND tenant_id = ? AND journal_entry_id IS NULL", match.InvoiceID, match.TenantID).
+				Updates(map[string]interface{}{
+					"journal_entry_id": *match.JournalEntryID,
+					"updated_at":       time.Now(),
+				})
+			if result.Error != nil {
+				return fmt.Errorf("link invoice journal entry: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return ErrInvoiceAlreadyPosted
+			}
+		}
+
+		return updatePurchaseOrderStatusTx(tx, schemaName, match.TenantID, match.PurchaseOrderID, status)
+	})
+}
+
+// ListInvoiceMatches retrieves purchase invoice matches for a purchase order with their lines
+func (r *GORMRepository) ListInvoiceMatches(ctx context.Context, schemaName, tenantID, poID string) ([]PurchaseInvoiceMatch, error) {
+	db, err := r.tenantTable(ctx, schemaName, "purchase_invoice_matches")
+	if err != nil {
+		return nil, fmt.Errorf("qualify purchase invoice matches table: %w", err)
+	}
+
+	var matchModels []models.PurchaseInvoiceMatch
+	if err := db.
+		Where("tenant_id = ? AND purchase_order_id = ?", tenantID, poID).
+		Order("match_date ASC").
+		Order("created_at ASC").
+		Find(&matchModels).Error; err != nil {
+		return nil, fmt.Errorf("list purchase invoice matches: %w", err)
+	}
+	if len(matchModels) == 0 {
+		return []PurchaseInvoiceMatch{}, nil
+	}
+
+	matchIDs := make([]string, len(matchModels))
+	for i := range matchModels {
+		matchIDs[i] = matchModels[i].ID
+	}
+	linesTable, err := r.tenantTable(ctx, schemaName, "purchase_invoice_match_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify purchase invoice match lines table: %w", err)
+	}
+	var lineModels []models.PurchaseInvoiceMatchLine
+	if err := linesTable.
+		Where("tenant_id = ? AND match_id IN ?", tenantID, matchIDs).
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("list purchase invoice match lines: %w", err)
+	}
+	linesByMatch := make(map[string][]PurchaseInvoiceMatchLine, len(matchModels))
+	for i := range lineModels {
+		line := invoiceMatchLineFromModel(&lineModels[i])
+		linesByMatch[line.MatchID] = append(linesByMatch[line.MatchID], *line)
+	}
+
+	matches := make([]PurchaseInvoiceMatch, len(matchModels))
+	for i := range matchModels {
+		matches[i] = *invoiceMatchFromModel(&matchModels[i])
+		matches[i].Lines = linesByMatch[matches[i].ID]
+	}
+	return matches, nil
+}
+
+// GetReceipt retrieves a goods receipt by ID with its lines
+func (r *GORMRepository) GetReceipt(ctx context.Context, schemaName, tenantID, receiptID string) (*GoodsReceipt, error) {
+	db, err := r.tenantTable(ctx, schemaName, "goods_receipts")
+	if err != nil {
+		return nil, fmt.Errorf("qualify goods receipts table: %w", err)
+	}
+
+	var receiptModel models.GoodsReceipt
+	err = db.Where("id = ? AND tenant_id = ?", receiptID, tenantID).First(&receiptModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrGoodsReceiptNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get goods receipt: %w", err)
+	}
+
+	receipt := goodsReceiptFromModel(&receiptModel)
+	lines, err := r.ListReceiptLines(ctx, schemaName, tenantID, &GoodsReceiptLineFilter{GoodsReceiptIDs: []string{receiptID}})
+	if err != nil {
+		return nil, err
+	}
+	receipt.Lines = lines
+	return receipt, nil
+}
+
+// ListReceiptLines retrieves goods receipt lines by receipt, line ID or
+// product lot, oldest receipt first
+func (r *GORMRepository) ListReceiptLines(ctx context.Context, schemaName, tenantID string, filter *GoodsReceiptLineFilter) ([]GoodsReceiptLine, error) {
+	db, err := r.tenantTable(ctx, schemaName, "goods_receipt_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify goods receipt lines table: %w", err)
+	}
+	receiptsTable, err := database.QualifiedTable(schemaName, "goods_receipts")
+	if err != nil {
+		return nil, fmt.Errorf("qualify goods receipts table: %w", err)
+	}
+
+	query := db.Select("goods_receipt_lines.*").
+		Joins(fmt.Sprintf("JOIN %s AS receipts ON receipts.id = goods_receipt_lines.goods_receipt_id", receiptsTable)).
+		Where("goods_receipt_lines.tenant_id = ?", tenantID)
+	if filter != nil {
+		if len(filter.GoodsReceiptIDs) > 0 {
+			query = query.Where("goods_receipt_lines.goods_receipt_id IN ?", filter.GoodsReceiptIDs)
+		}
+		if len(filter.LineIDs) > 0 {
+			query = query.Where("goods_receipt_lines.id IN ?", filter.LineIDs)
+		}
+		if filter.ProductID != "" {
+			query = query.Where("goods_receipt_lines.product_id = ?", filter.ProductID)
+		}
+		if filter.LotNumber != "" {
+			query = query.Where("goods_receipt_lines.lot_number = ?", filter.LotNumber)
+		}
+	}
+
+	var lineModels []models.GoodsReceiptLine
+	if err := query.
+		Order("receipts.receipt_date ASC").
+		Order("receipts.receipt_number ASC").
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("list goods receipt lines: %w", err)
+	}
+
+	lines := make([]GoodsReceiptLine, len(lineModels))
+	for i := range lineModels {
+		lines[i] = *goodsReceiptLineFromModel(&lineModels[i])
+	}
+	return lines, nil
+}
+
+// GenerateLandedCostNumber generates a new landed cost number
+func (r *GORMRepository) GenerateLandedCostNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	return r.generateSequenceNumber(ctx, schemaName, tenantID, "landed_costs", "landed_cost_number", "LC")
+}
+
+// CreateLandedCost inserts a landed cost with its lines and links the
+// journal entry to the allocated invoice.
+func (r *GORMRepository) CreateLandedCost(ctx context.Context, schemaName string, landedCost *LandedCost) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		landedCostsTable, err := database.TenantTable(tx, schemaName, "landed_costs")
+		if err != nil {
+			return fmt.Errorf("qualify landed costs table: %w", err)
+		}
+		if err := landedCostsTable.Create(landedCostToModel(landedCost)).Error; err != nil {
+			return fmt.Errorf("insert landed cost: %w", err)
+		}
+
+		lineModels := make([]models.LandedCostLine, len(landedCost.Lines))
+		for i := range landedCost.Lines {
+			landedCost.Lines[i].LandedCostID = landedCost.ID
+			lineModels[i] = *landedCostLineToModel(&landedCost.Lines[i])
+		}
+		if len(lineModels) > 0 {
+			linesTable, _ := database.TenantTable(tx, schemaName, "landed_cost_lines")
+			if err := linesTable.Create(&lineModels).Error; err != nil {
+				return fmt.Errorf("insert landed cost line: %w", err)
+			}
+		}
+
+		if landedCost.JournalEntryID == nil {
+			return nil
+		}
+		invoicesTable, err := database.TenantTable(tx, schemaName, "invoices")
+		if err != nil {
+			return fmt.Errorf("qualify invoices table: %w", err)
+		}
+		result := invoicesTable.Where("id = ? AND tenant_id = ? AND journal_entry_id IS NULL", landedCost.InvoiceID, landedCost.TenantID).
+			Updates(map[string]interface{}{
+				"journal_entry_id": *landedCost.JournalEntryID,
+				"updated_at":       time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("link invoice journal entry: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvoiceAlreadyPosted
+		}
+		return nil
+	})
+}
+
+// GetLandedCost retrieves a landed cost by ID with its lines
+func (r *GORMRepository) GetLandedCost(ctx context.Context, schemaName, tenantID, landedCostID string) (*LandedCost, error) {
+	db, err := r.tenantTable(ctx, schemaName, "landed_costs")
+	if err != nil {
+		return nil, fmt.Errorf("qualify landed costs table: %w", err)
+	}
+
+	var landedCostModel models.LandedCost
+	err = db.Where("id = ? AND tenant_id = ?", landedCostID, tenantID).First(&landedCostModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrLandedCostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get landed cost: %w", err)
+	}
+
+	landedCost := landedCostFromModel(&landedCostModel)
+	linesByLandedCost, err := r.listLandedCostLines(ctx, schemaName, tenantID, []string{landedCostID})
+	if err != nil {
+		return nil, err
+	}
+	landedCost.Lines = linesByLandedCost[landedCostID]
+	return landedCost, nil
+}
+
+// ListLandedCosts retrieves landed costs with their lines, optionally limited
+// to those allocated onto one goods receipt
+func (r *GORMRepository) ListLandedCosts(ctx context.Context, schemaName, tenantID string, filter *LandedCostFilter) ([]LandedCost, error) {
+	db, err := r.tenantTable(ctx, schemaName, "landed_costs")
+	if err != nil {
+		return nil, fmt.Errorf("qualify landed costs table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter != nil {
+		if filter.GoodsReceiptID != "" {
+			linesTable, err := database.QualifiedTable(schemaName, "landed_cost_lines")
+			if err != nil {
+				return nil, fmt.Errorf("qualify landed cost lines table: %w", err)
+			}
+			query = query.Where(fmt.Sprintf("id IN (SELECT landed_cost_id FROM %s WHERE tenant_id = ? AND goods_receipt_id = ?)", linesTable), tenantID, filter.GoodsReceiptID)
+		}
+		if filter.FromDate != nil {
+			query = query.Where("cost_date >= ?", filter.FromDate)
+		}
+		if filter.ToDate != nil {
+			query = query.Where("cost_date <= ?", filter.ToDate)
+		}
+	}
+
+	var landedCostModels []models.LandedCost
+	if err := query.
+		Order("cost_date DESC").
+		Order("landed_cost_number DESC").
+		Find(&landedCostModels).Error; err != nil {
+		return nil, fmt.Errorf("list landed costs: %w", err)
+	}
+	if len(landedCostModels) == 0 {
+		return []LandedCost{}, nil
+	}
+
+	landedCostIDs := make([]string, len(landedCostModels))
+	for i := range landedCostModels {
+		landedCostIDs[i] = landedCostModels[i].ID
+	}
+	linesByLandedCost, err := r.listLandedCostLines(ctx, schemaName, tenantID, landedCostIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	landedCosts := make([]LandedCost, len(landedCostModels))
+	for i := range landedCostModels {
+		landedCosts[i] = *landedCostFromModel(&landedCostModels[i])
+		landedCosts[i].Lines = linesByLandedCost[landedCosts[i].ID]
+	}
+	return landedCosts, nil
+}
+
+func (r *GORMRepository) listLandedCostLines(ctx context.Context, schemaName, tenantID string, landedCostIDs []string) (map[string][]LandedCostLine, error) {
+	db, err := r.tenantTable(ctx, schemaName, "landed_cost_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify landed cost lines table: %w", err)
+	}
+
+	var lineModels []models.LandedCostLine
+	if err := db.
+		Where("tenant_id = ? AND landed_cost_id IN ?", tenantID, landedCostIDs).
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("list landed cost lines: %w", err)
+	}
+	linesByLandedCost := make(map[string][]LandedCostLine, len(landedCostIDs))
+	for i := range lineModels {
+		line := landedCostLineFromModel(&lineModels[i])
+		linesByLandedCost[line.LandedCostID] = append(linesByLandedCost[line.LandedCostID], *line)
+	}
+	return linesByLandedCost, nil
+}
+
+func (r *GORMRepository) listPurchaseOrderLines(ctx context.Context, schemaName, tenantID, poID string) ([]PurchaseOrderLine, error) {
+	db, err := r.tenantTable(ctx, schemaName, "purchase_order_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify purchase order lines table: %w", err)
+	}
+
+	var lineModels []models.PurchaseOrderLine
+	if err := db.
+		Where("purchase_order_id = ? AND tenant_id = ?", poID, tenantID).
+		Order("line_number ASC").
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("get purchase order lines: %w", err)
+	}
+
+	lines := make([]PurchaseOrderLine, len(lineModels))
+	for i := range lineModels {
+		lines[i] = *purchaseOrderLineFromModel(&lineModels[i])
+	}
+	return lines, nil
+}
+
+func updatePurchaseOrderStatusTx(tx *gorm.DB, schemaName, tenantID, poID string, status PurchaseOrderStatus) error {
+	ordersTable, err := database.TenantTable(tx, schemaName, "purchase_orders")
+	if err != nil {
+		return fmt.Errorf("qualify purchase orders table: %w", err)
+	}
+	result := ordersTable.Where("id = ? AND tenant_id = ?", poID, tenantID).
+		Updates(map[string]interface{}{
+			"status":     string(status),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("update purchase order status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPurchaseOrderNotFound
+	}
+	return nil
+}
+
+func purchaseOrderToModel(po *PurchaseOrder) *models.PurchaseOrder {
+	return &models.PurchaseOrder{
+		ID:           po.ID,
+		TenantID:     po.TenantID,
+		PONumber:     po.PONumber,
+		ContactID:    po.ContactID,
+		WarehouseID:  po.WarehouseID,
+		OrderDate:    po.OrderDate,
+		ExpectedDate: po.ExpectedDate,
+		Status:       string(po.Status),
+		Currency:     po.Currency,
+		ExchangeRate: models.Decimal{Decimal: po.ExchangeRate},
+		Subtotal:     models.Decimal{Decimal: po.Subtotal},
+		VATAmount:    models.Decimal{Decimal: po.VATAmount},
+		Total:        models.Decimal{Decimal: po.Total},
+		Notes:        po.Notes,
+		ApprovedAt:   po.ApprovedAt,
+		ApprovedBy:   nilIfEmpty(po.ApprovedBy),
+		CreatedAt:    po.CreatedAt,
+		CreatedBy:    po.CreatedBy,
+		UpdatedAt:    po.UpdatedAt,
+	}
+}
+
+func purchaseOrderFromModel(po *models.PurchaseOrder) *PurchaseOrder {
+	return &PurchaseOrder{
+		ID:           po.ID,
+		TenantID:     po.TenantID,
+		PONumber:     po.PONumber,
+		ContactID:    po.ContactID,
+		WarehouseID:  po.WarehouseID,
+		OrderDate:    po.OrderDate,
+		ExpectedDate: po.ExpectedDate,
+		Status:       PurchaseOrderStatus(po.Status),
+		Currency:     po.Currency,
+		ExchangeRate: po.ExchangeRate.Decimal,
+		Subtotal:     po.Subtotal.Decimal,
+		VATAmount:    po.VATAmount.Decimal,
+		Total:        po.Total.Decimal,
+		Notes:        po.Notes,
+		ApprovedAt:   po.ApprovedAt,
+		ApprovedBy:   valueOrEmpty(po.ApprovedBy),
+		CreatedAt:    po.CreatedAt,
+		CreatedBy:    po.CreatedBy,
+		UpdatedAt:    po.UpdatedAt,
+	}
+}
+
+func purchaseOrderLineToModel(line *PurchaseOrderLine) *models.PurchaseOrderLine {
+	return &models.PurchaseOrderLine{
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		PurchaseOrderID:  line.PurchaseOrderID,
+		LineNumber:       line.LineNumber,
+		ProductID:        line.ProductID,
+		Description:      line.Description,
+		Quantity:         models.Decimal{Decimal: line.Quantity},
+		Unit:             line.Unit,
+		UnitPrice:        models.Decimal{Decimal: line.UnitPrice},
+		DiscountPercent:  models.Decimal{Decimal: line.DiscountPercent},
+		VATRate:          models.Decimal{Decimal: line.VATRate},
+		LineSubtotal:     models.Decimal{Decimal: line.LineSubtotal},
+		LineVAT:          models.Decimal{Decimal: line.LineVAT},
+		LineTotal:        models.Decimal{Decimal: line.LineTotal},
+		ReceivedQuantity: models.Decimal{Decimal: line.ReceivedQuantity},
+		ReceivedAmount:   models.Decimal{Decimal: line.ReceivedAmount},
+		InvoicedQuantity: models.Decimal{Decimal: line.InvoicedQuantity},
+		InvoicedAmount:   models.Decimal{Decimal: line.InvoicedAmount},
+		AccrualCleared:   models.Decimal{Decimal: line.AccrualCleared},
+	}
+}
+
+func purchaseOrderLineFromModel(line *models.PurchaseOrderLine) *PurchaseOrderLine {
+	return &PurchaseOrderLine{
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		PurchaseOrderID:  line.PurchaseOrderID,
+		LineNumber:       line.LineNumber,
+		ProductID:        line.ProductID,
+		Description:      line.Description,
+		Quantity:         line.Quantity.Decimal,
+		Unit:             line.Unit,
+		UnitPrice:        line.UnitPrice.Decimal,
+		DiscountPercent:  line.DiscountPercent.Decimal,
+		VATRate:          line.VATRate.Decimal,
+		LineSubtotal:     line.LineSubtotal.Decimal,
+		LineVAT:          line.LineVAT.Decimal,
+		LineTotal:        line.LineTotal.Decimal,
+		ReceivedQuantity: line.ReceivedQuantity.Decimal,
+		ReceivedAmount:   line.ReceivedAmount.Decimal,
+		InvoicedQuantity: line.InvoicedQuantity.Decimal,
+		InvoicedAmount:   line.InvoicedAmount.Decimal,
+		AccrualCleared:   line.AccrualCleared.Decimal,
+	}
+}
+
+func goodsReceiptToModel(receipt *GoodsReceipt) *models.GoodsReceipt {
+	return &models.GoodsReceipt{
+		ID:                receipt.ID,
+		TenantID:          receipt.TenantID,
+		ReceiptNumber:     receipt.ReceiptNumber,
+		PurchaseOrderID:   receipt.PurchaseOrderID,
+		WarehouseID:       receipt.WarehouseID,
+		ReceiptDate:       receipt.ReceiptDate,
+		SupplierReference: receipt.SupplierReference,
+		Notes:             receipt.Notes,
+		TotalCost:         models.Decimal{Decimal: receipt.TotalCost},
+		AccrualAccountID:  receipt.AccrualAccountID,
+		JournalEntryID:    receipt.JournalEntryID,
+		CreatedBy:         receipt.CreatedBy,
+		CreatedAt:         receipt.CreatedAt,
+	}
+}
+
+func goodsReceiptFromModel(receipt *models.GoodsReceipt) *GoodsReceipt {
+	return &GoodsReceipt{
+		ID:                receipt.ID,
+		TenantID:          receipt.TenantID,
+		ReceiptNumber:     receipt.ReceiptNumber,
+		PurchaseOrderID:   receipt.PurchaseOrderID,
+		WarehouseID:       receipt.WarehouseID,
+		ReceiptDate:       receipt.ReceiptDate,
+		SupplierReference: receipt.SupplierReference,
+		Notes:             receipt.Notes,
+		TotalCost:         receipt.TotalCost.Decimal,
+		AccrualAccountID:  receipt.AccrualAccountID,
+		JournalEntryID:    receipt.JournalEntryID,
+		CreatedBy:         receipt.CreatedBy,
+		CreatedAt:         receipt.CreatedAt,
+	}
+}
+
+func goodsReceiptLineToModel(line *GoodsReceiptLine) *models.GoodsReceiptLine {
+	return &models.GoodsReceiptLine{
+		ID:                  line.ID,
+		TenantID:            line.TenantID,
+		GoodsReceiptID:      line.GoodsReceiptID,
+		PurchaseOrderLineID: line.PurchaseOrderLineID,
+		ProductID:           line.ProductID,
+		Quantity:            models.Decimal{Decimal: line.Quantity},
+		UnitCost:            models.Decimal{Decimal: line.UnitCost},
+		TotalCost:           models.Decimal{Decimal: line.TotalCost},
+		LotNumber:           nilIfEmpty(line.LotNumber),
+		SerialNumber:        nilIfEmpty(line.SerialNumber),
+		ExpiryDate:          nilIfEmpty(line.ExpiryDate),
+	}
+}
+
+func goodsReceiptLineFromModel(line *models.GoodsReceiptLine) *GoodsReceiptLine {
+	expiryDate := valueOrEmpty(line.ExpiryDate)
+	if len(expiryDate) > len("2006-01-02") {
+		expiryDate = expiryDate[:len("2006-01-02")]
+	}
+	return &GoodsReceiptLine{
+		ID:                  line.ID,
+		TenantID:            line.TenantID,
+		GoodsReceiptID:      line.GoodsReceiptID,
+		PurchaseOrderLineID: line.PurchaseOrderLineID,
+		ProductID:           line.ProductID,
+		Quantity:            line.Quantity.Decimal,
+		UnitCost:            line.UnitCost.Decimal,
+		TotalCost:           line.TotalCost.Decimal,
+		LotNumber:           valueOrEmpty(line.LotNumber),
+		SerialNumber:        valueOrEmpty(line.SerialNumber),
+		ExpiryDate:          expiryDate,
+	}
+}
+
+func invoiceMatchToModel(match *PurchaseInvoiceMatch) *models.PurchaseInvoiceMatch {
+	return &models.PurchaseInvoiceMatch{
+		ID:                     match.ID,
+		TenantID:               match.TenantID,
+		PurchaseOrderID:        match.PurchaseOrderID,
+		InvoiceID:              match.InvoiceID,
+		MatchDate:              match.MatchDate,
+		AccrualCleared:         models.Decimal{Decimal: match.AccrualCleared},
+		InvoicedAmount:         models.Decimal{Decimal: match.InvoicedAmount},
+		VATAmount:              models.Decimal{Decimal: match.VATAmount},
+		PriceVariance:          models.Decimal{Decimal: match.PriceVariance},
+		PayableAmount:          models.Decimal{Decimal: match.PayableAmount},
+		AccrualAccountID:       match.AccrualAccountID,
+		PayableAccountID:       match.PayableAccountID,
+		VATAccountID:           nilIfEmpty(match.VATAccountID),
+		PriceVarianceAccountID: nilIfEmpty(match.PriceVarianceAccountID),
+		JournalEntryID:         match.JournalEntryID,
+		CreatedBy:              match.CreatedBy,
+		CreatedAt:              match.CreatedAt,
+	}
+}
+
+func invoiceMatchFromModel(match *models.PurchaseInvoiceMatch) *PurchaseInvoiceMatch {
+	return &PurchaseInvoiceMatch{
+		ID:                     match.ID,
+		TenantID:               match.TenantID,
+		PurchaseOrderID:        match.PurchaseOrderID,
+		InvoiceID:              match.InvoiceID,
+		MatchDate:              match.MatchDate,
+		AccrualCleared:         match.AccrualCleared.Decimal,
+		InvoicedAmount:         match.InvoicedAmount.Decimal,
+		VATAmount:              match.VATAmount.Decimal,
+		PriceVariance:          match.PriceVariance.Decimal,
+		PayableAmount:          match.PayableAmount.Decimal,
+		AccrualAccountID:       match.AccrualAccountID,
+		PayableAccountID:       match.PayableAccountID,
+		VATAccountID:           valueOrEmpty(match.VATAccountID),
+		PriceVarianceAccountID: valueOrEmpty(match.PriceVarianceAccountID),
+		JournalEntryID:         match.JournalEntryID,
+		CreatedBy:              match.CreatedBy,
+		CreatedAt:              match.CreatedAt,
+	}
+}
+
+func invoiceMatchLineToModel(line *PurchaseInvoiceMatchLine) *models.PurchaseInvoiceMatchLine {
+	return &models.PurchaseInvoiceMatchLine{
+		ID:                  line.ID,
+		TenantID:            line.TenantID,
+		MatchID:             line.MatchID,
+		PurchaseOrderLineID: line.PurchaseOrderLineID,
+		InvoiceLineID:       line.InvoiceLineID,
+		Quantity:            models.Decimal{Decimal: line.Quantity},
+		AccrualCleared:      models.Decimal{Decimal: line.AccrualCleared},
+		InvoicedAmount:      models.Decimal{Decimal: line.InvoicedAmount},
+		PriceVariance:       models.Decimal{Decimal: line.PriceVariance},
+	}
+}
+
+func invoiceMatchLineFromModel(line *models.PurchaseInvoiceMatchLine) *PurchaseInvoiceMatchLine {
+	return &PurchaseInvoiceMatchLine{
+		ID:                  line.ID,
+		TenantID:            line.TenantID,
+		MatchID:             line.MatchID,
+		PurchaseOrderLineID: line.PurchaseOrderLineID,
+		InvoiceLineID:       line.InvoiceLineID,
+		Quantity:            line.Quantity.Decimal,
+		AccrualCleared:      line.AccrualCleared.Decimal,
+		InvoicedAmount:      line.InvoicedAmount.Decimal,
+		PriceVariance:       line.PriceVariance.Decimal,
+	}
+}
+
+func landedCostToModel(landedCost *LandedCost) *models.LandedCost {
+	return &models.LandedCost{
+		ID:               landedCost.ID,
+		TenantID:         landedCost.TenantID,
+		LandedCostNumber: landedCost.LandedCostNumber,
+		InvoiceID:        landedCost.InvoiceID,
+		CostDate:         landedCost.CostDate,
+		AllocationMethod: string(landedCost.AllocationMethod),
+		Amount:           models.Decimal{Decimal: landedCost.Amount},
+		VATAmount:        models.Decimal{Decimal: landedCost.VATAmount},
+		PayableAmount:    models.Decimal{Decimal: landedCost.PayableAmount},
+		InventoryAmount:  models.Decimal{Decimal: landedCost.InventoryAmount},
+		COGSAmount:       models.Decimal{Decimal: landedCost.COGSAmount},
+		PayableAccountID: landedCost.PayableAccountID,
+		VATAccountID:     nilIfEmpty(landedCost.VATAccountID),
+		COGSAccountID:    nilIfEmpty(landedCost.CostOfGoodsSoldAccountID),
+		JournalEntryID:   landedCost.JournalEntryID,
+		Notes:            landedCost.Notes,
+		CreatedBy:        landedCost.CreatedBy,
+		CreatedAt:        landedCost.CreatedAt,
+	}
+}
+
+func landedCostFromModel(landedCost *models.LandedCost) *LandedCost {
+	return &LandedCost{
+		ID:                       landedCost.ID,
+		TenantID:                 landedCost.TenantID,
+		LandedCostNumber:         landedCost.LandedCostNumber,
+		InvoiceID:                landedCost.InvoiceID,
+		CostDate:                 landedCost.CostDate,
+		AllocationMethod:         LandedCostAllocationMethod(landedCost.AllocationMethod),
+		Amount:                   landedCost.Amount.Decimal,
+		VATAmount:                landedCost.VATAmount.Decimal,
+		PayableAmount:            landedCost.PayableAmount.Decimal,
+		InventoryAmount:          landedCost.InventoryAmount.Decimal,
+		COGSAmount:               landedCost.COGSAmount.Decimal,
+		PayableAccountID:         landedCost.PayableAccountID,
+		VATAccountID:             valueOrEmpty(landedCost.VATAccountID),
+		CostOfGoodsSoldAccountID: valueOrEmpty(landedCost.COGSAccountID),
+		JournalEntryID:           landedCost.JournalEntryID,
+		Notes:                    landedCost.Notes,
+		CreatedBy:                landedCost.CreatedBy,
+		CreatedAt:                landedCost.CreatedAt,
+	}
+}
+
+func landedCostLineToModel(line *LandedCostLine) *models.LandedCostLine {
+	return &models.LandedCostLine{
+		ID:                 line.ID,
+		TenantID:           line.TenantID,
+		LandedCostID:       line.LandedCostID,
+		GoodsReceiptID:     line.GoodsReceiptID,
+		GoodsReceiptLineID: line.GoodsReceiptLineID,
+		MovementID:         nilIfEmpty(line.MovementID),
+		ProductID:          line.ProductID,
+		WarehouseID:        line.WarehouseID,
+		LotNumber:          nilIfEmpty(line.LotNumber),
+		SerialNumber:       nilIfEmpty(line.SerialNumber),
+		ExpiryDate:         nilIfEmpty(line.ExpiryDate),
+		Quantity:           models.Decimal{Decimal: line.Quantity},
+		Basis:              models.Decimal{Decimal: line.Basis},
+		Amount:             models.Decimal{Decimal: line.Amount},
+		OnHandQuantity:     models.Decimal{Decimal: line.OnHandQuantity},
+		IssuedQuantity:     models.Decimal{Decimal: line.IssuedQuantity},
+		InventoryAmount:    models.Decimal{Decimal: line.InventoryAmount},
+		COGSAmount:         models.Decimal{Decimal: line.COGSAmount},
+		PreviousUnitCost:   models.Decimal{Decimal: line.PreviousUnitCost},
+		NewUnitCost:        models.Decimal{Decimal: line.NewUnitCost},
+	}
+}
+
+func landedCostLineFromModel(line *models.LandedCostLine) *LandedCostLine {
+	expiryDate := valueOrEmpty(line.ExpiryDate)
+	if len(expiryDate) > len("2006-01-02") {
+		expiryDate = expiryDate[:len("2006-01-02")]
+	}
+	return &LandedCostLine{
+		ID:                 line.ID,
+		TenantID:           line.TenantID,
+		LandedCostID:       line.LandedCostID,
+		GoodsReceiptID:     line.GoodsReceiptID,
+		GoodsReceiptLineID: line.GoodsReceiptLineID,
+		MovementID:         valueOrEmpty(line.MovementID),
+		ProductID:          line.ProductID,
+		WarehouseID:        line.WarehouseID,
+		LotNumber:          valueOrEmpty(line.LotNumber),
+		SerialNumber:       valueOrEmpty(line.SerialNumber),
+		ExpiryDate:         expiryDate,
+		Quantity:           line.Quantity.Decimal,
+		Basis:              line.Basis.Decimal,
+		Amount:             line.Amount.Decimal,
+		OnHandQuantity:     line.OnHandQuantity.Decimal,
+		IssuedQuantity:     line.IssuedQuantity.Decimal,
+		InventoryAmount:    line.InventoryAmount.Decimal,
+		COGSAmount:         line.COGSAmount.Decimal,
+		PreviousUnitCost:   line.PreviousUnitCost.Decimal,
+		NewUnitCost:        line.NewUnitCost.Decimal,
+	}
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
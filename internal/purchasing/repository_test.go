@@ -0,0 +1,259 @@
+package purchasing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_test"
+	tenantID := "tenant-1"
+	poID := "po-1"
+
+	repositories := []struct {
+		name string
+		repo *GORMRepository
+	}{
+		{name: "nil receiver", repo: nil},
+		{name: "nil gorm database", repo: NewGORMRepository(nil)},
+		{name: "nil pool constructor", repo: NewRepository(nil)},
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo *GORMRepository) error
+	}{
+		{name: "WithLedgerTransaction", run: func(t *testing.T, repo *GORMRepository) error {
+			called := false
+			err := repo.WithLedgerTransaction(ctx, func(Repository, stockReceiver, accountingPoster) error {
+				called = true
+				return nil
+			})
+			assert.False(t, called)
+			return err
+		}},
+		{name: "Create", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.Create(ctx, schemaName, &PurchaseOrder{ID: poID, TenantID: tenantID})
+		}},
+		{name: "GetByID", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetByID(ctx, schemaName, tenantID, poID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "List", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.List(ctx, schemaName, tenantID, &PurchaseOrderFilter{Search: "PO"})
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListOpen", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListOpen(ctx, schemaName, tenantID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "UpdateStatus", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.UpdateStatus(ctx, schemaName, tenantID, poID, PurchaseOrderStatusApproved, "user-1")
+		}},
+		{name: "GenerateNumber", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GenerateNumber(ctx, schemaName, tenantID)
+			assert.Empty(t, got)
+			return err
+		}},
+		{name: "GenerateReceiptNumber", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GenerateReceiptNumber(ctx, schemaName, tenantID)
+			assert.Empty(t, got)
+			return err
+		}},
+		{name: "CreateReceipt", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreateReceipt(ctx, schemaName, &GoodsReceipt{TenantID: tenantID, PurchaseOrderID: poID}, PurchaseOrderStatusReceived)
+		}},
+		{name: "ListReceipts", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListReceipts(ctx, schemaName, tenantID, poID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "CreateInvoiceMatch", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreateInvoiceMatch(ctx, schemaName, &PurchaseInvoiceMatch{TenantID: tenantID, PurchaseOrderID: poID}, PurchaseOrderStatusClosed)
+		}},
+		{name: "ListInvoiceMatches", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListInvoiceMatches(ctx, schemaName, tenantID, poID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "GetReceipt", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetReceipt(ctx, schemaName, tenantID, "grn-1")
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListReceiptLines", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListReceiptLines(ctx, schemaName, tenantID, &GoodsReceiptLineFilter{GoodsReceiptIDs: []string{"grn-1"}})
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "GenerateLandedCostNumber", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GenerateLandedCostNumber(ctx, schemaName, tenantID)
+			assert.Empty(t, got)
+			return err
+		}},
+		{name: "CreateLandedCost", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.CreateLandedCost(ctx, schemaName, &LandedCost{TenantID: tenantID, InvoiceID: "invoice-1"})
+		}},
+		{name: "GetLandedCost", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetLandedCost(ctx, schemaName, tenantID, "lc-1")
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "ListLandedCosts", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.ListLandedCosts(ctx, schemaName, tenantID, &LandedCostFilter{GoodsReceiptID: "grn-1"})
+			assert.Nil(t, got)
+			return err
+		}},
+	}
+
+	for _, repository := range repositories {
+		repository := repository
+		t.Run(repository.name, func(t *testing.T) {
+			for _, tt := range tests {
+				tt := tt
+				t.Run(tt.name, func(t *testing.T) {
+					err := tt.run(t, repository.repo)
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), "purchasing repository database is not configured")
+				})
+			}
+		})
+	}
+}
+
+func TestPurchaseOrderModelMappingRoundTrip(t *testing.T) {
+	orderDate := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	approvedAt := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.UTC)
+	po := &PurchaseOrder{
+		ID:           "po-1",
+		TenantID:     "tenant-1",
+		PONumber:     "PO-00001",
+		ContactID:    "supplier-1",
+		WarehouseID:  "warehouse-1",
+		OrderDate:    orderDate,
+		Status:       PurchaseOrderStatusApproved,
+		Currency:     "EUR",
+		ExchangeRate: decimal.NewFromInt(1),
+		Subtotal:     decimal.RequireFromString("50"),
+		VATAmount:    decimal.RequireFromString("11"),
+		Total:        decimal.RequireFromString("61"),
+		ApprovedAt:   &approvedAt,
+		ApprovedBy:   "user-1",
+		CreatedBy:    "user-1",
+	}
+	assert.Equal(t, po, purchaseOrderFromModel(purchaseOrderToModel(po)))
+
+	unapproved := purchaseOrderToModel(&PurchaseOrder{ID: "po-2"})
+	assert.Nil(t, unapproved.ApprovedBy)
+
+	line := &PurchaseOrderLine{
+		ID:               "line-1",
+		TenantID:         "tenant-1",
+		PurchaseOrderID:  "po-1",
+		LineNumber:       1,
+		ProductID:        "product-1",
+		Description:      "Widget",
+		Quantity:         decimal.NewFromInt(10),
+		UnitPrice:        decimal.RequireFromString("5"),
+		ReceivedQuantity: decimal.NewFromInt(6),
+		ReceivedAmount:   decimal.RequireFromString("30"),
+		InvoicedQuantity: decimal.NewFromInt(4),
+		InvoicedAmount:   decimal.RequireFromString("20.80"),
+		AccrualCleared:   decimal.RequireFromString("20"),
+	}
+	assert.Equal(t, line, purchaseOrderLineFromModel(purchaseOrderLineToModel(line)))
+}
+
+func TestGoodsReceiptLineModelMapping(t *testing.T) {
+	line := &GoodsReceiptLine{
+		ID:                  "grn-line-1",
+		GoodsReceiptID:      "grn-1",
+		PurchaseOrderLineID: "line-1",
+		ProductID:           "product-1",
+		Quantity:            decimal.NewFromInt(2),
+		UnitCost:            decimal.RequireFromString("5"),
+		TotalCost:           decimal.RequireFromString("10"),
+		LotNumber:           "LOT-1",
+	}
+	model := goodsReceiptLineToModel(line)
+	require.NotNil(t, model.LotNumber)
+	assert.Nil(t, model.SerialNumber)
+	assert.Nil(t, model.ExpiryDate)
+
+	expiry := "2027-06-30T00:00:00Z"
+	model.ExpiryDate = &expiry
+	assert.Equal(t, "2027-06-30", goodsReceiptLineFromModel(model).ExpiryDate)
+}
+
+func TestPurchaseInvoiceMatchModelMapping(t *testing.T) {
+	journalID := "journal-1"
+	match := &PurchaseInvoiceMatch{
+		ID:               "match-1",
+		TenantID:         "tenant-1",
+		PurchaseOrderID:  "po-1",
+		InvoiceID:        "invoice-1",
+		AccrualCleared:   decimal.RequireFromString("50"),
+		InvoicedAmount:   decimal.RequireFromString("52"),
+		PriceVariance:    decimal.RequireFromString("2"),
+		PayableAmount:    decimal.RequireFromString("63.44"),
+		VATAmount:        decimal.RequireFromString("11.44"),
+		AccrualAccountID: "accrual",
+		PayableAccountID: "payable",
+		VATAccountID:     "vat",
+		JournalEntryID:   &journalID,
+	}
+	model := invoiceMatchToModel(match)
+	assert.Nil(t, model.PriceVarianceAccountID)
+	assert.Equal(t, match, invoiceMatchFromModel(model))
+}
+
+func TestLandedCostModelMappingRoundTrip(t *testing.T) {
+	journalID := "journal-1"
+	landedCost := &LandedCost{
+		ID:                       "lc-1",
+		TenantID:                 "tenant-1",
+		LandedCostNumber:         "LC-00001",
+		InvoiceID:                "invoice-1",
+		CostDate:                 time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC),
+		AllocationMethod:         LandedCostAllocationByValue,
+		Amount:                   decimal.RequireFromString("10"),
+		VATAmount:                decimal.RequireFromString("2.20"),
+		PayableAmount:            decimal.RequireFromString("12.20"),
+		InventoryAmount:          decimal.RequireFromString("8"),
+		COGSAmount:               decimal.RequireFromString("2"),
+		PayableAccountID:         "payable",
+		VATAccountID:             "vat",
+		CostOfGoodsSoldAccountID: "cogs",
+		JournalEntryID:           &journalID,
+		CreatedBy:                "user-1",
+	}
+	assert.Equal(t, landedCost, landedCostFromModel(landedCostToModel(landedCost)))
+	assert.Nil(t, landedCostToModel(&LandedCost{ID: "lc-2"}).COGSAccountID)
+
+	line := &LandedCostLine{
+		ID:                 "lc-line-1",
+		LandedCostID:       "lc-1",
+		GoodsReceiptID:     "grn-1",
+		GoodsReceiptLineID: "grn-line-1",
+		ProductID:          "product-1",
+		WarehouseID:        "warehouse-1",
+		Quantity:           decimal.NewFromInt(3),
+		Amount:             decimal.RequireFromString("6"),
+	}
+	model := landedCostLineToModel(line)
+	assert.Nil(t, model.MovementID)
+	assert.Nil(t, model.LotNumber)
+
+	expiry := "2027-06-30T00:00:00Z"
+	model.ExpiryDate = &expiry
+	assert.Equal(t, "2027-06-30", landedCostLineFromModel(model).ExpiryDate)
+}
@@ -0,0 +1,767 @@
+package purchasing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+)
+
+type stockReceiver interface {
+	GetProductByID(ctx context.Context, tenantID, schemaName, productID string) (*inventory.Product, error)
+	GetWarehouseByID(ctx context.Context, tenantID, schemaName, warehouseID string) (*inventory.Warehouse, error)
+	ReceiveStock(ctx context.Context, tenantID, schemaName string, req *inventory.ReceiveStockRequest) (*inventory.ReceiveStockResult, error)
+	ApplyLandedCost(ctx context.Context, tenantID, schemaName string, req *inventory.ApplyLandedCostRequest) (*inventory.ApplyLandedCostResult, error)
+}
+
+type invoiceReader interface {
+	GetByID(ctx context.Context, tenantID, schemaName, invoiceID string) (*invoicing.Invoice, error)
+}
+
+type accountingPoster interface {
+	ListAccounts(ctx context.Context, schemaName, tenantID string, activeOnly bool) ([]accounting.Account, error)
+	CreateJournalEntry(ctx context.Context, schemaName, tenantID string, req *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error)
+	PostJournalEntry(ctx context.Context, schemaName, tenantID, entryID, userID, reason string) error
+}
+
+// Service provides purchase order, goods receipt, invoice matching and landed cost operations
+type Service struct {
+	repo     Repository
+	stock    stockReceiver
+	planner  stockPlanner
+	invoices invoiceReader
+	ledger   accountingPoster
+}
+
+// NewService creates a new purchasing service with an ORM-backed repository.
+func NewService(db *pgxpool.Pool, inventoryService *inventory.Service, invoicingService *invoicing.Service, accountingService *accounting.Service) *Service {
+	return &Service{
+		repo:     NewRepository(db),
+		stock:    inventoryService,
+		planner:  inventoryService,
+		invoices: invoicingService,
+		ledger:   accountingService,
+	}
+}
+
+// NewServiceWithRepository creates a new purchasing service with custom dependencies
+func NewServiceWithRepository(repo Repository, stock stockReceiver, invoices invoiceReader, ledger accountingPoster) *Service {
+	return &Service{
+		repo:     repo,
+		stock:    stock,
+		invoices: invoices,
+		ledger:   ledger,
+	}
+}
+
+// Create creates a new draft purchase order. Every line must reference a
+// product that tracks inventory so receipts can be booked into stock.
+func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *CreatePurchaseOrderRequest) (*PurchaseOrder, error) {
+	if req == nil {
+		return nil, fmt.Errorf("purchase order request is required")
+	}
+	now := time.Now()
+	po := &PurchaseOrder{
+		ID:           uuid.New().String(),
+		TenantID:     tenantID,
+		ContactID:    strings.TrimSpace(req.ContactID),
+		WarehouseID:  strings.TrimSpace(req.WarehouseID),
+		OrderDate:    req.OrderDate,
+		ExpectedDate: req.ExpectedDate,
+		Status:       PurchaseOrderStatusDraft,
+		Currency:     strings.ToUpper(strings.TrimSpace(req.Currency)),
+		ExchangeRate: req.ExchangeRate,
+		Notes:        req.Notes,
+		CreatedAt:    now,
+		CreatedBy:    req.UserID,
+		UpdatedAt:    now,
+	}
+	if po.Currency == "" {
+		po.Currency = "EUR"
+	}
+	if po.ExchangeRate.IsZero() {
+		po.ExchangeRate = decimal.NewFromInt(1)
+	}
+	if po.OrderDate.IsZero() {
+		po.OrderDate = now
+	}
+
+	for i, reqLine := range req.Lines {
+		po.Lines = append(po.Lines, PurchaseOrderLine{
+			ID:              uuid.New().String(),
+			TenantID:        tenantID,
+			LineNumber:      i + 1,
+			ProductID:       strings.TrimSpace(reqLine.ProductID),
+			Description:     strings.TrimSpace(reqLine.Description),
+			Quantity:        reqLine.Quantity,
+			Unit:            reqLine.Unit,
+			UnitPrice:       reqLine.UnitPrice,
+			DiscountPercent: reqLine.DiscountPercent,
+			VATRate:         reqLine.VATRate,
+		})
+	}
+
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for purchase orders")
+	}
+	if po.WarehouseID != "" {
+		if _, err := s.stock.GetWarehouseByID(ctx, tenantID, schemaName, po.WarehouseID); err != nil {
+			return nil, fmt.Errorf("get warehouse: %w", err)
+		}
+	}
+	for i := range po.Lines {
+		line := &po.Lines[i]
+		if line.ProductID == "" {
+			continue
+		}
+		product, err := s.stock.GetProductByID(ctx, tenantID, schemaName, line.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: get product: %w", i+1, err)
+		}
+		if product.ProductType != inventory.ProductTypeGoods || !product.TrackInventory {
+			return nil, fmt.Errorf("line %d: product %s does not track inventory", i+1, product.Code)
+		}
+		if line.Description == "" {
+			line.Description = product.Name
+		}
+		if line.Unit == "" {
+			line.Unit = product.Unit
+		}
+	}
+
+	po.Calculate()
+	if err := po.Validate(); err != nil {
+		return nil, fmt.Errorf("validation failed: %w", err)
+	}
+
+	poNumber, err := s.repo.GenerateNumber(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("generate purchase order number: %w", err)
+	}
+	po.PONumber = poNumber
+
+	if err := s.repo.Create(ctx, schemaName, po); err != nil {
+		return nil, fmt.Errorf("create purchase order: %w", err)
+	}
+	return po, nil
+}
+
+// GetByID retrieves a purchase order by ID
+func (s *Service) GetByID(ctx context.Context, tenantID, schemaName, poID string) (*PurchaseOrder, error) {
+	po, err := s.repo.GetByID(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, fmt.Errorf("get purchase order: %w", err)
+	}
+	return po, nil
+}
+
+// List retrieves purchase orders with optional filtering
+func (s *Service) List(ctx context.Context, tenantID, schemaName string, filter *PurchaseOrderFilter) ([]PurchaseOrder, error) {
+	orders, err := s.repo.List(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list purchase orders: %w", err)
+	}
+	return orders, nil
+}
+
+// Approve approves a draft purchase order so goods can be received against it
+func (s *Service) Approve(ctx context.Context, tenantID, schemaName, poID, userID string) error {
+	po, err := s.repo.GetByID(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return fmt.Errorf("get purchase order: %w", err)
+	}
+	if po.Status != PurchaseOrderStatusDraft {
+		return fmt.Errorf("purchase order is not in draft status")
+	}
+	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, poID, PurchaseOrderStatusApproved, userID); err != nil {
+		return fmt.Errorf("approve purchase order: %w", err)
+	}
+	return nil
+}
+
+// Cancel cancels a draft or approved purchase order that has no receipts
+func (s *Service) Cancel(ctx context.Context, tenantID, schemaName, poID, userID string) error {
+	po, err := s.repo.GetByID(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return fmt.Errorf("get purchase order: %w", err)
+	}
+	if po.Status != PurchaseOrderStatusDraft && po.Status != PurchaseOrderStatusApproved {
+		return fmt.Errorf("only draft or approved purchase orders can be canceled")
+	}
+	if po.HasReceipts() {
+		return fmt.Errorf("purchase order with received goods cannot be canceled")
+	}
+	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, poID, PurchaseOrderStatusCanceled, userID); err != nil {
+		return fmt.Errorf("cancel purchase order: %w", err)
+	}
+	return nil
+}
+
+// ReceiveGoods books a goods receipt note against an approved purchase order.
+// Received quantities are added to stock in the receiving warehouse at the
+// received cost, and the cost is posted to inventory against the accrual
+// account until the supplier invoice is matched.
+func (s *Service) ReceiveGoods(ctx context.Context, tenantID, schemaName, poID string, req *ReceiveGoodsRequest) (*GoodsReceipt, error) {
+	if req == nil || len(req.Lines) == 0 {
+		return nil, fmt.Errorf("at least one receipt line is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for goods receipts")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to receive goods")
+	}
+	accrualAccountID := strings.TrimSpace(req.AccrualAccountID)
+	if accrualAccountID == "" {
+		return nil, fmt.Errorf("accrual_account_id is required")
+	}
+
+	po, err := s.repo.GetByID(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, fmt.Errorf("get purchase order: %w", err)
+	}
+	if po.Status != PurchaseOrderStatusApproved && po.Status != PurchaseOrderStatusPartiallyReceived {
+		return nil, fmt.Errorf("goods can only be received against approved purchase orders")
+	}
+
+	warehouseID := strings.TrimSpace(req.WarehouseID)
+	if warehouseID == "" {
+		warehouseID = po.WarehouseID
+	}
+	receiptDate := req.ReceiptDate
+	if receiptDate.IsZero() {
+		receiptDate = time.Now()
+	}
+
+	linesByID := make(map[string]*PurchaseOrderLine, len(po.Lines))
+	for i := range po.Lines {
+		linesByID[po.Lines[i].ID] = &po.Lines[i]
+	}
+	pending := make(map[string]decimal.Decimal, len(req.Lines))
+	receipt := &GoodsReceipt{
+		ID:                uuid.New().String(),
+		TenantID:          tenantID,
+		PurchaseOrderID:   po.ID,
+		WarehouseID:       warehouseID,
+		ReceiptDate:       receiptDate,
+		SupplierReference: strings.TrimSpace(req.SupplierReference),
+		Notes:             req.Notes,
+		AccrualAccountID:  accrualAccountID,
+		CreatedBy:         userID,
+		CreatedAt:         time.Now(),
+	}
+	stockLines := make([]inventory.ReceiveStockLine, 0, len(req.Lines))
+	for i, reqLine := range req.Lines {
+		poLine, ok := linesByID[strings.TrimSpace(reqLine.PurchaseOrderLineID)]
+		if !ok {
+			return nil, fmt.Errorf("line %d: purchase order line %s not found", i+1, reqLine.PurchaseOrderLineID)
+		}
+		if reqLine.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("line %d: quantity must be positive", i+1)
+		}
+		open := poLine.OpenReceiptQuantity().Sub(pending[poLine.ID])
+		if reqLine.Quantity.GreaterThan(open) {
+			return nil, fmt.Errorf("line %d: quantity %s exceeds open quantity %s on purchase order line %d", i+1, reqLine.Quantity.String(), open.String(), poLine.LineNumber)
+		}
+		pending[poLine.ID] = pending[poLine.ID].Add(reqLine.Quantity)
+
+		unitCost := poLine.NetUnitPrice().Mul(po.ExchangeRate).Round(4)
+		if reqLine.UnitCost != nil {
+			if reqLine.UnitCost.IsNegative() {
+				return nil, fmt.Errorf("line %d: unit cost cannot be negative", i+1)
+			}
+			unitCost = *reqLine.UnitCost
+		}
+		receipt.Lines = append(receipt.Lines, GoodsReceiptLine{
+			ID:                  uuid.New().String(),
+			TenantID:            tenantID,
+			GoodsReceiptID:      receipt.ID,
+			PurchaseOrderLineID: poLine.ID,
+			ProductID:           poLine.ProductID,
+			Quantity:            reqLine.Quantity,
+			UnitCost:            unitCost,
+			TotalCost:           reqLine.Quantity.Mul(unitCost).Round(2),
+			LotNumber:           strings.TrimSpace(reqLine.LotNumber),
+			SerialNumber:        strings.TrimSpace(reqLine.SerialNumber),
+			ExpiryDate:          strings.TrimSpace(reqLine.ExpiryDate),
+		})
+		stockLines = append(stockLines, inventory.ReceiveStockLine{
+			ProductID:    poLine.ProductID,
+			Quantity:     reqLine.Quantity,
+			UnitCost:     unitCost,
+			LotNumber:    reqLine.LotNumber,
+			SerialNumber: reqLine.SerialNumber,
+			ExpiryDate:   reqLine.ExpiryDate,
+		})
+	}
+
+	for _, line := range receipt.Lines {
+		poLine := linesByID[line.PurchaseOrderLineID]
+		poLine.ReceivedQuantity = poLine.ReceivedQuantity.Add(line.Quantity)
+		poLine.ReceivedAmount = poLine.ReceivedAmount.Add(line.TotalCost)
+	}
+	status := PurchaseOrderStatusPartiallyReceived
+	if po.FullyReceived() {
+		status = PurchaseOrderStatusReceived
+	}
+
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		receiptNumber, err := tx.repo.GenerateReceiptNumber(ctx, schemaName, tenantID)
+		if err != nil {
+			return fmt.Errorf("generate goods receipt number: %w", err)
+		}
+		receipt.ReceiptNumber = receiptNumber
+
+		stockResult, err := tx.stock.ReceiveStock(ctx, tenantID, schemaName, &inventory.ReceiveStockRequest{
+			WarehouseID:        warehouseID,
+			ReceiptDate:        receiptDate,
+			Reference:          receiptNumber + " / " + po.PONumber,
+			SourceType:         GoodsReceiptSourceType,
+			SourceID:           receipt.ID,
+			AccrualAccountID:   accrualAccountID,
+			InventoryAccountID: req.InventoryAccountID,
+			Lines:              stockLines,
+			UserID:             userID,
+		})
+		if err != nil {
+			return fmt.Errorf("receive stock: %w", err)
+		}
+		receipt.TotalCost = stockResult.TotalCost
+		receipt.JournalEntryID = nil
+		if stockResult.JournalID != "" {
+			journalID := stockResult.JournalID
+			receipt.JournalEntryID = &journalID
+		}
+
+		if err := tx.repo.CreateReceipt(ctx, schemaName, receipt, status); err != nil {
+			return fmt.Errorf("create goods receipt: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// MatchInvoice matches a purchase invoice against the received but not yet
+// invoiced quantities of a purchase order. The matched receipt cost is cleared
+// from the accrual account, any difference to the invoiced net amount is
+// posted as a price variance, and the invoice total is credited to accounts
+// payable in one posted journal entry linked to the invoice.
+func (s *Service) MatchInvoice(ctx context.Context, tenantID, schemaName, poID string, req *MatchInvoiceRequest) (*PurchaseInvoiceMatch, error) {
+	if req == nil {
+		return nil, fmt.Errorf("invoice match request is required")
+	}
+	if s.invoices == nil {
+		return nil, fmt.Errorf("invoicing service is unavailable for invoice matching")
+	}
+	if s.ledger == nil {
+		return nil, fmt.Errorf("accounting service is unavailable for invoice matching")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to match invoices")
+	}
+	invoiceID := strings.TrimSpace(req.InvoiceID)
+	if invoiceID == "" {
+		return nil, fmt.Errorf("invoice_id is required")
+	}
+	accrualAccountID := strings.TrimSpace(req.AccrualAccountID)
+	if accrualAccountID == "" {
+		return nil, fmt.Errorf("accrual_account_id is required")
+	}
+	payableAccountID := strings.TrimSpace(req.PayableAccountID)
+	if payableAccountID == "" {
+		return nil, fmt.Errorf("payable_account_id is required")
+	}
+
+	po, err := s.repo.GetByID(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, fmt.Errorf("get purchase order: %w", err)
+	}
+	switch po.Status {
+	case PurchaseOrderStatusPartiallyReceived, PurchaseOrderStatusReceived:
+	default:
+		return nil, fmt.Errorf("invoices can only be matched to purchase orders with received goods")
+	}
+
+	invoice, err := s.invoices.GetByID(ctx, tenantID, schemaName, invoiceID)
+	if err != nil {
+		return nil, fmt.Errorf("get invoice: %w", err)
+	}
+	if err := validateMatchInvoice(po, invoice); err != nil {
+		return nil, err
+	}
+
+	match := &PurchaseInvoiceMatch{
+		ID:                     uuid.New().String(),
+		TenantID:               tenantID,
+		PurchaseOrderID:        po.ID,
+		InvoiceID:              invoice.ID,
+		MatchDate:              invoice.IssueDate,
+		VATAmount:              invoice.BaseVATAmount,
+		AccrualAccountID:       accrualAccountID,
+		PayableAccountID:       payableAccountID,
+		VATAccountID:           strings.TrimSpace(req.VATAccountID),
+		PriceVarianceAccountID: strings.TrimSpace(req.PriceVarianceAccountID),
+		CreatedBy:              userID,
+		CreatedAt:              time.Now(),
+	}
+	if match.MatchDate.IsZero() {
+		match.MatchDate = time.Now()
+	}
+	if req.PeriodLockDate != nil && !match.MatchDate.After(*req.PeriodLockDate) {
+		return nil, fmt.Errorf("%w through %s; invoice date %s must be later", ErrPeriodLocked, req.PeriodLockDate.Format("2006-01-02"), match.MatchDate.Format("2006-01-02"))
+	}
+	lines, err := allocateInvoiceLines(po, invoice)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lines {
+		lines[i].ID = uuid.New().String()
+		lines[i].TenantID = tenantID
+		lines[i].MatchID = match.ID
+		match.AccrualCleared = match.AccrualCleared.Add(lines[i].AccrualCleared)
+		match.InvoicedAmount = match.InvoicedAmount.Add(lines[i].InvoicedAmount)
+		match.PriceVariance = match.PriceVariance.Add(lines[i].PriceVariance)
+	}
+	match.Lines = lines
+	match.PayableAmount = match.InvoicedAmount.Add(match.VATAmount)
+
+	if !match.VATAmount.IsZero() && match.VATAccountID == "" {
+		return nil, fmt.Errorf("vat_account_id is required when the invoice has VAT")
+	}
+	if !match.PriceVariance.IsZero() && match.PriceVarianceAccountID == "" {
+		return nil, fmt.Errorf("price_variance_account_id is required when the invoice differs from the received cost")
+	}
+	if err := s.validateMatchAccounts(ctx, schemaName, tenantID, match); err != nil {
+		return nil, err
+	}
+
+	journalLines := invoiceMatchJournalLines(po, invoice, match)
+	linesByID := make(map[string]*PurchaseOrderLine, len(po.Lines))
+	for i := range po.Lines {
+		linesByID[po.Lines[i].ID] = &po.Lines[i]
+	}
+	for _, line := range match.Lines {
+		poLine := linesByID[line.PurchaseOrderLineID]
+		poLine.InvoicedQuantity = poLine.InvoicedQuantity.Add(line.Quantity)
+	}
+	status := po.Status
+	if po.FullyReceived() && po.FullyInvoiced() {
+		status = PurchaseOrderStatusClosed
+	}
+
+	// The journal and the match (unique per invoice) commit together, so a
+	// concurrent match of the same invoice cannot leave a second posting.
+	err = s.withLedgerTransaction(ctx, func(tx *Service) error {
+		entry, err := tx.ledger.CreateJournalEntry(ctx, schemaName, tenantID, &accounting.CreateJournalEntryRequest{
+			EntryDate:   match.MatchDate,
+			Description: fmt.Sprintf("Purchase invoice %s matched to %s", invoice.InvoiceNumber, po.PONumber),
+			Reference:   invoice.InvoiceNumber,
+			SourceType:  PurchaseInvoiceMatchSourceType,
+			SourceID:    &match.ID,
+			UserID:      userID,
+			Lines:       journalLines,
+		})
+		if err != nil {
+			return fmt.Errorf("create invoice match journal entry: %w", err)
+		}
+		if err := tx.ledger.PostJournalEntry(ctx, schemaName, tenantID, entry.ID, userID, "Purchase invoice matching ledger posting"); err != nil {
+			return fmt.Errorf("post invoice match journal entry: %w", err)
+		}
+		match.JournalEntryID = &entry.ID
+
+		if err := tx.repo.CreateInvoiceMatch(ctx, schemaName, match, status); err != nil {
+			return fmt.Errorf("create invoice match: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return match, nil
+}
+
+// withLedgerTransaction runs fn with a copy of the service bound to one
+// repository, inventory and ledger transaction when the repository supports it.
+func (s *Service) withLedgerTransaction(ctx context.Context, fn func(tx *Service) error) error {
+	transactioner, ok := s.repo.(LedgerTransactionRepository)
+	if !ok {
+		return fn(s)
+	}
+	return transactioner.WithLedgerTransaction(ctx, func(txRepo Repository, stock stockReceiver, ledger accountingPoster) error {
+		tx := *s
+		tx.repo = txRepo
+		if s.stock != nil {
+			tx.stock = stock
+		}
+		if s.ledger != nil {
+			tx.ledger = ledger
+		}
+		return fn(&tx)
+	})
+}
+
+// GetMatching returns the three-way matching status of a purchase order:
+// ordered, received and invoiced quantities and amounts per line, the cost
+// still accrued for goods received but not invoiced, and price variances.
+func (s *Service) GetMatching(ctx context.Context, tenantID, schemaName, poID string) (*PurchaseOrderMatching, error) {
+	po, err := s.repo.GetByID(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, fmt.Errorf("get purchase order: %w", err)
+	}
+	receipts, err := s.repo.ListReceipts(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, fmt.Errorf("list goods receipts: %w", err)
+	}
+	matches, err := s.repo.ListInvoiceMatches(ctx, schemaName, tenantID, poID)
+	if err != nil {
+		return nil, fmt.Errorf("list invoice matches: %w", err)
+	}
+
+	report := &PurchaseOrderMatching{
+		PurchaseOrderID: po.ID,
+		PONumber:        po.PONumber,
+		Status:          po.Status,
+		Currency:        po.Currency,
+		OrderedAmount:   po.Subtotal,
+		Lines:           make([]PurchaseOrderMatchingLine, 0, len(po.Lines)),
+		Receipts:        receipts,
+		InvoiceMatches:  matches,
+	}
+	for i := range po.Lines {
+		line := &po.Lines[i]
+		variance := line.InvoicedAmount.Sub(line.AccrualCleared)
+		matchingLine := PurchaseOrderMatchingLine{
+			LineID:             line.ID,
+			LineNumber:         line.LineNumber,
+			ProductID:          line.ProductID,
+			Description:        line.Description,
+			OrderedQuantity:    line.Quantity,
+			ReceivedQuantity:   line.ReceivedQuantity,
+			InvoicedQuantity:   line.InvoicedQuantity,
+			ReceivedAmount:     line.ReceivedAmount,
+			InvoicedAmount:     line.InvoicedAmount,
+			AccruedNotInvoiced: line.AccruedNotInvoiced(),
+			PriceVariance:      variance,
+			Status:             matchingLineStatus(line, variance),
+		}
+		report.Lines = append(report.Lines, matchingLine)
+		report.ReceivedAmount = report.ReceivedAmount.Add(matchingLine.ReceivedAmount)
+		report.InvoicedAmount = report.InvoicedAmount.Add(matchingLine.InvoicedAmount)
+		report.AccruedNotInvoiced = report.AccruedNotInvoiced.Add(matchingLine.AccruedNotInvoiced)
+		report.PriceVariance = report.PriceVariance.Add(matchingLine.PriceVariance)
+	}
+	return report, nil
+}
+
+func matchingLineStatus(line *PurchaseOrderLine, variance decimal.Decimal) string {
+	switch {
+	case line.InvoicedQuantity.LessThan(line.ReceivedQuantity):
+		return MatchingLineStatusReceivedNotInvoiced
+	case line.ReceivedQuantity.LessThan(line.Quantity):
+		return MatchingLineStatusAwaitingReceipt
+	case !variance.IsZero():
+		return MatchingLineStatusPriceVariance
+	default:
+		return MatchingLineStatusMatched
+	}
+}
+
+func validateMatchInvoice(po *PurchaseOrder, invoice *invoicing.Invoice) error {
+	if invoice.InvoiceType != invoicing.InvoiceTypePurchase {
+		return fmt.Errorf("invoice %s is not a purchase invoice", invoice.InvoiceNumber)
+	}
+	if invoice.Status == invoicing.StatusVoided {
+		return fmt.Errorf("invoice %s is voided", invoice.InvoiceNumber)
+	}
+	if invoice.ContactID != po.ContactID {
+		return fmt.Errorf("invoice %s is from a different supplier than purchase order %s", invoice.InvoiceNumber, po.PONumber)
+	}
+	if !strings.EqualFold(invoice.Currency, po.Currency) {
+		return fmt.Errorf("invoice currency %s does not match purchase order currency %s", invoice.Currency, po.Currency)
+	}
+	if invoice.JournalEntryID != nil {
+		return fmt.Errorf("invoice %s is already posted to the ledger", invoice.InvoiceNumber)
+	}
+	if len(invoice.Lines) == 0 {
+		return fmt.Errorf("invoice %s has no lines to match", invoice.InvoiceNumber)
+	}
+	return nil
+}
+
+// allocateInvoiceLines spreads each invoice line over the purchase order lines
+// for the same product in line order, limited to what has been received but
+// not yet invoiced. Matched receipt cost is cleared in proportion to the
+// uninvoiced quantity, and the invoiced base amounts always add up to the
+// invoice base subtotal.
+func allocateInvoiceLines(po *PurchaseOrder, invoice *invoicing.Invoice) ([]PurchaseInvoiceMatchLine, error) {
+	exchangeRate := invoice.ExchangeRate
+	if exchangeRate.IsZero() {
+		exchangeRate = decimal.NewFromInt(1)
+	}
+	allocatedQty := make(map[string]decimal.Decimal, len(po.Lines))
+	allocatedAccrual := make(map[string]decimal.Decimal, len(po.Lines))
+	lines := make([]PurchaseInvoiceMatchLine, 0, len(invoice.Lines))
+	for i, invoiceLine := range invoice.Lines {
+		if invoiceLine.ProductID == nil || strings.TrimSpace(*invoiceLine.ProductID) == "" {
+			return nil, fmt.Errorf("invoice line %d has no product to match", i+1)
+		}
+		productID := strings.TrimSpace(*invoiceLine.ProductID)
+		if invoiceLine.Quantity.LessThanOrEqual(decimal.Zero) {
+			return nil, fmt.Errorf("invoice line %d: quantity must be positive", i+1)
+		}
+		lineBase := invoiceLine.LineSubtotal.Mul(exchangeRate).Round(2)
+		remaining := invoiceLine.Quantity
+		allocatedBase := decimal.Zero
+		found := false
+		for j := range po.Lines {
+			poLine := &po.Lines[j]
+			if poLine.ProductID != productID {
+				continue
+			}
+			found = true
+			openQty := poLine.UninvoicedQuantity().Sub(allocatedQty[poLine.ID])
+			if openQty.LessThanOrEqual(decimal.Zero) {
+				continue
+			}
+			qty := decimal.Min(remaining, openQty)
+			openAccrual := poLine.AccruedNotInvoiced().Sub(allocatedAccrual[poLine.ID])
+			cleared := openAccrual
+			if qty.LessThan(openQty) {
+				cleared = openAccrual.Mul(qty).Div(openQty).Round(2)
+			}
+			remaining = remaining.Sub(qty)
+			invoiced := lineBase.Mul(qty).Div(invoiceLine.Quantity).Round(2)
+			if remaining.IsZero() {
+				invoiced = lineBase.Sub(allocatedBase)
+			}
+			allocatedBase = allocatedBase.Add(invoiced)
+			allocatedQty[poLine.ID] = allocatedQty[poLine.ID].Add(qty)
+			allocatedAccrual[poLine.ID] = allocatedAccrual[poLine.ID].Add(cleared)
+			lines = append(lines, PurchaseInvoiceMatchLine{
+				PurchaseOrderLineID: poLine.ID,
+				InvoiceLineID:       invoiceLine.ID,
+				Quantity:            qty,
+				AccrualCleared:      cleared,
+				InvoicedAmount:      invoiced,
+				PriceVariance:       invoiced.Sub(cleared),
+			})
+			if remaining.IsZero() {
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invoice line %d: product is not on purchase order %s", i+1, po.PONumber)
+		}
+		if remaining.IsPositive() {
+			return nil, fmt.Errorf("invoice line %d: quantity %s exceeds received but not invoiced quantity by %s", i+1, invoiceLine.Quantity.String(), remaining.String())
+		}
+	}
+
+	// Line rounding can leave a cent between the line amounts and the invoice
+	// base subtotal; book it on the last match line so the journal balances.
+	total := decimal.Zero
+	for _, line := range lines {
+		total = total.Add(line.InvoicedAmount)
+	}
+	if diff := invoice.BaseSubtotal.Sub(total); !diff.IsZero() && len(lines) > 0 {
+		last := &lines[len(lines)-1]
+		last.InvoicedAmount = last.InvoicedAmount.Add(diff)
+		last.PriceVariance = last.InvoicedAmount.Sub(last.AccrualCleared)
+	}
+	return lines, nil
+}
+
+func invoiceMatchJournalLines(po *PurchaseOrder, invoice *invoicing.Invoice, match *PurchaseInvoiceMatch) []accounting.CreateJournalEntryLineReq {
+	description := fmt.Sprintf("Purchase invoice %s / %s", invoice.InvoiceNumber, po.PONumber)
+	one := decimal.NewFromInt(1)
+	lines := make([]accounting.CreateJournalEntryLineReq, 0, 4)
+	if !match.AccrualCleared.IsZero() {
+		lines = append(lines, accounting.CreateJournalEntryLineReq{
+			AccountID:    match.AccrualAccountID,
+			Description:  description + " accrual cleared",
+			DebitAmount:  match.AccrualCleared,
+			Currency:     "EUR",
+			ExchangeRate: one,
+		})
+	}
+	if match.PriceVariance.IsPositive() {
+		lines = append(lines, accounting.CreateJournalEntryLineReq{
+			AccountID:    match.PriceVarianceAccountID,
+			Description:  description + " price variance",
+			DebitAmount:  match.PriceVariance,
+			Currency:     "EUR",
+			ExchangeRate: one,
+		})
+	} else if match.PriceVariance.IsNegative() {
+		lines = append(lines, accounting.CreateJournalEntryLineReq{
+			AccountID:    match.PriceVarianceAccountID,
+			Description:  description + " price variance",
+			CreditAmount: match.PriceVariance.Neg(),
+			Currency:     "EUR",
+			ExchangeRate: one,
+		})
+	}
+	if !match.VATAmount.IsZero() {
+		lines = append(lines, accounting.CreateJournalEntryLineReq{
+			AccountID:    match.VATAccountID,
+			Description:  description + " input VAT",
+			DebitAmount:  match.VATAmount,
+			Currency:     "EUR",
+			ExchangeRate: one,
+		})
+	}
+	return append(lines, accounting.CreateJournalEntryLineReq{
+		AccountID:    match.PayableAccountID,
+		Description:  description + " payable",
+		CreditAmount: match.PayableAmount,
+		Currency:     "EUR",
+		ExchangeRate: one,
+	})
+}
+
+func (s *Service) validateMatchAccounts(ctx context.Context, schemaName, tenantID string, match *PurchaseInvoiceMatch) error {
+	accounts, err := s.ledger.ListAccounts(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return fmt.Errorf("list accounts for invoice matching: %w", err)
+	}
+	byID := make(map[string]accounting.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+	checks := []struct {
+		field     string
+		accountID string
+		want      accounting.AccountType
+	}{
+		{field: "accrual_account_id", accountID: match.AccrualAccountID, want: accounting.AccountTypeLiability},
+		{field: "payable_account_id", accountID: match.PayableAccountID, want: accounting.AccountTypeLiability},
+		{field: "vat_account_id", accountID: match.VATAccountID, want: accounting.AccountTypeAsset},
+		{field: "price_variance_account_id", accountID: match.PriceVarianceAccountID, want: accounting.AccountTypeExpense},
+	}
+	for _, check := range checks {
+		if check.accountID == "" {
+			continue
+		}
+		account, ok := byID[check.accountID]
+		if !ok {
+			return fmt.Errorf("%s was not found", check.field)
+		}
+		if account.AccountType != check.want {
+			return fmt.Errorf("%s must reference a %s account", check.field, check.want)
+		}
+	}
+	return nil
+}
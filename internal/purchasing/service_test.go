@@ -0,0 +1,749 @@
+package purchasing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+)
+
+const (
+	testSupplierID        = "11111111-1111-4111-8111-111111111111"
+	testWarehouseID       = "22222222-2222-4222-8222-222222222222"
+	testProductID         = "33333333-3333-4333-8333-333333333333"
+	testAccrualAccountID  = "44444444-4444-4444-8444-444444444444"
+	testPayableAccountID  = "55555555-5555-4555-8555-555555555555"
+	testVATAccountID      = "66666666-6666-4666-8666-666666666666"
+	testVarianceAccountID = "77777777-7777-4777-8777-777777777777"
+	testInvoiceID         = "88888888-8888-4888-8888-888888888888"
+)
+
+type mockRepository struct {
+	orders      map[string]*PurchaseOrder
+	receipts    []GoodsReceipt
+	matches     []PurchaseInvoiceMatch
+	landedCosts []LandedCost
+	poSeq       int
+	grnSeq      int
+	lcSeq       int
+	matchErr    error
+	receiptErr  error
+	landedErr   error
+	stock       *fakeStock
+	ledger      *fakeLedger
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{orders: make(map[string]*PurchaseOrder)}
+}
+
+func (m *mockRepository) Create(_ context.Context, _ string, po *PurchaseOrder) error {
+	copyPO := *po
+	copyPO.Lines = append([]PurchaseOrderLine(nil), po.Lines...)
+	m.orders[po.ID] = &copyPO
+	return nil
+}
+
+func (m *mockRepository) GetByID(_ context.Context, _, tenantID, poID string) (*PurchaseOrder, error) {
+	po, ok := m.orders[poID]
+	if !ok || po.TenantID != tenantID {
+		return nil, ErrPurchaseOrderNotFound
+	}
+	copyPO := *po
+	copyPO.Lines = append([]PurchaseOrderLine(nil), po.Lines...)
+	return &copyPO, nil
+}
+
+func (m *mockRepository) List(_ context.Context, _, tenantID string, filter *PurchaseOrderFilter) ([]PurchaseOrder, error) {
+	var result []PurchaseOrder
+	for _, po := range m.orders {
+		if po.TenantID != tenantID {
+			continue
+		}
+		if filter != nil && filter.Status != "" && po.Status != filter.Status {
+			continue
+		}
+		result = append(result, *po)
+	}
+	return result, nil
+}
+
+func (m *mockRepository) ListOpen(_ context.Context, _, tenantID string) ([]PurchaseOrder, error) {
+	var result []PurchaseOrder
+	for _, po := range m.orders {
+		if po.TenantID != tenantID {
+			continue
+		}
+		switch po.Status {
+		case PurchaseOrderStatusDraft, PurchaseOrderStatusApproved, PurchaseOrderStatusPartiallyReceived:
+			result = append(result, *po)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) UpdateStatus(_ context.Context, _, tenantID, poID string, status PurchaseOrderStatus, userID string) error {
+	po, ok := m.orders[poID]
+	if !ok || po.TenantID != tenantID {
+		return ErrPurchaseOrderNotFound
+	}
+	po.Status = status
+	if status == PurchaseOrderStatusApproved {
+		po.ApprovedBy = userID
+	}
+	return nil
+}
+
+func (m *mockRepository) GenerateNumber(context.Context, string, string) (string, error) {
+	m.poSeq++
+	return fmt.Sprintf("PO-%05d", m.poSeq), nil
+}
+
+func (m *mockRepository) GenerateReceiptNumber(context.Context, string, string) (string, error) {
+	m.grnSeq++
+	return fmt.Sprintf("GRN-%05d", m.grnSeq), nil
+}
+
+func (m *mockRepository) CreateReceipt(_ context.Context, _ string, receipt *GoodsReceipt, status PurchaseOrderStatus) error {
+	if m.receiptErr != nil {
+		return m.receiptErr
+	}
+	po := m.orders[receipt.PurchaseOrderID]
+	for _, line := range receipt.Lines {
+		for i := range po.Lines {
+			if po.Lines[i].ID == line.PurchaseOrderLineID {
+				po.Lines[i].ReceivedQuantity = po.Lines[i].ReceivedQuantity.Add(line.Quantity)
+				po.Lines[i].ReceivedAmount = po.Lines[i].ReceivedAmount.Add(line.TotalCost)
+			}
+		}
+	}
+	po.Status = status
+	m.receipts = append(m.receipts, *receipt)
+	return nil
+}
+
+func (m *mockRepository) ListReceipts(_ context.Context, _, _, poID string) ([]GoodsReceipt, error) {
+	var result []GoodsReceipt
+	for _, receipt := range m.receipts {
+		if receipt.PurchaseOrderID == poID {
+			result = append(result, receipt)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) CreateInvoiceMatch(_ context.Context, _ string, match *PurchaseInvoiceMatch, status PurchaseOrderStatus) error {
+	if m.matchErr != nil {
+		return m.matchErr
+	}
+	po := m.orders[match.PurchaseOrderID]
+	for _, line := range match.Lines {
+		for i := range po.Lines {
+			if po.Lines[i].ID == line.PurchaseOrderLineID {
+				po.Lines[i].InvoicedQuantity = po.Lines[i].InvoicedQuantity.Add(line.Quantity)
+				po.Lines[i].InvoicedAmount = po.Lines[i].InvoicedAmount.Add(line.InvoicedAmount)
+				po.Lines[i].AccrualCleared = po.Lines[i].AccrualCleared.Add(line.AccrualCleared)
+			}
+		}
+	}
+	po.Status = status
+	m.matches = append(m.matches, *match)
+	return nil
+}
+
+func (m *mockRepository) ListInvoiceMatches(_ context.Context, _, _, poID string) ([]PurchaseInvoiceMatch, error) {
+	var result []PurchaseInvoiceMatch
+	for _, match := range m.matches {
+		if match.PurchaseOrderID == poID {
+			result = append(result, match)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) GetReceipt(_ context.Context, _, tenantID, receiptID string) (*GoodsReceipt, error) {
+	for _, receipt := range m.receipts {
+		if receipt.ID == receiptID && receipt.TenantID == tenantID {
+			copyReceipt := receipt
+			return &copyReceipt, nil
+		}
+	}
+	return nil, ErrGoodsReceiptNotFound
+}
+
+func (m *mockRepository) ListReceiptLines(_ context.Context, _, tenantID string, filter *GoodsReceiptLineFilter) ([]GoodsReceiptLine, error) {
+	contains := func(values []string, value string) bool {
+		for _, candidate := range values {
+			if candidate == value {
+				return true
+			}
+		}
+		return len(values) == 0
+	}
+	var result []GoodsReceiptLine
+	for _, receipt := range m.receipts {
+		for _, line := range receipt.Lines {
+			if line.TenantID != tenantID || !contains(filter.GoodsReceiptIDs, line.GoodsReceiptID) || !contains(filter.LineIDs, line.ID) {
+				continue
+			}
+			if filter.ProductID != "" && line.ProductID != filter.ProductID {
+				continue
+			}
+			if filter.LotNumber != "" && line.LotNumber != filter.LotNumber {
+				continue
+			}
+			result = append(result, line)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRepository) GenerateLandedCostNumber(context.Context, string, string) (string, error) {
+	m.lcSeq++
+	return fmt.Sprintf("LC-%05d", m.lcSeq), nil
+}
+
+func (m *mockRepository) CreateLandedCost(_ context.Context, _ string, landedCost *LandedCost) error {
+	if m.landedErr != nil {
+		return m.landedErr
+	}
+	m.landedCosts = append(m.landedCosts, *landedCost)
+	return nil
+}
+
+func (m *mockRepository) GetLandedCost(_ context.Context, _, tenantID, landedCostID string) (*LandedCost, error) {
+	for _, landedCost := range m.landedCosts {
+		if landedCost.ID == landedCostID && landedCost.TenantID == tenantID {
+			copyLandedCost := landedCost
+			return &copyLandedCost, nil
+		}
+	}
+	return nil, ErrLandedCostNotFound
+}
+
+func (m *mockRepository) ListLandedCosts(_ context.Context, _, tenantID string, filter *LandedCostFilter) ([]LandedCost, error) {
+	result := []LandedCost{}
+	for _, landedCost := range m.landedCosts {
+		if landedCost.TenantID != tenantID {
+			continue
+		}
+		if filter != nil && filter.GoodsReceiptID != "" {
+			found := false
+			for _, line := range landedCost.Lines {
+				found = found || line.GoodsReceiptID == filter.GoodsReceiptID
+			}
+			if !found {
+				continue
+			}
+		}
+		result = append(result, landedCost)
+	}
+	return result, nil
+}
+
+// WithLedgerTransaction restores orders, documents, stock requests and ledger
+// postings when fn fails, like a rolled back database transaction.
+func (m *mockRepository) WithLedgerTransaction(_ context.Context, fn func(txRepo Repository, stock stockReceiver, ledger accountingPoster) error) error {
+	orders := make(map[string]PurchaseOrder, len(m.orders))
+	for id, po := range m.orders {
+		snapshot := *po
+		snapshot.Lines = append([]PurchaseOrderLine(nil), po.Lines...)
+		orders[id] = snapshot
+	}
+	receipts, matches, landedCosts := len(m.receipts), len(m.matches), len(m.landedCosts)
+	stockRequests, landedRequests := len(m.stock.requests), len(m.stock.landedRequests)
+	created, posted := len(m.ledger.created), len(m.ledger.posted)
+
+	if err := fn(m, m.stock, m.ledger); err != nil {
+		for id, snapshot := range orders {
+			snapshot := snapshot
+			m.orders[id] = &snapshot
+		}
+		m.receipts = m.receipts[:receipts]
+		m.matches = m.matches[:matches]
+		m.landedCosts = m.landedCosts[:landedCosts]
+		m.stock.requests = m.stock.requests[:stockRequests]
+		m.stock.landedRequests = m.stock.landedRequests[:landedRequests]
+		m.ledger.created = m.ledger.created[:created]
+		m.ledger.posted = m.ledger.posted[:posted]
+		return err
+	}
+	return nil
+}
+
+type fakeStock struct {
+	products       map[string]*inventory.Product
+	requests       []*inventory.ReceiveStockRequest
+	landedRequests []*inventory.ApplyLandedCostRequest
+	err            error
+}
+
+func (f *fakeStock) GetProductByID(_ context.Context, _, _, productID string) (*inventory.Product, error) {
+	product, ok := f.products[productID]
+	if !ok {
+		return nil, fmt.Errorf("product not found")
+	}
+	return product, nil
+}
+
+func (f *fakeStock) GetWarehouseByID(_ context.Context, _, _, warehouseID string) (*inventory.Warehouse, error) {
+	if warehouseID != testWarehouseID {
+		return nil, fmt.Errorf("warehouse not found")
+	}
+	return &inventory.Warehouse{ID: warehouseID, Name: "Main", IsActive: true}, nil
+}
+
+func (f *fakeStock) ReceiveStock(_ context.Context, _, _ string, req *inventory.ReceiveStockRequest) (*inventory.ReceiveStockResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.requests = append(f.requests, req)
+	total := decimal.Zero
+	for _, line := range req.Lines {
+		total = total.Add(line.Quantity.Mul(line.UnitCost).Round(2))
+	}
+	return &inventory.ReceiveStockResult{WarehouseID: req.WarehouseID, TotalCost: total, JournalID: fmt.Sprintf("receipt-journal-%d", len(f.requests))}, nil
+}
+
+// ApplyLandedCost treats the first unit of every line as already issued.
+func (f *fakeStock) ApplyLandedCost(_ context.Context, _, _ string, req *inventory.ApplyLandedCostRequest) (*inventory.ApplyLandedCostResult, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.landedRequests = append(f.landedRequests, req)
+	result := &inventory.ApplyLandedCostResult{JournalID: fmt.Sprintf("landed-journal-%d", len(f.landedRequests))}
+	for i, line := range req.Lines {
+		issued := decimal.Min(decimal.NewFromInt(1), line.Quantity)
+		cogs := line.Amount.Mul(issued).Div(line.Quantity).Round(2)
+		result.Lines = append(result.Lines, inventory.AppliedLandedCostLine{
+			MovementID:      fmt.Sprintf("movement-%d", i+1),
+			ProductID:       line.ProductID,
+			Quantity:        line.Quantity,
+			OnHandQuantity:  line.Quantity.Sub(issued),
+			IssuedQuantity:  issued,
+			Amount:          line.Amount,
+			InventoryAmount: line.Amount.Sub(cogs),
+			COGSAmount:      cogs,
+		})
+		result.TotalAmount = result.TotalAmount.Add(line.Amount)
+		result.InventoryAmount = result.InventoryAmount.Add(line.Amount.Sub(cogs))
+		result.COGSAmount = result.COGSAmount.Add(cogs)
+	}
+	return result, nil
+}
+
+type fakeInvoices struct {
+	invoices map[string]*invoicing.Invoice
+}
+
+func (f *fakeInvoices) GetByID(_ context.Context, _, _, invoiceID string) (*invoicing.Invoice, error) {
+	invoice, ok := f.invoices[invoiceID]
+	if !ok {
+		return nil, fmt.Errorf("invoice not found")
+	}
+	return invoice, nil
+}
+
+type fakeLedger struct {
+	accounts []accounting.Account
+	created  []*accounting.CreateJournalEntryRequest
+	posted   []string
+}
+
+func (f *fakeLedger) ListAccounts(context.Context, string, string, bool) ([]accounting.Account, error) {
+	return f.accounts, nil
+}
+
+func (f *fakeLedger) CreateJournalEntry(_ context.Context, _, _ string, req *accounting.CreateJournalEntryRequest) (*accounting.JournalEntry, error) {
+	f.created = append(f.created, req)
+	return &accounting.JournalEntry{ID: fmt.Sprintf("match-journal-%d", len(f.created))}, nil
+}
+
+func (f *fakeLedger) PostJournalEntry(_ context.Context, _, _, entryID, _, _ string) error {
+	f.posted = append(f.posted, entryID)
+	return nil
+}
+
+type purchasingFixture struct {
+	svc      *Service
+	repo     *mockRepository
+	stock    *fakeStock
+	invoices *fakeInvoices
+	ledger   *fakeLedger
+}
+
+func newPurchasingFixture() *purchasingFixture {
+	repo := newMockRepository()
+	stock := &fakeStock{products: map[string]*inventory.Product{
+		testProductID: {ID: testProductID, Code: "SKU-1", Name: "Widget", Unit: "pcs", ProductType: inventory.ProductTypeGoods, TrackInventory: true},
+	}}
+	invoices := &fakeInvoices{invoices: make(map[string]*invoicing.Invoice)}
+	ledger := &fakeLedger{accounts: []accounting.Account{
+		{ID: testAccrualAccountID, AccountType: accounting.AccountTypeLiability},
+		{ID: testPayableAccountID, AccountType: accounting.AccountTypeLiability},
+		{ID: testVATAccountID, AccountType: accounting.AccountTypeAsset},
+		{ID: testVarianceAccountID, AccountType: accounting.AccountTypeExpense},
+	}}
+	repo.stock = stock
+	repo.ledger = ledger
+	return &purchasingFixture{
+		svc:      NewServiceWithRepository(repo, stock, invoices, ledger),
+		repo:     repo,
+		stock:    stock,
+		invoices: invoices,
+		ledger:   ledger,
+	}
+}
+
+func (f *purchasingFixture) approvedOrder(t *testing.T) *PurchaseOrder {
+	t.Helper()
+	po, err := f.svc.Create(context.Background(), "tenant-1", "tenant_schema", &CreatePurchaseOrderRequest{
+		ContactID:   testSupplierID,
+		WarehouseID: testWarehouseID,
+		OrderDate:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		Lines: []CreatePurchaseOrderLineRequest{
+			{ProductID: testProductID, Quantity: decimal.NewFromInt(10), UnitPrice: decimal.RequireFromString("5.00"), VATRate: decimal.NewFromInt(22)},
+		},
+		UserID: "user-1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, f.svc.Approve(context.Background(), "tenant-1", "tenant_schema", po.ID, "user-1"))
+	return po
+}
+
+func (f *purchasingFixture) receive(t *testing.T, po *PurchaseOrder, quantity string) *GoodsReceipt {
+	t.Helper()
+	receipt, err := f.svc.ReceiveGoods(context.Background(), "tenant-1", "tenant_schema", po.ID, &ReceiveGoodsRequest{
+		AccrualAccountID: testAccrualAccountID,
+		Lines: []ReceiveGoodsLineRequest{
+			{PurchaseOrderLineID: po.Lines[0].ID, Quantity: decimal.RequireFromString(quantity), LotNumber: "LOT-1"},
+		},
+		UserID: "user-1",
+	})
+	require.NoError(t, err)
+	return receipt
+}
+
+func (f *purchasingFixture) addInvoice(quantity, unitPrice string) *invoicing.Invoice {
+	productID := testProductID
+	line := invoicing.InvoiceLine{
+		ID:        "invoice-line-1",
+		Quantity:  decimal.RequireFromString(quantity),
+		UnitPrice: decimal.RequireFromString(unitPrice),
+		VATRate:   decimal.NewFromInt(22),
+		ProductID: &productID,
+	}
+	invoice := &invoicing.Invoice{
+		ID:            testInvoiceID,
+		InvoiceNumber: "SUP-100",
+		InvoiceType:   invoicing.InvoiceTypePurchase,
+		ContactID:     testSupplierID,
+		IssueDate:     time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Currency:      "EUR",
+		ExchangeRate:  decimal.NewFromInt(1),
+		Status:        invoicing.StatusSent,
+		Lines:         []invoicing.InvoiceLine{line},
+	}
+	invoice.Calculate()
+	f.invoices.invoices[invoice.ID] = invoice
+	return invoice
+}
+
+func TestService_CreateDefaultsAndValidatesProducts(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+
+	assert.Equal(t, "PO-00001", po.PONumber)
+	assert.Equal(t, "EUR", po.Currency)
+	assert.Equal(t, "Widget", po.Lines[0].Description)
+	assert.Equal(t, "pcs", po.Lines[0].Unit)
+	assert.True(t, po.Subtotal.Equal(decimal.RequireFromString("50")))
+	assert.True(t, po.Total.Equal(decimal.RequireFromString("61")))
+	assert.Equal(t, PurchaseOrderStatusApproved, f.repo.orders[po.ID].Status)
+	assert.Equal(t, "user-1", f.repo.orders[po.ID].ApprovedBy)
+
+	f.stock.products[testProductID].TrackInventory = false
+	_, err := f.svc.Create(context.Background(), "tenant-1", "tenant_schema", &CreatePurchaseOrderRequest{
+		ContactID:   testSupplierID,
+		WarehouseID: testWarehouseID,
+		Lines:       []CreatePurchaseOrderLineRequest{{ProductID: testProductID, Quantity: decimal.NewFromInt(1)}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not track inventory")
+}
+
+func TestService_ReceiveGoodsBooksStockAtOrderCost(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+
+	receipt := f.receive(t, po, "4")
+	assert.Equal(t, "GRN-00001", receipt.ReceiptNumber)
+	assert.True(t, receipt.TotalCost.Equal(decimal.RequireFromString("20")))
+	require.NotNil(t, receipt.JournalEntryID)
+	assert.Equal(t, "receipt-journal-1", *receipt.JournalEntryID)
+
+	require.Len(t, f.stock.requests, 1)
+	stockReq := f.stock.requests[0]
+	assert.Equal(t, testWarehouseID, stockReq.WarehouseID)
+	assert.Equal(t, GoodsReceiptSourceType, stockReq.SourceType)
+	assert.Equal(t, receipt.ID, stockReq.SourceID)
+	assert.Equal(t, testAccrualAccountID, stockReq.AccrualAccountID)
+	assert.Equal(t, "LOT-1", stockReq.Lines[0].LotNumber)
+	assert.True(t, stockReq.Lines[0].UnitCost.Equal(decimal.RequireFromString("5")))
+
+	stored := f.repo.orders[po.ID]
+	assert.Equal(t, PurchaseOrderStatusPartiallyReceived, stored.Status)
+	assert.True(t, stored.Lines[0].ReceivedQuantity.Equal(decimal.NewFromInt(4)))
+
+	f.receive(t, po, "6")
+	assert.Equal(t, PurchaseOrderStatusReceived, f.repo.orders[po.ID].Status)
+}
+
+func TestService_ReceiveGoodsRollsBackStockWhenReceiptCannotBeStored(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+	f.repo.receiptErr = fmt.Errorf("connection reset")
+
+	_, err := f.svc.ReceiveGoods(context.Background(), "tenant-1", "tenant_schema", po.ID, &ReceiveGoodsRequest{
+		AccrualAccountID: testAccrualAccountID,
+		Lines:            []ReceiveGoodsLineRequest{{PurchaseOrderLineID: po.Lines[0].ID, Quantity: decimal.NewFromInt(4)}},
+		UserID:           "user-1",
+	})
+	require.EqualError(t, err, "create goods receipt: connection reset")
+	assert.Empty(t, f.stock.requests)
+	assert.Empty(t, f.repo.receipts)
+	assert.Equal(t, PurchaseOrderStatusApproved, f.repo.orders[po.ID].Status)
+
+	f.repo.receiptErr = nil
+	f.receive(t, po, "4")
+	require.Len(t, f.stock.requests, 1)
+}
+
+func TestService_ReceiveGoodsValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*ReceiveGoodsRequest)
+		want   string
+	}{
+		{name: "no lines", mutate: func(req *ReceiveGoodsRequest) { req.Lines = nil }, want: "at least one receipt line"},
+		{name: "missing accrual", mutate: func(req *ReceiveGoodsRequest) { req.AccrualAccountID = "" }, want: "accrual_account_id is required"},
+		{name: "unknown line", mutate: func(req *ReceiveGoodsRequest) { req.Lines[0].PurchaseOrderLineID = "missing" }, want: "purchase order line missing not found"},
+		{name: "over receipt", mutate: func(req *ReceiveGoodsRequest) { req.Lines[0].Quantity = decimal.NewFromInt(11) }, want: "exceeds open quantity 10"},
+		{name: "negative cost", mutate: func(req *ReceiveGoodsRequest) {
+			cost := decimal.NewFromInt(-1)
+			req.Lines[0].UnitCost = &cost
+		}, want: "unit cost cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPurchasingFixture()
+			po := f.approvedOrder(t)
+			req := &ReceiveGoodsRequest{
+				AccrualAccountID: testAccrualAccountID,
+				Lines:            []ReceiveGoodsLineRequest{{PurchaseOrderLineID: po.Lines[0].ID, Quantity: decimal.NewFromInt(1)}},
+				UserID:           "user-1",
+			}
+			tt.mutate(req)
+
+			_, err := f.svc.ReceiveGoods(context.Background(), "tenant-1", "tenant_schema", po.ID, req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, f.stock.requests)
+		})
+	}
+
+	t.Run("draft order", func(t *testing.T) {
+		f := newPurchasingFixture()
+		po, err := f.svc.Create(context.Background(), "tenant-1", "tenant_schema", &CreatePurchaseOrderRequest{
+			ContactID:   testSupplierID,
+			WarehouseID: testWarehouseID,
+			Lines:       []CreatePurchaseOrderLineRequest{{ProductID: testProductID, Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(1)}},
+		})
+		require.NoError(t, err)
+		_, err = f.svc.ReceiveGoods(context.Background(), "tenant-1", "tenant_schema", po.ID, &ReceiveGoodsRequest{
+			AccrualAccountID: testAccrualAccountID,
+			Lines:            []ReceiveGoodsLineRequest{{PurchaseOrderLineID: po.Lines[0].ID, Quantity: decimal.NewFromInt(1)}},
+			UserID:           "user-1",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "approved purchase orders")
+	})
+}
+
+func TestService_MatchInvoiceClearsAccrualAndPostsVariance(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+	f.receive(t, po, "10")
+	f.addInvoice("10", "5.20")
+
+	match, err := f.svc.MatchInvoice(context.Background(), "tenant-1", "tenant_schema", po.ID, &MatchInvoiceRequest{
+		InvoiceID:              testInvoiceID,
+		AccrualAccountID:       testAccrualAccountID,
+		PayableAccountID:       testPayableAccountID,
+		VATAccountID:           testVATAccountID,
+		PriceVarianceAccountID: testVarianceAccountID,
+		UserID:                 "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, match.AccrualCleared.Equal(decimal.RequireFromString("50")))
+	assert.True(t, match.InvoicedAmount.Equal(decimal.RequireFromString("52")))
+	assert.True(t, match.PriceVariance.Equal(decimal.RequireFromString("2")))
+	assert.True(t, match.VATAmount.Equal(decimal.RequireFromString("11.44")))
+	assert.True(t, match.PayableAmount.Equal(decimal.RequireFromString("63.44")))
+	require.NotNil(t, match.JournalEntryID)
+	assert.Equal(t, []string{"match-journal-1"}, f.ledger.posted)
+
+	require.Len(t, f.ledger.created, 1)
+	entry := f.ledger.created[0]
+	assert.Equal(t, PurchaseInvoiceMatchSourceType, entry.SourceType)
+	debits, credits := decimal.Zero, decimal.Zero
+	for _, line := range entry.Lines {
+		debits = debits.Add(line.DebitAmount)
+		credits = credits.Add(line.CreditAmount)
+	}
+	assert.True(t, debits.Equal(credits))
+	assert.Equal(t, testAccrualAccountID, entry.Lines[0].AccountID)
+	assert.Equal(t, testVarianceAccountID, entry.Lines[1].AccountID)
+	assert.Equal(t, testVATAccountID, entry.Lines[2].AccountID)
+	assert.Equal(t, testPayableAccountID, entry.Lines[3].AccountID)
+
+	assert.Equal(t, PurchaseOrderStatusClosed, f.repo.orders[po.ID].Status)
+
+	report, err := f.svc.GetMatching(context.Background(), "tenant-1", "tenant_schema", po.ID)
+	require.NoError(t, err)
+	require.Len(t, report.Lines, 1)
+	assert.Equal(t, MatchingLineStatusPriceVariance, report.Lines[0].Status)
+	assert.True(t, report.AccruedNotInvoiced.IsZero())
+	assert.True(t, report.PriceVariance.Equal(decimal.RequireFromString("2")))
+	assert.Len(t, report.Receipts, 1)
+	assert.Len(t, report.InvoiceMatches, 1)
+}
+
+func TestService_MatchInvoicePartialKeepsRemainingAccrual(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+	f.receive(t, po, "6")
+	f.addInvoice("4", "5.00")
+
+	match, err := f.svc.MatchInvoice(context.Background(), "tenant-1", "tenant_schema", po.ID, &MatchInvoiceRequest{
+		InvoiceID:        testInvoiceID,
+		AccrualAccountID: testAccrualAccountID,
+		PayableAccountID: testPayableAccountID,
+		VATAccountID:     testVATAccountID,
+		UserID:           "user-1",
+	})
+	require.NoError(t, err)
+	assert.True(t, match.AccrualCleared.Equal(decimal.RequireFromString("20")))
+	assert.True(t, match.PriceVariance.IsZero())
+	assert.Len(t, f.ledger.created[0].Lines, 3)
+	assert.Equal(t, PurchaseOrderStatusPartiallyReceived, f.repo.orders[po.ID].Status)
+
+	report, err := f.svc.GetMatching(context.Background(), "tenant-1", "tenant_schema", po.ID)
+	require.NoError(t, err)
+	assert.Equal(t, MatchingLineStatusReceivedNotInvoiced, report.Lines[0].Status)
+	assert.True(t, report.AccruedNotInvoiced.Equal(decimal.RequireFromString("10")))
+	assert.True(t, report.ReceivedAmount.Equal(decimal.RequireFromString("30")))
+	assert.True(t, report.InvoicedAmount.Equal(decimal.RequireFromString("20")))
+}
+
+func TestService_MatchInvoiceRollsBackJournalWhenMatchCannotBeStored(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+	f.receive(t, po, "6")
+	f.addInvoice("6", "5.00")
+	f.repo.matchErr = fmt.Errorf(`duplicate key value violates unique constraint "purchase_invoice_matches_tenant_id_invoice_id_key"`)
+	req := &MatchInvoiceRequest{
+		InvoiceID:        testInvoiceID,
+		AccrualAccountID: testAccrualAccountID,
+		PayableAccountID: testPayableAccountID,
+		VATAccountID:     testVATAccountID,
+		UserID:           "user-1",
+	}
+
+	_, err := f.svc.MatchInvoice(context.Background(), "tenant-1", "tenant_schema", po.ID, req)
+	require.ErrorContains(t, err, "create invoice match")
+	assert.Empty(t, f.ledger.created)
+	assert.Empty(t, f.ledger.posted)
+	assert.Empty(t, f.repo.matches)
+
+	f.repo.matchErr = nil
+	match, err := f.svc.MatchInvoice(context.Background(), "tenant-1", "tenant_schema", po.ID, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{*match.JournalEntryID}, f.ledger.posted)
+}
+
+func TestService_MatchInvoiceValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*invoicing.Invoice, *MatchInvoiceRequest)
+		want   string
+	}{
+		{name: "sales invoice", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) { inv.InvoiceType = invoicing.InvoiceTypeSales }, want: "is not a purchase invoice"},
+		{name: "voided", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) { inv.Status = invoicing.StatusVoided }, want: "is voided"},
+		{name: "other supplier", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) { inv.ContactID = "other" }, want: "different supplier"},
+		{name: "currency", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) { inv.Currency = "USD" }, want: "does not match purchase order currency"},
+		{name: "already posted", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) {
+			journalID := "journal-1"
+			inv.JournalEntryID = &journalID
+		}, want: "already posted"},
+		{name: "line without product", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) { inv.Lines[0].ProductID = nil }, want: "has no product to match"},
+		{name: "over invoiced", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) {
+			inv.Lines[0].Quantity = decimal.NewFromInt(7)
+		}, want: "exceeds received but not invoiced quantity by 1"},
+		{name: "missing variance account", mutate: func(inv *invoicing.Invoice, _ *MatchInvoiceRequest) {
+			inv.Lines[0].UnitPrice = decimal.NewFromInt(6)
+			inv.Calculate()
+		}, want: "price_variance_account_id is required"},
+		{name: "missing VAT account", mutate: func(_ *invoicing.Invoice, req *MatchInvoiceRequest) { req.VATAccountID = "" }, want: "vat_account_id is required"},
+		{name: "payable type", mutate: func(_ *invoicing.Invoice, req *MatchInvoiceRequest) { req.PayableAccountID = testVATAccountID }, want: "payable_account_id must reference a LIABILITY account"},
+		{name: "locked period", mutate: func(inv *invoicing.Invoice, req *MatchInvoiceRequest) {
+			lockDate := inv.IssueDate
+			req.PeriodLockDate = &lockDate
+		}, want: "period locked through"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPurchasingFixture()
+			po := f.approvedOrder(t)
+			f.receive(t, po, "6")
+			invoice := f.addInvoice("6", "5.00")
+			req := &MatchInvoiceRequest{
+				InvoiceID:        testInvoiceID,
+				AccrualAccountID: testAccrualAccountID,
+				PayableAccountID: testPayableAccountID,
+				VATAccountID:     testVATAccountID,
+				UserID:           "user-1",
+			}
+			tt.mutate(invoice, req)
+
+			_, err := f.svc.MatchInvoice(context.Background(), "tenant-1", "tenant_schema", po.ID, req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+			assert.Empty(t, f.ledger.created)
+			assert.Empty(t, f.repo.matches)
+		})
+	}
+}
+
+func TestService_CancelRejectsReceivedOrders(t *testing.T) {
+	f := newPurchasingFixture()
+	po := f.approvedOrder(t)
+	f.receive(t, po, "1")
+
+	err := f.svc.Cancel(context.Background(), "tenant-1", "tenant_schema", po.ID, "user-1")
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "only draft or approved"))
+
+	other := f.approvedOrder(t)
+	require.NoError(t, f.svc.Cancel(context.Background(), "tenant-1", "tenant_schema", other.ID, "user-1"))
+	assert.Equal(t, PurchaseOrderStatusCanceled, f.repo.orders[other.ID].Status)
+}
@@ -0,0 +1,477 @@
+package purchasing
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PurchaseOrderStatus represents the status of a purchase order
+type PurchaseOrderStatus string
+
+const (
+	PurchaseOrderStatusDraft             PurchaseOrderStatus = "DRAFT"
+	PurchaseOrderStatusApproved          PurchaseOrderStatus = "APPROVED"
+	PurchaseOrderStatusPartiallyReceived PurchaseOrderStatus = "PARTIALLY_RECEIVED"
+	PurchaseOrderStatusReceived          PurchaseOrderStatus = "RECEIVED"
+	PurchaseOrderStatusClosed            PurchaseOrderStatus = "CLOSED"
+	PurchaseOrderStatusCanceled          PurchaseOrderStatus = "CANCELED"
+)
+
+const (
+	MatchingLineStatusAwaitingReceipt     = "AWAITING_RECEIPT"
+	MatchingLineStatusReceivedNotInvoiced = "RECEIVED_NOT_INVOICED"
+	MatchingLineStatusMatched             = "MATCHED"
+	MatchingLineStatusPriceVariance       = "PRICE_VARIANCE"
+)
+
+// GoodsReceiptSourceType marks inventory movements and journal entries created by goods receipts.
+const GoodsReceiptSourceType = "GOODS_RECEIPT"
+
+// PurchaseInvoiceMatchSourceType marks journal entries created by purchase invoice matches.
+const PurchaseInvoiceMatchSourceType = "PURCHASE_INVOICE_MATCH"
+
+// LandedCostSourceType marks journal entries created by landed cost allocations.
+const LandedCostSourceType = "LANDED_COST"
+
+// LandedCostAllocationMethod selects how a landed cost is spread over receipt lines
+type LandedCostAllocationMethod string
+
+const (
+	LandedCostAllocationByValue    LandedCostAllocationMethod = "VALUE"
+	LandedCostAllocationByQuantity LandedCostAllocationMethod = "QUANTITY"
+	LandedCostAllocationByWeight   LandedCostAllocationMethod = "WEIGHT"
+)
+
+// PurchaseOrder represents an order placed with a supplier
+type PurchaseOrder struct {
+	ID           string              `json:"id"`
+	TenantID     string              `json:"tenant_id"`
+	PONumber     string              `json:"po_number"`
+	ContactID    string              `json:"contact_id"`
+	WarehouseID  string              `json:"warehouse_id"`
+	OrderDate    time.Time           `json:"order_date"`
+	ExpectedDate *time.Time          `json:"expected_date,omitempty"`
+	Status       PurchaseOrderStatus `json:"status"`
+	Currency     string              `json:"currency"`
+	ExchangeRate decimal.Decimal     `json:"exchange_rate"`
+	Subtotal     decimal.Decimal     `json:"subtotal"`
+	VATAmount    decimal.Decimal     `json:"vat_amount"`
+	Total        decimal.Decimal     `json:"total"`
+	Notes        string              `json:"notes,omitempty"`
+	ApprovedAt   *time.Time          `json:"approved_at,omitempty"`
+	ApprovedBy   string              `json:"approved_by,omitempty"`
+	Lines        []PurchaseOrderLine `json:"lines"`
+	CreatedAt    time.Time           `json:"created_at"`
+	CreatedBy    string              `json:"created_by"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
+
+// PurchaseOrderLine represents a product line on a purchase order with its
+// received and invoiced progress
+type PurchaseOrderLine struct {
+	ID               string          `json:"id"`
+	TenantID         string          `json:"tenant_id"`
+	PurchaseOrderID  string          `json:"purchase_order_id"`
+	LineNumber       int             `json:"line_number"`
+	ProductID        string          `json:"product_id"`
+	Description      string          `json:"description"`
+	Quantity         decimal.Decimal `json:"quantity"`
+	Unit             string          `json:"unit,omitempty"`
+	UnitPrice        decimal.Decimal `json:"unit_price"`
+	DiscountPercent  decimal.Decimal `json:"discount_percent"`
+	VATRate          decimal.Decimal `json:"vat_rate"`
+	LineSubtotal     decimal.Decimal `json:"line_subtotal"`
+	LineVAT          decimal.Decimal `json:"line_vat"`
+	LineTotal        decimal.Decimal `json:"line_total"`
+	ReceivedQuantity decimal.Decimal `json:"received_quantity"`
+	ReceivedAmount   decimal.Decimal `json:"received_amount"`
+	InvoicedQuantity decimal.Decimal `json:"invoiced_quantity"`
+	InvoicedAmount   decimal.Decimal `json:"invoiced_amount"`
+	AccrualCleared   decimal.Decimal `json:"accrual_cleared"`
+}
+
+// Calculate computes the line totals
+func (l *PurchaseOrderLine) Calculate() {
+	grossAmount := l.Quantity.Mul(l.UnitPrice)
+	discountAmount := grossAmount.Mul(l.DiscountPercent).Div(decimal.NewFromInt(100))
+	l.LineSubtotal = grossAmount.Sub(discountAmount).Round(2)
+	l.LineVAT = l.LineSubtotal.Mul(l.VATRate).Div(decimal.NewFromInt(100)).Round(2)
+	l.LineTotal = l.LineSubtotal.Add(l.LineVAT)
+}
+
+// NetUnitPrice returns the unit price after the line discount in order currency
+func (l *PurchaseOrderLine) NetUnitPrice() decimal.Decimal {
+	discount := l.UnitPrice.Mul(l.DiscountPercent).Div(decimal.NewFromInt(100))
+	return l.UnitPrice.Sub(discount)
+}
+
+// OpenReceiptQuantity returns the quantity still expected from the supplier
+func (l *PurchaseOrderLine) OpenReceiptQuantity() decimal.Decimal {
+	open := l.Quantity.Sub(l.ReceivedQuantity)
+	if open.IsNegative() {
+		return decimal.Zero
+	}
+	return open
+}
+
+// UninvoicedQuantity returns the received quantity not yet matched to a supplier invoice
+func (l *PurchaseOrderLine) UninvoicedQuantity() decimal.Decimal {
+	open := l.ReceivedQuantity.Sub(l.InvoicedQuantity)
+	if open.IsNegative() {
+		return decimal.Zero
+	}
+	return open
+}
+
+// AccruedNotInvoiced returns the received cost still held on the accrual account
+func (l *PurchaseOrderLine) AccruedNotInvoiced() decimal.Decimal {
+	return l.ReceivedAmount.Sub(l.AccrualCleared)
+}
+
+// Calculate computes the purchase order totals from lines
+func (po *PurchaseOrder) Calculate() {
+	po.Subtotal = decimal.Zero
+	po.VATAmount = decimal.Zero
+	po.Total = decimal.Zero
+
+	for i := range po.Lines {
+		po.Lines[i].Calculate()
+		po.Subtotal = po.Subtotal.Add(po.Lines[i].LineSubtotal)
+		po.VATAmount = po.VATAmount.Add(po.Lines[i].LineVAT)
+		po.Total = po.Total.Add(po.Lines[i].LineTotal)
+	}
+}
+
+// Validate validates the purchase order
+func (po *PurchaseOrder) Validate() error {
+	if len(po.Lines) == 0 {
+		return errors.New("purchase order must have at least one line")
+	}
+	if po.ContactID == "" {
+		return errors.New("supplier contact is required")
+	}
+	if po.WarehouseID == "" {
+		return errors.New("warehouse is required")
+	}
+	if po.OrderDate.IsZero() {
+		return errors.New("order date is required")
+	}
+	if po.ExchangeRate.LessThanOrEqual(decimal.Zero) {
+		return errors.New("exchange rate must be positive")
+	}
+
+	for i, line := range po.Lines {
+		if line.ProductID == "" {
+			return errors.New("line product is required")
+		}
+		if line.Description == "" {
+			return errors.New("line description is required")
+		}
+		if line.Quantity.LessThanOrEqual(decimal.Zero) {
+			return errors.New("line quantity must be positive")
+		}
+		if line.UnitPrice.LessThan(decimal.Zero) {
+			return errors.New("line unit price cannot be negative")
+		}
+		if line.VATRate.LessThan(decimal.Zero) {
+			return errors.New("line VAT rate cannot be negative")
+		}
+		if line.DiscountPercent.LessThan(decimal.Zero) || line.DiscountPercent.GreaterThan(decimal.NewFromInt(100)) {
+			return errors.New("line discount must be between 0 and 100")
+		}
+		if line.LineNumber != i+1 {
+			po.Lines[i].LineNumber = i + 1
+		}
+	}
+
+	return nil
+}
+
+// FullyReceived reports whether every line has been received in full
+func (po *PurchaseOrder) FullyReceived() bool {
+	for i := range po.Lines {
+		if po.Lines[i].ReceivedQuantity.LessThan(po.Lines[i].Quantity) {
+			return false
+		}
+	}
+	return true
+}
+
+// FullyInvoiced reports whether every received quantity has been matched to a supplier invoice
+func (po *PurchaseOrder) FullyInvoiced() bool {
+	for i := range po.Lines {
+		if po.Lines[i].InvoicedQuantity.LessThan(po.Lines[i].ReceivedQuantity) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasReceipts reports whether any goods have been received against the order
+func (po *PurchaseOrder) HasReceipts() bool {
+	for i := range po.Lines {
+		if po.Lines[i].ReceivedQuantity.IsPositive() {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatePurchaseOrderRequest is the request to create a purchase order
+type CreatePurchaseOrderRequest struct {
+	ContactID    string                           `json:"contact_id"`
+	WarehouseID  string                           `json:"warehouse_id"`
+	OrderDate    time.Time                        `json:"order_date"`
+	ExpectedDate *time.Time                       `json:"expected_date,omitempty"`
+	Currency     string                           `json:"currency,omitempty"`
+	ExchangeRate decimal.Decimal                  `json:"exchange_rate,omitempty"`
+	Notes        string                           `json:"notes,omitempty"`
+	Lines        []CreatePurchaseOrderLineRequest `json:"lines"`
+	UserID       string                           `json:"-"`
+}
+
+// CreatePurchaseOrderLineRequest is a line in the create purchase order request
+type CreatePurchaseOrderLineRequest struct {
+	ProductID       string          `json:"product_id"`
+	Description     string          `json:"description,omitempty"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	Unit            string          `json:"unit,omitempty"`
+	UnitPrice       decimal.Decimal `json:"unit_price"`
+	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
+	VATRate         decimal.Decimal `json:"vat_rate"`
+}
+
+// PurchaseOrderFilter provides filtering options
+type PurchaseOrderFilter struct {
+	Status    PurchaseOrderStatus
+	ContactID string
+	FromDate  *time.Time
+	ToDate    *time.Time
+	Search    string
+}
+
+// GoodsReceipt records goods received against a purchase order
+type GoodsReceipt struct {
+	ID                string             `json:"id"`
+	TenantID          string             `json:"tenant_id"`
+	ReceiptNumber     string             `json:"receipt_number"`
+	PurchaseOrderID   string             `json:"purchase_order_id"`
+	WarehouseID       string             `json:"warehouse_id"`
+	ReceiptDate       time.Time          `json:"receipt_date"`
+	SupplierReference string             `json:"supplier_reference,omitempty"`
+	Notes             string             `json:"notes,omitempty"`
+	TotalCost         decimal.Decimal    `json:"total_cost"`
+	AccrualAccountID  string             `json:"accrual_account_id"`
+	JournalEntryID    *string            `json:"journal_entry_id,omitempty"`
+	Lines             []GoodsReceiptLine `json:"lines"`
+	CreatedBy         string             `json:"created_by"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// GoodsReceiptLine records one received purchase order line
+type GoodsReceiptLine struct {
+	ID                  string          `json:"id"`
+	TenantID            string          `json:"tenant_id"`
+	GoodsReceiptID      string          `json:"goods_receipt_id"`
+	PurchaseOrderLineID string          `json:"purchase_order_line_id"`
+	ProductID           string          `json:"product_id"`
+	Quantity            decimal.Decimal `json:"quantity"`
+	UnitCost            decimal.Decimal `json:"unit_cost"`
+	TotalCost           decimal.Decimal `json:"total_cost"`
+	LotNumber           string          `json:"lot_number,omitempty"`
+	SerialNumber        string          `json:"serial_number,omitempty"`
+	ExpiryDate          string          `json:"expiry_date,omitempty"`
+}
+
+// ReceiveGoodsRequest books a goods receipt note against a purchase order
+type ReceiveGoodsRequest struct {
+	ReceiptDate        time.Time                 `json:"receipt_date,omitempty"`
+	WarehouseID        string                    `json:"warehouse_id,omitempty"`
+	AccrualAccountID   string                    `json:"accrual_account_id"`
+	InventoryAccountID string                    `json:"inventory_account_id,omitempty"`
+	SupplierReference  string                    `json:"supplier_reference,omitempty"`
+	Notes              string                    `json:"notes,omitempty"`
+	Lines              []ReceiveGoodsLineRequest `json:"lines"`
+	UserID             string                    `json:"-"`
+}
+
+// ReceiveGoodsLineRequest receives a quantity of one purchase order line.
+// UnitCost defaults to the discounted order price converted to base currency.
+type ReceiveGoodsLineRequest struct {
+	PurchaseOrderLineID string           `json:"purchase_order_line_id"`
+	Quantity            decimal.Decimal  `json:"quantity"`
+	UnitCost            *decimal.Decimal `json:"unit_cost,omitempty"`
+	LotNumber           string           `json:"lot_number,omitempty"`
+	SerialNumber        string           `json:"serial_number,omitempty"`
+	ExpiryDate          string           `json:"expiry_date,omitempty"`
+}
+
+// PurchaseInvoiceMatch records a supplier invoice matched to a purchase order and its receipts
+type PurchaseInvoiceMatch struct {
+	ID                     string                     `json:"id"`
+	TenantID               string                     `json:"tenant_id"`
+	PurchaseOrderID        string                     `json:"purchase_order_id"`
+	InvoiceID              string                     `json:"invoice_id"`
+	MatchDate              time.Time                  `json:"match_date"`
+	AccrualCleared         decimal.Decimal            `json:"accrual_cleared"`
+	InvoicedAmount         decimal.Decimal            `json:"invoiced_amount"`
+	VATAmount              decimal.Decimal            `json:"vat_amount"`
+	PriceVariance          decimal.Decimal            `json:"price_variance"`
+	PayableAmount          decimal.Decimal            `json:"payable_amount"`
+	AccrualAccountID       string                     `json:"accrual_account_id"`
+	PayableAccountID       string                     `json:"payable_account_id"`
+	VATAccountID           string                     `json:"vat_account_id,omitempty"`
+	PriceVarianceAccountID string                     `json:"price_variance_account_id,omitempty"`
+	JournalEntryID         *string                    `json:"journal_entry_id,omitempty"`
+	Lines                  []PurchaseInvoiceMatchLine `json:"lines"`
+	CreatedBy              string                     `json:"created_by"`
+	CreatedAt              time.Time                  `json:"created_at"`
+}
+
+// PurchaseInvoiceMatchLine records one invoice line quantity matched to a purchase order line
+type PurchaseInvoiceMatchLine struct {
+	ID                  string          `json:"id"`
+	TenantID            string          `json:"tenant_id"`
+	MatchID             string          `json:"match_id"`
+	PurchaseOrderLineID string          `json:"purchase_order_line_id"`
+	InvoiceLineID       string          `json:"invoice_line_id"`
+	Quantity            decimal.Decimal `json:"quantity"`
+	AccrualCleared      decimal.Decimal `json:"accrual_cleared"`
+	InvoicedAmount      decimal.Decimal `json:"invoiced_amount"`
+	PriceVariance       decimal.Decimal `json:"price_variance"`
+}
+
+// MatchInvoiceRequest matches a purchase invoice to a purchase order and its receipts
+type MatchInvoiceRequest struct {
+	InvoiceID              string     `json:"invoice_id"`
+	AccrualAccountID       string     `json:"accrual_account_id"`
+	PayableAccountID       string     `json:"payable_account_id"`
+	VATAccountID           string     `json:"vat_account_id,omitempty"`
+	PriceVarianceAccountID string     `json:"price_variance_account_id,omitempty"`
+	PeriodLockDate         *time.Time `json:"-"`
+	UserID                 string     `json:"-"`
+}
+
+// PurchaseOrderMatching summarizes ordered, received and invoiced amounts for a purchase order
+type PurchaseOrderMatching struct {
+	PurchaseOrderID    string                      `json:"purchase_order_id"`
+	PONumber           string                      `json:"po_number"`
+	Status             PurchaseOrderStatus         `json:"status"`
+	Currency           string                      `json:"currency"`
+	OrderedAmount      decimal.Decimal             `json:"ordered_amount"`
+	ReceivedAmount     decimal.Decimal             `json:"received_amount"`
+	InvoicedAmount     decimal.Decimal             `json:"invoiced_amount"`
+	AccruedNotInvoiced decimal.Decimal             `json:"accrued_not_invoiced"`
+	PriceVariance      decimal.Decimal             `json:"price_variance"`
+	Lines              []PurchaseOrderMatchingLine `json:"lines"`
+	Receipts           []GoodsReceipt              `json:"receipts"`
+	InvoiceMatches     []PurchaseInvoiceMatch      `json:"invoice_matches"`
+}
+
+// PurchaseOrderMatchingLine compares one purchase order line with its receipts and invoices
+type PurchaseOrderMatchingLine struct {
+	LineID             string          `json:"line_id"`
+	LineNumber         int             `json:"line_number"`
+	ProductID          string          `json:"product_id"`
+	Description        string          `json:"description"`
+	OrderedQuantity    decimal.Decimal `json:"ordered_quantity"`
+	ReceivedQuantity   decimal.Decimal `json:"received_quantity"`
+	InvoicedQuantity   decimal.Decimal `json:"invoiced_quantity"`
+	ReceivedAmount     decimal.Decimal `json:"received_amount"`
+	InvoicedAmount     decimal.Decimal `json:"invoiced_amount"`
+	AccruedNotInvoiced decimal.Decimal `json:"accrued_not_invoiced"`
+	PriceVariance      decimal.Decimal `json:"price_variance"`
+	Status             string          `json:"status"`
+}
+
+// LandedCost records a freight, duty or broker invoice allocated onto received stock
+type LandedCost struct {
+	ID                       string                     `json:"id"`
+	TenantID                 string                     `json:"tenant_id"`
+	LandedCostNumber         string                     `json:"landed_cost_number"`
+	InvoiceID                string                     `json:"invoice_id"`
+	CostDate                 time.Time                  `json:"cost_date"`
+	AllocationMethod         LandedCostAllocationMethod `json:"allocation_method"`
+	Amount                   decimal.Decimal            `json:"amount"`
+	VATAmount                decimal.Decimal            `json:"vat_amount"`
+	PayableAmount            decimal.Decimal            `json:"payable_amount"`
+	InventoryAmount          decimal.Decimal            `json:"inventory_amount"`
+	COGSAmount               decimal.Decimal            `json:"cogs_amount"`
+	PayableAccountID         string                     `json:"payable_account_id"`
+	VATAccountID             string                     `json:"vat_account_id,omitempty"`
+	CostOfGoodsSoldAccountID string                     `json:"cost_of_goods_sold_account_id,omitempty"`
+	JournalEntryID           *string                    `json:"journal_entry_id,omitempty"`
+	Notes                    string                     `json:"notes,omitempty"`
+	Lines                    []LandedCostLine           `json:"lines"`
+	CreatedBy                string                     `json:"created_by"`
+	CreatedAt                time.Time                  `json:"created_at"`
+}
+
+// LandedCostLine records the landed cost allocated to one goods receipt line
+// and how much of it went to inventory and to cost of goods sold
+type LandedCostLine struct {
+	ID                 string          `json:"id"`
+	TenantID           string          `json:"tenant_id"`
+	LandedCostID       string          `json:"landed_cost_id"`
+	GoodsReceiptID     string          `json:"goods_receipt_id"`
+	GoodsReceiptLineID string          `json:"goods_receipt_line_id"`
+	MovementID         string          `json:"movement_id,omitempty"`
+	ProductID          string          `json:"product_id"`
+	WarehouseID        string          `json:"warehouse_id"`
+	LotNumber          string          `json:"lot_number,omitempty"`
+	SerialNumber       string          `json:"serial_number,omitempty"`
+	ExpiryDate         string          `json:"expiry_date,omitempty"`
+	Quantity           decimal.Decimal `json:"quantity"`
+	Basis              decimal.Decimal `json:"basis"`
+	Amount             decimal.Decimal `json:"amount"`
+	OnHandQuantity     decimal.Decimal `json:"on_hand_quantity"`
+	IssuedQuantity     decimal.Decimal `json:"issued_quantity"`
+	InventoryAmount    decimal.Decimal `json:"inventory_amount"`
+	COGSAmount         decimal.Decimal `json:"cogs_amount"`
+	PreviousUnitCost   decimal.Decimal `json:"previous_unit_cost"`
+	NewUnitCost        decimal.Decimal `json:"new_unit_cost"`
+}
+
+// AllocateLandedCostRequest allocates a purchase invoice for freight, duty or
+// fees onto goods receipts. Targets are whole receipts, single receipt lines
+// or every received line of a product lot.
+type AllocateLandedCostRequest struct {
+	InvoiceID                string                     `json:"invoice_id"`
+	AllocationMethod         LandedCostAllocationMethod `json:"allocation_method,omitempty"`
+	GoodsReceiptIDs          []string                   `json:"goods_receipt_ids,omitempty"`
+	Lines                    []LandedCostTargetRequest  `json:"lines,omitempty"`
+	PayableAccountID         string                     `json:"payable_account_id"`
+	VATAccountID             string                     `json:"vat_account_id,omitempty"`
+	CostOfGoodsSoldAccountID string                     `json:"cost_of_goods_sold_account_id,omitempty"`
+	InventoryAccountID       string                     `json:"inventory_account_id,omitempty"`
+	Notes                    string                     `json:"notes,omitempty"`
+	PeriodLockDate           *time.Time                 `json:"-"`
+	UserID                   string                     `json:"-"`
+}
+
+// LandedCostTargetRequest selects receipt lines by ID, or by product and lot
+// number. Weight is the total weight of the selected stock and is required
+// for weight allocation.
+type LandedCostTargetRequest struct {
+	GoodsReceiptLineID string           `json:"goods_receipt_line_id,omitempty"`
+	ProductID          string           `json:"product_id,omitempty"`
+	LotNumber          string           `json:"lot_number,omitempty"`
+	Weight             *decimal.Decimal `json:"weight,omitempty"`
+}
+
+// LandedCostFilter provides filtering options for landed costs
+type LandedCostFilter struct {
+	GoodsReceiptID string
+	FromDate       *time.Time
+	ToDate         *time.Time
+}
+
+// GoodsReceiptLineFilter selects goods receipt lines for landed cost allocation
+type GoodsReceiptLineFilter struct {
+	GoodsReceiptIDs []string
+	LineIDs         []string
+	ProductID       string
+	LotNumber       string
+}
@@ -0,0 +1,44 @@
+package quotes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkSigner(t *testing.T) {
+	signer := NewLinkSigner("secret")
+
+	token, err := signer.Sign("tenant-1", "quote-1", 2, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	claims, err := signer.Verify(token)
+	require.NoError(t, err)
+	assert.Equal(t, "tenant-1", claims.TenantID)
+	assert.Equal(t, "quote-1", claims.QuoteID)
+	assert.Equal(t, 2, claims.Revision)
+
+	_, err = NewLinkSigner("other-secret").Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidQuoteLink)
+	_, err = signer.Verify(token + "x")
+	assert.ErrorIs(t, err, ErrInvalidQuoteLink)
+
+	expired, err := signer.Sign("tenant-1", "quote-1", 2, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = signer.Verify(expired)
+	assert.ErrorIs(t, err, ErrInvalidQuoteLink)
+
+	// Tokens signed with the raw application secret, such as access tokens, are rejected
+	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &LinkClaims{
+		TenantID:         "tenant-1",
+		QuoteID:          "quote-1",
+		Revision:         2,
+		TokenKind:        tokenKindQuoteLink,
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = signer.Verify(accessToken)
+	assert.ErrorIs(t, err, ErrInvalidQuoteLink)
+}
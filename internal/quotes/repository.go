@@ -2,14 +2,17 @@ package quotes
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
 	"github.com/HMB-research/open-accounting/internal/models"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
@@ -24,11 +27,20 @@ type Repository interface {
 	GenerateNumber(ctx context.Context, schemaName, tenantID string) (string, error)
 	SetConvertedToOrder(ctx context.Context, schemaName, tenantID, quoteID, orderID string) error
 	SetConvertedToInvoice(ctx context.Context, schemaName, tenantID, quoteID, invoiceID string) error
+	RecordInvoice(ctx context.Context, schemaName string, billing *QuoteInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error
+	ListInvoices(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteInvoice, error)
+	MarkSent(ctx context.Context, schemaName string, revision *QuoteRevision) error
+	ListRevisions(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteRevision, error)
+	GetRevision(ctx context.Context, schemaName, tenantID, quoteID string, revision int) (*QuoteRevision, error)
+	ExpireDue(ctx context.Context, schemaName, tenantID string, asOf time.Time) ([]Quote, error)
 }
 
 // ErrQuoteNotFound is returned when a quote is not found
 var ErrQuoteNotFound = fmt.Errorf("quote not found")
 
+// ErrQuoteRevisionNotFound is returned when a quote revision is not found
+var ErrQuoteRevisionNotFound = errors.New("quote revision not found")
+
 var errQuotesRepositoryDatabaseNotConfigured = errors.New("quotes repository database is not configured")
 
 var newGormDBFromPool = database.NewGormDBFromPool
@@ -166,6 +178,12 @@ func (r *GORMRepository) List(ctx context.Context, schemaName, tenantID string,
 	return quotes, nil
 }
 
+// editableStatuses lists the statuses a quote can be edited from; edits of
+// anything but a draft start a new revision
+func editableStatuses() []string {
+	return []string{string(QuoteStatusDraft), string(QuoteStatusSent), string(QuoteStatusRejected), string(QuoteStatusExpired)}
+}
+
 // Update updates a quote and its lines
 func (r *GORMRepository) Update(ctx context.Context, schemaName string, quote *Quote) error {
 	db, err := r.dbWithContext(ctx)
@@ -177,8 +195,10 @@ func (r *GORMRepository) Update(ctx context.Context, schemaName string, quote *Q
 		if err != nil {
 			return fmt.Errorf("qualify quotes table: %w", err)
 		}
-		result := quotesTable.Where("id = ? AND tenant_id = ? AND status = ?", quote.ID, quote.TenantID, string(QuoteStatusDraft)).
+		result := quotesTable.Where("id = ? AND tenant_id = ? AND status IN ?", quote.ID, quote.TenantID, editableStatuses()).
 			Updates(map[string]interface{}{
+				"status":        string(quote.Status),
+				"revision":      max(quote.Revision, 1),
 				"contact_id":    quote.ContactID,
 				"quote_date":    quote.QuoteDate,
 				"valid_until":   quote.ValidUntil,
@@ -324,6 +344,231 @@ func (r *GORMRepository) SetConvertedToInvoice(ctx context.Context, schemaName,
 	return nil
 }
 
+// RecordInvoice stores the billing record of an invoice raised from a quote,
+// adds the billed quantities to the quote lines and the netted amounts to the
+// prepayments they deduct. The invoice completing the quote also marks it
+// converted, failing when another invoice already did.
+func (r *GORMRepository) RecordInvoice(ctx context.Context, schemaName string, billing *QuoteInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		invoicesTable, err := database.TenantTable(tx, schemaName, "quote_invoices")
+		if err != nil {
+			return fmt.Errorf("qualify quote invoices table: %w", err)
+		}
+		if err := invoicesTable.Create(quoteInvoiceToModel(billing)).Error; err != nil {
+			return fmt.Errorf("insert quote invoice: %w", err)
+		}
+
+		for lineID, quantity := range quantities {
+			quoteLinesTable, _ := database.TenantTable(tx, schemaName, "quote_lines")
+			result := quoteLinesTable.
+				Where("id = ? AND tenant_id = ? AND quote_id = ? AND invoiced_quantity + ? <= quantity", lineID, billing.TenantID, billing.QuoteID, quantity).
+				Update("invoiced_quantity", gorm.Expr("invoiced_quantity + ?", quantity))
+			if result.Error != nil {
+				return fmt.Errorf("update quote line invoiced quantity: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("quote line %s has less uninvoiced quantity than billed", lineID)
+			}
+		}
+
+		for billingID, amount := range deductions {
+			prepaymentsTable, _ := database.TenantTable(tx, schemaName, "quote_invoices")
+			result := prepaymentsTable.
+				Where("id = ? AND tenant_id = ? AND quote_id = ? AND kind = ? AND netted_amount + ? <= subtotal", billingID, billing.TenantID, billing.QuoteID, string(invoicing.BillingKindPrepayment), amount).
+				Update("netted_amount", gorm.Expr("netted_amount + ?", amount))
+			if result.Error != nil {
+				return fmt.Errorf("update quote prepayment netted amount: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("quote prepayment %s has less open amount than deducted", billingID)
+			}
+		}
+
+		if completes {
+			quotesTable, _ := database.TenantTable(tx, schemaName, "quotes")
+			result := quotesTable.
+				Where("id = ? AND tenant_id = ? AND converted_to_invoice_id IS NULL", billing.QuoteID, billing.TenantID).
+				Updates(map[string]interface{}{
+					"status":                  string(QuoteStatusConverted),
+					"converted_to_invoice_id": billing.InvoiceID,
+					"updated_at":              time.Now(),
+				})
+			if result.Error != nil {
+				return fmt.Errorf("set converted to invoice: %w", result.Error)
+			}
+			if result.RowsAffected == 0 {
+				return fmt.Errorf("quote %s has already been converted to an invoice", billing.QuoteID)
+			}
+		}
+		return nil
+	})
+}
+
+// ListInvoices retrieves the billing records of a quote, oldest first
+func (r *GORMRepository) ListInvoices(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteInvoice, error) {
+	db, err := r.tenantTable(ctx, schemaName, "quote_invoices")
+	if err != nil {
+		return nil, fmt.Errorf("qualify quote invoices table: %w", err)
+	}
+
+	var billingModels []models.QuoteInvoice
+	if err := db.
+		Where("tenant_id = ? AND quote_id = ?", tenantID, quoteID).
+		Order("created_at ASC").
+		Order("invoice_number ASC").
+		Find(&billingModels).Error; err != nil {
+		return nil, fmt.Errorf("list quote invoices: %w", err)
+	}
+
+	billings := make([]QuoteInvoice, len(billingModels))
+	for i := range billingModels {
+		billings[i] = *quoteInvoiceFromModel(&billingModels[i])
+	}
+	return billings, nil
+}
+
+// MarkSent freezes a quote revision and marks the draft quote sent
+func (r *GORMRepository) MarkSent(ctx context.Context, schemaName string, revision *QuoteRevision) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	revisionModel, err := quoteRevisionToModel(revision)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		quotesTable, err := database.TenantTable(tx, schemaName, "quotes")
+		if err != nil {
+			return fmt.Errorf("qualify quotes table: %w", err)
+		}
+		result := quotesTable.
+			Where("id = ? AND tenant_id = ? AND status = ? AND revision = ?", revision.QuoteID, revision.TenantID, string(QuoteStatusDraft), revision.Revision).
+			Updates(map[string]interface{}{
+				"status":     string(QuoteStatusSent),
+				"updated_at": time.Now(),
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update status: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrQuoteNotFound
+		}
+
+		revisionsTable, _ := database.TenantTable(tx, schemaName, "quote_revisions")
+		if err := revisionsTable.Create(revisionModel).Error; err != nil {
+			return fmt.Errorf("insert quote revision: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListRevisions retrieves the sent revisions of a quote without their
+// content, oldest first
+func (r *GORMRepository) ListRevisions(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteRevision, error) {
+	db, err := r.tenantTable(ctx, schemaName, "quote_revisions")
+	if err != nil {
+		return nil, fmt.Errorf("qualify quote revisions table: %w", err)
+	}
+
+	var revisionModels []models.QuoteRevision
+	if err := db.
+		Omit("snapshot").
+		Where("tenant_id = ? AND quote_id = ?", tenantID, quoteID).
+		Order("revision ASC").
+		Find(&revisionModels).Error; err != nil {
+		return nil, fmt.Errorf("list quote revisions: %w", err)
+	}
+
+	revisions := make([]QuoteRevision, len(revisionModels))
+	for i := range revisionModels {
+		revisions[i] = *quoteRevisionFromModel(&revisionModels[i])
+	}
+	return revisions, nil
+}
+
+// GetRevision retrieves one sent revision of a quote with its frozen content
+func (r *GORMRepository) GetRevision(ctx context.Context, schemaName, tenantID, quoteID string, revision int) (*QuoteRevision, error) {
+	db, err := r.tenantTable(ctx, schemaName, "quote_revisions")
+	if err != nil {
+		return nil, fmt.Errorf("qualify quote revisions table: %w", err)
+	}
+
+	var revisionModel models.QuoteRevision
+	err = db.Where("tenant_id = ? AND quote_id = ? AND revision = ?", tenantID, quoteID, revision).First(&revisionModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrQuoteRevisionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get quote revision: %w", err)
+	}
+
+	result := quoteRevisionFromModel(&revisionModel)
+	if len(revisionModel.Snapshot) > 0 {
+		var quote Quote
+		if err := json.Unmarshal(revisionModel.Snapshot, &quote); err != nil {
+			return nil, fmt.Errorf("decode quote revision: %w", err)
+		}
+		result.Quote = &quote
+	}
+	return result, nil
+}
+
+// ExpireDue marks sent quotes valid until before asOf as expired and returns them
+func (r *GORMRepository) ExpireDue(ctx context.Context, schemaName, tenantID string, asOf time.Time) ([]Quote, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var quotes []Quote
+	err = db.Transaction(func(tx *gorm.DB) error {
+		quotesTable, err := database.TenantTable(tx, schemaName, "quotes")
+		if err != nil {
+			return fmt.Errorf("qualify quotes table: %w", err)
+		}
+		var quoteModels []models.Quote
+		if err := quotesTable.
+			Where("tenant_id = ? AND status = ? AND valid_until < ?", tenantID, string(QuoteStatusSent), asOf.Format("2006-01-02")).
+			Order("valid_until ASC").
+			Order("quote_number ASC").
+			Find(&quoteModels).Error; err != nil {
+			return fmt.Errorf("list due quotes: %w", err)
+		}
+		if len(quoteModels) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(quoteModels))
+		for i := range quoteModels {
+			ids[i] = quoteModels[i].ID
+		}
+		updateTable, _ := database.TenantTable(tx, schemaName, "quotes")
+		if err := updateTable.
+			Where("tenant_id = ? AND status = ? AND id IN ?", tenantID, string(QuoteStatusSent), ids).
+			Updates(map[string]interface{}{
+				"status":     string(QuoteStatusExpired),
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+			return fmt.Errorf("expire quotes: %w", err)
+		}
+
+		quotes = make([]Quote, len(quoteModels))
+		for i := range quoteModels {
+			quotes[i] = *quoteFromModel(&quoteModels[i])
+			quotes[i].Status = QuoteStatusExpired
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
+
 func (r *GORMRepository) listQuoteLines(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteLine, error) {
 	db, err := r.tenantTable(ctx, schemaName, "quote_lines")
 	if err != nil {
@@ -354,6 +599,7 @@ func quoteToModel(quote *Quote) *models.Quote {
 		QuoteDate:            quote.QuoteDate,
 		ValidUntil:           quote.ValidUntil,
 		Status:               string(quote.Status),
+		Revision:             quote.Revision,
 		Currency:             quote.Currency,
 		ExchangeRate:         models.Decimal{Decimal: quote.ExchangeRate},
 		Subtotal:             models.Decimal{Decimal: quote.Subtotal},
@@ -377,6 +623,7 @@ func quoteFromModel(quote *models.Quote) *Quote {
 		QuoteDate:            quote.QuoteDate,
 		ValidUntil:           quote.ValidUntil,
 		Status:               QuoteStatus(quote.Status),
+		Revision:             quote.Revision,
 		Currency:             quote.Currency,
 		ExchangeRate:         quote.ExchangeRate.Decimal,
 		Subtotal:             quote.Subtotal.Decimal,
@@ -393,38 +640,114 @@ func quoteFromModel(quote *models.Quote) *Quote {
 
 func quoteLineToModel(line *QuoteLine) *models.QuoteLine {
 	return &models.QuoteLine{
-		ID:              line.ID,
-		TenantID:        line.TenantID,
-		QuoteID:         line.QuoteID,
-		LineNumber:      line.LineNumber,
-		Description:     line.Description,
-		Quantity:        models.Decimal{Decimal: line.Quantity},
-		Unit:            line.Unit,
-		UnitPrice:       models.Decimal{Decimal: line.UnitPrice},
-		DiscountPercent: models.Decimal{Decimal: line.DiscountPercent},
-		VATRate:         models.Decimal{Decimal: line.VATRate},
-		LineSubtotal:    models.Decimal{Decimal: line.LineSubtotal},
-		LineVAT:         models.Decimal{Decimal: line.LineVAT},
-		LineTotal:       models.Decimal{Decimal: line.LineTotal},
-		ProductID:       line.ProductID,
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		QuoteID:          line.QuoteID,
+		LineNumber:       line.LineNumber,
+		Description:      line.Description,
+		Quantity:         models.Decimal{Decimal: line.Quantity},
+		Unit:             line.Unit,
+		UnitPrice:        models.Decimal{Decimal: line.UnitPrice},
+		DiscountPercent:  models.Decimal{Decimal: line.DiscountPercent},
+		VATRate:          models.Decimal{Decimal: line.VATRate},
+		LineSubtotal:     models.Decimal{Decimal: line.LineSubtotal},
+		LineVAT:          models.Decimal{Decimal: line.LineVAT},
+		LineTotal:        models.Decimal{Decimal: line.LineTotal},
+		ProductID:        line.ProductID,
+		InvoicedQuantity: models.Decimal{Decimal: line.InvoicedQuantity},
 	}
 }
 
 func quoteLineFromModel(line *models.QuoteLine) *QuoteLine {
 	return &QuoteLine{
-		ID:              line.ID,
-		TenantID:        line.TenantID,
-		QuoteID:         line.QuoteID,
-		LineNumber:      line.LineNumber,
-		Description:     line.Description,
-		Quantity:        line.Quantity.Decimal,
-		Unit:            line.Unit,
-		UnitPrice:       line.UnitPrice.Decimal,
-		DiscountPercent: line.DiscountPercent.Decimal,
-		VATRate:         line.VATRate.Decimal,
-		LineSubtotal:    line.LineSubtotal.Decimal,
-		LineVAT:         line.LineVAT.Decimal,
-		LineTotal:       line.LineTotal.Decimal,
-		ProductID:       line.ProductID,
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		QuoteID:          line.QuoteID,
+		LineNumber:       line.LineNumber,
+		Description:      line.Description,
+		Quantity:         line.Quantity.Decimal,
+		Unit:             line.Unit,
+		UnitPrice:        line.UnitPrice.Decimal,
+		DiscountPercent:  line.DiscountPercent.Decimal,
+		VATRate:          line.VATRate.Decimal,
+		LineSubtotal:     line.LineSubtotal.Decimal,
+		LineVAT:          line.LineVAT.Decimal,
+		LineTotal:        line.LineTotal.Decimal,
+		ProductID:        line.ProductID,
+		InvoicedQuantity: line.InvoicedQuantity.Decimal,
+	}
+}
+
+func quoteInvoiceToModel(billing *QuoteInvoice) *models.QuoteInvoice {
+	var createdBy *string
+	if billing.CreatedBy != "" {
+		createdBy = &billing.CreatedBy
+	}
+	return &models.QuoteInvoice{
+		ID:                 billing.ID,
+		TenantID:           billing.TenantID,
+		QuoteID:            billing.QuoteID,
+		InvoiceID:          billing.InvoiceID,
+		InvoiceNumber:      billing.InvoiceNumber,
+		Kind:               string(billing.Kind),
+		Percent:            models.Decimal{Decimal: billing.Percent},
+		Subtotal:           models.Decimal{Decimal: billing.Subtotal},
+		VATRate:            models.Decimal{Decimal: billing.VATRate},
+		PrepaymentDeducted: models.Decimal{Decimal: billing.PrepaymentDeducted},
+		NettedAmount:       models.Decimal{Decimal: billing.NettedAmount},
+		CreatedBy:          createdBy,
+		CreatedAt:          billing.CreatedAt,
+	}
+}
+
+func quoteInvoiceFromModel(billing *models.QuoteInvoice) *QuoteInvoice {
+	result := &QuoteInvoice{
+		ID:                 billing.ID,
+		TenantID:           billing.TenantID,
+		QuoteID:            billing.QuoteID,
+		InvoiceID:          billing.InvoiceID,
+		InvoiceNumber:      billing.InvoiceNumber,
+		Kind:               invoicing.BillingKind(billing.Kind),
+		Percent:            billing.Percent.Decimal,
+		Subtotal:           billing.Subtotal.Decimal,
+		VATRate:            billing.VATRate.Decimal,
+		PrepaymentDeducted: billing.PrepaymentDeducted.Decimal,
+		NettedAmount:       billing.NettedAmount.Decimal,
+		CreatedAt:          billing.CreatedAt,
+	}
+	if billing.CreatedBy != nil {
+		result.CreatedBy = *billing.CreatedBy
+	}
+	return result
+}
+
+func quoteRevisionToModel(revision *QuoteRevision) (*models.QuoteRevision, error) {
+	snapshot, err := json.Marshal(revision.Quote)
+	if err != nil {
+		return nil, fmt.Errorf("encode quote revision: %w", err)
+	}
+	return &models.QuoteRevision{
+		ID:         revision.ID,
+		TenantID:   revision.TenantID,
+		QuoteID:    revision.QuoteID,
+		Revision:   revision.Revision,
+		ValidUntil: revision.ValidUntil,
+		Currency:   revision.Currency,
+		Total:      models.Decimal{Decimal: revision.Total},
+		Snapshot:   snapshot,
+		SentAt:     revision.SentAt,
+	}, nil
+}
+
+func quoteRevisionFromModel(revision *models.QuoteRevision) *QuoteRevision {
+	return &QuoteRevision{
+		ID:         revision.ID,
+		TenantID:   revision.TenantID,
+		QuoteID:    revision.QuoteID,
+		Revision:   revision.Revision,
+		ValidUntil: revision.ValidUntil,
+		Currency:   revision.Currency,
+		Total:      revision.Total.Decimal,
+		SentAt:     revision.SentAt,
 	}
 }
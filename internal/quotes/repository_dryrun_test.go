@@ -62,6 +62,7 @@ type quoteDryRunFixtures struct {
 	quote      *models.Quote
 	quotes     []models.Quote
 	quoteLines []models.QuoteLine
+	revision   *models.QuoteRevision
 	sequence   *int
 }
 
@@ -107,6 +108,16 @@ func withQuoteDryRunFixtures(fixtures quoteDryRunFixtures) quoteDryRunDBOption {
 					*dest = append([]models.QuoteLine(nil), fixtures.quoteLines...)
 					tx.RowsAffected = int64(len(fixtures.quoteLines))
 				}
+			case *models.QuoteRevision:
+				if fixtures.revision != nil {
+					*dest = *fixtures.revision
+					tx.RowsAffected = 1
+				}
+			case *[]models.QuoteRevision:
+				if fixtures.revision != nil {
+					*dest = []models.QuoteRevision{*fixtures.revision}
+					tx.RowsAffected = 1
+				}
 			case *int:
 				if fixtures.sequence != nil {
 					*dest = *fixtures.sequence
@@ -240,12 +251,16 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	quoteModel := quoteToModel(quote)
 	lineModel := quoteLineToModel(&quote.Lines[0])
 	sequence := 42
+	revision := &QuoteRevision{ID: "revision-1", TenantID: tenantID, QuoteID: quote.ID, Revision: 1, Currency: "EUR", Total: quote.Total, SentAt: now, Quote: quote}
+	revisionModel, err := quoteRevisionToModel(revision)
+	require.NoError(t, err)
 	capture := &quoteDryRunSQLCapture{}
 	repo := NewGORMRepository(newQuoteDryRunDB(t,
 		withQuoteDryRunFixtures(quoteDryRunFixtures{
 			quote:      quoteModel,
 			quotes:     []models.Quote{*quoteModel},
 			quoteLines: []models.QuoteLine{*lineModel},
+			revision:   revisionModel,
 			sequence:   &sequence,
 		}),
 		withQuoteWave11ScanRows(quoteWave11RowSet{
@@ -291,11 +306,28 @@ func TestGORMRepositoryDryRunOperations(t *testing.T) {
 	require.NoError(t, repo.SetConvertedToOrder(ctx, schemaName, tenantID, quote.ID, "order-1"))
 	require.NoError(t, repo.SetConvertedToInvoice(ctx, schemaName, tenantID, quote.ID, "invoice-1"))
 
+	require.NoError(t, repo.MarkSent(ctx, schemaName, revision))
+	revisions, err := repo.ListRevisions(ctx, schemaName, tenantID, quote.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	assert.Nil(t, revisions[0].Quote)
+	gotRevision, err := repo.GetRevision(ctx, schemaName, tenantID, quote.ID, 1)
+	require.NoError(t, err)
+	require.NotNil(t, gotRevision.Quote)
+	assert.Equal(t, quote.QuoteNumber, gotRevision.Quote.QuoteNumber)
+	require.Len(t, gotRevision.Quote.Lines, 1)
+	expired, err := repo.ExpireDue(ctx, schemaName, tenantID, now)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, QuoteStatusExpired, expired[0].Status)
+
 	capture.assertContains(t, `"tenant_quotes"."quotes"`)
 	capture.assertContains(t, `"tenant_quotes"."quote_lines"`)
 	capture.assertContains(t, `quote_number ILIKE`)
 	capture.assertContains(t, `ORDER BY quote_date DESC`)
 	capture.assertContains(t, `line_number ASC`)
+	capture.assertContains(t, `"tenant_quotes"."quote_revisions"`)
+	capture.assertContains(t, `valid_until <`)
 }
 
 func TestGORMRepositoryDryRunInvalidSchema(t *testing.T) {
@@ -370,6 +402,50 @@ func TestGORMRepositoryDryRunInvalidSchema(t *testing.T) {
 				return repo.SetConvertedToInvoice(ctx, invalidSchema, tenantID, quote.ID, "invoice-1")
 			},
 		},
+		{
+			name: "RecordInvoice",
+			run: func(t *testing.T) error {
+				return repo.RecordInvoice(ctx, invalidSchema, &QuoteInvoice{TenantID: tenantID, QuoteID: quote.ID}, nil, nil, false)
+			},
+		},
+		{
+			name: "ListInvoices",
+			run: func(t *testing.T) error {
+				got, err := repo.ListInvoices(ctx, invalidSchema, tenantID, quote.ID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "MarkSent",
+			run: func(t *testing.T) error {
+				return repo.MarkSent(ctx, invalidSchema, &QuoteRevision{TenantID: tenantID, QuoteID: quote.ID, Revision: 1})
+			},
+		},
+		{
+			name: "ListRevisions",
+			run: func(t *testing.T) error {
+				got, err := repo.ListRevisions(ctx, invalidSchema, tenantID, quote.ID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "GetRevision",
+			run: func(t *testing.T) error {
+				got, err := repo.GetRevision(ctx, invalidSchema, tenantID, quote.ID, 1)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "ExpireDue",
+			run: func(t *testing.T) error {
+				got, err := repo.ExpireDue(ctx, invalidSchema, tenantID, now)
+				assert.Nil(t, got)
+				return err
+			},
+		},
 		{
 			name: "listQuoteLines",
 			run: func(t *testing.T) error {
@@ -439,6 +515,34 @@ func TestGORMRepositoryDryRunErrors(t *testing.T) {
 		require.ErrorIs(t, err, ErrQuoteNotFound)
 	})
 
+	t.Run("MarkSent returns not found when the quote is no longer a draft", func(t *testing.T) {
+		repo := NewGORMRepository(newQuoteDryRunDB(t, withQuoteDryRunUpdateRows(0)))
+
+		err := repo.MarkSent(ctx, schemaName, &QuoteRevision{TenantID: tenantID, QuoteID: quote.ID, Revision: 1, Quote: quote})
+
+		require.ErrorIs(t, err, ErrQuoteNotFound)
+	})
+
+	t.Run("GetRevision maps missing rows to not found", func(t *testing.T) {
+		repo := NewGORMRepository(newQuoteDryRunDB(t, withQuoteDryRunQueryError(gorm.ErrRecordNotFound)))
+
+		got, err := repo.GetRevision(ctx, schemaName, tenantID, quote.ID, 7)
+
+		assert.Nil(t, got)
+		require.ErrorIs(t, err, ErrQuoteRevisionNotFound)
+	})
+
+	t.Run("ExpireDue wraps query errors", func(t *testing.T) {
+		expectedErr := errors.New("due query failed")
+		repo := NewGORMRepository(newQuoteDryRunDB(t, withQuoteDryRunQueryError(expectedErr)))
+
+		got, err := repo.ExpireDue(ctx, schemaName, tenantID, now)
+
+		assert.Nil(t, got)
+		require.ErrorIs(t, err, expectedErr)
+		assert.Contains(t, err.Error(), "list due quotes")
+	})
+
 	t.Run("Create wraps quote line insert errors", func(t *testing.T) {
 		expectedErr := errors.New("line insert failed")
 		repo := NewGORMRepository(newQuoteDryRunDB(t, withQuoteDryRunCreateErrorOnCall(2, expectedErr)))
@@ -449,6 +553,19 @@ func TestGORMRepositoryDryRunErrors(t *testing.T) {
 		assert.ErrorIs(t, err, expectedErr)
 		assert.Contains(t, err.Error(), "insert quote line")
 	})
+
+	t.Run("RecordInvoice marks the quote converted with the completing invoice", func(t *testing.T) {
+		billing := &QuoteInvoice{ID: "billing-1", TenantID: tenantID, QuoteID: quote.ID, InvoiceID: "invoice-1"}
+		capture := &quoteDryRunSQLCapture{}
+		repo := NewGORMRepository(newQuoteDryRunDB(t, withQuoteDryRunUpdateRows(1), withQuoteDryRunSQLCapture(capture)))
+
+		require.NoError(t, repo.RecordInvoice(ctx, schemaName, billing, nil, nil, true))
+		assert.Contains(t, strings.Join(capture.statements, "\n"), "converted_to_invoice_id IS NULL")
+
+		repo = NewGORMRepository(newQuoteDryRunDB(t, withQuoteDryRunUpdateRows(0)))
+		err := repo.RecordInvoice(ctx, schemaName, billing, nil, nil, true)
+		assert.ErrorContains(t, err, "has already been converted to an invoice")
+	})
 }
 
 type quoteWave11RowSet struct {
@@ -641,3 +641,91 @@ func TestRepository_ListQuotes_WithContactFilter(t *testing.T) {
 		t.Errorf("expected contact ID %s, got %s", contact1ID, quotes[0].ContactID)
 	}
 }
+
+func TestRepository_RevisionsAndExpiry(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	tenant := testutil.CreateTestTenant(t, pool)
+
+	repo := NewRepository(pool)
+	ctx := context.Background()
+
+	contactID := uuid.New().String()
+	_, err := pool.Exec(ctx, `
+		INSERT INTO `+tenant.SchemaName+`.contacts (id, tenant_id, name, contact_type, is_active, created_at, updated_at)
+		VALUES ($1, $2, 'Test Customer', 'CUSTOMER', true, NOW(), NOW())
+	`, contactID, tenant.ID)
+	if err != nil {
+		t.Fatalf("Failed to create contact: %v", err)
+	}
+
+	validUntil := time.Now().AddDate(0, 0, -1)
+	quote := &Quote{
+		ID:           uuid.New().String(),
+		TenantID:     tenant.ID,
+		QuoteNumber:  "QUO-REVISION",
+		ContactID:    contactID,
+		QuoteDate:    time.Now().AddDate(0, 0, -10),
+		ValidUntil:   &validUntil,
+		Status:       QuoteStatusDraft,
+		Revision:     1,
+		Currency:     "EUR",
+		ExchangeRate: decimal.NewFromInt(1),
+		Subtotal:     decimal.NewFromFloat(100.00),
+		VATAmount:    decimal.NewFromFloat(22.00),
+		Total:        decimal.NewFromFloat(122.00),
+		CreatedAt:    time.Now(),
+		CreatedBy:    uuid.New().String(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := repo.Create(ctx, tenant.SchemaName, quote); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	revision := &QuoteRevision{
+		ID:         uuid.New().String(),
+		TenantID:   tenant.ID,
+		QuoteID:    quote.ID,
+		Revision:   1,
+		ValidUntil: quote.ValidUntil,
+		Currency:   quote.Currency,
+		Total:      quote.Total,
+		SentAt:     time.Now(),
+		Quote:      quote,
+	}
+	if err := repo.MarkSent(ctx, tenant.SchemaName, revision); err != nil {
+		t.Fatalf("MarkSent failed: %v", err)
+	}
+	if err := repo.MarkSent(ctx, tenant.SchemaName, revision); err != ErrQuoteNotFound {
+		t.Fatalf("expected sending a sent quote to fail with ErrQuoteNotFound, got %v", err)
+	}
+
+	revisions, err := repo.ListRevisions(ctx, tenant.SchemaName, tenant.ID, quote.ID)
+	if err != nil {
+		t.Fatalf("ListRevisions failed: %v", err)
+	}
+	if len(revisions) != 1 || revisions[0].Revision != 1 {
+		t.Fatalf("expected one revision, got %+v", revisions)
+	}
+	frozen, err := repo.GetRevision(ctx, tenant.SchemaName, tenant.ID, quote.ID, 1)
+	if err != nil {
+		t.Fatalf("GetRevision failed: %v", err)
+	}
+	if frozen.Quote == nil || frozen.Quote.QuoteNumber != quote.QuoteNumber {
+		t.Fatalf("expected frozen quote content, got %+v", frozen.Quote)
+	}
+
+	expired, err := repo.ExpireDue(ctx, tenant.SchemaName, tenant.ID, time.Now())
+	if err != nil {
+		t.Fatalf("ExpireDue failed: %v", err)
+	}
+	if len(expired) != 1 || expired[0].ID != quote.ID {
+		t.Fatalf("expected the lapsed quote to expire, got %+v", expired)
+	}
+	retrieved, err := repo.GetByID(ctx, tenant.SchemaName, tenant.ID, quote.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if retrieved.Status != QuoteStatusExpired {
+		t.Errorf("expected status %s, got %s", QuoteStatusExpired, retrieved.Status)
+	}
+}
@@ -99,6 +99,50 @@ func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
 				return repo.SetConvertedToInvoice(ctx, schemaName, tenantID, quoteID, "invoice-1")
 			},
 		},
+		{
+			name: "RecordInvoice",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.RecordInvoice(ctx, schemaName, &QuoteInvoice{TenantID: tenantID, QuoteID: quoteID}, nil, nil, false)
+			},
+		},
+		{
+			name: "ListInvoices",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.ListInvoices(ctx, schemaName, tenantID, quoteID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "MarkSent",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.MarkSent(ctx, schemaName, &QuoteRevision{TenantID: tenantID, QuoteID: quoteID, Revision: 1})
+			},
+		},
+		{
+			name: "ListRevisions",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.ListRevisions(ctx, schemaName, tenantID, quoteID)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "GetRevision",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.GetRevision(ctx, schemaName, tenantID, quoteID, 1)
+				assert.Nil(t, got)
+				return err
+			},
+		},
+		{
+			name: "ExpireDue",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				got, err := repo.ExpireDue(ctx, schemaName, tenantID, time.Now())
+				assert.Nil(t, got)
+				return err
+			},
+		},
 		{
 			name: "tenantTable",
 			run: func(t *testing.T, repo *GORMRepository) error {
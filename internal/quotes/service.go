@@ -2,19 +2,38 @@ package quotes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/pricing"
 )
 
 // Service provides quote operations
 type Service struct {
-	repo Repository
+	repo    Repository
+	pricing pricing.Resolver
+	links   *LinkSigner
 }
 
+const (
+	defaultLinkExpiryDays = 14
+	maxLinkExpiryDays     = 90
+)
+
+var (
+	// ErrQuoteLapsed is returned when a quote is past its valid until date
+	ErrQuoteLapsed = errors.New("quote is past its valid until date")
+	// ErrQuoteNotOpen is returned when a customer answers a quote that is no longer awaiting an answer
+	ErrQuoteNotOpen = errors.New("quote is no longer awaiting an answer")
+)
+
 // NewService creates a new quotes service with an ORM-backed repository.
 func NewService(db *pgxpool.Pool) *Service {
 	return &Service{
@@ -29,6 +48,20 @@ func NewServiceWithRepository(repo Repository) *Service {
 	}
 }
 
+// WithPricing resolves unit prices and discounts from price lists for
+// product lines sent without a unit price.
+func (s *Service) WithPricing(prices pricing.Resolver) *Service {
+	s.pricing = prices
+	return s
+}
+
+// WithLinks enables signed public links through which customers view,
+// accept or reject sent quotes.
+func (s *Service) WithLinks(links *LinkSigner) *Service {
+	s.links = links
+	return s
+}
+
 // Create creates a new quote
 func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *CreateQuoteRequest) (*Quote, error) {
 	quote := &Quote{
@@ -40,6 +73,7 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 		Currency:     req.Currency,
 		ExchangeRate: req.ExchangeRate,
 		Status:       QuoteStatusDraft,
+		Revision:     1,
 		Notes:        req.Notes,
 		CreatedAt:    time.Now(),
 		CreatedBy:    req.UserID,
@@ -58,6 +92,9 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 
 	// Convert request lines to quote lines
 	for i, reqLine := range req.Lines {
+		if err := s.applyProductPrice(ctx, tenantID, schemaName, pricing.Document{ContactID: quote.ContactID, Currency: quote.Currency, Date: quote.QuoteDate}, i+1, &reqLine); err != nil {
+			return nil, err
+		}
 		line := QuoteLine{
 			ID:              uuid.New().String(),
 			TenantID:        tenantID,
@@ -65,7 +102,7 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 			Description:     reqLine.Description,
 			Quantity:        reqLine.Quantity,
 			Unit:            reqLine.Unit,
-			UnitPrice:       reqLine.UnitPrice,
+			UnitPrice:       reqLine.unitPrice(),
 			DiscountPercent: reqLine.DiscountPercent,
 			VATRate:         reqLine.VATRate,
 			ProductID:       reqLine.ProductID,
@@ -115,7 +152,9 @@ func (s *Service) List(ctx context.Context, tenantID, schemaName string, filter
 	return quotes, nil
 }
 
-// Update updates a quote (only drafts)
+// Update updates a quote. Drafts are edited in place; editing a sent,
+// rejected or expired quote leaves the sent revision frozen and starts the
+// next revision as a draft.
 func (s *Service) Update(ctx context.Context, tenantID, schemaName, quoteID string, req *UpdateQuoteRequest) (*Quote, error) {
 	// Get existing quote
 	existing, err := s.repo.GetByID(ctx, schemaName, tenantID, quoteID)
@@ -123,8 +162,13 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, quoteID stri
 		return nil, fmt.Errorf("get quote: %w", err)
 	}
 
-	if existing.Status != QuoteStatusDraft {
-		return nil, fmt.Errorf("only draft quotes can be updated")
+	revision := max(existing.Revision, 1)
+	switch existing.Status {
+	case QuoteStatusDraft:
+	case QuoteStatusSent, QuoteStatusRejected, QuoteStatusExpired:
+		revision++
+	default:
+		return nil, fmt.Errorf("accepted and converted quotes cannot be updated")
 	}
 
 	// Update fields
@@ -146,6 +190,9 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, quoteID stri
 	// Replace lines
 	existing.Lines = nil
 	for i, reqLine := range req.Lines {
+		if err := s.applyProductPrice(ctx, tenantID, schemaName, pricing.Document{ContactID: existing.ContactID, Currency: existing.Currency, Date: existing.QuoteDate}, i+1, &reqLine); err != nil {
+			return nil, err
+		}
 		line := QuoteLine{
 			ID:              uuid.New().String(),
 			TenantID:        tenantID,
@@ -154,7 +201,7 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, quoteID stri
 			Description:     reqLine.Description,
 			Quantity:        reqLine.Quantity,
 			Unit:            reqLine.Unit,
-			UnitPrice:       reqLine.UnitPrice,
+			UnitPrice:       reqLine.unitPrice(),
 			DiscountPercent: reqLine.DiscountPercent,
 			VATRate:         reqLine.VATRate,
 			ProductID:       reqLine.ProductID,
@@ -171,6 +218,9 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, quoteID stri
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
+	existing.Status = QuoteStatusDraft
+	existing.Revision = revision
+
 	// Update via repository
 	if err := s.repo.Update(ctx, schemaName, existing); err != nil {
 		return nil, fmt.Errorf("update quote: %w", err)
@@ -179,7 +229,7 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, quoteID stri
 	return existing, nil
 }
 
-// Send marks a quote as sent
+// Send marks a draft quote as sent and freezes its content as a revision
 func (s *Service) Send(ctx context.Context, tenantID, schemaName, quoteID string) error {
 	quote, err := s.repo.GetByID(ctx, schemaName, tenantID, quoteID)
 	if err != nil {
@@ -189,12 +239,176 @@ func (s *Service) Send(ctx context.Context, tenantID, schemaName, quoteID string
 		return fmt.Errorf("quote not in draft status")
 	}
 
-	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, quoteID, QuoteStatusSent); err != nil {
+	sentAt := time.Now()
+	snapshot := *quote
+	snapshot.Contact = nil
+	snapshot.Status = QuoteStatusSent
+	snapshot.Revision = max(quote.Revision, 1)
+	snapshot.UpdatedAt = sentAt
+	revision := &QuoteRevision{
+		ID:         uuid.New().String(),
+		TenantID:   tenantID,
+		QuoteID:    quoteID,
+		Revision:   snapshot.Revision,
+		ValidUntil: quote.ValidUntil,
+		Currency:   quote.Currency,
+		Total:      quote.Total,
+		SentAt:     sentAt,
+		Quote:      &snapshot,
+	}
+	if err := s.repo.MarkSent(ctx, schemaName, revision); err != nil {
 		return fmt.Errorf("send quote: %w", err)
 	}
 	return nil
 }
 
+// ListRevisions retrieves the sent revisions of a quote, oldest first
+func (s *Service) ListRevisions(ctx context.Context, tenantID, schemaName, quoteID string) ([]QuoteRevision, error) {
+	if _, err := s.repo.GetByID(ctx, schemaName, tenantID, quoteID); err != nil {
+		return nil, fmt.Errorf("get quote: %w", err)
+	}
+	revisions, err := s.repo.ListRevisions(ctx, schemaName, tenantID, quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("list quote revisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetRevision retrieves a sent revision of a quote with the content frozen when it was sent
+func (s *Service) GetRevision(ctx context.Context, tenantID, schemaName, quoteID string, revision int) (*QuoteRevision, error) {
+	result, err := s.repo.GetRevision(ctx, schemaName, tenantID, quoteID, revision)
+	if err != nil {
+		return nil, fmt.Errorf("get quote revision: %w", err)
+	}
+	return result, nil
+}
+
+// ExpireQuotes marks sent quotes whose valid until date is before asOf as
+// expired and returns them
+func (s *Service) ExpireQuotes(ctx context.Context, tenantID, schemaName string, asOf time.Time) ([]Quote, error) {
+	expired, err := s.repo.ExpireDue(ctx, schemaName, tenantID, asOf)
+	if err != nil {
+		return nil, fmt.Errorf("expire quotes: %w", err)
+	}
+	return expired, nil
+}
+
+// CreateLink signs a public link to the current revision of a sent quote.
+// The link expires after the requested days and never after the quote's
+// valid until date.
+func (s *Service) CreateLink(ctx context.Context, tenantID, schemaName, quoteID string, req *CreateQuoteLinkRequest) (*QuoteLink, error) {
+	if s.links == nil {
+		return nil, ErrQuoteLinksNotConfigured
+	}
+	days := defaultLinkExpiryDays
+	if req != nil && req.ExpiresInDays != 0 {
+		days = req.ExpiresInDays
+	}
+	if days < 1 || days > maxLinkExpiryDays {
+		return nil, fmt.Errorf("expires_in_days must be between 1 and %d", maxLinkExpiryDays)
+	}
+
+	quote, err := s.repo.GetByID(ctx, schemaName, tenantID, quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("get quote: %w", err)
+	}
+	if quote.Status != QuoteStatusSent {
+		return nil, fmt.Errorf("only sent quotes can be shared")
+	}
+
+	now := time.Now()
+	expiresAt := now.AddDate(0, 0, days)
+	if quote.ValidUntil != nil {
+		validThrough := endOfValidity(*quote.ValidUntil)
+		if !now.Before(validThrough) {
+			return nil, ErrQuoteLapsed
+		}
+		if expiresAt.After(validThrough) {
+			expiresAt = validThrough
+		}
+	}
+
+	revision := max(quote.Revision, 1)
+	token, err := s.links.Sign(tenantID, quote.ID, revision, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &QuoteLink{
+		Token:     token,
+		QuoteID:   quote.ID,
+		Revision:  revision,
+		ExpiresAt: expiresAt.UTC().Truncate(time.Second),
+	}, nil
+}
+
+// VerifyLink checks a public link token and returns its claims
+func (s *Service) VerifyLink(token string) (*LinkClaims, error) {
+	if s.links == nil {
+		return nil, ErrInvalidQuoteLink
+	}
+	return s.links.Verify(token)
+}
+
+// OpenLink retrieves the quote a public link grants access to. Links to a
+// revision that has since been replaced return ErrQuoteLinkSuperseded.
+func (s *Service) OpenLink(ctx context.Context, schemaName string, claims *LinkClaims) (*Quote, error) {
+	quote, err := s.repo.GetByID(ctx, schemaName, claims.TenantID, claims.QuoteID)
+	if err != nil {
+		return nil, fmt.Errorf("get quote: %w", err)
+	}
+	if max(quote.Revision, 1) != claims.Revision {
+		return nil, ErrQuoteLinkSuperseded
+	}
+	return quote, nil
+}
+
+// AcceptLink accepts the quote revision a public link was sent for
+func (s *Service) AcceptLink(ctx context.Context, schemaName string, claims *LinkClaims) (*Quote, error) {
+	quote, err := s.openForAnswer(ctx, schemaName, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Accept(ctx, claims.TenantID, schemaName, quote.ID); err != nil {
+		return nil, err
+	}
+	quote.Status = QuoteStatusAccepted
+	return quote, nil
+}
+
+// RejectLink rejects the quote revision a public link was sent for
+func (s *Service) RejectLink(ctx context.Context, schemaName string, claims *LinkClaims) (*Quote, error) {
+	quote, err := s.openForAnswer(ctx, schemaName, claims)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Reject(ctx, claims.TenantID, schemaName, quote.ID); err != nil {
+		return nil, err
+	}
+	quote.Status = QuoteStatusRejected
+	return quote, nil
+}
+
+// openForAnswer opens a linked quote that is still sent and within its validity
+func (s *Service) openForAnswer(ctx context.Context, schemaName string, claims *LinkClaims) (*Quote, error) {
+	quote, err := s.OpenLink(ctx, schemaName, claims)
+	if err != nil {
+		return nil, err
+	}
+	if quote.Status == QuoteStatusExpired || (quote.ValidUntil != nil && !time.Now().Before(endOfValidity(*quote.ValidUntil))) {
+		return nil, ErrQuoteLapsed
+	}
+	if quote.Status != QuoteStatusSent {
+		return nil, ErrQuoteNotOpen
+	}
+	return quote, nil
+}
+
+// endOfValidity returns the end of a quote's valid until day
+func endOfValidity(validUntil time.Time) time.Time {
+	year, month, day := validUntil.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+}
+
 // Accept marks a quote as accepted by the customer
 func (s *Service) Accept(ctx context.Context, tenantID, schemaName, quoteID string) error {
 	quote, err := s.repo.GetByID(ctx, schemaName, tenantID, quoteID)
@@ -250,3 +464,127 @@ func (s *Service) ConvertToInvoice(ctx context.Context, tenantID, schemaName, qu
 	}
 	return nil
 }
+
+// PlanInvoice builds the next invoice of an accepted quote and the
+// bookkeeping to record once it exists. Quantity and percentage invoices net
+// off open prepayments; invoicing.ErrNothingToInvoice is returned when
+// nothing is left to bill.
+func (s *Service) PlanInvoice(ctx context.Context, tenantID, schemaName string, quote *Quote, req *ConvertQuoteToInvoiceRequest) (*invoicing.CreateInvoiceRequest, *invoicing.PartialInvoicePlan, error) {
+	billings, err := s.repo.ListInvoices(ctx, schemaName, tenantID, quote.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list quote invoices: %w", err)
+	}
+	var prepayments []invoicing.Prepayment
+	for _, billing := range billings {
+		if billing.Kind != invoicing.BillingKindPrepayment {
+			continue
+		}
+		prepayments = append(prepayments, invoicing.Prepayment{
+			BillingID:     billing.ID,
+			InvoiceID:     billing.InvoiceID,
+			InvoiceNumber: billing.InvoiceNumber,
+			VATRate:       billing.VATRate,
+			Amount:        billing.Subtotal,
+			Netted:        billing.NettedAmount,
+		})
+	}
+	lines := make([]invoicing.BillableLine, len(quote.Lines))
+	for i, line := range quote.Lines {
+		lines[i] = invoicing.BillableLine{
+			ID:               line.ID,
+			LineNumber:       line.LineNumber,
+			Description:      line.Description,
+			Unit:             line.Unit,
+			ProductID:        line.ProductID,
+			Quantity:         line.Quantity,
+			InvoicedQuantity: line.InvoicedQuantity,
+			DueQuantity:      line.Quantity,
+			UnitPrice:        line.UnitPrice,
+			DiscountPercent:  line.DiscountPercent,
+			VATRate:          line.VATRate,
+		}
+	}
+
+	plan, err := invoicing.PlanPartialInvoice("quote "+quote.QuoteNumber, lines, prepayments, &req.PartialInvoiceRequest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	notes := strings.TrimSpace(req.Notes)
+	if notes == "" {
+		notes = quote.Notes
+	}
+	return &invoicing.CreateInvoiceRequest{
+		InvoiceType:  invoicing.InvoiceTypeSales,
+		ContactID:    quote.ContactID,
+		IssueDate:    req.IssueDate,
+		DueDate:      req.DueDate,
+		Currency:     quote.Currency,
+		ExchangeRate: quote.ExchangeRate,
+		Reference:    quote.QuoteNumber,
+		Notes:        notes,
+		Lines:        plan.Lines,
+		UserID:       req.UserID,
+	}, plan, nil
+}
+
+// RecordInvoice records an invoice created from a plan against the quote and
+// marks the quote converted once it is fully invoiced. The quote is updated
+// in place.
+func (s *Service) RecordInvoice(ctx context.Context, tenantID, schemaName string, quote *Quote, plan *invoicing.PartialInvoicePlan, invoice *invoicing.Invoice, userID string) (*QuoteInvoice, error) {
+	billing := &QuoteInvoice{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		QuoteID:            quote.ID,
+		InvoiceID:          invoice.ID,
+		InvoiceNumber:      invoice.InvoiceNumber,
+		Kind:               plan.Kind,
+		Percent:            plan.Percent,
+		Subtotal:           plan.Subtotal,
+		VATRate:            plan.VATRate,
+		PrepaymentDeducted: plan.PrepaymentDeducted,
+		CreatedBy:          userID,
+		CreatedAt:          time.Now(),
+	}
+	if err := s.repo.RecordInvoice(ctx, schemaName, billing, plan.Quantities, plan.Deductions, plan.Completes); err != nil {
+		return nil, fmt.Errorf("record quote invoice: %w", err)
+	}
+	for i := range quote.Lines {
+		quote.Lines[i].InvoicedQuantity = quote.Lines[i].InvoicedQuantity.Add(plan.Quantities[quote.Lines[i].ID])
+	}
+	if plan.Completes {
+		quote.Status = QuoteStatusConverted
+		quote.ConvertedToInvoiceID = &invoice.ID
+	}
+	return billing, nil
+}
+
+// ListInvoices retrieves the invoices billed from a quote
+func (s *Service) ListInvoices(ctx context.Context, tenantID, schemaName, quoteID string) ([]QuoteInvoice, error) {
+	billings, err := s.repo.ListInvoices(ctx, schemaName, tenantID, quoteID)
+	if err != nil {
+		return nil, fmt.Errorf("list quote invoices: %w", err)
+	}
+	return billings, nil
+}
+
+// applyProductPrice fills a product line sent without a unit price from the
+// customer's price lists.
+func (s *Service) applyProductPrice(ctx context.Context, tenantID, schemaName string, doc pricing.Document, lineNumber int, line *CreateQuoteLineRequest) error {
+	priced := pricing.DocumentLine{
+		ProductID:       line.ProductID,
+		Quantity:        line.Quantity,
+		UnitPrice:       line.UnitPrice,
+		DiscountPercent: line.DiscountPercent,
+		Description:     line.Description,
+		Unit:            line.Unit,
+	}
+	if err := pricing.ApplyProductPrice(ctx, s.pricing, tenantID, schemaName, doc, &priced); err != nil {
+		return fmt.Errorf("resolve price for line %d: %w", lineNumber, err)
+	}
+	line.UnitPrice = priced.UnitPrice
+	line.DiscountPercent = priced.DiscountPercent
+	line.Description = priced.Description
+	line.Unit = priced.Unit
+	return nil
+}
@@ -14,6 +14,8 @@ import (
 	"github.com/HMB-research/open-accounting/internal/contacts"
 	"github.com/HMB-research/open-accounting/internal/importrefs"
 	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/pricing"
 )
 
 // MockRepository implements Repository for testing
@@ -29,6 +31,11 @@ type MockRepository struct {
 	DeleteErr     error
 	ConvertOrdErr error
 	ConvertInvErr error
+	Billings      []QuoteInvoice
+	BillingErr    error
+	Revisions     []QuoteRevision
+	RevisionErr   error
+	ExpireErr     error
 }
 
 func NewMockRepository() *MockRepository {
@@ -121,6 +128,35 @@ func (m *MockRepository) SetConvertedToOrder(ctx context.Context, schemaName, te
 	return nil
 }
 
+func (m *MockRepository) RecordInvoice(ctx context.Context, schemaName string, billing *QuoteInvoice, quantities, deductions map[string]decimal.Decimal, completes bool) error {
+	if m.BillingErr != nil {
+		return m.BillingErr
+	}
+	if completes {
+		if err := m.SetConvertedToInvoice(ctx, schemaName, billing.TenantID, billing.QuoteID, billing.InvoiceID); err != nil {
+			return err
+		}
+	}
+	for i := range m.Billings {
+		m.Billings[i].NettedAmount = m.Billings[i].NettedAmount.Add(deductions[m.Billings[i].ID])
+	}
+	m.Billings = append(m.Billings, *billing)
+	return nil
+}
+
+func (m *MockRepository) ListInvoices(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteInvoice, error) {
+	if m.BillingErr != nil {
+		return nil, m.BillingErr
+	}
+	billings := []QuoteInvoice{}
+	for _, billing := range m.Billings {
+		if billing.TenantID == tenantID && billing.QuoteID == quoteID {
+			billings = append(billings, billing)
+		}
+	}
+	return billings, nil
+}
+
 func (m *MockRepository) SetConvertedToInvoice(ctx context.Context, schemaName, tenantID, quoteID, invoiceID string) error {
 	if m.ConvertInvErr != nil {
 		return m.ConvertInvErr
@@ -134,6 +170,60 @@ func (m *MockRepository) SetConvertedToInvoice(ctx context.Context, schemaName,
 	return nil
 }
 
+func (m *MockRepository) MarkSent(ctx context.Context, schemaName string, revision *QuoteRevision) error {
+	if m.UpdateStatErr != nil {
+		return m.UpdateStatErr
+	}
+	quote, ok := m.Quotes[revision.QuoteID]
+	if !ok {
+		return ErrQuoteNotFound
+	}
+	quote.Status = QuoteStatusSent
+	quote.Revision = revision.Revision
+	m.Revisions = append(m.Revisions, *revision)
+	return nil
+}
+
+func (m *MockRepository) ListRevisions(ctx context.Context, schemaName, tenantID, quoteID string) ([]QuoteRevision, error) {
+	if m.RevisionErr != nil {
+		return nil, m.RevisionErr
+	}
+	revisions := []QuoteRevision{}
+	for _, revision := range m.Revisions {
+		if revision.QuoteID == quoteID {
+			revision.Quote = nil
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+func (m *MockRepository) GetRevision(ctx context.Context, schemaName, tenantID, quoteID string, revision int) (*QuoteRevision, error) {
+	if m.RevisionErr != nil {
+		return nil, m.RevisionErr
+	}
+	for i := range m.Revisions {
+		if m.Revisions[i].QuoteID == quoteID && m.Revisions[i].Revision == revision {
+			return &m.Revisions[i], nil
+		}
+	}
+	return nil, ErrQuoteRevisionNotFound
+}
+
+func (m *MockRepository) ExpireDue(ctx context.Context, schemaName, tenantID string, asOf time.Time) ([]Quote, error) {
+	if m.ExpireErr != nil {
+		return nil, m.ExpireErr
+	}
+	var expired []Quote
+	for _, quote := range m.Quotes {
+		if quote.Status == QuoteStatusSent && quote.ValidUntil != nil && quote.ValidUntil.Before(asOf.Truncate(24*time.Hour)) {
+			quote.Status = QuoteStatusExpired
+			expired = append(expired, *quote)
+		}
+	}
+	return expired, nil
+}
+
 func TestNewServiceWithRepository(t *testing.T) {
 	repo := NewMockRepository()
 	svc := NewServiceWithRepository(repo)
@@ -163,7 +253,7 @@ func TestService_Create(t *testing.T) {
 				{
 					Description: "Test product",
 					Quantity:    decimal.NewFromInt(2),
-					UnitPrice:   decimal.NewFromFloat(100.00),
+					UnitPrice:   new(decimal.NewFromFloat(100.00)),
 					VATRate:     decimal.NewFromInt(20),
 				},
 			},
@@ -192,7 +282,7 @@ func TestService_Create(t *testing.T) {
 			Currency:  "",
 			UserID:    "user-1",
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -211,7 +301,7 @@ func TestService_Create(t *testing.T) {
 			QuoteDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -229,7 +319,7 @@ func TestService_Create(t *testing.T) {
 			ContactID: "contact-1",
 			UserID:    "user-1",
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -248,7 +338,7 @@ func TestService_Create(t *testing.T) {
 			QuoteDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -268,7 +358,7 @@ func TestService_Create(t *testing.T) {
 			QuoteDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -288,7 +378,7 @@ func TestService_Create(t *testing.T) {
 			QuoteDate: time.Now(),
 			UserID:    "user-1",
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Test", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
@@ -299,6 +389,63 @@ func TestService_Create(t *testing.T) {
 	})
 }
 
+type fakePriceResolver struct {
+	requests []pricing.ResolvePriceRequest
+}
+
+func (f *fakePriceResolver) ResolvePrice(_ context.Context, _, _ string, req *pricing.ResolvePriceRequest) (*pricing.ResolvedPrice, error) {
+	if req.ProductID != "product-1" {
+		return nil, errors.New("product not found")
+	}
+	f.requests = append(f.requests, *req)
+	return &pricing.ResolvedPrice{
+		ProductID:       req.ProductID,
+		ProductName:     "Widget",
+		Unit:            "pcs",
+		UnitPrice:       decimal.NewFromInt(16),
+		DiscountPercent: decimal.NewFromInt(5),
+	}, nil
+}
+
+func TestService_CreateResolvesProductPrices(t *testing.T) {
+	prices := &fakePriceResolver{}
+	repo := NewMockRepository()
+	svc := NewServiceWithRepository(repo).WithPricing(prices)
+	productID := "product-1"
+	quoteDate := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	quote, err := svc.Create(context.Background(), "tenant-1", "test_schema", &CreateQuoteRequest{
+		ContactID: "contact-1",
+		QuoteDate: quoteDate,
+		Currency:  "USD",
+		UserID:    "user-1",
+		Lines: []CreateQuoteLineRequest{
+			{Quantity: decimal.NewFromInt(10), VATRate: decimal.NewFromInt(24), ProductID: &productID},
+			{Description: "Agreed price", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromInt(12)), ProductID: &productID},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, prices.requests, 1)
+	assert.Equal(t, "contact-1", prices.requests[0].ContactID)
+	assert.Equal(t, "USD", prices.requests[0].Currency)
+	assert.True(t, prices.requests[0].Quantity.Equal(decimal.NewFromInt(10)))
+	assert.Equal(t, quoteDate, prices.requests[0].Date)
+	assert.Equal(t, "Widget", quote.Lines[0].Description)
+	assert.Equal(t, "pcs", quote.Lines[0].Unit)
+	assert.True(t, quote.Lines[0].UnitPrice.Equal(decimal.NewFromInt(16)))
+	assert.True(t, quote.Lines[0].LineSubtotal.Equal(decimal.NewFromInt(152)))
+	assert.True(t, quote.Lines[1].UnitPrice.Equal(decimal.NewFromInt(12)))
+
+	missing := "product-2"
+	_, err = svc.Create(context.Background(), "tenant-1", "test_schema", &CreateQuoteRequest{
+		ContactID: "contact-1",
+		UserID:    "user-1",
+		Lines:     []CreateQuoteLineRequest{{Quantity: decimal.NewFromInt(1), ProductID: &missing}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resolve price for line 1: product not found")
+}
+
 func TestService_ImportCSV(t *testing.T) {
 	t.Run("imports grouped quotes and preserves status", func(t *testing.T) {
 		repo := NewMockRepository()
@@ -802,7 +949,7 @@ func TestService_Update(t *testing.T) {
 			ContactID: "contact-2",
 			QuoteDate: time.Now(),
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Updated", Quantity: decimal.NewFromInt(3), UnitPrice: decimal.NewFromFloat(50)},
+				{Description: "Updated", Quantity: decimal.NewFromInt(3), UnitPrice: new(decimal.NewFromFloat(50))},
 			},
 		}
 
@@ -813,12 +960,13 @@ func TestService_Update(t *testing.T) {
 		assert.Len(t, quote.Lines, 1)
 	})
 
-	t.Run("returns error when updating sent quote", func(t *testing.T) {
+	t.Run("starts a new revision when updating sent quote", func(t *testing.T) {
 		repo := NewMockRepository()
 		repo.Quotes["quote-1"] = &Quote{
 			ID:       "quote-1",
 			TenantID: "tenant-1",
 			Status:   QuoteStatusSent,
+			Revision: 2,
 		}
 		svc := NewServiceWithRepository(repo)
 
@@ -826,14 +974,30 @@ func TestService_Update(t *testing.T) {
 			ContactID: "contact-2",
 			QuoteDate: time.Now(),
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		}
 
-		_, err := svc.Update(context.Background(), "tenant-1", "test_schema", "quote-1", req)
+		quote, err := svc.Update(context.Background(), "tenant-1", "test_schema", "quote-1", req)
+
+		require.NoError(t, err)
+		assert.Equal(t, QuoteStatusDraft, quote.Status)
+		assert.Equal(t, 3, quote.Revision)
+	})
+
+	t.Run("returns error when updating accepted quote", func(t *testing.T) {
+		repo := NewMockRepository()
+		repo.Quotes["quote-1"] = &Quote{
+			ID:       "quote-1",
+			TenantID: "tenant-1",
+			Status:   QuoteStatusAccepted,
+		}
+		svc := NewServiceWithRepository(repo)
+
+		_, err := svc.Update(context.Background(), "tenant-1", "test_schema", "quote-1", &UpdateQuoteRequest{})
 
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "only draft quotes can be updated")
+		assert.Contains(t, err.Error(), "accepted and converted quotes cannot be updated")
 	})
 
 	t.Run("returns error when get fails", func(t *testing.T) {
@@ -880,7 +1044,7 @@ func TestService_Update(t *testing.T) {
 			ContactID: "contact-1",
 			QuoteDate: time.Now(),
 			Lines: []CreateQuoteLineRequest{
-				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(10)},
+				{Description: "Updated", Quantity: decimal.NewFromInt(1), UnitPrice: new(decimal.NewFromFloat(10))},
 			},
 		})
 
@@ -899,6 +1063,9 @@ func TestService_Send(t *testing.T) {
 
 		require.NoError(t, err)
 		assert.Equal(t, QuoteStatusSent, repo.Quotes["quote-1"].Status)
+		require.Len(t, repo.Revisions, 1)
+		assert.Equal(t, 1, repo.Revisions[0].Revision)
+		assert.Equal(t, QuoteStatusSent, repo.Revisions[0].Quote.Status)
 	})
 
 	t.Run("returns error when not draft", func(t *testing.T) {
@@ -1468,3 +1635,203 @@ func TestService_ConvertToInvoice(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+func TestService_PartialInvoicing(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	quote := &Quote{
+		ID:          "quote-1",
+		TenantID:    "tenant-1",
+		QuoteNumber: "Q-00001",
+		ContactID:   "contact-1",
+		Status:      QuoteStatusAccepted,
+		Currency:    "EUR",
+		Notes:       "Website project",
+		Lines: []QuoteLine{
+			{ID: "line-1", LineNumber: 1, Description: "Implementation", Quantity: decimal.NewFromInt(10), UnitPrice: decimal.NewFromInt(100), VATRate: decimal.NewFromInt(24)},
+		},
+	}
+	repo.Quotes[quote.ID] = quote
+	svc := NewServiceWithRepository(repo)
+
+	req := &ConvertQuoteToInvoiceRequest{PartialInvoiceRequest: invoicing.PartialInvoiceRequest{Percent: decimal.NewFromInt(40)}}
+	invoiceReq, plan, err := svc.PlanInvoice(ctx, "tenant-1", "test_schema", quote, req)
+	require.NoError(t, err)
+	assert.Equal(t, invoicing.BillingKindPercentage, plan.Kind)
+	assert.Equal(t, "Q-00001", invoiceReq.Reference)
+	assert.Equal(t, "Website project", invoiceReq.Notes)
+	require.Len(t, invoiceReq.Lines, 1)
+	assert.True(t, invoiceReq.Lines[0].Quantity.Equal(decimal.NewFromInt(4)))
+	billing, err := svc.RecordInvoice(ctx, "tenant-1", "test_schema", quote, plan, &invoicing.Invoice{ID: "invoice-1", InvoiceNumber: "INV-1"}, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "INV-1", billing.InvoiceNumber)
+	assert.Equal(t, QuoteStatusAccepted, quote.Status)
+	assert.True(t, quote.IsInvoiced())
+
+	req.Percent = decimal.NewFromInt(100)
+	invoiceReq, plan, err = svc.PlanInvoice(ctx, "tenant-1", "test_schema", quote, req)
+	require.NoError(t, err)
+	assert.True(t, invoiceReq.Lines[0].Quantity.Equal(decimal.NewFromInt(6)))
+	assert.True(t, plan.Completes)
+	_, err = svc.RecordInvoice(ctx, "tenant-1", "test_schema", quote, plan, &invoicing.Invoice{ID: "invoice-2", InvoiceNumber: "INV-2"}, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, QuoteStatusConverted, quote.Status)
+	assert.Equal(t, "invoice-2", *quote.ConvertedToInvoiceID)
+
+	billings, err := svc.ListInvoices(ctx, "tenant-1", "test_schema", quote.ID)
+	require.NoError(t, err)
+	assert.Len(t, billings, 2)
+
+	repo.BillingErr = errors.New("db error")
+	_, _, err = svc.PlanInvoice(ctx, "tenant-1", "test_schema", quote, &ConvertQuoteToInvoiceRequest{})
+	assert.Error(t, err)
+	_, err = svc.RecordInvoice(ctx, "tenant-1", "test_schema", quote, plan, &invoicing.Invoice{ID: "invoice-3"}, "user-1")
+	assert.Error(t, err)
+	_, err = svc.ListInvoices(ctx, "tenant-1", "test_schema", quote.ID)
+	assert.Error(t, err)
+}
+
+func TestService_Revisions(t *testing.T) {
+	repo := NewMockRepository()
+	svc := NewServiceWithRepository(repo)
+	ctx := context.Background()
+	lines := []CreateQuoteLineRequest{{Description: "Consulting", Quantity: decimal.NewFromInt(2), UnitPrice: new(decimal.NewFromInt(100)), VATRate: decimal.NewFromInt(22)}}
+
+	quote, err := svc.Create(ctx, "tenant-1", "test_schema", &CreateQuoteRequest{ContactID: "contact-1", QuoteDate: time.Now(), Lines: lines, UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, quote.Revision)
+	require.NoError(t, svc.Send(ctx, "tenant-1", "test_schema", quote.ID))
+
+	lines[0].Quantity = decimal.NewFromInt(3)
+	revised, err := svc.Update(ctx, "tenant-1", "test_schema", quote.ID, &UpdateQuoteRequest{ContactID: "contact-1", QuoteDate: quote.QuoteDate, Lines: lines})
+	require.NoError(t, err)
+	assert.Equal(t, 2, revised.Revision)
+	require.NoError(t, svc.Send(ctx, "tenant-1", "test_schema", quote.ID))
+
+	revisions, err := svc.ListRevisions(ctx, "tenant-1", "test_schema", quote.ID)
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	assert.Nil(t, revisions[0].Quote)
+
+	first, err := svc.GetRevision(ctx, "tenant-1", "test_schema", quote.ID, 1)
+	require.NoError(t, err)
+	assert.True(t, first.Total.Equal(decimal.NewFromInt(244)))
+	assert.True(t, first.Quote.Lines[0].Quantity.Equal(decimal.NewFromInt(2)))
+
+	_, err = svc.GetRevision(ctx, "tenant-1", "test_schema", quote.ID, 3)
+	assert.ErrorIs(t, err, ErrQuoteRevisionNotFound)
+	_, err = svc.ListRevisions(ctx, "tenant-1", "test_schema", "missing")
+	assert.ErrorIs(t, err, ErrQuoteNotFound)
+
+	repo.RevisionErr = errors.New("db down")
+	_, err = svc.ListRevisions(ctx, "tenant-1", "test_schema", quote.ID)
+	assert.EqualError(t, err, "list quote revisions: db down")
+}
+
+func TestService_ExpireQuotes(t *testing.T) {
+	repo := NewMockRepository()
+	yesterday := time.Now().AddDate(0, 0, -1)
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	repo.Quotes["lapsed"] = &Quote{ID: "lapsed", Status: QuoteStatusSent, ValidUntil: &yesterday}
+	repo.Quotes["open"] = &Quote{ID: "open", Status: QuoteStatusSent, ValidUntil: &tomorrow}
+	repo.Quotes["draft"] = &Quote{ID: "draft", Status: QuoteStatusDraft, ValidUntil: &yesterday}
+	svc := NewServiceWithRepository(repo)
+
+	expired, err := svc.ExpireQuotes(context.Background(), "tenant-1", "test_schema", time.Now())
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "lapsed", expired[0].ID)
+	assert.Equal(t, QuoteStatusExpired, repo.Quotes["lapsed"].Status)
+	assert.Equal(t, QuoteStatusSent, repo.Quotes["open"].Status)
+
+	repo.ExpireErr = errors.New("db down")
+	_, err = svc.ExpireQuotes(context.Background(), "tenant-1", "test_schema", time.Now())
+	assert.EqualError(t, err, "expire quotes: db down")
+}
+
+func TestService_Links(t *testing.T) {
+	ctx := context.Background()
+	validUntil := time.Now().AddDate(0, 0, 5)
+	newLinkService := func(status QuoteStatus) (*Service, *MockRepository) {
+		repo := NewMockRepository()
+		repo.Quotes["quote-1"] = &Quote{ID: "quote-1", TenantID: "tenant-1", Status: status, Revision: 1, ValidUntil: &validUntil}
+		return NewServiceWithRepository(repo).WithLinks(NewLinkSigner("secret")), repo
+	}
+
+	t.Run("accepts through a link capped at the valid until date", func(t *testing.T) {
+		svc, repo := newLinkService(QuoteStatusSent)
+		link, err := svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", &CreateQuoteLinkRequest{ExpiresInDays: 30})
+		require.NoError(t, err)
+		assert.Equal(t, endOfValidity(validUntil), link.ExpiresAt)
+
+		claims, err := svc.VerifyLink(link.Token)
+		require.NoError(t, err)
+		quote, err := svc.AcceptLink(ctx, "test_schema", claims)
+		require.NoError(t, err)
+		assert.Equal(t, QuoteStatusAccepted, quote.Status)
+		assert.Equal(t, QuoteStatusAccepted, repo.Quotes["quote-1"].Status)
+
+		_, err = svc.RejectLink(ctx, "test_schema", claims)
+		assert.ErrorIs(t, err, ErrQuoteNotOpen)
+	})
+
+	t.Run("rejects through a link", func(t *testing.T) {
+		svc, repo := newLinkService(QuoteStatusSent)
+		link, err := svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", nil)
+		require.NoError(t, err)
+		claims, err := svc.VerifyLink(link.Token)
+		require.NoError(t, err)
+
+		_, err = svc.RejectLink(ctx, "test_schema", claims)
+		require.NoError(t, err)
+		assert.Equal(t, QuoteStatusRejected, repo.Quotes["quote-1"].Status)
+	})
+
+	t.Run("revised quotes supersede earlier links", func(t *testing.T) {
+		svc, repo := newLinkService(QuoteStatusSent)
+		link, err := svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", nil)
+		require.NoError(t, err)
+		claims, err := svc.VerifyLink(link.Token)
+		require.NoError(t, err)
+
+		repo.Quotes["quote-1"].Revision = 2
+		_, err = svc.OpenLink(ctx, "test_schema", claims)
+		assert.ErrorIs(t, err, ErrQuoteLinkSuperseded)
+		_, err = svc.AcceptLink(ctx, "test_schema", claims)
+		assert.ErrorIs(t, err, ErrQuoteLinkSuperseded)
+	})
+
+	t.Run("lapsed quotes cannot be answered", func(t *testing.T) {
+		svc, repo := newLinkService(QuoteStatusSent)
+		link, err := svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", nil)
+		require.NoError(t, err)
+		claims, err := svc.VerifyLink(link.Token)
+		require.NoError(t, err)
+
+		repo.Quotes["quote-1"].Status = QuoteStatusExpired
+		_, err = svc.AcceptLink(ctx, "test_schema", claims)
+		assert.ErrorIs(t, err, ErrQuoteLapsed)
+
+		lapsed := time.Now().AddDate(0, 0, -2)
+		repo.Quotes["quote-1"].Status = QuoteStatusSent
+		repo.Quotes["quote-1"].ValidUntil = &lapsed
+		_, err = svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", nil)
+		assert.ErrorIs(t, err, ErrQuoteLapsed)
+	})
+
+	t.Run("validates link requests", func(t *testing.T) {
+		svc, _ := newLinkService(QuoteStatusDraft)
+		_, err := svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", nil)
+		assert.EqualError(t, err, "only sent quotes can be shared")
+		_, err = svc.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", &CreateQuoteLinkRequest{ExpiresInDays: 91})
+		assert.EqualError(t, err, "expires_in_days must be between 1 and 90")
+		_, err = svc.CreateLink(ctx, "tenant-1", "test_schema", "missing", nil)
+		assert.ErrorIs(t, err, ErrQuoteNotFound)
+
+		unsigned := NewServiceWithRepository(NewMockRepository())
+		_, err = unsigned.CreateLink(ctx, "tenant-1", "test_schema", "quote-1", nil)
+		assert.ErrorIs(t, err, ErrQuoteLinksNotConfigured)
+		_, err = unsigned.VerifyLink("token")
+		assert.ErrorIs(t, err, ErrInvalidQuoteLink)
+	})
+}
@@ -32,6 +32,7 @@ type Quote struct {
 	QuoteDate            time.Time         `json:"quote_date"`
 	ValidUntil           *time.Time        `json:"valid_until,omitempty"`
 	Status               QuoteStatus       `json:"status"`
+	Revision             int               `json:"revision"`
 	Currency             string            `json:"currency"`
 	ExchangeRate         decimal.Decimal   `json:"exchange_rate"`
 	Subtotal             decimal.Decimal   `json:"subtotal"`
@@ -62,6 +63,8 @@ type QuoteLine struct {
 	LineVAT         decimal.Decimal `json:"line_vat"`
 	LineTotal       decimal.Decimal `json:"line_total"`
 	ProductID       *string         `json:"product_id,omitempty"`
+	// InvoicedQuantity is the part of the line already billed on invoices
+	InvoicedQuantity decimal.Decimal `json:"invoiced_quantity"`
 }
 
 // Calculate computes the line totals
@@ -92,6 +95,16 @@ func (q *Quote) Calculate() {
 	}
 }
 
+// IsInvoiced reports whether any line has been billed
+func (q *Quote) IsInvoiced() bool {
+	for i := range q.Lines {
+		if q.Lines[i].InvoicedQuantity.IsPositive() {
+			return true
+		}
+	}
+	return false
+}
+
 // Validate validates the quote
 func (q *Quote) Validate() error {
 	if len(q.Lines) == 0 {
@@ -157,13 +170,22 @@ type CreateQuoteRequest struct {
 
 // CreateQuoteLineRequest is a line in the create quote request
 type CreateQuoteLineRequest struct {
-	Description     string          `json:"description"`
-	Quantity        decimal.Decimal `json:"quantity"`
-	Unit            string          `json:"unit,omitempty"`
-	UnitPrice       decimal.Decimal `json:"unit_price"`
-	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
-	VATRate         decimal.Decimal `json:"vat_rate"`
-	ProductID       *string         `json:"product_id,omitempty"`
+	Description     string           `json:"description"`
+	Quantity        decimal.Decimal  `json:"quantity"`
+	Unit            string           `json:"unit,omitempty"`
+	UnitPrice       *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountPercent decimal.Decimal  `json:"discount_percent,omitempty"`
+	VATRate         decimal.Decimal  `json:"vat_rate"`
+	ProductID       *string          `json:"product_id,omitempty"`
+}
+
+// unitPrice returns the unit price sent on the line, zero when none was sent
+// and none was resolved.
+func (l *CreateQuoteLineRequest) unitPrice() decimal.Decimal {
+	if l.UnitPrice == nil {
+		return decimal.Zero
+	}
+	return *l.UnitPrice
 }
 
 // ImportQuotesRequest contains CSV payload for quote migration.
@@ -201,18 +223,134 @@ type UpdateQuoteRequest struct {
 	Lines        []CreateQuoteLineRequest `json:"lines"`
 }
 
-// ConvertQuoteToInvoiceRequest requests conversion of an accepted quote into a sales invoice.
+// ConvertQuoteToInvoiceRequest requests a sales invoice for an accepted quote.
+// Without lines, percent or a prepayment kind every quantity not yet invoiced
+// is billed.
 type ConvertQuoteToInvoiceRequest struct {
 	IssueDate time.Time `json:"issue_date,omitempty"`
 	DueDate   time.Time `json:"due_date,omitempty"`
 	Notes     string    `json:"notes,omitempty"`
-	UserID    string    `json:"-"`
+	invoicing.PartialInvoiceRequest
+	UserID string `json:"-"`
 }
 
-// QuoteInvoiceConversionResult returns the converted quote and created invoice.
+// QuoteInvoiceConversionResult returns the quote, the created invoice and its billing record.
 type QuoteInvoiceConversionResult struct {
 	Quote   *Quote             `json:"quote"`
 	Invoice *invoicing.Invoice `json:"invoice"`
+	Billing *QuoteInvoice      `json:"billing,omitempty"`
+}
+
+// QuoteInvoice records one invoice billed from a quote. For prepayments,
+// Subtotal is the amount paid in advance and NettedAmount the part later
+// invoices have deducted.
+type QuoteInvoice struct {
+	ID                 string                `json:"id"`
+	TenantID           string                `json:"tenant_id"`
+	QuoteID            string                `json:"quote_id"`
+	InvoiceID          string                `json:"invoice_id"`
+	InvoiceNumber      string                `json:"invoice_number"`
+	Kind               invoicing.BillingKind `json:"kind"`
+	Percent            decimal.Decimal       `json:"percent"`
+	Subtotal           decimal.Decimal       `json:"subtotal"`
+	VATRate            decimal.Decimal       `json:"vat_rate"`
+	PrepaymentDeducted decimal.Decimal       `json:"prepayment_deducted"`
+	NettedAmount       decimal.Decimal       `json:"netted_amount"`
+	CreatedBy          string                `json:"created_by,omitempty"`
+	CreatedAt          time.Time             `json:"created_at"`
+}
+
+// QuoteRevision is a sent revision of a quote. Quote holds the content
+// frozen when the revision was sent and is only loaded for single revisions.
+type QuoteRevision struct {
+	ID         string          `json:"id"`
+	TenantID   string          `json:"tenant_id"`
+	QuoteID    string          `json:"quote_id"`
+	Revision   int             `json:"revision"`
+	ValidUntil *time.Time      `json:"valid_until,omitempty"`
+	Currency   string          `json:"currency"`
+	Total      decimal.Decimal `json:"total"`
+	SentAt     time.Time       `json:"sent_at"`
+	Quote      *Quote          `json:"quote,omitempty"`
+}
+
+// CreateQuoteLinkRequest is the request to create a public customer link.
+// ExpiresInDays defaults to 14 and the link never outlives the quote.
+type CreateQuoteLinkRequest struct {
+	ExpiresInDays int `json:"expires_in_days,omitempty"`
+}
+
+// QuoteLink is a signed public link to the current revision of a sent quote
+type QuoteLink struct {
+	Token     string    `json:"token"`
+	QuoteID   string    `json:"quote_id"`
+	Revision  int       `json:"revision"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// PublicQuote is the customer's view of a quote opened through a public link
+type PublicQuote struct {
+	QuoteNumber   string            `json:"quote_number"`
+	Revision      int               `json:"revision"`
+	CompanyName   string            `json:"company_name,omitempty"`
+	CustomerName  string            `json:"customer_name,omitempty"`
+	QuoteDate     time.Time         `json:"quote_date"`
+	ValidUntil    *time.Time        `json:"valid_until,omitempty"`
+	Status        QuoteStatus       `json:"status"`
+	Currency      string            `json:"currency"`
+	Subtotal      decimal.Decimal   `json:"subtotal"`
+	VATAmount     decimal.Decimal   `json:"vat_amount"`
+	Total         decimal.Decimal   `json:"total"`
+	Notes         string            `json:"notes,omitempty"`
+	Lines         []PublicQuoteLine `json:"lines"`
+	LinkExpiresAt time.Time         `json:"link_expires_at"`
+}
+
+// PublicQuoteLine is a quote line as shown to the customer
+type PublicQuoteLine struct {
+	LineNumber      int             `json:"line_number"`
+	Description     string          `json:"description"`
+	Quantity        decimal.Decimal `json:"quantity"`
+	Unit            string          `json:"unit,omitempty"`
+	UnitPrice       decimal.Decimal `json:"unit_price"`
+	DiscountPercent decimal.Decimal `json:"discount_percent"`
+	VATRate         decimal.Decimal `json:"vat_rate"`
+	LineTotal       decimal.Decimal `json:"line_total"`
+}
+
+// NewPublicQuote builds the customer's view of a quote, leaving out internal
+// fields such as IDs, conversions, creators and invoiced quantities
+func NewPublicQuote(quote *Quote, linkExpiresAt time.Time) *PublicQuote {
+	view := &PublicQuote{
+		QuoteNumber:   quote.QuoteNumber,
+		Revision:      quote.Revision,
+		QuoteDate:     quote.QuoteDate,
+		ValidUntil:    quote.ValidUntil,
+		Status:        quote.Status,
+		Currency:      quote.Currency,
+		Subtotal:      quote.Subtotal,
+		VATAmount:     quote.VATAmount,
+		Total:         quote.Total,
+		Notes:         quote.Notes,
+		Lines:         make([]PublicQuoteLine, len(quote.Lines)),
+		LinkExpiresAt: linkExpiresAt,
+	}
+	if quote.Contact != nil {
+		view.CustomerName = quote.Contact.Name
+	}
+	for i, line := range quote.Lines {
+		view.Lines[i] = PublicQuoteLine{
+			LineNumber:      line.LineNumber,
+			Description:     line.Description,
+			Quantity:        line.Quantity,
+			Unit:            line.Unit,
+			UnitPrice:       line.UnitPrice,
+			DiscountPercent: line.DiscountPercent,
+			VATRate:         line.VATRate,
+			LineTotal:       line.LineTotal,
+		}
+	}
+	return view
 }
 
 // QuoteFilter provides filtering options
@@ -0,0 +1,248 @@
+package recurring
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/invoicing"
+)
+
+// ErrUsageMissing is returned when a usage-based line has no usage reported
+// for the period being invoiced. Report a zero quantity to bill no usage.
+var ErrUsageMissing = errors.New("usage data is missing")
+
+// billingPlan is what a single generation run bills.
+//
+// Fixed lines are billed in advance for the service period starting at the
+// next generation date; usage-based lines are billed in arrears for the
+// period that ended on it. Both are prorated when the period is cut short by
+// the billing anchor or the end date, and split where a scheduled price
+// change takes effect.
+type billingPlan struct {
+	periodStart time.Time
+	periodEnd   time.Time // exclusive; not after periodStart when no fixed period is billed
+	nextDate    time.Time
+	lines       []invoicing.CreateInvoiceLineRequest
+	usageIDs    []string
+	record      BillingRecord
+}
+
+type billingSegment struct {
+	start time.Time
+	end   time.Time // exclusive
+}
+
+// planGeneration works out the invoice lines for the run due on the
+// recurring invoice's next generation date. usage holds the unbilled usage
+// records of the recurring invoice.
+func planGeneration(ri *RecurringInvoice, usage []UsageRecord) (*billingPlan, error) {
+	periodStart := truncateToDate(ri.NextGenerationDate)
+	boundary := truncateToDate(ri.CalculateNextDate(periodStart))
+	fullStart, fullEnd := periodStart, boundary
+	if ri.BillingAnchorDate != nil && periodStart.Before(truncateToDate(*ri.BillingAnchorDate)) {
+		// The stub period up to the anchor is prorated against the first full period
+		boundary = truncateToDate(*ri.BillingAnchorDate)
+		fullStart, fullEnd = boundary, truncateToDate(ri.CalculateNextDate(boundary))
+	}
+
+	plan := &billingPlan{periodStart: periodStart, periodEnd: boundary, nextDate: boundary}
+	if ri.EndDate != nil {
+		serviceEnd := truncateToDate(*ri.EndDate).AddDate(0, 0, 1)
+		if plan.periodEnd.After(serviceEnd) {
+			plan.periodEnd = serviceEnd
+			// Usage of the final period is billed in a closing run once it has ended
+			if periodStart.Before(serviceEnd) && ri.hasUsageLines() {
+				plan.nextDate = serviceEnd
+			}
+		}
+	}
+
+	usageStart := periodStart
+	if ri.LastPeriodStart != nil {
+		usageStart = truncateToDate(*ri.LastPeriodStart)
+	}
+	changes := pendingPriceChanges(ri.PriceChanges)
+	fullDays := decimal.NewFromInt(int64(daysBetween(fullStart, fullEnd)))
+
+	var missing []string
+	for i := range ri.Lines {
+		line := &ri.Lines[i]
+		if line.IsUsageBased() {
+			lines, usageIDs, ok := planUsageLine(line, changes, usage, usageStart, periodStart)
+			if !ok {
+				missing = append(missing, line.UsageMetric)
+				continue
+			}
+			plan.lines = append(plan.lines, lines...)
+			plan.usageIDs = append(plan.usageIDs, usageIDs...)
+			continue
+		}
+		if !plan.periodStart.Before(plan.periodEnd) {
+			continue
+		}
+		for _, segment := range splitAtPriceChanges(line, changes, plan.periodStart, plan.periodEnd) {
+			unitPrice := priceOn(line, changes, segment.start)
+			description := line.Description
+			if !segment.start.Equal(fullStart) || !segment.end.Equal(fullEnd) {
+				fraction := decimal.NewFromInt(int64(daysBetween(segment.start, segment.end))).Div(fullDays)
+				unitPrice = unitPrice.Mul(fraction).Round(4)
+				description = describeSegment(description, segment)
+			}
+			plan.lines = append(plan.lines, invoiceLineFrom(line, description, line.Quantity, unitPrice))
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: no usage reported for %s between %s and %s",
+			ErrUsageMissing, strings.Join(missing, ", "),
+			usageStart.Format("02.01.2006"), periodStart.AddDate(0, 0, -1).Format("02.01.2006"))
+	}
+
+	plan.record = BillingRecord{
+		RecurringInvoiceID: ri.ID,
+		PeriodStart:        periodStart,
+		NextGenerationDate: plan.nextDate,
+		UsageIDs:           plan.usageIDs,
+		LinePrices:         make(map[string]decimal.Decimal),
+	}
+	// Changes in effect on the period start no longer affect any unbilled
+	// period and are folded into the line prices
+	for _, change := range changes {
+		if change.EffectiveDate.After(periodStart) {
+			continue
+		}
+		plan.record.AppliedPriceChangeIDs = append(plan.record.AppliedPriceChangeIDs, change.ID)
+		for i := range ri.Lines {
+			line := &ri.Lines[i]
+			if !change.AppliesTo(line.LineNumber) {
+				continue
+			}
+			price, ok := plan.record.LinePrices[line.ID]
+			if !ok {
+				price = line.UnitPrice
+			}
+			plan.record.LinePrices[line.ID] = change.Apply(price)
+		}
+	}
+	return plan, nil
+}
+
+// planUsageLine bills the unbilled usage of a usage-based line reported up to
+// the end of its period. Late records dated before the period are billed with
+// the first segment. It reports false when the period is not empty but no
+// usage was reported within it.
+func planUsageLine(line *RecurringInvoiceLine, changes []PriceChange, usage []UsageRecord, from, to time.Time) ([]invoicing.CreateInvoiceLineRequest, []string, bool) {
+	if !from.Before(to) {
+		return nil, nil, true
+	}
+	segments := splitAtPriceChanges(line, changes, from, to)
+	totals := make([]decimal.Decimal, len(segments))
+	var usageIDs []string
+	reported := false
+	for _, record := range usage {
+		day := truncateToDate(record.UsageDate)
+		if record.Metric != line.UsageMetric || record.InvoiceID != nil || !day.Before(to) {
+			continue
+		}
+		index := 0
+		for i, segment := range segments {
+			if !day.Before(segment.start) {
+				index = i
+			}
+		}
+		if !day.Before(from) {
+			reported = true
+		}
+		totals[index] = totals[index].Add(record.Quantity)
+		usageIDs = append(usageIDs, record.ID)
+	}
+	if !reported {
+		return nil, nil, false
+	}
+
+	var lines []invoicing.CreateInvoiceLineRequest
+	for i, segment := range segments {
+		if !totals[i].IsPositive() {
+			continue
+		}
+		lines = append(lines, invoiceLineFrom(line, describeSegment(line.Description, segment), totals[i], priceOn(line, changes, segment.start)))
+	}
+	return lines, usageIDs, true
+}
+
+// pendingPriceChanges returns the unapplied price changes in effective date order
+func pendingPriceChanges(changes []PriceChange) []PriceChange {
+	pending := make([]PriceChange, 0, len(changes))
+	for _, change := range changes {
+		if change.AppliedAt == nil {
+			change.EffectiveDate = truncateToDate(change.EffectiveDate)
+			pending = append(pending, change)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].EffectiveDate.Before(pending[j].EffectiveDate)
+	})
+	return pending
+}
+
+// priceOn returns the unit price of the line in effect on day
+func priceOn(line *RecurringInvoiceLine, changes []PriceChange, day time.Time) decimal.Decimal {
+	price := line.UnitPrice
+	for i := range changes {
+		if changes[i].AppliesTo(line.LineNumber) && !changes[i].EffectiveDate.After(day) {
+			price = changes[i].Apply(price)
+		}
+	}
+	return price
+}
+
+// splitAtPriceChanges splits [from, to) where a price change of the line takes effect
+func splitAtPriceChanges(line *RecurringInvoiceLine, changes []PriceChange, from, to time.Time) []billingSegment {
+	segments := []billingSegment{{start: from, end: to}}
+	for _, change := range changes {
+		last := &segments[len(segments)-1]
+		if change.AppliesTo(line.LineNumber) && change.EffectiveDate.After(last.start) && change.EffectiveDate.Before(to) {
+			last.end = change.EffectiveDate
+			segments = append(segments, billingSegment{start: change.EffectiveDate, end: to})
+		}
+	}
+	return segments
+}
+
+func describeSegment(description string, segment billingSegment) string {
+	return fmt.Sprintf("%s (%s - %s)", description, segment.start.Format("02.01.2006"), segment.end.AddDate(0, 0, -1).Format("02.01.2006"))
+}
+
+func invoiceLineFrom(line *RecurringInvoiceLine, description string, quantity, unitPrice decimal.Decimal) invoicing.CreateInvoiceLineRequest {
+	return invoicing.CreateInvoiceLineRequest{
+		Description:     description,
+		Quantity:        quantity,
+		Unit:            line.Unit,
+		UnitPrice:       new(unitPrice),
+		DiscountPercent: line.DiscountPercent,
+		VATRate:         line.VATRate,
+		AccountID:       line.AccountID,
+		ProductID:       line.ProductID,
+	}
+}
+
+func (r *RecurringInvoice) hasUsageLines() bool {
+	for i := range r.Lines {
+		if r.Lines[i].IsUsageBased() {
+			return true
+		}
+	}
+	return false
+}
+
+func truncateToDate(value time.Time) time.Time {
+	return time.Date(value.Year(), value.Month(), value.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
@@ -0,0 +1,243 @@
+package recurring
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func billingDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func billingTemplate(nextDate time.Time, lines ...RecurringInvoiceLine) *RecurringInvoice {
+	for i := range lines {
+		lines[i].ID = "line-" + string(rune('a'+i))
+		lines[i].LineNumber = i + 1
+		lines[i].VATRate = decimal.NewFromInt(22)
+	}
+	return &RecurringInvoice{
+		ID:                 "ri-1",
+		TenantID:           "tenant-1",
+		Name:               "Hosting",
+		Frequency:          FrequencyMonthly,
+		StartDate:          billingDate(2026, 1, 1),
+		NextGenerationDate: nextDate,
+		Lines:              lines,
+	}
+}
+
+func fixedLine(description string, price int64) RecurringInvoiceLine {
+	return RecurringInvoiceLine{Description: description, Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(price)}
+}
+
+func usageLine(description, metric string, price decimal.Decimal) RecurringInvoiceLine {
+	return RecurringInvoiceLine{Description: description, Quantity: decimal.NewFromInt(1), UnitPrice: price, UsageMetric: metric}
+}
+
+func TestPlanGenerationFullPeriod(t *testing.T) {
+	plan, err := planGeneration(billingTemplate(billingDate(2026, 2, 1), fixedLine("Hosting", 300)), nil)
+	require.NoError(t, err)
+
+	require.Len(t, plan.lines, 1)
+	assert.Equal(t, "Hosting", plan.lines[0].Description)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(300)))
+	assert.Equal(t, billingDate(2026, 3, 1), plan.nextDate)
+	assert.Equal(t, billingDate(2026, 2, 1), plan.record.PeriodStart)
+}
+
+func TestPlanGenerationProratesStubToBillingAnchor(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 3, 10), fixedLine("Hosting", 300))
+	ri.StartDate = billingDate(2026, 3, 10)
+	anchor := billingDate(2026, 4, 1)
+	ri.BillingAnchorDate = &anchor
+
+	plan, err := planGeneration(ri, nil)
+	require.NoError(t, err)
+
+	// 22 days of the 30-day first full period
+	require.Len(t, plan.lines, 1)
+	assert.Equal(t, "Hosting (10.03.2026 - 31.03.2026)", plan.lines[0].Description)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(220)), plan.lines[0].UnitPrice.String())
+	assert.Equal(t, anchor, plan.nextDate)
+
+	// From the anchor on whole periods are billed
+	ri.NextGenerationDate = anchor
+	plan, err = planGeneration(ri, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Hosting", plan.lines[0].Description)
+	assert.Equal(t, billingDate(2026, 5, 1), plan.nextDate)
+}
+
+func TestPlanGenerationProratesFinalPeriodToEndDate(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 6, 1), fixedLine("Hosting", 300))
+	endDate := billingDate(2026, 6, 15)
+	ri.EndDate = &endDate
+
+	plan, err := planGeneration(ri, nil)
+	require.NoError(t, err)
+
+	require.Len(t, plan.lines, 1)
+	assert.Equal(t, "Hosting (01.06.2026 - 15.06.2026)", plan.lines[0].Description)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(150)))
+	assert.Equal(t, billingDate(2026, 7, 1), plan.nextDate)
+	assert.Equal(t, billingDate(2026, 6, 16), plan.periodEnd)
+}
+
+func TestPlanGenerationSplitsAtPriceChange(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 1, 1), fixedLine("Licence", 310), fixedLine("Support", 100))
+	uplift := decimal.NewFromInt(10)
+	ri.PriceChanges = []PriceChange{{ID: "change-1", EffectiveDate: billingDate(2026, 1, 16), UpliftPercent: &uplift}}
+
+	plan, err := planGeneration(ri, nil)
+	require.NoError(t, err)
+
+	require.Len(t, plan.lines, 4)
+	assert.Equal(t, "Licence (01.01.2026 - 15.01.2026)", plan.lines[0].Description)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(150)), plan.lines[0].UnitPrice.String())
+	assert.Equal(t, "Licence (16.01.2026 - 31.01.2026)", plan.lines[1].Description)
+	assert.True(t, plan.lines[1].UnitPrice.Equal(decimal.NewFromInt(176)), plan.lines[1].UnitPrice.String())
+	assert.Empty(t, plan.record.AppliedPriceChangeIDs)
+
+	// Once the change is in effect for whole periods it is folded into the price
+	ri.NextGenerationDate = billingDate(2026, 2, 1)
+	plan, err = planGeneration(ri, nil)
+	require.NoError(t, err)
+	require.Len(t, plan.lines, 2)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(341)))
+	assert.True(t, plan.lines[1].UnitPrice.Equal(decimal.NewFromInt(110)))
+	assert.Equal(t, []string{"change-1"}, plan.record.AppliedPriceChangeIDs)
+	assert.True(t, plan.record.LinePrices["line-a"].Equal(decimal.NewFromInt(341)))
+	assert.True(t, plan.record.LinePrices["line-b"].Equal(decimal.NewFromInt(110)))
+}
+
+func TestPlanGenerationLineSpecificPriceChange(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 2, 1), fixedLine("Licence", 100), fixedLine("Support", 50))
+	lineNumber := 2
+	price := decimal.NewFromInt(80)
+	applied := billingDate(2026, 1, 1)
+	ri.PriceChanges = []PriceChange{
+		{ID: "applied", EffectiveDate: billingDate(2026, 1, 1), UnitPrice: &price, AppliedAt: &applied},
+		{ID: "support", LineNumber: &lineNumber, EffectiveDate: billingDate(2026, 2, 1), UnitPrice: &price},
+	}
+
+	plan, err := planGeneration(ri, nil)
+	require.NoError(t, err)
+
+	require.Len(t, plan.lines, 2)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(100)))
+	assert.True(t, plan.lines[1].UnitPrice.Equal(decimal.NewFromInt(80)))
+	assert.Equal(t, []string{"support"}, plan.record.AppliedPriceChangeIDs)
+	assert.NotContains(t, plan.record.LinePrices, "line-a")
+}
+
+func TestPlanGenerationBillsUsageInArrears(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 4, 1), fixedLine("Base fee", 20), usageLine("Energy", "kwh", decimal.NewFromFloat(0.2)))
+	lastPeriod := billingDate(2026, 3, 1)
+	ri.LastPeriodStart = &lastPeriod
+	invoiceID := "inv-0"
+	usage := []UsageRecord{
+		{ID: "u-late", Metric: "kwh", UsageDate: billingDate(2026, 2, 27), Quantity: decimal.NewFromInt(10)},
+		{ID: "u-1", Metric: "kwh", UsageDate: billingDate(2026, 3, 5), Quantity: decimal.NewFromInt(100)},
+		{ID: "u-2", Metric: "kwh", UsageDate: billingDate(2026, 3, 20), Quantity: decimal.NewFromInt(50)},
+		{ID: "u-next", Metric: "kwh", UsageDate: billingDate(2026, 4, 2), Quantity: decimal.NewFromInt(99)},
+		{ID: "u-billed", Metric: "kwh", UsageDate: billingDate(2026, 3, 2), Quantity: decimal.NewFromInt(7), InvoiceID: &invoiceID},
+		{ID: "u-other", Metric: "api_calls", UsageDate: billingDate(2026, 3, 2), Quantity: decimal.NewFromInt(7)},
+	}
+
+	plan, err := planGeneration(ri, usage)
+	require.NoError(t, err)
+
+	require.Len(t, plan.lines, 2)
+	assert.Equal(t, "Base fee", plan.lines[0].Description)
+	assert.Equal(t, "Energy (01.03.2026 - 31.03.2026)", plan.lines[1].Description)
+	assert.True(t, plan.lines[1].Quantity.Equal(decimal.NewFromInt(160)))
+	assert.True(t, plan.lines[1].UnitPrice.Equal(decimal.NewFromFloat(0.2)))
+	assert.Equal(t, []string{"u-late", "u-1", "u-2"}, plan.record.UsageIDs)
+}
+
+func TestPlanGenerationSplitsUsageAtPriceChange(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 4, 1), usageLine("Energy", "kwh", decimal.NewFromFloat(0.2)))
+	lastPeriod := billingDate(2026, 3, 1)
+	ri.LastPeriodStart = &lastPeriod
+	price := decimal.NewFromFloat(0.25)
+	ri.PriceChanges = []PriceChange{{ID: "change-1", EffectiveDate: billingDate(2026, 3, 16), UnitPrice: &price}}
+	usage := []UsageRecord{
+		{ID: "u-1", Metric: "kwh", UsageDate: billingDate(2026, 3, 5), Quantity: decimal.NewFromInt(100)},
+		{ID: "u-2", Metric: "kwh", UsageDate: billingDate(2026, 3, 20), Quantity: decimal.NewFromInt(40)},
+	}
+
+	plan, err := planGeneration(ri, usage)
+	require.NoError(t, err)
+
+	require.Len(t, plan.lines, 2)
+	assert.Equal(t, "Energy (01.03.2026 - 15.03.2026)", plan.lines[0].Description)
+	assert.True(t, plan.lines[0].Quantity.Equal(decimal.NewFromInt(100)))
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromFloat(0.2)))
+	assert.True(t, plan.lines[1].Quantity.Equal(decimal.NewFromInt(40)))
+	assert.True(t, plan.lines[1].UnitPrice.Equal(decimal.NewFromFloat(0.25)))
+	assert.Equal(t, []string{"change-1"}, plan.record.AppliedPriceChangeIDs)
+}
+
+func TestPlanGenerationUsageMissing(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 4, 1), usageLine("Energy", "kwh", decimal.NewFromFloat(0.2)), usageLine("API calls", "api_calls", decimal.NewFromFloat(0.001)))
+	lastPeriod := billingDate(2026, 3, 1)
+	ri.LastPeriodStart = &lastPeriod
+	usage := []UsageRecord{
+		{ID: "u-late", Metric: "kwh", UsageDate: billingDate(2026, 2, 20), Quantity: decimal.NewFromInt(10)},
+		{ID: "u-zero", Metric: "api_calls", UsageDate: billingDate(2026, 3, 31), Quantity: decimal.Zero},
+	}
+
+	_, err := planGeneration(ri, usage)
+	require.ErrorIs(t, err, ErrUsageMissing)
+	assert.EqualError(t, err, "usage data is missing: no usage reported for kwh between 01.03.2026 and 31.03.2026")
+
+	// A reported zero quantity bills nothing but satisfies the period
+	usage = append(usage, UsageRecord{ID: "u-1", Metric: "kwh", UsageDate: billingDate(2026, 3, 31), Quantity: decimal.NewFromInt(5)})
+	plan, err := planGeneration(ri, usage)
+	require.NoError(t, err)
+	require.Len(t, plan.lines, 1)
+	assert.True(t, plan.lines[0].Quantity.Equal(decimal.NewFromInt(15)))
+}
+
+func TestPlanGenerationUsageOnlyFirstRunBillsNothing(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 1, 1), usageLine("Energy", "kwh", decimal.NewFromFloat(0.2)))
+
+	plan, err := planGeneration(ri, nil)
+	require.NoError(t, err)
+	assert.Empty(t, plan.lines)
+	assert.Equal(t, billingDate(2026, 2, 1), plan.nextDate)
+}
+
+func TestPlanGenerationClosingRunBillsFinalUsage(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 3, 1), fixedLine("Base fee", 31), usageLine("Energy", "kwh", decimal.NewFromFloat(0.2)))
+	lastPeriod := billingDate(2026, 2, 1)
+	ri.LastPeriodStart = &lastPeriod
+	endDate := billingDate(2026, 3, 15)
+	ri.EndDate = &endDate
+	usage := []UsageRecord{
+		{ID: "u-feb", Metric: "kwh", UsageDate: billingDate(2026, 2, 28), Quantity: decimal.NewFromInt(10)},
+		{ID: "u-mar", Metric: "kwh", UsageDate: billingDate(2026, 3, 15), Quantity: decimal.NewFromInt(20)},
+	}
+
+	plan, err := planGeneration(ri, usage)
+	require.NoError(t, err)
+	require.Len(t, plan.lines, 2)
+	assert.True(t, plan.lines[0].UnitPrice.Equal(decimal.NewFromInt(15)))
+	assert.True(t, plan.lines[1].Quantity.Equal(decimal.NewFromInt(10)))
+	assert.Equal(t, billingDate(2026, 3, 16), plan.nextDate)
+
+	// The closing run bills only the usage up to the end date
+	ri.NextGenerationDate = plan.nextDate
+	ri.LastPeriodStart = &plan.periodStart
+	usage[0].InvoiceID = &ri.ID
+	plan, err = planGeneration(ri, usage)
+	require.NoError(t, err)
+	require.Len(t, plan.lines, 1)
+	assert.Equal(t, "Energy (01.03.2026 - 15.03.2026)", plan.lines[0].Description)
+	assert.True(t, plan.lines[0].Quantity.Equal(decimal.NewFromInt(20)))
+	assert.Equal(t, billingDate(2026, 4, 16), plan.nextDate)
+}
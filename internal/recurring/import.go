@@ -40,6 +40,7 @@ type recurringImportLine struct {
 	vatRate         decimal.Decimal
 	accountID       *string
 	productID       *string
+	usageMetric     string
 }
 
 type recurringImportHeader struct {
@@ -146,6 +147,8 @@ var recurringImportHeaderAliases = map[string]string{
 	"product_code":             "product_code",
 	"sku":                      "product_code",
 	"item_code":                "product_code",
+	"usage_metric":             "usage_metric",
+	"metric":                   "usage_metric",
 }
 
 // ImportCSV imports recurring invoice templates from grouped CSV rows.
@@ -538,6 +541,7 @@ func parseRecurringImportLine(row recurringImportRow, productLookup importrefs.P
 		vatRate:         vatRate,
 		accountID:       accountID,
 		productID:       productID,
+		usageMetric:     strings.TrimSpace(row.values["usage_metric"]),
 	}, nil
 }
 
@@ -653,6 +657,7 @@ func buildImportedRecurringInvoice(tenantID, userID, contactID string, group *re
 			VATRate:            line.vatRate,
 			AccountID:          line.accountID,
 			ProductID:          line.productID,
+			UsageMetric:        line.usageMetric,
 		})
 	}
 	if err := template.Validate(); err != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Repository defines the contract for recurring invoice data access
@@ -25,9 +27,35 @@ type Repository interface {
 	GetDueRecurringInvoiceIDs(ctx context.Context, schemaName, tenantID string, asOfDate time.Time) ([]string, error)
 	UpdateAfterGeneration(ctx context.Context, schemaName, tenantID, id string, nextDate time.Time, generatedAt time.Time) error
 	UpdateInvoiceEmailStatus(ctx context.Context, schemaName, invoiceID string, sentAt *time.Time, status, logID string) error
+	RecordBilling(ctx context.Context, schemaName, tenantID string, record *BillingRecord) error
+
+	// Usage records
+	CreateUsageRecords(ctx context.Context, schemaName string, records []UsageRecord) error
+	ListUsageRecords(ctx context.Context, schemaName, tenantID, recurringInvoiceID string, unbilledOnly bool) ([]UsageRecord, error)
+	DeleteUsageRecord(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error
+
+	// Scheduled price changes
+	CreatePriceChange(ctx context.Context, schemaName string, change *PriceChange) error
+	ListPriceChanges(ctx context.Context, schemaName, tenantID, recurringInvoiceID string) ([]PriceChange, error)
+	DeletePriceChange(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error
+}
+
+// BillingRecord captures what a generation run billed so that usage and
+// price changes are never billed twice
+type BillingRecord struct {
+	RecurringInvoiceID    string
+	PeriodStart           time.Time
+	NextGenerationDate    time.Time
+	InvoiceID             string
+	UsageIDs              []string
+	LinePrices            map[string]decimal.Decimal
+	AppliedPriceChangeIDs []string
+	AppliedAt             time.Time
 }
 
 // Common errors
 var (
 	ErrRecurringInvoiceNotFound = fmt.Errorf("recurring invoice not found")
+	ErrUsageRecordNotFound      = fmt.Errorf("usage record not found or already invoiced")
+	ErrPriceChangeNotFound      = fmt.Errorf("price change not found or already applied")
 )
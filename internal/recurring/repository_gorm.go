@@ -257,7 +257,8 @@ func (r *GORMRepository) GetDueRecurringInvoiceIDs(ctx context.Context, schemaNa
 		Where("tenant_id = ?", tenantID).
 		Where("is_active = ?", true).
 		Where("next_generation_date <= ?", asOfDate).
-		Where("end_date IS NULL OR end_date >= ?", asOfDate).
+		// A run is still due on the day after the end date to bill the usage of the final period
+		Where("end_date IS NULL OR end_date >= ? OR next_generation_date <= end_date + 1", asOfDate).
 		Pluck("id", &ids).Error
 	if err != nil {
 		return nil, fmt.Errorf("get due recurring invoice IDs: %w", err)
@@ -307,6 +308,173 @@ func (r *GORMRepository) UpdateInvoiceEmailStatus(ctx context.Context, schemaNam
 	return nil
 }
 
+// RecordBilling stores what a generation run billed: the period it started,
+// the next generation date, the usage records it invoiced and the price
+// changes folded into the line prices
+func (r *GORMRepository) RecordBilling(ctx context.Context, schemaName, tenantID string, record *BillingRecord) error {
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoices")
+	if err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		recurringDB, err := database.TenantTable(tx, schemaName, "recurring_invoices")
+		if err != nil {
+			return err
+		}
+		if err := recurringDB.
+			Where("id = ? AND tenant_id = ?", record.RecurringInvoiceID, tenantID).
+			Updates(map[string]interface{}{
+				"last_period_start":    record.PeriodStart,
+				"next_generation_date": record.NextGenerationDate,
+				"updated_at":           record.AppliedAt,
+			}).Error; err != nil {
+			return fmt.Errorf("update billed period: %w", err)
+		}
+
+		if record.InvoiceID != "" && len(record.UsageIDs) > 0 {
+			usageDB, _ := database.TenantTable(tx, schemaName, "recurring_invoice_usage")
+			if err := usageDB.
+				Where("tenant_id = ? AND recurring_invoice_id = ? AND id IN ? AND invoice_id IS NULL", tenantID, record.RecurringInvoiceID, record.UsageIDs).
+				Update("invoice_id", record.InvoiceID).Error; err != nil {
+				return fmt.Errorf("mark usage invoiced: %w", err)
+			}
+		}
+
+		for lineID, price := range record.LinePrices {
+			linesDB, _ := database.TenantTable(tx, schemaName, "recurring_invoice_lines")
+			if err := linesDB.
+				Where("id = ? AND recurring_invoice_id = ?", lineID, record.RecurringInvoiceID).
+				Update("unit_price", models.Decimal{Decimal: price}).Error; err != nil {
+				return fmt.Errorf("update line price: %w", err)
+			}
+		}
+
+		if len(record.AppliedPriceChangeIDs) > 0 {
+			changesDB, _ := database.TenantTable(tx, schemaName, "recurring_invoice_price_changes")
+			if err := changesDB.
+				Where("tenant_id = ? AND recurring_invoice_id = ? AND id IN ?", tenantID, record.RecurringInvoiceID, record.AppliedPriceChangeIDs).
+				Update("applied_at", record.AppliedAt).Error; err != nil {
+				return fmt.Errorf("mark price changes applied: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// CreateUsageRecords inserts usage records
+func (r *GORMRepository) CreateUsageRecords(ctx context.Context, schemaName string, records []UsageRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoice_usage")
+	if err != nil {
+		return err
+	}
+
+	usageModels := make([]models.RecurringInvoiceUsage, len(records))
+	for i := range records {
+		usageModels[i] = *usageRecordToModel(&records[i])
+	}
+	if err := db.Create(&usageModels).Error; err != nil {
+		return fmt.Errorf("create usage records: %w", err)
+	}
+	return nil
+}
+
+// ListUsageRecords lists the usage records of a recurring invoice by usage date
+func (r *GORMRepository) ListUsageRecords(ctx context.Context, schemaName, tenantID, recurringInvoiceID string, unbilledOnly bool) ([]UsageRecord, error) {
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoice_usage")
+	if err != nil {
+		return nil, err
+	}
+
+	query := db.Where("tenant_id = ? AND recurring_invoice_id = ?", tenantID, recurringInvoiceID)
+	if unbilledOnly {
+		query = query.Where("invoice_id IS NULL")
+	}
+	var usageModels []models.RecurringInvoiceUsage
+	if err := query.Order("usage_date, created_at").Find(&usageModels).Error; err != nil {
+		return nil, fmt.Errorf("list usage records: %w", err)
+	}
+
+	records := make([]UsageRecord, len(usageModels))
+	for i := range usageModels {
+		records[i] = *modelToUsageRecord(&usageModels[i])
+	}
+	return records, nil
+}
+
+// DeleteUsageRecord deletes a usage record that has not been invoiced
+func (r *GORMRepository) DeleteUsageRecord(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error {
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoice_usage")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ? AND recurring_invoice_id = ? AND invoice_id IS NULL", id, tenantID, recurringInvoiceID).
+		Delete(&models.RecurringInvoiceUsage{})
+	if result.Error != nil {
+		return fmt.Errorf("delete usage record: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrUsageRecordNotFound
+	}
+	return nil
+}
+
+// CreatePriceChange inserts a scheduled price change
+func (r *GORMRepository) CreatePriceChange(ctx context.Context, schemaName string, change *PriceChange) error {
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoice_price_changes")
+	if err != nil {
+		return err
+	}
+
+	if err := db.Create(priceChangeToModel(change)).Error; err != nil {
+		return fmt.Errorf("create price change: %w", err)
+	}
+	return nil
+}
+
+// ListPriceChanges lists the price changes of a recurring invoice by effective date
+func (r *GORMRepository) ListPriceChanges(ctx context.Context, schemaName, tenantID, recurringInvoiceID string) ([]PriceChange, error) {
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoice_price_changes")
+	if err != nil {
+		return nil, err
+	}
+
+	var changeModels []models.RecurringInvoicePriceChange
+	if err := db.Where("tenant_id = ? AND recurring_invoice_id = ?", tenantID, recurringInvoiceID).
+		Order("effective_date, created_at").
+		Find(&changeModels).Error; err != nil {
+		return nil, fmt.Errorf("list price changes: %w", err)
+	}
+
+	changes := make([]PriceChange, len(changeModels))
+	for i := range changeModels {
+		changes[i] = *modelToPriceChange(&changeModels[i])
+	}
+	return changes, nil
+}
+
+// DeletePriceChange deletes a price change that has not been applied
+func (r *GORMRepository) DeletePriceChange(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error {
+	db, err := r.tenantTable(ctx, schemaName, "recurring_invoice_price_changes")
+	if err != nil {
+		return err
+	}
+
+	result := db.Where("id = ? AND tenant_id = ? AND recurring_invoice_id = ? AND applied_at IS NULL", id, tenantID, recurringInvoiceID).
+		Delete(&models.RecurringInvoicePriceChange{})
+	if result.Error != nil {
+		return fmt.Errorf("delete price change: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrPriceChangeNotFound
+	}
+	return nil
+}
+
 // Conversion helpers
 
 func recurringInvoiceToModel(ri *RecurringInvoice) *models.RecurringInvoice {
@@ -321,6 +489,8 @@ func recurringInvoiceToModel(ri *RecurringInvoice) *models.RecurringInvoice {
 		StartDate:              ri.StartDate,
 		EndDate:                ri.EndDate,
 		NextGenerationDate:     ri.NextGenerationDate,
+		BillingAnchorDate:      ri.BillingAnchorDate,
+		LastPeriodStart:        ri.LastPeriodStart,
 		PaymentTermsDays:       ri.PaymentTermsDays,
 		Reference:              ri.Reference,
 		Notes:                  ri.Notes,
@@ -351,6 +521,8 @@ func modelToRecurringInvoice(m *models.RecurringInvoice) *RecurringInvoice {
 		StartDate:              m.StartDate,
 		EndDate:                m.EndDate,
 		NextGenerationDate:     m.NextGenerationDate,
+		BillingAnchorDate:      m.BillingAnchorDate,
+		LastPeriodStart:        m.LastPeriodStart,
 		PaymentTermsDays:       m.PaymentTermsDays,
 		Reference:              m.Reference,
 		Notes:                  m.Notes,
@@ -382,6 +554,7 @@ func recurringInvoiceLineToModel(line *RecurringInvoiceLine) *models.RecurringIn
 		VATRate:            models.Decimal{Decimal: line.VATRate},
 		AccountID:          line.AccountID,
 		ProductID:          line.ProductID,
+		UsageMetric:        line.UsageMetric,
 	}
 }
 
@@ -398,7 +571,94 @@ func modelToRecurringInvoiceLine(m *models.RecurringInvoiceLine) *RecurringInvoi
 		VATRate:            m.VATRate.Decimal,
 		AccountID:          m.AccountID,
 		ProductID:          m.ProductID,
+		UsageMetric:        m.UsageMetric,
+	}
+}
+
+func usageRecordToModel(record *UsageRecord) *models.RecurringInvoiceUsage {
+	return &models.RecurringInvoiceUsage{
+		ID:                 record.ID,
+		TenantID:           record.TenantID,
+		RecurringInvoiceID: record.RecurringInvoiceID,
+		Metric:             record.Metric,
+		UsageDate:          record.UsageDate,
+		Quantity:           models.Decimal{Decimal: record.Quantity},
+		Reference:          record.Reference,
+		InvoiceID:          record.InvoiceID,
+		CreatedAt:          record.CreatedAt,
+		CreatedBy:          optionalUUID(record.CreatedBy),
+	}
+}
+
+func modelToUsageRecord(m *models.RecurringInvoiceUsage) *UsageRecord {
+	record := &UsageRecord{
+		ID:                 m.ID,
+		TenantID:           m.TenantID,
+		RecurringInvoiceID: m.RecurringInvoiceID,
+		Metric:             m.Metric,
+		UsageDate:          m.UsageDate,
+		Quantity:           m.Quantity.Decimal,
+		Reference:          m.Reference,
+		InvoiceID:          m.InvoiceID,
+		CreatedAt:          m.CreatedAt,
+	}
+	if m.CreatedBy != nil {
+		record.CreatedBy = *m.CreatedBy
+	}
+	return record
+}
+
+func priceChangeToModel(change *PriceChange) *models.RecurringInvoicePriceChange {
+	m := &models.RecurringInvoicePriceChange{
+		ID:                 change.ID,
+		TenantID:           change.TenantID,
+		RecurringInvoiceID: change.RecurringInvoiceID,
+		LineNumber:         change.LineNumber,
+		EffectiveDate:      change.EffectiveDate,
+		Note:               change.Note,
+		AppliedAt:          change.AppliedAt,
+		CreatedAt:          change.CreatedAt,
+		CreatedBy:          optionalUUID(change.CreatedBy),
+	}
+	if change.UnitPrice != nil {
+		m.UnitPrice = &models.Decimal{Decimal: *change.UnitPrice}
+	}
+	if change.UpliftPercent != nil {
+		m.UpliftPercent = &models.Decimal{Decimal: *change.UpliftPercent}
+	}
+	return m
+}
+
+func modelToPriceChange(m *models.RecurringInvoicePriceChange) *PriceChange {
+	change := &PriceChange{
+		ID:                 m.ID,
+		TenantID:           m.TenantID,
+		RecurringInvoiceID: m.RecurringInvoiceID,
+		LineNumber:         m.LineNumber,
+		EffectiveDate:      m.EffectiveDate,
+		Note:               m.Note,
+		AppliedAt:          m.AppliedAt,
+		CreatedAt:          m.CreatedAt,
+	}
+	if m.UnitPrice != nil {
+		price := m.UnitPrice.Decimal
+		change.UnitPrice = &price
+	}
+	if m.UpliftPercent != nil {
+		uplift := m.UpliftPercent.Decimal
+		change.UpliftPercent = &uplift
+	}
+	if m.CreatedBy != nil {
+		change.CreatedBy = *m.CreatedBy
+	}
+	return change
+}
+
+func optionalUUID(value string) *string {
+	if value == "" {
+		return nil
 	}
+	return &value
 }
 
 // Ensure GORMRepository implements Repository interface
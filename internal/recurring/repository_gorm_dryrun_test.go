@@ -55,11 +55,13 @@ func (*recurringDryRunTx) Rollback() error {
 type recurringDryRunDBOption func(t *testing.T, db *gorm.DB)
 
 type recurringDryRunFixtures struct {
-	invoice     *models.RecurringInvoice
-	invoices    []models.RecurringInvoice
-	lines       []models.RecurringInvoiceLine
-	dueIDs      []string
-	contactName string
+	invoice      *models.RecurringInvoice
+	invoices     []models.RecurringInvoice
+	lines        []models.RecurringInvoiceLine
+	dueIDs       []string
+	usage        []models.RecurringInvoiceUsage
+	priceChanges []models.RecurringInvoicePriceChange
+	contactName  string
 }
 
 type recurringDryRunRecorder struct {
@@ -280,6 +282,16 @@ func populateRecurringDryRunQueryDest(tx *gorm.DB, dest any, fixtures *recurring
 			*typed = append([]models.RecurringInvoiceLine(nil), fixtures.lines...)
 			tx.RowsAffected = int64(len(fixtures.lines))
 		}
+	case *[]models.RecurringInvoiceUsage:
+		if fixtures.usage != nil {
+			*typed = append([]models.RecurringInvoiceUsage(nil), fixtures.usage...)
+			tx.RowsAffected = int64(len(fixtures.usage))
+		}
+	case *[]models.RecurringInvoicePriceChange:
+		if fixtures.priceChanges != nil {
+			*typed = append([]models.RecurringInvoicePriceChange(nil), fixtures.priceChanges...)
+			tx.RowsAffected = int64(len(fixtures.priceChanges))
+		}
 	case *[]string:
 		if fixtures.dueIDs != nil {
 			*typed = append([]string(nil), fixtures.dueIDs...)
@@ -621,6 +633,199 @@ func TestGORMRepositoryDryRunRecurringErrors(t *testing.T) {
 	})
 }
 
+func TestGORMRepositoryDryRunUsageBilling(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_recurring"
+	tenantID := "tenant-1"
+	now := time.Date(2026, time.June, 25, 14, 0, 0, 0, time.UTC)
+	invoice := recurringDryRunInvoice(tenantID, now)
+	uplift := decimal.RequireFromString("3.5")
+	usage := UsageRecord{
+		ID:                 "usage-1",
+		TenantID:           tenantID,
+		RecurringInvoiceID: invoice.ID,
+		Metric:             "kwh",
+		UsageDate:          now.AddDate(0, 0, -3),
+		Quantity:           decimal.RequireFromString("125.5"),
+		Reference:          "meter-7",
+		CreatedAt:          now,
+		CreatedBy:          "user-1",
+	}
+	change := PriceChange{
+		ID:                 "change-1",
+		TenantID:           tenantID,
+		RecurringInvoiceID: invoice.ID,
+		EffectiveDate:      now.AddDate(0, 1, 0),
+		UpliftPercent:      &uplift,
+		Note:               "CPI",
+		CreatedAt:          now,
+	}
+	recorder := &recurringDryRunRecorder{}
+	repo := NewGORMRepository(newRecurringDryRunDB(t,
+		withRecurringDryRunFixtures(recurringDryRunFixtures{
+			usage:        []models.RecurringInvoiceUsage{*usageRecordToModel(&usage)},
+			priceChanges: []models.RecurringInvoicePriceChange{*priceChangeToModel(&change)},
+		}, recorder),
+		withRecurringDryRunCreateCapture(recorder),
+		withRecurringDryRunUpdateRows(recorder, 1),
+		withRecurringDryRunDeleteRows(recorder, 1),
+	))
+
+	require.NoError(t, repo.CreateUsageRecords(ctx, schemaName, nil))
+	require.NoError(t, repo.CreateUsageRecords(ctx, schemaName, []UsageRecord{usage}))
+	require.NoError(t, repo.CreatePriceChange(ctx, schemaName, &change))
+
+	listed, err := repo.ListUsageRecords(ctx, schemaName, tenantID, invoice.ID, true)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, "kwh", listed[0].Metric)
+	assert.True(t, usage.Quantity.Equal(listed[0].Quantity))
+	assert.Equal(t, "user-1", listed[0].CreatedBy)
+
+	changes, err := repo.ListPriceChanges(ctx, schemaName, tenantID, invoice.ID)
+	require.NoError(t, err)
+	require.Len(t, changes, 1)
+	require.NotNil(t, changes[0].UpliftPercent)
+	assert.True(t, uplift.Equal(*changes[0].UpliftPercent))
+	assert.Nil(t, changes[0].UnitPrice)
+
+	require.NoError(t, repo.RecordBilling(ctx, schemaName, tenantID, &BillingRecord{
+		RecurringInvoiceID:    invoice.ID,
+		PeriodStart:           now,
+		NextGenerationDate:    now.AddDate(0, 1, 0),
+		InvoiceID:             "invoice-1",
+		UsageIDs:              []string{usage.ID},
+		LinePrices:            map[string]decimal.Decimal{"line-1": decimal.RequireFromString("155.25")},
+		AppliedPriceChangeIDs: []string{change.ID},
+		AppliedAt:             now,
+	}))
+	require.NoError(t, repo.DeleteUsageRecord(ctx, schemaName, tenantID, invoice.ID, usage.ID))
+	require.NoError(t, repo.DeletePriceChange(ctx, schemaName, tenantID, invoice.ID, change.ID))
+
+	assertRecurringRecordedSQLContains(t, recorder.creates,
+		`INSERT INTO "tenant_recurring"."recurring_invoice_usage"`,
+		`INSERT INTO "tenant_recurring"."recurring_invoice_price_changes"`,
+	)
+	assertRecurringRecordedSQLContains(t, recorder.queries,
+		`FROM "tenant_recurring"."recurring_invoice_usage"`,
+		`invoice_id IS NULL`,
+		`ORDER BY usage_date, created_at`,
+		`FROM "tenant_recurring"."recurring_invoice_price_changes"`,
+		`ORDER BY effective_date, created_at`,
+	)
+	assertRecurringRecordedSQLContains(t, recorder.updates,
+		`"last_period_start"`,
+		`UPDATE "tenant_recurring"."recurring_invoice_usage" SET "invoice_id"`,
+		`UPDATE "tenant_recurring"."recurring_invoice_lines" SET "unit_price"`,
+		`UPDATE "tenant_recurring"."recurring_invoice_price_changes" SET "applied_at"`,
+	)
+	assertRecurringRecordedSQLContains(t, recorder.deletes,
+		`DELETE FROM "tenant_recurring"."recurring_invoice_usage"`,
+		`DELETE FROM "tenant_recurring"."recurring_invoice_price_changes"`,
+		`applied_at IS NULL`,
+	)
+}
+
+func TestGORMRepositoryDryRunUsageBillingErrors(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_recurring"
+	tenantID := "tenant-1"
+	now := time.Date(2026, time.June, 25, 15, 0, 0, 0, time.UTC)
+	expectedErr := errors.New("dry-run failed")
+	price := decimal.NewFromInt(20)
+	usage := UsageRecord{ID: "usage-1", TenantID: tenantID, RecurringInvoiceID: "recurring-1", Metric: "kwh", UsageDate: now, Quantity: decimal.NewFromInt(1)}
+	change := &PriceChange{ID: "change-1", TenantID: tenantID, RecurringInvoiceID: "recurring-1", EffectiveDate: now, UnitPrice: &price}
+	record := &BillingRecord{
+		RecurringInvoiceID: "recurring-1",
+		PeriodStart:        now,
+		NextGenerationDate: now.AddDate(0, 1, 0),
+		InvoiceID:          "invoice-1",
+		UsageIDs:           []string{usage.ID},
+		AppliedAt:          now,
+	}
+
+	t.Run("rejects invalid tenant schema", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t))
+
+		assert.ErrorContains(t, repo.RecordBilling(ctx, "tenant-recurring", tenantID, record), "invalid SQL identifier")
+		assert.ErrorContains(t, repo.CreateUsageRecords(ctx, "tenant-recurring", []UsageRecord{usage}), "invalid SQL identifier")
+		assert.ErrorContains(t, repo.CreatePriceChange(ctx, "tenant-recurring", change), "invalid SQL identifier")
+	})
+
+	t.Run("wraps record billing errors", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunUpdateErrors(nil, expectedErr)))
+
+		err := repo.RecordBilling(ctx, schemaName, tenantID, record)
+
+		require.ErrorContains(t, err, "mark usage invoiced")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("wraps create usage errors", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunCreateErrors(expectedErr)))
+
+		err := repo.CreateUsageRecords(ctx, schemaName, []UsageRecord{usage})
+
+		require.ErrorContains(t, err, "create usage records")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("wraps list usage errors", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunQueryErrors(expectedErr)))
+
+		records, err := repo.ListUsageRecords(ctx, schemaName, tenantID, "recurring-1", false)
+
+		assert.Nil(t, records)
+		require.ErrorContains(t, err, "list usage records")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("maps delete usage not found", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunDeleteRows(nil, 0)))
+
+		err := repo.DeleteUsageRecord(ctx, schemaName, tenantID, "recurring-1", usage.ID)
+
+		assert.ErrorIs(t, err, ErrUsageRecordNotFound)
+	})
+
+	t.Run("wraps create price change errors", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunCreateErrors(expectedErr)))
+
+		err := repo.CreatePriceChange(ctx, schemaName, change)
+
+		require.ErrorContains(t, err, "create price change")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("wraps list price change errors", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunQueryErrors(expectedErr)))
+
+		changes, err := repo.ListPriceChanges(ctx, schemaName, tenantID, "recurring-1")
+
+		assert.Nil(t, changes)
+		require.ErrorContains(t, err, "list price changes")
+		assert.ErrorIs(t, err, expectedErr)
+	})
+
+	t.Run("maps delete price change not found", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunDeleteRows(nil, 0)))
+
+		err := repo.DeletePriceChange(ctx, schemaName, tenantID, "recurring-1", change.ID)
+
+		assert.ErrorIs(t, err, ErrPriceChangeNotFound)
+	})
+
+	t.Run("wraps delete errors", func(t *testing.T) {
+		repo := NewGORMRepository(newRecurringDryRunDB(t, withRecurringDryRunDeleteErrors(expectedErr)))
+
+		usageErr := repo.DeleteUsageRecord(ctx, schemaName, tenantID, "recurring-1", usage.ID)
+		changeErr := repo.DeletePriceChange(ctx, schemaName, tenantID, "recurring-1", change.ID)
+
+		assert.ErrorIs(t, usageErr, expectedErr)
+		assert.ErrorIs(t, changeErr, expectedErr)
+	})
+}
+
 func recurringDryRunInvoice(tenantID string, now time.Time) *RecurringInvoice {
 	endDate := now.AddDate(1, 0, 0)
 	return &RecurringInvoice{
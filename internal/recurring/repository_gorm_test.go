@@ -123,6 +123,57 @@ func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
 				return repo.UpdateInvoiceEmailStatus(ctx, schemaName, invoiceID, &sentAt, "SENT", "email-log-1")
 			},
 		},
+		{
+			name: "RecordBilling",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.RecordBilling(ctx, schemaName, tenantID, &BillingRecord{
+					RecurringInvoiceID: recurringInvoiceID,
+					PeriodStart:        asOfDate,
+					NextGenerationDate: nextDate,
+					AppliedAt:          generatedAt,
+				})
+			},
+		},
+		{
+			name: "CreateUsageRecords",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.CreateUsageRecords(ctx, schemaName, []UsageRecord{{ID: "usage-1", RecurringInvoiceID: recurringInvoiceID}})
+			},
+		},
+		{
+			name: "ListUsageRecords",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				records, err := repo.ListUsageRecords(ctx, schemaName, tenantID, recurringInvoiceID, true)
+				assert.Nil(t, records)
+				return err
+			},
+		},
+		{
+			name: "DeleteUsageRecord",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.DeleteUsageRecord(ctx, schemaName, tenantID, recurringInvoiceID, "usage-1")
+			},
+		},
+		{
+			name: "CreatePriceChange",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.CreatePriceChange(ctx, schemaName, &PriceChange{ID: "change-1", RecurringInvoiceID: recurringInvoiceID})
+			},
+		},
+		{
+			name: "ListPriceChanges",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				changes, err := repo.ListPriceChanges(ctx, schemaName, tenantID, recurringInvoiceID)
+				assert.Nil(t, changes)
+				return err
+			},
+		},
+		{
+			name: "DeletePriceChange",
+			run: func(t *testing.T, repo *GORMRepository) error {
+				return repo.DeletePriceChange(ctx, schemaName, tenantID, recurringInvoiceID, "change-1")
+			},
+		},
 		{
 			name: "tenantTable",
 			run: func(t *testing.T, repo *GORMRepository) error {
@@ -175,6 +226,8 @@ func TestRecurringInvoiceModelMappingRoundTrip(t *testing.T) {
 				StartDate:              startDate,
 				EndDate:                &endDate,
 				NextGenerationDate:     nextGenerationDate,
+				BillingAnchorDate:      &startDate,
+				LastPeriodStart:        &lastGeneratedAt,
 				PaymentTermsDays:       14,
 				Reference:              "PO-123",
 				Notes:                  "Bill on month end",
@@ -268,6 +321,7 @@ func TestRecurringInvoiceLineModelMappingRoundTrip(t *testing.T) {
 				LineNumber:         1,
 				Description:        "Subscription",
 				Quantity:           decimal.NewFromInt(1),
+				UsageMetric:        "seats",
 				UnitPrice:          decimal.RequireFromString("49.99"),
 				DiscountPercent:    decimal.Zero,
 				VATRate:            decimal.Zero,
@@ -291,3 +345,37 @@ func TestRecurringInvoiceLineModelMappingRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestUsageRecordAndPriceChangeModelMappingRoundTrip(t *testing.T) {
+	createdAt := time.Date(2026, time.March, 1, 8, 0, 0, 0, time.UTC)
+	invoiceID := "invoice-1"
+	lineNumber := 2
+	unitPrice := decimal.RequireFromString("12.50")
+	uplift := decimal.RequireFromString("3.5")
+
+	usage := &UsageRecord{
+		ID:                 "usage-1",
+		TenantID:           "tenant-1",
+		RecurringInvoiceID: "recurring-invoice-1",
+		Metric:             "kwh",
+		UsageDate:          time.Date(2026, time.February, 28, 0, 0, 0, 0, time.UTC),
+		Quantity:           decimal.RequireFromString("125.5"),
+		Reference:          "meter-7",
+		InvoiceID:          &invoiceID,
+		CreatedAt:          createdAt,
+		CreatedBy:          "user-1",
+	}
+	assert.Equal(t, usage, modelToUsageRecord(usageRecordToModel(usage)))
+
+	usage.CreatedBy = ""
+	model := usageRecordToModel(usage)
+	assert.Nil(t, model.CreatedBy)
+	assert.Equal(t, usage, modelToUsageRecord(model))
+
+	for _, change := range []*PriceChange{
+		{ID: "change-1", TenantID: "tenant-1", RecurringInvoiceID: "recurring-invoice-1", LineNumber: &lineNumber, EffectiveDate: createdAt, UnitPrice: &unitPrice, CreatedAt: createdAt, CreatedBy: "user-1"},
+		{ID: "change-2", TenantID: "tenant-1", RecurringInvoiceID: "recurring-invoice-1", EffectiveDate: createdAt, UpliftPercent: &uplift, Note: "CPI", AppliedAt: &createdAt, CreatedAt: createdAt},
+	} {
+		assert.Equal(t, change, modelToPriceChange(priceChangeToModel(change)))
+	}
+}
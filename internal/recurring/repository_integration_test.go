@@ -22,7 +22,7 @@ func TestCreateTenantSchemaIncludesRecurringTables(t *testing.T) {
 	tenant := testutil.CreateTestTenant(t, pool)
 	ctx := context.Background()
 
-	for _, tableName := range []string{"recurring_invoices", "recurring_invoice_lines"} {
+	for _, tableName := range []string{"recurring_invoices", "recurring_invoice_lines", "recurring_invoice_usage", "recurring_invoice_price_changes"} {
 		var exists bool
 		if err := pool.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", tenant.SchemaName+"."+tableName).Scan(&exists); err != nil {
 			t.Fatalf("failed to inspect %s: %v", tableName, err)
@@ -32,7 +32,7 @@ func TestCreateTenantSchemaIncludesRecurringTables(t *testing.T) {
 		}
 	}
 
-	for _, columnName := range []string{"invoice_type", "currency", "reference", "generated_count", "send_email_on_generation", "email_template_type", "recipient_email_override", "attach_pdf_to_email", "email_subject_override", "email_message"} {
+	for _, columnName := range []string{"invoice_type", "currency", "reference", "generated_count", "send_email_on_generation", "email_template_type", "recipient_email_override", "attach_pdf_to_email", "email_subject_override", "email_message", "billing_anchor_date", "last_period_start"} {
 		var exists bool
 		if err := pool.QueryRow(ctx, `
 			SELECT EXISTS (
@@ -50,7 +50,7 @@ func TestCreateTenantSchemaIncludesRecurringTables(t *testing.T) {
 		}
 	}
 
-	for _, columnName := range []string{"unit", "discount_percent", "product_id"} {
+	for _, columnName := range []string{"unit", "discount_percent", "product_id", "usage_metric"} {
 		var exists bool
 		if err := pool.QueryRow(ctx, `
 			SELECT EXISTS (
@@ -752,6 +752,222 @@ func TestRepository_Delete_NotFound(t *testing.T) {
 	}
 }
 
+func TestRepository_UsageBilling(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	tenant := testutil.CreateTestTenant(t, pool)
+
+	repo := newRecurringGORMRepository(t, pool)
+	contactID := uuid.New().String()
+	_, err := pool.Exec(context.Background(), `
+		INSERT INTO `+tenant.SchemaName+`.contacts
+		(id, tenant_id, code, name, contact_type, country_code, payment_terms_days, credit_limit, is_active, created_at, updated_at)
+		VALUES ($1, $2, 'C012', 'Test Customer', 'CUSTOMER', 'EE', 14, 0, true, NOW(), NOW())
+	`, contactID, tenant.ID)
+	if err != nil {
+		t.Fatalf("Failed to create test contact: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	periodStart := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	anchor := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)
+	ri := &RecurringInvoice{
+		ID:                 uuid.New().String(),
+		TenantID:           tenant.ID,
+		Name:               "Metered hosting",
+		ContactID:          contactID,
+		InvoiceType:        "SALES",
+		Currency:           "EUR",
+		Frequency:          FrequencyMonthly,
+		StartDate:          periodStart,
+		NextGenerationDate: periodStart,
+		BillingAnchorDate:  &anchor,
+		PaymentTermsDays:   14,
+		IsActive:           true,
+		CreatedAt:          now,
+		CreatedBy:          uuid.New().String(),
+		UpdatedAt:          now,
+	}
+	if err := repo.Create(ctx, tenant.SchemaName, ri); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	line := &RecurringInvoiceLine{
+		ID:                 uuid.New().String(),
+		RecurringInvoiceID: ri.ID,
+		LineNumber:         1,
+		Description:        "Electricity",
+		Quantity:           decimal.NewFromInt(1),
+		UnitPrice:          decimal.RequireFromString("0.20"),
+		DiscountPercent:    decimal.Zero,
+		VATRate:            decimal.NewFromInt(22),
+		UsageMetric:        "kwh",
+	}
+	if err := repo.CreateLine(ctx, tenant.SchemaName, line); err != nil {
+		t.Fatalf("CreateLine failed: %v", err)
+	}
+
+	usage := []UsageRecord{
+		{ID: uuid.New().String(), TenantID: tenant.ID, RecurringInvoiceID: ri.ID, Metric: "kwh", UsageDate: periodStart, Quantity: decimal.RequireFromString("120.5"), CreatedAt: now},
+		{ID: uuid.New().String(), TenantID: tenant.ID, RecurringInvoiceID: ri.ID, Metric: "kwh", UsageDate: periodStart.AddDate(0, 0, 5), Quantity: decimal.NewFromInt(80), Reference: "meter-2", CreatedAt: now, CreatedBy: uuid.New().String()},
+	}
+	if err := repo.CreateUsageRecords(ctx, tenant.SchemaName, usage); err != nil {
+		t.Fatalf("CreateUsageRecords failed: %v", err)
+	}
+	uplift := decimal.RequireFromString("3.5")
+	change := &PriceChange{
+		ID:                 uuid.New().String(),
+		TenantID:           tenant.ID,
+		RecurringInvoiceID: ri.ID,
+		EffectiveDate:      anchor,
+		UpliftPercent:      &uplift,
+		Note:               "CPI",
+		CreatedAt:          now,
+	}
+	if err := repo.CreatePriceChange(ctx, tenant.SchemaName, change); err != nil {
+		t.Fatalf("CreatePriceChange failed: %v", err)
+	}
+
+	retrievedLines, err := repo.GetLines(ctx, tenant.SchemaName, ri.ID)
+	if err != nil {
+		t.Fatalf("GetLines failed: %v", err)
+	}
+	if len(retrievedLines) != 1 || retrievedLines[0].UsageMetric != "kwh" {
+		t.Fatalf("expected usage-based line, got %+v", retrievedLines)
+	}
+
+	records, err := repo.ListUsageRecords(ctx, tenant.SchemaName, tenant.ID, ri.ID, true)
+	if err != nil {
+		t.Fatalf("ListUsageRecords failed: %v", err)
+	}
+	if len(records) != 2 || !records[0].Quantity.Equal(decimal.RequireFromString("120.5")) {
+		t.Fatalf("unexpected usage records: %+v", records)
+	}
+
+	if err := repo.DeleteUsageRecord(ctx, tenant.SchemaName, tenant.ID, ri.ID, uuid.New().String()); err != ErrUsageRecordNotFound {
+		t.Fatalf("expected ErrUsageRecordNotFound, got %v", err)
+	}
+
+	invoiceID := uuid.New().String()
+	if err := repo.RecordBilling(ctx, tenant.SchemaName, tenant.ID, &BillingRecord{
+		RecurringInvoiceID:    ri.ID,
+		PeriodStart:           anchor,
+		NextGenerationDate:    anchor.AddDate(0, 1, 0),
+		InvoiceID:             invoiceID,
+		UsageIDs:              []string{usage[0].ID},
+		LinePrices:            map[string]decimal.Decimal{line.ID: decimal.RequireFromString("0.207")},
+		AppliedPriceChangeIDs: []string{change.ID},
+		AppliedAt:             now,
+	}); err != nil {
+		t.Fatalf("RecordBilling failed: %v", err)
+	}
+
+	retrieved, err := repo.GetByID(ctx, tenant.SchemaName, tenant.ID, ri.ID)
+	if err != nil {
+		t.Fatalf("GetByID failed: %v", err)
+	}
+	if retrieved.LastPeriodStart == nil || !retrieved.LastPeriodStart.Equal(anchor) {
+		t.Errorf("expected last period start %v, got %v", anchor, retrieved.LastPeriodStart)
+	}
+	if retrieved.BillingAnchorDate == nil || !retrieved.BillingAnchorDate.Equal(anchor) {
+		t.Errorf("expected billing anchor %v, got %v", anchor, retrieved.BillingAnchorDate)
+	}
+	if !retrieved.NextGenerationDate.Equal(anchor.AddDate(0, 1, 0)) {
+		t.Errorf("expected next generation date %v, got %v", anchor.AddDate(0, 1, 0), retrieved.NextGenerationDate)
+	}
+
+	retrievedLines, err = repo.GetLines(ctx, tenant.SchemaName, ri.ID)
+	if err != nil {
+		t.Fatalf("GetLines failed: %v", err)
+	}
+	if !retrievedLines[0].UnitPrice.Equal(decimal.RequireFromString("0.207")) {
+		t.Errorf("expected repriced line 0.207, got %s", retrievedLines[0].UnitPrice)
+	}
+
+	unbilled, err := repo.ListUsageRecords(ctx, tenant.SchemaName, tenant.ID, ri.ID, true)
+	if err != nil {
+		t.Fatalf("ListUsageRecords failed: %v", err)
+	}
+	if len(unbilled) != 1 || unbilled[0].ID != usage[1].ID {
+		t.Fatalf("expected only the second record unbilled, got %+v", unbilled)
+	}
+	if err := repo.DeleteUsageRecord(ctx, tenant.SchemaName, tenant.ID, ri.ID, usage[0].ID); err != ErrUsageRecordNotFound {
+		t.Fatalf("expected invoiced usage to be kept, got %v", err)
+	}
+	if err := repo.DeleteUsageRecord(ctx, tenant.SchemaName, tenant.ID, ri.ID, usage[1].ID); err != nil {
+		t.Fatalf("DeleteUsageRecord failed: %v", err)
+	}
+
+	changes, err := repo.ListPriceChanges(ctx, tenant.SchemaName, tenant.ID, ri.ID)
+	if err != nil {
+		t.Fatalf("ListPriceChanges failed: %v", err)
+	}
+	if len(changes) != 1 || changes[0].AppliedAt == nil || changes[0].UpliftPercent == nil || !changes[0].UpliftPercent.Equal(uplift) {
+		t.Fatalf("expected applied uplift, got %+v", changes)
+	}
+	if err := repo.DeletePriceChange(ctx, tenant.SchemaName, tenant.ID, ri.ID, change.ID); err != ErrPriceChangeNotFound {
+		t.Fatalf("expected applied price change to be kept, got %v", err)
+	}
+}
+
+func TestRepository_GetDueRecurringInvoiceIDsIncludesClosingRun(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	tenant := testutil.CreateTestTenant(t, pool)
+
+	repo := newRecurringGORMRepository(t, pool)
+	contactID := uuid.New().String()
+	_, err := pool.Exec(context.Background(), `
+		INSERT INTO `+tenant.SchemaName+`.contacts
+		(id, tenant_id, code, name, contact_type, country_code, payment_terms_days, credit_limit, is_active, created_at, updated_at)
+		VALUES ($1, $2, 'C013', 'Test Customer', 'CUSTOMER', 'EE', 14, 0, true, NOW(), NOW())
+	`, contactID, tenant.ID)
+	if err != nil {
+		t.Fatalf("Failed to create test contact: %v", err)
+	}
+
+	ctx := context.Background()
+	now := time.Now()
+	endDate := now.AddDate(0, 0, -10)
+	ri := &RecurringInvoice{
+		ID:                 uuid.New().String(),
+		TenantID:           tenant.ID,
+		Name:               "Ended metered service",
+		ContactID:          contactID,
+		InvoiceType:        "SALES",
+		Currency:           "EUR",
+		Frequency:          FrequencyMonthly,
+		StartDate:          now.AddDate(0, -2, 0),
+		EndDate:            &endDate,
+		NextGenerationDate: endDate.AddDate(0, 0, 1),
+		PaymentTermsDays:   14,
+		IsActive:           true,
+		CreatedAt:          now,
+		CreatedBy:          uuid.New().String(),
+		UpdatedAt:          now,
+	}
+	if err := repo.Create(ctx, tenant.SchemaName, ri); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	dueIDs, err := repo.GetDueRecurringInvoiceIDs(ctx, tenant.SchemaName, tenant.ID, now)
+	if err != nil {
+		t.Fatalf("GetDueRecurringInvoiceIDs failed: %v", err)
+	}
+	if len(dueIDs) != 1 || dueIDs[0] != ri.ID {
+		t.Fatalf("expected the closing run to be due, got %v", dueIDs)
+	}
+
+	if err := repo.UpdateAfterGeneration(ctx, tenant.SchemaName, tenant.ID, ri.ID, endDate.AddDate(0, 1, 0), now); err != nil {
+		t.Fatalf("UpdateAfterGeneration failed: %v", err)
+	}
+	dueIDs, err = repo.GetDueRecurringInvoiceIDs(ctx, tenant.SchemaName, tenant.ID, now)
+	if err != nil {
+		t.Fatalf("GetDueRecurringInvoiceIDs failed: %v", err)
+	}
+	if len(dueIDs) != 0 {
+		t.Fatalf("expected no due runs after the closing run, got %v", dueIDs)
+	}
+}
+
 func newRecurringGORMRepository(t *testing.T, pool *pgxpool.Pool) *GORMRepository {
 	t.Helper()
 
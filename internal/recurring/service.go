@@ -3,6 +3,7 @@ package recurring
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/HMB-research/open-accounting/internal/database"
@@ -14,6 +15,7 @@ import (
 	"github.com/HMB-research/open-accounting/internal/email"
 	"github.com/HMB-research/open-accounting/internal/invoicing"
 	"github.com/HMB-research/open-accounting/internal/pdf"
+	"github.com/HMB-research/open-accounting/internal/pricing"
 	"github.com/HMB-research/open-accounting/internal/tenant"
 )
 
@@ -54,6 +56,7 @@ type Service struct {
 	pdfSvc    PDFService
 	tenant    TenantService
 	contacts  ContactsService
+	pricing   pricing.Resolver
 }
 
 var newGormDBFromPool = database.NewGormDBFromPool
@@ -95,6 +98,13 @@ func NewServiceWithDependencies(repo Repository, invoicing InvoicingService, ema
 	}
 }
 
+// WithPricing resolves unit prices and discounts from price lists for
+// product lines sent without a unit price.
+func (s *Service) WithPricing(prices pricing.Resolver) *Service {
+	s.pricing = prices
+	return s
+}
+
 func (s *Service) requireRepository() error {
 	if s.repo == nil {
 		return fmt.Errorf("repository not available")
@@ -131,6 +141,7 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 		StartDate:          req.StartDate,
 		EndDate:            req.EndDate,
 		NextGenerationDate: req.StartDate,
+		BillingAnchorDate:  req.BillingAnchorDate,
 		PaymentTermsDays:   req.PaymentTermsDays,
 		Reference:          req.Reference,
 		Notes:              req.Notes,
@@ -161,6 +172,11 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 
 	// Convert lines
 	for i, reqLine := range req.Lines {
+		if ri.InvoiceType == "SALES" {
+			if err := s.applyProductPrice(ctx, tenantID, schemaName, pricing.Document{ContactID: ri.ContactID, Currency: ri.Currency, Date: ri.StartDate}, i+1, &reqLine); err != nil {
+				return nil, err
+			}
+		}
 		line := RecurringInvoiceLine{
 			ID:                 uuid.New().String(),
 			RecurringInvoiceID: ri.ID,
@@ -168,11 +184,12 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 			Description:        reqLine.Description,
 			Quantity:           reqLine.Quantity,
 			Unit:               reqLine.Unit,
-			UnitPrice:          reqLine.UnitPrice,
+			UnitPrice:          reqLine.unitPrice(),
 			DiscountPercent:    reqLine.DiscountPercent,
 			VATRate:            reqLine.VATRate,
 			AccountID:          reqLine.AccountID,
 			ProductID:          reqLine.ProductID,
+			UsageMetric:        strings.TrimSpace(reqLine.UsageMetric),
 		}
 		if line.Quantity.IsZero() {
 			line.Quantity = decimal.NewFromInt(1)
@@ -200,6 +217,27 @@ func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *
 	return ri, nil
 }
 
+// applyProductPrice fills a product line sent without a unit price from the
+// customer's price lists.
+func (s *Service) applyProductPrice(ctx context.Context, tenantID, schemaName string, doc pricing.Document, lineNumber int, line *CreateRecurringInvoiceLineRequest) error {
+	priced := pricing.DocumentLine{
+		ProductID:       line.ProductID,
+		Quantity:        line.Quantity,
+		UnitPrice:       line.UnitPrice,
+		DiscountPercent: line.DiscountPercent,
+		Description:     line.Description,
+		Unit:            line.Unit,
+	}
+	if err := pricing.ApplyProductPrice(ctx, s.pricing, tenantID, schemaName, doc, &priced); err != nil {
+		return fmt.Errorf("resolve price for line %d: %w", lineNumber, err)
+	}
+	line.UnitPrice = priced.UnitPrice
+	line.DiscountPercent = priced.DiscountPercent
+	line.Description = priced.Description
+	line.Unit = priced.Unit
+	return nil
+}
+
 // CreateFromInvoice creates a recurring invoice from an existing invoice
 func (s *Service) CreateFromInvoice(ctx context.Context, tenantID, schemaName string, req *CreateFromInvoiceRequest) (*RecurringInvoice, error) {
 	invoice, err := s.invoicing.GetByID(ctx, tenantID, schemaName, req.InvoiceID)
@@ -214,7 +252,7 @@ func (s *Service) CreateFromInvoice(ctx context.Context, tenantID, schemaName st
 			Description:     invLine.Description,
 			Quantity:        invLine.Quantity,
 			Unit:            invLine.Unit,
-			UnitPrice:       invLine.UnitPrice,
+			UnitPrice:       new(invLine.UnitPrice),
 			DiscountPercent: invLine.DiscountPercent,
 			VATRate:         invLine.VATRate,
 			AccountID:       invLine.AccountID,
@@ -261,6 +299,12 @@ func (s *Service) GetByID(ctx context.Context, tenantID, schemaName, id string)
 	}
 	ri.Lines = lines
 
+	changes, err := s.repo.ListPriceChanges(ctx, schemaName, tenantID, id)
+	if err != nil {
+		return nil, fmt.Errorf("get recurring invoice price changes: %w", err)
+	}
+	ri.PriceChanges = changes
+
 	return ri, nil
 }
 
@@ -353,11 +397,12 @@ func (s *Service) Update(ctx context.Context, tenantID, schemaName, id string, r
 				Description:        reqLine.Description,
 				Quantity:           reqLine.Quantity,
 				Unit:               reqLine.Unit,
-				UnitPrice:          reqLine.UnitPrice,
+				UnitPrice:          reqLine.unitPrice(),
 				DiscountPercent:    reqLine.DiscountPercent,
 				VATRate:            reqLine.VATRate,
 				AccountID:          reqLine.AccountID,
 				ProductID:          reqLine.ProductID,
+				UsageMetric:        strings.TrimSpace(reqLine.UsageMetric),
 			}
 			if line.Quantity.IsZero() {
 				line.Quantity = decimal.NewFromInt(1)
@@ -427,6 +472,9 @@ func (s *Service) GenerateDueInvoices(ctx context.Context, tenantID, schemaName,
 			// Log error but continue with other recurring invoices
 			continue
 		}
+		if result.Skipped {
+			continue
+		}
 		results = append(results, *result)
 	}
 
@@ -444,24 +492,45 @@ func (s *Service) GenerateInvoice(ctx context.Context, tenantID, schemaName, rec
 		return nil, fmt.Errorf("recurring invoice is not active")
 	}
 
+	var usage []UsageRecord
+	if ri.hasUsageLines() {
+		usage, err = s.repo.ListUsageRecords(ctx, schemaName, tenantID, recurringID, true)
+		if err != nil {
+			return nil, fmt.Errorf("list usage records: %w", err)
+		}
+	}
+	plan, err := planGeneration(ri, usage)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GenerationResult{
+		RecurringInvoiceID: recurringID,
+		EmailSent:          false,
+		EmailStatus:        "SKIPPED",
+	}
+	if plan.periodStart.Before(plan.periodEnd) {
+		periodEnd := plan.periodEnd.AddDate(0, 0, -1)
+		result.PeriodStart = &plan.periodStart
+		result.PeriodEnd = &periodEnd
+	}
+
+	now := time.Now()
+	plan.record.AppliedAt = now
+	if len(plan.lines) == 0 {
+		// Nothing billable in this run, e.g. the first period of a usage-only
+		// recurring invoice: move on to the next period without an invoice
+		if err := s.repo.RecordBilling(ctx, schemaName, tenantID, &plan.record); err != nil {
+			return nil, fmt.Errorf("record billing: %w", err)
+		}
+		result.Skipped = true
+		return result, nil
+	}
+
 	// Create invoice request from recurring invoice
 	issueDate := time.Now()
 	dueDate := issueDate.AddDate(0, 0, ri.PaymentTermsDays)
 
-	lines := make([]invoicing.CreateInvoiceLineRequest, 0, len(ri.Lines))
-	for _, riLine := range ri.Lines {
-		lines = append(lines, invoicing.CreateInvoiceLineRequest{
-			Description:     riLine.Description,
-			Quantity:        riLine.Quantity,
-			Unit:            riLine.Unit,
-			UnitPrice:       riLine.UnitPrice,
-			DiscountPercent: riLine.DiscountPercent,
-			VATRate:         riLine.VATRate,
-			AccountID:       riLine.AccountID,
-			ProductID:       riLine.ProductID,
-		})
-	}
-
 	invoiceReq := &invoicing.CreateInvoiceRequest{
 		InvoiceType:  invoicing.InvoiceType(ri.InvoiceType),
 		ContactID:    ri.ContactID,
@@ -471,7 +540,7 @@ func (s *Service) GenerateInvoice(ctx context.Context, tenantID, schemaName, rec
 		ExchangeRate: decimal.NewFromInt(1),
 		Reference:    ri.Reference,
 		Notes:        ri.Notes,
-		Lines:        lines,
+		Lines:        plan.lines,
 		UserID:       userID,
 	}
 
@@ -481,21 +550,19 @@ func (s *Service) GenerateInvoice(ctx context.Context, tenantID, schemaName, rec
 		return nil, fmt.Errorf("create invoice: %w", err)
 	}
 
+	// Record the billed usage and period so they are not billed again
+	plan.record.InvoiceID = invoice.ID
+	if err := s.repo.RecordBilling(ctx, schemaName, tenantID, &plan.record); err != nil {
+		return nil, fmt.Errorf("record billing: %w", err)
+	}
+
 	// Update recurring invoice via repository
-	nextDate := ri.CalculateNextDate(ri.NextGenerationDate)
-	now := time.Now()
-	if err := s.repo.UpdateAfterGeneration(ctx, schemaName, tenantID, recurringID, nextDate, now); err != nil {
+	if err := s.repo.UpdateAfterGeneration(ctx, schemaName, tenantID, recurringID, plan.nextDate, now); err != nil {
 		return nil, fmt.Errorf("update recurring invoice: %w", err)
 	}
 
-	// Prepare result
-	result := &GenerationResult{
-		RecurringInvoiceID:     recurringID,
-		GeneratedInvoiceID:     invoice.ID,
-		GeneratedInvoiceNumber: invoice.InvoiceNumber,
-		EmailSent:              false,
-		EmailStatus:            "SKIPPED",
-	}
+	result.GeneratedInvoiceID = invoice.ID
+	result.GeneratedInvoiceNumber = invoice.InvoiceNumber
 
 	// Send email if configured
 	if ri.SendEmailOnGeneration {
@@ -36,6 +36,13 @@ type MockRepository struct {
 	getDueIDsErr                error
 	updateAfterGenErr           error
 	updateInvoiceEmailStatusErr error
+	recordBillingErr            error
+	createUsageErr              error
+	listUsageErr                error
+	listPriceChangesErr         error
+	createPriceChangeErr        error
+	usage                       []UsageRecord
+	priceChanges                []PriceChange
 }
 
 func NewMockRepository() *MockRepository {
@@ -174,6 +181,104 @@ func (m *MockRepository) UpdateInvoiceEmailStatus(ctx context.Context, schemaNam
 	return nil
 }
 
+func (m *MockRepository) RecordBilling(ctx context.Context, schemaName, tenantID string, record *BillingRecord) error {
+	if m.recordBillingErr != nil {
+		return m.recordBillingErr
+	}
+	ri, ok := m.recurring[tenantID+":"+record.RecurringInvoiceID]
+	if !ok {
+		return ErrRecurringInvoiceNotFound
+	}
+	periodStart := record.PeriodStart
+	ri.LastPeriodStart = &periodStart
+	ri.NextGenerationDate = record.NextGenerationDate
+	for i := range m.usage {
+		for _, id := range record.UsageIDs {
+			if m.usage[i].ID == id && record.InvoiceID != "" && m.usage[i].InvoiceID == nil {
+				invoiceID := record.InvoiceID
+				m.usage[i].InvoiceID = &invoiceID
+			}
+		}
+	}
+	lines := m.lines[record.RecurringInvoiceID]
+	for i := range lines {
+		if price, ok := record.LinePrices[lines[i].ID]; ok {
+			lines[i].UnitPrice = price
+		}
+	}
+	for i := range m.priceChanges {
+		for _, id := range record.AppliedPriceChangeIDs {
+			if m.priceChanges[i].ID == id {
+				appliedAt := record.AppliedAt
+				m.priceChanges[i].AppliedAt = &appliedAt
+			}
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) CreateUsageRecords(ctx context.Context, schemaName string, records []UsageRecord) error {
+	if m.createUsageErr != nil {
+		return m.createUsageErr
+	}
+	m.usage = append(m.usage, records...)
+	return nil
+}
+
+func (m *MockRepository) ListUsageRecords(ctx context.Context, schemaName, tenantID, recurringInvoiceID string, unbilledOnly bool) ([]UsageRecord, error) {
+	if m.listUsageErr != nil {
+		return nil, m.listUsageErr
+	}
+	var records []UsageRecord
+	for _, record := range m.usage {
+		if record.TenantID == tenantID && record.RecurringInvoiceID == recurringInvoiceID && (!unbilledOnly || record.InvoiceID == nil) {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func (m *MockRepository) DeleteUsageRecord(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error {
+	for i, record := range m.usage {
+		if record.ID == id && record.TenantID == tenantID && record.RecurringInvoiceID == recurringInvoiceID && record.InvoiceID == nil {
+			m.usage = append(m.usage[:i], m.usage[i+1:]...)
+			return nil
+		}
+	}
+	return ErrUsageRecordNotFound
+}
+
+func (m *MockRepository) CreatePriceChange(ctx context.Context, schemaName string, change *PriceChange) error {
+	if m.createPriceChangeErr != nil {
+		return m.createPriceChangeErr
+	}
+	m.priceChanges = append(m.priceChanges, *change)
+	return nil
+}
+
+func (m *MockRepository) ListPriceChanges(ctx context.Context, schemaName, tenantID, recurringInvoiceID string) ([]PriceChange, error) {
+	if m.listPriceChangesErr != nil {
+		return nil, m.listPriceChangesErr
+	}
+	var changes []PriceChange
+	for _, change := range m.priceChanges {
+		if change.TenantID == tenantID && change.RecurringInvoiceID == recurringInvoiceID {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (m *MockRepository) DeletePriceChange(ctx context.Context, schemaName, tenantID, recurringInvoiceID, id string) error {
+	for i, change := range m.priceChanges {
+		if change.ID == id && change.TenantID == tenantID && change.RecurringInvoiceID == recurringInvoiceID && change.AppliedAt == nil {
+			m.priceChanges = append(m.priceChanges[:i], m.priceChanges[i+1:]...)
+			return nil
+		}
+	}
+	return ErrPriceChangeNotFound
+}
+
 // =============================================================================
 // Mock Invoicing Service
 // =============================================================================
@@ -183,6 +288,7 @@ type MockInvoicingService struct {
 	createErr    error
 	getByIDErr   error
 	lastCreateID string
+	lastRequest  *invoicing.CreateInvoiceRequest
 }
 
 func NewMockInvoicingService() *MockInvoicingService {
@@ -219,6 +325,7 @@ func (m *MockInvoicingService) Create(ctx context.Context, tenantID, schemaName
 	}
 	m.invoices[inv.ID] = inv
 	m.lastCreateID = inv.ID
+	m.lastRequest = req
 	return inv, nil
 }
 
@@ -1214,7 +1321,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromFloat(20.00),
 					},
 				},
@@ -1236,7 +1343,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromFloat(20.00),
 					},
 				},
@@ -1257,7 +1364,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 					},
 				},
 			},
@@ -1295,7 +1402,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromFloat(20.00),
 					},
 				},
@@ -1323,7 +1430,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromFloat(20.00),
 					},
 				},
@@ -1347,7 +1454,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromFloat(20.00),
 					},
 				},
@@ -1369,7 +1476,7 @@ func TestService_Create(t *testing.T) {
 					{
 						Description: "Service Fee",
 						Quantity:    decimal.Zero, // Zero quantity should default to 1
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 						VATRate:     decimal.NewFromFloat(20.00),
 					},
 				},
@@ -1941,12 +2048,12 @@ func TestService_Update(t *testing.T) {
 					{
 						Description: "New Service A",
 						Quantity:    decimal.NewFromInt(2),
-						UnitPrice:   decimal.NewFromFloat(50.00),
+						UnitPrice:   new(decimal.NewFromFloat(50.00)),
 					},
 					{
 						Description: "New Service B",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(75.00),
+						UnitPrice:   new(decimal.NewFromFloat(75.00)),
 					},
 				},
 			},
@@ -1977,7 +2084,7 @@ func TestService_Update(t *testing.T) {
 					{
 						Description: "New Service",
 						Quantity:    decimal.Zero, // Zero quantity should default to 1
-						UnitPrice:   decimal.NewFromFloat(50.00),
+						UnitPrice:   new(decimal.NewFromFloat(50.00)),
 					},
 				},
 			},
@@ -2047,7 +2154,7 @@ func TestService_Update(t *testing.T) {
 					{
 						Description: "New Service",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 					},
 				},
 			},
@@ -2080,7 +2187,7 @@ func TestService_Update(t *testing.T) {
 					{
 						Description: "New Service",
 						Quantity:    decimal.NewFromInt(1),
-						UnitPrice:   decimal.NewFromFloat(100.00),
+						UnitPrice:   new(decimal.NewFromFloat(100.00)),
 					},
 				},
 			},
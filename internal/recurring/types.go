@@ -2,6 +2,7 @@ package recurring
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -31,6 +32,8 @@ type RecurringInvoice struct {
 	StartDate          time.Time              `json:"start_date"`
 	EndDate            *time.Time             `json:"end_date,omitempty"`
 	NextGenerationDate time.Time              `json:"next_generation_date"`
+	BillingAnchorDate  *time.Time             `json:"billing_anchor_date,omitempty"`
+	LastPeriodStart    *time.Time             `json:"last_period_start,omitempty"`
 	PaymentTermsDays   int                    `json:"payment_terms_days"`
 	Reference          string                 `json:"reference,omitempty"`
 	Notes              string                 `json:"notes,omitempty"`
@@ -38,6 +41,7 @@ type RecurringInvoice struct {
 	LastGeneratedAt    *time.Time             `json:"last_generated_at,omitempty"`
 	GeneratedCount     int                    `json:"generated_count"`
 	Lines              []RecurringInvoiceLine `json:"lines"`
+	PriceChanges       []PriceChange          `json:"price_changes,omitempty"`
 	CreatedAt          time.Time              `json:"created_at"`
 	CreatedBy          string                 `json:"created_by"`
 	UpdatedAt          time.Time              `json:"updated_at"`
@@ -64,6 +68,14 @@ type RecurringInvoiceLine struct {
 	VATRate            decimal.Decimal `json:"vat_rate"`
 	AccountID          *string         `json:"account_id,omitempty"`
 	ProductID          *string         `json:"product_id,omitempty"`
+	// UsageMetric makes the line usage-based: its quantity is the sum of the
+	// usage records reported for the metric instead of Quantity
+	UsageMetric string `json:"usage_metric,omitempty"`
+}
+
+// IsUsageBased reports whether the line bills reported usage
+func (l *RecurringInvoiceLine) IsUsageBased() bool {
+	return l.UsageMetric != ""
 }
 
 // Validate validates the recurring invoice
@@ -83,13 +95,23 @@ func (r *RecurringInvoice) Validate() error {
 	if !isValidFrequency(r.Frequency) {
 		return errors.New("invalid frequency")
 	}
+	if r.BillingAnchorDate != nil && (!r.BillingAnchorDate.After(r.StartDate) || r.BillingAnchorDate.After(r.CalculateNextDate(r.StartDate))) {
+		return errors.New("billing anchor date must fall within the first period after the start date")
+	}
 	if r.PaymentTermsDays < 0 {
 		return errors.New("payment terms days cannot be negative")
 	}
 	if len(r.Lines) == 0 {
 		return errors.New("at least one line item is required")
 	}
+	metrics := make(map[string]bool)
 	for _, line := range r.Lines {
+		if line.UsageMetric != "" {
+			if metrics[line.UsageMetric] {
+				return fmt.Errorf("usage metric %q is used on more than one line", line.UsageMetric)
+			}
+			metrics[line.UsageMetric] = true
+		}
 		if line.Description == "" {
 			return errors.New("line description is required")
 		}
@@ -129,12 +151,24 @@ func (r *RecurringInvoice) CalculateNextDate(from time.Time) time.Time {
 	}
 }
 
-// IsDue returns true if the recurring invoice is due for generation
+// UsageLine returns the usage-based line billing the metric
+func (r *RecurringInvoice) UsageLine(metric string) *RecurringInvoiceLine {
+	for i := range r.Lines {
+		if r.Lines[i].UsageMetric != "" && r.Lines[i].UsageMetric == metric {
+			return &r.Lines[i]
+		}
+	}
+	return nil
+}
+
+// IsDue returns true if the recurring invoice is due for generation. After
+// the end date one closing run remains due when the schedule stopped at the
+// day after the end date, so usage reported for the final period is billed.
 func (r *RecurringInvoice) IsDue() bool {
 	if !r.IsActive {
 		return false
 	}
-	if r.EndDate != nil && time.Now().After(*r.EndDate) {
+	if r.EndDate != nil && time.Now().After(*r.EndDate) && r.NextGenerationDate.After(r.EndDate.AddDate(0, 0, 1)) {
 		return false
 	}
 	return !time.Now().Before(r.NextGenerationDate)
@@ -155,6 +189,10 @@ type CreateRecurringInvoiceRequest struct {
 	Lines            []CreateRecurringInvoiceLineRequest `json:"lines"`
 	UserID           string                              `json:"-"`
 
+	// BillingAnchorDate starts the first full period after the start date;
+	// the first invoice is prorated from the start date up to it
+	BillingAnchorDate *time.Time `json:"billing_anchor_date,omitempty"`
+
 	// Email configuration
 	SendEmailOnGeneration  bool   `json:"send_email_on_generation"`
 	EmailTemplateType      string `json:"email_template_type,omitempty"`
@@ -166,14 +204,24 @@ type CreateRecurringInvoiceRequest struct {
 
 // CreateRecurringInvoiceLineRequest is a line in the create request
 type CreateRecurringInvoiceLineRequest struct {
-	Description     string          `json:"description"`
-	Quantity        decimal.Decimal `json:"quantity"`
-	Unit            string          `json:"unit,omitempty"`
-	UnitPrice       decimal.Decimal `json:"unit_price"`
-	DiscountPercent decimal.Decimal `json:"discount_percent,omitempty"`
-	VATRate         decimal.Decimal `json:"vat_rate"`
-	AccountID       *string         `json:"account_id,omitempty"`
-	ProductID       *string         `json:"product_id,omitempty"`
+	Description     string           `json:"description"`
+	Quantity        decimal.Decimal  `json:"quantity"`
+	Unit            string           `json:"unit,omitempty"`
+	UnitPrice       *decimal.Decimal `json:"unit_price,omitempty"`
+	DiscountPercent decimal.Decimal  `json:"discount_percent,omitempty"`
+	VATRate         decimal.Decimal  `json:"vat_rate"`
+	AccountID       *string          `json:"account_id,omitempty"`
+	ProductID       *string          `json:"product_id,omitempty"`
+	UsageMetric     string           `json:"usage_metric,omitempty"`
+}
+
+// unitPrice returns the unit price sent on the line, zero when none was sent
+// and none was resolved.
+func (l *CreateRecurringInvoiceLineRequest) unitPrice() decimal.Decimal {
+	if l.UnitPrice == nil {
+		return decimal.Zero
+	}
+	return *l.UnitPrice
 }
 
 // ImportRecurringInvoicesRequest contains CSV payload for recurring invoice template migration.
@@ -242,4 +290,96 @@ type GenerationResult struct {
 	EmailStatus string `json:"email_status,omitempty"` // SENT, FAILED, SKIPPED, NO_CONFIG
 	EmailLogID  string `json:"email_log_id,omitempty"`
 	EmailError  string `json:"email_error,omitempty"`
+
+	// Billed service period of the fixed lines, and whether the run was
+	// skipped because nothing was billable
+	PeriodStart *time.Time `json:"period_start,omitempty"`
+	PeriodEnd   *time.Time `json:"period_end,omitempty"`
+	Skipped     bool       `json:"skipped,omitempty"`
+}
+
+// UsageRecord is a metered quantity reported for a usage-based line
+type UsageRecord struct {
+	ID                 string          `json:"id"`
+	TenantID           string          `json:"tenant_id"`
+	RecurringInvoiceID string          `json:"recurring_invoice_id"`
+	Metric             string          `json:"metric"`
+	UsageDate          time.Time       `json:"usage_date"`
+	Quantity           decimal.Decimal `json:"quantity"`
+	Reference          string          `json:"reference,omitempty"`
+	InvoiceID          *string         `json:"invoice_id,omitempty"`
+	CreatedAt          time.Time       `json:"created_at"`
+	CreatedBy          string          `json:"created_by,omitempty"`
+}
+
+// UsageRecordInput is a single usage record in a record request
+type UsageRecordInput struct {
+	Metric    string          `json:"metric"`
+	UsageDate time.Time       `json:"usage_date"`
+	Quantity  decimal.Decimal `json:"quantity"`
+	Reference string          `json:"reference,omitempty"`
+}
+
+// RecordUsageRequest reports usage for a recurring invoice
+type RecordUsageRequest struct {
+	Records []UsageRecordInput `json:"records"`
+	UserID  string             `json:"-"`
+}
+
+// ImportUsageRequest contains a CSV of usage records for any recurring invoices
+type ImportUsageRequest struct {
+	CSVContent string `json:"csv_content"`
+	FileName   string `json:"file_name,omitempty"`
+	UserID     string `json:"-"`
+}
+
+// ImportUsageResult summarizes a usage CSV import
+type ImportUsageResult struct {
+	FileName       string                            `json:"file_name,omitempty"`
+	RowsProcessed  int                               `json:"rows_processed"`
+	RecordsCreated int                               `json:"records_created"`
+	RowsSkipped    int                               `json:"rows_skipped"`
+	Errors         []ImportRecurringInvoicesRowError `json:"errors,omitempty"`
+}
+
+// PriceChange schedules a new unit price or a percentage uplift (such as an
+// annual CPI indexation) from an effective date. It applies to one line, or
+// to every line when LineNumber is nil. Once every period before the
+// effective date has been invoiced the change is folded into the line unit
+// prices and marked applied.
+type PriceChange struct {
+	ID                 string           `json:"id"`
+	TenantID           string           `json:"tenant_id"`
+	RecurringInvoiceID string           `json:"recurring_invoice_id"`
+	LineNumber         *int             `json:"line_number,omitempty"`
+	EffectiveDate      time.Time        `json:"effective_date"`
+	UnitPrice          *decimal.Decimal `json:"unit_price,omitempty"`
+	UpliftPercent      *decimal.Decimal `json:"uplift_percent,omitempty"`
+	Note               string           `json:"note,omitempty"`
+	AppliedAt          *time.Time       `json:"applied_at,omitempty"`
+	CreatedAt          time.Time        `json:"created_at"`
+	CreatedBy          string           `json:"created_by,omitempty"`
+}
+
+// AppliesTo reports whether the change affects the line
+func (c *PriceChange) AppliesTo(lineNumber int) bool {
+	return c.LineNumber == nil || *c.LineNumber == lineNumber
+}
+
+// Apply returns the unit price after the change
+func (c *PriceChange) Apply(price decimal.Decimal) decimal.Decimal {
+	if c.UnitPrice != nil {
+		return *c.UnitPrice
+	}
+	return price.Mul(decimal.NewFromInt(100).Add(*c.UpliftPercent)).Div(decimal.NewFromInt(100)).Round(4)
+}
+
+// CreatePriceChangeRequest schedules a price change
+type CreatePriceChangeRequest struct {
+	LineNumber    *int             `json:"line_number,omitempty"`
+	EffectiveDate time.Time        `json:"effective_date"`
+	UnitPrice     *decimal.Decimal `json:"unit_price,omitempty"`
+	UpliftPercent *decimal.Decimal `json:"uplift_percent,omitempty"`
+	Note          string           `json:"note,omitempty"`
+	UserID        string           `json:"-"`
 }
@@ -299,6 +299,7 @@ func TestRecurringInvoice_IsDue(t *testing.T) {
 	yesterday := now.Add(-24 * time.Hour)
 	tomorrow := now.Add(24 * time.Hour)
 	lastMonth := now.AddDate(0, -1, 0)
+	twoDaysAgo := now.AddDate(0, 0, -2)
 
 	tests := []struct {
 		name               string
@@ -342,6 +343,13 @@ func TestRecurringInvoice_IsDue(t *testing.T) {
 			nextGenerationDate: yesterday,
 			expected:           false,
 		},
+		{
+			name:               "Closing run due the day after the end date",
+			isActive:           true,
+			endDate:            &twoDaysAgo,
+			nextGenerationDate: twoDaysAgo.AddDate(0, 0, 1),
+			expected:           true,
+		},
 		{
 			name:               "Active with future end date and due",
 			isActive:           true,
@@ -478,7 +486,7 @@ func TestCreateRecurringInvoiceRequest_JSONSerialization(t *testing.T) {
 			{
 				Description: "Annual Support",
 				Quantity:    decimal.NewFromInt(1),
-				UnitPrice:   decimal.NewFromFloat(12000.00),
+				UnitPrice:   new(decimal.NewFromFloat(12000.00)),
 				VATRate:     decimal.NewFromFloat(22.00),
 			},
 		},
@@ -0,0 +1,177 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// RecordUsage stores usage reported for the usage-based lines of a recurring
+// invoice. It is billed by the next generation run after its period ends.
+func (s *Service) RecordUsage(ctx context.Context, tenantID, schemaName, recurringID string, req *RecordUsageRequest) ([]UsageRecord, error) {
+	ri, err := s.GetByID(ctx, tenantID, schemaName, recurringID)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Records) == 0 {
+		return nil, errors.New("at least one usage record is required")
+	}
+
+	records := make([]UsageRecord, 0, len(req.Records))
+	now := time.Now()
+	for i, input := range req.Records {
+		record, err := newUsageRecord(ri, input, req.UserID, now)
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		records = append(records, *record)
+	}
+
+	if err := s.repo.CreateUsageRecords(ctx, schemaName, records); err != nil {
+		return nil, fmt.Errorf("record usage: %w", err)
+	}
+	return records, nil
+}
+
+func newUsageRecord(ri *RecurringInvoice, input UsageRecordInput, userID string, now time.Time) (*UsageRecord, error) {
+	metric := strings.TrimSpace(input.Metric)
+	if metric == "" {
+		return nil, errors.New("metric is required")
+	}
+	if ri.UsageLine(metric) == nil {
+		return nil, fmt.Errorf("recurring invoice %q has no usage-based line for metric %q", ri.Name, metric)
+	}
+	if input.UsageDate.IsZero() {
+		return nil, errors.New("usage date is required")
+	}
+	usageDate := truncateToDate(input.UsageDate)
+	if usageDate.Before(truncateToDate(ri.StartDate)) {
+		return nil, fmt.Errorf("usage date %s is before the start date", usageDate.Format("2006-01-02"))
+	}
+	if ri.EndDate != nil && usageDate.After(truncateToDate(*ri.EndDate)) {
+		return nil, fmt.Errorf("usage date %s is after the end date", usageDate.Format("2006-01-02"))
+	}
+	if input.Quantity.IsNegative() {
+		return nil, errors.New("quantity cannot be negative")
+	}
+	return &UsageRecord{
+		ID:                 uuid.New().String(),
+		TenantID:           ri.TenantID,
+		RecurringInvoiceID: ri.ID,
+		Metric:             metric,
+		UsageDate:          usageDate,
+		Quantity:           input.Quantity,
+		Reference:          strings.TrimSpace(input.Reference),
+		CreatedAt:          now,
+		CreatedBy:          userID,
+	}, nil
+}
+
+// ListUsage lists the usage records of a recurring invoice
+func (s *Service) ListUsage(ctx context.Context, tenantID, schemaName, recurringID string, unbilledOnly bool) ([]UsageRecord, error) {
+	if _, err := s.GetByID(ctx, tenantID, schemaName, recurringID); err != nil {
+		return nil, err
+	}
+
+	records, err := s.repo.ListUsageRecords(ctx, schemaName, tenantID, recurringID, unbilledOnly)
+	if err != nil {
+		return nil, fmt.Errorf("list usage records: %w", err)
+	}
+	return records, nil
+}
+
+// DeleteUsage deletes a usage record that has not been invoiced yet
+func (s *Service) DeleteUsage(ctx context.Context, tenantID, schemaName, recurringID, usageID string) error {
+	if err := s.requireRepository(); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteUsageRecord(ctx, schemaName, tenantID, recurringID, usageID); err != nil {
+		if errors.Is(err, ErrUsageRecordNotFound) {
+			return err
+		}
+		return fmt.Errorf("delete usage record: %w", err)
+	}
+	return nil
+}
+
+// CreatePriceChange schedules a new unit price or a percentage uplift for
+// one line or all lines of a recurring invoice. Periods already invoiced
+// cannot be repriced, so the change takes effect on or after the next
+// generation date.
+func (s *Service) CreatePriceChange(ctx context.Context, tenantID, schemaName, recurringID string, req *CreatePriceChangeRequest) (*PriceChange, error) {
+	ri, err := s.GetByID(ctx, tenantID, schemaName, recurringID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.EffectiveDate.IsZero() {
+		return nil, errors.New("effective date is required")
+	}
+	effectiveDate := truncateToDate(req.EffectiveDate)
+	if effectiveDate.Before(truncateToDate(ri.NextGenerationDate)) {
+		return nil, fmt.Errorf("effective date cannot be before the next generation date %s", ri.NextGenerationDate.Format("2006-01-02"))
+	}
+	if (req.UnitPrice == nil) == (req.UpliftPercent == nil) {
+		return nil, errors.New("give either a unit price or an uplift percent")
+	}
+	if req.UnitPrice != nil && req.UnitPrice.IsNegative() {
+		return nil, errors.New("unit price cannot be negative")
+	}
+	if req.UpliftPercent != nil && req.UpliftPercent.LessThanOrEqual(decimal.NewFromInt(-100)) {
+		return nil, errors.New("uplift percent must be greater than -100")
+	}
+	if req.LineNumber != nil {
+		found := false
+		for _, line := range ri.Lines {
+			found = found || line.LineNumber == *req.LineNumber
+		}
+		if !found {
+			return nil, fmt.Errorf("line %d not found", *req.LineNumber)
+		}
+	}
+
+	change := &PriceChange{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		RecurringInvoiceID: ri.ID,
+		LineNumber:         req.LineNumber,
+		EffectiveDate:      effectiveDate,
+		UnitPrice:          req.UnitPrice,
+		UpliftPercent:      req.UpliftPercent,
+		Note:               strings.TrimSpace(req.Note),
+		CreatedAt:          time.Now(),
+		CreatedBy:          req.UserID,
+	}
+	if err := s.repo.CreatePriceChange(ctx, schemaName, change); err != nil {
+		return nil, fmt.Errorf("create price change: %w", err)
+	}
+	return change, nil
+}
+
+// DeletePriceChange deletes a price change that has not affected any
+// generated invoice yet
+func (s *Service) DeletePriceChange(ctx context.Context, tenantID, schemaName, recurringID, changeID string) error {
+	ri, err := s.GetByID(ctx, tenantID, schemaName, recurringID)
+	if err != nil {
+		return err
+	}
+	for _, change := range ri.PriceChanges {
+		if change.ID == changeID && change.AppliedAt == nil && truncateToDate(change.EffectiveDate).Before(truncateToDate(ri.NextGenerationDate)) {
+			return errors.New("price change has already been invoiced")
+		}
+	}
+
+	if err := s.repo.DeletePriceChange(ctx, schemaName, tenantID, recurringID, changeID); err != nil {
+		if errors.Is(err, ErrPriceChangeNotFound) {
+			return err
+		}
+		return fmt.Errorf("delete price change: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package recurring
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+var usageImportHeaderAliases = map[string]string{
+	"recurring_invoice_id": "recurring_invoice_id",
+	"template_id":          "recurring_invoice_id",
+	"recurring_invoice":    "recurring_invoice",
+	"template":             "recurring_invoice",
+	"template_name":        "recurring_invoice",
+	"name":                 "recurring_invoice",
+	"metric":               "metric",
+	"usage_metric":         "metric",
+	"usage_date":           "usage_date",
+	"date":                 "usage_date",
+	"quantity":             "quantity",
+	"qty":                  "quantity",
+	"reference":            "reference",
+}
+
+// ImportUsageCSV records usage for any recurring invoices from CSV rows. Each
+// row names the recurring invoice by ID or name, the metric, the usage date
+// and the quantity; rows that fail validation are reported and skipped.
+func (s *Service) ImportUsageCSV(ctx context.Context, tenantID, schemaName string, req *ImportUsageRequest) (*ImportUsageResult, error) {
+	if req == nil || strings.TrimSpace(req.CSVContent) == "" {
+		return nil, fmt.Errorf("csv_content is required")
+	}
+	if err := s.requireRepository(); err != nil {
+		return nil, err
+	}
+
+	rows, err := parseUsageImportRows(req.CSVContent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no usage records found in CSV")
+	}
+
+	templates, err := s.repo.List(ctx, schemaName, tenantID, false)
+	if err != nil {
+		return nil, fmt.Errorf("list recurring invoices: %w", err)
+	}
+	idsByName := make(map[string][]string, len(templates))
+	for _, template := range templates {
+		key := normalizedRecurringImportKey(template.Name)
+		idsByName[key] = append(idsByName[key], template.ID)
+	}
+
+	result := &ImportUsageResult{FileName: req.FileName, Errors: []ImportRecurringInvoicesRowError{}}
+	loaded := make(map[string]*RecurringInvoice)
+	records := make([]UsageRecord, 0, len(rows))
+	now := time.Now()
+	for _, row := range rows {
+		result.RowsProcessed++
+		record, err := s.parseUsageImportRow(ctx, tenantID, schemaName, row, idsByName, loaded, req.UserID, now)
+		if err != nil {
+			result.RowsSkipped++
+			result.Errors = append(result.Errors, ImportRecurringInvoicesRowError{
+				Row:      row.rowNumber,
+				Template: firstNonEmpty(row.values["recurring_invoice"], row.values["recurring_invoice_id"]),
+				Message:  err.Error(),
+			})
+			continue
+		}
+		records = append(records, *record)
+	}
+
+	if err := s.repo.CreateUsageRecords(ctx, schemaName, records); err != nil {
+		return nil, fmt.Errorf("record usage: %w", err)
+	}
+	result.RecordsCreated = len(records)
+	if len(result.Errors) == 0 {
+		result.Errors = nil
+	}
+	return result, nil
+}
+
+func (s *Service) parseUsageImportRow(ctx context.Context, tenantID, schemaName string, row recurringImportRow, idsByName map[string][]string, loaded map[string]*RecurringInvoice, userID string, now time.Time) (*UsageRecord, error) {
+	id := strings.TrimSpace(row.values["recurring_invoice_id"])
+	if id == "" {
+		name := strings.TrimSpace(row.values["recurring_invoice"])
+		if name == "" {
+			return nil, fmt.Errorf("recurring_invoice_id or recurring_invoice is required")
+		}
+		ids := idsByName[normalizedRecurringImportKey(name)]
+		switch len(ids) {
+		case 0:
+			return nil, fmt.Errorf("recurring invoice %q not found", name)
+		case 1:
+			id = ids[0]
+		default:
+			return nil, fmt.Errorf("recurring invoice name %q is ambiguous; use recurring_invoice_id", name)
+		}
+	}
+	ri, ok := loaded[id]
+	if !ok {
+		var err error
+		ri, err = s.GetByID(ctx, tenantID, schemaName, id)
+		if err != nil {
+			return nil, err
+		}
+		loaded[id] = ri
+	}
+
+	usageDate, err := parseRecurringImportDate(row.values["usage_date"], "usage_date")
+	if err != nil {
+		return nil, err
+	}
+	quantity, err := parseRecurringImportDecimal(row.values["quantity"], "quantity")
+	if err != nil {
+		return nil, err
+	}
+	return newUsageRecord(ri, UsageRecordInput{
+		Metric:    row.values["metric"],
+		UsageDate: usageDate,
+		Quantity:  quantity,
+		Reference: row.values["reference"],
+	}, userID, now)
+}
+
+func parseUsageImportRows(content string) ([]recurringImportRow, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(content), "\ufeff")
+	reader := csv.NewReader(strings.NewReader(trimmed))
+	reader.Comma = detectRecurringImportDelimiter(trimmed)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("parse csv header: %w", err)
+	}
+	canonicalHeaders := make([]string, len(headers))
+	present := make(map[string]bool, len(headers))
+	for i, header := range headers {
+		canonicalHeaders[i] = usageImportHeaderAliases[normalizedRecurringImportKey(header)]
+		present[canonicalHeaders[i]] = true
+	}
+	if !present["recurring_invoice_id"] && !present["recurring_invoice"] {
+		return nil, fmt.Errorf("missing recurring_invoice_id or recurring_invoice column")
+	}
+	for _, column := range []string{"metric", "usage_date", "quantity"} {
+		if !present[column] {
+			return nil, fmt.Errorf("missing required %s column", column)
+		}
+	}
+
+	rows := make([]recurringImportRow, 0)
+	rowNumber := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse csv row %d: %w", rowNumber+1, err)
+		}
+		rowNumber++
+		values := make(map[string]string, len(canonicalHeaders))
+		isBlank := true
+		for i, header := range canonicalHeaders {
+			if header == "" || i >= len(record) {
+				continue
+			}
+			values[header] = strings.TrimSpace(record[i])
+			isBlank = isBlank && values[header] == ""
+		}
+		if !isBlank {
+			rows = append(rows, recurringImportRow{rowNumber: rowNumber, values: values})
+		}
+	}
+	return rows, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
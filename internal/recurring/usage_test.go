@@ -0,0 +1,254 @@
+package recurring
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupUsageTestService(t *testing.T, nextDate time.Time, lastPeriod *time.Time) (*Service, *MockRepository, *MockInvoicingService) {
+	t.Helper()
+	ctx := context.Background()
+	repo := NewMockRepository()
+	ri := &RecurringInvoice{
+		ID:                 "ri-1",
+		TenantID:           "tenant-1",
+		Name:               "Metered hosting",
+		ContactID:          "contact-1",
+		InvoiceType:        "SALES",
+		Currency:           "EUR",
+		Frequency:          FrequencyMonthly,
+		StartDate:          billingDate(2026, 1, 1),
+		NextGenerationDate: nextDate,
+		LastPeriodStart:    lastPeriod,
+		PaymentTermsDays:   14,
+		IsActive:           true,
+	}
+	require.NoError(t, repo.Create(ctx, "test_schema", ri))
+	for _, line := range []RecurringInvoiceLine{
+		{ID: "line-1", RecurringInvoiceID: "ri-1", LineNumber: 1, Description: "Base fee", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromInt(20)},
+		{ID: "line-2", RecurringInvoiceID: "ri-1", LineNumber: 2, Description: "Energy", Quantity: decimal.NewFromInt(1), UnitPrice: decimal.NewFromFloat(0.2), UsageMetric: "kwh"},
+	} {
+		line := line
+		require.NoError(t, repo.CreateLine(ctx, "test_schema", &line))
+	}
+	invoicingSvc := NewMockInvoicingService()
+	return NewServiceWithDependencies(repo, invoicingSvc, nil, nil, nil, nil), repo, invoicingSvc
+}
+
+func TestService_RecordUsage(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := setupUsageTestService(t, billingDate(2026, 4, 1), nil)
+
+	records, err := service.RecordUsage(ctx, "tenant-1", "test_schema", "ri-1", &RecordUsageRequest{
+		Records: []UsageRecordInput{{Metric: " kwh ", UsageDate: time.Date(2026, 3, 31, 15, 30, 0, 0, time.UTC), Quantity: decimal.NewFromInt(120), Reference: "meter 42"}},
+		UserID:  "user-1",
+	})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "kwh", records[0].Metric)
+	assert.Equal(t, billingDate(2026, 3, 31), records[0].UsageDate)
+	assert.Len(t, repo.usage, 1)
+
+	listed, err := service.ListUsage(ctx, "tenant-1", "test_schema", "ri-1", true)
+	require.NoError(t, err)
+	assert.Len(t, listed, 1)
+
+	tests := []struct {
+		name  string
+		input UsageRecordInput
+		want  string
+	}{
+		{name: "unknown metric", input: UsageRecordInput{Metric: "api_calls", UsageDate: billingDate(2026, 3, 1), Quantity: decimal.NewFromInt(1)}, want: `record 1: recurring invoice "Metered hosting" has no usage-based line for metric "api_calls"`},
+		{name: "missing date", input: UsageRecordInput{Metric: "kwh", Quantity: decimal.NewFromInt(1)}, want: "record 1: usage date is required"},
+		{name: "before start", input: UsageRecordInput{Metric: "kwh", UsageDate: billingDate(2025, 12, 31), Quantity: decimal.NewFromInt(1)}, want: "record 1: usage date 2025-12-31 is before the start date"},
+		{name: "negative quantity", input: UsageRecordInput{Metric: "kwh", UsageDate: billingDate(2026, 3, 1), Quantity: decimal.NewFromInt(-1)}, want: "record 1: quantity cannot be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.RecordUsage(ctx, "tenant-1", "test_schema", "ri-1", &RecordUsageRequest{Records: []UsageRecordInput{tt.input}})
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+
+	_, err = service.RecordUsage(ctx, "tenant-1", "test_schema", "ri-1", &RecordUsageRequest{})
+	assert.EqualError(t, err, "at least one usage record is required")
+
+	require.NoError(t, service.DeleteUsage(ctx, "tenant-1", "test_schema", "ri-1", records[0].ID))
+	assert.ErrorIs(t, service.DeleteUsage(ctx, "tenant-1", "test_schema", "ri-1", records[0].ID), ErrUsageRecordNotFound)
+}
+
+func TestService_GenerateInvoiceWithUsage(t *testing.T) {
+	ctx := context.Background()
+	lastPeriod := billingDate(2026, 3, 1)
+	service, repo, invoicingSvc := setupUsageTestService(t, billingDate(2026, 4, 1), &lastPeriod)
+
+	// Generation fails cleanly while the period's usage is missing
+	_, err := service.GenerateInvoice(ctx, "tenant-1", "test_schema", "ri-1", "user-1")
+	require.ErrorIs(t, err, ErrUsageMissing)
+	assert.Empty(t, invoicingSvc.invoices)
+	assert.Equal(t, billingDate(2026, 4, 1), repo.recurring["tenant-1:ri-1"].NextGenerationDate)
+
+	_, err = service.RecordUsage(ctx, "tenant-1", "test_schema", "ri-1", &RecordUsageRequest{Records: []UsageRecordInput{
+		{Metric: "kwh", UsageDate: billingDate(2026, 3, 31), Quantity: decimal.NewFromInt(500)},
+		{Metric: "kwh", UsageDate: billingDate(2026, 4, 3), Quantity: decimal.NewFromInt(40)},
+	}})
+	require.NoError(t, err)
+
+	result, err := service.GenerateInvoice(ctx, "tenant-1", "test_schema", "ri-1", "user-1")
+	require.NoError(t, err)
+	assert.False(t, result.Skipped)
+	require.NotNil(t, result.PeriodStart)
+	assert.Equal(t, billingDate(2026, 4, 1), *result.PeriodStart)
+	assert.Equal(t, billingDate(2026, 4, 30), *result.PeriodEnd)
+
+	require.Len(t, invoicingSvc.lastRequest.Lines, 2)
+	assert.Equal(t, "Energy (01.03.2026 - 31.03.2026)", invoicingSvc.lastRequest.Lines[1].Description)
+	assert.True(t, invoicingSvc.lastRequest.Lines[1].Quantity.Equal(decimal.NewFromInt(500)))
+
+	ri := repo.recurring["tenant-1:ri-1"]
+	assert.Equal(t, billingDate(2026, 5, 1), ri.NextGenerationDate)
+	assert.Equal(t, billingDate(2026, 4, 1), *ri.LastPeriodStart)
+	unbilled, err := service.ListUsage(ctx, "tenant-1", "test_schema", "ri-1", true)
+	require.NoError(t, err)
+	require.Len(t, unbilled, 1)
+	assert.Equal(t, billingDate(2026, 4, 3), unbilled[0].UsageDate)
+
+	// Invoiced usage can no longer be deleted
+	for _, record := range repo.usage {
+		if record.InvoiceID != nil {
+			assert.ErrorIs(t, service.DeleteUsage(ctx, "tenant-1", "test_schema", "ri-1", record.ID), ErrUsageRecordNotFound)
+		}
+	}
+}
+
+func TestService_GenerateInvoiceSkipsEmptyRun(t *testing.T) {
+	ctx := context.Background()
+	service, repo, invoicingSvc := setupUsageTestService(t, billingDate(2026, 1, 1), nil)
+	repo.lines["ri-1"] = repo.lines["ri-1"][1:]
+
+	result, err := service.GenerateInvoice(ctx, "tenant-1", "test_schema", "ri-1", "user-1")
+	require.NoError(t, err)
+	assert.True(t, result.Skipped)
+	assert.Empty(t, result.GeneratedInvoiceID)
+	assert.Empty(t, invoicingSvc.invoices)
+	assert.Equal(t, billingDate(2026, 2, 1), repo.recurring["tenant-1:ri-1"].NextGenerationDate)
+	assert.Zero(t, repo.recurring["tenant-1:ri-1"].GeneratedCount)
+
+	repo.recurring["tenant-1:ri-1"].LastPeriodStart = nil
+	repo.recordBillingErr = errors.New("db down")
+	_, err = service.GenerateInvoice(ctx, "tenant-1", "test_schema", "ri-1", "user-1")
+	assert.ErrorContains(t, err, "record billing: db down")
+}
+
+func TestService_GenerateInvoiceUsageListError(t *testing.T) {
+	lastPeriod := billingDate(2026, 3, 1)
+	service, repo, _ := setupUsageTestService(t, billingDate(2026, 4, 1), &lastPeriod)
+	repo.listUsageErr = errors.New("db down")
+
+	_, err := service.GenerateInvoice(context.Background(), "tenant-1", "test_schema", "ri-1", "user-1")
+	assert.EqualError(t, err, "list usage records: db down")
+}
+
+func TestService_PriceChanges(t *testing.T) {
+	ctx := context.Background()
+	service, repo, invoicingSvc := setupUsageTestService(t, billingDate(2026, 4, 1), nil)
+	repo.lines["ri-1"] = repo.lines["ri-1"][:1]
+	uplift := decimal.NewFromFloat(3.5)
+
+	change, err := service.CreatePriceChange(ctx, "tenant-1", "test_schema", "ri-1", &CreatePriceChangeRequest{
+		EffectiveDate: billingDate(2026, 4, 1),
+		UpliftPercent: &uplift,
+		Note:          "CPI 2026",
+		UserID:        "user-1",
+	})
+	require.NoError(t, err)
+	assert.Nil(t, change.LineNumber)
+
+	ri, err := service.GetByID(ctx, "tenant-1", "test_schema", "ri-1")
+	require.NoError(t, err)
+	require.Len(t, ri.PriceChanges, 1)
+
+	_, err = service.GenerateInvoice(ctx, "tenant-1", "test_schema", "ri-1", "user-1")
+	require.NoError(t, err)
+	assert.True(t, invoicingSvc.lastRequest.Lines[0].UnitPrice.Equal(decimal.NewFromFloat(20.7)))
+	assert.True(t, repo.lines["ri-1"][0].UnitPrice.Equal(decimal.NewFromFloat(20.7)))
+	require.NotNil(t, repo.priceChanges[0].AppliedAt)
+	assert.ErrorIs(t, service.DeletePriceChange(ctx, "tenant-1", "test_schema", "ri-1", change.ID), ErrPriceChangeNotFound)
+
+	price := decimal.NewFromInt(25)
+	lineNumber := 3
+	tests := []struct {
+		name string
+		req  CreatePriceChangeRequest
+		want string
+	}{
+		{name: "missing date", req: CreatePriceChangeRequest{UnitPrice: &price}, want: "effective date is required"},
+		{name: "invoiced period", req: CreatePriceChangeRequest{EffectiveDate: billingDate(2026, 4, 15), UnitPrice: &price}, want: "effective date cannot be before the next generation date 2026-05-01"},
+		{name: "price and uplift", req: CreatePriceChangeRequest{EffectiveDate: billingDate(2026, 6, 1), UnitPrice: &price, UpliftPercent: &uplift}, want: "give either a unit price or an uplift percent"},
+		{name: "neither", req: CreatePriceChangeRequest{EffectiveDate: billingDate(2026, 6, 1)}, want: "give either a unit price or an uplift percent"},
+		{name: "unknown line", req: CreatePriceChangeRequest{EffectiveDate: billingDate(2026, 6, 1), UnitPrice: &price, LineNumber: &lineNumber}, want: "line 3 not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.CreatePriceChange(ctx, "tenant-1", "test_schema", "ri-1", &tt.req)
+			assert.EqualError(t, err, tt.want)
+		})
+	}
+
+	scheduled, err := service.CreatePriceChange(ctx, "tenant-1", "test_schema", "ri-1", &CreatePriceChangeRequest{EffectiveDate: billingDate(2026, 6, 1), UnitPrice: &price})
+	require.NoError(t, err)
+	require.NoError(t, service.DeletePriceChange(ctx, "tenant-1", "test_schema", "ri-1", scheduled.ID))
+}
+
+func TestService_ImportUsageCSV(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := setupUsageTestService(t, billingDate(2026, 4, 1), nil)
+
+	result, err := service.ImportUsageCSV(ctx, "tenant-1", "test_schema", &ImportUsageRequest{
+		FileName: "march.csv",
+		CSVContent: "recurring_invoice;metric;usage_date;quantity;reference\n" +
+			"Metered hosting;kwh;2026-03-31;1 250,5;meter 42\n" +
+			"Unknown;kwh;2026-03-31;1;\n" +
+			"metered hosting;gas;2026-03-31;1;\n" +
+			"Metered hosting;kwh;31.03.2026;1;\n",
+		UserID: "user-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.RowsProcessed)
+	assert.Equal(t, 1, result.RecordsCreated)
+	assert.Equal(t, 3, result.RowsSkipped)
+	require.Len(t, result.Errors, 3)
+	assert.Equal(t, `recurring invoice "Unknown" not found`, result.Errors[0].Message)
+	assert.Equal(t, 3, result.Errors[0].Row)
+	assert.Equal(t, "usage_date must use YYYY-MM-DD", result.Errors[2].Message)
+	require.Len(t, repo.usage, 1)
+	assert.True(t, repo.usage[0].Quantity.Equal(decimal.NewFromFloat(1250.5)))
+	assert.Equal(t, "user-1", repo.usage[0].CreatedBy)
+
+	_, err = service.ImportUsageCSV(ctx, "tenant-1", "test_schema", &ImportUsageRequest{CSVContent: "metric,usage_date,quantity\nkwh,2026-03-31,1\n"})
+	assert.EqualError(t, err, "missing recurring_invoice_id or recurring_invoice column")
+	_, err = service.ImportUsageCSV(ctx, "tenant-1", "test_schema", &ImportUsageRequest{CSVContent: "recurring_invoice_id,metric,usage_date\n"})
+	assert.EqualError(t, err, "missing required quantity column")
+	_, err = service.ImportUsageCSV(ctx, "tenant-1", "test_schema", &ImportUsageRequest{})
+	assert.EqualError(t, err, "csv_content is required")
+}
+
+func TestRecurringInvoiceValidateUsageAndAnchor(t *testing.T) {
+	ri := billingTemplate(billingDate(2026, 1, 10), usageLine("Energy", "kwh", decimal.NewFromFloat(0.2)), usageLine("Energy night", "kwh", decimal.NewFromFloat(0.1)))
+	ri.ContactID = "contact-1"
+	ri.StartDate = billingDate(2026, 1, 10)
+	assert.EqualError(t, ri.Validate(), `usage metric "kwh" is used on more than one line`)
+
+	ri.Lines[1].UsageMetric = "kwh_night"
+	anchor := billingDate(2026, 2, 11)
+	ri.BillingAnchorDate = &anchor
+	assert.EqualError(t, ri.Validate(), "billing anchor date must fall within the first period after the start date")
+	anchor = billingDate(2026, 2, 1)
+	assert.NoError(t, ri.Validate())
+}
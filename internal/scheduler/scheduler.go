@@ -12,12 +12,19 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/database"
 	"github.com/HMB-research/open-accounting/internal/documents"
 	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
 )
 
+// systemUserID records scheduled depreciation runs as made by the system. The
+// created_by columns are UUIDs without a users foreign key, so the nil UUID
+// stands in for a real user.
+const systemUserID = "00000000-0000-0000-0000-000000000000"
+
 // RecurringService defines the interface for recurring invoice generation
 type RecurringService interface {
 	GenerateDueInvoices(ctx context.Context, tenantID, schemaName, userID string) ([]recurring.GenerationResult, error)
@@ -38,6 +45,16 @@ type RecurringJournalEntryService interface {
 	GenerateDueJournalEntryTemplates(ctx context.Context, schemaName, tenantID string, req *accounting.GenerateDueJournalEntryTemplatesRequest) ([]accounting.JournalEntryTemplateGenerationResult, error)
 }
 
+// DepreciationRunService defines the interface for scheduled fixed-asset depreciation runs.
+type DepreciationRunService interface {
+	RunDepreciation(ctx context.Context, tenantID, schemaName string, req *assets.CreateDepreciationRunRequest) (*assets.DepreciationRun, bool, error)
+}
+
+// QuoteExpiryService defines the interface for expiring sent quotes past their validity.
+type QuoteExpiryService interface {
+	ExpireQuotes(ctx context.Context, tenantID, schemaName string, asOf time.Time) ([]quotes.Quote, error)
+}
+
 // Config holds scheduler configuration
 type Config struct {
 	// Schedule in cron format (e.g., "0 6 * * *" for 6:00 AM daily)
@@ -52,6 +69,10 @@ type Config struct {
 	DocumentRetentionReminderHorizonDays int
 	// Whether to include missing retention metadata in scheduled reminders
 	DocumentRetentionReminderIncludeMissing bool
+	// Schedule for the month-end depreciation run of the previous month
+	DepreciationRunSchedule string
+	// Schedule for expiring sent quotes past their valid until date
+	QuoteExpirySchedule string
 	// Whether the scheduler is enabled
 	Enabled bool
 }
@@ -65,6 +86,8 @@ func DefaultConfig() Config {
 		DocumentRetentionReminderSchedule:       "30 9 * * *", // 9:30 AM daily
 		DocumentRetentionReminderHorizonDays:    documents.DefaultRetentionReminderHorizonDays,
 		DocumentRetentionReminderIncludeMissing: true,
+		DepreciationRunSchedule:                 "0 5 1 * *", // 5:00 AM on the 1st of each month
+		QuoteExpirySchedule:                     "0 1 * * *", // 1:00 AM daily
 		Enabled:                                 true,
 	}
 }
@@ -77,6 +100,8 @@ type Scheduler struct {
 	journalEntries    RecurringJournalEntryService
 	reminder          AutomatedReminderService
 	documentRetention DocumentRetentionReminderService
+	depreciation      DepreciationRunService
+	quoteExpiry       QuoteExpiryService
 	config            Config
 	running           bool
 	mu                sync.Mutex
@@ -128,6 +153,16 @@ func (s *Scheduler) SetDocumentRetentionReminderService(service DocumentRetentio
 	s.documentRetention = service
 }
 
+// SetDepreciationRunService enables the scheduled month-end depreciation run.
+func (s *Scheduler) SetDepreciationRunService(service DepreciationRunService) {
+	s.depreciation = service
+}
+
+// SetQuoteExpiryService enables the scheduled expiry of lapsed quotes.
+func (s *Scheduler) SetQuoteExpiryService(service QuoteExpiryService) {
+	s.quoteExpiry = service
+}
+
 // Start starts the scheduler
 func (s *Scheduler) Start() error {
 	s.mu.Lock()
@@ -183,6 +218,24 @@ func (s *Scheduler) Start() error {
 			Msg("Document retention reminder job scheduled")
 	}
 
+	if s.depreciation != nil && s.config.DepreciationRunSchedule != "" {
+		depreciationSchedule := "0 " + s.config.DepreciationRunSchedule
+		_, err := s.cron.AddFunc(depreciationSchedule, s.runMonthEndDepreciation)
+		if err != nil {
+			return fmt.Errorf("failed to add depreciation run job: %w", err)
+		}
+		log.Info().Str("schedule", s.config.DepreciationRunSchedule).Msg("Depreciation run job scheduled")
+	}
+
+	if s.quoteExpiry != nil && s.config.QuoteExpirySchedule != "" {
+		quoteExpirySchedule := "0 " + s.config.QuoteExpirySchedule
+		_, err := s.cron.AddFunc(quoteExpirySchedule, s.expireLapsedQuotes)
+		if err != nil {
+			return fmt.Errorf("failed to add quote expiry job: %w", err)
+		}
+		log.Info().Str("schedule", s.config.QuoteExpirySchedule).Msg("Quote expiry job scheduled")
+	}
+
 	s.cron.Start()
 	s.running = true
 
@@ -191,6 +244,8 @@ func (s *Scheduler) Start() error {
 		Str("recurring_journal_schedule", s.config.RecurringJournalEntrySchedule).
 		Str("reminder_schedule", s.config.ReminderSchedule).
 		Str("document_retention_reminder_schedule", s.config.DocumentRetentionReminderSchedule).
+		Str("depreciation_run_schedule", s.config.DepreciationRunSchedule).
+		Str("quote_expiry_schedule", s.config.QuoteExpirySchedule).
 		Msg("Scheduler started")
 
 	return nil
@@ -478,6 +533,128 @@ func (s *Scheduler) processDocumentRetentionReminders() {
 		Msg("Completed scheduled document retention reminder processing")
 }
 
+// runMonthEndDepreciation posts the depreciation run for the previous month for
+// all tenants. Months already covered by a posted run are left unchanged, and
+// months inside a closed period are skipped.
+func (s *Scheduler) runMonthEndDepreciation() {
+	if s.depreciation == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	log.Info().Msg("Starting scheduled depreciation run")
+
+	tenants, err := s.repo.ListActiveTenants(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get tenants for scheduled depreciation run")
+		return
+	}
+
+	now := time.Now().UTC()
+	periodEnd := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	totalPosted := 0
+	totalExisting := 0
+	totalSkipped := 0
+	totalErrors := 0
+
+	for _, t := range tenants {
+		if lockDate := parseTenantPeriodLockDate(t); lockDate != nil && !periodEnd.After(*lockDate) {
+			totalSkipped++
+			log.Debug().
+				Str("tenant_id", t.ID).
+				Str("period_end", periodEnd.Format("2006-01-02")).
+				Msg("Skipping depreciation run for closed period")
+			continue
+		}
+
+		run, created, err := s.depreciation.RunDepreciation(ctx, t.ID, t.SchemaName, &assets.CreateDepreciationRunRequest{
+			Year:        periodEnd.Year(),
+			Month:       int(periodEnd.Month()),
+			PostingMode: assets.DepreciationPostingAggregated,
+			UserID:      systemUserID,
+		})
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("tenant_id", t.ID).
+				Msg("Failed to run depreciation for tenant")
+			totalErrors++
+			continue
+		}
+		if !created {
+			totalExisting++
+			continue
+		}
+
+		totalPosted++
+		log.Info().
+			Str("tenant_id", t.ID).
+			Str("run_id", run.ID).
+			Int("asset_count", run.AssetCount).
+			Str("total_amount", run.TotalAmount.String()).
+			Msg("Posted depreciation run")
+	}
+
+	log.Info().
+		Str("period_end", periodEnd.Format("2006-01-02")).
+		Int("runs_posted", totalPosted).
+		Int("runs_already_posted", totalExisting).
+		Int("closed_periods_skipped", totalSkipped).
+		Int("tenant_errors", totalErrors).
+		Msg("Completed scheduled depreciation run")
+}
+
+// expireLapsedQuotes marks sent quotes whose valid until date has passed as
+// expired for all tenants
+func (s *Scheduler) expireLapsedQuotes() {
+	if s.quoteExpiry == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+
+	log.Info().Msg("Starting scheduled quote expiry")
+
+	tenants, err := s.repo.ListActiveTenants(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to get tenants for scheduled quote expiry")
+		return
+	}
+
+	asOf := time.Now().UTC()
+	totalExpired := 0
+	totalErrors := 0
+
+	for _, t := range tenants {
+		expired, err := s.quoteExpiry.ExpireQuotes(ctx, t.ID, t.SchemaName, asOf)
+		if err != nil {
+			log.Error().
+				Err(err).
+				Str("tenant_id", t.ID).
+				Msg("Failed to expire quotes for tenant")
+			totalErrors++
+			continue
+		}
+
+		if len(expired) > 0 {
+			log.Info().
+				Str("tenant_id", t.ID).
+				Int("count", len(expired)).
+				Msg("Expired lapsed quotes")
+			totalExpired += len(expired)
+		}
+	}
+
+	log.Info().
+		Int("tenants_processed", len(tenants)).
+		Int("quotes_expired", totalExpired).
+		Int("tenant_errors", totalErrors).
+		Msg("Completed scheduled quote expiry")
+}
+
 // RunNow manually triggers the recurring invoice generation
 func (s *Scheduler) RunNow() {
 	s.generateDueInvoices()
@@ -498,6 +675,16 @@ func (s *Scheduler) RunDocumentRetentionRemindersNow() {
 	s.processDocumentRetentionReminders()
 }
 
+// RunDepreciationNow manually triggers the month-end depreciation run.
+func (s *Scheduler) RunDepreciationNow() {
+	s.runMonthEndDepreciation()
+}
+
+// RunQuoteExpiryNow manually triggers the expiry of lapsed quotes.
+func (s *Scheduler) RunQuoteExpiryNow() {
+	s.expireLapsedQuotes()
+}
+
 // IsRunning returns whether the scheduler is currently running
 func (s *Scheduler) IsRunning() bool {
 	s.mu.Lock()
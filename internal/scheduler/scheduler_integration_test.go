@@ -4,11 +4,16 @@ package scheduler
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/database"
 	"github.com/HMB-research/open-accounting/internal/testutil"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 func TestGORMRepository_ListActiveTenants(t *testing.T) {
@@ -123,6 +128,56 @@ func TestScheduler_WithRealRepository(t *testing.T) {
 	}
 }
 
+func TestScheduler_RunDepreciationNowStoresSystemUser(t *testing.T) {
+	pool := testutil.SetupTestDB(t)
+	tenant := testutil.CreateTestTenant(t, pool)
+	accounts := testutil.GetTestAccounts(t, pool, tenant.SchemaName)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	periodEnd := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	purchaseDate := periodEnd.AddDate(0, -2, 0)
+	asset := &assets.FixedAsset{
+		ID:                            uuid.New().String(),
+		TenantID:                      tenant.ID,
+		AssetNumber:                   "FA-00001",
+		Name:                          "Delivery van",
+		Status:                        assets.AssetStatusActive,
+		PurchaseDate:                  purchaseDate,
+		PurchaseCost:                  decimal.NewFromInt(24000),
+		DepreciationMethod:            assets.DepreciationStraightLine,
+		UsefulLifeMonths:              60,
+		BookValue:                     decimal.NewFromInt(24000),
+		AssetAccountID:                &accounts.AssetAccountID,
+		DepreciationExpenseAccountID:  &accounts.DepreciationExpenseAccountID,
+		AccumulatedDepreciationAcctID: &accounts.AccumulatedDepreciationAcctID,
+		CreatedAt:                     now,
+		CreatedBy:                     uuid.New().String(),
+		UpdatedAt:                     now,
+	}
+	if err := assets.NewRepository(pool).Create(ctx, tenant.SchemaName, asset); err != nil {
+		t.Fatalf("create asset: %v", err)
+	}
+
+	scheduler := NewSchedulerWithRepository(newTestGORMRepository(t, pool), nil, nil, DefaultConfig())
+	scheduler.SetDepreciationRunService(assets.NewService(pool))
+	scheduler.RunDepreciationNow()
+
+	var runCreatedBy, journalCreatedBy string
+	err := pool.QueryRow(ctx, fmt.Sprintf(`
+		SELECT r.created_by::text, j.created_by::text
+		FROM %[1]s.depreciation_runs r
+		JOIN %[1]s.journal_entries j ON j.source_id = r.id AND j.source_type = $2
+		WHERE r.tenant_id = $1 AND r.period_end = $3
+	`, tenant.SchemaName), tenant.ID, assets.SourceTypeAssetDepreciationRun, periodEnd).Scan(&runCreatedBy, &journalCreatedBy)
+	if err != nil {
+		t.Fatalf("scheduled depreciation run was not stored: %v", err)
+	}
+	if runCreatedBy != systemUserID || journalCreatedBy != systemUserID {
+		t.Errorf("expected run and journal created by %s, got %s and %s", systemUserID, runCreatedBy, journalCreatedBy)
+	}
+}
+
 func newTestGORMRepository(t *testing.T, pool *pgxpool.Pool) *GORMRepository {
 	t.Helper()
 
@@ -8,10 +8,14 @@ import (
 	"time"
 
 	"github.com/HMB-research/open-accounting/internal/accounting"
+	"github.com/HMB-research/open-accounting/internal/assets"
 	"github.com/HMB-research/open-accounting/internal/documents"
 	"github.com/HMB-research/open-accounting/internal/invoicing"
+	"github.com/HMB-research/open-accounting/internal/quotes"
 	"github.com/HMB-research/open-accounting/internal/recurring"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
 )
 
 // MockRepository implements Repository for testing
@@ -143,6 +147,48 @@ func (m *MockJournalEntryService) GenerateDueJournalEntryTemplates(ctx context.C
 	return m.results[tenantID], nil
 }
 
+type MockDepreciationRunService struct {
+	existing map[string]bool
+	errors   map[string]error
+	calls    []assets.CreateDepreciationRunRequest
+}
+
+func NewMockDepreciationRunService() *MockDepreciationRunService {
+	return &MockDepreciationRunService{
+		existing: make(map[string]bool),
+		errors:   make(map[string]error),
+	}
+}
+
+func (m *MockDepreciationRunService) RunDepreciation(ctx context.Context, tenantID, schemaName string, req *assets.CreateDepreciationRunRequest) (*assets.DepreciationRun, bool, error) {
+	m.calls = append(m.calls, *req)
+	if err, ok := m.errors[tenantID]; ok && err != nil {
+		return nil, false, err
+	}
+	return &assets.DepreciationRun{ID: "run-" + tenantID, AssetCount: 2, TotalAmount: decimal.NewFromInt(150)}, !m.existing[tenantID], nil
+}
+
+type MockQuoteExpiryService struct {
+	expired map[string][]quotes.Quote
+	errors  map[string]error
+	calls   []string
+}
+
+func NewMockQuoteExpiryService() *MockQuoteExpiryService {
+	return &MockQuoteExpiryService{
+		expired: make(map[string][]quotes.Quote),
+		errors:  make(map[string]error),
+	}
+}
+
+func (m *MockQuoteExpiryService) ExpireQuotes(ctx context.Context, tenantID, schemaName string, asOf time.Time) ([]quotes.Quote, error) {
+	m.calls = append(m.calls, tenantID)
+	if err, ok := m.errors[tenantID]; ok && err != nil {
+		return nil, err
+	}
+	return m.expired[tenantID], nil
+}
+
 func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 
@@ -161,6 +207,12 @@ func TestDefaultConfig(t *testing.T) {
 	if !config.DocumentRetentionReminderIncludeMissing {
 		t.Error("DocumentRetentionReminderIncludeMissing should be true by default")
 	}
+	if config.DepreciationRunSchedule != "0 5 1 * *" {
+		t.Errorf("DepreciationRunSchedule = %q, want %q", config.DepreciationRunSchedule, "0 5 1 * *")
+	}
+	if config.QuoteExpirySchedule != "0 1 * * *" {
+		t.Errorf("QuoteExpirySchedule = %q, want %q", config.QuoteExpirySchedule, "0 1 * * *")
+	}
 	if !config.Enabled {
 		t.Error("Enabled should be true by default")
 	}
@@ -988,3 +1040,137 @@ func TestScheduler_InvalidJournalScheduleFormat(t *testing.T) {
 		t.Fatalf("expected journal job error, got %v", err)
 	}
 }
+
+func TestScheduler_RunDepreciationNow_NoService(t *testing.T) {
+	mockRepo := &MockRepository{
+		tenants: []TenantInfo{{ID: "tenant-1", SchemaName: "tenant_1"}},
+	}
+	scheduler := NewSchedulerWithRepository(mockRepo, nil, nil, DefaultConfig())
+
+	scheduler.RunDepreciationNow()
+
+	if mockRepo.listActiveTenantsCalls != 0 {
+		t.Fatalf("expected no repository calls without depreciation service, got %d", mockRepo.listActiveTenantsCalls)
+	}
+}
+
+func TestScheduler_RunDepreciationNow_RunsPreviousMonth(t *testing.T) {
+	now := time.Now().UTC()
+	previousMonthEnd := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -1)
+	tenants := []TenantInfo{
+		{ID: "tenant-1", SchemaName: "tenant_1"},
+		{ID: "tenant-2", SchemaName: "tenant_2", PeriodLockDate: previousMonthEnd.Format("2006-01-02")},
+		{ID: "tenant-3", SchemaName: "tenant_3", PeriodLockDate: previousMonthEnd.AddDate(0, -1, 0).Format("2006-01-02")},
+		{ID: "tenant-4", SchemaName: "tenant_4"},
+	}
+	mockRepo := &MockRepository{tenants: tenants}
+	mockDepreciation := NewMockDepreciationRunService()
+	mockDepreciation.existing["tenant-3"] = true
+	mockDepreciation.errors["tenant-4"] = errors.New("missing depreciation accounts")
+
+	scheduler := NewSchedulerWithRepository(mockRepo, nil, nil, DefaultConfig())
+	scheduler.SetDepreciationRunService(mockDepreciation)
+	scheduler.RunDepreciationNow()
+
+	if len(mockDepreciation.calls) != 3 {
+		t.Fatalf("expected closed tenant to be skipped, got %d calls", len(mockDepreciation.calls))
+	}
+	call := mockDepreciation.calls[0]
+	if call.Year != previousMonthEnd.Year() || call.Month != int(previousMonthEnd.Month()) {
+		t.Fatalf("expected previous month %s, got %d-%02d", previousMonthEnd.Format("2006-01"), call.Year, call.Month)
+	}
+	if call.PostingMode != assets.DepreciationPostingAggregated || call.UserID != systemUserID {
+		t.Fatalf("unexpected depreciation run request: %#v", call)
+	}
+	if _, err := uuid.Parse(call.UserID); err != nil {
+		t.Fatalf("depreciation run user must fit the UUID created_by column: %v", err)
+	}
+}
+
+func TestScheduler_RunDepreciationNow_WithRepositoryError(t *testing.T) {
+	mockRepo := &MockRepository{listActiveTenantsErr: errors.New("database error")}
+	mockDepreciation := NewMockDepreciationRunService()
+	scheduler := NewSchedulerWithRepository(mockRepo, nil, nil, DefaultConfig())
+	scheduler.SetDepreciationRunService(mockDepreciation)
+
+	scheduler.RunDepreciationNow()
+
+	if len(mockDepreciation.calls) != 0 {
+		t.Fatalf("expected no depreciation calls on repository error, got %#v", mockDepreciation.calls)
+	}
+}
+
+func TestScheduler_InvalidDepreciationRunScheduleFormat(t *testing.T) {
+	config := DefaultConfig()
+	config.DepreciationRunSchedule = "invalid cron expression"
+	scheduler := NewScheduler(nil, nil, nil, config)
+	scheduler.SetDepreciationRunService(NewMockDepreciationRunService())
+
+	err := scheduler.Start()
+	if err == nil {
+		t.Fatal("Start() should return error for invalid depreciation run cron expression")
+	}
+	if !strings.Contains(err.Error(), "depreciation run job") {
+		t.Fatalf("expected depreciation run job error, got %v", err)
+	}
+}
+
+func TestScheduler_RunQuoteExpiryNow_NoService(t *testing.T) {
+	mockRepo := &MockRepository{
+		tenants: []TenantInfo{{ID: "tenant-1", SchemaName: "tenant_1"}},
+	}
+	scheduler := NewSchedulerWithRepository(mockRepo, nil, nil, DefaultConfig())
+
+	scheduler.RunQuoteExpiryNow()
+
+	if mockRepo.listActiveTenantsCalls != 0 {
+		t.Fatalf("expected no repository calls without quote expiry service, got %d", mockRepo.listActiveTenantsCalls)
+	}
+}
+
+func TestScheduler_RunQuoteExpiryNow_ExpiresAllTenants(t *testing.T) {
+	mockRepo := &MockRepository{tenants: []TenantInfo{
+		{ID: "tenant-1", SchemaName: "tenant_1"},
+		{ID: "tenant-2", SchemaName: "tenant_2"},
+		{ID: "tenant-3", SchemaName: "tenant_3"},
+	}}
+	mockExpiry := NewMockQuoteExpiryService()
+	mockExpiry.expired["tenant-1"] = []quotes.Quote{{ID: "quote-1"}, {ID: "quote-2"}}
+	mockExpiry.errors["tenant-2"] = errors.New("database error")
+
+	scheduler := NewSchedulerWithRepository(mockRepo, nil, nil, DefaultConfig())
+	scheduler.SetQuoteExpiryService(mockExpiry)
+	scheduler.RunQuoteExpiryNow()
+
+	if len(mockExpiry.calls) != 3 {
+		t.Fatalf("expected every tenant to be processed despite errors, got %#v", mockExpiry.calls)
+	}
+}
+
+func TestScheduler_RunQuoteExpiryNow_WithRepositoryError(t *testing.T) {
+	mockRepo := &MockRepository{listActiveTenantsErr: errors.New("database error")}
+	mockExpiry := NewMockQuoteExpiryService()
+	scheduler := NewSchedulerWithRepository(mockRepo, nil, nil, DefaultConfig())
+	scheduler.SetQuoteExpiryService(mockExpiry)
+
+	scheduler.RunQuoteExpiryNow()
+
+	if len(mockExpiry.calls) != 0 {
+		t.Fatalf("expected no quote expiry calls on repository error, got %#v", mockExpiry.calls)
+	}
+}
+
+func TestScheduler_InvalidQuoteExpiryScheduleFormat(t *testing.T) {
+	config := DefaultConfig()
+	config.QuoteExpirySchedule = "invalid cron expression"
+	scheduler := NewScheduler(nil, nil, nil, config)
+	scheduler.SetQuoteExpiryService(NewMockQuoteExpiryService())
+
+	err := scheduler.Start()
+	if err == nil {
+		t.Fatal("Start() should return error for invalid quote expiry cron expression")
+	}
+	if !strings.Contains(err.Error(), "quote expiry job") {
+		t.Fatalf("expected quote expiry job error, got %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+package stocktake
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type countImportRow struct {
+	rowNumber int
+	values    map[string]string
+}
+
+var countImportHeaderAliases = map[string]string{
+	"barcode":         "barcode",
+	"ean":             "barcode",
+	"gtin":            "barcode",
+	"upc":             "barcode",
+	"quantity":        "quantity",
+	"qty":             "quantity",
+	"count":           "quantity",
+	"counted":         "quantity",
+	"lot":             "lot_number",
+	"lot_number":      "lot_number",
+	"batch":           "lot_number",
+	"batch_number":    "lot_number",
+	"serial":          "serial_number",
+	"serial_number":   "serial_number",
+	"expiry":          "expiry_date",
+	"expiry_date":     "expiry_date",
+	"expiration_date": "expiry_date",
+}
+
+// ImportCountsCSV records counted quantities from scanner output. Rows are
+// keyed by barcode with an optional lot, serial and expiry; repeated scans of
+// the same item are summed. Rows that cannot be matched are reported and
+// skipped while the remaining rows are recorded.
+func (s *Service) ImportCountsCSV(ctx context.Context, tenantID, schemaName, countID string, req *ImportCountsRequest) (*ImportCountsResult, error) {
+	if req == nil || strings.TrimSpace(req.CSVContent) == "" {
+		return nil, fmt.Errorf("csv_content is required")
+	}
+	rows, err := parseCountImportRows(req.CSVContent)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("no counts found in CSV")
+	}
+
+	count, applier, err := s.openCountApplier(ctx, tenantID, schemaName, countID, req.Accumulate, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	result := &ImportCountsResult{
+		FileName: req.FileName,
+		Errors:   []ImportCountRowError{},
+	}
+	for _, row := range rows {
+		result.RowsProcessed++
+		entry, err := countEntryFromImportRow(row)
+		if err == nil {
+			err = applier.apply(entry)
+		}
+		if err != nil {
+			result.RowsSkipped++
+			result.Errors = append(result.Errors, ImportCountRowError{
+				Row:      row.rowNumber,
+				Barcode:  row.values["barcode"],
+				Quantity: row.values["quantity"],
+				Message:  err.Error(),
+			})
+			continue
+		}
+		result.RowsImported++
+	}
+	if err := s.saveCounts(ctx, tenantID, schemaName, applier); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) == 0 {
+		result.Errors = nil
+	}
+	result.StockCount = count
+	return result, nil
+}
+
+func countEntryFromImportRow(row countImportRow) (CountEntry, error) {
+	if row.values["barcode"] == "" {
+		return CountEntry{}, fmt.Errorf("barcode is required")
+	}
+	quantity := row.values["quantity"]
+	if quantity == "" {
+		return CountEntry{}, fmt.Errorf("quantity is required")
+	}
+	parsedQuantity, err := decimal.NewFromString(strings.ReplaceAll(quantity, ",", "."))
+	if err != nil {
+		return CountEntry{}, fmt.Errorf("quantity must be a decimal")
+	}
+	return CountEntry{
+		Barcode:      row.values["barcode"],
+		LotNumber:    row.values["lot_number"],
+		SerialNumber: row.values["serial_number"],
+		ExpiryDate:   row.values["expiry_date"],
+		Quantity:     parsedQuantity,
+	}, nil
+}
+
+func parseCountImportRows(content string) ([]countImportRow, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(content), "\ufeff")
+	if trimmed == "" {
+		return nil, fmt.Errorf("csv_content is required")
+	}
+
+	reader := csv.NewReader(strings.NewReader(trimmed))
+	reader.Comma = detectCountImportDelimiter(trimmed)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("parse csv header: %w", err)
+	}
+	canonicalHeaders := make([]string, len(headers))
+	columns := make(map[string]bool, len(headers))
+	for i, header := range headers {
+		canonicalHeaders[i] = canonicalCountImportHeader(header)
+		columns[canonicalHeaders[i]] = true
+	}
+	var missing []string
+	for _, required := range []string{"barcode", "quantity"} {
+		if !columns[required] {
+			missing = append(missing, required)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required columns: %s", strings.Join(missing, ", "))
+	}
+
+	rows := make([]countImportRow, 0)
+	rowNumber := 1
+	for {
+		record, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("parse csv row %d: %w", rowNumber+1, err)
+		}
+
+		rowNumber++
+		values := make(map[string]string, len(canonicalHeaders))
+		isBlank := true
+		for i, header := range canonicalHeaders {
+			if i >= len(record) {
+				continue
+			}
+			value := strings.TrimSpace(record[i])
+			if value != "" {
+				isBlank = false
+			}
+			values[header] = value
+		}
+		if isBlank {
+			continue
+		}
+		rows = append(rows, countImportRow{rowNumber: rowNumber, values: values})
+	}
+	return rows, nil
+}
+
+func detectCountImportDelimiter(content string) rune {
+	firstLine := content
+	if idx := strings.IndexByte(content, '\n'); idx >= 0 {
+		firstLine = content[:idx]
+	}
+
+	bestDelimiter := ','
+	bestCount := strings.Count(firstLine, string(bestDelimiter))
+	for _, delimiter := range []rune{';', '\t'} {
+		count := strings.Count(firstLine, string(delimiter))
+		if count > bestCount {
+			bestDelimiter = delimiter
+			bestCount = count
+		}
+	}
+	return bestDelimiter
+}
+
+func canonicalCountImportHeader(header string) string {
+	normalized := strings.TrimSpace(strings.ToLower(header))
+	normalized = strings.NewReplacer(" ", "_", "-", "_", ".", "_").Replace(normalized)
+	if canonical, ok := countImportHeaderAliases[normalized]; ok {
+		return canonical
+	}
+	return normalized
+}
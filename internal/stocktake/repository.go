@@ -0,0 +1,454 @@
+package stocktake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/HMB-research/open-accounting/internal/database"
+	"github.com/HMB-research/open-accounting/internal/inventory"
+	"github.com/HMB-research/open-accounting/internal/models"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"gorm.io/gorm"
+)
+
+// Repository defines the contract for stock count data access
+type Repository interface {
+	Create(ctx context.Context, schemaName string, count *StockCount) error
+	GetByID(ctx context.Context, schemaName, tenantID, countID string) (*StockCount, error)
+	List(ctx context.Context, schemaName, tenantID string, filter *StockCountFilter) ([]StockCount, error)
+	SaveCounts(ctx context.Context, schemaName, tenantID, countID string, counted, added []StockCountLine) error
+	UpdateStatus(ctx context.Context, schemaName, tenantID, countID string, status StockCountStatus, userID string) error
+	Approve(ctx context.Context, schemaName string, count *StockCount) error
+	GenerateNumber(ctx context.Context, schemaName, tenantID string) (string, error)
+}
+
+// InventoryLedgerTransactionRepository runs stock count writes, variance
+// stock movements and their journal postings in one database transaction.
+// Repositories that do not implement it write them one at a time.
+type InventoryLedgerTransactionRepository interface {
+	WithInventoryLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockCounter) error) error
+}
+
+// ErrStockCountNotFound is returned when a stock count is not found
+var ErrStockCountNotFound = fmt.Errorf("stock count not found")
+
+// ErrPeriodLocked is returned when an approval would post into a locked accounting period
+var ErrPeriodLocked = fmt.Errorf("period locked")
+
+var errStocktakeRepositoryDatabaseNotConfigured = errors.New("stocktake repository database is not configured")
+
+var newGormDBFromPool = database.NewGormDBFromPool
+
+// GORMRepository implements Repository with the shared ORM layer.
+type GORMRepository struct {
+	db *gorm.DB
+}
+
+func NewRepository(db *pgxpool.Pool) *GORMRepository {
+	if db == nil {
+		return &GORMRepository{}
+	}
+	gormDB, err := newGormDBFromPool(context.Background(), db)
+	if err != nil {
+		panic(fmt.Errorf("create stocktake GORM repository: %w", err))
+	}
+	return NewGORMRepository(gormDB)
+}
+
+func NewGORMRepository(db *gorm.DB) *GORMRepository {
+	return &GORMRepository{db: db}
+}
+
+// WithInventoryLedgerTransaction runs fn inside a GORM-backed transaction
+// shared by the stocktake repository, inventory and the general ledger.
+func (r *GORMRepository) WithInventoryLedgerTransaction(ctx context.Context, fn func(txRepo Repository, stock stockCounter) error) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return fn(&GORMRepository{db: tx}, inventory.NewServiceWithGORM(tx))
+	})
+}
+
+func (r *GORMRepository) dbWithContext(ctx context.Context) (*gorm.DB, error) {
+	if r == nil || r.db == nil {
+		return nil, errStocktakeRepositoryDatabaseNotConfigured
+	}
+	return r.db.WithContext(ctx), nil
+}
+
+func (r *GORMRepository) tenantTable(ctx context.Context, schemaName, tableName string) (*gorm.DB, error) {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return database.TenantTable(db, schemaName, tableName)
+}
+
+// Create inserts a new stock count with its lines
+func (r *GORMRepository) Create(ctx context.Context, schemaName string, count *StockCount) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		countsTable, err := database.TenantTable(tx, schemaName, "stock_counts")
+		if err != nil {
+			return fmt.Errorf("qualify stock counts table: %w", err)
+		}
+		if err := countsTable.Create(stockCountToModel(count)).Error; err != nil {
+			return fmt.Errorf("insert stock count: %w", err)
+		}
+
+		if len(count.Lines) == 0 {
+			return nil
+		}
+
+		linesTable, _ := database.TenantTable(tx, schemaName, "stock_count_lines")
+		lineModels := make([]models.StockCountLine, len(count.Lines))
+		for i := range count.Lines {
+			count.Lines[i].StockCountID = count.ID
+			lineModels[i] = *stockCountLineToModel(&count.Lines[i])
+		}
+		if err := linesTable.Create(&lineModels).Error; err != nil {
+			return fmt.Errorf("insert stock count line: %w", err)
+		}
+		return nil
+	})
+}
+
+// GetByID retrieves a stock count by ID with its lines
+func (r *GORMRepository) GetByID(ctx context.Context, schemaName, tenantID, countID string) (*StockCount, error) {
+	db, err := r.tenantTable(ctx, schemaName, "stock_counts")
+	if err != nil {
+		return nil, fmt.Errorf("qualify stock counts table: %w", err)
+	}
+
+	var countModel models.StockCount
+	err = db.Where("id = ? AND tenant_id = ?", countID, tenantID).First(&countModel).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrStockCountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get stock count: %w", err)
+	}
+
+	linesTable, err := r.tenantTable(ctx, schemaName, "stock_count_lines")
+	if err != nil {
+		return nil, fmt.Errorf("qualify stock count lines table: %w", err)
+	}
+	var lineModels []models.StockCountLine
+	if err := linesTable.
+		Where("stock_count_id = ? AND tenant_id = ?", countID, tenantID).
+		Order("line_number ASC").
+		Find(&lineModels).Error; err != nil {
+		return nil, fmt.Errorf("get stock count lines: %w", err)
+	}
+
+	count := stockCountFromModel(&countModel)
+	count.Lines = make([]StockCountLine, len(lineModels))
+	for i := range lineModels {
+		count.Lines[i] = *stockCountLineFromModel(&lineModels[i])
+	}
+	return count, nil
+}
+
+// List retrieves stock counts without lines, newest first
+func (r *GORMRepository) List(ctx context.Context, schemaName, tenantID string, filter *StockCountFilter) ([]StockCount, error) {
+	db, err := r.tenantTable(ctx, schemaName, "stock_counts")
+	if err != nil {
+		return nil, fmt.Errorf("qualify stock counts table: %w", err)
+	}
+
+	query := db.Where("tenant_id = ?", tenantID)
+	if filter != nil {
+		if filter.Status != "" {
+			query = query.Where("status = ?", string(filter.Status))
+		}
+		if filter.WarehouseID != "" {
+			query = query.Where("warehouse_id = ?", filter.WarehouseID)
+		}
+	}
+
+	var countModels []models.StockCount
+	if err := query.
+		Order("count_date DESC").
+		Order("count_number DESC").
+		Find(&countModels).Error; err != nil {
+		return nil, fmt.Errorf("list stock counts: %w", err)
+	}
+
+	counts := make([]StockCount, len(countModels))
+	for i := range countModels {
+		counts[i] = *stockCountFromModel(&countModels[i])
+	}
+	return counts, nil
+}
+
+// SaveCounts stores counted quantities on existing lines and inserts lines
+// added for unexpected products or lots
+func (r *GORMRepository) SaveCounts(ctx context.Context, schemaName, tenantID, countID string, counted, added []StockCountLine) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := updateStockCountLineCountsTx(tx, schemaName, tenantID, countID, counted); err != nil {
+			return err
+		}
+		if len(added) > 0 {
+			linesTable, err := database.TenantTable(tx, schemaName, "stock_count_lines")
+			if err != nil {
+				return fmt.Errorf("qualify stock count lines table: %w", err)
+			}
+			lineModels := make([]models.StockCountLine, len(added))
+			for i := range added {
+				lineModels[i] = *stockCountLineToModel(&added[i])
+			}
+			if err := linesTable.Create(&lineModels).Error; err != nil {
+				return fmt.Errorf("insert stock count line: %w", err)
+			}
+		}
+
+		countsTable, err := database.TenantTable(tx, schemaName, "stock_counts")
+		if err != nil {
+			return fmt.Errorf("qualify stock counts table: %w", err)
+		}
+		result := countsTable.Where("id = ? AND tenant_id = ?", countID, tenantID).Update("updated_at", time.Now())
+		if result.Error != nil {
+			return fmt.Errorf("update stock count: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrStockCountNotFound
+		}
+		return nil
+	})
+}
+
+// UpdateStatus updates the status of a stock count. Submissions also record
+// who submitted the count and when.
+func (r *GORMRepository) UpdateStatus(ctx context.Context, schemaName, tenantID, countID string, status StockCountStatus, userID string) error {
+	db, err := r.tenantTable(ctx, schemaName, "stock_counts")
+	if err != nil {
+		return fmt.Errorf("qualify stock counts table: %w", err)
+	}
+
+	now := time.Now()
+	updates := map[string]interface{}{
+		"status":     string(status),
+		"updated_at": now,
+	}
+	if status == StockCountStatusSubmitted {
+		updates["submitted_at"] = now
+		updates["submitted_by"] = nilIfEmpty(userID)
+	}
+	result := db.Where("id = ? AND tenant_id = ?", countID, tenantID).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("update stock count status: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrStockCountNotFound
+	}
+	return nil
+}
+
+// Approve stores the approval, the variance posting and the final counted
+// quantities of a stock count
+func (r *GORMRepository) Approve(ctx context.Context, schemaName string, count *StockCount) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := updateStockCountLineCountsTx(tx, schemaName, count.TenantID, count.ID, count.Lines); err != nil {
+			return err
+		}
+
+		countsTable, err := database.TenantTable(tx, schemaName, "stock_counts")
+		if err != nil {
+			return fmt.Errorf("qualify stock counts table: %w", err)
+		}
+		result := countsTable.Where("id = ? AND tenant_id = ?", count.ID, count.TenantID).
+			Updates(map[string]interface{}{
+				"status":              string(count.Status),
+				"variance_account_id": nilIfEmpty(count.VarianceAccountID),
+				"journal_entry_id":    count.JournalEntryID,
+				"approved_at":         count.ApprovedAt,
+				"approved_by":         nilIfEmpty(count.ApprovedBy),
+				"updated_at":          count.UpdatedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("approve stock count: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return ErrStockCountNotFound
+		}
+		return nil
+	})
+}
+
+// GenerateNumber generates a new stock count number
+func (r *GORMRepository) GenerateNumber(ctx context.Context, schemaName, tenantID string) (string, error) {
+	db, err := r.tenantTable(ctx, schemaName, "stock_counts")
+	if err != nil {
+		return "", fmt.Errorf("qualify stock counts table: %w", err)
+	}
+
+	var seq int
+	if err := db.
+		Select(`
+			COALESCE(MAX(
+				CASE
+					WHEN count_number ~ ? THEN CAST(SUBSTRING(count_number FROM ?) AS INTEGER)
+					ELSE 0
+				END
+			), 0) + 1
+		`, "SC-[0-9]+$", "SC-([0-9]+)$").
+		Where("tenant_id = ?", tenantID).
+		Scan(&seq).Error; err != nil {
+		return "", fmt.Errorf("generate count number: %w", err)
+	}
+	return fmt.Sprintf("SC-%05d", seq), nil
+}
+
+func updateStockCountLineCountsTx(tx *gorm.DB, schemaName, tenantID, countID string, lines []StockCountLine) error {
+	for i := range lines {
+		if !lines[i].Counted() {
+			continue
+		}
+		linesTable, err := database.TenantTable(tx, schemaName, "stock_count_lines")
+		if err != nil {
+			return fmt.Errorf("qualify stock count lines table: %w", err)
+		}
+		model := stockCountLineToModel(&lines[i])
+		result := linesTable.Where("id = ? AND tenant_id = ? AND stock_count_id = ?", lines[i].ID, tenantID, countID).
+			Updates(map[string]interface{}{
+				"counted_quantity": model.CountedQuantity,
+				"counted_by":       model.CountedBy,
+				"counted_at":       model.CountedAt,
+			})
+		if result.Error != nil {
+			return fmt.Errorf("update stock count line: %w", result.Error)
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("stock count line %s not found", lines[i].ID)
+		}
+	}
+	return nil
+}
+
+func stockCountToModel(count *StockCount) *models.StockCount {
+	return &models.StockCount{
+		ID:                count.ID,
+		TenantID:          count.TenantID,
+		CountNumber:       count.CountNumber,
+		WarehouseID:       count.WarehouseID,
+		CountDate:         count.CountDate,
+		Status:            string(count.Status),
+		ValuationMethod:   count.ValuationMethod,
+		Notes:             count.Notes,
+		VarianceAccountID: nilIfEmpty(count.VarianceAccountID),
+		JournalEntryID:    count.JournalEntryID,
+		SubmittedAt:       count.SubmittedAt,
+		SubmittedBy:       nilIfEmpty(count.SubmittedBy),
+		ApprovedAt:        count.ApprovedAt,
+		ApprovedBy:        nilIfEmpty(count.ApprovedBy),
+		CreatedBy:         count.CreatedBy,
+		CreatedAt:         count.CreatedAt,
+		UpdatedAt:         count.UpdatedAt,
+	}
+}
+
+func stockCountFromModel(count *models.StockCount) *StockCount {
+	return &StockCount{
+		ID:                count.ID,
+		TenantID:          count.TenantID,
+		CountNumber:       count.CountNumber,
+		WarehouseID:       count.WarehouseID,
+		CountDate:         count.CountDate,
+		Status:            StockCountStatus(count.Status),
+		ValuationMethod:   count.ValuationMethod,
+		Notes:             count.Notes,
+		VarianceAccountID: valueOrEmpty(count.VarianceAccountID),
+		JournalEntryID:    count.JournalEntryID,
+		SubmittedAt:       count.SubmittedAt,
+		SubmittedBy:       valueOrEmpty(count.SubmittedBy),
+		ApprovedAt:        count.ApprovedAt,
+		ApprovedBy:        valueOrEmpty(count.ApprovedBy),
+		CreatedBy:         count.CreatedBy,
+		CreatedAt:         count.CreatedAt,
+		UpdatedAt:         count.UpdatedAt,
+	}
+}
+
+func stockCountLineToModel(line *StockCountLine) *models.StockCountLine {
+	model := &models.StockCountLine{
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		StockCountID:     line.StockCountID,
+		LineNumber:       line.LineNumber,
+		ProductID:        line.ProductID,
+		ProductCode:      line.ProductCode,
+		ProductName:      line.ProductName,
+		Barcode:          nilIfEmpty(line.Barcode),
+		Unit:             line.Unit,
+		LotNumber:        nilIfEmpty(line.LotNumber),
+		SerialNumber:     nilIfEmpty(line.SerialNumber),
+		ExpiryDate:       nilIfEmpty(line.ExpiryDate),
+		ExpectedQuantity: models.Decimal{Decimal: line.ExpectedQuantity},
+		UnitCost:         models.Decimal{Decimal: line.UnitCost},
+		CountedBy:        nilIfEmpty(line.CountedBy),
+		CountedAt:        line.CountedAt,
+	}
+	if line.CountedQuantity != nil {
+		model.CountedQuantity = &models.Decimal{Decimal: *line.CountedQuantity}
+	}
+	return model
+}
+
+func stockCountLineFromModel(line *models.StockCountLine) *StockCountLine {
+	expiryDate := valueOrEmpty(line.ExpiryDate)
+	if len(expiryDate) > len("2006-01-02") {
+		expiryDate = expiryDate[:len("2006-01-02")]
+	}
+	result := &StockCountLine{
+		ID:               line.ID,
+		TenantID:         line.TenantID,
+		StockCountID:     line.StockCountID,
+		LineNumber:       line.LineNumber,
+		ProductID:        line.ProductID,
+		ProductCode:      line.ProductCode,
+		ProductName:      line.ProductName,
+		Barcode:          valueOrEmpty(line.Barcode),
+		Unit:             line.Unit,
+		LotNumber:        valueOrEmpty(line.LotNumber),
+		SerialNumber:     valueOrEmpty(line.SerialNumber),
+		ExpiryDate:       expiryDate,
+		ExpectedQuantity: line.ExpectedQuantity.Decimal,
+		UnitCost:         line.UnitCost.Decimal,
+		CountedBy:        valueOrEmpty(line.CountedBy),
+		CountedAt:        line.CountedAt,
+	}
+	if line.CountedQuantity != nil {
+		counted := line.CountedQuantity.Decimal
+		result.CountedQuantity = &counted
+	}
+	return result
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func valueOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
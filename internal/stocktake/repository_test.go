@@ -0,0 +1,133 @@
+package stocktake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGORMRepositoryNilDatabaseGuards(t *testing.T) {
+	ctx := context.Background()
+	schemaName := "tenant_test"
+	tenantID := "tenant-1"
+	countID := "count-1"
+
+	repositories := []struct {
+		name string
+		repo *GORMRepository
+	}{
+		{name: "nil receiver", repo: nil},
+		{name: "nil gorm database", repo: NewGORMRepository(nil)},
+		{name: "nil pool constructor", repo: NewRepository(nil)},
+	}
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo *GORMRepository) error
+	}{
+		{name: "WithInventoryLedgerTransaction", run: func(t *testing.T, repo *GORMRepository) error {
+			called := false
+			err := repo.WithInventoryLedgerTransaction(ctx, func(Repository, stockCounter) error {
+				called = true
+				return nil
+			})
+			assert.False(t, called)
+			return err
+		}},
+		{name: "Create", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.Create(ctx, schemaName, &StockCount{ID: countID, TenantID: tenantID})
+		}},
+		{name: "GetByID", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GetByID(ctx, schemaName, tenantID, countID)
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "List", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.List(ctx, schemaName, tenantID, &StockCountFilter{Status: StockCountStatusOpen})
+			assert.Nil(t, got)
+			return err
+		}},
+		{name: "SaveCounts", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.SaveCounts(ctx, schemaName, tenantID, countID, nil, nil)
+		}},
+		{name: "UpdateStatus", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.UpdateStatus(ctx, schemaName, tenantID, countID, StockCountStatusSubmitted, "user-1")
+		}},
+		{name: "Approve", run: func(t *testing.T, repo *GORMRepository) error {
+			return repo.Approve(ctx, schemaName, &StockCount{ID: countID, TenantID: tenantID})
+		}},
+		{name: "GenerateNumber", run: func(t *testing.T, repo *GORMRepository) error {
+			got, err := repo.GenerateNumber(ctx, schemaName, tenantID)
+			assert.Empty(t, got)
+			return err
+		}},
+	}
+
+	for _, repository := range repositories {
+		repository := repository
+		t.Run(repository.name, func(t *testing.T) {
+			for _, tt := range tests {
+				tt := tt
+				t.Run(tt.name, func(t *testing.T) {
+					err := tt.run(t, repository.repo)
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), "stocktake repository database is not configured")
+				})
+			}
+		})
+	}
+}
+
+func TestStockCountModelMappingRoundTrip(t *testing.T) {
+	countDate := time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+	approvedAt := time.Date(2027, time.January, 5, 9, 0, 0, 0, time.UTC)
+	journalID := "journal-1"
+	count := &StockCount{
+		ID:                "count-1",
+		TenantID:          "tenant-1",
+		CountNumber:       "SC-00001",
+		WarehouseID:       "warehouse-1",
+		CountDate:         countDate,
+		Status:            StockCountStatusApproved,
+		ValuationMethod:   "FIFO",
+		VarianceAccountID: "variance",
+		JournalEntryID:    &journalID,
+		ApprovedAt:        &approvedAt,
+		ApprovedBy:        "user-2",
+		CreatedBy:         "user-1",
+	}
+	assert.Equal(t, count, stockCountFromModel(stockCountToModel(count)))
+
+	open := stockCountToModel(&StockCount{ID: "count-2"})
+	assert.Nil(t, open.VarianceAccountID)
+	assert.Nil(t, open.SubmittedBy)
+
+	counted := decimal.NewFromInt(7)
+	line := &StockCountLine{
+		ID:               "line-1",
+		TenantID:         "tenant-1",
+		StockCountID:     "count-1",
+		LineNumber:       1,
+		ProductID:        "product-1",
+		ProductCode:      "SKU-1",
+		ProductName:      "Widget",
+		Barcode:          "4740001",
+		LotNumber:        "LOT-A",
+		ExpectedQuantity: decimal.NewFromInt(8),
+		CountedQuantity:  &counted,
+		UnitCost:         decimal.RequireFromString("2.5"),
+		CountedBy:        "user-1",
+	}
+	assert.Equal(t, line, stockCountLineFromModel(stockCountLineToModel(line)))
+
+	model := stockCountLineToModel(&StockCountLine{ID: "line-2"})
+	assert.Nil(t, model.CountedQuantity)
+	assert.Nil(t, model.SerialNumber)
+	expiry := "2027-06-30T00:00:00Z"
+	model.ExpiryDate = &expiry
+	assert.Equal(t, "2027-06-30", stockCountLineFromModel(model).ExpiryDate)
+}
@@ -0,0 +1,614 @@
+package stocktake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+type stockCounter interface {
+	GetWarehouseByID(ctx context.Context, tenantID, schemaName, warehouseID string) (*inventory.Warehouse, error)
+	ListProducts(ctx context.Context, tenantID, schemaName string, filter *inventory.ProductFilter) ([]inventory.Product, error)
+	GetInventoryLotReport(ctx context.Context, tenantID, schemaName, productID, warehouseID string, includeEmpty bool) (*inventory.InventoryLotReport, error)
+	GetInventoryValuation(ctx context.Context, tenantID, schemaName, warehouseID, method string) (*inventory.InventoryValuationReport, error)
+	PostStockCountVariances(ctx context.Context, tenantID, schemaName string, req *inventory.PostStockCountRequest) (*inventory.PostStockCountResult, error)
+}
+
+// Service provides stock count sessions, count entry and variance posting
+type Service struct {
+	repo  Repository
+	stock stockCounter
+}
+
+// NewService creates a new stocktake service with an ORM-backed repository.
+func NewService(db *pgxpool.Pool, inventoryService *inventory.Service) *Service {
+	return &Service{
+		repo:  NewRepository(db),
+		stock: inventoryService,
+	}
+}
+
+// NewServiceWithRepository creates a new stocktake service with custom dependencies
+func NewServiceWithRepository(repo Repository, stock stockCounter) *Service {
+	return &Service{
+		repo:  repo,
+		stock: stock,
+	}
+}
+
+// Create opens a stock count for a warehouse. The expected quantity of every
+// product, lot and serial position on hand is frozen together with its unit
+// cost at the valuation method, so later stock movements do not change the
+// count being reviewed. A warehouse can have only one open or submitted count.
+func (s *Service) Create(ctx context.Context, tenantID, schemaName string, req *CreateStockCountRequest) (*StockCount, error) {
+	if req == nil {
+		return nil, fmt.Errorf("stock count request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for stock counts")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to create a stock count")
+	}
+	warehouseID := strings.TrimSpace(req.WarehouseID)
+	if warehouseID == "" {
+		return nil, fmt.Errorf("warehouse_id is required")
+	}
+	if _, err := s.stock.GetWarehouseByID(ctx, tenantID, schemaName, warehouseID); err != nil {
+		return nil, fmt.Errorf("get warehouse: %w", err)
+	}
+
+	existing, err := s.repo.List(ctx, schemaName, tenantID, &StockCountFilter{WarehouseID: warehouseID})
+	if err != nil {
+		return nil, fmt.Errorf("list stock counts: %w", err)
+	}
+	for _, other := range existing {
+		if other.Status == StockCountStatusOpen || other.Status == StockCountStatusSubmitted {
+			return nil, fmt.Errorf("stock count %s is already in progress for this warehouse", other.CountNumber)
+		}
+	}
+
+	valuation, err := s.stock.GetInventoryValuation(ctx, tenantID, schemaName, warehouseID, req.ValuationMethod)
+	if err != nil {
+		return nil, fmt.Errorf("get inventory valuation: %w", err)
+	}
+	unitCosts := make(map[string]decimal.Decimal, len(valuation.Lines))
+	for _, line := range valuation.Lines {
+		unitCosts[line.ProductID] = line.UnitCost
+	}
+	lots, err := s.stock.GetInventoryLotReport(ctx, tenantID, schemaName, "", warehouseID, false)
+	if err != nil {
+		return nil, fmt.Errorf("get inventory lot report: %w", err)
+	}
+	products, err := s.trackedProducts(ctx, tenantID, schemaName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	count := &StockCount{
+		ID:              uuid.New().String(),
+		TenantID:        tenantID,
+		WarehouseID:     warehouseID,
+		CountDate:       req.CountDate,
+		Status:          StockCountStatusOpen,
+		ValuationMethod: valuation.ValuationMethod,
+		Notes:           req.Notes,
+		Lines:           []StockCountLine{},
+		CreatedBy:       userID,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if count.CountDate.IsZero() {
+		count.CountDate = now
+	}
+	for _, lot := range lots.Lines {
+		product, ok := products[lot.ProductID]
+		if !ok {
+			continue
+		}
+		unitCost, ok := unitCosts[lot.ProductID]
+		if !ok {
+			unitCost = product.PurchasePrice
+		}
+		count.Lines = append(count.Lines, StockCountLine{
+			ID:               uuid.New().String(),
+			TenantID:         tenantID,
+			StockCountID:     count.ID,
+			LineNumber:       len(count.Lines) + 1,
+			ProductID:        product.ID,
+			ProductCode:      product.Code,
+			ProductName:      product.Name,
+			Barcode:          strings.TrimSpace(product.Barcode),
+			Unit:             product.Unit,
+			LotNumber:        lot.LotNumber,
+			SerialNumber:     lot.SerialNumber,
+			ExpiryDate:       lot.ExpiryDate,
+			ExpectedQuantity: lot.Quantity,
+			UnitCost:         unitCost,
+		})
+	}
+
+	countNumber, err := s.repo.GenerateNumber(ctx, schemaName, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("generate stock count number: %w", err)
+	}
+	count.CountNumber = countNumber
+
+	if err := s.repo.Create(ctx, schemaName, count); err != nil {
+		return nil, fmt.Errorf("create stock count: %w", err)
+	}
+	return count, nil
+}
+
+// GetByID retrieves a stock count by ID with its lines
+func (s *Service) GetByID(ctx context.Context, tenantID, schemaName, countID string) (*StockCount, error) {
+	count, err := s.repo.GetByID(ctx, schemaName, tenantID, countID)
+	if err != nil {
+		return nil, fmt.Errorf("get stock count: %w", err)
+	}
+	return count, nil
+}
+
+// List retrieves stock counts with optional filtering
+func (s *Service) List(ctx context.Context, tenantID, schemaName string, filter *StockCountFilter) ([]StockCount, error) {
+	counts, err := s.repo.List(ctx, schemaName, tenantID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list stock counts: %w", err)
+	}
+	return counts, nil
+}
+
+// RecordCounts enters counted quantities on an open stock count
+func (s *Service) RecordCounts(ctx context.Context, tenantID, schemaName, countID string, req *RecordCountsRequest) (*StockCount, error) {
+	if req == nil || len(req.Counts) == 0 {
+		return nil, fmt.Errorf("at least one count is required")
+	}
+	count, applier, err := s.openCountApplier(ctx, tenantID, schemaName, countID, req.Accumulate, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range req.Counts {
+		if err := applier.apply(entry); err != nil {
+			return nil, fmt.Errorf("count %d: %w", i+1, err)
+		}
+	}
+	if err := s.saveCounts(ctx, tenantID, schemaName, applier); err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// Submit closes counting on an open stock count and hands it over for review
+func (s *Service) Submit(ctx context.Context, tenantID, schemaName, countID, userID string) error {
+	count, err := s.repo.GetByID(ctx, schemaName, tenantID, countID)
+	if err != nil {
+		return fmt.Errorf("get stock count: %w", err)
+	}
+	if count.Status != StockCountStatusOpen {
+		return fmt.Errorf("only open stock counts can be submitted")
+	}
+	if count.UncountedLines() == len(count.Lines) {
+		return fmt.Errorf("stock count has no counted lines")
+	}
+	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, countID, StockCountStatusSubmitted, userID); err != nil {
+		return fmt.Errorf("submit stock count: %w", err)
+	}
+	return nil
+}
+
+// Cancel cancels an open or submitted stock count without posting it
+func (s *Service) Cancel(ctx context.Context, tenantID, schemaName, countID, userID string) error {
+	count, err := s.repo.GetByID(ctx, schemaName, tenantID, countID)
+	if err != nil {
+		return fmt.Errorf("get stock count: %w", err)
+	}
+	if count.Status != StockCountStatusOpen && count.Status != StockCountStatusSubmitted {
+		return fmt.Errorf("only open or submitted stock counts can be canceled")
+	}
+	if err := s.repo.UpdateStatus(ctx, schemaName, tenantID, countID, StockCountStatusCanceled, userID); err != nil {
+		return fmt.Errorf("cancel stock count: %w", err)
+	}
+	return nil
+}
+
+// GetVariance compares counted with frozen expected quantities and values
+// the differences at the unit costs frozen when the count was created
+func (s *Service) GetVariance(ctx context.Context, tenantID, schemaName, countID string) (*StockCountVariance, error) {
+	count, err := s.repo.GetByID(ctx, schemaName, tenantID, countID)
+	if err != nil {
+		return nil, fmt.Errorf("get stock count: %w", err)
+	}
+	return buildStockCountVariance(count), nil
+}
+
+// Approve approves a submitted stock count. Every difference between counted
+// and expected quantities is booked to stock and posted in one journal entry
+// against the stock variance account. Uncounted lines must be counted first
+// unless ZeroUncounted treats them as counted at zero.
+func (s *Service) Approve(ctx context.Context, tenantID, schemaName, countID string, req *ApproveStockCountRequest) (*StockCount, error) {
+	if req == nil {
+		return nil, fmt.Errorf("approval request is required")
+	}
+	if s.stock == nil {
+		return nil, fmt.Errorf("inventory service is unavailable for stock counts")
+	}
+	userID := strings.TrimSpace(req.UserID)
+	if userID == "" {
+		return nil, fmt.Errorf("user id is required to approve a stock count")
+	}
+	varianceAccountID := strings.TrimSpace(req.VarianceAccountID)
+	if varianceAccountID == "" {
+		return nil, fmt.Errorf("variance_account_id is required")
+	}
+
+	count, err := s.repo.GetByID(ctx, schemaName, tenantID, countID)
+	if err != nil {
+		return nil, fmt.Errorf("get stock count: %w", err)
+	}
+	if count.Status != StockCountStatusSubmitted {
+		return nil, fmt.Errorf("only submitted stock counts can be approved")
+	}
+	if req.PeriodLockDate != nil && !count.CountDate.After(*req.PeriodLockDate) {
+		return nil, fmt.Errorf("%w through %s; count date %s must be later", ErrPeriodLocked, req.PeriodLockDate.Format("2006-01-02"), count.CountDate.Format("2006-01-02"))
+	}
+
+	now := time.Now()
+	if uncounted := count.UncountedLines(); uncounted > 0 {
+		if !req.ZeroUncounted {
+			return nil, fmt.Errorf("%d stock count lines are not counted; count them or set zero_uncounted", uncounted)
+		}
+		for i := range count.Lines {
+			line := &count.Lines[i]
+			if line.Counted() {
+				continue
+			}
+			zero := decimal.Zero
+			line.CountedQuantity = &zero
+			line.CountedBy = userID
+			line.CountedAt = &now
+		}
+	}
+
+	stockLines := make([]inventory.PostStockCountLine, 0, len(count.Lines))
+	for _, line := range count.Lines {
+		variance := line.VarianceQuantity()
+		if variance.IsZero() {
+			continue
+		}
+		stockLines = append(stockLines, inventory.PostStockCountLine{
+			ProductID:    line.ProductID,
+			Quantity:     variance,
+			UnitCost:     line.UnitCost,
+			LotNumber:    line.LotNumber,
+			SerialNumber: line.SerialNumber,
+			ExpiryDate:   line.ExpiryDate,
+		})
+	}
+
+	count.Status = StockCountStatusApproved
+	count.VarianceAccountID = varianceAccountID
+	count.ApprovedAt = &now
+	count.ApprovedBy = userID
+	count.UpdatedAt = now
+
+	// Post the variances and approve the count in one transaction so a failed
+	// approval leaves no stock movement or journal behind.
+	err = s.withInventoryLedgerTransaction(ctx, func(tx *Service) error {
+		if len(stockLines) > 0 {
+			result, err := tx.stock.PostStockCountVariances(ctx, tenantID, schemaName, &inventory.PostStockCountRequest{
+				WarehouseID:        count.WarehouseID,
+				CountDate:          count.CountDate,
+				Reference:          count.CountNumber,
+				SourceType:         StockCountSourceType,
+				SourceID:           count.ID,
+				VarianceAccountID:  varianceAccountID,
+				InventoryAccountID: req.InventoryAccountID,
+				Lines:              stockLines,
+				UserID:             userID,
+			})
+			if err != nil {
+				return fmt.Errorf("post stock count variances: %w", err)
+			}
+			if result.JournalID != "" {
+				journalID := result.JournalID
+				count.JournalEntryID = &journalID
+			}
+		}
+		if err := tx.repo.Approve(ctx, schemaName, count); err != nil {
+			return fmt.Errorf("approve stock count: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return count, nil
+}
+
+// withInventoryLedgerTransaction runs fn with a copy of the service bound to
+// one repository and inventory transaction when the repository supports it.
+func (s *Service) withInventoryLedgerTransaction(ctx context.Context, fn func(tx *Service) error) error {
+	transactioner, ok := s.repo.(InventoryLedgerTransactionRepository)
+	if !ok {
+		return fn(s)
+	}
+	return transactioner.WithInventoryLedgerTransaction(ctx, func(txRepo Repository, stock stockCounter) error {
+		tx := *s
+		tx.repo = txRepo
+		tx.stock = stock
+		return fn(&tx)
+	})
+}
+
+func (s *Service) trackedProducts(ctx context.Context, tenantID, schemaName string) (map[string]inventory.Product, error) {
+	products, err := s.stock.ListProducts(ctx, tenantID, schemaName, &inventory.ProductFilter{ProductType: inventory.ProductTypeGoods})
+	if err != nil {
+		return nil, fmt.Errorf("list products: %w", err)
+	}
+	byID := make(map[string]inventory.Product, len(products))
+	for _, product := range products {
+		if product.ProductType != inventory.ProductTypeGoods || !product.TrackInventory {
+			continue
+		}
+		byID[product.ID] = product
+	}
+	return byID, nil
+}
+
+func (s *Service) openCountApplier(ctx context.Context, tenantID, schemaName, countID string, accumulate bool, userID string) (*StockCount, *countApplier, error) {
+	if s.stock == nil {
+		return nil, nil, fmt.Errorf("inventory service is unavailable for stock counts")
+	}
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, nil, fmt.Errorf("user id is required to record counts")
+	}
+	count, err := s.repo.GetByID(ctx, schemaName, tenantID, countID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get stock count: %w", err)
+	}
+	if count.Status != StockCountStatusOpen {
+		return nil, nil, fmt.Errorf("counts can only be recorded on open stock counts")
+	}
+	products, err := s.trackedProducts(ctx, tenantID, schemaName)
+	if err != nil {
+		return nil, nil, err
+	}
+	return count, newCountApplier(count, products, accumulate, userID), nil
+}
+
+func (s *Service) saveCounts(ctx context.Context, tenantID, schemaName string, applier *countApplier) error {
+	counted, added := applier.changes()
+	if len(counted) == 0 && len(added) == 0 {
+		return nil
+	}
+	if err := s.repo.SaveCounts(ctx, schemaName, tenantID, applier.count.ID, counted, added); err != nil {
+		return fmt.Errorf("save stock counts: %w", err)
+	}
+	return nil
+}
+
+func buildStockCountVariance(count *StockCount) *StockCountVariance {
+	variance := &StockCountVariance{
+		StockCountID:    count.ID,
+		CountNumber:     count.CountNumber,
+		WarehouseID:     count.WarehouseID,
+		CountDate:       count.CountDate,
+		Status:          count.Status,
+		ValuationMethod: count.ValuationMethod,
+		Lines:           make([]StockCountVarianceLine, 0, len(count.Lines)),
+		ExpectedValue:   decimal.Zero,
+		CountedValue:    decimal.Zero,
+		SurplusValue:    decimal.Zero,
+		ShortageValue:   decimal.Zero,
+		NetVariance:     decimal.Zero,
+	}
+	for i := range count.Lines {
+		line := &count.Lines[i]
+		value := line.VarianceValue()
+		variance.Lines = append(variance.Lines, StockCountVarianceLine{
+			LineID:           line.ID,
+			LineNumber:       line.LineNumber,
+			ProductID:        line.ProductID,
+			ProductCode:      line.ProductCode,
+			ProductName:      line.ProductName,
+			Barcode:          line.Barcode,
+			LotNumber:        line.LotNumber,
+			SerialNumber:     line.SerialNumber,
+			ExpiryDate:       line.ExpiryDate,
+			ExpectedQuantity: line.ExpectedQuantity,
+			CountedQuantity:  line.CountedQuantity,
+			VarianceQuantity: line.VarianceQuantity(),
+			UnitCost:         line.UnitCost,
+			VarianceValue:    value,
+		})
+		variance.ExpectedValue = variance.ExpectedValue.Add(line.ExpectedQuantity.Mul(line.UnitCost).Round(2))
+		if !line.Counted() {
+			variance.UncountedLines++
+			continue
+		}
+		variance.CountedLines++
+		variance.CountedValue = variance.CountedValue.Add(line.CountedQuantity.Mul(line.UnitCost).Round(2))
+		if value.IsNegative() {
+			variance.ShortageValue = variance.ShortageValue.Add(value.Neg())
+		} else {
+			variance.SurplusValue = variance.SurplusValue.Add(value)
+		}
+		variance.NetVariance = variance.NetVariance.Add(value)
+	}
+	return variance
+}
+
+// countApplier resolves count entries to stock count lines and sums the
+// quantities entered for each line.
+type countApplier struct {
+	count      *StockCount
+	products   map[string]inventory.Product
+	barcodes   map[string]inventory.Product
+	accumulate bool
+	userID     string
+	now        time.Time
+	touched    map[string]bool
+	added      map[string]bool
+}
+
+func newCountApplier(count *StockCount, products map[string]inventory.Product, accumulate bool, userID string) *countApplier {
+	barcodes := make(map[string]inventory.Product, len(products))
+	for _, product := range products {
+		if barcode := strings.TrimSpace(product.Barcode); barcode != "" {
+			barcodes[barcode] = product
+		}
+	}
+	return &countApplier{
+		count:      count,
+		products:   products,
+		barcodes:   barcodes,
+		accumulate: accumulate,
+		userID:     userID,
+		now:        time.Now(),
+		touched:    make(map[string]bool),
+		added:      make(map[string]bool),
+	}
+}
+
+func (a *countApplier) apply(entry CountEntry) error {
+	if entry.Quantity.IsNegative() {
+		return fmt.Errorf("quantity cannot be negative")
+	}
+	index, err := a.resolve(entry)
+	if err != nil {
+		return err
+	}
+	line := &a.count.Lines[index]
+	total := entry.Quantity
+	if (a.accumulate || a.touched[line.ID]) && line.CountedQuantity != nil {
+		total = line.CountedQuantity.Add(entry.Quantity)
+	}
+	now := a.now
+	line.CountedQuantity = &total
+	line.CountedBy = a.userID
+	line.CountedAt = &now
+	a.touched[line.ID] = true
+	return nil
+}
+
+func (a *countApplier) resolve(entry CountEntry) (int, error) {
+	if lineID := strings.TrimSpace(entry.LineID); lineID != "" {
+		for i := range a.count.Lines {
+			if a.count.Lines[i].ID == lineID {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("stock count line %s not found", lineID)
+	}
+
+	product, err := a.product(entry)
+	if err != nil {
+		return -1, err
+	}
+	lotNumber := strings.TrimSpace(entry.LotNumber)
+	serialNumber := strings.TrimSpace(entry.SerialNumber)
+	expiryDate := strings.TrimSpace(entry.ExpiryDate)
+	if expiryDate != "" {
+		if _, err := time.Parse("2006-01-02", expiryDate); err != nil {
+			return -1, fmt.Errorf("expiry_date must use YYYY-MM-DD")
+		}
+	}
+
+	candidates := make([]int, 0, 1)
+	for i := range a.count.Lines {
+		line := &a.count.Lines[i]
+		if line.ProductID != product.ID {
+			continue
+		}
+		if line.LotNumber == lotNumber && line.SerialNumber == serialNumber {
+			return i, nil
+		}
+		candidates = append(candidates, i)
+	}
+	if lotNumber == "" && serialNumber == "" {
+		switch len(candidates) {
+		case 0:
+		case 1:
+			return candidates[0], nil
+		default:
+			return -1, fmt.Errorf("product %s is counted by lot or serial; set lot_number or serial_number", product.Code)
+		}
+	}
+	return a.addLine(product, lotNumber, serialNumber, expiryDate), nil
+}
+
+func (a *countApplier) product(entry CountEntry) (inventory.Product, error) {
+	var (
+		product inventory.Product
+		ok      bool
+	)
+	switch {
+	case strings.TrimSpace(entry.ProductID) != "":
+		product, ok = a.products[strings.TrimSpace(entry.ProductID)]
+		if !ok {
+			return product, fmt.Errorf("product %s was not found or does not track inventory", strings.TrimSpace(entry.ProductID))
+		}
+	case strings.TrimSpace(entry.Barcode) != "":
+		product, ok = a.barcodes[strings.TrimSpace(entry.Barcode)]
+		if !ok {
+			return product, fmt.Errorf("barcode %s does not match a tracked product", strings.TrimSpace(entry.Barcode))
+		}
+	default:
+		return product, fmt.Errorf("line_id, product_id or barcode is required")
+	}
+	return product, nil
+}
+
+// addLine adds a line for a product or lot that was not expected in the
+// warehouse. Its unit cost follows the product's other lines on the count and
+// falls back to the purchase price.
+func (a *countApplier) addLine(product inventory.Product, lotNumber, serialNumber, expiryDate string) int {
+	unitCost := product.PurchasePrice
+	for i := range a.count.Lines {
+		if a.count.Lines[i].ProductID == product.ID {
+			unitCost = a.count.Lines[i].UnitCost
+			break
+		}
+	}
+	line := StockCountLine{
+		ID:               uuid.New().String(),
+		TenantID:         a.count.TenantID,
+		StockCountID:     a.count.ID,
+		LineNumber:       len(a.count.Lines) + 1,
+		ProductID:        product.ID,
+		ProductCode:      product.Code,
+		ProductName:      product.Name,
+		Barcode:          strings.TrimSpace(product.Barcode),
+		Unit:             product.Unit,
+		LotNumber:        lotNumber,
+		SerialNumber:     serialNumber,
+		ExpiryDate:       expiryDate,
+		ExpectedQuantity: decimal.Zero,
+		UnitCost:         unitCost,
+	}
+	a.count.Lines = append(a.count.Lines, line)
+	a.added[line.ID] = true
+	return len(a.count.Lines) - 1
+}
+
+func (a *countApplier) changes() (counted, added []StockCountLine) {
+	for _, line := range a.count.Lines {
+		switch {
+		case a.added[line.ID]:
+			added = append(added, line)
+		case a.touched[line.ID]:
+			counted = append(counted, line)
+		}
+	}
+	return counted, added
+}
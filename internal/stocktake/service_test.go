@@ -0,0 +1,439 @@
+package stocktake
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/HMB-research/open-accounting/internal/inventory"
+)
+
+const (
+	testWarehouseID       = "22222222-2222-4222-8222-222222222222"
+	testProductID         = "33333333-3333-4333-8333-333333333333"
+	testLotProductID      = "44444444-4444-4444-8444-444444444444"
+	testVarianceAccountID = "77777777-7777-4777-8777-777777777777"
+)
+
+type mockRepository struct {
+	counts     map[string]*StockCount
+	seq        int
+	approveErr error
+	stock      *fakeStock
+}
+
+func newMockRepository() *mockRepository {
+	return &mockRepository{counts: make(map[string]*StockCount)}
+}
+
+func (m *mockRepository) Create(_ context.Context, _ string, count *StockCount) error {
+	m.counts[count.ID] = cloneStockCount(count)
+	return nil
+}
+
+func (m *mockRepository) GetByID(_ context.Context, _, tenantID, countID string) (*StockCount, error) {
+	count, ok := m.counts[countID]
+	if !ok || count.TenantID != tenantID {
+		return nil, ErrStockCountNotFound
+	}
+	return cloneStockCount(count), nil
+}
+
+func (m *mockRepository) List(_ context.Context, _, tenantID string, filter *StockCountFilter) ([]StockCount, error) {
+	var result []StockCount
+	for _, count := range m.counts {
+		if count.TenantID != tenantID {
+			continue
+		}
+		if filter != nil && filter.WarehouseID != "" && count.WarehouseID != filter.WarehouseID {
+			continue
+		}
+		if filter != nil && filter.Status != "" && count.Status != filter.Status {
+			continue
+		}
+		result = append(result, *count)
+	}
+	return result, nil
+}
+
+func (m *mockRepository) SaveCounts(_ context.Context, _, _, countID string, counted, added []StockCountLine) error {
+	count := m.counts[countID]
+	for _, line := range counted {
+		for i := range count.Lines {
+			if count.Lines[i].ID == line.ID {
+				count.Lines[i] = line
+			}
+		}
+	}
+	count.Lines = append(count.Lines, added...)
+	return nil
+}
+
+func (m *mockRepository) UpdateStatus(_ context.Context, _, tenantID, countID string, status StockCountStatus, userID string) error {
+	count, ok := m.counts[countID]
+	if !ok || count.TenantID != tenantID {
+		return ErrStockCountNotFound
+	}
+	count.Status = status
+	if status == StockCountStatusSubmitted {
+		count.SubmittedBy = userID
+	}
+	return nil
+}
+
+func (m *mockRepository) Approve(_ context.Context, _ string, count *StockCount) error {
+	if m.approveErr != nil {
+		return m.approveErr
+	}
+	m.counts[count.ID] = cloneStockCount(count)
+	return nil
+}
+
+func (m *mockRepository) GenerateNumber(context.Context, string, string) (string, error) {
+	m.seq++
+	return fmt.Sprintf("SC-%05d", m.seq), nil
+}
+
+// WithInventoryLedgerTransaction restores the stored counts and the posted
+// variances when fn fails.
+func (m *mockRepository) WithInventoryLedgerTransaction(_ context.Context, fn func(txRepo Repository, stock stockCounter) error) error {
+	counts := make(map[string]*StockCount, len(m.counts))
+	for id, count := range m.counts {
+		counts[id] = cloneStockCount(count)
+	}
+	posted := m.stock.posted
+	if err := fn(m, m.stock); err != nil {
+		m.counts = counts
+		m.stock.posted = posted
+		return err
+	}
+	return nil
+}
+
+func cloneStockCount(count *StockCount) *StockCount {
+	copyCount := *count
+	copyCount.Lines = append([]StockCountLine(nil), count.Lines...)
+	return &copyCount
+}
+
+type fakeStock struct {
+	products  []inventory.Product
+	lots      []inventory.InventoryLotLine
+	unitCosts map[string]decimal.Decimal
+	methods   []string
+	posted    *inventory.PostStockCountRequest
+	postErr   error
+}
+
+func newFakeStock() *fakeStock {
+	return &fakeStock{
+		products: []inventory.Product{
+			{ID: testProductID, Code: "SKU-1", Name: "Widget", Barcode: "4740001", Unit: "pcs", ProductType: inventory.ProductTypeGoods, TrackInventory: true, PurchasePrice: decimal.NewFromInt(3)},
+			{ID: testLotProductID, Code: "SKU-2", Name: "Serum", Barcode: "4740002", ProductType: inventory.ProductTypeGoods, TrackInventory: true, PurchasePrice: decimal.NewFromInt(9)},
+			{ID: "service-1", Code: "SRV", Name: "Service", Barcode: "4740009", ProductType: inventory.ProductTypeService},
+		},
+		lots: []inventory.InventoryLotLine{
+			{ProductID: testProductID, Quantity: decimal.NewFromInt(10)},
+			{ProductID: testLotProductID, LotNumber: "LOT-A", ExpiryDate: "2027-01-31", Quantity: decimal.NewFromInt(4)},
+			{ProductID: testLotProductID, LotNumber: "LOT-B", ExpiryDate: "2027-06-30", Quantity: decimal.NewFromInt(2)},
+		},
+		unitCosts: map[string]decimal.Decimal{
+			testProductID:    decimal.RequireFromString("2.50"),
+			testLotProductID: decimal.RequireFromString("8"),
+		},
+	}
+}
+
+func (f *fakeStock) GetWarehouseByID(_ context.Context, _, _, warehouseID string) (*inventory.Warehouse, error) {
+	if warehouseID != testWarehouseID {
+		return nil, fmt.Errorf("warehouse not found")
+	}
+	return &inventory.Warehouse{ID: warehouseID, Code: "MAIN"}, nil
+}
+
+func (f *fakeStock) ListProducts(context.Context, string, string, *inventory.ProductFilter) ([]inventory.Product, error) {
+	return f.products, nil
+}
+
+func (f *fakeStock) GetInventoryLotReport(context.Context, string, string, string, string, bool) (*inventory.InventoryLotReport, error) {
+	return &inventory.InventoryLotReport{Lines: f.lots}, nil
+}
+
+func (f *fakeStock) GetInventoryValuation(_ context.Context, _, _, warehouseID, method string) (*inventory.InventoryValuationReport, error) {
+	f.methods = append(f.methods, method)
+	report := &inventory.InventoryValuationReport{WarehouseID: warehouseID, ValuationMethod: inventory.InventoryValuationMethodWeightedAverage}
+	for productID, unitCost := range f.unitCosts {
+		report.Lines = append(report.Lines, inventory.InventoryValuationLine{ProductID: productID, UnitCost: unitCost})
+	}
+	return report, nil
+}
+
+func (f *fakeStock) PostStockCountVariances(_ context.Context, _, _ string, req *inventory.PostStockCountRequest) (*inventory.PostStockCountResult, error) {
+	if f.postErr != nil {
+		return nil, f.postErr
+	}
+	f.posted = req
+	return &inventory.PostStockCountResult{JournalID: "journal-1"}, nil
+}
+
+func newTestService() (*Service, *mockRepository, *fakeStock) {
+	repo := newMockRepository()
+	stock := newFakeStock()
+	repo.stock = stock
+	return NewServiceWithRepository(repo, stock), repo, stock
+}
+
+func createTestCount(t *testing.T, svc *Service) *StockCount {
+	t.Helper()
+	count, err := svc.Create(context.Background(), "tenant-1", "tenant_test", &CreateStockCountRequest{
+		WarehouseID:     testWarehouseID,
+		CountDate:       time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC),
+		ValuationMethod: "WEIGHTED_AVERAGE",
+		UserID:          "user-1",
+	})
+	require.NoError(t, err)
+	return count
+}
+
+func qty(value string) decimal.Decimal {
+	return decimal.RequireFromString(value)
+}
+
+func TestCreateFreezesExpectedQuantitiesAndCosts(t *testing.T) {
+	svc, _, stock := newTestService()
+
+	count := createTestCount(t, svc)
+	assert.Equal(t, "SC-00001", count.CountNumber)
+	assert.Equal(t, StockCountStatusOpen, count.Status)
+	assert.Equal(t, inventory.InventoryValuationMethodWeightedAverage, count.ValuationMethod)
+	assert.Equal(t, []string{"WEIGHTED_AVERAGE"}, stock.methods)
+	require.Len(t, count.Lines, 3)
+	assert.Equal(t, "4740001", count.Lines[0].Barcode)
+	assert.True(t, count.Lines[0].ExpectedQuantity.Equal(qty("10")))
+	assert.True(t, count.Lines[0].UnitCost.Equal(qty("2.50")))
+	assert.Equal(t, "LOT-B", count.Lines[2].LotNumber)
+	assert.Equal(t, 3, count.Lines[2].LineNumber)
+	assert.Nil(t, count.Lines[0].CountedQuantity)
+
+	_, err := svc.Create(context.Background(), "tenant-1", "tenant_test", &CreateStockCountRequest{WarehouseID: testWarehouseID, UserID: "user-1"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SC-00001 is already in progress")
+}
+
+func TestRecordCountsResolvesLinesAndAddsUnexpectedLots(t *testing.T) {
+	svc, _, _ := newTestService()
+	count := createTestCount(t, svc)
+
+	updated, err := svc.RecordCounts(context.Background(), "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{
+		UserID: "user-2",
+		Counts: []CountEntry{
+			{Barcode: "4740001", Quantity: qty("5")},
+			{Barcode: "4740001", Quantity: qty("3")},
+			{LineID: count.Lines[1].ID, Quantity: qty("4")},
+			{ProductID: testLotProductID, LotNumber: "LOT-C", ExpiryDate: "2028-01-31", Quantity: qty("1")},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, updated.Lines, 4)
+	assert.True(t, updated.Lines[0].CountedQuantity.Equal(qty("8")))
+	assert.Equal(t, "user-2", updated.Lines[0].CountedBy)
+	assert.True(t, updated.Lines[1].CountedQuantity.Equal(qty("4")))
+	assert.Nil(t, updated.Lines[2].CountedQuantity)
+	assert.Equal(t, "LOT-C", updated.Lines[3].LotNumber)
+	assert.True(t, updated.Lines[3].ExpectedQuantity.IsZero())
+	assert.True(t, updated.Lines[3].UnitCost.Equal(qty("8")))
+
+	stored, err := svc.GetByID(context.Background(), "tenant-1", "tenant_test", count.ID)
+	require.NoError(t, err)
+	require.Len(t, stored.Lines, 4)
+
+	updated, err = svc.RecordCounts(context.Background(), "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{
+		UserID:     "user-2",
+		Accumulate: true,
+		Counts:     []CountEntry{{Barcode: "4740001", Quantity: qty("2")}},
+	})
+	require.NoError(t, err)
+	assert.True(t, updated.Lines[0].CountedQuantity.Equal(qty("10")))
+
+	updated, err = svc.RecordCounts(context.Background(), "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{
+		UserID: "user-2",
+		Counts: []CountEntry{{Barcode: "4740001", Quantity: qty("7")}},
+	})
+	require.NoError(t, err)
+	assert.True(t, updated.Lines[0].CountedQuantity.Equal(qty("7")))
+}
+
+func TestRecordCountsValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry CountEntry
+		want  string
+	}{
+		{name: "ambiguous lot", entry: CountEntry{Barcode: "4740002", Quantity: qty("1")}, want: "is counted by lot or serial"},
+		{name: "unknown barcode", entry: CountEntry{Barcode: "999", Quantity: qty("1")}, want: "barcode 999 does not match a tracked product"},
+		{name: "untracked product", entry: CountEntry{Barcode: "4740009", Quantity: qty("1")}, want: "does not match a tracked product"},
+		{name: "negative quantity", entry: CountEntry{Barcode: "4740001", Quantity: qty("-1")}, want: "quantity cannot be negative"},
+		{name: "missing key", entry: CountEntry{Quantity: qty("1")}, want: "line_id, product_id or barcode is required"},
+		{name: "bad expiry", entry: CountEntry{ProductID: testLotProductID, LotNumber: "LOT-X", ExpiryDate: "31.01.2028", Quantity: qty("1")}, want: "expiry_date must use YYYY-MM-DD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, _, _ := newTestService()
+			count := createTestCount(t, svc)
+
+			_, err := svc.RecordCounts(context.Background(), "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{UserID: "user-1", Counts: []CountEntry{tt.entry}})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestImportCountsCSVSumsScansAndReportsRowErrors(t *testing.T) {
+	svc, _, _ := newTestService()
+	count := createTestCount(t, svc)
+
+	result, err := svc.ImportCountsCSV(context.Background(), "tenant-1", "tenant_test", count.ID, &ImportCountsRequest{
+		FileName:   "scanner.csv",
+		UserID:     "user-1",
+		CSVContent: "Barcode;Qty;Lot\n4740001;6;\n4740001;1,5;\n4740002;4;LOT-A\n0000;1;\n4740001;x;\n",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.RowsProcessed)
+	assert.Equal(t, 3, result.RowsImported)
+	assert.Equal(t, 2, result.RowsSkipped)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, 5, result.Errors[0].Row)
+	assert.Contains(t, result.Errors[1].Message, "quantity must be a decimal")
+	assert.True(t, result.StockCount.Lines[0].CountedQuantity.Equal(qty("7.5")))
+	assert.True(t, result.StockCount.Lines[1].CountedQuantity.Equal(qty("4")))
+
+	_, err = svc.ImportCountsCSV(context.Background(), "tenant-1", "tenant_test", count.ID, &ImportCountsRequest{UserID: "user-1", CSVContent: "sku,qty\nA,1\n"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing required columns: barcode")
+}
+
+func TestApprovePostsVariancesInOneEntry(t *testing.T) {
+	svc, repo, stock := newTestService()
+	count := createTestCount(t, svc)
+	ctx := context.Background()
+
+	_, err := svc.RecordCounts(ctx, "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{
+		UserID: "user-1",
+		Counts: []CountEntry{
+			{Barcode: "4740001", Quantity: qty("8")},
+			{Barcode: "4740002", LotNumber: "LOT-A", Quantity: qty("5")},
+		},
+	})
+	require.NoError(t, err)
+
+	variance, err := svc.GetVariance(ctx, "tenant-1", "tenant_test", count.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, variance.CountedLines)
+	assert.Equal(t, 1, variance.UncountedLines)
+	assert.True(t, variance.ShortageValue.Equal(qty("5")))
+	assert.True(t, variance.SurplusValue.Equal(qty("8")))
+	assert.True(t, variance.NetVariance.Equal(qty("3")))
+
+	_, err = svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, UserID: "user-2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only submitted stock counts can be approved")
+
+	require.NoError(t, svc.Submit(ctx, "tenant-1", "tenant_test", count.ID, "user-1"))
+	_, err = svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, UserID: "user-2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 stock count lines are not counted")
+
+	lockDate := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	_, err = svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, ZeroUncounted: true, PeriodLockDate: &lockDate, UserID: "user-2"})
+	require.ErrorIs(t, err, ErrPeriodLocked)
+
+	approved, err := svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, ZeroUncounted: true, UserID: "user-2"})
+	require.NoError(t, err)
+	assert.Equal(t, StockCountStatusApproved, approved.Status)
+	require.NotNil(t, approved.JournalEntryID)
+	assert.Equal(t, "journal-1", *approved.JournalEntryID)
+	assert.Equal(t, "user-2", approved.ApprovedBy)
+	assert.True(t, approved.Lines[2].CountedQuantity.IsZero())
+
+	require.NotNil(t, stock.posted)
+	assert.Equal(t, StockCountSourceType, stock.posted.SourceType)
+	assert.Equal(t, count.ID, stock.posted.SourceID)
+	assert.Equal(t, "SC-00001", stock.posted.Reference)
+	require.Len(t, stock.posted.Lines, 3)
+	assert.True(t, stock.posted.Lines[0].Quantity.Equal(qty("-2")))
+	assert.True(t, stock.posted.Lines[1].Quantity.Equal(qty("1")))
+	assert.Equal(t, "LOT-A", stock.posted.Lines[1].LotNumber)
+	assert.True(t, stock.posted.Lines[2].Quantity.Equal(qty("-2")))
+	assert.Equal(t, StockCountStatusApproved, repo.counts[count.ID].Status)
+
+	assert.Error(t, svc.Cancel(ctx, "tenant-1", "tenant_test", count.ID, "user-1"))
+}
+
+func TestApproveKeepsCountSubmittedWhenPostingFails(t *testing.T) {
+	svc, repo, stock := newTestService()
+	count := createTestCount(t, svc)
+	ctx := context.Background()
+	_, err := svc.RecordCounts(ctx, "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{UserID: "user-1", Counts: []CountEntry{{Barcode: "4740001", Quantity: qty("9")}}})
+	require.NoError(t, err)
+	require.NoError(t, svc.Submit(ctx, "tenant-1", "tenant_test", count.ID, "user-1"))
+	stock.postErr = fmt.Errorf("variance_account_id must reference an EXPENSE account")
+
+	_, err = svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, ZeroUncounted: true, UserID: "user-2"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must reference an EXPENSE account")
+	assert.Equal(t, StockCountStatusSubmitted, repo.counts[count.ID].Status)
+	assert.Nil(t, repo.counts[count.ID].Lines[1].CountedQuantity)
+}
+
+func TestApproveRollsBackVariancesWhenCountCannotBeApproved(t *testing.T) {
+	svc, repo, stock := newTestService()
+	count := createTestCount(t, svc)
+	ctx := context.Background()
+	_, err := svc.RecordCounts(ctx, "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{UserID: "user-1", Counts: []CountEntry{{Barcode: "4740001", Quantity: qty("9")}}})
+	require.NoError(t, err)
+	require.NoError(t, svc.Submit(ctx, "tenant-1", "tenant_test", count.ID, "user-1"))
+	repo.approveErr = fmt.Errorf("connection reset")
+
+	_, err = svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, ZeroUncounted: true, UserID: "user-2"})
+	require.ErrorContains(t, err, "approve stock count: connection reset")
+	assert.Nil(t, stock.posted)
+	assert.Equal(t, StockCountStatusSubmitted, repo.counts[count.ID].Status)
+	assert.Nil(t, repo.counts[count.ID].JournalEntryID)
+
+	repo.approveErr = nil
+	approved, err := svc.Approve(ctx, "tenant-1", "tenant_test", count.ID, &ApproveStockCountRequest{VarianceAccountID: testVarianceAccountID, ZeroUncounted: true, UserID: "user-2"})
+	require.NoError(t, err)
+	require.NotNil(t, stock.posted)
+	require.NotNil(t, approved.JournalEntryID)
+	assert.Equal(t, StockCountStatusApproved, repo.counts[count.ID].Status)
+}
+
+func TestSubmitAndCancelTransitions(t *testing.T) {
+	svc, repo, _ := newTestService()
+	count := createTestCount(t, svc)
+	ctx := context.Background()
+
+	err := svc.Submit(ctx, "tenant-1", "tenant_test", count.ID, "user-1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no counted lines")
+
+	_, err = svc.RecordCounts(ctx, "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{UserID: "user-1", Counts: []CountEntry{{Barcode: "4740001", Quantity: qty("10")}}})
+	require.NoError(t, err)
+	require.NoError(t, svc.Submit(ctx, "tenant-1", "tenant_test", count.ID, "user-1"))
+	assert.Equal(t, "user-1", repo.counts[count.ID].SubmittedBy)
+
+	_, err = svc.RecordCounts(ctx, "tenant-1", "tenant_test", count.ID, &RecordCountsRequest{UserID: "user-1", Counts: []CountEntry{{Barcode: "4740001", Quantity: qty("1")}}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only be recorded on open stock counts")
+
+	require.NoError(t, svc.Cancel(ctx, "tenant-1", "tenant_test", count.ID, "user-1"))
+	assert.Equal(t, StockCountStatusCanceled, repo.counts[count.ID].Status)
+
+	_, err = svc.Create(ctx, "tenant-1", "tenant_test", &CreateStockCountRequest{WarehouseID: testWarehouseID, UserID: "user-1"})
+	require.NoError(t, err)
+}
@@ -0,0 +1,204 @@
+package stocktake
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// StockCountStatus represents the status of a stock count session
+type StockCountStatus string
+
+const (
+	StockCountStatusOpen      StockCountStatus = "OPEN"
+	StockCountStatusSubmitted StockCountStatus = "SUBMITTED"
+	StockCountStatusApproved  StockCountStatus = "APPROVED"
+	StockCountStatusCanceled  StockCountStatus = "CANCELED"
+)
+
+// StockCountSourceType marks inventory movements and journal entries created by approved stock counts.
+const StockCountSourceType = "STOCK_COUNT"
+
+// StockCount is a count session for one warehouse. Expected quantities and
+// unit costs are frozen when the session is created.
+type StockCount struct {
+	ID                string           `json:"id"`
+	TenantID          string           `json:"tenant_id"`
+	CountNumber       string           `json:"count_number"`
+	WarehouseID       string           `json:"warehouse_id"`
+	CountDate         time.Time        `json:"count_date"`
+	Status            StockCountStatus `json:"status"`
+	ValuationMethod   string           `json:"valuation_method"`
+	Notes             string           `json:"notes,omitempty"`
+	VarianceAccountID string           `json:"variance_account_id,omitempty"`
+	JournalEntryID    *string          `json:"journal_entry_id,omitempty"`
+	SubmittedAt       *time.Time       `json:"submitted_at,omitempty"`
+	SubmittedBy       string           `json:"submitted_by,omitempty"`
+	ApprovedAt        *time.Time       `json:"approved_at,omitempty"`
+	ApprovedBy        string           `json:"approved_by,omitempty"`
+	Lines             []StockCountLine `json:"lines"`
+	CreatedBy         string           `json:"created_by"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
+}
+
+// StockCountLine is one product, lot and serial position in a stock count
+type StockCountLine struct {
+	ID               string           `json:"id"`
+	TenantID         string           `json:"tenant_id"`
+	StockCountID     string           `json:"stock_count_id"`
+	LineNumber       int              `json:"line_number"`
+	ProductID        string           `json:"product_id"`
+	ProductCode      string           `json:"product_code"`
+	ProductName      string           `json:"product_name"`
+	Barcode          string           `json:"barcode,omitempty"`
+	Unit             string           `json:"unit,omitempty"`
+	LotNumber        string           `json:"lot_number,omitempty"`
+	SerialNumber     string           `json:"serial_number,omitempty"`
+	ExpiryDate       string           `json:"expiry_date,omitempty"`
+	ExpectedQuantity decimal.Decimal  `json:"expected_quantity"`
+	CountedQuantity  *decimal.Decimal `json:"counted_quantity,omitempty"`
+	UnitCost         decimal.Decimal  `json:"unit_cost"`
+	CountedBy        string           `json:"counted_by,omitempty"`
+	CountedAt        *time.Time       `json:"counted_at,omitempty"`
+}
+
+// Counted reports whether a quantity has been entered for the line
+func (l *StockCountLine) Counted() bool {
+	return l.CountedQuantity != nil
+}
+
+// VarianceQuantity returns the counted quantity minus the expected quantity.
+// Uncounted lines have no variance.
+func (l *StockCountLine) VarianceQuantity() decimal.Decimal {
+	if l.CountedQuantity == nil {
+		return decimal.Zero
+	}
+	return l.CountedQuantity.Sub(l.ExpectedQuantity)
+}
+
+// VarianceValue returns the variance quantity valued at the frozen unit cost
+func (l *StockCountLine) VarianceValue() decimal.Decimal {
+	return l.VarianceQuantity().Mul(l.UnitCost).Round(2)
+}
+
+// UncountedLines returns the number of lines without a counted quantity
+func (c *StockCount) UncountedLines() int {
+	uncounted := 0
+	for i := range c.Lines {
+		if !c.Lines[i].Counted() {
+			uncounted++
+		}
+	}
+	return uncounted
+}
+
+// CreateStockCountRequest opens a stock count session for a warehouse
+type CreateStockCountRequest struct {
+	WarehouseID     string    `json:"warehouse_id"`
+	CountDate       time.Time `json:"count_date,omitempty"`
+	ValuationMethod string    `json:"valuation_method,omitempty"`
+	Notes           string    `json:"notes,omitempty"`
+	UserID          string    `json:"-"`
+}
+
+// StockCountFilter provides filtering options
+type StockCountFilter struct {
+	Status      StockCountStatus
+	WarehouseID string
+}
+
+// CountEntry is one counted quantity. The line is found by LineID, or by
+// ProductID or Barcode together with the lot and serial number. Counts for a
+// lot or product that was not expected add a new line with zero expected
+// quantity.
+type CountEntry struct {
+	LineID       string          `json:"line_id,omitempty"`
+	ProductID    string          `json:"product_id,omitempty"`
+	Barcode      string          `json:"barcode,omitempty"`
+	LotNumber    string          `json:"lot_number,omitempty"`
+	SerialNumber string          `json:"serial_number,omitempty"`
+	ExpiryDate   string          `json:"expiry_date,omitempty"`
+	Quantity     decimal.Decimal `json:"quantity"`
+}
+
+// RecordCountsRequest enters counted quantities. Entries for the same line are
+// summed; Accumulate adds them to quantities counted earlier instead of
+// replacing them.
+type RecordCountsRequest struct {
+	Counts     []CountEntry `json:"counts"`
+	Accumulate bool         `json:"accumulate,omitempty"`
+	UserID     string       `json:"-"`
+}
+
+// ImportCountsRequest contains scanner output as CSV keyed by barcode
+type ImportCountsRequest struct {
+	CSVContent string `json:"csv_content"`
+	FileName   string `json:"file_name,omitempty"`
+	Accumulate bool   `json:"accumulate,omitempty"`
+	UserID     string `json:"-"`
+}
+
+// ImportCountsResult summarizes a scanner CSV import
+type ImportCountsResult struct {
+	FileName      string                `json:"file_name,omitempty"`
+	RowsProcessed int                   `json:"rows_processed"`
+	RowsImported  int                   `json:"rows_imported"`
+	RowsSkipped   int                   `json:"rows_skipped"`
+	Errors        []ImportCountRowError `json:"errors,omitempty"`
+	StockCount    *StockCount           `json:"stock_count"`
+}
+
+// ImportCountRowError describes a row-level scanner import failure
+type ImportCountRowError struct {
+	Row      int    `json:"row"`
+	Barcode  string `json:"barcode,omitempty"`
+	Quantity string `json:"quantity,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ApproveStockCountRequest approves a submitted stock count and posts its variances
+type ApproveStockCountRequest struct {
+	VarianceAccountID  string     `json:"variance_account_id"`
+	InventoryAccountID string     `json:"inventory_account_id,omitempty"`
+	ZeroUncounted      bool       `json:"zero_uncounted,omitempty"`
+	PeriodLockDate     *time.Time `json:"-"`
+	UserID             string     `json:"-"`
+}
+
+// StockCountVariance compares counted with expected quantities valued at the
+// count's valuation method
+type StockCountVariance struct {
+	StockCountID    string                   `json:"stock_count_id"`
+	CountNumber     string                   `json:"count_number"`
+	WarehouseID     string                   `json:"warehouse_id"`
+	CountDate       time.Time                `json:"count_date"`
+	Status          StockCountStatus         `json:"status"`
+	ValuationMethod string                   `json:"valuation_method"`
+	Lines           []StockCountVarianceLine `json:"lines"`
+	CountedLines    int                      `json:"counted_lines"`
+	UncountedLines  int                      `json:"uncounted_lines"`
+	ExpectedValue   decimal.Decimal          `json:"expected_value"`
+	CountedValue    decimal.Decimal          `json:"counted_value"`
+	SurplusValue    decimal.Decimal          `json:"surplus_value"`
+	ShortageValue   decimal.Decimal          `json:"shortage_value"`
+	NetVariance     decimal.Decimal          `json:"net_variance"`
+}
+
+// StockCountVarianceLine is the variance of one stock count line
+type StockCountVarianceLine struct {
+	LineID           string           `json:"line_id"`
+	LineNumber       int              `json:"line_number"`
+	ProductID        string           `json:"product_id"`
+	ProductCode      string           `json:"product_code"`
+	ProductName      string           `json:"product_name"`
+	Barcode          string           `json:"barcode,omitempty"`
+	LotNumber        string           `json:"lot_number,omitempty"`
+	SerialNumber     string           `json:"serial_number,omitempty"`
+	ExpiryDate       string           `json:"expiry_date,omitempty"`
+	ExpectedQuantity decimal.Decimal  `json:"expected_quantity"`
+	CountedQuantity  *decimal.Decimal `json:"counted_quantity,omitempty"`
+	VarianceQuantity decimal.Decimal  `json:"variance_quantity"`
+	UnitCost         decimal.Decimal  `json:"unit_cost"`
+	VarianceValue    decimal.Decimal  `json:"variance_value"`
+}
@@ -64,6 +64,13 @@ func tenantTableAfterSchemaValidated(db *gorm.DB, schemaName, tableName string)
 	return db.Session(&gorm.Session{NewDB: true}).Table(qualifiedTableAfterSchemaValidated(schemaName, tableName))
 }
 
+// Credit notes are reported in the rows of the invoice they credit, with the
+// amounts negated. Both expressions expect the original invoice joined as "o".
+const (
+	effectiveInvoiceTypeSQL = "(CASE WHEN i.invoice_type = 'CREDIT_NOTE' THEN o.invoice_type ELSE i.invoice_type END)"
+	creditNoteSignSQL       = "(CASE WHEN i.invoice_type = 'CREDIT_NOTE' THEN -1 ELSE 1 END)"
+)
+
 // QueryVATData queries VAT data from journal entries for a period
 func (r *GORMRepository) QueryVATData(ctx context.Context, schemaName, tenantID string, startDate, endDate time.Time) ([]VATAggregateRow, error) {
 	entriesTable, err := database.QualifiedTable(schemaName, "journal_entries")
@@ -109,12 +116,13 @@ func (r *GORMRepository) QueryVATData(ctx context.Context, schemaName, tenantID
 		Table(invoicesTable+" AS i").
 		Select(`
 			il.vat_rate,
-			SUM(il.line_subtotal * i.exchange_rate) AS tax_base,
-			SUM(il.line_subtotal * i.exchange_rate * il.vat_rate / 100) AS tax_amount
+			SUM(il.line_subtotal * i.exchange_rate * `+creditNoteSignSQL+`) AS tax_base,
+			SUM(il.line_subtotal * i.exchange_rate * il.vat_rate / 100 * `+creditNoteSignSQL+`) AS tax_amount
 		`).
 		Joins("JOIN "+invoiceLinesTable+" AS il ON il.invoice_id = i.id AND il.tenant_id = i.tenant_id").
+		Joins("LEFT JOIN "+invoicesTable+" AS o ON o.id = i.original_invoice_id AND o.tenant_id = i.tenant_id").
 		Where("i.tenant_id = ?", tenantID).
-		Where("i.invoice_type = ?", "PURCHASE").
+		Where(effectiveInvoiceTypeSQL+" = ?", "PURCHASE").
 		Where("i.status NOT IN ?", []string{"DRAFT", "VOIDED"}).
 		Where("i.issue_date >= ?", startDate).
 		Where("i.issue_date <= ?", endDate).
@@ -209,7 +217,7 @@ func (r *GORMRepository) QueryKMDINFData(ctx context.Context, schemaName, tenant
 	invoiceRows := db.
 		Table(invoicesTable+" AS i").
 		Select(`
-			CASE WHEN i.invoice_type = 'SALES' THEN 'A' WHEN i.invoice_type = 'PURCHASE' THEN 'B' END AS part,
+			CASE `+effectiveInvoiceTypeSQL+` WHEN 'SALES' THEN 'A' WHEN 'PURCHASE' THEN 'B' END AS part,
 			i.contact_id,
 			COALESCE(c.name, '') AS contact_name,
 			COALESCE(c.reg_code, '') AS contact_reg_code,
@@ -218,16 +226,17 @@ func (r *GORMRepository) QueryKMDINFData(ctx context.Context, schemaName, tenant
 			i.invoice_number,
 			i.issue_date AS invoice_date,
 			i.invoice_type,
-			i.base_subtotal AS taxable_amount,
-			i.base_vat_amount AS vat_amount,
-			i.base_total AS total_amount
+			i.base_subtotal * `+creditNoteSignSQL+` AS taxable_amount,
+			i.base_vat_amount * `+creditNoteSignSQL+` AS vat_amount,
+			i.base_total * `+creditNoteSignSQL+` AS total_amount
 		`).
 		Joins("JOIN "+contactsTable+" AS c ON c.id = i.contact_id AND c.tenant_id = i.tenant_id").
+		Joins("LEFT JOIN "+invoicesTable+" AS o ON o.id = i.original_invoice_id AND o.tenant_id = i.tenant_id").
 		Where("i.tenant_id = ?", tenantID).
 		Where("i.issue_date >= ?", startDate).
 		Where("i.issue_date < ?", endDate).
 		Where("i.status NOT IN ?", []string{"DRAFT", "VOIDED"}).
-		Where("i.invoice_type IN ?", []string{"SALES", "PURCHASE"}).
+		Where(effectiveInvoiceTypeSQL+" IN ?", []string{"SALES", "PURCHASE"}).
 		Where("COALESCE(i.base_vat_amount, 0) <> 0").
 		Where("COALESCE(NULLIF(c.country_code, ''), 'EE') = ?", "EE")
 	qualifiedRows := db.
@@ -558,6 +558,8 @@ func TestGORMRepositoryDryRunTaxScanQueryErrorsAndSQL(t *testing.T) {
 		`FROM "tenant_tax"."invoices" AS i`,
 		`JOIN "tenant_tax"."contacts" AS c ON c.id = i.contact_id AND c.tenant_id = i.tenant_id`,
 		`JOIN "tenant_tax"."invoice_lines" AS il ON il.invoice_id = i.id AND il.tenant_id = i.tenant_id`,
+		`LEFT JOIN "tenant_tax"."invoices" AS o ON o.id = i.original_invoice_id AND o.tenant_id = i.tenant_id`,
+		`WHEN i.invoice_type = 'CREDIT_NOTE' THEN -1 ELSE 1 END`,
 	)
 }
 
@@ -0,0 +1,24 @@
+package tenant
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DocumentLanguageEstonian renders customer documents with Estonian labels.
+	DocumentLanguageEstonian = "et"
+	// DocumentLanguageEnglish renders customer documents with English labels.
+	DocumentLanguageEnglish = "en"
+)
+
+// NormalizeDocumentLanguage returns the canonical document language code.
+// An empty value is kept so contacts can fall back to the tenant default.
+func NormalizeDocumentLanguage(value string) (string, error) {
+	switch language := strings.ToLower(strings.TrimSpace(value)); language {
+	case "", DocumentLanguageEstonian, DocumentLanguageEnglish:
+		return language, nil
+	default:
+		return "", fmt.Errorf("invalid document language %q", value)
+	}
+}
@@ -0,0 +1,51 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDocumentLanguage(t *testing.T) {
+	for _, tt := range []struct {
+		input string
+		want  string
+		valid bool
+	}{
+		{input: "", want: "", valid: true},
+		{input: " ET ", want: DocumentLanguageEstonian, valid: true},
+		{input: "en", want: DocumentLanguageEnglish, valid: true},
+		{input: "fi", valid: false},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := NormalizeDocumentLanguage(tt.input)
+			if !tt.valid {
+				require.ErrorContains(t, err, "invalid document language")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTenantServiceValidatesDocumentLanguage(t *testing.T) {
+	ctx := context.Background()
+	service := newTestServiceWithRepository(NewMockRepository())
+
+	created, err := service.CreateTenant(ctx, &CreateTenantRequest{Name: "Language", Slug: "language"})
+	require.NoError(t, err)
+	assert.Equal(t, DocumentLanguageEstonian, created.Settings.DocumentLanguage)
+
+	updated, err := service.UpdateTenant(ctx, created.ID, &UpdateTenantRequest{Settings: &TenantSettings{DocumentLanguage: "EN"}})
+	require.NoError(t, err)
+	assert.Equal(t, DocumentLanguageEnglish, updated.Settings.DocumentLanguage)
+
+	_, err = service.UpdateTenant(ctx, created.ID, &UpdateTenantRequest{Settings: &TenantSettings{DocumentLanguage: "fi"}})
+	require.ErrorContains(t, err, "invalid document language")
+
+	_, err = service.CreateTenant(ctx, &CreateTenantRequest{Name: "Bad", Slug: "bad-language", Settings: &TenantSettings{DocumentLanguage: "fi"}})
+	require.ErrorContains(t, err, "invalid document language")
+}
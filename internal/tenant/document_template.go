@@ -0,0 +1,198 @@
+package tenant
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const (
+	DocumentTypeInvoice  = "INVOICE"
+	DocumentTypeQuote    = "QUOTE"
+	DocumentTypeOrder    = "ORDER"
+	DocumentTypeReminder = "REMINDER"
+
+	PaperSizeA4     = "A4"
+	PaperSizeA5     = "A5"
+	PaperSizeLetter = "LETTER"
+	PaperSizeLegal  = "LEGAL"
+
+	OrientationPortrait  = "PORTRAIT"
+	OrientationLandscape = "LANDSCAPE"
+
+	LogoPositionNone   = "NONE"
+	LogoPositionLeft   = "LEFT"
+	LogoPositionRight  = "RIGHT"
+	LogoPositionCenter = "CENTER"
+
+	maxDocumentTemplateFields = 10
+)
+
+// DocumentTypes lists the document types that accept a PDF template.
+var DocumentTypes = []string{DocumentTypeInvoice, DocumentTypeQuote, DocumentTypeOrder, DocumentTypeReminder}
+
+// DocumentTemplate customises the PDF layout of one document type. HeaderText,
+// FooterText and custom field values are Go text/template strings rendered
+// against the document data model described in docs/API.md.
+type DocumentTemplate struct {
+	PaperSize          string                  `json:"paper_size,omitempty"`
+	Orientation        string                  `json:"orientation,omitempty"`
+	LogoPosition       string                  `json:"logo_position,omitempty"`
+	HideDiscountColumn bool                    `json:"hide_discount_column,omitempty"`
+	ShowPaymentQRCode  bool                    `json:"show_payment_qr_code,omitempty"`
+	HeaderText         string                  `json:"header_text,omitempty"`
+	FooterText         string                  `json:"footer_text,omitempty"`
+	CustomFields       []DocumentTemplateField `json:"custom_fields,omitempty"`
+}
+
+// DocumentTemplateField is a labelled value printed below the document title.
+type DocumentTemplateField struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// DocumentTemplateEntry is the effective template for one document type.
+type DocumentTemplateEntry struct {
+	DocumentType string           `json:"document_type"`
+	Customized   bool             `json:"customized"`
+	Template     DocumentTemplate `json:"template"`
+}
+
+// DocumentTemplatePreviewRequest renders a draft template, or the saved
+// template when Template is omitted.
+type DocumentTemplatePreviewRequest struct {
+	Template *DocumentTemplate `json:"template,omitempty"`
+}
+
+// NormalizeDocumentType validates a document template type.
+func NormalizeDocumentType(value string) (string, error) {
+	documentType := strings.ToUpper(strings.TrimSpace(value))
+	for _, candidate := range DocumentTypes {
+		if documentType == candidate {
+			return documentType, nil
+		}
+	}
+	return "", fmt.Errorf("invalid document type %q", value)
+}
+
+// NormalizeDocumentTemplate uppercases enumerations, trims text and checks that
+// template strings parse.
+func NormalizeDocumentTemplate(tmpl DocumentTemplate) (DocumentTemplate, error) {
+	tmpl.PaperSize = strings.ToUpper(strings.TrimSpace(tmpl.PaperSize))
+	switch tmpl.PaperSize {
+	case "", PaperSizeA4, PaperSizeA5, PaperSizeLetter, PaperSizeLegal:
+	default:
+		return DocumentTemplate{}, fmt.Errorf("invalid paper size %q", tmpl.PaperSize)
+	}
+	tmpl.Orientation = strings.ToUpper(strings.TrimSpace(tmpl.Orientation))
+	switch tmpl.Orientation {
+	case "", OrientationPortrait, OrientationLandscape:
+	default:
+		return DocumentTemplate{}, fmt.Errorf("invalid orientation %q", tmpl.Orientation)
+	}
+	tmpl.LogoPosition = strings.ToUpper(strings.TrimSpace(tmpl.LogoPosition))
+	switch tmpl.LogoPosition {
+	case "", LogoPositionNone, LogoPositionLeft, LogoPositionRight, LogoPositionCenter:
+	default:
+		return DocumentTemplate{}, fmt.Errorf("invalid logo position %q", tmpl.LogoPosition)
+	}
+	if len(tmpl.CustomFields) > maxDocumentTemplateFields {
+		return DocumentTemplate{}, fmt.Errorf("document templates support at most %d custom fields", maxDocumentTemplateFields)
+	}
+
+	tmpl.HeaderText = strings.TrimSpace(tmpl.HeaderText)
+	tmpl.FooterText = strings.TrimSpace(tmpl.FooterText)
+	if err := parseDocumentTemplateText("header_text", tmpl.HeaderText); err != nil {
+		return DocumentTemplate{}, err
+	}
+	if err := parseDocumentTemplateText("footer_text", tmpl.FooterText); err != nil {
+		return DocumentTemplate{}, err
+	}
+	for i := range tmpl.CustomFields {
+		field := &tmpl.CustomFields[i]
+		field.Label = strings.TrimSpace(field.Label)
+		field.Value = strings.TrimSpace(field.Value)
+		if field.Label == "" {
+			return DocumentTemplate{}, fmt.Errorf("custom field %d label is required", i+1)
+		}
+		if err := parseDocumentTemplateText(fmt.Sprintf("custom field %q", field.Label), field.Value); err != nil {
+			return DocumentTemplate{}, err
+		}
+	}
+	return tmpl, nil
+}
+
+func parseDocumentTemplateText(name, value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := template.New(name).Parse(value); err != nil {
+		return fmt.Errorf("invalid template in %s: %w", name, err)
+	}
+	return nil
+}
+
+// ListDocumentTemplates returns the effective PDF template for every document type.
+func (s *Service) ListDocumentTemplates(ctx context.Context, tenantID string) ([]DocumentTemplateEntry, error) {
+	current, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]DocumentTemplateEntry, 0, len(DocumentTypes))
+	for _, documentType := range DocumentTypes {
+		tmpl, ok := current.Settings.DocumentTemplates[documentType]
+		entries = append(entries, DocumentTemplateEntry{
+			DocumentType: documentType,
+			Customized:   ok,
+			Template:     tmpl,
+		})
+	}
+	return entries, nil
+}
+
+// SetDocumentTemplate stores the PDF template for a document type. A nil
+// template removes the customisation and restores the built-in layout.
+func (s *Service) SetDocumentTemplate(ctx context.Context, tenantID, documentType string, tmpl *DocumentTemplate) (*DocumentTemplateEntry, error) {
+	documentType, err := NormalizeDocumentType(documentType)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &DocumentTemplateEntry{DocumentType: documentType}
+	if tmpl == nil {
+		delete(current.Settings.DocumentTemplates, documentType)
+	} else {
+		normalized, err := NormalizeDocumentTemplate(*tmpl)
+		if err != nil {
+			return nil, err
+		}
+		if current.Settings.DocumentTemplates == nil {
+			current.Settings.DocumentTemplates = make(map[string]DocumentTemplate)
+		}
+		current.Settings.DocumentTemplates[documentType] = normalized
+		entry.Customized = true
+		entry.Template = normalized
+	}
+	if len(current.Settings.DocumentTemplates) == 0 {
+		current.Settings.DocumentTemplates = nil
+	}
+	current.UpdatedAt = time.Now()
+
+	settingsJSON, err := json.Marshal(current.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("marshal settings: %w", err)
+	}
+	if err := s.repo.UpdateTenant(ctx, tenantID, current.Name, settingsJSON, current.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,107 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDocumentTemplate(t *testing.T) {
+	normalized, err := NormalizeDocumentTemplate(DocumentTemplate{
+		PaperSize:    " letter ",
+		Orientation:  "landscape",
+		LogoPosition: "center",
+		HeaderText:   "  {{.Company.Name}}  ",
+		CustomFields: []DocumentTemplateField{{Label: " PO ", Value: " {{.Reference}} "}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, PaperSizeLetter, normalized.PaperSize)
+	assert.Equal(t, OrientationLandscape, normalized.Orientation)
+	assert.Equal(t, LogoPositionCenter, normalized.LogoPosition)
+	assert.Equal(t, "{{.Company.Name}}", normalized.HeaderText)
+	assert.Equal(t, []DocumentTemplateField{{Label: "PO", Value: "{{.Reference}}"}}, normalized.CustomFields)
+
+	tooManyFields := make([]DocumentTemplateField, maxDocumentTemplateFields+1)
+	for i := range tooManyFields {
+		tooManyFields[i] = DocumentTemplateField{Label: "Field", Value: "x"}
+	}
+
+	for _, tt := range []struct {
+		name string
+		tmpl DocumentTemplate
+		want string
+	}{
+		{name: "paper size", tmpl: DocumentTemplate{PaperSize: "A0"}, want: "invalid paper size"},
+		{name: "orientation", tmpl: DocumentTemplate{Orientation: "diagonal"}, want: "invalid orientation"},
+		{name: "logo position", tmpl: DocumentTemplate{LogoPosition: "top"}, want: "invalid logo position"},
+		{name: "header syntax", tmpl: DocumentTemplate{HeaderText: "{{.Number"}, want: "invalid template in header_text"},
+		{name: "footer syntax", tmpl: DocumentTemplate{FooterText: "{{end}}"}, want: "invalid template in footer_text"},
+		{name: "field label", tmpl: DocumentTemplate{CustomFields: []DocumentTemplateField{{Value: "x"}}}, want: "custom field 1 label is required"},
+		{name: "field syntax", tmpl: DocumentTemplate{CustomFields: []DocumentTemplateField{{Label: "PO", Value: "{{"}}}, want: `invalid template in custom field "PO"`},
+		{name: "field count", tmpl: DocumentTemplate{CustomFields: tooManyFields}, want: "at most 10 custom fields"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizeDocumentTemplate(tt.tmpl)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
+
+func TestNormalizeDocumentType(t *testing.T) {
+	documentType, err := NormalizeDocumentType(" quote ")
+	require.NoError(t, err)
+	assert.Equal(t, DocumentTypeQuote, documentType)
+
+	_, err = NormalizeDocumentType("payslip")
+	require.ErrorContains(t, err, "invalid document type")
+}
+
+func TestTenantServiceDocumentTemplates(t *testing.T) {
+	ctx := context.Background()
+	service := newTestServiceWithRepository(NewMockRepository())
+
+	created, err := service.CreateTenant(ctx, &CreateTenantRequest{Name: "Templates", Slug: "templates"})
+	require.NoError(t, err)
+
+	entries, err := service.ListDocumentTemplates(ctx, created.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, len(DocumentTypes))
+	for _, entry := range entries {
+		assert.False(t, entry.Customized)
+	}
+
+	entry, err := service.SetDocumentTemplate(ctx, created.ID, "invoice", &DocumentTemplate{PaperSize: "a5", HideDiscountColumn: true})
+	require.NoError(t, err)
+	assert.True(t, entry.Customized)
+	assert.Equal(t, DocumentTypeInvoice, entry.DocumentType)
+	assert.Equal(t, PaperSizeA5, entry.Template.PaperSize)
+
+	entries, err = service.ListDocumentTemplates(ctx, created.ID)
+	require.NoError(t, err)
+	assert.True(t, entries[0].Customized)
+	assert.True(t, entries[0].Template.HideDiscountColumn)
+	assert.False(t, entries[1].Customized)
+
+	// Regular settings updates keep saved templates.
+	updated, err := service.UpdateTenant(ctx, created.ID, &UpdateTenantRequest{Settings: &TenantSettings{Email: "billing@example.com"}})
+	require.NoError(t, err)
+	assert.Contains(t, updated.Settings.DocumentTemplates, DocumentTypeInvoice)
+
+	entry, err = service.SetDocumentTemplate(ctx, created.ID, DocumentTypeInvoice, nil)
+	require.NoError(t, err)
+	assert.False(t, entry.Customized)
+	reset, err := service.GetTenant(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Nil(t, reset.Settings.DocumentTemplates)
+
+	_, err = service.SetDocumentTemplate(ctx, created.ID, "payslip", &DocumentTemplate{})
+	require.ErrorContains(t, err, "invalid document type")
+	_, err = service.SetDocumentTemplate(ctx, created.ID, DocumentTypeQuote, &DocumentTemplate{Orientation: "sideways"})
+	require.ErrorContains(t, err, "invalid orientation")
+	_, err = service.SetDocumentTemplate(ctx, "missing", DocumentTypeQuote, &DocumentTemplate{})
+	require.Error(t, err)
+	_, err = service.ListDocumentTemplates(ctx, "missing")
+	require.Error(t, err)
+}
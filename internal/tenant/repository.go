@@ -32,6 +32,7 @@ type Repository interface {
 	ListUserTenants(ctx context.Context, userID string) ([]TenantMembership, error)
 	ListTenantUsers(ctx context.Context, tenantID string) ([]TenantUser, error)
 	UpdateTenantUserRole(ctx context.Context, tenantID, userID, newRole string) error
+	UpdateTenantUserEmployee(ctx context.Context, tenantID, userID, employeeID string) error
 	SetTenantUserActive(ctx context.Context, tenantID, userID string, active bool) error
 	RemoveTenantUser(ctx context.Context, tenantID, userID string) error
 
@@ -309,14 +309,7 @@ func (r *GORMRepository) GetTenantUser(ctx context.Context, tenantID, userID str
 	if err != nil {
 		return nil, fmt.Errorf("get tenant user: %w", err)
 	}
-	return &TenantUser{
-		TenantID:  tu.TenantID,
-		UserID:    tu.UserID,
-		Role:      tu.Role,
-		IsDefault: tu.IsDefault,
-		IsActive:  tu.IsActive,
-		CreatedAt: tu.CreatedAt,
-	}, nil
+	return modelToTenantUser(&tu), nil
 }
 
 // ListUserTenants retrieves all tenants a user belongs to
@@ -328,13 +321,14 @@ func (r *GORMRepository) ListUserTenants(ctx context.Context, userID string) ([]
 
 	var results []struct {
 		models.Tenant
-		Role      string
-		IsDefault bool
+		Role       string
+		EmployeeID *string
+		IsDefault  bool
 	}
 
 	err = db.
 		Model(&models.Tenant{}).
-		Select("tenants.*, tu.role, tu.is_default").
+		Select("tenants.*, tu.role, tu.employee_id, tu.is_default").
 		Joins("JOIN tenant_users tu ON tu.tenant_id = tenants.id").
 		Where("tu.user_id = ? AND tenants.is_active = ? AND tu.is_active = ?", userID, true, true).
 		Order("tu.is_default DESC, tenants.name").
@@ -346,9 +340,10 @@ func (r *GORMRepository) ListUserTenants(ctx context.Context, userID string) ([]
 	memberships := make([]TenantMembership, len(results))
 	for i, res := range results {
 		memberships[i] = TenantMembership{
-			Tenant:    *modelToTenant(&res.Tenant),
-			Role:      res.Role,
-			IsDefault: res.IsDefault,
+			Tenant:     *modelToTenant(&res.Tenant),
+			Role:       res.Role,
+			EmployeeID: stringValue(res.EmployeeID),
+			IsDefault:  res.IsDefault,
 		}
 	}
 
@@ -371,15 +366,8 @@ func (r *GORMRepository) ListTenantUsers(ctx context.Context, tenantID string) (
 	}
 
 	users := make([]TenantUser, len(tuModels))
-	for i, tu := range tuModels {
-		users[i] = TenantUser{
-			TenantID:  tu.TenantID,
-			UserID:    tu.UserID,
-			Role:      tu.Role,
-			IsDefault: tu.IsDefault,
-			IsActive:  tu.IsActive,
-			CreatedAt: tu.CreatedAt,
-		}
+	for i := range tuModels {
+		users[i] = *modelToTenantUser(&tuModels[i])
 	}
 
 	return users, nil
@@ -394,12 +382,28 @@ func (r *GORMRepository) UpdateTenantUserRole(ctx context.Context, tenantID, use
 
 	if err := db.Model(&models.TenantUserModel{}).
 		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
-		Update("role", newRole).Error; err != nil {
+		Updates(map[string]interface{}{"role": newRole, "employee_id": nil}).Error; err != nil {
 		return fmt.Errorf("update role: %w", err)
 	}
 	return nil
 }
 
+// UpdateTenantUserEmployee switches a membership to the employee role linked
+// to one payroll employee record.
+func (r *GORMRepository) UpdateTenantUserEmployee(ctx context.Context, tenantID, userID, employeeID string) error {
+	db, err := r.dbWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := db.Model(&models.TenantUserModel{}).
+		Where("tenant_id = ? AND user_id = ?", tenantID, userID).
+		Updates(map[string]interface{}{"role": RoleEmployee, "employee_id": employeeID}).Error; err != nil {
+		return fmt.Errorf("update employee link: %w", err)
+	}
+	return nil
+}
+
 // SetTenantUserActive updates a tenant membership active flag.
 func (r *GORMRepository) SetTenantUserActive(ctx context.Context, tenantID, userID string, active bool) error {
 	db, err := r.dbWithContext(ctx)
@@ -534,6 +538,7 @@ func (r *GORMRepository) CreateInvitation(ctx context.Context, inv *UserInvitati
 			Columns: []clause.Column{{Name: "tenant_id"}, {Name: "email"}},
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"role":        inv.Role,
+				"employee_id": optionalString(inv.EmployeeID),
 				"invited_by":  inv.InvitedBy,
 				"token":       inv.Token,
 				"expires_at":  inv.ExpiresAt,
@@ -597,14 +602,15 @@ func (r *GORMRepository) AcceptInvitation(ctx context.Context, inv *UserInvitati
 
 		now := time.Now()
 		membership := &models.TenantUserModel{
-			TenantID:  inv.TenantID,
-			UserID:    userID,
-			Role:      inv.Role,
-			IsDefault: false,
-			IsActive:  true,
-			InvitedBy: &inv.InvitedBy,
-			InvitedAt: &now,
-			CreatedAt: now,
+			TenantID:   inv.TenantID,
+			UserID:     userID,
+			Role:       inv.Role,
+			EmployeeID: optionalString(inv.EmployeeID),
+			IsDefault:  false,
+			IsActive:   true,
+			InvitedBy:  &inv.InvitedBy,
+			InvitedAt:  &now,
+			CreatedAt:  now,
 		}
 		if err := r.upsertTenantUser(ctx, tx, membership); err != nil {
 			return fmt.Errorf("add user to tenant: %w", err)
@@ -684,8 +690,9 @@ func (r *GORMRepository) CheckUserIsMember(ctx context.Context, tenantID, email
 
 func (r *GORMRepository) upsertTenantUser(ctx context.Context, db *gorm.DB, membership *models.TenantUserModel) error {
 	assignments := map[string]interface{}{
-		"role":      membership.Role,
-		"is_active": true,
+		"role":        membership.Role,
+		"employee_id": membership.EmployeeID,
+		"is_active":   true,
 	}
 	if membership.InvitedBy != nil {
 		assignments["invited_by"] = membership.InvitedBy
@@ -735,12 +742,25 @@ func modelToUser(m *models.User) *User {
 	}
 }
 
+func modelToTenantUser(m *models.TenantUserModel) *TenantUser {
+	return &TenantUser{
+		TenantID:   m.TenantID,
+		UserID:     m.UserID,
+		Role:       m.Role,
+		EmployeeID: stringValue(m.EmployeeID),
+		IsDefault:  m.IsDefault,
+		IsActive:   m.IsActive,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
 func modelToUserInvitation(m *models.UserInvitation) *UserInvitation {
 	return &UserInvitation{
 		ID:         m.ID,
 		TenantID:   m.TenantID,
 		Email:      m.Email,
 		Role:       m.Role,
+		EmployeeID: stringValue(m.EmployeeID),
 		InvitedBy:  m.InvitedBy,
 		Token:      m.Token,
 		ExpiresAt:  m.ExpiresAt,
@@ -755,6 +775,7 @@ func userInvitationToModel(inv *UserInvitation) *models.UserInvitation {
 		TenantID:   inv.TenantID,
 		Email:      inv.Email,
 		Role:       inv.Role,
+		EmployeeID: optionalString(inv.EmployeeID),
 		InvitedBy:  inv.InvitedBy,
 		Token:      inv.Token,
 		ExpiresAt:  inv.ExpiresAt,
@@ -805,5 +826,12 @@ func optionalString(value string) *string {
 	return &value
 }
 
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
 // Ensure GORMRepository implements Repository interface
 var _ Repository = (*GORMRepository)(nil)
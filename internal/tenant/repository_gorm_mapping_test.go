@@ -145,6 +145,37 @@ func TestUserInvitationModelMappings(t *testing.T) {
 	}
 }
 
+func TestEmployeeLinkModelMappings(t *testing.T) {
+	employeeID := "employee-1"
+	createdAt := time.Date(2026, 6, 5, 13, 0, 0, 0, time.UTC)
+	membership := modelToTenantUser(&models.TenantUserModel{
+		TenantID:   "tenant-1",
+		UserID:     "user-1",
+		Role:       RoleEmployee,
+		EmployeeID: &employeeID,
+		IsActive:   true,
+		CreatedAt:  createdAt,
+	})
+	if membership.Role != RoleEmployee || membership.EmployeeID != employeeID || !membership.IsActive || !membership.CreatedAt.Equal(createdAt) {
+		t.Fatalf("modelToTenantUser() = %#v, want employee link", membership)
+	}
+	if staff := modelToTenantUser(&models.TenantUserModel{Role: RoleViewer}); staff.EmployeeID != "" {
+		t.Fatalf("modelToTenantUser() employee id = %q, want empty", staff.EmployeeID)
+	}
+
+	invitation := &UserInvitation{ID: "invitation-1", Role: RoleEmployee, EmployeeID: employeeID}
+	model := userInvitationToModel(invitation)
+	if model.EmployeeID == nil || *model.EmployeeID != employeeID {
+		t.Fatalf("userInvitationToModel() employee id = %v, want %q", model.EmployeeID, employeeID)
+	}
+	if roundTrip := modelToUserInvitation(model); roundTrip.EmployeeID != employeeID {
+		t.Fatalf("modelToUserInvitation() employee id = %q, want %q", roundTrip.EmployeeID, employeeID)
+	}
+	if staff := userInvitationToModel(&UserInvitation{Role: RoleViewer}); staff.EmployeeID != nil {
+		t.Fatalf("userInvitationToModel() employee id = %v, want nil", staff.EmployeeID)
+	}
+}
+
 func TestTenantAuditEventModelMappings(t *testing.T) {
 	createdAt := time.Date(2026, 6, 6, 15, 0, 0, 0, time.UTC)
 	event := &TenantAuditEvent{
@@ -79,6 +79,11 @@ func (s *Service) CreateTenant(ctx context.Context, req *CreateTenantRequest) (*
 	if err := normalizeEvidencePolicySettings(&settings); err != nil {
 		return nil, err
 	}
+	documentLanguage, err := NormalizeDocumentLanguage(settings.DocumentLanguage)
+	if err != nil {
+		return nil, err
+	}
+	settings.DocumentLanguage = documentLanguage
 
 	settingsJSON, err := json.Marshal(settings)
 	if err != nil {
@@ -182,6 +187,22 @@ func (s *Service) UpdateTenant(ctx context.Context, tenantID string, req *Update
 		if req.Settings.InvoiceTerms != "" {
 			current.Settings.InvoiceTerms = req.Settings.InvoiceTerms
 		}
+		if req.Settings.TaxPrepaymentReference != "" {
+			current.Settings.TaxPrepaymentReference = strings.TrimSpace(req.Settings.TaxPrepaymentReference)
+		}
+		if req.Settings.DocumentLanguage != "" {
+			language, err := NormalizeDocumentLanguage(req.Settings.DocumentLanguage)
+			if err != nil {
+				return nil, err
+			}
+			current.Settings.DocumentLanguage = language
+		}
+		if req.Settings.ExpenseClaimAccountID != "" {
+			current.Settings.ExpenseClaimAccountID = strings.TrimSpace(req.Settings.ExpenseClaimAccountID)
+		}
+		if req.Settings.EmployeeReimbursementAccountID != "" {
+			current.Settings.EmployeeReimbursementAccountID = strings.TrimSpace(req.Settings.EmployeeReimbursementAccountID)
+		}
 		if req.Settings.Timezone != "" {
 			current.Settings.Timezone = req.Settings.Timezone
 		}
@@ -463,6 +484,13 @@ func (s *Service) CreateInvitation(ctx context.Context, tenantID, invitedByUserI
 	if !IsValidRole(req.Role) {
 		return nil, fmt.Errorf("invalid role: %s", req.Role)
 	}
+	if err := ValidateEmployeeLink(req.Role, req.EmployeeID); err != nil {
+		return nil, err
+	}
+	employeeID := strings.TrimSpace(req.EmployeeID)
+	if err := s.ensureEmployeeUnlinked(ctx, tenantID, "", employeeID); err != nil {
+		return nil, err
+	}
 
 	// Normalize email
 	email := strings.ToLower(strings.TrimSpace(req.Email))
@@ -484,14 +512,15 @@ func (s *Service) CreateInvitation(ctx context.Context, tenantID, invitedByUserI
 	expiresAt := time.Now().Add(7 * 24 * time.Hour) // 7 days
 
 	inv := &UserInvitation{
-		ID:        uuid.New().String(),
-		TenantID:  tenantID,
-		Email:     email,
-		Role:      req.Role,
-		InvitedBy: invitedByUserID,
-		Token:     token,
-		ExpiresAt: expiresAt,
-		CreatedAt: time.Now(),
+		ID:         uuid.New().String(),
+		TenantID:   tenantID,
+		Email:      email,
+		Role:       req.Role,
+		EmployeeID: employeeID,
+		InvitedBy:  invitedByUserID,
+		Token:      token,
+		ExpiresAt:  expiresAt,
+		CreatedAt:  time.Now(),
 	}
 
 	if err := s.repo.CreateInvitation(ctx, inv); err != nil {
@@ -567,9 +596,10 @@ func (s *Service) AcceptInvitation(ctx context.Context, req *AcceptInvitationReq
 	}
 
 	return &TenantMembership{
-		Tenant:    *tenant,
-		Role:      inv.Role,
-		IsDefault: false,
+		Tenant:     *tenant,
+		Role:       inv.Role,
+		EmployeeID: inv.EmployeeID,
+		IsDefault:  false,
 	}, nil
 }
 
@@ -605,6 +635,9 @@ func (s *Service) UpdateTenantUserRole(ctx context.Context, tenantID, userID, ne
 	if !IsValidRole(newRole) {
 		return fmt.Errorf("invalid role: %s", newRole)
 	}
+	if err := ValidateEmployeeLink(newRole, ""); err != nil {
+		return err
+	}
 
 	// Check current role
 	currentRole, err := s.repo.GetUserRole(ctx, tenantID, userID)
@@ -621,6 +654,47 @@ func (s *Service) UpdateTenantUserRole(ctx context.Context, tenantID, userID, ne
 	return s.repo.UpdateTenantUserRole(ctx, tenantID, userID, newRole)
 }
 
+// AssignEmployeeRole switches a user to the employee self-service role linked
+// to one payroll employee record. Each employee can be linked to one user.
+func (s *Service) AssignEmployeeRole(ctx context.Context, tenantID, userID, employeeID string) error {
+	employeeID = strings.TrimSpace(employeeID)
+	if err := ValidateEmployeeLink(RoleEmployee, employeeID); err != nil {
+		return err
+	}
+
+	currentRole, err := s.repo.GetUserRole(ctx, tenantID, userID)
+	if err == ErrUserNotInTenant {
+		return fmt.Errorf("user not found in tenant")
+	}
+	if err != nil {
+		return fmt.Errorf("check current role: %w", err)
+	}
+	if currentRole == RoleOwner {
+		return fmt.Errorf("cannot change owner role")
+	}
+	if err := s.ensureEmployeeUnlinked(ctx, tenantID, userID, employeeID); err != nil {
+		return err
+	}
+
+	return s.repo.UpdateTenantUserEmployee(ctx, tenantID, userID, employeeID)
+}
+
+func (s *Service) ensureEmployeeUnlinked(ctx context.Context, tenantID, userID, employeeID string) error {
+	if employeeID == "" {
+		return nil
+	}
+	users, err := s.repo.ListTenantUsers(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("check employee link: %w", err)
+	}
+	for _, user := range users {
+		if user.EmployeeID == employeeID && user.UserID != userID {
+			return fmt.Errorf("employee is already linked to another user")
+		}
+	}
+	return nil
+}
+
 // SetTenantUserActive suspends or restores a user's membership in one tenant.
 func (s *Service) SetTenantUserActive(ctx context.Context, tenantID, userID string, active bool) error {
 	membership, err := s.repo.GetTenantUser(ctx, tenantID, userID)
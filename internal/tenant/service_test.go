@@ -303,6 +303,21 @@ func (m *MockRepository) UpdateTenantUserRole(ctx context.Context, tenantID, use
 	for i := range m.tenantUsers[tenantID] {
 		if m.tenantUsers[tenantID][i].UserID == userID {
 			m.tenantUsers[tenantID][i].Role = newRole
+			m.tenantUsers[tenantID][i].EmployeeID = ""
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *MockRepository) UpdateTenantUserEmployee(ctx context.Context, tenantID, userID, employeeID string) error {
+	if m.updateTenantUserRoleErr != nil {
+		return m.updateTenantUserRoleErr
+	}
+	for i := range m.tenantUsers[tenantID] {
+		if m.tenantUsers[tenantID][i].UserID == userID {
+			m.tenantUsers[tenantID][i].Role = RoleEmployee
+			m.tenantUsers[tenantID][i].EmployeeID = employeeID
 			return nil
 		}
 	}
@@ -714,21 +729,22 @@ func TestService_UpdateTenant(t *testing.T) {
 			req: &UpdateTenantRequest{
 				Name: strPtr("Updated Company"),
 				Settings: &TenantSettings{
-					VATNumber:       "EE123456789",
-					RegCode:         "12345678",
-					Address:         "123 Main St",
-					Email:           "company@example.com",
-					Phone:           "+372 555 1234",
-					Logo:            "logo.png",
-					PDFPrimaryColor: "#FF0000",
-					PDFFooterText:   "Thank you for your business",
-					BankDetails:     "EE123456789012345678",
-					InvoiceTerms:    "Net 30",
-					Timezone:        "Europe/Tallinn",
-					DateFormat:      "DD.MM.YYYY",
-					DecimalSep:      ",",
-					ThousandsSep:    " ",
-					FiscalYearStart: 7,
+					VATNumber:              "EE123456789",
+					RegCode:                "12345678",
+					Address:                "123 Main St",
+					Email:                  "company@example.com",
+					Phone:                  "+372 555 1234",
+					Logo:                   "logo.png",
+					PDFPrimaryColor:        "#FF0000",
+					PDFFooterText:          "Thank you for your business",
+					BankDetails:            "EE123456789012345678",
+					InvoiceTerms:           "Net 30",
+					TaxPrepaymentReference: " 10123456781 ",
+					Timezone:               "Europe/Tallinn",
+					DateFormat:             "DD.MM.YYYY",
+					DecimalSep:             ",",
+					ThousandsSep:           " ",
+					FiscalYearStart:        7,
 				},
 			},
 			setupRepo: func(m *MockRepository) {
@@ -925,6 +941,46 @@ func TestService_UpdateTenantNormalizesInventoryPolicySettings(t *testing.T) {
 	assert.Equal(t, InventoryValuationMethodWeightedAverage, updatedTenant.Settings.InventoryValuationMethod)
 }
 
+func TestService_UpdateTenantStoresTaxPrepaymentReference(t *testing.T) {
+	repo := NewMockRepository()
+	repo.AddTestTenant(&Tenant{
+		ID:       "tenant-123",
+		Name:     "Test",
+		Slug:     "test",
+		Settings: DefaultSettings(),
+	})
+	svc := newTestServiceWithRepository(repo)
+
+	updatedTenant, err := svc.UpdateTenant(context.Background(), "tenant-123", &UpdateTenantRequest{
+		Settings: &TenantSettings{TaxPrepaymentReference: " 10123456781 "},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "10123456781", updatedTenant.Settings.TaxPrepaymentReference)
+}
+
+func TestService_UpdateTenantStoresExpenseClaimAccounts(t *testing.T) {
+	repo := NewMockRepository()
+	repo.AddTestTenant(&Tenant{
+		ID:       "tenant-123",
+		Name:     "Test",
+		Slug:     "test",
+		Settings: DefaultSettings(),
+	})
+	svc := newTestServiceWithRepository(repo)
+
+	updatedTenant, err := svc.UpdateTenant(context.Background(), "tenant-123", &UpdateTenantRequest{
+		Settings: &TenantSettings{
+			ExpenseClaimAccountID:          " travel-expense ",
+			EmployeeReimbursementAccountID: " employee-payable ",
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "travel-expense", updatedTenant.Settings.ExpenseClaimAccountID)
+	assert.Equal(t, "employee-payable", updatedTenant.Settings.EmployeeReimbursementAccountID)
+}
+
 func TestService_UpdateTenantRejectsInvalidInventoryPolicySettings(t *testing.T) {
 	repo := NewMockRepository()
 	repo.AddTestTenant(&Tenant{
@@ -2327,6 +2383,17 @@ func TestService_UpdateTenantUserRole(t *testing.T) {
 			newRole:  RoleAdmin,
 			wantErr:  true,
 		},
+		{
+			name:     "employee role needs an employee link",
+			tenantID: "tenant-1",
+			userID:   "user-1",
+			newRole:  RoleEmployee,
+			setupRepo: func(m *MockRepository) {
+				m.AddTestTenantUser(TenantUser{TenantID: "tenant-1", UserID: "user-1", Role: RoleViewer})
+			},
+			wantErr:    true,
+			errContain: "employee_id is required",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2352,6 +2419,58 @@ func TestService_UpdateTenantUserRole(t *testing.T) {
 	}
 }
 
+func TestService_AssignEmployeeRole(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	repo.AddTestTenantUser(TenantUser{TenantID: "tenant-1", UserID: "user-1", Role: RoleViewer})
+	repo.AddTestTenantUser(TenantUser{TenantID: "tenant-1", UserID: "user-2", Role: RoleViewer})
+	repo.AddTestTenantUser(TenantUser{TenantID: "tenant-1", UserID: "user-owner", Role: RoleOwner})
+	svc := newTestServiceWithRepository(repo)
+
+	require.NoError(t, svc.AssignEmployeeRole(ctx, "tenant-1", "user-1", " emp-1 "))
+	membership, err := repo.GetTenantUser(ctx, "tenant-1", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, RoleEmployee, membership.Role)
+	assert.Equal(t, "emp-1", membership.EmployeeID)
+
+	// Re-linking the same user is allowed; linking the employee to a second user is not.
+	require.NoError(t, svc.AssignEmployeeRole(ctx, "tenant-1", "user-1", "emp-1"))
+	assert.EqualError(t, svc.AssignEmployeeRole(ctx, "tenant-1", "user-2", "emp-1"), "employee is already linked to another user")
+	assert.EqualError(t, svc.AssignEmployeeRole(ctx, "tenant-1", "user-owner", "emp-2"), "cannot change owner role")
+	assert.EqualError(t, svc.AssignEmployeeRole(ctx, "tenant-1", "user-2", ""), "employee_id is required for the employee role")
+	assert.EqualError(t, svc.AssignEmployeeRole(ctx, "tenant-1", "missing", "emp-2"), "user not found in tenant")
+
+	// Switching back to a staff role clears the employee link.
+	require.NoError(t, svc.UpdateTenantUserRole(ctx, "tenant-1", "user-1", RoleViewer))
+	membership, err = repo.GetTenantUser(ctx, "tenant-1", "user-1")
+	require.NoError(t, err)
+	assert.Empty(t, membership.EmployeeID)
+}
+
+func TestService_CreateEmployeeInvitation(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMockRepository()
+	repo.AddTestTenant(&Tenant{ID: "tenant-1", Name: "Acme"})
+	repo.AddTestTenantUser(TenantUser{TenantID: "tenant-1", UserID: "user-2", Role: RoleEmployee, EmployeeID: "emp-2"})
+	svc := newTestServiceWithRepository(repo)
+
+	inv, err := svc.CreateInvitation(ctx, "tenant-1", "user-1", &CreateInvitationRequest{Email: "mari@example.com", Role: RoleEmployee, EmployeeID: " emp-1 "})
+	require.NoError(t, err)
+	assert.Equal(t, "emp-1", inv.EmployeeID)
+
+	_, err = svc.CreateInvitation(ctx, "tenant-1", "user-1", &CreateInvitationRequest{Email: "jaan@example.com", Role: RoleEmployee})
+	assert.EqualError(t, err, "employee_id is required for the employee role")
+	_, err = svc.CreateInvitation(ctx, "tenant-1", "user-1", &CreateInvitationRequest{Email: "jaan@example.com", Role: RoleViewer, EmployeeID: "emp-1"})
+	assert.EqualError(t, err, "employee_id is only valid for the employee role")
+	_, err = svc.CreateInvitation(ctx, "tenant-1", "user-1", &CreateInvitationRequest{Email: "jaan@example.com", Role: RoleEmployee, EmployeeID: "emp-2"})
+	assert.EqualError(t, err, "employee is already linked to another user")
+
+	membership, err := svc.AcceptInvitation(ctx, &AcceptInvitationRequest{Token: inv.Token, Password: "password123", Name: "Mari"})
+	require.NoError(t, err)
+	assert.Equal(t, RoleEmployee, membership.Role)
+	assert.Equal(t, "emp-1", membership.EmployeeID)
+}
+
 func TestService_SetTenantUserActive(t *testing.T) {
 	t.Run("suspends non-owner membership", func(t *testing.T) {
 		repo := NewMockRepository()
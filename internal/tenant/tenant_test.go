@@ -321,11 +321,12 @@ func TestCreateUserRequest(t *testing.T) {
 func TestValidRoles(t *testing.T) {
 	roles := ValidRoles()
 
-	// Should contain exactly these three roles
-	assert.Len(t, roles, 3)
+	// Should contain exactly these four roles
+	assert.Len(t, roles, 4)
 	assert.Contains(t, roles, RoleAdmin)
 	assert.Contains(t, roles, RoleAccountant)
 	assert.Contains(t, roles, RoleViewer)
+	assert.Contains(t, roles, RoleEmployee)
 
 	// Should NOT contain owner (owner is assigned at creation, not invited)
 	assert.NotContains(t, roles, RoleOwner)
@@ -340,6 +341,7 @@ func TestIsValidRole(t *testing.T) {
 		{"admin is valid", RoleAdmin, true},
 		{"accountant is valid", RoleAccountant, true},
 		{"viewer is valid", RoleViewer, true},
+		{"employee is valid", RoleEmployee, true},
 		{"owner is NOT valid for invitation", RoleOwner, false},
 		{"empty string is invalid", "", false},
 		{"unknown role is invalid", "unknown", false},
@@ -356,6 +358,13 @@ func TestIsValidRole(t *testing.T) {
 	}
 }
 
+func TestValidateEmployeeLink(t *testing.T) {
+	assert.NoError(t, ValidateEmployeeLink(RoleEmployee, "emp-1"))
+	assert.NoError(t, ValidateEmployeeLink(RoleViewer, ""))
+	assert.EqualError(t, ValidateEmployeeLink(RoleEmployee, "  "), "employee_id is required for the employee role")
+	assert.EqualError(t, ValidateEmployeeLink(RoleAccountant, "emp-1"), "employee_id is only valid for the employee role")
+}
+
 func TestGetRolePermissions_AllRoles(t *testing.T) {
 	t.Run("owner has all permissions", func(t *testing.T) {
 		perms := GetRolePermissions(RoleOwner)
@@ -417,6 +426,10 @@ func TestGetRolePermissions_AllRoles(t *testing.T) {
 		assert.False(t, perms.CanExportData)
 	})
 
+	t.Run("employee has no tenant permissions", func(t *testing.T) {
+		assert.Equal(t, RolePermissions{}, GetRolePermissions(RoleEmployee))
+	})
+
 	t.Run("unknown role has no permissions", func(t *testing.T) {
 		perms := GetRolePermissions("invalid-role")
 		assert.False(t, perms.CanManageUsers)
@@ -2,6 +2,8 @@ package tenant
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -40,6 +42,20 @@ type TenantSettings struct {
 	PDFFooterText   string `json:"pdf_footer_text,omitempty"`
 	BankDetails     string `json:"bank_details,omitempty"`
 	InvoiceTerms    string `json:"invoice_terms,omitempty"`
+	// TaxPrepaymentReference is the Tax and Customs Board prepayment account
+	// reference number used for payroll tax transfers.
+	TaxPrepaymentReference string `json:"tax_prepayment_reference,omitempty"`
+	// DocumentLanguage is the default label language for customer documents
+	// and payslips; contacts can override it.
+	DocumentLanguage string `json:"document_language,omitempty"`
+	// ExpenseClaimAccountID is the expense account charged by employee
+	// self-service expense claims.
+	ExpenseClaimAccountID string `json:"expense_claim_account_id,omitempty"`
+	// EmployeeReimbursementAccountID is the liability account credited with
+	// amounts owed to employees for self-service expense claims.
+	EmployeeReimbursementAccountID string `json:"employee_reimbursement_account_id,omitempty"`
+	// DocumentTemplates holds per-document-type PDF layouts keyed by document type.
+	DocumentTemplates map[string]DocumentTemplate `json:"document_templates,omitempty"`
 
 	// Late payment interest settings
 	// Rate is expressed as daily rate (e.g., 0.0005 = 0.05% per day ≈ 18% annually)
@@ -140,6 +156,7 @@ func DefaultSettings() TenantSettings {
 		DateFormat:                  "DD.MM.YYYY",
 		DecimalSep:                  ",",
 		ThousandsSep:                " ",
+		DocumentLanguage:            DocumentLanguageEstonian,
 		FiscalYearStart:             1, // January
 		InventoryIssueCostingMethod: InventoryIssueCostingMethodLot,
 		InventoryValuationMethod:    InventoryValuationMethodStandardCost,
@@ -172,12 +189,13 @@ type User struct {
 
 // TenantUser represents a user's membership in a tenant
 type TenantUser struct {
-	TenantID  string    `json:"tenant_id"`
-	UserID    string    `json:"user_id"`
-	Role      string    `json:"role"`
-	IsDefault bool      `json:"is_default"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
+	TenantID   string    `json:"tenant_id"`
+	UserID     string    `json:"user_id"`
+	Role       string    `json:"role"`
+	EmployeeID string    `json:"employee_id,omitempty"`
+	IsDefault  bool      `json:"is_default"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 // Role constants
@@ -186,6 +204,7 @@ const (
 	RoleAdmin      = "admin"      // Administrative access - can manage users and settings
 	RoleAccountant = "accountant" // Accounting access - can manage all accounting functions
 	RoleViewer     = "viewer"     // Read-only access - can only view reports
+	RoleEmployee   = "employee"   // Self-service access - own payslips, leave and expense claims only
 )
 
 // RolePermissions defines what each role can do
@@ -252,6 +271,10 @@ func GetRolePermissions(role string) RolePermissions {
 			CanManageBanking:  false,
 			CanExportData:     false,
 		}
+	case RoleEmployee:
+		// Employees only reach the /self routes, which scope every request to
+		// the linked employee record instead of checking tenant permissions.
+		return RolePermissions{}
 	default:
 		return RolePermissions{} // No permissions for unknown roles
 	}
@@ -286,9 +309,10 @@ type UserWithTenants struct {
 
 // TenantMembership represents a user's membership in a tenant
 type TenantMembership struct {
-	Tenant    Tenant `json:"tenant"`
-	Role      string `json:"role"`
-	IsDefault bool   `json:"is_default"`
+	Tenant     Tenant `json:"tenant"`
+	Role       string `json:"role"`
+	EmployeeID string `json:"employee_id,omitempty"`
+	IsDefault  bool   `json:"is_default"`
 }
 
 // UserInvitation represents a pending invitation to join a tenant
@@ -298,6 +322,7 @@ type UserInvitation struct {
 	TenantName string     `json:"tenant_name,omitempty"`
 	Email      string     `json:"email"`
 	Role       string     `json:"role"`
+	EmployeeID string     `json:"employee_id,omitempty"`
 	InvitedBy  string     `json:"invited_by"`
 	Token      string     `json:"-"` // Never expose token in JSON
 	ExpiresAt  time.Time  `json:"expires_at"`
@@ -307,8 +332,9 @@ type UserInvitation struct {
 
 // CreateInvitationRequest is the request to invite a user
 type CreateInvitationRequest struct {
-	Email string `json:"email"`
-	Role  string `json:"role"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	EmployeeID string `json:"employee_id,omitempty"` // Required for the employee role
 }
 
 // AcceptInvitationRequest is the request to accept an invitation
@@ -320,7 +346,7 @@ type AcceptInvitationRequest struct {
 
 // ValidRoles returns the list of valid roles for invitations
 func ValidRoles() []string {
-	return []string{RoleAdmin, RoleAccountant, RoleViewer}
+	return []string{RoleAdmin, RoleAccountant, RoleViewer, RoleEmployee}
 }
 
 // IsValidRole checks if a role is valid for invitation
@@ -332,3 +358,16 @@ func IsValidRole(role string) bool {
 	}
 	return false
 }
+
+// ValidateEmployeeLink checks that only the employee role is linked to an
+// employee record, and that every employee membership has one.
+func ValidateEmployeeLink(role, employeeID string) error {
+	employeeID = strings.TrimSpace(employeeID)
+	if role == RoleEmployee && employeeID == "" {
+		return fmt.Errorf("employee_id is required for the employee role")
+	}
+	if role != RoleEmployee && employeeID != "" {
+		return fmt.Errorf("employee_id is only valid for the employee role")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+-- Migration 070: Employment register events (TÖR) per employee
+
+CREATE OR REPLACE FUNCTION add_employment_events(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.employment_events (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            employee_id UUID NOT NULL REFERENCES %I.employees(id) ON DELETE CASCADE,
+            event_type VARCHAR(30) NOT NULL,
+            event_date DATE NOT NULL,
+            termination_code VARCHAR(20),
+            suspension_reason VARCHAR(30),
+            working_time_ratio NUMERIC(5,4) NOT NULL DEFAULT 1,
+            position VARCHAR(200),
+            notes TEXT,
+            exported_at TIMESTAMPTZ,
+            created_by UUID,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            CONSTRAINT employment_events_type_check CHECK (event_type IN (''START'', ''END'', ''SUSPENSION_START'', ''SUSPENSION_END'', ''WORKING_TIME_CHANGE'')),
+            CONSTRAINT employment_events_ratio_check CHECK (working_time_ratio > 0 AND working_time_ratio <= 1)
+        )
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_employment_events_employee
+        ON %I.employment_events(tenant_id, employee_id, event_date)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_employment_events_pending
+        ON %I.employment_events(tenant_id, event_date)
+        WHERE exported_at IS NULL
+    ', schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_employment_events(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,33 @@
+-- Migration 071 down: remove employee self-service role
+
+DELETE FROM user_invitations WHERE role = 'employee';
+DELETE FROM tenant_users WHERE role = 'employee';
+DELETE FROM role_permissions WHERE role = 'employee';
+
+ALTER TABLE user_invitations
+DROP CONSTRAINT IF EXISTS user_invitations_employee_link_check;
+
+ALTER TABLE user_invitations
+DROP CONSTRAINT IF EXISTS user_invitations_role_check;
+
+ALTER TABLE user_invitations
+ADD CONSTRAINT user_invitations_role_check
+CHECK (role IN ('admin', 'accountant', 'viewer'));
+
+ALTER TABLE user_invitations
+DROP COLUMN IF EXISTS employee_id;
+
+DROP INDEX IF EXISTS idx_tenant_users_employee;
+
+ALTER TABLE tenant_users
+DROP CONSTRAINT IF EXISTS tenant_users_employee_link_check;
+
+ALTER TABLE tenant_users
+DROP CONSTRAINT IF EXISTS tenant_users_role_check;
+
+ALTER TABLE tenant_users
+ADD CONSTRAINT tenant_users_role_check
+CHECK (role IN ('owner', 'admin', 'accountant', 'viewer'));
+
+ALTER TABLE tenant_users
+DROP COLUMN IF EXISTS employee_id;
@@ -0,0 +1,46 @@
+-- Migration 071: employee self-service role
+-- Employee memberships are linked to one payroll employee record in the tenant schema.
+
+ALTER TABLE tenant_users
+ADD COLUMN IF NOT EXISTS employee_id UUID;
+
+ALTER TABLE tenant_users
+DROP CONSTRAINT IF EXISTS tenant_users_role_check;
+
+ALTER TABLE tenant_users
+ADD CONSTRAINT tenant_users_role_check
+CHECK (role IN ('owner', 'admin', 'accountant', 'viewer', 'employee'));
+
+ALTER TABLE tenant_users
+DROP CONSTRAINT IF EXISTS tenant_users_employee_link_check;
+
+ALTER TABLE tenant_users
+ADD CONSTRAINT tenant_users_employee_link_check
+CHECK ((role = 'employee') = (employee_id IS NOT NULL));
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tenant_users_employee
+ON tenant_users(tenant_id, employee_id)
+WHERE employee_id IS NOT NULL;
+
+ALTER TABLE user_invitations
+ADD COLUMN IF NOT EXISTS employee_id UUID;
+
+ALTER TABLE user_invitations
+DROP CONSTRAINT IF EXISTS user_invitations_role_check;
+
+ALTER TABLE user_invitations
+ADD CONSTRAINT user_invitations_role_check
+CHECK (role IN ('admin', 'accountant', 'viewer', 'employee'));
+
+ALTER TABLE user_invitations
+DROP CONSTRAINT IF EXISTS user_invitations_employee_link_check;
+
+ALTER TABLE user_invitations
+ADD CONSTRAINT user_invitations_employee_link_check
+CHECK ((role = 'employee') = (employee_id IS NOT NULL));
+
+INSERT INTO role_permissions (role, description, can_manage_users, can_manage_settings, can_manage_accounts, can_create_entries, can_approve_entries, can_view_reports, can_manage_invoices, can_manage_payments, can_manage_contacts, can_manage_banking, can_export_data)
+VALUES
+    ('employee', 'Self-service access - can view own payslips and leave balances, request leave and submit own expense claims', false, false, false, false, false, false, false, false, false, false, false)
+ON CONFLICT (role) DO UPDATE SET
+    description = EXCLUDED.description;
@@ -0,0 +1,117 @@
+-- Migration 072: Batch depreciation runs for fixed assets
+
+CREATE OR REPLACE FUNCTION add_depreciation_runs(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.depreciation_runs (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            period_start DATE NOT NULL,
+            period_end DATE NOT NULL,
+            posting_mode VARCHAR(20) NOT NULL DEFAULT ''AGGREGATED'',
+            status VARCHAR(20) NOT NULL DEFAULT ''POSTED'',
+            asset_count INTEGER NOT NULL DEFAULT 0,
+            total_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            category_totals JSONB NOT NULL DEFAULT ''[]'',
+            journal_entry_ids TEXT[] NOT NULL DEFAULT ''{}'',
+            reversed_at TIMESTAMPTZ,
+            reversed_by UUID,
+            reversal_reason TEXT,
+            created_by UUID NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            CONSTRAINT depreciation_runs_mode_check CHECK (posting_mode IN (''AGGREGATED'', ''PER_ASSET'')),
+            CONSTRAINT depreciation_runs_status_check CHECK (status IN (''POSTED'', ''REVERSED''))
+        )
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE UNIQUE INDEX IF NOT EXISTS idx_depreciation_runs_posted_period
+        ON %I.depreciation_runs(tenant_id, period_start, period_end)
+        WHERE status = ''POSTED''
+    ', schema_name);
+
+    EXECUTE format('
+        ALTER TABLE %I.depreciation_entries
+        ADD COLUMN IF NOT EXISTS run_id UUID REFERENCES %I.depreciation_runs(id) ON DELETE SET NULL
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_depreciation_entries_run
+        ON %I.depreciation_entries(run_id)
+        WHERE run_id IS NOT NULL
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_depreciation_entries_period
+        ON %I.depreciation_entries(tenant_id, period_start, period_end)
+    ', schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_depreciation_runs(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,213 @@
+-- Migration 074: Purchase orders, goods receipts and purchase invoice matching
+
+CREATE OR REPLACE FUNCTION add_purchase_orders(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.purchase_orders (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            po_number VARCHAR(50) NOT NULL,
+            contact_id UUID NOT NULL REFERENCES %I.contacts(id),
+            warehouse_id UUID NOT NULL REFERENCES %I.warehouses(id),
+            order_date DATE NOT NULL,
+            expected_date DATE,
+            status VARCHAR(20) NOT NULL DEFAULT ''DRAFT'',
+            currency CHAR(3) NOT NULL DEFAULT ''EUR'',
+            exchange_rate NUMERIC(18,10) NOT NULL DEFAULT 1,
+            subtotal NUMERIC(28,8) NOT NULL DEFAULT 0,
+            vat_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            total NUMERIC(28,8) NOT NULL DEFAULT 0,
+            notes TEXT,
+            approved_at TIMESTAMPTZ,
+            approved_by UUID,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            created_by UUID NOT NULL,
+            updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (tenant_id, po_number),
+            CONSTRAINT purchase_orders_status_check CHECK (status IN (''DRAFT'', ''APPROVED'', ''PARTIALLY_RECEIVED'', ''RECEIVED'', ''CLOSED'', ''CANCELED''))
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_purchase_orders_contact
+        ON %I.purchase_orders(tenant_id, contact_id)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.purchase_order_lines (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            purchase_order_id UUID NOT NULL REFERENCES %I.purchase_orders(id) ON DELETE CASCADE,
+            line_number INTEGER NOT NULL,
+            product_id UUID NOT NULL REFERENCES %I.products(id),
+            description TEXT NOT NULL,
+            quantity NUMERIC(18,6) NOT NULL,
+            unit VARCHAR(20),
+            unit_price NUMERIC(28,8) NOT NULL,
+            discount_percent NUMERIC(5,2) NOT NULL DEFAULT 0,
+            vat_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+            line_subtotal NUMERIC(28,8) NOT NULL,
+            line_vat NUMERIC(28,8) NOT NULL,
+            line_total NUMERIC(28,8) NOT NULL,
+            received_quantity NUMERIC(18,6) NOT NULL DEFAULT 0,
+            received_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            invoiced_quantity NUMERIC(18,6) NOT NULL DEFAULT 0,
+            invoiced_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            accrual_cleared NUMERIC(28,8) NOT NULL DEFAULT 0,
+            UNIQUE (purchase_order_id, line_number)
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.goods_receipts (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            receipt_number VARCHAR(50) NOT NULL,
+            purchase_order_id UUID NOT NULL REFERENCES %I.purchase_orders(id),
+            warehouse_id UUID NOT NULL REFERENCES %I.warehouses(id),
+            receipt_date DATE NOT NULL,
+            supplier_reference VARCHAR(100),
+            notes TEXT,
+            total_cost NUMERIC(28,8) NOT NULL DEFAULT 0,
+            accrual_account_id UUID NOT NULL,
+            journal_entry_id UUID,
+            created_by UUID NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (tenant_id, receipt_number)
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_goods_receipts_purchase_order
+        ON %I.goods_receipts(tenant_id, purchase_order_id)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.goods_receipt_lines (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            goods_receipt_id UUID NOT NULL REFERENCES %I.goods_receipts(id) ON DELETE CASCADE,
+            purchase_order_line_id UUID NOT NULL REFERENCES %I.purchase_order_lines(id),
+            product_id UUID NOT NULL,
+            quantity NUMERIC(18,6) NOT NULL,
+            unit_cost NUMERIC(28,8) NOT NULL,
+            total_cost NUMERIC(28,8) NOT NULL,
+            lot_number VARCHAR(100),
+            serial_number VARCHAR(100),
+            expiry_date DATE
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.purchase_invoice_matches (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            purchase_order_id UUID NOT NULL REFERENCES %I.purchase_orders(id),
+            invoice_id UUID NOT NULL REFERENCES %I.invoices(id),
+            match_date DATE NOT NULL,
+            accrual_cleared NUMERIC(28,8) NOT NULL DEFAULT 0,
+            invoiced_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            vat_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            price_variance NUMERIC(28,8) NOT NULL DEFAULT 0,
+            payable_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            accrual_account_id UUID NOT NULL,
+            payable_account_id UUID NOT NULL,
+            vat_account_id UUID,
+            price_variance_account_id UUID,
+            journal_entry_id UUID,
+            created_by UUID NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (tenant_id, invoice_id)
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_purchase_invoice_matches_purchase_order
+        ON %I.purchase_invoice_matches(tenant_id, purchase_order_id)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.purchase_invoice_match_lines (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            match_id UUID NOT NULL REFERENCES %I.purchase_invoice_matches(id) ON DELETE CASCADE,
+            purchase_order_line_id UUID NOT NULL REFERENCES %I.purchase_order_lines(id),
+            invoice_line_id UUID NOT NULL,
+            quantity NUMERIC(18,6) NOT NULL,
+            accrual_cleared NUMERIC(28,8) NOT NULL,
+            invoiced_amount NUMERIC(28,8) NOT NULL,
+            price_variance NUMERIC(28,8) NOT NULL
+        )
+    ', schema_name, schema_name, schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_purchase_orders(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+    PERFORM add_asset_events(schema_name);
+    PERFORM add_purchase_orders(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,133 @@
+-- Migration 075: Order shipments, delivery notes and shipped quantities per order line
+
+CREATE OR REPLACE FUNCTION add_order_shipments(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        ALTER TABLE %I.order_lines
+        ADD COLUMN IF NOT EXISTS shipped_quantity NUMERIC(18,6) NOT NULL DEFAULT 0
+    ', schema_name);
+
+    -- Orders shipped before shipment tracking were shipped in full
+    EXECUTE format('
+        UPDATE %I.order_lines l
+        SET shipped_quantity = l.quantity
+        FROM %I.orders o
+        WHERE o.id = l.order_id
+          AND o.status IN (''SHIPPED'', ''DELIVERED'')
+          AND l.shipped_quantity = 0
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.order_shipments (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            shipment_number VARCHAR(50) NOT NULL,
+            order_id UUID NOT NULL REFERENCES %I.orders(id),
+            warehouse_id UUID REFERENCES %I.warehouses(id),
+            shipment_date DATE NOT NULL,
+            costing_method VARCHAR(20),
+            total_cost NUMERIC(28,8) NOT NULL DEFAULT 0,
+            notes TEXT,
+            created_by UUID NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (tenant_id, shipment_number)
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_order_shipments_order
+        ON %I.order_shipments(tenant_id, order_id)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.order_shipment_lines (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            shipment_id UUID NOT NULL REFERENCES %I.order_shipments(id) ON DELETE CASCADE,
+            order_line_id UUID NOT NULL REFERENCES %I.order_lines(id),
+            line_number INTEGER NOT NULL,
+            product_id UUID,
+            description TEXT NOT NULL,
+            quantity NUMERIC(18,6) NOT NULL,
+            unit VARCHAR(20),
+            unit_cost NUMERIC(28,8) NOT NULL DEFAULT 0,
+            total_cost NUMERIC(28,8) NOT NULL DEFAULT 0,
+            lot_number VARCHAR(100),
+            serial_number VARCHAR(100),
+            expiry_date DATE,
+            journal_entry_id UUID
+        )
+    ', schema_name, schema_name, schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_order_shipments(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+    PERFORM add_asset_events(schema_name);
+    PERFORM add_purchase_orders(schema_name);
+    PERFORM add_order_shipments(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,178 @@
+-- Migration 077: Bills of materials, kits and assembly orders
+
+CREATE OR REPLACE FUNCTION add_assembly(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.bills_of_materials (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            product_id UUID NOT NULL REFERENCES %I.products(id),
+            bom_type VARCHAR(20) NOT NULL DEFAULT ''ASSEMBLY'',
+            output_quantity NUMERIC(18,6) NOT NULL DEFAULT 1,
+            labour_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+            overhead_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+            is_active BOOLEAN NOT NULL DEFAULT true,
+            notes TEXT,
+            created_by UUID NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (tenant_id, product_id),
+            CONSTRAINT bills_of_materials_type_check CHECK (bom_type IN (''ASSEMBLY'', ''KIT'')),
+            CONSTRAINT bills_of_materials_output_check CHECK (output_quantity > 0)
+        )
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.bill_of_material_lines (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            bom_id UUID NOT NULL REFERENCES %I.bills_of_materials(id) ON DELETE CASCADE,
+            line_number INTEGER NOT NULL,
+            component_product_id UUID NOT NULL REFERENCES %I.products(id),
+            quantity NUMERIC(18,6) NOT NULL,
+            notes TEXT,
+            CONSTRAINT bill_of_material_lines_quantity_check CHECK (quantity > 0)
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_bill_of_material_lines_component
+        ON %I.bill_of_material_lines(tenant_id, component_product_id)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.assembly_orders (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            order_number VARCHAR(50) NOT NULL,
+            order_type VARCHAR(20) NOT NULL DEFAULT ''ASSEMBLY'',
+            bom_id UUID NOT NULL REFERENCES %I.bills_of_materials(id),
+            product_id UUID NOT NULL REFERENCES %I.products(id),
+            warehouse_id UUID NOT NULL REFERENCES %I.warehouses(id),
+            quantity NUMERIC(18,6) NOT NULL,
+            order_date DATE NOT NULL,
+            status VARCHAR(20) NOT NULL DEFAULT ''DRAFT'',
+            lot_number VARCHAR(100),
+            serial_number VARCHAR(100),
+            expiry_date DATE,
+            labour_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+            overhead_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+            component_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+            total_cost NUMERIC(18,2) NOT NULL DEFAULT 0,
+            unit_cost NUMERIC(28,8) NOT NULL DEFAULT 0,
+            costing_method VARCHAR(30),
+            absorption_account_id UUID REFERENCES %I.accounts(id),
+            journal_entry_id UUID,
+            notes TEXT,
+            completed_at TIMESTAMPTZ,
+            completed_by UUID,
+            created_by UUID NOT NULL,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (tenant_id, order_number),
+            CONSTRAINT assembly_orders_type_check CHECK (order_type IN (''ASSEMBLY'', ''DISASSEMBLY'')),
+            CONSTRAINT assembly_orders_status_check CHECK (status IN (''DRAFT'', ''COMPLETED'', ''CANCELED'')),
+            CONSTRAINT assembly_orders_quantity_check CHECK (quantity > 0)
+        )
+    ', schema_name, schema_name, schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_assembly_orders_product
+        ON %I.assembly_orders(tenant_id, product_id, status)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.assembly_order_lines (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            assembly_order_id UUID NOT NULL REFERENCES %I.assembly_orders(id) ON DELETE CASCADE,
+            line_number INTEGER NOT NULL,
+            product_id UUID NOT NULL REFERENCES %I.products(id),
+            quantity NUMERIC(18,6) NOT NULL,
+            lot_number VARCHAR(100),
+            serial_number VARCHAR(100),
+            expiry_date DATE,
+            unit_cost NUMERIC(28,8) NOT NULL DEFAULT 0,
+            total_cost NUMERIC(18,2) NOT NULL DEFAULT 0
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_assembly_order_lines_order
+        ON %I.assembly_order_lines(tenant_id, assembly_order_id, line_number)
+    ', schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_assembly(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+    PERFORM add_asset_events(schema_name);
+    PERFORM add_purchase_orders(schema_name);
+    PERFORM add_order_shipments(schema_name);
+    PERFORM add_stock_counts(schema_name);
+    PERFORM add_assembly(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,177 @@
+-- Migration 080: Partial and prepayment invoicing of orders and quotes
+
+CREATE OR REPLACE FUNCTION add_partial_invoicing(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        ALTER TABLE %I.invoice_lines
+        ADD COLUMN IF NOT EXISTS prepayment_invoice_id UUID
+    ', schema_name);
+
+    EXECUTE format('
+        ALTER TABLE %I.order_lines
+        ADD COLUMN IF NOT EXISTS invoiced_quantity NUMERIC(18,6) NOT NULL DEFAULT 0
+    ', schema_name);
+
+    EXECUTE format('
+        ALTER TABLE %I.quote_lines
+        ADD COLUMN IF NOT EXISTS invoiced_quantity NUMERIC(18,6) NOT NULL DEFAULT 0
+    ', schema_name);
+
+    -- Documents converted before partial invoicing were invoiced in one go
+    EXECUTE format('
+        UPDATE %I.order_lines l
+        SET invoiced_quantity = LEAST(l.shipped_quantity, l.quantity)
+        FROM %I.orders o
+        WHERE o.id = l.order_id
+          AND o.converted_to_invoice_id IS NOT NULL
+          AND l.invoiced_quantity = 0
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        UPDATE %I.quote_lines l
+        SET invoiced_quantity = l.quantity
+        FROM %I.quotes q
+        WHERE q.id = l.quote_id
+          AND q.converted_to_invoice_id IS NOT NULL
+          AND l.invoiced_quantity = 0
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.order_invoices (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            order_id UUID NOT NULL REFERENCES %I.orders(id) ON DELETE CASCADE,
+            invoice_id UUID NOT NULL REFERENCES %I.invoices(id),
+            invoice_number VARCHAR(50) NOT NULL,
+            kind VARCHAR(20) NOT NULL DEFAULT ''QUANTITY'',
+            percent NUMERIC(9,4) NOT NULL DEFAULT 0,
+            subtotal NUMERIC(28,8) NOT NULL DEFAULT 0,
+            vat_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+            prepayment_deducted NUMERIC(28,8) NOT NULL DEFAULT 0,
+            netted_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            created_by UUID,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_order_invoices_order
+        ON %I.order_invoices(tenant_id, order_id)
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.quote_invoices (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            quote_id UUID NOT NULL REFERENCES %I.quotes(id) ON DELETE CASCADE,
+            invoice_id UUID NOT NULL REFERENCES %I.invoices(id),
+            invoice_number VARCHAR(50) NOT NULL,
+            kind VARCHAR(20) NOT NULL DEFAULT ''QUANTITY'',
+            percent NUMERIC(9,4) NOT NULL DEFAULT 0,
+            subtotal NUMERIC(28,8) NOT NULL DEFAULT 0,
+            vat_rate NUMERIC(5,2) NOT NULL DEFAULT 0,
+            prepayment_deducted NUMERIC(28,8) NOT NULL DEFAULT 0,
+            netted_amount NUMERIC(28,8) NOT NULL DEFAULT 0,
+            created_by UUID,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+        )
+    ', schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_quote_invoices_quote
+        ON %I.quote_invoices(tenant_id, quote_id)
+    ', schema_name);
+
+    EXECUTE format('
+        INSERT INTO %I.order_invoices (tenant_id, order_id, invoice_id, invoice_number, subtotal, created_by, created_at)
+        SELECT o.tenant_id, o.id, i.id, i.invoice_number, i.subtotal, i.created_by, i.created_at
+        FROM %I.orders o
+        JOIN %I.invoices i ON i.id = o.converted_to_invoice_id
+        WHERE NOT EXISTS (SELECT 1 FROM %I.order_invoices b WHERE b.order_id = o.id)
+    ', schema_name, schema_name, schema_name, schema_name);
+
+    EXECUTE format('
+        INSERT INTO %I.quote_invoices (tenant_id, quote_id, invoice_id, invoice_number, subtotal, created_by, created_at)
+        SELECT q.tenant_id, q.id, i.id, i.invoice_number, i.subtotal, i.created_by, i.created_at
+        FROM %I.quotes q
+        JOIN %I.invoices i ON i.id = q.converted_to_invoice_id
+        WHERE NOT EXISTS (SELECT 1 FROM %I.quote_invoices b WHERE b.quote_id = q.id)
+    ', schema_name, schema_name, schema_name, schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_partial_invoicing(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+    PERFORM add_asset_events(schema_name);
+    PERFORM add_purchase_orders(schema_name);
+    PERFORM add_order_shipments(schema_name);
+    PERFORM add_stock_counts(schema_name);
+    PERFORM add_assembly(schema_name);
+    PERFORM add_landed_costs(schema_name);
+    PERFORM add_price_lists(schema_name);
+    PERFORM add_partial_invoicing(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,126 @@
+-- Migration 081: Quote revisions frozen on send
+
+CREATE OR REPLACE FUNCTION add_quote_revisions(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        ALTER TABLE %I.quotes
+        ADD COLUMN IF NOT EXISTS revision INTEGER NOT NULL DEFAULT 1
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.quote_revisions (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            quote_id UUID NOT NULL REFERENCES %I.quotes(id) ON DELETE CASCADE,
+            revision INTEGER NOT NULL,
+            valid_until DATE,
+            currency VARCHAR(3) NOT NULL DEFAULT ''EUR'',
+            total NUMERIC(28,8) NOT NULL DEFAULT 0,
+            snapshot JSONB NOT NULL DEFAULT ''{}'',
+            sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            UNIQUE (quote_id, revision)
+        )
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_quote_revisions_quote
+        ON %I.quote_revisions(tenant_id, quote_id)
+    ', schema_name);
+
+    -- Quotes sent before revisions existed keep their current content as revision 1
+    EXECUTE format('
+        INSERT INTO %I.quote_revisions (tenant_id, quote_id, revision, valid_until, currency, total, snapshot, sent_at)
+        SELECT q.tenant_id, q.id, q.revision, q.valid_until, q.currency, q.total,
+               to_jsonb(q) || jsonb_build_object(
+                   ''quote_date'', to_char(q.quote_date, ''YYYY-MM-DD"T00:00:00Z"''),
+                   ''valid_until'', to_char(q.valid_until, ''YYYY-MM-DD"T00:00:00Z"''),
+                   ''lines'', COALESCE((
+                       SELECT jsonb_agg(to_jsonb(l) ORDER BY l.line_number)
+                       FROM %I.quote_lines l
+                       WHERE l.quote_id = q.id
+                   ), ''[]''::jsonb)
+               ),
+               q.updated_at
+        FROM %I.quotes q
+        WHERE q.status <> ''DRAFT''
+          AND NOT EXISTS (SELECT 1 FROM %I.quote_revisions r WHERE r.quote_id = q.id)
+    ', schema_name, schema_name, schema_name, schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_quote_revisions(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+    PERFORM add_asset_events(schema_name);
+    PERFORM add_purchase_orders(schema_name);
+    PERFORM add_order_shipments(schema_name);
+    PERFORM add_stock_counts(schema_name);
+    PERFORM add_assembly(schema_name);
+    PERFORM add_landed_costs(schema_name);
+    PERFORM add_price_lists(schema_name);
+    PERFORM add_partial_invoicing(schema_name);
+    PERFORM add_quote_revisions(schema_name);
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,137 @@
+-- Migration 082: Usage-based, prorated and escalating recurring invoice lines
+
+CREATE OR REPLACE FUNCTION add_recurring_usage_billing(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('
+        ALTER TABLE %I.recurring_invoices
+        ADD COLUMN IF NOT EXISTS billing_anchor_date DATE,
+        ADD COLUMN IF NOT EXISTS last_period_start DATE
+    ', schema_name);
+
+    EXECUTE format('
+        ALTER TABLE %I.recurring_invoice_lines
+        ADD COLUMN IF NOT EXISTS usage_metric VARCHAR(50) NOT NULL DEFAULT ''''
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.recurring_invoice_usage (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            recurring_invoice_id UUID NOT NULL REFERENCES %I.recurring_invoices(id) ON DELETE CASCADE,
+            metric VARCHAR(50) NOT NULL,
+            usage_date DATE NOT NULL,
+            quantity NUMERIC(18,6) NOT NULL DEFAULT 0,
+            reference TEXT NOT NULL DEFAULT '''',
+            invoice_id UUID,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            created_by UUID
+        )
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_recurring_invoice_usage_unbilled
+        ON %I.recurring_invoice_usage(tenant_id, recurring_invoice_id, usage_date)
+        WHERE invoice_id IS NULL
+    ', schema_name);
+
+    EXECUTE format('
+        CREATE TABLE IF NOT EXISTS %I.recurring_invoice_price_changes (
+            id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+            tenant_id UUID NOT NULL,
+            recurring_invoice_id UUID NOT NULL REFERENCES %I.recurring_invoices(id) ON DELETE CASCADE,
+            line_number INTEGER,
+            effective_date DATE NOT NULL,
+            unit_price NUMERIC(28,8),
+            uplift_percent NUMERIC(9,4),
+            note TEXT NOT NULL DEFAULT '''',
+            applied_at TIMESTAMPTZ,
+            created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+            created_by UUID,
+            CHECK ((unit_price IS NULL) <> (uplift_percent IS NULL))
+        )
+    ', schema_name, schema_name);
+
+    EXECUTE format('
+        CREATE INDEX IF NOT EXISTS idx_recurring_invoice_price_changes_template
+        ON %I.recurring_invoice_price_changes(tenant_id, recurring_invoice_id, effective_date)
+    ', schema_name);
+END;
+$$ LANGUAGE plpgsql;
+
+DO $$
+DECLARE
+    tenant_schema TEXT;
+BEGIN
+    FOR tenant_schema IN
+        SELECT nspname
+        FROM pg_namespace
+        WHERE nspname LIKE 'tenant_%'
+    LOOP
+        PERFORM add_recurring_usage_billing(tenant_schema);
+    END LOOP;
+END $$;
+
+CREATE OR REPLACE FUNCTION create_tenant_schema(schema_name TEXT) RETURNS VOID AS $$
+BEGIN
+    EXECUTE format('CREATE SCHEMA IF NOT EXISTS %I', schema_name);
+
+    PERFORM create_accounting_tables(schema_name);
+    PERFORM add_journal_entry_post_reason(schema_name);
+    PERFORM add_vat_columns_to_journal_lines(schema_name);
+    PERFORM add_payment_reversal_columns(schema_name);
+    PERFORM add_reconciliation_tables_to_schema(schema_name);
+    PERFORM add_recurring_tables_to_schema(schema_name);
+    PERFORM add_quotes_and_orders_tables(schema_name);
+    PERFORM add_fixed_assets_tables(schema_name);
+    PERFORM add_fixed_asset_disposal_journal_links(schema_name);
+    PERFORM create_inventory_tables(schema_name);
+    PERFORM add_inventory_movement_tracking_metadata(schema_name);
+    PERFORM add_inventory_lot_reservations(schema_name);
+    PERFORM add_payroll_tables(schema_name);
+    PERFORM add_leave_management_tables(schema_name);
+    PERFORM create_email_tables_only(schema_name);
+    PERFORM add_kmd_tables_to_schema(schema_name);
+    PERFORM fix_email_log_schema(schema_name);
+    PERFORM add_reminder_rules_to_schema(schema_name);
+    PERFORM sync_email_template_type_constraint(schema_name);
+    PERFORM add_interest_tables(schema_name);
+    PERFORM add_document_tables(schema_name);
+    PERFORM add_document_review_workflow(schema_name);
+    PERFORM add_bank_transaction_review_columns(schema_name);
+    PERFORM add_close_pack_document_entity(schema_name);
+    PERFORM add_order_stock_reservations(schema_name);
+    PERFORM add_journal_entry_evidence_requirement(schema_name);
+    PERFORM add_journal_entry_templates(schema_name);
+    PERFORM add_journal_entry_template_recurrence(schema_name);
+    PERFORM add_bank_match_rules(schema_name);
+    PERFORM add_invoice_vat_treatment(schema_name);
+    PERFORM add_expense_tables(schema_name);
+    PERFORM add_commercial_document_entities(schema_name);
+    PERFORM add_leave_record_document_entity(schema_name);
+    PERFORM add_tax_declaration_document_entities(schema_name);
+    PERFORM add_document_lifecycle_workflow(schema_name);
+    PERFORM add_document_legal_hold_workflow(schema_name);
+    PERFORM add_document_lifecycle_integrity(schema_name);
+    PERFORM add_cost_center_tables(schema_name);
+    PERFORM add_migration_execution_run_tables(schema_name);
+    PERFORM add_financial_report_indexes(schema_name);
+    PERFORM add_invoice_credit_note_links(schema_name);
+    PERFORM add_contact_document_language(schema_name);
+    PERFORM add_payroll_posting_accounts(schema_name);
+    PERFORM add_payroll_payments(schema_name);
+    PERFORM add_payslip_components(schema_name);
+    PERFORM add_timesheets(schema_name);
+    PERFORM add_employment_events(schema_name);
+    PERFORM add_depreciation_runs(schema_name);
+    PERFORM add_asset_events(schema_name);
+    PERFORM add_purchase_orders(schema_name);
+    PERFORM add_order_shipments(schema_name);
+    PERFORM add_stock_counts(schema_name);
+    PERFORM add_assembly(schema_name);
+    PERFORM add_landed_costs(schema_name);
+    PERFORM add_price_lists(schema_name);
+    PERFORM add_partial_invoicing(schema_name);
+    PERFORM add_quote_revisions(schema_name);
+    PERFORM add_recurring_usage_billing(schema_name);
+END;
+$$ LANGUAGE plpgsql;